@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+)
+
+// テスト時にモック可能な関数変数
+var (
+	getRepoRootFunc = func(ctx context.Context) (string, error) {
+		return git.NewRepository(&nullLogger{}).GetRootPath(ctx)
+	}
+	// openArtifactFunc は成果物を$PAGER（未設定の場合はless）で開く
+	openArtifactFunc = func(cmd *cobra.Command, path string) error {
+		pager := os.Getenv("PAGER")
+		if pager == "" {
+			pager = "less"
+		}
+		c := exec.Command("sh", "-c", pager+` "$1"`, "sh", path)
+		c.Stdin = os.Stdin
+		c.Stdout = cmd.OutOrStdout()
+		c.Stderr = cmd.ErrOrStderr()
+		return c.Run()
+	}
+)
+
+func newArtifactsCmd() *cobra.Command {
+	var issueNumber int
+	cmd := &cobra.Command{
+		Use:   "artifacts [ファイル名]",
+		Short: "Issueのフェーズが残した成果物を表示",
+		Long: `フェーズがIssueの成果物ディレクトリ（.git/osoba/artifacts/issue-<番号>）に置いたファイル
+（plan.md、review.md、test-report.xmlなど）の一覧を表示します。
+ファイル名を指定すると、$PAGER（未設定の場合はless）で開きます。
+
+成果物ディレクトリはプロンプトの {{artifacts-dir}}（{{.ArtifactsDir}}）で参照でき、
+後のフェーズは以前のフェーズの成果物を {{.Artifacts}} や {{.Artifact "plan.md"}} で受け取れます。
+
+使用例:
+  osoba artifacts --issue 83
+  osoba artifacts --issue 83 plan.md
+  osoba artifacts                   # 処理中のIssueから選択`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			number, err := resolveIssueNumber(cmd, issueNumber)
+			if err != nil {
+				return err
+			}
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return runArtifacts(cmd, number, name)
+		},
+	}
+	cmd.Flags().IntVar(&issueNumber, "issue", 0, "成果物を表示するIssue番号（省略時は処理中のIssueから選択）")
+	return withJSONOutput(cmd)
+}
+
+func runArtifacts(cmd *cobra.Command, issueNumber int, name string) error {
+	if issueNumber <= 0 {
+		return fmt.Errorf("Issue番号は正の整数で指定してください")
+	}
+	cfg := config.NewConfig()
+	if _, err := cfg.LoadOrDefaultWithError(viper.GetString("config")); err != nil {
+		return fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+	}
+	root, err := getRepoRootFunc(context.Background())
+	if err != nil {
+		return fmt.Errorf("リポジトリのルートの取得に失敗しました: %w", err)
+	}
+	dir := git.ArtifactsDirForIssue(root, issueNumber)
+	artifacts, err := git.ListArtifacts(dir)
+	if err != nil {
+		return err
+	}
+
+	if name != "" {
+		for _, artifact := range artifacts {
+			if artifact.Name == filepath.ToSlash(name) {
+				return openArtifactFunc(cmd, artifact.Path)
+			}
+		}
+		return fmt.Errorf("Issue #%d の成果物に %s はありません（osoba artifacts --issue %d で一覧を確認してください）", issueNumber, name, issueNumber)
+	}
+
+	if isJSONOutput() {
+		if artifacts == nil {
+			artifacts = []git.Artifact{}
+		}
+		return renderJSON(cmd, artifacts)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "成果物ディレクトリ: %s\n", dir)
+	if len(artifacts) == 0 {
+		fmt.Fprintln(out, "成果物はありません")
+		return nil
+	}
+	loc := cfg.Location()
+	for _, artifact := range artifacts {
+		fmt.Fprintf(out, "  %-32s %8d bytes  %s\n", artifact.Name, artifact.Size, artifact.ModTime.In(loc).Format("2006-01-02 15:04:05"))
+	}
+	return nil
+}
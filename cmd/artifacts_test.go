@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunArtifacts(t *testing.T) {
+	origRoot := getRepoRootFunc
+	origOpen := openArtifactFunc
+	defer func() {
+		getRepoRootFunc = origRoot
+		openArtifactFunc = origOpen
+	}()
+
+	root := t.TempDir()
+	getRepoRootFunc = func(ctx context.Context) (string, error) { return root, nil }
+	dir, err := git.EnsureArtifactsDirForIssue(root, 83)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plan.md"), []byte("# Plan"), 0644))
+
+	var opened string
+	openArtifactFunc = func(cmd *cobra.Command, path string) error {
+		opened = path
+		return nil
+	}
+
+	tests := []struct {
+		name       string
+		issue      int
+		file       string
+		wantOutput []string
+		wantOpened string
+		wantErr    string
+	}{
+		{
+			name:       "成果物の一覧",
+			issue:      83,
+			wantOutput: []string{"成果物ディレクトリ: " + dir, "plan.md", "6 bytes"},
+		},
+		{
+			name:       "成果物がないIssue",
+			issue:      84,
+			wantOutput: []string{"成果物はありません"},
+		},
+		{
+			name:       "ファイル名を指定して開く",
+			issue:      83,
+			file:       "plan.md",
+			wantOpened: filepath.Join(dir, "plan.md"),
+		},
+		{
+			name:    "存在しないファイル",
+			issue:   83,
+			file:    "review.md",
+			wantErr: "review.md はありません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opened = ""
+			var buf bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&buf)
+
+			err := runArtifacts(cmd, tt.issue, tt.file)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, want := range tt.wantOutput {
+				assert.Contains(t, buf.String(), want)
+			}
+			assert.Equal(t, tt.wantOpened, opened)
+		})
+	}
+}
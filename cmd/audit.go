@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/douhashi/osoba/internal/config"
+	githubPkg "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/paths"
+)
+
+// auditOptions はosoba auditの絞り込み条件
+type auditOptions struct {
+	since     time.Duration
+	operation string
+	issue     int
+	failed    bool
+	limit     int
+}
+
+func newAuditCmd() *cobra.Command {
+	var opts auditOptions
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "GitHub操作の監査ログを表示",
+		Long: `osobaが行った変更を伴うGitHub操作（ラベルの変更・コメント・マージなど）の監査ログを表示します。
+監査ログは osoba start の実行中に記録されます（audit.enabled で無効にできます）。
+
+使用例:
+  osoba audit
+  osoba audit --since 24h --issue 83
+  osoba audit --operation "pr merge" --failed`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAudit(cmd, opts)
+		},
+	}
+	cmd.Flags().DurationVar(&opts.since, "since", 0, "指定した期間内の操作のみ表示（例: 24h）")
+	cmd.Flags().StringVar(&opts.operation, "operation", "", "操作で絞り込む（前方一致。例: issue, \"pr merge\", api）")
+	cmd.Flags().IntVar(&opts.issue, "issue", 0, "対象のIssue・PR番号で絞り込む")
+	cmd.Flags().BoolVar(&opts.failed, "failed", false, "失敗した操作のみ表示")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 50, "表示する最大件数（新しいものから。0の場合はすべて）")
+	return withJSONOutput(cmd)
+}
+
+func runAudit(cmd *cobra.Command, opts auditOptions) error {
+	if opts.limit < 0 {
+		return fmt.Errorf("--limit には0以上の値を指定してください")
+	}
+
+	cfg := config.NewConfig()
+	configPath := viper.ConfigFileUsed()
+	if configPath == "" {
+		configPath = viper.GetString("config")
+	}
+	if _, err := cfg.LoadOrDefaultWithError(configPath); err != nil {
+		return fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+	}
+
+	auditPath := cfg.Audit.Path
+	if auditPath == "" {
+		repoIdentifier, err := getRepoIdentifierFunc()
+		if err != nil {
+			return fmt.Errorf("リポジトリ識別子の取得に失敗しました: %w", err)
+		}
+		auditPath = paths.NewPathManager("").AuditFile(repoIdentifier)
+	}
+
+	entries, err := githubPkg.ReadAuditLog(auditPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("監査ログの読み込みに失敗しました: %w", err)
+	}
+	entries = filterAuditEntries(entries, opts, time.Now())
+
+	if isJSONOutput() {
+		if entries == nil {
+			entries = []githubPkg.AuditEntry{}
+		}
+		return renderJSON(cmd, entries)
+	}
+	printAuditEntries(cmd, auditPath, entries, cfg.Location())
+	return nil
+}
+
+// filterAuditEntries は絞り込み条件に一致するエントリを記録順に返す（limitを超える場合は新しいものを残す）
+func filterAuditEntries(entries []githubPkg.AuditEntry, opts auditOptions, now time.Time) []githubPkg.AuditEntry {
+	var filtered []githubPkg.AuditEntry
+	for _, entry := range entries {
+		if opts.since > 0 && entry.Time.Before(now.Add(-opts.since)) {
+			continue
+		}
+		if opts.operation != "" && !strings.HasPrefix(entry.Operation, opts.operation) {
+			continue
+		}
+		if opts.issue > 0 && entry.Number != opts.issue {
+			continue
+		}
+		if opts.failed && entry.Result != githubPkg.AuditResultError {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	if opts.limit > 0 && len(filtered) > opts.limit {
+		filtered = filtered[len(filtered)-opts.limit:]
+	}
+	return filtered
+}
+
+// printAuditEntries は監査ログのエントリを表示する
+func printAuditEntries(cmd *cobra.Command, auditPath string, entries []githubPkg.AuditEntry, loc *time.Location) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "監査ログ: %s\n", auditPath)
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "該当する操作はありません")
+		return
+	}
+	for _, entry := range entries {
+		target := entry.Repository
+		if entry.Number > 0 {
+			target += fmt.Sprintf("#%d", entry.Number)
+		}
+		result := "成功"
+		if entry.Result == githubPkg.AuditResultError {
+			result = "失敗"
+		}
+		fmt.Fprintf(out, "%s  %-12s %-24s %s  %s\n",
+			entry.Time.In(loc).Format("2006-01-02 15:04:05"), entry.Operation, target, result, entry.Actor)
+		if verbose {
+			fmt.Fprintf(out, "    gh %s\n", strings.Join(entry.Args, " "))
+		}
+		if entry.Error != "" {
+			fmt.Fprintf(out, "    エラー: %s\n", entry.Error)
+		}
+	}
+}
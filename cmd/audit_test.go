@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	githubPkg "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterAuditEntries(t *testing.T) {
+	now := time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC)
+	entries := []githubPkg.AuditEntry{
+		{Time: now.Add(-48 * time.Hour), Operation: "issue edit", Number: 12, Result: githubPkg.AuditResultOK},
+		{Time: now.Add(-2 * time.Hour), Operation: "issue comment", Number: 12, Result: githubPkg.AuditResultOK},
+		{Time: now.Add(-1 * time.Hour), Operation: "pr merge", Number: 34, Result: githubPkg.AuditResultError},
+	}
+
+	tests := []struct {
+		name string
+		opts auditOptions
+		want []string
+	}{
+		{name: "条件なし", want: []string{"issue edit", "issue comment", "pr merge"}},
+		{name: "期間", opts: auditOptions{since: 24 * time.Hour}, want: []string{"issue comment", "pr merge"}},
+		{name: "操作の前方一致", opts: auditOptions{operation: "issue"}, want: []string{"issue edit", "issue comment"}},
+		{name: "Issue番号", opts: auditOptions{issue: 34}, want: []string{"pr merge"}},
+		{name: "失敗のみ", opts: auditOptions{failed: true}, want: []string{"pr merge"}},
+		{name: "件数の上限は新しいものを残す", opts: auditOptions{limit: 1}, want: []string{"pr merge"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, entry := range filterAuditEntries(entries, tt.opts, now) {
+				got = append(got, entry.Operation)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAuditCmd(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("OSOBA_DATA_DIR", dataDir)
+	origRepoIdentifier := getRepoIdentifierFunc
+	defer func() {
+		getRepoIdentifierFunc = origRepoIdentifier
+		outputFormat = outputText
+	}()
+	getRepoIdentifierFunc = func() (string, error) { return "douhashi/osoba", nil }
+
+	auditPath := filepath.Join(dataDir, "audit", "douhashi_osoba.jsonl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(auditPath), 0700))
+	audit, err := githubPkg.NewAuditLog(auditPath, "token")
+	require.NoError(t, err)
+	require.NoError(t, audit.Record([]string{"pr", "merge", "34", "--repo", "douhashi/osoba"}, nil))
+
+	t.Run("テキスト出力", func(t *testing.T) {
+		var buf bytes.Buffer
+		rootCmd := NewRootCmd()
+		rootCmd.SetOut(&buf)
+		rootCmd.SetArgs([]string{"audit"})
+		require.NoError(t, rootCmd.Execute())
+		assert.Contains(t, buf.String(), "pr merge")
+		assert.Contains(t, buf.String(), "douhashi/osoba#34")
+		assert.Contains(t, buf.String(), "成功")
+	})
+
+	t.Run("JSON出力", func(t *testing.T) {
+		var buf bytes.Buffer
+		rootCmd := NewRootCmd()
+		rootCmd.SetOut(&buf)
+		rootCmd.SetArgs([]string{"audit", "--output", "json", "--failed"})
+		require.NoError(t, rootCmd.Execute())
+		var entries []githubPkg.AuditEntry
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entries))
+		assert.Empty(t, entries)
+	})
+}
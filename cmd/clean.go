@@ -4,21 +4,55 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
 
+	"github.com/douhashi/osoba/internal/cleanup"
+	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/git"
 	"github.com/douhashi/osoba/internal/logger"
 	"github.com/douhashi/osoba/internal/tmux"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var (
-	allFlag   bool
-	forceFlag bool
+	allFlag    bool
+	forceFlag  bool
+	yesFlag    bool
+	dryRunFlag bool
 )
 
+// cleanResult はcleanの結果（--output json）
+type cleanResult struct {
+	IssueNumber int      `json:"issue_number,omitempty"` // --allの場合は省略
+	Windows     []string `json:"windows"`
+	Worktrees   []string `json:"worktrees"`
+	Uncommitted []string `json:"uncommitted_worktrees"` // 未コミットの変更があるworktree
+	Removed     bool     `json:"removed"`               // 削除を実行したか（対象なし・キャンセル時はfalse）
+	DryRun      bool     `json:"dry_run,omitempty"`     // --dry-runで削除対象の表示のみ行ったか
+	Errors      []string `json:"errors"`
+	// Plan は--dry-runで作成したクリーンアップの計画（自動クリーンアップと同じ計画）
+	Plan *cleanup.Plan `json:"plan,omitempty"`
+}
+
+// newCleanResult は削除対象から結果を作成する
+func newCleanResult(issueNumber int, windows []*tmux.WindowInfo, worktrees []git.WorktreeInfo) *cleanResult {
+	result := &cleanResult{
+		IssueNumber: issueNumber,
+		Windows:     getWindowNames(windows),
+		Worktrees:   make([]string, 0, len(worktrees)),
+		Uncommitted: []string{},
+		Errors:      []string{},
+	}
+	for _, wt := range worktrees {
+		result.Worktrees = append(result.Worktrees, wt.Path)
+	}
+	return result
+}
+
 func newCleanCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "clean [issue-number]",
@@ -29,15 +63,19 @@ func newCleanCmd() *cobra.Command {
   osoba clean 83        # Issue #83に関連するウィンドウとworktreeを削除
   osoba clean --all     # すべてのIssue関連リソースを削除（確認あり）
   osoba clean --force   # 確認なしで削除
-  osoba clean --all --force  # すべてのリソースを確認なしで削除`,
+  osoba clean --all --force  # すべてのリソースを確認なしで削除
+  osoba clean 83 --yes  # safety.confirm_destructive が有効な場合も確認なしで削除
+  osoba clean 83 --dry-run  # 削除せずに削除対象のリソースを表示`,
 		Args: validateCleanArgs,
 		RunE: runClean,
 	}
 
 	cmd.Flags().BoolVar(&allFlag, "all", false, "すべてのIssue関連リソースを削除")
 	cmd.Flags().BoolVar(&forceFlag, "force", false, "確認プロンプトを表示せずに削除")
+	cmd.Flags().BoolVarP(&yesFlag, "yes", "y", false, "破壊的操作を確認なしで許可（safety.confirm_destructive 有効時）")
+	cmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "削除せずに削除対象のリソースを表示")
 
-	return cmd
+	return withJSONOutput(cmd)
 }
 
 func validateCleanArgs(cmd *cobra.Command, args []string) error {
@@ -110,6 +148,10 @@ func parseIssueNumber(arg string) (int, error) {
 }
 
 func cleanIssueWindows(cmd *cobra.Command, sessionName string, issueNumber int) error {
+	if dryRunFlag {
+		return planIssueCleanup(cmd, sessionName, issueNumber)
+	}
+
 	// Issue番号に関連するウィンドウを取得
 	windows, err := listWindowsForIssueFunc(sessionName, issueNumber)
 	if err != nil {
@@ -122,9 +164,11 @@ func cleanIssueWindows(cmd *cobra.Command, sessionName string, issueNumber int)
 		return fmt.Errorf("worktree一覧の取得に失敗しました: %w", err)
 	}
 
+	out := textOut(cmd)
+	result := newCleanResult(issueNumber, windows, worktrees)
 	if len(windows) == 0 && len(worktrees) == 0 {
-		fmt.Fprintf(cmd.OutOrStdout(), "Issue #%d に関連するリソースが見つかりませんでした。\n", issueNumber)
-		return nil
+		fmt.Fprintf(out, "Issue #%d に関連するリソースが見つかりませんでした。\n", issueNumber)
+		return renderJSON(cmd, result)
 	}
 
 	// 未コミット変更のチェック
@@ -133,31 +177,42 @@ func cleanIssueWindows(cmd *cobra.Command, sessionName string, issueNumber int)
 	for _, wt := range worktrees {
 		hasChanges, err := hasUncommittedChangesFunc(context.Background(), wt.Path)
 		if err != nil {
-			fmt.Fprintf(cmd.OutOrStdout(), "警告: %s の未コミット変更チェックに失敗しました: %v\n", wt.Path, err)
+			fmt.Fprintf(out, "警告: %s の未コミット変更チェックに失敗しました: %v\n", wt.Path, err)
 			continue
 		}
 		if hasChanges {
 			hasUncommittedChanges = true
 			uncommittedWorktrees = append(uncommittedWorktrees, wt)
+			result.Uncommitted = append(result.Uncommitted, wt.Path)
 		}
 	}
 
 	// 未コミット変更がある場合は警告を表示
 	if hasUncommittedChanges {
-		fmt.Fprintf(cmd.OutOrStdout(), "警告: 以下のworktreeに未コミットの変更があります:\n")
+		fmt.Fprintf(out, "警告: 以下のworktreeに未コミットの変更があります:\n")
 		for _, wt := range uncommittedWorktrees {
-			fmt.Fprintf(cmd.OutOrStdout(), "  - %s\n", wt.Path)
+			fmt.Fprintf(out, "  - %s\n", wt.Path)
 		}
 
-		if !forceFlag {
-			confirmed, err := confirmPromptFunc("本当に削除しますか？ (yes/no): ")
-			if err != nil {
-				return fmt.Errorf("確認の読み取りに失敗しました: %w", err)
-			}
-			if !confirmed {
-				fmt.Fprintln(cmd.OutOrStdout(), "削除をキャンセルしました。")
-				return nil
-			}
+	}
+
+	// 確認プロンプト（未コミット変更がある場合、またはsafety.confirm_destructiveが有効な場合）
+	needsConfirm := hasUncommittedChanges && !forceFlag
+	confirmDestructive, err := requiresDestructiveConfirmation(cmd, len(windows) > 0, len(worktrees) > 0)
+	if err != nil {
+		return err
+	}
+	if confirmDestructive {
+		needsConfirm = true
+	}
+	if needsConfirm {
+		confirmed, err := confirmPromptFunc("本当に削除しますか？ (yes/no): ")
+		if err != nil {
+			return fmt.Errorf("確認の読み取りに失敗しました: %w", err)
+		}
+		if !confirmed {
+			fmt.Fprintln(out, "削除をキャンセルしました。")
+			return renderJSON(cmd, result)
 		}
 	}
 
@@ -179,33 +234,38 @@ func cleanIssueWindows(cmd *cobra.Command, sessionName string, issueNumber int)
 
 	// 結果を表示
 	if len(windows) > 0 || len(worktrees) > 0 {
-		fmt.Fprintf(cmd.OutOrStdout(), "Issue #%d のリソースを削除しました:\n", issueNumber)
+		fmt.Fprintf(out, "Issue #%d のリソースを削除しました:\n", issueNumber)
 		if len(windows) > 0 {
-			fmt.Fprintf(cmd.OutOrStdout(), "  ウィンドウ:\n")
+			fmt.Fprintf(out, "  ウィンドウ:\n")
 			for _, window := range windows {
-				fmt.Fprintf(cmd.OutOrStdout(), "    - %s\n", window.Name)
+				fmt.Fprintf(out, "    - %s\n", window.Name)
 			}
 		}
 		if len(worktrees) > 0 {
-			fmt.Fprintf(cmd.OutOrStdout(), "  worktree:\n")
+			fmt.Fprintf(out, "  worktree:\n")
 			for _, wt := range worktrees {
-				fmt.Fprintf(cmd.OutOrStdout(), "    - %s\n", wt.Path)
+				fmt.Fprintf(out, "    - %s\n", wt.Path)
 			}
 		}
 	}
 
+	result.Removed = true
+	for _, err := range append(windowErrors, worktreeErrors...) {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
 	// エラーがあれば報告
 	if len(windowErrors) > 0 || len(worktreeErrors) > 0 {
-		fmt.Fprintf(cmd.OutOrStdout(), "\n以下のエラーが発生しました:\n")
+		fmt.Fprintf(out, "\n以下のエラーが発生しました:\n")
 		for _, err := range windowErrors {
-			fmt.Fprintf(cmd.OutOrStdout(), "  - %v\n", err)
+			fmt.Fprintf(out, "  - %v\n", err)
 		}
 		for _, err := range worktreeErrors {
-			fmt.Fprintf(cmd.OutOrStdout(), "  - %v\n", err)
+			fmt.Fprintf(out, "  - %v\n", err)
 		}
 	}
 
-	return nil
+	return renderJSON(cmd, result)
 }
 
 // performCleanupAllForce は clean --all --force 相当の処理を実行します
@@ -225,7 +285,7 @@ func performCleanupAllForce(sessionName string) error {
 	// osoba関連のworktreeをフィルタリング
 	var worktrees []git.WorktreeInfo
 	for _, wt := range allWorktrees {
-		if strings.Contains(wt.Path, ".git/worktree/") || strings.Contains(wt.Path, ".git/osoba/") {
+		if isOsobaWorktree(wt.Path) {
 			worktrees = append(worktrees, wt)
 		}
 	}
@@ -272,74 +332,97 @@ func cleanAllWindows(cmd *cobra.Command, sessionName string) error {
 	// osoba関連のworktreeをフィルタリング
 	var worktrees []git.WorktreeInfo
 	for _, wt := range allWorktrees {
-		if strings.Contains(wt.Path, ".git/worktree/") || strings.Contains(wt.Path, ".git/osoba/") {
+		if isOsobaWorktree(wt.Path) {
 			worktrees = append(worktrees, wt)
 		}
 	}
 
+	out := textOut(cmd)
+	result := newCleanResult(0, windows, worktrees)
 	if len(windows) == 0 && len(worktrees) == 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "削除対象のリソースが見つかりませんでした。")
-		return nil
+		fmt.Fprintln(out, "削除対象のリソースが見つかりませんでした。")
+		return renderJSON(cmd, result)
 	}
 
 	// リソース一覧を表示
-	fmt.Fprintln(cmd.OutOrStdout(), "以下のリソースを削除します:")
+	fmt.Fprintln(out, "以下のリソースを削除します:")
 	if len(windows) > 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "  ウィンドウ:")
+		fmt.Fprintln(out, "  ウィンドウ:")
 		for _, window := range windows {
-			fmt.Fprintf(cmd.OutOrStdout(), "    - %s\n", window.Name)
+			fmt.Fprintf(out, "    - %s\n", window.Name)
 		}
 	}
 	if len(worktrees) > 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "  worktree:")
+		fmt.Fprintln(out, "  worktree:")
 		for _, wt := range worktrees {
-			fmt.Fprintf(cmd.OutOrStdout(), "    - %s\n", wt.Path)
+			fmt.Fprintf(out, "    - %s\n", wt.Path)
 		}
 	}
 
 	// 未コミット変更のチェック
+	progress := newProgressIndicator(cmd)
+	progress.Start("未コミット変更を確認しています", len(worktrees))
 	hasUncommittedChanges := false
 	var uncommittedWorktrees []git.WorktreeInfo
 	for _, wt := range worktrees {
 		hasChanges, err := hasUncommittedChangesFunc(context.Background(), wt.Path)
+		progress.Advance()
 		if err != nil {
-			fmt.Fprintf(cmd.OutOrStdout(), "警告: %s の未コミット変更チェックに失敗しました: %v\n", wt.Path, err)
+			fmt.Fprintf(progress.Writer(out), "警告: %s の未コミット変更チェックに失敗しました: %v\n", wt.Path, err)
 			continue
 		}
 		if hasChanges {
 			hasUncommittedChanges = true
 			uncommittedWorktrees = append(uncommittedWorktrees, wt)
+			result.Uncommitted = append(result.Uncommitted, wt.Path)
 		}
 	}
+	progress.Stop()
 
 	// 未コミット変更がある場合は警告を表示
 	if hasUncommittedChanges {
-		fmt.Fprintf(cmd.OutOrStdout(), "\n警告: 以下のworktreeに未コミットの変更があります:\n")
+		fmt.Fprintf(out, "\n警告: 以下のworktreeに未コミットの変更があります:\n")
 		for _, wt := range uncommittedWorktrees {
-			fmt.Fprintf(cmd.OutOrStdout(), "  - %s\n", wt.Path)
+			fmt.Fprintf(out, "  - %s\n", wt.Path)
 		}
 	}
 
+	if dryRunFlag {
+		fmt.Fprintln(out, "\n--dry-run のため削除は行いませんでした。")
+		result.DryRun = true
+		return renderJSON(cmd, result)
+	}
+
 	// 確認プロンプト
-	if !forceFlag {
+	confirmDestructive, err := requiresDestructiveConfirmation(cmd, len(windows) > 0, len(worktrees) > 0)
+	if err != nil {
+		return err
+	}
+	if !forceFlag || confirmDestructive {
 		confirmed, err := confirmPromptFunc("本当に削除しますか？ (yes/no): ")
 		if err != nil {
 			return fmt.Errorf("確認の読み取りに失敗しました: %w", err)
 		}
 
 		if !confirmed {
-			fmt.Fprintln(cmd.OutOrStdout(), "削除をキャンセルしました。")
-			return nil
+			fmt.Fprintln(out, "削除をキャンセルしました。")
+			return renderJSON(cmd, result)
 		}
 	}
 
-	// ウィンドウを削除
+	// ウィンドウを削除（ウィンドウはまとめて1件、worktreeは1つずつ進捗に数える）
+	steps := len(worktrees)
+	if len(windows) > 0 {
+		steps++
+	}
+	progress.Start("リソースを削除しています", steps)
 	windowErrors := []error{}
 	if len(windows) > 0 {
 		windowNames := getWindowNames(windows)
 		if err := killWindowsFunc(sessionName, windowNames); err != nil {
 			windowErrors = append(windowErrors, fmt.Errorf("ウィンドウの削除に失敗しました: %w", err))
 		}
+		progress.Advance()
 	}
 
 	// worktreeを削除
@@ -348,37 +431,180 @@ func cleanAllWindows(cmd *cobra.Command, sessionName string) error {
 		if err := removeWorktreeFunc(context.Background(), wt.Path); err != nil {
 			worktreeErrors = append(worktreeErrors, fmt.Errorf("worktree %s の削除に失敗しました: %w", wt.Path, err))
 		}
+		progress.Advance()
 	}
+	progress.Stop()
 
 	// 結果を表示
 	if len(windows) > 0 || len(worktrees) > 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "以下のリソースを削除しました:")
+		fmt.Fprintln(out, "以下のリソースを削除しました:")
 		if len(windows) > 0 {
-			fmt.Fprintln(cmd.OutOrStdout(), "  ウィンドウ:")
+			fmt.Fprintln(out, "  ウィンドウ:")
 			for _, window := range windows {
-				fmt.Fprintf(cmd.OutOrStdout(), "    - %s\n", window.Name)
+				fmt.Fprintf(out, "    - %s\n", window.Name)
 			}
 		}
 		if len(worktrees) > 0 {
-			fmt.Fprintln(cmd.OutOrStdout(), "  worktree:")
+			fmt.Fprintln(out, "  worktree:")
 			for _, wt := range worktrees {
-				fmt.Fprintf(cmd.OutOrStdout(), "    - %s\n", wt.Path)
+				fmt.Fprintf(out, "    - %s\n", wt.Path)
 			}
 		}
 	}
 
+	result.Removed = true
+	for _, err := range append(windowErrors, worktreeErrors...) {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
 	// エラーがあれば報告
 	if len(windowErrors) > 0 || len(worktreeErrors) > 0 {
-		fmt.Fprintf(cmd.OutOrStdout(), "\n以下のエラーが発生しました:\n")
+		fmt.Fprintf(out, "\n以下のエラーが発生しました:\n")
 		for _, err := range windowErrors {
-			fmt.Fprintf(cmd.OutOrStdout(), "  - %v\n", err)
+			fmt.Fprintf(out, "  - %v\n", err)
 		}
 		for _, err := range worktreeErrors {
-			fmt.Fprintf(cmd.OutOrStdout(), "  - %v\n", err)
+			fmt.Fprintf(out, "  - %v\n", err)
 		}
 	}
 
-	return nil
+	return renderJSON(cmd, result)
+}
+
+// isOsobaWorktree はosobaが作成したworktreeのパスかを返す
+func isOsobaWorktree(path string) bool {
+	return strings.Contains(path, ".git/worktree/") || strings.Contains(path, ".git/osoba/") || strings.Contains(path, "/osoba/worktrees/")
+}
+
+// planIssueCleanup は削除せずに、自動クリーンアップと同じ計画でIssueの削除対象のリソースを表示する（--dry-run）
+func planIssueCleanup(cmd *cobra.Command, sessionName string, issueNumber int) error {
+	plan, err := planCleanupFunc(context.Background(), sessionName, issueNumber)
+	if err != nil {
+		return fmt.Errorf("クリーンアップの計画の作成に失敗しました: %w", err)
+	}
+
+	out := textOut(cmd)
+	result := &cleanResult{
+		IssueNumber: issueNumber,
+		Windows:     make([]string, 0, len(plan.Windows)),
+		Worktrees:   plan.Worktrees,
+		Uncommitted: []string{},
+		DryRun:      true,
+		Errors:      []string{},
+		Plan:        plan,
+	}
+	for _, window := range plan.Windows {
+		result.Windows = append(result.Windows, window.Name)
+	}
+	for _, path := range plan.Worktrees {
+		hasChanges, err := hasUncommittedChangesFunc(context.Background(), path)
+		if err != nil {
+			fmt.Fprintf(out, "警告: %s の未コミット変更チェックに失敗しました: %v\n", path, err)
+			continue
+		}
+		if hasChanges {
+			result.Uncommitted = append(result.Uncommitted, path)
+		}
+	}
+	if len(result.Uncommitted) > 0 {
+		fmt.Fprintf(out, "警告: 以下のworktreeに未コミットの変更があります:\n")
+		for _, path := range result.Uncommitted {
+			fmt.Fprintf(out, "  - %s\n", path)
+		}
+	}
+
+	printCleanupPlan(out, plan)
+	return renderJSON(cmd, result)
+}
+
+// planCleanup は自動クリーンアップと同じクリーンアップマネージャーでIssueのクリーンアップの計画を作成する
+// osoba cleanは削除前に確認するため、safety.confirm_destructiveでスキップする操作はないものとして計画する
+func planCleanup(ctx context.Context, sessionName string, issueNumber int) (*cleanup.Plan, error) {
+	cfg := config.NewConfig()
+	if _, err := cfg.LoadOrDefaultWithError(viper.GetString("config")); err != nil {
+		return nil, fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+	}
+	cfg.Safety = config.SafetyConfig{}
+
+	nullLogger := &nullLogger{}
+	repo := git.NewRepository(nullLogger)
+	worktreeManager, err := newWorktreeManager(cfg.Worktree, repo, git.NewWorktree(nullLogger), git.NewBranch(nullLogger), git.NewSync(nullLogger))
+	if err != nil {
+		return nil, err
+	}
+	artifactsRoot, err := repo.GetRootPath(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("リポジトリのルートの取得に失敗しました: %w", err)
+	}
+	return newCleanupManager(cfg, sessionName, worktreeManager, artifactsRoot, loadPaneRegistryFunc(), nullLogger).Plan(ctx, issueNumber)
+}
+
+// printCleanupPlan はクリーンアップで削除されるリソースを表示する
+func printCleanupPlan(out io.Writer, plan *cleanup.Plan) {
+	if plan.IsEmpty() {
+		fmt.Fprintf(out, "Issue #%d に関連するリソースが見つかりませんでした。\n", plan.IssueNumber)
+	} else {
+		fmt.Fprintf(out, "Issue #%d のクリーンアップで削除されるリソース:\n", plan.IssueNumber)
+	}
+	if len(plan.Windows) > 0 {
+		fmt.Fprintln(out, "  ウィンドウ:")
+		for _, window := range plan.Windows {
+			fmt.Fprintf(out, "    - %s（ペイン: %d）\n", window.Name, window.Panes)
+		}
+	}
+	printPlanItems(out, "worktree", plan.Worktrees)
+	printPlanItems(out, "ブランチ", plan.Branches)
+	printPlanItems(out, "成果物ディレクトリ", plan.Artifacts)
+	printPlanItems(out, "保存された状態", plan.StateEntries)
+	printPlanItems(out, "確認が必要なためスキップする操作", plan.Skipped)
+	fmt.Fprintln(out, "\n--dry-run のため削除は行いませんでした。")
+}
+
+// printPlanItems はクリーンアップの計画の項目を見出し付きで表示する（項目がない場合は表示しない）
+func printPlanItems(out io.Writer, heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "  %s:\n", heading)
+	for _, item := range items {
+		fmt.Fprintf(out, "    - %s\n", item)
+	}
+}
+
+// requiresDestructiveConfirmation はsafety.confirm_destructiveにより削除前の確認が必要かを判定する
+func requiresDestructiveConfirmation(cmd *cobra.Command, hasWindows, hasWorktrees bool) (bool, error) {
+	if yesFlag {
+		return false, nil
+	}
+
+	safety, err := loadSafetyConfigFunc()
+	if err != nil {
+		return false, err
+	}
+	if (hasWindows && safety.RequiresConfirmation(config.OperationKillWindow)) ||
+		(hasWorktrees && safety.RequiresConfirmation(config.OperationRemoveWorktree)) {
+		fmt.Fprintln(promptOut(cmd), "safety.confirm_destructive が有効なため、削除前に確認します（--yes で省略できます）")
+		return true, nil
+	}
+	return false, nil
+}
+
+// loadWorktreeConfig は設定ファイルからworktreeの設定を読み込む
+func loadWorktreeConfig() (config.WorktreeConfig, error) {
+	cfg := config.NewConfig()
+	if _, err := cfg.LoadOrDefaultWithError(viper.GetString("config")); err != nil {
+		return config.WorktreeConfig{}, fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+	}
+	return cfg.Worktree, nil
+}
+
+// loadSafetyConfig は設定ファイルから破壊的操作の安全設定を読み込む
+func loadSafetyConfig() (config.SafetyConfig, error) {
+	cfg := config.NewConfig()
+	if _, err := cfg.LoadOrDefaultWithError(viper.GetString("config")); err != nil {
+		return config.SafetyConfig{}, fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+	}
+	return cfg.Safety, nil
 }
 
 func getWindowNames(windows []*tmux.WindowInfo) []string {
@@ -394,7 +620,12 @@ func getWindowNames(windows []*tmux.WindowInfo) []string {
 }
 
 func confirmPrompt(prompt string) (bool, error) {
-	fmt.Print(prompt)
+	// JSON出力時は標準出力をJSONのみにするため、プロンプトは標準エラー出力に表示する
+	if isJSONOutput() {
+		fmt.Fprint(os.Stderr, prompt)
+	} else {
+		fmt.Print(prompt)
+	}
 	reader := bufio.NewReader(os.Stdin)
 	response, err := reader.ReadString('\n')
 	if err != nil {
@@ -430,6 +661,9 @@ var (
 	listAllWorktreesFunc      = createListAllWorktreesFunc()
 	hasUncommittedChangesFunc = createHasUncommittedChangesFunc()
 	removeWorktreeFunc        = createRemoveWorktreeFunc()
+	loadSafetyConfigFunc      = loadSafetyConfig
+	loadWorktreeConfigFunc    = loadWorktreeConfig
+	planCleanupFunc           = planCleanup
 )
 
 // WorktreeManagerのインスタンスを作成する関数
@@ -442,7 +676,11 @@ func createListWorktreesForIssueFunc() func(context.Context, int) ([]git.Worktre
 		branch := git.NewBranch(nullLogger)
 		sync := git.NewSync(nullLogger)
 
-		manager, err := git.NewWorktreeManager(repo, worktree, branch, sync)
+		worktreeConfig, err := loadWorktreeConfigFunc()
+		if err != nil {
+			return nil, err
+		}
+		manager, err := newWorktreeManager(worktreeConfig, repo, worktree, branch, sync)
 		if err != nil {
 			return nil, err
 		}
@@ -460,7 +698,11 @@ func createListAllWorktreesFunc() func(context.Context) ([]git.WorktreeInfo, err
 		branch := git.NewBranch(nullLogger)
 		sync := git.NewSync(nullLogger)
 
-		manager, err := git.NewWorktreeManager(repo, worktree, branch, sync)
+		worktreeConfig, err := loadWorktreeConfigFunc()
+		if err != nil {
+			return nil, err
+		}
+		manager, err := newWorktreeManager(worktreeConfig, repo, worktree, branch, sync)
 		if err != nil {
 			return nil, err
 		}
@@ -478,7 +720,11 @@ func createHasUncommittedChangesFunc() func(context.Context, string) (bool, erro
 		branch := git.NewBranch(nullLogger)
 		sync := git.NewSync(nullLogger)
 
-		manager, err := git.NewWorktreeManager(repo, worktree, branch, sync)
+		worktreeConfig, err := loadWorktreeConfigFunc()
+		if err != nil {
+			return false, err
+		}
+		manager, err := newWorktreeManager(worktreeConfig, repo, worktree, branch, sync)
 		if err != nil {
 			return false, err
 		}
@@ -489,6 +735,28 @@ func createHasUncommittedChangesFunc() func(context.Context, string) (bool, erro
 
 func createRemoveWorktreeFunc() func(context.Context, string) error {
 	return func(ctx context.Context, worktreePath string) error {
+		worktreeConfig, err := loadWorktreeConfigFunc()
+		if err != nil {
+			return err
+		}
+
+		// worktree.scratch_dirの場合は作業内容を退避してから削除
+		if worktreeConfig.ScratchDir != "" {
+			nullLogger := &nullLogger{}
+			manager, err := newWorktreeManager(worktreeConfig, git.NewRepository(nullLogger), git.NewWorktree(nullLogger), git.NewBranch(nullLogger), git.NewSync(nullLogger))
+			if err != nil {
+				return err
+			}
+			if discarder, ok := manager.(git.WorkspaceDiscarder); ok {
+				return discarder.DiscardWorkspace(ctx, worktreePath)
+			}
+		}
+
+		// worktree.modeがcloneの場合はcloneのディレクトリを削除
+		if worktreeConfig.Mode == config.WorktreeModeClone {
+			return git.RemoveClone(worktreePath)
+		}
+
 		// 実際の実装では、Worktreeを使用
 		nullLogger := &nullLogger{}
 		worktree := git.NewWorktree(nullLogger)
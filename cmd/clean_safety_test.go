@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/testutil/helpers"
+	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanCmd_ConfirmDestructive(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		safety        config.SafetyConfig
+		confirm       bool
+		wantPrompt    bool
+		wantRemoved   bool
+		wantOutputHas string
+	}{
+		{
+			name:        "無効時は未コミット変更がなければ確認しない",
+			args:        []string{"83"},
+			safety:      config.SafetyConfig{},
+			wantPrompt:  false,
+			wantRemoved: true,
+		},
+		{
+			name:          "有効時は確認し、拒否されたら削除しない",
+			args:          []string{"83"},
+			safety:        config.SafetyConfig{ConfirmDestructive: true},
+			confirm:       false,
+			wantPrompt:    true,
+			wantRemoved:   false,
+			wantOutputHas: "削除をキャンセルしました。",
+		},
+		{
+			name:        "有効時でも--forceだけでは確認を省略しない",
+			args:        []string{"83", "--force"},
+			safety:      config.SafetyConfig{ConfirmDestructive: true},
+			confirm:     true,
+			wantPrompt:  true,
+			wantRemoved: true,
+		},
+		{
+			name:        "--yes指定時は確認しない",
+			args:        []string{"83", "--yes"},
+			safety:      config.SafetyConfig{ConfirmDestructive: true},
+			wantPrompt:  false,
+			wantRemoved: true,
+		},
+		{
+			name:        "allowに含まれる操作は確認しない",
+			args:        []string{"83"},
+			safety:      config.SafetyConfig{ConfirmDestructive: true, Allow: []string{config.OperationKillWindow, config.OperationRemoveWorktree}},
+			wantPrompt:  false,
+			wantRemoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocker := helpers.NewFunctionMocker()
+			defer mocker.Restore()
+
+			prompted := false
+			removed := false
+			mocker.MockFunc(&checkTmuxInstalledFunc, func() error { return nil })
+			mocker.MockFunc(&getRepositoryNameFunc, func() (string, error) { return "osoba", nil })
+			mocker.MockFunc(&sessionExistsFunc, func(name string) (bool, error) { return true, nil })
+			mocker.MockFunc(&listWindowsForIssueFunc, func(sessionName string, issueNumber int) ([]*tmux.WindowInfo, error) {
+				return []*tmux.WindowInfo{{Name: "83-plan"}}, nil
+			})
+			mocker.MockFunc(&listWorktreesForIssueFunc, func(ctx context.Context, issueNumber int) ([]git.WorktreeInfo, error) {
+				return []git.WorktreeInfo{{Path: "/repo/.git/osoba/worktrees/issue-83"}}, nil
+			})
+			mocker.MockFunc(&hasUncommittedChangesFunc, func(ctx context.Context, path string) (bool, error) { return false, nil })
+			mocker.MockFunc(&killWindowsForIssueFunc, func(sessionName string, issueNumber int) error { return nil })
+			mocker.MockFunc(&removeWorktreeFunc, func(ctx context.Context, path string) error {
+				removed = true
+				return nil
+			})
+			mocker.MockFunc(&confirmPromptFunc, func(prompt string) (bool, error) {
+				prompted = true
+				return tt.confirm, nil
+			})
+			mocker.MockFunc(&loadSafetyConfigFunc, func() (config.SafetyConfig, error) { return tt.safety, nil })
+
+			cmd := newCleanCmd()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetArgs(tt.args)
+
+			require.NoError(t, cmd.Execute())
+			assert.Equal(t, tt.wantPrompt, prompted)
+			assert.Equal(t, tt.wantRemoved, removed)
+			if tt.wantOutputHas != "" {
+				assert.Contains(t, buf.String(), tt.wantOutputHas)
+			}
+		})
+	}
+}
+
+func TestStopCmd_ConfirmDestructive(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		confirm     bool
+		wantPrompt  bool
+		wantCleanup bool
+	}{
+		{name: "確認を拒否した場合はクリーンアップしない", args: []string{}, confirm: false, wantPrompt: true, wantCleanup: false},
+		{name: "確認を承諾した場合はクリーンアップする", args: []string{}, confirm: true, wantPrompt: true, wantCleanup: true},
+		{name: "--yes指定時は確認しない", args: []string{"--yes"}, wantPrompt: false, wantCleanup: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocker := helpers.NewFunctionMocker()
+			defer mocker.Restore()
+
+			prompted := false
+			cleaned := false
+			mocker.MockFunc(&getRepoIdentifierFunc, func() (string, error) { return "douhashi-osoba", nil })
+			mocker.MockFunc(&getRepositoryNameFunc, func() (string, error) { return "osoba", nil })
+			mocker.MockFunc(&stopProcessFunc, func(pidFile string) error { return nil })
+			mocker.MockFunc(&performCleanupFunc, func(sessionName string) error {
+				cleaned = true
+				return nil
+			})
+			mocker.MockFunc(&killTmuxSessionFunc, func(sessionName string) error { return nil })
+			mocker.MockFunc(&confirmPromptFunc, func(prompt string) (bool, error) {
+				prompted = true
+				return tt.confirm, nil
+			})
+			mocker.MockFunc(&loadSafetyConfigFunc, func() (config.SafetyConfig, error) {
+				return config.SafetyConfig{ConfirmDestructive: true}, nil
+			})
+
+			cmd := newStopCmd()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetArgs(tt.args)
+
+			require.NoError(t, cmd.Execute())
+			assert.Equal(t, tt.wantPrompt, prompted)
+			assert.Equal(t, tt.wantCleanup, cleaned)
+		})
+	}
+}
+
+func TestLoadSafetyConfig_BrokenConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "osoba.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("github:\n  poll_interval: abc\n"), 0644))
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("config", configPath)
+
+	// 設定ファイルを読み込めない場合は、既定の安全設定で削除を進めずにエラーにする
+	_, err := loadSafetyConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "設定ファイルの読み込みに失敗しました")
+}
@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/douhashi/osoba/internal/cleanup"
 	"github.com/douhashi/osoba/internal/git"
 	"github.com/douhashi/osoba/internal/tmux"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 func TestCleanCmd(t *testing.T) {
@@ -18,6 +22,7 @@ func TestCleanCmd(t *testing.T) {
 		args                     []string
 		allFlag                  bool
 		forceFlag                bool
+		dryRunFlag               bool
 		checkTmuxErr             error
 		getRepoNameErr           error
 		repoName                 string
@@ -32,6 +37,7 @@ func TestCleanCmd(t *testing.T) {
 		hasUncommittedChangesErr error
 		uncommittedChangesMap    map[string]bool
 		removeWorktreeErr        error
+		plan                     *cleanup.Plan
 		expectedOutput           string
 		expectedError            string
 	}{
@@ -53,6 +59,37 @@ func TestCleanCmd(t *testing.T) {
 			},
 			expectedOutput: "Issue #83 のリソースを削除しました:\n  ウィンドウ:\n    - 83-plan\n    - 83-implement\n    - 83-review\n  worktree:\n    - /repo/.git/osoba/worktrees/issue-83\n",
 		},
+		{
+			name:          "正常系: --dry-runでは削除せずに削除対象を表示",
+			args:          []string{"83"},
+			dryRunFlag:    true,
+			repoName:      "test-repo",
+			sessionExists: true,
+			plan: &cleanup.Plan{
+				IssueNumber:  83,
+				Windows:      []cleanup.PlanWindow{{Session: "osoba-test-repo", Name: "83-plan", Panes: 2}},
+				Worktrees:    []string{"/repo/.git/osoba/worktrees/issue-83"},
+				Branches:     []string{"osoba/#83"},
+				Artifacts:    []string{"/repo/.git/osoba/artifacts/issue-83"},
+				StateEntries: []string{"panes:83/implement"},
+			},
+			uncommittedChangesMap: map[string]bool{
+				"/repo/.git/osoba/worktrees/issue-83": true,
+			},
+			listWindowsErr:    errors.New("should not be called"),
+			killWindowsErr:    errors.New("should not be called"),
+			removeWorktreeErr: errors.New("should not be called"),
+			expectedOutput:    "警告: 以下のworktreeに未コミットの変更があります:\n  - /repo/.git/osoba/worktrees/issue-83\nIssue #83 のクリーンアップで削除されるリソース:\n  ウィンドウ:\n    - 83-plan（ペイン: 2）\n  worktree:\n    - /repo/.git/osoba/worktrees/issue-83\n  ブランチ:\n    - osoba/#83\n  成果物ディレクトリ:\n    - /repo/.git/osoba/artifacts/issue-83\n  保存された状態:\n    - panes:83/implement\n\n--dry-run のため削除は行いませんでした。\n",
+		},
+		{
+			name:           "正常系: --dry-runで削除対象がない場合",
+			args:           []string{"999"},
+			dryRunFlag:     true,
+			repoName:       "test-repo",
+			sessionExists:  true,
+			plan:           &cleanup.Plan{IssueNumber: 999},
+			expectedOutput: "Issue #999 に関連するリソースが見つかりませんでした。\n\n--dry-run のため削除は行いませんでした。\n",
+		},
 		{
 			name:           "正常系: 該当するリソースがない場合",
 			args:           []string{"999"},
@@ -218,6 +255,7 @@ func TestCleanCmd(t *testing.T) {
 			origListAllWorktrees := listAllWorktreesFunc
 			origHasUncommittedChanges := hasUncommittedChangesFunc
 			origRemoveWorktree := removeWorktreeFunc
+			origPlanCleanup := planCleanupFunc
 
 			// テスト後に復元
 			defer func() {
@@ -233,6 +271,7 @@ func TestCleanCmd(t *testing.T) {
 				listAllWorktreesFunc = origListAllWorktrees
 				hasUncommittedChangesFunc = origHasUncommittedChanges
 				removeWorktreeFunc = origRemoveWorktree
+				planCleanupFunc = origPlanCleanup
 			}()
 
 			// モック設定
@@ -293,6 +332,9 @@ func TestCleanCmd(t *testing.T) {
 			removeWorktreeFunc = func(ctx context.Context, worktreePath string) error {
 				return tt.removeWorktreeErr
 			}
+			planCleanupFunc = func(ctx context.Context, sessionName string, issueNumber int) (*cleanup.Plan, error) {
+				return tt.plan, nil
+			}
 
 			// コマンド実行
 			cmd := newCleanCmd()
@@ -307,6 +349,9 @@ func TestCleanCmd(t *testing.T) {
 			if tt.forceFlag {
 				cmd.Flags().Set("force", "true")
 			}
+			if tt.dryRunFlag {
+				cmd.Flags().Set("dry-run", "true")
+			}
 
 			err := cmd.Execute()
 
@@ -507,3 +552,21 @@ func TestGetWindowNames(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadWorktreeConfig_BrokenConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "osoba.yml")
+	if err := os.WriteFile(configPath, []byte("github:\n  poll_interval: abc\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("config", configPath)
+
+	// 設定ファイルを読み込めない場合は、既定のworktreeの設定で削除を進めずにエラーにする
+	if _, err := loadWorktreeConfig(); err == nil || !strings.Contains(err.Error(), "設定ファイルの読み込みに失敗しました") {
+		t.Errorf("loadWorktreeConfig() error = %v, want config load error", err)
+	}
+	if err := createRemoveWorktreeFunc()(context.Background(), filepath.Join(t.TempDir(), "issue-12")); err == nil {
+		t.Error("removeWorktree() error = nil, want config load error")
+	}
+}
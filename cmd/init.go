@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"embed"
 	"fmt"
@@ -16,7 +17,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-//go:embed templates/* templates/commands/*
+//go:embed templates/* templates/commands/* templates/issue_templates/*
 var templateFS embed.FS
 
 // githubInterface はテスト用のGitHubクライアントインターフェース
@@ -70,72 +71,119 @@ func GetRemoteURL(remoteName string) (string, error) {
 	return getRemoteURLFunc(remoteName)
 }
 
+// initStep は初期化の1ステップ
+type initStep struct {
+	label string // 進捗表示の見出し（位置揃えの空白を含む。番号は実行時に付与する）
+	name  string
+	run   func(out, errOut io.Writer) error // 結果の記号（✅、⚠️）をoutに、警告の詳細をerrOutに出力する
+}
+
+// initStepResult は初期化の各ステップの結果（--output json）
+type initStepResult struct {
+	Name     string   `json:"name"`
+	Status   string   `json:"status"`           // ok または warning
+	Detail   string   `json:"detail,omitempty"` // 既存・一部既存など
+	Messages []string `json:"messages,omitempty"`
+}
+
+// initResult はinitの結果（--output json）
+type initResult struct {
+	Steps []initStepResult `json:"steps"`
+}
+
 func newInitCmd() *cobra.Command {
+	var issueTemplates bool
+	var adopt adoptOptions
+	var fromExisting bool
 	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "プロジェクトを初期化",
-		Long:  `osobaプロジェクトのための初期設定を行います。`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			out := cmd.OutOrStdout()
-			errOut := cmd.ErrOrStderr()
+		Long: `osobaプロジェクトのための初期設定を行います。
 
-			// 初期化開始メッセージ
-			fmt.Fprintln(out, "🚀 osobaの初期化を開始します...")
-			fmt.Fprintln(out, "")
+--issue-templates を指定すると、計画フェーズが扱いやすい構成（ユーザーストーリー・受け入れ条件など）の
+GitHub Issueテンプレートを .github/ISSUE_TEMPLATE に配置します。
 
-			// 1. Gitリポジトリの確認
-			fmt.Fprint(out, "[1/8] Gitリポジトリの確認          ")
-			if err := checkGitRepository(out); err != nil {
-				fmt.Fprintln(out, "❌")
-				return err
+--from-existing を指定すると、ワークフローが稼働中のリポジトリにosobaを導入します。
+既存のラベル・オープンなIssue・worktree・tmuxセッションを調べ、osobaのステータスラベルに
+対応する既存のラベルを対話的に選択して、実態に合わせた設定ファイルと状態ファイルを書き出します。
+--yes を指定すると、名前から推測したラベルを確認せずに採用します。`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromExisting {
+				return runInitFromExisting(cmd, adopt)
 			}
 
-			// 2. 必要ツールの確認
-			fmt.Fprint(out, "[2/9] 必要なツールの確認            ")
-			if err := checkRequiredTools(out); err != nil {
-				fmt.Fprintln(out, "❌")
-				return err
+			steps := []initStep{
+				{label: "Gitリポジトリの確認          ", name: "Gitリポジトリの確認", run: func(out, errOut io.Writer) error {
+					return checkGitRepository(out)
+				}},
+				{label: "必要なツールの確認            ", name: "必要なツールの確認", run: func(out, errOut io.Writer) error {
+					return checkRequiredTools(out)
+				}},
+				{label: "GitHub CLI (gh)の確認        ", name: "GitHub CLI (gh)の確認", run: checkGitHubCLI},
+				{label: "GitHub認証の確認             ", name: "GitHub認証の確認", run: func(out, errOut io.Writer) error {
+					checkGitHubAuth(out, errOut)
+					return nil
+				}},
+				{label: "GitHubリポジトリへのアクセス確認  ", name: "GitHubリポジトリへのアクセス確認", run: func(out, errOut io.Writer) error {
+					checkRepositoryAccess(out, errOut)
+					return nil
+				}},
+				{label: "設定ファイルの作成           ", name: "設定ファイルの作成", run: func(out, errOut io.Writer) error {
+					if err := setupConfigFile(out); err != nil {
+						return fmt.Errorf("設定ファイルの作成に失敗しました: %w", err)
+					}
+					return nil
+				}},
+				{label: "Claude commandsの配置        ", name: "Claude commandsの配置", run: func(out, errOut io.Writer) error {
+					if err := setupClaudeCommands(out); err != nil {
+						return fmt.Errorf("Claude commandsの配置に失敗しました: %w", err)
+					}
+					return nil
+				}},
+				{label: "ドキュメントシステムの配置   ", name: "ドキュメントシステムの配置", run: func(out, errOut io.Writer) error {
+					if err := setupDocumentSystem(out); err != nil {
+						return fmt.Errorf("ドキュメントシステムの配置に失敗しました: %w", err)
+					}
+					return nil
+				}},
+				// GitHubラベルの作成（エラーは警告）
+				{label: "GitHubラベルの作成           ", name: "GitHubラベルの作成", run: func(out, errOut io.Writer) error {
+					setupGitHubLabels(out, errOut)
+					return nil
+				}},
 			}
-
-			// 3. GitHub CLI (gh)の確認
-			fmt.Fprint(out, "[3/9] GitHub CLI (gh)の確認        ")
-			if err := checkGitHubCLI(out, errOut); err != nil {
-				fmt.Fprintln(out, "❌")
-				return err
+			if issueTemplates {
+				steps = append(steps, initStep{label: "Issueテンプレートの配置      ", name: "Issueテンプレートの配置", run: func(out, errOut io.Writer) error {
+					if err := setupIssueTemplates(out); err != nil {
+						return fmt.Errorf("Issueテンプレートの配置に失敗しました: %w", err)
+					}
+					return nil
+				}})
 			}
 
-			// 4. GitHub認証の確認
-			fmt.Fprint(out, "[4/9] GitHub認証の確認             ")
-			checkGitHubAuth(out, errOut)
-
-			// 5. GitHubリポジトリへのアクセス確認
-			fmt.Fprint(out, "[5/9] GitHubリポジトリへのアクセス確認  ")
-			checkRepositoryAccess(out, errOut)
-
-			// 6. 設定ファイルの作成
-			fmt.Fprint(out, "[6/9] 設定ファイルの作成           ")
-			if err := setupConfigFile(out); err != nil {
-				fmt.Fprintln(out, "❌")
-				return fmt.Errorf("設定ファイルの作成に失敗しました: %w", err)
+			if isJSONOutput() {
+				return runInitStepsJSON(cmd, steps)
 			}
 
-			// 7. Claude commandsの配置
-			fmt.Fprint(out, "[7/9] Claude commandsの配置        ")
-			if err := setupClaudeCommands(out); err != nil {
-				fmt.Fprintln(out, "❌")
-				return fmt.Errorf("Claude commandsの配置に失敗しました: %w", err)
-			}
+			out := cmd.OutOrStdout()
+			errOut := cmd.ErrOrStderr()
 
-			// 8. ドキュメントシステムの配置
-			fmt.Fprint(out, "[8/9] ドキュメントシステムの配置   ")
-			if err := setupDocumentSystem(out); err != nil {
-				fmt.Fprintln(out, "❌")
-				return fmt.Errorf("ドキュメントシステムの配置に失敗しました: %w", err)
-			}
+			// 初期化開始メッセージ
+			fmt.Fprintln(out, "🚀 osobaの初期化を開始します...")
+			fmt.Fprintln(out, "")
 
-			// 9. GitHubラベルの作成（エラーは警告）
-			fmt.Fprint(out, "[9/9] GitHubラベルの作成           ")
-			setupGitHubLabels(out, errOut)
+			// ghコマンドの実行に時間がかかるステップでも止まっていないことが分かるよう、見出しの後ろにスピナーを表示する
+			progress := newProgressIndicator(cmd)
+			for i, step := range steps {
+				fmt.Fprintf(out, "[%d/%d] %s", i+1, len(steps), step.label)
+				progress.Start("", 0)
+				err := step.run(progress.Writer(out), progress.Writer(errOut))
+				progress.Stop()
+				if err != nil {
+					fmt.Fprintln(out, "❌")
+					return err
+				}
+			}
 
 			fmt.Fprintln(out, "")
 
@@ -145,7 +193,42 @@ func newInitCmd() *cobra.Command {
 			return nil
 		},
 	}
-	return cmd
+	cmd.Flags().BoolVar(&issueTemplates, "issue-templates", false, "GitHub Issueテンプレートを .github/ISSUE_TEMPLATE に配置する")
+	cmd.Flags().BoolVar(&fromExisting, "from-existing", false, "既存のワークフローを調べて設定ファイルと状態ファイルを作成する")
+	cmd.Flags().BoolVarP(&adopt.yes, "yes", "y", false, "--from-existing でラベルの候補を確認せずに採用する")
+	return withJSONOutput(cmd)
+}
+
+// runInitStepsJSON は初期化の各ステップを実行し、結果をJSONで出力する
+// 各ステップが出力する結果の記号と警告の詳細を、ステップごとの状態とメッセージに変換する
+func runInitStepsJSON(cmd *cobra.Command, steps []initStep) error {
+	result := initResult{Steps: make([]initStepResult, 0, len(steps))}
+	for _, step := range steps {
+		var out, errOut bytes.Buffer
+		if err := step.run(&out, &errOut); err != nil {
+			return fmt.Errorf("%s: %w", step.name, err)
+		}
+
+		stepResult := initStepResult{Name: step.name, Status: "ok"}
+		mark := strings.TrimSpace(out.String())
+		if strings.HasPrefix(mark, "⚠️") {
+			stepResult.Status = "warning"
+			mark = strings.TrimSpace(strings.TrimPrefix(mark, "⚠️"))
+		} else {
+			mark = strings.TrimSpace(strings.TrimPrefix(mark, "✅"))
+		}
+		if strings.HasPrefix(mark, "(") && strings.HasSuffix(mark, ")") {
+			mark = mark[1 : len(mark)-1]
+		}
+		stepResult.Detail = mark
+		for _, line := range strings.Split(errOut.String(), "\n") {
+			if line = strings.TrimSpace(strings.TrimPrefix(line, "⚠️")); line != "" {
+				stepResult.Messages = append(stepResult.Messages, line)
+			}
+		}
+		result.Steps = append(result.Steps, stepResult)
+	}
+	return renderJSON(cmd, result)
 }
 
 func checkCommand(command string) error {
@@ -277,13 +360,16 @@ func setupConfigFile(out io.Writer) error {
 
 func setupClaudeCommands(out io.Writer) error {
 	// .claude/commands/osoba ディレクトリの作成
-	dir := filepath.Join(".claude", "commands", "osoba")
+	dir := claudeCommandDir
 	if err := mkdirAllFunc(dir, 0755); err != nil {
 		return fmt.Errorf("ディレクトリの作成に失敗しました: %w", err)
 	}
 
+	// プロジェクト種別に応じてテンプレート変数を置換する
+	profile := detectProjectProfile(".")
+
 	// テンプレートファイルの配置
-	files := []string{"plan.md", "implement.md", "review.md", "revise.md", "add-backlog.md"}
+	files := claudeCommandFiles
 	allExist := true
 	someExist := false
 
@@ -307,7 +393,7 @@ func setupClaudeCommands(out io.Writer) error {
 			return fmt.Errorf("テンプレートファイルの読み込みに失敗しました: %w", err)
 		}
 
-		if err := writeFileFunc(dst, data, 0644); err != nil {
+		if err := writeFileFunc(dst, renderCommandTemplate(data, profile), 0644); err != nil {
 			return fmt.Errorf("ファイルの作成に失敗しました: %w", err)
 		}
 	}
@@ -317,6 +403,8 @@ func setupClaudeCommands(out io.Writer) error {
 		fmt.Fprintln(out, "✅ (既存)")
 	} else if someExist {
 		fmt.Fprintln(out, "✅ (一部既存)")
+	} else if profile.Name != "" {
+		fmt.Fprintf(out, "✅ (%s)\n", profile.Name)
 	} else {
 		fmt.Fprintln(out, "✅")
 	}
@@ -356,6 +444,45 @@ func setupDocumentSystem(out io.Writer) error {
 	return nil
 }
 
+// issueTemplateFiles はosoba initで配置するIssueテンプレート
+var issueTemplateFiles = []string{"backlog.md", "bug.md"}
+
+// setupIssueTemplates は計画フェーズが扱いやすい構成のIssueテンプレートを .github/ISSUE_TEMPLATE に配置する
+// 既存のテンプレートは上書きしない
+func setupIssueTemplates(out io.Writer) error {
+	dir := filepath.Join(".github", "ISSUE_TEMPLATE")
+	if err := mkdirAllFunc(dir, 0755); err != nil {
+		return fmt.Errorf("ディレクトリの作成に失敗しました: %w", err)
+	}
+
+	created := 0
+	for _, file := range issueTemplateFiles {
+		dst := filepath.Join(dir, file)
+		if _, err := statFunc(dst); err == nil {
+			continue
+		}
+
+		data, err := templateFS.ReadFile("templates/issue_templates/" + file)
+		if err != nil {
+			return fmt.Errorf("テンプレートファイルの読み込みに失敗しました: %w", err)
+		}
+		if err := writeFileFunc(dst, data, 0644); err != nil {
+			return fmt.Errorf("ファイルの作成に失敗しました: %w", err)
+		}
+		created++
+	}
+
+	switch created {
+	case 0:
+		fmt.Fprintln(out, "✅ (既存)")
+	case len(issueTemplateFiles):
+		fmt.Fprintln(out, "✅")
+	default:
+		fmt.Fprintln(out, "✅ (一部既存)")
+	}
+	return nil
+}
+
 func setupGitHubLabels(out, errOut io.Writer) {
 	// config.GetGitHubTokenを使用してトークンを取得
 	cfg := config.NewConfig()
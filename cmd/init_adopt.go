@@ -0,0 +1,459 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/gh"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/paths"
+	"github.com/douhashi/osoba/internal/watcher"
+	"github.com/spf13/cobra"
+)
+
+// adoptionClient は既存のリポジトリの取り込みで使用するGitHubクライアント
+type adoptionClient interface {
+	ListLabelNames(ctx context.Context, owner, repo string) ([]string, error)
+	ListAllOpenIssues(ctx context.Context, owner, repo string) ([]*github.Issue, error)
+}
+
+// モック用の関数変数
+var createAdoptionClientFunc = func() (adoptionClient, error) {
+	return gh.NewClient(gh.NewRealCommandExecutor())
+}
+
+// adoptLabelRole は既存のラベルに対応付けられるosobaのステータスラベル
+type adoptLabelRole struct {
+	key      string   // github.labelsの設定キー
+	label    string   // osobaの標準ラベル
+	keywords []string // 既存のラベルから候補を推測するキーワード
+}
+
+// adoptLabelRoles は対応付けの対象とするステータスラベル（github.labelsで名前を変更できるもの）
+var adoptLabelRoles = []adoptLabelRole{
+	{key: "plan", label: "status:needs-plan", keywords: []string{"plan", "triage", "todo"}},
+	{key: "ready", label: "status:ready", keywords: []string{"ready", "approved", "accepted"}},
+	{key: "review", label: "status:review-requested", keywords: []string{"review"}},
+	{key: "requires_changes", label: "status:requires-changes", keywords: []string{"changes", "rework"}},
+	{key: "revising", label: "status:revising", keywords: []string{"revis", "fixing"}},
+}
+
+// adoptWorkspacePattern はosobaが作成したworktreeのパス・tmuxウィンドウ名からIssue番号を取り出す
+var adoptWorkspacePattern = regexp.MustCompile(`(?:^|/)issue-(\d+)$|^(\d+)-\w+$`)
+
+// adoptOptions はinit --from-existingのオプション
+type adoptOptions struct {
+	yes bool // ラベルの候補を確認せずに採用する
+}
+
+// adoptionResult は既存のリポジトリの取り込み結果（--output json）
+type adoptionResult struct {
+	ConfigPath    string            `json:"config_path"`
+	ConfigWritten bool              `json:"config_written"` // falseの場合は既存の設定ファイルを残した
+	StatePath     string            `json:"state_path,omitempty"`
+	Labels        map[string]string `json:"labels"`    // github.labelsの設定キーごとのラベル
+	Issues        map[string][]int  `json:"issues"`    // osobaのステータスラベルごとのIssue
+	Worktrees     []string          `json:"worktrees"` // osobaが作成したworktree
+	Windows       []string          `json:"windows"`   // osobaのtmuxセッションのIssueウィンドウ
+	Orphaned      []int             `json:"orphaned"`  // 作業環境はあるがステータスラベルのないIssue
+}
+
+// runInitFromExisting はワークフローが稼働中のリポジトリにosobaを導入する
+// 既存のラベル・オープンなIssue・worktree・tmuxセッションを調べ、実態に合わせた設定ファイルと状態ファイルを書き出す
+func runInitFromExisting(cmd *cobra.Command, opts adoptOptions) error {
+	ctx := context.Background()
+	out := textOut(cmd)
+	// JSON出力時は対話せずに候補を採用する
+	if isJSONOutput() {
+		opts.yes = true
+	}
+
+	repoInfo, err := getGitHubRepoInfoFunc(ctx)
+	if err != nil {
+		return fmt.Errorf("GitHubリポジトリ情報の取得に失敗しました: %w", err)
+	}
+	client, err := createAdoptionClientFunc()
+	if err != nil {
+		return fmt.Errorf("GitHubクライアントの作成に失敗しました: %w", err)
+	}
+
+	fmt.Fprintf(out, "🔍 %s/%s の既存のワークフローを調べています...\n\n", repoInfo.Owner, repoInfo.Repo)
+
+	existing, err := client.ListLabelNames(ctx, repoInfo.Owner, repoInfo.Repo)
+	if err != nil {
+		return fmt.Errorf("ラベル一覧の取得に失敗しました: %w", err)
+	}
+	issues, err := client.ListAllOpenIssues(ctx, repoInfo.Owner, repoInfo.Repo)
+	if err != nil {
+		return fmt.Errorf("オープンなIssueの取得に失敗しました: %w", err)
+	}
+
+	result := &adoptionResult{ConfigPath: ".osoba.yml", Labels: make(map[string]string)}
+	reader := bufio.NewReader(cmd.InOrStdin())
+	for _, role := range adoptLabelRoles {
+		label, err := chooseAdoptedLabel(reader, out, role, existing, result.Labels, opts)
+		if err != nil {
+			return err
+		}
+		result.Labels[role.key] = label
+	}
+
+	result.Issues = classifyAdoptedIssues(issues, result.Labels)
+	result.Worktrees, result.Windows = findOsobaWorkspaces(ctx)
+	result.Orphaned = findOrphanedIssues(result.Issues, result.Worktrees, result.Windows)
+
+	written, err := writeAdoptedConfig(result.ConfigPath, result.Labels)
+	if err != nil {
+		return err
+	}
+	result.ConfigWritten = written
+
+	if repoIdentifier, err := getRepoIdentifierFunc(); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  リポジトリ識別子の取得に失敗したため状態ファイルを書き出しません: %v\n", err)
+	} else {
+		result.StatePath = paths.NewPathManager("").StateFile(repoIdentifier)
+		if err := watcher.WriteStatusState(result.StatePath, buildAdoptedState(repoInfo.Owner, repoInfo.Repo, issues, result.Labels)); err != nil {
+			return fmt.Errorf("状態ファイルの書き出しに失敗しました: %w", err)
+		}
+	}
+
+	if isJSONOutput() {
+		return renderJSON(cmd, result)
+	}
+	printAdoptionResult(out, result)
+	return nil
+}
+
+// chooseAdoptedLabel はosobaのステータスラベルに対応する既存のラベルを選ぶ
+// 標準のラベルがすでにある場合はそれを使い、ない場合は既存のラベルから選択させる
+func chooseAdoptedLabel(reader *bufio.Reader, out io.Writer, role adoptLabelRole, existing []string, chosen map[string]string, opts adoptOptions) (string, error) {
+	if hasLabelName(existing, role.label) {
+		return role.label, nil
+	}
+
+	var candidates []string
+	for _, name := range existing {
+		if strings.HasPrefix(name, "status:") || isChosenLabel(chosen, name) {
+			continue
+		}
+		candidates = append(candidates, name)
+	}
+	suggestion := suggestAdoptedLabel(role, candidates)
+	if opts.yes || len(candidates) == 0 {
+		if suggestion == "" {
+			return role.label, nil
+		}
+		return suggestion, nil
+	}
+
+	fmt.Fprintf(out, "%s に対応する既存のラベルを選択してください:\n", role.label)
+	for i, name := range candidates {
+		fmt.Fprintf(out, "  %d) %s\n", i+1, name)
+	}
+	defaultLabel := role.label
+	if suggestion != "" {
+		defaultLabel = suggestion
+	}
+	for {
+		fmt.Fprintf(out, "番号を入力してください（Enter: %s、0: osobaの標準ラベルを使用）: ", defaultLabel)
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			if err == io.EOF {
+				return defaultLabel, nil
+			}
+			return "", fmt.Errorf("入力の読み込みに失敗しました: %w", err)
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			return defaultLabel, nil
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n == 0 {
+			return role.label, nil
+		}
+		if err == nil && n >= 1 && n <= len(candidates) {
+			return candidates[n-1], nil
+		}
+		fmt.Fprintf(out, "0〜%d の番号を入力してください\n", len(candidates))
+	}
+}
+
+// suggestAdoptedLabel はキーワードに一致する最初のラベルを候補として返す（一致しない場合は空文字）
+func suggestAdoptedLabel(role adoptLabelRole, candidates []string) string {
+	for _, keyword := range role.keywords {
+		for _, name := range candidates {
+			if strings.Contains(strings.ToLower(name), keyword) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func isChosenLabel(chosen map[string]string, name string) bool {
+	for _, label := range chosen {
+		if label == name {
+			return true
+		}
+	}
+	return false
+}
+
+// adoptedStatusLabels は対応付けたラベルをosobaのステータスラベルに変換する表を返す
+func adoptedStatusLabels(labels map[string]string) map[string]string {
+	statusLabels := make(map[string]string)
+	for _, label := range watcher.StatusLabels {
+		statusLabels[label] = label
+	}
+	for _, role := range adoptLabelRoles {
+		if label := labels[role.key]; label != "" {
+			statusLabels[label] = role.label
+		}
+	}
+	return statusLabels
+}
+
+// classifyAdoptedIssues はオープンなIssueをosobaのステータスラベルごとに分類する
+func classifyAdoptedIssues(issues []*github.Issue, labels map[string]string) map[string][]int {
+	statusLabels := adoptedStatusLabels(labels)
+	classified := make(map[string][]int)
+	for _, issue := range issues {
+		if issue == nil || issue.Number == nil {
+			continue
+		}
+		for _, label := range issue.Labels {
+			if label == nil || label.Name == nil {
+				continue
+			}
+			if status, ok := statusLabels[*label.Name]; ok {
+				classified[status] = append(classified[status], *issue.Number)
+			}
+		}
+	}
+	return classified
+}
+
+// buildAdoptedState はosoba statusが参照する状態を既存のIssueから作成する
+func buildAdoptedState(owner, repo string, issues []*github.Issue, labels map[string]string) *watcher.StatusState {
+	statusLabels := adoptedStatusLabels(labels)
+	state := &watcher.StatusState{
+		UpdatedAt: time.Now(),
+		Owner:     owner,
+		Repo:      repo,
+		Issues:    make(map[string][]watcher.StatusStateIssue),
+	}
+	for _, issue := range issues {
+		if issue == nil || issue.Number == nil {
+			continue
+		}
+		title := ""
+		if issue.Title != nil {
+			title = *issue.Title
+		}
+		for _, label := range issue.Labels {
+			if label == nil || label.Name == nil {
+				continue
+			}
+			if status, ok := statusLabels[*label.Name]; ok {
+				state.Issues[status] = append(state.Issues[status], watcher.StatusStateIssue{Number: *issue.Number, Title: title})
+			}
+		}
+	}
+	return state
+}
+
+// findOsobaWorkspaces はosobaが作成したworktreeとtmuxウィンドウを返す（取得できない場合は空）
+func findOsobaWorkspaces(ctx context.Context) ([]string, []string) {
+	worktrees := []string{}
+	if all, err := listAllWorktreesFunc(ctx); err == nil {
+		for _, wt := range all {
+			if strings.Contains(wt.Path, ".git/osoba/") {
+				worktrees = append(worktrees, wt.Path)
+			}
+		}
+	}
+
+	windows := []string{}
+	if repoName, err := getRepositoryNameFunc(); err == nil {
+		sessionName := fmt.Sprintf("osoba-%s", repoName)
+		if exists, err := sessionExistsFunc(sessionName); err == nil && exists {
+			if found, err := listWindowsByPatternFunc(sessionName, `^\d+-\w+$|^issue-\d+$`); err == nil {
+				windows = getWindowNames(found)
+			}
+		}
+	}
+	return worktrees, windows
+}
+
+// findOrphanedIssues は作業環境はあるがステータスラベルのないIssueを返す
+func findOrphanedIssues(classified map[string][]int, worktrees, windows []string) []int {
+	labeled := make(map[int]bool)
+	for _, numbers := range classified {
+		for _, n := range numbers {
+			labeled[n] = true
+		}
+	}
+
+	seen := make(map[int]bool)
+	orphaned := []int{}
+	for _, name := range append(append([]string{}, worktrees...), windows...) {
+		matches := adoptWorkspacePattern.FindStringSubmatch(name)
+		if matches == nil {
+			continue
+		}
+		n, _ := strconv.Atoi(matches[1] + matches[2])
+		if n > 0 && !labeled[n] && !seen[n] {
+			seen[n] = true
+			orphaned = append(orphaned, n)
+		}
+	}
+	sort.Ints(orphaned)
+	return orphaned
+}
+
+// writeAdoptedConfig は対応付けたラベルを含む設定ファイルを作成する
+// 既存の設定ファイルは上書きせず、falseを返す
+func writeAdoptedConfig(path string, labels map[string]string) (bool, error) {
+	if _, err := statFunc(path); err == nil {
+		return false, nil
+	}
+
+	templateContent, err := templateFS.ReadFile("templates/config.yml")
+	if err != nil {
+		return false, fmt.Errorf("設定ファイルテンプレートの読み込みに失敗しました: %w", err)
+	}
+	content := string(templateContent)
+	if block := adoptedLabelsYAML(labels); block != "" {
+		content = strings.Replace(content, "github:\n", "github:\n"+block+adoptedWorkflowYAML(labels), 1)
+	}
+
+	if err := writeFileFunc(path, []byte(content), 0644); err != nil {
+		return false, fmt.Errorf("設定ファイルの作成に失敗しました: %w", err)
+	}
+	return true, nil
+}
+
+// adoptedLabelsYAML は標準と異なるラベルのgithub.labels設定を返す（すべて標準の場合は空文字）
+func adoptedLabelsYAML(labels map[string]string) string {
+	var b strings.Builder
+	for _, role := range adoptLabelRoles {
+		if label := labels[role.key]; label != "" && label != role.label {
+			fmt.Fprintf(&b, "    %s: %q\n", role.key, label)
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return "  # osoba init --from-existing で既存のラベルに対応付けたステータスラベル\n  labels:\n" + b.String()
+}
+
+// adoptedWorkflowYAML は既定の遷移のラベルを対応付けたラベルに置き換えたgithub.workflow設定を返す
+// 遷移はgithub.labelsとは別に判定に使われるため、ラベルを変更した場合は遷移も合わせて書き出す
+func adoptedWorkflowYAML(labels map[string]string) string {
+	adopted := func(label string) string {
+		for _, role := range adoptLabelRoles {
+			if role.label == label && labels[role.key] != "" {
+				return labels[role.key]
+			}
+		}
+		return label
+	}
+
+	var b strings.Builder
+	b.WriteString("  workflow:\n    transitions:\n")
+	for _, t := range config.DefaultWorkflowTransitions() {
+		fmt.Fprintf(&b, "      - { from: %q, phase: %s", adopted(t.From), t.Phase)
+		if t.Executing != "" {
+			fmt.Fprintf(&b, ", executing: %q", adopted(t.Executing))
+		}
+		if t.Next != "" {
+			fmt.Fprintf(&b, ", next: %q", adopted(t.Next))
+		}
+		if t.To != "" {
+			fmt.Fprintf(&b, ", to: %q", adopted(t.To))
+		}
+		b.WriteString(" }\n")
+	}
+	return b.String()
+}
+
+// adoptedStatusOrder は取り込み結果に表示するステータスラベルの順序を返す
+func adoptedStatusOrder() []string {
+	order := append([]string{}, watcher.StatusLabels...)
+	for _, role := range adoptLabelRoles {
+		if !hasLabelName(order, role.label) {
+			order = append(order, role.label)
+		}
+	}
+	return order
+}
+
+// printAdoptionResult は取り込み結果を表示する
+func printAdoptionResult(out io.Writer, result *adoptionResult) {
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "ラベルの対応付け:")
+	for _, role := range adoptLabelRoles {
+		label := result.Labels[role.key]
+		if label == role.label {
+			fmt.Fprintf(out, "   %-24s (標準)\n", role.label)
+		} else {
+			fmt.Fprintf(out, "   %-24s → %s\n", role.label, label)
+		}
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "オープンなIssue:")
+	count := 0
+	for _, label := range adoptedStatusOrder() {
+		numbers := result.Issues[label]
+		if len(numbers) == 0 {
+			continue
+		}
+		count++
+		refs := make([]string, len(numbers))
+		for i, n := range numbers {
+			refs[i] = fmt.Sprintf("#%d", n)
+		}
+		fmt.Fprintf(out, "   %-24s %s\n", label, strings.Join(refs, ", "))
+	}
+	if count == 0 {
+		fmt.Fprintln(out, "   ステータスラベルの付いたIssueはありません")
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "作業環境: worktree %d件 / tmuxウィンドウ %d件\n", len(result.Worktrees), len(result.Windows))
+	for _, n := range result.Orphaned {
+		fmt.Fprintf(out, "   ⚠️  Issue #%d は作業環境がありますがステータスラベルがありません（osoba clean %d で削除できます）\n", n, n)
+	}
+
+	fmt.Fprintln(out)
+	if result.ConfigWritten {
+		fmt.Fprintf(out, "✅ %s を作成しました\n", result.ConfigPath)
+	} else {
+		fmt.Fprintf(out, "⚠️  %s はすでに存在するため変更していません\n", result.ConfigPath)
+		if block := adoptedLabelsYAML(result.Labels); block != "" {
+			block += adoptedWorkflowYAML(result.Labels)
+			fmt.Fprintln(out, "   次の設定を github: に追加してください:")
+			for _, line := range strings.Split(strings.TrimRight(block, "\n"), "\n") {
+				fmt.Fprintf(out, "   %s\n", line)
+			}
+		}
+	}
+	if result.StatePath != "" {
+		fmt.Fprintf(out, "✅ 状態ファイルを書き出しました: %s\n", result.StatePath)
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "次のステップ:")
+	fmt.Fprintln(out, "1. osoba labels sync - osobaが使用するラベルを作成")
+	fmt.Fprintln(out, "2. osoba templates sync - Claude commandsを配置")
+	fmt.Fprintln(out, "3. osoba start - Watcherを起動してIssueの監視を開始")
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/paths"
+	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/douhashi/osoba/internal/utils"
+	"github.com/douhashi/osoba/internal/watcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAdoptionClient struct {
+	labels []string
+	issues []*github.Issue
+}
+
+func (c *fakeAdoptionClient) ListLabelNames(ctx context.Context, owner, repo string) ([]string, error) {
+	return c.labels, nil
+}
+
+func (c *fakeAdoptionClient) ListAllOpenIssues(ctx context.Context, owner, repo string) ([]*github.Issue, error) {
+	return c.issues, nil
+}
+
+func newAdoptionIssue(number int, labels ...string) *github.Issue {
+	issue := &github.Issue{Number: github.Int(number), Title: github.String("Issue")}
+	for _, label := range labels {
+		issue.Labels = append(issue.Labels, &github.Label{Name: github.String(label)})
+	}
+	return issue
+}
+
+// setupAdoptionTest はinit --from-existingが参照する外部環境を差し替え、書き込まれた設定ファイルを返す
+func setupAdoptionTest(t *testing.T, client *fakeAdoptionClient, configExists bool) *[]byte {
+	t.Helper()
+	t.Setenv("OSOBA_DATA_DIR", t.TempDir())
+
+	origRepoInfo, origClient, origIdentifier := getGitHubRepoInfoFunc, createAdoptionClientFunc, getRepoIdentifierFunc
+	origWorktrees, origRepoName, origSession, origWindows := listAllWorktreesFunc, getRepositoryNameFunc, sessionExistsFunc, listWindowsByPatternFunc
+	origStat, origWrite := statFunc, writeFileFunc
+	t.Cleanup(func() {
+		getGitHubRepoInfoFunc, createAdoptionClientFunc, getRepoIdentifierFunc = origRepoInfo, origClient, origIdentifier
+		listAllWorktreesFunc, getRepositoryNameFunc, sessionExistsFunc, listWindowsByPatternFunc = origWorktrees, origRepoName, origSession, origWindows
+		statFunc, writeFileFunc = origStat, origWrite
+		outputFormat = outputText
+	})
+
+	getGitHubRepoInfoFunc = func(ctx context.Context) (*utils.GitHubRepoInfo, error) {
+		return &utils.GitHubRepoInfo{Owner: "douhashi", Repo: "osoba"}, nil
+	}
+	createAdoptionClientFunc = func() (adoptionClient, error) { return client, nil }
+	getRepoIdentifierFunc = func() (string, error) { return "douhashi/osoba", nil }
+	listAllWorktreesFunc = func(ctx context.Context) ([]git.WorktreeInfo, error) {
+		return []git.WorktreeInfo{
+			{Path: "/repo"},
+			{Path: "/repo/.git/osoba/worktrees/issue-12", Branch: "osoba/#12"},
+			{Path: "/repo/.git/osoba/worktrees/issue-99", Branch: "osoba/#99"},
+		}, nil
+	}
+	getRepositoryNameFunc = func() (string, error) { return "osoba", nil }
+	sessionExistsFunc = func(sessionName string) (bool, error) { return sessionName == "osoba-osoba", nil }
+	listWindowsByPatternFunc = func(sessionName, pattern string) ([]*tmux.WindowInfo, error) {
+		return []*tmux.WindowInfo{{Name: "12-implement"}}, nil
+	}
+	statFunc = func(name string) (os.FileInfo, error) {
+		if configExists {
+			return nil, nil
+		}
+		return nil, os.ErrNotExist
+	}
+	var written []byte
+	writeFileFunc = func(name string, data []byte, perm os.FileMode) error {
+		written = data
+		return nil
+	}
+	return &written
+}
+
+func TestRunInitFromExisting(t *testing.T) {
+	client := &fakeAdoptionClient{
+		labels: []string{"bug", "triage", "approved", "in review", "status:planning"},
+		issues: []*github.Issue{
+			newAdoptionIssue(10, "triage"),
+			newAdoptionIssue(12, "approved", "bug"),
+			newAdoptionIssue(13, "status:planning"),
+			newAdoptionIssue(14, "bug"),
+		},
+	}
+	written := setupAdoptionTest(t, client, false)
+
+	cmd := newInitCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	// status:needs-planはEnterで候補を採用、status:readyは番号で選択、それ以外は標準ラベル
+	cmd.SetIn(strings.NewReader("\n2\n0\n0\n0\n"))
+	cmd.SetArgs([]string{"--from-existing"})
+	require.NoError(t, cmd.Execute())
+
+	config := string(*written)
+	assert.Contains(t, config, "  labels:\n    plan: \"triage\"\n    ready: \"approved\"\n")
+	assert.NotContains(t, config, `    review: "`)
+	// 遷移のラベルも対応付けたラベルに置き換える
+	assert.Contains(t, config, "  workflow:\n    transitions:\n")
+	assert.Contains(t, config, `      - { from: "triage", phase: plan, executing: "status:planning", next: "approved" }`)
+	assert.Contains(t, config, `      - { from: "approved", phase: implement, executing: "status:implementing", next: "status:review-requested" }`)
+	assert.Contains(t, config, `      - { from: "status:requires-changes", phase: revise, to: "approved" }`)
+	assert.Contains(t, out.String(), "status:needs-plan に対応する既存のラベルを選択してください")
+	assert.Contains(t, out.String(), "Issue #99 は作業環境がありますがステータスラベルがありません")
+
+	state, err := watcher.ReadStatusState(paths.NewPathManager("").StateFile("douhashi/osoba"))
+	require.NoError(t, err)
+	assert.Equal(t, []watcher.StatusStateIssue{{Number: 10, Title: "Issue"}}, state.Issues["status:needs-plan"])
+	assert.Equal(t, []watcher.StatusStateIssue{{Number: 12, Title: "Issue"}}, state.Issues["status:ready"])
+	assert.Equal(t, []watcher.StatusStateIssue{{Number: 13, Title: "Issue"}}, state.Issues["status:planning"])
+}
+
+func TestRunInitFromExisting_KeepsExistingConfig(t *testing.T) {
+	client := &fakeAdoptionClient{labels: []string{"status:needs-plan", "approved"}}
+	written := setupAdoptionTest(t, client, true)
+
+	cmd := newInitCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--from-existing", "--yes"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Nil(t, *written)
+	assert.Contains(t, out.String(), ".osoba.yml はすでに存在するため変更していません")
+	assert.Contains(t, out.String(), `ready: "approved"`)
+	assert.NotContains(t, out.String(), "選択してください")
+}
+
+func TestSuggestAdoptedLabel(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       adoptLabelRole
+		candidates []string
+		want       string
+	}{
+		{name: "キーワードに一致", role: adoptLabelRoles[1], candidates: []string{"bug", "Approved"}, want: "Approved"},
+		{name: "キーワードの優先順", role: adoptLabelRoles[0], candidates: []string{"todo", "needs planning"}, want: "needs planning"},
+		{name: "一致しない", role: adoptLabelRoles[2], candidates: []string{"bug"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, suggestAdoptedLabel(tt.role, tt.candidates))
+		})
+	}
+}
+
+func TestRunInitFromExisting_RepoInfoError(t *testing.T) {
+	setupAdoptionTest(t, &fakeAdoptionClient{}, false)
+	getGitHubRepoInfoFunc = func(ctx context.Context) (*utils.GitHubRepoInfo, error) {
+		return nil, errors.New("no remote")
+	}
+
+	cmd := newInitCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--from-existing"})
+	assert.ErrorContains(t, cmd.Execute(), "GitHubリポジトリ情報の取得に失敗しました")
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// projectProfile はプロジェクト種別ごとのテンプレート変数
+type projectProfile struct {
+	Name         string // 表示名
+	TestCommand  string
+	BuildCommand string
+	LintCommand  string
+}
+
+// genericProjectProfile はプロジェクト種別を判定できない場合のテンプレート変数
+var genericProjectProfile = projectProfile{
+	Name:         "",
+	TestCommand:  "your project's standard test command",
+	BuildCommand: "your project's standard build command",
+	LintCommand:  "your project's standard lint command",
+}
+
+// projectDetectors はマーカーファイルとプロジェクト種別の対応（先に一致したものを優先）
+var projectDetectors = []struct {
+	marker  string
+	profile func(dir string) projectProfile
+}{
+	{
+		marker: "go.mod",
+		profile: func(string) projectProfile {
+			return projectProfile{Name: "Go", TestCommand: "`go test ./...`", BuildCommand: "`go build ./...`", LintCommand: "`go vet ./...`"}
+		},
+	},
+	{
+		marker: "Cargo.toml",
+		profile: func(string) projectProfile {
+			return projectProfile{Name: "Rust", TestCommand: "`cargo test`", BuildCommand: "`cargo build`", LintCommand: "`cargo clippy`"}
+		},
+	},
+	{
+		marker:  "package.json",
+		profile: nodeProjectProfile,
+	},
+}
+
+// nodeProjectProfile はロックファイルからパッケージマネージャを判定する
+func nodeProjectProfile(dir string) projectProfile {
+	runner := "npm"
+	switch {
+	case fileExists(filepath.Join(dir, "pnpm-lock.yaml")):
+		runner = "pnpm"
+	case fileExists(filepath.Join(dir, "yarn.lock")):
+		runner = "yarn"
+	}
+	return projectProfile{
+		Name:         "Node.js",
+		TestCommand:  "`" + runner + " test`",
+		BuildCommand: "`" + runner + " run build`",
+		LintCommand:  "`" + runner + " run lint`",
+	}
+}
+
+// detectProjectProfile はディレクトリ内のマーカーファイルからプロジェクト種別を判定する
+func detectProjectProfile(dir string) projectProfile {
+	for _, d := range projectDetectors {
+		if fileExists(filepath.Join(dir, d.marker)) {
+			return d.profile(dir)
+		}
+	}
+	return genericProjectProfile
+}
+
+// renderCommandTemplate はClaude commandテンプレートのプロジェクト変数を置換する
+func renderCommandTemplate(data []byte, profile projectProfile) []byte {
+	replacer := strings.NewReplacer(
+		"{{test-command}}", profile.TestCommand,
+		"{{build-command}}", profile.BuildCommand,
+		"{{lint-command}}", profile.LintCommand,
+	)
+	return []byte(replacer.Replace(string(data)))
+}
+
+func fileExists(path string) bool {
+	_, err := statFunc(path)
+	return err == nil
+}
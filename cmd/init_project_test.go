@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectProjectProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		wantName string
+		wantTest string
+	}{
+		{name: "Goプロジェクト", files: []string{"go.mod"}, wantName: "Go", wantTest: "`go test ./...`"},
+		{name: "Rustプロジェクト", files: []string{"Cargo.toml"}, wantName: "Rust", wantTest: "`cargo test`"},
+		{name: "Node.jsプロジェクト（npm）", files: []string{"package.json"}, wantName: "Node.js", wantTest: "`npm test`"},
+		{name: "Node.jsプロジェクト（yarn）", files: []string{"package.json", "yarn.lock"}, wantName: "Node.js", wantTest: "`yarn test`"},
+		{name: "Node.jsプロジェクト（pnpm）", files: []string{"package.json", "pnpm-lock.yaml"}, wantName: "Node.js", wantTest: "`pnpm test`"},
+		{name: "go.modとpackage.jsonが共存する場合はGoを優先", files: []string{"package.json", "go.mod"}, wantName: "Go", wantTest: "`go test ./...`"},
+		{name: "判定できない場合は汎用", files: nil, wantName: "", wantTest: genericProjectProfile.TestCommand},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, f := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, f), []byte{}, 0644))
+			}
+
+			profile := detectProjectProfile(dir)
+			assert.Equal(t, tt.wantName, profile.Name)
+			assert.Equal(t, tt.wantTest, profile.TestCommand)
+		})
+	}
+}
+
+func TestRenderCommandTemplate(t *testing.T) {
+	profile := projectProfile{Name: "Go", TestCommand: "`go test ./...`", BuildCommand: "`go build ./...`", LintCommand: "`go vet ./...`"}
+
+	got := renderCommandTemplate([]byte("Run {{test-command}}, {{build-command}} and {{lint-command}}"), profile)
+	assert.Equal(t, "Run `go test ./...`, `go build ./...` and `go vet ./...`", string(got))
+
+	// 埋め込みテンプレートに未置換の変数が残らないこと
+	for _, file := range []string{"implement.md", "revise.md"} {
+		data, err := templateFS.ReadFile("templates/commands/" + file)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "{{test-command}}")
+		assert.NotContains(t, string(renderCommandTemplate(data, profile)), "-command}}", file)
+	}
+}
@@ -370,7 +370,7 @@ func TestInitCmd_SetupOperations(t *testing.T) {
 			wantErr: false,
 			wantOutputContains: []string{
 				"🚀 osobaの初期化を開始します",
-				"[1/8] Gitリポジトリの確認          ✅",
+				"[1/9] Gitリポジトリの確認          ✅",
 				"[2/9] 必要なツールの確認            ✅",
 				"[6/9] 設定ファイルの作成           ✅",
 				"[7/9] Claude commandsの配置        ✅",
@@ -829,6 +829,8 @@ func TestSetupClaudeCommands(t *testing.T) {
 				".claude/commands/osoba/implement.md":   true,
 				".claude/commands/osoba/review.md":      true,
 				".claude/commands/osoba/revise.md":      true,
+				".claude/commands/osoba/review-pr.md":   true,
+				".claude/commands/osoba/revise-pr.md":   true,
 				".claude/commands/osoba/add-backlog.md": true,
 			},
 		},
@@ -1075,3 +1077,82 @@ func TestSetupDocumentSystem(t *testing.T) {
 		})
 	}
 }
+
+func TestSetupIssueTemplates(t *testing.T) {
+	origMkdirAll := mkdirAllFunc
+	origWriteFile := writeFileFunc
+	origStat := statFunc
+	defer func() {
+		mkdirAllFunc = origMkdirAll
+		writeFileFunc = origWriteFile
+		statFunc = origStat
+	}()
+
+	tests := []struct {
+		name        string
+		existing    map[string]bool
+		wantOutput  string
+		wantWritten []string
+	}{
+		{
+			name:        "正常系: 全テンプレートを作成",
+			wantOutput:  "✅\n",
+			wantWritten: []string{".github/ISSUE_TEMPLATE/backlog.md", ".github/ISSUE_TEMPLATE/bug.md"},
+		},
+		{
+			name:        "正常系: 既存のテンプレートは上書きしない",
+			existing:    map[string]bool{".github/ISSUE_TEMPLATE/bug.md": true},
+			wantOutput:  "✅ (一部既存)\n",
+			wantWritten: []string{".github/ISSUE_TEMPLATE/backlog.md"},
+		},
+		{
+			name:       "正常系: 全テンプレートが既存",
+			existing:   map[string]bool{".github/ISSUE_TEMPLATE/backlog.md": true, ".github/ISSUE_TEMPLATE/bug.md": true},
+			wantOutput: "✅ (既存)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mkdirPath string
+			mkdirAllFunc = func(path string, perm os.FileMode) error {
+				mkdirPath = path
+				return nil
+			}
+			statFunc = func(name string) (os.FileInfo, error) {
+				if tt.existing[filepath.ToSlash(name)] {
+					return nil, nil
+				}
+				return nil, os.ErrNotExist
+			}
+			written := make(map[string][]byte)
+			var order []string
+			writeFileFunc = func(path string, data []byte, perm os.FileMode) error {
+				written[filepath.ToSlash(path)] = data
+				order = append(order, filepath.ToSlash(path))
+				return nil
+			}
+
+			var out bytes.Buffer
+			if err := setupIssueTemplates(&out); err != nil {
+				t.Fatalf("setupIssueTemplates() error = %v", err)
+			}
+			if out.String() != tt.wantOutput {
+				t.Errorf("output = %q, want %q", out.String(), tt.wantOutput)
+			}
+			if filepath.ToSlash(mkdirPath) != ".github/ISSUE_TEMPLATE" {
+				t.Errorf("mkdir path = %q, want .github/ISSUE_TEMPLATE", mkdirPath)
+			}
+			if strings.Join(order, ",") != strings.Join(tt.wantWritten, ",") {
+				t.Errorf("written = %v, want %v", order, tt.wantWritten)
+			}
+			if data, ok := written[".github/ISSUE_TEMPLATE/backlog.md"]; ok {
+				for _, section := range []string{"name: バックログ", "## ユーザーストーリー", "## 受け入れ条件（完了の定義）"} {
+					if !strings.Contains(string(data), section) {
+						t.Errorf("backlog template does not contain %q", section)
+					}
+				}
+			}
+		})
+	}
+}
@@ -74,7 +74,7 @@ func TestInitCmd_ProgressDisplay(t *testing.T) {
 			name: "正常系: 進行状況表示とチェックマークが表示される",
 			wantOutputContains: []string{
 				"🚀 osobaの初期化を開始します",
-				"[1/8] Gitリポジトリの確認",
+				"[1/9] Gitリポジトリの確認",
 				"[2/9] 必要なツールの確認",
 				"[3/9] GitHub CLI (gh)の確認",
 				"[4/9] GitHub認証の確認",
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	githubClient "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/utils"
+	"github.com/douhashi/osoba/internal/watcher"
+)
+
+// errIssuePickerCanceled はIssueの選択がキャンセルされたことを表す
+var errIssuePickerCanceled = errors.New("Issueの選択をキャンセルしました")
+
+// issueCandidate は選択候補のIssue
+type issueCandidate struct {
+	Number int
+	Phase  string // ステータスラベルから"status:"を除いたもの（planning、implementingなど）
+	Title  string
+}
+
+// String は候補の表示文字列を返す（絞り込みの対象にもなる）
+func (c issueCandidate) String() string {
+	return fmt.Sprintf("#%d [%s] %s", c.Number, c.Phase, c.Title)
+}
+
+// テスト時にモック可能な関数変数
+var (
+	listActiveIssuesFunc = listActiveIssues
+	stdinIsTerminalFunc  = func() bool {
+		info, err := os.Stdin.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0
+	}
+)
+
+// resolveIssueNumber は--issueが省略された場合に、処理中のIssueを対話的に選択させる
+// JSON出力時や標準入力が端末でない場合は選択できないため、--issueの指定を求めるエラーを返す
+func resolveIssueNumber(cmd *cobra.Command, issueNumber int) (int, error) {
+	if cmd.Flags().Changed("issue") {
+		return issueNumber, nil
+	}
+	if isJSONOutput() || !stdinIsTerminalFunc() {
+		return 0, fmt.Errorf("--issue でIssue番号を指定してください")
+	}
+
+	ctx := context.Background()
+	repoInfo, err := getGitHubRepoInfoFunc(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("GitHubリポジトリ情報の取得に失敗しました: %w", err)
+	}
+	candidates, err := listActiveIssuesFunc(ctx, repoInfo)
+	if err != nil {
+		return 0, fmt.Errorf("処理中のIssueの取得に失敗しました: %w", err)
+	}
+	if len(candidates) == 0 {
+		return 0, fmt.Errorf("処理中のIssueはありません。--issue でIssue番号を指定してください")
+	}
+
+	picker := &issuePicker{in: cmd.InOrStdin(), out: cmd.ErrOrStderr()}
+	return picker.Pick(candidates)
+}
+
+// listActiveIssues はosobaのステータスラベルが付いたオープンなIssueをIssue番号順に返す
+func listActiveIssues(ctx context.Context, repoInfo *utils.GitHubRepoInfo) ([]issueCandidate, error) {
+	client, err := githubClient.NewClient("")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool)
+	var candidates []issueCandidate
+	for _, label := range watcher.StatusLabels {
+		issues, err := client.ListIssuesByLabels(ctx, repoInfo.Owner, repoInfo.Repo, []string{label})
+		if err != nil {
+			return nil, fmt.Errorf("ラベル '%s' のIssue取得に失敗: %w", label, err)
+		}
+		for _, issue := range issues {
+			if issue.Number == nil || seen[*issue.Number] {
+				continue
+			}
+			seen[*issue.Number] = true
+			title := ""
+			if issue.Title != nil {
+				title = *issue.Title
+			}
+			candidates = append(candidates, issueCandidate{
+				Number: *issue.Number,
+				Phase:  strings.TrimPrefix(label, "status:"),
+				Title:  title,
+			})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Number < candidates[j].Number })
+	return candidates, nil
+}
+
+// issuePicker は行入力で候補を絞り込んでIssueを選択する
+// 入力した文字列で候補を絞り込み（あいまい一致）、一覧の番号または"#Issue番号"で選択する
+// 絞り込みの結果が1件になった場合はそのIssueを選択し、空行またはEOFでキャンセルする
+type issuePicker struct {
+	in  io.Reader
+	out io.Writer
+}
+
+// Pick は候補から選択されたIssue番号を返す
+func (p *issuePicker) Pick(candidates []issueCandidate) (int, error) {
+	reader := bufio.NewReader(p.in)
+	filtered := candidates
+	for {
+		for i, c := range filtered {
+			fmt.Fprintf(p.out, "  %2d) %s\n", i+1, c)
+		}
+		fmt.Fprint(p.out, "絞り込む文字列・一覧の番号・#Issue番号を入力（空行でキャンセル）: ")
+
+		line, err := reader.ReadString('\n')
+		input := strings.TrimSpace(line)
+		if input == "" {
+			if err != nil && err != io.EOF {
+				return 0, err
+			}
+			fmt.Fprintln(p.out)
+			return 0, errIssuePickerCanceled
+		}
+
+		if strings.HasPrefix(input, "#") {
+			if number, convErr := strconv.Atoi(input[1:]); convErr == nil {
+				for _, c := range candidates {
+					if c.Number == number {
+						return number, nil
+					}
+				}
+				fmt.Fprintf(p.out, "#%d は処理中のIssueではありません\n", number)
+				continue
+			}
+		}
+		if index, convErr := strconv.Atoi(input); convErr == nil && index >= 1 && index <= len(filtered) {
+			return filtered[index-1].Number, nil
+		}
+
+		matched := filterIssueCandidates(candidates, input)
+		switch len(matched) {
+		case 0:
+			fmt.Fprintf(p.out, "%q に一致するIssueはありません\n", input)
+			filtered = candidates
+		case 1:
+			fmt.Fprintf(p.out, "%s を選択しました\n", matched[0])
+			return matched[0].Number, nil
+		default:
+			filtered = matched
+		}
+		if err != nil {
+			return 0, errIssuePickerCanceled
+		}
+	}
+}
+
+// filterIssueCandidates はqueryの文字を順に含む（大文字小文字を区別しない）候補を返す
+// 空白で区切った場合は、すべての語に一致する候補を返す
+func filterIssueCandidates(candidates []issueCandidate, query string) []issueCandidate {
+	terms := strings.Fields(strings.ToLower(query))
+	var matched []issueCandidate
+	for _, c := range candidates {
+		text := strings.ToLower(c.String())
+		ok := true
+		for _, term := range terms {
+			if !fuzzyContains(text, term) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
+
+// fuzzyContains はtextがpatternの文字をこの順に含むかを返す
+func fuzzyContains(text, pattern string) bool {
+	remaining := []rune(pattern)
+	for _, r := range text {
+		if len(remaining) == 0 {
+			break
+		}
+		if unicode.ToLower(r) == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+	return len(remaining) == 0
+}
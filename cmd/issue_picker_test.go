@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/douhashi/osoba/internal/utils"
+)
+
+var pickerCandidates = []issueCandidate{
+	{Number: 12, Phase: "implementing", Title: "Add retry to label manager"},
+	{Number: 34, Phase: "planning", Title: "Support tmux shards"},
+	{Number: 56, Phase: "reviewing", Title: "Fix retry backoff"},
+}
+
+func TestIssuePicker_Pick(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr error
+		wantOut string
+	}{
+		{
+			name:  "一覧の番号で選択",
+			input: "2\n",
+			want:  34,
+		},
+		{
+			name:  "Issue番号で選択",
+			input: "#56\n",
+			want:  56,
+		},
+		{
+			name:    "絞り込みで1件になったら選択",
+			input:   "shards\n",
+			want:    34,
+			wantOut: "#34 [planning] Support tmux shards を選択しました",
+		},
+		{
+			name:  "絞り込んだ一覧の番号で選択",
+			input: "retry\n2\n",
+			want:  56,
+		},
+		{
+			name:  "あいまい一致と複数語での絞り込み",
+			input: "rtry impl\n",
+			want:  12,
+		},
+		{
+			name:    "一致しない場合は全件に戻す",
+			input:   "zzz\n1\n",
+			want:    12,
+			wantOut: `"zzz" に一致するIssueはありません`,
+		},
+		{
+			name:    "処理中ではないIssue番号",
+			input:   "#99\n\n",
+			wantErr: errIssuePickerCanceled,
+			wantOut: "#99 は処理中のIssueではありません",
+		},
+		{
+			name:    "空行でキャンセル",
+			input:   "\n",
+			wantErr: errIssuePickerCanceled,
+		},
+		{
+			name:    "EOFでキャンセル",
+			input:   "",
+			wantErr: errIssuePickerCanceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			picker := &issuePicker{in: strings.NewReader(tt.input), out: out}
+
+			got, err := picker.Pick(pickerCandidates)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.Contains(t, out.String(), tt.wantOut)
+		})
+	}
+}
+
+func TestResolveIssueNumber(t *testing.T) {
+	origRepoInfo := getGitHubRepoInfoFunc
+	origList := listActiveIssuesFunc
+	origStdinIsTerminal := stdinIsTerminalFunc
+	defer func() {
+		getGitHubRepoInfoFunc = origRepoInfo
+		listActiveIssuesFunc = origList
+		stdinIsTerminalFunc = origStdinIsTerminal
+		outputFormat = outputText
+	}()
+
+	getGitHubRepoInfoFunc = func(ctx context.Context) (*utils.GitHubRepoInfo, error) {
+		return &utils.GitHubRepoInfo{Owner: "douhashi", Repo: "osoba"}, nil
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		terminal   bool
+		json       bool
+		candidates []issueCandidate
+		input      string
+		want       int
+		wantErr    string
+	}{
+		{
+			name:     "--issue指定時はそのまま返す",
+			args:     []string{"--issue", "83"},
+			terminal: true,
+			want:     83,
+		},
+		{
+			name:       "省略時は候補から選択",
+			terminal:   true,
+			candidates: pickerCandidates,
+			input:      "3\n",
+			want:       56,
+		},
+		{
+			name:    "端末でない場合はエラー",
+			wantErr: "--issue でIssue番号を指定してください",
+		},
+		{
+			name:     "JSON出力時はエラー",
+			terminal: true,
+			json:     true,
+			wantErr:  "--issue でIssue番号を指定してください",
+		},
+		{
+			name:     "処理中のIssueがない場合はエラー",
+			terminal: true,
+			wantErr:  "処理中のIssueはありません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdinIsTerminalFunc = func() bool { return tt.terminal }
+			listActiveIssuesFunc = func(ctx context.Context, repoInfo *utils.GitHubRepoInfo) ([]issueCandidate, error) {
+				return tt.candidates, nil
+			}
+			outputFormat = outputText
+			if tt.json {
+				outputFormat = outputJSON
+			}
+
+			var issueNumber int
+			cmd := &cobra.Command{}
+			cmd.Flags().IntVar(&issueNumber, "issue", 0, "")
+			require.NoError(t, cmd.Flags().Parse(tt.args))
+			cmd.SetIn(strings.NewReader(tt.input))
+			cmd.SetErr(new(bytes.Buffer))
+
+			got, err := resolveIssueNumber(cmd, issueNumber)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
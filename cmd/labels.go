@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/gh"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/paths"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// labelSyncer はラベル同期用のインターフェース
+type labelSyncer interface {
+	// SetLabelNames は同期するラベルに設定のラベル名を加える
+	SetLabelNames(names []string)
+	SyncLabels(ctx context.Context, owner, repo string, apply bool) (*gh.LabelSyncReport, error)
+}
+
+// モック用の関数変数
+var createLabelSyncerFunc = func() (labelSyncer, error) {
+	return gh.NewClient(gh.NewRealCommandExecutor())
+}
+
+func newLabelsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "labels",
+		Short: "osobaが使用するGitHubラベルを管理",
+		Long:  `osobaが使用するstatus:ラベルの作成・修正・確認を行います。`,
+	}
+
+	cmd.AddCommand(newLabelsSyncCmd())
+	cmd.AddCommand(newLabelsReportCmd())
+
+	return cmd
+}
+
+func newLabelsSyncCmd() *cobra.Command {
+	return withJSONOutput(&cobra.Command{
+		Use:   "sync",
+		Short: "ラベルを作成し、色・説明のずれを修正",
+		Long: `不足しているラベルを作成し、色・説明が定義と異なるラベルを修正します。
+status:名前空間の未知のラベルは変更せず、一覧のみ表示します。`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLabels(cmd, true)
+		},
+	})
+}
+
+func newLabelsReportCmd() *cobra.Command {
+	return withJSONOutput(&cobra.Command{
+		Use:   "report",
+		Short: "ラベルの差分を表示（変更は行わない）",
+		Long: `不足しているラベル、色・説明が定義と異なるラベル、
+watcherを混乱させる可能性のあるstatus:名前空間の未知のラベルを一覧表示します。`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLabels(cmd, false)
+		},
+	})
+}
+
+func runLabels(cmd *cobra.Command, apply bool) error {
+	cfg := config.NewConfig()
+	configPath := viper.ConfigFileUsed()
+	if configPath == "" {
+		configPath = viper.GetString("config")
+	}
+	if _, err := cfg.LoadOrDefaultWithError(configPath); err != nil {
+		return fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("設定の検証に失敗しました: %w", err)
+	}
+
+	ctx := context.Background()
+	repoInfo, err := getGitHubRepoInfoFunc(ctx)
+	if err != nil {
+		return fmt.Errorf("GitHubリポジトリ情報の取得に失敗しました: %w", err)
+	}
+
+	client, err := createLabelSyncerFunc()
+	if err != nil {
+		return fmt.Errorf("GitHubクライアントの作成に失敗しました: %w", err)
+	}
+	// 設定で名前を変更したラベルや、有効にした機能が使うラベルも同期する
+	client.SetLabelNames(cfg.ManagedLabels())
+
+	report, err := client.SyncLabels(ctx, repoInfo.Owner, repoInfo.Repo, apply)
+	if err != nil && apply && cfg.GitHub.OfflineQueue.Enabled && github.IsUnreachableError(err) {
+		// GitHubに接続できない間は同期を保留し、次回のosoba startで反映する
+		if err := savePendingLabelSync(repoInfo.Owner, repoInfo.Repo); err != nil {
+			return fmt.Errorf("ラベルの同期の保留に失敗しました: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "GitHubに接続できないため、ラベルの同期を保留しました（次回の osoba start で反映します）")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("ラベルの同期に失敗しました: %w", err)
+	}
+
+	if isJSONOutput() {
+		// jqで扱いやすいよう、差分がない項目もnullではなく空の配列にする
+		if report.Missing == nil {
+			report.Missing = []gh.LabelDefinition{}
+		}
+		if report.Drifted == nil {
+			report.Drifted = []gh.LabelDrift{}
+		}
+		if report.Unknown == nil {
+			report.Unknown = []string{}
+		}
+		return renderJSON(cmd, report)
+	}
+	printLabelSyncReport(cmd.OutOrStdout(), report)
+	return nil
+}
+
+// pendingLabelSyncPath は保留したラベルの同期を記録するファイルのパスを返す
+func pendingLabelSyncPath(owner, repo string) string {
+	return paths.NewPathManager("").StoreFile(fmt.Sprintf("%s-%s", owner, repo), "label-sync")
+}
+
+// savePendingLabelSync はGitHubに接続できずに実行できなかったラベルの同期を記録する
+func savePendingLabelSync(owner, repo string) error {
+	path := pendingLabelSyncPath(owner, repo)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(time.Now().Format(time.RFC3339)+"\n"), 0644)
+}
+
+// applyPendingLabelSync は保留したラベルの同期があれば実行し、成功した場合は記録を削除する
+// 保留した同期がない場合はfalseを返す
+func applyPendingLabelSync(ctx context.Context, owner, repo string, names []string) (bool, error) {
+	path := pendingLabelSyncPath(owner, repo)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	client, err := createLabelSyncerFunc()
+	if err != nil {
+		return true, err
+	}
+	client.SetLabelNames(names)
+	if _, err := client.SyncLabels(ctx, owner, repo, true); err != nil {
+		return true, err
+	}
+	return true, os.Remove(path)
+}
+
+// printLabelSyncReport はラベル同期の結果を表示する
+func printLabelSyncReport(out io.Writer, report *gh.LabelSyncReport) {
+	if !report.HasChanges() && len(report.Unknown) == 0 {
+		fmt.Fprintln(out, "✅ ラベルは定義と一致しています")
+		return
+	}
+
+	missingHeader, driftedHeader := "不足しているラベル:", "色・説明が異なるラベル:"
+	if report.Applied {
+		missingHeader, driftedHeader = "作成したラベル:", "修正したラベル:"
+	}
+
+	if len(report.Missing) > 0 {
+		fmt.Fprintln(out, missingHeader)
+		for _, label := range report.Missing {
+			fmt.Fprintf(out, "  - %s (#%s)\n", label.Name, label.Color)
+		}
+	}
+
+	if len(report.Drifted) > 0 {
+		fmt.Fprintln(out, driftedHeader)
+		for _, drift := range report.Drifted {
+			fmt.Fprintf(out, "  - %s\n", drift.Name)
+			if !strings.EqualFold(drift.Current.Color, drift.Expected.Color) {
+				fmt.Fprintf(out, "      色: #%s -> #%s\n", drift.Current.Color, drift.Expected.Color)
+			}
+			if drift.Current.Description != drift.Expected.Description {
+				fmt.Fprintf(out, "      説明: %q -> %q\n", drift.Current.Description, drift.Expected.Description)
+			}
+		}
+	}
+
+	if len(report.Unknown) > 0 {
+		fmt.Fprintln(out, "⚠️  osobaが認識しないstatus:ラベル（watcherの動作を妨げる可能性があります）:")
+		for _, name := range report.Unknown {
+			fmt.Fprintf(out, "  - %s\n", name)
+		}
+	}
+
+	if !report.Applied && report.HasChanges() {
+		fmt.Fprintln(out, "\n'osoba labels sync' で修正できます")
+	}
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/gh"
+	"github.com/douhashi/osoba/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubLabelSyncer struct {
+	report     *gh.LabelSyncReport
+	err        error
+	apply      bool
+	labelNames []string
+}
+
+func (s *stubLabelSyncer) SetLabelNames(names []string) {
+	s.labelNames = names
+}
+
+func (s *stubLabelSyncer) SyncLabels(ctx context.Context, owner, repo string, apply bool) (*gh.LabelSyncReport, error) {
+	s.apply = apply
+	if s.report != nil {
+		s.report.Applied = apply
+	}
+	return s.report, s.err
+}
+
+func TestLabelsCmd(t *testing.T) {
+	driftReport := func() *gh.LabelSyncReport {
+		return &gh.LabelSyncReport{
+			Missing: []gh.LabelDefinition{{Name: "status:revising", Color: "d4c5f9"}},
+			Drifted: []gh.LabelDrift{{
+				Name:     "status:ready",
+				Current:  gh.LabelDefinition{Name: "status:ready", Color: "ffffff", Description: "Ready for implementation"},
+				Expected: gh.LabelDefinition{Name: "status:ready", Color: "0e8a16", Description: "Ready for implementation"},
+			}},
+			Unknown: []string{"status:on-hold"},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		report      *gh.LabelSyncReport
+		syncErr     error
+		wantApply   bool
+		wantErr     string
+		wantOutputs []string
+	}{
+		{
+			name:      "reportは変更を行わず差分を表示",
+			args:      []string{"labels", "report"},
+			report:    driftReport(),
+			wantApply: false,
+			wantOutputs: []string{
+				"不足しているラベル:",
+				"status:revising",
+				"色: #ffffff -> #0e8a16",
+				"status:on-hold",
+				"'osoba labels sync' で修正できます",
+			},
+		},
+		{
+			name:      "syncは修正結果を表示",
+			args:      []string{"labels", "sync"},
+			report:    driftReport(),
+			wantApply: true,
+			wantOutputs: []string{
+				"作成したラベル:",
+				"修正したラベル:",
+				"status:on-hold",
+			},
+		},
+		{
+			name:        "差分なし",
+			args:        []string{"labels", "report"},
+			report:      &gh.LabelSyncReport{},
+			wantOutputs: []string{"ラベルは定義と一致しています"},
+		},
+		{
+			name:    "同期エラー",
+			args:    []string{"labels", "sync"},
+			syncErr: errors.New("API error"),
+			wantErr: "ラベルの同期に失敗しました: API error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origRepoInfo := getGitHubRepoInfoFunc
+			origSyncer := createLabelSyncerFunc
+			defer func() {
+				getGitHubRepoInfoFunc = origRepoInfo
+				createLabelSyncerFunc = origSyncer
+			}()
+
+			getGitHubRepoInfoFunc = func(ctx context.Context) (*utils.GitHubRepoInfo, error) {
+				return &utils.GitHubRepoInfo{Owner: "douhashi", Repo: "osoba"}, nil
+			}
+			syncer := &stubLabelSyncer{report: tt.report, err: tt.syncErr}
+			createLabelSyncerFunc = func() (labelSyncer, error) {
+				return syncer, nil
+			}
+
+			rootCmd := NewRootCmd()
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantApply, syncer.apply)
+			// 設定のラベル名（機能が使うラベルを含む）を同期する
+			assert.Contains(t, syncer.labelNames, "status:needs-plan")
+			assert.Contains(t, syncer.labelNames, "status:needs-human")
+			for _, want := range tt.wantOutputs {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestLabelsCmd_DefersSyncWhileUnreachable(t *testing.T) {
+	t.Setenv("OSOBA_DATA_DIR", t.TempDir())
+	configPath := filepath.Join(t.TempDir(), "osoba.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("github:\n  offline_queue:\n    enabled: true\n"), 0644))
+
+	origRepoInfo := getGitHubRepoInfoFunc
+	origSyncer := createLabelSyncerFunc
+	defer func() {
+		getGitHubRepoInfoFunc = origRepoInfo
+		createLabelSyncerFunc = origSyncer
+	}()
+	getGitHubRepoInfoFunc = func(ctx context.Context) (*utils.GitHubRepoInfo, error) {
+		return &utils.GitHubRepoInfo{Owner: "douhashi", Repo: "osoba"}, nil
+	}
+	syncer := &stubLabelSyncer{err: errors.New("error connecting to api.github.com")}
+	createLabelSyncerFunc = func() (labelSyncer, error) {
+		return syncer, nil
+	}
+
+	// GitHubに接続できない場合は同期を保留する
+	rootCmd := NewRootCmd()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"--config", configPath, "labels", "sync"})
+	require.NoError(t, rootCmd.Execute())
+	assert.Contains(t, buf.String(), "ラベルの同期を保留しました")
+	assert.FileExists(t, pendingLabelSyncPath("douhashi", "osoba"))
+
+	// 接続が戻った後の起動時に保留した同期を反映し、記録を削除する
+	syncer.err = nil
+	syncer.report = &gh.LabelSyncReport{}
+	applied, err := applyPendingLabelSync(context.Background(), "douhashi", "osoba", []string{"status:needs-plan"})
+	require.NoError(t, err)
+	assert.True(t, applied)
+	assert.True(t, syncer.apply)
+	assert.NoFileExists(t, pendingLabelSyncPath("douhashi", "osoba"))
+
+	applied, err = applyPendingLabelSync(context.Background(), "douhashi", "osoba", nil)
+	require.NoError(t, err)
+	assert.False(t, applied)
+}
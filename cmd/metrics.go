@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/metrics"
+	"github.com/douhashi/osoba/internal/paths"
+)
+
+// metricsShutdownTimeout は --listen の終了時に処理中のスクレイプを待つ時間
+const metricsShutdownTimeout = 5 * time.Second
+
+func newMetricsCmd() *cobra.Command {
+	var listen string
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "すべての監視プロセスのメトリクスをまとめて表示",
+		Long: `このホストで実行中のすべての監視プロセス（osoba start）から制御ソケットでメトリクスを取得し、
+Prometheusのテキスト形式でまとめて表示します。どのメトリクスもrepoラベル（owner/repo）で監視プロセスを区別します。
+応答しない監視プロセスは osoba_daemon_up が0になります。
+
+--listen を指定すると、/metrics でスクレイプのたびにメトリクスを集約して返すHTTPサーバーとして動作します。
+/healthz はすべての監視プロセスが応答し、不健全な状態でない場合に200、それ以外の場合は503を返します。
+リポジトリごとにポートを用意せず、1つのスクレイプ対象ですべての監視プロセスを監視できます。Ctrl+Cで終了します。
+
+使用例:
+  osoba metrics
+  osoba metrics --listen :9464`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			collector, err := metrics.NewCollector(paths.NewPathManager(""), sendControlCommandFunc)
+			if err != nil {
+				return err
+			}
+			if listen != "" {
+				return serveMetrics(cmd, collector, listen)
+			}
+			return runMetrics(cmd, collector)
+		},
+	}
+	cmd.Flags().StringVar(&listen, "listen", "", "/metrics を公開するアドレス（例: :9464）")
+	return cmd
+}
+
+func runMetrics(cmd *cobra.Command, collector *metrics.Collector) error {
+	daemons, err := collector.Collect()
+	if err != nil {
+		return fmt.Errorf("監視プロセスの一覧の取得に失敗しました: %w", err)
+	}
+	for _, d := range daemons {
+		if d.Err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "警告: %s の監視プロセスからメトリクスを取得できませんでした: %v\n", d.Name(), d.Err)
+		}
+	}
+	return metrics.WritePrometheus(cmd.OutOrStdout(), daemons)
+}
+
+func serveMetrics(cmd *cobra.Command, collector *metrics.Collector, listen string) error {
+	log, err := logger.New()
+	if err != nil {
+		return fmt.Errorf("ロガーの作成に失敗しました: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler(collector, log))
+	mux.Handle("/healthz", metrics.HealthHandler(collector, log))
+	server := &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+		defer shutdownCancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(cmd.OutOrStdout(), "http://%s/metrics でメトリクスを公開しています（Ctrl+Cで終了）\n", listen)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("メトリクスの公開に失敗しました: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsCmd(t *testing.T) {
+	origSend := sendControlCommandFunc
+	defer func() { sendControlCommandFunc = origSend }()
+	dataDir := t.TempDir()
+	t.Setenv("OSOBA_DATA_DIR", dataDir)
+	runDir := filepath.Join(dataDir, "run")
+	require.NoError(t, os.MkdirAll(runDir, 0755))
+	for _, name := range []string{"douhashi_osoba.sock", "douhashi_other.sock"} {
+		require.NoError(t, os.WriteFile(filepath.Join(runDir, name), nil, 0600))
+	}
+
+	sendControlCommandFunc = func(path, command string) (string, error) {
+		assert.Equal(t, "metrics", command)
+		if filepath.Base(path) == "douhashi_other.sock" {
+			return "", errors.New("connection refused")
+		}
+		return `{"repo":"douhashi/osoba","issue_polls":{"total":2,"successful":2,"failed":0}}`, nil
+	}
+
+	cmd := newMetricsCmd()
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, out.String(), `osoba_daemon_up{repo="douhashi/osoba"} 1`)
+	assert.Contains(t, out.String(), `osoba_daemon_up{repo="douhashi_other"} 0`)
+	assert.Contains(t, out.String(), `osoba_issue_polls_total{repo="douhashi/osoba",result="success"} 2`)
+	assert.Contains(t, errOut.String(), "douhashi_other の監視プロセスからメトリクスを取得できませんでした")
+}
@@ -16,23 +16,38 @@ import (
 	"github.com/spf13/viper"
 )
 
+// openResult はopenで解決した接続先（--output json）
+// JSON出力時はセッションに接続せず、接続先のみを出力する
+type openResult struct {
+	Repository    string `json:"repository"`
+	Session       string `json:"session"`
+	Recovered     bool   `json:"recovered"` // セッションを自動復旧したか
+	InsideTmux    bool   `json:"inside_tmux"`
+	AttachCommand string `json:"attach_command"`
+}
+
 func newOpenCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "open",
 		Short: "tmuxセッションに接続",
-		Long:  `現在のGitリポジトリに対応するtmuxセッションに接続します。`,
-		RunE:  runOpen,
+		Long: `現在のGitリポジトリに対応するtmuxセッションに接続します。
+tmux.shards でIssueを振り分けている場合は --shard でシャードのセッションに接続できます。
+--output json を指定した場合は接続せず、接続先のセッションをJSONで出力します。
+
+--editor を指定した場合は、tmuxセッションに接続する代わりにIssueのworktreeをエディタで開きます。
+エディタは OSOBA_EDITOR、editor.command、VISUAL、EDITOR の順に解決します。
+editor.attach（または --attach）を有効にすると、エディタで開いた後にtmuxセッションにも接続します。`,
+		RunE: runOpen,
 	}
-	return cmd
+	cmd.Flags().String("shard", "", "接続するシャードのセッション（tmux.shardsのname）")
+	cmd.Flags().Bool("editor", false, "Issueのworktreeをエディタで開く")
+	cmd.Flags().Int("issue", 0, "エディタで開くIssue番号（省略時は処理中のIssueから選択）")
+	cmd.Flags().Bool("attach", false, "エディタで開いた後にtmuxセッションにも接続する（デフォルトはeditor.attach）")
+	return withJSONOutput(cmd)
 }
 
 func runOpen(cmd *cobra.Command, args []string) error {
-	// 1. tmuxがインストールされているか確認
-	if err := checkTmuxInstalledFunc(); err != nil {
-		return err
-	}
-
-	// 2. 設定を読み込み
+	// 1. 設定を読み込み
 	cfg := config.NewConfig()
 
 	// rootコマンドで読み込まれた設定ファイルのパスを取得
@@ -42,12 +57,30 @@ func runOpen(cmd *cobra.Command, args []string) error {
 		configPath = viper.GetString("config")
 	}
 
-	// 設定ファイルのパスが取得できた場合、またはデフォルトパスから読み込み
-	if configPath != "" {
-		cfg.LoadOrDefault(configPath)
-	} else {
-		// configPathが空の場合もデフォルト設定ファイルをチェック
-		cfg.LoadOrDefault("")
+	// 設定ファイルのパスが取得できた場合、またはデフォルトパスから読み込み（configPathが空の場合はデフォルト設定ファイルをチェック）
+	if _, err := cfg.LoadOrDefaultWithError(configPath); err != nil {
+		return fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+	}
+
+	// 2. --editor の場合はIssueのworktreeをエディタで開く
+	if cmd != nil {
+		if useEditor, _ := cmd.Flags().GetBool("editor"); useEditor {
+			attach := cfg.Editor.Attach
+			if cmd.Flags().Changed("attach") {
+				attach, _ = cmd.Flags().GetBool("attach")
+			}
+			if err := openIssueInEditor(cmd, cfg); err != nil {
+				return err
+			}
+			if !attach || isJSONOutput() {
+				return nil
+			}
+		}
+	}
+
+	// tmuxがインストールされているか確認
+	if err := checkTmuxInstalledFunc(); err != nil {
+		return err
 	}
 
 	// 3. Gitリポジトリ名を取得
@@ -64,6 +97,11 @@ func runOpen(cmd *cobra.Command, args []string) error {
 
 	// 4. セッション名を生成（設定から接頭辞を使用）
 	sessionName := fmt.Sprintf("%s%s", cfg.Tmux.SessionPrefix, repoName)
+	if cmd != nil {
+		if shard, _ := cmd.Flags().GetString("shard"); shard != "" {
+			return openShardSession(cmd, cfg, repoName, sessionName, shard)
+		}
+	}
 
 	// 5. セッションが存在するか確認
 	exists, err := sessionExistsFunc(sessionName)
@@ -79,6 +117,19 @@ func runOpen(cmd *cobra.Command, args []string) error {
 	}
 
 	// 6. tmux内から実行されているか確認
+	if isJSONOutput() {
+		attachCommand := "tmux attach-session -t " + sessionName
+		if isInsideTmux() {
+			attachCommand = "tmux switch-client -t " + sessionName
+		}
+		return renderJSON(cmd, openResult{
+			Repository:    repoName,
+			Session:       sessionName,
+			Recovered:     !exists,
+			InsideTmux:    isInsideTmux(),
+			AttachCommand: attachCommand,
+		})
+	}
 	if isInsideTmux() {
 		// tmux内からの場合は switch-client を使用
 		return switchToSession(sessionName)
@@ -88,6 +139,47 @@ func runOpen(cmd *cobra.Command, args []string) error {
 	return attachToSession(sessionName)
 }
 
+// openShardSession はtmux.shardsのシャードのセッションに接続する
+// シャードのセッションはIssueが振り分けられた時に作成されるため、自動復旧は行わない
+func openShardSession(cmd *cobra.Command, cfg *config.Config, repoName, baseSession, shard string) error {
+	found := false
+	for _, s := range cfg.Tmux.Shards {
+		if s.Name == shard {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("シャード '%s' は tmux.shards に設定されていません", shard)
+	}
+
+	sessionName := baseSession + "-" + shard
+	exists, err := sessionExistsFunc(sessionName)
+	if err != nil {
+		return fmt.Errorf("セッションの確認に失敗しました: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("シャード '%s' のセッション '%s' はまだ作成されていません（振り分けられたIssueがありません）", shard, sessionName)
+	}
+
+	if isJSONOutput() {
+		attachCommand := "tmux attach-session -t " + sessionName
+		if isInsideTmux() {
+			attachCommand = "tmux switch-client -t " + sessionName
+		}
+		return renderJSON(cmd, openResult{
+			Repository:    repoName,
+			Session:       sessionName,
+			InsideTmux:    isInsideTmux(),
+			AttachCommand: attachCommand,
+		})
+	}
+	if isInsideTmux() {
+		return switchToSession(sessionName)
+	}
+	return attachToSession(sessionName)
+}
+
 // isInsideTmux はtmux内から実行されているかを確認
 func isInsideTmux() bool {
 	return os.Getenv("TMUX") != ""
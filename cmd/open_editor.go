@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// editorPresets はeditor.commandに指定できるエディタのプリセット
+var editorPresets = map[string]string{
+	"code":   "code --folder-uri {{uri}}",
+	"cursor": "cursor --folder-uri {{uri}}",
+	"nvim":   "nvim --server $NVIM --remote {{path}}",
+}
+
+// テスト時にモック可能な関数変数
+var (
+	openInEditorFunc      = openInEditor
+	issueWorktreePathFunc = issueWorktreePath
+)
+
+// resolveEditorCommand はエディタのコマンドのテンプレートを解決する
+// OSOBA_EDITOR（ユーザーごとの設定）、editor.command、VISUAL、EDITORの順に使用する
+func resolveEditorCommand(cfg config.EditorConfig, getenv func(string) string) (string, error) {
+	command := getenv("OSOBA_EDITOR")
+	if command == "" {
+		command = cfg.Command
+	}
+	if command == "" {
+		command = getenv("VISUAL")
+	}
+	if command == "" {
+		command = getenv("EDITOR")
+	}
+	if command == "" {
+		return "", fmt.Errorf("エディタが設定されていません（editor.command または OSOBA_EDITOR を設定してください）")
+	}
+	if preset, ok := editorPresets[strings.TrimSpace(command)]; ok {
+		return preset, nil
+	}
+	return command, nil
+}
+
+// buildEditorCommand はテンプレートの{{path}}・{{uri}}をworktreeのパスに置き換えて、エディタの引数を返す
+// プレースホルダーがない場合はパスを最後の引数に追加する
+func buildEditorCommand(template, path string, getenv func(string) string) ([]string, error) {
+	uri := (&url.URL{Scheme: "file", Path: path}).String()
+	fields := strings.Fields(template)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("エディタのコマンドが空です")
+	}
+
+	args := make([]string, 0, len(fields)+1)
+	hasPlaceholder := false
+	for _, field := range fields {
+		if strings.Contains(field, "{{path}}") || strings.Contains(field, "{{uri}}") {
+			hasPlaceholder = true
+		}
+		arg := strings.NewReplacer("{{path}}", path, "{{uri}}", uri).Replace(field)
+		arg = os.Expand(arg, getenv)
+		if arg == "" {
+			return nil, fmt.Errorf("エディタのコマンドの引数 '%s' が空になりました（環境変数が設定されていません）", field)
+		}
+		args = append(args, arg)
+	}
+	if !hasPlaceholder {
+		args = append(args, path)
+	}
+	return args, nil
+}
+
+// openInEditor はエディタを起動し、終了するまで待つ
+func openInEditor(args []string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("エディタの起動に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// issueWorktreePath はIssueのworktreeのパスを返す（worktreeがない場合はエラー）
+func issueWorktreePath(cfg *config.Config, issueNumber int) (string, error) {
+	nullLogger := &nullLogger{}
+	repo := git.NewRepository(nullLogger)
+	worktree := git.NewWorktree(nullLogger)
+	branch := git.NewBranch(nullLogger)
+	sync := git.NewSync(nullLogger)
+
+	manager, err := newWorktreeManager(cfg.Worktree, repo, worktree, branch, sync)
+	if err != nil {
+		return "", err
+	}
+	exists, err := manager.WorktreeExistsForIssue(context.Background(), issueNumber)
+	if err != nil {
+		return "", fmt.Errorf("worktreeの確認に失敗しました: %w", err)
+	}
+	if !exists {
+		return "", fmt.Errorf("Issue #%d のworktreeがありません", issueNumber)
+	}
+	return manager.GetWorktreePathForIssue(issueNumber), nil
+}
+
+// openEditorResult はエディタで開くworktreeとコマンド（--output json）
+// JSON出力時はエディタを起動せず、コマンドのみを出力する
+type openEditorResult struct {
+	Issue    int      `json:"issue"`
+	Worktree string   `json:"worktree"`
+	Command  []string `json:"command"`
+}
+
+// openIssueInEditor は--issue（省略時は選択）のIssueのworktreeをエディタで開く
+func openIssueInEditor(cmd *cobra.Command, cfg *config.Config) error {
+	issueNumber, _ := cmd.Flags().GetInt("issue")
+	issueNumber, err := resolveIssueNumber(cmd, issueNumber)
+	if err != nil {
+		return err
+	}
+
+	template, err := resolveEditorCommand(cfg.Editor, os.Getenv)
+	if err != nil {
+		return err
+	}
+	path, err := issueWorktreePathFunc(cfg, issueNumber)
+	if err != nil {
+		return err
+	}
+	editorArgs, err := buildEditorCommand(template, path, os.Getenv)
+	if err != nil {
+		return err
+	}
+
+	if isJSONOutput() {
+		return renderJSON(cmd, openEditorResult{Issue: issueNumber, Worktree: path, Command: editorArgs})
+	}
+	return openInEditorFunc(editorArgs)
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveEditorCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.EditorConfig
+		env     map[string]string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "OSOBA_EDITORは設定より優先する",
+			cfg:  config.EditorConfig{Command: "code"},
+			env:  map[string]string{"OSOBA_EDITOR": "nvim"},
+			want: "nvim --server $NVIM --remote {{path}}",
+		},
+		{
+			name: "設定のプリセット",
+			cfg:  config.EditorConfig{Command: "cursor"},
+			want: "cursor --folder-uri {{uri}}",
+		},
+		{
+			name: "設定のコマンド",
+			cfg:  config.EditorConfig{Command: "idea {{path}}"},
+			env:  map[string]string{"VISUAL": "vim"},
+			want: "idea {{path}}",
+		},
+		{
+			name: "VISUALはEDITORより優先する",
+			env:  map[string]string{"VISUAL": "emacs", "EDITOR": "vim"},
+			want: "emacs",
+		},
+		{
+			name: "EDITOR",
+			env:  map[string]string{"EDITOR": "vim"},
+			want: "vim",
+		},
+		{
+			name:    "エディタが設定されていない",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			got, err := resolveEditorCommand(tt.cfg, getenv)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBuildEditorCommand(t *testing.T) {
+	path := "/repo/.git/osoba/worktrees/issue-12"
+	tests := []struct {
+		name     string
+		template string
+		env      map[string]string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "uriに置き換える",
+			template: "code --folder-uri {{uri}}",
+			want:     []string{"code", "--folder-uri", "file:///repo/.git/osoba/worktrees/issue-12"},
+		},
+		{
+			name:     "環境変数を展開する",
+			template: "nvim --server $NVIM --remote {{path}}",
+			env:      map[string]string{"NVIM": "/tmp/nvim.sock"},
+			want:     []string{"nvim", "--server", "/tmp/nvim.sock", "--remote", path},
+		},
+		{
+			name:     "プレースホルダーがない場合はパスを追加する",
+			template: "vim",
+			want:     []string{"vim", path},
+		},
+		{
+			name:     "環境変数が設定されていない",
+			template: "nvim --server $NVIM --remote {{path}}",
+			wantErr:  true,
+		},
+		{
+			name:     "空のコマンド",
+			template: "  ",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			got, err := buildEditorCommand(tt.template, path, getenv)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunOpen_Editor(t *testing.T) {
+	originalOpenInEditor := openInEditorFunc
+	originalWorktreePath := issueWorktreePathFunc
+	originalCheckTmux := checkTmuxInstalledFunc
+	defer func() {
+		openInEditorFunc = originalOpenInEditor
+		issueWorktreePathFunc = originalWorktreePath
+		checkTmuxInstalledFunc = originalCheckTmux
+	}()
+	t.Setenv("OSOBA_EDITOR", "code")
+
+	var opened []string
+	openInEditorFunc = func(args []string) error {
+		opened = args
+		return nil
+	}
+	issueWorktreePathFunc = func(cfg *config.Config, issueNumber int) (string, error) {
+		assert.Equal(t, 12, issueNumber)
+		return "/repo/issue-12", nil
+	}
+	tmuxChecked := false
+	checkTmuxInstalledFunc = func() error {
+		tmuxChecked = true
+		return nil
+	}
+
+	cmd := newOpenCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	require.NoError(t, cmd.ParseFlags([]string{"--editor", "--issue", "12"}))
+	require.NoError(t, runOpen(cmd, nil))
+
+	assert.Equal(t, []string{"code", "--folder-uri", "file:///repo/issue-12"}, opened)
+	// editor.attachが無効の場合はtmuxセッションに接続しない
+	assert.False(t, tmuxChecked)
+}
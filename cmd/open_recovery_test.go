@@ -23,6 +23,10 @@ func (m *MockDaemonManager) Start(ctx context.Context, args []string) error {
 	return nil
 }
 
+func (m *MockDaemonManager) Spawn(ctx context.Context, args []string) (int, error) {
+	return 0, nil
+}
+
 func (m *MockDaemonManager) Stop(pidFile string) error {
 	return nil
 }
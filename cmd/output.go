@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// 出力形式（--output）
+const (
+	outputText = "text"
+	outputJSON = "json"
+
+	// jsonOutputAnnotation はJSON出力に対応したコマンドに付与するアノテーション
+	jsonOutputAnnotation = "osoba/json-output"
+)
+
+// outputFormat は--outputフラグの値
+var outputFormat = outputText
+
+// withJSONOutput はコマンドを--output jsonに対応させる
+func withJSONOutput(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[jsonOutputAnnotation] = "true"
+	return cmd
+}
+
+// validateOutputFormat は--outputの値と、実行するコマンドがJSON出力に対応しているかを検証する
+func validateOutputFormat(cmd *cobra.Command) error {
+	switch outputFormat {
+	case outputText:
+		return nil
+	case outputJSON:
+		if cmd.Annotations[jsonOutputAnnotation] != "true" {
+			return fmt.Errorf("%s は --output json に対応していません", cmd.CommandPath())
+		}
+		return nil
+	default:
+		return fmt.Errorf("不正な出力形式です: %s（text または json を指定してください）", outputFormat)
+	}
+}
+
+// isJSONOutput はJSON出力モードかを返す
+func isJSONOutput() bool {
+	return outputFormat == outputJSON
+}
+
+// textOut は人向けの表示の出力先を返す
+// JSON出力時は標準出力をJSONのみにするため、人向けの表示は破棄する
+func textOut(cmd *cobra.Command) io.Writer {
+	if isJSONOutput() {
+		return io.Discard
+	}
+	return cmd.OutOrStdout()
+}
+
+// promptOut は確認プロンプトの出力先を返す（JSON出力時は標準エラー出力）
+func promptOut(cmd *cobra.Command) io.Writer {
+	if isJSONOutput() {
+		return cmd.ErrOrStderr()
+	}
+	return cmd.OutOrStdout()
+}
+
+// renderJSON はJSON出力時に結果を標準出力に書き出す（テキスト出力時は何もしない）
+func renderJSON(cmd *cobra.Command, v interface{}) error {
+	if !isJSONOutput() {
+		return nil
+	}
+	return writeJSON(cmd.OutOrStdout(), v)
+}
+
+// jsonError はJSON出力時のエラー
+type jsonError struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("JSONの出力に失敗しました: %w", err)
+	}
+	return nil
+}
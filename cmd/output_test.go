@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/gh"
+	"github.com/douhashi/osoba/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "不正な出力形式",
+			args:    []string{"labels", "report", "--output", "yaml"},
+			wantErr: "不正な出力形式です: yaml",
+		},
+		{
+			name:    "JSON出力に対応していないコマンド",
+			args:    []string{"resize", "--output", "json"},
+			wantErr: "osoba resize は --output json に対応していません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() { outputFormat = outputText }()
+
+			rootCmd := NewRootCmd()
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLabelsCmd_JSONOutput(t *testing.T) {
+	origRepoInfo := getGitHubRepoInfoFunc
+	origSyncer := createLabelSyncerFunc
+	defer func() {
+		getGitHubRepoInfoFunc = origRepoInfo
+		createLabelSyncerFunc = origSyncer
+		outputFormat = outputText
+	}()
+
+	getGitHubRepoInfoFunc = func(ctx context.Context) (*utils.GitHubRepoInfo, error) {
+		return &utils.GitHubRepoInfo{Owner: "douhashi", Repo: "osoba"}, nil
+	}
+	createLabelSyncerFunc = func() (labelSyncer, error) {
+		return &stubLabelSyncer{report: &gh.LabelSyncReport{
+			Missing: []gh.LabelDefinition{{Name: "status:revising", Color: "d4c5f9"}},
+		}}, nil
+	}
+
+	rootCmd := NewRootCmd()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"labels", "report", "-o", "json"})
+	require.NoError(t, rootCmd.Execute())
+
+	// 標準出力はJSONのみ
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, false, report["applied"])
+	assert.Equal(t, []interface{}{}, report["drifted"])
+	assert.Equal(t, []interface{}{}, report["unknown"])
+	missing := report["missing"].([]interface{})
+	require.Len(t, missing, 1)
+	assert.Equal(t, "status:revising", missing[0].(map[string]interface{})["name"])
+}
+
+func TestRunInitStepsJSON(t *testing.T) {
+	defer func() { outputFormat = outputText }()
+	outputFormat = outputJSON
+
+	steps := []initStep{
+		{name: "成功", run: func(out, errOut io.Writer) error {
+			fmt.Fprintln(out, "✅")
+			return nil
+		}},
+		{name: "既存", run: func(out, errOut io.Writer) error {
+			fmt.Fprintln(out, "✅ (既存)")
+			return nil
+		}},
+		{name: "警告", run: func(out, errOut io.Writer) error {
+			fmt.Fprintln(out, "⚠️")
+			fmt.Fprintln(errOut, "⚠️  GitHub認証が設定されていません")
+			fmt.Fprintln(errOut, "   gh auth login を実行してください")
+			return nil
+		}},
+	}
+
+	cmd := newInitCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	require.NoError(t, runInitStepsJSON(cmd, steps))
+
+	var result initResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, []initStepResult{
+		{Name: "成功", Status: "ok"},
+		{Name: "既存", Status: "ok", Detail: "既存"},
+		{Name: "警告", Status: "warning", Messages: []string{"GitHub認証が設定されていません", "gh auth login を実行してください"}},
+	}, result.Steps)
+}
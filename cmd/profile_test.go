@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportStartupProfile(t *testing.T) {
+	origMarks, origEnabled, origReported := startupMarks, profileStartup, startupReported
+	defer func() {
+		startupMarks, profileStartup, startupReported = origMarks, origEnabled, origReported
+	}()
+
+	startupMarks = []startupMark{
+		{name: "コマンド登録", at: 2 * time.Millisecond},
+		{name: "設定読み込み", at: 5500 * time.Microsecond},
+	}
+
+	t.Run("フラグ未指定の場合は出力しない", func(t *testing.T) {
+		profileStartup, startupReported = false, false
+		var buf bytes.Buffer
+		reportStartupProfile(&buf)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("各段階の所要時間と累計を一度だけ出力する", func(t *testing.T) {
+		profileStartup, startupReported = true, false
+		var buf bytes.Buffer
+		reportStartupProfile(&buf)
+		assert.Contains(t, buf.String(), "+2.0ms      (累計 2.0ms) コマンド登録")
+		assert.Contains(t, buf.String(), "+3.5ms      (累計 5.5ms) 設定読み込み")
+		assert.Contains(t, buf.String(), "GODEBUG=inittrace=1")
+
+		buf.Reset()
+		reportStartupProfile(&buf)
+		assert.Empty(t, buf.String())
+	})
+}
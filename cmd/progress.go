@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// progressInterval はスピナーを再描画する間隔
+const progressInterval = 100 * time.Millisecond
+
+// progressFrames はスピナーのフレーム
+var progressFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// テスト時にモック可能な関数変数
+var stderrIsTerminalFunc = func() bool {
+	info, err := os.Stderr.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// progressIndicator は時間のかかるコマンドの進捗（スピナー・件数・経過時間）を標準エラー出力に表示する
+// 処理が遅いだけなのか止まっているのかを区別できるよう、ghコマンドなどの待ち時間にも表示を更新し続ける
+// 標準エラー出力が端末でない場合（パイプ・ファイル・テスト）とJSON出力時は何も表示しない
+// スピナーはカーソルの位置に描画して戻すため、行の途中（init の各ステップの見出しの後ろなど）でも使用できる
+type progressIndicator struct {
+	out     io.Writer
+	enabled bool
+
+	mu      sync.Mutex
+	label   string
+	done    int
+	total   int
+	frame   int
+	started time.Time
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// newProgressIndicator はコマンドの標準エラー出力に進捗を表示するprogressIndicatorを作成する
+func newProgressIndicator(cmd *cobra.Command) *progressIndicator {
+	return &progressIndicator{
+		out:     cmd.ErrOrStderr(),
+		enabled: !isJSONOutput() && stderrIsTerminalFunc(),
+	}
+}
+
+// Start は進捗の表示を開始する（totalが0の場合は件数を表示しない）
+// 表示中の場合は見出しと件数を置き換える
+func (p *progressIndicator) Start(label string, total int) {
+	if !p.enabled {
+		return
+	}
+	p.Stop()
+
+	p.mu.Lock()
+	p.label, p.done, p.total = label, 0, total
+	p.started = time.Now()
+	p.stop = make(chan struct{})
+	p.stopped = make(chan struct{})
+	stop, stopped := p.stop, p.stopped
+	p.render()
+	p.mu.Unlock()
+
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				p.mu.Lock()
+				p.render()
+				p.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// Advance は完了した件数を1つ進める
+func (p *progressIndicator) Advance() {
+	if !p.enabled {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done < p.total {
+		p.done++
+	}
+	if p.stop != nil {
+		p.render()
+	}
+}
+
+// Stop は進捗の表示を止めて消去する（表示していない場合は何もしない）
+func (p *progressIndicator) Stop() {
+	if !p.enabled {
+		return
+	}
+	p.mu.Lock()
+	stop, stopped := p.stop, p.stopped
+	p.stop, p.stopped = nil, nil
+	p.mu.Unlock()
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-stopped
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprint(p.out, "\x1b[K")
+}
+
+// Writer は書き込みの前に進捗の表示を止めるWriterを返す
+// 処理中に結果や警告を出力しても、スピナーの描画と混ざらないようにする
+func (p *progressIndicator) Writer(w io.Writer) io.Writer {
+	if !p.enabled {
+		return w
+	}
+	return progressPauseWriter{progress: p, w: w}
+}
+
+// render はカーソルの位置にスピナー・見出し・件数・経過時間を描画し、カーソルを元の位置に戻す（mu を保持して呼び出す）
+func (p *progressIndicator) render() {
+	text := progressFrames[p.frame%len(progressFrames)]
+	p.frame++
+	if p.label != "" {
+		text += " " + p.label
+	}
+	if p.total > 0 {
+		text += fmt.Sprintf(" %d/%d", p.done, p.total)
+	}
+	text += fmt.Sprintf(" (%ds)", int(time.Since(p.started).Seconds()))
+	fmt.Fprintf(p.out, "\x1b7\x1b[K%s\x1b8", text)
+}
+
+// progressPauseWriter は書き込みの前に進捗の表示を止めるWriter
+type progressPauseWriter struct {
+	progress *progressIndicator
+	w        io.Writer
+}
+
+func (w progressPauseWriter) Write(b []byte) (int, error) {
+	w.progress.Stop()
+	return w.w.Write(b)
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressIndicator(t *testing.T) {
+	newProgress := func(t *testing.T, terminal bool) (*progressIndicator, *bytes.Buffer) {
+		origTerminal := stderrIsTerminalFunc
+		t.Cleanup(func() { stderrIsTerminalFunc = origTerminal })
+		stderrIsTerminalFunc = func() bool { return terminal }
+
+		cmd := &cobra.Command{}
+		errBuf := new(bytes.Buffer)
+		cmd.SetErr(errBuf)
+		return newProgressIndicator(cmd), errBuf
+	}
+
+	t.Run("端末の場合はスピナーと件数を表示して消去する", func(t *testing.T) {
+		progress, errBuf := newProgress(t, true)
+
+		progress.Start("リソースを削除しています", 3)
+		progress.Advance()
+		progress.Stop()
+
+		output := errBuf.String()
+		assert.Contains(t, output, "リソースを削除しています 0/3")
+		assert.Contains(t, output, "リソースを削除しています 1/3")
+		assert.Contains(t, output, "\x1b7", "カーソルの位置を保存して描画する")
+		assert.Contains(t, output, "\x1b8", "描画後にカーソルを元の位置に戻す")
+		assert.Equal(t, "\x1b[K", output[len(output)-3:], "停止時に表示を消去する")
+	})
+
+	t.Run("出力の前に表示を止める", func(t *testing.T) {
+		progress, errBuf := newProgress(t, true)
+		out := new(bytes.Buffer)
+
+		progress.Start("", 0)
+		fmt.Fprint(progress.Writer(out), "✅\n")
+		errBuf.Reset()
+		progress.Stop()
+
+		assert.Equal(t, "✅\n", out.String())
+		assert.Empty(t, errBuf.String(), "既に止まっている場合は何も出力しない")
+	})
+
+	t.Run("端末でない場合は何も表示しない", func(t *testing.T) {
+		progress, errBuf := newProgress(t, false)
+		out := new(bytes.Buffer)
+
+		progress.Start("未コミット変更を確認しています", 2)
+		progress.Advance()
+		fmt.Fprint(progress.Writer(out), "警告\n")
+		progress.Stop()
+
+		assert.Empty(t, errBuf.String())
+		assert.Equal(t, "警告\n", out.String())
+	})
+
+	t.Run("JSON出力時は何も表示しない", func(t *testing.T) {
+		defer func() { outputFormat = outputText }()
+		outputFormat = outputJSON
+		progress, errBuf := newProgress(t, true)
+
+		progress.Start("リソースを削除しています", 1)
+		progress.Stop()
+
+		assert.Empty(t, errBuf.String())
+	})
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/douhashi/osoba/internal/config"
+)
+
+// remoteCommands はosoba remoteで実行できるサブコマンドと、端末（ssh -t）が必要かどうか
+var remoteCommands = map[string]bool{
+	"status":    false,
+	"open":      true,
+	"stop":      false,
+	"tail":      true,
+	"reprocess": false,
+}
+
+// remoteCommandAliases はosoba remoteで使えるコマンドの別名と、リモートで実行するサブコマンド
+var remoteCommandAliases = map[string]string{
+	"logs": "tail",
+	"redo": "reprocess",
+}
+
+// モック用の関数変数
+var runSSHFunc = func(cmd *cobra.Command, sshArgs []string) error {
+	c := exec.Command("ssh", sshArgs...)
+	c.Stdin = cmd.InOrStdin()
+	c.Stdout = cmd.OutOrStdout()
+	c.Stderr = cmd.ErrOrStderr()
+	return c.Run()
+}
+
+func newRemoteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remote [flags] <command> [args...]",
+		Short: "別ホストで動作しているosobaを操作",
+		Long: `ssh経由で別ホストのosobaにコマンドを転送します。
+osoba startは別ホストで実行したまま、手元の端末から状態の確認やtmuxセッションへの接続を行えます。
+
+利用できるコマンド: ` + strings.Join(remoteCommandNames(), ", ") + `
+
+例:
+  osoba remote --host dev-box --dir ~/src/myrepo status
+  osoba remote --host dev-box --dir ~/src/myrepo open
+  osoba remote --host dev-box --dir ~/src/myrepo logs --issue 83
+  osoba remote --host dev-box --dir ~/src/myrepo redo 83
+
+--host と --dir は設定ファイルの remote.host / remote.dir でも指定できます。`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runRemote,
+	}
+
+	// サブコマンド以降のフラグはリモートのosobaに渡す
+	cmd.Flags().SetInterspersed(false)
+	cmd.Flags().String("host", "", "接続先ホスト（~/.ssh/configのHost名またはuser@host）")
+	cmd.Flags().String("dir", "", "リモートホスト上のリポジトリのパス")
+
+	return withJSONOutput(cmd)
+}
+
+func runRemote(cmd *cobra.Command, args []string) error {
+	cfg := config.NewConfig()
+	configPath := viper.ConfigFileUsed()
+	if configPath == "" {
+		configPath = viper.GetString("config")
+	}
+	if _, err := cfg.LoadOrDefaultWithError(configPath); err != nil {
+		return fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+	}
+
+	remote := cfg.Remote
+	if host, _ := cmd.Flags().GetString("host"); host != "" {
+		remote.Host = host
+	}
+	if dir, _ := cmd.Flags().GetString("dir"); dir != "" {
+		remote.Dir = dir
+	}
+	if remote.Host == "" {
+		return fmt.Errorf("接続先ホストが指定されていません（--host または remote.host を指定してください）")
+	}
+
+	if name, ok := remoteCommandAliases[args[0]]; ok {
+		args = append([]string{name}, args[1:]...)
+	}
+	tty, ok := remoteCommands[args[0]]
+	if !ok {
+		return fmt.Errorf("remoteで実行できないコマンドです: %s（利用できるコマンド: %s）", args[0], strings.Join(remoteCommandNames(), ", "))
+	}
+
+	// JSON出力はリモートのosobaが生成する
+	if isJSONOutput() {
+		args = append(append([]string{}, args...), "--output", outputJSON)
+		tty = false
+	}
+
+	if err := runSSHFunc(cmd, buildRemoteSSHArgs(remote, tty, args)); err != nil {
+		return fmt.Errorf("リモートホスト %s でのコマンド実行に失敗しました: %w", remote.Host, err)
+	}
+	return nil
+}
+
+// buildRemoteSSHArgs はリモートホストでosobaを実行するsshの引数を生成する
+func buildRemoteSSHArgs(remote config.RemoteConfig, tty bool, args []string) []string {
+	command := remote.Command
+	if command == "" {
+		command = "osoba"
+	}
+
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, shellQuote(command))
+	for _, arg := range args {
+		parts = append(parts, shellQuote(arg))
+	}
+	remoteCmd := strings.Join(parts, " ")
+	if remote.Dir != "" {
+		remoteCmd = "cd " + shellQuoteDir(remote.Dir) + " && " + remoteCmd
+	}
+
+	sshArgs := append([]string{}, remote.SSHOptions...)
+	if tty {
+		sshArgs = append(sshArgs, "-t")
+	}
+	return append(sshArgs, remote.Host, "--", remoteCmd)
+}
+
+// shellQuote はリモートのシェルで1つの引数として扱われるよう文字列をクォートする
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./=:@%+,", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// shellQuoteDir はディレクトリをクォートする（先頭の~/はリモートのホームディレクトリとして展開させる）
+func shellQuoteDir(dir string) string {
+	if dir == "~" {
+		return dir
+	}
+	if strings.HasPrefix(dir, "~/") {
+		return "~/" + shellQuote(strings.TrimPrefix(dir, "~/"))
+	}
+	return shellQuote(dir)
+}
+
+func remoteCommandNames() []string {
+	names := make([]string, 0, len(remoteCommands)+len(remoteCommandAliases))
+	for name := range remoteCommands {
+		names = append(names, name)
+	}
+	for name := range remoteCommandAliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
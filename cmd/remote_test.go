@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteCmd(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		sshErr      error
+		wantSSHArgs []string
+		wantErr     string
+	}{
+		{
+			name:        "statusを転送",
+			args:        []string{"remote", "--host", "dev-box", "--dir", "~/src/my repo", "status", "--debug"},
+			wantSSHArgs: []string{"dev-box", "--", "cd ~/'src/my repo' && osoba status --debug"},
+		},
+		{
+			name:        "openは端末を割り当てる",
+			args:        []string{"remote", "--host", "user@dev-box", "open"},
+			wantSSHArgs: []string{"-t", "user@dev-box", "--", "osoba open"},
+		},
+		{
+			name:    "ホスト未指定",
+			args:    []string{"remote", "status"},
+			wantErr: "接続先ホストが指定されていません",
+		},
+		{
+			name:    "転送できないコマンド",
+			args:    []string{"remote", "--host", "dev-box", "start"},
+			wantErr: "remoteで実行できないコマンドです: start（利用できるコマンド: logs, open, redo, reprocess, status, stop, tail）",
+		},
+		{
+			name:        "logsはtailとして端末を割り当てて転送",
+			args:        []string{"remote", "--host", "dev-box", "logs", "--issue", "83"},
+			wantSSHArgs: []string{"-t", "dev-box", "--", "osoba tail --issue 83"},
+		},
+		{
+			name:        "redoはreprocessとして転送",
+			args:        []string{"remote", "--host", "dev-box", "redo", "83"},
+			wantSSHArgs: []string{"dev-box", "--", "osoba reprocess 83"},
+		},
+		{
+			name:        "リモートでの実行に失敗",
+			args:        []string{"remote", "--host", "dev-box", "stop"},
+			sshErr:      errors.New("exit status 255"),
+			wantSSHArgs: []string{"dev-box", "--", "osoba stop"},
+			wantErr:     "リモートホスト dev-box でのコマンド実行に失敗しました: exit status 255",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origRunSSH := runSSHFunc
+			defer func() { runSSHFunc = origRunSSH }()
+
+			var gotSSHArgs []string
+			runSSHFunc = func(cmd *cobra.Command, sshArgs []string) error {
+				gotSSHArgs = sshArgs
+				return tt.sshErr
+			}
+
+			rootCmd := NewRootCmd()
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			assert.Equal(t, tt.wantSSHArgs, gotSSHArgs)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRemoteCmd_BrokenConfig(t *testing.T) {
+	origRunSSH := runSSHFunc
+	defer func() { runSSHFunc = origRunSSH }()
+	called := false
+	runSSHFunc = func(cmd *cobra.Command, sshArgs []string) error {
+		called = true
+		return nil
+	}
+
+	configPath := filepath.Join(t.TempDir(), "osoba.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("github:\n  poll_interval: abc\n"), 0644))
+
+	rootCmd := NewRootCmd()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"--config", configPath, "remote", "--host", "dev-box", "status"})
+
+	err := rootCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "設定ファイルの読み込みに失敗しました")
+	assert.False(t, called)
+}
+
+func TestBuildRemoteSSHArgs(t *testing.T) {
+	remote := config.RemoteConfig{
+		Host:       "dev-box",
+		Dir:        "/srv/it's",
+		Command:    "/usr/local/bin/osoba",
+		SSHOptions: []string{"-p", "2222"},
+	}
+
+	got := buildRemoteSSHArgs(remote, false, []string{"status"})
+	assert.Equal(t, []string{"-p", "2222", "dev-box", "--", `cd '/srv/it'\''s' && /usr/local/bin/osoba status`}, got)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/douhashi/osoba/internal/daemon"
+	"github.com/douhashi/osoba/internal/paths"
+	"github.com/spf13/cobra"
+)
+
+// モック用の関数変数
+var sendControlCommandFunc = daemon.SendControlCommand
+
+func newReprocessCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reprocess <issue-number>",
+		Short: "Issueのアクションを次回の確認で再評価",
+		Long: `実行中の監視プロセスに、Issueの処理状態の記録を破棄して次回の確認でアクションを再評価するよう要求します。
+フェーズを開始したことになっているのに何も実行されていない場合（実行中ラベルが残っている場合など）の復旧に使用します。
+トリガーラベルと実行中ラベル（例: status:ready と status:implementing）が両方ある場合は、実行中ラベルを外してフェーズを開始し直します。
+実行中ラベルのみが残っている場合は、実行中ラベルをトリガーラベルに戻してからフェーズを開始し直します。
+再評価できない場合（どちらのラベルもない場合や status:manual が付いている場合）はエラーになります。
+
+監視プロセスは制御ソケット（~/.local/share/osoba/run/<リポジトリ>.sock）でコマンドを受け付けます。
+ポーリング間隔を待たずに確認するだけの場合は、監視プロセスにSIGUSR1を送ってください。
+
+使用例:
+  osoba reprocess 83`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			issueNumber, err := parseIssueNumber(args[0])
+			if err != nil {
+				return err
+			}
+			return runReprocess(cmd, issueNumber)
+		},
+	}
+	return cmd
+}
+
+func runReprocess(cmd *cobra.Command, issueNumber int) error {
+	repoIdentifier, err := getRepoIdentifierFunc()
+	if err != nil {
+		return err
+	}
+
+	socketPath := paths.NewPathManager("").ControlSocket(repoIdentifier)
+	reply, err := sendControlCommandFunc(socketPath, fmt.Sprintf("reprocess %d", issueNumber))
+	var commandErr *daemon.CommandError
+	if errors.As(err, &commandErr) {
+		return commandErr
+	}
+	if err != nil {
+		return fmt.Errorf("監視プロセスへの要求に失敗しました（osoba start で監視を開始しているか確認してください）: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), reply)
+	return nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/daemon"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReprocessCmd(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		sendErr     error
+		wantCommand string
+		wantOutput  string
+		wantErr     string
+	}{
+		{name: "監視プロセスに再評価を要求", args: []string{"83"}, wantCommand: "reprocess 83", wantOutput: "Issue #83 を次回の確認で再評価します\n"},
+		{name: "監視プロセスが起動していない", args: []string{"83"}, sendErr: errors.New("connection refused"), wantCommand: "reprocess 83", wantErr: "監視プロセスへの要求に失敗しました"},
+		{name: "監視プロセスが再評価を拒否", args: []string{"83"}, sendErr: &daemon.CommandError{Message: "Issue #83 を再評価できません: issue #83 has no trigger or execution label"}, wantCommand: "reprocess 83", wantErr: "Issue #83 を再評価できません"},
+		{name: "不正なIssue番号", args: []string{"abc"}, wantErr: "Issue番号は正の整数で指定してください"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origIdentifier, origSend := getRepoIdentifierFunc, sendControlCommandFunc
+			defer func() { getRepoIdentifierFunc, sendControlCommandFunc = origIdentifier, origSend }()
+			t.Setenv("OSOBA_DATA_DIR", "/data")
+
+			getRepoIdentifierFunc = func() (string, error) { return "douhashi/osoba", nil }
+			var gotPath, gotCommand string
+			sendControlCommandFunc = func(path, command string) (string, error) {
+				gotPath, gotCommand = path, command
+				if tt.sendErr != nil {
+					return "", tt.sendErr
+				}
+				return "Issue #83 を次回の確認で再評価します", nil
+			}
+
+			cmd := newReprocessCmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+
+			assert.Equal(t, tt.wantCommand, gotCommand)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "/data/run/douhashi_osoba.sock", gotPath)
+			assert.Equal(t, tt.wantOutput, out.String())
+		})
+	}
+}
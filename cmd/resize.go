@@ -51,10 +51,8 @@ func runResizeCmd(cmd *cobra.Command, args []string) error {
 	if configPath == "" {
 		configPath = viper.GetString("config")
 	}
-	if configPath != "" {
-		_ = cfg.LoadOrDefault(configPath)
-	} else {
-		_ = cfg.LoadOrDefault("")
+	if _, err := cfg.LoadOrDefaultWithError(configPath); err != nil {
+		return fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
 	}
 
 	// セッション名が指定されていない場合はデフォルトを使用
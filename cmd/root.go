@@ -3,9 +3,8 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
-	"github.com/douhashi/osoba/internal/config"
-	"github.com/douhashi/osoba/internal/logger"
 	"github.com/douhashi/osoba/internal/version"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -16,30 +15,19 @@ var (
 	verbose  bool
 	logLevel string
 	rootCmd  *cobra.Command
-	appLog   logger.Logger
 )
 
 func init() {
 	rootCmd = newRootCmd()
 
 	// サブコマンドの追加
-	addCommands()
+	addCommands(rootCmd)
+	markStartup("コマンド登録")
 }
 
-func addCommands() {
-	rootCmd.AddCommand(newInitCmd())
-	rootCmd.AddCommand(newStartCmd())
-	rootCmd.AddCommand(newStopCmd())
-	rootCmd.AddCommand(newOpenCmd())
-	rootCmd.AddCommand(newStatusCmd())
-	rootCmd.AddCommand(newCleanCmd())
-	rootCmd.AddCommand(newResizeCmd())
-}
-
-// NewRootCmd creates a new root command with all subcommands
-func NewRootCmd() *cobra.Command {
-	cmd := newRootCmd()
-	// サブコマンドを追加
+// addCommands はサブコマンドを追加する
+// GitHubクライアントやwatcherなどの重い処理は各コマンドの実行時に初期化し、ここでは行わない
+func addCommands(cmd *cobra.Command) {
 	cmd.AddCommand(newInitCmd())
 	cmd.AddCommand(newStartCmd())
 	cmd.AddCommand(newStopCmd())
@@ -47,6 +35,24 @@ func NewRootCmd() *cobra.Command {
 	cmd.AddCommand(newStatusCmd())
 	cmd.AddCommand(newCleanCmd())
 	cmd.AddCommand(newResizeCmd())
+	cmd.AddCommand(newLabelsCmd())
+	cmd.AddCommand(newTemplatesCmd())
+	cmd.AddCommand(newRemoteCmd())
+	cmd.AddCommand(newTakeoverCmd())
+	cmd.AddCommand(newReleaseCmd())
+	cmd.AddCommand(newTailCmd())
+	cmd.AddCommand(newScratchCmd())
+	cmd.AddCommand(newAuditCmd())
+	cmd.AddCommand(newReprocessCmd())
+	cmd.AddCommand(newArtifactsCmd())
+	cmd.AddCommand(newWorktreesCmd())
+	cmd.AddCommand(newMetricsCmd())
+}
+
+// NewRootCmd creates a new root command with all subcommands
+func NewRootCmd() *cobra.Command {
+	cmd := newRootCmd()
+	addCommands(cmd)
 	return cmd
 }
 
@@ -59,24 +65,24 @@ func newRootCmd() *cobra.Command {
 		Version: version.Get().Version,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// 設定ファイルを先に読み込む
+			// ロガーは各コマンドが必要な場合にのみ作成する
 			if err := initConfig(); err != nil {
 				return fmt.Errorf("failed to initialize config: %w", err)
 			}
-
-			// ロガーの初期化
-			var err error
-			appLog, err = initLogger()
-			if err != nil {
-				return fmt.Errorf("failed to initialize logger: %w", err)
+			if err := applyRepoPath(); err != nil {
+				return err
 			}
+			markStartup("設定読み込み")
 
-			return nil
+			return validateOutputFormat(cmd)
 		},
 	}
 
 	cmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "設定ファイルのパス")
 	cmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "詳細出力")
 	cmd.PersistentFlags().StringVarP(&logLevel, "log-level", "l", "", "ログレベル (debug, info, warn, error)")
+	cmd.PersistentFlags().BoolVar(&profileStartup, "profile-startup", false, "起動処理の所要時間を表示")
+	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputText, "出力形式 (text, json)")
 
 	viper.BindPFlag("config", cmd.PersistentFlags().Lookup("config"))
 	viper.BindPFlag("verbose", cmd.PersistentFlags().Lookup("verbose"))
@@ -86,8 +92,14 @@ func newRootCmd() *cobra.Command {
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+	err := rootCmd.Execute()
+	reportStartupProfile(os.Stderr)
+	if err != nil {
+		if isJSONOutput() {
+			_ = writeJSON(os.Stdout, jsonError{Error: err.Error()})
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+		}
 		os.Exit(1)
 	}
 }
@@ -128,28 +140,33 @@ func initConfig() error {
 	return nil
 }
 
-// initLogger はロガーを初期化する
-func initLogger() (logger.Logger, error) {
-	// 設定を読み込む
-	cfg := config.NewConfig()
-	if cfgFile != "" {
-		if err := cfg.Load(cfgFile); err != nil {
-			// 設定ファイルの読み込みに失敗した場合でも、デフォルト値を使って続行
-			cfg = config.NewConfig()
-		}
-	} else {
-		cfg.LoadOrDefault("")
+// applyRepoPath はリポジトリを固定のパスにマウントしている場合（container.repo_path または OSOBA_REPO_PATH）、
+// そのパスを作業ディレクトリにする。git・tmux・設定ファイルの検索はいずれも作業ディレクトリを基準に行うため
+func applyRepoPath() error {
+	repoPath := os.Getenv("OSOBA_REPO_PATH")
+	if repoPath == "" {
+		repoPath = viper.GetString("container.repo_path")
+	}
+	if repoPath == "" {
+		return nil
 	}
 
-	// コマンドラインオプションで上書き
-	if logLevel != "" {
-		cfg.Log.Level = logLevel
+	// 読み込み済みの設定ファイルが相対パスの場合は、移動前の作業ディレクトリを基準に解決する
+	if used := viper.ConfigFileUsed(); used != "" && !filepath.IsAbs(used) {
+		abs, err := filepath.Abs(used)
+		if err != nil {
+			return fmt.Errorf("設定ファイルのパスの解決に失敗しました: %w", err)
+		}
+		viper.SetConfigFile(abs)
 	}
 
-	// verboseフラグが設定されている場合はdebugレベルに設定
-	if verbose {
-		cfg.Log.Level = "debug"
+	if err := os.Chdir(repoPath); err != nil {
+		return fmt.Errorf("リポジトリのパス %s に移動できません: %w", repoPath, err)
 	}
 
-	return cfg.CreateLogger()
+	// 設定ファイルが見つかっていなかった場合は、リポジトリの設定ファイルを読み込む
+	if viper.ConfigFileUsed() == "" {
+		return initConfig()
+	}
+	return nil
 }
@@ -368,3 +368,85 @@ tmux:
 		})
 	}
 }
+
+func TestApplyRepoPath(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current dir: %v", err)
+	}
+	defer func() {
+		os.Chdir(originalDir)
+		viper.Reset()
+		cfgFile = ""
+	}()
+
+	// マウントしたリポジトリの設定ファイルを、作業ディレクトリによらず読み込む
+	repoDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoDir, ".osoba.yml"), []byte("tmux:\n  session_prefix: \"container-\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change dir: %v", err)
+	}
+	t.Setenv("OSOBA_REPO_PATH", repoDir)
+	viper.Reset()
+	cfgFile = ""
+
+	if err := initConfig(); err != nil {
+		t.Fatalf("initConfig() error = %v", err)
+	}
+	if err := applyRepoPath(); err != nil {
+		t.Fatalf("applyRepoPath() error = %v", err)
+	}
+
+	cwd, _ := os.Getwd()
+	wantDir, _ := filepath.EvalSymlinks(repoDir)
+	if gotDir, _ := filepath.EvalSymlinks(cwd); gotDir != wantDir {
+		t.Errorf("working directory = %v, want %v", gotDir, wantDir)
+	}
+	if got := viper.GetString("tmux.session_prefix"); got != "container-" {
+		t.Errorf("tmux.session_prefix = %v, want container-", got)
+	}
+
+	// 存在しないパスはエラー
+	t.Setenv("OSOBA_REPO_PATH", filepath.Join(repoDir, "missing"))
+	if err := applyRepoPath(); err == nil || !strings.Contains(err.Error(), "リポジトリのパス") {
+		t.Errorf("applyRepoPath() error = %v, want error about repository path", err)
+	}
+}
+
+func TestCommands_BrokenConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "osoba.yml")
+	if err := os.WriteFile(configPath, []byte("github:\n  poll_interval: abc\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "status", args: []string{"status"}},
+		{name: "audit", args: []string{"audit"}},
+		{name: "open", args: []string{"open", "12"}},
+		{name: "resize", args: []string{"resize", "12"}},
+		{name: "scratch", args: []string{"scratch", "--issue", "12"}},
+		{name: "artifacts", args: []string{"artifacts", "--issue", "12"}},
+		{name: "worktrees", args: []string{"worktrees"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			rootCmd := NewRootCmd()
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+			rootCmd.SetArgs(append([]string{"--config", configPath}, tt.args...))
+
+			err := rootCmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), "設定ファイルの読み込みに失敗しました") {
+				t.Errorf("Execute() error = %v, want config load error", err)
+			}
+		})
+	}
+}
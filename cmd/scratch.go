@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/douhashi/osoba/internal/utils"
+)
+
+// scratchPanePercentage はScratchペインの分割サイズ（%）
+const scratchPanePercentage = 30
+
+// テスト時にモック可能な関数変数
+var (
+	createPaneFunc = func(sessionName, windowName string, opts tmux.PaneOptions) (*tmux.PaneInfo, error) {
+		return tmux.NewDefaultManager().CreatePane(sessionName, windowName, opts)
+	}
+	loadScratchConfigFunc = func() (*config.Config, error) {
+		cfg := config.NewConfig()
+		if _, err := cfg.LoadOrDefaultWithError(viper.GetString("config")); err != nil {
+			return nil, fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+		}
+		return cfg, nil
+	}
+)
+
+func newScratchCmd() *cobra.Command {
+	var issueNumber int
+	cmd := &cobra.Command{
+		Use:   "scratch",
+		Short: "Issueのウィンドウに手動作業用のペインを開く",
+		Long: `Issueのtmuxウィンドウに、worktreeを作業ディレクトリとしたScratchペインを追加します。
+自動で実行されるペインの横で、ビルドや動作確認などを手動で行うためのペインです。
+ペインのシェルには以下の環境変数が設定されます。
+
+  OSOBA_ISSUE_NUMBER  Issue番号
+  OSOBA_ISSUE_URL     IssueのURL
+  OSOBA_REPO          リポジトリ（owner/repo）
+  OSOBA_WORKTREE      worktreeのパス
+  OSOBA_BRANCH        worktreeのブランチ
+
+Scratchペインはペイン数の上限によるフェーズの再利用の対象にならず、Issueのウィンドウとともに削除されます。
+tmux.phases.scratch.reap_after を設定すると、無操作のまま経過したScratchペインの出力を保存して削除します。
+
+使用例:
+  osoba scratch --issue 83
+  osoba scratch                   # 処理中のIssueから選択`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			number, err := resolveIssueNumber(cmd, issueNumber)
+			if err != nil {
+				return err
+			}
+			return runScratch(cmd, number)
+		},
+	}
+	cmd.Flags().IntVar(&issueNumber, "issue", 0, "ペインを開くIssue番号（省略時は処理中のIssueから選択）")
+	return cmd
+}
+
+func runScratch(cmd *cobra.Command, issueNumber int) error {
+	if issueNumber <= 0 {
+		return fmt.Errorf("Issue番号は正の整数で指定してください")
+	}
+	cfg, err := loadScratchConfigFunc()
+	if err != nil {
+		return err
+	}
+	if err := checkTmuxInstalledFunc(); err != nil {
+		return fmt.Errorf("tmuxがインストールされていません: %w", err)
+	}
+
+	ctx := context.Background()
+	repoInfo, err := getGitHubRepoInfoFunc(ctx)
+	if err != nil {
+		return fmt.Errorf("GitHubリポジトリ情報の取得に失敗しました: %w", err)
+	}
+
+	sessionName, windowName, err := findIssueWindow(cfg, cfg.Tmux.SessionPrefix+repoInfo.Repo, issueNumber)
+	if err != nil {
+		return err
+	}
+
+	worktrees, err := listWorktreesForIssueFunc(ctx, issueNumber)
+	if err != nil {
+		return fmt.Errorf("worktreeの取得に失敗しました: %w", err)
+	}
+	if len(worktrees) == 0 {
+		return fmt.Errorf("Issue #%d のworktreeが見つかりません", issueNumber)
+	}
+	worktree := worktrees[0]
+
+	pane, err := createPaneFunc(sessionName, windowName, tmux.PaneOptions{
+		Split:      scratchSplitFlag(cfg.Tmux.PaneSplit),
+		Percentage: scratchPanePercentage,
+		Title:      tmux.ScratchPaneTitle,
+		StartDir:   worktree.Path,
+		Env:        scratchEnv(repoInfo, issueNumber, worktree.Path, worktree.Branch),
+	})
+	if err != nil {
+		return fmt.Errorf("Scratchペインの作成に失敗しました: %w", err)
+	}
+
+	target := fmt.Sprintf("%s:%s", sessionName, windowName)
+	fmt.Fprintf(cmd.OutOrStdout(), "🧪 Issue #%d のScratchペインを作成しました（%s.%d、%s）\n", issueNumber, target, pane.Index, worktree.Path)
+	if isInsideTmux() {
+		return switchToSession(target)
+	}
+	return nil
+}
+
+// findIssueWindow はIssueのウィンドウを通常のセッションとシャードのセッションから探す
+// フェーズ専用ウィンドウより、Issueで共有するウィンドウ（issue-N）を優先する
+func findIssueWindow(cfg *config.Config, baseSession string, issueNumber int) (string, string, error) {
+	for _, sessionName := range cfg.Tmux.SessionNames(baseSession) {
+		windows, err := listWindowsForIssueFunc(sessionName, issueNumber)
+		if err != nil || len(windows) == 0 {
+			continue
+		}
+		windowName := windows[0].Name
+		for _, w := range windows {
+			if w.Name == tmux.GetWindowName(issueNumber) {
+				windowName = w.Name
+				break
+			}
+		}
+		return sessionName, windowName, nil
+	}
+	return "", "", fmt.Errorf("Issue #%d のウィンドウが見つかりません（osobaがフェーズを開始すると作成されます）", issueNumber)
+}
+
+// scratchSplitFlag はtmux.pane_splitの設定からペインの分割フラグを返す
+func scratchSplitFlag(paneSplit string) string {
+	switch paneSplit {
+	case config.PaneSplitVertical:
+		return tmux.SplitVertical
+	case config.PaneSplitAuto:
+		return tmux.SplitAuto
+	default:
+		return tmux.SplitHorizontal
+	}
+}
+
+// scratchEnv はScratchペインのシェルに設定するIssueの情報
+func scratchEnv(repoInfo *utils.GitHubRepoInfo, issueNumber int, worktreePath, branch string) []string {
+	return []string{
+		"OSOBA_ISSUE_NUMBER=" + strconv.Itoa(issueNumber),
+		fmt.Sprintf("OSOBA_ISSUE_URL=https://github.com/%s/%s/issues/%d", repoInfo.Owner, repoInfo.Repo, issueNumber),
+		fmt.Sprintf("OSOBA_REPO=%s/%s", repoInfo.Owner, repoInfo.Repo),
+		"OSOBA_WORKTREE=" + worktreePath,
+		"OSOBA_BRANCH=" + branch,
+	}
+}
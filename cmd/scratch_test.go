@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/douhashi/osoba/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunScratch(t *testing.T) {
+	origCheckTmux := checkTmuxInstalledFunc
+	origRepoInfo := getGitHubRepoInfoFunc
+	origLoadConfig := loadScratchConfigFunc
+	origWindows := listWindowsForIssueFunc
+	origWorktrees := listWorktreesForIssueFunc
+	origCreatePane := createPaneFunc
+	t.Setenv("TMUX", "")
+	defer func() {
+		checkTmuxInstalledFunc = origCheckTmux
+		getGitHubRepoInfoFunc = origRepoInfo
+		loadScratchConfigFunc = origLoadConfig
+		listWindowsForIssueFunc = origWindows
+		listWorktreesForIssueFunc = origWorktrees
+		createPaneFunc = origCreatePane
+	}()
+
+	checkTmuxInstalledFunc = func() error { return nil }
+	getGitHubRepoInfoFunc = func(ctx context.Context) (*utils.GitHubRepoInfo, error) {
+		return &utils.GitHubRepoInfo{Owner: "douhashi", Repo: "osoba"}, nil
+	}
+	loadScratchConfigFunc = func() (*config.Config, error) {
+		cfg := config.NewConfig()
+		cfg.Tmux.Shards = []config.SessionShardConfig{{Name: "web", Labels: []string{"area:web"}}}
+		return cfg, nil
+	}
+	listWorktreesForIssueFunc = func(ctx context.Context, issueNumber int) ([]git.WorktreeInfo, error) {
+		return []git.WorktreeInfo{{Path: "/work/issue-83", Branch: "osoba/#83"}}, nil
+	}
+
+	tests := []struct {
+		name        string
+		windows     map[string][]*tmux.WindowInfo
+		wantSession string
+		wantWindow  string
+		wantErr     string
+	}{
+		{
+			name: "Issueで共有するウィンドウを優先",
+			windows: map[string][]*tmux.WindowInfo{
+				"osoba-osoba": {{Name: "83-review"}, {Name: "issue-83"}},
+			},
+			wantSession: "osoba-osoba",
+			wantWindow:  "issue-83",
+		},
+		{
+			name: "シャードのセッションから探す",
+			windows: map[string][]*tmux.WindowInfo{
+				"osoba-osoba-web": {{Name: "83-plan"}},
+			},
+			wantSession: "osoba-osoba-web",
+			wantWindow:  "83-plan",
+		},
+		{
+			name:    "ウィンドウがない",
+			windows: map[string][]*tmux.WindowInfo{},
+			wantErr: "Issue #83 のウィンドウが見つかりません（osobaがフェーズを開始すると作成されます）",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listWindowsForIssueFunc = func(sessionName string, issueNumber int) ([]*tmux.WindowInfo, error) {
+				return tt.windows[sessionName], nil
+			}
+			var gotSession, gotWindow string
+			var gotOpts tmux.PaneOptions
+			createPaneFunc = func(sessionName, windowName string, opts tmux.PaneOptions) (*tmux.PaneInfo, error) {
+				gotSession, gotWindow, gotOpts = sessionName, windowName, opts
+				return &tmux.PaneInfo{Index: 2, Title: opts.Title}, nil
+			}
+
+			cmd := newScratchCmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+
+			err := runScratch(cmd, 83)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSession, gotSession)
+			assert.Equal(t, tt.wantWindow, gotWindow)
+			assert.Equal(t, tmux.ScratchPaneTitle, gotOpts.Title)
+			assert.Equal(t, "/work/issue-83", gotOpts.StartDir)
+			assert.Nil(t, gotOpts.Config, "Scratchペインはフェーズのペインを再利用しない")
+			assert.Contains(t, gotOpts.Env, "OSOBA_ISSUE_NUMBER=83")
+			assert.Contains(t, gotOpts.Env, "OSOBA_ISSUE_URL=https://github.com/douhashi/osoba/issues/83")
+			assert.Contains(t, gotOpts.Env, "OSOBA_BRANCH=osoba/#83")
+			assert.Contains(t, out.String(), tt.wantSession+":"+tt.wantWindow+".2")
+		})
+	}
+}
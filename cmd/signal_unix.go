@@ -0,0 +1,12 @@
+//go:build !windows
+// +build !windows
+
+package cmd
+
+import (
+	"os"
+	"syscall"
+)
+
+// repollSignals は監視プロセスにポーリング間隔を待たない再確認を要求するシグナル
+var repollSignals = []os.Signal{syscall.SIGUSR1}
@@ -0,0 +1,9 @@
+//go:build windows
+// +build windows
+
+package cmd
+
+import "os"
+
+// repollSignals は監視プロセスにポーリング間隔を待たない再確認を要求するシグナル（Windowsは非対応）
+var repollSignals []os.Signal
@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
+	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -15,13 +19,20 @@ import (
 	"github.com/douhashi/osoba/internal/cleanup"
 	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/daemon"
+	"github.com/douhashi/osoba/internal/faultinject"
+	"github.com/douhashi/osoba/internal/gh"
 	"github.com/douhashi/osoba/internal/git"
 	githubPkg "github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/metrics"
+	"github.com/douhashi/osoba/internal/migrate"
+	"github.com/douhashi/osoba/internal/notify"
 	"github.com/douhashi/osoba/internal/paths"
 	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/douhashi/osoba/internal/trace"
 	"github.com/douhashi/osoba/internal/utils"
 	"github.com/douhashi/osoba/internal/watcher"
+	"github.com/douhashi/osoba/internal/watcher/actions"
 	"github.com/spf13/cobra"
 )
 
@@ -31,6 +42,9 @@ func newStartCmd() *cobra.Command {
 		configFlag     string
 		foregroundFlag bool
 		logFileFlag    string
+		attachFlag     bool
+		assumeYesFlag  bool
+		tokenStdinFlag bool
 	)
 
 	cmd := &cobra.Command{
@@ -38,7 +52,11 @@ func newStartCmd() *cobra.Command {
 		Short: "Issue監視を開始",
 		Long: `現在のGitリポジトリでGitHub Issueの監視を開始します。
 tmuxセッションが存在しない場合は自動的に作成されます。
-デフォルトではバックグラウンドで実行されます。`,
+デフォルトではバックグラウンドで実行されます。
+
+--foreground を指定すると、PIDファイルを作成せず現在の端末で監視します
+（osoba自体の開発や、systemd・Dockerなどのプロセス管理下での実行向け）。
+監視中のプロセスにSIGUSR1を送ると、ポーリング間隔を待たずにIssueとPRを確認します。`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// ヘルプフラグが指定されている場合は何もしない（ヘルプ表示のみ）
 			help, _ := cmd.Flags().GetBool("help")
@@ -46,8 +64,18 @@ tmuxセッションが存在しない場合は自動的に作成されます。
 				return nil
 			}
 
+			// 標準入力のトークンは環境変数に設定し、バックグラウンドや組織モードの子プロセスへ引き継ぐ
+			if tokenStdinFlag && !isDaemonModeFunc() {
+				if err := readTokenFromStdin(cmd.InOrStdin()); err != nil {
+					return err
+				}
+			}
+
 			// フォアグラウンドフラグが指定されている場合は従来の動作
 			if foregroundFlag {
+				if attachFlag {
+					fmt.Fprintln(cmd.ErrOrStderr(), "警告: --attachはフォアグラウンド実行時には無視されます")
+				}
 				return runWatchWithFlagsFunc(cmd, args, intervalFlag, configFlag)
 			}
 
@@ -72,8 +100,18 @@ tmuxセッションが存在しない場合は自動的に作成されます。
 
 	cmd.Flags().StringVarP(&intervalFlag, "interval", "i", "5s", "ポーリング間隔")
 	cmd.Flags().StringVarP(&configFlag, "config", "c", "", "設定ファイルのパス")
-	cmd.Flags().BoolVar(&foregroundFlag, "foreground", false, "フォアグラウンドで実行（デフォルト: false）")
+	cmd.Flags().BoolVar(&foregroundFlag, "foreground", false, "PIDファイルを作成せずフォアグラウンドで実行（デフォルト: false）")
 	cmd.Flags().StringVar(&logFileFlag, "log-file", "", "ログファイルパス（デフォルト: 自動生成）")
+	cmd.Flags().BoolVar(&attachFlag, "attach", false, "起動後にtmuxセッションへ接続（設定: tmux.auto_attach）")
+	cmd.Flags().BoolVarP(&assumeYesFlag, "yes", "y", false, "破壊的操作を確認なしで許可（設定: safety.confirm_destructive）")
+	cmd.Flags().BoolVar(&tokenStdinFlag, "token-stdin", false, "GitHubトークンを標準入力から読み込み、ghの認証情報を使わない（CI・ヘッドレス環境向け）")
+	// 組織モードで起動したリポジトリごとの子プロセスであることを示す（内部用）
+	cmd.Flags().Bool("org-member", false, "組織モードの子プロセスとして実行")
+	_ = cmd.Flags().MarkHidden("org-member")
+	// ghとtmuxの呼び出しに障害を注入してリトライ・復旧処理を検証する（開発・CI用）
+	cmd.Flags().String("fault-injection", "", "ghとtmuxの呼び出しに障害を注入（例: fail=0.1,delay=0.3,max-delay=2s,targets=gh+tmux,seed=1）")
+	_ = cmd.Flags().MarkHidden("fault-injection")
+	cmd.Flags().Bool("trace-commands", false, "実行した外部コマンド（tmux, gh, git, claude）をサイクルごとのトランスクリプトに記録")
 
 	return cmd
 }
@@ -88,6 +126,15 @@ var (
 	checkExistingProcessFunc = checkExistingProcess
 	createPIDFileFunc        = createPIDFile
 	osUserHomeDirFunc        = os.UserHomeDir
+	attachToSessionFunc      = attachToSession
+	switchToSessionFunc      = switchToSession
+	authenticatedUserFunc    = githubPkg.AuthenticatedUser
+)
+
+// 起動後の自動接続でセッション作成を待機する設定
+var (
+	attachWaitTimeout  = 30 * time.Second
+	attachPollInterval = 500 * time.Millisecond
 )
 
 // checkConfigFileExists は設定ファイルの存在をチェックし、存在しない場合はエラーメッセージを出力します
@@ -113,9 +160,11 @@ func checkConfigFileExists(errOut io.Writer) error {
 }
 
 func runWatchWithFlags(cmd *cobra.Command, args []string, intervalFlag, configFlag string) error {
-	// 設定ファイルの存在チェック
-	if err := checkConfigFileExists(cmd.OutOrStderr()); err != nil {
-		return err
+	// 設定ファイルの存在チェック（組織モードの子プロセスのように--configで既存のファイルを指定した場合を除く）
+	if _, err := os.Stat(configFlag); configFlag == "" || err != nil {
+		if err := checkConfigFileExists(cmd.OutOrStderr()); err != nil {
+			return err
+		}
 	}
 
 	fmt.Fprintln(cmd.OutOrStdout(), "Issue監視モードを開始します")
@@ -124,6 +173,7 @@ func runWatchWithFlags(cmd *cobra.Command, args []string, intervalFlag, configFl
 	cfg := config.NewConfig()
 	// LoadOrDefaultを使用してデフォルト設定ファイルも読み込む
 	actualConfigPath := cfg.LoadOrDefault(configFlag)
+	markStartup("設定ファイル読み込み")
 
 	// 設定ファイルの使用状況をログに出力
 	if actualConfigPath != "" {
@@ -145,9 +195,20 @@ func runWatchWithFlags(cmd *cobra.Command, args []string, intervalFlag, configFl
 		cfg.GitHub.PollInterval = interval
 	}
 
+	// --yesが指定された場合は破壊的操作を許可
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		cfg.Safety.AssumeYes = true
+	}
+
 	// 設定値の詳細をログ出力
 	fmt.Fprintln(cmd.OutOrStdout(), "\n設定値:")
 	fmt.Fprintf(cmd.OutOrStdout(), "  ポーリング間隔: %s\n", cfg.GitHub.PollInterval)
+	if cfg.Safety.ConfirmDestructive && !cfg.Safety.AssumeYes {
+		fmt.Fprintf(cmd.OutOrStdout(), "  破壊的操作の確認: 有効 (許可済み: %s)\n", formatAllowedOperations(cfg.Safety.Allow))
+	}
+	if len(repollSignals) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "  即時の再確認: kill -USR1 %d\n", os.Getpid())
+	}
 
 	// gh認証状態を表示
 	token, source := config.GetGitHubToken(cfg)
@@ -162,6 +223,26 @@ func runWatchWithFlags(cmd *cobra.Command, args []string, intervalFlag, configFl
 		return err
 	}
 
+	// ghコマンドの実行設定を適用
+	if err := applyGHCommandConfig(cmd, cfg); err != nil {
+		return err
+	}
+
+	// OSOBA_GITHUB_TOKENのトークンはghの認証情報を使わずに渡し、起動時に有効かを確認する
+	if source == config.GitHubTokenEnv {
+		if err := verifyEnvToken(cmd.OutOrStdout(), token); err != nil {
+			return err
+		}
+	}
+
+	// ラベルによる状態遷移の定義を適用
+	watcher.SetWorkflow(cfg.GitHub.Workflow)
+
+	// github.orgが設定されている場合は組織のリポジトリを検出して監視する
+	if orgMember, _ := cmd.Flags().GetBool("org-member"); cfg.GitHub.Org != "" && !orgMember {
+		return runOrgWatch(cmd, cfg, actualConfigPath)
+	}
+
 	// リポジトリ情報を取得
 	repoInfo, err := utils.GetGitHubRepoInfo(context.Background())
 	if err != nil {
@@ -177,11 +258,16 @@ func runWatchWithFlags(cmd *cobra.Command, args []string, intervalFlag, configFl
 	if logLevel == "" {
 		logLevel = "info"
 	}
-	appLogger, err := logger.New(logger.WithLevel(logLevel))
+	appLogger, err := logger.New(logger.WithLevel(logLevel), logger.WithLocation(cfg.Location()))
 	if err != nil {
 		return fmt.Errorf("ロガーの作成に失敗: %w", err)
 	}
 
+	// token_commandのトークンはGH_TOKENとしてghコマンドに渡す
+	if cfg.GitHub.CLI.TokenCommand != "" && token != "" {
+		githubPkg.SetToken(token)
+	}
+
 	// GitHubクライアントを作成（ghコマンドのみ使用）
 	githubClient, err := githubPkg.NewClientWithLogger("", appLogger)
 	if err != nil {
@@ -189,6 +275,71 @@ func runWatchWithFlags(cmd *cobra.Command, args []string, intervalFlag, configFl
 	}
 	fmt.Fprintln(cmd.OutOrStdout(), "  GitHub接続: ghコマンドを使用")
 
+	// 変更を伴うGitHub操作を監査ログに記録
+	var auditLog *githubPkg.AuditLog
+	if cfg.Audit.Enabled {
+		auditPath := cfg.Audit.Path
+		if auditPath == "" {
+			if repoIdentifier, err := getRepoIdentifierFunc(); err == nil {
+				auditPath = paths.NewPathManager("").AuditFile(repoIdentifier)
+			}
+		}
+		if auditPath == "" {
+			fmt.Fprintln(cmd.OutOrStderr(), "警告: リポジトリ識別子を取得できないため監査ログを記録しません")
+		} else {
+			auditLog, err = githubPkg.NewAuditLog(auditPath, token)
+			if err != nil {
+				return fmt.Errorf("監査ログの作成に失敗: %w", err)
+			}
+			githubClient.SetAuditLog(auditLog)
+			fmt.Fprintf(cmd.OutOrStdout(), "  監査ログ: %s\n", auditPath)
+		}
+	}
+	githubClient.SetIssueContentCache(cfg.GitHub.IssueCache.Enabled)
+	if cfg.GitHub.CommentConsolidation.Enabled {
+		githubClient.SetCommentConsolidation(cfg.GitHub.CommentConsolidation.Window)
+		fmt.Fprintf(cmd.OutOrStdout(), "  自動コメントのまとめ: %s以内のコメントを1つに編集\n", cfg.GitHub.CommentConsolidation.Window)
+	}
+	if cfg.GitHub.OfflineQueue.Enabled {
+		githubClient.SetOfflineQueue(cfg.GitHub.OfflineQueue.Max, cfg.GitHub.OfflineQueue.Labels)
+		fmt.Fprintf(cmd.OutOrStdout(), "  オフラインキュー: GitHubに接続できない間の通知のコメント・情報のラベル・ラベルの作成を最大%d件保留\n", cfg.GitHub.OfflineQueue.Max)
+	}
+	markStartup("GitHubクライアント初期化")
+
+	// 障害注入が指定された場合はghとtmuxの呼び出しを失敗・遅延させる（注入した回数はosoba statusで表示）
+	var faultInjector *faultinject.Injector
+	if spec, _ := cmd.Flags().GetString("fault-injection"); spec != "" {
+		injector, err := faultinject.Parse(spec)
+		if err != nil {
+			return fmt.Errorf("--fault-injection の指定が不正です: %w", err)
+		}
+		githubClient.SetFaultInjector(injector)
+		tmux.SetFaultInjector(injector)
+		fmt.Fprintf(cmd.OutOrStderr(), "警告: 障害注入が有効です (%s)\n", injector)
+		appLogger.Warn("Fault injection enabled", "spec", injector.String())
+		faultInjector = injector
+	}
+
+	// 外部コマンドの実行をサイクルごとのトランスクリプトに記録する
+	if traceCommands, _ := cmd.Flags().GetBool("trace-commands"); traceCommands {
+		repoIdentifier, err := getRepoIdentifierFunc()
+		if err != nil {
+			return fmt.Errorf("リポジトリ識別子の取得に失敗: %w", err)
+		}
+		transcriptDir := filepath.Join(paths.NewPathManager("").LogDir(repoIdentifier), "transcripts", time.Now().In(cfg.Location()).Format("20060102-150405"))
+		recorder, err := trace.NewRecorder(transcriptDir)
+		if err != nil {
+			return fmt.Errorf("コマンドのトランスクリプトの作成に失敗: %w", err)
+		}
+		defer func() {
+			trace.SetRecorder(nil)
+			_ = recorder.Close()
+		}()
+		trace.SetRecorder(recorder)
+		fmt.Fprintf(cmd.OutOrStdout(), "  コマンドのトランスクリプト: %s\n", transcriptDir)
+		appLogger.Info("Command tracing enabled", "dir", transcriptDir)
+	}
+
 	// tmuxがインストールされているか確認
 	if err := tmux.CheckTmuxInstalled(); err != nil {
 		return fmt.Errorf("%w", err)
@@ -197,21 +348,88 @@ func runWatchWithFlags(cmd *cobra.Command, args []string, intervalFlag, configFl
 	// セッション名を生成
 	sessionName := fmt.Sprintf("%s%s", cfg.Tmux.SessionPrefix, repoName)
 
+	// token_command・OSOBA_GITHUB_TOKEN（--token-stdin）のトークンは、フェーズのペインで実行するghコマンドにもGH_TOKENとして渡す
+	if token != "" && (source == "token_command" || source == config.GitHubTokenEnv) {
+		tmux.SetSessionEnv("GH_TOKEN", token)
+	}
+
 	// tmuxセッションを確保（存在しない場合は作成）
 	fmt.Fprintf(cmd.OutOrStdout(), "tmuxセッション '%s' を確認中...\n", sessionName)
 	if err := tmux.EnsureSession(sessionName); err != nil {
 		return fmt.Errorf("tmuxセッションの確保に失敗: %w", err)
 	}
 	fmt.Fprintf(cmd.OutOrStdout(), "tmuxセッション '%s' が利用可能です\n", sessionName)
+	if cfg.Tmux.Keybindings.Enabled {
+		if err := tmux.InstallKeybindings(cfg.Tmux.SessionPrefix, cfg.Tmux.Keybindings.Key); err != nil {
+			fmt.Fprintf(cmd.OutOrStderr(), "警告: tmuxのキーバインドのインストールに失敗しました: %v\n", err)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "tmuxのキーバインドをインストールしました（prefix + %s でosobaのメニューを表示）\n", cfg.Tmux.Keybindings.Key)
+		}
+	}
+	markStartup("tmuxセッション確保")
 
 	// 必要なラベルが存在することを確認
 	fmt.Fprintln(cmd.OutOrStdout(), "必要なラベルを確認中...")
-	if err := githubClient.EnsureLabelsExist(context.Background(), owner, repoName); err != nil {
+	// 設定で名前を変更したラベルや、有効にした機能が使うラベルも同じクライアント（トークン・監査ログ）で作成する
+	githubClient.AddLabelDefinitions(configuredLabelDefinitions(cfg.ManagedLabels()))
+	// オフラインキューが有効な場合、GitHubに接続できない間はラベルの作成を保留し、接続が戻った後に作成する
+	if err := githubClient.EnsureLabelsExistDeferred(context.Background(), owner, repoName); err != nil {
 		// エラーでも処理は続行（ラベル作成権限がない場合もあるため）
 		fmt.Fprintf(cmd.OutOrStderr(), "警告: ラベルの確認/作成に失敗しました: %v\n", err)
 	} else {
 		fmt.Fprintln(cmd.OutOrStdout(), "ラベルの確認が完了しました")
 	}
+	// GitHubに接続できずに保留したosoba labels syncを反映する
+	if applied, err := applyPendingLabelSync(context.Background(), owner, repoName, cfg.ManagedLabels()); err != nil {
+		fmt.Fprintf(cmd.OutOrStderr(), "警告: 保留していたラベルの同期に失敗しました（次回の起動時に再試行します）: %v\n", err)
+	} else if applied {
+		fmt.Fprintln(cmd.OutOrStdout(), "保留していたラベルの同期を反映しました")
+	}
+	markStartup("ラベル確認")
+
+	// リポジトリの前提条件（ブランチ保護・CIワークフロー・CODEOWNERS）を確認
+	preconditionChecker, err := watcher.NewPreconditionChecker(githubClient, owner, repoName, cfg.GitHub.Preconditions, appLogger)
+	if err != nil {
+		return fmt.Errorf("PreconditionCheckerの作成に失敗: %w", err)
+	}
+	preconditions := preconditionChecker.Check(context.Background())
+	printPreconditionReport(cmd.OutOrStdout(), preconditions)
+	if preconditions.BlocksAll() {
+		return fmt.Errorf("リポジトリの前提条件を満たしていないため自動処理を開始しません（github.preconditions で扱いを変更できます）")
+	}
+	if preconditions.BlocksAutoMerge() && cfg.GitHub.AutoMergeLGTM {
+		fmt.Fprintln(cmd.OutOrStdout(), "リポジトリの前提条件を満たしていないため自動マージを無効にします")
+		cfg.GitHub.AutoMergeLGTM = false
+	}
+	markStartup("前提条件の確認")
+
+	// デフォルトブランチの保護ルールに合わせて自動マージの方法と条件を調整
+	branchProtection, err := watcher.DetectBranchProtection(context.Background(), githubClient, owner, repoName)
+	if err != nil {
+		fmt.Fprintf(cmd.OutOrStderr(), "警告: ブランチ保護ルールの取得に失敗しました（自動マージはsquashで行います）: %v\n", err)
+	} else {
+		printBranchProtection(cmd.OutOrStdout(), branchProtection)
+		githubClient.SetMergeMethod(branchProtection.MergeMethod())
+	}
+	markStartup("ブランチ保護の確認")
+
+	// マージキューを使うリポジトリでは自動マージでPRをキューに追加する
+	useMergeQueue := false
+	if cfg.GitHub.AutoMergeLGTM {
+		switch cfg.GitHub.AutoMerge.MergeQueue {
+		case config.MergeQueueOn:
+			useMergeQueue = true
+		case config.MergeQueueAuto:
+			detected, err := watcher.DetectMergeQueue(context.Background(), githubClient, owner, repoName)
+			if err != nil {
+				fmt.Fprintf(cmd.OutOrStderr(), "警告: マージキューの設定の取得に失敗しました（自動マージは直接マージします）: %v\n", err)
+			}
+			useMergeQueue = detected
+		}
+		if useMergeQueue {
+			fmt.Fprintln(cmd.OutOrStdout(), "  マージキュー: 有効（自動マージはPRをキューに追加し、マージ後にクリーンアップします）")
+		}
+	}
 
 	// Git関連のコンポーネントを作成
 	gitRepository := git.NewRepository(appLogger)
@@ -219,18 +437,27 @@ func runWatchWithFlags(cmd *cobra.Command, args []string, intervalFlag, configFl
 	gitBranch := git.NewBranch(appLogger)
 	gitSync := git.NewSync(appLogger)
 
-	// WorktreeManagerを作成
-	worktreeManager, err := git.NewWorktreeManager(gitRepository, gitWorktree, gitBranch, gitSync)
+	// WorktreeManagerを作成（worktree.modeがcloneの場合はIssueごとのcloneを使用）
+	worktreeManager, err := newWorktreeManager(cfg.Worktree, gitRepository, gitWorktree, gitBranch, gitSync,
+		git.WithKeepBranches(cfg.Safety.RequiresConfirmation(config.OperationDeleteBranch)))
 	if err != nil {
 		return fmt.Errorf("WorktreeManagerの作成に失敗: %w", err)
 	}
+	if cfg.Worktree.Mode == config.WorktreeModeClone {
+		fmt.Fprintln(cmd.OutOrStdout(), "  作業ディレクトリ: Issueごとのclone（worktree.mode: clone）")
+	}
+	if cfg.Worktree.ScratchDir != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "  作業ディレクトリの配置: %s（削除前にブランチをpush）\n", cfg.Worktree.ScratchDir)
+	}
 
 	// Claude関連の設定とExecutorを作成
 	claudeConfig := cfg.Claude
 	if claudeConfig == nil {
 		claudeConfig = claude.NewDefaultClaudeConfig()
 	}
-	claudeExecutor := claude.NewClaudeExecutorWithLogger(appLogger)
+	claudeExecutor := claude.NewClaudeExecutorWithCapabilities(appLogger, detectClaudeCapabilities(cmd, claudeConfig),
+		claude.WithEnvironmentBootstrap(claudeConfig.EnvironmentBootstrap),
+		claude.WithReadinessProbe(claudeConfig.ReadyTimeout))
 
 	// TmuxManagerを作成
 	tmuxManager := tmux.NewManager(appLogger)
@@ -249,31 +476,389 @@ func runWatchWithFlags(cmd *cobra.Command, args []string, intervalFlag, configFl
 		appLogger,
 	)
 
+	// フェーズ間でファイルを受け渡す成果物ディレクトリ（.git/osoba/artifacts/issue-N）をリポジトリのルートに置く
+	var artifactsRoot string
+	if rootPath, err := gitRepository.GetRootPath(context.Background()); err == nil {
+		artifactsRoot = rootPath
+		actionFactory.SetArtifactsRoot(rootPath)
+	} else {
+		appLogger.Warn("Failed to get repository root, phases run without artifacts directory", "error", err)
+	}
+
+	// 端末サイズによりフェーズ専用ウィンドウへフォールバックしたペインの配置を記録し、進捗コメントの取得で参照する
+	// osoba tail・takeover・releaseから参照できるよう、配置はファイルに保存する
+	paneRegistry := tmux.NewPaneRegistry()
+	if repoIdentifier, err := getRepoIdentifierFunc(); err != nil {
+		appLogger.Warn("リポジトリ識別子の取得に失敗したためペインの配置を保存しません", "error", err)
+	} else if err := paneRegistry.SetStorePath(paths.NewPathManager("").StoreFile(repoIdentifier, "panes")); err != nil {
+		appLogger.Warn("保存したペインの配置の読み込みに失敗しました", "error", err)
+	}
+	actionFactory.SetPaneRegistry(paneRegistry)
+
+	// カナリアモード: カナリアのラベルが付いたIssueのフェーズのみこの設定で実行し、それ以外は安定版の設定で実行する
+	var phaseFactory watcher.ActionFactory = actionFactory
+	var stableFactory *watcher.DefaultActionFactory
+	if cfg.Canary.Enabled() {
+		if cfg.Canary.StableConfig != "" {
+			stableCfg, err := cfg.LoadCanaryStableConfig(actualConfigPath)
+			if err != nil {
+				return fmt.Errorf("安定版の設定の読み込みに失敗: %w", err)
+			}
+			stableClaudeConfig := stableCfg.Claude
+			if stableClaudeConfig == nil {
+				stableClaudeConfig = claude.NewDefaultClaudeConfig()
+			}
+			// 安定版のworktreeの設定（mode・scratch_dirなど）でフェーズの作業ディレクトリを作成する
+			stableWorktreeManager, err := newWorktreeManager(stableCfg.Worktree, gitRepository, gitWorktree, gitBranch, gitSync,
+				git.WithKeepBranches(stableCfg.Safety.RequiresConfirmation(config.OperationDeleteBranch)))
+			if err != nil {
+				return fmt.Errorf("安定版のWorktreeManagerの作成に失敗: %w", err)
+			}
+			stableLogger := appLogger.WithFields("canary", "stable")
+			stableFactory = watcher.NewDefaultActionFactory(sessionName, githubClient, tmux.NewManager(stableLogger), stableWorktreeManager,
+				claudeExecutor, stableClaudeConfig, stableCfg, owner, repoName, stableLogger)
+			if rootPath, err := gitRepository.GetRootPath(context.Background()); err == nil {
+				stableFactory.SetArtifactsRoot(rootPath)
+			}
+			stableFactory.SetPaneRegistry(paneRegistry)
+			fmt.Fprintf(cmd.OutOrStdout(), "  カナリアモード: %s のIssueのみ新しい設定で実行（その他: %s）\n",
+				strings.Join(cfg.Canary.Labels, ", "), cfg.Canary.StableConfig)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "  カナリアモード: %s のIssueのみ自動化\n", strings.Join(cfg.Canary.Labels, ", "))
+		}
+		var stable watcher.ActionFactory
+		if stableFactory != nil {
+			stable = stableFactory
+		}
+		canaryFactory := watcher.NewCanaryActionFactory(actionFactory, stable, cfg.Canary, appLogger)
+		canaryFactory.SetIssueLabelReader(githubClient, owner, repoName)
+		phaseFactory = canaryFactory
+	}
+
+	// 無効にしている自動化の機能を表示
+	if disabled := cfg.Features.Disabled(); len(disabled) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "  無効な機能: %s\n", strings.Join(disabled, ", "))
+	}
+
+	// マージ後のクリーンアップ（features.auto_cleanupが無効の場合は何も削除しない）
+	mergeCleanup := newCleanupManager(cfg, sessionName, worktreeManager, artifactsRoot, paneRegistry, appLogger)
+	if !cfg.Features.AutoCleanup {
+		mergeCleanup = cleanup.NewDisabledManager(appLogger)
+	}
+
 	// Issue監視を作成
-	issueWatcher, err := watcher.NewIssueWatcherWithConfig(githubClient, owner, repoName, sessionName, cfg.GetLabels(), cfg.GitHub.PollInterval, appLogger, cfg, nil)
+	issueWatcher, err := watcher.NewIssueWatcherWithConfig(githubClient, owner, repoName, sessionName, cfg.GetLabels(), cfg.GitHub.PollInterval, appLogger, cfg, mergeCleanup)
 	if err != nil {
 		return fmt.Errorf("Issue監視の作成に失敗: %w", err)
 	}
 
 	// ActionManagerにActionFactoryを設定
-	issueWatcher.GetActionManager().SetActionFactory(actionFactory)
+	issueWatcher.GetActionManager().SetActionFactory(phaseFactory)
+
+	// Issueに対して何もしなかった理由を記録する（osoba status --explainで表示）
+	skipExplainer := watcher.NewSkipExplainer()
+	issueWatcher.SetSkipExplainer(skipExplainer)
+
+	// 計画のサブタスク展開を設定（設定で有効な場合）
+	var subIssueExpander *watcher.SubIssueExpander
+	if cfg.GitHub.SubIssues.Enabled {
+		subIssueExpander, err = watcher.NewSubIssueExpander(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("SubIssueExpanderの作成に失敗: %w", err)
+		}
+		subIssueExpander.SetSkipExplainer(skipExplainer)
+		issueWatcher.SetSubIssueExpander(subIssueExpander)
+	}
+
+	// 計画前の既存PRの確認を設定（設定で有効な場合）
+	if cfg.GitHub.ExistingPRGuard.Enabled {
+		existingPRGuard, err := watcher.NewExistingPRGuard(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("ExistingPRGuardの作成に失敗: %w", err)
+		}
+		issueWatcher.SetExistingPRGuard(existingPRGuard)
+	}
+
+	// 計画前の重複Issue検出を設定（設定で有効な場合）
+	if cfg.GitHub.DuplicateDetection.Enabled {
+		duplicateDetector, err := watcher.NewDuplicateDetector(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("DuplicateDetectorの作成に失敗: %w", err)
+		}
+		issueWatcher.SetDuplicateDetector(duplicateDetector)
+	}
+
+	// 実装前の計画後のIssue編集の確認を設定（設定で有効な場合）
+	if cfg.GitHub.PlanStaleness.Enabled {
+		planStalenessDetector, err := watcher.NewPlanStalenessDetector(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("PlanStalenessDetectorの作成に失敗: %w", err)
+		}
+		issueWatcher.SetPlanStalenessDetector(planStalenessDetector)
+	}
+
+	// 実装前の計画承認の確認を設定（設定で有効な場合）
+	if cfg.GitHub.PlanApproval.Enabled {
+		planApprovalGate, err := watcher.NewPlanApprovalGate(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("PlanApprovalGateの作成に失敗: %w", err)
+		}
+		issueWatcher.SetPlanApprovalGate(planApprovalGate)
+	}
+
+	// レビュー前のCIの完了の確認を設定（設定で有効な場合）
+	if cfg.GitHub.CIGate.Enabled {
+		ciGate, err := watcher.NewCIGate(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("CIGateの作成に失敗: %w", err)
+		}
+		if rootPath, err := gitRepository.GetRootPath(context.Background()); err == nil {
+			ciGate.SetArtifactsRoot(rootPath)
+		}
+		// CIの待機の開始時刻と失敗の回数を監視プロセスの再起動後も引き継ぐ
+		if repoIdentifier, err := getRepoIdentifierFunc(); err != nil {
+			appLogger.Warn("リポジトリ識別子の取得に失敗したためCIの待機状況を保存しません", "error", err)
+		} else if err := ciGate.SetStorePath(paths.NewPathManager("").StoreFile(repoIdentifier, "ci-gate")); err != nil {
+			appLogger.Warn("保存したCIの待機状況の読み込みに失敗しました", "error", err)
+		}
+		issueWatcher.SetCIGate(ciGate)
+	}
+
+	// 複数Issueのworktreeの並行作成を設定（worktree.max_parallelが2以上の場合）
+	if cfg.Worktree.MaxParallel > 1 {
+		worktreePrefetcher, err := watcher.NewWorktreePrefetcher(worktreeManager, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("WorktreePrefetcherの作成に失敗: %w", err)
+		}
+		issueWatcher.SetWorktreePrefetcher(worktreePrefetcher)
+	}
 
 	// PR監視を作成（status:lgtmとstatus:requires-changesラベル付きPRを監視）
 	prLabels := []string{"status:lgtm"}
 	if cfg.GitHub.AutoRevisePR {
 		prLabels = append(prLabels, "status:requires-changes")
 	}
-	prWatcher, err := watcher.NewPRWatcherWithConfig(githubClient, owner, repoName, prLabels, cfg.GitHub.PRPollInterval, appLogger, cfg, nil)
+	prWatcher, err := watcher.NewPRWatcherWithConfig(githubClient, owner, repoName, prLabels, cfg.GitHub.PRPollInterval, appLogger, cfg, mergeCleanup)
 	if err != nil {
 		return fmt.Errorf("PR監視の作成に失敗: %w", err)
 	}
 
 	// PR watcherにActionManagerとsessionNameを設定（Reviseアクション用）
 	prActionManager := watcher.NewActionManager(sessionName)
-	prActionManager.SetActionFactory(actionFactory)
+	prActionManager.SetActionFactory(phaseFactory)
 	prWatcher.SetActionManager(prActionManager)
 	prWatcher.SetSessionName(sessionName)
 
+	// 自動マージやRevertへの対応、レビューと修正の往復を記録するイベントストア
+	var events *watcher.EventStore
+	if cfg.GitHub.AutoMergeLGTM || cfg.GitHub.RevertDetection.Enabled || cfg.GitHub.ReviewEscalation.Enabled {
+		if repoIdentifier, err := getRepoIdentifierFunc(); err != nil {
+			appLogger.Warn("リポジトリ識別子の取得に失敗したためマージの記録を行いません", "error", err)
+		} else if events, err = watcher.NewEventStore(paths.NewPathManager("").EventsFile(repoIdentifier)); err != nil {
+			return fmt.Errorf("EventStoreの作成に失敗: %w", err)
+		}
+	}
+
+	// 自動マージ後にリンクされたIssueがクローズされたかを確認し、対応をイベントストアに記録
+	var closureVerifier *watcher.IssueClosureVerifier
+	if cfg.GitHub.AutoMergeLGTM {
+		closureVerifier, err = watcher.NewIssueClosureVerifier(githubClient, owner, repoName, cfg, events, appLogger)
+		if err != nil {
+			return fmt.Errorf("IssueClosureVerifierの作成に失敗: %w", err)
+		}
+		issueWatcher.SetIssueClosureVerifier(closureVerifier)
+		prWatcher.SetIssueClosureVerifier(closureVerifier)
+	}
+
+	// 自動マージとローカルのクリーンアップの後に、マージしたPRのリモートのブランチを削除
+	var remoteBranches *watcher.RemoteBranchCleaner
+	if cfg.GitHub.AutoMergeLGTM && cfg.GitHub.RemoteBranchCleanup.Enabled {
+		remoteBranches, err = watcher.NewRemoteBranchCleaner(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("RemoteBranchCleanerの作成に失敗: %w", err)
+		}
+		// マージを待っているPRのブランチを監視プロセスの再起動後も引き継ぐ
+		if repoIdentifier, err := getRepoIdentifierFunc(); err != nil {
+			appLogger.Warn("リポジトリ識別子の取得に失敗したためリモートのブランチの削除の保留を保存しません", "error", err)
+		} else if err := remoteBranches.SetStorePath(paths.NewPathManager("").StoreFile(repoIdentifier, "remote-branches")); err != nil {
+			appLogger.Warn("保存したリモートのブランチの削除の保留の読み込みに失敗しました", "error", err)
+		}
+		issueWatcher.SetRemoteBranchCleaner(remoteBranches)
+		prWatcher.SetRemoteBranchCleaner(remoteBranches)
+	}
+
+	// ブランチの履歴が書き換えられた（force-pushされた）PRは人が確認するまで自動マージしない
+	if cfg.GitHub.AutoMergeLGTM && cfg.GitHub.HistoryGuard.Enabled {
+		historyGuard, err := watcher.NewHistoryGuard(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("HistoryGuardの作成に失敗: %w", err)
+		}
+		issueWatcher.SetHistoryGuard(historyGuard)
+		prWatcher.SetHistoryGuard(historyGuard)
+	}
+
+	// status:lgtmラベルに加えて設定された条件（ブランチ保護で必要な承認を含む）を満たすPRのみを自動マージする
+	requiresReviews := branchProtection != nil && branchProtection.RequiredApprovingReviews > 0
+	if cfg.GitHub.AutoMergeLGTM && (cfg.GitHub.AutoMerge.HasRules() || requiresReviews) {
+		autoMergePolicy, err := watcher.NewAutoMergePolicy(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("AutoMergePolicyの作成に失敗: %w", err)
+		}
+		autoMergePolicy.SetBranchProtection(branchProtection)
+		issueWatcher.SetAutoMergePolicy(autoMergePolicy)
+		prWatcher.SetAutoMergePolicy(autoMergePolicy)
+	}
+
+	// レビューと修正の往復が続くIssueを人間のレビュアーに引き継ぐ
+	if cfg.GitHub.ReviewEscalation.Enabled {
+		escalator, err := watcher.NewReviewEscalator(githubClient, owner, repoName, cfg, events, appLogger)
+		if err != nil {
+			return fmt.Errorf("ReviewEscalatorの作成に失敗: %w", err)
+		}
+		issueWatcher.SetReviewEscalator(escalator)
+	}
+
+	// 既存のPRレビューボットのレビューを修正に取り込む（設定で有効な場合）
+	if cfg.GitHub.ReviewBots.Enabled {
+		reviewBots, err := watcher.NewReviewBots(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("ReviewBotsの作成に失敗: %w", err)
+		}
+		actionFactory.SetBotReviewSource(reviewBots)
+		if stableFactory != nil {
+			stableFactory.SetBotReviewSource(reviewBots)
+		}
+		issueWatcher.SetReviewBots(reviewBots)
+	}
+
+	// マシンの負荷が高い間は新しいフェーズの開始を保留（設定で有効な場合）
+	var resourceGuard *watcher.ResourceGuard
+	if cfg.ResourceGuard.Enabled {
+		resourceGuard, err = watcher.NewResourceGuard(cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("ResourceGuardの作成に失敗: %w", err)
+		}
+		issueWatcher.SetResourceGuard(resourceGuard)
+	}
+
+	// フェーズごとの同時実行数を上限までに抑える（上限が設定されている場合）
+	if cfg.Concurrency.HasLimits() {
+		phaseBudget, err := watcher.NewPhaseBudget(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("PhaseBudgetの作成に失敗: %w", err)
+		}
+		issueWatcher.SetPhaseBudget(phaseBudget)
+	}
+
+	// 同じ領域に触れるIssueの実装フェーズを同時に実行しない（領域が設定されている場合）
+	if len(cfg.ConflictFences.Areas) > 0 {
+		conflictFence, err := watcher.NewConflictFence(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("ConflictFenceの作成に失敗: %w", err)
+		}
+		issueWatcher.SetConflictFence(conflictFence)
+	}
+
+	// 起動時に処理待ちだった積み残しのIssueを設定した間隔で1件ずつ開始する（設定で有効な場合）
+	var backfill *watcher.Backfill
+	if cfg.Backfill.Enabled {
+		backfill, err = watcher.NewBackfill(cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("Backfillの作成に失敗: %w", err)
+		}
+		issueWatcher.SetBackfill(backfill)
+	}
+
+	// リトライが積み重なったIssueに深刻度のラベル（status:degraded → status:failing）を付与する（設定で有効な場合）
+	var retryBudget *watcher.RetryBudget
+	if cfg.RetryBudget.Enabled {
+		retryBudget, err = watcher.NewRetryBudget(githubClient, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("RetryBudgetの作成に失敗: %w", err)
+		}
+		// リトライの回数を監視プロセスの再起動後も引き継ぐ
+		if repoIdentifier, err := getRepoIdentifierFunc(); err != nil {
+			appLogger.Warn("リポジトリ識別子の取得に失敗したためリトライの回数を保存しません", "error", err)
+		} else if err := retryBudget.SetStorePath(paths.NewPathManager("").StoreFile(repoIdentifier, "retry-budget")); err != nil {
+			appLogger.Warn("保存したリトライの回数の読み込みに失敗しました", "error", err)
+		}
+		issueWatcher.SetRetryBudget(retryBudget)
+	}
+
+	// フェーズの失敗や自動マージの停止をメールで通知
+	var emailNotifier *notify.EmailNotifier
+	if cfg.Notifications.Email.Enabled {
+		emailNotifier = notify.NewEmailNotifier(cfg.Notifications.Email, appLogger)
+		emailNotifier.SetLocation(cfg.Location())
+		issueWatcher.SetNotifier(emailNotifier)
+		prWatcher.SetNotifier(emailNotifier)
+	}
+
+	// ポーリング・フェーズの開始の失敗が続いた場合に不健全な状態にして通知する
+	var degradation *watcher.DegradationMonitor
+	if cfg.Degradation.Enabled {
+		degradation, err = watcher.NewDegradationMonitor(cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("DegradationMonitorの作成に失敗: %w", err)
+		}
+		if emailNotifier != nil {
+			degradation.SetNotifier(emailNotifier, owner+"/"+repoName)
+		}
+		issueWatcher.SetDegradationMonitor(degradation)
+		prWatcher.SetDegradationMonitor(degradation)
+	}
+
+	// マージキューに追加したPRを追跡し、キューがマージしたらクリーンアップする
+	var mergeQueue *watcher.MergeQueue
+	if useMergeQueue {
+		mergeQueue, err = watcher.NewMergeQueue(githubClient, mergeCleanup, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("MergeQueueの作成に失敗: %w", err)
+		}
+		mergeQueue.SetIssueClosureVerifier(closureVerifier)
+		mergeQueue.SetRemoteBranchCleaner(remoteBranches)
+		if emailNotifier != nil {
+			mergeQueue.SetNotifier(emailNotifier)
+		}
+		issueWatcher.SetMergeQueue(mergeQueue)
+		prWatcher.SetMergeQueue(mergeQueue)
+	}
+
+	statePath, panesPath := "", ""
+	if repoIdentifier, err := getRepoIdentifierFunc(); err == nil {
+		statePath = paths.NewPathManager("").StateFile(repoIdentifier)
+		panesPath = paths.NewPathManager("").StoreFile(repoIdentifier, "panes")
+	}
+
+	// 古いバージョンのレイアウト（フェーズ名のウィンドウ・旧形式のworktree）を現在の形式に移行
+	// 実行中のIssueのウィンドウ・worktreeを突き合わせで見失わないよう、突き合わせの前に行う
+	if rootPath, err := gitRepository.GetRootPath(context.Background()); err == nil {
+		runMigrations(cmd, &migrate.Env{
+			RepoRoot:        rootPath,
+			StatePath:       statePath,
+			PanesPath:       panesPath,
+			Sessions:        cfg.Tmux.SessionNames(sessionName),
+			Tmux:            tmuxManager,
+			WorktreeManager: worktreeManager,
+			Worktree:        gitWorktree,
+			Config:          cfg,
+			Logger:          appLogger,
+		})
+	}
+
+	// 前回の実行の残り（ラベル・tmuxウィンドウ・worktree）を突き合わせて結果を表示
+	reconciler, err := watcher.NewStartupReconciler(githubClient, tmuxManager, worktreeManager, owner, repoName, sessionName, statePath, appLogger)
+	if err != nil {
+		return fmt.Errorf("StartupReconcilerの作成に失敗: %w", err)
+	}
+	reconciler.SetShardSessions(cfg.Tmux.SessionNames(sessionName)[1:])
+	if report, err := reconciler.Reconcile(context.Background()); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "警告: 起動時の状態の突き合わせに失敗しました: %v\n", err)
+	} else {
+		printReconcileReport(cmd.OutOrStdout(), report, cfg.Location())
+	}
+	markStartup("状態の突き合わせ")
+
 	// シグナルハンドリング
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -287,6 +872,35 @@ func runWatchWithFlags(cmd *cobra.Command, args []string, intervalFlag, configFl
 		cancel()
 	}()
 
+	// SIGUSR1を受信したらポーリング間隔を待たずにIssueとPRを確認する
+	if len(repollSignals) > 0 {
+		repollCh := make(chan os.Signal, 1)
+		signal.Notify(repollCh, repollSignals...)
+		defer signal.Stop(repollCh)
+
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-repollCh:
+					appLogger.Info("再確認のシグナルを受信しました。IssueとPRを確認します")
+					issueWatcher.RequestPoll()
+					prWatcher.RequestPoll()
+				}
+			}
+		}()
+	}
+
+	// 制御ソケットで再確認（repoll）とIssueの再評価（reprocess N）、メトリクスの要求（metrics）を受け付ける
+	if repoIdentifier, err := getRepoIdentifierFunc(); err != nil {
+		appLogger.Warn("リポジトリ識別子の取得に失敗したため制御ソケットを作成しません", "error", err)
+	} else if controlServer, err := daemon.NewControlServer(paths.NewPathManager("").ControlSocket(repoIdentifier), newControlHandler(appLogger, repoIdentifier, issueWatcher, prWatcher, degradation)); err != nil {
+		appLogger.Warn("制御ソケットの作成に失敗しました", "error", err)
+	} else {
+		go controlServer.Serve(ctx)
+	}
+
 	// Issue監視とPR監視を並行で開始
 	var wg sync.WaitGroup
 
@@ -311,7 +925,7 @@ func runWatchWithFlags(cmd *cobra.Command, args []string, intervalFlag, configFl
 	// クリーンアップ監視を開始（設定で有効な場合）
 	if cfg.Cleanup.Enabled && cfg.Cleanup.IssueWindows.Enabled {
 		// クリーンアップマネージャーを作成
-		cleanupManager := cleanup.NewManager(sessionName, appLogger)
+		cleanupManager := newCleanupManager(cfg, sessionName, worktreeManager, artifactsRoot, paneRegistry, appLogger)
 
 		// クリーンアップ間隔を設定から取得（分単位を秒に変換）
 		cleanupInterval := time.Duration(cfg.Cleanup.IntervalMinutes) * time.Minute
@@ -345,11 +959,581 @@ func runWatchWithFlags(cmd *cobra.Command, args []string, intervalFlag, configFl
 			"issue_windows_enabled", cfg.Cleanup.IssueWindows.Enabled)
 	}
 
+	// 進捗コメントの定期更新を開始（設定で有効な場合）
+	if cfg.GitHub.ProgressComment.Enabled {
+		progressReporter, err := watcher.NewProgressReporter(githubClient, tmuxManager, owner, repoName, sessionName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("ProgressReporterの作成に失敗: %w", err)
+		}
+		progressReporter.SetPaneRegistry(paneRegistry)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			progressReporter.Start(ctx)
+		}()
+	}
+
+	// ハートビートとペインの出力による停止したエージェントの検出を開始（設定で有効な場合）
+	if cfg.Heartbeat.Enabled {
+		stuckDetector, err := watcher.NewStuckDetector(githubClient, tmuxManager, worktreeManager, owner, repoName, sessionName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("StuckDetectorの作成に失敗: %w", err)
+		}
+		stuckDetector.SetPaneRegistry(paneRegistry)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stuckDetector.Start(ctx)
+		}()
+	}
+
+	// フェーズの実行中のIssueへの担当者のアサインを開始（設定で有効な場合）
+	if cfg.GitHub.Assignment.Enabled {
+		assignee := cfg.GitHub.Assignment.Assignee
+		var userErr error
+		if assignee == "" {
+			userCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+			assignee, userErr = authenticatedUserFunc(userCtx)
+			cancel()
+		}
+		if userErr != nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "  ⚠️  アサインするユーザーを取得できないため、Issueの担当者のアサインを無効にします: %v\n", userErr)
+		} else {
+			assigner, err := watcher.NewAssigner(githubClient, owner, repoName, assignee, cfg, appLogger)
+			if err != nil {
+				return fmt.Errorf("Assignerの作成に失敗: %w", err)
+			}
+			// アサインしたIssueを保存し、停止中にフェーズが終わったIssueも再起動後に外す
+			if repoIdentifier, err := getRepoIdentifierFunc(); err != nil {
+				appLogger.Warn("リポジトリ識別子の取得に失敗したためアサインしたIssueを保存しません", "error", err)
+			} else if err := assigner.SetStorePath(paths.NewPathManager("").StoreFile(repoIdentifier, "assigner")); err != nil {
+				appLogger.Warn("保存したアサインしたIssueの読み込みに失敗しました", "error", err)
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assigner.Start(ctx)
+			}()
+		}
+	}
+
+	// Issueのない人が作成したPRのレビュー・修正を開始（設定で有効な場合）
+	if cfg.PRMode.Enabled {
+		prWorktrees, ok := worktreeManager.(git.PullRequestWorktreeCreator)
+		if !ok {
+			fmt.Fprintln(cmd.OutOrStdout(), "  ⚠️  PRモードはworktree.mode: cloneでは利用できません")
+		} else {
+			prReview, err := actionFactory.CreatePullRequestAction(actions.PullRequestPhaseReview)
+			if err != nil {
+				return fmt.Errorf("PRモードのアクションの作成に失敗: %w", err)
+			}
+			prRevise, err := actionFactory.CreatePullRequestAction(actions.PullRequestPhaseRevise)
+			if err != nil {
+				return fmt.Errorf("PRモードのアクションの作成に失敗: %w", err)
+			}
+			prModeWatcher, err := watcher.NewPRModeWatcher(githubClient, prWorktrees, prReview, prRevise, owner, repoName, cfg, appLogger)
+			if err != nil {
+				return fmt.Errorf("PRModeWatcherの作成に失敗: %w", err)
+			}
+			prModeWatcher.SetCleanupManager(mergeCleanup)
+			fmt.Fprintf(cmd.OutOrStdout(), "  PRモード: %s のPRをレビュー\n", cfg.PRMode.ReviewLabel)
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				prModeWatcher.Start(ctx)
+			}()
+		}
+	}
+
+	// マージキューの追跡を開始（マージキューを使う場合）
+	if mergeQueue != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mergeQueue.Start(ctx)
+		}()
+	}
+
+	// フェーズ完了後の無操作ペインの削除を開始（いずれかのフェーズでreap_afterが設定されている場合）
+	if cfg.Tmux.PaneReaperEnabled() {
+		repoIdentifier, err := getRepoIdentifierFunc()
+		if err != nil {
+			return fmt.Errorf("リポジトリ識別子の取得に失敗: %w", err)
+		}
+		outputDir := filepath.Join(paths.NewPathManager("").LogDir(repoIdentifier), "panes")
+		paneReaper, err := watcher.NewPaneReaper(githubClient, tmuxManager, owner, repoName, sessionName, outputDir, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("PaneReaperの作成に失敗: %w", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			paneReaper.Start(ctx)
+		}()
+	}
+
+	// osobaがマージしたPRのRevertの検出を開始
+	if cfg.GitHub.RevertDetection.Enabled {
+		revertDetector, err := watcher.NewRevertDetector(githubClient, owner, repoName, cfg, events, appLogger)
+		if err != nil {
+			return fmt.Errorf("RevertDetectorの作成に失敗: %w", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			revertDetector.Start(ctx)
+		}()
+	}
+
+	// GitHubトークンの変更を確認し、監視を再起動せずに新しいトークンを使用する
+	tokenRefresher, err := watcher.NewTokenRefresher(cfg, token, appLogger)
+	if err != nil {
+		return fmt.Errorf("TokenRefresherの作成に失敗: %w", err)
+	}
+	if cfg.GitHub.CLI.TokenCommand != "" {
+		tokenRefresher.OnRotate(githubPkg.SetToken)
+		tokenRefresher.OnRotate(func(token string) {
+			tmux.SetSessionEnv("GH_TOKEN", token)
+			for _, name := range cfg.Tmux.SessionNames(sessionName) {
+				if exists, err := tmux.SessionExists(name); err != nil || !exists {
+					continue
+				}
+				if err := tmux.ApplySessionEnv(name); err != nil {
+					appLogger.Warn("tmuxセッションのトークンの更新に失敗しました", "session", name, "error", err)
+				}
+			}
+		})
+	}
+	if auditLog != nil {
+		tokenRefresher.OnRotate(auditLog.SetToken)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		tokenRefresher.Start(ctx)
+	}()
+
+	// キューのディレクトリに置かれた作業指示ファイル（YAML/JSON）からのIssue作成を開始
+	if cfg.GitHub.WorkQueue.Enabled {
+		queueDir := cfg.GitHub.WorkQueue.Dir
+		if !filepath.IsAbs(queueDir) {
+			rootPath, err := gitRepository.GetRootPath(context.Background())
+			if err != nil {
+				return fmt.Errorf("リポジトリのルートパスの取得に失敗: %w", err)
+			}
+			queueDir = filepath.Join(rootPath, queueDir)
+		}
+		workQueue, err := watcher.NewWorkQueue(githubClient, owner, repoName, queueDir, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("WorkQueueの作成に失敗: %w", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workQueue.Start(ctx)
+		}()
+	}
+
+	// フェーズが書き出した結果ファイル（.osoba/result.json）の監視を開始
+	if cfg.GitHub.PhaseResult.Enabled {
+		phaseResultWatcher, err := watcher.NewPhaseResultWatcher(githubClient, worktreeManager, owner, repoName, cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("PhaseResultWatcherの作成に失敗: %w", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			phaseResultWatcher.Start(ctx)
+		}()
+	}
+
+	// osoba statusが参照する状態ファイルの書き出しを開始
+	if repoIdentifier, err := getRepoIdentifierFunc(); err != nil {
+		appLogger.Warn("リポジトリ識別子の取得に失敗したため状態ファイルを書き出しません", "error", err)
+	} else {
+		statusWriter, err := watcher.NewStatusStateWriter(githubClient, owner, repoName, paths.NewPathManager("").StateFile(repoIdentifier), cfg, appLogger)
+		if err != nil {
+			return fmt.Errorf("StatusStateWriterの作成に失敗: %w", err)
+		}
+		statusWriter.SetResourceGuard(resourceGuard)
+		statusWriter.SetBranchProtection(branchProtection)
+		statusWriter.SetFaultInjector(faultInjector)
+		statusWriter.SetSkipExplainer(skipExplainer)
+		statusWriter.SetMergeQueue(mergeQueue)
+		statusWriter.SetBackfill(backfill)
+		statusWriter.SetRetryBudget(retryBudget)
+		statusWriter.SetDegradationMonitor(degradation)
+		if cfg.Tmux.StatusOptions {
+			statusWriter.SetStatusLineSessions(cfg.Tmux.SessionNames(sessionName))
+		}
+		if cfg.Badge.Enabled {
+			badgePath := cfg.Badge.Path
+			if badgePath == "" {
+				badgePath = paths.NewPathManager("").BadgeFile(repoIdentifier)
+			}
+			statusWriter.SetBadgePath(badgePath)
+			jsonPath, svgPath := watcher.BadgePaths(badgePath)
+			appLogger.Info("ステータスバッジを書き出します", "path", jsonPath, "svg", svgPath)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statusWriter.Start(ctx)
+		}()
+	}
+
+	// 通知のダイジェスト送信を開始（digestが設定されている場合）
+	if emailNotifier != nil && cfg.Notifications.Email.Digest > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			emailNotifier.Run(ctx)
+		}()
+	}
+
+	// ブロック中の親Issueの監視を開始（サブタスク展開が有効な場合）
+	if subIssueExpander != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			subIssueExpander.Start(ctx)
+		}()
+	}
+
+	markStartup("watcher起動")
+	reportStartupProfile(cmd.ErrOrStderr())
+
 	// すべての監視が終了するまで待機
 	wg.Wait()
+	if pending := githubClient.PendingMutations(); pending > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "警告: GitHubに反映できなかった%d件のコメント・ラベルの追加を破棄しました\n", pending)
+		appLogger.Warn("Dropped deferred GitHub mutations on shutdown", "count", pending)
+	}
 	return nil
 }
 
+// newControlHandler は制御ソケットで受け付けるコマンドを処理するハンドラーを返す
+func newControlHandler(appLogger logger.Logger, repoIdentifier string, issueWatcher *watcher.IssueWatcher, prWatcher *watcher.PRWatcher, degradation *watcher.DegradationMonitor) daemon.ControlHandler {
+	return func(ctx context.Context, command string, args []string) (string, error) {
+		switch command {
+		case "repoll":
+			appLogger.Info("制御コマンドを受信しました。IssueとPRを確認します")
+			issueWatcher.RequestPoll()
+			prWatcher.RequestPoll()
+			return "IssueとPRを確認します", nil
+		case "reprocess":
+			if len(args) != 1 {
+				return "", fmt.Errorf("usage: reprocess <issue-number>")
+			}
+			issueNumber, err := parseIssueNumber(args[0])
+			if err != nil {
+				return "", err
+			}
+			if err := issueWatcher.Reprocess(ctx, issueNumber); err != nil {
+				return "", fmt.Errorf("Issue #%d を再評価できません: %w", issueNumber, err)
+			}
+			return fmt.Sprintf("Issue #%d を次回の確認で再評価します", issueNumber), nil
+		case metrics.ControlCommand:
+			return newMetricsSnapshot(repoIdentifier, issueWatcher, prWatcher, degradation).Encode()
+		}
+		return "", fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+// newMetricsSnapshot は osoba metrics で集約する監視プロセスのメトリクスを返す
+func newMetricsSnapshot(repoIdentifier string, issueWatcher *watcher.IssueWatcher, prWatcher *watcher.PRWatcher, degradation *watcher.DegradationMonitor) metrics.Snapshot {
+	issueStats := issueWatcher.GetHealthStats()
+	prStats := prWatcher.GetHealthStats()
+	snapshot := metrics.Snapshot{
+		Repo:          repoIdentifier,
+		PID:           os.Getpid(),
+		StartTime:     issueStats.StartTime,
+		IssuePolls:    pollCounters(issueStats),
+		PRPolls:       pollCounters(prStats),
+		LastIssuePoll: issueStats.LastExecutionTime,
+		LastPRPoll:    prStats.LastExecutionTime,
+	}
+	if transitions := issueWatcher.GetLabelTransitionMetrics(); transitions != nil {
+		snapshot.LabelTransitions = metrics.Counters{
+			Total:      transitions.TotalTransitions,
+			Successful: transitions.SuccessfulTransitions,
+			Failed:     transitions.FailedTransitions,
+		}
+	}
+	// 自動マージはIssue監視とPR監視のそれぞれで記録している
+	for _, merges := range []watcher.AutoMergeMetricsSnapshot{issueWatcher.GetAutoMergeMetrics(), prWatcher.GetAutoMergeMetrics()} {
+		snapshot.AutoMerges.Total += merges.TotalAttempts
+		snapshot.AutoMerges.Successful += merges.SuccessfulMerges
+		snapshot.AutoMerges.Failed += merges.FailedMerges
+	}
+	if status := degradation.Status(); status != nil {
+		snapshot.Degraded = status.Summary()
+	}
+	return snapshot
+}
+
+// pollCounters はポーリングの実行回数をメトリクスの形式に変換する
+func pollCounters(stats watcher.HealthStats) metrics.Counters {
+	return metrics.Counters{
+		Total:      int64(stats.TotalExecutions),
+		Successful: int64(stats.SuccessfulExecutions),
+		Failed:     int64(stats.FailedExecutions),
+	}
+}
+
+// preconditionLabels は前提条件の表示名
+var preconditionLabels = map[string]string{
+	config.PreconditionBranchProtection: "ブランチ保護",
+	config.PreconditionCIWorkflow:       "CIワークフロー",
+	config.PreconditionCodeowners:       "CODEOWNERS",
+}
+
+// preconditionActionLabels は前提条件を満たしていない場合の扱いの表示名
+var preconditionActionLabels = map[string]string{
+	config.PreconditionWarn:           "警告のみ",
+	config.PreconditionBlockAutoMerge: "自動マージを無効化",
+	config.PreconditionBlockAll:       "自動処理を停止",
+}
+
+// printPreconditionReport はリポジトリの前提条件の確認結果を表示する（確認した前提条件がない場合は何も表示しない）
+func printPreconditionReport(out io.Writer, report *watcher.PreconditionReport) {
+	if len(report.Results) == 0 {
+		return
+	}
+	fmt.Fprintln(out, "\nリポジトリの前提条件:")
+	for _, result := range report.Results {
+		name := preconditionLabels[result.Rule]
+		switch {
+		case result.Error != "":
+			fmt.Fprintf(out, "  ? %s: 確認できませんでした (%s)\n", name, result.Error)
+		case result.Satisfied:
+			fmt.Fprintf(out, "  ✓ %s: %s\n", name, result.Detail)
+		default:
+			fmt.Fprintf(out, "  ✗ %s: %s [%s]\n", name, preconditionViolation(result), preconditionActionLabels[result.Action])
+		}
+	}
+}
+
+// preconditionViolation は前提条件を満たしていない内容を返す
+func preconditionViolation(result watcher.PreconditionResult) string {
+	switch result.Rule {
+	case config.PreconditionBranchProtection:
+		return fmt.Sprintf("デフォルトブランチ %s が保護されていません", result.Detail)
+	case config.PreconditionCIWorkflow:
+		return "有効なワークフローがありません"
+	case config.PreconditionCodeowners:
+		return "CODEOWNERSファイルがありません"
+	}
+	return "満たしていません"
+}
+
+// printBranchProtection はデフォルトブランチの保護ルールによる自動マージの制約を表示する
+func printBranchProtection(out io.Writer, protection *githubPkg.BranchProtection) {
+	constraints := branchProtectionConstraints(protection)
+	if len(constraints) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\nブランチ保護 (%s):\n", protection.Branch)
+	for _, constraint := range constraints {
+		fmt.Fprintf(out, "  - %s\n", constraint)
+	}
+}
+
+// branchProtectionConstraints はブランチ保護ルールのうち自動マージに影響する制約を返す
+func branchProtectionConstraints(protection *githubPkg.BranchProtection) []string {
+	if protection == nil || !protection.Protected {
+		return nil
+	}
+	var constraints []string
+	if protection.RequiredApprovingReviews > 0 {
+		constraints = append(constraints, fmt.Sprintf("必要な承認: %d件（承認のないPRは自動マージしません）", protection.RequiredApprovingReviews))
+	}
+	if len(protection.RequiredChecks) > 0 {
+		constraints = append(constraints, fmt.Sprintf("必須チェック: %s", strings.Join(protection.RequiredChecks, ", ")))
+	}
+	if protection.RequireLinearHistory {
+		constraints = append(constraints, "直線的な履歴（rebaseでマージします）")
+	}
+	return constraints
+}
+
+// printReconcileReport は起動時の突き合わせ結果を表示する
+func printReconcileReport(out io.Writer, report *watcher.ReconcileReport, loc *time.Location) {
+	fmt.Fprintln(out, "\n起動時の状態の突き合わせ:")
+	if !report.PreviousRun.IsZero() {
+		fmt.Fprintf(out, "  前回の状態: %s\n", report.PreviousRun.In(loc).Format("2006-01-02 15:04:05"))
+	}
+	fmt.Fprintf(out, "  作業を継続: %d件\n", len(report.Resumed))
+	for _, item := range report.Resumed {
+		fmt.Fprintf(out, "    #%d %s (ウィンドウ: %s)\n", item.IssueNumber, item.Label, item.Resource)
+	}
+	fmt.Fprintf(out, "  ラベルを修復: %d件\n", len(report.Repaired))
+	for _, item := range report.Repaired {
+		fmt.Fprintf(out, "    #%d %s → %s\n", item.IssueNumber, item.Label, item.NewLabel)
+	}
+	fmt.Fprintf(out, "  孤立したリソース: %d件\n", len(report.Orphans))
+	for _, item := range report.Orphans {
+		fmt.Fprintf(out, "    #%d %s\n", item.IssueNumber, item.Resource)
+	}
+	if len(report.Orphans) > 0 {
+		fmt.Fprintln(out, "    （osoba clean <Issue番号> で削除できます）")
+	}
+	if len(report.Changed) > 0 {
+		fmt.Fprintf(out, "  停止中に状態が変わったIssue: %d件\n", len(report.Changed))
+		for _, item := range report.Changed {
+			fmt.Fprintf(out, "    #%d %s → %s\n", item.IssueNumber, item.Label, item.NewLabel)
+		}
+	}
+}
+
+// formatAllowedOperations はsafety.allowの表示用文字列を返す
+func formatAllowedOperations(allow []string) string {
+	if len(allow) == 0 {
+		return "なし"
+	}
+	return strings.Join(allow, ", ")
+}
+
+// newWorktreeManager はworktree.modeとworktree.scratch_dirに応じたWorktreeManagerを作成する
+func newWorktreeManager(cfg config.WorktreeConfig, repository git.Repository, worktree *git.Worktree, branch *git.Branch, sync *git.Sync, opts ...git.WorktreeManagerOption) (git.WorktreeManager, error) {
+	if cfg.ScratchDir != "" {
+		opts = append(opts, git.WithScratchDir(cfg.ScratchDir))
+	}
+	if cfg.Mode == config.WorktreeModeClone {
+		return git.NewCloneManager(repository, worktree, branch, sync, opts...)
+	}
+	return git.NewWorktreeManager(repository, worktree, branch, sync, opts...)
+}
+
+// runMigrations は未適用のレイアウトの移行を適用し、結果を表示する
+// 移行に失敗した場合はその移行の変更を取り消し、警告を表示して起動を続ける
+func runMigrations(cmd *cobra.Command, env *migrate.Env) {
+	runner, err := migrate.NewRunner(env)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "警告: レイアウトの移行を開始できませんでした: %v\n", err)
+		return
+	}
+	result, err := runner.Run(context.Background())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "警告: レイアウトの移行に失敗しました: %v\n", err)
+		if result != nil && result.BackupDir != "" {
+			fmt.Fprintf(cmd.ErrOrStderr(), "  移行前のファイルと記録: %s\n", result.BackupDir)
+		}
+		return
+	}
+	// 新しくインストールした場合など、移行する対象がなかった場合は何も表示しない
+	if len(result.Applied) == 0 {
+		return
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "レイアウトを移行しました（v%d → v%d）\n", result.From, result.To)
+	for _, step := range result.Applied {
+		fmt.Fprintf(cmd.OutOrStdout(), "  - %s\n", step)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "  移行前のファイルと記録: %s\n", result.BackupDir)
+}
+
+// newCleanupManager はtmux.shardsのセッションも対象とするクリーンアップマネージャーを作成する
+// worktree.scratch_dirの作業ディレクトリは、WorktreeManagerで作業内容を退避してから削除する
+// artifactsRootが空でない場合は、Issueの成果物ディレクトリも削除する
+// panesが設定されている場合は、監視プロセスが保存したIssueのペインの配置も削除する
+func newCleanupManager(cfg *config.Config, sessionName string, worktreeManager git.WorktreeManager, artifactsRoot string, panes *tmux.PaneRegistry, logger logger.Logger) cleanup.Manager {
+	manager := cleanup.NewManagerWithShards(cfg.Tmux.SessionNames(sessionName), logger, cfg.Safety)
+	if m, ok := manager.(*cleanup.DefaultManager); ok {
+		if cfg.Worktree.ScratchDir != "" {
+			m.SetWorktreeManager(worktreeManager)
+		}
+		m.SetArtifactsRoot(artifactsRoot)
+		m.SetPaneRegistry(panes)
+	}
+	return manager
+}
+
+// applyGHCommandConfig はgithub.ghの設定（実行ファイル・タイムアウト・追加の引数）をghコマンドの実行に適用する
+func applyGHCommandConfig(cmd *cobra.Command, cfg *config.Config) error {
+	ghCfg := cfg.GitHub.CLI
+	if ghCfg.Path != "" {
+		path, err := exec.LookPath(ghCfg.Path)
+		if err != nil {
+			return fmt.Errorf("ghの実行ファイルが見つかりません (github.gh.path: %s): %w", ghCfg.Path, err)
+		}
+		ghCfg.Path = path
+		fmt.Fprintf(cmd.OutOrStdout(), "  ghの実行ファイル: %s\n", path)
+	}
+	if len(ghCfg.Args) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "  ghの追加の引数: %s\n", strings.Join(ghCfg.Args, " "))
+	}
+	if ghCfg.Host != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "  ghの接続先: %s\n", ghCfg.Host)
+	}
+	if ghCfg.Proxy != "" {
+		proxy := ghCfg.Proxy
+		if u, err := url.Parse(proxy); err == nil {
+			proxy = u.Redacted()
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "  ghのプロキシ: %s\n", proxy)
+	}
+	githubPkg.SetCommandConfig(githubPkg.CommandConfig{
+		Path:    ghCfg.Path,
+		Timeout: ghCfg.Timeout,
+		Args:    ghCfg.Args,
+		Host:    ghCfg.Host,
+		Proxy:   ghCfg.Proxy,
+		NoProxy: ghCfg.NoProxy,
+	})
+	return nil
+}
+
+// readTokenFromStdin は標準入力からGitHubトークンを読み込み、OSOBA_GITHUB_TOKENに設定する
+func readTokenFromStdin(in io.Reader) error {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return fmt.Errorf("標準入力からのトークンの読み込みに失敗: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return errors.New("--token-stdin が指定されましたが、標準入力からトークンを読み込めませんでした")
+	}
+	return os.Setenv(config.GitHubTokenEnv, token)
+}
+
+// verifyEnvToken はOSOBA_GITHUB_TOKENのトークンをghコマンドに渡し、認証できるかを確認する
+// ghが対話的な確認を求めないよう、GH_PROMPT_DISABLEDも設定する
+func verifyEnvToken(out io.Writer, token string) error {
+	githubPkg.SetToken(token)
+	if err := os.Setenv("GH_PROMPT_DISABLED", "1"); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	login, err := authenticatedUserFunc(ctx)
+	if err != nil {
+		return fmt.Errorf("%sのトークンで認証できません: %w", config.GitHubTokenEnv, err)
+	}
+	fmt.Fprintf(out, "  GitHubユーザー: %s (ghの認証情報は使用しません)\n", login)
+	return nil
+}
+
+// configuredLabelDefinitions は設定のラベル名から、起動時に作成するラベルの定義を作る
+func configuredLabelDefinitions(names []string) []githubPkg.LabelDefinition {
+	labels := gh.ConfiguredLabels(names)
+	defs := make([]githubPkg.LabelDefinition, 0, len(labels))
+	for _, label := range labels {
+		defs = append(defs, githubPkg.LabelDefinition{Name: label.Name, Color: label.Color, Description: label.Description})
+	}
+	return defs
+}
+
 // isDaemonMode はデーモンモードで起動されているかを確認します
 func isDaemonMode() bool {
 	return os.Getenv("OSOBA_DAEMON_MODE") == "1"
@@ -433,6 +1617,20 @@ func startInBackground(cmd *cobra.Command, args []string) error {
 	// 現在のコマンドライン引数を取得
 	cmdArgs := os.Args[1:]
 
+	// 起動後に自動接続する場合は親プロセスを終了させずに起動する
+	attachFlag, _ := cmd.Flags().GetBool("attach")
+	if attachFlag || cfg.Tmux.AutoAttach {
+		pid, err := dm.Spawn(context.Background(), cmdArgs)
+		if err != nil {
+			return fmt.Errorf("バックグラウンド起動に失敗: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "バックグラウンドで起動しました")
+		fmt.Fprintf(cmd.OutOrStdout(), "PID: %d\n", pid)
+
+		sessionName := fmt.Sprintf("%s%s", cfg.Tmux.SessionPrefix, repoInfo.Repo)
+		return waitAndAttachSession(cmd.OutOrStdout(), sessionName)
+	}
+
 	// デーモンモードで起動
 	if err := dm.Start(context.Background(), cmdArgs); err != nil {
 		return fmt.Errorf("バックグラウンド起動に失敗: %w", err)
@@ -445,6 +1643,28 @@ func startInBackground(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// waitAndAttachSession はデーモンがtmuxセッションを作成するまで待機してから接続します
+func waitAndAttachSession(out io.Writer, sessionName string) error {
+	fmt.Fprintf(out, "tmuxセッション '%s' に接続します...\n", sessionName)
+
+	deadline := time.Now().Add(attachWaitTimeout)
+	for {
+		exists, err := sessionExistsFunc(sessionName)
+		if err == nil && exists {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("tmuxセッション '%s' の作成待機がタイムアウトしました（osoba open で手動接続してください）", sessionName)
+		}
+		time.Sleep(attachPollInterval)
+	}
+
+	if isInsideTmux() {
+		return switchToSessionFunc(sessionName)
+	}
+	return attachToSessionFunc(sessionName)
+}
+
 // runInDaemonMode はデーモンモードでの実行を処理します
 func runInDaemonMode(cmd *cobra.Command, pidFile string, intervalFlag, configFlag string) error {
 	// PIDファイルを作成
@@ -465,8 +1685,11 @@ func runInDaemonMode(cmd *cobra.Command, pidFile string, intervalFlag, configFla
 		return fmt.Errorf("ログディレクトリの作成に失敗: %w", err)
 	}
 
-	// ログファイルパスを生成（日付ベース）
-	logFile := filepath.Join(logDir, time.Now().Format("2006-01-02")+".log")
+	// ログファイルパスを生成（設定のタイムゾーンでの日付ベース）
+	// 設定ファイルを読み込めない場合も、エラーを記録するためにログファイルは開く
+	cfg := config.NewConfig()
+	_, configErr := cfg.LoadOrDefaultWithError(configFlag)
+	logFile := dailyLogFile(logDir, cfg.Location())
 
 	// ログファイルを開く
 	f, err := os.OpenFile(logFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
@@ -479,6 +1702,11 @@ func runInDaemonMode(cmd *cobra.Command, pidFile string, intervalFlag, configFla
 	cmd.SetOut(f)
 	cmd.SetErr(f)
 
+	if configErr != nil {
+		fmt.Fprintf(f, "エラー: 設定ファイルの読み込みに失敗しました: %v\n", configErr)
+		return fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", configErr)
+	}
+
 	// 通常の監視処理を実行
 	return runWatchWithFlagsFunc(cmd, []string{}, intervalFlag, configFlag)
 }
@@ -504,3 +1732,21 @@ func createPIDFile(pidFile string) error {
 
 	return daemon.WritePIDFile(pidFile, info)
 }
+
+// detectClaudeCapabilities はclaude CLIのバージョンと対応フラグを検出し、設定との非互換を警告する
+// 検出できない場合もwatcherは起動し、フェーズ実行時の引数は調整しない
+func detectClaudeCapabilities(cmd *cobra.Command, claudeConfig *claude.ClaudeConfig) *claude.Capabilities {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	caps, err := claude.DetectCapabilities(ctx)
+	if err != nil {
+		fmt.Fprintf(cmd.OutOrStderr(), "警告: claude CLIのバージョンを検出できませんでした: %v\n", err)
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "  Claude CLI: %s\n", caps.Version)
+	for _, warning := range caps.Warnings(claudeConfig) {
+		fmt.Fprintf(cmd.OutOrStderr(), "警告: %s\n", warning)
+	}
+	return caps
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitAndAttachSession(t *testing.T) {
+	origSessionExists := sessionExistsFunc
+	origAttach := attachToSessionFunc
+	origSwitch := switchToSessionFunc
+	origTimeout := attachWaitTimeout
+	origInterval := attachPollInterval
+	defer func() {
+		sessionExistsFunc = origSessionExists
+		attachToSessionFunc = origAttach
+		switchToSessionFunc = origSwitch
+		attachWaitTimeout = origTimeout
+		attachPollInterval = origInterval
+	}()
+
+	attachWaitTimeout = 50 * time.Millisecond
+	attachPollInterval = time.Millisecond
+
+	tests := []struct {
+		name         string
+		insideTmux   bool
+		existsAfter  int // セッションが存在すると判定されるまでの呼び出し回数（-1は存在しない）
+		wantErr      string
+		wantAttached bool
+		wantSwitched bool
+	}{
+		{
+			name:         "正常系: セッション作成を待ってattach",
+			existsAfter:  3,
+			wantAttached: true,
+		},
+		{
+			name:         "正常系: tmux内からはswitch-client",
+			insideTmux:   true,
+			existsAfter:  1,
+			wantSwitched: true,
+		},
+		{
+			name:        "異常系: セッションが作成されずタイムアウト",
+			existsAfter: -1,
+			wantErr:     "タイムアウト",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.insideTmux {
+				t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+			} else {
+				t.Setenv("TMUX", "")
+			}
+
+			calls := 0
+			sessionExistsFunc = func(sessionName string) (bool, error) {
+				calls++
+				return tt.existsAfter > 0 && calls >= tt.existsAfter, nil
+			}
+			attached, switched := false, false
+			attachToSessionFunc = func(sessionName string) error {
+				attached = true
+				return nil
+			}
+			switchToSessionFunc = func(sessionName string) error {
+				switched = true
+				return nil
+			}
+
+			buf := new(bytes.Buffer)
+			err := waitAndAttachSession(buf, "osoba-test")
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("waitAndAttachSession() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("waitAndAttachSession() unexpected error: %v", err)
+			}
+			if attached != tt.wantAttached || switched != tt.wantSwitched {
+				t.Errorf("attached = %v, switched = %v; want %v, %v", attached, switched, tt.wantAttached, tt.wantSwitched)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/douhashi/osoba/internal/config"
+	githubPkg "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/paths"
+	"github.com/douhashi/osoba/internal/watcher"
+)
+
+// orgRepoStopTimeout はリポジトリのwatcherの終了を待つ時間
+const orgRepoStopTimeout = 30 * time.Second
+
+// テスト用にモック可能な関数変数
+var (
+	// cloneRepoFunc はリポジトリをdirにcloneする
+	cloneRepoFunc = func(ctx context.Context, fullName, dir string) error {
+		c := githubPkg.Command(ctx, "repo", "clone", fullName, dir)
+		if token := os.Getenv(config.GitHubTokenEnv); token != "" {
+			env := c.Env
+			if env == nil {
+				env = os.Environ()
+			}
+			c.Env = append(env, "GH_TOKEN="+token)
+		}
+		output, err := c.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+	// startRepoProcessFunc はリポジトリのディレクトリでwatcherのプロセスを起動する
+	startRepoProcessFunc = func(dir string, args []string, logFile *os.File) (*exec.Cmd, error) {
+		executable, err := os.Executable()
+		if err != nil {
+			return nil, err
+		}
+		c := exec.Command(executable, args...)
+		c.Dir = dir
+		c.Stdout = logFile
+		c.Stderr = logFile
+		if err := c.Start(); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+)
+
+// runOrgWatch は組織モードでリポジトリを検出し、リポジトリごとにwatcherのプロセスを起動する
+func runOrgWatch(cmd *cobra.Command, cfg *config.Config, configPath string) error {
+	appLogger, err := logger.New(logger.WithLevel(cfg.Log.Level), logger.WithLocation(cfg.Location()))
+	if err != nil {
+		return fmt.Errorf("ロガーの作成に失敗: %w", err)
+	}
+
+	githubClient, err := githubPkg.NewClientWithLogger("", appLogger)
+	if err != nil {
+		return fmt.Errorf("GitHubクライアントの作成に失敗: %w", err)
+	}
+
+	absConfigPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return fmt.Errorf("設定ファイルのパスの解決に失敗: %w", err)
+	}
+	cloneDir, err := resolveOrgCloneDir(cfg)
+	if err != nil {
+		return err
+	}
+
+	runner := newOrgRepoRunner(absConfigPath, cloneDir, paths.NewPathManager(""), appLogger)
+	runner.location = cfg.Location()
+	discoverer, err := watcher.NewRepoDiscoverer(githubClient, runner, cfg, appLogger)
+	if err != nil {
+		return fmt.Errorf("RepoDiscovererの作成に失敗: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "組織モード: %s のリポジトリを検出して監視します\n", cfg.GitHub.Org)
+	fmt.Fprintf(cmd.OutOrStdout(), "  cloneディレクトリ: %s\n", cloneDir)
+	fmt.Fprintf(cmd.OutOrStdout(), "  検出間隔: %s\n", cfg.GitHub.OrgRepos.DiscoveryInterval)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// 再確認のシグナルは各リポジトリのwatcherに転送する
+	if len(repollSignals) > 0 {
+		repollCh := make(chan os.Signal, 1)
+		signal.Notify(repollCh, repollSignals...)
+		defer signal.Stop(repollCh)
+
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case sig := <-repollCh:
+					runner.signalAll(sig)
+				}
+			}
+		}()
+	}
+
+	// 終了時はすべてのリポジトリのwatcherを停止してから戻る
+	discoverer.Start(ctx)
+	return nil
+}
+
+// resolveOrgCloneDir はリポジトリをcloneするディレクトリを返す
+func resolveOrgCloneDir(cfg *config.Config) (string, error) {
+	dir := cfg.GitHub.OrgRepos.CloneDir
+	if dir == "" {
+		return filepath.Join(paths.NewPathManager("").DataDir(), "repos", cfg.GitHub.Org), nil
+	}
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		home, err := osUserHomeDirFunc()
+		if err != nil {
+			return "", fmt.Errorf("ホームディレクトリの取得に失敗: %w", err)
+		}
+		dir = filepath.Join(home, strings.TrimPrefix(dir, "~"))
+	}
+	return filepath.Abs(dir)
+}
+
+// orgRepoProcess は起動中のリポジトリのwatcherプロセス
+type orgRepoProcess struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+// orgRepoRunner はリポジトリのcloneでosoba start --foregroundを子プロセスとして実行するRepoRunner
+type orgRepoRunner struct {
+	configPath  string
+	cloneDir    string
+	pathManager paths.PathManager
+	logger      logger.Logger
+	location    *time.Location // ログファイルの日付のタイムゾーン
+
+	mu        sync.Mutex
+	processes map[string]*orgRepoProcess
+}
+
+func newOrgRepoRunner(configPath, cloneDir string, pathManager paths.PathManager, logger logger.Logger) *orgRepoRunner {
+	return &orgRepoRunner{
+		configPath:  configPath,
+		cloneDir:    cloneDir,
+		pathManager: pathManager,
+		logger:      logger,
+		location:    time.Local,
+		processes:   make(map[string]*orgRepoProcess),
+	}
+}
+
+// StartRepo は必要に応じてリポジトリをcloneし、watcherのプロセスを起動する
+func (r *orgRepoRunner) StartRepo(ctx context.Context, repo *githubPkg.OrgRepository) error {
+	dir := filepath.Join(r.cloneDir, repo.Name)
+	if _, err := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
+		r.logger.Info("リポジトリをcloneします", "repo", repo.FullName(), "dir", dir)
+		if err := os.MkdirAll(r.cloneDir, 0755); err != nil {
+			return fmt.Errorf("failed to create clone directory: %w", err)
+		}
+		if err := cloneRepoFunc(ctx, repo.FullName(), dir); err != nil {
+			return fmt.Errorf("failed to clone %s: %w", repo.FullName(), err)
+		}
+	}
+
+	logDir := r.pathManager.LogDir(fmt.Sprintf("%s-%s", repo.Owner, repo.Name))
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	logFile, err := os.OpenFile(dailyLogFile(logDir, r.location), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	// 子プロセスは組織モードに入らず、cloneしたリポジトリのみを監視する
+	args := []string{"start", "--foreground", "--org-member", "--config", r.configPath}
+	c, err := startRepoProcessFunc(dir, args, logFile)
+	if err != nil {
+		logFile.Close()
+		return fmt.Errorf("failed to start watcher process: %w", err)
+	}
+
+	proc := &orgRepoProcess{cmd: c, done: make(chan struct{})}
+	go func() {
+		defer close(proc.done)
+		defer logFile.Close()
+		if err := c.Wait(); err != nil {
+			r.logger.Warn("リポジトリのwatcherが終了しました", "repo", repo.FullName(), "error", err)
+		}
+	}()
+
+	r.mu.Lock()
+	r.processes[repo.FullName()] = proc
+	r.mu.Unlock()
+	return nil
+}
+
+// IsRunning はwatcherのプロセスが動作中かを返す
+func (r *orgRepoRunner) IsRunning(repo *githubPkg.OrgRepository) bool {
+	r.mu.Lock()
+	proc, ok := r.processes[repo.FullName()]
+	r.mu.Unlock()
+	if !ok {
+		return false
+	}
+	select {
+	case <-proc.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// signalAll は起動中のすべてのwatcherのプロセスにシグナルを送る
+func (r *orgRepoRunner) signalAll(sig os.Signal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, proc := range r.processes {
+		if err := proc.cmd.Process.Signal(sig); err != nil {
+			r.logger.Warn("watcherプロセスへのシグナル送信に失敗しました", "repo", name, "signal", sig, "error", err)
+		}
+	}
+}
+
+// StopRepo はwatcherのプロセスにSIGTERMを送り、終了を待つ
+func (r *orgRepoRunner) StopRepo(repo *githubPkg.OrgRepository) error {
+	r.mu.Lock()
+	proc, ok := r.processes[repo.FullName()]
+	delete(r.processes, repo.FullName())
+	r.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	select {
+	case <-proc.done:
+		return nil
+	default:
+	}
+
+	if err := proc.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		return fmt.Errorf("failed to stop watcher process: %w", err)
+	}
+	select {
+	case <-proc.done:
+	case <-time.After(orgRepoStopTimeout):
+		_ = proc.cmd.Process.Kill()
+		<-proc.done
+	}
+	return nil
+}
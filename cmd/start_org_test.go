@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	githubPkg "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/paths"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgRepoRunner(t *testing.T) {
+	origClone := cloneRepoFunc
+	origStart := startRepoProcessFunc
+	defer func() {
+		cloneRepoFunc = origClone
+		startRepoProcessFunc = origStart
+	}()
+
+	baseDir := t.TempDir()
+	cloneDir := filepath.Join(baseDir, "repos")
+
+	var clonedTo string
+	cloneRepoFunc = func(ctx context.Context, fullName, dir string) error {
+		clonedTo = dir
+		return os.MkdirAll(filepath.Join(dir, ".git"), 0755)
+	}
+	var gotDir string
+	var gotArgs []string
+	startRepoProcessFunc = func(dir string, args []string, logFile *os.File) (*exec.Cmd, error) {
+		gotDir, gotArgs = dir, args
+		c := exec.Command("sleep", "30")
+		return c, c.Start()
+	}
+
+	testLogger, err := logger.New(logger.WithLevel("error"))
+	require.NoError(t, err)
+	runner := newOrgRepoRunner("/etc/osoba/org.yml", cloneDir, paths.NewPathManager(baseDir), testLogger)
+	repo := &githubPkg.OrgRepository{Owner: "myorg", Name: "api"}
+
+	require.NoError(t, runner.StartRepo(context.Background(), repo))
+	assert.Equal(t, filepath.Join(cloneDir, "api"), clonedTo)
+	assert.Equal(t, filepath.Join(cloneDir, "api"), gotDir)
+	assert.Equal(t, []string{"start", "--foreground", "--org-member", "--config", "/etc/osoba/org.yml"}, gotArgs)
+	assertSingleLogFile(t, filepath.Join(baseDir, "logs", "myorg-api"))
+	assert.True(t, runner.IsRunning(repo))
+
+	require.NoError(t, runner.StopRepo(repo))
+	assert.False(t, runner.IsRunning(repo))
+
+	// clone済みの場合は再度cloneしない
+	clonedTo = ""
+	require.NoError(t, runner.StartRepo(context.Background(), repo))
+	assert.Empty(t, clonedTo)
+	require.NoError(t, runner.StopRepo(repo))
+}
+
+// assertSingleLogFile は子プロセスのログファイルが1つ作成されていることを確認する
+func assertSingleLogFile(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestResolveOrgCloneDir(t *testing.T) {
+	origHome := osUserHomeDirFunc
+	defer func() { osUserHomeDirFunc = origHome }()
+	osUserHomeDirFunc = func() (string, error) { return "/home/dev", nil }
+
+	cfg := config.NewConfig()
+	cfg.GitHub.Org = "myorg"
+	cfg.GitHub.OrgRepos.CloneDir = "~/work/myorg"
+
+	dir, err := resolveOrgCloneDir(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "/home/dev/work/myorg", dir)
+}
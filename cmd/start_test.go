@@ -2,15 +2,20 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/git"
+	githubPkg "github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/testutil/helpers"
+	"github.com/douhashi/osoba/internal/watcher"
 	"github.com/spf13/cobra"
 )
 
@@ -474,3 +479,142 @@ func TestStartCmdExecution(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintReconcileReport(t *testing.T) {
+	var buf bytes.Buffer
+	printReconcileReport(&buf, &watcher.ReconcileReport{
+		Resumed:  []watcher.ReconcileItem{{IssueNumber: 10, Label: "status:implementing", Resource: "issue-10"}},
+		Repaired: []watcher.ReconcileItem{{IssueNumber: 11, Label: "status:planning", NewLabel: "status:needs-plan"}},
+		Orphans:  []watcher.ReconcileItem{{IssueNumber: 8, Resource: "issue-8"}},
+	}, time.UTC)
+
+	output := buf.String()
+	for _, want := range []string{
+		"作業を継続: 1件",
+		"#10 status:implementing (ウィンドウ: issue-10)",
+		"ラベルを修復: 1件",
+		"#11 status:planning → status:needs-plan",
+		"孤立したリソース: 1件",
+		"osoba clean <Issue番号>",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output does not contain %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "前回の状態") || strings.Contains(output, "停止中に状態が変わったIssue") {
+		t.Errorf("output should omit empty sections:\n%s", output)
+	}
+}
+
+func TestPrintReconcileReport_PreviousRunInLocation(t *testing.T) {
+	var buf bytes.Buffer
+	printReconcileReport(&buf, &watcher.ReconcileReport{
+		PreviousRun: time.Date(2024, 5, 1, 3, 4, 5, 0, time.UTC),
+	}, time.FixedZone("JST", 9*60*60))
+
+	if want := "前回の状態: 2024-05-01 12:04:05"; !strings.Contains(buf.String(), want) {
+		t.Errorf("output does not contain %q:\n%s", want, buf.String())
+	}
+}
+
+func TestReadTokenFromStdin(t *testing.T) {
+	t.Setenv(config.GitHubTokenEnv, "")
+
+	if err := readTokenFromStdin(strings.NewReader("  ci-token\n")); err != nil {
+		t.Fatalf("readTokenFromStdin() error = %v", err)
+	}
+	if got := os.Getenv(config.GitHubTokenEnv); got != "ci-token" {
+		t.Errorf("%s = %q, want %q", config.GitHubTokenEnv, got, "ci-token")
+	}
+
+	if err := readTokenFromStdin(strings.NewReader("\n")); err == nil {
+		t.Error("readTokenFromStdin() with empty input should return error")
+	}
+}
+
+func TestVerifyEnvToken(t *testing.T) {
+	original := authenticatedUserFunc
+	defer func() {
+		authenticatedUserFunc = original
+		githubPkg.SetToken("")
+	}()
+	t.Setenv("GH_PROMPT_DISABLED", "")
+
+	authenticatedUserFunc = func(ctx context.Context) (string, error) {
+		return "ci-bot", nil
+	}
+	var buf bytes.Buffer
+	if err := verifyEnvToken(&buf, "ci-token"); err != nil {
+		t.Fatalf("verifyEnvToken() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "GitHubユーザー: ci-bot") {
+		t.Errorf("output = %q", buf.String())
+	}
+	if os.Getenv("GH_PROMPT_DISABLED") != "1" {
+		t.Error("GH_PROMPT_DISABLED should be set")
+	}
+
+	authenticatedUserFunc = func(ctx context.Context) (string, error) {
+		return "", fmt.Errorf("HTTP 401: Bad credentials")
+	}
+	if err := verifyEnvToken(&buf, "bad-token"); err == nil || !strings.Contains(err.Error(), config.GitHubTokenEnv) {
+		t.Errorf("verifyEnvToken() error = %v, want error mentioning %s", err, config.GitHubTokenEnv)
+	}
+}
+
+func TestPrintPreconditionReport(t *testing.T) {
+	var buf bytes.Buffer
+	printPreconditionReport(&buf, &watcher.PreconditionReport{
+		Results: []watcher.PreconditionResult{
+			{Rule: config.PreconditionBranchProtection, Action: config.PreconditionBlockAutoMerge, Detail: "main"},
+			{Rule: config.PreconditionCIWorkflow, Action: config.PreconditionWarn, Satisfied: true, Detail: "ci.yml"},
+			{Rule: config.PreconditionCodeowners, Action: config.PreconditionBlockAll, Error: "HTTP 403"},
+		},
+	})
+
+	output := buf.String()
+	for _, want := range []string{
+		"✗ ブランチ保護: デフォルトブランチ main が保護されていません [自動マージを無効化]",
+		"✓ CIワークフロー: ci.yml",
+		"? CODEOWNERS: 確認できませんでした (HTTP 403)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output does not contain %q:\n%s", want, output)
+		}
+	}
+
+	buf.Reset()
+	printPreconditionReport(&buf, &watcher.PreconditionReport{})
+	if buf.Len() != 0 {
+		t.Errorf("output should be empty when no precondition is checked:\n%s", buf.String())
+	}
+}
+
+func TestPrintBranchProtection(t *testing.T) {
+	var buf bytes.Buffer
+	printBranchProtection(&buf, &githubPkg.BranchProtection{
+		Branch:                   "main",
+		Protected:                true,
+		RequiredApprovingReviews: 1,
+		RequiredChecks:           []string{"test", "lint"},
+		RequireLinearHistory:     true,
+	})
+
+	output := buf.String()
+	for _, want := range []string{
+		"ブランチ保護 (main):",
+		"必要な承認: 1件（承認のないPRは自動マージしません）",
+		"必須チェック: test, lint",
+		"直線的な履歴（rebaseでマージします）",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output does not contain %q:\n%s", want, output)
+		}
+	}
+
+	buf.Reset()
+	printBranchProtection(&buf, &githubPkg.BranchProtection{Branch: "main"})
+	if buf.Len() != 0 {
+		t.Errorf("output should be empty when the branch is not protected:\n%s", buf.String())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,11 +13,13 @@ import (
 
 	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/daemon"
+	"github.com/douhashi/osoba/internal/faultinject"
 	githubClient "github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/logger"
 	"github.com/douhashi/osoba/internal/paths"
 	"github.com/douhashi/osoba/internal/tmux"
 	"github.com/douhashi/osoba/internal/utils"
+	"github.com/douhashi/osoba/internal/watcher"
 )
 
 func newStatusCmd() *cobra.Command {
@@ -31,16 +34,15 @@ func newStatusCmd() *cobra.Command {
 
 	// --debugフラグを追加
 	cmd.Flags().Bool("debug", false, "詳細な診断情報を表示")
+	cmd.Flags().Bool("fresh", false, "監視プロセスのキャッシュを使わずGitHubから最新の状態を取得")
+	cmd.Flags().Bool("explain", false, "最後のポーリングで各Issueに何も実行しなかった理由を表示")
 
-	return cmd
+	return withJSONOutput(cmd)
 }
 
 func runStatusCmd(cmd *cobra.Command) error {
 	ctx := context.Background()
 
-	fmt.Fprintln(cmd.OutOrStdout(), "=== osobaステータス ===")
-	fmt.Fprintln(cmd.OutOrStdout())
-
 	// 設定を読み込み
 	cfg := config.NewConfig()
 
@@ -51,14 +53,18 @@ func runStatusCmd(cmd *cobra.Command) error {
 		configPath = viper.GetString("config")
 	}
 
-	// 設定ファイルのパスが取得できた場合、またはデフォルトパスから読み込み
-	if configPath != "" {
-		_ = cfg.LoadOrDefault(configPath)
-	} else {
-		// configPathが空の場合もデフォルト設定ファイルをチェック
-		_ = cfg.LoadOrDefault("")
+	// 設定ファイルのパスが取得できた場合、またはデフォルトパスから読み込み（configPathが空の場合はデフォルト設定ファイルをチェック）
+	if _, err := cfg.LoadOrDefaultWithError(configPath); err != nil {
+		return fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+	}
+
+	if isJSONOutput() {
+		return runStatusJSON(cmd, ctx, cfg)
 	}
 
+	fmt.Fprintln(cmd.OutOrStdout(), "=== osobaステータス ===")
+	fmt.Fprintln(cmd.OutOrStdout())
+
 	// tmuxがインストールされているかチェック
 	if err := tmux.CheckTmuxInstalled(); err != nil {
 		fmt.Fprintln(cmd.OutOrStdout(), "⚠️  tmuxがインストールされていません")
@@ -75,7 +81,7 @@ func runStatusCmd(cmd *cobra.Command) error {
 		fmt.Fprintf(cmd.OutOrStdout(), "⚠️  tmuxセッション取得エラー: %v\n", err)
 	} else {
 		if debugMode {
-			displayTmuxSessionsWithDiagnostics(cmd, sessions, cfg.Tmux.SessionPrefix)
+			displayTmuxSessionsWithDiagnostics(cmd, sessions, cfg.Tmux.SessionPrefix, cfg.Location())
 		} else {
 			displayTmuxSessions(cmd, sessions)
 		}
@@ -84,7 +90,7 @@ func runStatusCmd(cmd *cobra.Command) error {
 	fmt.Fprintln(cmd.OutOrStdout())
 
 	// バックグラウンドプロセスの状態を表示
-	displayBackgroundProcess(cmd)
+	displayBackgroundProcess(cmd, cfg.Location())
 
 	fmt.Fprintln(cmd.OutOrStdout())
 
@@ -121,6 +127,67 @@ func runStatusCmd(cmd *cobra.Command) error {
 		fmt.Fprintf(cmd.OutOrStdout(), "⚠️  設定表示エラー: %v\n", err)
 	}
 
+	// マシンの負荷が高いために新しいフェーズの開始を保留している場合は表示する
+	state := loadStatusState(cfg, repoInfo)
+	if state != nil && state.Degradation != nil {
+		displayDegradation(cmd, state.Degradation)
+		fmt.Fprintln(cmd.OutOrStdout())
+	}
+	if state != nil && state.ResourcePressure != nil {
+		displayResourcePressure(cmd, state.ResourcePressure)
+		fmt.Fprintln(cmd.OutOrStdout())
+	}
+	if state != nil && state.Throttle != nil {
+		displayThrottle(cmd, state.Throttle)
+		fmt.Fprintln(cmd.OutOrStdout())
+	}
+	if state != nil && len(state.FaultInjection) > 0 {
+		displayFaultInjection(cmd, state.FaultInjection)
+		fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	// ブランチ保護による自動マージの制約を表示する
+	if state != nil {
+		if constraints := branchProtectionConstraints(state.BranchProtection); len(constraints) > 0 {
+			displayBranchProtection(cmd, state.BranchProtection.Branch, constraints)
+			fmt.Fprintln(cmd.OutOrStdout())
+		}
+	}
+
+	// マージキューでマージを待っているPRを表示する
+	if state != nil && len(state.MergeQueue) > 0 {
+		displayMergeQueue(cmd, state.MergeQueue)
+		fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	// 起動時の積み残しのIssueで開始を待っているものを表示する
+	if state != nil && state.Backfill != nil {
+		displayBackfill(cmd, state.Backfill)
+		fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	// リトライが積み重なっているIssueを表示する
+	if state != nil && len(state.Retries) > 0 {
+		displayRetries(cmd, state.Retries)
+		fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	// 各Issueに何も実行しなかった理由を表示する
+	if explain, _ := cmd.Flags().GetBool("explain"); explain {
+		displaySkipExplanations(cmd, state)
+		fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	// 監視プロセスのキャッシュがあればghコマンドを実行せずに表示する
+	if fresh, _ := cmd.Flags().GetBool("fresh"); !fresh {
+		if state != nil {
+			displayCachedIssues(cmd, state)
+			fmt.Fprintln(cmd.OutOrStdout())
+			displayAutoMergeMetrics(cmd, cfg)
+			return nil
+		}
+	}
+
 	// GitHub認証が利用可能かチェック
 	token, _ := config.GetGitHubToken(cfg)
 	if token == "" {
@@ -170,7 +237,7 @@ func displayTmuxSessions(cmd *cobra.Command, sessions []*tmux.SessionInfo) {
 	}
 }
 
-func displayTmuxSessionsWithDiagnostics(cmd *cobra.Command, sessions []*tmux.SessionInfo, prefix string) {
+func displayTmuxSessionsWithDiagnostics(cmd *cobra.Command, sessions []*tmux.SessionInfo, prefix string, loc *time.Location) {
 	fmt.Fprintln(cmd.OutOrStdout(), "🖥️  tmuxセッション（診断モード）:")
 
 	// tmuxマネージャーを作成
@@ -207,7 +274,7 @@ func displayTmuxSessionsWithDiagnostics(cmd *cobra.Command, sessions []*tmux.Ses
 
 		// デバッグ情報を表示
 		fmt.Fprintf(cmd.OutOrStdout(), "      Created: %s\n", diag.Created)
-		fmt.Fprintf(cmd.OutOrStdout(), "      Timestamp: %s\n", diag.Timestamp.Format("2006-01-02 15:04:05"))
+		fmt.Fprintf(cmd.OutOrStdout(), "      Timestamp: %s\n", diag.Timestamp.In(loc).Format("2006-01-02 15:04:05"))
 
 		if len(diag.Errors) > 0 {
 			fmt.Fprintln(cmd.OutOrStdout(), "      Errors:")
@@ -225,12 +292,12 @@ func displayTmuxSessionsWithDiagnostics(cmd *cobra.Command, sessions []*tmux.Ses
 		}
 
 		// セッション内のウィンドウ詳細を診断モードで表示
-		displaySessionWindowsWithDiagnostics(cmd, diag.Name, manager)
+		displaySessionWindowsWithDiagnostics(cmd, diag.Name, manager, loc)
 		fmt.Fprintln(cmd.OutOrStdout())
 	}
 }
 
-func displaySessionWindowsWithDiagnostics(cmd *cobra.Command, sessionName string, manager *tmux.DefaultManager) {
+func displaySessionWindowsWithDiagnostics(cmd *cobra.Command, sessionName string, manager *tmux.DefaultManager, loc *time.Location) {
 	// ウィンドウ診断情報を取得
 	windowDiags, err := manager.ListWindowDiagnostics(sessionName)
 	if err != nil {
@@ -268,7 +335,7 @@ func displaySessionWindowsWithDiagnostics(cmd *cobra.Command, sessionName string
 		fmt.Fprintf(cmd.OutOrStdout(), "         Index: %d, Panes: %d, Exists: %v\n",
 			diag.Index, diag.Panes, diag.Exists)
 		fmt.Fprintf(cmd.OutOrStdout(), "         Timestamp: %s\n",
-			diag.Timestamp.Format("2006-01-02 15:04:05"))
+			diag.Timestamp.In(loc).Format("2006-01-02 15:04:05"))
 
 		if len(diag.Errors) > 0 {
 			fmt.Fprintln(cmd.OutOrStdout(), "         Errors:")
@@ -343,19 +410,10 @@ func getLogger() logger.Logger {
 }
 
 func displayGitHubIssues(cmd *cobra.Command, ctx context.Context, client githubClient.GitHubClient, repoInfo *utils.GitHubRepoInfo, cfg *config.Config) error {
-	statusLabels := []string{
-		"status:planning",
-		"status:implementing",
-		"status:reviewing",
-		"status:needs-plan",
-		"status:ready",
-		"status:review-requested",
-	}
-
 	fmt.Fprintln(cmd.OutOrStdout(), "📋 Issues:")
 
 	hasIssues := false
-	for _, label := range statusLabels {
+	for _, label := range watcher.StatusLabels {
 		issues, err := client.ListIssuesByLabels(ctx, repoInfo.Owner, repoInfo.Repo, []string{label})
 		if err != nil {
 			return fmt.Errorf("ラベル '%s' のIssue取得に失敗: %w", label, err)
@@ -379,11 +437,211 @@ func displayIssuesForLabel(cmd *cobra.Command, label string, issues []*githubCli
 	fmt.Fprintf(cmd.OutOrStdout(), "   %s %s:\n", emoji, label)
 
 	for _, issue := range issues {
-		title := *issue.Title
-		if len(title) > 50 {
-			title = title[:47] + "..."
+		displayIssueLine(cmd, *issue.Number, *issue.Title)
+	}
+}
+
+func displayIssueLine(cmd *cobra.Command, number int, title string) {
+	if len(title) > 50 {
+		title = title[:47] + "..."
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "     #%d %s\n", number, title)
+}
+
+// loadStatusState は監視プロセスが書き出した状態ファイルを読み込む
+// 状態ファイルがない、別のリポジトリのもの、または古い場合はnilを返す
+func loadStatusState(cfg *config.Config, repoInfo *utils.GitHubRepoInfo) *watcher.StatusState {
+	repoIdentifier := fmt.Sprintf("%s-%s", repoInfo.Owner, repoInfo.Repo)
+	state, err := watcher.ReadStatusState(paths.NewPathManager("").StateFile(repoIdentifier))
+	if err != nil {
+		return nil
+	}
+	if state.Owner != repoInfo.Owner || state.Repo != repoInfo.Repo {
+		return nil
+	}
+	if time.Since(state.UpdatedAt) > statusStateMaxAge(cfg) {
+		return nil
+	}
+	return state
+}
+
+// statusStateMaxAge はキャッシュ状態を有効とみなす期間を返す
+// 監視プロセスはポーリング間隔ごとに書き出すため、数回分の取得失敗までは許容する
+func statusStateMaxAge(cfg *config.Config) time.Duration {
+	maxAge := 3 * cfg.GitHub.PollInterval
+	if maxAge < time.Minute {
+		maxAge = time.Minute
+	}
+	return maxAge
+}
+
+// displayCachedIssues はキャッシュ状態のIssueを表示する
+func displayCachedIssues(cmd *cobra.Command, state *watcher.StatusState) {
+	fmt.Fprintf(cmd.OutOrStdout(), "📋 Issues (%s前の監視プロセスのキャッシュ、最新の状態は --fresh で取得):\n",
+		formatDuration(time.Since(state.UpdatedAt)))
+
+	hasIssues := false
+	for _, label := range watcher.StatusLabels {
+		issues := state.Issues[label]
+		if len(issues) == 0 {
+			continue
+		}
+		hasIssues = true
+		fmt.Fprintf(cmd.OutOrStdout(), "   %s %s:\n", getEmojiForLabel(label), label)
+		for _, issue := range issues {
+			displayIssueLine(cmd, issue.Number, issue.Title)
+		}
+	}
+
+	if !hasIssues {
+		fmt.Fprintln(cmd.OutOrStdout(), "   処理中のIssueはありません")
+	}
+}
+
+// skipReasonLabels は何もしなかった理由の表示名
+var skipReasonLabels = map[string]string{
+	watcher.SkipReasonFiltered:       "ラベルにより対象外",
+	watcher.SkipReasonOverBudget:     "同時実行数の上限",
+	watcher.SkipReasonBlocked:        "ブロック中",
+	watcher.SkipReasonPaused:         "一時停止中",
+	watcher.SkipReasonDependencyOpen: "サブIssueの完了待ち",
+	watcher.SkipReasonBackfill:       "積み残しの順番待ち",
+	watcher.SkipReasonDisabled:       "機能が無効",
+	watcher.SkipReasonConflict:       "領域の競合待ち",
+}
+
+// displaySkipExplanations は最後のポーリングで各Issueに何も実行しなかった理由を表示する
+func displaySkipExplanations(cmd *cobra.Command, state *watcher.StatusState) {
+	if state == nil {
+		fmt.Fprintln(cmd.OutOrStdout(), "⚠️  監視プロセスの記録がないため、何も実行しなかった理由を表示できません")
+		fmt.Fprintln(cmd.OutOrStdout(), "   'osoba start' で監視を開始すると、ポーリングごとに理由が記録されます")
+		return
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "🔍 何も実行しなかった理由（最後のポーリング）:")
+	if len(state.Explanations) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "   記録された理由はありません")
+		return
+	}
+	for _, explanation := range state.Explanations {
+		label, ok := skipReasonLabels[explanation.Reason]
+		if !ok {
+			label = explanation.Reason
+		}
+		if explanation.Detail == "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "   #%d %s\n", explanation.IssueNumber, label)
+			continue
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "   #%d %s: %s\n", explanation.IssueNumber, label, explanation.Detail)
+	}
+}
+
+// displayDegradation は監視の不健全な状態を表示する
+func displayDegradation(cmd *cobra.Command, degradation *watcher.DegradationStatus) {
+	fmt.Fprintf(cmd.OutOrStdout(), "🚨 監視が不健全な状態です（%s前から）\n", formatDuration(time.Since(degradation.Since)))
+	for _, source := range degradation.Sources {
+		fmt.Fprintf(cmd.OutOrStdout(), "   %s: %d回連続で失敗（最後のエラー: %s）\n", source.Source, source.Failures, source.LastError)
+	}
+}
+
+// displayThrottle はGitHubによるスロットリングで待機している状態を表示する
+func displayThrottle(cmd *cobra.Command, throttle *githubClient.ThrottleStatus) {
+	fmt.Fprintf(cmd.OutOrStdout(), "🐢 GitHubのレート制限によりAPI呼び出しを待機中（%sまで）\n", throttle.Until.Local().Format("15:04:05"))
+	if throttle.Reason != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "   理由: %s\n", throttle.Reason)
+	}
+}
+
+// displayFaultInjection は--fault-injectionで注入した障害の回数を対象ごとに表示する
+func displayFaultInjection(cmd *cobra.Command, stats map[string]faultinject.Stats) {
+	targets := make([]string, 0, len(stats))
+	for target := range stats {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	fmt.Fprintln(cmd.OutOrStdout(), "🧪 障害注入が有効です:")
+	for _, target := range targets {
+		s := stats[target]
+		fmt.Fprintf(cmd.OutOrStdout(), "   %s: 呼び出し%d回（失敗%d回、遅延%d回）\n", target, s.Calls, s.Failures, s.Delays)
+	}
+}
+
+// displayResourcePressure はフェーズ開始の保留状態を表示する
+func displayResourcePressure(cmd *cobra.Command, pressure *watcher.ResourcePressure) {
+	fmt.Fprintf(cmd.OutOrStdout(), "⏸️  マシンの負荷が高いため新しいフェーズの開始を保留中（%s前から、CPUあたりのロードアベレージ: %.2f、利用可能なメモリ: %dMB）\n",
+		formatDuration(time.Since(pressure.Since)), pressure.LoadPerCPU, pressure.AvailableMemoryMB)
+	if len(pressure.DeferredIssues) > 0 {
+		numbers := make([]string, 0, len(pressure.DeferredIssues))
+		for _, n := range pressure.DeferredIssues {
+			numbers = append(numbers, fmt.Sprintf("#%d", n))
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "   保留中のIssue: %s（負荷が下がると自動的に開始します）\n", strings.Join(numbers, ", "))
+	}
+}
+
+// displayMergeQueue はマージキューでマージを待っているPRを表示する
+func displayMergeQueue(cmd *cobra.Command, entries []watcher.MergeQueueStatus) {
+	fmt.Fprintln(cmd.OutOrStdout(), "🚦 マージキュー:")
+	for _, entry := range entries {
+		state := entry.State
+		if state == "" {
+			state = "チェック待ち"
+		}
+		issue := ""
+		if entry.IssueNumber > 0 {
+			issue = fmt.Sprintf(" (#%d)", entry.IssueNumber)
+		}
+		position := ""
+		if entry.Position > 0 {
+			position = fmt.Sprintf("、%d番目", entry.Position)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "   PR #%d%s: %s%s（%s前に追加）\n", entry.PRNumber, issue, state, position, formatDuration(time.Since(entry.EnqueuedAt)))
+	}
+}
+
+// displayBackfill は起動時の積み残しのIssueの開始の進み具合を表示する
+func displayBackfill(cmd *cobra.Command, backfill *watcher.BackfillStatus) {
+	numbers := make([]string, 0, len(backfill.Pending))
+	for _, n := range backfill.Pending {
+		numbers = append(numbers, fmt.Sprintf("#%d", n))
+	}
+	started := fmt.Sprintf("%d件", backfill.Started)
+	if backfill.Max > 0 {
+		started = fmt.Sprintf("%d/%d件", backfill.Started, backfill.Max)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "📥 積み残しのIssue: 開始済み%s、順番待ち: %s\n", started, strings.Join(numbers, ", "))
+	switch {
+	case backfill.Max > 0 && backfill.Started >= backfill.Max:
+		fmt.Fprintln(cmd.OutOrStdout(), "   今回の起動で開始する上限に達しました（次回の起動後に開始します）")
+	case backfill.NextLaunchAt.After(time.Now()):
+		fmt.Fprintf(cmd.OutOrStdout(), "   次の開始: %s後\n", formatDuration(time.Until(backfill.NextLaunchAt)))
+	}
+}
+
+// retrySeverityLabels はリトライの深刻度の表示名
+var retrySeverityLabels = map[string]string{
+	watcher.RetrySeverityDegraded: "不安定",
+	watcher.RetrySeverityFailing:  "失敗が続いている",
+}
+
+// displayRetries はIssueごとのリトライの回数と深刻度を表示する
+func displayRetries(cmd *cobra.Command, retries []watcher.RetryStatus) {
+	fmt.Fprintln(cmd.OutOrStdout(), "🔁 リトライしたIssue:")
+	for _, r := range retries {
+		severity := ""
+		if label, ok := retrySeverityLabels[r.Severity]; ok {
+			severity = fmt.Sprintf(" [%s]", label)
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "     #%d %s\n", *issue.Number, title)
+		fmt.Fprintf(cmd.OutOrStdout(), "   #%d%s ghの失敗: %d回、フェーズの失敗: %d回、フェーズの再実行: %d回\n",
+			r.IssueNumber, severity, r.GHFailures, r.PhaseFailures, r.PhaseRestarts)
+	}
+}
+
+// displayBranchProtection はブランチ保護による自動マージの制約を表示する
+func displayBranchProtection(cmd *cobra.Command, branch string, constraints []string) {
+	fmt.Fprintf(cmd.OutOrStdout(), "🛡️  ブランチ保護 (%s):\n", branch)
+	for _, constraint := range constraints {
+		fmt.Fprintf(cmd.OutOrStdout(), "   - %s\n", constraint)
 	}
 }
 
@@ -401,6 +659,10 @@ func getEmojiForLabel(label string) string {
 		return "👀"
 	case "status:reviewing":
 		return "🔍"
+	case "status:manual":
+		return "🙋"
+	case "status:needs-human":
+		return "🆘"
 	default:
 		return "📌"
 	}
@@ -477,6 +739,13 @@ func displayConfiguration(cmd *cobra.Command, cfg *config.Config) error {
 
 	fmt.Fprintln(cmd.OutOrStdout())
 
+	// 無効にしている自動化の機能
+	if disabled := cfg.Features.Disabled(); len(disabled) > 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "  Features:")
+		fmt.Fprintf(cmd.OutOrStdout(), "    Disabled: %s\n", strings.Join(disabled, ", "))
+		fmt.Fprintln(cmd.OutOrStdout())
+	}
+
 	// Claude設定
 	if cfg.Claude != nil && cfg.Claude.Phases != nil {
 		fmt.Fprintln(cmd.OutOrStdout(), "  Claude Phases:")
@@ -488,7 +757,13 @@ func displayConfiguration(cmd *cobra.Command, cfg *config.Config) error {
 			if phaseConfig, exists := cfg.Claude.Phases[phaseName]; exists && phaseConfig != nil {
 				fmt.Fprintf(cmd.OutOrStdout(), "    %s:\n", strings.Title(phaseName))
 				fmt.Fprintf(cmd.OutOrStdout(), "      Args: %v\n", phaseConfig.Args)
+				if phaseConfig.Permissions != nil {
+					fmt.Fprintf(cmd.OutOrStdout(), "      Permissions: %v\n", phaseConfig.CommandArgs())
+				}
 				fmt.Fprintf(cmd.OutOrStdout(), "      Prompt: %s\n", phaseConfig.Prompt)
+				for _, variant := range phaseConfig.Variants {
+					fmt.Fprintf(cmd.OutOrStdout(), "      Prompt (%s): %s\n", strings.Join(variant.Labels, ", "), variant.Prompt)
+				}
 			}
 		}
 	}
@@ -497,7 +772,7 @@ func displayConfiguration(cmd *cobra.Command, cfg *config.Config) error {
 }
 
 // displayBackgroundProcess はバックグラウンドプロセスの状態を表示します
-func displayBackgroundProcess(cmd *cobra.Command) {
+func displayBackgroundProcess(cmd *cobra.Command, loc *time.Location) {
 	fmt.Fprintln(cmd.OutOrStdout(), "🔄 バックグラウンドプロセス:")
 
 	// リポジトリ識別子を取得
@@ -533,7 +808,7 @@ func displayBackgroundProcess(cmd *cobra.Command) {
 
 	// ログファイルのパスを表示
 	logDir := pm.LogDir(repoIdentifier)
-	logFile := fmt.Sprintf("%s/%s.log", logDir, time.Now().Format("2006-01-02"))
+	logFile := dailyLogFile(logDir, loc)
 	fmt.Fprintf(cmd.OutOrStdout(), "   ログファイル: %s\n", logFile)
 }
 
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/daemon"
+	"github.com/douhashi/osoba/internal/faultinject"
+	githubClient "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/paths"
+	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/douhashi/osoba/internal/utils"
+	"github.com/douhashi/osoba/internal/watcher"
+)
+
+// statusResult はstatusの結果（--output json）
+type statusResult struct {
+	Repository string                                `json:"repository,omitempty"`
+	Sessions   []statusSession                       `json:"sessions"`
+	Process    *statusProcess                        `json:"process"`
+	Source     string                                `json:"source,omitempty"` // Issueの取得元（cache または github）
+	CachedAt   *time.Time                            `json:"cached_at,omitempty"`
+	Issues     map[string][]watcher.StatusStateIssue `json:"issues"` // ステータスラベルごとのIssue
+	// Degradation はghコマンド・フェーズの開始の失敗が続いている不健全な状態
+	Degradation *watcher.DegradationStatus `json:"degradation,omitempty"`
+	// ResourcePressure はマシンの負荷が高いために新しいフェーズの開始を保留している状態
+	ResourcePressure *watcher.ResourcePressure `json:"resource_pressure,omitempty"`
+	// Throttle はGitHubによるスロットリングでAPI呼び出しを待機している状態
+	Throttle *githubClient.ThrottleStatus `json:"throttle,omitempty"`
+	// FaultInjection は--fault-injectionで注入した障害の対象ごとの回数
+	FaultInjection map[string]faultinject.Stats `json:"fault_injection,omitempty"`
+	// BranchProtection は監視プロセスが起動時に検出したデフォルトブランチの保護ルール
+	BranchProtection *githubClient.BranchProtection `json:"branch_protection,omitempty"`
+	// Explanations は最後のポーリングで各Issueに何も実行しなかった理由（--explain指定時）
+	Explanations []watcher.SkipExplanation `json:"explanations,omitempty"`
+	// MergeQueue はマージキューに追加してマージを待っているPR
+	MergeQueue []watcher.MergeQueueStatus `json:"merge_queue,omitempty"`
+	// Backfill は起動時の積み残しのIssueの開始の進み具合
+	Backfill *watcher.BackfillStatus `json:"backfill,omitempty"`
+	// Retries はリトライしたIssueのリトライの回数と深刻度
+	Retries  []watcher.RetryStatus `json:"retries,omitempty"`
+	Warnings []string              `json:"warnings,omitempty"`
+}
+
+type statusSession struct {
+	Name     string         `json:"name"`
+	Attached bool           `json:"attached"`
+	Windows  []statusWindow `json:"windows"`
+}
+
+type statusWindow struct {
+	Name        string `json:"name"`
+	IssueNumber int    `json:"issue_number,omitempty"`
+	Phase       string `json:"phase,omitempty"`
+	Active      bool   `json:"active"`
+}
+
+type statusProcess struct {
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"started_at"`
+	RepoPath  string    `json:"repo_path"`
+}
+
+// runStatusJSON はstatusの結果をJSONで出力する
+// 取得に失敗した項目は表示と同様に処理を継続し、warningsに記録する
+func runStatusJSON(cmd *cobra.Command, ctx context.Context, cfg *config.Config) error {
+	result := statusResult{
+		Sessions: []statusSession{},
+		Issues:   map[string][]watcher.StatusStateIssue{},
+	}
+	warn := func(format string, args ...interface{}) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	if err := tmux.CheckTmuxInstalled(); err != nil {
+		warn("tmuxがインストールされていません: %v", err)
+	} else if sessions, err := tmux.ListSessionsAsSessionInfo(cfg.Tmux.SessionPrefix); err != nil {
+		warn("tmuxセッション取得エラー: %v", err)
+	} else {
+		for _, s := range sessions {
+			session := statusSession{Name: s.Name, Attached: s.Attached, Windows: []statusWindow{}}
+			details, err := tmux.GetSortedWindowDetails(s.Name)
+			if err != nil {
+				warn("ウィンドウ詳細取得エラー (%s): %v", s.Name, err)
+			}
+			for _, d := range details {
+				session.Windows = append(session.Windows, statusWindow{
+					Name:        d.Name,
+					IssueNumber: d.IssueNumber,
+					Phase:       d.Phase,
+					Active:      d.Active,
+				})
+			}
+			result.Sessions = append(result.Sessions, session)
+		}
+	}
+
+	if repoIdentifier, err := getRepoIdentifier(); err == nil {
+		status, err := daemon.NewDaemonManager().Status(paths.NewPathManager("").PIDFile(repoIdentifier))
+		if err == nil && status.Running {
+			result.Process = &statusProcess{PID: status.PID, StartedAt: status.StartTime, RepoPath: status.RepoPath}
+		}
+	}
+
+	repoInfo, err := utils.GetGitHubRepoInfo(ctx)
+	if err != nil {
+		warn("GitHubリポジトリ情報取得エラー: %v", err)
+		return renderJSON(cmd, result)
+	}
+	result.Repository = fmt.Sprintf("%s/%s", repoInfo.Owner, repoInfo.Repo)
+
+	state := loadStatusState(cfg, repoInfo)
+	if state != nil {
+		result.Degradation = state.Degradation
+		result.ResourcePressure = state.ResourcePressure
+		result.Throttle = state.Throttle
+		result.FaultInjection = state.FaultInjection
+		result.BranchProtection = state.BranchProtection
+		result.MergeQueue = state.MergeQueue
+		result.Backfill = state.Backfill
+		result.Retries = state.Retries
+	}
+	if explain, _ := cmd.Flags().GetBool("explain"); explain {
+		if state != nil {
+			result.Explanations = state.Explanations
+		} else {
+			warn("監視プロセスの記録がないため、何も実行しなかった理由を表示できません")
+		}
+	}
+	if fresh, _ := cmd.Flags().GetBool("fresh"); !fresh {
+		if state != nil {
+			result.Source = "cache"
+			result.CachedAt = &state.UpdatedAt
+			for _, label := range watcher.StatusLabels {
+				if issues := state.Issues[label]; len(issues) > 0 {
+					result.Issues[label] = issues
+				}
+			}
+			return renderJSON(cmd, result)
+		}
+	}
+
+	if token, _ := config.GetGitHubToken(cfg); token == "" {
+		warn("GitHub認証が設定されていません")
+		return renderJSON(cmd, result)
+	}
+	client, err := githubClient.NewClient("")
+	if err != nil {
+		warn("GitHub クライアント作成エラー: %v", err)
+		return renderJSON(cmd, result)
+	}
+
+	result.Source = "github"
+	for _, label := range watcher.StatusLabels {
+		issues, err := client.ListIssuesByLabels(ctx, repoInfo.Owner, repoInfo.Repo, []string{label})
+		if err != nil {
+			warn("ラベル '%s' のIssue取得に失敗: %v", label, err)
+			continue
+		}
+		for _, issue := range issues {
+			if issue.Number == nil {
+				continue
+			}
+			item := watcher.StatusStateIssue{Number: *issue.Number}
+			if issue.Title != nil {
+				item.Title = *issue.Title
+			}
+			result.Issues[label] = append(result.Issues[label], item)
+		}
+	}
+
+	return renderJSON(cmd, result)
+}
@@ -2,17 +2,26 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/faultinject"
+	githubClient "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/paths"
+	"github.com/douhashi/osoba/internal/utils"
+	"github.com/douhashi/osoba/internal/watcher"
 )
 
 func TestStatusCmd(t *testing.T) {
@@ -124,6 +133,18 @@ claude:
 			},
 			expectError: false,
 		},
+		{
+			name: "無効にしている機能を表示",
+			setupConfig: func(cfg *config.Config) {
+				cfg.Features.AutoReview = false
+				cfg.Features.AutoMerge = false
+			},
+			expectedOutput: []string{
+				"Features:",
+				"Disabled: auto_review, auto_merge",
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -141,7 +162,7 @@ claude:
 
 			// ghコマンドのモック
 			originalGhAuthTokenFunc := config.GhAuthTokenFunc
-			config.GhAuthTokenFunc = func() (string, error) {
+			config.GhAuthTokenFunc = func(string) (string, error) {
 				return "", fmt.Errorf("gh auth token not available")
 			}
 			defer func() {
@@ -371,7 +392,7 @@ tmux:
 
 			// ghコマンドのモック
 			originalGhAuthTokenFunc := config.GhAuthTokenFunc
-			config.GhAuthTokenFunc = func() (string, error) {
+			config.GhAuthTokenFunc = func(string) (string, error) {
 				return "", fmt.Errorf("gh auth token not available")
 			}
 			defer func() {
@@ -478,3 +499,170 @@ tmux:
 		})
 	}
 }
+
+func TestLoadStatusState(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	cfg := config.NewConfig()
+	repoInfo := &utils.GitHubRepoInfo{Owner: "owner", Repo: "repo"}
+	statePath := paths.NewPathManager("").StateFile("owner-repo")
+	require.NoError(t, os.MkdirAll(filepath.Dir(statePath), 0755))
+
+	writeState := func(state watcher.StatusState) {
+		data, err := json.Marshal(state)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(statePath, data, 0644))
+	}
+
+	t.Run("状態ファイルがない場合はnil", func(t *testing.T) {
+		assert.Nil(t, loadStatusState(cfg, repoInfo))
+	})
+
+	t.Run("新しい状態ファイルはキャッシュとして使用", func(t *testing.T) {
+		writeState(watcher.StatusState{
+			UpdatedAt: time.Now().Add(-10 * time.Second),
+			Owner:     "owner",
+			Repo:      "repo",
+			Issues:    map[string][]watcher.StatusStateIssue{"status:ready": {{Number: 5, Title: "実装待ち"}}},
+		})
+		state := loadStatusState(cfg, repoInfo)
+		require.NotNil(t, state)
+
+		buf := new(bytes.Buffer)
+		cmd := &cobra.Command{}
+		cmd.SetOut(buf)
+		displayCachedIssues(cmd, state)
+		assert.Contains(t, buf.String(), "--fresh")
+		assert.Contains(t, buf.String(), "status:ready")
+		assert.Contains(t, buf.String(), "#5 実装待ち")
+	})
+
+	t.Run("古い状態ファイルは使用しない", func(t *testing.T) {
+		writeState(watcher.StatusState{
+			UpdatedAt: time.Now().Add(-time.Hour),
+			Owner:     "owner",
+			Repo:      "repo",
+		})
+		assert.Nil(t, loadStatusState(cfg, repoInfo))
+	})
+
+	t.Run("別のリポジトリの状態ファイルは使用しない", func(t *testing.T) {
+		writeState(watcher.StatusState{
+			UpdatedAt: time.Now(),
+			Owner:     "owner-repo",
+			Repo:      "",
+		})
+		assert.Nil(t, loadStatusState(cfg, repoInfo))
+	})
+}
+
+func TestDisplaySkipExplanations(t *testing.T) {
+	tests := []struct {
+		name  string
+		state *watcher.StatusState
+		want  []string
+	}{
+		{
+			name: "理由をIssueごとに表示",
+			state: &watcher.StatusState{Explanations: []watcher.SkipExplanation{
+				{IssueNumber: 3, Reason: watcher.SkipReasonOverBudget, Detail: "implement phase concurrency limit reached"},
+				{IssueNumber: 5, Reason: watcher.SkipReasonDependencyOpen, Detail: "waiting for sub-issues: #6"},
+			}},
+			want: []string{"#3 同時実行数の上限: implement phase concurrency limit reached", "#5 サブIssueの完了待ち: waiting for sub-issues: #6"},
+		},
+		{
+			name:  "理由がない場合",
+			state: &watcher.StatusState{},
+			want:  []string{"記録された理由はありません"},
+		},
+		{
+			name: "監視プロセスの記録がない場合",
+			want: []string{"監視プロセスの記録がない", "osoba start"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			cmd := &cobra.Command{}
+			cmd.SetOut(buf)
+			displaySkipExplanations(cmd, tt.state)
+			for _, want := range tt.want {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestDisplayBackfill(t *testing.T) {
+	tests := []struct {
+		name     string
+		backfill *watcher.BackfillStatus
+		want     []string
+	}{
+		{
+			name:     "次の開始を待っている",
+			backfill: &watcher.BackfillStatus{Pending: []int{3, 7}, Started: 1, NextLaunchAt: time.Now().Add(5 * time.Minute)},
+			want:     []string{"開始済み1件、順番待ち: #3, #7", "次の開始:"},
+		},
+		{
+			name:     "上限に達した",
+			backfill: &watcher.BackfillStatus{Pending: []int{9}, Started: 2, Max: 2},
+			want:     []string{"開始済み2/2件", "上限に達しました"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			cmd := &cobra.Command{}
+			cmd.SetOut(buf)
+
+			displayBackfill(cmd, tt.backfill)
+			for _, want := range tt.want {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestDisplayThrottle(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cmd := &cobra.Command{}
+	cmd.SetOut(buf)
+
+	displayThrottle(cmd, &githubClient.ThrottleStatus{Throttled: true, Until: time.Now().Add(time.Minute), Reason: "secondary rate limit"})
+
+	assert.Contains(t, buf.String(), "GitHubのレート制限によりAPI呼び出しを待機中")
+	assert.Contains(t, buf.String(), "理由: secondary rate limit")
+}
+
+func TestDisplayFaultInjection(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cmd := &cobra.Command{}
+	cmd.SetOut(buf)
+
+	displayFaultInjection(cmd, map[string]faultinject.Stats{
+		faultinject.TargetTmux: {Calls: 5, Delays: 2},
+		faultinject.TargetGH:   {Calls: 10, Failures: 1},
+	})
+
+	assert.Contains(t, buf.String(), "gh: 呼び出し10回（失敗1回、遅延0回）")
+	assert.Contains(t, buf.String(), "tmux: 呼び出し5回（失敗0回、遅延2回）")
+	assert.Less(t, strings.Index(buf.String(), "gh:"), strings.Index(buf.String(), "tmux:"))
+}
+
+func TestDisplayMergeQueue(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cmd := &cobra.Command{}
+	cmd.SetOut(buf)
+
+	displayMergeQueue(cmd, []watcher.MergeQueueStatus{
+		{IssueNumber: 12, PRNumber: 25, State: "AWAITING_CHECKS", Position: 2, EnqueuedAt: time.Now().Add(-5 * time.Minute)},
+		{PRNumber: 30, EnqueuedAt: time.Now()},
+	})
+
+	assert.Contains(t, buf.String(), "PR #25 (#12): AWAITING_CHECKS、2番目")
+	assert.Contains(t, buf.String(), "PR #30: チェック待ち")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os/exec"
 
+	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/daemon"
 	"github.com/douhashi/osoba/internal/paths"
 	"github.com/douhashi/osoba/internal/tmux"
@@ -15,12 +16,17 @@ func newStopCmd() *cobra.Command {
 		Use:   "stop",
 		Short: "バックグラウンドで実行中のIssue監視を停止",
 		Long: `バックグラウンドで実行中のIssue監視プロセスを停止します。
-現在のリポジトリに対応するプロセスのみを停止します。`,
+現在のリポジトリに対応するプロセスのみを停止します。
+
+safety.confirm_destructive が有効な場合、ウィンドウとworktreeの削除前に確認します。
+--yes を指定すると確認を省略します。`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStop(cmd, args)
 		},
 	}
 
+	cmd.Flags().BoolP("yes", "y", false, "破壊的操作を確認なしで許可（safety.confirm_destructive 有効時）")
+
 	return cmd
 }
 
@@ -61,6 +67,15 @@ func runStop(cmd *cobra.Command, args []string) error {
 
 	// 2. クリーンアップ処理（clean --all --force 相当）
 	sessionName := fmt.Sprintf("osoba-%s", repoName)
+
+	// safety.confirm_destructiveが有効な場合は確認し、拒否された場合はリソースを残す
+	if confirmed, err := confirmStopCleanup(cmd); err != nil {
+		return err
+	} else if !confirmed {
+		fmt.Fprintln(cmd.OutOrStdout(), "クリーンアップをキャンセルしました。tmuxセッションとworktreeは残ります。")
+		return nil
+	}
+
 	if err := performCleanupFunc(sessionName); err != nil {
 		errors = append(errors, fmt.Errorf("クリーンアップに失敗: %w", err))
 		fmt.Fprintf(cmd.OutOrStderr(), "クリーンアップに失敗しましたが、tmux削除を継続します: %v\n", err)
@@ -86,6 +101,28 @@ func runStop(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// confirmStopCleanup はsafety.confirm_destructiveが有効な場合にクリーンアップの確認を行う
+func confirmStopCleanup(cmd *cobra.Command) (bool, error) {
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		return true, nil
+	}
+
+	safety, err := loadSafetyConfigFunc()
+	if err != nil {
+		return false, err
+	}
+	if !safety.RequiresConfirmation(config.OperationKillWindow) && !safety.RequiresConfirmation(config.OperationRemoveWorktree) {
+		return true, nil
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "safety.confirm_destructive が有効なため、ウィンドウとworktreeの削除前に確認します（--yes で省略できます）")
+	confirmed, err := confirmPromptFunc("tmuxセッションとworktreeを削除しますか？ (yes/no): ")
+	if err != nil {
+		return false, fmt.Errorf("確認の読み取りに失敗しました: %w", err)
+	}
+	return confirmed, nil
+}
+
 // stopProcess はプロセスを停止します
 func stopProcess(pidFile string) error {
 	dm := daemon.NewDaemonManager()
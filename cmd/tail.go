@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/douhashi/osoba/internal/paths"
+	"github.com/douhashi/osoba/internal/tmux"
+)
+
+// tailCaptureLines は新しい出力を検出するために各ペインから取得する行数
+// ポーリング間隔の間にこれより多くの行が出力された場合は、超えた分を表示できない
+const tailCaptureLines = 500
+
+// テスト時にモック可能な関数変数
+var (
+	listPanesFunc = func(sessionName, windowName string) ([]*tmux.PaneInfo, error) {
+		return tmux.NewDefaultManager().ListPanes(sessionName, windowName)
+	}
+	capturePaneFunc = func(sessionName, windowName string, paneIndex int, lines int) (string, error) {
+		return tmux.NewDefaultManager().CapturePane(sessionName, windowName, paneIndex, lines)
+	}
+	// loadPaneRegistryFunc は監視プロセスが保存したペインの配置を読み込む（読み込めない場合はnil）
+	loadPaneRegistryFunc = func() *tmux.PaneRegistry {
+		repoIdentifier, err := getRepoIdentifierFunc()
+		if err != nil {
+			return nil
+		}
+		registry, err := tmux.LoadPaneRegistry(paths.NewPathManager("").StoreFile(repoIdentifier, "panes"))
+		if err != nil {
+			return nil
+		}
+		return registry
+	}
+)
+
+// issueWindow はIssueのペインがあるtmuxのウィンドウ
+type issueWindow struct {
+	Session string
+	Window  string
+}
+
+// name はウィンドウの表示名を返す（既定のセッション以外のウィンドウはセッション名を付ける）
+func (w issueWindow) name(defaultSession string) string {
+	if w.Session == defaultSession {
+		return w.Window
+	}
+	return w.Session + ":" + w.Window
+}
+
+// listIssueWindows はIssueのウィンドウをウィンドウ名順に返す
+// sessionNameのウィンドウに加え、監視プロセスが記録したペインの配置が別のセッションにある場合はそのウィンドウも含める
+func listIssueWindows(sessionName string, issueNumber int, registry *tmux.PaneRegistry) []issueWindow {
+	var windows []issueWindow
+	seen := make(map[issueWindow]bool)
+	if found, err := listWindowsForIssueFunc(sessionName, issueNumber); err == nil {
+		names := getWindowNames(found)
+		sort.Strings(names)
+		for _, name := range names {
+			w := issueWindow{Session: sessionName, Window: name}
+			seen[w] = true
+			windows = append(windows, w)
+		}
+	}
+	for _, placement := range registry.PlacementsForIssue(issueNumber) {
+		w := issueWindow{Session: placement.SessionName, Window: placement.WindowName}
+		if seen[w] || w.Session == sessionName {
+			continue
+		}
+		// 記録後に閉じられたウィンドウは含めない
+		if _, err := listPanesFunc(w.Session, w.Window); err != nil {
+			continue
+		}
+		seen[w] = true
+		windows = append(windows, w)
+	}
+	return windows
+}
+
+func newTailCmd() *cobra.Command {
+	var (
+		issueNumber int
+		lines       int
+		interval    time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Issueの全ペインの出力をまとめて表示",
+		Long: `Issueのtmuxウィンドウにあるすべてのペイン（Plan/Implementation/Reviewなど）の出力を、
+ペイン名を行頭に付けた1つのストリームにまとめて表示します。
+ペインを切り替えずにIssueの作業全体を監視できます。Ctrl+Cで終了します。
+
+使用例:
+  osoba tail --issue 83
+  osoba tail --issue 83 --lines 50
+  osoba tail                      # 処理中のIssueから選択`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			number, err := resolveIssueNumber(cmd, issueNumber)
+			if err != nil {
+				return err
+			}
+			return runTail(cmd, number, lines, interval)
+		},
+	}
+	cmd.Flags().IntVar(&issueNumber, "issue", 0, "表示するIssue番号（省略時は処理中のIssueから選択）")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 20, "開始時に表示する各ペインの直近の行数")
+	cmd.Flags().DurationVar(&interval, "interval", time.Second, "ペイン出力を確認する間隔")
+	return cmd
+}
+
+func runTail(cmd *cobra.Command, issueNumber, lines int, interval time.Duration) error {
+	if issueNumber <= 0 {
+		return fmt.Errorf("Issue番号は正の整数で指定してください")
+	}
+	if lines < 0 {
+		return fmt.Errorf("--lines には0以上の値を指定してください")
+	}
+	if interval <= 0 {
+		return fmt.Errorf("--interval には正の値を指定してください")
+	}
+
+	if err := checkTmuxInstalledFunc(); err != nil {
+		return fmt.Errorf("tmuxがインストールされていません: %w", err)
+	}
+	repoName, err := getRepositoryNameFunc()
+	if err != nil {
+		return fmt.Errorf("リポジトリ名の取得に失敗しました: %w", err)
+	}
+	sessionName := fmt.Sprintf("osoba-%s", repoName)
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "📡 Issue #%d のペイン出力を表示しています（Ctrl+Cで終了）\n", issueNumber)
+
+	tailer := newPaneTailer(sessionName, issueNumber, lines, out)
+	tailer.registry = loadPaneRegistryFunc()
+	return tailer.Run(ctx, interval)
+}
+
+// paneTailer はIssueのペイン出力を定期的に取得し、新しい行だけをペイン名付きで出力する
+type paneTailer struct {
+	sessionName string
+	issueNumber int
+	lines       int // 初めて見つけたペインで表示する直近の行数
+	out         io.Writer
+	// registry は監視プロセスが記録したペインの配置（nilの場合はsessionNameのウィンドウのみ表示する）
+	registry *tmux.PaneRegistry
+
+	seen    map[string][]string // ペインごとの前回取得した出力
+	waiting bool                // ウィンドウがない旨を表示済みか
+}
+
+func newPaneTailer(sessionName string, issueNumber, lines int, out io.Writer) *paneTailer {
+	return &paneTailer{
+		sessionName: sessionName,
+		issueNumber: issueNumber,
+		lines:       lines,
+		out:         out,
+		seen:        make(map[string][]string),
+	}
+}
+
+// Run はコンテキストがキャンセルされるまでinterval間隔でペイン出力を表示する
+func (t *paneTailer) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		t.Poll()
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll はIssueのすべてのペインから出力を取得し、前回から増えた行を出力する
+// フェーズの進行で追加されたウィンドウ・ペインも毎回検出する
+func (t *paneTailer) Poll() {
+	windows := listIssueWindows(t.sessionName, t.issueNumber, t.registry)
+	if len(windows) == 0 {
+		if !t.waiting {
+			fmt.Fprintf(t.out, "⏳ Issue #%d のウィンドウがセッション '%s' にありません。作成されるまで待機します\n", t.issueNumber, t.sessionName)
+			t.waiting = true
+		}
+		return
+	}
+	t.waiting = false
+
+	current := make(map[string]bool)
+	for _, window := range windows {
+		windowName := window.name(t.sessionName)
+		panes, err := listPanesFunc(window.Session, window.Window)
+		if err != nil {
+			continue
+		}
+		for _, pane := range panes {
+			key := fmt.Sprintf("%s.%d", windowName, pane.Index)
+			output, err := capturePaneFunc(window.Session, window.Window, pane.Index, tailCaptureLines)
+			if err != nil {
+				continue
+			}
+			current[key] = true
+
+			captured := splitPaneOutput(output)
+			prev, ok := t.seen[key]
+			var lines []string
+			if ok {
+				lines = newPaneLines(prev, captured)
+			} else {
+				lines = captured[max(0, len(captured)-t.lines):]
+			}
+			t.seen[key] = captured
+
+			prefix := paneLabel(windowName, pane)
+			for _, line := range lines {
+				fmt.Fprintf(t.out, "[%s] %s\n", prefix, line)
+			}
+		}
+	}
+
+	// 閉じられたペインの記録を破棄
+	for key := range t.seen {
+		if !current[key] {
+			delete(t.seen, key)
+		}
+	}
+}
+
+// paneLabel は出力の行頭に付けるペイン名を返す（タイトルがない場合はウィンドウ名とペイン番号）
+func paneLabel(windowName string, pane *tmux.PaneInfo) string {
+	if pane.Title != "" {
+		return pane.Title
+	}
+	return fmt.Sprintf("%s.%d", windowName, pane.Index)
+}
+
+// splitPaneOutput はペイン出力を行に分割する（capture-paneが付ける末尾の空行は除く）
+func splitPaneOutput(output string) []string {
+	output = strings.TrimRight(output, "\n")
+	if strings.TrimSpace(output) == "" {
+		return nil
+	}
+	lines := strings.Split(output, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// newPaneLines は前回の出力の末尾と今回の出力の先頭が最も長く重なる位置を探し、それ以降の行を返す
+// 前回の最終行は書き換え中（進捗表示など）の可能性があるため、一致しない場合は最終行を除いて探す
+// 重なりがない場合（画面のクリアや取得範囲を超える出力）は今回の出力をすべて返す
+func newPaneLines(prev, current []string) []string {
+	if overlap, ok := findOverlap(prev, current); ok {
+		return current[overlap:]
+	}
+	if len(prev) > 1 {
+		if overlap, ok := findOverlap(prev[:len(prev)-1], current); ok {
+			return current[overlap:]
+		}
+	}
+	return current
+}
+
+// findOverlap はprevの末尾とcurrentの先頭が重なる最大の行数を返す
+func findOverlap(prev, current []string) (int, bool) {
+	for overlap := min(len(prev), len(current)); overlap > 0; overlap-- {
+		if equalLines(prev[len(prev)-overlap:], current[:overlap]) {
+			return overlap, true
+		}
+	}
+	return 0, false
+}
+
+func equalLines(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPaneLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		prev    []string
+		current []string
+		want    []string
+	}{
+		{
+			name:    "行が追加された",
+			prev:    []string{"a", "b"},
+			current: []string{"a", "b", "c"},
+			want:    []string{"c"},
+		},
+		{
+			name:    "変化なし",
+			prev:    []string{"a", "b"},
+			current: []string{"a", "b"},
+			want:    []string{},
+		},
+		{
+			name:    "スクロールして先頭の行が取得範囲から外れた",
+			prev:    []string{"a", "b", "c"},
+			current: []string{"b", "c", "d", "e"},
+			want:    []string{"d", "e"},
+		},
+		{
+			name:    "最終行が書き換えられた",
+			prev:    []string{"a", "b", "loading 10%"},
+			current: []string{"a", "b", "loading 20%"},
+			want:    []string{"loading 20%"},
+		},
+		{
+			name:    "重なりがない",
+			prev:    []string{"a", "b"},
+			current: []string{"x", "y"},
+			want:    []string{"x", "y"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newPaneLines(tt.prev, tt.current)
+			assert.Equal(t, tt.want, append([]string{}, got...))
+		})
+	}
+}
+
+func TestPaneTailer_Poll(t *testing.T) {
+	origWindows := listWindowsForIssueFunc
+	origPanes := listPanesFunc
+	origCapture := capturePaneFunc
+	defer func() {
+		listWindowsForIssueFunc = origWindows
+		listPanesFunc = origPanes
+		capturePaneFunc = origCapture
+	}()
+
+	var windows []*tmux.WindowInfo
+	outputs := map[string]string{}
+	listWindowsForIssueFunc = func(sessionName string, issueNumber int) ([]*tmux.WindowInfo, error) {
+		assert.Equal(t, "osoba-repo", sessionName)
+		assert.Equal(t, 83, issueNumber)
+		if windows == nil {
+			return nil, errors.New("no server running")
+		}
+		return windows, nil
+	}
+	listPanesFunc = func(sessionName, windowName string) ([]*tmux.PaneInfo, error) {
+		if windowName == "83-review" || windowName == "83-revise" {
+			return []*tmux.PaneInfo{{Index: 0}}, nil
+		}
+		return []*tmux.PaneInfo{{Index: 0, Title: "Plan"}, {Index: 1, Title: "Implementation"}}, nil
+	}
+	capturePaneFunc = func(sessionName, windowName string, paneIndex int, lines int) (string, error) {
+		output, ok := outputs[fmt.Sprintf("%s/%d", windowName, paneIndex)]
+		if !ok {
+			return "", errors.New("pane not found")
+		}
+		return output, nil
+	}
+
+	var out bytes.Buffer
+	tailer := newPaneTailer("osoba-repo", 83, 2, &out)
+
+	// ウィンドウがない間は1度だけ待機中と表示する
+	tailer.Poll()
+	tailer.Poll()
+	assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte("作成されるまで待機します")))
+
+	// 初回は各ペインの直近の行を表示する
+	out.Reset()
+	windows = []*tmux.WindowInfo{{Name: "issue-83"}}
+	outputs["issue-83/0"] = "plan 1\nplan 2\nplan 3\n\n\n"
+	outputs["issue-83/1"] = "impl 1\n"
+	tailer.Poll()
+	assert.Equal(t, "[Plan] plan 2\n[Plan] plan 3\n[Implementation] impl 1\n", out.String())
+
+	// 以降は増えた行のみを表示し、追加されたウィンドウも検出する
+	out.Reset()
+	windows = []*tmux.WindowInfo{{Name: "issue-83"}, {Name: "83-review"}}
+	outputs["issue-83/1"] = "impl 1\nimpl 2\n"
+	outputs["83-review/0"] = "review 1\n"
+	tailer.Poll()
+	assert.Equal(t, "[83-review.0] review 1\n[Implementation] impl 2\n", out.String())
+
+	// 監視プロセスが別のセッションに記録したフェーズ専用ウィンドウも表示する
+	out.Reset()
+	registry := tmux.NewPaneRegistry()
+	registry.Record(83, "revise", tmux.PanePlacement{SessionName: "osoba-repo-2", WindowName: "83-revise", Fallback: true})
+	tailer.registry = registry
+	outputs["83-revise/0"] = "revise 1\n"
+	tailer.Poll()
+	assert.Equal(t, "[osoba-repo-2:83-revise.0] revise 1\n", out.String())
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/douhashi/osoba/internal/git"
+	githubClient "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/utils"
+	"github.com/douhashi/osoba/internal/watcher"
+	"github.com/spf13/cobra"
+)
+
+// manualControlClient はtakeover / releaseで使用するGitHubクライアント
+type manualControlClient interface {
+	AddLabel(ctx context.Context, owner, repo string, issueNumber int, label string) error
+	RemoveLabel(ctx context.Context, owner, repo string, issueNumber int, label string) error
+	GetIssueLabels(ctx context.Context, owner, repo string, issueNumber int) ([]string, error)
+}
+
+// モック用の関数変数
+var (
+	createManualControlClientFunc = func() (manualControlClient, error) {
+		return githubClient.NewClient("")
+	}
+	recordPhaseEndFunc = git.RecordPhaseEnd
+)
+
+// manualControlResult はtakeover / releaseの結果（--output json）
+type manualControlResult struct {
+	IssueNumber int      `json:"issue_number"`
+	Manual      bool     `json:"manual"`                       // 実行後に手動対応中か
+	Changed     bool     `json:"changed"`                      // ラベルを変更したか（すでにその状態の場合はfalse）
+	Labels      []string `json:"labels"`                       // 実行後のIssueのラベル
+	Windows     []string `json:"windows,omitempty"`            // Issueのtmuxウィンドウ
+	Worktrees   []string `json:"worktrees,omitempty"`          // takeover: Issueのworktree
+	ResumeLabel string   `json:"resume_label,omitempty"`       // release: 自動処理を再開するトリガーラベル
+	Accepted    []string `json:"accepted_worktrees,omitempty"` // release: 未コミットの変更を受け入れたworktree
+}
+
+func newTakeoverCmd() *cobra.Command {
+	var issueNumber int
+	cmd := &cobra.Command{
+		Use:   "takeover",
+		Short: "Issueの作業を人間に引き継ぐ",
+		Long: `Issueに status:manual ラベルを付与し、osobaによる自動処理（フェーズの開始・ラベル遷移）を停止します。
+tmuxウィンドウとworktreeはそのまま残るため、続きの作業を手動で行えます。
+作業が終わったら osoba release で自動処理を再開します。
+
+使用例:
+  osoba takeover --issue 83
+  osoba takeover             # 処理中のIssueから選択`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			number, err := resolveIssueNumber(cmd, issueNumber)
+			if err != nil {
+				return err
+			}
+			return runTakeover(cmd, number)
+		},
+	}
+	cmd.Flags().IntVar(&issueNumber, "issue", 0, "引き継ぐIssue番号（省略時は処理中のIssueから選択）")
+	return withJSONOutput(cmd)
+}
+
+func newReleaseCmd() *cobra.Command {
+	var issueNumber int
+	cmd := &cobra.Command{
+		Use:   "release",
+		Short: "手動対応中のIssueの自動処理を再開",
+		Long: `Issueから status:manual ラベルを削除し、osobaによる自動処理を再開します。
+自動処理は現在のラベルの状態から再開されます。次のフェーズから再開する場合は、
+先にトリガーラベル（例: status:review-requested）を付与してください。
+worktreeの未コミットの変更は人が加えた変更として受け入れ、外部の編集による一時停止を解除します。
+
+使用例:
+  osoba release --issue 83
+  osoba release              # 処理中のIssueから選択`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			number, err := resolveIssueNumber(cmd, issueNumber)
+			if err != nil {
+				return err
+			}
+			return runRelease(cmd, number)
+		},
+	}
+	cmd.Flags().IntVar(&issueNumber, "issue", 0, "自動処理を再開するIssue番号（省略時は処理中のIssueから選択）")
+	return withJSONOutput(cmd)
+}
+
+func runTakeover(cmd *cobra.Command, issueNumber int) error {
+	ctx := context.Background()
+	client, repoInfo, err := prepareManualControl(ctx, issueNumber)
+	if err != nil {
+		return err
+	}
+
+	labels, err := client.GetIssueLabels(ctx, repoInfo.Owner, repoInfo.Repo, issueNumber)
+	if err != nil {
+		return fmt.Errorf("Issue #%d のラベル取得に失敗しました: %w", issueNumber, err)
+	}
+
+	result := &manualControlResult{IssueNumber: issueNumber, Manual: true}
+	out := textOut(cmd)
+	if hasLabelName(labels, watcher.ManualLabel) {
+		fmt.Fprintf(out, "Issue #%d はすでに手動対応中です\n", issueNumber)
+	} else {
+		if err := client.AddLabel(ctx, repoInfo.Owner, repoInfo.Repo, issueNumber, watcher.ManualLabel); err != nil {
+			return fmt.Errorf("%s ラベルの付与に失敗しました: %w", watcher.ManualLabel, err)
+		}
+		labels = append(labels, watcher.ManualLabel)
+		result.Changed = true
+		fmt.Fprintf(out, "🙋 Issue #%d を手動対応に切り替えました（%s を付与）\n", issueNumber, watcher.ManualLabel)
+		fmt.Fprintln(out, "   osoba release を実行するまで、このIssueのフェーズの開始・ラベル遷移は行われません")
+	}
+	result.Labels = labels
+
+	result.Windows, result.Worktrees = findIssueWorkspace(ctx, issueNumber)
+
+	fmt.Fprintf(out, "   現在のラベル: %s\n", strings.Join(labels, ", "))
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "作業環境:")
+	if len(result.Windows) > 0 {
+		fmt.Fprintf(out, "   tmuxウィンドウ: %s（osoba open で接続）\n", strings.Join(result.Windows, ", "))
+		fmt.Fprintln(out, "   ウィンドウ内で実行中のClaudeは停止しません。必要に応じて中断してください")
+	} else {
+		fmt.Fprintln(out, "   tmuxウィンドウ: なし")
+	}
+	if len(result.Worktrees) > 0 {
+		fmt.Fprintf(out, "   worktree: %s\n", strings.Join(result.Worktrees, ", "))
+	} else {
+		fmt.Fprintln(out, "   worktree: なし")
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "作業が終わったら、次のフェーズのトリガーラベル（例: status:review-requested）を付与して")
+	fmt.Fprintf(out, "   osoba release --issue %d\n", issueNumber)
+	fmt.Fprintln(out, "を実行すると自動処理を再開します")
+
+	return renderJSON(cmd, result)
+}
+
+func runRelease(cmd *cobra.Command, issueNumber int) error {
+	ctx := context.Background()
+	client, repoInfo, err := prepareManualControl(ctx, issueNumber)
+	if err != nil {
+		return err
+	}
+
+	labels, err := client.GetIssueLabels(ctx, repoInfo.Owner, repoInfo.Repo, issueNumber)
+	if err != nil {
+		return fmt.Errorf("Issue #%d のラベル取得に失敗しました: %w", issueNumber, err)
+	}
+
+	result := &manualControlResult{IssueNumber: issueNumber}
+	out := textOut(cmd)
+	if !hasLabelName(labels, watcher.ManualLabel) {
+		fmt.Fprintf(out, "Issue #%d は手動対応中ではありません\n", issueNumber)
+	} else {
+		if err := client.RemoveLabel(ctx, repoInfo.Owner, repoInfo.Repo, issueNumber, watcher.ManualLabel); err != nil {
+			return fmt.Errorf("%s ラベルの削除に失敗しました: %w", watcher.ManualLabel, err)
+		}
+		labels = removeLabelName(labels, watcher.ManualLabel)
+		result.Changed = true
+		fmt.Fprintf(out, "▶️  Issue #%d の手動対応を終了しました（%s を削除）\n", issueNumber, watcher.ManualLabel)
+	}
+	result.Labels = labels
+	result.ResumeLabel = resumeTriggerLabel(issueNumber, labels)
+
+	// 現在の未コミットの変更を受け入れ、次のフェーズで外部の編集とみなさないようにする
+	if worktrees, err := listWorktreesForIssueFunc(ctx, issueNumber); err == nil {
+		for _, wt := range worktrees {
+			if err := recordPhaseEndFunc(ctx, wt.Path); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  %s の未コミットの変更を受け入れられませんでした: %v\n", wt.Path, err)
+				continue
+			}
+			result.Accepted = append(result.Accepted, wt.Path)
+			fmt.Fprintf(out, "   %s の未コミットの変更を受け入れました（外部の編集による一時停止を解除）\n", wt.Path)
+		}
+	}
+
+	result.Windows = findIssueWindows(issueNumber)
+	if len(result.Windows) > 0 {
+		fmt.Fprintf(out, "   tmuxウィンドウ: %s\n", strings.Join(result.Windows, ", "))
+	}
+
+	if result.ResumeLabel != "" {
+		fmt.Fprintf(out, "   次回のポーリングで %s からフェーズを開始します\n", result.ResumeLabel)
+	} else {
+		fmt.Fprintf(out, "   現在のラベル（%s）では開始するフェーズはありません\n", strings.Join(labels, ", "))
+		fmt.Fprintln(out, "   フェーズを開始するには、実行中ラベル（status:implementing など）を外してトリガーラベル（status:ready など）を付与してください")
+	}
+
+	return renderJSON(cmd, result)
+}
+
+// prepareManualControl はIssue番号を検証し、GitHubクライアントとリポジトリ情報を用意する
+func prepareManualControl(ctx context.Context, issueNumber int) (manualControlClient, *utils.GitHubRepoInfo, error) {
+	if issueNumber <= 0 {
+		return nil, nil, fmt.Errorf("Issue番号は正の整数で指定してください")
+	}
+
+	repoInfo, err := getGitHubRepoInfoFunc(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("GitHubリポジトリ情報の取得に失敗しました: %w", err)
+	}
+
+	client, err := createManualControlClientFunc()
+	if err != nil {
+		return nil, nil, fmt.Errorf("GitHubクライアントの作成に失敗しました: %w", err)
+	}
+	return client, repoInfo, nil
+}
+
+// findIssueWorkspace はIssueのtmuxウィンドウとworktreeを返す
+// 取得できない場合（tmuxセッションがないなど）は空として扱う
+func findIssueWorkspace(ctx context.Context, issueNumber int) ([]string, []string) {
+	var worktrees []string
+	if found, err := listWorktreesForIssueFunc(ctx, issueNumber); err == nil {
+		for _, wt := range found {
+			worktrees = append(worktrees, wt.Path)
+		}
+	}
+	return findIssueWindows(issueNumber), worktrees
+}
+
+// findIssueWindows はIssueのtmuxウィンドウを返す
+// 監視プロセスが記録したペインの配置から、別のセッションに作成されたフェーズ専用ウィンドウも含める
+func findIssueWindows(issueNumber int) []string {
+	repoName, err := getRepositoryNameFunc()
+	if err != nil {
+		return nil
+	}
+	sessionName := fmt.Sprintf("osoba-%s", repoName)
+	var windows []string
+	for _, w := range listIssueWindows(sessionName, issueNumber, loadPaneRegistryFunc()) {
+		windows = append(windows, w.name(sessionName))
+	}
+	return windows
+}
+
+// resumeTriggerLabel はラベルの状態から、自動処理を再開するトリガーラベルを返す
+// フェーズを開始しない状態の場合は空文字を返す
+func resumeTriggerLabel(issueNumber int, labels []string) string {
+	issue := &githubClient.Issue{Number: &issueNumber}
+	for _, name := range labels {
+		name := name
+		issue.Labels = append(issue.Labels, &githubClient.Label{Name: &name})
+	}
+	if shouldProcess, _ := watcher.ShouldProcessIssue(issue); !shouldProcess {
+		return ""
+	}
+	for _, trigger := range []string{
+		watcher.TriggerLabelNeedsPlan,
+		watcher.TriggerLabelReady,
+		watcher.TriggerLabelReviewRequested,
+		watcher.TriggerLabelRequiresChanges,
+	} {
+		if hasLabelName(labels, trigger) {
+			return trigger
+		}
+	}
+	return ""
+}
+
+func hasLabelName(labels []string, name string) bool {
+	for _, label := range labels {
+		if label == name {
+			return true
+		}
+	}
+	return false
+}
+
+func removeLabelName(labels []string, name string) []string {
+	result := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label != name {
+			result = append(result, label)
+		}
+	}
+	return result
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/douhashi/osoba/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubManualControlClient struct {
+	labels  []string
+	added   []string
+	removed []string
+}
+
+func (s *stubManualControlClient) AddLabel(ctx context.Context, owner, repo string, issueNumber int, label string) error {
+	s.added = append(s.added, label)
+	return nil
+}
+
+func (s *stubManualControlClient) RemoveLabel(ctx context.Context, owner, repo string, issueNumber int, label string) error {
+	s.removed = append(s.removed, label)
+	return nil
+}
+
+func (s *stubManualControlClient) GetIssueLabels(ctx context.Context, owner, repo string, issueNumber int) ([]string, error) {
+	return s.labels, nil
+}
+
+func TestTakeoverAndReleaseCmd(t *testing.T) {
+	origRepoInfo := getGitHubRepoInfoFunc
+	origClient := createManualControlClientFunc
+	origRepoName := getRepositoryNameFunc
+	origWindows := listWindowsForIssueFunc
+	origWorktrees := listWorktreesForIssueFunc
+	origStdinIsTerminal := stdinIsTerminalFunc
+	origPaneRegistry := loadPaneRegistryFunc
+	defer func() {
+		loadPaneRegistryFunc = origPaneRegistry
+		getGitHubRepoInfoFunc = origRepoInfo
+		createManualControlClientFunc = origClient
+		getRepositoryNameFunc = origRepoName
+		listWindowsForIssueFunc = origWindows
+		listWorktreesForIssueFunc = origWorktrees
+		stdinIsTerminalFunc = origStdinIsTerminal
+		outputFormat = outputText
+	}()
+
+	stdinIsTerminalFunc = func() bool { return false }
+	loadPaneRegistryFunc = func() *tmux.PaneRegistry { return nil }
+
+	getGitHubRepoInfoFunc = func(ctx context.Context) (*utils.GitHubRepoInfo, error) {
+		return &utils.GitHubRepoInfo{Owner: "douhashi", Repo: "osoba"}, nil
+	}
+	getRepositoryNameFunc = func() (string, error) { return "osoba", nil }
+	listWindowsForIssueFunc = func(sessionName string, issueNumber int) ([]*tmux.WindowInfo, error) {
+		return []*tmux.WindowInfo{{Name: "issue-83-implement"}}, nil
+	}
+	listWorktreesForIssueFunc = func(ctx context.Context, issueNumber int) ([]git.WorktreeInfo, error) {
+		return nil, errors.New("not a git repository")
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		labels      []string
+		wantAdded   []string
+		wantRemoved []string
+		wantOutput  []string
+		wantErr     string
+	}{
+		{
+			name:       "takeoverでstatus:manualを付与",
+			args:       []string{"takeover", "--issue", "83"},
+			labels:     []string{"status:implementing"},
+			wantAdded:  []string{"status:manual"},
+			wantOutput: []string{"Issue #83 を手動対応に切り替えました", "issue-83-implement", "worktree: なし", "osoba release --issue 83"},
+		},
+		{
+			name:       "手動対応中のIssueをtakeover",
+			args:       []string{"takeover", "--issue", "83"},
+			labels:     []string{"status:implementing", "status:manual"},
+			wantOutput: []string{"Issue #83 はすでに手動対応中です"},
+		},
+		{
+			name:        "releaseでトリガーラベルから再開",
+			args:        []string{"release", "--issue", "83"},
+			labels:      []string{"status:review-requested", "status:manual"},
+			wantRemoved: []string{"status:manual"},
+			wantOutput:  []string{"Issue #83 の手動対応を終了しました", "次回のポーリングで status:review-requested からフェーズを開始します"},
+		},
+		{
+			name:        "実行中ラベルが残っている場合はフェーズを開始しない",
+			args:        []string{"release", "--issue", "83"},
+			labels:      []string{"status:ready", "status:implementing", "status:manual"},
+			wantRemoved: []string{"status:manual"},
+			wantOutput:  []string{"では開始するフェーズはありません"},
+		},
+		{
+			name:       "手動対応中ではないIssueをrelease",
+			args:       []string{"release", "--issue", "83"},
+			labels:     []string{"status:ready"},
+			wantOutput: []string{"Issue #83 は手動対応中ではありません"},
+		},
+		{
+			name:    "Issue番号が不正",
+			args:    []string{"takeover", "--issue", "0"},
+			wantErr: "Issue番号は正の整数で指定してください",
+		},
+		{
+			name:    "Issue番号の指定なし",
+			args:    []string{"release"},
+			wantErr: "--issue でIssue番号を指定してください",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &stubManualControlClient{labels: tt.labels}
+			createManualControlClientFunc = func() (manualControlClient, error) {
+				return client, nil
+			}
+
+			rootCmd := NewRootCmd()
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAdded, client.added)
+			assert.Equal(t, tt.wantRemoved, client.removed)
+			for _, want := range tt.wantOutput {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestReleaseCmd_JSONOutput(t *testing.T) {
+	origRepoInfo := getGitHubRepoInfoFunc
+	origClient := createManualControlClientFunc
+	defer func() {
+		getGitHubRepoInfoFunc = origRepoInfo
+		createManualControlClientFunc = origClient
+		outputFormat = outputText
+	}()
+
+	getGitHubRepoInfoFunc = func(ctx context.Context) (*utils.GitHubRepoInfo, error) {
+		return &utils.GitHubRepoInfo{Owner: "douhashi", Repo: "osoba"}, nil
+	}
+	createManualControlClientFunc = func() (manualControlClient, error) {
+		return &stubManualControlClient{labels: []string{"status:ready", "status:manual"}}, nil
+	}
+
+	rootCmd := NewRootCmd()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"release", "--issue", "83", "-o", "json"})
+	require.NoError(t, rootCmd.Execute())
+
+	var result manualControlResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, manualControlResult{
+		IssueNumber: 83,
+		Changed:     true,
+		Labels:      []string{"status:ready"},
+		ResumeLabel: "status:ready",
+	}, result)
+}
+
+func TestReleaseCmd_AcceptsWorktreeEdits(t *testing.T) {
+	origRepoInfo := getGitHubRepoInfoFunc
+	origClient := createManualControlClientFunc
+	origWorktrees := listWorktreesForIssueFunc
+	origRecord := recordPhaseEndFunc
+	defer func() {
+		getGitHubRepoInfoFunc = origRepoInfo
+		createManualControlClientFunc = origClient
+		listWorktreesForIssueFunc = origWorktrees
+		recordPhaseEndFunc = origRecord
+	}()
+
+	getGitHubRepoInfoFunc = func(ctx context.Context) (*utils.GitHubRepoInfo, error) {
+		return &utils.GitHubRepoInfo{Owner: "douhashi", Repo: "osoba"}, nil
+	}
+	createManualControlClientFunc = func() (manualControlClient, error) {
+		return &stubManualControlClient{labels: []string{"status:ready"}}, nil
+	}
+	listWorktreesForIssueFunc = func(ctx context.Context, issueNumber int) ([]git.WorktreeInfo, error) {
+		return []git.WorktreeInfo{{Path: "/repo/.git/osoba/worktrees/issue-83"}}, nil
+	}
+	var recorded []string
+	recordPhaseEndFunc = func(ctx context.Context, worktreePath string) error {
+		recorded = append(recorded, worktreePath)
+		return nil
+	}
+
+	rootCmd := NewRootCmd()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"release", "--issue", "83"})
+	require.NoError(t, rootCmd.Execute())
+
+	// 手動対応中でなくても、worktreeの未コミットの変更を受け入れて一時停止を解除する
+	assert.Equal(t, []string{"/repo/.git/osoba/worktrees/issue-83"}, recorded)
+	assert.Contains(t, buf.String(), "/repo/.git/osoba/worktrees/issue-83 の未コミットの変更を受け入れました")
+}
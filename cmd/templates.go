@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// claudeCommandDir はosobaのClaude commandを配置するディレクトリ
+var claudeCommandDir = filepath.Join(".claude", "commands", "osoba")
+
+// claudeCommandFiles はosobaが配置するClaude commandのファイル
+var claudeCommandFiles = []string{"plan.md", "implement.md", "review.md", "revise.md", "review-pr.md", "revise-pr.md", "add-backlog.md"}
+
+// templatesSyncOptions はtemplates syncの実行オプション
+type templatesSyncOptions struct {
+	from   string // 外部テンプレートパックのディレクトリ（空の場合は組み込みテンプレート）
+	yes    bool   // 確認せずに差分のあるファイルをすべて更新する
+	dryRun bool   // 差分の表示のみ行う
+}
+
+func newTemplatesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "osobaが配置するテンプレートを管理",
+		Long:  `osoba initで配置したClaude commandテンプレートの確認・更新・検証を行います。`,
+	}
+
+	cmd.AddCommand(newTemplatesSyncCmd())
+	cmd.AddCommand(newTemplatesLintCmd())
+
+	return cmd
+}
+
+func newTemplatesSyncCmd() *cobra.Command {
+	opts := &templatesSyncOptions{}
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Claude commandテンプレートの差分を表示して更新",
+		Long: `.claude/commands/osoba 以下のファイルを組み込みテンプレート（または --from で指定したテンプレートパック）と比較し、
+差分を表示してファイルごとに更新するかを確認します。
+テンプレートのプロジェクト変数（テストコマンドなど）は osoba init と同様に置換してから比較します。`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return syncClaudeCommands(cmd.InOrStdin(), cmd.OutOrStdout(), ".", *opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", "", "テンプレートパックのディレクトリ（plan.mdなどを含むディレクトリ）")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "確認せずに差分のあるファイルをすべて更新")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "差分の表示のみ行い、ファイルは更新しない")
+
+	return cmd
+}
+
+// syncClaudeCommands はrootディレクトリのClaude commandをテンプレートと比較し、選択されたファイルを更新する
+func syncClaudeCommands(in io.Reader, out io.Writer, root string, opts templatesSyncOptions) error {
+	profile := detectProjectProfile(root)
+	reader := bufio.NewReader(in)
+	var updated, skipped, upToDate int
+
+	for _, file := range claudeCommandFiles {
+		want, err := loadCommandTemplate(opts.from, file)
+		if err != nil {
+			return err
+		}
+		want = renderCommandTemplate(want, profile)
+
+		rel := filepath.Join(claudeCommandDir, file)
+		dst := filepath.Join(root, rel)
+		have, err := os.ReadFile(dst)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("ファイルの読み込みに失敗しました: %w", err)
+		}
+		if err == nil && bytes.Equal(have, want) {
+			upToDate++
+			continue
+		}
+
+		fromName := "a/" + filepath.ToSlash(rel)
+		if err != nil {
+			fromName = "/dev/null"
+		}
+		fmt.Fprint(out, unifiedDiff(fromName, "b/"+filepath.ToSlash(rel), have, want))
+
+		if opts.dryRun {
+			skipped++
+			continue
+		}
+		if !opts.yes && !confirmTemplateUpdate(reader, out, rel) {
+			skipped++
+			continue
+		}
+
+		if err := mkdirAllFunc(filepath.Dir(dst), 0755); err != nil {
+			return fmt.Errorf("ディレクトリの作成に失敗しました: %w", err)
+		}
+		if err := writeFileFunc(dst, want, 0644); err != nil {
+			return fmt.Errorf("ファイルの更新に失敗しました: %w", err)
+		}
+		fmt.Fprintf(out, "✅ %s を更新しました\n", rel)
+		updated++
+	}
+
+	fmt.Fprintf(out, "\n更新: %d件 / スキップ: %d件 / 最新: %d件\n", updated, skipped, upToDate)
+	return nil
+}
+
+// loadCommandTemplate はClaude commandのテンプレートを読み込む
+// テンプレートパックにないファイルは組み込みテンプレートを使用する
+func loadCommandTemplate(from, file string) ([]byte, error) {
+	if from != "" {
+		data, err := os.ReadFile(filepath.Join(from, file))
+		if err == nil {
+			return data, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("テンプレートパックの読み込みに失敗しました: %w", err)
+		}
+	}
+
+	data, err := templateFS.ReadFile("templates/commands/" + file)
+	if err != nil {
+		return nil, fmt.Errorf("テンプレートファイルの読み込みに失敗しました: %w", err)
+	}
+	return data, nil
+}
+
+// confirmTemplateUpdate はファイルを更新するかを確認する（y/yes以外・EOFは更新しない）
+func confirmTemplateUpdate(reader *bufio.Reader, out io.Writer, rel string) bool {
+	fmt.Fprintf(out, "%s を更新しますか？ [y/N]: ", rel)
+	line, err := reader.ReadString('\n')
+	answer := strings.ToLower(strings.TrimSpace(line))
+	if err != nil && answer == "" {
+		fmt.Fprintln(out)
+	}
+	return answer == "y" || answer == "yes"
+}
+
+// diffContextLines はunified形式の差分で変更箇所の前後に表示する行数
+const diffContextLines = 3
+
+// diffLine は差分の1行（kindは' '・'-'・'+'のいずれか）
+type diffLine struct {
+	kind byte
+	text string
+}
+
+// unifiedDiff はaからbへの差分をunified形式で返す（差分がない場合は空文字列）
+func unifiedDiff(fromName, toName string, a, b []byte) string {
+	lines := diffLines(splitLines(a), splitLines(b))
+
+	var changes []int
+	for i, l := range lines {
+		if l.kind != ' ' {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	// 各行より前にあるa・bの行数（ハンクの開始行の計算に使う）
+	aBefore := make([]int, len(lines)+1)
+	bBefore := make([]int, len(lines)+1)
+	for i, l := range lines {
+		aBefore[i+1], bBefore[i+1] = aBefore[i], bBefore[i]
+		if l.kind != '+' {
+			aBefore[i+1]++
+		}
+		if l.kind != '-' {
+			bBefore[i+1]++
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for i := 0; i < len(changes); {
+		start := max(changes[i]-diffContextLines, 0)
+		end := changes[i] + 1
+		// 前後の表示行が重なる変更箇所は1つのハンクにまとめる
+		for i < len(changes) && changes[i] <= end+2*diffContextLines {
+			end = changes[i] + 1
+			i++
+		}
+		end = min(end+diffContextLines, len(lines))
+
+		aCount, bCount := aBefore[end]-aBefore[start], bBefore[end]-bBefore[start]
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(aBefore[start], aCount), hunkRange(bBefore[start], bCount))
+		for _, l := range lines[start:end] {
+			sb.WriteByte(l.kind)
+			sb.WriteString(l.text)
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+// hunkRange はハンクヘッダーの範囲を返す（行がない場合は直前の行番号を開始行とする）
+func hunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// splitLines はデータを行に分割する（末尾の改行は行の区切りとして扱う）
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+// diffLines は最長共通部分列によりaからbへの行単位の差分を求める
+func diffLines(a, b []string) []diffLine {
+	// lcs[i][j] はa[i:]とb[j:]の最長共通部分列の長さ
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+	return lines
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/gh"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	// commandReferencePattern はプロンプトで参照するClaude command（/osoba:plan）
+	commandReferencePattern = regexp.MustCompile(`/osoba:([A-Za-z0-9_-]+)`)
+	// templateVariablePattern はClaude command内の{{変数名}}
+	templateVariablePattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+	// statusLabelPattern はClaude command内で参照するstatus:ラベル
+	statusLabelPattern = regexp.MustCompile(`status:[a-z0-9][a-z0-9-]*[a-z0-9]`)
+)
+
+// requiredPlaceholders は組み込みテンプレートが含む場合にClaude commandにも必要なプレースホルダー
+var requiredPlaceholders = []string{"$ARGUMENTS"}
+
+// projectVariables はosoba init・templates syncで置換するプロジェクト変数
+var projectVariables = map[string]bool{"test-command": true, "build-command": true, "lint-command": true}
+
+// templateLintProblem はClaude commandの問題
+type templateLintProblem struct {
+	File    string // リポジトリのルートからの相対パス
+	Message string
+}
+
+func newTemplatesLintCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint",
+		Short: "Claude commandテンプレートと設定の不整合を検出",
+		Long: `.claude/commands/osoba 以下のファイルを検証します。
+
+- フェーズのプロンプト（claude.phases）で参照しているClaude commandが存在するか
+- 組み込みテンプレートにあるプレースホルダー（$ARGUMENTS）が残っているか
+- 参照しているstatus:ラベルが設定（github.labels・github.workflowなど）にあるか
+- 置換されないテンプレート変数（{{test-command}}など）が残っていないか
+
+問題がある場合は一覧を表示して終了コード1で終了します。`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.NewConfig()
+			configPath := viper.ConfigFileUsed()
+			if configPath == "" {
+				configPath = viper.GetString("config")
+			}
+			if _, err := cfg.LoadOrDefaultWithError(configPath); err != nil {
+				return fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+			}
+			if err := cfg.Validate(); err != nil {
+				return fmt.Errorf("設定の検証に失敗しました: %w", err)
+			}
+
+			problems, err := lintClaudeCommands(".", cfg)
+			if err != nil {
+				return err
+			}
+			return reportTemplateLint(cmd.OutOrStdout(), problems)
+		},
+	}
+}
+
+// reportTemplateLint は検出した問題を表示し、問題がある場合はエラーを返す
+func reportTemplateLint(out io.Writer, problems []templateLintProblem) error {
+	if len(problems) == 0 {
+		fmt.Fprintln(out, "✅ Claude commandに問題は見つかりませんでした")
+		return nil
+	}
+	for _, p := range problems {
+		fmt.Fprintf(out, "%s: %s\n", p.File, p.Message)
+	}
+	return fmt.Errorf("Claude commandに%d件の問題が見つかりました", len(problems))
+}
+
+// lintClaudeCommands はrootディレクトリのClaude commandを設定と組み込みテンプレートに照らして検証する
+func lintClaudeCommands(root string, cfg *config.Config) ([]templateLintProblem, error) {
+	var problems []templateLintProblem
+
+	// フェーズのプロンプトで参照しているClaude commandの存在
+	for _, ref := range referencedCommands(cfg) {
+		rel := filepath.Join(claudeCommandDir, ref.name+".md")
+		if !fileExists(filepath.Join(root, rel)) {
+			problems = append(problems, templateLintProblem{
+				File:    rel,
+				Message: fmt.Sprintf("claude.phases.%s のプロンプトで参照されていますが、ファイルがありません", ref.phase),
+			})
+		}
+	}
+
+	paths, err := filepath.Glob(filepath.Join(root, claudeCommandDir, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	known := knownTemplateLabels(cfg)
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("ファイルの読み込みに失敗しました: %w", err)
+		}
+		rel := filepath.Join(claudeCommandDir, filepath.Base(path))
+		content := string(data)
+
+		if builtin, err := templateFS.ReadFile("templates/commands/" + filepath.Base(path)); err == nil {
+			for _, placeholder := range requiredPlaceholders {
+				if strings.Contains(string(builtin), placeholder) && !strings.Contains(content, placeholder) {
+					problems = append(problems, templateLintProblem{
+						File:    rel,
+						Message: fmt.Sprintf("プレースホルダー %s がありません（対象のIssue・PR番号を受け取れません）", placeholder),
+					})
+				}
+			}
+		}
+
+		seen := make(map[string]bool)
+		for _, m := range templateVariablePattern.FindAllStringSubmatch(content, -1) {
+			if seen[m[0]] {
+				continue
+			}
+			seen[m[0]] = true
+			message := fmt.Sprintf("不明なテンプレート変数 %s があります（Claude commandでは展開されません）", m[0])
+			if projectVariables[m[1]] {
+				message = fmt.Sprintf("プロジェクト変数 %s が置換されていません（osoba templates sync で更新できます）", m[0])
+			}
+			problems = append(problems, templateLintProblem{File: rel, Message: message})
+		}
+
+		for _, label := range statusLabelPattern.FindAllString(content, -1) {
+			if seen[label] || known[label] {
+				continue
+			}
+			seen[label] = true
+			problems = append(problems, templateLintProblem{
+				File:    rel,
+				Message: fmt.Sprintf("ラベル %s は設定にありません（github.labels・github.workflowを確認してください）", label),
+			})
+		}
+	}
+	return problems, nil
+}
+
+// commandReference はフェーズのプロンプトで参照しているClaude command
+type commandReference struct {
+	phase string
+	name  string
+}
+
+// referencedCommands はフェーズのプロンプト（バリアントを含む）で参照しているClaude commandを重複なく返す
+func referencedCommands(cfg *config.Config) []commandReference {
+	if cfg.Claude == nil {
+		return nil
+	}
+	phases := make([]string, 0, len(cfg.Claude.Phases))
+	for phase := range cfg.Claude.Phases {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+
+	var refs []commandReference
+	seen := make(map[string]bool)
+	for _, phase := range phases {
+		pc := cfg.Claude.Phases[phase]
+		if pc == nil {
+			continue
+		}
+		prompts := []string{pc.Prompt}
+		for _, variant := range pc.Variants {
+			prompts = append(prompts, variant.Prompt)
+		}
+		for _, prompt := range prompts {
+			for _, m := range commandReferencePattern.FindAllStringSubmatch(prompt, -1) {
+				if !seen[m[1]] {
+					seen[m[1]] = true
+					refs = append(refs, commandReference{phase: phase, name: m[1]})
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// knownTemplateLabels はClaude commandで参照できるラベルを返す
+// 設定のラベルと、設定で変更できない標準のラベル（status:lgtmなど）を含む
+// 設定で名前を変更した標準のラベルは含めない
+func knownTemplateLabels(cfg *config.Config) map[string]bool {
+	defaults := config.NewConfig()
+	_ = defaults.Validate()
+	configurable := make(map[string]bool)
+	for _, label := range defaults.ManagedLabels() {
+		configurable[label] = true
+	}
+
+	known := make(map[string]bool)
+	for _, label := range gh.RequiredLabelNames() {
+		if !configurable[label] {
+			known[label] = true
+		}
+	}
+	for _, label := range cfg.ManagedLabels() {
+		known[label] = true
+	}
+	return known
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/claude"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintClaudeCommands(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, root string, cfg *config.Config)
+		want  []templateLintProblem
+	}{
+		{
+			name:  "組み込みテンプレートのまま",
+			setup: func(t *testing.T, root string, cfg *config.Config) {},
+		},
+		{
+			name: "$ARGUMENTSを削除",
+			setup: func(t *testing.T, root string, cfg *config.Config) {
+				writeCommand(t, root, "review.md", "Review the PR for the Issue.\n")
+			},
+			want: []templateLintProblem{
+				{File: filepath.Join(claudeCommandDir, "review.md"), Message: "プレースホルダー $ARGUMENTS がありません（対象のIssue・PR番号を受け取れません）"},
+			},
+		},
+		{
+			name: "置換されていない変数と不明な変数",
+			setup: func(t *testing.T, root string, cfg *config.Config) {
+				writeCommand(t, root, "plan.md", "Run {{test-command}}.\nIssue {{issue-number}} and {{issue-number}}.\n")
+			},
+			want: []templateLintProblem{
+				{File: filepath.Join(claudeCommandDir, "plan.md"), Message: "プロジェクト変数 {{test-command}} が置換されていません（osoba templates sync で更新できます）"},
+				{File: filepath.Join(claudeCommandDir, "plan.md"), Message: "不明なテンプレート変数 {{issue-number}} があります（Claude commandでは展開されません）"},
+			},
+		},
+		{
+			name: "設定で名前を変更したラベル",
+			setup: func(t *testing.T, root string, cfg *config.Config) {
+				cfg.GitHub.Labels.Ready = "status:approved-plan"
+				cfg.GitHub.Workflow.Transitions[0].Next = "status:approved-plan"
+				cfg.GitHub.Workflow.Transitions[1].From = "status:approved-plan"
+				cfg.GitHub.Workflow.Transitions[3].To = "status:approved-plan"
+				writeCommand(t, root, "plan.md", "Add `status:ready` (or status:approved-plan) and status:lgtm.\n")
+			},
+			want: []templateLintProblem{
+				{File: filepath.Join(claudeCommandDir, "plan.md"), Message: "ラベル status:ready は設定にありません（github.labels・github.workflowを確認してください）"},
+			},
+		},
+		{
+			name: "バリアントで参照しているcommandがない",
+			setup: func(t *testing.T, root string, cfg *config.Config) {
+				cfg.Claude.Phases["implement"].Variants = []claude.PromptVariant{
+					{Labels: []string{"bug"}, Prompt: "/osoba:implement-bugfix {{issue-number}}"},
+				}
+			},
+			want: []templateLintProblem{
+				{File: filepath.Join(claudeCommandDir, "implement-bugfix.md"), Message: "claude.phases.implement のプロンプトで参照されていますが、ファイルがありません"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeClaudeCommands(t, root)
+			cfg := config.NewConfig()
+			require.NoError(t, cfg.Validate())
+			tt.setup(t, root, cfg)
+
+			problems, err := lintClaudeCommands(root, cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, problems)
+		})
+	}
+}
+
+func TestReportTemplateLint(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, reportTemplateLint(&out, nil))
+	assert.Contains(t, out.String(), "問題は見つかりませんでした")
+
+	out.Reset()
+	err := reportTemplateLint(&out, []templateLintProblem{{File: "a.md", Message: "x"}})
+	require.Error(t, err)
+	assert.Equal(t, "a.md: x\n", out.String())
+}
+
+func TestTemplatesLintCmd_BrokenConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "osoba.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("github:\n  poll_interval: abc\n"), 0644))
+
+	rootCmd := NewRootCmd()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"--config", configPath, "templates", "lint"})
+
+	err := rootCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "設定ファイルの読み込みに失敗しました")
+	assert.NotContains(t, buf.String(), "問題は見つかりませんでした")
+}
+
+func writeCommand(t *testing.T, root, file, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(root, claudeCommandDir, file), []byte(content), 0644))
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want string
+	}{
+		{
+			name: "差分なし",
+			a:    "a\nb\n",
+			b:    "a\nb\n",
+			want: "",
+		},
+		{
+			name: "1行の変更",
+			a:    "1\n2\n3\n4\n5\n",
+			b:    "1\n2\nthree\n4\n5\n",
+			want: "--- a/f\n+++ b/f\n@@ -1,5 +1,5 @@\n 1\n 2\n-3\n+three\n 4\n 5\n",
+		},
+		{
+			name: "離れた変更は別のハンク",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			b:    "x\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ny\n",
+			want: "--- a/f\n+++ b/f\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+y\n",
+		},
+		{
+			name: "新規ファイル",
+			a:    "",
+			b:    "a\nb\n",
+			want: "--- a/f\n+++ b/f\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unifiedDiff("a/f", "b/f", []byte(tt.a), []byte(tt.b)))
+		})
+	}
+}
+
+// writeClaudeCommands はrootに組み込みテンプレートと同じClaude commandを配置する
+func writeClaudeCommands(t *testing.T, root string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, claudeCommandDir), 0755))
+	for _, file := range claudeCommandFiles {
+		data, err := loadCommandTemplate("", file)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(root, claudeCommandDir, file), renderCommandTemplate(data, genericProjectProfile), 0644))
+	}
+}
+
+func TestSyncClaudeCommands(t *testing.T) {
+	t.Run("変更されたファイルは確認して更新する", func(t *testing.T) {
+		root := t.TempDir()
+		writeClaudeCommands(t, root)
+		require.NoError(t, os.WriteFile(filepath.Join(root, claudeCommandDir, "plan.md"), []byte("local plan\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(root, claudeCommandDir, "review.md"), []byte("local review\n"), 0644))
+
+		var out bytes.Buffer
+		require.NoError(t, syncClaudeCommands(strings.NewReader("y\nn\n"), &out, root, templatesSyncOptions{}))
+
+		assert.Contains(t, out.String(), "--- a/.claude/commands/osoba/plan.md")
+		assert.Contains(t, out.String(), "-local plan")
+		assert.Contains(t, out.String(), "更新: 1件 / スキップ: 1件 / 最新: 5件")
+
+		plan, err := os.ReadFile(filepath.Join(root, claudeCommandDir, "plan.md"))
+		require.NoError(t, err)
+		assert.NotEqual(t, "local plan\n", string(plan))
+		review, err := os.ReadFile(filepath.Join(root, claudeCommandDir, "review.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "local review\n", string(review))
+	})
+
+	t.Run("dry-runでは更新しない", func(t *testing.T) {
+		root := t.TempDir()
+
+		var out bytes.Buffer
+		require.NoError(t, syncClaudeCommands(strings.NewReader(""), &out, root, templatesSyncOptions{dryRun: true}))
+
+		assert.Contains(t, out.String(), "--- /dev/null")
+		assert.Contains(t, out.String(), "更新: 0件 / スキップ: 7件 / 最新: 0件")
+		assert.NoFileExists(t, filepath.Join(root, claudeCommandDir, "plan.md"))
+	})
+
+	t.Run("テンプレートパックのファイルと比較する", func(t *testing.T) {
+		root := t.TempDir()
+		writeClaudeCommands(t, root)
+		pack := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(pack, "implement.md"), []byte("team implement {{test-command}}\n"), 0644))
+
+		var out bytes.Buffer
+		require.NoError(t, syncClaudeCommands(strings.NewReader(""), &out, root, templatesSyncOptions{from: pack, yes: true}))
+
+		// パックにないファイルは組み込みテンプレートと比較する
+		assert.Contains(t, out.String(), "更新: 1件 / スキップ: 0件 / 最新: 6件")
+		implement, err := os.ReadFile(filepath.Join(root, claudeCommandDir, "implement.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "team implement "+genericProjectProfile.TestCommand+"\n", string(implement))
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/douhashi/osoba/internal/utils"
 )
@@ -40,3 +42,8 @@ func findConfigFile() (string, bool) {
 
 	return "", false
 }
+
+// dailyLogFile はlogDir配下の当日（locのタイムゾーンでの日付）のログファイルのパスを返す
+func dailyLogFile(logDir string, loc *time.Location) string {
+	return filepath.Join(logDir, time.Now().In(loc).Format("2006-01-02")+".log")
+}
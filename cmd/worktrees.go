@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+)
+
+// テスト時にモック可能な関数変数
+var (
+	loadWorktreesConfigFunc = func() (*config.Config, error) {
+		cfg := config.NewConfig()
+		if _, err := cfg.LoadOrDefaultWithError(viper.GetString("config")); err != nil {
+			return nil, fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+		}
+		return cfg, nil
+	}
+	worktreesNowFunc = time.Now
+)
+
+// worktreeIssuePatterns はworktreeのディレクトリ名・ブランチ名からIssue番号を取り出すパターン
+var worktreeIssuePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^issue-(\d+)$`),  // .git/osoba/worktrees/issue-83
+	regexp.MustCompile(`^(\d+)-[a-z]+$`), // 旧形式の.git/osoba/worktrees/83-implement
+	regexp.MustCompile(`^osoba/#(\d+)$`), // ブランチ osoba/#83
+}
+
+// worktreeEntry はosobaが作成したworktreeの情報
+type worktreeEntry struct {
+	IssueNumber int              `json:"issue_number,omitempty"` // Issue番号（取り出せない場合は省略）
+	Path        string           `json:"path"`
+	Branch      string           `json:"branch"`
+	Commit      string           `json:"commit"`
+	Dirty       *bool            `json:"dirty"` // 未コミットの変更があるか（確認できない場合はnull）
+	DiskUsage   int64            `json:"disk_usage_bytes"`
+	CreatedAt   *time.Time       `json:"created_at,omitempty"`
+	Windows     []worktreeWindow `json:"windows"`
+}
+
+// worktreeWindow はworktreeのIssueのtmuxウィンドウ
+type worktreeWindow struct {
+	Session string   `json:"session"`
+	Window  string   `json:"window"`
+	Panes   []string `json:"panes"` // ペインのタイトル
+}
+
+func newWorktreesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "worktrees",
+		Short: "osobaが作成したworktreeの一覧を表示",
+		Long: `osobaが作成したworktreeについて、Issue番号・ブランチ・未コミットの変更の有無・ディスク使用量・
+作成からの経過時間と、Issueのtmuxウィンドウ・ペインを一覧で表示します。
+git worktree list とtmuxの状態を突き合わせる手間を省くためのコマンドです。
+
+使用例:
+  osoba worktrees
+  osoba worktrees --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorktrees(cmd)
+		},
+	}
+	return withJSONOutput(cmd)
+}
+
+func runWorktrees(cmd *cobra.Command) error {
+	cfg, err := loadWorktreesConfigFunc()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	allWorktrees, err := listAllWorktreesFunc(ctx)
+	if err != nil {
+		return fmt.Errorf("worktree一覧の取得に失敗しました: %w", err)
+	}
+	var worktrees []git.WorktreeInfo
+	for _, wt := range allWorktrees {
+		if isOsobaWorktree(wt.Path) {
+			worktrees = append(worktrees, wt)
+		}
+	}
+
+	var sessions []string
+	if checkTmuxInstalledFunc() == nil {
+		if repoName, err := getRepositoryNameFunc(); err == nil {
+			sessions = cfg.Tmux.SessionNames(cfg.Tmux.SessionPrefix + repoName)
+		}
+	}
+
+	progress := newProgressIndicator(cmd)
+	progress.Start("worktreeを調べています", len(worktrees))
+	entries := make([]worktreeEntry, 0, len(worktrees))
+	for _, wt := range worktrees {
+		entries = append(entries, inspectWorktree(ctx, wt, sessions))
+		progress.Advance()
+	}
+	progress.Stop()
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].IssueNumber < entries[j].IssueNumber })
+
+	if isJSONOutput() {
+		return renderJSON(cmd, entries)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "osobaが作成したworktreeはありません")
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ISSUE\tブランチ\t変更\tサイズ\t経過\tウィンドウ\tパス")
+	now := worktreesNowFunc()
+	for _, e := range entries {
+		issue := "-"
+		if e.IssueNumber > 0 {
+			issue = "#" + strconv.Itoa(e.IssueNumber)
+		}
+		dirty := "?"
+		if e.Dirty != nil {
+			dirty = "なし"
+			if *e.Dirty {
+				dirty = "あり"
+			}
+		}
+		age := "-"
+		if e.CreatedAt != nil {
+			age = formatDuration(now.Sub(*e.CreatedAt))
+		}
+		windows := make([]string, 0, len(e.Windows))
+		for _, win := range e.Windows {
+			name := fmt.Sprintf("%s:%s", win.Session, win.Window)
+			if len(win.Panes) > 0 {
+				name += "(" + strings.Join(win.Panes, ",") + ")"
+			}
+			windows = append(windows, name)
+		}
+		if len(windows) == 0 {
+			windows = append(windows, "-")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", issue, e.Branch, dirty, formatBytes(e.DiskUsage), age, strings.Join(windows, " "), e.Path)
+	}
+	return w.Flush()
+}
+
+// inspectWorktree はworktreeの変更の有無・ディスク使用量・作成日時と、Issueのウィンドウ・ペインを調べる
+// 調べられない項目は空のまま返す
+func inspectWorktree(ctx context.Context, wt git.WorktreeInfo, sessions []string) worktreeEntry {
+	entry := worktreeEntry{
+		IssueNumber: worktreeIssueNumber(wt),
+		Path:        wt.Path,
+		Branch:      wt.Branch,
+		Commit:      wt.Commit,
+		Windows:     []worktreeWindow{},
+	}
+	if dirty, err := hasUncommittedChangesFunc(ctx, wt.Path); err == nil {
+		entry.Dirty = &dirty
+	}
+	entry.DiskUsage = diskUsage(wt.Path)
+	// worktreeの.gitファイルは作成時に書き込まれ、以降は更新されない
+	if info, err := os.Stat(filepath.Join(wt.Path, ".git")); err == nil {
+		createdAt := info.ModTime()
+		entry.CreatedAt = &createdAt
+	}
+
+	if entry.IssueNumber <= 0 {
+		return entry
+	}
+	for _, sessionName := range sessions {
+		windows, err := listWindowsForIssueFunc(sessionName, entry.IssueNumber)
+		if err != nil {
+			continue
+		}
+		for _, window := range windows {
+			win := worktreeWindow{Session: sessionName, Window: window.Name, Panes: []string{}}
+			if panes, err := listPanesFunc(sessionName, window.Name); err == nil {
+				for _, pane := range panes {
+					win.Panes = append(win.Panes, pane.Title)
+				}
+			}
+			entry.Windows = append(entry.Windows, win)
+		}
+	}
+	return entry
+}
+
+// worktreeIssueNumber はworktreeのディレクトリ名またはブランチ名からIssue番号を取り出す（取り出せない場合は0）
+func worktreeIssueNumber(wt git.WorktreeInfo) int {
+	for _, name := range []string{filepath.Base(wt.Path), strings.TrimPrefix(wt.Branch, "refs/heads/")} {
+		for _, pattern := range worktreeIssuePatterns {
+			if m := pattern.FindStringSubmatch(name); m != nil {
+				if n, err := strconv.Atoi(m[1]); err == nil {
+					return n
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// diskUsage はディレクトリ配下のファイルサイズの合計を返す（読み取れないファイルは数えない）
+func diskUsage(root string) int64 {
+	var total int64
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
+
+// formatBytes はバイト数を読みやすい単位に変換する
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
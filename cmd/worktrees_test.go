@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunWorktrees(t *testing.T) {
+	origListAll := listAllWorktreesFunc
+	origDirty := hasUncommittedChangesFunc
+	origConfig := loadWorktreesConfigFunc
+	origCheckTmux := checkTmuxInstalledFunc
+	origRepoName := getRepositoryNameFunc
+	origWindows := listWindowsForIssueFunc
+	origPanes := listPanesFunc
+	origNow := worktreesNowFunc
+	defer func() {
+		listAllWorktreesFunc = origListAll
+		hasUncommittedChangesFunc = origDirty
+		loadWorktreesConfigFunc = origConfig
+		checkTmuxInstalledFunc = origCheckTmux
+		getRepositoryNameFunc = origRepoName
+		listWindowsForIssueFunc = origWindows
+		listPanesFunc = origPanes
+		worktreesNowFunc = origNow
+		outputFormat = outputText
+	}()
+
+	base := filepath.Join(t.TempDir(), ".git", "osoba", "worktrees")
+	issue83 := filepath.Join(base, "issue-83")
+	issue90 := filepath.Join(base, "issue-90")
+	for _, dir := range []string{issue83, issue90} {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".git"), []byte("gitdir: x\n"), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(issue83, "main.go"), make([]byte, 2048), 0644))
+	createdAt := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(filepath.Join(issue83, ".git"), createdAt, createdAt))
+	worktreesNowFunc = func() time.Time { return createdAt.Add(3 * time.Hour) }
+
+	listAllWorktreesFunc = func(ctx context.Context) ([]git.WorktreeInfo, error) {
+		return []git.WorktreeInfo{
+			{Path: "/repo", Branch: "main", Commit: "aaa"},
+			{Path: issue90, Branch: "osoba/#90", Commit: "ccc"},
+			{Path: issue83, Branch: "osoba/#83", Commit: "bbb"},
+		}, nil
+	}
+	hasUncommittedChangesFunc = func(ctx context.Context, path string) (bool, error) {
+		if path == issue90 {
+			return false, errors.New("git status failed")
+		}
+		return true, nil
+	}
+	loadWorktreesConfigFunc = func() (*config.Config, error) { return config.NewConfig(), nil }
+	checkTmuxInstalledFunc = func() error { return nil }
+	getRepositoryNameFunc = func() (string, error) { return "repo", nil }
+	listWindowsForIssueFunc = func(sessionName string, issueNumber int) ([]*tmux.WindowInfo, error) {
+		if sessionName == "osoba-repo" && issueNumber == 83 {
+			return []*tmux.WindowInfo{{Name: "issue-83"}}, nil
+		}
+		return nil, nil
+	}
+	listPanesFunc = func(sessionName, windowName string) ([]*tmux.PaneInfo, error) {
+		return []*tmux.PaneInfo{{Index: 0, Title: "Plan"}, {Index: 1, Title: "Implementation"}}, nil
+	}
+
+	t.Run("テキスト形式", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+
+		require.NoError(t, runWorktrees(cmd))
+		output := buf.String()
+		assert.Contains(t, output, "#83")
+		assert.Contains(t, output, "osoba/#83")
+		assert.Contains(t, output, "3時間")
+		assert.Contains(t, output, "osoba-repo:issue-83(Plan,Implementation)")
+		assert.NotContains(t, output, "/repo ", "osobaが作成していないworktreeは表示しない")
+		assert.Less(t, bytes.Index(buf.Bytes(), []byte("#83")), bytes.Index(buf.Bytes(), []byte("#90")), "Issue番号順に表示する")
+	})
+
+	t.Run("JSON形式", func(t *testing.T) {
+		outputFormat = outputJSON
+		defer func() { outputFormat = outputText }()
+		cmd := &cobra.Command{}
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+
+		require.NoError(t, runWorktrees(cmd))
+		var entries []worktreeEntry
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entries))
+		require.Len(t, entries, 2)
+
+		assert.Equal(t, 83, entries[0].IssueNumber)
+		require.NotNil(t, entries[0].Dirty)
+		assert.True(t, *entries[0].Dirty)
+		assert.Equal(t, int64(2048+len("gitdir: x\n")), entries[0].DiskUsage)
+		require.NotNil(t, entries[0].CreatedAt)
+		assert.True(t, createdAt.Equal(*entries[0].CreatedAt))
+		assert.Equal(t, []worktreeWindow{{Session: "osoba-repo", Window: "issue-83", Panes: []string{"Plan", "Implementation"}}}, entries[0].Windows)
+
+		assert.Equal(t, 90, entries[1].IssueNumber)
+		assert.Nil(t, entries[1].Dirty, "確認できない場合はnull")
+		assert.Empty(t, entries[1].Windows)
+	})
+}
+
+func TestWorktreeIssueNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		wt   git.WorktreeInfo
+		want int
+	}{
+		{name: "issue-N形式のディレクトリ", wt: git.WorktreeInfo{Path: "/repo/.git/osoba/worktrees/issue-83"}, want: 83},
+		{name: "旧形式のディレクトリ", wt: git.WorktreeInfo{Path: "/repo/.git/osoba/worktrees/12-implement"}, want: 12},
+		{name: "ブランチ名", wt: git.WorktreeInfo{Path: "/scratch/osoba/worktrees/work", Branch: "refs/heads/osoba/#7"}, want: 7},
+		{name: "取り出せない", wt: git.WorktreeInfo{Path: "/repo/.git/osoba/worktrees/work", Branch: "feature"}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, worktreeIssueNumber(tt.wt))
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512B", formatBytes(512))
+	assert.Equal(t, "2.0KiB", formatBytes(2048))
+	assert.Equal(t, "1.5MiB", formatBytes(1536*1024))
+}
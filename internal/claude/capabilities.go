@@ -0,0 +1,173 @@
+package claude
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/douhashi/osoba/internal/trace"
+)
+
+// MinimumVersion はosobaが動作を確認しているclaude CLIの最小バージョン
+const MinimumVersion = "1.0.0"
+
+var (
+	versionPattern    = regexp.MustCompile(`(\d+)\.(\d+)\.(\d+)`)
+	helpFlagPattern   = regexp.MustCompile(`(?:^|[\s,])(--?[A-Za-z][A-Za-z0-9-]*)`)
+	helpColumnPattern = regexp.MustCompile(`\s{2,}`)
+)
+
+// runClaudeCommand はclaudeコマンドを実行して出力を返す（テスト時に差し替え可能）
+var runClaudeCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+	done := trace.Start("claude", args, "")
+	output, err := exec.CommandContext(ctx, "claude", args...).CombinedOutput()
+	done(err)
+	return output, err
+}
+
+// Capabilities はインストールされているclaude CLIのバージョンと対応フラグ
+type Capabilities struct {
+	// Version はclaude --versionから取得したバージョン（例: 1.0.44）
+	Version string
+	// Flags はclaude --helpに記載されているフラグ
+	Flags map[string]bool
+}
+
+// DetectCapabilities はclaude CLIのバージョンと対応フラグを検出する
+// --helpの解析に失敗した場合はFlagsを空のままにし、すべてのフラグを対応済みとみなす
+func DetectCapabilities(ctx context.Context) (*Capabilities, error) {
+	out, err := runClaudeCommand(ctx, "--version")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claude version: %w", err)
+	}
+	version := versionPattern.FindString(string(out))
+	if version == "" {
+		return nil, fmt.Errorf("failed to parse claude version: %q", strings.TrimSpace(string(out)))
+	}
+
+	caps := &Capabilities{Version: version}
+	if help, err := runClaudeCommand(ctx, "--help"); err == nil {
+		caps.Flags = parseHelpFlags(string(help))
+	}
+	return caps, nil
+}
+
+// parseHelpFlags は--helpの出力からオプション列のフラグを取り出す
+func parseHelpFlags(help string) map[string]bool {
+	flags := make(map[string]bool)
+	for _, line := range strings.Split(help, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "-") {
+			continue
+		}
+		// 説明文中のフラグを拾わないよう、オプション列のみを対象とする
+		column := helpColumnPattern.Split(trimmed, 2)[0]
+		for _, m := range helpFlagPattern.FindAllStringSubmatch(column, -1) {
+			flags[m[1]] = true
+		}
+	}
+	if len(flags) == 0 {
+		return nil
+	}
+	return flags
+}
+
+// Supports は指定されたフラグに対応しているかを返す
+// 対応フラグが不明な場合は対応しているとみなす
+func (c *Capabilities) Supports(flag string) bool {
+	if c == nil || len(c.Flags) == 0 {
+		return true
+	}
+	name, _, _ := strings.Cut(flag, "=")
+	return c.Flags[name]
+}
+
+// IsOutdated はMinimumVersionより古いバージョンかを返す
+func (c *Capabilities) IsOutdated() bool {
+	if c == nil {
+		return false
+	}
+	return compareVersions(c.Version, MinimumVersion) < 0
+}
+
+// UnsupportedArgs は引数のうち対応していないフラグを返す
+func (c *Capabilities) UnsupportedArgs(args []string) []string {
+	var unsupported []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") && !c.Supports(arg) {
+			unsupported = append(unsupported, arg)
+		}
+	}
+	return unsupported
+}
+
+// AdaptArgs は対応していないフラグを取り除いた引数と、取り除いたフラグを返す
+// 対応していないフラグの直後にある値（-で始まらない引数）も合わせて取り除く
+func (c *Capabilities) AdaptArgs(args []string) (adapted, dropped []string) {
+	adapted = make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || c.Supports(arg) {
+			adapted = append(adapted, arg)
+			continue
+		}
+		dropped = append(dropped, arg)
+		if !strings.Contains(arg, "=") && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			i++
+		}
+	}
+	return adapted, dropped
+}
+
+// Warnings はclaude設定とCLIの非互換をユーザー向けの警告として返す
+func (c *Capabilities) Warnings(config *ClaudeConfig) []string {
+	if c == nil {
+		return nil
+	}
+	var warnings []string
+	if c.IsOutdated() {
+		warnings = append(warnings, fmt.Sprintf("claude CLI %s は古いバージョンです（%s 以上を推奨）", c.Version, MinimumVersion))
+	}
+	if config == nil {
+		return warnings
+	}
+
+	phases := make([]string, 0, len(config.Phases))
+	for phase := range config.Phases {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+	for _, phase := range phases {
+		if config.Phases[phase] == nil {
+			continue
+		}
+		if unsupported := c.UnsupportedArgs(config.Phases[phase].CommandArgs()); len(unsupported) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s フェーズの引数 %s は claude CLI %s で利用できないため除外します",
+				phase, strings.Join(unsupported, ", "), c.Version))
+		}
+	}
+	return warnings
+}
+
+// compareVersions はx.y.z形式のバージョンを比較する
+func compareVersions(a, b string) int {
+	pa, pb := versionPattern.FindStringSubmatch(a), versionPattern.FindStringSubmatch(b)
+	if pa == nil || pb == nil {
+		return 0
+	}
+	for i := 1; i <= 3; i++ {
+		na, _ := strconv.Atoi(pa[i])
+		nb, _ := strconv.Atoi(pb[i])
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,123 @@
+package claude
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleHelp = `Usage: claude [options] [command] [prompt]
+
+Options:
+  -d, --debug                     Enable debug mode
+  -p, --print                     Print response and exit (useful for pipes)
+  --model <model>                 Model for the current session
+  --dangerously-skip-permissions  Bypass all permission checks
+  -r, --resume [sessionId]        Resume a conversation (see also --continue)
+  -h, --help                      Display help for command
+`
+
+func stubClaudeCommand(t *testing.T, outputs map[string]string, errs map[string]error) {
+	t.Helper()
+	orig := runClaudeCommand
+	t.Cleanup(func() { runClaudeCommand = orig })
+	runClaudeCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		return []byte(outputs[args[0]]), errs[args[0]]
+	}
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	t.Run("バージョンとフラグを検出", func(t *testing.T) {
+		stubClaudeCommand(t, map[string]string{
+			"--version": "1.0.44 (Claude Code)\n",
+			"--help":    sampleHelp,
+		}, nil)
+
+		caps, err := DetectCapabilities(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.44", caps.Version)
+		assert.True(t, caps.Supports("--dangerously-skip-permissions"))
+		assert.True(t, caps.Supports("--resume"))
+		assert.True(t, caps.Supports("-p"))
+		assert.True(t, caps.Supports("--model=sonnet"))
+		assert.False(t, caps.Supports("--continue"), "説明文中のフラグは対象外")
+	})
+
+	t.Run("--helpに失敗した場合はすべて対応とみなす", func(t *testing.T) {
+		stubClaudeCommand(t, map[string]string{"--version": "2.1.0"}, map[string]error{"--help": errors.New("exit status 1")})
+
+		caps, err := DetectCapabilities(context.Background())
+		require.NoError(t, err)
+		assert.True(t, caps.Supports("--anything"))
+	})
+
+	t.Run("バージョンを解析できない", func(t *testing.T) {
+		stubClaudeCommand(t, map[string]string{"--version": "unknown"}, nil)
+
+		_, err := DetectCapabilities(context.Background())
+		assert.ErrorContains(t, err, "failed to parse claude version")
+	})
+}
+
+func TestCapabilities_AdaptArgs(t *testing.T) {
+	caps := &Capabilities{Version: "1.0.44", Flags: parseHelpFlags(sampleHelp)}
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantAdapted []string
+		wantDropped []string
+	}{
+		{
+			name:        "すべて対応",
+			args:        []string{"--dangerously-skip-permissions", "--model", "sonnet"},
+			wantAdapted: []string{"--dangerously-skip-permissions", "--model", "sonnet"},
+		},
+		{
+			name:        "未対応フラグとその値を除外",
+			args:        []string{"--permission-mode", "plan", "--dangerously-skip-permissions"},
+			wantAdapted: []string{"--dangerously-skip-permissions"},
+			wantDropped: []string{"--permission-mode"},
+		},
+		{
+			name:        "=形式の未対応フラグ",
+			args:        []string{"--max-turns=3", "--print"},
+			wantAdapted: []string{"--print"},
+			wantDropped: []string{"--max-turns=3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapted, dropped := caps.AdaptArgs(tt.args)
+			assert.Equal(t, tt.wantAdapted, adapted)
+			assert.Equal(t, tt.wantDropped, dropped)
+		})
+	}
+}
+
+func TestCapabilities_Warnings(t *testing.T) {
+	config := NewDefaultClaudeConfig()
+	config.Phases["plan"].Args = append(config.Phases["plan"].Args, "--permission-mode", "plan")
+
+	caps := &Capabilities{Version: "0.2.9", Flags: parseHelpFlags(sampleHelp)}
+	warnings := caps.Warnings(config)
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "古いバージョン")
+	assert.Contains(t, warnings[1], "plan フェーズの引数 --permission-mode")
+
+	var unknown *Capabilities
+	assert.Empty(t, unknown.Warnings(config))
+}
+
+func TestDefaultClaudeExecutor_AdaptArgs(t *testing.T) {
+	log := newTestLogger()
+	executor := NewClaudeExecutorWithCapabilities(log, &Capabilities{Version: "1.0.44", Flags: parseHelpFlags(sampleHelp)}).(*DefaultClaudeExecutor)
+
+	assert.Equal(t, []string{"--print"}, executor.adaptArgs([]string{"--verbose", "--print"}))
+	require.Len(t, log.warnCalls, 1)
+	assert.Equal(t, "Dropping claude arguments unsupported by installed CLI", log.warnCalls[0].Msg)
+}
@@ -1,19 +1,53 @@
 package claude
 
+import "time"
+
 // PhaseConfig はフェーズごとのClaude実行設定
 type PhaseConfig struct {
 	Args   []string `mapstructure:"args"`
 	Prompt string   `mapstructure:"prompt"`
+	// Permissions は許可する操作の設定（未設定の場合はArgsをそのまま使用）
+	Permissions *PermissionConfig `mapstructure:"permissions"`
+	// Variants はIssueのラベルで切り替えるプロンプト（上から順に判定し、一致しない場合はPromptを使用）
+	Variants []PromptVariant `mapstructure:"variants"`
+	// AfterExit はClaudeの終了後に同じペインで実行するシェルコマンド（osobaがフェーズごとに設定する）
+	AfterExit string `mapstructure:"-"`
+}
+
+// PromptVariant はIssueのラベルで選択するフェーズのプロンプト
+type PromptVariant struct {
+	Labels []string `mapstructure:"labels"` // いずれかのラベルを持つIssueでこのプロンプトを使用する
+	Prompt string   `mapstructure:"prompt"`
+}
+
+// ResolvePrompt はIssueのラベルに一致する最初のバリアントのプロンプトを返す（一致しない場合はPrompt）
+func (p *PhaseConfig) ResolvePrompt(labels []string) string {
+	for _, variant := range p.Variants {
+		for _, want := range variant.Labels {
+			for _, label := range labels {
+				if label == want {
+					return variant.Prompt
+				}
+			}
+		}
+	}
+	return p.Prompt
 }
 
 // ClaudeConfig はClaude実行の全体設定
 type ClaudeConfig struct {
 	Phases map[string]*PhaseConfig `mapstructure:"phases"`
+	// EnvironmentBootstrap はworktreeの.envrc・devbox.json・flake.nixを検出して開発環境を有効化するか
+	EnvironmentBootstrap bool `mapstructure:"environment_bootstrap"`
+	// ReadyTimeout はコマンドを送信する前にペインのシェルの準備完了を待つ時間（0の場合は待たない）
+	ReadyTimeout time.Duration `mapstructure:"ready_timeout"`
 }
 
 // NewDefaultClaudeConfig はデフォルトのClaude設定を生成する
 func NewDefaultClaudeConfig() *ClaudeConfig {
 	return &ClaudeConfig{
+		EnvironmentBootstrap: false,
+		ReadyTimeout:         DefaultReadyTimeout,
 		Phases: map[string]*PhaseConfig{
 			"plan": {
 				Args:   []string{"--dangerously-skip-permissions"},
@@ -31,6 +65,15 @@ func NewDefaultClaudeConfig() *ClaudeConfig {
 				Args:   []string{"--dangerously-skip-permissions"},
 				Prompt: "/osoba:revise {{issue-number}}",
 			},
+			// PRモード（pr_mode）でIssueのないPRをレビュー・修正する（{{issue-number}}はPR番号）
+			"pr_review": {
+				Args:   []string{"--dangerously-skip-permissions"},
+				Prompt: "/osoba:review-pr {{issue-number}}",
+			},
+			"pr_revise": {
+				Args:   []string{"--dangerously-skip-permissions"},
+				Prompt: "/osoba:revise-pr {{issue-number}}",
+			},
 		},
 	}
 }
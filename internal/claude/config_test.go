@@ -18,6 +18,33 @@ func TestPhaseConfig(t *testing.T) {
 	})
 }
 
+func TestPhaseConfig_ResolvePrompt(t *testing.T) {
+	config := &PhaseConfig{
+		Prompt: "/osoba:implement {{issue-number}}",
+		Variants: []PromptVariant{
+			{Labels: []string{"type:bug", "bug"}, Prompt: "/osoba:implement-bugfix {{issue-number}}"},
+			{Labels: []string{"type:feature"}, Prompt: "/osoba:implement-feature {{issue-number}}"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		labels []string
+		want   string
+	}{
+		{name: "一致するラベルがない場合は既定のプロンプト", labels: []string{"status:ready"}, want: "/osoba:implement {{issue-number}}"},
+		{name: "いずれかのラベルに一致", labels: []string{"status:ready", "bug"}, want: "/osoba:implement-bugfix {{issue-number}}"},
+		{name: "type:feature", labels: []string{"type:feature"}, want: "/osoba:implement-feature {{issue-number}}"},
+		{name: "複数に一致する場合は先に定義したバリアント", labels: []string{"type:feature", "type:bug"}, want: "/osoba:implement-bugfix {{issue-number}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, config.ResolvePrompt(tt.labels))
+		})
+	}
+}
+
 func TestClaudeConfig(t *testing.T) {
 	t.Run("ClaudeConfigの基本的な構造", func(t *testing.T) {
 		config := &ClaudeConfig{
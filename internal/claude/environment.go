@@ -0,0 +1,89 @@
+package claude
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// 環境の有効化に使用するツール
+const (
+	EnvironmentDirenv = "direnv"
+	EnvironmentDevbox = "devbox"
+	EnvironmentNix    = "nix"
+)
+
+// environmentMarkers はリポジトリに含まれるファイルと、それを有効化するツール（優先順）
+// .envrcはdevbox・flakeを読み込むことが多いため、direnvを最優先にする
+var environmentMarkers = []struct {
+	file string
+	tool string
+}{
+	{".envrc", EnvironmentDirenv},
+	{"devbox.json", EnvironmentDevbox},
+	{"flake.nix", EnvironmentNix},
+}
+
+// lookPath はコマンドを探す（テスト時に差し替え可能）
+var lookPath = exec.LookPath
+
+// EnvironmentBootstrap はworktreeの開発環境（ツールチェイン）を有効化してからclaudeを実行する方法
+type EnvironmentBootstrap struct {
+	Tool string // EnvironmentDirenv・EnvironmentDevbox・EnvironmentNix
+	File string // 検出したファイル名
+}
+
+// DetectEnvironmentBootstrap はworkdirに.envrc・devbox.json・flake.nixがあり、対応するツールがインストールされている場合に
+// 環境の有効化方法を返す（該当しない場合はnil）
+// skippedにはファイルはあるがツールがインストールされていないものを返す
+func DetectEnvironmentBootstrap(workdir string) (bootstrap *EnvironmentBootstrap, skipped []string) {
+	if workdir == "" {
+		return nil, nil
+	}
+	for _, marker := range environmentMarkers {
+		if _, err := os.Stat(filepath.Join(workdir, marker.file)); err != nil {
+			continue
+		}
+		if _, err := lookPath(marker.tool); err != nil {
+			skipped = append(skipped, marker.file)
+			continue
+		}
+		return &EnvironmentBootstrap{Tool: marker.tool, File: marker.file}, skipped
+	}
+	return nil, skipped
+}
+
+// ShellPrefix はworkdirに移動したシェルでclaudeコマンドの前に付ける有効化コマンドを返す
+func (b *EnvironmentBootstrap) ShellPrefix() string {
+	switch b.Tool {
+	case EnvironmentDirenv:
+		return "direnv allow . && direnv exec . "
+	case EnvironmentDevbox:
+		return `eval "$(devbox shellenv)" && `
+	case EnvironmentNix:
+		return "nix develop --command "
+	}
+	return ""
+}
+
+// WrapArgs はworkdirでcommandを実行する引数を、環境を有効化して実行する引数に変換する
+// direnvの場合は事前に AllowArgs を実行しておく必要がある
+func (b *EnvironmentBootstrap) WrapArgs(workdir string, command []string) []string {
+	switch b.Tool {
+	case EnvironmentDirenv:
+		return append([]string{"direnv", "exec", workdir}, command...)
+	case EnvironmentDevbox:
+		return append([]string{"devbox", "run", "--config", workdir, "--"}, command...)
+	case EnvironmentNix:
+		return append([]string{"nix", "develop", workdir, "--command"}, command...)
+	}
+	return command
+}
+
+// AllowArgs は環境の有効化前に実行が必要なコマンドの引数を返す（不要な場合はnil）
+func (b *EnvironmentBootstrap) AllowArgs(workdir string) []string {
+	if b.Tool == EnvironmentDirenv {
+		return []string{"direnv", "allow", workdir}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package claude
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectEnvironmentBootstrap(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       []string
+		installed   []string
+		wantTool    string
+		wantSkipped []string
+	}{
+		{
+			name:      "ファイルがない場合は有効化しない",
+			installed: []string{"direnv", "devbox", "nix"},
+		},
+		{
+			name:      ".envrcがある場合はdirenvを使用",
+			files:     []string{".envrc", "devbox.json"},
+			installed: []string{"direnv", "devbox"},
+			wantTool:  EnvironmentDirenv,
+		},
+		{
+			name:      "devbox.jsonのみの場合はdevboxを使用",
+			files:     []string{"devbox.json"},
+			installed: []string{"devbox"},
+			wantTool:  EnvironmentDevbox,
+		},
+		{
+			name:      "flake.nixのみの場合はnixを使用",
+			files:     []string{"flake.nix"},
+			installed: []string{"nix"},
+			wantTool:  EnvironmentNix,
+		},
+		{
+			name:        "direnvがない場合は次の候補を使用",
+			files:       []string{".envrc", "flake.nix"},
+			installed:   []string{"nix"},
+			wantTool:    EnvironmentNix,
+			wantSkipped: []string{".envrc"},
+		},
+		{
+			name:        "ツールがインストールされていない場合は有効化しない",
+			files:       []string{"devbox.json"},
+			wantSkipped: []string{"devbox.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workdir := t.TempDir()
+			for _, file := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(workdir, file), []byte(""), 0644))
+			}
+			origLookPath := lookPath
+			defer func() { lookPath = origLookPath }()
+			lookPath = func(file string) (string, error) {
+				for _, tool := range tt.installed {
+					if tool == file {
+						return "/usr/bin/" + file, nil
+					}
+				}
+				return "", errors.New("not found")
+			}
+
+			bootstrap, skipped := DetectEnvironmentBootstrap(workdir)
+			if tt.wantTool == "" {
+				assert.Nil(t, bootstrap)
+			} else {
+				require.NotNil(t, bootstrap)
+				assert.Equal(t, tt.wantTool, bootstrap.Tool)
+			}
+			assert.Equal(t, tt.wantSkipped, skipped)
+		})
+	}
+}
+
+func TestEnvironmentBootstrap_Commands(t *testing.T) {
+	command := []string{"claude", "--dangerously-skip-permissions", "/osoba:plan 1"}
+
+	direnv := &EnvironmentBootstrap{Tool: EnvironmentDirenv, File: ".envrc"}
+	assert.Equal(t, "direnv allow . && direnv exec . ", direnv.ShellPrefix())
+	assert.Equal(t, []string{"direnv", "allow", "/wt"}, direnv.AllowArgs("/wt"))
+	assert.Equal(t, append([]string{"direnv", "exec", "/wt"}, command...), direnv.WrapArgs("/wt", command))
+
+	devbox := &EnvironmentBootstrap{Tool: EnvironmentDevbox, File: "devbox.json"}
+	assert.Equal(t, `eval "$(devbox shellenv)" && `, devbox.ShellPrefix())
+	assert.Nil(t, devbox.AllowArgs("/wt"))
+	assert.Equal(t, append([]string{"devbox", "run", "--config", "/wt", "--"}, command...), devbox.WrapArgs("/wt", command))
+
+	nix := &EnvironmentBootstrap{Tool: EnvironmentNix, File: "flake.nix"}
+	assert.Equal(t, "nix develop --command ", nix.ShellPrefix())
+	assert.Equal(t, append([]string{"nix", "develop", "/wt", "--command"}, command...), nix.WrapArgs("/wt", command))
+}
+
+func TestDefaultClaudeExecutor_DetectEnvironmentBootstrap(t *testing.T) {
+	workdir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workdir, ".envrc"), []byte("use flake"), 0644))
+	origLookPath := lookPath
+	defer func() { lookPath = origLookPath }()
+	lookPath = func(file string) (string, error) { return "/usr/bin/" + file, nil }
+
+	t.Run("デフォルトでは検出しない", func(t *testing.T) {
+		e := NewClaudeExecutorWithCapabilities(&testLogger{}, nil).(*DefaultClaudeExecutor)
+		assert.Nil(t, e.detectEnvironmentBootstrap(workdir))
+	})
+
+	t.Run("有効にした場合は検出する", func(t *testing.T) {
+		e := NewClaudeExecutorWithCapabilities(&testLogger{}, nil, WithEnvironmentBootstrap(true)).(*DefaultClaudeExecutor)
+		bootstrap := e.detectEnvironmentBootstrap(workdir)
+		require.NotNil(t, bootstrap)
+		assert.Equal(t, EnvironmentDirenv, bootstrap.Tool)
+	})
+}
@@ -6,10 +6,16 @@ import (
 	"log"
 	"os/exec"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/trace"
 )
 
+// safeShellArgPattern はクォートせずにシェルに渡せる引数
+var safeShellArgPattern = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
 // ClaudeExecutor はClaude実行を管理するインターフェース
 type ClaudeExecutor interface {
 	CheckClaudeExists() error
@@ -20,7 +26,29 @@ type ClaudeExecutor interface {
 
 // DefaultClaudeExecutor はClaudeExecutorのデフォルト実装
 type DefaultClaudeExecutor struct {
-	logger logger.Logger
+	logger       logger.Logger
+	capabilities *Capabilities
+	// environmentBootstrap はworktreeの開発環境（direnv・devbox・nix）を有効化する場合にtrue
+	environmentBootstrap bool
+	// readyTimeout はコマンドを送信する前にペインのシェルの準備完了を待つ時間（0の場合は待たない）
+	readyTimeout time.Duration
+}
+
+// ExecutorOption はDefaultClaudeExecutorのオプション
+type ExecutorOption func(*DefaultClaudeExecutor)
+
+// WithEnvironmentBootstrap はworktreeの.envrc・devbox.json・flake.nixを検出して開発環境を有効化するかを設定する（デフォルト: 無効）
+func WithEnvironmentBootstrap(enabled bool) ExecutorOption {
+	return func(e *DefaultClaudeExecutor) {
+		e.environmentBootstrap = enabled
+	}
+}
+
+// WithReadinessProbe はコマンドを送信する前にペインのシェルの準備完了を待つ時間を設定する（0の場合は待たない）
+func WithReadinessProbe(timeout time.Duration) ExecutorOption {
+	return func(e *DefaultClaudeExecutor) {
+		e.readyTimeout = timeout
+	}
 }
 
 // NewClaudeExecutor は新しいClaudeExecutorを作成する
@@ -38,6 +66,21 @@ func NewClaudeExecutorWithLogger(logger logger.Logger) ClaudeExecutor {
 	}
 }
 
+// NewClaudeExecutorWithCapabilities は検出済みのclaude CLIの機能に合わせて引数を調整するClaudeExecutorを作成する
+func NewClaudeExecutorWithCapabilities(logger logger.Logger, capabilities *Capabilities, opts ...ExecutorOption) ClaudeExecutor {
+	if logger == nil {
+		return nil
+	}
+	e := &DefaultClaudeExecutor{
+		logger:       logger,
+		capabilities: capabilities,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
 // CheckClaudeExists はclaudeコマンドが存在するかチェックする
 func (e *DefaultClaudeExecutor) CheckClaudeExists() error {
 	_, err := exec.LookPath("claude")
@@ -69,10 +112,27 @@ func (e *DefaultClaudeExecutor) ExecuteInWorktree(ctx context.Context, config *P
 	}
 
 	// プロンプトを展開
-	prompt := ExpandTemplate(config.Prompt, vars)
+	prompt, err := RenderPrompt(config.ResolvePrompt(vars.Labels), vars, workdir)
+	if err != nil {
+		return err
+	}
 
 	// コマンドを構築
-	cmd := e.BuildCommand(ctx, config.Args, prompt, workdir)
+	args := e.adaptArgs(config.CommandArgs())
+	cmd := e.BuildCommand(ctx, args, prompt, workdir)
+	if bootstrap := e.detectEnvironmentBootstrap(workdir); bootstrap != nil {
+		if allowArgs := bootstrap.AllowArgs(workdir); allowArgs != nil {
+			done := trace.Start(allowArgs[0], allowArgs[1:], "")
+			output, err := exec.CommandContext(ctx, allowArgs[0], allowArgs[1:]...).CombinedOutput()
+			done(err)
+			if err != nil {
+				return fmt.Errorf("failed to allow %s: %w: %s", bootstrap.File, err, strings.TrimSpace(string(output)))
+			}
+		}
+		wrapped := bootstrap.WrapArgs(workdir, cmd.Args)
+		cmd = exec.CommandContext(ctx, wrapped[0], wrapped[1:]...)
+		cmd.Dir = workdir
+	}
 
 	if e.logger != nil {
 		e.logger.Info("Executing Claude in worktree",
@@ -80,17 +140,20 @@ func (e *DefaultClaudeExecutor) ExecuteInWorktree(ctx context.Context, config *P
 			"issueNumber", vars.IssueNumber,
 		)
 		e.logger.Debug("Claude command details",
-			"args", config.Args,
+			"args", args,
 			"prompt", e.maskSensitiveData(prompt),
 		)
 	} else {
 		// 互換性のためのフォールバック
 		log.Printf("Executing Claude in worktree: %s", workdir)
-		log.Printf("Command: claude %v %s", config.Args, prompt)
+		log.Printf("Command: claude %v %s", args, prompt)
 	}
 
 	// コマンドを実行
-	if err := cmd.Run(); err != nil {
+	done := trace.Start(cmd.Args[0], cmd.Args[1:], cmd.Dir)
+	err = cmd.Run()
+	done(err)
+	if err != nil {
 		if e.logger != nil {
 			e.logger.Error("Failed to execute Claude",
 				"error", err,
@@ -120,17 +183,29 @@ func (e *DefaultClaudeExecutor) ExecuteInTmux(ctx context.Context, config *Phase
 	}
 
 	// プロンプトを展開
-	prompt := ExpandTemplate(config.Prompt, vars)
+	prompt, err := RenderPrompt(config.ResolvePrompt(vars.Labels), vars, workdir)
+	if err != nil {
+		return err
+	}
 
 	// tmuxコマンドを構築
 	// send-keysを使ってコマンドを送信
-	claudeCmd := fmt.Sprintf("cd %s && claude", workdir)
-	for _, arg := range config.Args {
-		claudeCmd += fmt.Sprintf(" %s", arg)
+	args := e.adaptArgs(config.CommandArgs())
+	claudeCmd := fmt.Sprintf("cd %s && ", workdir)
+	if bootstrap := e.detectEnvironmentBootstrap(workdir); bootstrap != nil {
+		claudeCmd += bootstrap.ShellPrefix()
+	}
+	claudeCmd += "claude"
+	for _, arg := range args {
+		claudeCmd += " " + shellQuoteArg(arg)
+	}
+	// テンプレートで展開した値に含まれる ' でコマンドが壊れないようにエスケープする
+	claudeCmd += " '" + strings.ReplaceAll(prompt, "'", `'\''`) + "'"
+	if config.AfterExit != "" {
+		claudeCmd += "; " + config.AfterExit
 	}
-	claudeCmd += fmt.Sprintf(" '%s'", prompt)
 
-	tmuxCmd := exec.CommandContext(ctx, "tmux", "send-keys", "-t", fmt.Sprintf("%s:%s", sessionName, windowName), claudeCmd, "Enter")
+	target := fmt.Sprintf("%s:%s", sessionName, windowName)
 
 	if e.logger != nil {
 		e.logger.Info("Executing Claude in tmux window",
@@ -141,7 +216,7 @@ func (e *DefaultClaudeExecutor) ExecuteInTmux(ctx context.Context, config *Phase
 		)
 		e.logger.Debug("Claude command details",
 			"command", e.maskSensitiveData(claudeCmd),
-			"args", config.Args,
+			"args", args,
 		)
 	} else {
 		// 互換性のためのフォールバック
@@ -149,8 +224,21 @@ func (e *DefaultClaudeExecutor) ExecuteInTmux(ctx context.Context, config *Phase
 		log.Printf("Command: %s", claudeCmd)
 	}
 
+	// 作成直後のペインはシェルの初期化が終わるまでに送信した入力を失うことがあるため、準備完了を待ってから送信する
+	// 確認できなかった場合も、従来どおりコマンドを送信する
+	if e.readyTimeout > 0 {
+		if err := waitForShellReady(ctx, target, e.readyTimeout); err != nil && e.logger != nil {
+			e.logger.Warn("Shell in tmux pane is not ready, sending command anyway",
+				"session", sessionName,
+				"window", windowName,
+				"issueNumber", vars.IssueNumber,
+				"error", err,
+			)
+		}
+	}
+
 	// tmuxコマンドを実行
-	if err := tmuxCmd.Run(); err != nil {
+	if _, err := runTmuxCommand(ctx, "send-keys", "-t", target, claudeCmd, "Enter"); err != nil {
 		if e.logger != nil {
 			e.logger.Error("Failed to execute Claude in tmux",
 				"error", err,
@@ -174,6 +262,53 @@ func (e *DefaultClaudeExecutor) ExecuteInTmux(ctx context.Context, config *Phase
 	return nil
 }
 
+// detectEnvironmentBootstrap はworktreeの開発環境の有効化方法を検出する（無効な場合や該当しない場合はnil）
+func (e *DefaultClaudeExecutor) detectEnvironmentBootstrap(workdir string) *EnvironmentBootstrap {
+	if !e.environmentBootstrap {
+		return nil
+	}
+	bootstrap, skipped := DetectEnvironmentBootstrap(workdir)
+	if e.logger != nil {
+		if len(skipped) > 0 {
+			e.logger.Warn("Environment files found but their tools are not installed",
+				"workdir", workdir,
+				"files", skipped,
+			)
+		}
+		if bootstrap != nil {
+			e.logger.Info("Activating worktree environment",
+				"workdir", workdir,
+				"tool", bootstrap.Tool,
+				"file", bootstrap.File,
+			)
+		}
+	}
+	return bootstrap
+}
+
+// shellQuoteArg はシェルで解釈される文字（Bash(git diff:*) の括弧や空白など）を含む引数をクォートする
+func shellQuoteArg(arg string) string {
+	if arg != "" && safeShellArgPattern.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// adaptArgs はインストールされているclaude CLIが対応していないフラグを取り除く
+func (e *DefaultClaudeExecutor) adaptArgs(args []string) []string {
+	if e.capabilities == nil {
+		return args
+	}
+	adapted, dropped := e.capabilities.AdaptArgs(args)
+	if len(dropped) > 0 && e.logger != nil {
+		e.logger.Warn("Dropping claude arguments unsupported by installed CLI",
+			"version", e.capabilities.Version,
+			"dropped", dropped,
+		)
+	}
+	return adapted
+}
+
 // maskSensitiveData は機密情報をマスクする
 func (e *DefaultClaudeExecutor) maskSensitiveData(data string) string {
 	// GitHubトークンのマスキング (ghp_, github_pat_, ghs_)
@@ -0,0 +1,124 @@
+package claude
+
+import (
+	"fmt"
+	"strings"
+)
+
+// サンドボックスのプリセット
+const (
+	// SandboxReadOnly はファイルの編集を禁止し、参照系のツールとgh・gitの参照系コマンドのみを許可する
+	SandboxReadOnly = "read-only"
+	// SandboxWorkspaceWrite はworktree内のファイル編集とコマンド実行を確認なしで許可する
+	SandboxWorkspaceWrite = "workspace-write"
+	// SandboxFull はすべての操作を確認なしで許可する（--dangerously-skip-permissions）
+	SandboxFull = "full"
+)
+
+// sandboxPreset はサンドボックスのプリセットが生成する権限フラグ
+type sandboxPreset struct {
+	mode       string
+	allowed    []string
+	disallowed []string
+}
+
+// 参照系のツール
+var readTools = []string{"Read", "Glob", "Grep", "LS", "TodoWrite"}
+
+// ファイルを編集するツール
+var editTools = []string{"Edit", "MultiEdit", "Write", "NotebookEdit"}
+
+var sandboxPresets = map[string]sandboxPreset{
+	"": {},
+	SandboxReadOnly: {
+		mode: "default",
+		// ghはPR・Issueの参照のみを許可する（コメントの投稿やラベルの変更はtmuxウィンドウ内で確認を求める）
+		allowed: append(append([]string{}, readTools...),
+			"Bash(git status:*)", "Bash(git diff:*)", "Bash(git log:*)", "Bash(git show:*)",
+			"Bash(gh pr view:*)", "Bash(gh pr diff:*)", "Bash(gh pr checks:*)", "Bash(gh issue view:*)"),
+		disallowed: editTools,
+	},
+	SandboxWorkspaceWrite: {
+		mode:    "acceptEdits",
+		allowed: append(append(append([]string{}, readTools...), editTools...), "Bash"),
+	},
+	SandboxFull: {},
+}
+
+// permissionFlags はPermissionConfigが生成するため、Argsから取り除く権限フラグ
+var permissionFlags = map[string]bool{
+	"--dangerously-skip-permissions": true,
+	"--permission-mode":              true,
+	"--allowedTools":                 true,
+	"--allowed-tools":                true,
+	"--disallowedTools":              true,
+	"--disallowed-tools":             true,
+}
+
+// PermissionConfig はフェーズでclaudeに許可する操作の設定
+// claude CLIの権限フラグ（--permission-mode / --allowedTools / --disallowedTools）に変換する
+type PermissionConfig struct {
+	// Sandbox はプリセット（read-only / workspace-write / full）
+	Sandbox string `mapstructure:"sandbox"`
+	// AllowedTools はプリセットに加えて確認なしで許可するツール（例: "Bash(npm test:*)"）
+	AllowedTools []string `mapstructure:"allowed_tools"`
+	// DisallowedTools はプリセットに加えて使用を禁止するツール
+	DisallowedTools []string `mapstructure:"disallowed_tools"`
+}
+
+// Validate は権限設定の妥当性を検証する
+func (c *PermissionConfig) Validate() error {
+	if _, ok := sandboxPresets[c.Sandbox]; !ok {
+		return fmt.Errorf("invalid sandbox: %q (must be one of %s, %s, %s)", c.Sandbox, SandboxReadOnly, SandboxWorkspaceWrite, SandboxFull)
+	}
+	for _, tool := range append(append([]string{}, c.AllowedTools...), c.DisallowedTools...) {
+		if strings.TrimSpace(tool) == "" {
+			return fmt.Errorf("tool name must not be empty")
+		}
+	}
+	return nil
+}
+
+// Flags は権限設定をclaude CLIのフラグに変換する
+func (c *PermissionConfig) Flags() []string {
+	preset := sandboxPresets[c.Sandbox]
+	disallowed := append(append([]string{}, preset.disallowed...), c.DisallowedTools...)
+
+	var flags []string
+	if c.Sandbox == SandboxFull {
+		flags = append(flags, "--dangerously-skip-permissions")
+	} else {
+		allowed := append(append([]string{}, preset.allowed...), c.AllowedTools...)
+		if preset.mode != "" {
+			flags = append(flags, "--permission-mode", preset.mode)
+		}
+		if len(allowed) > 0 {
+			flags = append(flags, "--allowedTools", strings.Join(allowed, ","))
+		}
+	}
+	if len(disallowed) > 0 {
+		flags = append(flags, "--disallowedTools", strings.Join(disallowed, ","))
+	}
+	return flags
+}
+
+// CommandArgs はclaudeに渡す引数を返す
+// Permissionsが設定されている場合は、Argsの権限フラグを権限設定から生成したフラグに置き換える
+func (p *PhaseConfig) CommandArgs() []string {
+	if p.Permissions == nil {
+		return p.Args
+	}
+	args := make([]string, 0, len(p.Args))
+	for i := 0; i < len(p.Args); i++ {
+		name, _, hasValue := strings.Cut(p.Args[i], "=")
+		if !permissionFlags[name] {
+			args = append(args, p.Args[i])
+			continue
+		}
+		// 値を別の引数で指定している場合は値も取り除く
+		for !hasValue && name != "--dangerously-skip-permissions" && i+1 < len(p.Args) && !strings.HasPrefix(p.Args[i+1], "-") {
+			i++
+		}
+	}
+	return append(args, p.Permissions.Flags()...)
+}
@@ -0,0 +1,82 @@
+package claude
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhaseConfig_CommandArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *PhaseConfig
+		want   []string
+	}{
+		{
+			name:   "権限設定なしの場合はArgsをそのまま使用",
+			config: &PhaseConfig{Args: []string{"--dangerously-skip-permissions"}},
+			want:   []string{"--dangerously-skip-permissions"},
+		},
+		{
+			name: "read-onlyは編集ツールを禁止",
+			config: &PhaseConfig{
+				Args:        []string{"--dangerously-skip-permissions", "--model", "opus"},
+				Permissions: &PermissionConfig{Sandbox: SandboxReadOnly},
+			},
+			want: []string{
+				"--model", "opus",
+				"--permission-mode", "default",
+				"--allowedTools", "Read,Glob,Grep,LS,TodoWrite,Bash(git status:*),Bash(git diff:*),Bash(git log:*),Bash(git show:*),Bash(gh pr view:*),Bash(gh pr diff:*),Bash(gh pr checks:*),Bash(gh issue view:*)",
+				"--disallowedTools", "Edit,MultiEdit,Write,NotebookEdit",
+			},
+		},
+		{
+			name: "workspace-writeに追加のツールを許可",
+			config: &PhaseConfig{
+				Args:        []string{"--permission-mode=plan", "--allowedTools", "Read", "Grep"},
+				Permissions: &PermissionConfig{Sandbox: SandboxWorkspaceWrite, DisallowedTools: []string{"WebFetch"}},
+			},
+			want: []string{
+				"--permission-mode", "acceptEdits",
+				"--allowedTools", "Read,Glob,Grep,LS,TodoWrite,Edit,MultiEdit,Write,NotebookEdit,Bash",
+				"--disallowedTools", "WebFetch",
+			},
+		},
+		{
+			name: "fullは確認なしで許可",
+			config: &PhaseConfig{
+				Permissions: &PermissionConfig{Sandbox: SandboxFull, DisallowedTools: []string{"Bash(git push:*)"}},
+			},
+			want: []string{"--dangerously-skip-permissions", "--disallowedTools", "Bash(git push:*)"},
+		},
+		{
+			name: "プリセットなしでツールのみ指定",
+			config: &PhaseConfig{
+				Args:        []string{"--dangerously-skip-permissions"},
+				Permissions: &PermissionConfig{AllowedTools: []string{"Read", "Bash(npm test:*)"}},
+			},
+			want: []string{"--allowedTools", "Read,Bash(npm test:*)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.CommandArgs())
+		})
+	}
+}
+
+func TestPermissionConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&PermissionConfig{Sandbox: SandboxReadOnly}).Validate())
+	assert.NoError(t, (&PermissionConfig{AllowedTools: []string{"Read"}}).Validate())
+	assert.EqualError(t, (&PermissionConfig{Sandbox: "readonly"}).Validate(),
+		`invalid sandbox: "readonly" (must be one of read-only, workspace-write, full)`)
+	assert.EqualError(t, (&PermissionConfig{DisallowedTools: []string{" "}}).Validate(), "tool name must not be empty")
+}
+
+func TestShellQuoteArg(t *testing.T) {
+	assert.Equal(t, "--permission-mode", shellQuoteArg("--permission-mode"))
+	assert.Equal(t, "'Read,Bash(git diff:*)'", shellQuoteArg("Read,Bash(git diff:*)"))
+	assert.Equal(t, `'it'\''s'`, shellQuoteArg("it's"))
+	assert.Equal(t, "''", shellQuoteArg(""))
+}
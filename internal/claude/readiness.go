@@ -0,0 +1,77 @@
+package claude
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/douhashi/osoba/internal/trace"
+)
+
+// DefaultReadyTimeout はペインのシェルの準備完了を待つ時間のデフォルト値
+const DefaultReadyTimeout = 10 * time.Second
+
+// readySentinelPrefix は準備完了の確認に使う目印の接頭辞
+const readySentinelPrefix = "osoba-ready"
+
+// テスト時に差し替え可能な確認の間隔
+var (
+	readinessPollInterval   = 200 * time.Millisecond // capture-paneで出力を確認する間隔
+	readinessResendInterval = 2 * time.Second        // 目印が出力されない場合に再送する間隔
+)
+
+// runTmuxCommand はtmuxコマンドを実行する（テスト時に差し替え可能）
+var runTmuxCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+	done := trace.Start("tmux", args, "")
+	output, err := exec.CommandContext(ctx, "tmux", args...).CombinedOutput()
+	done(err)
+	return output, err
+}
+
+// waitForShellReady はペインのシェルがコマンドを受け付けられる状態になるまで待つ
+// 目印をechoするコマンドを送信し、その出力をcapture-paneで確認できたら準備完了とみなす
+// 初期化中のシェルは入力を破棄することがあるため、出力を確認できない間は一定間隔で再送する
+func waitForShellReady(ctx context.Context, target string, timeout time.Duration) error {
+	token := strconv.FormatInt(time.Now().UnixNano(), 36)
+	sentinel := []byte(readySentinelPrefix + "-" + token)
+	// 入力した行（echo "osoba-ready"-<token>）と出力（osoba-ready-<token>）を区別できるように一部をクォートする
+	probe := fmt.Sprintf(`echo "%s"-%s`, readySentinelPrefix, token)
+
+	deadline := time.Now().Add(timeout)
+	var nextSend time.Time
+	for {
+		now := time.Now()
+		if !now.Before(nextSend) {
+			if output, err := runTmuxCommand(ctx, "send-keys", "-t", target, probe, "Enter"); err != nil {
+				return fmt.Errorf("failed to send readiness probe: %w: %s", err, bytes.TrimSpace(output))
+			}
+			nextSend = now.Add(readinessResendInterval)
+		}
+
+		if output, err := runTmuxCommand(ctx, "capture-pane", "-p", "-J", "-t", target); err == nil && containsLine(output, sentinel) {
+			return nil
+		}
+		if !now.Before(deadline) {
+			return fmt.Errorf("shell in tmux pane %s did not become ready within %s", target, timeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(readinessPollInterval):
+		}
+	}
+}
+
+// containsLine は出力に前後の空白を除いてlineと一致する行があるかを返す
+func containsLine(output, line []byte) bool {
+	for _, l := range bytes.Split(output, []byte("\n")) {
+		if bytes.Equal(bytes.TrimSpace(l), line) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package claude
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePane はsend-keysとcapture-paneを記録・応答するテスト用のペイン
+type fakePane struct {
+	mu        sync.Mutex
+	probes    int
+	dropFirst int // 最初のn回の入力をシェルの初期化中として破棄する
+	sendErr   error
+	screen    []string
+	sent      []string
+}
+
+func (p *fakePane) run(ctx context.Context, args ...string) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch args[0] {
+	case "send-keys":
+		if p.sendErr != nil {
+			return []byte("no server running"), p.sendErr
+		}
+		keys := args[3]
+		p.sent = append(p.sent, keys)
+		if strings.HasPrefix(keys, "echo ") {
+			p.probes++
+			if p.probes <= p.dropFirst {
+				return nil, nil
+			}
+			p.screen = append(p.screen, "$ "+keys, strings.ReplaceAll(strings.TrimPrefix(keys, "echo "), `"`, ""))
+		}
+		return nil, nil
+	case "capture-pane":
+		return []byte(strings.Join(p.screen, "\n") + "\n"), nil
+	}
+	return nil, errors.New("unexpected tmux command")
+}
+
+func setFakePane(t *testing.T, pane *fakePane) {
+	t.Helper()
+	origRun, origPoll, origResend := runTmuxCommand, readinessPollInterval, readinessResendInterval
+	t.Cleanup(func() {
+		runTmuxCommand, readinessPollInterval, readinessResendInterval = origRun, origPoll, origResend
+	})
+	runTmuxCommand = pane.run
+	readinessPollInterval = time.Millisecond
+	readinessResendInterval = 5 * time.Millisecond
+}
+
+func TestWaitForShellReady(t *testing.T) {
+	t.Run("目印の出力を確認できたら準備完了", func(t *testing.T) {
+		pane := &fakePane{}
+		setFakePane(t, pane)
+
+		require.NoError(t, waitForShellReady(context.Background(), "osoba:issue-1", time.Second))
+		assert.Equal(t, 1, pane.probes)
+	})
+
+	t.Run("初期化中に失われた場合は再送する", func(t *testing.T) {
+		pane := &fakePane{dropFirst: 2}
+		setFakePane(t, pane)
+
+		require.NoError(t, waitForShellReady(context.Background(), "osoba:issue-1", time.Second))
+		assert.Equal(t, 3, pane.probes)
+	})
+
+	t.Run("入力した行だけでは準備完了とみなさない", func(t *testing.T) {
+		pane := &fakePane{dropFirst: 1000}
+		setFakePane(t, pane)
+		pane.screen = []string{`$ echo "osoba-ready"-abc`}
+
+		err := waitForShellReady(context.Background(), "osoba:issue-1", 20*time.Millisecond)
+		assert.EqualError(t, err, "shell in tmux pane osoba:issue-1 did not become ready within 20ms")
+	})
+
+	t.Run("送信に失敗した場合はエラー", func(t *testing.T) {
+		pane := &fakePane{sendErr: errors.New("exit status 1")}
+		setFakePane(t, pane)
+
+		err := waitForShellReady(context.Background(), "osoba:issue-1", time.Second)
+		assert.EqualError(t, err, "failed to send readiness probe: exit status 1: no server running")
+	})
+}
+
+func TestExecuteInTmux_WaitsForShellBeforeSending(t *testing.T) {
+	if err := (&DefaultClaudeExecutor{}).CheckClaudeExists(); err != nil {
+		t.Skip("claude command is not installed")
+	}
+	pane := &fakePane{dropFirst: 1}
+	setFakePane(t, pane)
+
+	executor := NewClaudeExecutorWithCapabilities(newTestLogger(), nil, WithEnvironmentBootstrap(false), WithReadinessProbe(time.Second))
+	err := executor.ExecuteInTmux(context.Background(), &PhaseConfig{Prompt: "/osoba:plan {{issue-number}}"},
+		&TemplateVariables{IssueNumber: 1}, "osoba", "issue-1", t.TempDir())
+	require.NoError(t, err)
+
+	// 目印の出力を確認してからclaudeコマンドを送信する
+	require.Len(t, pane.sent, 3)
+	assert.Contains(t, pane.sent[2], "claude")
+}
@@ -1,19 +1,163 @@
 package claude
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
+	"text/template"
+
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/trace"
+)
+
+// PartialsDir はプロンプトから参照するパーシャルを配置するディレクトリ（リポジトリのルートからの相対パス）
+// <PartialsDir>/<名前>.tmpl は {{template "<名前>" .}} で参照できる
+const PartialsDir = ".osoba/templates"
+
+// partialExt はパーシャルのファイルの拡張子
+const partialExt = ".tmpl"
+
+// legacyVariables は従来の{{変数名}}形式の変数と、対応するtext/templateのフィールド
+var legacyVariables = map[string]string{
+	"{{issue-number}}":  "{{.IssueNumber}}",
+	"{{issue-title}}":   "{{.IssueTitle}}",
+	"{{repo-name}}":     "{{.RepoName}}",
+	"{{artifacts-dir}}": "{{.ArtifactsDir}}",
+}
+
+// templateFuncs はプロンプトで使用できる関数
+// ファイルやコマンドにアクセスする関数は提供しない
+var templateFuncs = template.FuncMap{
+	"join":      strings.Join,
+	"hasPrefix": strings.HasPrefix,
+	"trim":      strings.TrimSpace,
+}
+
+// テスト用にモック可能な関数変数
+var (
+	// listChangedFiles はworktreeでベースブランチから変更されたファイルを取得する
+	listChangedFiles = func(workdir string) ([]string, error) {
+		args := []string{"-C", workdir, "diff", "--name-only", "origin/HEAD...HEAD"}
+		done := trace.Start("git", args, "")
+		output, err := exec.Command("git", args...).Output()
+		done(err)
+		if err != nil {
+			return nil, err
+		}
+		return strings.Fields(string(output)), nil
+	}
 )
 
 // TemplateVariables はテンプレート展開で使用する変数
 type TemplateVariables struct {
 	IssueNumber int
 	IssueTitle  string
+	IssueBody   string // Issueの本文（ポーリングで取得した本文のため、ghコマンドを実行せずに参照できる）
 	RepoName    string
+	Labels      []string // Issueのラベル
+	BotReviews  string   // レビューボットのレビュー（reviseフェーズのみ、Markdown）
+	// ArtifactsDir はフェーズ間でファイルを受け渡すIssueの成果物ディレクトリ（.git/osoba/artifacts/issue-{issue番号}）
+	ArtifactsDir string
+}
+
+// promptData はテンプレートに渡すデータ
+type promptData struct {
+	*TemplateVariables
+	workdir string
+}
+
+// HasLabel はIssueが指定されたラベルを持っているかを返す（{{if .HasLabel "bug"}}）
+func (d promptData) HasLabel(name string) bool {
+	for _, label := range d.Labels {
+		if label == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Files はworktreeでベースブランチから変更されたファイルを返す（{{range .Files}}）
+// 参照された場合のみgitを実行する
+func (d promptData) Files() []string {
+	if d.workdir == "" {
+		return nil
+	}
+	files, err := listChangedFiles(d.workdir)
+	if err != nil {
+		return nil
+	}
+	return files
+}
+
+// Artifacts は以前のフェーズが成果物ディレクトリに置いたファイルのパスを返す（{{range .Artifacts}}）
+func (d promptData) Artifacts() []string {
+	if d.ArtifactsDir == "" {
+		return nil
+	}
+	artifacts, err := git.ListArtifacts(d.ArtifactsDir)
+	if err != nil {
+		return nil
+	}
+	paths := make([]string, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		paths = append(paths, artifact.Path)
+	}
+	return paths
+}
+
+// Artifact は成果物ディレクトリのファイルのパスを返す（{{with .Artifact "plan.md"}}）
+// ファイルがない場合は空文字列を返す
+func (d promptData) Artifact(name string) string {
+	if d.ArtifactsDir == "" {
+		return ""
+	}
+	path := filepath.Join(d.ArtifactsDir, filepath.FromSlash(name))
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return ""
+	}
+	return path
+}
+
+// ParsePrompt はプロンプトのテンプレートを解析する
+// 従来の{{issue-number}}形式の変数はtext/templateのフィールドに変換する
+func ParsePrompt(prompt string) (*template.Template, error) {
+	tmpl, err := template.New("prompt").Funcs(templateFuncs).Option("missingkey=error").Parse(convertLegacyVariables(prompt))
+	if err != nil {
+		return nil, fmt.Errorf("invalid prompt template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// RenderPrompt はプロンプトのテンプレートを展開する
+// workdirが指定されている場合は、<workdir>/.osoba/templates のパーシャルを読み込む
+func RenderPrompt(prompt string, vars *TemplateVariables, workdir string) (string, error) {
+	tmpl, err := ParsePrompt(prompt)
+	if err != nil {
+		return "", err
+	}
+	if workdir != "" {
+		if err := loadPartials(tmpl, filepath.Join(workdir, PartialsDir)); err != nil {
+			return "", err
+		}
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, promptData{TemplateVariables: vars, workdir: workdir}); err != nil {
+		return "", fmt.Errorf("failed to render prompt template: %w", err)
+	}
+	return out.String(), nil
 }
 
 // ExpandTemplate はテンプレート文字列内の変数を実際の値に置換する
+// テンプレートの展開に失敗した場合は、従来の{{変数名}}形式の変数のみを置換する
 func ExpandTemplate(template string, vars *TemplateVariables) string {
+	if result, err := RenderPrompt(template, vars, ""); err == nil {
+		return result
+	}
+
 	result := template
 
 	// {{issue-number}} の置換
@@ -25,5 +169,35 @@ func ExpandTemplate(template string, vars *TemplateVariables) string {
 	// {{repo-name}} の置換
 	result = strings.ReplaceAll(result, "{{repo-name}}", vars.RepoName)
 
+	// {{artifacts-dir}} の置換
+	result = strings.ReplaceAll(result, "{{artifacts-dir}}", vars.ArtifactsDir)
+
 	return result
 }
+
+// loadPartials はディレクトリ内のパーシャルをテンプレートに追加する（ディレクトリがない場合は何もしない）
+func loadPartials(tmpl *template.Template, dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+partialExt))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read prompt partial %s: %w", path, err)
+		}
+		name := strings.TrimSuffix(filepath.Base(path), partialExt)
+		if _, err := tmpl.New(name).Parse(convertLegacyVariables(string(content))); err != nil {
+			return fmt.Errorf("invalid prompt partial %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// convertLegacyVariables は従来の{{変数名}}形式の変数をtext/templateのフィールドに変換する
+func convertLegacyVariables(prompt string) string {
+	for legacy, field := range legacyVariables {
+		prompt = strings.ReplaceAll(prompt, legacy, field)
+	}
+	return prompt
+}
@@ -1,9 +1,12 @@
 package claude
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTemplateVariables(t *testing.T) {
@@ -101,3 +104,130 @@ func TestExpandTemplate_SpecialCharacters(t *testing.T) {
 		assert.Equal(t, "feat: Claude起動機能 & 設定管理", got)
 	})
 }
+
+func TestRenderPrompt(t *testing.T) {
+	vars := &TemplateVariables{
+		IssueNumber: 46,
+		IssueTitle:  "Claude起動機能",
+		IssueBody:   "tmuxでclaudeを起動する\n",
+		RepoName:    "douhashi/osoba",
+		Labels:      []string{"bug", "status:ready"},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "従来形式とtext/template形式の変数の混在",
+			template: "/osoba:plan {{issue-number}} {{.IssueTitle}}",
+			want:     "/osoba:plan 46 Claude起動機能",
+		},
+		{
+			name:     "Issueの本文",
+			template: `{{with trim .IssueBody}}本文: {{.}}{{end}}`,
+			want:     "本文: tmuxでclaudeを起動する",
+		},
+		{
+			name:     "ラベルによる条件分岐",
+			template: `{{if .HasLabel "bug"}}再現手順を確認すること{{else}}通常の実装{{end}}`,
+			want:     "再現手順を確認すること",
+		},
+		{
+			name:     "ラベルのループと関数",
+			template: `{{range .Labels}}[{{.}}]{{end}} {{join .Labels ", "}}`,
+			want:     "[bug][status:ready] bug, status:ready",
+		},
+		{
+			name:     "存在しないフィールド",
+			template: "{{.Unknown}}",
+			wantErr:  "failed to render prompt template",
+		},
+		{
+			name:     "構文エラー",
+			template: "{{if .Labels}}",
+			wantErr:  "invalid prompt template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderPrompt(tt.template, vars, "")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenderPrompt_Partials(t *testing.T) {
+	workdir := t.TempDir()
+	partialsDir := filepath.Join(workdir, PartialsDir)
+	require.NoError(t, os.MkdirAll(partialsDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(partialsDir, "rules.tmpl"), []byte("#{{issue-number}}のルール{{range .Files}} {{.}}{{end}}"), 0644))
+
+	orig := listChangedFiles
+	listChangedFiles = func(dir string) ([]string, error) {
+		assert.Equal(t, workdir, dir)
+		return []string{"main.go", "README.md"}, nil
+	}
+	t.Cleanup(func() { listChangedFiles = orig })
+
+	got, err := RenderPrompt(`/osoba:implement {{issue-number}} {{template "rules" .}}`, &TemplateVariables{IssueNumber: 7}, workdir)
+	require.NoError(t, err)
+	assert.Equal(t, "/osoba:implement 7 #7のルール main.go README.md", got)
+
+	t.Run("存在しないパーシャル", func(t *testing.T) {
+		_, err := RenderPrompt(`{{template "missing" .}}`, &TemplateVariables{}, workdir)
+		assert.Error(t, err)
+	})
+}
+
+func TestRenderPrompt_Artifacts(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plan.md"), []byte("# Plan"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "review.md"), []byte("LGTM"), 0644))
+	vars := &TemplateVariables{IssueNumber: 7, ArtifactsDir: dir}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "成果物ディレクトリ",
+			template: "/osoba:implement {{issue-number}} {{artifacts-dir}}",
+			want:     "/osoba:implement 7 " + dir,
+		},
+		{
+			name:     "以前のフェーズの成果物",
+			template: `{{range .Artifacts}}[{{.}}]{{end}}`,
+			want:     "[" + filepath.Join(dir, "plan.md") + "][" + filepath.Join(dir, "review.md") + "]",
+		},
+		{
+			name:     "存在する成果物のみ参照する",
+			template: `{{with .Artifact "plan.md"}}計画: {{.}}{{end}}{{with .Artifact "test-report.xml"}}テスト: {{.}}{{end}}`,
+			want:     "計画: " + filepath.Join(dir, "plan.md"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderPrompt(tt.template, vars, "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpandTemplate_InvalidTemplateFallback(t *testing.T) {
+	// テンプレートとして解釈できない場合は従来の変数のみを置換する
+	got := ExpandTemplate("#{{issue-number}} {{if}}", &TemplateVariables{IssueNumber: 3})
+	assert.Equal(t, "#3 {{if}}", got)
+}
@@ -3,22 +3,81 @@ package cleanup
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
 	"github.com/douhashi/osoba/internal/logger"
 	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/douhashi/osoba/internal/trace"
 )
 
 // Manager はクリーンアップ処理のインターフェース
 type Manager interface {
 	CleanupIssueResources(ctx context.Context, issueNumber int) error
+	// Plan はクリーンアップで削除されるリソースを返す（削除は行わない）
+	Plan(ctx context.Context, issueNumber int) (*Plan, error)
 }
 
+// Plan はクリーンアップで削除されるリソース
+type Plan struct {
+	IssueNumber  int          `json:"issue_number"`
+	Windows      []PlanWindow `json:"windows"`
+	Worktrees    []string     `json:"worktrees"`
+	Branches     []string     `json:"branches"`      // worktreeとともに削除されるブランチ
+	Artifacts    []string     `json:"artifacts"`     // フェーズ間でファイルを受け渡した成果物ディレクトリ
+	StateEntries []string     `json:"state_entries"` // 監視プロセスが保存したIssueの状態（ペインの配置など）
+	Skipped      []string     `json:"skipped"`       // 確認が必要なためスキップされる操作
+}
+
+// PlanWindow はクリーンアップで削除されるtmuxウィンドウ
+type PlanWindow struct {
+	Session string `json:"session"`
+	Name    string `json:"name"`
+	Panes   int    `json:"panes"` // ウィンドウとともに削除されるペインの数
+}
+
+// IsEmpty は削除されるリソースがないかを返す
+func (p *Plan) IsEmpty() bool {
+	return len(p.Windows) == 0 && len(p.Worktrees) == 0 && len(p.Branches) == 0 &&
+		len(p.Artifacts) == 0 && len(p.StateEntries) == 0
+}
+
+// WindowNames は削除されるウィンドウを「セッション:ウィンドウ」の形式で返す
+func (p *Plan) WindowNames() []string {
+	names := make([]string, 0, len(p.Windows))
+	for _, w := range p.Windows {
+		names = append(names, w.Session+":"+w.Name)
+	}
+	return names
+}
+
+// PaneCount は削除されるペインの合計を返す
+func (p *Plan) PaneCount() int {
+	count := 0
+	for _, w := range p.Windows {
+		count += w.Panes
+	}
+	return count
+}
+
+// statWorktree はworktreeの存在確認に使用する（テスト時に差し替え可能）
+var statWorktree = os.Stat
+
 // DefaultManager は標準のクリーンアップマネージャー
 type DefaultManager struct {
-	sessionName string
-	logger      logger.Logger
-	executor    tmux.CommandExecutor // テスト可能にするため
+	sessionName   string
+	shardSessions []string // tmux.shardsのセッション（Issueのウィンドウが振り分けられている可能性がある）
+	logger        logger.Logger
+	executor      tmux.CommandExecutor // テスト可能にするため
+	safety        config.SafetyConfig
+	// worktreeManager が設定されている場合は、worktreeの特定と削除をWorktreeManagerで行う
+	worktreeManager git.WorktreeManager
+	// artifactsRoot は成果物ディレクトリ（.git/osoba/artifacts）を置くリポジトリのルート（空の場合は成果物ディレクトリを削除しない）
+	artifactsRoot string
+	// panes はフェーズのペインの配置の記録（nilの場合は記録を削除しない）
+	panes *tmux.PaneRegistry
 }
 
 // NewManager は新しいクリーンアップマネージャーを作成する
@@ -31,23 +90,83 @@ func NewManager(sessionName string, logger logger.Logger) Manager {
 	}
 }
 
+// NewManagerWithSafety は破壊的操作の安全設定を考慮するクリーンアップマネージャーを作成する
+// 確認が必要な操作は非対話で実行できないため、スキップして警告ログを出力する
+func NewManagerWithSafety(sessionName string, logger logger.Logger, safety config.SafetyConfig) Manager {
+	return &DefaultManager{
+		sessionName: sessionName,
+		logger:      logger,
+		executor:    &tmux.DefaultCommandExecutor{},
+		safety:      safety,
+	}
+}
+
+// NewManagerWithShards はtmux.shardsのセッションにあるウィンドウも閉じるクリーンアップマネージャーを作成する
+// sessionNamesの先頭は既定のセッション、以降はシャードのセッション
+func NewManagerWithShards(sessionNames []string, logger logger.Logger, safety config.SafetyConfig) Manager {
+	m := &DefaultManager{
+		logger:   logger,
+		executor: &tmux.DefaultCommandExecutor{},
+		safety:   safety,
+	}
+	if len(sessionNames) > 0 {
+		m.sessionName = sessionNames[0]
+		m.shardSessions = sessionNames[1:]
+	}
+	return m
+}
+
+// SetWorktreeManager はworktreeの特定と削除に使うWorktreeManagerを設定する
+// worktree.scratch_dirの作業ディレクトリは、WorktreeManagerが未コミットの変更を確認しブランチをpushしてから削除する
+func (m *DefaultManager) SetWorktreeManager(worktreeManager git.WorktreeManager) {
+	m.worktreeManager = worktreeManager
+}
+
+// SetArtifactsRoot は成果物ディレクトリを置くリポジトリのルートを設定する
+func (m *DefaultManager) SetArtifactsRoot(root string) {
+	m.artifactsRoot = root
+}
+
+// SetPaneRegistry はクリーンアップ時にIssueの記録を削除するペインの配置のレジストリを設定する
+func (m *DefaultManager) SetPaneRegistry(registry *tmux.PaneRegistry) {
+	m.panes = registry
+}
+
 // CleanupIssueResources はIssueに関連するリソースをクリーンアップする
+// 削除の前に、追跡できるよう削除するリソースをログに記録する
 func (m *DefaultManager) CleanupIssueResources(ctx context.Context, issueNumber int) error {
+	m.logPlan(ctx, issueNumber)
+
 	// tmuxウィンドウをクローズ
-	if err := m.closeTmuxWindowsForIssue(ctx, issueNumber); err != nil {
-		if m.logger != nil {
-			m.logger.Warn("Failed to close tmux windows",
-				"issue_number", issueNumber,
-				"error", err,
-			)
+	if !m.skipUnconfirmed(config.OperationKillWindow, issueNumber) {
+		if err := m.closeTmuxWindowsForIssue(ctx, issueNumber); err != nil {
+			if m.logger != nil {
+				m.logger.Warn("Failed to close tmux windows",
+					"issue_number", issueNumber,
+					"error", err,
+				)
+			}
+			// エラーは無視して続行
 		}
-		// エラーは無視して続行
 	}
 
 	// worktreeを削除
-	if err := m.removeWorktree(ctx, issueNumber); err != nil {
+	if !m.skipUnconfirmed(config.OperationRemoveWorktree, issueNumber) {
+		if err := m.removeWorktree(ctx, issueNumber); err != nil {
+			if m.logger != nil {
+				m.logger.Warn("Failed to remove worktree",
+					"issue_number", issueNumber,
+					"error", err,
+				)
+			}
+			// エラーは無視して続行
+		}
+	}
+
+	// 成果物ディレクトリを削除
+	if err := m.removeArtifacts(issueNumber); err != nil {
 		if m.logger != nil {
-			m.logger.Warn("Failed to remove worktree",
+			m.logger.Warn("Failed to remove artifacts directory",
 				"issue_number", issueNumber,
 				"error", err,
 			)
@@ -55,9 +174,113 @@ func (m *DefaultManager) CleanupIssueResources(ctx context.Context, issueNumber
 		// エラーは無視して続行
 	}
 
+	// 監視プロセスが保存したペインの配置を削除
+	m.panes.Forget(issueNumber)
+
 	return nil
 }
 
+// Plan はIssueのクリーンアップで削除されるtmuxウィンドウ・worktree・ブランチ・成果物ディレクトリ・状態を返す（削除は行わない）
+// 確認が必要なためスキップされる操作のリソースは含めない
+func (m *DefaultManager) Plan(ctx context.Context, issueNumber int) (*Plan, error) {
+	plan := &Plan{
+		IssueNumber:  issueNumber,
+		Windows:      []PlanWindow{},
+		Worktrees:    []string{},
+		Branches:     []string{},
+		Artifacts:    []string{},
+		StateEntries: []string{},
+		Skipped:      []string{},
+	}
+
+	if m.safety.RequiresConfirmation(config.OperationKillWindow) {
+		plan.Skipped = append(plan.Skipped, config.OperationKillWindow)
+	} else if m.sessionName != "" {
+		// セッション名が指定されていない従来の動作では、削除するウィンドウを事前に特定できない
+		sessions := []string{m.sessionName}
+		for _, session := range m.shardSessions {
+			if _, err := m.executor.Execute("tmux", "has-session", "-t", session); err == nil {
+				sessions = append(sessions, session)
+			}
+		}
+		for _, session := range sessions {
+			windows, err := tmux.ListWindowsForIssueWithExecutor(session, issueNumber, m.executor)
+			if err != nil {
+				return plan, fmt.Errorf("failed to list windows in session %s: %w", session, err)
+			}
+			for _, window := range windows {
+				plan.Windows = append(plan.Windows, PlanWindow{Session: session, Name: window.Name, Panes: window.Panes})
+			}
+		}
+	}
+
+	if m.safety.RequiresConfirmation(config.OperationRemoveWorktree) {
+		plan.Skipped = append(plan.Skipped, config.OperationRemoveWorktree)
+	} else {
+		if path := m.worktreePathForIssue(issueNumber); pathExists(path) {
+			plan.Worktrees = append(plan.Worktrees, path)
+		}
+		if planner, ok := m.worktreeManager.(git.BranchPlanner); ok {
+			plan.Branches = append(plan.Branches, planner.BranchesRemovedForIssue(ctx, issueNumber)...)
+		}
+	}
+
+	if m.artifactsRoot != "" {
+		if dir := git.ArtifactsDirForIssue(m.artifactsRoot, issueNumber); pathExists(dir) {
+			plan.Artifacts = append(plan.Artifacts, dir)
+		}
+	}
+
+	for _, entry := range m.panes.EntriesForIssue(issueNumber) {
+		plan.StateEntries = append(plan.StateEntries, "panes:"+entry)
+	}
+
+	return plan, nil
+}
+
+// logPlan はクリーンアップで削除するリソースをログに記録する
+func (m *DefaultManager) logPlan(ctx context.Context, issueNumber int) {
+	if m.logger == nil {
+		return
+	}
+	plan, err := m.Plan(ctx, issueNumber)
+	if err != nil {
+		m.logger.Warn("Failed to plan cleanup",
+			"issue_number", issueNumber,
+			"error", err,
+		)
+		return
+	}
+	if plan.IsEmpty() {
+		return
+	}
+	m.logger.Info("Cleanup plan",
+		"issue_number", issueNumber,
+		"windows", plan.WindowNames(),
+		"panes", plan.PaneCount(),
+		"worktrees", plan.Worktrees,
+		"branches", plan.Branches,
+		"artifacts", plan.Artifacts,
+		"state_entries", plan.StateEntries,
+		"skipped", plan.Skipped,
+	)
+}
+
+// skipUnconfirmed は確認が必要な操作かを判定し、スキップする場合は警告ログを出力する
+func (m *DefaultManager) skipUnconfirmed(operation string, issueNumber int) bool {
+	if !m.safety.RequiresConfirmation(operation) {
+		return false
+	}
+	if m.logger != nil {
+		m.logger.Warn("Skipping destructive operation that requires confirmation",
+			"operation", operation,
+			"issue_number", issueNumber,
+			"hint", "run with --yes or add the operation to safety.allow",
+		)
+	}
+	return true
+}
+
 // closeTmuxWindowsForIssue はIssueに関連するすべてのtmuxウィンドウを閉じる
 func (m *DefaultManager) closeTmuxWindowsForIssue(ctx context.Context, issueNumber int) error {
 	// セッション名が指定されていない場合は警告を出して従来の動作
@@ -72,35 +295,48 @@ func (m *DefaultManager) closeTmuxWindowsForIssue(ctx context.Context, issueNumb
 		return m.closeTmuxWindowLegacy(ctx, windowName)
 	}
 
+	m.closeTmuxWindowsInSession(m.sessionName, issueNumber)
+	for _, session := range m.shardSessions {
+		// まだウィンドウが振り分けられていないシャードのセッションは存在しない
+		if _, err := m.executor.Execute("tmux", "has-session", "-t", session); err != nil {
+			continue
+		}
+		m.closeTmuxWindowsInSession(session, issueNumber)
+	}
+	return nil
+}
+
+// closeTmuxWindowsInSession はセッション内のIssueに関連するtmuxウィンドウを閉じる
+// 失敗してもクリーンアップを継続するため、エラーはログに記録するのみ
+func (m *DefaultManager) closeTmuxWindowsInSession(sessionName string, issueNumber int) {
 	if m.logger != nil {
 		m.logger.Debug("Cleaning up tmux windows for issue",
-			"session", m.sessionName,
+			"session", sessionName,
 			"issue_number", issueNumber,
 		)
 	}
 
 	// Issue番号に関連するすべてのウィンドウを取得
-	windows, err := tmux.ListWindowsForIssueWithExecutor(m.sessionName, issueNumber, m.executor)
+	windows, err := tmux.ListWindowsForIssueWithExecutor(sessionName, issueNumber, m.executor)
 	if err != nil {
 		if m.logger != nil {
 			m.logger.Warn("Failed to list windows for issue",
-				"session", m.sessionName,
+				"session", sessionName,
 				"issue_number", issueNumber,
 				"error", err,
 			)
 		}
-		// リスト取得に失敗してもエラーを返さない（クリーンアップを継続）
-		return nil
+		return
 	}
 
 	if len(windows) == 0 {
 		if m.logger != nil {
 			m.logger.Info("No tmux windows found for issue",
-				"session", m.sessionName,
+				"session", sessionName,
 				"issue_number", issueNumber,
 			)
 		}
-		return nil
+		return
 	}
 
 	// 各ウィンドウを削除
@@ -111,43 +347,44 @@ func (m *DefaultManager) closeTmuxWindowsForIssue(ctx context.Context, issueNumb
 
 	if m.logger != nil {
 		m.logger.Info("Closing tmux windows for issue",
-			"session", m.sessionName,
+			"session", sessionName,
 			"issue_number", issueNumber,
 			"windows", windowNames,
 		)
 	}
 
 	// ウィンドウを一括削除
-	err = tmux.KillWindowsWithExecutor(m.sessionName, windowNames, m.executor)
+	err = tmux.KillWindowsWithExecutor(sessionName, windowNames, m.executor)
 	if err != nil {
 		if m.logger != nil {
 			m.logger.Warn("Some windows could not be closed",
-				"session", m.sessionName,
+				"session", sessionName,
 				"issue_number", issueNumber,
 				"windows", windowNames,
 				"error", err,
 			)
 		}
-		// 一部のウィンドウが削除できなくてもエラーを返さない
-		return nil
+		return
 	}
 
 	if m.logger != nil {
 		m.logger.Info("Successfully closed all tmux windows for issue",
-			"session", m.sessionName,
+			"session", sessionName,
 			"issue_number", issueNumber,
 			"window_count", len(windows),
 		)
 	}
 
-	return nil
+	return
 }
 
 // closeTmuxWindowLegacy は従来の方法でtmuxウィンドウを閉じる（後方互換性のため）
 func (m *DefaultManager) closeTmuxWindowLegacy(ctx context.Context, windowName string) error {
 	// tmux kill-window -t <window-name>
 	cmd := exec.CommandContext(ctx, "tmux", "kill-window", "-t", windowName)
+	done := trace.Start(cmd.Args[0], cmd.Args[1:], "")
 	output, err := cmd.CombinedOutput()
+	done(err)
 	if err != nil {
 		// ウィンドウが存在しない場合もエラーになるが、それは問題ない
 		return fmt.Errorf("failed to kill tmux window: %s", string(output))
@@ -164,12 +401,16 @@ func (m *DefaultManager) closeTmuxWindowLegacy(ctx context.Context, windowName s
 
 // removeWorktree はgit worktreeを削除する
 func (m *DefaultManager) removeWorktree(ctx context.Context, issueNumber int) error {
-	// worktreeのパス（例: .git/osoba/worktrees/issue-123）
-	worktreePath := fmt.Sprintf(".git/osoba/worktrees/issue-%d", issueNumber)
+	if m.worktreeManager != nil {
+		return m.worktreeManager.RemoveWorktreeForIssue(ctx, issueNumber)
+	}
+	worktreePath := worktreePathForIssue(issueNumber)
 
 	// git worktree remove <path> --force
 	cmd := exec.CommandContext(ctx, "git", "worktree", "remove", worktreePath, "--force")
+	done := trace.Start(cmd.Args[0], cmd.Args[1:], "")
 	output, err := cmd.CombinedOutput()
+	done(err)
 	if err != nil {
 		// worktreeが存在しない場合もエラーになるが、それは問題ない
 		return fmt.Errorf("failed to remove worktree: %s", string(output))
@@ -183,3 +424,59 @@ func (m *DefaultManager) removeWorktree(ctx context.Context, issueNumber int) er
 
 	return nil
 }
+
+// worktreePathForIssue はIssueのworktreeのパスを返す
+func (m *DefaultManager) worktreePathForIssue(issueNumber int) string {
+	if m.worktreeManager != nil {
+		return m.worktreeManager.GetWorktreePathForIssue(issueNumber)
+	}
+	return worktreePathForIssue(issueNumber)
+}
+
+// removeArtifacts はIssueの成果物ディレクトリを削除する
+func (m *DefaultManager) removeArtifacts(issueNumber int) error {
+	if m.artifactsRoot == "" {
+		return nil
+	}
+	dir := git.ArtifactsDirForIssue(m.artifactsRoot, issueNumber)
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove artifacts directory: %w", err)
+	}
+	if m.logger != nil {
+		m.logger.Debug("Removed artifacts directory", "path", dir)
+	}
+	return nil
+}
+
+// worktreePathForIssue はIssueのworktreeのパス（例: .git/osoba/worktrees/issue-123）を返す
+func worktreePathForIssue(issueNumber int) string {
+	return fmt.Sprintf(".git/osoba/worktrees/issue-%d", issueNumber)
+}
+
+func pathExists(path string) bool {
+	_, err := statWorktree(path)
+	return err == nil
+}
+
+// disabledManager は自動クリーンアップが無効な場合に使用する、リソースを削除しないManager
+type disabledManager struct {
+	logger logger.Logger
+}
+
+// NewDisabledManager はリソースを削除しないクリーンアップマネージャーを作成する
+func NewDisabledManager(logger logger.Logger) Manager {
+	return &disabledManager{logger: logger}
+}
+
+// CleanupIssueResources はクリーンアップを行わずにスキップしたことを記録する
+func (m *disabledManager) CleanupIssueResources(ctx context.Context, issueNumber int) error {
+	if m.logger != nil {
+		m.logger.Info("Skipping cleanup: automatic cleanup is disabled", "issue_number", issueNumber)
+	}
+	return nil
+}
+
+// Plan は削除されるリソースがない計画を返す
+func (m *disabledManager) Plan(ctx context.Context, issueNumber int) (*Plan, error) {
+	return &Plan{IssueNumber: issueNumber}, nil
+}
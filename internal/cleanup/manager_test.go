@@ -3,11 +3,17 @@ package cleanup
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
 	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/tmux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // mockLogger はテスト用のロガー実装
@@ -147,6 +153,35 @@ func TestCleanupIssueResources_NoWindow(t *testing.T) {
 	mockExecutor.AssertExpectations(t)
 }
 
+func TestCleanupIssueResources_ShardSessions(t *testing.T) {
+	// tmux.shardsのセッションに振り分けられたウィンドウも削除する
+	mockLog := &mockLogger{}
+	mockExecutor := &mockCommandExecutor{}
+
+	mockLog.On("Debug", mock.Anything, mock.Anything).Return()
+	mockLog.On("Info", mock.Anything, mock.Anything).Return()
+	mockLog.On("Warn", mock.Anything, mock.Anything).Return() // worktree削除のWarning用
+
+	listArgs := func(session string) []string {
+		return []string{"list-windows", "-t", session, "-F", "#{window_index}:#{window_name}:#{window_active}:#{window_panes}"}
+	}
+	mockExecutor.On("Execute", "tmux", listArgs("test-session")).Return("0:other-window:1:1", nil)
+	// 存在しないシャードのセッションはウィンドウを確認しない
+	mockExecutor.On("Execute", "tmux", []string{"has-session", "-t", "test-session-backend"}).Return("", errors.New("can't find session"))
+	mockExecutor.On("Execute", "tmux", []string{"has-session", "-t", "test-session-frontend"}).Return("", nil)
+	mockExecutor.On("Execute", "tmux", listArgs("test-session-frontend")).Return("0:issue-123:1:1", nil)
+	mockExecutor.On("Execute", "tmux", []string{"kill-window", "-t", "test-session-frontend:issue-123"}).Return("", nil)
+
+	manager := NewManagerWithShards([]string{"test-session", "test-session-backend", "test-session-frontend"}, mockLog, config.SafetyConfig{}).(*DefaultManager)
+	manager.executor = mockExecutor
+
+	err := manager.CleanupIssueResources(context.Background(), 123)
+	assert.NoError(t, err)
+
+	mockExecutor.AssertExpectations(t)
+	mockExecutor.AssertNotCalled(t, "Execute", "tmux", listArgs("test-session-backend"))
+}
+
 func TestCleanupIssueResources_TmuxError(t *testing.T) {
 	// tmuxコマンドがエラーを返す場合のテスト
 	mockLog := &mockLogger{}
@@ -226,3 +261,148 @@ func TestCleanupIssueResources_MultipleWindows(t *testing.T) {
 
 	mockExecutor.AssertExpectations(t)
 }
+
+func TestDefaultManager_CleanupIssueResources_RequiresConfirmation(t *testing.T) {
+	mockLog := &mockLogger{}
+	mockLog.On("Warn", "Skipping destructive operation that requires confirmation", mock.Anything).Twice()
+	mockExec := &mockCommandExecutor{}
+
+	manager := &DefaultManager{
+		sessionName: "test-session",
+		logger:      mockLog,
+		executor:    mockExec,
+		safety:      config.SafetyConfig{ConfirmDestructive: true},
+	}
+
+	err := manager.CleanupIssueResources(context.Background(), 123)
+
+	assert.NoError(t, err)
+	// ウィンドウ削除もworktree削除も実行されない
+	mockExec.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	mockLog.AssertExpectations(t)
+}
+
+func TestDefaultManager_Plan(t *testing.T) {
+	origStat := statWorktree
+	defer func() { statWorktree = origStat }()
+	statWorktree = func(name string) (os.FileInfo, error) {
+		if name == ".git/osoba/worktrees/issue-123" {
+			return nil, nil
+		}
+		return nil, os.ErrNotExist
+	}
+
+	listArgs := func(session string) []string {
+		return []string{"list-windows", "-t", session, "-F", "#{window_index}:#{window_name}:#{window_active}:#{window_panes}"}
+	}
+	mockExecutor := &mockCommandExecutor{}
+	mockExecutor.On("Execute", "tmux", listArgs("test-session")).Return("0:123-plan:0:2\n1:other-window:0:1\n2:123-review:1:1", nil)
+	mockExecutor.On("Execute", "tmux", []string{"has-session", "-t", "test-session-frontend"}).Return("", nil)
+	mockExecutor.On("Execute", "tmux", listArgs("test-session-frontend")).Return("0:issue-123:1:1", nil)
+
+	manager := NewManagerWithShards([]string{"test-session", "test-session-frontend"}, nil, config.SafetyConfig{}).(*DefaultManager)
+	manager.executor = mockExecutor
+
+	plan, err := manager.Plan(context.Background(), 123)
+	assert.NoError(t, err)
+	assert.Equal(t, []PlanWindow{
+		{Session: "test-session", Name: "123-plan", Panes: 2},
+		{Session: "test-session", Name: "123-review", Panes: 1},
+		{Session: "test-session-frontend", Name: "issue-123", Panes: 1},
+	}, plan.Windows)
+	assert.Equal(t, []string{".git/osoba/worktrees/issue-123"}, plan.Worktrees)
+	assert.Equal(t, 4, plan.PaneCount())
+	assert.Empty(t, plan.Skipped)
+	// 削除は行わない
+	mockExecutor.AssertNotCalled(t, "Execute", "tmux", []string{"kill-window", "-t", "test-session:123-plan"})
+}
+
+// planWorktreeManager はworktreeのパスと削除されるブランチだけを返すWorktreeManager
+type planWorktreeManager struct {
+	git.WorktreeManager
+	path     string
+	branches []string
+}
+
+func (m *planWorktreeManager) GetWorktreePathForIssue(issueNumber int) string {
+	return m.path
+}
+
+func (m *planWorktreeManager) BranchesRemovedForIssue(ctx context.Context, issueNumber int) []string {
+	return m.branches
+}
+
+func TestDefaultManager_Plan_BranchesAndStateEntries(t *testing.T) {
+	worktreePath := t.TempDir()
+	registry := tmux.NewPaneRegistry()
+	registry.Record(123, "implement", tmux.PanePlacement{SessionName: "test-session", WindowName: "issue-123"})
+	registry.Record(124, "plan", tmux.PanePlacement{SessionName: "test-session", WindowName: "issue-124"})
+
+	manager := &DefaultManager{
+		executor: &mockCommandExecutor{},
+		safety:   config.SafetyConfig{ConfirmDestructive: true, Allow: []string{config.OperationRemoveWorktree}},
+	}
+	manager.SetWorktreeManager(&planWorktreeManager{path: worktreePath, branches: []string{"osoba/#123"}})
+	manager.SetPaneRegistry(registry)
+
+	plan, err := manager.Plan(context.Background(), 123)
+	require.NoError(t, err)
+	assert.Equal(t, []string{worktreePath}, plan.Worktrees)
+	assert.Equal(t, []string{"osoba/#123"}, plan.Branches)
+	assert.Equal(t, []string{"panes:123/implement"}, plan.StateEntries)
+
+	// worktreeの削除に確認が必要な場合はブランチも削除されない
+	manager.safety = config.SafetyConfig{ConfirmDestructive: true}
+	plan, err = manager.Plan(context.Background(), 123)
+	require.NoError(t, err)
+	assert.Empty(t, plan.Worktrees)
+	assert.Empty(t, plan.Branches)
+	assert.Equal(t, []string{"panes:123/implement"}, plan.StateEntries)
+
+	// クリーンアップでIssueの状態を削除する
+	require.NoError(t, manager.CleanupIssueResources(context.Background(), 123))
+	assert.Empty(t, registry.EntriesForIssue(123))
+	assert.Equal(t, []string{"124/plan"}, registry.EntriesForIssue(124))
+}
+
+func TestDefaultManager_Plan_RequiresConfirmation(t *testing.T) {
+	mockExecutor := &mockCommandExecutor{}
+	manager := &DefaultManager{
+		sessionName: "test-session",
+		executor:    mockExecutor,
+		safety:      config.SafetyConfig{ConfirmDestructive: true, Allow: []string{config.OperationRemoveWorktree}},
+	}
+
+	plan, err := manager.Plan(context.Background(), 123)
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+	assert.Equal(t, []string{config.OperationKillWindow}, plan.Skipped)
+	mockExecutor.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestDefaultManager_CleanupIssueResources_Artifacts(t *testing.T) {
+	root := t.TempDir()
+	dir, err := git.EnsureArtifactsDirForIssue(root, 123)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plan.md"), []byte("# Plan"), 0o644))
+	other, err := git.EnsureArtifactsDirForIssue(root, 124)
+	require.NoError(t, err)
+
+	mockExecutor := &mockCommandExecutor{}
+	manager := &DefaultManager{
+		sessionName: "test-session",
+		executor:    mockExecutor,
+		safety:      config.SafetyConfig{ConfirmDestructive: true},
+	}
+	manager.SetArtifactsRoot(root)
+
+	plan, err := manager.Plan(context.Background(), 123)
+	require.NoError(t, err)
+	assert.Equal(t, []string{dir}, plan.Artifacts)
+	assert.False(t, plan.IsEmpty())
+
+	// 確認が必要な操作をスキップしても、成果物ディレクトリは削除する
+	require.NoError(t, manager.CleanupIssueResources(context.Background(), 123))
+	assert.NoDirExists(t, dir)
+	assert.DirExists(t, other, "他のIssueの成果物ディレクトリは削除しない")
+}
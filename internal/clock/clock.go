@@ -0,0 +1,40 @@
+// Package clock はテストで時間を進められるよう、現在時刻とタイマーを抽象化する
+package clock
+
+import "time"
+
+// Clock は現在時刻の取得とタイマーの作成を行うインターフェース
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	NewTicker(d time.Duration) Ticker
+	After(d time.Duration) <-chan time.Time
+}
+
+// Ticker は一定間隔で時刻を送るタイマー
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// New は実時間のClockを返す
+func New() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return &realTicker{ticker: time.NewTicker(d)}
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t *realTicker) C() <-chan time.Time { return t.ticker.C }
+func (t *realTicker) Stop()               { t.ticker.Stop() }
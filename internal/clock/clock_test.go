@@ -0,0 +1,72 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fakeStart = time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+// received はチャネルに値が届いているかを待たずに確認する
+func received(ch <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-ch:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFake_After(t *testing.T) {
+	f := NewFake(fakeStart)
+	ch := f.After(time.Minute)
+
+	f.Advance(59 * time.Second)
+	_, ok := received(ch)
+	assert.False(t, ok, "期限前は発火しない")
+
+	f.Advance(time.Second)
+	got, ok := received(ch)
+	require.True(t, ok)
+	assert.Equal(t, fakeStart.Add(time.Minute), got)
+	assert.Equal(t, time.Minute, f.Since(fakeStart))
+}
+
+func TestFake_Ticker(t *testing.T) {
+	f := NewFake(fakeStart)
+	ticker := f.NewTicker(10 * time.Second)
+
+	f.Advance(10 * time.Second)
+	got, ok := received(ticker.C())
+	require.True(t, ok)
+	assert.Equal(t, fakeStart.Add(10*time.Second), got)
+
+	// 受信されなかった発火は読み捨てる（実時間のTickerと同じ）
+	f.Advance(30 * time.Second)
+	got, ok = received(ticker.C())
+	require.True(t, ok)
+	assert.Equal(t, fakeStart.Add(20*time.Second), got)
+	_, ok = received(ticker.C())
+	assert.False(t, ok)
+
+	ticker.Stop()
+	f.Advance(time.Minute)
+	_, ok = received(ticker.C())
+	assert.False(t, ok, "停止後は発火しない")
+}
+
+func TestFake_BlockUntil(t *testing.T) {
+	f := NewFake(fakeStart)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-f.After(time.Hour)
+	}()
+
+	f.BlockUntil(1)
+	f.Advance(time.Hour)
+	<-done
+}
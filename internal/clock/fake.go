@@ -0,0 +1,132 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake はAdvanceを呼んだときだけ時間が進むテスト用のClock
+// time.Sleepで実時間の経過を待つ代わりに、Advanceで仮想時間を進めてタイマーを発火させる
+type Fake struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter はFakeが発火を管理するTickerまたはAfterのタイマー
+type fakeWaiter struct {
+	at     time.Time
+	period time.Duration // 0の場合はAfter（1回のみ発火）
+	ch     chan time.Time
+}
+
+// NewFake は指定時刻から始まるFakeを作成する
+func NewFake(start time.Time) *Fake {
+	f := &Fake{now: start}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+// Now は仮想時間の現在時刻を返す
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since は仮想時間での経過時間を返す
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// After は仮想時間がd進んだときに1回だけ時刻を送るチャネルを返す
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := &fakeWaiter{at: f.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		w.ch <- f.now
+		return w.ch
+	}
+	f.addWaiterLocked(w)
+	return w.ch
+}
+
+// NewTicker は仮想時間がdごとに進むたびに時刻を送るTickerを返す
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := &fakeWaiter{at: f.now.Add(d), period: d, ch: make(chan time.Time, 1)}
+	f.addWaiterLocked(w)
+	return &fakeTicker{clock: f, waiter: w}
+}
+
+// Advance は仮想時間をd進め、期限を迎えたタイマーを時刻順に発火させる
+// 実時間のTickerと同様に、受信されていない発火は読み捨てる
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	end := f.now.Add(d)
+	for len(f.waiters) > 0 && !f.waiters[0].at.After(end) {
+		w := f.waiters[0]
+		f.now = w.at
+		select {
+		case w.ch <- w.at:
+		default:
+		}
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+		} else {
+			f.waiters = f.waiters[1:]
+		}
+		f.sortWaitersLocked()
+	}
+	f.now = end
+}
+
+// BlockUntil は待機中のタイマーがn個以上になるまで待つ
+// 別のgoroutineがTickerやAfterを作成してからAdvanceするために使う
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+func (f *Fake) addWaiterLocked(w *fakeWaiter) {
+	f.waiters = append(f.waiters, w)
+	f.sortWaitersLocked()
+	f.cond.Broadcast()
+}
+
+func (f *Fake) removeWaiter(target *fakeWaiter) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, w := range f.waiters {
+		if w == target {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+func (f *Fake) sortWaitersLocked() {
+	sort.SliceStable(f.waiters, func(i, j int) bool {
+		return f.waiters[i].at.Before(f.waiters[j].at)
+	})
+}
+
+type fakeTicker struct {
+	clock  *Fake
+	waiter *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.waiter.ch }
+func (t *fakeTicker) Stop()               { t.clock.removeWaiter(t.waiter) }
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// コメントテンプレート名
+const (
+	CommentPhasePlan           = "phase_plan"            // 計画フェーズ開始
+	CommentPhaseImplement      = "phase_implement"       // 実装フェーズ開始
+	CommentPhaseReview         = "phase_review"          // レビューフェーズ開始
+	CommentProgress            = "progress"              // 長時間フェーズの進捗
+	CommentWorkspaceBlocked    = "workspace_blocked"     // worktree事前チェックの失敗
+	CommentSubIssues           = "sub_issues"            // サブIssueの一覧
+	CommentSubIssueBody        = "sub_issue_body"        // サブIssueの本文
+	CommentPossibleDuplicate   = "possible_duplicate"    // 重複の可能性があるIssueの通知
+	CommentAwaitingExistingPR  = "awaiting_existing_pr"  // 既存のPRで対応中のIssueの通知
+	CommentPlanApprovalPending = "plan_approval_pending" // 計画の承認待ちの通知
+	CommentPlanStale           = "plan_stale"            // 計画後にIssueが編集された通知
+	CommentPlanStaleReplan     = "plan_stale_replan"     // 計画後にIssueが編集されたための再計画の通知
+	CommentIssueClosedByMerge  = "issue_closed_by_merge" // マージ後にクローズされなかったIssueのクローズ
+	CommentPhaseResult         = "phase_result"          // フェーズが書き出した結果
+	CommentReverted            = "reverted"              // マージしたPRのRevert
+	CommentReviewEscalated     = "review_escalated"      // レビューと修正の往復を人間に引き継いだ通知
+	CommentReviewBotsUsed      = "review_bots_used"      // レビューボットのレビューを使ってレビューフェーズを省略した通知
+	CommentHistoryRewritten    = "history_rewritten"     // PRのブランチの履歴の書き換えを検出して自動マージを止めた通知
+	CommentAgentStuck          = "agent_stuck"           // ハートビートもペインの出力も更新されないフェーズを検出した通知
+	CommentCIFailed            = "ci_failed"             // PRのCIが失敗したため実装フェーズに戻した通知
+	CommentCIEscalated         = "ci_escalated"          // CIの失敗が上限に達したため人間に引き継いだ通知
+)
+
+// CommentTemplatesConfig はosobaが投稿するコメントのテンプレート設定
+// テンプレート内の{{変数名}}はコメント投稿時に置換される
+type CommentTemplatesConfig struct {
+	// Dir は<テンプレート名>.mdを配置するディレクトリ（設定ファイルからの相対パス）
+	Dir string `mapstructure:"dir"`
+	// Templates はテンプレート名ごとの本文（Dir内のファイルより優先）
+	Templates map[string]string `mapstructure:"templates"`
+}
+
+// defaultCommentTemplates は組み込みのコメントテンプレート
+// フェーズ開始メッセージはgithub.messagesの値を既定値とする
+var defaultCommentTemplates = map[string]string{
+	CommentProgress: "### osoba progress\n\n" +
+		"- フェーズ: `{{phase}}`\n" +
+		"- 経過時間: {{elapsed}}\n" +
+		"- 最終更新: {{updated-at}}\n" +
+		"{{output}}",
+	CommentWorkspaceBlocked: "### ⚠️ osoba: worktreeに問題があるためフェーズを開始できません\n\n" +
+		"- worktree: `{{worktree-path}}`\n" +
+		"- 期待するブランチ: `{{expected-branch}}`\n\n" +
+		"#### 診断結果\n\n" +
+		"{{diagnostics}}\n" +
+		"worktreeを修復すると、次回のポーリングで自動的に再開します。\n",
+	CommentSubIssues: "### osoba: サブIssue\n\n" +
+		"計画のサブタスクをサブIssueとして作成しました。すべてクローズされるまでこのIssueは `{{blocked-label}}` になります。\n\n" +
+		"{{sub-issues}}",
+	CommentSubIssueBody: "Part of #{{parent-number}}",
+	CommentPossibleDuplicate: "### osoba: 重複の可能性があります\n\n" +
+		"既存のIssueと内容が似ているため、計画フェーズを開始せず `{{label}}` を付与しました。\n\n" +
+		"{{duplicates}}\n" +
+		"重複でない場合は `{{plan-label}}` を付け直すと計画フェーズを開始します。\n",
+	CommentAwaitingExistingPR: "### osoba: 既存のPRで対応中です\n\n" +
+		"このIssueを参照するオープンなPRがあるため、計画フェーズを開始せず `{{label}}` を付与しました。\n\n" +
+		"{{pull-requests}}\n" +
+		"osobaで対応する場合は `{{label}}` を外して `{{plan-label}}` を付け直してください。\n",
+	CommentPlanApprovalPending: "### osoba: 計画の承認待ち\n\n" +
+		"[実行計画]({{plan-url}})が承認されるまで実装フェーズを開始しません。承認する場合は次のいずれかを行ってください（承認できるユーザー: {{approvers}}）。\n\n" +
+		"{{methods}}\n" +
+		"承認後、次回のポーリングで実装フェーズを開始します。\n",
+	CommentPlanStale: "### osoba: 計画後にIssueが編集されました\n\n" +
+		"[実行計画]({{plan-url}})の作成後にIssueの本文が編集されたため、実装フェーズを開始せず `{{label}}` を付与しました。\n\n" +
+		"- 計画: {{planned-at}}\n" +
+		"- 本文の編集: {{edited-at}}\n\n" +
+		"計画をやり直す場合は `{{label}}` を外して `{{plan-label}}` を付与してください（worktreeはそのまま使われます）。\n" +
+		"現在の計画のまま実装する場合は `{{label}}` を外して `{{ready-label}}` を付け直してください。\n",
+	CommentPlanStaleReplan: "### osoba: 計画後にIssueが編集されたため再計画します\n\n" +
+		"[実行計画]({{plan-url}})の作成後にIssueの本文が編集されたため、実装フェーズを開始せず `{{plan-label}}` を付与しました。次回のポーリングで計画フェーズをやり直します（worktreeはそのまま使われます）。\n\n" +
+		"- 計画: {{planned-at}}\n" +
+		"- 本文の編集: {{edited-at}}\n",
+	CommentIssueClosedByMerge: "### osoba: Issueをクローズしました\n\n" +
+		"#{{pr-number}} をマージしましたが、このIssueがクローズされていなかったためクローズします。\n" +
+		"PRの本文にクローズキーワード（`Closes #{{issue-number}}` など）が含まれていなかった可能性があります。\n",
+	CommentPhaseResult: "### osoba: {{phase}} の結果\n\n" +
+		"- 結果: `{{status}}`\n" +
+		"- 次のラベル: {{next-label}}\n\n" +
+		"{{summary}}\n" +
+		"{{artifacts}}",
+	CommentReverted: "### osoba: マージした変更がRevertされました\n\n" +
+		"#{{pr-number}} の変更が #{{revert-number}} でRevertされたため、このIssueを `{{label}}` にしました。\n\n" +
+		"- Revert: {{revert-url}}\n\n" +
+		"原因を確認し、再度取り組む場合はフェーズのラベル（`status:needs-plan` など）を付与してください。\n",
+	CommentReviewEscalated: "### osoba: レビューを人間に引き継ぎました\n\n" +
+		"レビューで修正が必要と判定された回数が {{cycles}} 回に達したため、自動の修正を止めて `{{label}}` を付与しました。\n\n" +
+		"- PR: {{pr}}\n" +
+		"- レビュー依頼: {{reviewers}}\n\n" +
+		"レビュー後に自動処理を再開する場合は、`{{label}}` を外してフェーズのラベル（`status:ready` など）を付与してください。\n",
+	CommentReviewBotsUsed: "### osoba: レビューボットのレビューを使用しました\n\n" +
+		"{{bots}} が最新のコミット（{{commit}}）をレビュー済みのため、osobaのレビューフェーズを省略しました。\n\n" +
+		"- PR: {{pr}}\n" +
+		"- 判定: `{{label}}`\n",
+	CommentHistoryRewritten: "### osoba: ブランチの履歴の書き換えを検出しました\n\n" +
+		"PR {{pr}} のブランチがforce-pushされたため、自動マージを止めて `{{label}}` を付与しました。\n\n" +
+		"{{force-pushes}}\n\n" +
+		"失われたコミットや意図しない変更がないことを確認した後、PRに `{{allow-label}}` を付与すると自動マージを再開します。\n",
+	CommentAgentStuck: "### osoba: フェーズが停止している可能性があります\n\n" +
+		"`{{phase}}` のエージェントから{{idle}}以上ハートビートもペインの出力もないため、`{{label}}` を付与しました。\n\n" +
+		"- 最後のハートビート: {{last-heartbeat}}\n" +
+		"- 最後のペインの出力: {{last-output}}\n" +
+		"- 状態: {{reason}}\n\n" +
+		"`osoba open` でペインを確認し、必要に応じて `osoba reprocess` でフェーズをやり直してください。\n",
+	CommentCIFailed: "### osoba: CIが失敗したため実装フェーズに戻します\n\n" +
+		"PR #{{pr-number}} のCIチェックが失敗したため、レビューフェーズを開始せず `{{label}}` に戻しました。" +
+		"実装フェーズでは以下の失敗したジョブのログを確認して修正してください。\n\n" +
+		"{{failures}}",
+	CommentCIEscalated: "### osoba: CIの失敗を人間に引き継ぎました\n\n" +
+		"PR #{{pr-number}} のCIが {{count}} 回続けて失敗したため、実装フェーズに戻さず `{{label}}` を付与しました。\n\n" +
+		"{{failures}}" +
+		"修正後に自動処理を再開する場合は、`{{label}}` を外してフェーズのラベル（`status:review-requested` など）を付与してください。\n",
+}
+
+// commentTemplateNames は利用可能なテンプレート名を返す
+func commentTemplateNames() []string {
+	names := []string{CommentPhasePlan, CommentPhaseImplement, CommentPhaseReview}
+	for name := range defaultCommentTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isCommentTemplateName(name string) bool {
+	for _, n := range commentTemplateNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate はテンプレート名が既知のものかを検証する
+// サブIssueの一覧はサブIssueの追跡に使うため{{sub-issues}}を必須とする
+func (c CommentTemplatesConfig) Validate() error {
+	for name, tmpl := range c.Templates {
+		if !isCommentTemplateName(name) {
+			return fmt.Errorf("unknown comment template: %q (must be one of %s)", name, strings.Join(commentTemplateNames(), ", "))
+		}
+		if name == CommentSubIssues && !strings.Contains(tmpl, "{{sub-issues}}") {
+			return fmt.Errorf("comment template %q must contain {{sub-issues}} template variable", name)
+		}
+	}
+	return nil
+}
+
+// LoadDir はテンプレートディレクトリの<テンプレート名>.mdを読み込む
+// 設定ファイルで直接指定されたテンプレートは上書きしない
+func (c *CommentTemplatesConfig) LoadDir(baseDir string) error {
+	if c.Dir == "" {
+		return nil
+	}
+	dir := c.Dir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(baseDir, dir)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read comment templates directory: %w", err)
+	}
+	if c.Templates == nil {
+		c.Templates = make(map[string]string)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".md")
+		if !isCommentTemplateName(name) {
+			return fmt.Errorf("unknown comment template file: %s (must be one of %s)", entry.Name(), strings.Join(commentTemplateNames(), ", "))
+		}
+		if _, ok := c.Templates[name]; ok {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read comment template %s: %w", entry.Name(), err)
+		}
+		c.Templates[name] = string(content)
+	}
+	return nil
+}
+
+// CommentTemplate は指定されたテンプレートの本文を返す
+// 設定がnilの場合は組み込みのテンプレートを返す
+func (c *Config) CommentTemplate(name string) (string, error) {
+	messages := NewDefaultPhaseMessageConfig()
+	if c != nil {
+		if tmpl, ok := c.GitHub.CommentTemplates.Templates[name]; ok {
+			return tmpl, nil
+		}
+		messages = c.GitHub.Messages
+	}
+	switch name {
+	case CommentPhasePlan:
+		return messages.Plan, nil
+	case CommentPhaseImplement:
+		return messages.Implement, nil
+	case CommentPhaseReview:
+		return messages.Review, nil
+	}
+	if tmpl, ok := defaultCommentTemplates[name]; ok {
+		return tmpl, nil
+	}
+	return "", errors.New("unknown comment template: " + name)
+}
+
+// RenderComment はテンプレートの{{変数名}}をvarsの値で置換したコメント本文を返す
+// 未知のテンプレート名はプログラムの誤りのため空文字列を返す
+func (c *Config) RenderComment(name string, vars map[string]string) string {
+	tmpl, err := c.CommentTemplate(name)
+	if err != nil {
+		return ""
+	}
+	return ExpandCommentTemplate(tmpl, vars)
+}
+
+// ExpandCommentTemplate はテンプレート内の{{変数名}}を置換する
+func ExpandCommentTemplate(tmpl string, vars map[string]string) string {
+	if len(vars) == 0 {
+		return tmpl
+	}
+	pairs := make([]string, 0, len(vars)*2)
+	for key, value := range vars {
+		pairs = append(pairs, "{{"+key+"}}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(tmpl)
+}
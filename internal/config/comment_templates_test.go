@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_RenderComment(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates map[string]string
+		template  string
+		vars      map[string]string
+		want      string
+	}{
+		{
+			name:     "組み込みテンプレート",
+			template: CommentSubIssueBody,
+			vars:     map[string]string{"parent-number": "12"},
+			want:     "Part of #12",
+		},
+		{
+			name:      "設定で上書き",
+			templates: map[string]string{CommentSubIssueBody: "Parent: #{{parent-number}} ({{unknown}})"},
+			template:  CommentSubIssueBody,
+			vars:      map[string]string{"parent-number": "12"},
+			want:      "Parent: #12 ({{unknown}})",
+		},
+		{
+			name:     "フェーズ開始メッセージはmessagesを既定値とする",
+			template: CommentPhasePlan,
+			want:     "osoba: 計画を作成します",
+		},
+		{
+			name:     "未知のテンプレート",
+			template: "merge_done",
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.GitHub.CommentTemplates.Templates = tt.templates
+			assert.Equal(t, tt.want, cfg.RenderComment(tt.template, tt.vars))
+		})
+	}
+}
+
+func TestConfig_GetPhaseMessage_CommentTemplateOverride(t *testing.T) {
+	cfg := NewConfig()
+	cfg.GitHub.CommentTemplates.Templates = map[string]string{CommentPhaseReview: "Starting review"}
+
+	msg, found := cfg.GetPhaseMessage("review")
+	assert.True(t, found)
+	assert.Equal(t, "Starting review", msg)
+
+	msg, found = cfg.GetPhaseMessage("plan")
+	assert.True(t, found)
+	assert.Equal(t, "osoba: 計画を作成します", msg)
+}
+
+func TestCommentTemplatesConfig_LoadDir(t *testing.T) {
+	baseDir := t.TempDir()
+	dir := filepath.Join(baseDir, ".osoba", "templates")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "progress.md"), []byte("Phase {{phase}}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub_issue_body.md"), []byte("from file"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.txt"), []byte("ignored"), 0644))
+
+	c := CommentTemplatesConfig{
+		Dir:       ".osoba/templates",
+		Templates: map[string]string{CommentSubIssueBody: "from config"},
+	}
+	require.NoError(t, c.LoadDir(baseDir))
+	assert.Equal(t, "Phase {{phase}}", c.Templates[CommentProgress])
+	assert.Equal(t, "from config", c.Templates[CommentSubIssueBody])
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "merge_done.md"), []byte("typo"), 0644))
+	assert.ErrorContains(t, (&CommentTemplatesConfig{Dir: dir}).LoadDir(""), "unknown comment template file: merge_done.md")
+
+	assert.ErrorContains(t, (&CommentTemplatesConfig{Dir: "missing"}).LoadDir(baseDir), "failed to read comment templates directory")
+}
+
+func TestCommentTemplatesConfig_Validate(t *testing.T) {
+	assert.NoError(t, CommentTemplatesConfig{Templates: map[string]string{CommentProgress: "x"}}.Validate())
+
+	err := CommentTemplatesConfig{Templates: map[string]string{"merge_done": "x"}}.Validate()
+	assert.ErrorContains(t, err, `unknown comment template: "merge_done"`)
+
+	err = CommentTemplatesConfig{Templates: map[string]string{CommentSubIssues: "Sub-issues created"}}.Validate()
+	assert.ErrorContains(t, err, "must contain {{sub-issues}}")
+}
@@ -1,10 +1,15 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -15,12 +20,641 @@ import (
 
 // Config はアプリケーション全体の設定
 type Config struct {
-	GitHub     GitHubConfig         `mapstructure:"github"`
-	Tmux       TmuxConfig           `mapstructure:"tmux"`
-	Claude     *claude.ClaudeConfig `mapstructure:"claude"`
-	Log        LogConfig            `mapstructure:"log"`
-	Cleanup    CleanupConfig        `mapstructure:"cleanup"`
-	IsTestMode bool                 // テストモードかどうかを示すフラグ
+	GitHub         GitHubConfig         `mapstructure:"github"`
+	Tmux           TmuxConfig           `mapstructure:"tmux"`
+	Claude         *claude.ClaudeConfig `mapstructure:"claude"`
+	Log            LogConfig            `mapstructure:"log"`
+	Cleanup        CleanupConfig        `mapstructure:"cleanup"`
+	Worktree       WorktreeConfig       `mapstructure:"worktree"`
+	Safety         SafetyConfig         `mapstructure:"safety"`
+	Remote         RemoteConfig         `mapstructure:"remote"`
+	Container      ContainerConfig      `mapstructure:"container"`
+	Notifications  NotificationsConfig  `mapstructure:"notifications"`
+	Audit          AuditConfig          `mapstructure:"audit"`
+	ResourceGuard  ResourceGuardConfig  `mapstructure:"resource_guard"`
+	Degradation    DegradationConfig    `mapstructure:"degradation"`
+	Concurrency    ConcurrencyConfig    `mapstructure:"concurrency"`
+	Backfill       BackfillConfig       `mapstructure:"backfill"`
+	ConflictFences ConflictFencesConfig `mapstructure:"conflict_fences"`
+	RetryBudget    RetryBudgetConfig    `mapstructure:"retry_budget"`
+	Badge          BadgeConfig          `mapstructure:"badge"`
+	Heartbeat      HeartbeatConfig      `mapstructure:"heartbeat"`
+	PRMode         PRModeConfig         `mapstructure:"pr_mode"`
+	Canary         CanaryConfig         `mapstructure:"canary"`
+	Features       FeaturesConfig       `mapstructure:"features"`
+	Editor         EditorConfig         `mapstructure:"editor"`
+	// TimeZone は表示・記録する時刻のタイムゾーン（IANAの名前。空の場合はホストのタイムゾーン）
+	TimeZone   string `mapstructure:"timezone"`
+	IsTestMode bool   // テストモードかどうかを示すフラグ
+}
+
+// 確認が必要な破壊的操作
+const (
+	OperationKillWindow     = "kill_window"     // tmuxウィンドウの削除
+	OperationRemoveWorktree = "remove_worktree" // git worktreeの削除
+	OperationDeleteBranch   = "delete_branch"   // ブランチの削除
+	OperationAutoMerge      = "auto_merge"      // PRの自動マージ
+)
+
+// destructiveOperations は確認対象となる破壊的操作の一覧
+var destructiveOperations = []string{
+	OperationKillWindow,
+	OperationRemoveWorktree,
+	OperationDeleteBranch,
+	OperationAutoMerge,
+}
+
+// SafetyConfig は破壊的操作の安全設定
+type SafetyConfig struct {
+	// ConfirmDestructive が有効な場合、破壊的操作には対話的な確認か --yes の指定が必要になる
+	ConfirmDestructive bool `mapstructure:"confirm_destructive"`
+	// Allow は確認なしで実行を許可する操作の一覧（完全自動化環境向け）
+	Allow []string `mapstructure:"allow"`
+	// AssumeYes は --yes が指定されたかどうか（設定ファイルからは読み込まない）
+	AssumeYes bool `mapstructure:"-"`
+}
+
+// RequiresConfirmation は指定された操作の実行に確認が必要かを返す
+func (s SafetyConfig) RequiresConfirmation(operation string) bool {
+	if !s.ConfirmDestructive || s.AssumeYes {
+		return false
+	}
+	for _, allowed := range s.Allow {
+		if allowed == operation {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate は安全設定の妥当性を検証する
+func (s SafetyConfig) Validate() error {
+	for _, op := range s.Allow {
+		known := false
+		for _, d := range destructiveOperations {
+			if op == d {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown operation in safety.allow: %q (must be one of %s)", op, strings.Join(destructiveOperations, ", "))
+		}
+	}
+	return nil
+}
+
+// AuditConfig は変更を伴うGitHub操作の監査ログの設定
+type AuditConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Path は監査ログのパス（空の場合は ~/.local/share/osoba/audit/<リポジトリ>.jsonl）
+	Path string `mapstructure:"path"`
+}
+
+// RemoteConfig はosoba remoteで別ホストのosobaを操作するための設定
+type RemoteConfig struct {
+	// Host はssh接続先（~/.ssh/configのHost名またはuser@host）
+	Host string `mapstructure:"host"`
+	// Dir はリモートホスト上のリポジトリのパス
+	Dir string `mapstructure:"dir"`
+	// Command はリモートホスト上のosobaコマンドのパス
+	Command string `mapstructure:"command"`
+	// SSHOptions はsshに追加で渡すオプション（例: ["-p", "2222"]）
+	SSHOptions []string `mapstructure:"ssh_options"`
+}
+
+// ContainerConfig はコンテナ内でosobaを実行するための設定
+type ContainerConfig struct {
+	// RepoPath はコンテナにマウントしたリポジトリのパス（例: /workspace）
+	// 指定した場合は作業ディレクトリにかかわらず、このパスのリポジトリを対象にする（環境変数 OSOBA_REPO_PATH でも指定可能）
+	RepoPath string `mapstructure:"repo_path"`
+}
+
+// DefaultWorktreeMaxParallel はworktreeを並行して作成する上限のデフォルト値
+const DefaultWorktreeMaxParallel = 4
+
+// Issueの作業ディレクトリの作成方法
+const (
+	// WorktreeModeWorktree はgit worktreeでIssueごとの作業ディレクトリを作成する
+	WorktreeModeWorktree = "worktree"
+	// WorktreeModeClone はIssueごとのcloneを作成し、clone内でブランチを切り替える
+	WorktreeModeClone = "clone"
+)
+
+// WorktreeConfig はIssue用worktreeの設定
+type WorktreeConfig struct {
+	// Mode はIssueの作業ディレクトリの作成方法（worktree または clone）
+	// git worktreeが正しく動作しないファイルシステム（一部のネットワークマウントなど）ではcloneを指定する
+	Mode string `mapstructure:"mode"`
+	// PauseOnExternalEdits はフェーズ開始時にworktreeへ未コミットの変更（人手による編集）がある場合、
+	// そのIssueの自動フェーズ実行を一時停止するか
+	PauseOnExternalEdits bool `mapstructure:"pause_on_external_edits"`
+	// PreflightChecks はフェーズ開始前にworktreeの健全性（ブランチ・HEAD・未解決マージ・ロックファイル）を確認するか
+	PreflightChecks bool `mapstructure:"preflight_checks"`
+	// MaxParallel は1回のポーリングで複数のIssueのフェーズを開始する場合に、worktreeを並行して作成する上限（1で並行作成しない）
+	MaxParallel int `mapstructure:"max_parallel"`
+	// ScratchDir はIssueの作業ディレクトリを作成するscratch volume（tmpfsや高速なNVMeなど）のディレクトリ（空の場合はリポジトリの.git配下）
+	// 作業ディレクトリは削除する前にブランチをpushし、未コミットの変更がある場合は削除しない
+	ScratchDir string `mapstructure:"scratch_dir"`
+	// CleanPhases はフェーズの開始時にworktreeを最新のコミットの状態に戻す（git reset --hard・git clean -fdx）フェーズ
+	// 前のフェーズのビルド成果物などが残らないため、フェーズの結果を再現できる
+	CleanPhases []string `mapstructure:"clean_phases"`
+	// CleanExclude はworktreeを戻す際に削除しないパス（git clean -e のパターン、.envなど）
+	CleanExclude []string `mapstructure:"clean_exclude"`
+}
+
+// CleansPhase はフェーズの開始時にworktreeを最新のコミットの状態に戻すかを返す
+func (w WorktreeConfig) CleansPhase(phase string) bool {
+	return containsString(w.CleanPhases, phase)
+}
+
+// リソース確認の閾値のデフォルト値
+const (
+	DefaultMaxLoadPerCPU        = 2.0
+	DefaultMinAvailableMemoryMB = 1024
+)
+
+// DefaultDegradationThreshold は監視を不健全とみなす連続した失敗の回数のデフォルト値
+const DefaultDegradationThreshold = 5
+
+// DegradationConfig は監視の連続した失敗（ghコマンド・フェーズの開始）を検出する設定
+// 失敗がThreshold回続いた場合は1回だけ通知し、osoba status・ヘルスチェックで不健全として表示する
+type DegradationConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Threshold は不健全とみなす連続した失敗の回数
+	Threshold int `mapstructure:"threshold"`
+}
+
+// ResourceGuardConfig はマシンの負荷が高い場合に新しいフェーズの開始を保留する設定
+type ResourceGuardConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MaxLoadPerCPU は1分間のロードアベレージをCPUコア数で割った値の上限（0で確認しない）
+	MaxLoadPerCPU float64 `mapstructure:"max_load_per_cpu"`
+	// MinAvailableMemoryMB は利用可能なメモリの下限（MB、0で確認しない）
+	MinAvailableMemoryMB int `mapstructure:"min_available_memory_mb"`
+}
+
+// Validate はリソース確認の設定を検証する
+func (r ResourceGuardConfig) Validate() error {
+	if r.MaxLoadPerCPU < 0 {
+		return errors.New("resource_guard.max_load_per_cpu must not be negative")
+	}
+	if r.MinAvailableMemoryMB < 0 {
+		return errors.New("resource_guard.min_available_memory_mb must not be negative")
+	}
+	return nil
+}
+
+// ConcurrencyConfig はフェーズごとに同時に実行するIssueの上限（0で上限なし）
+// 上限に達している間は新しいフェーズの開始を保留し、実行中のIssueが減った後のポーリングで開始する
+type ConcurrencyConfig struct {
+	Plan      int `mapstructure:"plan"`
+	Implement int `mapstructure:"implement"`
+	Review    int `mapstructure:"review"`
+	// Estimates はIssueの見積もり（estimate:Lラベル・本文のEstimate: L）ごとの重み
+	// 設定した場合、上限は同時に実行するIssueの数ではなく、実行中のIssueの重みの合計になる
+	Estimates map[string]int `mapstructure:"estimates"`
+	// DefaultEstimate は見積もりのないIssue・重みが設定されていない見積もりのIssueの重み（0の場合は1）
+	DefaultEstimate int `mapstructure:"default_estimate"`
+}
+
+// EstimateLabelPrefix はIssueの見積もりを表すラベルの接頭辞（estimate:S・estimate:XLなど）
+const EstimateLabelPrefix = "estimate:"
+
+// UsesEstimates は上限を見積もりの重みの合計で数えるかを返す
+func (c ConcurrencyConfig) UsesEstimates() bool {
+	return len(c.Estimates) > 0
+}
+
+// EstimateWeight は見積もりの重みを返す（見積もりは大文字・小文字を区別しない）
+// 見積もりがない場合や重みが設定されていない場合はDefaultEstimateを返す
+func (c ConcurrencyConfig) EstimateWeight(estimate string) int {
+	if estimate != "" {
+		for size, weight := range c.Estimates {
+			if strings.EqualFold(size, estimate) {
+				return weight
+			}
+		}
+	}
+	if c.DefaultEstimate > 0 {
+		return c.DefaultEstimate
+	}
+	return 1
+}
+
+// Limit はフェーズの同時実行数の上限を返す（上限がない場合は0）
+func (c ConcurrencyConfig) Limit(phase string) int {
+	switch phase {
+	case PhasePlan:
+		return c.Plan
+	case PhaseImplement:
+		return c.Implement
+	case PhaseReview:
+		return c.Review
+	}
+	return 0
+}
+
+// HasLimits は同時実行数の上限が設定されているかを返す
+func (c ConcurrencyConfig) HasLimits() bool {
+	return c.Plan > 0 || c.Implement > 0 || c.Review > 0
+}
+
+// Validate は同時実行数の設定を検証する
+func (c ConcurrencyConfig) Validate() error {
+	if c.Plan < 0 || c.Implement < 0 || c.Review < 0 {
+		return errors.New("concurrency limits must not be negative")
+	}
+	for size, weight := range c.Estimates {
+		if weight <= 0 {
+			return fmt.Errorf("concurrency.estimates.%s must be positive", size)
+		}
+	}
+	if c.DefaultEstimate < 0 {
+		return errors.New("concurrency.default_estimate must not be negative")
+	}
+	return nil
+}
+
+// DefaultConflictLabel は同じ領域を実装中のIssueがあるため順番を待つIssueに付与するラベルのデフォルト
+const DefaultConflictLabel = "status:queued-conflict"
+
+// ConflictFencesConfig は同じ領域に触れるIssueの実装フェーズを同時に実行しない設定
+// 順番を待つIssueにはLabelを付与し、実装中のIssueの実装フェーズが終わった後のポーリングで開始する
+type ConflictFencesConfig struct {
+	Label string         `mapstructure:"label"` // 順番を待つIssueに付与するラベル
+	Areas []ConflictArea `mapstructure:"areas"`
+}
+
+// ConflictArea は同時に実装しない領域
+// IssueにLabelsのいずれかが付いているか、Issueの本文にPathsのいずれかが含まれる場合に領域に触れるとみなす
+type ConflictArea struct {
+	Name   string   `mapstructure:"name"`
+	Paths  []string `mapstructure:"paths"`
+	Labels []string `mapstructure:"labels"`
+}
+
+// Validate は領域の設定を検証する
+func (c *ConflictFencesConfig) Validate() error {
+	if c.Label == "" {
+		c.Label = DefaultConflictLabel
+	}
+	names := make(map[string]bool, len(c.Areas))
+	for i, area := range c.Areas {
+		if area.Name == "" {
+			return fmt.Errorf("conflict_fences.areas[%d].name is required", i)
+		}
+		if names[area.Name] {
+			return fmt.Errorf("duplicate conflict_fences area: %q", area.Name)
+		}
+		names[area.Name] = true
+		if len(area.Paths) == 0 && len(area.Labels) == 0 {
+			return fmt.Errorf("conflict_fences area %q must have paths or labels", area.Name)
+		}
+	}
+	return nil
+}
+
+// リトライが積み重なったIssueに付与するラベルのデフォルト
+const (
+	DefaultDegradedLabel = "status:degraded"
+	DefaultFailingLabel  = "status:failing"
+)
+
+// RetryBudgetConfig はIssueごとのリトライ（ghコマンドの失敗・フェーズの失敗・フェーズの再実行）の回数に応じて、
+// Issueに深刻度のラベルを付与する設定
+type RetryBudgetConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// DegradedAfter はDegradedLabelを付与するリトライの回数
+	DegradedAfter int `mapstructure:"degraded_after"`
+	// FailingAfter はDegradedLabelをFailingLabelに付け替えるリトライの回数
+	FailingAfter  int    `mapstructure:"failing_after"`
+	DegradedLabel string `mapstructure:"degraded_label"`
+	FailingLabel  string `mapstructure:"failing_label"`
+}
+
+// Validate はリトライの回数のしきい値を検証する
+func (r *RetryBudgetConfig) Validate() error {
+	if r.DegradedLabel == "" {
+		r.DegradedLabel = DefaultDegradedLabel
+	}
+	if r.FailingLabel == "" {
+		r.FailingLabel = DefaultFailingLabel
+	}
+	if !r.Enabled {
+		return nil
+	}
+	if r.DegradedAfter < 1 {
+		return errors.New("retry_budget.degraded_after must be at least 1")
+	}
+	if r.FailingAfter <= r.DegradedAfter {
+		return errors.New("retry_budget.failing_after must be greater than retry_budget.degraded_after")
+	}
+	return nil
+}
+
+// ステータスバッジのデフォルト
+const (
+	DefaultBadgeLabel     = "osoba"
+	DefaultBadgeWarnAfter = 4 * time.Hour
+	DefaultBadgeFailAfter = 24 * time.Hour
+)
+
+// BadgeConfig はパイプラインの健全性を示すステータスバッジの書き出し設定
+// 監視プロセスが状態ファイルと同じ間隔でshields.ioのendpoint JSONとSVGを書き出す
+type BadgeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Path はendpoint JSONの書き出し先（空の場合は状態ファイルと同じディレクトリ。SVGは拡張子を.svgにしたパス）
+	Path  string `mapstructure:"path"`
+	Label string `mapstructure:"label"` // バッジの左側に表示する文字列
+	// WarnAfter は待ち状態のIssue・PRがこの時間を超えて進まない場合に黄色にする
+	WarnAfter time.Duration `mapstructure:"warn_after"`
+	// FailAfter は待ち状態のIssue・PRがこの時間を超えて進まない場合に赤にする
+	FailAfter time.Duration `mapstructure:"fail_after"`
+}
+
+// Validate はステータスバッジのしきい値を検証する
+func (b *BadgeConfig) Validate() error {
+	if b.Label == "" {
+		b.Label = DefaultBadgeLabel
+	}
+	if !b.Enabled {
+		return nil
+	}
+	if b.WarnAfter <= 0 {
+		return errors.New("badge.warn_after must be positive")
+	}
+	if b.FailAfter <= b.WarnAfter {
+		return errors.New("badge.fail_after must be greater than badge.warn_after")
+	}
+	return nil
+}
+
+// エージェントのハートビートのデフォルト
+const (
+	DefaultHeartbeatInterval   = 5 * time.Minute
+	DefaultHeartbeatStuckAfter = 30 * time.Minute
+	DefaultHeartbeatLabel      = "status:needs-human"
+)
+
+// HeartbeatConfig はエージェントのハートビートによる停止の検出の設定
+// フェーズのプロンプトでworktreeの.osoba/heartbeatを定期的に更新するよう指示し、
+// ハートビートもペインの出力も更新されない状態が続いたIssueを停止とみなして人の確認を求める
+// 出力がなくてもハートビートが更新されていれば、考え中のエージェントを停止とみなさない
+type HeartbeatConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Interval はエージェントにハートビートの更新を指示する間隔（停止の検出もこの間隔で行う）
+	Interval time.Duration `mapstructure:"interval"`
+	// StuckAfter はハートビートもペインの出力も更新されない状態がこの時間続いた場合に停止とみなす
+	StuckAfter time.Duration `mapstructure:"stuck_after"`
+	Label      string        `mapstructure:"label"` // 停止を検出したIssueに付与するラベル
+}
+
+// Validate はハートビートの間隔を検証する
+func (h *HeartbeatConfig) Validate() error {
+	if h.Interval <= 0 {
+		h.Interval = DefaultHeartbeatInterval
+	}
+	if h.StuckAfter <= 0 {
+		h.StuckAfter = DefaultHeartbeatStuckAfter
+	}
+	if h.Label == "" {
+		h.Label = DefaultHeartbeatLabel
+	}
+	if h.Enabled && h.StuckAfter <= h.Interval {
+		return errors.New("heartbeat.stuck_after must be greater than heartbeat.interval")
+	}
+	return nil
+}
+
+// PRモードのラベルのデフォルト
+const (
+	DefaultPRModeReviewLabel    = "status:needs-review"
+	DefaultPRModeReviewingLabel = "status:reviewing"
+	DefaultPRModeRevisingLabel  = "status:revising"
+	DefaultPRModeApprovedLabel  = "status:pr-approved"
+)
+
+// PRModeConfig はIssueを起点とせずにPRのラベルを監視してレビュー・修正を行うモードの設定
+// 人が作成したPRにReviewLabelを付けるとレビューを、status:requires-changesを付けると修正を実行する
+// Issueを閉じるPRは通常のIssueのワークフローで扱うため対象外
+type PRModeConfig struct {
+	Enabled        bool   `mapstructure:"enabled"`
+	ReviewLabel    string `mapstructure:"review_label"`    // レビューを依頼するラベル
+	ReviewingLabel string `mapstructure:"reviewing_label"` // レビュー中に付与するラベル
+	RevisingLabel  string `mapstructure:"revising_label"`  // 修正中に付与するラベル
+	// ApprovedLabel はレビューで承認したPRに付与するラベル
+	// status:lgtmは自動マージの対象になるため、人が作成したPRには使わない
+	ApprovedLabel string `mapstructure:"approved_label"`
+}
+
+// Validate はPRモードのラベルを補完する
+func (p *PRModeConfig) Validate() error {
+	if p.ReviewLabel == "" {
+		p.ReviewLabel = DefaultPRModeReviewLabel
+	}
+	if p.ReviewingLabel == "" {
+		p.ReviewingLabel = DefaultPRModeReviewingLabel
+	}
+	if p.RevisingLabel == "" {
+		p.RevisingLabel = DefaultPRModeRevisingLabel
+	}
+	if p.ApprovedLabel == "" {
+		p.ApprovedLabel = DefaultPRModeApprovedLabel
+	}
+	if p.ReviewLabel == p.ReviewingLabel || p.ReviewLabel == p.RevisingLabel {
+		return errors.New("pr_mode.review_label must differ from the reviewing and revising labels")
+	}
+	if p.ApprovedLabel == "status:lgtm" {
+		return errors.New("pr_mode.approved_label must not be status:lgtm (it triggers auto-merge)")
+	}
+	return nil
+}
+
+// CanaryConfig は新しいプロンプトや設定を一部のIssueで試すカナリアモードの設定
+// Labelsのいずれかが付いたIssueのフェーズはこの設定で実行し、それ以外のIssueのフェーズはStableConfigの設定で実行する
+// StableConfigが空の場合は、Labelsの付いたIssueのみを自動化の対象にする
+type CanaryConfig struct {
+	Labels []string `mapstructure:"labels"` // カナリアのIssueを示すラベル（空の場合はカナリアモードを使わない）
+	// StableConfig はカナリアでないIssueのフェーズに使う設定ファイル（相対パスはこの設定ファイルのディレクトリから解決する）
+	StableConfig string `mapstructure:"stable_config"`
+}
+
+// Enabled はカナリアモードを使うかを返す
+func (c CanaryConfig) Enabled() bool {
+	return len(c.Labels) > 0
+}
+
+// Matches はラベルにカナリアのラベルが含まれるかを返す
+func (c CanaryConfig) Matches(labels []string) bool {
+	for _, l := range labels {
+		for _, canary := range c.Labels {
+			if l == canary {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Validate はカナリアのラベルを検証する
+func (c *CanaryConfig) Validate() error {
+	labels := make([]string, 0, len(c.Labels))
+	for _, l := range c.Labels {
+		if l = strings.TrimSpace(l); l != "" {
+			labels = append(labels, l)
+		}
+	}
+	c.Labels = labels
+	if c.StableConfig != "" && len(c.Labels) == 0 {
+		return errors.New("canary.stable_config requires canary.labels")
+	}
+	return nil
+}
+
+// LoadCanaryStableConfig はcanary.stable_configの設定ファイルを読み込む
+// configPathはこの設定の読み込み元のファイル（canary.stable_configの相対パスの基準）
+func (c *Config) LoadCanaryStableConfig(configPath string) (*Config, error) {
+	if c.Canary.StableConfig == "" {
+		return nil, errors.New("canary.stable_config is not set")
+	}
+	path := c.Canary.StableConfig
+	if !filepath.IsAbs(path) && configPath != "" {
+		path = filepath.Join(filepath.Dir(configPath), path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("canary.stable_config: %w", err)
+	}
+
+	stable := NewConfig()
+	if err := stable.Load(path); err != nil {
+		return nil, fmt.Errorf("failed to load canary.stable_config %s: %w", path, err)
+	}
+	if err := stable.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid canary.stable_config %s: %w", path, err)
+	}
+	return stable, nil
+}
+
+// EditorConfig はosoba open --editorでIssueのworktreeを開くエディタの設定
+type EditorConfig struct {
+	// Command はエディタのコマンド（code・cursor・nvimのプリセット名、または{{path}}・{{uri}}を含むコマンド）
+	// 空の場合はOSOBA_EDITOR・VISUAL・EDITORの順に環境変数を使う（OSOBA_EDITORは設定より優先する）
+	Command string `mapstructure:"command"`
+	// Attach はエディタで開いた後にtmuxセッションにも接続するか
+	Attach bool `mapstructure:"attach"`
+}
+
+// FeaturesConfig は自動化の機能ごとの有効/無効
+// 最初はラベルの管理のみを有効にするなど、osobaを段階的に導入するために機能を個別に切り替える
+type FeaturesConfig struct {
+	// AutoPlan は処理中のIssueがない場合に次のIssueを計画フェーズに進める（未指定の場合はgithub.auto_plan_issue）
+	AutoPlan bool `mapstructure:"auto_plan"`
+	// AutoTransitionLabels はフェーズの結果やフェーズを実行しない遷移でosobaがラベルを遷移する
+	AutoTransitionLabels bool `mapstructure:"auto_transition_labels"`
+	// AutoExecutePhases はトリガーラベルでフェーズ（Claude）を実行する
+	AutoExecutePhases bool `mapstructure:"auto_execute_phases"`
+	// AutoCreatePR は実装フェーズでPRを作成する
+	AutoCreatePR bool `mapstructure:"auto_create_pr"`
+	// AutoReview はレビューフェーズを実行する
+	AutoReview bool `mapstructure:"auto_review"`
+	// AutoMerge はstatus:lgtmのPRを自動マージする（未指定の場合はgithub.auto_merge_lgtm）
+	AutoMerge bool `mapstructure:"auto_merge"`
+	// AutoCleanup はマージ後と定期的なリソースのクリーンアップを行う（falseの場合はcleanup.enabledも無効にする）
+	AutoCleanup bool `mapstructure:"auto_cleanup"`
+}
+
+// DefaultFeatures はすべての自動化を有効にした機能の設定を返す（自動計画は従来どおり無効）
+func DefaultFeatures() FeaturesConfig {
+	return FeaturesConfig{
+		AutoTransitionLabels: true,
+		AutoExecutePhases:    true,
+		AutoCreatePR:         true,
+		AutoReview:           true,
+		AutoMerge:            true,
+		AutoCleanup:          true,
+	}
+}
+
+// PhaseEnabled はフェーズを実行してよいかを返す（実行しない場合は理由を返す）
+func (f FeaturesConfig) PhaseEnabled(phase string) (bool, string) {
+	if !f.AutoExecutePhases {
+		return false, "features.auto_execute_phases is disabled"
+	}
+	if phase == PhaseReview && !f.AutoReview {
+		return false, "features.auto_review is disabled"
+	}
+	return true, ""
+}
+
+// Disabled は既定で有効な機能のうち、無効にしているものの設定名を返す
+func (f FeaturesConfig) Disabled() []string {
+	var disabled []string
+	for _, feature := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"auto_transition_labels", f.AutoTransitionLabels},
+		{"auto_execute_phases", f.AutoExecutePhases},
+		{"auto_create_pr", f.AutoCreatePR},
+		{"auto_review", f.AutoReview},
+		{"auto_merge", f.AutoMerge},
+		{"auto_cleanup", f.AutoCleanup},
+	} {
+		if !feature.enabled {
+			disabled = append(disabled, feature.name)
+		}
+	}
+	return disabled
+}
+
+// applyFeatures は機能の設定と従来の個別の設定を揃える
+// features.*で指定された値は従来の設定より優先し、未指定の場合は従来の設定の値を使用する
+func (c *Config) applyFeatures(v *viper.Viper) {
+	if v.IsSet("features.auto_plan") {
+		c.GitHub.AutoPlanIssue = c.Features.AutoPlan
+	} else {
+		c.Features.AutoPlan = c.GitHub.AutoPlanIssue
+	}
+	if v.IsSet("features.auto_merge") {
+		c.GitHub.AutoMergeLGTM = c.Features.AutoMerge
+	} else {
+		c.Features.AutoMerge = c.GitHub.AutoMergeLGTM
+	}
+	if v.IsSet("features.auto_cleanup") {
+		if !c.Features.AutoCleanup {
+			c.Cleanup.Enabled = false
+		}
+	} else {
+		c.Features.AutoCleanup = true
+	}
+}
+
+// DefaultBackfillInterval は積み残しのIssueのフェーズを開始する間隔のデフォルト値
+const DefaultBackfillInterval = 10 * time.Minute
+
+// BackfillConfig は起動時に処理待ちだった積み残しのIssueを少しずつ開始する設定
+// 途中から導入したリポジトリで、ラベル付きのIssueのフェーズを一度に開始しないために使う
+type BackfillConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Interval は積み残しのIssueのフェーズを開始する間隔（1回につき1件）
+	Interval time.Duration `mapstructure:"interval"`
+	// Max は1回の起動で開始する積み残しのIssueの上限（0で上限なし）
+	Max int `mapstructure:"max"`
+}
+
+// Validate は積み残しの開始の設定を検証する
+func (b *BackfillConfig) Validate() error {
+	if b.Interval < 0 {
+		return errors.New("backfill.interval must not be negative")
+	}
+	if b.Max < 0 {
+		return errors.New("backfill.max must not be negative")
+	}
+	if b.Interval == 0 {
+		b.Interval = DefaultBackfillInterval
+	}
+	return nil
 }
 
 // CleanupConfig はクリーンアップ機能の設定
@@ -35,39 +669,531 @@ type IssueWindowsConfig struct {
 	Enabled bool `mapstructure:"enabled"`
 }
 
-// GitHubConfig はGitHub関連の設定
-type GitHubConfig struct {
-	PollInterval   time.Duration      `mapstructure:"poll_interval"`
-	PRPollInterval time.Duration      `mapstructure:"pr_poll_interval"` // PR監視専用のポーリング間隔
-	Labels         LabelConfig        `mapstructure:"labels"`
-	Messages       PhaseMessageConfig `mapstructure:"messages"`
-	AutoMergeLGTM  bool               `mapstructure:"auto_merge_lgtm"` // status:lgtmラベルが付いたPRを自動マージする機能の有効/無効
-	AutoPlanIssue  bool               `mapstructure:"auto_plan_issue"` // 処理中のIssueがない場合に自動的に次のIssueをplanフェーズに移行させる機能の有効/無効
-	AutoRevisePR   bool               `mapstructure:"auto_revise_pr"`  // status:requires-changesラベルが付いたPRに対して自動的にreviseアクションを実行する機能の有効/無効
+// GitHubConfig はGitHub関連の設定
+type GitHubConfig struct {
+	PollInterval   time.Duration      `mapstructure:"poll_interval"`
+	PRPollInterval time.Duration      `mapstructure:"pr_poll_interval"` // PR監視専用のポーリング間隔
+	Labels         LabelConfig        `mapstructure:"labels"`
+	Messages       PhaseMessageConfig `mapstructure:"messages"`
+	AutoMergeLGTM  bool               `mapstructure:"auto_merge_lgtm"` // status:lgtmラベルが付いたPRを自動マージする機能の有効/無効
+	// AutoMerge は自動マージを行う追加の条件
+	AutoMerge     AutoMergeConfig `mapstructure:"auto_merge"`
+	AutoPlanIssue bool            `mapstructure:"auto_plan_issue"` // 処理中のIssueがない場合に自動的に次のIssueをplanフェーズに移行させる機能の有効/無効
+	AutoRevisePR  bool            `mapstructure:"auto_revise_pr"`  // status:requires-changesラベルが付いたPRに対して自動的にreviseアクションを実行する機能の有効/無効
+	// ProgressComment は長時間フェーズ中の進捗コメント設定
+	ProgressComment ProgressCommentConfig `mapstructure:"progress_comment"`
+	// OutputSanitizer はペインの出力をコメントとして投稿する前の整形の設定
+	OutputSanitizer OutputSanitizerConfig `mapstructure:"output_sanitizer"`
+	// CommentConsolidation は短時間に続く自動コメントを1つのコメントにまとめる設定
+	CommentConsolidation CommentConsolidationConfig `mapstructure:"comment_consolidation"`
+	// IssueCache はIssueのコメント一覧をIssueの更新日時が変わるまで再利用する設定
+	IssueCache IssueCacheConfig `mapstructure:"issue_cache"`
+	// OfflineQueue はGitHubに接続できない間のコメント・ラベルの変更を保留し、接続が戻った後に反映する設定
+	OfflineQueue OfflineQueueConfig `mapstructure:"offline_queue"`
+	// SubIssues は計画のチェックリストからサブIssueを作成する設定
+	SubIssues SubIssuesConfig `mapstructure:"sub_issues"`
+	// DuplicateDetection は計画前の重複Issue検出の設定
+	DuplicateDetection DuplicateDetectionConfig `mapstructure:"duplicate_detection"`
+	// ExistingPRGuard は計画前に既存のPRで対応中のIssueを検出する設定
+	ExistingPRGuard ExistingPRGuardConfig `mapstructure:"existing_pr_guard"`
+	// PlanApproval は計画から実装へ進む前のメンテナー承認の設定
+	PlanApproval PlanApprovalConfig `mapstructure:"plan_approval"`
+	// CIGate は実装からレビューへ進む前にPRのCIの完了を待つ設定
+	CIGate CIGateConfig `mapstructure:"ci_gate"`
+	// Assignment はフェーズの実行中のIssueに担当者をアサインする設定
+	Assignment AssignmentConfig `mapstructure:"assignment"`
+	// PlanStaleness は計画後にIssue本文が編集された場合の設定
+	PlanStaleness PlanStalenessConfig `mapstructure:"plan_staleness"`
+	// PhaseResult はフェーズがworktreeに書き出す結果ファイル（.osoba/result.json）の設定
+	PhaseResult PhaseResultConfig `mapstructure:"phase_result"`
+	// RevertDetection はosobaがマージしたPRのRevertを検出する設定
+	RevertDetection RevertDetectionConfig `mapstructure:"revert_detection"`
+	// RemoteBranchCleanup は自動マージ後にリモートのブランチを削除する設定
+	RemoteBranchCleanup RemoteBranchCleanupConfig `mapstructure:"remote_branch_cleanup"`
+	// HistoryGuard はPRのブランチの履歴の書き換え（force-push）を検出して自動マージを止める設定
+	HistoryGuard HistoryGuardConfig `mapstructure:"history_guard"`
+	// ReviewEscalation はレビューと修正の往復が続くIssueを人間のレビュアーに引き継ぐ設定
+	ReviewEscalation ReviewEscalationConfig `mapstructure:"review_escalation"`
+	// ReviewBots は既存のPRレビューボットのレビューを修正に取り込む設定
+	ReviewBots ReviewBotsConfig `mapstructure:"review_bots"`
+	// WorkQueue はディレクトリに置かれた作業指示ファイルからIssueを作成する設定
+	WorkQueue WorkQueueConfig `mapstructure:"work_queue"`
+	// Workflow はラベルによる状態遷移の定義
+	Workflow WorkflowConfig `mapstructure:"workflow"`
+	// CLI はghコマンドの実行設定
+	CLI GHCLIConfig `mapstructure:"gh"`
+	// CommentTemplates はosobaが投稿するコメントのテンプレート設定
+	CommentTemplates CommentTemplatesConfig `mapstructure:"comment_templates"`
+	// Preconditions は起動時に確認するリポジトリの前提条件
+	Preconditions PreconditionsConfig `mapstructure:"preconditions"`
+	// Org は監視対象のリポジトリを検出する組織（指定時は組織モードで起動する）
+	Org string `mapstructure:"org"`
+	// OrgRepos は組織モードで監視するリポジトリの条件
+	OrgRepos OrgReposConfig `mapstructure:"org_repos"`
+}
+
+// OrgReposConfig は組織モードで監視するリポジトリの条件
+// TopicsとNamesの両方を指定した場合は、どちらにも一致するリポジトリが対象になる
+type OrgReposConfig struct {
+	// Topics はいずれかを持つリポジトリを対象とする（空の場合はすべて）
+	Topics []string `mapstructure:"topics"`
+	// Names はいずれかに一致するリポジトリを対象とするglobパターン（空の場合はすべて）
+	Names []string `mapstructure:"names"`
+	// CloneDir は検出したリポジトリをcloneするディレクトリ（空の場合は~/.local/share/osoba/repos/<org>）
+	CloneDir string `mapstructure:"clone_dir"`
+	// DiscoveryInterval はリポジトリ一覧を再取得する間隔
+	DiscoveryInterval time.Duration `mapstructure:"discovery_interval"`
+}
+
+// Matches はリポジトリが監視条件に一致するかを返す
+func (o OrgReposConfig) Matches(name string, topics []string) bool {
+	if len(o.Names) > 0 {
+		matched := false
+		for _, pattern := range o.Names {
+			if ok, _ := path.Match(pattern, name); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(o.Topics) == 0 {
+		return true
+	}
+	for _, want := range o.Topics {
+		for _, topic := range topics {
+			if topic == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SubIssuesConfig は計画のチェックリストをサブIssueに展開する設定
+// 展開した親IssueはサブIssueがすべてクローズされるまでstatus:blockedになる
+type SubIssuesConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Label   string `mapstructure:"label"` // サブIssueに付与するラベル
+}
+
+// DuplicateDetectionConfig は計画フェーズ開始前の重複Issue検出の設定
+// 重複の可能性がある場合は計画を開始せずstatus:possible-duplicateを付与する
+type DuplicateDetectionConfig struct {
+	Enabled    bool    `mapstructure:"enabled"`
+	Threshold  float64 `mapstructure:"threshold"`   // 重複とみなす類似度（0〜1）
+	MaxResults int     `mapstructure:"max_results"` // 検索で比較する既存Issueの最大件数
+}
+
+// ExistingPRGuardConfig は計画フェーズ開始前に既存のPRで対応中のIssueを検出する設定
+// オープンなPRが本文のクローズキーワード（Fixes #N など）でIssueを参照している場合は計画を開始せず、Labelを付与する
+type ExistingPRGuardConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Label   string `mapstructure:"label"` // 計画を見送ったIssueに付与するラベル
+}
+
+// AutoMergeConfig は自動マージを行う追加の条件
+// status:lgtmラベルに加えて、設定したすべての条件を満たした場合のみマージする（未設定の条件は確認しない）
+type AutoMergeConfig struct {
+	RequiredLabels []string      `mapstructure:"required_labels"` // PRに付与されている必要があるラベル
+	MinApprovals   int           `mapstructure:"min_approvals"`   // 必要な承認レビューの数
+	RequiredChecks []string      `mapstructure:"required_checks"` // 成功している必要があるチェック名
+	AllowedAuthors []string      `mapstructure:"allowed_authors"` // 自動マージを許可するPRの作成者
+	MinAge         time.Duration `mapstructure:"min_age"`         // status:lgtmラベルの付与からマージまでの最小待機時間
+	// MergeQueue はGitHubのマージキューの扱い（auto: デフォルトブランチのルールから検出 / on / off）
+	MergeQueue string `mapstructure:"merge_queue"`
+}
+
+// マージキューの扱い
+const (
+	MergeQueueAuto = "auto" // デフォルトブランチのルールセットにマージキューがある場合に使う
+	MergeQueueOn   = "on"   // 常にマージキューに追加する
+	MergeQueueOff  = "off"  // 直接マージする
+)
+
+// HasRules は追加の条件が設定されているかを返す
+func (c AutoMergeConfig) HasRules() bool {
+	return len(c.RequiredLabels) > 0 || c.MinApprovals > 0 || len(c.RequiredChecks) > 0 || len(c.AllowedAuthors) > 0 || c.MinAge > 0
+}
+
+// PlanStalenessConfig は計画後にIssue本文が編集された場合の設定
+// 実装フェーズの開始前に、実行計画コメントより後に本文が編集されていれば計画が古いとみなし、実装フェーズを開始しない
+type PlanStalenessConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	Label      string `mapstructure:"label"`       // 計画が古いIssueに付与するラベル
+	AutoReplan bool   `mapstructure:"auto_replan"` // ラベルを付与せず計画フェーズをやり直す（worktreeは維持する）
+}
+
+// PlanApprovalConfig は計画から実装フェーズへ進む前の承認の設定
+// 計画コメントへのリアクションまたは承認コメントがあるまで実装フェーズを開始しない
+type PlanApprovalConfig struct {
+	Enabled   bool     `mapstructure:"enabled"`
+	Approvers []string `mapstructure:"approvers"` // 承認できるユーザー（空の場合はwrite権限以上のユーザー）
+	Reaction  string   `mapstructure:"reaction"`  // 承認とみなす計画コメントへのリアクション
+	Comment   string   `mapstructure:"comment"`   // 承認とみなすコメント（計画コメントより後に投稿されたもの）
+}
+
+// CIGateConfig は実装フェーズの後、PRのCIチェックが完了するまでレビューフェーズを開始しない設定
+// 失敗したチェックがある場合は失敗したジョブのログをIssueにコメントして実装フェーズに戻す
+type CIGateConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Timeout     time.Duration `mapstructure:"timeout"`       // CIの完了を待つ上限（超えた場合はレビューフェーズを開始する、0の場合は無期限）
+	MaxLogLines int           `mapstructure:"max_log_lines"` // コメントに含める失敗したジョブ1件あたりのログの行数（末尾から）
+	// NoChecksGrace はPRにチェックが1件もない場合に、チェックの登録を待つ時間（超えた場合はレビューフェーズを開始する）
+	NoChecksGrace time.Duration `mapstructure:"no_checks_grace"`
+	// MaxFailures はCIの失敗で実装フェーズに戻す回数の上限（超えた場合はLabelを付与して人間に引き継ぐ、0の場合は無制限）
+	MaxFailures int    `mapstructure:"max_failures"`
+	Label       string `mapstructure:"label"` // 人間に引き継ぐ際に付与するラベル
+}
+
+// AssignmentConfig はフェーズの実行中のIssueにGitHubのユーザーを担当者としてアサインし、
+// フェーズが終わると外す設定（GitHub上で誰が・何がIssueを処理しているかを確認できるようにする）
+type AssignmentConfig struct {
+	Enabled  bool     `mapstructure:"enabled"`
+	Assignee string   `mapstructure:"assignee"` // アサインするユーザー（空の場合はosobaが認証しているユーザー）
+	Phases   []string `mapstructure:"phases"`   // アサインするフェーズ（plan / implement / review / revise、空の場合はすべて）
+}
+
+// AssignsPhase はフェーズの実行中にアサインするかを返す
+func (a AssignmentConfig) AssignsPhase(phase string) bool {
+	return len(a.Phases) == 0 || containsString(a.Phases, phase)
+}
+
+// PhaseResultConfig はフェーズがworktreeに書き出す結果ファイルの設定
+// 結果ファイルがある場合は、その内容でフェーズの成否と次のラベルを判断する
+type PhaseResultConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	FailureLabel string `mapstructure:"failure_label"` // 失敗した場合に付与するラベル
+}
+
+// RevertDetectionConfig はosobaがマージしたPRのRevertを検出する設定
+// Revertを検出した場合は元のIssueを再オープンしてラベルを付与し、RevertしたPRへのリンクをコメントする
+type RevertDetectionConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Label    string        `mapstructure:"label"`    // 元のIssueに付与するラベル
+	Interval time.Duration `mapstructure:"interval"` // マージ済みPRを確認する間隔
+	Lookback time.Duration `mapstructure:"lookback"` // 起動時に遡って確認する期間
+}
+
+// RemoteBranchCleanupConfig は自動マージとローカルのクリーンアップの後にリモートのブランチを削除する設定
+// Allowのいずれかに一致し、Denyのいずれにも一致しないブランチを削除する（保護されたブランチは削除しない）
+type RemoteBranchCleanupConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Allow   []string `mapstructure:"allow"` // 削除するブランチのglobパターン
+	Deny    []string `mapstructure:"deny"`  // 削除しないブランチのglobパターン（Allowより優先する）
+}
+
+// DefaultRemoteBranchCleanupAllow は削除するブランチのデフォルトのパターン（osobaが作成するブランチ）
+var DefaultRemoteBranchCleanupAllow = []string{"osoba/*"}
+
+// Matches はブランチが削除の対象かを返す
+func (c RemoteBranchCleanupConfig) Matches(branch string) bool {
+	for _, pattern := range c.Deny {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return false
+		}
+	}
+	for _, pattern := range c.Allow {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate はリモートのブランチの削除設定を検証する
+func (c *RemoteBranchCleanupConfig) Validate() error {
+	if len(c.Allow) == 0 {
+		c.Allow = append([]string(nil), DefaultRemoteBranchCleanupAllow...)
+	}
+	for _, pattern := range append(append([]string(nil), c.Allow...), c.Deny...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid github.remote_branch_cleanup pattern: %q", pattern)
+		}
+	}
+	return nil
+}
+
+// HistoryGuardConfig はPRのブランチの履歴の書き換え（force-push）を検出して自動マージを止める設定
+// 書き換えを検出したPRは、AllowLabelが最後の書き換えより後に付与されるまで自動マージしない
+type HistoryGuardConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	Label      string `mapstructure:"label"`       // 書き換えを検出したIssueに付与するラベル
+	AllowLabel string `mapstructure:"allow_label"` // 人が確認した後にPRに付与して自動マージを再開するラベル
+}
+
+// WorkQueueConfig はディレクトリに置かれた作業指示ファイル（YAML/JSON）からIssueを作成する設定
+// 作成したIssueにはLabelを付与し、通常のIssueと同様にフェーズを開始する
+type WorkQueueConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Dir     string `mapstructure:"dir"`   // 作業指示ファイルを置くディレクトリ（相対パスはリポジトリのルートから）
+	Label   string `mapstructure:"label"` // 作成したIssueに付与するラベル
+}
+
+// ReviewEscalationConfig はレビューと修正の往復が続くIssueを人間のレビュアーに引き継ぐ設定
+// レビューでstatus:requires-changesになった回数がMaxCyclesに達すると、自動の修正を止めて
+// Reviewersにレビューを依頼し、Labelを付与する
+type ReviewEscalationConfig struct {
+	Enabled   bool     `mapstructure:"enabled"`
+	MaxCycles int      `mapstructure:"max_cycles"` // 人間に引き継ぐまでのレビューと修正の往復回数
+	Reviewers []string `mapstructure:"reviewers"`  // レビューを依頼するユーザーまたはチーム（org/team）
+	Label     string   `mapstructure:"label"`      // 引き継いだIssueに付与するラベル
+}
+
+// ReviewBotsConfig は既存のPRレビューボット（coderabbit、copilotなど）のレビューを修正に取り込む設定
+// Accountsのボットが最新のコミットに投稿したレビューとコメントをreviseのプロンプトに渡す
+// SkipReviewが有効な場合、ボットがレビュー済みのPRではosobaのレビューフェーズを省略する
+type ReviewBotsConfig struct {
+	Enabled    bool     `mapstructure:"enabled"`
+	Accounts   []string `mapstructure:"accounts"`    // ボットのアカウント（[bot]の接尾辞は省略できる）
+	SkipReview bool     `mapstructure:"skip_review"` // ボットがレビュー済みの場合にレビューフェーズを省略する
+}
+
+// GHCLIConfig はghコマンドの実行設定
+type GHCLIConfig struct {
+	Path    string        `mapstructure:"path"`    // ghの実行ファイル（空の場合はPATHから検索する）
+	Timeout time.Duration `mapstructure:"timeout"` // 1コマンドあたりのタイムアウト
+	Args    []string      `mapstructure:"args"`    // すべてのghコマンドの末尾に付与する引数
+	// Host はghコマンドの接続先のホスト（GitHub Enterprise向け。GH_HOSTとしてghに渡す）
+	Host string `mapstructure:"host"`
+	// TokenCommand はGitHubトークンを出力するコマンド（指定時はgh auth tokenの代わりに使用する）
+	TokenCommand string `mapstructure:"token_command"`
+	// TokenRefreshInterval はトークンの変更を確認する間隔
+	TokenRefreshInterval time.Duration `mapstructure:"token_refresh_interval"`
+	// Proxy はghコマンドが使用するHTTP(S)プロキシのURL（空の場合は環境変数のHTTPS_PROXYをそのまま使う）
+	Proxy string `mapstructure:"proxy"`
+	// NoProxy はプロキシを経由しないホスト（カンマ区切り。空の場合は環境変数のNO_PROXYをそのまま使う）
+	NoProxy string `mapstructure:"no_proxy"`
+}
+
+// commandSpecificGHFlags は一部のghコマンドでしか受け付けられないフラグ
+// github.gh.argsはすべてのghコマンドに付与されるため、指定するとghの呼び出しが失敗する
+var commandSpecificGHFlags = []string{"--hostname", "--repo", "-R"}
+
+// isCommandSpecificGHFlag はargが一部のghコマンドでしか受け付けられないフラグかどうかを返す
+func isCommandSpecificGHFlag(arg string) bool {
+	for _, flag := range commandSpecificGHFlags {
+		if arg == flag || strings.HasPrefix(arg, flag+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultGHCommandTimeout はghコマンド1回あたりのタイムアウトのデフォルト値
+const DefaultGHCommandTimeout = 2 * time.Minute
+
+// DefaultTokenRefreshInterval はGitHubトークンの変更を確認する間隔のデフォルト値
+const DefaultTokenRefreshInterval = 5 * time.Minute
+
+// DefaultCIGateMaxLogLines はCIの失敗をコメントする際のジョブ1件あたりのログの行数のデフォルト値
+const DefaultCIGateMaxLogLines = 50
+
+// reactionContents はGitHubで利用できるリアクション
+var reactionContents = []string{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}
+
+func isReactionContent(content string) bool {
+	for _, r := range reactionContents {
+		if r == content {
+			return true
+		}
+	}
+	return false
+}
+
+// ProgressCommentConfig はIssueへの進捗コメント投稿の設定
+// 進捗コメントはIssueごとに1件のみ作成し、以降は同じコメントを更新する
+type ProgressCommentConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Interval  time.Duration `mapstructure:"interval"`   // 更新間隔
+	TailLines int           `mapstructure:"tail_lines"` // コメントに含めるペイン出力の行数
+}
+
+// CommentConsolidationConfig はIssueへの自動コメント（フェーズ遷移・エラーなど）をまとめる設定
+// Window以内に同じIssueへ投稿するコメントは、新しいコメントを作成せず最初のコメントに追記する
+type CommentConsolidationConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Window  time.Duration `mapstructure:"window"` // 1つのコメントにまとめる期間
+}
+
+// OfflineQueueConfig はGitHubに接続できない間の急がない変更（通知のコメント・情報のラベルの追加・ラベルの作成）を保留する設定
+// 保留した変更は次にghコマンドが成功した時に順に反映する（保留はメモリ上のみで、終了すると破棄される）
+type OfflineQueueConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Max     int      `mapstructure:"max"`    // 保留する変更の上限（超えた場合は保留せずにエラーを返す）
+	Labels  []string `mapstructure:"labels"` // 追加を保留できる情報のラベル（フェーズの遷移に使うラベルは指定できない）
+}
+
+// DefaultOfflineQueueMax は保留する変更の上限のデフォルト値
+const DefaultOfflineQueueMax = 100
+
+// IssueCacheConfig はIssueのコメント一覧のキャッシュの設定
+// ポーリングで取得したIssueの更新日時が変わるまで、同じIssueのコメント一覧の取得を1回にまとめる
+type IssueCacheConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// DefaultOutputMaxLength はコメントに含めるペイン出力の最大文字数のデフォルト
+// GitHubのコメントの上限（65536文字）からコメントの本文の分を差し引いた値
+const DefaultOutputMaxLength = 60000
+
+// OutputSanitizerConfig はペインの出力をIssue・PRのコメントとして投稿する前の整形の設定
+// ANSIエスケープシーケンスの除去とスピナーの行の集約は常に行う
+type OutputSanitizerConfig struct {
+	// RedactPatterns は伏せ字にする値の正規表現（GitHubトークンなどの組み込みのパターンに追加する）
+	RedactPatterns []string `mapstructure:"redact_patterns"`
+	// MaxLength はコメントに含める出力の最大文字数（超えた場合は末尾を残す）
+	MaxLength int `mapstructure:"max_length"`
+}
+
+// LabelConfig は監視対象のラベル設定
+type LabelConfig struct {
+	Plan            string `mapstructure:"plan"`
+	Ready           string `mapstructure:"ready"`
+	Review          string `mapstructure:"review"`
+	RequiresChanges string `mapstructure:"requires_changes"`
+	Revising        string `mapstructure:"revising"`
+}
+
+// PhaseMessageConfig はフェーズ開始時のコメントメッセージ設定
+type PhaseMessageConfig struct {
+	Plan      string `mapstructure:"plan"`
+	Implement string `mapstructure:"implement"`
+	Review    string `mapstructure:"review"`
+}
+
+// TmuxConfig はtmux関連の設定
+type TmuxConfig struct {
+	SessionPrefix     string `mapstructure:"session_prefix"`
+	MaxPanesPerWindow int    `mapstructure:"max_panes_per_window"`
+	LimitPanesEnabled bool   `mapstructure:"limit_panes_enabled"`
+	AutoResizePanes   bool   `mapstructure:"auto_resize_panes"`
+	PaneSplit         string `mapstructure:"pane_split"`
+	// MinPaneWidth・MinPaneHeight は分割後のペインの最小サイズ（下回る場合はフェーズ専用ウィンドウに作成する、0で無効）
+	MinPaneWidth  int `mapstructure:"min_pane_width"`
+	MinPaneHeight int `mapstructure:"min_pane_height"`
+	// AutoAttach はosoba start後に自動でtmuxセッションへ接続するか
+	AutoAttach bool `mapstructure:"auto_attach"`
+	// Phases はフェーズごとのペイン・ウィンドウ利用ポリシー（キー: plan, implement, review, revise）
+	Phases map[string]PhasePaneConfig `mapstructure:"phases"`
+	// Shards はラベル・マイルストーンでIssueのウィンドウを振り分けるセッション（上から順に判定する）
+	Shards []SessionShardConfig `mapstructure:"shards"`
+	// Keybindings はosobaのセッションで使うメニューのキーバインド
+	Keybindings TmuxKeybindingsConfig `mapstructure:"keybindings"`
+	// StatusOptions はIssueの集計をセッションのユーザーオプション（@osoba_active など）としてステータスラインに公開するか
+	StatusOptions bool `mapstructure:"status_options"`
+}
+
+// DefaultTmuxKeybindingKey はosobaのメニューを開くキー（prefixキーの後に押す）のデフォルト値
+const DefaultTmuxKeybindingKey = "O"
+
+// TmuxKeybindingsConfig はosobaのメニュー（Issueウィンドウへの移動、ウィンドウを閉じる、IssueのURL表示）のキーバインド設定
+type TmuxKeybindingsConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Key     string `mapstructure:"key"` // prefixキーの後に押すキー（デフォルト: DefaultTmuxKeybindingKey）
+}
+
+// shardNamePattern はシャード名に使える文字（tmuxのセッション名で区切り文字として扱われる"."と":"を除く）
+var shardNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// SessionShardConfig はIssueのウィンドウを振り分けるセッション
+// セッション名は"<session_prefix><リポジトリ名>-<name>"になる
+type SessionShardConfig struct {
+	Name       string   `mapstructure:"name"`
+	Labels     []string `mapstructure:"labels"`     // いずれかのラベルを持つIssueを振り分ける
+	Milestones []string `mapstructure:"milestones"` // いずれかのマイルストーンのIssueを振り分ける
+}
+
+// Matches はIssueのラベル・マイルストーンが振り分け条件に一致するかを返す
+func (s SessionShardConfig) Matches(labels []string, milestone string) bool {
+	for _, want := range s.Labels {
+		for _, label := range labels {
+			if label == want {
+				return true
+			}
+		}
+	}
+	for _, want := range s.Milestones {
+		if milestone != "" && milestone == want {
+			return true
+		}
+	}
+	return false
+}
+
+// PhasePaneConfig はフェーズごとのペイン・ウィンドウ利用ポリシー
+type PhasePaneConfig struct {
+	// Pane は既存ペインの扱い（reuse: そのまま再利用 / replace: クリアして再利用 / append: 常に新規ペイン）
+	Pane string `mapstructure:"pane"`
+	// Window はウィンドウの扱い（shared: Issueウィンドウを共有 / separate: フェーズ専用ウィンドウ）
+	Window string `mapstructure:"window"`
+	// ReapAfter はフェーズ完了後、ペインが無操作のまま経過したら出力を保存して削除するまでの時間（0で無効）
+	ReapAfter time.Duration `mapstructure:"reap_after"`
+}
+
+// ペイン分割方向
+const (
+	PaneSplitHorizontal = "horizontal"
+	PaneSplitVertical   = "vertical"
+	PaneSplitAuto       = "auto"
+)
+
+// ペイン利用ポリシー
+const (
+	PanePolicyReuse   = "reuse"
+	PanePolicyReplace = "replace"
+	PanePolicyAppend  = "append"
+)
+
+// ウィンドウ利用ポリシー
+const (
+	WindowPolicyShared   = "shared"
+	WindowPolicySeparate = "separate"
+)
+
+// GetPhasePaneConfig は指定フェーズのペインポリシーを返す
+// 未設定の項目にはデフォルト値（reuse / shared）を補完する
+func (t TmuxConfig) GetPhasePaneConfig(phase string) PhasePaneConfig {
+	pc := t.Phases[phase]
+	if pc.Pane == "" {
+		pc.Pane = PanePolicyReuse
+	}
+	if pc.Window == "" {
+		pc.Window = WindowPolicyShared
+	}
+	return pc
 }
 
-// LabelConfig は監視対象のラベル設定
-type LabelConfig struct {
-	Plan            string `mapstructure:"plan"`
-	Ready           string `mapstructure:"ready"`
-	Review          string `mapstructure:"review"`
-	RequiresChanges string `mapstructure:"requires_changes"`
-	Revising        string `mapstructure:"revising"`
+// ShardSessionName はIssueのウィンドウを作成するセッション名を返す
+// いずれのシャードにも一致しない場合はbaseSessionを返す
+func (t TmuxConfig) ShardSessionName(baseSession string, labels []string, milestone string) string {
+	for _, shard := range t.Shards {
+		if shard.Matches(labels, milestone) {
+			return baseSession + "-" + shard.Name
+		}
+	}
+	return baseSession
 }
 
-// PhaseMessageConfig はフェーズ開始時のコメントメッセージ設定
-type PhaseMessageConfig struct {
-	Plan      string `mapstructure:"plan"`
-	Implement string `mapstructure:"implement"`
-	Review    string `mapstructure:"review"`
+// SessionNames はbaseSessionとすべてのシャードのセッション名を返す
+func (t TmuxConfig) SessionNames(baseSession string) []string {
+	names := []string{baseSession}
+	for _, shard := range t.Shards {
+		names = append(names, baseSession+"-"+shard.Name)
+	}
+	return names
 }
 
-// TmuxConfig はtmux関連の設定
-type TmuxConfig struct {
-	SessionPrefix     string `mapstructure:"session_prefix"`
-	MaxPanesPerWindow int    `mapstructure:"max_panes_per_window"`
-	LimitPanesEnabled bool   `mapstructure:"limit_panes_enabled"`
-	AutoResizePanes   bool   `mapstructure:"auto_resize_panes"`
+// PaneReaperEnabled はいずれかのフェーズでペインの自動削除が有効かを返す
+func (t TmuxConfig) PaneReaperEnabled() bool {
+	for _, pc := range t.Phases {
+		if pc.ReapAfter > 0 {
+			return true
+		}
+	}
+	return false
 }
 
 // LogConfig はログ関連の設定
@@ -108,15 +1234,117 @@ func NewConfig() *Config {
 				Revising:        "status:revising",
 			},
 			Messages:      NewDefaultPhaseMessageConfig(),
-			AutoMergeLGTM: true,  // デフォルトで自動マージ機能を有効化
+			AutoMergeLGTM: true, // デフォルトで自動マージ機能を有効化
+			AutoMerge: AutoMergeConfig{
+				MergeQueue: MergeQueueAuto,
+			},
 			AutoPlanIssue: false, // デフォルトで自動計画機能を無効化
 			AutoRevisePR:  true,  // デフォルトで自動Revise機能を有効化
+			ProgressComment: ProgressCommentConfig{
+				Enabled:   false,
+				Interval:  5 * time.Minute,
+				TailLines: 20,
+			},
+			OutputSanitizer: OutputSanitizerConfig{
+				MaxLength: DefaultOutputMaxLength,
+			},
+			CommentConsolidation: CommentConsolidationConfig{
+				Enabled: false,
+				Window:  10 * time.Minute,
+			},
+			IssueCache: IssueCacheConfig{
+				Enabled: true,
+			},
+			OfflineQueue: OfflineQueueConfig{
+				Enabled: false,
+				Max:     DefaultOfflineQueueMax,
+			},
+			SubIssues: SubIssuesConfig{
+				Enabled: false,
+				Label:   "status:needs-plan",
+			},
+			DuplicateDetection: DuplicateDetectionConfig{
+				Enabled:    false,
+				Threshold:  0.6,
+				MaxResults: 5,
+			},
+			ExistingPRGuard: ExistingPRGuardConfig{
+				Enabled: true,
+				Label:   "status:awaiting-existing-pr",
+			},
+			PlanStaleness: PlanStalenessConfig{
+				Enabled: true,
+				Label:   "status:plan-stale",
+			},
+			PlanApproval: PlanApprovalConfig{
+				Enabled:  false,
+				Reaction: "+1",
+				Comment:  "/approve",
+			},
+			CIGate: CIGateConfig{
+				Enabled:       false,
+				Timeout:       time.Hour,
+				MaxLogLines:   DefaultCIGateMaxLogLines,
+				NoChecksGrace: 5 * time.Minute,
+				MaxFailures:   3,
+				Label:         "status:needs-human",
+			},
+			Assignment: AssignmentConfig{
+				Enabled: false,
+			},
+			PhaseResult: PhaseResultConfig{
+				Enabled:      true,
+				FailureLabel: "status:manual",
+			},
+			RevertDetection: RevertDetectionConfig{
+				Enabled:  true,
+				Label:    "status:reverted",
+				Interval: 5 * time.Minute,
+				Lookback: 24 * time.Hour,
+			},
+			RemoteBranchCleanup: RemoteBranchCleanupConfig{
+				Allow: append([]string(nil), DefaultRemoteBranchCleanupAllow...),
+			},
+			HistoryGuard: HistoryGuardConfig{
+				Enabled:    true,
+				Label:      "status:needs-human",
+				AllowLabel: "status:history-reviewed",
+			},
+			ReviewEscalation: ReviewEscalationConfig{
+				Enabled:   true,
+				MaxCycles: 3,
+				Label:     "status:needs-human",
+			},
+			WorkQueue: WorkQueueConfig{
+				Enabled: true,
+				Dir:     ".osoba/queue",
+				Label:   "status:needs-plan",
+			},
+			CLI: GHCLIConfig{
+				Timeout:              DefaultGHCommandTimeout,
+				TokenRefreshInterval: DefaultTokenRefreshInterval,
+			},
+			Preconditions: PreconditionsConfig{
+				BranchProtection: PreconditionWarn,
+				CIWorkflow:       PreconditionWarn,
+				Codeowners:       PreconditionWarn,
+			},
+			OrgRepos: OrgReposConfig{
+				DiscoveryInterval: 10 * time.Minute,
+			},
 		},
 		Tmux: TmuxConfig{
 			SessionPrefix:     sessionPrefix,
 			MaxPanesPerWindow: 3,
 			LimitPanesEnabled: true,
 			AutoResizePanes:   true,
+			PaneSplit:         PaneSplitHorizontal,
+			MinPaneWidth:      0,
+			MinPaneHeight:     0,
+			Keybindings: TmuxKeybindingsConfig{
+				Key: DefaultTmuxKeybindingKey,
+			},
+			StatusOptions: true,
 		},
 		Claude: claude.NewDefaultClaudeConfig(),
 		Log: LogConfig{
@@ -130,6 +1358,62 @@ func NewConfig() *Config {
 				Enabled: true,
 			},
 		},
+		Worktree: WorktreeConfig{
+			Mode:                 WorktreeModeWorktree,
+			PauseOnExternalEdits: true,
+			PreflightChecks:      true,
+			MaxParallel:          DefaultWorktreeMaxParallel,
+		},
+		Remote: RemoteConfig{
+			Command: "osoba",
+		},
+		Notifications: NotificationsConfig{
+			Email: EmailNotificationConfig{
+				Port: DefaultSMTPPort,
+			},
+		},
+		Audit: AuditConfig{
+			Enabled: true,
+		},
+		ResourceGuard: ResourceGuardConfig{
+			Enabled:              false,
+			MaxLoadPerCPU:        DefaultMaxLoadPerCPU,
+			MinAvailableMemoryMB: DefaultMinAvailableMemoryMB,
+		},
+		Degradation: DegradationConfig{
+			Enabled:   true,
+			Threshold: DefaultDegradationThreshold,
+		},
+		Backfill: BackfillConfig{
+			Interval: DefaultBackfillInterval,
+		},
+		RetryBudget: RetryBudgetConfig{
+			Enabled:       false,
+			DegradedAfter: 3,
+			FailingAfter:  6,
+			DegradedLabel: DefaultDegradedLabel,
+			FailingLabel:  DefaultFailingLabel,
+		},
+		Badge: BadgeConfig{
+			Label:     DefaultBadgeLabel,
+			WarnAfter: DefaultBadgeWarnAfter,
+			FailAfter: DefaultBadgeFailAfter,
+		},
+		Heartbeat: HeartbeatConfig{
+			Interval:   DefaultHeartbeatInterval,
+			StuckAfter: DefaultHeartbeatStuckAfter,
+			Label:      DefaultHeartbeatLabel,
+		},
+		PRMode: PRModeConfig{
+			ReviewLabel:    DefaultPRModeReviewLabel,
+			ReviewingLabel: DefaultPRModeReviewingLabel,
+			RevisingLabel:  DefaultPRModeRevisingLabel,
+			ApprovedLabel:  DefaultPRModeApprovedLabel,
+		},
+		ConflictFences: ConflictFencesConfig{
+			Label: DefaultConflictLabel,
+		},
+		Features:   DefaultFeatures(),
 		IsTestMode: isTestMode,
 	}
 }
@@ -150,6 +1434,8 @@ func (c *Config) Load(configPath string) error {
 	// ログレベルの環境変数バインド
 	v.BindEnv("log.level", "OSOBA_LOG_LEVEL")
 	v.BindEnv("log.format", "OSOBA_LOG_FORMAT")
+	v.BindEnv("container.repo_path", "OSOBA_REPO_PATH")
+	v.BindEnv("notifications.email.password", "OSOBA_SMTP_PASSWORD")
 
 	// デフォルト値の設定
 	v.SetDefault("github.poll_interval", 20*time.Second)
@@ -163,10 +1449,73 @@ func (c *Config) Load(configPath string) error {
 	v.SetDefault("github.messages.implement", "osoba: 実装を開始します")
 	v.SetDefault("github.messages.review", "osoba: レビューを開始します")
 	v.SetDefault("github.auto_merge_lgtm", true)
+	v.SetDefault("github.auto_merge.merge_queue", MergeQueueAuto)
 	v.SetDefault("github.auto_plan_issue", false)
 	v.SetDefault("github.auto_revise_pr", true)
+	v.SetDefault("github.progress_comment.enabled", false)
+	v.SetDefault("github.progress_comment.interval", 5*time.Minute)
+	v.SetDefault("github.progress_comment.tail_lines", 20)
+	v.SetDefault("github.comment_consolidation.enabled", false)
+	v.SetDefault("github.comment_consolidation.window", 10*time.Minute)
+	v.SetDefault("github.issue_cache.enabled", true)
+	v.SetDefault("github.offline_queue.enabled", false)
+	v.SetDefault("github.offline_queue.max", DefaultOfflineQueueMax)
+	v.SetDefault("github.output_sanitizer.max_length", DefaultOutputMaxLength)
+	v.SetDefault("github.sub_issues.enabled", false)
+	v.SetDefault("github.sub_issues.label", "status:needs-plan")
+	v.SetDefault("github.duplicate_detection.enabled", false)
+	v.SetDefault("github.duplicate_detection.threshold", 0.6)
+	v.SetDefault("github.duplicate_detection.max_results", 5)
+	v.SetDefault("github.existing_pr_guard.enabled", true)
+	v.SetDefault("github.existing_pr_guard.label", "status:awaiting-existing-pr")
+	v.SetDefault("github.plan_staleness.enabled", true)
+	v.SetDefault("github.plan_staleness.label", "status:plan-stale")
+	v.SetDefault("github.plan_approval.enabled", false)
+	v.SetDefault("github.plan_approval.reaction", "+1")
+	v.SetDefault("github.plan_approval.comment", "/approve")
+	v.SetDefault("github.ci_gate.enabled", false)
+	v.SetDefault("github.ci_gate.timeout", time.Hour)
+	v.SetDefault("github.ci_gate.max_log_lines", DefaultCIGateMaxLogLines)
+	v.SetDefault("github.ci_gate.no_checks_grace", 5*time.Minute)
+	v.SetDefault("github.ci_gate.max_failures", 3)
+	v.SetDefault("github.ci_gate.label", "status:needs-human")
+	v.SetDefault("github.assignment.enabled", false)
+	v.SetDefault("github.phase_result.enabled", true)
+	v.SetDefault("github.phase_result.failure_label", "status:manual")
+	v.SetDefault("github.revert_detection.enabled", true)
+	v.SetDefault("github.revert_detection.label", "status:reverted")
+	v.SetDefault("github.revert_detection.interval", 5*time.Minute)
+	v.SetDefault("github.revert_detection.lookback", 24*time.Hour)
+	v.SetDefault("github.remote_branch_cleanup.enabled", false)
+	v.SetDefault("github.remote_branch_cleanup.allow", DefaultRemoteBranchCleanupAllow)
+	v.SetDefault("github.history_guard.enabled", true)
+	v.SetDefault("github.history_guard.label", "status:needs-human")
+	v.SetDefault("github.history_guard.allow_label", "status:history-reviewed")
+	v.SetDefault("github.review_escalation.enabled", true)
+	v.SetDefault("github.review_escalation.max_cycles", 3)
+	v.SetDefault("github.review_escalation.label", "status:needs-human")
+	v.SetDefault("github.work_queue.enabled", true)
+	v.SetDefault("github.work_queue.dir", ".osoba/queue")
+	v.SetDefault("github.work_queue.label", "status:needs-plan")
+	v.SetDefault("github.review_bots.enabled", false)
+	v.SetDefault("github.review_bots.skip_review", false)
+	v.SetDefault("github.gh.timeout", DefaultGHCommandTimeout)
+	v.SetDefault("github.gh.token_refresh_interval", DefaultTokenRefreshInterval)
+	v.SetDefault("github.preconditions.branch_protection", PreconditionWarn)
+	v.SetDefault("github.preconditions.ci_workflow", PreconditionWarn)
+	v.SetDefault("github.preconditions.codeowners", PreconditionWarn)
+	v.SetDefault("github.org_repos.discovery_interval", 10*time.Minute)
 	v.SetDefault("tmux.session_prefix", "osoba-")
 	v.SetDefault("tmux.auto_resize_panes", true)
+	v.SetDefault("tmux.max_panes_per_window", 3)
+	v.SetDefault("tmux.limit_panes_enabled", true)
+	v.SetDefault("tmux.pane_split", PaneSplitHorizontal)
+	v.SetDefault("tmux.min_pane_width", 0)
+	v.SetDefault("tmux.min_pane_height", 0)
+	v.SetDefault("tmux.auto_attach", false)
+	v.SetDefault("tmux.keybindings.enabled", false)
+	v.SetDefault("tmux.keybindings.key", DefaultTmuxKeybindingKey)
+	v.SetDefault("tmux.status_options", true)
 
 	// ログ設定のデフォルト値
 	v.SetDefault("log.level", "info")
@@ -177,6 +1526,63 @@ func (c *Config) Load(configPath string) error {
 	v.SetDefault("cleanup.interval_minutes", 5)
 	v.SetDefault("cleanup.issue_windows.enabled", true)
 
+	// Worktree設定のデフォルト値
+	v.SetDefault("worktree.mode", WorktreeModeWorktree)
+	v.SetDefault("worktree.pause_on_external_edits", true)
+	v.SetDefault("worktree.preflight_checks", true)
+	v.SetDefault("worktree.max_parallel", DefaultWorktreeMaxParallel)
+
+	// Safety設定のデフォルト値
+	v.SetDefault("safety.confirm_destructive", false)
+
+	// Remote設定のデフォルト値
+	v.SetDefault("remote.command", "osoba")
+
+	// 通知設定のデフォルト値
+	v.SetDefault("notifications.email.enabled", false)
+	v.SetDefault("notifications.email.port", DefaultSMTPPort)
+	v.SetDefault("audit.enabled", true)
+
+	// リソース確認のデフォルト値
+	v.SetDefault("resource_guard.enabled", false)
+	v.SetDefault("resource_guard.max_load_per_cpu", DefaultMaxLoadPerCPU)
+	v.SetDefault("resource_guard.min_available_memory_mb", DefaultMinAvailableMemoryMB)
+
+	// 監視の不健全な状態の検出のデフォルト値
+	v.SetDefault("degradation.enabled", true)
+	v.SetDefault("degradation.threshold", DefaultDegradationThreshold)
+
+	// 積み残しの開始のデフォルト値
+	v.SetDefault("backfill.interval", DefaultBackfillInterval)
+	v.SetDefault("conflict_fences.label", DefaultConflictLabel)
+	v.SetDefault("retry_budget.enabled", false)
+	v.SetDefault("retry_budget.degraded_after", 3)
+	v.SetDefault("retry_budget.failing_after", 6)
+	v.SetDefault("retry_budget.degraded_label", DefaultDegradedLabel)
+	v.SetDefault("retry_budget.failing_label", DefaultFailingLabel)
+	v.SetDefault("badge.enabled", false)
+	v.SetDefault("badge.label", DefaultBadgeLabel)
+	v.SetDefault("badge.warn_after", DefaultBadgeWarnAfter)
+	v.SetDefault("badge.fail_after", DefaultBadgeFailAfter)
+	v.SetDefault("heartbeat.enabled", false)
+	v.SetDefault("heartbeat.interval", DefaultHeartbeatInterval)
+	v.SetDefault("heartbeat.stuck_after", DefaultHeartbeatStuckAfter)
+	v.SetDefault("heartbeat.label", DefaultHeartbeatLabel)
+	v.SetDefault("pr_mode.enabled", false)
+	v.SetDefault("pr_mode.review_label", DefaultPRModeReviewLabel)
+	v.SetDefault("pr_mode.reviewing_label", DefaultPRModeReviewingLabel)
+	v.SetDefault("pr_mode.revising_label", DefaultPRModeRevisingLabel)
+	v.SetDefault("pr_mode.approved_label", DefaultPRModeApprovedLabel)
+
+	// 機能ごとの有効/無効のデフォルト値（auto_plan・auto_merge・auto_cleanupは従来の設定から決める）
+	v.SetDefault("features.auto_transition_labels", true)
+	v.SetDefault("features.auto_execute_phases", true)
+	v.SetDefault("features.auto_create_pr", true)
+	v.SetDefault("features.auto_review", true)
+
+	// エディタ設定のデフォルト値
+	v.SetDefault("editor.attach", false)
+
 	// Claude設定のデフォルト値
 	v.SetDefault("claude.phases.plan.args", []string{"--dangerously-skip-permissions"})
 	v.SetDefault("claude.phases.plan.prompt", "/osoba:plan {{issue-number}}")
@@ -186,9 +1592,16 @@ func (c *Config) Load(configPath string) error {
 	v.SetDefault("claude.phases.review.prompt", "/osoba:review {{issue-number}}")
 	v.SetDefault("claude.phases.revise.args", []string{"--dangerously-skip-permissions"})
 	v.SetDefault("claude.phases.revise.prompt", "/osoba:revise {{issue-number}}")
+	v.SetDefault("claude.environment_bootstrap", false)
+	v.SetDefault("claude.ready_timeout", claude.DefaultReadyTimeout)
+
+	// 設定ファイルを読み込む（sopsで暗号化されている場合は復号する）
+	if err := readConfigFile(v, configPath); err != nil {
+		return err
+	}
 
-	// 設定ファイルを読み込む
-	if err := v.ReadInConfig(); err != nil {
+	// ageで暗号化された値を復号する
+	if err := decryptValues(v); err != nil {
 		return err
 	}
 
@@ -196,6 +1609,12 @@ func (c *Config) Load(configPath string) error {
 	if err := v.Unmarshal(c); err != nil {
 		return err
 	}
+	c.applyFeatures(v)
+
+	// コメントテンプレートのディレクトリは設定ファイルからの相対パスで解決する
+	if err := c.GitHub.CommentTemplates.LoadDir(filepath.Dir(configPath)); err != nil {
+		return err
+	}
 
 	// テストモードの場合、セッションプレフィックスを上書き
 	if os.Getenv("OSOBA_TEST_MODE") == "true" {
@@ -211,7 +1630,15 @@ func (c *Config) Load(configPath string) error {
 // LoadOrDefault は設定ファイルを読み込み、失敗した場合はデフォルト値を使用する
 // 実際に読み込んだファイルパスを返す（読み込まなかった場合は空文字列）
 func (c *Config) LoadOrDefault(configPath string) string {
+	actualPath, _ := c.LoadOrDefaultWithError(configPath)
+	return actualPath
+}
+
+// LoadOrDefaultWithError はLoadOrDefaultと同様に設定ファイルを読み込むが、
+// 設定ファイルが存在するのに読み込めない場合はデフォルト値を設定したうえでエラーを返す
+func (c *Config) LoadOrDefaultWithError(configPath string) (string, error) {
 	actualPath := configPath
+	var loadErr error
 
 	// configPathが空の場合はカレントディレクトリのデフォルトパスを試す
 	if configPath == "" {
@@ -232,11 +1659,12 @@ func (c *Config) LoadOrDefault(configPath string) string {
 	// 設定ファイルが見つかった場合は読み込む
 	if actualPath != "" {
 		if _, err := os.Stat(actualPath); err == nil {
-			// 設定ファイルを読み込む（エラーは無視）
-			if err := c.Load(actualPath); err == nil {
+			err := c.Load(actualPath)
+			if err == nil {
 				// 読み込み成功時のみパスを返す
-				return actualPath
+				return actualPath, nil
 			}
+			loadErr = fmt.Errorf("failed to load config file %s: %w", actualPath, err)
 		}
 	}
 
@@ -254,7 +1682,7 @@ func (c *Config) LoadOrDefault(configPath string) string {
 		c.Tmux.SessionPrefix = "test-osoba-"
 	}
 
-	return ""
+	return "", loadErr
 }
 
 // Validate は設定の妥当性を検証する
@@ -287,10 +1715,237 @@ func (c *Config) Validate() error {
 		c.GitHub.Labels.Revising = "status:revising"
 	}
 
+	if c.GitHub.ProgressComment.Enabled && c.GitHub.ProgressComment.Interval < 10*time.Second {
+		return errors.New("progress comment interval must be at least 10 seconds")
+	}
+	if c.GitHub.CommentConsolidation.Enabled && c.GitHub.CommentConsolidation.Window < time.Minute {
+		return errors.New("comment consolidation window must be at least 1 minute")
+	}
+	if c.GitHub.OutputSanitizer.MaxLength <= 0 {
+		c.GitHub.OutputSanitizer.MaxLength = DefaultOutputMaxLength
+	}
+	for _, pattern := range c.GitHub.OutputSanitizer.RedactPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid output_sanitizer redact pattern %q: %w", pattern, err)
+		}
+	}
+	if c.GitHub.SubIssues.Label == "" {
+		c.GitHub.SubIssues.Label = "status:needs-plan"
+	}
+	if c.GitHub.DuplicateDetection.Enabled && (c.GitHub.DuplicateDetection.Threshold <= 0 || c.GitHub.DuplicateDetection.Threshold > 1) {
+		return errors.New("duplicate detection threshold must be greater than 0 and at most 1")
+	}
+	if c.GitHub.DuplicateDetection.MaxResults <= 0 {
+		c.GitHub.DuplicateDetection.MaxResults = 5
+	}
+	if c.GitHub.ExistingPRGuard.Label == "" {
+		c.GitHub.ExistingPRGuard.Label = "status:awaiting-existing-pr"
+	}
+	if c.GitHub.PlanStaleness.Label == "" {
+		c.GitHub.PlanStaleness.Label = "status:plan-stale"
+	}
+	if c.GitHub.AutoMerge.MinApprovals < 0 {
+		return errors.New("auto merge min_approvals must not be negative")
+	}
+	if c.GitHub.AutoMerge.MinAge < 0 {
+		return errors.New("auto merge min_age must not be negative")
+	}
+	switch c.GitHub.AutoMerge.MergeQueue {
+	case "":
+		c.GitHub.AutoMerge.MergeQueue = MergeQueueAuto
+	case MergeQueueAuto, MergeQueueOn, MergeQueueOff:
+	default:
+		return fmt.Errorf("invalid github.auto_merge.merge_queue: %q (must be auto, on or off)", c.GitHub.AutoMerge.MergeQueue)
+	}
+	if c.GitHub.PhaseResult.FailureLabel == "" {
+		c.GitHub.PhaseResult.FailureLabel = "status:manual"
+	}
+	if c.GitHub.RevertDetection.Label == "" {
+		c.GitHub.RevertDetection.Label = "status:reverted"
+	}
+	if c.GitHub.RevertDetection.Enabled && c.GitHub.RevertDetection.Interval < 30*time.Second {
+		return errors.New("revert detection interval must be at least 30 seconds")
+	}
+	if err := c.GitHub.RemoteBranchCleanup.Validate(); err != nil {
+		return err
+	}
+	if c.GitHub.HistoryGuard.Label == "" {
+		c.GitHub.HistoryGuard.Label = "status:needs-human"
+	}
+	if c.GitHub.HistoryGuard.AllowLabel == "" {
+		c.GitHub.HistoryGuard.AllowLabel = "status:history-reviewed"
+	}
+	if c.GitHub.ReviewEscalation.Label == "" {
+		c.GitHub.ReviewEscalation.Label = "status:needs-human"
+	}
+	if c.GitHub.ReviewEscalation.Enabled && c.GitHub.ReviewEscalation.MaxCycles <= 0 {
+		return errors.New("review escalation max_cycles must be at least 1")
+	}
+	if c.GitHub.ReviewBots.Enabled && len(c.GitHub.ReviewBots.Accounts) == 0 {
+		return errors.New("review bots accounts are required when review_bots is enabled")
+	}
+	if c.GitHub.WorkQueue.Dir == "" {
+		c.GitHub.WorkQueue.Dir = ".osoba/queue"
+	}
+	if c.GitHub.WorkQueue.Label == "" {
+		c.GitHub.WorkQueue.Label = "status:needs-plan"
+	}
+	// 既定の遷移は設定ファイルの読み込み後に補う（リストは既存の値と要素ごとにマージされてしまうため）
+	if len(c.GitHub.Workflow.Transitions) == 0 {
+		c.GitHub.Workflow.Transitions = DefaultWorkflowTransitions()
+	}
+	if err := c.GitHub.Workflow.Validate(); err != nil {
+		return err
+	}
+	if c.GitHub.CLI.Timeout < 0 {
+		return errors.New("gh command timeout must not be negative")
+	}
+	if c.GitHub.CLI.Timeout == 0 {
+		c.GitHub.CLI.Timeout = DefaultGHCommandTimeout
+	}
+	if c.GitHub.CLI.TokenRefreshInterval < 0 {
+		return errors.New("token refresh interval must not be negative")
+	}
+	if c.GitHub.CLI.TokenRefreshInterval == 0 {
+		c.GitHub.CLI.TokenRefreshInterval = DefaultTokenRefreshInterval
+	}
+	for _, arg := range c.GitHub.CLI.Args {
+		if isCommandSpecificGHFlag(arg) {
+			return fmt.Errorf("gh args must not contain %q: it is not accepted by every gh command (use github.gh.host to select the host)", arg)
+		}
+	}
+	if proxy := c.GitHub.CLI.Proxy; proxy != "" {
+		u, err := url.Parse(proxy)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") {
+			return fmt.Errorf("invalid gh proxy %q (must be an http, https or socks5 URL)", proxy)
+		}
+	}
+	if c.GitHub.OfflineQueue.Max < 0 {
+		return errors.New("offline queue max must not be negative")
+	}
+	if c.GitHub.OfflineQueue.Max == 0 {
+		c.GitHub.OfflineQueue.Max = DefaultOfflineQueueMax
+	}
+	for _, label := range c.GitHub.OfflineQueue.Labels {
+		transition := containsString(c.GetLabels(), label)
+		for _, t := range c.GitHub.Workflow.Transitions {
+			transition = transition || label == t.Executing || label == t.Next || label == t.To
+		}
+		if transition {
+			return fmt.Errorf("offline queue label %q is a workflow transition label and cannot be deferred", label)
+		}
+	}
+	if c.Worktree.MaxParallel <= 0 {
+		c.Worktree.MaxParallel = DefaultWorktreeMaxParallel
+	}
+	switch c.Worktree.Mode {
+	case "":
+		c.Worktree.Mode = WorktreeModeWorktree
+	case WorktreeModeWorktree, WorktreeModeClone:
+	default:
+		return fmt.Errorf("invalid worktree.mode: %q (must be %s or %s)", c.Worktree.Mode, WorktreeModeWorktree, WorktreeModeClone)
+	}
+	for _, phase := range c.Worktree.CleanPhases {
+		if !containsString(workflowPhases, phase) {
+			return fmt.Errorf("invalid worktree.clean_phases phase: %q (must be one of %s)", phase, strings.Join(workflowPhases, ", "))
+		}
+	}
+	if c.Worktree.ScratchDir != "" && !filepath.IsAbs(c.Worktree.ScratchDir) {
+		return fmt.Errorf("worktree.scratch_dir must be an absolute path: %q", c.Worktree.ScratchDir)
+	}
+	if c.GitHub.PlanApproval.Enabled {
+		if c.GitHub.PlanApproval.Reaction != "" && !isReactionContent(c.GitHub.PlanApproval.Reaction) {
+			return fmt.Errorf("invalid plan approval reaction: %q (must be one of %s)", c.GitHub.PlanApproval.Reaction, strings.Join(reactionContents, ", "))
+		}
+		if c.GitHub.PlanApproval.Reaction == "" && strings.TrimSpace(c.GitHub.PlanApproval.Comment) == "" {
+			return errors.New("plan approval requires a reaction or a comment")
+		}
+	}
+	if c.GitHub.CIGate.Timeout < 0 {
+		return errors.New("github.ci_gate.timeout must not be negative")
+	}
+	if c.GitHub.CIGate.MaxLogLines <= 0 {
+		c.GitHub.CIGate.MaxLogLines = DefaultCIGateMaxLogLines
+	}
+	if c.GitHub.CIGate.NoChecksGrace < 0 {
+		return errors.New("github.ci_gate.no_checks_grace must not be negative")
+	}
+	if c.GitHub.CIGate.MaxFailures < 0 {
+		return errors.New("github.ci_gate.max_failures must not be negative")
+	}
+	if c.GitHub.CIGate.Label == "" {
+		c.GitHub.CIGate.Label = "status:needs-human"
+	}
+	for _, phase := range c.GitHub.Assignment.Phases {
+		if !containsString(workflowPhases, phase) {
+			return fmt.Errorf("invalid github.assignment phase: %q (must be one of %s)", phase, strings.Join(workflowPhases, ", "))
+		}
+	}
+	if c.GitHub.Org != "" && c.GitHub.OrgRepos.DiscoveryInterval < time.Minute {
+		return errors.New("org repository discovery interval must be at least 1 minute")
+	}
+	for _, pattern := range c.GitHub.OrgRepos.Names {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid github.org_repos.names pattern: %q", pattern)
+		}
+	}
+	if err := c.GitHub.CommentTemplates.Validate(); err != nil {
+		return err
+	}
+	if err := c.GitHub.Preconditions.Validate(); err != nil {
+		return err
+	}
+
 	// tmux設定のバリデーション
 	if c.Tmux.SessionPrefix == "" {
 		c.Tmux.SessionPrefix = "osoba-"
 	}
+	switch c.Tmux.PaneSplit {
+	case "":
+		c.Tmux.PaneSplit = PaneSplitHorizontal
+	case PaneSplitHorizontal, PaneSplitVertical, PaneSplitAuto:
+	default:
+		return fmt.Errorf("invalid tmux.pane_split: %q (must be vertical, horizontal or auto)", c.Tmux.PaneSplit)
+	}
+	if c.Tmux.Keybindings.Key == "" {
+		c.Tmux.Keybindings.Key = DefaultTmuxKeybindingKey
+	} else if strings.ContainsAny(c.Tmux.Keybindings.Key, " \t") {
+		return fmt.Errorf("invalid tmux.keybindings.key: %q (must be a single tmux key)", c.Tmux.Keybindings.Key)
+	}
+	shardNames := make(map[string]bool)
+	for i, shard := range c.Tmux.Shards {
+		if !shardNamePattern.MatchString(shard.Name) {
+			return fmt.Errorf("invalid tmux.shards[%d].name: %q (must contain only letters, digits, '-' and '_')", i, shard.Name)
+		}
+		if shardNames[shard.Name] {
+			return fmt.Errorf("duplicate tmux.shards name: %q", shard.Name)
+		}
+		shardNames[shard.Name] = true
+		if len(shard.Labels) == 0 && len(shard.Milestones) == 0 {
+			return fmt.Errorf("tmux.shards[%d] (%s) requires labels or milestones", i, shard.Name)
+		}
+	}
+	if c.Tmux.MaxPanesPerWindow < 0 {
+		return errors.New("tmux.max_panes_per_window must not be negative")
+	}
+	if c.Tmux.MinPaneWidth < 0 || c.Tmux.MinPaneHeight < 0 {
+		return errors.New("tmux.min_pane_width and tmux.min_pane_height must not be negative")
+	}
+	for phase, pc := range c.Tmux.Phases {
+		switch pc.Pane {
+		case "", PanePolicyReuse, PanePolicyReplace, PanePolicyAppend:
+		default:
+			return fmt.Errorf("invalid tmux.phases.%s.pane: %q (must be reuse, replace or append)", phase, pc.Pane)
+		}
+		switch pc.Window {
+		case "", WindowPolicyShared, WindowPolicySeparate:
+		default:
+			return fmt.Errorf("invalid tmux.phases.%s.window: %q (must be shared or separate)", phase, pc.Window)
+		}
+		if pc.ReapAfter < 0 {
+			return fmt.Errorf("tmux.phases.%s.reap_after must not be negative", phase)
+		}
+	}
 
 	// Claude設定のバリデーション
 	if c.Claude != nil {
@@ -304,9 +1959,89 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid cleanup config: %w", err)
 	}
 
+	// Safety設定のバリデーション
+	if err := c.Safety.Validate(); err != nil {
+		return err
+	}
+
+	// 通知設定のバリデーション
+	if err := c.Notifications.Email.Validate(); err != nil {
+		return err
+	}
+
+	// リソース確認の設定のバリデーション
+	if err := c.ResourceGuard.Validate(); err != nil {
+		return err
+	}
+	if c.Degradation.Threshold < 0 {
+		return errors.New("degradation.threshold must not be negative")
+	}
+	if c.Degradation.Threshold == 0 {
+		c.Degradation.Threshold = DefaultDegradationThreshold
+	}
+
+	// 同時実行数の設定のバリデーション
+	if err := c.Concurrency.Validate(); err != nil {
+		return err
+	}
+
+	// 積み残しの開始の設定のバリデーション
+	if err := c.Backfill.Validate(); err != nil {
+		return err
+	}
+
+	// 同時に実装しない領域の設定のバリデーション
+	if err := c.ConflictFences.Validate(); err != nil {
+		return err
+	}
+
+	// リトライの回数のしきい値のバリデーション
+	if err := c.RetryBudget.Validate(); err != nil {
+		return err
+	}
+
+	// ステータスバッジのしきい値のバリデーション
+	if err := c.Badge.Validate(); err != nil {
+		return err
+	}
+
+	// ハートビートの間隔のバリデーション
+	if err := c.Heartbeat.Validate(); err != nil {
+		return err
+	}
+
+	// PRモードのラベルのバリデーション
+	if err := c.PRMode.Validate(); err != nil {
+		return err
+	}
+
+	// カナリアのラベルのバリデーション
+	if err := c.Canary.Validate(); err != nil {
+		return err
+	}
+
+	if c.TimeZone != "" {
+		if _, err := time.LoadLocation(c.TimeZone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", c.TimeZone, err)
+		}
+	}
+
 	return nil
 }
 
+// Location は表示・記録する時刻のタイムゾーンを返す
+// 未設定または読み込めない場合はホストのタイムゾーンを返す
+func (c *Config) Location() *time.Location {
+	if c == nil || c.TimeZone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
 // validateClaudeConfig はClaude設定の妥当性を検証する
 func (c *Config) validateClaudeConfig() error {
 	if c.Claude == nil {
@@ -332,12 +2067,40 @@ func (c *Config) validateClaudeConfig() error {
 			return fmt.Errorf("phase '%s' prompt is empty", phase)
 		}
 
-		// プロンプトに必要なテンプレート変数が含まれているかチェック
+		// プロンプトのテンプレートの構文をチェック
+		if _, err := claude.ParsePrompt(config.Prompt); err != nil {
+			return fmt.Errorf("phase '%s' prompt: %w", phase, err)
+		}
+
+		// プロンプトに必要なテンプレート変数が含まれているかチェック（{{issue-number}} または {{.IssueNumber}}）
 		if phase == "plan" || phase == "implement" || phase == "review" {
-			if !containsTemplate(config.Prompt, "{{issue-number}}") {
+			if !containsTemplate(config.Prompt, "{{issue-number}}") && !containsTemplate(config.Prompt, ".IssueNumber") {
 				return fmt.Errorf("phase '%s' prompt must contain {{issue-number}} template variable", phase)
 			}
 		}
+
+		for i, variant := range config.Variants {
+			if len(variant.Labels) == 0 {
+				return fmt.Errorf("phase '%s' variants[%d] requires labels", phase, i)
+			}
+			if variant.Prompt == "" {
+				return fmt.Errorf("phase '%s' variants[%d] prompt is empty", phase, i)
+			}
+			if _, err := claude.ParsePrompt(variant.Prompt); err != nil {
+				return fmt.Errorf("phase '%s' variants[%d] prompt: %w", phase, i, err)
+			}
+			if phase == "plan" || phase == "implement" || phase == "review" {
+				if !containsTemplate(variant.Prompt, "{{issue-number}}") && !containsTemplate(variant.Prompt, ".IssueNumber") {
+					return fmt.Errorf("phase '%s' variants[%d] prompt must contain {{issue-number}} template variable", phase, i)
+				}
+			}
+		}
+
+		if config.Permissions != nil {
+			if err := config.Permissions.Validate(); err != nil {
+				return fmt.Errorf("phase '%s' permissions: %w", phase, err)
+			}
+		}
 	}
 
 	return nil
@@ -365,25 +2128,68 @@ func findSubstring(str, substr string) bool {
 }
 
 // GetLabels は監視対象のラベルをスライスで返す
+// workflowで追加したトリガーラベルも監視対象に含める
 func (c *Config) GetLabels() []string {
-	return []string{
+	labels := []string{
 		c.GitHub.Labels.Plan,
 		c.GitHub.Labels.Ready,
 		c.GitHub.Labels.Review,
 		c.GitHub.Labels.RequiresChanges,
 		c.GitHub.Labels.Revising,
 	}
+	for _, t := range c.GitHub.Workflow.Transitions {
+		if !containsString(labels, t.From) {
+			labels = append(labels, t.From)
+		}
+	}
+	return labels
+}
+
+// ManagedLabels はosobaが監視・付与するラベルのうち設定で変更できるものを重複なく返す
+// ワークフローの遷移のラベルと、各機能が付与するラベルを含む
+func (c *Config) ManagedLabels() []string {
+	labels := c.GetLabels()
+	add := func(names ...string) {
+		for _, name := range names {
+			if name != "" && !containsString(labels, name) {
+				labels = append(labels, name)
+			}
+		}
+	}
+	for _, t := range c.GitHub.Workflow.Transitions {
+		add(t.Executing, t.Next, t.To)
+	}
+	add(
+		c.GitHub.SubIssues.Label,
+		c.GitHub.ExistingPRGuard.Label,
+		c.GitHub.PlanStaleness.Label,
+		c.GitHub.PhaseResult.FailureLabel,
+		c.GitHub.RevertDetection.Label,
+		c.GitHub.HistoryGuard.Label,
+		c.GitHub.HistoryGuard.AllowLabel,
+		c.GitHub.ReviewEscalation.Label,
+		c.GitHub.CIGate.Label,
+		c.GitHub.WorkQueue.Label,
+		c.ConflictFences.Label,
+		c.Heartbeat.Label,
+		c.PRMode.ReviewLabel,
+		c.PRMode.ReviewingLabel,
+		c.PRMode.RevisingLabel,
+		c.PRMode.ApprovedLabel,
+	)
+	if c.RetryBudget.Enabled {
+		add(c.RetryBudget.DegradedLabel, c.RetryBudget.FailingLabel)
+	}
+	return labels
 }
 
 // GetPhaseMessage は指定されたフェーズのメッセージを返す
+// comment_templatesでphase_<フェーズ>が指定されている場合はそちらを優先する
 func (c *Config) GetPhaseMessage(phase string) (string, bool) {
 	switch phase {
-	case "plan":
-		return c.GitHub.Messages.Plan, true
-	case "implement":
-		return c.GitHub.Messages.Implement, true
-	case "review":
-		return c.GitHub.Messages.Review, true
+	case "plan", "implement", "review":
+		message, err := c.CommentTemplate("phase_" + phase)
+		return message, err == nil
 	default:
 		return "", false
 	}
@@ -392,21 +2198,68 @@ func (c *Config) GetPhaseMessage(phase string) (string, bool) {
 // GhAuthTokenFunc はテスト用のモック可能な関数変数（公開）
 var GhAuthTokenFunc = executeGhAuthToken
 
-// executeGhAuthToken は実際の gh auth token コマンドを実行する
-func executeGhAuthToken() (string, error) {
-	cmd := exec.Command("gh", "auth", "token")
+// executeGhAuthToken は実際の gh auth token コマンドを実行する（ghPathが空の場合はPATHから検索する）
+func executeGhAuthToken(ghPath string) (string, error) {
+	if ghPath == "" {
+		ghPath = "gh"
+	}
+	cmd := exec.Command(ghPath, "auth", "token")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// TokenCommandFunc はテスト用のモック可能な関数変数（公開）
+var TokenCommandFunc = executeTokenCommand
+
+// tokenCommandTimeout はtoken_commandの実行を待つ最大時間（応答しないシークレットストアで起動やトークンの更新が止まらないようにする）
+var tokenCommandTimeout = 30 * time.Second
+
+// executeTokenCommand はgithub.gh.token_commandをシェルで実行し、出力をトークンとして返す
+func executeTokenCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	// シェルを終了しても子プロセスが出力を開いたままの場合に、待ち続けないようにする
+	cmd.WaitDelay = time.Second
 	output, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("token_command timed out after %s", tokenCommandTimeout)
+	}
 	if err != nil {
 		return "", err
 	}
 	return strings.TrimSpace(string(output)), nil
 }
 
+// GitHubTokenEnv はGitHubトークンを直接指定する環境変数
+// 設定されている場合はtoken_commandもgh auth tokenも実行しない（gh auth loginできないCIやサーバー向け）
+const GitHubTokenEnv = "OSOBA_GITHUB_TOKEN"
+
 // GetGitHubToken はGitHubトークンを取得し、取得元を返す
-// ghコマンドのみをサポート
+// OSOBA_GITHUB_TOKENが設定されている場合はその値を、github.gh.token_commandが指定されている場合はその出力を、
+// それ以外はgh auth tokenの出力を使用する
 func GetGitHubToken(cfg *Config) (token string, source string) {
+	if token := strings.TrimSpace(os.Getenv(GitHubTokenEnv)); token != "" {
+		return token, GitHubTokenEnv
+	}
+
+	ghPath := ""
+	if cfg != nil {
+		if command := cfg.GitHub.CLI.TokenCommand; command != "" {
+			if token, err := TokenCommandFunc(command); err == nil && token != "" {
+				return token, "token_command"
+			}
+			return "", ""
+		}
+		ghPath = cfg.GitHub.CLI.Path
+	}
+
 	// gh auth tokenコマンドを試す
-	if ghToken, err := GhAuthTokenFunc(); err == nil && ghToken != "" {
+	if ghToken, err := GhAuthTokenFunc(ghPath); err == nil && ghToken != "" {
 		return ghToken, "gh auth token"
 	}
 
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// 通知対象のイベント
+const (
+	NotifyPhaseFailed    = "phase_failed"    // フェーズの実行失敗
+	NotifyMergeBlocked   = "merge_blocked"   // 自動マージの停止（コンフリクト・チェック失敗・マージAPIのエラー）
+	NotifyBudgetExceeded = "budget_exceeded" // フェーズの同時実行数の上限による開始の保留
+	NotifyPlanStale      = "plan_stale"      // 計画後のIssue本文の編集
+	NotifyDegraded       = "degraded"        // 監視の連続した失敗
+)
+
+// notifyEvents は通知対象として指定できるイベントの一覧
+var notifyEvents = []string{
+	NotifyPhaseFailed,
+	NotifyMergeBlocked,
+	NotifyBudgetExceeded,
+	NotifyPlanStale,
+	NotifyDegraded,
+}
+
+// DefaultSMTPPort はSMTPサーバーのポートのデフォルト値（STARTTLS）
+const DefaultSMTPPort = 587
+
+// NotificationsConfig は重要なイベントの通知設定
+type NotificationsConfig struct {
+	Email EmailNotificationConfig `mapstructure:"email"`
+}
+
+// EmailNotificationConfig はSMTPによるメール通知の設定
+type EmailNotificationConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Host はSMTPサーバーのホスト名
+	Host string `mapstructure:"host"`
+	// Port はSMTPサーバーのポート
+	Port int `mapstructure:"port"`
+	// Username はSMTP認証のユーザー名（空の場合は認証しない）
+	Username string `mapstructure:"username"`
+	// Password はSMTP認証のパスワード（環境変数 OSOBA_SMTP_PASSWORD でも指定可能）
+	Password string `mapstructure:"password"`
+	// From は送信元アドレス
+	From string `mapstructure:"from"`
+	// To は宛先アドレスの一覧
+	To []string `mapstructure:"to"`
+	// Events は通知するイベントの一覧（空の場合はすべて）
+	Events []string `mapstructure:"events"`
+	// Digest はイベントをまとめて送信する間隔（0の場合はイベントごとに送信）
+	Digest time.Duration `mapstructure:"digest"`
+}
+
+// Notifies は指定されたイベントが通知対象かを返す
+func (c EmailNotificationConfig) Notifies(event string) bool {
+	if len(c.Events) == 0 {
+		return true
+	}
+	for _, e := range c.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate はメール通知設定の妥当性を検証する
+func (c EmailNotificationConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Host == "" {
+		return errors.New("notifications.email.host is required")
+	}
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid notifications.email.port: %d", c.Port)
+	}
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		return fmt.Errorf("invalid notifications.email.from: %q", c.From)
+	}
+	if len(c.To) == 0 {
+		return errors.New("notifications.email.to requires at least one recipient")
+	}
+	for _, to := range c.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("invalid notifications.email.to address: %q", to)
+		}
+	}
+	for _, event := range c.Events {
+		known := false
+		for _, e := range notifyEvents {
+			if event == e {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown event in notifications.email.events: %q (must be one of %s)", event, strings.Join(notifyEvents, ", "))
+		}
+	}
+	if c.Digest < 0 {
+		return errors.New("notifications.email.digest must not be negative")
+	}
+	if c.Digest > 0 && c.Digest < time.Minute {
+		return errors.New("notifications.email.digest must be at least 1 minute")
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// リポジトリの前提条件を満たしていない場合の扱い
+const (
+	PreconditionOff            = "off"              // 確認しない
+	PreconditionWarn           = "warn"             // 起動時に報告のみ行う
+	PreconditionBlockAutoMerge = "block_auto_merge" // 自動マージを無効にする
+	PreconditionBlockAll       = "block_all"        // 自動処理を開始しない
+)
+
+// preconditionActions は前提条件の扱いとして指定できる値の一覧
+var preconditionActions = []string{
+	PreconditionOff,
+	PreconditionWarn,
+	PreconditionBlockAutoMerge,
+	PreconditionBlockAll,
+}
+
+// 起動時に確認するリポジトリの前提条件
+const (
+	PreconditionBranchProtection = "branch_protection" // デフォルトブランチの保護
+	PreconditionCIWorkflow       = "ci_workflow"       // GitHub ActionsのCIワークフロー
+	PreconditionCodeowners       = "codeowners"        // CODEOWNERSファイル
+)
+
+// PreconditionsConfig は起動時に確認するリポジトリの前提条件と、満たしていない場合の扱い
+type PreconditionsConfig struct {
+	BranchProtection string `mapstructure:"branch_protection"`
+	CIWorkflow       string `mapstructure:"ci_workflow"`
+	Codeowners       string `mapstructure:"codeowners"`
+}
+
+// Rules は前提条件ごとの扱いを確認する順序で返す（未指定の場合はwarn）
+func (c PreconditionsConfig) Rules() []PreconditionRule {
+	rules := []PreconditionRule{
+		{Name: PreconditionBranchProtection, Action: c.BranchProtection},
+		{Name: PreconditionCIWorkflow, Action: c.CIWorkflow},
+		{Name: PreconditionCodeowners, Action: c.Codeowners},
+	}
+	for i := range rules {
+		if rules[i].Action == "" {
+			rules[i].Action = PreconditionWarn
+		}
+	}
+	return rules
+}
+
+// PreconditionRule は前提条件とその扱い
+type PreconditionRule struct {
+	Name   string
+	Action string
+}
+
+// Validate は前提条件の設定の妥当性を検証する
+func (c PreconditionsConfig) Validate() error {
+	for _, rule := range c.Rules() {
+		known := false
+		for _, action := range preconditionActions {
+			if rule.Action == action {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("invalid github.preconditions.%s: %q (must be one of %s)", rule.Name, rule.Action, strings.Join(preconditionActions, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ageArmorHeader はASCII armor形式のageの暗号文の先頭行
+	ageArmorHeader = "-----BEGIN AGE ENCRYPTED FILE-----"
+	// encryptedValuePrefix は1行で書けるよう暗号文をbase64にした値のプレフィックス（ENC[age,<base64>]）
+	encryptedValuePrefix = "ENC[age,"
+	// ageKeyEnv はageの秘密鍵（AGE-SECRET-KEY-1...）を指定する環境変数
+	ageKeyEnv = "OSOBA_AGE_KEY"
+	// ageKeyFileEnv はageの秘密鍵ファイルを指定する環境変数
+	ageKeyFileEnv = "OSOBA_AGE_KEY_FILE"
+)
+
+// テスト用にモック可能な関数変数
+var (
+	// runAgeDecrypt はageコマンドで暗号文を復号する
+	runAgeDecrypt = func(identityFile string, ciphertext []byte) ([]byte, error) {
+		cmd := exec.Command("age", "--decrypt", "-i", identityFile)
+		cmd.Stdin = bytes.NewReader(ciphertext)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return output, nil
+	}
+	// runSopsDecrypt はsopsコマンドで設定ファイル全体を復号する（鍵はsopsの環境変数で指定する）
+	runSopsDecrypt = func(path string) ([]byte, error) {
+		var stderr bytes.Buffer
+		cmd := exec.Command("sops", "--decrypt", path)
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return output, nil
+	}
+)
+
+// readConfigFile は設定ファイルを読み込む
+// sopsで暗号化されたファイル（トップレベルにsopsキーを持つ）はsopsで復号してから読み込む
+func readConfigFile(v *viper.Viper, configPath string) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil || !isSopsFile(data) {
+		// 読み込みエラーを含め、通常のファイルはviperに任せる
+		return v.ReadInConfig()
+	}
+
+	decrypted, err := runSopsDecrypt(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt config with sops: %w", err)
+	}
+	v.SetConfigType("yaml")
+	return v.ReadConfig(bytes.NewReader(decrypted))
+}
+
+func isSopsFile(data []byte) bool {
+	var top map[string]interface{}
+	if err := yaml.Unmarshal(data, &top); err != nil {
+		return false
+	}
+	_, ok := top["sops"]
+	return ok
+}
+
+// decryptValues はageで暗号化された値を復号して置き換える
+// 値はASCII armor形式（YAMLのブロックスカラーで記述）またはENC[age,<base64>]形式で記述する
+func decryptValues(v *viper.Viper) error {
+	identityFile := ""
+	for _, key := range v.AllKeys() {
+		value, ok := v.Get(key).(string)
+		if !ok {
+			continue
+		}
+		ciphertext, ok, err := parseEncryptedValue(value)
+		if err != nil {
+			return fmt.Errorf("invalid encrypted value for %s: %w", key, err)
+		}
+		if !ok {
+			continue
+		}
+
+		if identityFile == "" {
+			var cleanup func()
+			identityFile, cleanup, err = ageIdentityFile()
+			if err != nil {
+				return fmt.Errorf("failed to decrypt %s: %w", key, err)
+			}
+			defer cleanup()
+		}
+		plaintext, err := runAgeDecrypt(identityFile, ciphertext)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s: %w", key, err)
+		}
+		v.Set(key, strings.TrimRight(string(plaintext), "\n"))
+	}
+	return nil
+}
+
+// parseEncryptedValue は暗号化された値であればageに渡す暗号文を返す
+func parseEncryptedValue(value string) ([]byte, bool, error) {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, ageArmorHeader) {
+		return []byte(trimmed + "\n"), true, nil
+	}
+	if strings.HasPrefix(trimmed, encryptedValuePrefix) && strings.HasSuffix(trimmed, "]") {
+		encoded := strings.TrimSuffix(strings.TrimPrefix(trimmed, encryptedValuePrefix), "]")
+		ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, false, err
+		}
+		return ciphertext, true, nil
+	}
+	return nil, false, nil
+}
+
+// ageIdentityFile は環境変数で指定されたageの秘密鍵ファイルのパスを返す
+// OSOBA_AGE_KEYで鍵を直接指定した場合は一時ファイルに書き出し、cleanupで削除する
+func ageIdentityFile() (string, func(), error) {
+	if path := os.Getenv(ageKeyFileEnv); path != "" {
+		return path, func() {}, nil
+	}
+	key := os.Getenv(ageKeyEnv)
+	if key == "" {
+		return "", nil, fmt.Errorf("encrypted values require %s or %s", ageKeyEnv, ageKeyFileEnv)
+	}
+
+	f, err := os.CreateTemp("", "osoba-age-key-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create identity file: %w", err)
+	}
+	cleanup := func() { os.Remove(f.Name()) }
+	if _, err := f.WriteString(key + "\n"); err != nil {
+		f.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write identity file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write identity file: %w", err)
+	}
+	return f.Name(), cleanup, nil
+}
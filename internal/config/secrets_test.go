@@ -0,0 +1,111 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func mockAgeDecrypt(t *testing.T, plaintext string) *string {
+	t.Helper()
+	orig := runAgeDecrypt
+	t.Cleanup(func() { runAgeDecrypt = orig })
+
+	var gotKey string
+	runAgeDecrypt = func(identityFile string, ciphertext []byte) ([]byte, error) {
+		key, err := os.ReadFile(identityFile)
+		if err != nil {
+			return nil, err
+		}
+		gotKey = strings.TrimSpace(string(key))
+		if !strings.Contains(string(ciphertext), "ciphertext") {
+			return nil, errors.New("unexpected ciphertext")
+		}
+		return []byte(plaintext + "\n"), nil
+	}
+	return &gotKey
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "osoba.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_DecryptsAgeValues(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString([]byte("binary ciphertext"))
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "ASCII armor形式",
+			content: "remote:\n  host: |\n    " + ageArmorHeader + "\n    armored ciphertext\n" +
+				"    -----END AGE ENCRYPTED FILE-----\n",
+		},
+		{
+			name:    "ENC[age,...]形式",
+			content: "remote:\n  host: \"ENC[age," + encoded + "]\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ageKeyFileEnv, "")
+			t.Setenv(ageKeyEnv, "AGE-SECRET-KEY-1TEST")
+			gotKey := mockAgeDecrypt(t, "dev-box")
+
+			cfg := NewConfig()
+			if err := cfg.Load(writeConfigFile(t, tt.content)); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Remote.Host != "dev-box" {
+				t.Errorf("Remote.Host = %q, want %q", cfg.Remote.Host, "dev-box")
+			}
+			if *gotKey != "AGE-SECRET-KEY-1TEST" {
+				t.Errorf("identity = %q, want key from %s", *gotKey, ageKeyEnv)
+			}
+		})
+	}
+}
+
+func TestLoad_EncryptedValueRequiresKey(t *testing.T) {
+	t.Setenv(ageKeyFileEnv, "")
+	t.Setenv(ageKeyEnv, "")
+	mockAgeDecrypt(t, "dev-box")
+
+	cfg := NewConfig()
+	err := cfg.Load(writeConfigFile(t, "remote:\n  host: \"ENC[age,"+base64.StdEncoding.EncodeToString([]byte("ciphertext"))+"]\"\n"))
+	if err == nil || err.Error() != "failed to decrypt remote.host: encrypted values require OSOBA_AGE_KEY or OSOBA_AGE_KEY_FILE" {
+		t.Errorf("Load() error = %v, want missing key error", err)
+	}
+}
+
+func TestLoad_DecryptsSopsFile(t *testing.T) {
+	orig := runSopsDecrypt
+	defer func() { runSopsDecrypt = orig }()
+
+	var gotPath string
+	runSopsDecrypt = func(path string) ([]byte, error) {
+		gotPath = path
+		return []byte("remote:\n  host: dev-box\n"), nil
+	}
+
+	path := writeConfigFile(t, "remote:\n  host: ENC[AES256_GCM,data:abc,type:str]\nsops:\n  version: 3.8.1\n")
+	cfg := NewConfig()
+	if err := cfg.Load(path); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if gotPath != path {
+		t.Errorf("sops decrypted %q, want %q", gotPath, path)
+	}
+	if cfg.Remote.Host != "dev-box" {
+		t.Errorf("Remote.Host = %q, want %q", cfg.Remote.Host, "dev-box")
+	}
+}
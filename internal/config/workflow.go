@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ワークフローで実行できるフェーズ
+const (
+	PhasePlan      = "plan"
+	PhaseImplement = "implement"
+	PhaseReview    = "review"
+	PhaseRevise    = "revise"
+)
+
+// workflowPhases はワークフローで指定できるフェーズの一覧
+var workflowPhases = []string{PhasePlan, PhaseImplement, PhaseReview, PhaseRevise}
+
+// WorkflowConfig はラベルによる状態遷移の定義
+// Transitionsの順序がトリガーラベルの優先順位になる（省略時はDefaultWorkflowTransitions）
+type WorkflowConfig struct {
+	Transitions []WorkflowTransition `mapstructure:"transitions"`
+}
+
+// WorkflowTransition はトリガーラベルからの遷移
+// Executingを指定した場合はフェーズの実行中にExecutingを付与し、フェーズの成功後はNextに遷移する
+// Toを指定した場合はフェーズの実行後（Phaseを省略した場合はすぐに）Toに遷移する
+type WorkflowTransition struct {
+	From      string `mapstructure:"from"`      // トリガーラベル
+	Phase     string `mapstructure:"phase"`     // 実行するフェーズ（plan / implement / review / revise）
+	Executing string `mapstructure:"executing"` // フェーズの実行中に付与するラベル
+	Next      string `mapstructure:"next"`      // フェーズの成功後に付与するラベル（空の場合はフェーズ自身が決める）
+	To        string `mapstructure:"to"`        // 実行中ラベルを使わずに遷移する先のラベル
+}
+
+// DefaultWorkflowTransitions は既定の遷移
+// needs-plan → planning → ready → implementing → review-requested → reviewing → lgtm / requires-changes → ready
+func DefaultWorkflowTransitions() []WorkflowTransition {
+	return []WorkflowTransition{
+		{From: "status:needs-plan", Phase: PhasePlan, Executing: "status:planning", Next: "status:ready"},
+		{From: "status:ready", Phase: PhaseImplement, Executing: "status:implementing", Next: "status:review-requested"},
+		{From: "status:review-requested", Phase: PhaseReview, Executing: "status:reviewing"},
+		{From: "status:requires-changes", Phase: PhaseRevise, To: "status:ready"},
+	}
+}
+
+// Find はトリガーラベルの遷移を返す
+func (w WorkflowConfig) Find(from string) (WorkflowTransition, bool) {
+	for _, t := range w.Transitions {
+		if t.From == from {
+			return t, true
+		}
+	}
+	return WorkflowTransition{}, false
+}
+
+// FindByPhase はフェーズを実行する遷移のうち最初のものを返す
+func (w WorkflowConfig) FindByPhase(phase string) (WorkflowTransition, bool) {
+	for _, t := range w.Transitions {
+		if t.Phase == phase {
+			return t, true
+		}
+	}
+	return WorkflowTransition{}, false
+}
+
+// Validate は遷移の定義が矛盾していないかを確認する
+func (w WorkflowConfig) Validate() error {
+	if len(w.Transitions) == 0 {
+		return fmt.Errorf("workflow must define at least one transition")
+	}
+
+	froms := make(map[string]bool, len(w.Transitions))
+	executings := make(map[string]string)
+	direct := make(map[string]string) // フェーズを実行しない遷移
+	for i, t := range w.Transitions {
+		if t.From == "" {
+			return fmt.Errorf("workflow transition #%d: from is required", i+1)
+		}
+		if froms[t.From] {
+			return fmt.Errorf("workflow transition %q is defined more than once", t.From)
+		}
+		froms[t.From] = true
+
+		if t.Phase != "" && !containsString(workflowPhases, t.Phase) {
+			return fmt.Errorf("workflow transition %q: unknown phase %q (must be one of %s)", t.From, t.Phase, strings.Join(workflowPhases, ", "))
+		}
+		switch {
+		case t.Executing != "" && t.To != "":
+			return fmt.Errorf("workflow transition %q: executing and to cannot both be set", t.From)
+		case t.Executing == "" && t.To == "":
+			return fmt.Errorf("workflow transition %q: executing or to is required", t.From)
+		case t.Executing != "":
+			if t.Phase == "" {
+				return fmt.Errorf("workflow transition %q: executing requires a phase", t.From)
+			}
+			if other, ok := executings[t.Executing]; ok {
+				return fmt.Errorf("workflow transitions %q and %q share executing label %q", other, t.From, t.Executing)
+			}
+			executings[t.Executing] = t.From
+		default:
+			if t.Next != "" {
+				return fmt.Errorf("workflow transition %q: next requires executing", t.From)
+			}
+			if t.To == t.From {
+				return fmt.Errorf("workflow transition %q: to must differ from from", t.From)
+			}
+			if t.Phase == "" {
+				direct[t.From] = t.To
+			}
+		}
+	}
+
+	for label, from := range executings {
+		if froms[label] {
+			return fmt.Errorf("workflow transition %q: executing label %q is also a trigger label", from, label)
+		}
+	}
+
+	// フェーズを実行しない遷移だけで循環すると、ポーリングのたびにラベルが付け替わり続ける
+	for _, t := range w.Transitions {
+		seen := make(map[string]bool)
+		for label := t.From; direct[label] != ""; label = direct[label] {
+			if seen[label] {
+				return fmt.Errorf("workflow transition %q: transitions without a phase form a cycle", t.From)
+			}
+			seen[label] = true
+		}
+	}
+	return nil
+}
+
+// containsString はスライスが値を含むかを返す
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
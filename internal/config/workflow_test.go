@@ -0,0 +1,122 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		transitions []WorkflowTransition
+		wantErr     string
+	}{
+		{
+			name:        "既定の遷移",
+			transitions: DefaultWorkflowTransitions(),
+		},
+		{
+			name: "レビューを省略した遷移",
+			transitions: []WorkflowTransition{
+				{From: "status:needs-plan", Phase: PhasePlan, Executing: "status:planning", Next: "status:ready"},
+				{From: "status:ready", Phase: PhaseImplement, Executing: "status:implementing", Next: "status:lgtm"},
+			},
+		},
+		{
+			name:        "遷移がない",
+			transitions: []WorkflowTransition{},
+			wantErr:     "workflow must define at least one transition",
+		},
+		{
+			name: "トリガーラベルの重複",
+			transitions: []WorkflowTransition{
+				{From: "status:ready", Phase: PhaseImplement, Executing: "status:implementing"},
+				{From: "status:ready", Phase: PhaseReview, Executing: "status:reviewing"},
+			},
+			wantErr: `workflow transition "status:ready" is defined more than once`,
+		},
+		{
+			name: "未知のフェーズ",
+			transitions: []WorkflowTransition{
+				{From: "status:ready", Phase: "deploy", Executing: "status:deploying"},
+			},
+			wantErr: `workflow transition "status:ready": unknown phase "deploy" (must be one of plan, implement, review, revise)`,
+		},
+		{
+			name: "executingとtoの両方を指定",
+			transitions: []WorkflowTransition{
+				{From: "status:ready", Phase: PhaseImplement, Executing: "status:implementing", To: "status:lgtm"},
+			},
+			wantErr: `workflow transition "status:ready": executing and to cannot both be set`,
+		},
+		{
+			name: "フェーズのない実行中ラベル",
+			transitions: []WorkflowTransition{
+				{From: "status:ready", Executing: "status:implementing"},
+			},
+			wantErr: `workflow transition "status:ready": executing requires a phase`,
+		},
+		{
+			name: "実行中ラベルがトリガーラベルと同じ",
+			transitions: []WorkflowTransition{
+				{From: "status:needs-plan", Phase: PhasePlan, Executing: "status:ready"},
+				{From: "status:ready", Phase: PhaseImplement, Executing: "status:implementing"},
+			},
+			wantErr: `workflow transition "status:needs-plan": executing label "status:ready" is also a trigger label`,
+		},
+		{
+			name: "フェーズのない遷移の循環",
+			transitions: []WorkflowTransition{
+				{From: "status:a", To: "status:b"},
+				{From: "status:b", To: "status:a"},
+			},
+			wantErr: `workflow transition "status:a": transitions without a phase form a cycle`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WorkflowConfig{Transitions: tt.transitions}.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConfig_Workflow_LoadReplacesDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`github:
+  workflow:
+    transitions:
+      - from: status:needs-plan
+        phase: plan
+        executing: status:planning
+      - from: status:ready
+        phase: implement
+        executing: status:implementing
+        next: status:lgtm
+`), 0644))
+
+	cfg := NewConfig()
+	require.NoError(t, cfg.Load(path))
+	require.NoError(t, cfg.Validate())
+
+	// 既定の遷移と要素ごとにマージされず、設定ファイルの遷移だけになる
+	assert.Equal(t, []WorkflowTransition{
+		{From: "status:needs-plan", Phase: PhasePlan, Executing: "status:planning"},
+		{From: "status:ready", Phase: PhaseImplement, Executing: "status:implementing", Next: "status:lgtm"},
+	}, cfg.GitHub.Workflow.Transitions)
+}
+
+func TestConfig_Workflow_DefaultsWhenOmitted(t *testing.T) {
+	cfg := NewConfig()
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, DefaultWorkflowTransitions(), cfg.GitHub.Workflow.Transitions)
+}
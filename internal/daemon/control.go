@@ -0,0 +1,129 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// controlTimeout は制御コマンド1件の読み書きの期限
+const controlTimeout = 10 * time.Second
+
+// ControlHandler は制御コマンド（"reprocess 12" など）を処理し、応答メッセージを返す
+type ControlHandler func(ctx context.Context, command string, args []string) (string, error)
+
+// ControlServer は監視プロセスがUnixソケットで制御コマンドを受け付けるサーバー
+// 1接続につき1行のコマンドを受け取り、"ok <メッセージ>" または "error <メッセージ>" の1行を返す
+type ControlServer struct {
+	path     string
+	handler  ControlHandler
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// NewControlServer はソケットを作成してControlServerを返す
+// 前回の監視プロセスが残したソケットファイルは削除してから作成する
+func NewControlServer(path string, handler ControlHandler) (*ControlServer, error) {
+	if path == "" {
+		return nil, errors.New("control socket path is required")
+	}
+	if handler == nil {
+		return nil, errors.New("control handler is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create control socket directory: %w", err)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale control socket: %w", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on control socket: %w", err)
+	}
+	return &ControlServer{path: path, handler: handler, listener: listener}, nil
+}
+
+// Serve はコンテキストが終了するまで制御コマンドを受け付け、終了時にソケットファイルを削除する
+func (s *ControlServer) Serve(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		s.listener.Close()
+	}()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			break
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(ctx, conn)
+		}()
+	}
+	s.wg.Wait()
+	os.Remove(s.path)
+}
+
+func (s *ControlServer) handle(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(controlTimeout))
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && line == "" {
+		return
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		fmt.Fprintln(conn, "error empty command")
+		return
+	}
+
+	message, err := s.handler(ctx, fields[0], fields[1:])
+	if err != nil {
+		fmt.Fprintf(conn, "error %s\n", err)
+		return
+	}
+	fmt.Fprintf(conn, "ok %s\n", message)
+}
+
+// CommandError は監視プロセスが制御コマンドを受け付けたうえで失敗を返したことを表すエラー
+type CommandError struct {
+	Message string
+}
+
+func (e *CommandError) Error() string {
+	return e.Message
+}
+
+// SendControlCommand は監視プロセスのソケットに制御コマンドを送り、応答メッセージを返す
+// 監視プロセスがコマンドの失敗を返した場合は*CommandErrorを返す
+func SendControlCommand(path, command string) (string, error) {
+	conn, err := net.DialTimeout("unix", path, controlTimeout)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to control socket: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(controlTimeout))
+
+	if _, err := fmt.Fprintln(conn, command); err != nil {
+		return "", fmt.Errorf("failed to send control command: %w", err)
+	}
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && reply == "" {
+		return "", fmt.Errorf("failed to read control reply: %w", err)
+	}
+
+	status, message, _ := strings.Cut(strings.TrimSpace(reply), " ")
+	if status != "ok" {
+		return "", &CommandError{Message: message}
+	}
+	return message, nil
+}
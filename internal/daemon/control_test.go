@@ -0,0 +1,64 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestControlServer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping unix socket test on Windows")
+	}
+
+	dir, err := os.MkdirTemp("", "osoba-ctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.sock")
+
+	server, err := NewControlServer(path, func(ctx context.Context, command string, args []string) (string, error) {
+		if command != "reprocess" {
+			return "", errors.New("unknown command: " + command)
+		}
+		return "issue " + strings.Join(args, " ") + " will be reprocessed", nil
+	})
+	if err != nil {
+		t.Fatalf("NewControlServer() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		server.Serve(ctx)
+		close(done)
+	}()
+
+	reply, err := SendControlCommand(path, "reprocess 12")
+	if err != nil {
+		t.Fatalf("SendControlCommand() error = %v", err)
+	}
+	if reply != "issue 12 will be reprocessed" {
+		t.Errorf("reply = %q", reply)
+	}
+
+	_, err = SendControlCommand(path, "unknown")
+	var commandErr *CommandError
+	if !errors.As(err, &commandErr) || err.Error() != "unknown command: unknown" {
+		t.Errorf("SendControlCommand() error = %v, want unknown command", err)
+	}
+
+	cancel()
+	<-done
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("control socket should be removed after Serve returns: %v", err)
+	}
+	if _, err := SendControlCommand(path, "reprocess 12"); err == nil {
+		t.Error("SendControlCommand() should fail when the server is stopped")
+	}
+}
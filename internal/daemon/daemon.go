@@ -27,6 +27,20 @@ func (dm *daemonManager) Start(ctx context.Context, args []string) error {
 		return nil
 	}
 
+	if _, err := dm.Spawn(ctx, args); err != nil {
+		return err
+	}
+
+	// テスト中はos.Exitを呼ばない
+	if os.Getenv("GO_TEST") != "1" {
+		// 親プロセスは終了
+		os.Exit(0)
+	}
+	return nil
+}
+
+// Spawn はデーモンプロセスを起動し、親プロセスを終了せずに子プロセスのPIDを返します
+func (dm *daemonManager) Spawn(ctx context.Context, args []string) (int, error) {
 	// 自分自身をバックグラウンドで再起動
 	cmd := exec.Command(os.Args[0], args...)
 	cmd.Env = append(os.Environ(), "OSOBA_DAEMON_MODE=1")
@@ -43,15 +57,10 @@ func (dm *daemonManager) Start(ctx context.Context, args []string) error {
 	}
 
 	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start daemon: %w", err)
+		return 0, fmt.Errorf("failed to start daemon: %w", err)
 	}
 
-	// テスト中はos.Exitを呼ばない
-	if os.Getenv("GO_TEST") != "1" {
-		// 親プロセスは終了
-		os.Exit(0)
-	}
-	return nil
+	return cmd.Process.Pid, nil
 }
 
 // Stop はプロセスを停止します
@@ -12,6 +12,8 @@ import (
 // DaemonManager はバックグラウンドプロセスを管理するインターフェース
 type DaemonManager interface {
 	Start(ctx context.Context, args []string) error
+	// Spawn はデーモンを起動し、親プロセスを終了せずに子プロセスのPIDを返す
+	Spawn(ctx context.Context, args []string) (int, error)
 	Stop(pidFile string) error
 	Status(pidFile string) (*ProcessStatus, error)
 	IsRunning(pidFile string) bool
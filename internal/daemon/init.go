@@ -0,0 +1,88 @@
+//go:build !windows
+// +build !windows
+
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+)
+
+// initChildEnv はPID 1のosobaが起動した子プロセスであることを示す環境変数
+const initChildEnv = "OSOBA_INIT_CHILD"
+
+// forwardedSignals はPID 1から子プロセスに転送するシグナル
+var forwardedSignals = []os.Signal{
+	syscall.SIGTERM,
+	syscall.SIGINT,
+	syscall.SIGHUP,
+	syscall.SIGQUIT,
+	syscall.SIGUSR1,
+	syscall.SIGUSR2,
+}
+
+// ShouldRunAsInit はosobaがPID 1として起動されているか（--initなしのコンテナなど）を返す
+func ShouldRunAsInit() bool {
+	return os.Getpid() == 1 && os.Getenv(initChildEnv) != "1"
+}
+
+// RunAsInit はosoba自身を子プロセスとして起動し、その終了までinitプロセスとして動作する
+// tmuxサーバーはデーモン化してPID 1の子になり、ペインで終了したプロセスもPID 1に引き取られるため、
+// PID 1が回収しないとゾンビプロセスが残り続ける。exec.CmdのWaitと回収が競合しないよう、
+// osobaの処理は子プロセスで行い、PID 1はシグナルの転送と終了したプロセスの回収のみを行う
+// 戻り値は子プロセスの終了コード
+func RunAsInit(args []string) int {
+	sigCh := make(chan os.Signal, 8)
+	signal.Notify(sigCh, append([]os.Signal{syscall.SIGCHLD}, forwardedSignals...)...)
+
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), initChildEnv+"=1")
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "osoba: failed to start process: %v\n", err)
+		return 1
+	}
+	child := cmd.Process.Pid
+
+	for sig := range sigCh {
+		if sig != syscall.SIGCHLD {
+			_ = cmd.Process.Signal(sig)
+			continue
+		}
+		if code, exited := reapChildren(child); exited {
+			return code
+		}
+	}
+	return 0
+}
+
+// reapChildren は終了したプロセスをすべて回収し、osobaの子プロセスが終了した場合はその終了コードを返す
+func reapChildren(child int) (int, bool) {
+	code, exited := 0, false
+	for {
+		var status syscall.WaitStatus
+		pid, err := syscall.Wait4(-1, &status, syscall.WNOHANG, nil)
+		if errors.Is(err, syscall.EINTR) {
+			continue
+		}
+		if err != nil || pid <= 0 {
+			return code, exited
+		}
+		if pid != child {
+			continue
+		}
+		exited = true
+		switch {
+		case status.Exited():
+			code = status.ExitStatus()
+		case status.Signaled():
+			code = 128 + int(status.Signal())
+		}
+	}
+}
@@ -0,0 +1,30 @@
+//go:build !windows
+// +build !windows
+
+package daemon
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestReapChildren(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "exit 3")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start process: %v", err)
+	}
+
+	// 終了したプロセスを回収し、対象のプロセスの終了コードを返す
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if code, exited := reapChildren(cmd.Process.Pid); exited {
+			if code != 3 {
+				t.Errorf("exit code = %d, want 3", code)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("process was not reaped")
+}
@@ -0,0 +1,167 @@
+// Package faultinject はghコマンドとtmuxコマンドの呼び出しに失敗と遅延を注入し、
+// watcherのリトライ・バックオフ・起動時の突き合わせが実際に回復できるかを検証する
+package faultinject
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 障害を注入する対象
+const (
+	TargetGH   = "gh"
+	TargetTmux = "tmux"
+)
+
+// defaultMaxDelay は遅延の最大値のデフォルト値
+const defaultMaxDelay = 2 * time.Second
+
+// ErrInjected は注入された失敗を表すエラー
+var ErrInjected = errors.New("fault injection")
+
+// Stats は対象ごとの注入の回数
+type Stats struct {
+	Calls    int `json:"calls"`
+	Failures int `json:"failures"`
+	Delays   int `json:"delays"`
+}
+
+// Injector は指定された割合で呼び出しを失敗・遅延させる
+type Injector struct {
+	failureRate float64
+	delayRate   float64
+	maxDelay    time.Duration
+	targets     map[string]bool
+
+	mu    sync.Mutex
+	rand  *rand.Rand
+	stats map[string]*Stats
+	sleep func(time.Duration)
+}
+
+// Parse は"fail=0.1,delay=0.2,max-delay=2s,targets=gh+tmux,seed=42"形式の指定からInjectorを作成する
+// 省略した項目は fail=0, delay=0, max-delay=2s, targets=gh+tmux, seed=現在時刻 になる
+func Parse(spec string) (*Injector, error) {
+	i := &Injector{
+		maxDelay: defaultMaxDelay,
+		targets:  map[string]bool{TargetGH: true, TargetTmux: true},
+		stats:    make(map[string]*Stats),
+		sleep:    time.Sleep,
+	}
+	seed := time.Now().UnixNano()
+
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid fault injection option: %q (expected key=value)", item)
+		}
+		switch key {
+		case "fail", "delay":
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil || rate < 0 || rate > 1 {
+				return nil, fmt.Errorf("invalid fault injection %s rate: %q (must be between 0 and 1)", key, value)
+			}
+			if key == "fail" {
+				i.failureRate = rate
+			} else {
+				i.delayRate = rate
+			}
+		case "max-delay":
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("invalid fault injection max-delay: %q", value)
+			}
+			i.maxDelay = d
+		case "targets":
+			i.targets = make(map[string]bool)
+			for _, target := range strings.Split(value, "+") {
+				if target != TargetGH && target != TargetTmux {
+					return nil, fmt.Errorf("invalid fault injection target: %q (must be %s or %s)", target, TargetGH, TargetTmux)
+				}
+				i.targets[target] = true
+			}
+		case "seed":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid fault injection seed: %q", value)
+			}
+			seed = n
+		default:
+			return nil, fmt.Errorf("unknown fault injection option: %q", key)
+		}
+	}
+	if i.failureRate == 0 && i.delayRate == 0 {
+		return nil, errors.New("fault injection requires fail or delay rate")
+	}
+
+	i.rand = rand.New(rand.NewSource(seed))
+	return i, nil
+}
+
+// Inject は対象への呼び出しの前に呼び出す
+// 失敗を注入する場合はErrInjectedをラップしたエラーを返し、遅延を注入する場合は待機してからnilを返す
+func (i *Injector) Inject(target string, args []string) error {
+	if i == nil || !i.targets[target] {
+		return nil
+	}
+
+	i.mu.Lock()
+	stats, ok := i.stats[target]
+	if !ok {
+		stats = &Stats{}
+		i.stats[target] = stats
+	}
+	stats.Calls++
+	fail := i.rand.Float64() < i.failureRate
+	var delay time.Duration
+	if !fail && i.rand.Float64() < i.delayRate {
+		delay = time.Duration(i.rand.Int63n(int64(i.maxDelay)) + 1)
+		stats.Delays++
+	}
+	if fail {
+		stats.Failures++
+	}
+	i.mu.Unlock()
+
+	if fail {
+		return fmt.Errorf("%w: simulated failure of %s %s", ErrInjected, target, strings.Join(args, " "))
+	}
+	if delay > 0 {
+		i.sleep(delay)
+	}
+	return nil
+}
+
+// Stats は対象ごとの注入の回数を返す（障害注入が無効の場合はnil）
+func (i *Injector) Stats() map[string]Stats {
+	if i == nil {
+		return nil
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	stats := make(map[string]Stats, len(i.stats))
+	for target, s := range i.stats {
+		stats[target] = *s
+	}
+	return stats
+}
+
+// String は注入の設定を返す
+func (i *Injector) String() string {
+	targets := make([]string, 0, len(i.targets))
+	for target := range i.targets {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return fmt.Sprintf("fail=%g, delay=%g, max-delay=%s, targets=%s", i.failureRate, i.delayRate, i.maxDelay, strings.Join(targets, "+"))
+}
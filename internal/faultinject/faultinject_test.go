@@ -0,0 +1,130 @@
+package faultinject
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "失敗率のみを指定",
+			spec: "fail=0.1",
+			want: "fail=0.1, delay=0, max-delay=2s, targets=gh+tmux",
+		},
+		{
+			name: "すべての項目を指定",
+			spec: "fail=0.2, delay=0.5, max-delay=500ms, targets=gh, seed=42",
+			want: "fail=0.2, delay=0.5, max-delay=500ms, targets=gh",
+		},
+		{
+			name:    "割合が範囲外",
+			spec:    "fail=1.5",
+			wantErr: "must be between 0 and 1",
+		},
+		{
+			name:    "不明な対象",
+			spec:    "fail=0.1,targets=gh+git",
+			wantErr: "invalid fault injection target",
+		},
+		{
+			name:    "不明な項目",
+			spec:    "fail=0.1,rate=0.2",
+			wantErr: "unknown fault injection option",
+		},
+		{
+			name:    "key=value形式でない",
+			spec:    "fail",
+			wantErr: "expected key=value",
+		},
+		{
+			name:    "失敗率も遅延率も0",
+			spec:    "max-delay=1s",
+			wantErr: "requires fail or delay rate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			injector, err := Parse(tt.spec)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, injector.String())
+		})
+	}
+}
+
+func TestInjector_Inject(t *testing.T) {
+	t.Run("失敗率1の場合は常に失敗する", func(t *testing.T) {
+		injector, err := Parse("fail=1,seed=1")
+		require.NoError(t, err)
+
+		err = injector.Inject(TargetGH, []string{"issue", "list"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInjected))
+		assert.Contains(t, err.Error(), "gh issue list")
+		assert.Equal(t, Stats{Calls: 1, Failures: 1}, injector.Stats()[TargetGH])
+	})
+
+	t.Run("遅延率1の場合は最大遅延以内で待機する", func(t *testing.T) {
+		injector, err := Parse("delay=1,max-delay=100ms,seed=1")
+		require.NoError(t, err)
+		var slept []time.Duration
+		injector.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+		for i := 0; i < 5; i++ {
+			require.NoError(t, injector.Inject(TargetTmux, []string{"list-windows"}))
+		}
+		require.Len(t, slept, 5)
+		for _, d := range slept {
+			assert.Greater(t, d, time.Duration(0))
+			assert.LessOrEqual(t, d, 100*time.Millisecond)
+		}
+		assert.Equal(t, Stats{Calls: 5, Delays: 5}, injector.Stats()[TargetTmux])
+	})
+
+	t.Run("対象外の呼び出しには注入しない", func(t *testing.T) {
+		injector, err := Parse("fail=1,targets=gh")
+		require.NoError(t, err)
+
+		assert.NoError(t, injector.Inject(TargetTmux, []string{"list-windows"}))
+		assert.NotContains(t, injector.Stats(), TargetTmux)
+	})
+
+	t.Run("無効の場合は回数を返さない", func(t *testing.T) {
+		var injector *Injector
+		assert.NoError(t, injector.Inject(TargetGH, []string{"issue", "list"}))
+		assert.Nil(t, injector.Stats())
+	})
+
+	t.Run("同じシードでは同じ結果になる", func(t *testing.T) {
+		run := func() []bool {
+			injector, err := Parse("fail=0.5,seed=7")
+			require.NoError(t, err)
+			var results []bool
+			for i := 0; i < 20; i++ {
+				results = append(results, injector.Inject(TargetGH, nil) != nil)
+			}
+			return results
+		}
+		assert.Equal(t, run(), run())
+	})
+
+	t.Run("nilのInjectorは何もしない", func(t *testing.T) {
+		var injector *Injector
+		assert.NoError(t, injector.Inject(TargetGH, []string{"issue", "list"}))
+	})
+}
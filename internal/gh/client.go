@@ -10,6 +10,7 @@ import (
 // Client はghコマンドを使用してGitHub操作を行うクライアント
 type Client struct {
 	executor CommandExecutor
+	labels   []LabelDefinition // 作成するラベルの定義（nilの場合は標準のラベル）
 }
 
 // NewClient は新しいClientを作成する
@@ -8,9 +8,9 @@ import (
 
 // LabelDefinition defines a GitHub label with its properties
 type LabelDefinition struct {
-	Name        string
-	Color       string
-	Description string
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
 }
 
 // 必要なラベル定義
@@ -63,6 +63,120 @@ var requiredLabels = []LabelDefinition{
 		Color:       "f29513",
 		Description: "Currently addressing review feedback",
 	},
+	// Dependency labels
+	{
+		Name:        "status:blocked",
+		Color:       "b60205",
+		Description: "Waiting for sub-issues to close",
+	},
+	// Manual control labels
+	{
+		Name:        "status:manual",
+		Color:       "5319e7",
+		Description: "Handed over to a human, automation paused",
+	},
+	{
+		Name:        "status:awaiting-existing-pr",
+		Color:       "c5def5",
+		Description: "Already being addressed by an open pull request",
+	},
+	{
+		Name:        "status:plan-stale",
+		Color:       "fef2c0",
+		Description: "Issue was edited after planning",
+	},
+	{
+		Name:        "status:possible-duplicate",
+		Color:       "cfd3d7",
+		Description: "Possible duplicate of an existing issue",
+	},
+	{
+		Name:        "status:reverted",
+		Color:       "d93f0b",
+		Description: "Merged changes were reverted",
+	},
+}
+
+// 設定で有効にした機能が使うラベルの定義（設定のラベル名に含まれる場合だけ作成する）
+var optionalLabels = []LabelDefinition{
+	{
+		Name:        "status:needs-human",
+		Color:       "b60205",
+		Description: "Review loop escalated to human reviewers",
+	},
+	{
+		Name:        "status:queued-conflict",
+		Color:       "d4c5f9",
+		Description: "Waiting for an issue touching the same area",
+	},
+	{
+		Name:        "status:degraded",
+		Color:       "fbca04",
+		Description: "Retries are accumulating for this issue",
+	},
+	{
+		Name:        "status:failing",
+		Color:       "b60205",
+		Description: "Retries keep failing for this issue",
+	},
+	{
+		Name:        "status:history-reviewed",
+		Color:       "0e8a16",
+		Description: "Rewritten branch history reviewed, auto-merge allowed",
+	},
+}
+
+// 定義のない設定のラベルの色と説明
+const (
+	configuredLabelColor       = "ededed"
+	configuredLabelDescription = "Managed by osoba"
+)
+
+// ConfiguredLabels は標準のラベルに、設定のラベル名（config.ManagedLabels）のうち標準にないものを加えた定義を返す
+// 定義のないラベル名は共通の色と説明で作成する
+func ConfiguredLabels(names []string) []LabelDefinition {
+	labels := append([]LabelDefinition{}, requiredLabels...)
+	known := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		known[label.Name] = true
+	}
+	for _, name := range names {
+		if name == "" || known[name] {
+			continue
+		}
+		known[name] = true
+		label := LabelDefinition{Name: name, Color: configuredLabelColor, Description: configuredLabelDescription}
+		for _, optional := range optionalLabels {
+			if optional.Name == name {
+				label = optional
+				break
+			}
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// SetLabelNames は作成するラベルに設定のラベル名を加える
+func (c *Client) SetLabelNames(names []string) {
+	c.labels = ConfiguredLabels(names)
+}
+
+// labelDefinitions は作成するラベルの定義を返す（SetLabelNamesを呼んでいない場合は標準のラベル）
+func (c *Client) labelDefinitions() []LabelDefinition {
+	if c.labels == nil {
+		return requiredLabels
+	}
+	return c.labels
+}
+
+// RequiredLabelNames はosobaが作成する標準のラベル名を返す
+func RequiredLabelNames() []string {
+	names := make([]string, 0, len(requiredLabels))
+	for _, label := range requiredLabels {
+		names = append(names, label.Name)
+	}
+	return names
 }
 
 // EnsureLabelsExist は必要なラベルがリポジトリに存在することを保証する
@@ -88,7 +202,7 @@ func (c *Client) EnsureLabelsExist(ctx context.Context, owner, repo string) erro
 	}
 
 	// 不足しているラベルを作成
-	for _, requiredLabel := range requiredLabels {
+	for _, requiredLabel := range c.labelDefinitions() {
 		if !existingLabelMap[requiredLabel.Name] {
 			if err := c.createLabel(ctx, owner, repo, requiredLabel); err != nil {
 				return fmt.Errorf("failed to create label %s: %w", requiredLabel.Name, err)
@@ -125,6 +239,26 @@ func (c *Client) getRepositoryLabels(ctx context.Context, owner, repo string) ([
 	return labels, nil
 }
 
+// ListLabelNames はリポジトリのラベル名の一覧を返す
+func (c *Client) ListLabelNames(ctx context.Context, owner, repo string) ([]string, error) {
+	if owner == "" {
+		return nil, fmt.Errorf("owner is required")
+	}
+	if repo == "" {
+		return nil, fmt.Errorf("repo is required")
+	}
+
+	labels, err := c.getRepositoryLabels(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository labels: %w", err)
+	}
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return names, nil
+}
+
 // createLabel はリポジトリに新しいラベルを作成する
 func (c *Client) createLabel(ctx context.Context, owner, repo string, label LabelDefinition) error {
 	_, err := c.executor.Execute(ctx, "gh", "label", "create", label.Name,
@@ -15,15 +15,21 @@ func TestClient_EnsureLabelsExist(t *testing.T) {
 		color       string
 		description string
 	}{
-		"status:needs-plan":       {"0075ca", "Planning phase required"},
-		"status:ready":            {"0e8a16", "Ready for implementation"},
-		"status:review-requested": {"d93f0b", "Review requested"},
-		"status:planning":         {"1d76db", "Currently in planning phase"},
-		"status:implementing":     {"28a745", "Currently being implemented"},
-		"status:reviewing":        {"e99695", "Currently under review"},
-		"status:lgtm":             {"0e8a16", "Approved"},
-		"status:requires-changes": {"fbca04", "Changes requested"},
-		"status:revising":         {"f29513", "Currently addressing review feedback"},
+		"status:needs-plan":           {"0075ca", "Planning phase required"},
+		"status:ready":                {"0e8a16", "Ready for implementation"},
+		"status:review-requested":     {"d93f0b", "Review requested"},
+		"status:planning":             {"1d76db", "Currently in planning phase"},
+		"status:implementing":         {"28a745", "Currently being implemented"},
+		"status:reviewing":            {"e99695", "Currently under review"},
+		"status:lgtm":                 {"0e8a16", "Approved"},
+		"status:requires-changes":     {"fbca04", "Changes requested"},
+		"status:revising":             {"f29513", "Currently addressing review feedback"},
+		"status:blocked":              {"b60205", "Waiting for sub-issues to close"},
+		"status:possible-duplicate":   {"cfd3d7", "Possible duplicate of an existing issue"},
+		"status:manual":               {"5319e7", "Handed over to a human, automation paused"},
+		"status:awaiting-existing-pr": {"c5def5", "Already being addressed by an open pull request"},
+		"status:reverted":             {"d93f0b", "Merged changes were reverted"},
+		"status:plan-stale":           {"fef2c0", "Issue was edited after planning"},
 	}
 
 	tests := []struct {
@@ -55,6 +61,12 @@ func TestClient_EnsureLabelsExist(t *testing.T) {
 								{"name": "status:lgtm", "color": "0e8a16", "description": "Approved"},
 								{"name": "status:requires-changes", "color": "fbca04", "description": "Changes requested"},
 								{"name": "status:revising", "color": "f29513", "description": "Currently addressing review feedback"},
+								{"name": "status:blocked", "color": "b60205", "description": "Waiting for sub-issues to close"},
+								{"name": "status:manual", "color": "5319e7", "description": "Handed over to a human, automation paused"},
+								{"name": "status:awaiting-existing-pr", "color": "c5def5", "description": "Already being addressed by an open pull request"},
+								{"name": "status:plan-stale", "color": "fef2c0", "description": "Issue was edited after planning"},
+								{"name": "status:possible-duplicate", "color": "cfd3d7", "description": "Possible duplicate of an existing issue"},
+								{"name": "status:reverted", "color": "d93f0b", "description": "Merged changes were reverted"},
 								{"name": "bug", "color": "d73a4a", "description": "Something isn't working"}
 							]`, nil
 						}
@@ -110,8 +122,8 @@ func TestClient_EnsureLabelsExist(t *testing.T) {
 					if callCount == 1 {
 						// 最初の呼び出し: 空のラベル一覧
 						return `[]`, nil
-					} else if callCount <= 10 {
-						// 9つのラベルを作成
+					} else if callCount <= 16 {
+						// 15個のラベルを作成
 						return "", nil
 					}
 					return "", fmt.Errorf("unexpected call count: %d", callCount)
@@ -205,3 +217,40 @@ func TestClient_EnsureLabelsExist(t *testing.T) {
 		})
 	}
 }
+
+func TestConfiguredLabels(t *testing.T) {
+	labels := ConfiguredLabels([]string{"status:needs-plan", "status:degraded", "status:history-reviewed", "status:needs-human", "status:queued-conflict", "ops:failing", ""})
+
+	byName := make(map[string]LabelDefinition)
+	for _, label := range labels {
+		byName[label.Name] = label
+	}
+	assert.Len(t, labels, len(requiredLabels)+5)
+	assert.Equal(t, "fbca04", byName["status:degraded"].Color)
+	assert.Equal(t, "0e8a16", byName["status:history-reviewed"].Color)
+	assert.Equal(t, "b60205", byName["status:needs-human"].Color)
+	assert.Equal(t, "d4c5f9", byName["status:queued-conflict"].Color)
+	assert.Equal(t, LabelDefinition{Name: "ops:failing", Color: configuredLabelColor, Description: configuredLabelDescription}, byName["ops:failing"])
+	_, ok := byName["status:failing"]
+	assert.False(t, ok, "設定にないラベルは作成しない")
+}
+
+func TestClient_EnsureLabelsExist_ConfiguredLabels(t *testing.T) {
+	var created []string
+	mockExec := &MockCommandExecutor{
+		ExecuteFunc: func(ctx context.Context, command string, args ...string) (string, error) {
+			if args[1] == "list" {
+				return `[]`, nil
+			}
+			created = append(created, args[2])
+			return "", nil
+		},
+	}
+	client, err := NewClient(mockExec)
+	require.NoError(t, err)
+	client.SetLabelNames([]string{"status:degraded"})
+
+	require.NoError(t, client.EnsureLabelsExist(context.Background(), "douhashi", "osoba"))
+	assert.Contains(t, created, "status:degraded")
+	assert.NotContains(t, created, "status:failing")
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+
+	"github.com/douhashi/osoba/internal/trace"
 )
 
 // CommandExecutor はコマンド実行の抽象化インターフェース
@@ -45,7 +47,9 @@ func (r *RealCommandExecutor) Execute(ctx context.Context, command string, args
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
+	done := trace.Start(command, args, "")
 	err := cmd.Run()
+	done(err)
 	if err != nil {
 		exitCode := -1
 		if exitError, ok := err.(*exec.ExitError); ok {
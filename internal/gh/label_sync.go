@@ -0,0 +1,113 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// statusLabelPrefix はosobaが管理するラベルの名前空間
+const statusLabelPrefix = "status:"
+
+// LabelDrift は定義と色・説明が異なるラベル
+type LabelDrift struct {
+	Name     string          `json:"name"`
+	Current  LabelDefinition `json:"current"`
+	Expected LabelDefinition `json:"expected"`
+}
+
+// LabelSyncReport はラベル同期の結果
+type LabelSyncReport struct {
+	Missing []LabelDefinition `json:"missing"` // 存在しないラベル
+	Drifted []LabelDrift      `json:"drifted"` // 色・説明が定義と異なるラベル
+	Unknown []string          `json:"unknown"` // status:名前空間の未知ラベル（watcherを混乱させる可能性がある）
+	Applied bool              `json:"applied"` // 修正を適用したか（falseの場合はレポートのみ）
+}
+
+// HasChanges は修正が必要な差分があるかを返す
+func (r *LabelSyncReport) HasChanges() bool {
+	return len(r.Missing) > 0 || len(r.Drifted) > 0
+}
+
+// SyncLabels はリポジトリのラベルを定義（SetLabelNamesで設定のラベル名を加えた定義）と比較し、
+// applyがtrueの場合は不足ラベルの作成と色・説明のずれの修正を行う。applyがfalseの場合は差分のレポートのみを返す
+func (c *Client) SyncLabels(ctx context.Context, owner, repo string, apply bool) (*LabelSyncReport, error) {
+	if owner == "" {
+		return nil, fmt.Errorf("owner is required")
+	}
+	if repo == "" {
+		return nil, fmt.Errorf("repo is required")
+	}
+
+	existingLabels, err := c.getRepositoryLabels(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository labels: %w", err)
+	}
+
+	report := diffLabels(c.labelDefinitions(), existingLabels)
+	if !apply {
+		return report, nil
+	}
+
+	for _, label := range report.Missing {
+		if err := c.createLabel(ctx, owner, repo, label); err != nil {
+			return report, fmt.Errorf("failed to create label %s: %w", label.Name, err)
+		}
+	}
+	for _, drift := range report.Drifted {
+		if err := c.editLabel(ctx, owner, repo, drift.Expected); err != nil {
+			return report, fmt.Errorf("failed to update label %s: %w", drift.Name, err)
+		}
+	}
+	report.Applied = true
+
+	return report, nil
+}
+
+// diffLabels は定義済みラベルと既存ラベルの差分を計算する
+func diffLabels(definitions []LabelDefinition, existing []ghLabelResponse) *LabelSyncReport {
+	report := &LabelSyncReport{}
+
+	existingMap := make(map[string]ghLabelResponse, len(existing))
+	for _, label := range existing {
+		existingMap[label.Name] = label
+	}
+
+	known := make(map[string]bool, len(definitions))
+	for _, def := range definitions {
+		known[def.Name] = true
+
+		current, ok := existingMap[def.Name]
+		if !ok {
+			report.Missing = append(report.Missing, def)
+			continue
+		}
+		// 色は大文字小文字を区別しない（GitHubは小文字で返す）
+		if !strings.EqualFold(current.Color, def.Color) || current.Description != def.Description {
+			report.Drifted = append(report.Drifted, LabelDrift{
+				Name:     def.Name,
+				Current:  LabelDefinition{Name: current.Name, Color: current.Color, Description: current.Description},
+				Expected: def,
+			})
+		}
+	}
+
+	for _, label := range existing {
+		if strings.HasPrefix(label.Name, statusLabelPrefix) && !known[label.Name] {
+			report.Unknown = append(report.Unknown, label.Name)
+		}
+	}
+	sort.Strings(report.Unknown)
+
+	return report
+}
+
+// editLabel は既存ラベルの色と説明を更新する
+func (c *Client) editLabel(ctx context.Context, owner, repo string, label LabelDefinition) error {
+	_, err := c.executor.Execute(ctx, "gh", "label", "edit", label.Name,
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--color", label.Color,
+		"--description", label.Description)
+	return err
+}
@@ -0,0 +1,143 @@
+package gh
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const driftedLabelList = `[
+	{"name": "status:needs-plan", "color": "0075CA", "description": "Planning phase required"},
+	{"name": "status:ready", "color": "ffffff", "description": "Ready for implementation"},
+	{"name": "status:review-requested", "color": "d93f0b", "description": "old description"},
+	{"name": "status:planning", "color": "1d76db", "description": "Currently in planning phase"},
+	{"name": "status:implementing", "color": "28a745", "description": "Currently being implemented"},
+	{"name": "status:reviewing", "color": "e99695", "description": "Currently under review"},
+	{"name": "status:lgtm", "color": "0e8a16", "description": "Approved"},
+	{"name": "status:requires-changes", "color": "fbca04", "description": "Changes requested"},
+	{"name": "status:blocked", "color": "b60205", "description": "Waiting for sub-issues to close"},
+	{"name": "status:manual", "color": "5319e7", "description": "Handed over to a human, automation paused"},
+	{"name": "status:needs-human", "color": "b60205", "description": "Review loop escalated to human reviewers"},
+	{"name": "status:awaiting-existing-pr", "color": "c5def5", "description": "Already being addressed by an open pull request"},
+	{"name": "status:plan-stale", "color": "fef2c0", "description": "Issue was edited after planning"},
+	{"name": "status:possible-duplicate", "color": "cfd3d7", "description": "Possible duplicate of an existing issue"},
+	{"name": "status:reverted", "color": "d93f0b", "description": "Merged changes were reverted"},
+	{"name": "status:queued-conflict", "color": "d4c5f9", "description": "Waiting for an issue touching the same area"},
+	{"name": "status:on-hold", "color": "000000", "description": ""},
+	{"name": "bug", "color": "d73a4a", "description": "Something isn't working"}
+]`
+
+func TestClient_SyncLabels(t *testing.T) {
+	configuredNames := []string{"status:needs-plan", "status:ready", "status:needs-human", "status:queued-conflict"}
+
+	tests := []struct {
+		name        string
+		apply       bool
+		labelNames  []string
+		wantMissing []string
+		wantUnknown []string
+		wantCalls   []string
+		wantApplied bool
+	}{
+		{
+			name:        "レポートモード: 変更は行わない",
+			apply:       false,
+			labelNames:  configuredNames,
+			wantMissing: []string{"status:revising"},
+			wantUnknown: []string{"status:on-hold"},
+			wantCalls:   nil,
+		},
+		{
+			name:        "同期モード: 不足ラベルの作成とずれの修正",
+			apply:       true,
+			labelNames:  configuredNames,
+			wantMissing: []string{"status:revising"},
+			wantUnknown: []string{"status:on-hold"},
+			wantCalls: []string{
+				"label create status:revising",
+				"label edit status:ready",
+				"label edit status:review-requested",
+			},
+			wantApplied: true,
+		},
+		{
+			name:        "設定で名前を変更したラベルも作成する",
+			apply:       true,
+			labelNames:  []string{"status:needs-plan", "status:waiting-human"},
+			wantMissing: []string{"status:revising", "status:waiting-human"},
+			wantUnknown: []string{"status:needs-human", "status:on-hold", "status:queued-conflict"},
+			wantCalls: []string{
+				"label create status:revising",
+				"label create status:waiting-human",
+				"label edit status:ready",
+				"label edit status:review-requested",
+			},
+			wantApplied: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			executor := &MockCommandExecutor{
+				ExecuteFunc: func(ctx context.Context, command string, args ...string) (string, error) {
+					if args[0] == "label" && args[1] == "list" {
+						return driftedLabelList, nil
+					}
+					calls = append(calls, strings.Join(args[:3], " "))
+					return "", nil
+				},
+			}
+			client, err := NewClient(executor)
+			require.NoError(t, err)
+			client.SetLabelNames(tt.labelNames)
+
+			report, err := client.SyncLabels(context.Background(), "douhashi", "osoba", tt.apply)
+			require.NoError(t, err)
+
+			var missing []string
+			for _, label := range report.Missing {
+				missing = append(missing, label.Name)
+			}
+			assert.Equal(t, tt.wantMissing, missing)
+			require.Len(t, report.Drifted, 2)
+			assert.Equal(t, "status:ready", report.Drifted[0].Name)
+			assert.Equal(t, "ffffff", report.Drifted[0].Current.Color)
+			assert.Equal(t, "status:review-requested", report.Drifted[1].Name)
+			assert.Equal(t, tt.wantUnknown, report.Unknown)
+			assert.True(t, report.HasChanges())
+			assert.Equal(t, tt.wantApplied, report.Applied)
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestClient_SyncLabels_Validation(t *testing.T) {
+	client, err := NewClient(&MockCommandExecutor{})
+	require.NoError(t, err)
+
+	_, err = client.SyncLabels(context.Background(), "", "osoba", false)
+	assert.EqualError(t, err, "owner is required")
+	_, err = client.SyncLabels(context.Background(), "douhashi", "", false)
+	assert.EqualError(t, err, "repo is required")
+}
+
+func TestClient_ListLabelNames(t *testing.T) {
+	executor := &MockCommandExecutor{
+		ExecuteFunc: func(ctx context.Context, command string, args ...string) (string, error) {
+			return `[{"name": "approved", "color": "0e8a16", "description": ""}, {"name": "bug", "color": "d73a4a", "description": ""}]`, nil
+		},
+	}
+	client, err := NewClient(executor)
+	require.NoError(t, err)
+
+	names, err := client.ListLabelNames(context.Background(), "douhashi", "osoba")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"approved", "bug"}, names)
+
+	_, err = client.ListLabelNames(context.Background(), "", "osoba")
+	assert.EqualError(t, err, "owner is required")
+}
@@ -0,0 +1,65 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Artifact はフェーズが成果物ディレクトリに置いたファイル
+type Artifact struct {
+	Name    string    `json:"name"` // 成果物ディレクトリからの相対パス
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// ArtifactsDirForIssue はIssueの成果物ディレクトリを返す
+// フェーズ間でファイル（plan.md、review.md、test-report.xmlなど）を受け渡すため、worktreeの外（.git/osoba/artifacts/issue-{issue番号}）に置く
+func ArtifactsDirForIssue(repoRoot string, issueNumber int) string {
+	return filepath.Join(repoRoot, ".git", "osoba", "artifacts", fmt.Sprintf("issue-%d", issueNumber))
+}
+
+// EnsureArtifactsDirForIssue はIssueの成果物ディレクトリを作成してパスを返す
+func EnsureArtifactsDirForIssue(repoRoot string, issueNumber int) (string, error) {
+	dir := ArtifactsDirForIssue(repoRoot, issueNumber)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create artifacts directory: %w", err)
+	}
+	return dir, nil
+}
+
+// ListArtifacts は成果物ディレクトリのファイルを名前順に返す（ディレクトリがない場合は空）
+func ListArtifacts(dir string) ([]Artifact, error) {
+	var artifacts []Artifact
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, Artifact{Name: filepath.ToSlash(name), Path: path, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Name < artifacts[j].Name })
+	return artifacts, nil
+}
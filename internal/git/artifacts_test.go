@@ -0,0 +1,36 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifacts(t *testing.T) {
+	root := t.TempDir()
+	assert.Equal(t, filepath.Join(root, ".git", "osoba", "artifacts", "issue-12"), ArtifactsDirForIssue(root, 12))
+
+	// ディレクトリがない場合は空
+	artifacts, err := ListArtifacts(ArtifactsDirForIssue(root, 12))
+	require.NoError(t, err)
+	assert.Empty(t, artifacts)
+
+	dir, err := EnsureArtifactsDirForIssue(root, 12)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "review.md"), []byte("LGTM"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "reports"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "reports", "test-report.xml"), []byte("<testsuites/>"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plan.md"), []byte("# Plan"), 0o644))
+
+	artifacts, err = ListArtifacts(dir)
+	require.NoError(t, err)
+	require.Len(t, artifacts, 3)
+	assert.Equal(t, "plan.md", artifacts[0].Name)
+	assert.Equal(t, "reports/test-report.xml", artifacts[1].Name)
+	assert.Equal(t, filepath.Join(dir, "reports", "test-report.xml"), artifacts[1].Path)
+	assert.Equal(t, "review.md", artifacts[2].Name)
+	assert.Equal(t, int64(4), artifacts[2].Size)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/trace"
 )
 
 // Command はgitコマンド実行を管理する構造体
@@ -50,7 +51,9 @@ func (c *Command) Run(ctx context.Context, command string, args []string, workDi
 	cmd.Stderr = &stderr
 
 	// コマンドを実行
+	done := trace.Start(command, args, workDir)
 	err := cmd.Run()
+	done(err)
 
 	// 出力を文字列として取得
 	stdoutStr := strings.TrimSpace(stdout.String())
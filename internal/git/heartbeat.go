@@ -0,0 +1,59 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// HeartbeatFile は実行中のエージェントが定期的に更新するハートビートのファイル（worktreeのルートからの相対パス）
+const HeartbeatFile = ".osoba/heartbeat"
+
+// HeartbeatPath はworktreeのハートビートのファイルのパスを返す
+func HeartbeatPath(worktreePath string) string {
+	return filepath.Join(worktreePath, filepath.FromSlash(HeartbeatFile))
+}
+
+// ReadHeartbeat はworktreeのハートビートが最後に更新された日時を返す（ファイルがない場合はfalse）
+func ReadHeartbeat(worktreePath string) (time.Time, bool) {
+	info, err := os.Stat(HeartbeatPath(worktreePath))
+	if err != nil || info.IsDir() {
+		return time.Time{}, false
+	}
+	return info.ModTime(), true
+}
+
+// EnsureHeartbeatExcluded はハートビートのファイルをリポジトリの.git/info/excludeに追加する
+// worktreeは.git/info/excludeを共有するため、ハートビートがコミットされたり未コミットの変更とみなされたりしない
+func EnsureHeartbeatExcluded(repoRoot string) error {
+	return EnsureExcluded(repoRoot, HeartbeatFile, "osobaのエージェントのハートビート")
+}
+
+// EnsureExcluded はosobaがworktreeに書き出すファイル（worktreeのルートからの相対パス）をリポジトリの.git/info/excludeに追加する
+func EnsureExcluded(repoRoot, file, comment string) error {
+	path := filepath.Join(repoRoot, ".git", "info", "exclude")
+	pattern := "/" + file
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == pattern {
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		data = append(data, '\n')
+	}
+	data = append(data, []byte("# "+comment+"\n"+pattern+"\n")...)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
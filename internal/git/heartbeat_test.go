@@ -0,0 +1,49 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadHeartbeat(t *testing.T) {
+	worktree := t.TempDir()
+	_, ok := ReadHeartbeat(worktree)
+	assert.False(t, ok, "ハートビートがない")
+
+	beatAt := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, os.MkdirAll(filepath.Join(worktree, ".osoba"), 0o755))
+	require.NoError(t, os.WriteFile(HeartbeatPath(worktree), nil, 0o644))
+	require.NoError(t, os.Chtimes(HeartbeatPath(worktree), beatAt, beatAt))
+
+	got, ok := ReadHeartbeat(worktree)
+	assert.True(t, ok)
+	assert.True(t, beatAt.Equal(got))
+}
+
+func TestEnsureHeartbeatExcluded(t *testing.T) {
+	root := t.TempDir()
+	excludePath := filepath.Join(root, ".git", "info", "exclude")
+	require.NoError(t, os.MkdirAll(filepath.Dir(excludePath), 0o755))
+	require.NoError(t, os.WriteFile(excludePath, []byte("*.log"), 0o644))
+
+	require.NoError(t, EnsureHeartbeatExcluded(root))
+	require.NoError(t, EnsureHeartbeatExcluded(root), "追加済みの場合は何もしない")
+
+	data, err := os.ReadFile(excludePath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "*.log\n"), "既存の内容は残す")
+	assert.Equal(t, 1, strings.Count(string(data), "/.osoba/heartbeat\n"))
+
+	// ハートビート以外のファイルも同じ方法で除外する
+	require.NoError(t, EnsureExcluded(root, ".osoba/bot-reviews.md", "レビューボットのレビュー"))
+	data, err = os.ReadFile(excludePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# レビューボットのレビュー\n/.osoba/bot-reviews.md\n")
+	assert.Equal(t, 1, strings.Count(string(data), "/.osoba/heartbeat\n"))
+}
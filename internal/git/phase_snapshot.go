@@ -0,0 +1,105 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// PhaseEndSnapshotFile はフェーズの終了時点の未コミットの変更を記録するファイル（worktreeのgitディレクトリからの相対パス）
+// worktreeの作業ツリーの外に置くため、記録自体が未コミットの変更とみなされることはない
+const PhaseEndSnapshotFile = "osoba-phase-end"
+
+// PhaseEndSnapshotCommand はフェーズのエージェントの終了後にペインで実行し、未コミットの変更を記録するシェルコマンドを返す
+func PhaseEndSnapshotCommand(worktreePath string) string {
+	dir := "'" + strings.ReplaceAll(worktreePath, "'", `'\''`) + "'"
+	return fmt.Sprintf(`git -C %s status --porcelain=v1 --untracked-files=all > "$(git -C %s rev-parse --absolute-git-dir)/%s"`, dir, dir, PhaseEndSnapshotFile)
+}
+
+// RecordPhaseEnd は現在の未コミットの変更をフェーズの終了時点として記録する
+// 人が加えた変更を受け入れて自動処理を再開する場合（osoba release）に使う
+func RecordPhaseEnd(ctx context.Context, worktreePath string) error {
+	gitDir, err := runGitIn(ctx, worktreePath, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return err
+	}
+	status, err := runGitIn(ctx, worktreePath, "status", "--porcelain=v1", "--untracked-files=all")
+	if err != nil {
+		return err
+	}
+	if status != "" {
+		status += "\n"
+	}
+	if err := os.WriteFile(filepath.Join(gitDir, PhaseEndSnapshotFile), []byte(status), 0o644); err != nil {
+		return fmt.Errorf("failed to record phase end snapshot: %w", err)
+	}
+	return nil
+}
+
+// ChangesSincePhaseEnd はフェーズの終了時点の記録より後に加えられた未コミットの変更を返す
+// 記録にない変更と、記録にあるが記録より後に更新されたファイルを変更とみなす（記録がない場合はrecorded=false）
+func ChangesSincePhaseEnd(ctx context.Context, worktreePath string) (changes []string, recorded bool, err error) {
+	gitDir, err := runGitIn(ctx, worktreePath, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return nil, false, err
+	}
+	snapshotPath := filepath.Join(gitDir, PhaseEndSnapshotFile)
+	info, err := os.Stat(snapshotPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read phase end snapshot: %w", err)
+	}
+	data, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read phase end snapshot: %w", err)
+	}
+	snapshot := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			snapshot[line] = true
+		}
+	}
+
+	status, err := runGitIn(ctx, worktreePath, "status", "--porcelain=v1", "--untracked-files=all")
+	if err != nil {
+		return nil, true, err
+	}
+	for _, line := range strings.Split(status, "\n") {
+		if line == "" {
+			continue
+		}
+		if !snapshot[line] {
+			changes = append(changes, line)
+			continue
+		}
+		if len(line) > 3 {
+			path := line[3:]
+			if _, to, ok := strings.Cut(path, " -> "); ok {
+				path = to
+			}
+			if fi, err := os.Stat(filepath.Join(worktreePath, path)); err == nil && fi.ModTime().After(info.ModTime()) {
+				changes = append(changes, line)
+			}
+		}
+	}
+	return changes, true, nil
+}
+
+// runGitIn はディレクトリでgitコマンドを実行し、末尾の改行を除いた標準出力を返す
+func runGitIn(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(string(output), "\n"), nil
+}
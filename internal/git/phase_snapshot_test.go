@@ -0,0 +1,61 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/testutil/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestChangesSincePhaseEnd(t *testing.T) {
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	cmd := NewCommand(logger)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	runGit(t, cmd, dir, "init")
+	runGit(t, cmd, dir, "config", "user.email", "test@example.com")
+	runGit(t, cmd, dir, "config", "user.name", "Test User")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "util.go"), []byte("package main\n"), 0644))
+	runGit(t, cmd, dir, "add", ".")
+	runGit(t, cmd, dir, "commit", "-m", "initial commit")
+
+	// 記録がない場合は判定できない
+	_, recorded, err := ChangesSincePhaseEnd(ctx, dir)
+	require.NoError(t, err)
+	assert.False(t, recorded)
+
+	// フェーズが残した未コミットの変更をペインのコマンドで記録する
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package agent\n"), 0644))
+	require.NoError(t, exec.Command("sh", "-c", PhaseEndSnapshotCommand(dir)).Run())
+
+	changes, recorded, err := ChangesSincePhaseEnd(ctx, dir)
+	require.NoError(t, err)
+	assert.True(t, recorded)
+	assert.Empty(t, changes, "フェーズが残した変更は外部の変更とみなさない")
+
+	// 記録より後に加えた変更は外部の変更とみなす
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "util.go"), []byte("package human\n"), 0644))
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package human\n"), 0644))
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "main.go"), future, future))
+
+	changes, _, err = ChangesSincePhaseEnd(ctx, dir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{" M main.go", " M util.go"}, changes)
+
+	// 現在の変更を受け入れた後は外部の変更とみなさない
+	require.NoError(t, RecordPhaseEnd(ctx, dir))
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "main.go"), time.Now().Add(-time.Minute), time.Now().Add(-time.Minute)))
+	changes, _, err = ChangesSincePhaseEnd(ctx, dir)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
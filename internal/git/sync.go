@@ -399,6 +399,37 @@ func (s *Sync) FetchBranch(ctx context.Context, repoPath, remote, branch string)
 	return nil
 }
 
+// FetchRef はリモートの参照（refs/pull/<番号>/headなど）をローカルのブランチにフェッチする
+// ローカルのブランチが既にある場合はリモートの参照の位置に更新する
+// branchが空の場合はFETCH_HEADにのみ取得する（チェックアウト中のブランチを更新する場合に使う）
+func (s *Sync) FetchRef(ctx context.Context, repoPath, remote, ref, branch string) error {
+	logFields := []interface{}{
+		"repoPath", repoPath,
+		"remote", remote,
+		"ref", ref,
+		"branch", branch,
+	}
+
+	s.logger.Info("Fetching ref from remote", logFields...)
+
+	// git fetch <remote> +<ref>:refs/heads/<branch> を実行
+	args := []string{"fetch", remote, fmt.Sprintf("+%s:refs/heads/%s", ref, branch)}
+	if branch == "" {
+		args = []string{"fetch", remote, ref}
+	}
+	output, err := s.command.Run(ctx, "git", args, repoPath)
+	if err != nil {
+		errorFields := append(logFields, "error", err.Error())
+		s.logger.Error("Failed to fetch ref from remote", errorFields...)
+		return fmt.Errorf("failed to fetch ref: %w", err)
+	}
+
+	successFields := append(logFields, "output", output)
+	s.logger.Info("Ref fetched successfully", successFields...)
+
+	return nil
+}
+
 // ResetHard はgit reset --hardを実行してローカル変更を破棄する
 func (s *Sync) ResetHard(ctx context.Context, repoPath, ref string) error {
 	logFields := []interface{}{
@@ -33,6 +33,26 @@ func NewWorktree(logger logger.Logger) *Worktree {
 
 // Create は新しいworktreeを作成する
 func (w *Worktree) Create(ctx context.Context, repoPath, worktreePath, branch string) error {
+	return w.create(ctx, repoPath, worktreePath, branch, true)
+}
+
+// CreateWithoutCheckout はファイルをチェックアウトせずに新しいworktreeを作成する
+// git worktree addは同じリポジトリで並行して実行できないため、複数のworktreeを作成する場合は
+// この処理だけを直列に行い、時間のかかるチェックアウト（Populate）を並行して行う
+func (w *Worktree) CreateWithoutCheckout(ctx context.Context, repoPath, worktreePath, branch string) error {
+	return w.create(ctx, repoPath, worktreePath, branch, false)
+}
+
+// Populate はCreateWithoutCheckoutで作成したworktreeにブランチのファイルをチェックアウトする
+func (w *Worktree) Populate(ctx context.Context, worktreePath string) error {
+	if _, err := w.command.Run(ctx, "git", []string{"reset", "--hard", "--quiet"}, worktreePath); err != nil {
+		w.logger.Error("Failed to populate git worktree", "worktreePath", worktreePath, "error", err.Error())
+		return fmt.Errorf("failed to populate worktree: %w", err)
+	}
+	return nil
+}
+
+func (w *Worktree) create(ctx context.Context, repoPath, worktreePath, branch string, checkout bool) error {
 	logFields := []interface{}{
 		"repoPath", repoPath,
 		"worktreePath", worktreePath,
@@ -43,6 +63,9 @@ func (w *Worktree) Create(ctx context.Context, repoPath, worktreePath, branch st
 
 	// worktreeを作成（ブランチは既に存在するので-bフラグは使わない）
 	args := []string{"worktree", "add", worktreePath, branch}
+	if !checkout {
+		args = []string{"worktree", "add", "--no-checkout", worktreePath, branch}
+	}
 	output, err := w.command.Run(ctx, "git", args, repoPath)
 	if err != nil {
 		errorFields := append(logFields, "error", err.Error())
@@ -97,6 +120,28 @@ func (w *Worktree) Remove(ctx context.Context, repoPath, worktreePath string) er
 	return nil
 }
 
+// Move はworktreeを別のパスに移動する（ブランチと未コミットの変更はそのまま引き継がれる）
+func (w *Worktree) Move(ctx context.Context, repoPath, worktreePath, newPath string) error {
+	logFields := []interface{}{
+		"repoPath", repoPath,
+		"worktreePath", worktreePath,
+		"newPath", newPath,
+	}
+
+	w.logger.Info("Moving git worktree", logFields...)
+
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		return fmt.Errorf("failed to create worktree parent directory: %w", err)
+	}
+	if _, err := w.command.Run(ctx, "git", []string{"worktree", "move", worktreePath, newPath}, repoPath); err != nil {
+		w.logger.Error("Failed to move git worktree", append(logFields, "error", err.Error())...)
+		return fmt.Errorf("failed to move worktree: %w", err)
+	}
+
+	w.logger.Info("Git worktree moved successfully", logFields...)
+	return nil
+}
+
 // List は全てのworktreeの情報を取得する
 func (w *Worktree) List(ctx context.Context, repoPath string) ([]WorktreeInfo, error) {
 	logFields := []interface{}{
@@ -0,0 +1,124 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// WorktreeBatchPreparer は複数のIssueのworktreeをまとめて作成できるWorktreeManager
+type WorktreeBatchPreparer interface {
+	// PrepareWorktreesForIssues は未作成のworktreeを最大maxParallel件ずつ並行して作成する
+	// mainブランチの最新化は新しいブランチが必要な場合に1回だけ行う
+	// 戻り値は作成に失敗したIssueごとのエラー（既存のworktreeは対象外）
+	PrepareWorktreesForIssues(ctx context.Context, issueNumbers []int, maxParallel int) map[int]error
+}
+
+var _ WorktreeBatchPreparer = (*worktreeManager)(nil)
+
+// PrepareWorktreesForIssues は未作成のworktreeを最大maxParallel件ずつ並行して作成する
+func (m *worktreeManager) PrepareWorktreesForIssues(ctx context.Context, issueNumbers []int, maxParallel int) map[int]error {
+	failed := make(map[int]error)
+
+	worktrees, err := m.worktree.List(ctx, m.basePath)
+	if err != nil {
+		for _, n := range issueNumbers {
+			failed[n] = fmt.Errorf("failed to list worktrees: %w", err)
+		}
+		return failed
+	}
+	existing := make(map[string]bool, len(worktrees))
+	for _, wt := range worktrees {
+		existing[wt.Path] = true
+	}
+
+	var targets []int
+	seen := make(map[int]bool, len(issueNumbers))
+	needsMain := false
+	for _, n := range issueNumbers {
+		if n <= 0 {
+			failed[n] = fmt.Errorf("invalid issue number: %d", n)
+			continue
+		}
+		if seen[n] || existing[m.GetWorktreePathForIssue(n)] {
+			continue
+		}
+		seen[n] = true
+		targets = append(targets, n)
+		if !m.branch.Exists(ctx, m.basePath, m.generateBranchNameForIssue(n)) {
+			needsMain = true
+		}
+	}
+	if len(targets) == 0 {
+		return failed
+	}
+
+	// mainブランチの取得はIssueごとではなく1回だけ行う
+	if needsMain {
+		if err := m.UpdateMainBranch(ctx); err != nil {
+			for _, n := range targets {
+				failed[n] = fmt.Errorf("failed to update main branch: %w", err)
+			}
+			return failed
+		}
+	}
+
+	if maxParallel < 1 {
+		maxParallel = 1
+	}
+	var (
+		mu       sync.Mutex // failedの保護用
+		registry sync.Mutex // ブランチとworktreeの登録（リポジトリの管理情報の更新）は直列に行う
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, maxParallel)
+	)
+	for _, n := range targets {
+		wg.Add(1)
+		go func(issueNumber int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := m.createWorktreeFromMain(ctx, issueNumber, &registry); err != nil {
+				mu.Lock()
+				failed[issueNumber] = err
+				mu.Unlock()
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	return failed
+}
+
+// createWorktreeFromMain はmainブランチを最新化せずにIssueのブランチとworktreeを作成する
+// ブランチとworktreeの登録はregistryで直列化し、ファイルのチェックアウトのみを並行して行う
+// チェックアウトに失敗した場合は、フェーズ開始時に作り直せるようファイルのないworktreeを削除する
+func (m *worktreeManager) createWorktreeFromMain(ctx context.Context, issueNumber int, registry *sync.Mutex) error {
+	branchName := m.generateBranchNameForIssue(issueNumber)
+	worktreePath := m.GetWorktreePathForIssue(issueNumber)
+
+	registry.Lock()
+	if !m.branch.Exists(ctx, m.basePath, branchName) {
+		if err := m.branch.Create(ctx, m.basePath, branchName, "main"); err != nil {
+			registry.Unlock()
+			return fmt.Errorf("failed to create branch: %w", err)
+		}
+	}
+	err := m.worktree.CreateWithoutCheckout(ctx, m.basePath, worktreePath, branchName)
+	registry.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	if err := m.worktree.Populate(ctx, worktreePath); err != nil {
+		registry.Lock()
+		removeErr := m.worktree.Remove(ctx, m.basePath, worktreePath)
+		registry.Unlock()
+		if removeErr != nil {
+			return fmt.Errorf("%w (failed to remove the unpopulated worktree: %v)", err, removeErr)
+		}
+		return err
+	}
+	return nil
+}
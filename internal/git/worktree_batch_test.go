@@ -0,0 +1,58 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/testutil/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWorktreeManager_PrepareWorktreesForIssues(t *testing.T) {
+	ctx := context.Background()
+	basePath := t.TempDir()
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	cmd := NewCommand(logger)
+
+	runGit(t, cmd, basePath, "init")
+	runGit(t, cmd, basePath, "config", "user.email", "test@example.com")
+	runGit(t, cmd, basePath, "config", "user.name", "Test User")
+	require.NoError(t, os.WriteFile(filepath.Join(basePath, "test.txt"), []byte("initial content"), 0644))
+	runGit(t, cmd, basePath, "add", ".")
+	runGit(t, cmd, basePath, "commit", "-m", "initial commit")
+	runGit(t, cmd, basePath, "branch", "-M", "main")
+	// リモートがないためmainの取得は失敗するが、ローカルのmainで続行する
+	runGit(t, cmd, basePath, "checkout", "-b", "develop")
+
+	branch := NewBranch(logger)
+	manager, err := NewWorktreeManager(&mockRepository{rootPath: basePath}, NewWorktree(logger), branch, NewSync(logger))
+	require.NoError(t, err)
+
+	// 既存のworktreeは作り直さない
+	existingPath := manager.GetWorktreePathForIssue(1)
+	runGit(t, cmd, basePath, "worktree", "add", "-b", "osoba/#1", existingPath, "main")
+	require.NoError(t, os.WriteFile(filepath.Join(existingPath, "wip.txt"), []byte("wip"), 0644))
+
+	preparer, ok := manager.(WorktreeBatchPreparer)
+	require.True(t, ok)
+	failed := preparer.PrepareWorktreesForIssues(ctx, []int{1, 2, 3, 4, 4, 0}, 2)
+
+	require.Len(t, failed, 1)
+	assert.Error(t, failed[0])
+
+	for _, n := range []int{1, 2, 3, 4} {
+		exists, err := manager.WorktreeExistsForIssue(ctx, n)
+		require.NoError(t, err)
+		assert.True(t, exists, "issue %d", n)
+	}
+	assert.FileExists(t, filepath.Join(existingPath, "wip.txt"))
+
+	current, err := branch.GetCurrent(ctx, manager.GetWorktreePathForIssue(3))
+	require.NoError(t, err)
+	assert.Equal(t, "osoba/#3", current)
+	assert.FileExists(t, filepath.Join(manager.GetWorktreePathForIssue(3), "test.txt"))
+}
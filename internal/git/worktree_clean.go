@@ -0,0 +1,44 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// WorktreeCleaner はフェーズの開始時にIssueのworktreeを最新のコミットの状態に戻せるWorktreeManager
+type WorktreeCleaner interface {
+	// CleanWorktree はworktreeの未コミットの変更を破棄し、追跡していないファイル（.gitignoreで無視されたファイルを含む）を削除する
+	// excludesのパターンに一致するファイルは削除しない
+	CleanWorktree(ctx context.Context, worktreePath string, excludes []string) error
+}
+
+var (
+	_ WorktreeCleaner = (*worktreeManager)(nil)
+	_ WorktreeCleaner = (*cloneManager)(nil)
+)
+
+// Clean はworktreeをHEADの状態に戻し、追跡していないファイルを削除する（git reset --hard・git clean -fdx）
+func (w *Worktree) Clean(ctx context.Context, worktreePath string, excludes []string) error {
+	if _, err := w.command.Run(ctx, "git", []string{"reset", "--hard", "HEAD"}, worktreePath); err != nil {
+		return fmt.Errorf("failed to reset worktree: %w", err)
+	}
+
+	args := []string{"clean", "-fdx"}
+	for _, pattern := range excludes {
+		args = append(args, "-e", pattern)
+	}
+	if _, err := w.command.Run(ctx, "git", args, worktreePath); err != nil {
+		return fmt.Errorf("failed to clean worktree: %w", err)
+	}
+	return nil
+}
+
+// CleanWorktree はworktreeを最新のコミットの状態に戻す
+func (m *worktreeManager) CleanWorktree(ctx context.Context, worktreePath string, excludes []string) error {
+	return m.worktree.Clean(ctx, worktreePath, excludes)
+}
+
+// CleanWorktree はcloneを最新のコミットの状態に戻す
+func (c *cloneManager) CleanWorktree(ctx context.Context, worktreePath string, excludes []string) error {
+	return c.main.CleanWorktree(ctx, worktreePath, excludes)
+}
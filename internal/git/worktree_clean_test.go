@@ -0,0 +1,43 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/testutil/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWorktree_Clean(t *testing.T) {
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	cmd := NewCommand(logger)
+
+	dir := t.TempDir()
+	runGit(t, cmd, dir, "init")
+	runGit(t, cmd, dir, "config", "user.email", "test@example.com")
+	runGit(t, cmd, dir, "config", "user.name", "Test User")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("build/\n.env\n"), 0644))
+	runGit(t, cmd, dir, "add", ".")
+	runGit(t, cmd, dir, "commit", "-m", "initial commit")
+
+	// 前のフェーズの変更・ビルド成果物・追跡していないファイル
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package changed\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "build"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "build", "app"), []byte("binary"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scratch.txt"), []byte("notes"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("TOKEN=x"), 0644))
+
+	require.NoError(t, NewWorktree(logger).Clean(context.Background(), dir, []string{".env"}))
+
+	content, err := os.ReadFile(filepath.Join(dir, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n", string(content))
+	assert.NoDirExists(t, filepath.Join(dir, "build"))
+	assert.NoFileExists(t, filepath.Join(dir, "scratch.txt"))
+	assert.FileExists(t, filepath.Join(dir, ".env"))
+}
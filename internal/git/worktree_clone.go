@@ -0,0 +1,315 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// cloneManager はgit worktreeを使わず、Issueごとのcloneでブランチを切り替えるWorktreeManagerの実装
+// git worktreeが正しく動作しないファイルシステム（一部のネットワークマウントなど）で使用する
+// cloneはworktreeと同じパス（.git/osoba/worktrees/配下、またはscratch volume上）に作成するため、パスを前提とする処理はそのまま使える
+type cloneManager struct {
+	main *worktreeManager // メインのチェックアウトに対する操作（mainブランチの最新化など）
+}
+
+var _ WorktreeBatchPreparer = (*cloneManager)(nil)
+
+// NewCloneManager はIssueごとのcloneを作業ディレクトリとするWorktreeManagerを作成する
+func NewCloneManager(repository Repository, worktree *Worktree, branch *Branch, sync *Sync, opts ...WorktreeManagerOption) (WorktreeManager, error) {
+	manager, err := NewWorktreeManager(repository, worktree, branch, sync, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &cloneManager{main: manager.(*worktreeManager)}, nil
+}
+
+// UpdateMainBranch はmainブランチを最新化する
+func (c *cloneManager) UpdateMainBranch(ctx context.Context) error {
+	return c.main.UpdateMainBranch(ctx)
+}
+
+// CreateWorktree は指定されたIssueとフェーズのcloneを作成する
+func (c *cloneManager) CreateWorktree(ctx context.Context, issueNumber int, phase Phase) error {
+	return c.create(ctx, c.GetWorktreePath(issueNumber, phase), c.main.generateBranchName(issueNumber, phase))
+}
+
+// RemoveWorktree は指定されたIssueとフェーズのcloneを削除する
+func (c *cloneManager) RemoveWorktree(ctx context.Context, issueNumber int, phase Phase) error {
+	return c.remove(ctx, c.GetWorktreePath(issueNumber, phase), c.main.generateBranchName(issueNumber, phase))
+}
+
+// GetWorktreePath は指定されたIssueとフェーズのcloneのパスを返す
+func (c *cloneManager) GetWorktreePath(issueNumber int, phase Phase) string {
+	return c.main.GetWorktreePath(issueNumber, phase)
+}
+
+// WorktreeExists は指定されたIssueとフェーズのcloneが存在するかを確認する
+func (c *cloneManager) WorktreeExists(ctx context.Context, issueNumber int, phase Phase) (bool, error) {
+	return isClone(c.GetWorktreePath(issueNumber, phase)), nil
+}
+
+// GetWorktreePathForIssue は指定されたIssueのcloneのパスを返す
+func (c *cloneManager) GetWorktreePathForIssue(issueNumber int) string {
+	return c.main.GetWorktreePathForIssue(issueNumber)
+}
+
+// WorktreeExistsForIssue は指定されたIssueのcloneが存在するかを確認する
+func (c *cloneManager) WorktreeExistsForIssue(ctx context.Context, issueNumber int) (bool, error) {
+	return isClone(c.GetWorktreePathForIssue(issueNumber)), nil
+}
+
+// CreateWorktreeForIssue は指定されたIssueのcloneを作成する
+func (c *cloneManager) CreateWorktreeForIssue(ctx context.Context, issueNumber int) error {
+	if issueNumber <= 0 {
+		return fmt.Errorf("invalid issue number: %d", issueNumber)
+	}
+	return c.create(ctx, c.GetWorktreePathForIssue(issueNumber), c.main.generateBranchNameForIssue(issueNumber))
+}
+
+// RemoveWorktreeForIssue は指定されたIssueのcloneを削除する
+func (c *cloneManager) RemoveWorktreeForIssue(ctx context.Context, issueNumber int) error {
+	if issueNumber <= 0 {
+		return fmt.Errorf("invalid issue number: %d", issueNumber)
+	}
+	return c.remove(ctx, c.GetWorktreePathForIssue(issueNumber), c.main.generateBranchNameForIssue(issueNumber))
+}
+
+// BranchesRemovedForIssue はRemoveWorktreeForIssueで削除されるブランチ（cloneとともに失われるブランチ）を返す
+func (c *cloneManager) BranchesRemovedForIssue(ctx context.Context, issueNumber int) []string {
+	if c.main.keepBranches || !isClone(c.GetWorktreePathForIssue(issueNumber)) {
+		return nil
+	}
+	return []string{c.main.generateBranchNameForIssue(issueNumber)}
+}
+
+// ListWorktreesForIssue は指定されたIssueに関連するcloneを全て検索する
+func (c *cloneManager) ListWorktreesForIssue(ctx context.Context, issueNumber int) ([]WorktreeInfo, error) {
+	all, err := c.ListAllWorktrees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterWorktreesForIssue(all, issueNumber), nil
+}
+
+// ListAllWorktrees はメインのチェックアウトと全てのcloneを返す（git worktree listと同様に先頭がメインのチェックアウト）
+func (c *cloneManager) ListAllWorktrees(ctx context.Context) ([]WorktreeInfo, error) {
+	workspaces := []WorktreeInfo{c.info(ctx, c.main.basePath)}
+
+	dir := filepath.Dir(c.GetWorktreePathForIssue(1))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return workspaces, nil
+		}
+		return nil, fmt.Errorf("failed to list clones: %w", err)
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.IsDir() && isClone(path) {
+			workspaces = append(workspaces, c.info(ctx, path))
+		}
+	}
+	return workspaces, nil
+}
+
+// HasUncommittedChanges はcloneに未コミットの変更があるかを確認する
+func (c *cloneManager) HasUncommittedChanges(ctx context.Context, worktreePath string) (bool, error) {
+	return c.main.HasUncommittedChanges(ctx, worktreePath)
+}
+
+// PreflightWorktreeForIssue はフェーズ開始前にIssueのcloneの健全性を確認し、単純な問題を自動修復する
+// ブランチはclone自身にあるため、ブランチの存在はclone内で確認する
+func (c *cloneManager) PreflightWorktreeForIssue(ctx context.Context, issueNumber int) (*WorktreeHealth, error) {
+	path := c.GetWorktreePathForIssue(issueNumber)
+	return preflightWorkspace(ctx, c.main.worktree, c.main.branch, path, path, c.main.generateBranchNameForIssue(issueNumber))
+}
+
+// PrepareWorktreesForIssues は未作成のcloneを最大maxParallel件ずつ並行して作成する
+// cloneはメインのチェックアウトの管理情報を更新しないため、作成をすべて並行して行う
+func (c *cloneManager) PrepareWorktreesForIssues(ctx context.Context, issueNumbers []int, maxParallel int) map[int]error {
+	failed := make(map[int]error)
+
+	var targets []int
+	seen := make(map[int]bool, len(issueNumbers))
+	for _, n := range issueNumbers {
+		if n <= 0 {
+			failed[n] = fmt.Errorf("invalid issue number: %d", n)
+			continue
+		}
+		if seen[n] || isClone(c.GetWorktreePathForIssue(n)) {
+			continue
+		}
+		seen[n] = true
+		targets = append(targets, n)
+	}
+	if len(targets) == 0 {
+		return failed
+	}
+
+	// mainブランチの取得はIssueごとではなく1回だけ行う
+	if err := c.UpdateMainBranch(ctx); err != nil {
+		for _, n := range targets {
+			failed[n] = fmt.Errorf("failed to update main branch: %w", err)
+		}
+		return failed
+	}
+
+	if maxParallel < 1 {
+		maxParallel = 1
+	}
+	var (
+		mu  sync.Mutex // failedの保護用
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, maxParallel)
+	)
+	for _, n := range targets {
+		wg.Add(1)
+		go func(issueNumber int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := c.clone(ctx, c.GetWorktreePathForIssue(issueNumber), c.main.generateBranchNameForIssue(issueNumber)); err != nil {
+				mu.Lock()
+				failed[issueNumber] = err
+				mu.Unlock()
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	return failed
+}
+
+// create は既存のcloneを削除してから、mainブランチを最新化してcloneを作り直す
+func (c *cloneManager) create(ctx context.Context, path, branchName string) error {
+	if isClone(path) {
+		if err := c.remove(ctx, path, branchName); err != nil {
+			return fmt.Errorf("failed to remove existing clone: %w", err)
+		}
+	}
+	if err := c.UpdateMainBranch(ctx); err != nil {
+		return fmt.Errorf("failed to update main branch: %w", err)
+	}
+	return c.clone(ctx, path, branchName)
+}
+
+// clone はメインのチェックアウトからcloneを作成し、Issueのブランチに切り替える
+// メインのチェックアウトにIssueのブランチが残っている場合はそこから、ない場合はmainから作成する
+// pushやPRの作成がGitHubに向くよう、originはメインのチェックアウトのoriginに合わせる
+func (c *cloneManager) clone(ctx context.Context, path, branchName string) error {
+	cmd := c.main.worktree.command
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create clone directory: %w", err)
+	}
+	// ネットワークマウントではハードリンクを作成できないことがあるため、オブジェクトはコピーする
+	if _, err := cmd.Run(ctx, "git", []string{"clone", "--no-hardlinks", "--quiet", c.main.basePath, path}, c.main.basePath); err != nil {
+		os.RemoveAll(path)
+		return fmt.Errorf("failed to create clone: %w", err)
+	}
+
+	startPoint := "origin/main"
+	if c.main.branch.Exists(ctx, c.main.basePath, branchName) {
+		startPoint = "origin/" + branchName
+	}
+	if _, err := cmd.Run(ctx, "git", []string{"checkout", "--quiet", "--no-track", "-b", branchName, startPoint}, path); err != nil {
+		os.RemoveAll(path)
+		return fmt.Errorf("failed to checkout branch in clone: %w", err)
+	}
+	if err := shareExclude(c.main.basePath, path); err != nil {
+		os.RemoveAll(path)
+		return err
+	}
+
+	remotes, err := c.main.sync.GetRemotes(ctx, c.main.basePath)
+	if err != nil {
+		return fmt.Errorf("failed to get remotes: %w", err)
+	}
+	for _, remote := range remotes {
+		if remote.Name != "origin" || remote.URL == "" {
+			continue
+		}
+		if _, err := cmd.Run(ctx, "git", []string{"remote", "set-url", "origin", remote.URL}, path); err != nil {
+			return fmt.Errorf("failed to set origin of clone: %w", err)
+		}
+	}
+	return nil
+}
+
+// shareExclude はcloneの.git/info/excludeをメインのチェックアウトの.git/info/excludeへのシンボリックリンクにする
+// worktreeと同じく、osobaが除外するファイル（ハートビートなど）をcloneでもコミットや未コミットの変更の対象から外す
+func shareExclude(basePath, clonePath string) error {
+	mainExclude := filepath.Join(basePath, ".git", "info", "exclude")
+	if err := os.MkdirAll(filepath.Dir(mainExclude), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(mainExclude), err)
+	}
+	// 後から追加する除外のパターンもcloneに反映されるよう、ファイルがない場合は作成しておく
+	file, err := os.OpenFile(mainExclude, os.O_CREATE|os.O_RDONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", mainExclude, err)
+	}
+	file.Close()
+
+	cloneExclude := filepath.Join(clonePath, ".git", "info", "exclude")
+	if err := os.MkdirAll(filepath.Dir(cloneExclude), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(cloneExclude), err)
+	}
+	if err := os.Remove(cloneExclude); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to replace %s: %w", cloneExclude, err)
+	}
+	if err := os.Symlink(mainExclude, cloneExclude); err != nil {
+		return fmt.Errorf("failed to share %s with clone: %w", mainExclude, err)
+	}
+	return nil
+}
+
+// remove はcloneを削除する
+// ブランチを残す設定の場合は、削除する前にcloneのブランチをメインのチェックアウトに取り込む
+func (c *cloneManager) remove(ctx context.Context, path, branchName string) error {
+	if !isClone(path) {
+		return nil
+	}
+	if err := c.main.preserveWork(ctx, path); err != nil {
+		return err
+	}
+	if c.main.keepBranches {
+		refspec := fmt.Sprintf("+%s:%s", branchName, branchName)
+		if _, err := c.main.worktree.command.Run(ctx, "git", []string{"fetch", "--quiet", path, refspec}, c.main.basePath); err != nil {
+			// ブランチを取り込めない場合はcloneを残し、作業内容を失わないようにする
+			return fmt.Errorf("failed to keep branch %s from clone: %w", branchName, err)
+		}
+	}
+	return RemoveClone(path)
+}
+
+// info はcloneのブランチとコミットを返す（取得できない項目は空のまま返す）
+func (c *cloneManager) info(ctx context.Context, path string) WorktreeInfo {
+	info := WorktreeInfo{Path: path}
+	info.Branch, _ = c.main.branch.GetCurrent(ctx, path)
+	if output, err := c.main.worktree.command.Run(ctx, "git", []string{"rev-parse", "HEAD"}, path); err == nil {
+		info.Commit = strings.TrimSpace(output)
+	}
+	return info
+}
+
+// RemoveClone はIssueのcloneのディレクトリを削除する（cloneでないディレクトリは削除しない）
+func RemoveClone(path string) error {
+	if !isClone(path) {
+		return fmt.Errorf("%s is not a clone", path)
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("failed to remove clone: %w", err)
+	}
+	return nil
+}
+
+// isClone はパスがcloneのディレクトリ（.gitディレクトリを持つ）かを返す
+// worktreeの.gitはファイルのため、worktreeとは区別される
+func isClone(path string) bool {
+	info, err := os.Stat(filepath.Join(path, ".git"))
+	return err == nil && info.IsDir()
+}
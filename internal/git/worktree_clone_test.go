@@ -0,0 +1,121 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/testutil/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// setupCloneTestRepository はoriginを持つリポジトリを作成し、そのルートパスとoriginのパスを返す
+func setupCloneTestRepository(t *testing.T, cmd *Command) (string, string) {
+	t.Helper()
+	originPath := filepath.Join(t.TempDir(), "origin.git")
+	basePath := t.TempDir()
+
+	runGit(t, cmd, basePath, "init", "--bare", originPath)
+	runGit(t, cmd, basePath, "init")
+	runGit(t, cmd, basePath, "config", "user.email", "test@example.com")
+	runGit(t, cmd, basePath, "config", "user.name", "Test User")
+	require.NoError(t, os.WriteFile(filepath.Join(basePath, "test.txt"), []byte("initial content"), 0644))
+	runGit(t, cmd, basePath, "add", ".")
+	runGit(t, cmd, basePath, "commit", "-m", "initial commit")
+	runGit(t, cmd, basePath, "branch", "-M", "main")
+	runGit(t, cmd, basePath, "remote", "add", "origin", originPath)
+	runGit(t, cmd, basePath, "push", "-u", "origin", "main")
+	return basePath, originPath
+}
+
+func TestCloneManager_CreateWorktreeForIssue(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	cmd := NewCommand(logger)
+	basePath, originPath := setupCloneTestRepository(t, cmd)
+
+	branch := NewBranch(logger)
+	manager, err := NewCloneManager(&mockRepository{rootPath: basePath}, NewWorktree(logger), branch, NewSync(logger))
+	require.NoError(t, err)
+
+	require.NoError(t, manager.CreateWorktreeForIssue(ctx, 5))
+
+	path := manager.GetWorktreePathForIssue(5)
+	exists, err := manager.WorktreeExistsForIssue(ctx, 5)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	current, err := branch.GetCurrent(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, "osoba/#5", current)
+	assert.False(t, branch.Exists(ctx, basePath, "osoba/#5"), "ブランチはclone内にのみ作成する")
+
+	url, err := cmd.Run(ctx, "git", []string{"remote", "get-url", "origin"}, path)
+	require.NoError(t, err)
+	assert.Equal(t, originPath, strings.TrimSpace(url), "originはメインのチェックアウトのoriginに合わせる")
+
+	// メインのチェックアウトの.git/info/excludeを共有する
+	require.NoError(t, EnsureHeartbeatExcluded(basePath))
+	require.NoError(t, os.MkdirAll(filepath.Dir(HeartbeatPath(path)), 0755))
+	require.NoError(t, os.WriteFile(HeartbeatPath(path), nil, 0644))
+	dirty, err := manager.HasUncommittedChanges(ctx, path)
+	require.NoError(t, err)
+	assert.False(t, dirty, "ハートビートは未コミットの変更とみなさない")
+	require.NoError(t, os.Remove(HeartbeatPath(path)))
+
+	worktrees, err := manager.ListWorktreesForIssue(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, worktrees, 1)
+	assert.Equal(t, path, worktrees[0].Path)
+	assert.Equal(t, "osoba/#5", worktrees[0].Branch)
+
+	all, err := manager.ListAllWorktrees(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, basePath, all[0].Path)
+
+	health, err := manager.PreflightWorktreeForIssue(ctx, 5)
+	require.NoError(t, err)
+	assert.True(t, health.Healthy(), health.Diagnostics())
+
+	require.NoError(t, manager.RemoveWorktreeForIssue(ctx, 5))
+	assert.NoDirExists(t, path)
+}
+
+func TestCloneManager_KeepBranches(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	cmd := NewCommand(logger)
+	basePath, _ := setupCloneTestRepository(t, cmd)
+
+	branch := NewBranch(logger)
+	manager, err := NewCloneManager(&mockRepository{rootPath: basePath}, NewWorktree(logger), branch, NewSync(logger), WithKeepBranches(true))
+	require.NoError(t, err)
+
+	require.NoError(t, manager.CreateWorktreeForIssue(ctx, 7))
+	path := manager.GetWorktreePathForIssue(7)
+	runGit(t, cmd, path, "config", "user.email", "test@example.com")
+	runGit(t, cmd, path, "config", "user.name", "Test User")
+	require.NoError(t, os.WriteFile(filepath.Join(path, "feature.txt"), []byte("feature"), 0644))
+	runGit(t, cmd, path, "add", ".")
+	runGit(t, cmd, path, "commit", "-m", "add feature")
+
+	require.NoError(t, manager.RemoveWorktreeForIssue(ctx, 7))
+	assert.True(t, branch.Exists(ctx, basePath, "osoba/#7"), "削除前にブランチをメインのチェックアウトに取り込む")
+
+	// 作り直したcloneは残したブランチから再開する
+	require.NoError(t, manager.CreateWorktreeForIssue(ctx, 7))
+	assert.FileExists(t, filepath.Join(path, "feature.txt"))
+}
+
+func TestRemoveClone_RejectsNonClone(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git"), []byte("gitdir: /elsewhere\n"), 0644))
+
+	assert.Error(t, RemoveClone(dir), "worktreeは削除しない")
+	assert.DirExists(t, dir)
+}
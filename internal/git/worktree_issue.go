@@ -9,7 +9,7 @@ import (
 // GetWorktreePathForIssue は指定されたIssueのworktreeパスを返す（フェーズを含まない）
 func (m *worktreeManager) GetWorktreePathForIssue(issueNumber int) string {
 	// .git/osoba/worktrees/issue-{issue番号}
-	return filepath.Join(m.basePath, ".git", "osoba", "worktrees", fmt.Sprintf("issue-%d", issueNumber))
+	return filepath.Join(m.worktreesRoot(), fmt.Sprintf("issue-%d", issueNumber))
 }
 
 // WorktreeExistsForIssue は指定されたIssueのworktreeが存在するかを確認する
@@ -37,6 +37,18 @@ func (m *worktreeManager) generateBranchNameForIssue(issueNumber int) string {
 	return fmt.Sprintf("osoba/#%d", issueNumber)
 }
 
+// BranchesRemovedForIssue はRemoveWorktreeForIssueで削除されるブランチを返す（削除は行わない）
+func (m *worktreeManager) BranchesRemovedForIssue(ctx context.Context, issueNumber int) []string {
+	if m.keepBranches {
+		return nil
+	}
+	branchName := m.generateBranchNameForIssue(issueNumber)
+	if !m.branch.Exists(ctx, m.basePath, branchName) {
+		return nil
+	}
+	return []string{branchName}
+}
+
 // CreateWorktreeForIssue は指定されたIssueのworktreeを作成する
 func (m *worktreeManager) CreateWorktreeForIssue(ctx context.Context, issueNumber int) error {
 	if issueNumber <= 0 {
@@ -87,6 +99,9 @@ func (m *worktreeManager) RemoveWorktreeForIssue(ctx context.Context, issueNumbe
 	}
 
 	worktreePath := m.GetWorktreePathForIssue(issueNumber)
+	if err := m.preserveWork(ctx, worktreePath); err != nil {
+		return err
+	}
 
 	// worktreeを削除
 	if err := m.worktree.Remove(ctx, m.basePath, worktreePath); err != nil {
@@ -94,6 +109,9 @@ func (m *worktreeManager) RemoveWorktreeForIssue(ctx context.Context, issueNumbe
 	}
 
 	// ブランチも削除
+	if m.keepBranches {
+		return nil
+	}
 	branchName := m.generateBranchNameForIssue(issueNumber)
 	if err := m.branch.Delete(ctx, m.basePath, branchName, true); err != nil {
 		// ブランチ削除のエラーは無視（既に削除されている可能性がある）
@@ -20,6 +20,8 @@ const (
 )
 
 // WorktreeManager はIssueベースのworktree管理を行うインターフェース
+// Issueごとの作業ディレクトリの用意を抽象化しており、git worktreeを使う実装（NewWorktreeManager）と
+// Issueごとのcloneを使う実装（NewCloneManager）がある
 type WorktreeManager interface {
 	// UpdateMainBranch はmainブランチを最新化する
 	UpdateMainBranch(ctx context.Context) error
@@ -56,8 +58,22 @@ type WorktreeManager interface {
 
 	// HasUncommittedChanges はworktreeに未コミットの変更があるかを確認する
 	HasUncommittedChanges(ctx context.Context, worktreePath string) (bool, error)
+
+	// PreflightWorktreeForIssue はフェーズ開始前にIssueのworktreeの健全性を確認し、単純な問題を自動修復する
+	PreflightWorktreeForIssue(ctx context.Context, issueNumber int) (*WorktreeHealth, error)
+}
+
+// BranchPlanner はIssueのworktreeとともに削除されるブランチを確認できるWorktreeManager
+type BranchPlanner interface {
+	// BranchesRemovedForIssue はRemoveWorktreeForIssueで削除されるブランチを返す（削除は行わない）
+	BranchesRemovedForIssue(ctx context.Context, issueNumber int) []string
 }
 
+var (
+	_ BranchPlanner = (*worktreeManager)(nil)
+	_ BranchPlanner = (*cloneManager)(nil)
+)
+
 // worktreeManager はWorktreeManagerの実装
 type worktreeManager struct {
 	repository Repository
@@ -65,23 +81,44 @@ type worktreeManager struct {
 	branch     *Branch
 	sync       *Sync
 	basePath   string
+	// worktreesDir はIssueの作業ディレクトリを作成するディレクトリ（空の場合は.git/osoba/worktrees）
+	worktreesDir string
+	// keepBranches がtrueの場合、worktree削除時にブランチを削除しない
+	keepBranches bool
+	// scratch がtrueの場合、作業ディレクトリはscratch volume上にあり、削除前に作業内容を退避する
+	scratch bool
+}
+
+// WorktreeManagerOption はWorktreeManagerのオプション
+type WorktreeManagerOption func(*worktreeManager)
+
+// WithKeepBranches はworktree削除時にブランチを残すかを設定する
+func WithKeepBranches(keep bool) WorktreeManagerOption {
+	return func(m *worktreeManager) {
+		m.keepBranches = keep
+	}
 }
 
 // NewWorktreeManager は新しいWorktreeManagerインスタンスを作成する
-func NewWorktreeManager(repository Repository, worktree *Worktree, branch *Branch, sync *Sync) (WorktreeManager, error) {
+func NewWorktreeManager(repository Repository, worktree *Worktree, branch *Branch, sync *Sync, opts ...WorktreeManagerOption) (WorktreeManager, error) {
 	// リポジトリのルートパスを取得
 	basePath, err := repository.GetRootPath(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get repository root path: %w", err)
 	}
 
-	return &worktreeManager{
+	m := &worktreeManager{
 		repository: repository,
 		worktree:   worktree,
 		branch:     branch,
 		sync:       sync,
 		basePath:   basePath,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m, nil
 }
 
 // UpdateMainBranch はmainブランチを最新化する
@@ -154,19 +191,27 @@ func (m *worktreeManager) CreateWorktree(ctx context.Context, issueNumber int, p
 		return fmt.Errorf("failed to list branches: %w", err)
 	}
 
+	branchExists := false
 	for _, b := range branches {
 		if b.Name == branchName {
-			// ローカルブランチを削除
-			if err := m.branch.Delete(ctx, m.basePath, branchName, true); err != nil {
-				return fmt.Errorf("failed to delete existing branch: %w", err)
-			}
+			branchExists = true
 			break
 		}
 	}
 
-	// ブランチを作成（既存の場合は削除したので再作成）
-	if err := m.branch.Create(ctx, m.basePath, branchName, "main"); err != nil {
-		return fmt.Errorf("failed to create branch: %w", err)
+	if branchExists && !m.keepBranches {
+		// ローカルブランチを削除
+		if err := m.branch.Delete(ctx, m.basePath, branchName, true); err != nil {
+			return fmt.Errorf("failed to delete existing branch: %w", err)
+		}
+		branchExists = false
+	}
+
+	// ブランチを作成（ブランチを残す設定の場合は既存ブランチを再利用）
+	if !branchExists {
+		if err := m.branch.Create(ctx, m.basePath, branchName, "main"); err != nil {
+			return fmt.Errorf("failed to create branch: %w", err)
+		}
 	}
 
 	// 新しいworktreeを作成
@@ -180,6 +225,9 @@ func (m *worktreeManager) CreateWorktree(ctx context.Context, issueNumber int, p
 // RemoveWorktree は指定されたIssueとフェーズのworktreeを削除する
 func (m *worktreeManager) RemoveWorktree(ctx context.Context, issueNumber int, phase Phase) error {
 	worktreePath := m.GetWorktreePath(issueNumber, phase)
+	if err := m.preserveWork(ctx, worktreePath); err != nil {
+		return err
+	}
 
 	// worktreeを削除
 	if err := m.worktree.Remove(ctx, m.basePath, worktreePath); err != nil {
@@ -187,6 +235,9 @@ func (m *worktreeManager) RemoveWorktree(ctx context.Context, issueNumber int, p
 	}
 
 	// ブランチも削除
+	if m.keepBranches {
+		return nil
+	}
 	branchName := m.generateBranchName(issueNumber, phase)
 	if err := m.branch.Delete(ctx, m.basePath, branchName, true); err != nil {
 		// ブランチ削除のエラーは無視（既に削除されている可能性がある）
@@ -199,7 +250,15 @@ func (m *worktreeManager) RemoveWorktree(ctx context.Context, issueNumber int, p
 // GetWorktreePath は指定されたIssueとフェーズのworktreeパスを返す
 func (m *worktreeManager) GetWorktreePath(issueNumber int, phase Phase) string {
 	// .git/osoba/worktrees/{issue番号}-{フェーズ}
-	return filepath.Join(m.basePath, ".git", "osoba", "worktrees", fmt.Sprintf("%d-%s", issueNumber, phase))
+	return filepath.Join(m.worktreesRoot(), fmt.Sprintf("%d-%s", issueNumber, phase))
+}
+
+// worktreesRoot はIssueの作業ディレクトリを作成するディレクトリを返す
+func (m *worktreeManager) worktreesRoot() string {
+	if m.worktreesDir != "" {
+		return m.worktreesDir
+	}
+	return filepath.Join(m.basePath, ".git", "osoba", "worktrees")
 }
 
 // WorktreeExists は指定されたworktreeが存在するかを確認する
@@ -236,6 +295,11 @@ func (m *worktreeManager) ListWorktreesForIssue(ctx context.Context, issueNumber
 		return nil, fmt.Errorf("failed to list worktrees: %w", err)
 	}
 
+	return filterWorktreesForIssue(allWorktrees, issueNumber), nil
+}
+
+// filterWorktreesForIssue はパスからIssueに関連するworktreeを抽出する
+func filterWorktreesForIssue(allWorktrees []WorktreeInfo, issueNumber int) []WorktreeInfo {
 	var issueWorktrees []WorktreeInfo
 	issueStr := fmt.Sprintf("%d", issueNumber)
 
@@ -247,17 +311,17 @@ func (m *worktreeManager) ListWorktreesForIssue(ctx context.Context, issueNumber
 				issueWorktrees = append(issueWorktrees, wt)
 			}
 		}
-		// 新しい形式のworktreeパスをチェック (.git/osoba/worktrees/issue-{issue番号})
-		if strings.Contains(wt.Path, fmt.Sprintf(".git/osoba/worktrees/issue-%s", issueStr)) {
+		// 新しい形式のworktreeパスをチェック (.git/osoba/worktrees/issue-{issue番号}、scratch volume上の場合は.gitを含まない)
+		if strings.Contains(wt.Path, fmt.Sprintf("osoba/worktrees/issue-%s", issueStr)) {
 			issueWorktrees = append(issueWorktrees, wt)
 		}
 		// 新しい形式でのフェーズ付きワークツリーもチェック (.git/osoba/worktrees/{issue番号}-{フェーズ})
-		if strings.Contains(wt.Path, fmt.Sprintf(".git/osoba/worktrees/%s-", issueStr)) {
+		if strings.Contains(wt.Path, fmt.Sprintf("osoba/worktrees/%s-", issueStr)) {
 			issueWorktrees = append(issueWorktrees, wt)
 		}
 	}
 
-	return issueWorktrees, nil
+	return issueWorktrees
 }
 
 // ListAllWorktrees は全てのworktreeを検索する
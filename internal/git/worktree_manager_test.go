@@ -361,3 +361,85 @@ func (m *mockRepository) GetLogger() logger.Logger {
 	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
 	return logger
 }
+
+func TestWorktreeManager_RemoveWorktree_KeepBranches(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	cmd := NewCommand(logger)
+	ctx := context.Background()
+
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+	} {
+		_, err := cmd.Run(ctx, "git", args, tmpDir)
+		require.NoError(t, err)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("initial content"), 0644))
+	for _, args := range [][]string{
+		{"add", "."},
+		{"commit", "-m", "initial commit"},
+		{"branch", "-M", "main"},
+	} {
+		_, err := cmd.Run(ctx, "git", args, tmpDir)
+		require.NoError(t, err)
+	}
+
+	branch := NewBranch(logger)
+	manager, err := NewWorktreeManager(&mockRepository{rootPath: tmpDir}, NewWorktree(logger), branch, NewSync(logger), WithKeepBranches(true))
+	require.NoError(t, err)
+
+	issueNumber := 46
+	phase := PhasePlan
+	require.NoError(t, manager.CreateWorktree(ctx, issueNumber, phase))
+	require.NoError(t, manager.RemoveWorktree(ctx, issueNumber, phase))
+
+	// worktreeは削除されるがブランチは残る
+	exists, err := manager.WorktreeExists(ctx, issueNumber, phase)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.True(t, branch.Exists(ctx, tmpDir, "osoba/#46-plan"))
+
+	// 既存ブランチを再利用してworktreeを再作成できる
+	require.NoError(t, manager.CreateWorktree(ctx, issueNumber, phase))
+}
+
+func TestWorktreeManager_BranchesRemovedForIssue(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	cmd := NewCommand(logger)
+	ctx := context.Background()
+
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+		{"commit", "--allow-empty", "-m", "initial commit"},
+		{"branch", "-M", "main"},
+		{"branch", "osoba/#47"},
+	} {
+		_, err := cmd.Run(ctx, "git", args, tmpDir)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name        string
+		issueNumber int
+		keep        bool
+		want        []string
+	}{
+		{name: "ブランチを削除する", issueNumber: 47, want: []string{"osoba/#47"}},
+		{name: "ブランチを残す設定", issueNumber: 47, keep: true, want: nil},
+		{name: "ブランチが存在しない", issueNumber: 48, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, err := NewWorktreeManager(&mockRepository{rootPath: tmpDir}, NewWorktree(logger), NewBranch(logger), NewSync(logger), WithKeepBranches(tt.keep))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, manager.(BranchPlanner).BranchesRemovedForIssue(ctx, tt.issueNumber))
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// staleLockAge はロックファイルを放置されたものとみなすまでの経過時間
+const staleLockAge = 5 * time.Minute
+
+// worktreeLockFiles は事前チェックで確認するgitのロックファイル
+var worktreeLockFiles = []string{"index.lock", "HEAD.lock"}
+
+// WorktreeProblemKind はworktreeの事前チェックで検出された問題の種類
+type WorktreeProblemKind string
+
+const (
+	// ProblemLockFile はgitのロックファイルが残っている
+	ProblemLockFile WorktreeProblemKind = "lock_file"
+	// ProblemUnresolvedMerge はマージ・リベースが未解決のまま残っている
+	ProblemUnresolvedMerge WorktreeProblemKind = "unresolved_merge"
+	// ProblemBranchMissing はIssueのブランチが存在しない
+	ProblemBranchMissing WorktreeProblemKind = "branch_missing"
+	// ProblemHeadMismatch はHEADが期待するブランチを指していない
+	ProblemHeadMismatch WorktreeProblemKind = "head_mismatch"
+)
+
+// WorktreeProblem はworktreeの事前チェックで検出された問題
+type WorktreeProblem struct {
+	Kind     WorktreeProblemKind
+	Detail   string
+	Repaired bool // 自動修復済みかどうか
+}
+
+// WorktreeHealth はworktreeの事前チェック結果
+type WorktreeHealth struct {
+	Path           string
+	ExpectedBranch string
+	Problems       []WorktreeProblem
+}
+
+// Healthy は未修復の問題がない場合にtrueを返す
+func (h *WorktreeHealth) Healthy() bool {
+	return len(h.Unresolved()) == 0
+}
+
+// Unresolved は自動修復できなかった問題を返す
+func (h *WorktreeHealth) Unresolved() []WorktreeProblem {
+	var problems []WorktreeProblem
+	for _, p := range h.Problems {
+		if !p.Repaired {
+			problems = append(problems, p)
+		}
+	}
+	return problems
+}
+
+// Diagnostics は未修復の問題を人が読める形式で返す
+func (h *WorktreeHealth) Diagnostics() string {
+	var lines []string
+	for _, p := range h.Unresolved() {
+		lines = append(lines, fmt.Sprintf("%s: %s", p.Kind, p.Detail))
+	}
+	return strings.Join(lines, "; ")
+}
+
+func (h *WorktreeHealth) add(kind WorktreeProblemKind, detail string, repaired bool) {
+	h.Problems = append(h.Problems, WorktreeProblem{Kind: kind, Detail: detail, Repaired: repaired})
+}
+
+// PreflightWorktreeForIssue はフェーズ開始前にIssueのworktreeの健全性を確認する
+// 放置されたロックファイルの削除やブランチの切り替えなど単純な問題は自動修復する
+func (m *worktreeManager) PreflightWorktreeForIssue(ctx context.Context, issueNumber int) (*WorktreeHealth, error) {
+	return preflightWorkspace(ctx, m.worktree, m.branch, m.basePath, m.GetWorktreePathForIssue(issueNumber), m.generateBranchNameForIssue(issueNumber))
+}
+
+// preflightWorkspace はIssueの作業ディレクトリの健全性を確認する
+// branchRepoPathはブランチの存在を確認するリポジトリ（worktreeの場合はメインのチェックアウト、cloneの場合はclone自身）
+func preflightWorkspace(ctx context.Context, worktree *Worktree, branch *Branch, branchRepoPath, worktreePath, expectedBranch string) (*WorktreeHealth, error) {
+	health := &WorktreeHealth{
+		Path:           worktreePath,
+		ExpectedBranch: expectedBranch,
+	}
+
+	output, err := worktree.command.Run(ctx, "git", []string{"rev-parse", "--absolute-git-dir"}, worktreePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve git dir for worktree: %w", err)
+	}
+	gitDir := strings.TrimSpace(output)
+
+	checkLockFiles(health, gitDir)
+
+	unmerged, err := hasUnresolvedMerge(ctx, worktree, worktreePath, gitDir)
+	if err != nil {
+		return nil, err
+	}
+	if unmerged != "" {
+		// 未解決のマージがある状態でブランチ操作はしない
+		health.add(ProblemUnresolvedMerge, unmerged, false)
+		return health, nil
+	}
+
+	if !branch.Exists(ctx, branchRepoPath, health.ExpectedBranch) {
+		// 作業内容を失わないよう、worktreeのHEADからブランチを作り直す
+		if _, err := worktree.command.Run(ctx, "git", []string{"checkout", "-b", health.ExpectedBranch}, worktreePath); err != nil {
+			health.add(ProblemBranchMissing, fmt.Sprintf("branch %s does not exist and could not be recreated: %v", health.ExpectedBranch, err), false)
+		} else {
+			health.add(ProblemBranchMissing, fmt.Sprintf("recreated branch %s from worktree HEAD", health.ExpectedBranch), true)
+		}
+		return health, nil
+	}
+
+	current, err := branch.GetCurrent(ctx, worktreePath)
+	if err != nil {
+		return nil, err
+	}
+	if current == health.ExpectedBranch {
+		return health, nil
+	}
+
+	head := current
+	if head == "" {
+		head = "detached HEAD"
+	}
+	dirty, err := worktree.HasUncommittedChanges(ctx, worktreePath)
+	if err != nil {
+		return nil, err
+	}
+	if dirty {
+		health.add(ProblemHeadMismatch, fmt.Sprintf("HEAD is on %s instead of %s and the worktree has uncommitted changes", head, health.ExpectedBranch), false)
+		return health, nil
+	}
+	if err := branch.Checkout(ctx, worktreePath, health.ExpectedBranch, false); err != nil {
+		health.add(ProblemHeadMismatch, fmt.Sprintf("HEAD is on %s instead of %s and checkout failed: %v", head, health.ExpectedBranch, err), false)
+		return health, nil
+	}
+	health.add(ProblemHeadMismatch, fmt.Sprintf("switched HEAD from %s to %s", head, health.ExpectedBranch), true)
+
+	return health, nil
+}
+
+// checkLockFiles はgitのロックファイルを確認し、放置されたものを削除する
+// 作成から間もないロックファイルは実行中のgit操作のものとみなして残す
+func checkLockFiles(health *WorktreeHealth, gitDir string) {
+	for _, name := range worktreeLockFiles {
+		lockPath := filepath.Join(gitDir, name)
+		info, err := os.Stat(lockPath)
+		if err != nil {
+			continue
+		}
+
+		age := time.Since(info.ModTime())
+		if age < staleLockAge {
+			health.add(ProblemLockFile, fmt.Sprintf("%s is held (created %s ago, another git process may be running)", lockPath, age.Truncate(time.Second)), false)
+			continue
+		}
+		if err := os.Remove(lockPath); err != nil {
+			health.add(ProblemLockFile, fmt.Sprintf("failed to remove stale %s: %v", lockPath, err), false)
+			continue
+		}
+		health.add(ProblemLockFile, fmt.Sprintf("removed stale %s", lockPath), true)
+	}
+}
+
+// hasUnresolvedMerge は進行中のマージ・リベースや未解決のコンフリクトを検出し、その説明を返す
+func hasUnresolvedMerge(ctx context.Context, worktree *Worktree, worktreePath, gitDir string) (string, error) {
+	for _, marker := range []string{"MERGE_HEAD", "rebase-merge", "rebase-apply", "CHERRY_PICK_HEAD"} {
+		if _, err := os.Stat(filepath.Join(gitDir, marker)); err == nil {
+			return fmt.Sprintf("%s is present in %s", marker, gitDir), nil
+		}
+	}
+
+	output, err := worktree.command.Run(ctx, "git", []string{"diff", "--name-only", "--diff-filter=U"}, worktreePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to check unmerged paths: %w", err)
+	}
+	if files := strings.Fields(output); len(files) > 0 {
+		return fmt.Sprintf("unmerged paths: %s", strings.Join(files, ", ")), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,139 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/testutil/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWorktreeManager_PreflightWorktreeForIssue(t *testing.T) {
+	const issueNumber = 5
+	const branchName = "osoba/#5"
+
+	tests := []struct {
+		name        string
+		setup       func(t *testing.T, cmd *Command, basePath, worktreePath, gitDir string)
+		wantHealthy bool
+		wantKinds   []WorktreeProblemKind
+		wantBranch  string
+	}{
+		{
+			name:        "問題なし",
+			setup:       func(t *testing.T, cmd *Command, basePath, worktreePath, gitDir string) {},
+			wantHealthy: true,
+			wantBranch:  branchName,
+		},
+		{
+			name: "放置されたロックファイル - 削除して続行",
+			setup: func(t *testing.T, cmd *Command, basePath, worktreePath, gitDir string) {
+				lock := filepath.Join(gitDir, "index.lock")
+				require.NoError(t, os.WriteFile(lock, nil, 0644))
+				old := time.Now().Add(-time.Hour)
+				require.NoError(t, os.Chtimes(lock, old, old))
+			},
+			wantHealthy: true,
+			wantKinds:   []WorktreeProblemKind{ProblemLockFile},
+			wantBranch:  branchName,
+		},
+		{
+			name: "作成直後のロックファイル - 停止",
+			setup: func(t *testing.T, cmd *Command, basePath, worktreePath, gitDir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(gitDir, "index.lock"), nil, 0644))
+			},
+			wantKinds:  []WorktreeProblemKind{ProblemLockFile},
+			wantBranch: branchName,
+		},
+		{
+			name: "未解決のマージ - 停止",
+			setup: func(t *testing.T, cmd *Command, basePath, worktreePath, gitDir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(gitDir, "MERGE_HEAD"), []byte("deadbeef\n"), 0644))
+			},
+			wantKinds:  []WorktreeProblemKind{ProblemUnresolvedMerge},
+			wantBranch: branchName,
+		},
+		{
+			name: "HEADが別ブランチ - 切り替えて続行",
+			setup: func(t *testing.T, cmd *Command, basePath, worktreePath, gitDir string) {
+				runGit(t, cmd, worktreePath, "checkout", "-b", "other")
+			},
+			wantHealthy: true,
+			wantKinds:   []WorktreeProblemKind{ProblemHeadMismatch},
+			wantBranch:  branchName,
+		},
+		{
+			name: "HEADが別ブランチで未コミット変更あり - 停止",
+			setup: func(t *testing.T, cmd *Command, basePath, worktreePath, gitDir string) {
+				runGit(t, cmd, worktreePath, "checkout", "-b", "other")
+				require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "test.txt"), []byte("edited"), 0644))
+			},
+			wantKinds:  []WorktreeProblemKind{ProblemHeadMismatch},
+			wantBranch: "other",
+		},
+		{
+			name: "ブランチが削除済み - HEADから再作成",
+			setup: func(t *testing.T, cmd *Command, basePath, worktreePath, gitDir string) {
+				runGit(t, cmd, worktreePath, "checkout", "--detach")
+				runGit(t, cmd, basePath, "branch", "-D", branchName)
+			},
+			wantHealthy: true,
+			wantKinds:   []WorktreeProblemKind{ProblemBranchMissing},
+			wantBranch:  branchName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			basePath := t.TempDir()
+			logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+			cmd := NewCommand(logger)
+
+			runGit(t, cmd, basePath, "init")
+			runGit(t, cmd, basePath, "config", "user.email", "test@example.com")
+			runGit(t, cmd, basePath, "config", "user.name", "Test User")
+			require.NoError(t, os.WriteFile(filepath.Join(basePath, "test.txt"), []byte("initial content"), 0644))
+			runGit(t, cmd, basePath, "add", ".")
+			runGit(t, cmd, basePath, "commit", "-m", "initial commit")
+			runGit(t, cmd, basePath, "branch", "-M", "main")
+
+			branch := NewBranch(logger)
+			manager, err := NewWorktreeManager(&mockRepository{rootPath: basePath}, NewWorktree(logger), branch, NewSync(logger))
+			require.NoError(t, err)
+
+			worktreePath := manager.GetWorktreePathForIssue(issueNumber)
+			runGit(t, cmd, basePath, "worktree", "add", "-b", branchName, worktreePath, "main")
+			gitDir, err := cmd.Run(ctx, "git", []string{"rev-parse", "--absolute-git-dir"}, worktreePath)
+			require.NoError(t, err)
+
+			tt.setup(t, cmd, basePath, worktreePath, strings.TrimSpace(gitDir))
+
+			health, err := manager.PreflightWorktreeForIssue(ctx, issueNumber)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantHealthy, health.Healthy(), health.Diagnostics())
+			var kinds []WorktreeProblemKind
+			for _, p := range health.Problems {
+				kinds = append(kinds, p.Kind)
+			}
+			assert.Equal(t, tt.wantKinds, kinds)
+
+			current, err := branch.GetCurrent(ctx, worktreePath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBranch, current)
+		})
+	}
+}
+
+func runGit(t *testing.T, cmd *Command, dir string, args ...string) {
+	t.Helper()
+	_, err := cmd.Run(context.Background(), "git", args, dir)
+	require.NoError(t, err)
+}
@@ -0,0 +1,53 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// PullRequestWorktreeCreator はIssueのないPRのworktreeを作成できるWorktreeManager
+// PRモードで人が作成したPRをレビュー・修正するために使う
+type PullRequestWorktreeCreator interface {
+	// CreateWorktreeForPullRequest はPRのheadをチェックアウトしたworktreeを作成する
+	// PRとIssueの番号は重複しないため、worktreeとブランチ（osoba/#<番号>）はIssueと同じ形式で作成する
+	// worktreeが既にある場合は、再レビュー・再修正を最新のheadで行うためPRのheadに更新する（追跡していないファイルは残る）
+	CreateWorktreeForPullRequest(ctx context.Context, prNumber int) error
+}
+
+var _ PullRequestWorktreeCreator = (*worktreeManager)(nil)
+
+// CreateWorktreeForPullRequest はPRのheadをチェックアウトしたworktreeを作成する
+func (m *worktreeManager) CreateWorktreeForPullRequest(ctx context.Context, prNumber int) error {
+	if prNumber <= 0 {
+		return fmt.Errorf("invalid pull request number: %d", prNumber)
+	}
+
+	exists, err := m.WorktreeExistsForIssue(ctx, prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to check worktree existence: %w", err)
+	}
+
+	// フォークからのPRも取得できるように、ブランチ名ではなくrefs/pull/<番号>/headを取得する
+	ref := fmt.Sprintf("refs/pull/%d/head", prNumber)
+	worktreePath := m.GetWorktreePathForIssue(prNumber)
+	if exists {
+		// チェックアウト中のブランチにはフェッチできないため、FETCH_HEADに取得してから移動する
+		if err := m.sync.FetchRef(ctx, worktreePath, "origin", ref, ""); err != nil {
+			return fmt.Errorf("failed to fetch pull request #%d: %w", prNumber, err)
+		}
+		if err := m.sync.ResetHard(ctx, worktreePath, "FETCH_HEAD"); err != nil {
+			return fmt.Errorf("failed to update worktree to pull request #%d: %w", prNumber, err)
+		}
+		return nil
+	}
+
+	branchName := m.generateBranchNameForIssue(prNumber)
+	if err := m.sync.FetchRef(ctx, m.basePath, "origin", ref, branchName); err != nil {
+		return fmt.Errorf("failed to fetch pull request #%d: %w", prNumber, err)
+	}
+
+	if err := m.worktree.Create(ctx, m.basePath, worktreePath, branchName); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/testutil/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWorktreeManager_CreateWorktreeForPullRequest(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	cmd := NewCommand(logger)
+
+	// GitHubのようにPRのheadをrefs/pull/<番号>/headに持つリモート
+	origin := t.TempDir()
+	runGit(t, cmd, origin, "init")
+	runGit(t, cmd, origin, "config", "user.email", "test@example.com")
+	runGit(t, cmd, origin, "config", "user.name", "Test User")
+	require.NoError(t, os.WriteFile(filepath.Join(origin, "test.txt"), []byte("initial content"), 0644))
+	runGit(t, cmd, origin, "add", ".")
+	runGit(t, cmd, origin, "commit", "-m", "initial commit")
+	runGit(t, cmd, origin, "branch", "-M", "main")
+	runGit(t, cmd, origin, "checkout", "-b", "feature/login")
+	require.NoError(t, os.WriteFile(filepath.Join(origin, "login.txt"), []byte("login"), 0644))
+	runGit(t, cmd, origin, "add", ".")
+	runGit(t, cmd, origin, "commit", "-m", "add login")
+	runGit(t, cmd, origin, "update-ref", "refs/pull/7/head", "HEAD")
+	runGit(t, cmd, origin, "checkout", "main")
+
+	basePath := filepath.Join(t.TempDir(), "repo")
+	runGit(t, cmd, filepath.Dir(basePath), "clone", origin, basePath)
+
+	branch := NewBranch(logger)
+	manager, err := NewWorktreeManager(&mockRepository{rootPath: basePath}, NewWorktree(logger), branch, NewSync(logger))
+	require.NoError(t, err)
+	creator, ok := manager.(PullRequestWorktreeCreator)
+	require.True(t, ok)
+
+	require.NoError(t, creator.CreateWorktreeForPullRequest(ctx, 7))
+
+	worktreePath := manager.GetWorktreePathForIssue(7)
+	assert.FileExists(t, filepath.Join(worktreePath, "login.txt"))
+	current, err := branch.GetCurrent(ctx, worktreePath)
+	require.NoError(t, err)
+	assert.Equal(t, "osoba/#7", current)
+
+	t.Run("既存のworktreeはPRの最新のheadに更新する", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "wip.txt"), []byte("wip"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "login.txt"), []byte("stale edit"), 0644))
+
+		// 作成者がPRに追加でpushする
+		runGit(t, cmd, origin, "checkout", "feature/login")
+		require.NoError(t, os.WriteFile(filepath.Join(origin, "logout.txt"), []byte("logout"), 0644))
+		runGit(t, cmd, origin, "add", ".")
+		runGit(t, cmd, origin, "commit", "-m", "add logout")
+		runGit(t, cmd, origin, "update-ref", "refs/pull/7/head", "HEAD")
+		runGit(t, cmd, origin, "checkout", "main")
+
+		require.NoError(t, creator.CreateWorktreeForPullRequest(ctx, 7))
+		assert.FileExists(t, filepath.Join(worktreePath, "logout.txt"))
+		content, err := os.ReadFile(filepath.Join(worktreePath, "login.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "login", string(content))
+		// 追跡していないファイルは残す
+		assert.FileExists(t, filepath.Join(worktreePath, "wip.txt"))
+	})
+
+	t.Run("存在しないPR", func(t *testing.T) {
+		assert.Error(t, creator.CreateWorktreeForPullRequest(ctx, 8))
+	})
+}
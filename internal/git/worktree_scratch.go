@@ -0,0 +1,112 @@
+package git
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUncommittedWork はscratch volume上の作業ディレクトリに未コミットの変更があり、削除を拒否したことを示す
+var ErrUncommittedWork = errors.New("refusing to discard workspace with uncommitted changes")
+
+// WorkspaceDiscarder はscratch volume上の作業ディレクトリを、作業内容を退避してから削除できるWorktreeManager
+type WorkspaceDiscarder interface {
+	// DiscardWorkspace は未コミットの変更がないことを確認し、ブランチをpushしてから作業ディレクトリを削除する
+	DiscardWorkspace(ctx context.Context, path string) error
+}
+
+var (
+	_ WorkspaceDiscarder = (*worktreeManager)(nil)
+	_ WorkspaceDiscarder = (*cloneManager)(nil)
+)
+
+// WithScratchDir はIssueの作業ディレクトリをscratch volume（tmpfsや高速なNVMeなど）のdir配下に作成する
+// 作業ディレクトリは<dir>/<リポジトリのディレクトリ名>-<リポジトリのパスのハッシュ>/osoba/worktrees/に作成し、
+// 削除する前に未コミットの変更がないことを確認してブランチをpushする
+func WithScratchDir(dir string) WorktreeManagerOption {
+	return func(m *worktreeManager) {
+		if dir == "" {
+			return
+		}
+		m.worktreesDir = filepath.Join(dir, scratchRepoDir(m.basePath), "osoba", "worktrees")
+		m.scratch = true
+	}
+}
+
+// scratchRepoDir はscratch volume上でリポジトリの作業ディレクトリをまとめるディレクトリ名を返す
+// ディレクトリ名が同じ別のリポジトリと衝突しないよう、リポジトリのパスのハッシュを付ける
+func scratchRepoDir(basePath string) string {
+	sum := sha256.Sum256([]byte(filepath.Clean(basePath)))
+	return fmt.Sprintf("%s-%x", filepath.Base(basePath), sum[:4])
+}
+
+// DiscardWorkspace はworktreeの作業内容を退避してから削除する
+func (m *worktreeManager) DiscardWorkspace(ctx context.Context, path string) error {
+	if err := m.preserveWork(ctx, path); err != nil {
+		return err
+	}
+	return m.worktree.Remove(ctx, m.basePath, path)
+}
+
+// DiscardWorkspace はcloneの作業内容を退避してから削除する
+func (c *cloneManager) DiscardWorkspace(ctx context.Context, path string) error {
+	if err := c.main.preserveWork(ctx, path); err != nil {
+		return err
+	}
+	return RemoveClone(path)
+}
+
+// preserveWork はscratch volume上の作業ディレクトリを削除する前に、作業内容を失わないことを確認する
+// 未コミットの変更がある場合は削除を拒否し、ベースブランチにないコミットがある場合はブランチをoriginにpushする
+// scratch volumeを使わない場合は何もしない
+func (m *worktreeManager) preserveWork(ctx context.Context, path string) error {
+	if !m.scratch {
+		return nil
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	dirty, err := m.worktree.HasUncommittedChanges(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to check uncommitted changes: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("%w: %s", ErrUncommittedWork, path)
+	}
+
+	branchName, err := m.branch.GetCurrent(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to get current branch: %w", err)
+	}
+	baseBranch := m.baseBranch(ctx)
+	if branchName == "" || branchName == baseBranch {
+		return nil
+	}
+
+	// ベースブランチを取得できない場合は、コミットを失わないようpushする
+	if output, err := m.worktree.command.Run(ctx, "git", []string{"rev-list", "--count", "origin/" + baseBranch + "..HEAD"}, path); err == nil && strings.TrimSpace(output) == "0" {
+		return nil
+	}
+	if _, err := m.worktree.command.Run(ctx, "git", []string{"push", "--quiet", "origin", "HEAD:refs/heads/" + branchName}, path); err != nil {
+		// pushできない場合は作業ディレクトリを残し、コミットを失わないようにする
+		return fmt.Errorf("failed to push branch %s before discarding workspace: %w", branchName, err)
+	}
+	return nil
+}
+
+// baseBranch はoriginのデフォルトブランチ（refs/remotes/origin/HEAD）を返す（設定されていない場合はmain）
+func (m *worktreeManager) baseBranch(ctx context.Context) string {
+	output, err := m.worktree.command.Run(ctx, "git", []string{"symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD"}, m.basePath)
+	if err != nil {
+		return "main"
+	}
+	if branch := strings.TrimPrefix(strings.TrimSpace(output), "origin/"); branch != "" {
+		return branch
+	}
+	return "main"
+}
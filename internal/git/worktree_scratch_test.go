@@ -0,0 +1,110 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/testutil/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithScratchDir_RemoveWorktreeForIssue(t *testing.T) {
+	tests := []struct {
+		name        string
+		newManager  func(Repository, *Worktree, *Branch, *Sync, ...WorktreeManagerOption) (WorktreeManager, error)
+		commit      bool
+		uncommitted bool
+		wantPushed  bool
+		wantErr     error
+	}{
+		{name: "worktreeのコミットをpushしてから削除", newManager: NewWorktreeManager, commit: true, wantPushed: true},
+		{name: "cloneのコミットをpushしてから削除", newManager: NewCloneManager, commit: true, wantPushed: true},
+		{name: "mainにないコミットがない場合はpushしない", newManager: NewWorktreeManager},
+		{name: "未コミットの変更がある場合は削除しない", newManager: NewWorktreeManager, commit: true, uncommitted: true, wantErr: ErrUncommittedWork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+			cmd := NewCommand(logger)
+			basePath, originPath := setupCloneTestRepository(t, cmd)
+			scratchDir := t.TempDir()
+
+			branch := NewBranch(logger)
+			manager, err := tt.newManager(&mockRepository{rootPath: basePath}, NewWorktree(logger), branch, NewSync(logger), WithScratchDir(scratchDir))
+			require.NoError(t, err)
+
+			path := manager.GetWorktreePathForIssue(3)
+			assert.Equal(t, filepath.Join(scratchDir, scratchRepoDir(basePath), "osoba", "worktrees", "issue-3"), path)
+			require.NoError(t, manager.CreateWorktreeForIssue(ctx, 3))
+			assert.DirExists(t, path)
+
+			worktrees, err := manager.ListWorktreesForIssue(ctx, 3)
+			require.NoError(t, err)
+			require.Len(t, worktrees, 1)
+
+			if tt.commit {
+				runGit(t, cmd, path, "config", "user.email", "test@example.com")
+				runGit(t, cmd, path, "config", "user.name", "Test User")
+				require.NoError(t, os.WriteFile(filepath.Join(path, "feature.txt"), []byte("feature"), 0644))
+				runGit(t, cmd, path, "add", ".")
+				runGit(t, cmd, path, "commit", "-m", "add feature")
+			}
+			if tt.uncommitted {
+				require.NoError(t, os.WriteFile(filepath.Join(path, "wip.txt"), []byte("wip"), 0644))
+			}
+
+			err = manager.RemoveWorktreeForIssue(ctx, 3)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.DirExists(t, path, "作業内容を失わないよう作業ディレクトリを残す")
+				return
+			}
+			require.NoError(t, err)
+			assert.NoDirExists(t, path)
+			assert.Equal(t, tt.wantPushed, branch.Exists(ctx, originPath, "osoba/#3"))
+		})
+	}
+}
+
+func TestScratchRepoDir(t *testing.T) {
+	// ディレクトリ名が同じ別のリポジトリは別のディレクトリに作成する
+	first := scratchRepoDir("/home/alice/src/app")
+	second := scratchRepoDir("/home/alice/work/app")
+	assert.NotEqual(t, first, second)
+	assert.Regexp(t, `^app-[0-9a-f]{8}$`, first)
+	assert.Equal(t, first, scratchRepoDir("/home/alice/src/app/"))
+}
+
+func TestWithScratchDir_BaseBranch(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	cmd := NewCommand(logger)
+	basePath, originPath := setupCloneTestRepository(t, cmd)
+
+	// originのデフォルトブランチをdevelopにする
+	runGit(t, cmd, basePath, "checkout", "-b", "develop")
+	require.NoError(t, os.WriteFile(filepath.Join(basePath, "develop.txt"), []byte("develop"), 0644))
+	runGit(t, cmd, basePath, "add", ".")
+	runGit(t, cmd, basePath, "commit", "-m", "develop commit")
+	runGit(t, cmd, basePath, "push", "-u", "origin", "develop")
+	runGit(t, cmd, basePath, "remote", "set-head", "origin", "develop")
+	runGit(t, cmd, basePath, "checkout", "main")
+
+	branch := NewBranch(logger)
+	manager, err := NewWorktreeManager(&mockRepository{rootPath: basePath}, NewWorktree(logger), branch, NewSync(logger), WithScratchDir(t.TempDir()))
+	require.NoError(t, err)
+	require.NoError(t, manager.CreateWorktreeForIssue(ctx, 4))
+	path := manager.GetWorktreePathForIssue(4)
+	runGit(t, cmd, path, "reset", "--hard", "origin/develop")
+
+	// ベースブランチにないコミットがないため、mainにないコミットがあってもpushしない
+	require.NoError(t, manager.RemoveWorktreeForIssue(ctx, 4))
+	assert.NoDirExists(t, path)
+	assert.False(t, branch.Exists(ctx, originPath, "osoba/#4"))
+}
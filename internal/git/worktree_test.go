@@ -290,3 +290,36 @@ func TestWorktree_List(t *testing.T) {
 		assert.NotEmpty(t, wtInfo.Commit)
 	}
 }
+
+func TestWorktree_Move(t *testing.T) {
+	tmpDir := t.TempDir()
+	testLogger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	cmd := NewCommand(testLogger)
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+		{"commit", "--allow-empty", "-m", "initial commit"},
+	} {
+		_, err := cmd.Run(context.Background(), "git", args, tmpDir)
+		require.NoError(t, err)
+	}
+	oldPath := filepath.Join(tmpDir, ".git", "worktree", "plan", "83")
+	_, err := cmd.Run(context.Background(), "git", []string{"worktree", "add", oldPath, "-b", "osoba/#83-plan"}, tmpDir)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(oldPath, "wip.txt"), []byte("wip"), 0644))
+
+	wt := NewWorktree(testLogger)
+	newPath := filepath.Join(tmpDir, ".git", "osoba", "worktrees", "issue-83")
+	require.NoError(t, wt.Move(context.Background(), tmpDir, oldPath, newPath))
+
+	assert.NoDirExists(t, oldPath)
+	assert.FileExists(t, filepath.Join(newPath, "wip.txt"), "未コミットの変更を引き継ぐ")
+	worktrees, err := wt.List(context.Background(), tmpDir)
+	require.NoError(t, err)
+	var paths []string
+	for _, info := range worktrees {
+		paths = append(paths, info.Path)
+	}
+	assert.Contains(t, paths, newPath)
+}
@@ -0,0 +1,261 @@
+package github
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 監査ログに記録する操作の結果
+const (
+	AuditResultOK    = "ok"
+	AuditResultError = "error"
+)
+
+// auditMaxArgLength は監査ログに記録する引数の最大文字数（コメント本文など長い引数は省略する）
+const auditMaxArgLength = 200
+
+// mutatingSubcommands はリポジトリを変更するghのサブコマンド
+var mutatingSubcommands = map[string]map[string]bool{
+	"issue": {"create": true, "edit": true, "comment": true, "close": true, "reopen": true, "delete": true, "lock": true, "unlock": true, "transfer": true, "pin": true, "unpin": true},
+	"pr":    {"create": true, "edit": true, "comment": true, "merge": true, "close": true, "reopen": true, "review": true, "ready": true},
+	"label": {"create": true, "edit": true, "delete": true, "clone": true},
+}
+
+var (
+	// auditRepoPattern はgh apiのエンドポイントからリポジトリを抽出する
+	auditRepoPattern = regexp.MustCompile(`^/?repos/([^/]+/[^/]+)`)
+	// auditNumberPattern はgh apiのエンドポイントからIssue・PR番号を抽出する
+	auditNumberPattern = regexp.MustCompile(`/(?:issues|pulls)/(\d+)`)
+)
+
+// AuditEntry は変更を伴うGitHub操作の監査ログ1件
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	Actor      string    `json:"actor"`                // 認証トークンのフィンガープリント
+	Operation  string    `json:"operation"`            // "issue edit"、"pr merge"、"api PATCH" など
+	Repository string    `json:"repository,omitempty"` // owner/repo（特定できない場合は空）
+	Number     int       `json:"number,omitempty"`     // 対象のIssue・PR番号（特定できない場合は0）
+	Args       []string  `json:"args"`                 // ghコマンドの引数
+	Result     string    `json:"result"`               // AuditResultOK または AuditResultError
+	Error      string    `json:"error,omitempty"`
+}
+
+// AuditLog は変更を伴うGitHub操作を1行1件のJSONでファイルに追記する
+type AuditLog struct {
+	path  string
+	actor string
+	now   func() time.Time
+	mu    sync.Mutex
+}
+
+// NewAuditLog は新しいAuditLogを作成する
+// tokenは記録者を識別するフィンガープリントの計算にのみ使用し、記録しない
+func NewAuditLog(path, token string) (*AuditLog, error) {
+	if path == "" {
+		return nil, errors.New("audit log path is required")
+	}
+	return &AuditLog{path: path, actor: TokenFingerprint(token), now: time.Now}, nil
+}
+
+// SetToken はトークンの更新後に記録者のフィンガープリントを更新する
+func (l *AuditLog) SetToken(token string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.actor = TokenFingerprint(token)
+}
+
+// Path は監査ログのパスを返す
+func (l *AuditLog) Path() string {
+	return l.path
+}
+
+// Record はghコマンドが変更を伴う操作の場合に、その結果を追記する（変更を伴わない操作は無視する）
+func (l *AuditLog) Record(args []string, cmdErr error) error {
+	operation, ok := mutatingOperation(args)
+	if !ok {
+		return nil
+	}
+
+	l.mu.Lock()
+	actor := l.actor
+	l.mu.Unlock()
+
+	entry := AuditEntry{
+		Time:       l.now(),
+		Actor:      actor,
+		Operation:  operation,
+		Repository: auditRepository(args),
+		Number:     auditNumber(args),
+		Args:       truncateAuditArgs(args),
+		Result:     AuditResultOK,
+	}
+	if cmdErr != nil {
+		entry.Result = AuditResultError
+		entry.Error = cmdErr.Error()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// ReadAuditLog は監査ログを記録順に読み込む
+// 書き込み途中などで壊れた行は読み飛ばす
+func ReadAuditLog(path string) ([]AuditEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// TokenFingerprint は認証トークンを識別するフィンガープリント（SHA-256の先頭12桁）を返す
+// トークンが空の場合は"unknown"を返す
+func TokenFingerprint(token string) string {
+	if token == "" {
+		return "unknown"
+	}
+	sum := sha256.Sum256([]byte(token))
+	return "sha256:" + hex.EncodeToString(sum[:])[:12]
+}
+
+// mutatingOperation はghコマンドが変更を伴う操作かを判定し、操作名を返す
+func mutatingOperation(args []string) (string, bool) {
+	if len(args) < 2 {
+		return "", false
+	}
+	if args[0] != "api" {
+		if mutatingSubcommands[args[0]][args[1]] {
+			return args[0] + " " + args[1], true
+		}
+		return "", false
+	}
+
+	method := ""
+	hasFields := false
+	query := ""
+	for i := 1; i < len(args); i++ {
+		switch arg := args[i]; {
+		case (arg == "-X" || arg == "--method") && i+1 < len(args):
+			method = strings.ToUpper(args[i+1])
+			i++
+		case strings.HasPrefix(arg, "--method="):
+			method = strings.ToUpper(strings.TrimPrefix(arg, "--method="))
+		case arg == "-f" || arg == "-F" || arg == "--field" || arg == "--raw-field" || arg == "--input":
+			hasFields = true
+			if i+1 < len(args) && strings.HasPrefix(args[i+1], "query=") {
+				query = strings.TrimPrefix(args[i+1], "query=")
+			}
+			i++
+		}
+	}
+
+	if args[1] == "graphql" {
+		if strings.HasPrefix(strings.TrimSpace(query), "mutation") {
+			return "api graphql", true
+		}
+		return "", false
+	}
+	if method == "" && hasFields {
+		method = "POST"
+	}
+	if method == "" || method == "GET" || method == "HEAD" {
+		return "", false
+	}
+	return "api " + method, true
+}
+
+// auditRepository はghコマンドの引数から対象のリポジトリを抽出する
+func auditRepository(args []string) string {
+	for i, arg := range args {
+		if (arg == "--repo" || arg == "-R") && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(arg, "--repo=") {
+			return strings.TrimPrefix(arg, "--repo=")
+		}
+	}
+	if len(args) > 1 && args[0] == "api" {
+		for _, arg := range args[1:] {
+			if m := auditRepoPattern.FindStringSubmatch(arg); m != nil {
+				return m[1]
+			}
+		}
+	}
+	return ""
+}
+
+// auditNumber はghコマンドの引数から対象のIssue・PR番号を抽出する
+func auditNumber(args []string) int {
+	if len(args) > 2 && (args[0] == "issue" || args[0] == "pr") {
+		if n, err := strconv.Atoi(strings.TrimPrefix(args[2], "#")); err == nil {
+			return n
+		}
+		return 0
+	}
+	if len(args) > 1 && args[0] == "api" {
+		for _, arg := range args[1:] {
+			if m := auditNumberPattern.FindStringSubmatch(arg); m != nil {
+				n, _ := strconv.Atoi(m[1])
+				return n
+			}
+		}
+	}
+	return 0
+}
+
+// truncateAuditArgs は長い引数を省略した引数のコピーを返す
+func truncateAuditArgs(args []string) []string {
+	truncated := make([]string, len(args))
+	for i, arg := range args {
+		if runes := []rune(arg); len(runes) > auditMaxArgLength {
+			arg = fmt.Sprintf("%s…(%d bytes)", string(runes[:auditMaxArgLength]), len(arg))
+		}
+		truncated[i] = arg
+	}
+	return truncated
+}
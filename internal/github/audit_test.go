@@ -0,0 +1,130 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutatingOperation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "ラベルの変更", args: []string{"issue", "edit", "12", "--repo", "owner/repo", "--add-label", "status:ready"}, want: "issue edit"},
+		{name: "PRのマージ", args: []string{"pr", "merge", "34", "--squash"}, want: "pr merge"},
+		{name: "ラベルの作成", args: []string{"label", "create", "status:ready"}, want: "label create"},
+		{name: "メソッド指定のapi", args: []string{"api", "-X", "PATCH", "repos/owner/repo/issues/comments/1", "-f", "body=x"}, want: "api PATCH"},
+		{name: "フィールド指定のapiはPOST", args: []string{"api", "repos/owner/repo/issues/12/comments", "-f", "body=x"}, want: "api POST"},
+		{name: "GraphQLのmutation", args: []string{"api", "graphql", "-f", "query=mutation { closeIssue }"}, want: "api graphql"},
+		{name: "GraphQLのquery", args: []string{"api", "graphql", "-f", "query=query { repository }"}},
+		{name: "参照のみのapi", args: []string{"api", "repos/owner/repo", "--jq", ".default_branch"}},
+		{name: "Issueの参照", args: []string{"issue", "view", "12"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := mutatingOperation(tt.args)
+			assert.Equal(t, tt.want != "", ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAuditLog_RecordAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "owner_repo.jsonl")
+	audit, err := NewAuditLog(path, "ghp_secret")
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	audit.now = func() time.Time { return now }
+
+	longBody := strings.Repeat("あ", auditMaxArgLength+10)
+	require.NoError(t, audit.Record([]string{"issue", "comment", "12", "--repo", "owner/repo", "--body", longBody}, nil))
+	require.NoError(t, audit.Record([]string{"issue", "view", "12"}, nil))
+	require.NoError(t, audit.Record([]string{"api", "-X", "PUT", "repos/owner/repo/pulls/34/merge"}, errors.New("HTTP 405")))
+
+	entries, err := ReadAuditLog(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "変更を伴わない操作は記録しない")
+
+	assert.Equal(t, now, entries[0].Time)
+	assert.Equal(t, TokenFingerprint("ghp_secret"), entries[0].Actor)
+	assert.NotContains(t, entries[0].Actor, "ghp_secret")
+	assert.Equal(t, "issue comment", entries[0].Operation)
+	assert.Equal(t, "owner/repo", entries[0].Repository)
+	assert.Equal(t, 12, entries[0].Number)
+	assert.Equal(t, AuditResultOK, entries[0].Result)
+	assert.Contains(t, entries[0].Args[6], "…(")
+
+	assert.Equal(t, "api PUT", entries[1].Operation)
+	assert.Equal(t, "owner/repo", entries[1].Repository)
+	assert.Equal(t, 34, entries[1].Number)
+	assert.Equal(t, AuditResultError, entries[1].Result)
+	assert.Equal(t, "HTTP 405", entries[1].Error)
+}
+
+func TestGHClient_SetAuditLog(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		return []byte("ok"), nil
+	}
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	audit, err := NewAuditLog(path, "")
+	require.NoError(t, err)
+
+	client := &GHClient{labelManager: NewGHLabelManager(nil, 0, 0)}
+	client.SetAuditLog(audit)
+	assert.Same(t, audit, client.labelManager.(*GHLabelManager).audit)
+
+	require.NoError(t, client.CreateIssueComment(context.Background(), "owner", "repo", 5, "hello"))
+	_, err = client.GetRepository(context.Background(), "owner", "repo")
+	require.Error(t, err) // 出力がJSONでないためパースに失敗するが、記録対象外であることを確認する
+
+	entries, err := ReadAuditLog(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "unknown", entries[0].Actor)
+	assert.Equal(t, 5, entries[0].Number)
+}
+
+func TestGHClient_AddLabelDefinitions(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+	var created []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		if args[0] == "label" && args[1] == "list" {
+			return []byte(`[{"name":"status:needs-plan"},{"name":"status:ready"},{"name":"status:review-requested"},{"name":"status:planning"},{"name":"status:implementing"},{"name":"status:reviewing"}]`), nil
+		}
+		created = append(created, strings.Join(args, " "))
+		return []byte("ok"), nil
+	}
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	audit, err := NewAuditLog(path, "")
+	require.NoError(t, err)
+
+	client := &GHClient{labelManager: NewGHLabelManager(nil, 0, 0)}
+	client.SetAuditLog(audit)
+	client.AddLabelDefinitions([]LabelDefinition{
+		{Name: "status:ready", Color: "ffffff", Description: "上書きしない"},
+		{Name: "osoba:ready", Color: "0e8a16", Description: "Ready for implementation"},
+	})
+
+	require.NoError(t, client.EnsureLabelsExist(context.Background(), "owner", "repo"))
+	require.Equal(t, []string{"label create osoba:ready --repo owner/repo --color 0e8a16 --description Ready for implementation"}, created)
+
+	// 設定のラベルの作成もosobaのクライアントを通るため監査ログに記録される
+	entries, err := ReadAuditLog(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Contains(t, strings.Join(entries[0].Args, " "), "label create osoba:ready")
+}
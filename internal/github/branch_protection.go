@@ -0,0 +1,107 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// マージ方法（gh pr mergeのオプション名）
+const (
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// BranchProtection はブランチ保護ルールのうち自動マージに影響する制約
+type BranchProtection struct {
+	Branch                   string   `json:"branch"`
+	Protected                bool     `json:"protected"`
+	RequiredApprovingReviews int      `json:"required_approving_reviews,omitempty"` // マージに必要な承認数
+	RequiredChecks           []string `json:"required_checks,omitempty"`            // マージに必要なステータスチェック
+	RequireLinearHistory     bool     `json:"require_linear_history,omitempty"`     // マージコミットを禁止しているか
+}
+
+// MergeMethod はブランチ保護の制約に合うマージ方法を返す
+func (p *BranchProtection) MergeMethod() string {
+	if p != nil && p.RequireLinearHistory {
+		return MergeMethodRebase
+	}
+	return MergeMethodSquash
+}
+
+// BranchProtectionReader はブランチ保護ルールを取得する（起動時に保護ルールに合わせて自動マージの方法を選ぶために使う）
+type BranchProtectionReader interface {
+	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*BranchProtection, error)
+}
+
+var _ BranchProtectionReader = (*GHClient)(nil)
+
+// GetBranchProtection はブランチ保護ルールを取得する（保護されていない場合はProtectedがfalse）
+func (c *GHClient) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*BranchProtection, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if branch == "" {
+		return nil, errors.New("branch is required")
+	}
+
+	output, err := c.executeGHCommand(ctx, "api", fmt.Sprintf("repos/%s/%s/branches/%s/protection", owner, repo, branch))
+	if err != nil {
+		// 保護されていないブランチは404（Branch not protected）になる
+		// 保護ルールを読む権限がない場合も404（Not Found）になるため、それ以外の404は保護の有無が不明として扱う
+		if ghErr := ParseGHError(err.Error(), err); ghErr.Type == ErrorTypeNotFound || ghErr.StatusCode == 404 {
+			if strings.Contains(err.Error(), "Branch not protected") {
+				return &BranchProtection{Branch: branch}, nil
+			}
+			return nil, fmt.Errorf("branch protection is unknown (no permission to read it or the branch does not exist): %w", err)
+		}
+		return nil, fmt.Errorf("failed to get branch protection: %w", err)
+	}
+
+	var resp struct {
+		RequiredPullRequestReviews *struct {
+			RequiredApprovingReviewCount int `json:"required_approving_review_count"`
+		} `json:"required_pull_request_reviews"`
+		RequiredStatusChecks *struct {
+			Contexts []string `json:"contexts"`
+			Checks   []struct {
+				Context string `json:"context"`
+			} `json:"checks"`
+		} `json:"required_status_checks"`
+		RequiredLinearHistory *struct {
+			Enabled bool `json:"enabled"`
+		} `json:"required_linear_history"`
+	}
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse branch protection: %w", err)
+	}
+
+	protection := &BranchProtection{Branch: branch, Protected: true}
+	if resp.RequiredPullRequestReviews != nil {
+		protection.RequiredApprovingReviews = resp.RequiredPullRequestReviews.RequiredApprovingReviewCount
+	}
+	if resp.RequiredStatusChecks != nil {
+		seen := make(map[string]bool)
+		for _, name := range resp.RequiredStatusChecks.Contexts {
+			if !seen[name] {
+				seen[name] = true
+				protection.RequiredChecks = append(protection.RequiredChecks, name)
+			}
+		}
+		for _, check := range resp.RequiredStatusChecks.Checks {
+			if !seen[check.Context] {
+				seen[check.Context] = true
+				protection.RequiredChecks = append(protection.RequiredChecks, check.Context)
+			}
+		}
+	}
+	if resp.RequiredLinearHistory != nil {
+		protection.RequireLinearHistory = resp.RequiredLinearHistory.Enabled
+	}
+	return protection, nil
+}
@@ -0,0 +1,101 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_GetBranchProtection(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	tests := []struct {
+		name    string
+		output  string
+		err     error
+		want    *BranchProtection
+		wantErr bool
+	}{
+		{
+			name: "承認・チェック・直線的な履歴を要求",
+			output: `{
+				"required_pull_request_reviews": {"required_approving_review_count": 2},
+				"required_status_checks": {"contexts": ["test"], "checks": [{"context": "test"}, {"context": "lint"}]},
+				"required_linear_history": {"enabled": true}
+			}`,
+			want: &BranchProtection{Branch: "main", Protected: true, RequiredApprovingReviews: 2, RequiredChecks: []string{"test", "lint"}, RequireLinearHistory: true},
+		},
+		{
+			name:   "制約のない保護ルール",
+			output: `{"required_linear_history": {"enabled": false}}`,
+			want:   &BranchProtection{Branch: "main", Protected: true},
+		},
+		{
+			name:   "保護されていない",
+			output: "gh: Branch not protected (HTTP 404)",
+			err:    errors.New("exit status 1"),
+			want:   &BranchProtection{Branch: "main"},
+		},
+		{
+			name:    "権限がなく404になる場合は不明",
+			output:  "gh: Not Found (HTTP 404)",
+			err:     errors.New("exit status 1"),
+			wantErr: true,
+		},
+		{
+			name:    "権限がない",
+			output:  "gh: Resource not accessible by integration (HTTP 403)",
+			err:     errors.New("exit status 1"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+				gotArgs = args
+				return []byte(tt.output), tt.err
+			}
+
+			client := &GHClient{}
+			got, err := client.GetBranchProtection(context.Background(), "owner", "repo", "main")
+			assert.Equal(t, []string{"api", "repos/owner/repo/branches/main/protection"}, gotArgs)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBranchProtection_MergeMethod(t *testing.T) {
+	assert.Equal(t, MergeMethodRebase, (&BranchProtection{RequireLinearHistory: true}).MergeMethod())
+	assert.Equal(t, MergeMethodSquash, (&BranchProtection{}).MergeMethod())
+	assert.Equal(t, MergeMethodSquash, (*BranchProtection)(nil).MergeMethod())
+}
+
+func TestGHClient_MergePullRequest_MergeMethod(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return nil, nil
+	}
+
+	client := &GHClient{}
+	require.NoError(t, client.MergePullRequest(context.Background(), 42))
+	assert.Equal(t, []string{"pr", "merge", "42", "--squash", "--auto"}, gotArgs)
+
+	client.SetMergeMethod(MergeMethodRebase)
+	require.NoError(t, client.MergePullRequest(context.Background(), 42))
+	assert.Equal(t, []string{"pr", "merge", "42", "--rebase", "--auto"}, gotArgs)
+}
@@ -5,20 +5,27 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/douhashi/osoba/internal/faultinject"
 	"github.com/douhashi/osoba/internal/logger"
 )
 
 // GHClient はghコマンドを使用するGitHub APIクライアント
 type GHClient struct {
-	logger       logger.Logger
-	labelManager LabelManagerInterface
-	owner        string
-	repo         string
+	logger        logger.Logger
+	labelManager  LabelManagerInterface
+	owner         string
+	repo          string
+	throttle      throttleState
+	audit         *AuditLog             // 変更を伴う操作の監査ログ（無効の場合はnil）
+	faultInjector *faultinject.Injector // 障害注入（無効の場合はnil）
+	mergeMethod   string                // PRのマージ方法（空の場合はsquash）
+	comments      *commentConsolidator  // 自動コメントのまとめ（無効の場合はnil）
+	issueCache    *issueContentCache    // Issueのコメント一覧のキャッシュ（無効の場合はnil）
+	offline       *offlineQueue         // GitHubに接続できない間に保留した変更（無効の場合はnil）
 }
 
 // NewClient は新しいGitHub APIクライアントを作成する（ghコマンドベース）
@@ -120,6 +127,8 @@ func (c *GHClient) ListIssuesByLabels(ctx context.Context, owner, repo string, l
 	}
 
 	issues := make([]*Issue, 0)
+	listed := make(map[int]bool, len(ghIssues))
+	defer c.issueCache.forgetUnlisted(owner, repo, listed)
 	for _, ghIssue := range ghIssues {
 		issue, err := convertMapToIssue(ghIssue)
 		if err != nil {
@@ -128,6 +137,12 @@ func (c *GHClient) ListIssuesByLabels(ctx context.Context, owner, repo string, l
 			}
 			continue
 		}
+		// ラベルで絞り込む前に、すべてのオープンIssueの更新日時をキャッシュの基準として記録する
+		// （一覧に含まれなかったIssueの記録は取得後に破棄する）
+		c.issueCache.observe(owner, repo, issue)
+		if issue.Number != nil {
+			listed[*issue.Number] = true
+		}
 
 		// ラベルが指定されている場合は、OR条件でフィルタリング
 		if len(labels) > 0 {
@@ -249,6 +264,13 @@ func (c *GHClient) EnsureLabelsExist(ctx context.Context, owner, repo string) er
 	return c.labelManager.EnsureLabelsExistWithRetry(ctx, owner, repo)
 }
 
+// AddLabelDefinitions はEnsureLabelsExistで作成するラベルに、設定で名前を変更したラベルや有効にした機能が使うラベルを加える
+func (c *GHClient) AddLabelDefinitions(defs []LabelDefinition) {
+	if lm, ok := c.labelManager.(interface{ AddLabelDefinitions([]LabelDefinition) }); ok {
+		lm.AddLabelDefinitions(defs)
+	}
+}
+
 // CreateIssueComment はIssueにコメントを作成する
 func (c *GHClient) CreateIssueComment(ctx context.Context, owner, repo string, issueNumber int, comment string) error {
 	if owner == "" {
@@ -261,9 +283,20 @@ func (c *GHClient) CreateIssueComment(ctx context.Context, owner, repo string, i
 		return errors.New("comment is required")
 	}
 
-	if _, err := c.executeGHCommand(ctx, "issue", "comment", strconv.Itoa(issueNumber), "--repo", fmt.Sprintf("%s/%s", owner, repo), "--body", comment); err != nil {
-		return fmt.Errorf("failed to create comment: %w", err)
+	if c.comments.consolidates(comment) {
+		return c.postConsolidated(ctx, owner, repo, issueNumber, comment)
+	}
+	_, err := c.createIssueComment(ctx, owner, repo, issueNumber, comment)
+	return err
+}
+
+// createIssueComment はIssueに新しいコメントを作成し、ghの出力（コメントのURL）を返す
+func (c *GHClient) createIssueComment(ctx context.Context, owner, repo string, issueNumber int, comment string) ([]byte, error) {
+	output, err := c.executeGHCommand(ctx, "issue", "comment", strconv.Itoa(issueNumber), "--repo", fmt.Sprintf("%s/%s", owner, repo), "--body", comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
+	c.issueCache.invalidate(owner, repo, issueNumber)
 
 	if c.logger != nil {
 		c.logger.Debug("Created issue comment",
@@ -273,7 +306,7 @@ func (c *GHClient) CreateIssueComment(ctx context.Context, owner, repo string, i
 		)
 	}
 
-	return nil
+	return output, nil
 }
 
 // RemoveLabel はIssueからラベルを削除する
@@ -514,6 +547,40 @@ func convertMapToIssue(issueMap map[string]interface{}) (*Issue, error) {
 		}
 	}
 
+	// Assignees
+	if assigneesSlice, ok := issueMap["assignees"].([]interface{}); ok {
+		for _, assigneeVal := range assigneesSlice {
+			if assigneeMap, ok := assigneeVal.(map[string]interface{}); ok {
+				if loginStr, ok := assigneeMap["login"].(string); ok && loginStr != "" {
+					issue.Assignees = append(issue.Assignees, &User{Login: &loginStr})
+				}
+			}
+		}
+	}
+
+	// CreatedAt / UpdatedAt
+	if createdStr, ok := issueMap["createdAt"].(string); ok {
+		if createdAt, err := time.Parse(time.RFC3339, createdStr); err == nil {
+			issue.CreatedAt = &createdAt
+		}
+	}
+	if updatedStr, ok := issueMap["updatedAt"].(string); ok {
+		if updatedAt, err := time.Parse(time.RFC3339, updatedStr); err == nil {
+			issue.UpdatedAt = &updatedAt
+		}
+	}
+
+	// Milestone
+	if milestoneMap, ok := issueMap["milestone"].(map[string]interface{}); ok {
+		if titleStr, ok := milestoneMap["title"].(string); ok && titleStr != "" {
+			issue.Milestone = &Milestone{Title: &titleStr}
+			if numberFloat, ok := milestoneMap["number"].(float64); ok {
+				number := int(numberFloat)
+				issue.Milestone.Number = &number
+			}
+		}
+	}
+
 	// Labels
 	if labelsVal, ok := issueMap["labels"]; ok {
 		if labelsSlice, ok := labelsVal.([]interface{}); ok {
@@ -552,9 +619,28 @@ func (c *GHClient) executeGHCommand(ctx context.Context, args ...string) ([]byte
 		)
 	}
 
-	cmd := exec.CommandContext(ctx, "gh", args...)
-	output, err := cmd.CombinedOutput()
+	// スロットリング中はRetry-Afterで示された時間まで待機する
+	if err := c.waitForThrottle(ctx); err != nil {
+		return nil, err
+	}
+
+	output, injected, err := runGHCommandWithFaults(ctx, c.faultInjector, args)
+	if !injected {
+		c.recordAudit(args, err)
+	}
 	if err != nil {
+		// セカンダリレート制限・不正利用検知は汎用エラーとして扱わず、待機時間を記録する
+		if ghErr := ParseGHError(string(output), err); ghErr.Type == ErrorTypeRateLimit {
+			if c.recordThrottle(ghErr) && c.logger != nil {
+				c.logger.Warn("GitHub throttled",
+					"args", args,
+					"retry_after", throttleDelay(ghErr),
+					"message", ghErr.Message,
+				)
+			}
+			return nil, ghErr
+		}
+
 		if c.logger != nil {
 			c.logger.Error("gh command failed",
 				"args", args,
@@ -576,9 +662,31 @@ func (c *GHClient) executeGHCommand(ctx context.Context, args ...string) ([]byte
 		)
 	}
 
+	// 接続が戻ったため保留中の変更を反映する
+	c.flushOffline(ctx)
+
 	return output, nil
 }
 
+// SetAuditLog は変更を伴う操作を記録する監査ログを設定する
+// ラベルの遷移・作成を行うLabelManagerの操作も記録する
+func (c *GHClient) SetAuditLog(audit *AuditLog) {
+	c.audit = audit
+	if lm, ok := c.labelManager.(interface{ SetAuditLog(*AuditLog) }); ok {
+		lm.SetAuditLog(audit)
+	}
+}
+
+// recordAudit はghコマンドを監査ログに記録する（監査ログが無効の場合は何もしない）
+func (c *GHClient) recordAudit(args []string, cmdErr error) {
+	if c.audit == nil {
+		return
+	}
+	if err := c.audit.Record(args, cmdErr); err != nil && c.logger != nil {
+		c.logger.Warn("Failed to write audit log", "args", args, "error", err)
+	}
+}
+
 // hasLabel はIssueが指定されたラベルを持っているかを確認する
 func hasLabel(issue *Issue, labelName string) bool {
 	if issue == nil || issue.Labels == nil {
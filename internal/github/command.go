@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/trace"
+)
+
+// defaultGHPath はghの実行ファイルのデフォルト値（PATHから検索する）
+const defaultGHPath = "gh"
+
+// CommandConfig はghコマンドの実行設定
+type CommandConfig struct {
+	Path    string        // ghの実行ファイル（空の場合はPATHから"gh"を検索する）
+	Timeout time.Duration // 1コマンドあたりのタイムアウト（0の場合は無制限）
+	Args    []string      // すべてのghコマンドの末尾に付与する引数
+	Host    string        // ghコマンドの接続先のホスト（GH_HOSTとして渡す。空の場合は環境変数をそのまま使う）
+	Proxy   string        // ghコマンドに渡すHTTP(S)プロキシ（空の場合は環境変数をそのまま使う）
+	NoProxy string        // プロキシを経由しないホスト（空の場合は環境変数をそのまま使う）
+}
+
+// commandConfig はghコマンドの実行設定（起動時にSetCommandConfigで設定する）
+var commandConfig CommandConfig
+
+// SetCommandConfig はghコマンドの実行設定を変更する
+// ghの実行ファイルはプロセス全体で共通のため、クライアントごとではなくパッケージ全体に適用する
+func SetCommandConfig(cfg CommandConfig) {
+	commandConfig = cfg
+}
+
+// tokenOverride はghコマンドにGH_TOKENとして渡すトークン（空の場合はghの認証情報をそのまま使う）
+var tokenOverride struct {
+	sync.RWMutex
+	token string
+}
+
+// SetToken はghコマンドに渡すトークンを変更する（以降に実行するghコマンドから適用される）
+// 空文字列を指定するとghの認証情報をそのまま使う
+func SetToken(token string) {
+	tokenOverride.Lock()
+	defer tokenOverride.Unlock()
+	tokenOverride.token = token
+}
+
+// currentToken はghコマンドに渡すトークンを返す
+func currentToken() string {
+	tokenOverride.RLock()
+	defer tokenOverride.RUnlock()
+	return tokenOverride.token
+}
+
+// AuthenticatedUser は現在のトークンで認証されたユーザーのログイン名を返す
+// トークンが無効な場合やGitHubに接続できない場合はエラーを返す
+func AuthenticatedUser(ctx context.Context) (string, error) {
+	output, err := execGHCommand(ctx, []string{"api", "user", "--jq", ".login"})
+	if err != nil {
+		return "", fmt.Errorf("failed to get authenticated user: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	login := strings.TrimSpace(string(output))
+	if login == "" {
+		return "", errors.New("failed to get authenticated user: empty login")
+	}
+	return login, nil
+}
+
+// ghPath はghの実行ファイルのパスを返す
+func ghPath() string {
+	if commandConfig.Path == "" {
+		return defaultGHPath
+	}
+	return commandConfig.Path
+}
+
+// execGHCommand はタイムアウトと追加の引数を適用してghコマンドを実行する
+// タイムアウトした場合はリトライ可能なErrorTypeNetworkTimeoutのエラーを返す
+func execGHCommand(ctx context.Context, args []string) ([]byte, error) {
+	cfg := commandConfig
+	if len(cfg.Args) > 0 {
+		args = append(args[:len(args):len(args)], cfg.Args...)
+	}
+	if cfg.Timeout <= 0 {
+		return runGHCommand(ctx, args...)
+	}
+
+	cmdCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	output, err := runGHCommand(cmdCtx, args...)
+	if err != nil && ctx.Err() == nil && errors.Is(cmdCtx.Err(), context.DeadlineExceeded) {
+		return output, &GitHubError{
+			Type:        ErrorTypeNetworkTimeout,
+			Message:     fmt.Sprintf("gh command timed out after %s", cfg.Timeout),
+			OriginalErr: err,
+		}
+	}
+	return output, err
+}
+
+// ghCommandEnv はghコマンドの環境変数を返す（変更がない場合はnilを返し、osobaの環境変数をそのまま引き継ぐ）
+// HTTPS_PROXY・NO_PROXYなどのプロキシの環境変数はそのまま引き継ぎ、github.ghで指定された場合のみ上書きする
+func ghCommandEnv() []string {
+	var env []string
+	if token := currentToken(); token != "" {
+		env = append(env, "GH_TOKEN="+token)
+	}
+	if host := commandConfig.Host; host != "" {
+		env = append(env, "GH_HOST="+host)
+	}
+	if proxy := commandConfig.Proxy; proxy != "" {
+		env = append(env, "HTTPS_PROXY="+proxy, "HTTP_PROXY="+proxy)
+	}
+	if noProxy := commandConfig.NoProxy; noProxy != "" {
+		env = append(env, "NO_PROXY="+noProxy)
+	}
+	if len(env) == 0 {
+		return nil
+	}
+	return append(os.Environ(), env...)
+}
+
+// Command はgithub.ghの実行ファイルと環境変数を適用したghコマンドを作成する
+// github.ghの追加の引数は付与しないため、osobaのAPI呼び出し以外（gh repo cloneなど）に使う
+func Command(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, ghPath(), args...)
+	cmd.Env = ghCommandEnv()
+	return cmd
+}
+
+// runGHCommand はghコマンドを実行する（テスト時に差し替え可能）
+var runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := Command(ctx, args...)
+	// ghが起動した子プロセスが出力を保持し続けても、キャンセル後は待ち続けない
+	cmd.WaitDelay = 5 * time.Second
+	done := trace.Start(ghPath(), args, "")
+	output, err := cmd.CombinedOutput()
+	done(err)
+	return output, err
+}
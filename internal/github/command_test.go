@@ -0,0 +1,150 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecGHCommand(t *testing.T) {
+	origRun := runGHCommand
+	origCfg := commandConfig
+	defer func() {
+		runGHCommand = origRun
+		commandConfig = origCfg
+	}()
+
+	t.Run("追加の引数を末尾に付与する", func(t *testing.T) {
+		var gotArgs []string
+		runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+			gotArgs = args
+			return []byte("ok"), nil
+		}
+		SetCommandConfig(CommandConfig{Args: []string{"--hostname", "ghe.example.com"}})
+
+		args := []string{"api", "user"}
+		output, err := execGHCommand(context.Background(), args)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", string(output))
+		assert.Equal(t, []string{"api", "user", "--hostname", "ghe.example.com"}, gotArgs)
+		assert.Equal(t, []string{"api", "user"}, args)
+	})
+
+	t.Run("タイムアウトした場合はリトライ可能なエラーを返す", func(t *testing.T) {
+		runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+			<-ctx.Done()
+			return nil, errors.New("signal: killed")
+		}
+		SetCommandConfig(CommandConfig{Timeout: 10 * time.Millisecond})
+
+		_, err := execGHCommand(context.Background(), []string{"issue", "list"})
+		var ghErr *GitHubError
+		require.ErrorAs(t, err, &ghErr)
+		assert.Equal(t, ErrorTypeNetworkTimeout, ghErr.Type)
+		assert.True(t, ghErr.IsRetryable())
+		assert.Contains(t, ghErr.Message, "timed out after 10ms")
+	})
+
+	t.Run("呼び出し元のキャンセルはタイムアウトとして扱わない", func(t *testing.T) {
+		runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		SetCommandConfig(CommandConfig{Timeout: time.Minute})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := execGHCommand(ctx, []string{"issue", "list"})
+		assert.ErrorIs(t, err, context.Canceled)
+		var ghErr *GitHubError
+		assert.False(t, errors.As(err, &ghErr))
+	})
+}
+
+func TestSetToken(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping shell script test on Windows")
+	}
+	origCfg := commandConfig
+	defer func() {
+		commandConfig = origCfg
+		SetToken("")
+	}()
+
+	// 受け取ったGH_TOKENを出力するghの代わりのスクリプト
+	script := filepath.Join(t.TempDir(), "gh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nprintf '%s' \"$GH_TOKEN\"\n"), 0755))
+	SetCommandConfig(CommandConfig{Path: script})
+	t.Setenv("GH_TOKEN", "old-token")
+
+	output, err := runGHCommand(context.Background(), "api", "user")
+	require.NoError(t, err)
+	assert.Equal(t, "old-token", string(output), "トークン未設定の場合は環境変数をそのまま使う")
+
+	SetToken("rotated-token")
+	output, err = runGHCommand(context.Background(), "api", "user")
+	require.NoError(t, err)
+	assert.Equal(t, "rotated-token", strings.TrimSpace(string(output)))
+}
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping shell script test on Windows")
+	}
+	origCfg := commandConfig
+	defer func() { commandConfig = origCfg }()
+
+	// 受け取った引数とGH_HOSTを出力するghの代わりのスクリプト
+	script := filepath.Join(t.TempDir(), "gh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nprintf '%s %s' \"$*\" \"$GH_HOST\"\n"), 0755))
+	SetCommandConfig(CommandConfig{Path: script, Args: []string{"--extra"}, Host: "ghe.example.com"})
+
+	output, err := Command(context.Background(), "repo", "clone", "owner/repo", "dir").CombinedOutput()
+	require.NoError(t, err)
+	assert.Equal(t, "repo clone owner/repo dir ghe.example.com", string(output), "github.gh.pathとhostを適用し、追加の引数は付与しない")
+}
+
+func TestAuthenticatedUser(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	tests := []struct {
+		name    string
+		output  string
+		err     error
+		want    string
+		wantErr string
+	}{
+		{name: "ログイン名を返す", output: "osoba-bot\n", want: "osoba-bot"},
+		{name: "認証に失敗した場合はghの出力を含める", output: "HTTP 401: Bad credentials", err: errors.New("exit status 1"), wantErr: "Bad credentials"},
+		{name: "ログイン名が空の場合", output: "\n", wantErr: "empty login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+				gotArgs = args
+				return []byte(tt.output), tt.err
+			}
+
+			login, err := AuthenticatedUser(context.Background())
+			assert.Equal(t, []string{"api", "user", "--jq", ".login"}, gotArgs)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, login)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// consolidatedSectionSeparator はまとめたコメントの各更新の区切り
+	consolidatedSectionSeparator = "\n\n---\n\n"
+	// maxConsolidatedCommentLength はまとめたコメントの最大文字数（GitHubのコメントの上限）
+	maxConsolidatedCommentLength = 65536
+	// markedCommentPrefix はosobaがマーカーで検索するコメントの先頭（まとめずに個別に投稿する）
+	markedCommentPrefix = "<!-- osoba:"
+)
+
+// issueCommentIDPattern は gh issue comment が出力するコメントのURLからIDを取り出す
+var issueCommentIDPattern = regexp.MustCompile(`#issuecomment-(\d+)`)
+
+// commentConsolidator は一定時間内のIssueへの自動コメントを1つのコメントにまとめる
+type commentConsolidator struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu       sync.Mutex
+	comments map[string]*consolidatedComment // "owner/repo#番号"ごとの現在のまとめ先
+}
+
+// consolidatedComment はまとめ先のコメント
+type consolidatedComment struct {
+	id       int64
+	openedAt time.Time
+	sections []string
+}
+
+// SetCommentConsolidation はwindow以内に同じIssueへ投稿する自動コメント（フェーズ遷移・エラーなど）を、
+// 最初のコメントを編集して1つにまとめるよう設定する（0以下で無効）
+// マーカー（<!-- osoba:...）で始まるコメントは、他の処理が検索・更新するためまとめずに投稿する
+func (c *GHClient) SetCommentConsolidation(window time.Duration) {
+	if window <= 0 {
+		c.comments = nil
+		return
+	}
+	c.comments = &commentConsolidator{
+		window:   window,
+		now:      time.Now,
+		comments: make(map[string]*consolidatedComment),
+	}
+}
+
+// postConsolidated はまとめ先のコメントに追記する（まとめ先がない場合は新しいコメントを作成する）
+func (c *GHClient) postConsolidated(ctx context.Context, owner, repo string, issueNumber int, comment string) error {
+	cc := c.comments
+	key := fmt.Sprintf("%s/%s#%d", owner, repo, issueNumber)
+
+	// 同じIssueへの投稿が並行して別々のコメントにならないよう、投稿が終わるまでロックする
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	now := cc.now()
+	if current, ok := cc.comments[key]; ok && now.Sub(current.openedAt) < cc.window {
+		sections := append(current.sections[:len(current.sections):len(current.sections)], comment)
+		body := strings.Join(sections, consolidatedSectionSeparator)
+		if len(body) <= maxConsolidatedCommentLength {
+			if err := c.UpdateIssueComment(ctx, owner, repo, current.id, body); err == nil {
+				current.sections = sections
+				return nil
+			} else if c.logger != nil {
+				c.logger.Warn("Failed to append to consolidated comment, posting a new comment",
+					"issue", issueNumber,
+					"comment_id", current.id,
+					"error", err)
+			}
+		}
+	}
+	delete(cc.comments, key)
+
+	output, err := c.createIssueComment(ctx, owner, repo, issueNumber, comment)
+	if err != nil {
+		return err
+	}
+	// コメントのIDを取得できない場合は、次の投稿も新しいコメントにする
+	if m := issueCommentIDPattern.FindStringSubmatch(string(output)); m != nil {
+		if id, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+			cc.comments[key] = &consolidatedComment{id: id, openedAt: now, sections: []string{comment}}
+		}
+	}
+	return nil
+}
+
+// consolidates はコメントをまとめる対象かを返す
+func (cc *commentConsolidator) consolidates(comment string) bool {
+	return cc != nil && !strings.HasPrefix(comment, markedCommentPrefix)
+}
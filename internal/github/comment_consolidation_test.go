@@ -0,0 +1,110 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// consolidationCall はghコマンドの呼び出しの記録
+type consolidationCall struct {
+	create bool
+	issue  string
+	body   string
+}
+
+func stubCommentCommands(t *testing.T, failUpdate bool) *[]consolidationCall {
+	t.Helper()
+	origRun := runGHCommand
+	t.Cleanup(func() { runGHCommand = origRun })
+
+	var calls []consolidationCall
+	nextID := 100
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		switch {
+		case args[0] == "issue" && args[1] == "comment":
+			calls = append(calls, consolidationCall{create: true, issue: args[2], body: args[len(args)-1]})
+			nextID++
+			return []byte("https://github.com/owner/repo/issues/" + args[2] + "#issuecomment-" + strconv.Itoa(nextID) + "\n"), nil
+		case args[0] == "api" && args[1] == "-X":
+			if failUpdate {
+				return []byte("HTTP 404: Not Found"), errors.New("exit status 1")
+			}
+			calls = append(calls, consolidationCall{issue: args[3], body: strings.TrimPrefix(args[len(args)-1], "body=")})
+			return []byte("{}"), nil
+		}
+		return nil, errors.New("unexpected command")
+	}
+	return &calls
+}
+
+func TestGHClient_CommentConsolidation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("期間内のコメントは最初のコメントに追記する", func(t *testing.T) {
+		calls := stubCommentCommands(t, false)
+		client := &GHClient{}
+		client.SetCommentConsolidation(10 * time.Minute)
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		client.comments.now = func() time.Time { return now }
+
+		require.NoError(t, client.CreateIssueComment(ctx, "owner", "repo", 5, "計画を開始しました"))
+		now = now.Add(3 * time.Minute)
+		require.NoError(t, client.CreateIssueComment(ctx, "owner", "repo", 5, "実装を開始しました"))
+		require.NoError(t, client.CreateIssueComment(ctx, "owner", "repo", 6, "計画を開始しました"))
+
+		require.Len(t, *calls, 3)
+		assert.True(t, (*calls)[0].create)
+		assert.False(t, (*calls)[1].create)
+		assert.Equal(t, "repos/owner/repo/issues/comments/101", (*calls)[1].issue)
+		assert.Equal(t, "計画を開始しました\n\n---\n\n実装を開始しました", (*calls)[1].body)
+		assert.True(t, (*calls)[2].create, "別のIssueのコメントはまとめない")
+
+		// 期間を過ぎた後は新しいコメントを作成する
+		now = now.Add(10 * time.Minute)
+		require.NoError(t, client.CreateIssueComment(ctx, "owner", "repo", 5, "レビューを開始しました"))
+		require.Len(t, *calls, 4)
+		assert.True(t, (*calls)[3].create)
+		assert.Equal(t, "レビューを開始しました", (*calls)[3].body)
+	})
+
+	t.Run("マーカー付きのコメントはまとめない", func(t *testing.T) {
+		calls := stubCommentCommands(t, false)
+		client := &GHClient{}
+		client.SetCommentConsolidation(10 * time.Minute)
+
+		require.NoError(t, client.CreateIssueComment(ctx, "owner", "repo", 5, "計画を開始しました"))
+		require.NoError(t, client.CreateIssueComment(ctx, "owner", "repo", 5, "<!-- osoba:progress -->\n進捗"))
+		require.Len(t, *calls, 2)
+		assert.True(t, (*calls)[1].create)
+	})
+
+	t.Run("追記できない場合は新しいコメントを作成する", func(t *testing.T) {
+		calls := stubCommentCommands(t, true)
+		client := &GHClient{}
+		client.SetCommentConsolidation(10 * time.Minute)
+
+		require.NoError(t, client.CreateIssueComment(ctx, "owner", "repo", 5, "計画を開始しました"))
+		require.NoError(t, client.CreateIssueComment(ctx, "owner", "repo", 5, "実装を開始しました"))
+		require.Len(t, *calls, 2)
+		assert.True(t, (*calls)[1].create)
+		assert.Equal(t, "実装を開始しました", (*calls)[1].body)
+	})
+
+	t.Run("無効の場合は毎回新しいコメントを作成する", func(t *testing.T) {
+		calls := stubCommentCommands(t, false)
+		client := &GHClient{}
+		client.SetCommentConsolidation(0)
+
+		require.NoError(t, client.CreateIssueComment(ctx, "owner", "repo", 5, "計画を開始しました"))
+		require.NoError(t, client.CreateIssueComment(ctx, "owner", "repo", 5, "実装を開始しました"))
+		require.Len(t, *calls, 2)
+		assert.True(t, (*calls)[1].create)
+	})
+}
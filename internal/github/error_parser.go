@@ -10,10 +10,10 @@ import (
 
 var (
 	// Regular expressions for parsing gh command errors
-	rateLimitRegex   = regexp.MustCompile(`(?i)(rate limit|API rate limit exceeded|You have exceeded a secondary rate limit)`)
+	rateLimitRegex   = regexp.MustCompile(`(?i)(rate limit|API rate limit exceeded|You have exceeded a secondary rate limit|abuse detection|was submitted too quickly)`)
 	notFoundRegex    = regexp.MustCompile(`(?i)(not found|could not resolve to|does not have the label)`)
 	authRegex        = regexp.MustCompile(`(?i)(authentication|unauthorized|bad credentials|requires authentication|owner is required)`)
-	networkRegex     = regexp.MustCompile(`(?i)(timeout|connection refused|network|dial tcp)`)
+	networkRegex     = regexp.MustCompile(`(?i)(timeout|connection refused|network|dial tcp|error connecting to|no such host)`)
 	serverErrorRegex = regexp.MustCompile(`(?i)(internal server error|server error|502|503|504)`)
 	httpStatusRegex  = regexp.MustCompile(`HTTP (\d{3})`)
 	retryAfterRegex  = regexp.MustCompile(`(?i)retry.?after:\s*(\d+)`)
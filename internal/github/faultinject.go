@@ -0,0 +1,36 @@
+package github
+
+import (
+	"context"
+
+	"github.com/douhashi/osoba/internal/faultinject"
+)
+
+// injectedFaultOutput は注入した失敗のghコマンドの出力（リトライ可能なサーバーエラーとして扱われる）
+const injectedFaultOutput = "HTTP 502: Bad Gateway (fault injection)"
+
+// SetFaultInjector はghコマンドの実行に障害を注入するInjectorを設定する（nilで無効）
+// ラベルの遷移・作成を行うLabelManagerの操作にも注入する
+func (c *GHClient) SetFaultInjector(injector *faultinject.Injector) {
+	c.faultInjector = injector
+	if lm, ok := c.labelManager.(interface {
+		SetFaultInjector(*faultinject.Injector)
+	}); ok {
+		lm.SetFaultInjector(injector)
+	}
+}
+
+// SetFaultInjector はghコマンドの実行に障害を注入するInjectorを設定する（nilで無効）
+func (lm *GHLabelManager) SetFaultInjector(injector *faultinject.Injector) {
+	lm.faultInjector = injector
+}
+
+// runGHCommandWithFaults は障害を注入したうえでghコマンドを実行する
+// 失敗を注入した場合はghを実行せず、injectedを true にして返す
+func runGHCommandWithFaults(ctx context.Context, injector *faultinject.Injector, args []string) (output []byte, injected bool, err error) {
+	if err := injector.Inject(faultinject.TargetGH, args); err != nil {
+		return []byte(injectedFaultOutput), true, err
+	}
+	output, err = execGHCommand(ctx, args)
+	return output, false, err
+}
@@ -0,0 +1,38 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/faultinject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_SetFaultInjector(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+	called := 0
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		called++
+		return []byte("ok"), nil
+	}
+
+	injector, err := faultinject.Parse("fail=1,targets=gh")
+	require.NoError(t, err)
+
+	client := &GHClient{labelManager: NewGHLabelManager(nil, 0, 0)}
+	client.SetFaultInjector(injector)
+	assert.Same(t, injector, client.labelManager.(*GHLabelManager).faultInjector)
+
+	_, err = client.executeGHCommand(context.Background(), "issue", "list")
+	require.Error(t, err)
+	assert.Equal(t, 0, called)
+	assert.True(t, errors.Is(err, faultinject.ErrInjected))
+
+	_, err = client.labelManager.(*GHLabelManager).executeGHCommand(context.Background(), "label", "list")
+	var ghErr *GitHubError
+	require.True(t, errors.As(err, &ghErr))
+	assert.True(t, ghErr.IsRetryable())
+}
@@ -0,0 +1,61 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ForcePush はPRのブランチへのforce-push（履歴の書き換え）の記録
+type ForcePush struct {
+	Actor     string    // force-pushしたユーザー
+	CreatedAt time.Time // force-pushした日時
+}
+
+// ForcePushReader はPRのブランチへのforce-pushの一覧を取得する（履歴の書き換えを検出するHistoryGuardが使う）
+type ForcePushReader interface {
+	ListForcePushes(ctx context.Context, owner, repo string, prNumber int) ([]ForcePush, error)
+}
+
+var _ ForcePushReader = (*GHClient)(nil)
+
+// ListForcePushes はPRのイベント（head_ref_force_pushed）からforce-pushの一覧を古い順に返す
+func (c *GHClient) ListForcePushes(ctx context.Context, owner, repo string, prNumber int) ([]ForcePush, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if prNumber <= 0 {
+		return nil, errors.New("pull request number must be positive")
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/events", owner, repo, prNumber)
+	jq := `.[] | select(.event == "head_ref_force_pushed") | [.created_at, (.actor.login // "")] | @tsv`
+	output, err := c.executeGHCommand(ctx, "api", endpoint, "--paginate", "--jq", jq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request events: %w", err)
+	}
+
+	var pushes []ForcePush
+	for _, line := range strings.Split(string(bytes.TrimSpace(output)), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 2)
+		createdAt, err := time.Parse(time.RFC3339, strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse force-push event time %q: %w", fields[0], err)
+		}
+		push := ForcePush{CreatedAt: createdAt}
+		if len(fields) == 2 {
+			push.Actor = strings.TrimSpace(fields[1])
+		}
+		pushes = append(pushes, push)
+	}
+	return pushes, nil
+}
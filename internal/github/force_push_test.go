@@ -0,0 +1,47 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_ListForcePushes(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	tests := []struct {
+		name   string
+		output string
+		want   []ForcePush
+	}{
+		{
+			name:   "force-pushの記録",
+			output: "2024-05-01T10:00:00Z\tosoba-bot\n2024-05-02T09:30:00Z\t\n",
+			want: []ForcePush{
+				{Actor: "osoba-bot", CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+				{CreatedAt: time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC)},
+			},
+		},
+		{name: "force-pushされていない", output: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+				gotArgs = args
+				return []byte(tt.output), nil
+			}
+
+			client := &GHClient{}
+			got, err := client.ListForcePushes(context.Background(), "owner", "repo", 12)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Contains(t, gotArgs, "repos/owner/repo/issues/12/events")
+		})
+	}
+}
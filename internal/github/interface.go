@@ -5,6 +5,8 @@ import (
 )
 
 // GitHubClient はGitHub APIクライアントのインターフェース
+// 一部の機能でのみ必要な操作は別のインターフェース（IssueCommentEditorなど）に分けてGHClientに実装する
+// 利用する側はGitHubClientを型アサーションし、対応していないクライアントでは機能を無効にするかエラーを返す
 type GitHubClient interface {
 	GetRepository(ctx context.Context, owner, repo string) (*Repository, error)
 	ListIssuesByLabels(ctx context.Context, owner, repo string, labels []string) ([]*Issue, error)
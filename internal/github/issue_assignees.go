@@ -0,0 +1,48 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// IssueAssigner はIssueの担当者を追加・削除する（フェーズの実行中にosobaをアサインするAssignerが使う）
+type IssueAssigner interface {
+	AddIssueAssignee(ctx context.Context, owner, repo string, issueNumber int, login string) error
+	RemoveIssueAssignee(ctx context.Context, owner, repo string, issueNumber int, login string) error
+}
+
+var _ IssueAssigner = (*GHClient)(nil)
+
+// AddIssueAssignee はIssueに担当者を追加する（@meは認証しているユーザー）
+func (c *GHClient) AddIssueAssignee(ctx context.Context, owner, repo string, issueNumber int, login string) error {
+	return c.editIssueAssignee(ctx, owner, repo, issueNumber, "--add-assignee", login)
+}
+
+// RemoveIssueAssignee はIssueから担当者を削除する（@meは認証しているユーザー）
+func (c *GHClient) RemoveIssueAssignee(ctx context.Context, owner, repo string, issueNumber int, login string) error {
+	return c.editIssueAssignee(ctx, owner, repo, issueNumber, "--remove-assignee", login)
+}
+
+func (c *GHClient) editIssueAssignee(ctx context.Context, owner, repo string, issueNumber int, flag, login string) error {
+	if owner == "" {
+		return errors.New("owner is required")
+	}
+	if repo == "" {
+		return errors.New("repo is required")
+	}
+	if issueNumber <= 0 {
+		return errors.New("issue number must be positive")
+	}
+	if login == "" {
+		return errors.New("assignee is required")
+	}
+
+	if _, err := c.executeGHCommand(ctx, "issue", "edit", strconv.Itoa(issueNumber),
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		flag, login); err != nil {
+		return fmt.Errorf("failed to edit issue assignee: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_IssueAssignee(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	tests := []struct {
+		name     string
+		edit     func(c *GHClient) error
+		wantArgs []string
+		wantErr  string
+	}{
+		{
+			name: "担当者を追加",
+			edit: func(c *GHClient) error {
+				return c.AddIssueAssignee(context.Background(), "owner", "repo", 42, "osoba-bot")
+			},
+			wantArgs: []string{"issue", "edit", "42", "--repo", "owner/repo", "--add-assignee", "osoba-bot"},
+		},
+		{
+			name: "担当者を削除",
+			edit: func(c *GHClient) error {
+				return c.RemoveIssueAssignee(context.Background(), "owner", "repo", 42, "@me")
+			},
+			wantArgs: []string{"issue", "edit", "42", "--repo", "owner/repo", "--remove-assignee", "@me"},
+		},
+		{
+			name: "担当者の指定なし",
+			edit: func(c *GHClient) error {
+				return c.AddIssueAssignee(context.Background(), "owner", "repo", 42, "")
+			},
+			wantErr: "assignee is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+				gotArgs = args
+				return nil, nil
+			}
+
+			err := tt.edit(&GHClient{})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, gotArgs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestConvertMapToIssue_Assignees(t *testing.T) {
+	var issueMap map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"number":42,"assignees":[{"login":"osoba-bot"},{"login":"alice"}]}`), &issueMap))
+
+	issue, err := convertMapToIssue(issueMap)
+	require.NoError(t, err)
+	require.Len(t, issue.Assignees, 2)
+	assert.Equal(t, "osoba-bot", *issue.Assignees[0].Login)
+	assert.Equal(t, "alice", *issue.Assignees[1].Login)
+}
@@ -0,0 +1,153 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// issueContentCache はポーリングで取得したIssueの更新日時を基準に、Issueのコメント一覧を再利用する
+// 同じポーリングの周期で複数の処理（重複の検出・計画の承認の確認など）が同じIssueのコメントを取得する場合に、
+// ghコマンドの実行を1回にまとめる。Issueの更新日時が変わるか、osobaがコメントを投稿・編集した場合は取得し直す
+type issueContentCache struct {
+	mu        sync.Mutex
+	updatedAt map[string]time.Time            // "owner/repo#番号"ごとに最後のポーリングで取得した更新日時
+	comments  map[string]*cachedIssueComments // "owner/repo#番号"ごとのコメント一覧
+	owners    map[int64]string                // コメントのIDごとのキャッシュのキー
+}
+
+// cachedIssueComments はキャッシュしたコメント一覧
+type cachedIssueComments struct {
+	updatedAt time.Time // 取得した時点のIssueの更新日時
+	comments  []*IssueComment
+}
+
+// SetIssueContentCache はIssueのコメント一覧をIssueの更新日時が変わるまで再利用するよう設定する
+func (c *GHClient) SetIssueContentCache(enabled bool) {
+	if !enabled {
+		c.issueCache = nil
+		return
+	}
+	c.issueCache = &issueContentCache{
+		updatedAt: make(map[string]time.Time),
+		comments:  make(map[string]*cachedIssueComments),
+		owners:    make(map[int64]string),
+	}
+}
+
+func issueCacheKey(owner, repo string, issueNumber int) string {
+	return fmt.Sprintf("%s/%s#%d", owner, repo, issueNumber)
+}
+
+// observe はポーリングで取得したIssueの更新日時を記録する
+func (ic *issueContentCache) observe(owner, repo string, issue *Issue) {
+	if ic == nil || issue == nil || issue.Number == nil || issue.UpdatedAt == nil {
+		return
+	}
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	ic.updatedAt[issueCacheKey(owner, repo, *issue.Number)] = *issue.UpdatedAt
+}
+
+// forgetUnlisted はポーリングで取得できなかったIssue（クローズ・取得件数の上限の外）の記録を破棄する
+// 古い更新日時のままキャッシュを使い続けないよう、次に取得できるまで毎回コメントを取得し直す
+func (ic *issueContentCache) forgetUnlisted(owner, repo string, listed map[int]bool) {
+	if ic == nil {
+		return
+	}
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	prefix := fmt.Sprintf("%s/%s#", owner, repo)
+	keep := make(map[string]bool, len(listed))
+	for number := range listed {
+		keep[issueCacheKey(owner, repo, number)] = true
+	}
+	for key := range ic.updatedAt {
+		if strings.HasPrefix(key, prefix) && !keep[key] {
+			delete(ic.updatedAt, key)
+			delete(ic.comments, key)
+		}
+	}
+	for id, key := range ic.owners {
+		if _, ok := ic.comments[key]; !ok {
+			delete(ic.owners, id)
+		}
+	}
+}
+
+// lookup はIssueの更新日時が変わっていない場合にキャッシュしたコメント一覧を返す
+func (ic *issueContentCache) lookup(owner, repo string, issueNumber int) ([]*IssueComment, bool) {
+	if ic == nil {
+		return nil, false
+	}
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	key := issueCacheKey(owner, repo, issueNumber)
+	cached, ok := ic.comments[key]
+	if !ok {
+		return nil, false
+	}
+	if updatedAt, known := ic.updatedAt[key]; !known || !updatedAt.Equal(cached.updatedAt) {
+		return nil, false
+	}
+	return copyIssueComments(cached.comments), true
+}
+
+// store は取得したコメント一覧を記録する（ポーリングで更新日時を取得していないIssueは記録しない）
+func (ic *issueContentCache) store(owner, repo string, issueNumber int, comments []*IssueComment) {
+	if ic == nil {
+		return
+	}
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	key := issueCacheKey(owner, repo, issueNumber)
+	updatedAt, known := ic.updatedAt[key]
+	if !known {
+		return
+	}
+	ic.comments[key] = &cachedIssueComments{updatedAt: updatedAt, comments: copyIssueComments(comments)}
+	for _, comment := range comments {
+		if comment != nil && comment.ID != nil {
+			ic.owners[*comment.ID] = key
+		}
+	}
+}
+
+// invalidate はIssueにコメントを投稿した後に、キャッシュしたコメント一覧を破棄する
+func (ic *issueContentCache) invalidate(owner, repo string, issueNumber int) {
+	if ic == nil {
+		return
+	}
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	delete(ic.comments, issueCacheKey(owner, repo, issueNumber))
+}
+
+// invalidateComment はコメントを編集した後に、そのコメントを含むコメント一覧を破棄する
+func (ic *issueContentCache) invalidateComment(commentID int64) {
+	if ic == nil {
+		return
+	}
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	if key, ok := ic.owners[commentID]; ok {
+		delete(ic.comments, key)
+		delete(ic.owners, commentID)
+	}
+}
+
+// copyIssueComments は呼び出し元の変更がキャッシュに影響しないようコメント一覧を複製する
+func copyIssueComments(comments []*IssueComment) []*IssueComment {
+	if comments == nil {
+		return nil
+	}
+	copied := make([]*IssueComment, len(comments))
+	for i, comment := range comments {
+		if comment != nil {
+			c := *comment
+			copied[i] = &c
+		}
+	}
+	return copied
+}
@@ -0,0 +1,129 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIssueCommands はIssue一覧の取得・コメントの取得・投稿・編集に応答し、コメントの取得回数を返す
+func stubIssueCommands(t *testing.T, updatedAt *string) *int {
+	t.Helper()
+	origRun := runGHCommand
+	t.Cleanup(func() { runGHCommand = origRun })
+
+	fetches := 0
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		switch {
+		case args[0] == "issue" && args[1] == "list":
+			return []byte(`[{"number": 5, "title": "Issue", "state": "OPEN", "updatedAt": "` + *updatedAt + `", "labels": []}]`), nil
+		case args[0] == "issue" && args[1] == "comment":
+			return []byte("https://github.com/owner/repo/issues/5#issuecomment-200\n"), nil
+		case args[0] == "api" && args[1] == "-X":
+			return []byte("{}"), nil
+		case args[0] == "api":
+			fetches++
+			return []byte(`{"id": 100, "body": "計画"}` + "\n"), nil
+		}
+		return nil, errors.New("unexpected command")
+	}
+	return &fetches
+}
+
+func TestGHClient_IssueContentCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("更新日時が変わるまでコメント一覧を再利用する", func(t *testing.T) {
+		updatedAt := "2024-05-01T12:00:00Z"
+		fetches := stubIssueCommands(t, &updatedAt)
+		client := &GHClient{}
+		client.SetIssueContentCache(true)
+
+		_, err := client.ListIssuesByLabels(ctx, "owner", "repo", nil)
+		require.NoError(t, err)
+		comments, err := client.ListIssueComments(ctx, "owner", "repo", 5)
+		require.NoError(t, err)
+		require.Len(t, comments, 1)
+		comments[0].Body = String("呼び出し元の変更")
+		comments, err = client.ListIssueComments(ctx, "owner", "repo", 5)
+		require.NoError(t, err)
+		assert.Equal(t, 1, *fetches)
+		assert.Equal(t, "計画", *comments[0].Body, "呼び出し元の変更はキャッシュに影響しない")
+
+		// 次のポーリングで更新日時が変わった場合は取得し直す
+		updatedAt = "2024-05-01T12:05:00Z"
+		_, err = client.ListIssuesByLabels(ctx, "owner", "repo", nil)
+		require.NoError(t, err)
+		_, err = client.ListIssueComments(ctx, "owner", "repo", 5)
+		require.NoError(t, err)
+		assert.Equal(t, 2, *fetches)
+	})
+
+	t.Run("コメントの投稿・編集の後は取得し直す", func(t *testing.T) {
+		updatedAt := "2024-05-01T12:00:00Z"
+		fetches := stubIssueCommands(t, &updatedAt)
+		client := &GHClient{}
+		client.SetIssueContentCache(true)
+
+		_, err := client.ListIssuesByLabels(ctx, "owner", "repo", nil)
+		require.NoError(t, err)
+		_, err = client.ListIssueComments(ctx, "owner", "repo", 5)
+		require.NoError(t, err)
+		require.NoError(t, client.CreateIssueComment(ctx, "owner", "repo", 5, "実装を開始しました"))
+		_, err = client.ListIssueComments(ctx, "owner", "repo", 5)
+		require.NoError(t, err)
+		assert.Equal(t, 2, *fetches)
+
+		require.NoError(t, client.UpdateIssueComment(ctx, "owner", "repo", 100, "計画（更新）"))
+		_, err = client.ListIssueComments(ctx, "owner", "repo", 5)
+		require.NoError(t, err)
+		assert.Equal(t, 3, *fetches)
+	})
+
+	t.Run("一覧に含まれなくなったIssueは取得し直す", func(t *testing.T) {
+		updatedAt := "2024-05-01T12:00:00Z"
+		fetches := stubIssueCommands(t, &updatedAt)
+		client := &GHClient{}
+		client.SetIssueContentCache(true)
+
+		_, err := client.ListIssuesByLabels(ctx, "owner", "repo", nil)
+		require.NoError(t, err)
+		_, err = client.ListIssueComments(ctx, "owner", "repo", 5)
+		require.NoError(t, err)
+
+		// 取得件数の上限の外に出た場合など、次のポーリングの一覧に含まれなかった
+		listIssues := runGHCommand
+		runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+			if args[0] == "issue" && args[1] == "list" {
+				return []byte(`[]`), nil
+			}
+			return listIssues(ctx, args...)
+		}
+		_, err = client.ListIssuesByLabels(ctx, "owner", "repo", nil)
+		require.NoError(t, err)
+		_, _ = client.ListIssueComments(ctx, "owner", "repo", 5)
+		_, _ = client.ListIssueComments(ctx, "owner", "repo", 5)
+		assert.Equal(t, 3, *fetches)
+	})
+
+	t.Run("ポーリングで取得していないIssueと無効の場合は毎回取得する", func(t *testing.T) {
+		updatedAt := "2024-05-01T12:00:00Z"
+		fetches := stubIssueCommands(t, &updatedAt)
+		client := &GHClient{}
+		client.SetIssueContentCache(true)
+
+		_, _ = client.ListIssueComments(ctx, "owner", "repo", 5)
+		_, _ = client.ListIssueComments(ctx, "owner", "repo", 5)
+		assert.Equal(t, 2, *fetches)
+
+		client.SetIssueContentCache(false)
+		_, err := client.ListIssuesByLabels(ctx, "owner", "repo", nil)
+		require.NoError(t, err)
+		_, _ = client.ListIssueComments(ctx, "owner", "repo", 5)
+		_, _ = client.ListIssueComments(ctx, "owner", "repo", 5)
+		assert.Equal(t, 4, *fetches)
+	})
+}
@@ -0,0 +1,91 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// IssueCommentEditor はIssueのコメントを取得・更新する（進捗コメントの更新や、マーカーのコメントによる処理済みの判定に使う）
+type IssueCommentEditor interface {
+	ListIssueComments(ctx context.Context, owner, repo string, issueNumber int) ([]*IssueComment, error)
+	UpdateIssueComment(ctx context.Context, owner, repo string, commentID int64, body string) error
+}
+
+var _ IssueCommentEditor = (*GHClient)(nil)
+
+// ListIssueComments はIssueのコメント一覧を取得する
+func (c *GHClient) ListIssueComments(ctx context.Context, owner, repo string, issueNumber int) ([]*IssueComment, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+
+	if comments, ok := c.issueCache.lookup(owner, repo, issueNumber); ok {
+		return comments, nil
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/comments", owner, repo, issueNumber)
+	output, err := c.executeGHCommand(ctx, "api", endpoint, "--paginate", "--jq", ".[]")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue comments: %w", err)
+	}
+
+	comments, err := parseIssueComments(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse issue comments: %w", err)
+	}
+	c.issueCache.store(owner, repo, issueNumber, comments)
+	return comments, nil
+}
+
+// UpdateIssueComment は既存のIssueコメントを更新する
+func (c *GHClient) UpdateIssueComment(ctx context.Context, owner, repo string, commentID int64, body string) error {
+	if owner == "" {
+		return errors.New("owner is required")
+	}
+	if repo == "" {
+		return errors.New("repo is required")
+	}
+	if body == "" {
+		return errors.New("comment is required")
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/comments/%s", owner, repo, strconv.FormatInt(commentID, 10))
+	if _, err := c.executeGHCommand(ctx, "api", "-X", "PATCH", endpoint, "-f", "body="+body); err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+	c.issueCache.invalidateComment(commentID)
+
+	if c.logger != nil {
+		c.logger.Debug("Updated issue comment",
+			"owner", owner,
+			"repo", repo,
+			"comment_id", commentID,
+		)
+	}
+	return nil
+}
+
+// parseIssueComments は1行1オブジェクト形式のJSON出力をパースする
+func parseIssueComments(output []byte) ([]*IssueComment, error) {
+	var comments []*IssueComment
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for {
+		var comment IssueComment
+		if err := decoder.Decode(&comment); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		comments = append(comments, &comment)
+	}
+	return comments, nil
+}
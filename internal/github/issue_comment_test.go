@@ -0,0 +1,43 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIssueComments(t *testing.T) {
+	output := []byte(`{"id":1,"body":"first","user":{"login":"alice"}}
+{"id":2,"body":"<!-- osoba:progress -->\nprogress","user":{"login":"osoba-bot"}}
+`)
+
+	comments, err := parseIssueComments(output)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, int64(1), *comments[0].ID)
+	assert.Equal(t, "first", *comments[0].Body)
+	assert.Equal(t, "osoba-bot", *comments[1].User.Login)
+
+	empty, err := parseIssueComments([]byte(""))
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	_, err = parseIssueComments([]byte(`{"id":`))
+	assert.Error(t, err)
+}
+
+func TestGHClient_IssueCommentEditor_Validation(t *testing.T) {
+	client := &GHClient{}
+	ctx := context.Background()
+
+	_, err := client.ListIssueComments(ctx, "", "repo", 1)
+	assert.EqualError(t, err, "owner is required")
+	_, err = client.ListIssueComments(ctx, "owner", "", 1)
+	assert.EqualError(t, err, "repo is required")
+
+	assert.EqualError(t, client.UpdateIssueComment(ctx, "", "repo", 1, "body"), "owner is required")
+	assert.EqualError(t, client.UpdateIssueComment(ctx, "owner", "", 1, "body"), "repo is required")
+	assert.EqualError(t, client.UpdateIssueComment(ctx, "owner", "repo", 1, ""), "comment is required")
+}
@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IssueCreator はIssueを作成し、その状態を取得する（サブIssueの作成と作業キューの取り込みに使う）
+type IssueCreator interface {
+	CreateIssue(ctx context.Context, owner, repo, title, body string, labels []string) (int, error)
+	GetIssueState(ctx context.Context, owner, repo string, issueNumber int) (string, error)
+}
+
+var _ IssueCreator = (*GHClient)(nil)
+
+// CreateIssue はIssueを作成し、作成したIssue番号を返す
+func (c *GHClient) CreateIssue(ctx context.Context, owner, repo, title, body string, labels []string) (int, error) {
+	if owner == "" {
+		return 0, errors.New("owner is required")
+	}
+	if repo == "" {
+		return 0, errors.New("repo is required")
+	}
+	if title == "" {
+		return 0, errors.New("title is required")
+	}
+
+	args := []string{"issue", "create", "--repo", fmt.Sprintf("%s/%s", owner, repo), "--title", title, "--body", body}
+	for _, label := range labels {
+		args = append(args, "--label", label)
+	}
+
+	output, err := c.executeGHCommand(ctx, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	number, err := parseCreatedIssueNumber(string(output))
+	if err != nil {
+		return 0, err
+	}
+
+	if c.logger != nil {
+		c.logger.Debug("Created issue",
+			"owner", owner,
+			"repo", repo,
+			"issue", number,
+		)
+	}
+	return number, nil
+}
+
+// GetIssueState はIssueの状態（OPEN / CLOSED）を返す
+func (c *GHClient) GetIssueState(ctx context.Context, owner, repo string, issueNumber int) (string, error) {
+	if owner == "" {
+		return "", errors.New("owner is required")
+	}
+	if repo == "" {
+		return "", errors.New("repo is required")
+	}
+
+	output, err := c.executeGHCommand(ctx, "issue", "view", strconv.Itoa(issueNumber),
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--json", "state", "--jq", ".state")
+	if err != nil {
+		return "", fmt.Errorf("failed to get issue state: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// IssueCloser はコメントを付けてIssueをクローズする（PRのマージ後にIssueのクローズを確認するIssueClosureVerifierが使う）
+type IssueCloser interface {
+	CloseIssue(ctx context.Context, owner, repo string, issueNumber int, comment string) error
+}
+
+var _ IssueCloser = (*GHClient)(nil)
+
+// CloseIssue はIssueをクローズする（commentが空でない場合はクローズ時にコメントを投稿する）
+func (c *GHClient) CloseIssue(ctx context.Context, owner, repo string, issueNumber int, comment string) error {
+	if owner == "" {
+		return errors.New("owner is required")
+	}
+	if repo == "" {
+		return errors.New("repo is required")
+	}
+
+	args := []string{"issue", "close", strconv.Itoa(issueNumber), "--repo", fmt.Sprintf("%s/%s", owner, repo)}
+	if comment != "" {
+		args = append(args, "--comment", comment)
+	}
+	if _, err := c.executeGHCommand(ctx, args...); err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+
+	if c.logger != nil {
+		c.logger.Debug("Closed issue",
+			"owner", owner,
+			"repo", repo,
+			"issue", issueNumber,
+		)
+	}
+	return nil
+}
+
+// parseCreatedIssueNumber は`gh issue create`が出力するIssueのURLからIssue番号を取り出す
+func parseCreatedIssueNumber(output string) (int, error) {
+	url := strings.TrimSpace(output)
+	if i := strings.LastIndex(url, "\n"); i >= 0 {
+		url = url[i+1:]
+	}
+	idx := strings.LastIndex(url, "/issues/")
+	if idx < 0 {
+		return 0, fmt.Errorf("unexpected output from gh issue create: %q", output)
+	}
+	number, err := strconv.Atoi(url[idx+len("/issues/"):])
+	if err != nil {
+		return 0, fmt.Errorf("unexpected output from gh issue create: %q", output)
+	}
+	return number, nil
+}
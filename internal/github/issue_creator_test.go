@@ -0,0 +1,91 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreatedIssueNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:   "IssueのURL",
+			output: "https://github.com/douhashi/osoba/issues/123\n",
+			want:   123,
+		},
+		{
+			name:   "警告の後にURL",
+			output: "Warning: 1 uncommitted change\nhttps://github.com/douhashi/osoba/issues/7\n",
+			want:   7,
+		},
+		{
+			name:    "URL以外",
+			output:  "something went wrong",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCreatedIssueNumber(tt.output)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGHClient_CreateIssue(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte("https://github.com/owner/repo/issues/42\n"), nil
+	}
+
+	client := &GHClient{}
+	number, err := client.CreateIssue(context.Background(), "owner", "repo", "Child task", "Part of #1", []string{"status:needs-plan"})
+	require.NoError(t, err)
+	assert.Equal(t, 42, number)
+	assert.Equal(t, []string{
+		"issue", "create", "--repo", "owner/repo",
+		"--title", "Child task", "--body", "Part of #1",
+		"--label", "status:needs-plan",
+	}, gotArgs)
+
+	_, err = client.CreateIssue(context.Background(), "owner", "repo", "", "body", nil)
+	assert.EqualError(t, err, "title is required")
+}
+
+func TestGHClient_CloseIssue(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return nil, nil
+	}
+
+	client := &GHClient{}
+	require.NoError(t, client.CloseIssue(context.Background(), "owner", "repo", 42, "Merged in #43"))
+	assert.Equal(t, []string{
+		"issue", "close", "42", "--repo", "owner/repo",
+		"--comment", "Merged in #43",
+	}, gotArgs)
+
+	require.NoError(t, client.CloseIssue(context.Background(), "owner", "repo", 42, ""))
+	assert.Equal(t, []string{"issue", "close", "42", "--repo", "owner/repo"}, gotArgs)
+}
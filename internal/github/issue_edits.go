@@ -0,0 +1,58 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// issueLastEditedAtQuery はIssue本文の最終編集日時を取得するGraphQLクエリ
+const issueLastEditedAtQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    issue(number: $number) { lastEditedAt }
+  }
+}`
+
+// IssueEditTimeReader はIssue本文の最終編集日時を取得する（計画後のIssueの編集を検出するPlanStalenessDetectorが使う）
+type IssueEditTimeReader interface {
+	GetIssueLastEditedAt(ctx context.Context, owner, repo string, issueNumber int) (*time.Time, error)
+}
+
+var _ IssueEditTimeReader = (*GHClient)(nil)
+
+// GetIssueLastEditedAt はIssue本文の最終編集日時を返す（作成後に編集されていない場合はnil）
+// updatedAtはコメントやラベルの変更でも更新されるため、本文の編集の判定にはlastEditedAtを使う
+func (c *GHClient) GetIssueLastEditedAt(ctx context.Context, owner, repo string, issueNumber int) (*time.Time, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if issueNumber <= 0 {
+		return nil, errors.New("issue number must be positive")
+	}
+
+	output, err := c.executeGHCommand(ctx, "api", "graphql",
+		"-f", "query="+issueLastEditedAtQuery,
+		"-f", "owner="+owner,
+		"-f", "name="+repo,
+		"-F", "number="+strconv.Itoa(issueNumber),
+		"--jq", ".data.repository.issue.lastEditedAt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue last edited time: %w", err)
+	}
+
+	value := string(bytes.TrimSpace(output))
+	if value == "" || value == "null" {
+		return nil, nil
+	}
+	editedAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse issue last edited time %q: %w", value, err)
+	}
+	return &editedAt, nil
+}
@@ -0,0 +1,42 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_GetIssueLastEditedAt(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	editedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		output string
+		want   *time.Time
+	}{
+		{name: "編集されている", output: "2024-05-01T10:00:00Z\n", want: &editedAt},
+		{name: "編集されていない", output: "null\n", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+				gotArgs = args
+				return []byte(tt.output), nil
+			}
+
+			client := &GHClient{}
+			got, err := client.GetIssueLastEditedAt(context.Background(), "owner", "repo", 12)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Contains(t, gotArgs, "number=12")
+			assert.Contains(t, gotArgs, "owner=owner")
+		})
+	}
+}
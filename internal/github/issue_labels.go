@@ -0,0 +1,42 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// IssueLabelReader は単一Issueのラベルを取得する（カナリアの対象かを判定するために使う）
+type IssueLabelReader interface {
+	GetIssueLabels(ctx context.Context, owner, repo string, issueNumber int) ([]string, error)
+}
+
+var _ IssueLabelReader = (*GHClient)(nil)
+
+// GetIssueLabels はIssueに付与されているラベル名を返す
+func (c *GHClient) GetIssueLabels(ctx context.Context, owner, repo string, issueNumber int) ([]string, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if issueNumber <= 0 {
+		return nil, errors.New("issue number must be positive")
+	}
+
+	output, err := c.executeGHCommand(ctx, "issue", "view", strconv.Itoa(issueNumber),
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--json", "labels", "--jq", "[.labels[].name]")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue labels: %w", err)
+	}
+
+	var labels []string
+	if err := json.Unmarshal(output, &labels); err != nil {
+		return nil, fmt.Errorf("failed to parse issue labels: %w", err)
+	}
+	return labels, nil
+}
@@ -0,0 +1,32 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_GetIssueLabels(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte("[\"status:implementing\",\"status:manual\"]\n"), nil
+	}
+
+	client := &GHClient{}
+	labels, err := client.GetIssueLabels(context.Background(), "owner", "repo", 12)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"status:implementing", "status:manual"}, labels)
+	assert.Equal(t, []string{
+		"issue", "view", "12", "--repo", "owner/repo",
+		"--json", "labels", "--jq", "[.labels[].name]",
+	}, gotArgs)
+
+	_, err = client.GetIssueLabels(context.Background(), "owner", "repo", 0)
+	assert.EqualError(t, err, "issue number must be positive")
+}
@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// IssueSearchResult はIssue検索の1件分
+type IssueSearchResult struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"`
+	URL    string `json:"url"`
+}
+
+// IssueSearcher はオープン・クローズ済みの両方のIssueを検索する（重複Issueを検出するDuplicateDetectorが使う）
+type IssueSearcher interface {
+	SearchIssues(ctx context.Context, owner, repo, query string, limit int) ([]*IssueSearchResult, error)
+}
+
+var _ IssueSearcher = (*GHClient)(nil)
+
+// SearchIssues はタイトル・本文を対象にIssueを検索する（Pull Requestは含まない）
+func (c *GHClient) SearchIssues(ctx context.Context, owner, repo, query string, limit int) ([]*IssueSearchResult, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if query == "" {
+		return nil, errors.New("query is required")
+	}
+
+	output, err := c.executeGHCommand(ctx, "search", "issues",
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--match", "title", "--match", "body",
+		"--json", "number,title,body,state,url",
+		"--limit", strconv.Itoa(limit),
+		"--", query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	var results []*IssueSearchResult
+	if err := json.Unmarshal(output, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse search results: %w", err)
+	}
+
+	if c.logger != nil {
+		c.logger.Debug("Searched issues",
+			"owner", owner,
+			"repo", repo,
+			"results", len(results),
+		)
+	}
+	return results, nil
+}
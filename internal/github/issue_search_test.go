@@ -0,0 +1,39 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_SearchIssues(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`[{"number":12,"title":"ログイン画面を追加","body":"","state":"closed","url":"https://github.com/owner/repo/issues/12"}]`), nil
+	}
+
+	client := &GHClient{}
+	results, err := client.SearchIssues(context.Background(), "owner", "repo", "ログイン画面", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"search", "issues", "--repo", "owner/repo",
+		"--match", "title", "--match", "body",
+		"--json", "number,title,body,state,url",
+		"--limit", "10", "--", "ログイン画面",
+	}, gotArgs)
+	assert.Equal(t, []*IssueSearchResult{{
+		Number: 12,
+		Title:  "ログイン画面を追加",
+		State:  "closed",
+		URL:    "https://github.com/owner/repo/issues/12",
+	}}, results)
+
+	_, err = client.SearchIssues(context.Background(), "owner", "repo", "", 10)
+	assert.EqualError(t, err, "query is required")
+}
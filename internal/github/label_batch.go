@@ -0,0 +1,141 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LabelBatchEditor は複数ラベルの追加・削除を1回の操作で行う（フェーズの遷移でラベルの付け替えを途中で止めないために使う）
+type LabelBatchEditor interface {
+	BatchEditLabels(ctx context.Context, owner, repo string, issueNumber int, add, remove []string) error
+}
+
+var _ LabelBatchEditor = (*GHClient)(nil)
+
+// LabelStateError はラベルの一括変更後のIssueのラベルが期待した状態になっていないことを表す
+type LabelStateError struct {
+	IssueNumber int
+	Missing     []string // 追加したはずが付与されていないラベル
+	Unexpected  []string // 削除したはずが残っているラベル
+}
+
+func (e *LabelStateError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Unexpected) > 0 {
+		parts = append(parts, "unexpected "+strings.Join(e.Unexpected, ", "))
+	}
+	return fmt.Sprintf("labels on issue #%d are not in the expected state: %s", e.IssueNumber, strings.Join(parts, "; "))
+}
+
+// BatchEditLabels はIssueのラベルの追加と削除を1回のghコマンドで行い、変更後のラベルを検証する
+// AddLabelとRemoveLabelを順に呼び出す場合と異なり、途中で失敗してもラベルが遷移の途中の状態で残らない
+// 変更後のラベルが期待と異なる場合は*LabelStateErrorを返す
+func (c *GHClient) BatchEditLabels(ctx context.Context, owner, repo string, issueNumber int, add, remove []string) error {
+	if owner == "" {
+		return errors.New("owner is required")
+	}
+	if repo == "" {
+		return errors.New("repo is required")
+	}
+	if issueNumber <= 0 {
+		return errors.New("issue number must be positive")
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return errors.New("at least one label to add or remove is required")
+	}
+	for _, label := range add {
+		if label == "" {
+			return errors.New("label is required")
+		}
+		for _, r := range remove {
+			if label == r {
+				return fmt.Errorf("label %s cannot be both added and removed", label)
+			}
+		}
+	}
+	for _, label := range remove {
+		if label == "" {
+			return errors.New("label is required")
+		}
+	}
+
+	// ラベル名にカンマを含む場合があるため、フラグはラベルごとに指定する
+	args := []string{"issue", "edit", strconv.Itoa(issueNumber), "--repo", fmt.Sprintf("%s/%s", owner, repo)}
+	for _, label := range add {
+		args = append(args, "--add-label", label)
+	}
+	for _, label := range remove {
+		args = append(args, "--remove-label", label)
+	}
+	if _, err := c.executeGHCommand(ctx, args...); err != nil {
+		if c.logger != nil {
+			c.logger.Error("Failed to batch edit labels",
+				"owner", owner,
+				"repo", repo,
+				"issue", issueNumber,
+				"add", add,
+				"remove", remove,
+				"error", err,
+			)
+		}
+		return fmt.Errorf("failed to edit labels on issue #%d: %w", issueNumber, err)
+	}
+
+	current, err := c.GetIssueLabels(ctx, owner, repo, issueNumber)
+	if err != nil {
+		return fmt.Errorf("failed to verify labels on issue #%d: %w", issueNumber, err)
+	}
+	if stateErr := checkLabelState(issueNumber, current, add, remove); stateErr != nil {
+		if c.logger != nil {
+			c.logger.Error("Labels are not in the expected state after batch edit",
+				"owner", owner,
+				"repo", repo,
+				"issue", issueNumber,
+				"missing", stateErr.Missing,
+				"unexpected", stateErr.Unexpected,
+			)
+		}
+		return stateErr
+	}
+
+	if c.logger != nil {
+		c.logger.Debug("Batch edited labels",
+			"owner", owner,
+			"repo", repo,
+			"issue", issueNumber,
+			"add", add,
+			"remove", remove,
+		)
+	}
+	return nil
+}
+
+// checkLabelState は現在のラベルに追加したラベルがすべてあり、削除したラベルが残っていないかを確認する
+func checkLabelState(issueNumber int, current, add, remove []string) *LabelStateError {
+	has := make(map[string]bool, len(current))
+	for _, label := range current {
+		has[label] = true
+	}
+
+	stateErr := &LabelStateError{IssueNumber: issueNumber}
+	for _, label := range add {
+		if !has[label] {
+			stateErr.Missing = append(stateErr.Missing, label)
+		}
+	}
+	for _, label := range remove {
+		if has[label] {
+			stateErr.Unexpected = append(stateErr.Unexpected, label)
+		}
+	}
+	if len(stateErr.Missing) == 0 && len(stateErr.Unexpected) == 0 {
+		return nil
+	}
+	return stateErr
+}
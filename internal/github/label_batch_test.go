@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_BatchEditLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		add      []string
+		remove   []string
+		labels   string
+		editErr  error
+		wantArgs []string
+		wantErr  string
+	}{
+		{
+			name:   "1回のコマンドで追加と削除を行う",
+			add:    []string{"status:revising"},
+			remove: []string{"status:requires-changes", "status:reviewing"},
+			labels: `["bug","status:revising"]`,
+			wantArgs: []string{
+				"issue", "edit", "12", "--repo", "owner/repo",
+				"--add-label", "status:revising",
+				"--remove-label", "status:requires-changes",
+				"--remove-label", "status:reviewing",
+			},
+		},
+		{
+			name:    "削除したラベルが残っている場合はエラー",
+			add:     []string{"status:implementing"},
+			remove:  []string{"status:ready"},
+			labels:  `["status:implementing","status:ready"]`,
+			wantErr: "labels on issue #12 are not in the expected state: unexpected status:ready",
+		},
+		{
+			name:    "追加したラベルがない場合はエラー",
+			add:     []string{"status:implementing"},
+			remove:  []string{"status:ready"},
+			labels:  `[]`,
+			wantErr: "labels on issue #12 are not in the expected state: missing status:implementing",
+		},
+		{
+			name:    "ghコマンドが失敗した場合はエラー",
+			add:     []string{"status:implementing"},
+			editErr: errors.New("exit status 1"),
+			wantErr: "failed to edit labels on issue #12: gh command failed: exit status 1",
+		},
+		{
+			name:    "同じラベルの追加と削除はエラー",
+			add:     []string{"status:ready"},
+			remove:  []string{"status:ready"},
+			wantErr: "label status:ready cannot be both added and removed",
+		},
+		{
+			name:    "ラベルの指定がない場合はエラー",
+			wantErr: "at least one label to add or remove is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origRun := runGHCommand
+			defer func() { runGHCommand = origRun }()
+
+			var editArgs []string
+			runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+				if args[1] == "edit" {
+					editArgs = args
+					return nil, tt.editErr
+				}
+				return []byte(tt.labels), nil
+			}
+
+			err := (&GHClient{}).BatchEditLabels(context.Background(), "owner", "repo", 12, tt.add, tt.remove)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantArgs, editArgs)
+		})
+	}
+}
+
+func TestLabelStateError_As(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		if args[1] == "edit" {
+			return nil, nil
+		}
+		return []byte(`["status:ready"]`), nil
+	}
+
+	err := (&GHClient{}).BatchEditLabels(context.Background(), "owner", "repo", 7, []string{"status:implementing"}, []string{"status:ready"})
+	var stateErr *LabelStateError
+	require.True(t, errors.As(err, &stateErr))
+	assert.Equal(t, []string{"status:implementing"}, stateErr.Missing)
+	assert.Equal(t, []string{"status:ready"}, stateErr.Unexpected)
+}
@@ -3,11 +3,12 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"time"
 
+	"github.com/douhashi/osoba/internal/faultinject"
 	"github.com/douhashi/osoba/internal/logger"
 )
 
@@ -18,6 +19,8 @@ type GHLabelManager struct {
 	transitionRules  map[string]string
 	maxRetries       int
 	retryDelay       time.Duration
+	audit            *AuditLog             // 変更を伴う操作の監査ログ（無効の場合はnil）
+	faultInjector    *faultinject.Injector // 障害注入（無効の場合はnil）
 }
 
 // NewGHLabelManager は新しいghコマンドベースのLabelManagerを作成する
@@ -76,6 +79,18 @@ func (lm *GHLabelManager) initializeLabelDefinitions() {
 	}
 }
 
+// AddLabelDefinitions はEnsureLabelsExistWithRetryで作成するラベルに定義を加える（既に定義されているラベルは変更しない）
+func (lm *GHLabelManager) AddLabelDefinitions(defs []LabelDefinition) {
+	for _, def := range defs {
+		if def.Name == "" {
+			continue
+		}
+		if _, ok := lm.labelDefinitions[def.Name]; !ok {
+			lm.labelDefinitions[def.Name] = def
+		}
+	}
+}
+
 // initializeTransitionRules sets up the label transition rules
 func (lm *GHLabelManager) initializeTransitionRules() {
 	lm.transitionRules["status:needs-plan"] = "status:planning"
@@ -389,14 +404,30 @@ func (lm *GHLabelManager) createLabel(ctx context.Context, owner, repo string, d
 	return nil
 }
 
+// SetAuditLog は変更を伴う操作を記録する監査ログを設定する
+func (lm *GHLabelManager) SetAuditLog(audit *AuditLog) {
+	lm.audit = audit
+}
+
 // executeGHCommand はghコマンドを実行する
 func (lm *GHLabelManager) executeGHCommand(ctx context.Context, args ...string) ([]byte, error) {
-	cmd := exec.CommandContext(ctx, "gh", args...)
-	output, err := cmd.CombinedOutput()
+	if err := lm.faultInjector.Inject(faultinject.TargetGH, args); err != nil {
+		return nil, ParseGHError(injectedFaultOutput, err)
+	}
+	output, err := execGHCommand(ctx, args)
+	if lm.audit != nil {
+		if auditErr := lm.audit.Record(args, err); auditErr != nil && lm.logger != nil {
+			lm.logger.Warn("Failed to write audit log", "args", args, "error", auditErr)
+		}
+	}
 	if err != nil {
+		// タイムアウトなど構造化済みのエラーはそのまま返す
+		var ghErr *GitHubError
+		if errors.As(err, &ghErr) {
+			return nil, ghErr
+		}
 		// Parse the error output to create a structured GitHubError
-		ghErr := ParseGHError(string(output), err)
-		return nil, ghErr
+		return nil, ParseGHError(string(output), err)
 	}
 	return output, nil
 }
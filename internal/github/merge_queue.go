@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// MergeQueueEntry はマージキューに追加したPRの状態
+type MergeQueueEntry struct {
+	PRNumber int    `json:"pr_number"`
+	PRState  string `json:"pr_state"`           // PRの状態（OPEN、MERGED、CLOSED）
+	State    string `json:"state,omitempty"`    // キュー内の状態（QUEUED、AWAITING_CHECKS、MERGEABLE、UNMERGEABLE、LOCKED）。キューにない場合は空
+	Position int    `json:"position,omitempty"` // キュー内の順番
+}
+
+// Queued はPRがマージキューに入っているかを返す
+func (e *MergeQueueEntry) Queued() bool {
+	return e.State != ""
+}
+
+// Merged はPRがマージされたかを返す
+func (e *MergeQueueEntry) Merged() bool {
+	return e.PRState == "MERGED"
+}
+
+// MergeQueueClient はGitHubのマージキューへのPRの追加と状態の取得を行う（マージキューを使うリポジトリで自動マージが使う）
+type MergeQueueClient interface {
+	// UsesMergeQueue はブランチのルールセットでマージキューが必須になっているかを返す
+	UsesMergeQueue(ctx context.Context, owner, repo, branch string) (bool, error)
+	// EnqueuePullRequest はPRをマージキューに追加する（チェックの完了後にキューに入る）
+	EnqueuePullRequest(ctx context.Context, prNumber int) error
+	// GetMergeQueueEntry はPRの状態とマージキュー内の状態を返す
+	GetMergeQueueEntry(ctx context.Context, owner, repo string, prNumber int) (*MergeQueueEntry, error)
+}
+
+var _ MergeQueueClient = (*GHClient)(nil)
+
+// mergeQueueEntryQuery はPRの状態とマージキューのエントリを取得するGraphQLクエリ
+const mergeQueueEntryQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      state
+      mergeQueueEntry { state position }
+    }
+  }
+}`
+
+// UsesMergeQueue はブランチに適用されるルールにmerge_queueがあるかを返す
+func (c *GHClient) UsesMergeQueue(ctx context.Context, owner, repo, branch string) (bool, error) {
+	if owner == "" {
+		return false, errors.New("owner is required")
+	}
+	if repo == "" {
+		return false, errors.New("repo is required")
+	}
+	if branch == "" {
+		return false, errors.New("branch is required")
+	}
+
+	output, err := c.executeGHCommand(ctx, "api", fmt.Sprintf("repos/%s/%s/rules/branches/%s", owner, repo, branch))
+	if err != nil {
+		return false, fmt.Errorf("failed to get branch rules: %w", err)
+	}
+
+	var rules []struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(output, &rules); err != nil {
+		return false, fmt.Errorf("failed to parse branch rules: %w", err)
+	}
+	for _, rule := range rules {
+		if rule.Type == "merge_queue" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// EnqueuePullRequest はPRをマージキューに追加する
+// マージ方法はマージキューの設定で決まるため指定しない
+func (c *GHClient) EnqueuePullRequest(ctx context.Context, prNumber int) error {
+	if prNumber <= 0 {
+		return errors.New("pull request number must be positive")
+	}
+
+	if c.logger != nil {
+		c.logger.Info("Adding pull request to merge queue", "pr_number", prNumber)
+	}
+	if _, err := c.executeGHCommand(ctx, "pr", "merge", strconv.Itoa(prNumber), "--auto"); err != nil {
+		return fmt.Errorf("failed to add pull request #%d to merge queue: %w", prNumber, err)
+	}
+	return nil
+}
+
+// GetMergeQueueEntry はPRの状態とマージキュー内の状態を返す
+func (c *GHClient) GetMergeQueueEntry(ctx context.Context, owner, repo string, prNumber int) (*MergeQueueEntry, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if prNumber <= 0 {
+		return nil, errors.New("pull request number must be positive")
+	}
+
+	output, err := c.executeGHCommand(ctx, "api", "graphql",
+		"-f", "query="+mergeQueueEntryQuery,
+		"-F", "owner="+owner,
+		"-F", "repo="+repo,
+		"-F", "number="+strconv.Itoa(prNumber),
+		"--jq", ".data.repository.pullRequest")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge queue entry: %w", err)
+	}
+
+	var resp struct {
+		State           string `json:"state"`
+		MergeQueueEntry *struct {
+			State    string `json:"state"`
+			Position int    `json:"position"`
+		} `json:"mergeQueueEntry"`
+	}
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse merge queue entry: %w", err)
+	}
+
+	entry := &MergeQueueEntry{PRNumber: prNumber, PRState: resp.State}
+	if resp.MergeQueueEntry != nil {
+		entry.State = resp.MergeQueueEntry.State
+		entry.Position = resp.MergeQueueEntry.Position
+	}
+	return entry, nil
+}
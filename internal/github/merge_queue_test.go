@@ -0,0 +1,89 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_UsesMergeQueue(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{name: "マージキューのルールがある", output: `[{"type":"pull_request"},{"type":"merge_queue"}]`, want: true},
+		{name: "マージキューのルールがない", output: `[{"type":"required_linear_history"}]`, want: false},
+		{name: "ルールがない", output: `[]`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+				assert.Equal(t, []string{"api", "repos/owner/repo/rules/branches/main"}, args)
+				return []byte(tt.output), nil
+			}
+
+			client := &GHClient{}
+			got, err := client.UsesMergeQueue(context.Background(), "owner", "repo", "main")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGHClient_GetMergeQueueEntry(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	tests := []struct {
+		name       string
+		output     string
+		want       MergeQueueEntry
+		wantQueued bool
+		wantMerged bool
+	}{
+		{
+			name:       "キューで待機中",
+			output:     `{"state":"OPEN","mergeQueueEntry":{"state":"AWAITING_CHECKS","position":2}}`,
+			want:       MergeQueueEntry{PRNumber: 42, PRState: "OPEN", State: "AWAITING_CHECKS", Position: 2},
+			wantQueued: true,
+		},
+		{
+			name:       "マージ済み",
+			output:     `{"state":"MERGED","mergeQueueEntry":null}`,
+			want:       MergeQueueEntry{PRNumber: 42, PRState: "MERGED"},
+			wantMerged: true,
+		},
+		{
+			name:   "キューから外れた",
+			output: `{"state":"OPEN","mergeQueueEntry":null}`,
+			want:   MergeQueueEntry{PRNumber: 42, PRState: "OPEN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls [][]string
+			runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+				calls = append(calls, args)
+				return []byte(tt.output), nil
+			}
+
+			client := &GHClient{}
+			entry, err := client.GetMergeQueueEntry(context.Background(), "owner", "repo", 42)
+			require.NoError(t, err)
+			require.Len(t, calls, 1)
+			assert.Equal(t, []string{"api", "graphql"}, calls[0][:2])
+			assert.Contains(t, calls[0], "number=42")
+			assert.Equal(t, tt.want, *entry)
+			assert.Equal(t, tt.wantQueued, entry.Queued())
+			assert.Equal(t, tt.wantMerged, entry.Merged())
+		})
+	}
+}
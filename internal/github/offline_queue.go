@@ -0,0 +1,238 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// offlineMutationKind は保留する変更の種類
+type offlineMutationKind string
+
+const (
+	offlineComment      offlineMutationKind = "comment"
+	offlineAddLabel     offlineMutationKind = "add-label"
+	offlineEnsureLabels offlineMutationKind = "ensure-labels"
+)
+
+// offlineMutation はGitHubに接続できない間に保留した変更
+type offlineMutation struct {
+	kind     offlineMutationKind
+	owner    string
+	repo     string
+	issue    int
+	value    string // コメントの本文またはラベル（ラベルの作成の場合は空）
+	queuedAt time.Time
+}
+
+// offlineQueue はGitHubに接続できない間の急がない変更（通知のコメント・情報のラベルの追加・ラベルの作成）を保留する
+type offlineQueue struct {
+	max    int
+	labels map[string]bool // 保留できるラベル
+	now    func() time.Time
+
+	mu       sync.Mutex
+	pending  []offlineMutation
+	flushing bool
+}
+
+// OfflineQueueStatus は保留中の変更の状態を提供するクライアントのインターフェース
+type OfflineQueueStatus interface {
+	PendingMutations() int
+}
+
+// DeferredMutator はGitHubに接続できない間に保留できる変更を提供するクライアントのインターフェース
+// 状態を持たない通知のコメントと、許可された情報のラベルの追加のみを対象とし、フェーズの遷移やマーカーには使わない
+type DeferredMutator interface {
+	// CreateIssueCommentDeferred はIssueにコメントを投稿し、接続できない場合はエラーにせず保留する
+	CreateIssueCommentDeferred(ctx context.Context, owner, repo string, issueNumber int, comment string) error
+	// AddInformationalLabel はIssueにラベルを追加し、接続できない場合は許可されたラベルのみ保留する
+	AddInformationalLabel(ctx context.Context, owner, repo string, issueNumber int, label string) error
+}
+
+var (
+	_ OfflineQueueStatus = (*GHClient)(nil)
+	_ DeferredMutator    = (*GHClient)(nil)
+)
+
+// SetOfflineQueue はGitHubに接続できない間の通知のコメントとlabelsのラベルの追加を、エラーにせず最大max件まで保留するよう設定する
+// 保留した変更は次にghコマンドが成功した時（接続が戻った時）に保留した順に反映する（0以下で無効）
+func (c *GHClient) SetOfflineQueue(max int, labels []string) {
+	if max <= 0 {
+		c.offline = nil
+		return
+	}
+	allowed := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		allowed[label] = true
+	}
+	c.offline = &offlineQueue{max: max, labels: allowed, now: time.Now}
+}
+
+// CreateIssueCommentDeferred はIssueにコメントを投稿し、接続できない場合はエラーにせず保留する
+func (c *GHClient) CreateIssueCommentDeferred(ctx context.Context, owner, repo string, issueNumber int, comment string) error {
+	err := c.CreateIssueComment(ctx, owner, repo, issueNumber, comment)
+	if err != nil && c.deferOffline(offlineMutation{kind: offlineComment, owner: owner, repo: repo, issue: issueNumber, value: comment}, err) {
+		return nil
+	}
+	return err
+}
+
+// AddInformationalLabel はIssueにラベルを追加し、接続できない場合は許可されたラベルのみエラーにせず保留する
+func (c *GHClient) AddInformationalLabel(ctx context.Context, owner, repo string, issueNumber int, label string) error {
+	err := c.AddLabel(ctx, owner, repo, issueNumber, label)
+	if err == nil || c.offline == nil || !c.offline.labels[label] {
+		return err
+	}
+	if c.deferOffline(offlineMutation{kind: offlineAddLabel, owner: owner, repo: repo, issue: issueNumber, value: label}, err) {
+		return nil
+	}
+	return err
+}
+
+// EnsureLabelsExistDeferred は必要なラベルが存在することを確認し、接続できない場合はエラーにせず作成を保留する
+// ラベルの作成は何度実行しても結果が変わらないため、接続が戻った後に改めて確認・作成する
+func (c *GHClient) EnsureLabelsExistDeferred(ctx context.Context, owner, repo string) error {
+	err := c.EnsureLabelsExist(ctx, owner, repo)
+	if err != nil && c.deferOffline(offlineMutation{kind: offlineEnsureLabels, owner: owner, repo: repo}, err) {
+		return nil
+	}
+	return err
+}
+
+// PendingMutations は保留中の変更の件数を返す
+func (c *GHClient) PendingMutations() int {
+	if c.offline == nil {
+		return 0
+	}
+	c.offline.mu.Lock()
+	defer c.offline.mu.Unlock()
+	return len(c.offline.pending)
+}
+
+// IsUnreachableError はGitHubに接続できないことによるエラーかを判定する
+func IsUnreachableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var ghErr *GitHubError
+	if errors.As(err, &ghErr) {
+		return ghErr.Type == ErrorTypeNetworkTimeout
+	}
+	return ParseGHError(err.Error(), err).Type == ErrorTypeNetworkTimeout
+}
+
+// deferOffline はGitHubに接続できないことによる失敗の場合に変更を保留し、保留した場合はtrueを返す
+func (c *GHClient) deferOffline(m offlineMutation, err error) bool {
+	q := c.offline
+	if q == nil || !IsUnreachableError(err) {
+		return false
+	}
+
+	q.mu.Lock()
+	if len(q.pending) >= q.max {
+		q.mu.Unlock()
+		if c.logger != nil {
+			c.logger.Warn("Offline queue is full, not deferring GitHub mutation",
+				"kind", string(m.kind),
+				"issue", m.issue,
+				"max", q.max)
+		}
+		return false
+	}
+	m.queuedAt = q.now()
+	q.pending = append(q.pending, m)
+	pending := len(q.pending)
+	q.mu.Unlock()
+
+	if c.logger != nil {
+		c.logger.Warn("GitHub unreachable, deferred mutation until connectivity returns",
+			"kind", string(m.kind),
+			"owner", m.owner,
+			"repo", m.repo,
+			"issue", m.issue,
+			"pending", pending,
+			"error", err)
+	}
+	return true
+}
+
+// flushOffline は保留中の変更を保留した順に反映する
+// 再び接続できなかった場合は残りを保留したまま中断し、それ以外のエラーの変更はログに記録して破棄する
+func (c *GHClient) flushOffline(ctx context.Context) {
+	q := c.offline
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	if q.flushing || len(q.pending) == 0 {
+		q.mu.Unlock()
+		return
+	}
+	q.flushing = true
+	q.mu.Unlock()
+
+	defer func() {
+		q.mu.Lock()
+		q.flushing = false
+		q.mu.Unlock()
+	}()
+
+	flushed := 0
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.mu.Unlock()
+			break
+		}
+		m := q.pending[0]
+		q.mu.Unlock()
+
+		err := c.applyOffline(ctx, m)
+		if err != nil && (IsUnreachableError(err) || ctx.Err() != nil) {
+			if c.logger != nil {
+				c.logger.Warn("GitHub still unreachable, keeping deferred mutations", "pending", c.PendingMutations(), "error", err)
+			}
+			return
+		}
+
+		q.mu.Lock()
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+		if err != nil {
+			if c.logger != nil {
+				c.logger.Error("Failed to apply deferred mutation, dropping it",
+					"kind", string(m.kind),
+					"issue", m.issue,
+					"queued_at", m.queuedAt,
+					"error", err)
+			}
+			continue
+		}
+		flushed++
+	}
+
+	if flushed > 0 && c.logger != nil {
+		c.logger.Info("Applied deferred GitHub mutations", "count", flushed)
+	}
+}
+
+// applyOffline は保留した変更を1件反映する
+func (c *GHClient) applyOffline(ctx context.Context, m offlineMutation) error {
+	repo := fmt.Sprintf("%s/%s", m.owner, m.repo)
+	switch m.kind {
+	case offlineComment:
+		_, err := c.createIssueComment(ctx, m.owner, m.repo, m.issue, m.value)
+		return err
+	case offlineAddLabel:
+		_, err := c.executeGHCommand(ctx, "issue", "edit", strconv.Itoa(m.issue), "--repo", repo, "--add-label", m.value)
+		return err
+	case offlineEnsureLabels:
+		return c.EnsureLabelsExist(ctx, m.owner, m.repo)
+	default:
+		return fmt.Errorf("unknown deferred mutation: %s", m.kind)
+	}
+}
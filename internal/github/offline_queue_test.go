@@ -0,0 +1,152 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_OfflineQueue(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	offline := true
+	var executed [][]string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		if offline {
+			return []byte("error connecting to api.github.com\ncheck your internet connection or https://githubstatus.com"), errors.New("exit status 1")
+		}
+		executed = append(executed, args)
+		return []byte("[]"), nil
+	}
+
+	client := NewClientWithLabelManager(nil)
+	client.SetOfflineQueue(2, []string{"status:needs-human"})
+	ctx := context.Background()
+
+	// 接続できない間は通知のコメントと許可されたラベルの追加をエラーにせず保留する
+	require.NoError(t, client.CreateIssueCommentDeferred(ctx, "owner", "repo", 1, "エージェントが停止しています"))
+	require.NoError(t, client.AddInformationalLabel(ctx, "owner", "repo", 1, "status:needs-human"))
+	assert.Equal(t, 2, client.PendingMutations())
+
+	// 上限を超えた変更は保留せずにエラーを返す
+	err := client.CreateIssueCommentDeferred(ctx, "owner", "repo", 2, "コメント")
+	require.Error(t, err)
+	assert.Equal(t, 2, client.PendingMutations())
+
+	// 接続が戻った後の最初のghコマンドで、保留した順に反映する
+	offline = false
+	_, err = client.executeGHCommand(ctx, "issue", "list")
+	require.NoError(t, err)
+	assert.Equal(t, 0, client.PendingMutations())
+	require.Len(t, executed, 3)
+	assert.Equal(t, []string{"issue", "list"}, executed[0])
+	assert.Equal(t, []string{"issue", "comment", "1", "--repo", "owner/repo", "--body", "エージェントが停止しています"}, executed[1])
+	assert.Equal(t, []string{"issue", "edit", "1", "--repo", "owner/repo", "--add-label", "status:needs-human"}, executed[2])
+}
+
+func TestGHClient_OfflineQueue_EnsureLabels(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	offline := true
+	var created []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		if offline {
+			return []byte("error connecting to api.github.com"), errors.New("exit status 1")
+		}
+		if args[0] == "label" && args[1] == "create" {
+			created = append(created, args[2])
+		}
+		return []byte("[]"), nil
+	}
+
+	client := NewClientWithLabelManager(NewGHLabelManager(nil, 1, 0))
+	client.SetOfflineQueue(10, nil)
+	ctx := context.Background()
+
+	// 接続できない間はラベルの作成を保留し、接続が戻った後に作成する
+	require.NoError(t, client.EnsureLabelsExistDeferred(ctx, "owner", "repo"))
+	assert.Equal(t, 1, client.PendingMutations())
+	assert.Empty(t, created)
+
+	offline = false
+	_, err := client.executeGHCommand(ctx, "issue", "list")
+	require.NoError(t, err)
+	assert.Equal(t, 0, client.PendingMutations())
+	assert.Len(t, created, 6)
+}
+
+func TestGHClient_OfflineQueue_DoesNotDeferStateChanges(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		return []byte("error connecting to api.github.com"), errors.New("exit status 1")
+	}
+
+	client := NewClientWithLabelManager(nil)
+	client.SetOfflineQueue(10, []string{"status:needs-human"})
+	ctx := context.Background()
+
+	// 通常のラベルの変更とコメントは保留せず、呼び出し元にエラーを返す
+	require.Error(t, client.AddLabel(ctx, "owner", "repo", 1, "status:lgtm"))
+	require.Error(t, client.RemoveLabel(ctx, "owner", "repo", 1, "status:implementing"))
+	require.Error(t, client.CreateIssueComment(ctx, "owner", "repo", 1, "<!-- osoba:marker -->"))
+	// 許可されていないラベルは保留しない
+	require.Error(t, client.AddInformationalLabel(ctx, "owner", "repo", 1, "status:requires-changes"))
+	assert.Equal(t, 0, client.PendingMutations())
+}
+
+func TestGHClient_OfflineQueue_KeepsOtherErrors(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		return []byte("HTTP 422: Validation Failed"), errors.New("exit status 1")
+	}
+
+	client := NewClientWithLabelManager(nil)
+	client.SetOfflineQueue(10, []string{"status:needs-human"})
+
+	// 接続できないこと以外の失敗は保留しない
+	err := client.AddInformationalLabel(context.Background(), "owner", "repo", 1, "status:needs-human")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Validation Failed")
+	assert.Equal(t, 0, client.PendingMutations())
+}
+
+func TestGHClient_OfflineQueue_Disabled(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		return []byte("dial tcp: lookup api.github.com: no such host"), errors.New("exit status 1")
+	}
+
+	client := NewClientWithLabelManager(nil)
+	err := client.CreateIssueCommentDeferred(context.Background(), "owner", "repo", 1, "コメント")
+	require.Error(t, err)
+	assert.Equal(t, 0, client.PendingMutations())
+}
+
+func TestGHCommandEnv(t *testing.T) {
+	origCfg := commandConfig
+	defer func() {
+		commandConfig = origCfg
+		SetToken("")
+	}()
+
+	SetCommandConfig(CommandConfig{})
+	SetToken("")
+	assert.Nil(t, ghCommandEnv(), "変更がない場合はosobaの環境変数をそのまま引き継ぐ")
+
+	SetCommandConfig(CommandConfig{Proxy: "http://proxy.example.com:8080", NoProxy: "localhost,.internal"})
+	env := ghCommandEnv()
+	assert.Contains(t, env, "HTTPS_PROXY=http://proxy.example.com:8080")
+	assert.Contains(t, env, "HTTP_PROXY=http://proxy.example.com:8080")
+	assert.Contains(t, env, "NO_PROXY=localhost,.internal")
+}
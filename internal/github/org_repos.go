@@ -0,0 +1,86 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// orgRepoListLimit は組織のリポジトリ一覧で取得する最大件数
+const orgRepoListLimit = 1000
+
+// OrgRepository は組織のリポジトリの情報
+type OrgRepository struct {
+	Owner    string
+	Name     string
+	Archived bool
+	Topics   []string
+}
+
+// FullName は"owner/name"形式のリポジトリ名を返す
+func (r *OrgRepository) FullName() string {
+	return r.Owner + "/" + r.Name
+}
+
+// OrgRepositoryLister は組織のリポジトリ一覧を取得する（組織単位で監視するリポジトリの検出に使う）
+type OrgRepositoryLister interface {
+	ListOrgRepositories(ctx context.Context, org string) ([]*OrgRepository, error)
+}
+
+var _ OrgRepositoryLister = (*GHClient)(nil)
+
+// ghOrgRepository はgh repo list --jsonの1件分
+type ghOrgRepository struct {
+	Name       string `json:"name"`
+	IsArchived bool   `json:"isArchived"`
+	Owner      struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	RepositoryTopics []struct {
+		Name string `json:"name"`
+	} `json:"repositoryTopics"`
+}
+
+// ListOrgRepositories は組織のリポジトリ一覧（アーカイブ済みを含む）を返す
+func (c *GHClient) ListOrgRepositories(ctx context.Context, org string) ([]*OrgRepository, error) {
+	if org == "" {
+		return nil, errors.New("org is required")
+	}
+
+	output, err := c.executeGHCommand(ctx, "repo", "list", org,
+		"--json", "name,owner,isArchived,repositoryTopics",
+		"--limit", fmt.Sprintf("%d", orgRepoListLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	var raw []ghOrgRepository
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse repository list: %w", err)
+	}
+
+	repos := make([]*OrgRepository, 0, len(raw))
+	for _, r := range raw {
+		repo := &OrgRepository{
+			Owner:    r.Owner.Login,
+			Name:     r.Name,
+			Archived: r.IsArchived,
+		}
+		if repo.Owner == "" {
+			repo.Owner = org
+		}
+		for _, t := range r.RepositoryTopics {
+			repo.Topics = append(repo.Topics, t.Name)
+		}
+		repos = append(repos, repo)
+	}
+
+	if c.logger != nil {
+		c.logger.Debug("Listed organization repositories",
+			"org", org,
+			"count", len(repos),
+		)
+	}
+	return repos, nil
+}
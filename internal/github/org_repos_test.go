@@ -0,0 +1,36 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_ListOrgRepositories(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`[
+  {"name":"api","owner":{"login":"myorg"},"isArchived":false,"repositoryTopics":[{"name":"osoba"},{"name":"go"}]},
+  {"name":"legacy","owner":{"login":"myorg"},"isArchived":true,"repositoryTopics":null}
+]`), nil
+	}
+
+	client := &GHClient{}
+	repos, err := client.ListOrgRepositories(context.Background(), "myorg")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"repo", "list", "myorg", "--json", "name,owner,isArchived,repositoryTopics", "--limit", "1000"}, gotArgs)
+	assert.Equal(t, []*OrgRepository{
+		{Owner: "myorg", Name: "api", Topics: []string{"osoba", "go"}},
+		{Owner: "myorg", Name: "legacy", Archived: true},
+	}, repos)
+	assert.Equal(t, "myorg/api", repos[0].FullName())
+
+	_, err = client.ListOrgRepositories(context.Background(), "")
+	assert.EqualError(t, err, "org is required")
+}
@@ -187,19 +187,30 @@ func (c *GHClient) GetPullRequestForIssue(ctx context.Context, issueNumber int)
 
 }
 
+// SetMergeMethod はPRのマージ方法（MergeMethodSquash または MergeMethodRebase）を設定する
+func (c *GHClient) SetMergeMethod(method string) {
+	c.mergeMethod = method
+}
+
 // MergePullRequest は指定されたPRをマージする
 func (c *GHClient) MergePullRequest(ctx context.Context, prNumber int) error {
+	method := c.mergeMethod
+	if method == "" {
+		method = MergeMethodSquash
+	}
+
 	// gh pr merge <pr-number> --squash --auto
 	args := []string{
 		"pr", "merge",
 		strconv.Itoa(prNumber),
-		"--squash",
+		"--" + method,
 		"--auto",
 	}
 
 	if c.logger != nil {
 		c.logger.Info("Merging pull request",
 			"pr_number", prNumber,
+			"merge_method", method,
 		)
 	}
 
@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// CIチェックの状態
+const (
+	CheckStatePending = "pending" // 実行中・待機中
+	CheckStatePass    = "pass"    // 成功（スキップ・neutralを含む）
+	CheckStateFail    = "fail"    // 失敗（キャンセル・タイムアウトを含む）
+)
+
+// CheckResult はPRのCIチェック1件の結果
+type CheckResult struct {
+	Name     string // チェック名（GitHub Actionsの場合はジョブ名）
+	Workflow string // ワークフロー名（GitHub Actions以外の場合は空）
+	State    string // CheckStatePending / CheckStatePass / CheckStateFail
+	URL      string // チェックの詳細のURL
+}
+
+// PullRequestChecksReader はPRのCIチェックの結果と失敗したジョブのログを取得する（レビューの前にCIを待つCIGateが使う）
+type PullRequestChecksReader interface {
+	ListPullRequestChecks(ctx context.Context, owner, repo string, prNumber int) ([]CheckResult, error)
+	GetFailedJobLog(ctx context.Context, owner, repo string, check CheckResult) (string, error)
+}
+
+var _ PullRequestChecksReader = (*GHClient)(nil)
+
+// statusCheckRollupItem はstatusCheckRollupの要素（CheckRunまたはStatusContext）
+type statusCheckRollupItem struct {
+	Typename     string `json:"__typename"`
+	Name         string `json:"name"`
+	WorkflowName string `json:"workflowName"`
+	Status       string `json:"status"`
+	Conclusion   string `json:"conclusion"`
+	DetailsURL   string `json:"detailsUrl"`
+	Context      string `json:"context"`
+	State        string `json:"state"`
+	TargetURL    string `json:"targetUrl"`
+}
+
+// ListPullRequestChecks はPRの最新のコミットに対するCIチェックの結果を返す
+func (c *GHClient) ListPullRequestChecks(ctx context.Context, owner, repo string, prNumber int) ([]CheckResult, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if prNumber <= 0 {
+		return nil, errors.New("pull request number must be positive")
+	}
+
+	output, err := c.executeGHCommand(ctx, "pr", "view", strconv.Itoa(prNumber),
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--json", "statusCheckRollup", "--jq", ".statusCheckRollup")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request checks: %w", err)
+	}
+
+	var items []statusCheckRollupItem
+	if err := json.Unmarshal(output, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse pull request checks: %w", err)
+	}
+	checks := make([]CheckResult, 0, len(items))
+	for _, item := range items {
+		checks = append(checks, item.toCheckResult())
+	}
+	return checks, nil
+}
+
+// toCheckResult はstatusCheckRollupの要素をCheckResultに変換する
+func (i statusCheckRollupItem) toCheckResult() CheckResult {
+	if i.Typename == "StatusContext" {
+		check := CheckResult{Name: i.Context, URL: i.TargetURL, State: CheckStateFail}
+		switch i.State {
+		case "PENDING", "EXPECTED":
+			check.State = CheckStatePending
+		case "SUCCESS":
+			check.State = CheckStatePass
+		}
+		return check
+	}
+
+	check := CheckResult{Name: i.Name, Workflow: i.WorkflowName, URL: i.DetailsURL, State: CheckStateFail}
+	switch {
+	case i.Status != "COMPLETED":
+		check.State = CheckStatePending
+	case i.Conclusion == "SUCCESS" || i.Conclusion == "NEUTRAL" || i.Conclusion == "SKIPPED":
+		check.State = CheckStatePass
+	}
+	return check
+}
+
+// actionsJobURLPattern はGitHub ActionsのジョブのURL（.../actions/runs/<run>/job/<job>）
+var actionsJobURLPattern = regexp.MustCompile(`/actions/runs/\d+/job/(\d+)`)
+
+// GetFailedJobLog は失敗したGitHub Actionsのジョブのうち、失敗したステップのログを返す
+// GitHub Actions以外のチェックの場合はエラーを返す
+func (c *GHClient) GetFailedJobLog(ctx context.Context, owner, repo string, check CheckResult) (string, error) {
+	m := actionsJobURLPattern.FindStringSubmatch(check.URL)
+	if m == nil {
+		return "", fmt.Errorf("check %q is not a GitHub Actions job", check.Name)
+	}
+
+	output, err := c.executeGHCommand(ctx, "run", "view",
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--job", m[1], "--log-failed")
+	if err != nil {
+		return "", fmt.Errorf("failed to get job log: %w", err)
+	}
+	return string(output), nil
+}
@@ -0,0 +1,59 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_ListPullRequestChecks(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`[
+			{"__typename":"CheckRun","name":"test","workflowName":"CI","status":"COMPLETED","conclusion":"FAILURE","detailsUrl":"https://github.com/owner/repo/actions/runs/1/job/11"},
+			{"__typename":"CheckRun","name":"lint","workflowName":"CI","status":"COMPLETED","conclusion":"SKIPPED","detailsUrl":"https://github.com/owner/repo/actions/runs/1/job/12"},
+			{"__typename":"CheckRun","name":"build","workflowName":"CI","status":"IN_PROGRESS","conclusion":"","detailsUrl":"https://github.com/owner/repo/actions/runs/1/job/13"},
+			{"__typename":"StatusContext","context":"ci/external","state":"SUCCESS","targetUrl":"https://ci.example.com/1"}
+		]`), nil
+	}
+
+	client := &GHClient{}
+	checks, err := client.ListPullRequestChecks(context.Background(), "owner", "repo", 5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr", "view", "5", "--repo", "owner/repo", "--json", "statusCheckRollup", "--jq", ".statusCheckRollup"}, gotArgs)
+	assert.Equal(t, []CheckResult{
+		{Name: "test", Workflow: "CI", State: CheckStateFail, URL: "https://github.com/owner/repo/actions/runs/1/job/11"},
+		{Name: "lint", Workflow: "CI", State: CheckStatePass, URL: "https://github.com/owner/repo/actions/runs/1/job/12"},
+		{Name: "build", Workflow: "CI", State: CheckStatePending, URL: "https://github.com/owner/repo/actions/runs/1/job/13"},
+		{Name: "ci/external", State: CheckStatePass, URL: "https://ci.example.com/1"},
+	}, checks)
+
+	_, err = client.ListPullRequestChecks(context.Background(), "owner", "repo", 0)
+	assert.EqualError(t, err, "pull request number must be positive")
+}
+
+func TestGHClient_GetFailedJobLog(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte("test\tRun go test\t--- FAIL: TestFoo\n"), nil
+	}
+
+	client := &GHClient{}
+	log, err := client.GetFailedJobLog(context.Background(), "owner", "repo", CheckResult{Name: "test", URL: "https://github.com/owner/repo/actions/runs/1/job/11"})
+	require.NoError(t, err)
+	assert.Equal(t, "test\tRun go test\t--- FAIL: TestFoo\n", log)
+	assert.Equal(t, []string{"run", "view", "--repo", "owner/repo", "--job", "11", "--log-failed"}, gotArgs)
+
+	_, err = client.GetFailedJobLog(context.Background(), "owner", "repo", CheckResult{Name: "ci/external", URL: "https://ci.example.com/1"})
+	assert.EqualError(t, err, `check "ci/external" is not a GitHub Actions job`)
+}
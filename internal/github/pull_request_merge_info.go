@@ -0,0 +1,146 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PullRequestMergeInfo は自動マージの条件の判定に使用するPRの情報
+type PullRequestMergeInfo struct {
+	Author    string
+	Labels    []string
+	Approvals int               // 承認しているレビュアーの数（各レビュアーの最新のレビューで判定）
+	Checks    map[string]string // チェック名ごとの結果（SUCCESS、FAILURE、PENDINGなど）
+}
+
+// PullRequestMergeInfoReader はPRの変更量・レビュー状態とラベルの付与日時を取得する（自動マージのポリシーと履歴ガードの判定に使う）
+type PullRequestMergeInfoReader interface {
+	GetPullRequestMergeInfo(ctx context.Context, owner, repo string, prNumber int) (*PullRequestMergeInfo, error)
+	GetLabelAddedAt(ctx context.Context, owner, repo string, number int, label string) (*time.Time, error)
+}
+
+var _ PullRequestMergeInfoReader = (*GHClient)(nil)
+
+// GetPullRequestMergeInfo はPRの作成者、ラベル、承認数、チェックの結果を返す
+func (c *GHClient) GetPullRequestMergeInfo(ctx context.Context, owner, repo string, prNumber int) (*PullRequestMergeInfo, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if prNumber <= 0 {
+		return nil, errors.New("pull request number must be positive")
+	}
+
+	output, err := c.executeGHCommand(ctx, "pr", "view", strconv.Itoa(prNumber),
+		"--repo", owner+"/"+repo,
+		"--json", "author,labels,reviews,statusCheckRollup")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request merge info: %w", err)
+	}
+
+	var raw struct {
+		Author struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+		Reviews []struct {
+			Author struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			State string `json:"state"`
+		} `json:"reviews"`
+		StatusCheckRollup []struct {
+			Name       string `json:"name"`    // CheckRun
+			Context    string `json:"context"` // StatusContext
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+			State      string `json:"state"`
+		} `json:"statusCheckRollup"`
+	}
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse pull request merge info: %w", err)
+	}
+
+	info := &PullRequestMergeInfo{
+		Author: raw.Author.Login,
+		Checks: make(map[string]string),
+	}
+	for _, label := range raw.Labels {
+		info.Labels = append(info.Labels, label.Name)
+	}
+
+	// コメントのみのレビューは承認状態を変えないため、承認・変更要求・却下のみを最新の状態として扱う
+	latest := make(map[string]string)
+	for _, review := range raw.Reviews {
+		switch review.State {
+		case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+			latest[review.Author.Login] = review.State
+		}
+	}
+	for _, state := range latest {
+		if state == "APPROVED" {
+			info.Approvals++
+		}
+	}
+
+	for _, check := range raw.StatusCheckRollup {
+		name := check.Name
+		if name == "" {
+			name = check.Context
+		}
+		if name == "" {
+			continue
+		}
+		switch {
+		case check.Conclusion != "":
+			info.Checks[name] = check.Conclusion
+		case check.State != "":
+			info.Checks[name] = check.State
+		default:
+			info.Checks[name] = "PENDING"
+		}
+	}
+
+	return info, nil
+}
+
+// GetLabelAddedAt はIssueまたはPRにラベルが最後に付与された日時を返す（付与の記録がない場合はnil）
+func (c *GHClient) GetLabelAddedAt(ctx context.Context, owner, repo string, number int, label string) (*time.Time, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if number <= 0 {
+		return nil, errors.New("issue number must be positive")
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/events", owner, repo, number)
+	jq := fmt.Sprintf(`.[] | select(.event == "labeled" and .label.name == %s) | .created_at`, strconv.Quote(label))
+	output, err := c.executeGHCommand(ctx, "api", endpoint, "--paginate", "--jq", jq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue events: %w", err)
+	}
+
+	lines := strings.Split(string(bytes.TrimSpace(output)), "\n")
+	value := strings.TrimSpace(lines[len(lines)-1])
+	if value == "" {
+		return nil, nil
+	}
+	addedAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse label event time %q: %w", value, err)
+	}
+	return &addedAt, nil
+}
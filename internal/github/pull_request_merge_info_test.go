@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_GetPullRequestMergeInfo(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`{
+			"author": {"login": "alice"},
+			"labels": [{"name": "status:lgtm"}, {"name": "ready-to-merge"}],
+			"reviews": [
+				{"author": {"login": "bob"}, "state": "APPROVED"},
+				{"author": {"login": "bob"}, "state": "COMMENTED"},
+				{"author": {"login": "carol"}, "state": "APPROVED"},
+				{"author": {"login": "carol"}, "state": "CHANGES_REQUESTED"},
+				{"author": {"login": "dave"}, "state": "CHANGES_REQUESTED"},
+				{"author": {"login": "dave"}, "state": "APPROVED"}
+			],
+			"statusCheckRollup": [
+				{"__typename": "CheckRun", "name": "test", "status": "COMPLETED", "conclusion": "SUCCESS"},
+				{"__typename": "CheckRun", "name": "lint", "status": "IN_PROGRESS", "conclusion": ""},
+				{"__typename": "StatusContext", "context": "ci/deploy", "state": "FAILURE"}
+			]
+		}`), nil
+	}
+
+	client := &GHClient{}
+	info, err := client.GetPullRequestMergeInfo(context.Background(), "owner", "repo", 42)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr", "view", "42", "--repo", "owner/repo", "--json", "author,labels,reviews,statusCheckRollup"}, gotArgs)
+	assert.Equal(t, "alice", info.Author)
+	assert.Equal(t, []string{"status:lgtm", "ready-to-merge"}, info.Labels)
+	assert.Equal(t, 2, info.Approvals)
+	assert.Equal(t, map[string]string{"test": "SUCCESS", "lint": "PENDING", "ci/deploy": "FAILURE"}, info.Checks)
+}
+
+func TestGHClient_GetLabelAddedAt(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	addedAt := time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		output string
+		want   *time.Time
+	}{
+		{name: "複数回付与された場合は最後の日時", output: "2024-05-01T10:00:00Z\n2024-05-02T09:30:00Z\n", want: &addedAt},
+		{name: "付与の記録がない", output: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+				gotArgs = args
+				return []byte(tt.output), nil
+			}
+
+			client := &GHClient{}
+			got, err := client.GetLabelAddedAt(context.Background(), "owner", "repo", 12, "status:lgtm")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Contains(t, gotArgs, "repos/owner/repo/issues/12/events")
+			assert.Contains(t, gotArgs, `.[] | select(.event == "labeled" and .label.name == "status:lgtm") | .created_at`)
+		})
+	}
+}
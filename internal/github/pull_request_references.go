@@ -0,0 +1,95 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// closingReferencePattern はPR本文のクローズキーワードによるIssueの参照（Fixes #12、closes owner/repo#12 など）
+var closingReferencePattern = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s*:?\s+([\w.-]+/[\w.-]+)?#(\d+)\b`)
+
+// referencingPullRequestLimit はIssueを参照するPRを探すために取得するオープンなPRの最大件数
+const referencingPullRequestLimit = 100
+
+// ReferencingPullRequest はIssueをクローズキーワードで参照するオープンなPR
+type ReferencingPullRequest struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	HeadRefName string `json:"headRefName"`
+	Author      string `json:"author"`
+}
+
+// PullRequestReferenceFinder はIssueをクローズするオープンなPRを検索する（計画前に既存のPRを検出するExistingPRGuardが使う）
+type PullRequestReferenceFinder interface {
+	ListOpenPullRequestsClosingIssue(ctx context.Context, owner, repo string, issueNumber int) ([]*ReferencingPullRequest, error)
+}
+
+var _ PullRequestReferenceFinder = (*GHClient)(nil)
+
+// ListOpenPullRequestsClosingIssue は本文のクローズキーワードでIssueを参照するオープンなPRを返す
+func (c *GHClient) ListOpenPullRequestsClosingIssue(ctx context.Context, owner, repo string, issueNumber int) ([]*ReferencingPullRequest, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+
+	output, err := c.executeGHCommand(ctx, "pr", "list",
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--state", "open",
+		"--json", "number,title,url,headRefName,author,body",
+		"--limit", strconv.Itoa(referencingPullRequestLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open pull requests: %w", err)
+	}
+
+	var prs []struct {
+		Number      int    `json:"number"`
+		Title       string `json:"title"`
+		URL         string `json:"url"`
+		HeadRefName string `json:"headRefName"`
+		Body        string `json:"body"`
+		Author      struct {
+			Login string `json:"login"`
+		} `json:"author"`
+	}
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return nil, fmt.Errorf("failed to parse open pull requests: %w", err)
+	}
+
+	var referencing []*ReferencingPullRequest
+	for _, pr := range prs {
+		if !ClosesIssue(pr.Body, owner, repo, issueNumber) {
+			continue
+		}
+		referencing = append(referencing, &ReferencingPullRequest{
+			Number:      pr.Number,
+			Title:       pr.Title,
+			URL:         pr.URL,
+			HeadRefName: pr.HeadRefName,
+			Author:      pr.Author.Login,
+		})
+	}
+	return referencing, nil
+}
+
+// ClosesIssue はPR本文がクローズキーワードでIssueを参照しているかを返す
+// 別のリポジトリのIssueへの参照（other/repo#12）は対象外とする
+func ClosesIssue(body, owner, repo string, issueNumber int) bool {
+	for _, m := range closingReferencePattern.FindAllStringSubmatch(body, -1) {
+		if m[1] != "" && !strings.EqualFold(m[1], owner+"/"+repo) {
+			continue
+		}
+		if n, err := strconv.Atoi(m[2]); err == nil && n == issueNumber {
+			return true
+		}
+	}
+	return false
+}
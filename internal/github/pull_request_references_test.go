@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClosesIssue(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{name: "Fixes", body: "Fixes #12", want: true},
+		{name: "小文字とコロン", body: "closes: #12", want: true},
+		{name: "同じリポジトリの完全な参照", body: "Resolved Owner/Repo#12", want: true},
+		{name: "複数の参照の2件目", body: "Fixes #3, fixes #12", want: true},
+		{name: "別のリポジトリの参照", body: "Fixes other/repo#12"},
+		{name: "キーワードのない言及", body: "Related to #12"},
+		{name: "別のIssue番号", body: "Fixes #123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClosesIssue(tt.body, "owner", "repo", 12))
+		})
+	}
+}
+
+func TestGHClient_ListOpenPullRequestsClosingIssue(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`[
+			{"number":40,"title":"Fix login","url":"https://github.com/owner/repo/pull/40","headRefName":"fix-login","author":{"login":"alice"},"body":"Fixes #12"},
+			{"number":41,"title":"Refactor","url":"https://github.com/owner/repo/pull/41","headRefName":"refactor","author":{"login":"bob"},"body":"See #12"}
+		]`), nil
+	}
+
+	client := &GHClient{}
+	prs, err := client.ListOpenPullRequestsClosingIssue(context.Background(), "owner", "repo", 12)
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, &ReferencingPullRequest{
+		Number:      40,
+		Title:       "Fix login",
+		URL:         "https://github.com/owner/repo/pull/40",
+		HeadRefName: "fix-login",
+		Author:      "alice",
+	}, prs[0])
+	assert.Equal(t, []string{"pr", "list", "--repo", "owner/repo", "--state", "open", "--json", "number,title,url,headRefName,author,body", "--limit", "100"}, gotArgs)
+}
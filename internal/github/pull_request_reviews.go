@@ -0,0 +1,114 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// PullRequestReview はPRのレビュー
+type PullRequestReview struct {
+	Author      string    `json:"author"`
+	State       string    `json:"state"` // APPROVED、CHANGES_REQUESTED、COMMENTEDなど
+	Body        string    `json:"body"`
+	Commit      string    `json:"commit"` // レビューしたコミット
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// PullRequestReviewComment はPRの差分の行に付けられたレビューコメント
+type PullRequestReviewComment struct {
+	Author string `json:"author"`
+	Path   string `json:"path"`
+	Line   int    `json:"line"`
+	Body   string `json:"body"`
+	Commit string `json:"commit"` // コメントしたコミット
+}
+
+// PullRequestReviews はPRのレビューと行へのレビューコメント
+type PullRequestReviews struct {
+	HeadCommit string // PRの最新のコミット
+	Reviews    []PullRequestReview
+	Comments   []PullRequestReviewComment
+}
+
+// PullRequestReviewReader はPRのレビューとインラインコメントを取得する（レビューボットの結果を判定するReviewBotsが使う）
+type PullRequestReviewReader interface {
+	GetPullRequestReviews(ctx context.Context, owner, repo string, prNumber int) (*PullRequestReviews, error)
+}
+
+var _ PullRequestReviewReader = (*GHClient)(nil)
+
+// GetPullRequestReviews はPRの最新のコミット、レビュー、行へのレビューコメントを返す
+func (c *GHClient) GetPullRequestReviews(ctx context.Context, owner, repo string, prNumber int) (*PullRequestReviews, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if prNumber <= 0 {
+		return nil, errors.New("pull request number must be positive")
+	}
+
+	output, err := c.executeGHCommand(ctx, "pr", "view", strconv.Itoa(prNumber),
+		"--repo", owner+"/"+repo,
+		"--json", "headRefOid,reviews")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request reviews: %w", err)
+	}
+
+	var raw struct {
+		HeadRefOid string `json:"headRefOid"`
+		Reviews    []struct {
+			Author struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			State       string    `json:"state"`
+			Body        string    `json:"body"`
+			SubmittedAt time.Time `json:"submittedAt"`
+			Commit      struct {
+				Oid string `json:"oid"`
+			} `json:"commit"`
+		} `json:"reviews"`
+	}
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse pull request reviews: %w", err)
+	}
+
+	reviews := &PullRequestReviews{HeadCommit: raw.HeadRefOid}
+	for _, review := range raw.Reviews {
+		reviews.Reviews = append(reviews.Reviews, PullRequestReview{
+			Author:      review.Author.Login,
+			State:       review.State,
+			Body:        review.Body,
+			Commit:      review.Commit.Oid,
+			SubmittedAt: review.SubmittedAt,
+		})
+	}
+
+	// 行へのレビューコメントはgh pr viewで取得できないため、APIから取得する
+	endpoint := fmt.Sprintf("repos/%s/%s/pulls/%d/comments", owner, repo, prNumber)
+	jq := `.[] | {author: .user.login, path: .path, line: (.line // .original_line // 0), body: .body, commit: .commit_id}`
+	output, err = c.executeGHCommand(ctx, "api", endpoint, "--paginate", "--jq", jq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request review comments: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for {
+		var comment PullRequestReviewComment
+		if err := decoder.Decode(&comment); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse pull request review comments: %w", err)
+		}
+		reviews.Comments = append(reviews.Comments, comment)
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,52 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_GetPullRequestReviews(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	var calls [][]string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		if args[0] == "pr" {
+			return []byte(`{
+				"headRefOid": "abc123",
+				"reviews": [
+					{"author": {"login": "coderabbitai"}, "state": "CHANGES_REQUESTED", "body": "nil チェックがありません", "submittedAt": "2026-10-01T09:00:00Z", "commit": {"oid": "abc123"}},
+					{"author": {"login": "alice"}, "state": "APPROVED", "body": "", "submittedAt": "2026-10-01T10:00:00Z", "commit": {"oid": "old456"}}
+				]
+			}`), nil
+		}
+		return []byte(`{"author":"coderabbitai[bot]","path":"main.go","line":12,"body":"errを確認してください","commit":"abc123"}
+{"author":"alice","path":"README.md","line":0,"body":"typo","commit":"old456"}
+`), nil
+	}
+
+	client := &GHClient{}
+	reviews, err := client.GetPullRequestReviews(context.Background(), "owner", "repo", 42)
+	require.NoError(t, err)
+
+	require.Len(t, calls, 2)
+	assert.Equal(t, []string{"pr", "view", "42", "--repo", "owner/repo", "--json", "headRefOid,reviews"}, calls[0])
+	assert.Equal(t, []string{"api", "repos/owner/repo/pulls/42/comments", "--paginate"}, calls[1][:3])
+
+	assert.Equal(t, "abc123", reviews.HeadCommit)
+	require.Len(t, reviews.Reviews, 2)
+	assert.Equal(t, PullRequestReview{
+		Author:      "coderabbitai",
+		State:       "CHANGES_REQUESTED",
+		Body:        "nil チェックがありません",
+		Commit:      "abc123",
+		SubmittedAt: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+	}, reviews.Reviews[0])
+	require.Len(t, reviews.Comments, 2)
+	assert.Equal(t, PullRequestReviewComment{Author: "coderabbitai[bot]", Path: "main.go", Line: 12, Body: "errを確認してください", Commit: "abc123"}, reviews.Comments[0])
+}
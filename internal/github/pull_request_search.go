@@ -7,7 +7,17 @@ import (
 	"regexp"
 )
 
+// PullRequestSearcher はリンクされていないPRも含めてIssueのPRを検索する（自動マージでIssueのPRを特定するために使う）
+type PullRequestSearcher interface {
+	// SearchPullRequestForIssue はosobaのブランチ名・PR本文とコミットのトレーラーのクローズキーワードからIssueのPRを検索する
+	SearchPullRequestForIssue(ctx context.Context, issueNumber int) (*PullRequest, error)
+}
+
+var _ PullRequestSearcher = (*GHClient)(nil)
+
 // SearchPullRequestForIssue はIssue番号に関連するPRを複数の方法で検索する
+// 無関係なPRを自動マージ・クリーンアップしないよう、リンクされたPR・osobaのブランチ（osoba/#N）のPR・
+// 本文やコミットのクローズキーワード（Fixes #N など）でIssueを参照するPRのみを対象にする
 func (c *GHClient) SearchPullRequestForIssue(ctx context.Context, issueNumber int) (*PullRequest, error) {
 	if c.logger != nil {
 		c.logger.Debug("Starting comprehensive PR search for issue",
@@ -27,25 +37,17 @@ func (c *GHClient) SearchPullRequestForIssue(ctx context.Context, issueNumber in
 		return pr, nil
 	}
 
-	// 方法2: PR本文でのIssue番号検索
-	pr, err = c.searchByBodyMention(ctx, issueNumber)
-	if err == nil && pr != nil {
-		if c.logger != nil {
-			c.logger.Info("Found PR via body mention search",
-				"issue_number", issueNumber,
-				"pr_number", pr.Number,
-			)
-		}
-		return pr, nil
+	// 方法2: オープンなPRを1回で取得し、ブランチ名・本文・コミットのトレーラーを確認
+	pr, method, err := c.searchOpenPullRequests(ctx, issueNumber)
+	if err != nil {
+		return nil, err
 	}
-
-	// 方法3: ブランチ名パターンによる検索
-	pr, err = c.searchByBranchPattern(ctx, issueNumber)
-	if err == nil && pr != nil {
+	if pr != nil {
 		if c.logger != nil {
-			c.logger.Info("Found PR via branch pattern",
+			c.logger.Info("Found unlinked PR for issue",
 				"issue_number", issueNumber,
 				"pr_number", pr.Number,
+				"method", method,
 			)
 		}
 		return pr, nil
@@ -86,114 +88,59 @@ func (c *GHClient) searchByLinkedQuery(ctx context.Context, issueNumber int) (*P
 	return nil, nil
 }
 
-// searchByBodyMention はPR本文にIssue番号が記載されているPRを検索
-func (c *GHClient) searchByBodyMention(ctx context.Context, issueNumber int) (*PullRequest, error) {
-	// すべてのオープンPRを取得
+// searchOpenPullRequests はオープンなPRからIssueのPRを探し、見つかったPRと方法を返す
+// osobaのブランチ名（osoba/#N）を最優先し、次に本文、最後にコミットのトレーラーのクローズキーワードで探す
+func (c *GHClient) searchOpenPullRequests(ctx context.Context, issueNumber int) (*PullRequest, string, error) {
 	args := []string{
 		"pr", "list",
-		"--json", "number,title,state,mergeable,isDraft,headRefName,statusCheckRollup,body",
+		"--json", "number,title,state,mergeable,isDraft,headRefName,statusCheckRollup,body,commits",
 		"--state", "open",
 		"--limit", "100",
 	}
 
 	output, err := c.executeGHCommand(ctx, args...)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	var prs []struct {
 		pullRequestWithStatus
-		Body string `json:"body"`
+		Body    string `json:"body"`
+		Commits []struct {
+			MessageHeadline string `json:"messageHeadline"`
+			MessageBody     string `json:"messageBody"`
+		} `json:"commits"`
 	}
-
 	if err := json.Unmarshal(output, &prs); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	// Issue番号の記載パターン
-	patterns := []*regexp.Regexp{
-		regexp.MustCompile(fmt.Sprintf(`(?i)fixes\s+#%d\b`, issueNumber)),
-		regexp.MustCompile(fmt.Sprintf(`(?i)closes\s+#%d\b`, issueNumber)),
-		regexp.MustCompile(fmt.Sprintf(`(?i)resolves\s+#%d\b`, issueNumber)),
-		regexp.MustCompile(fmt.Sprintf(`#%d\b`, issueNumber)),
-	}
-
-	for _, pr := range prs {
-		for _, pattern := range patterns {
-			if pattern.MatchString(pr.Body) {
-				if c.logger != nil {
-					c.logger.Debug("Found PR with issue mention in body",
-						"issue_number", issueNumber,
-						"pr_number", pr.Number,
-						"pattern", pattern.String(),
-					)
-				}
-				return convertToPullRequest(&pr.pullRequestWithStatus), nil
-			}
+	branch := fmt.Sprintf("osoba/#%d", issueNumber)
+	for i := range prs {
+		if prs[i].HeadRefName == branch {
+			return convertToPullRequest(&prs[i].pullRequestWithStatus), "branch", nil
 		}
 	}
-
-	return nil, nil
-}
-
-// searchByBranchPattern はブランチ名パターンでPRを検索
-func (c *GHClient) searchByBranchPattern(ctx context.Context, issueNumber int) (*PullRequest, error) {
-	branchPatterns := []string{
-		fmt.Sprintf("issue-%d", issueNumber),
-		fmt.Sprintf("fix-%d", issueNumber),
-		fmt.Sprintf("feature-%d", issueNumber),
-		fmt.Sprintf("issue/%d", issueNumber),
-		fmt.Sprintf("fix/%d", issueNumber),
-		fmt.Sprintf("feature/%d", issueNumber),
-		fmt.Sprintf("issue/%03d", issueNumber),
-		fmt.Sprintf("fix/%03d", issueNumber),
-	}
-
-	for _, pattern := range branchPatterns {
-		pr, err := c.SearchPullRequestByBranch(ctx, pattern)
-		if err != nil {
-			continue
+	for i := range prs {
+		if ClosesIssue(prs[i].Body, c.owner, c.repo, issueNumber) {
+			return convertToPullRequest(&prs[i].pullRequestWithStatus), "body", nil
 		}
-		if pr != nil {
-			return pr, nil
-		}
-	}
-
-	// 正規表現パターンで幅広く検索
-	args := []string{
-		"pr", "list",
-		"--json", "number,title,state,mergeable,isDraft,headRefName,statusCheckRollup",
-		"--state", "open",
-		"--limit", "100",
-	}
-
-	output, err := c.executeGHCommand(ctx, args...)
-	if err != nil {
-		return nil, err
 	}
-
-	var prs []pullRequestWithStatus
-	if err := json.Unmarshal(output, &prs); err != nil {
-		return nil, err
-	}
-
-	// Issue番号を含むブランチ名のパターン
-	branchRegex := regexp.MustCompile(fmt.Sprintf(`\b%d\b`, issueNumber))
-
-	for _, pr := range prs {
-		if branchRegex.MatchString(pr.HeadRefName) {
-			if c.logger != nil {
-				c.logger.Debug("Found PR with issue number in branch name",
-					"issue_number", issueNumber,
-					"pr_number", pr.Number,
-					"branch", pr.HeadRefName,
-				)
+	trailer := commitTrailerPattern(issueNumber)
+	for i := range prs {
+		for _, commit := range prs[i].Commits {
+			if trailer.MatchString(commit.MessageHeadline) || trailer.MatchString(commit.MessageBody) {
+				return convertToPullRequest(&prs[i].pullRequestWithStatus), "commit_trailer", nil
 			}
-			return convertToPullRequest(&pr), nil
 		}
 	}
+	return nil, "", nil
+}
 
-	return nil, nil
+// commitTrailerPattern はクローズキーワードでIssue番号を参照するコミットのトレーラー行（例: "Fixes #12"、"Closes: #12"）の正規表現を返す
+// Refs #N などクローズしない参照は、関連するだけのPRを誤って対象にしないよう含めない
+func commitTrailerPattern(issueNumber int) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf(`(?im)^\s*(fix(es|ed)?|close[sd]?|resolve[sd]?)\s*:?\s*#%d\b`, issueNumber))
 }
 
 // convertToPullRequest はpullRequestWithStatusをPullRequestに変換
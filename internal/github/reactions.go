@@ -0,0 +1,76 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Reaction はIssueコメントへのリアクション
+type Reaction struct {
+	ID      *int64  `json:"id,omitempty"`
+	Content *string `json:"content,omitempty"` // +1, -1, laugh, confused, heart, hooray, rocket, eyes
+	User    *User   `json:"user,omitempty"`
+}
+
+// PlanApprovalChecker はコメントのリアクションと投稿者の権限を取得する（計画の承認を判定するPlanApprovalGateが使う）
+type PlanApprovalChecker interface {
+	ListCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*Reaction, error)
+	GetCollaboratorPermission(ctx context.Context, owner, repo, username string) (string, error)
+}
+
+var _ PlanApprovalChecker = (*GHClient)(nil)
+
+// ListCommentReactions はIssueコメントへのリアクション一覧を取得する
+func (c *GHClient) ListCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*Reaction, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/comments/%s/reactions", owner, repo, strconv.FormatInt(commentID, 10))
+	output, err := c.executeGHCommand(ctx, "api", endpoint, "--paginate", "--jq", ".[]")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment reactions: %w", err)
+	}
+
+	var reactions []*Reaction
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for {
+		var reaction Reaction
+		if err := decoder.Decode(&reaction); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse comment reactions: %w", err)
+		}
+		reactions = append(reactions, &reaction)
+	}
+	return reactions, nil
+}
+
+// GetCollaboratorPermission はユーザーのリポジトリに対する権限（admin, maintain, write, triage, read, none）を取得する
+func (c *GHClient) GetCollaboratorPermission(ctx context.Context, owner, repo, username string) (string, error) {
+	if owner == "" {
+		return "", errors.New("owner is required")
+	}
+	if repo == "" {
+		return "", errors.New("repo is required")
+	}
+	if username == "" {
+		return "", errors.New("username is required")
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/%s/collaborators/%s/permission", owner, repo, username)
+	output, err := c.executeGHCommand(ctx, "api", endpoint, "--jq", ".role_name")
+	if err != nil {
+		return "", fmt.Errorf("failed to get collaborator permission: %w", err)
+	}
+	return string(bytes.TrimSpace(output)), nil
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_ListCommentReactions(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`{"id":1,"content":"+1","user":{"login":"alice"}}
+{"id":2,"content":"eyes","user":{"login":"bob"}}
+`), nil
+	}
+
+	client := &GHClient{}
+	reactions, err := client.ListCommentReactions(context.Background(), "owner", "repo", 42)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "repos/owner/repo/issues/comments/42/reactions", "--paginate", "--jq", ".[]"}, gotArgs)
+	require.Len(t, reactions, 2)
+	assert.Equal(t, "+1", *reactions[0].Content)
+	assert.Equal(t, "alice", *reactions[0].User.Login)
+	assert.Equal(t, "eyes", *reactions[1].Content)
+}
+
+func TestGHClient_GetCollaboratorPermission(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte("maintain\n"), nil
+	}
+
+	client := &GHClient{}
+	permission, err := client.GetCollaboratorPermission(context.Background(), "owner", "repo", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "repos/owner/repo/collaborators/alice/permission", "--jq", ".role_name"}, gotArgs)
+	assert.Equal(t, "maintain", permission)
+
+	_, err = client.GetCollaboratorPermission(context.Background(), "owner", "repo", "")
+	assert.EqualError(t, err, "username is required")
+}
@@ -0,0 +1,49 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// BranchDeleter はリモートのブランチを削除する（マージ後のブランチを片付けるRemoteBranchCleanerが使う）
+type BranchDeleter interface {
+	DeleteBranch(ctx context.Context, owner, repo, branch string) error
+}
+
+var _ BranchDeleter = (*GHClient)(nil)
+
+// DeleteBranch はリモートのブランチを削除する（すでに削除されている場合は何もしない）
+func (c *GHClient) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	if owner == "" {
+		return errors.New("owner is required")
+	}
+	if repo == "" {
+		return errors.New("repo is required")
+	}
+	if branch == "" {
+		return errors.New("branch is required")
+	}
+
+	_, err := c.executeGHCommand(ctx, "api", "-X", "DELETE", fmt.Sprintf("repos/%s/%s/git/refs/heads/%s", owner, repo, escapeBranchPath(branch)))
+	if err != nil {
+		// GitHubの設定（マージ後にブランチを自動で削除する）などで削除済みのブランチは422になる
+		if strings.Contains(err.Error(), "Reference does not exist") {
+			return nil
+		}
+		return fmt.Errorf("failed to delete branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// escapeBranchPath はブランチ名をAPIのパスに埋め込めるようエスケープする（osoba/#12の#など）
+// 区切りの/はそのまま残す
+func escapeBranchPath(branch string) string {
+	segments := strings.Split(branch, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,44 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_DeleteBranch(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	tests := []struct {
+		name    string
+		output  string
+		err     error
+		wantErr bool
+	}{
+		{name: "ブランチを削除する"},
+		{name: "削除済みのブランチはエラーにしない", output: `{"message":"Reference does not exist"} (HTTP 422)`, err: errors.New("exit status 1")},
+		{name: "権限がない場合はエラー", output: "gh: Resource not accessible by integration (HTTP 403)", err: errors.New("exit status 1"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+				gotArgs = args
+				return []byte(tt.output), tt.err
+			}
+
+			err := (&GHClient{}).DeleteBranch(context.Background(), "owner", "repo", "osoba/#12")
+			assert.Equal(t, []string{"api", "-X", "DELETE", "repos/owner/repo/git/refs/heads/osoba/%2312"}, gotArgs)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
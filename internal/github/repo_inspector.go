@@ -0,0 +1,97 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RepositoryInspector はデフォルトブランチ・ブランチ保護・CIワークフロー・ファイルの有無を確認する（起動時の前提条件の確認などに使う）
+type RepositoryInspector interface {
+	GetDefaultBranch(ctx context.Context, owner, repo string) (string, error)
+	IsBranchProtected(ctx context.Context, owner, repo, branch string) (bool, error)
+	ListWorkflowPaths(ctx context.Context, owner, repo string) ([]string, error)
+	FileExists(ctx context.Context, owner, repo, path string) (bool, error)
+}
+
+var _ RepositoryInspector = (*GHClient)(nil)
+
+// GetDefaultBranch はリポジトリのデフォルトブランチ名を返す
+func (c *GHClient) GetDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
+	if owner == "" {
+		return "", errors.New("owner is required")
+	}
+	if repo == "" {
+		return "", errors.New("repo is required")
+	}
+
+	output, err := c.executeGHCommand(ctx, "api", fmt.Sprintf("repos/%s/%s", owner, repo), "--jq", ".default_branch")
+	if err != nil {
+		return "", fmt.Errorf("failed to get default branch: %w", err)
+	}
+	branch := string(bytes.TrimSpace(output))
+	if branch == "" {
+		return "", errors.New("default branch is empty")
+	}
+	return branch, nil
+}
+
+// IsBranchProtected はブランチが保護されているか（ブランチ保護ルールまたはルールセットの対象か）を返す
+func (c *GHClient) IsBranchProtected(ctx context.Context, owner, repo, branch string) (bool, error) {
+	if owner == "" {
+		return false, errors.New("owner is required")
+	}
+	if repo == "" {
+		return false, errors.New("repo is required")
+	}
+	if branch == "" {
+		return false, errors.New("branch is required")
+	}
+
+	output, err := c.executeGHCommand(ctx, "api", fmt.Sprintf("repos/%s/%s/branches/%s", owner, repo, escapeBranchPath(branch)), "--jq", ".protected")
+	if err != nil {
+		return false, fmt.Errorf("failed to get branch: %w", err)
+	}
+	return string(bytes.TrimSpace(output)) == "true", nil
+}
+
+// ListWorkflowPaths は有効なGitHub Actionsワークフローのファイルパスを返す
+func (c *GHClient) ListWorkflowPaths(ctx context.Context, owner, repo string) ([]string, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+
+	output, err := c.executeGHCommand(ctx, "api", fmt.Sprintf("repos/%s/%s/actions/workflows", owner, repo),
+		"--paginate", "--jq", `.workflows[] | select(.state == "active") | .path`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflows: %w", err)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// FileExists はデフォルトブランチにファイルが存在するかを返す
+func (c *GHClient) FileExists(ctx context.Context, owner, repo, path string) (bool, error) {
+	if owner == "" {
+		return false, errors.New("owner is required")
+	}
+	if repo == "" {
+		return false, errors.New("repo is required")
+	}
+	if path == "" {
+		return false, errors.New("path is required")
+	}
+
+	_, err := c.executeGHCommand(ctx, "api", fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, path), "--jq", ".path")
+	if err != nil {
+		if ParseGHError(err.Error(), err).Type == ErrorTypeNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get file: %w", err)
+	}
+	return true, nil
+}
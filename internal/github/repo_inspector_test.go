@@ -0,0 +1,55 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_RepositoryInspector(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	responses := map[string]string{
+		"repos/owner/repo":                             "main\n",
+		"repos/owner/repo/branches/main":               "true\n",
+		"repos/owner/repo/actions/workflows":           ".github/workflows/ci.yml\n.github/workflows/release.yml\n",
+		"repos/owner/repo/contents/.github/CODEOWNERS": ".github/CODEOWNERS\n",
+	}
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		if output, ok := responses[args[1]]; ok {
+			return []byte(output), nil
+		}
+		return []byte("gh: Not Found (HTTP 404)"), errors.New("exit status 1")
+	}
+
+	client := &GHClient{}
+	ctx := context.Background()
+
+	branch, err := client.GetDefaultBranch(ctx, "owner", "repo")
+	require.NoError(t, err)
+	assert.Equal(t, "main", branch)
+
+	protected, err := client.IsBranchProtected(ctx, "owner", "repo", "main")
+	require.NoError(t, err)
+	assert.True(t, protected)
+
+	workflows, err := client.ListWorkflowPaths(ctx, "owner", "repo")
+	require.NoError(t, err)
+	assert.Equal(t, []string{".github/workflows/ci.yml", ".github/workflows/release.yml"}, workflows)
+
+	exists, err := client.FileExists(ctx, "owner", "repo", ".github/CODEOWNERS")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// 存在しないファイルはエラーにしない
+	exists, err = client.FileExists(ctx, "owner", "repo", "CODEOWNERS")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = client.IsBranchProtected(ctx, "owner", "repo", "develop")
+	assert.Error(t, err)
+}
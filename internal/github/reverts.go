@@ -0,0 +1,130 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	// revertBodyPattern はGitHubのRevertボタンが作成するPR本文（Reverts owner/repo#123）
+	revertBodyPattern = regexp.MustCompile(`(?m)^Reverts\s+(?:[\w.-]+/[\w.-]+)?#(\d+)`)
+	// revertBranchPattern はGitHubのRevertボタンが作成するブランチ名（revert-123-<元のブランチ>）
+	revertBranchPattern = regexp.MustCompile(`^revert-(\d+)-`)
+)
+
+// MergedPullRequest はマージ済みPRの情報
+type MergedPullRequest struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	State       string    `json:"state"`
+	HeadRefName string    `json:"headRefName"`
+	MergedAt    time.Time `json:"mergedAt"`
+	URL         string    `json:"url"`
+	// ClosingIssuesReferences はPRのマージでクローズされるIssue
+	ClosingIssuesReferences []struct {
+		Number int `json:"number"`
+	} `json:"closingIssuesReferences"`
+}
+
+// ClosingIssueNumber はPRがクローズするIssueの番号を返す（存在しない場合は0）
+func (pr *MergedPullRequest) ClosingIssueNumber() int {
+	if len(pr.ClosingIssuesReferences) == 0 {
+		return 0
+	}
+	return pr.ClosingIssuesReferences[0].Number
+}
+
+// RevertedNumber はPRが別のPRをRevertするものであれば、Revert対象のPR番号を返す
+func (pr *MergedPullRequest) RevertedNumber() (int, bool) {
+	if m := revertBodyPattern.FindStringSubmatch(pr.Body); m != nil {
+		n, err := strconv.Atoi(m[1])
+		return n, err == nil
+	}
+	if m := revertBranchPattern.FindStringSubmatch(pr.HeadRefName); m != nil {
+		n, err := strconv.Atoi(m[1])
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// RevertTracker はマージ済みPRの取得とIssueの再オープンを行う（Revertを検出するRevertDetectorが使う）
+type RevertTracker interface {
+	ListMergedPullRequests(ctx context.Context, owner, repo string, since time.Time) ([]*MergedPullRequest, error)
+	GetMergedPullRequest(ctx context.Context, owner, repo string, prNumber int) (*MergedPullRequest, error)
+	ReopenIssue(ctx context.Context, owner, repo string, issueNumber int) error
+}
+
+var _ RevertTracker = (*GHClient)(nil)
+
+// mergedPullRequestFields はマージ済みPRの取得に使用するフィールド
+const mergedPullRequestFields = "number,title,body,state,headRefName,mergedAt,url,closingIssuesReferences"
+
+// ListMergedPullRequests はsince以降にマージされたPRを返す
+func (c *GHClient) ListMergedPullRequests(ctx context.Context, owner, repo string, since time.Time) ([]*MergedPullRequest, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+
+	output, err := c.executeGHCommand(ctx, "pr", "list",
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--state", "merged",
+		"--search", "merged:>="+since.UTC().Format(time.RFC3339),
+		"--json", mergedPullRequestFields,
+		"--limit", "100")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merged pull requests: %w", err)
+	}
+
+	var prs []*MergedPullRequest
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return nil, fmt.Errorf("failed to parse merged pull requests: %w", err)
+	}
+	return prs, nil
+}
+
+// GetMergedPullRequest はPRの情報を返す（マージされていない場合もStateを含めて返す）
+func (c *GHClient) GetMergedPullRequest(ctx context.Context, owner, repo string, prNumber int) (*MergedPullRequest, error) {
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+
+	output, err := c.executeGHCommand(ctx, "pr", "view", strconv.Itoa(prNumber),
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--json", mergedPullRequestFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	var pr MergedPullRequest
+	if err := json.Unmarshal(output, &pr); err != nil {
+		return nil, fmt.Errorf("failed to parse pull request: %w", err)
+	}
+	return &pr, nil
+}
+
+// ReopenIssue はクローズされたIssueを再オープンする
+func (c *GHClient) ReopenIssue(ctx context.Context, owner, repo string, issueNumber int) error {
+	if owner == "" {
+		return errors.New("owner is required")
+	}
+	if repo == "" {
+		return errors.New("repo is required")
+	}
+
+	if _, err := c.executeGHCommand(ctx, "issue", "reopen", strconv.Itoa(issueNumber), "--repo", fmt.Sprintf("%s/%s", owner, repo)); err != nil {
+		return fmt.Errorf("failed to reopen issue: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergedPullRequest_RevertedNumber(t *testing.T) {
+	tests := []struct {
+		name   string
+		pr     MergedPullRequest
+		want   int
+		wantOK bool
+	}{
+		{
+			name:   "Revertボタンが作成した本文",
+			pr:     MergedPullRequest{Body: "Reverts owner/repo#25\n\n理由: 本番で障害"},
+			want:   25,
+			wantOK: true,
+		},
+		{
+			name:   "リポジトリを省略した本文",
+			pr:     MergedPullRequest{Body: "Reverts #7"},
+			want:   7,
+			wantOK: true,
+		},
+		{
+			name:   "Revertボタンが作成したブランチ名",
+			pr:     MergedPullRequest{HeadRefName: "revert-42-osoba/#12"},
+			want:   42,
+			wantOK: true,
+		},
+		{
+			name: "Revertでない",
+			pr:   MergedPullRequest{Body: "This reverts nothing #3", HeadRefName: "osoba/#3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.pr.RevertedNumber()
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGHClient_ListMergedPullRequests(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+	var gotArgs []string
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`[{"number":30,"title":"Revert \"x\"","body":"Reverts owner/repo#25","state":"MERGED","headRefName":"revert-25-osoba/#12","mergedAt":"2026-10-16T10:00:00Z","url":"https://github.com/owner/repo/pull/30"}]`), nil
+	}
+
+	client := &GHClient{}
+	since := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	prs, err := client.ListMergedPullRequests(context.Background(), "owner", "repo", since)
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, 30, prs[0].Number)
+	assert.Contains(t, gotArgs, "merged:>=2026-10-15T12:00:00Z")
+	assert.Contains(t, gotArgs, "merged")
+}
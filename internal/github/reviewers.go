@@ -0,0 +1,37 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ReviewRequester はPRにレビューを依頼する（レビューの往復が続いた時に人に引き継ぐReviewEscalatorが使う）
+type ReviewRequester interface {
+	RequestPullRequestReviewers(ctx context.Context, owner, repo string, prNumber int, reviewers []string) error
+}
+
+var _ ReviewRequester = (*GHClient)(nil)
+
+// RequestPullRequestReviewers はPRにレビュアーを追加する
+// レビュアーにはユーザー名またはチーム（org/team）を指定できる
+func (c *GHClient) RequestPullRequestReviewers(ctx context.Context, owner, repo string, prNumber int, reviewers []string) error {
+	if owner == "" {
+		return errors.New("owner is required")
+	}
+	if repo == "" {
+		return errors.New("repo is required")
+	}
+	if len(reviewers) == 0 {
+		return errors.New("at least one reviewer is required")
+	}
+
+	if _, err := c.executeGHCommand(ctx, "pr", "edit", strconv.Itoa(prNumber),
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--add-reviewer", strings.Join(reviewers, ",")); err != nil {
+		return fmt.Errorf("failed to request reviewers: %w", err)
+	}
+	return nil
+}
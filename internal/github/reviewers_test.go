@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGHClient_RequestPullRequestReviewers(t *testing.T) {
+	origRun := runGHCommand
+	defer func() { runGHCommand = origRun }()
+
+	tests := []struct {
+		name      string
+		reviewers []string
+		wantArgs  []string
+		wantErr   string
+	}{
+		{
+			name:      "ユーザーとチームを追加",
+			reviewers: []string{"alice", "org/team"},
+			wantArgs:  []string{"pr", "edit", "42", "--repo", "owner/repo", "--add-reviewer", "alice,org/team"},
+		},
+		{
+			name:    "レビュアーの指定なし",
+			wantErr: "at least one reviewer is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+				gotArgs = args
+				return nil, nil
+			}
+
+			client := &GHClient{}
+			err := client.RequestPullRequestReviewers(context.Background(), "owner", "repo", 42, tt.reviewers)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, gotArgs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultThrottleDelay はRetry-Afterが示されない場合の待機時間（GitHubは最低1分の待機を推奨）
+	defaultThrottleDelay = time.Minute
+	// maxThrottleDelay は1回のスロットリングで待機する最大時間
+	maxThrottleDelay = 15 * time.Minute
+)
+
+// テスト時に差し替え可能な時刻取得関数
+var throttleNow = time.Now
+
+// ThrottleStatus はGitHubによるスロットリング（レート制限・不正利用検知）の状態
+type ThrottleStatus struct {
+	Throttled bool      `json:"throttled"`
+	Until     time.Time `json:"until"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// ThrottleStatusProvider はスロットリング状態を提供するクライアントのインターフェース
+type ThrottleStatusProvider interface {
+	ThrottleStatus() ThrottleStatus
+}
+
+// throttleState はクライアントのスロットリング状態
+type throttleState struct {
+	mu     sync.Mutex
+	until  time.Time
+	reason string
+}
+
+// IsThrottledError はGitHubによるスロットリングでAPI呼び出しが拒否されたエラーかを判定する
+func IsThrottledError(err error) bool {
+	return IsRateLimitError(err)
+}
+
+// ThrottleRetryAfter はスロットリングエラーで示された待機時間を返す
+func ThrottleRetryAfter(err error) time.Duration {
+	var ghErr *GitHubError
+	if errors.As(err, &ghErr) && ghErr.Type == ErrorTypeRateLimit {
+		return throttleDelay(ghErr)
+	}
+	return 0
+}
+
+// throttleDelay はスロットリングエラーから待機時間を決定する
+func throttleDelay(ghErr *GitHubError) time.Duration {
+	delay := ghErr.RetryAfter
+	if delay <= 0 {
+		delay = defaultThrottleDelay
+	}
+	if delay > maxThrottleDelay {
+		delay = maxThrottleDelay
+	}
+	return delay
+}
+
+// ThrottleStatus は現在のスロットリング状態を返す
+func (c *GHClient) ThrottleStatus() ThrottleStatus {
+	c.throttle.mu.Lock()
+	defer c.throttle.mu.Unlock()
+
+	if !throttleNow().Before(c.throttle.until) {
+		return ThrottleStatus{}
+	}
+	return ThrottleStatus{
+		Throttled: true,
+		Until:     c.throttle.until,
+		Reason:    c.throttle.reason,
+	}
+}
+
+// recordThrottle はスロットリングを記録し、新たにスロットリング状態になった場合はtrueを返す
+func (c *GHClient) recordThrottle(ghErr *GitHubError) bool {
+	c.throttle.mu.Lock()
+	defer c.throttle.mu.Unlock()
+
+	now := throttleNow()
+	wasThrottled := now.Before(c.throttle.until)
+	until := now.Add(throttleDelay(ghErr))
+	if until.After(c.throttle.until) {
+		c.throttle.until = until
+	}
+	c.throttle.reason = ghErr.Message
+	return !wasThrottled
+}
+
+// waitForThrottle はスロットリング中であれば指定された時間まで待機する
+func (c *GHClient) waitForThrottle(ctx context.Context) error {
+	c.throttle.mu.Lock()
+	wait := c.throttle.until.Sub(throttleNow())
+	c.throttle.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	if c.logger != nil {
+		c.logger.Info("GitHub throttled, waiting before next request",
+			"wait", wait.Round(time.Second),
+		)
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGHError_Throttled(t *testing.T) {
+	tests := []struct {
+		name           string
+		errOutput      string
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:           "セカンダリレート制限（Retry-Afterあり）",
+			errOutput:      "HTTP 403: You have exceeded a secondary rate limit. Please wait a few minutes before you try again.\nRetry-After: 30",
+			wantRetryAfter: 30 * time.Second,
+		},
+		{
+			name:           "不正利用検知",
+			errOutput:      "HTTP 403: You have triggered an abuse detection mechanism. Please wait a few minutes before you try again.",
+			wantRetryAfter: defaultThrottleDelay,
+		},
+		{
+			name:           "コンテンツ作成の連続投稿",
+			errOutput:      "GraphQL: was submitted too quickly (createIssue)",
+			wantRetryAfter: defaultThrottleDelay,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ParseGHError(tt.errOutput, errors.New("exit status 1"))
+			assert.Equal(t, ErrorTypeRateLimit, err.Type)
+			assert.True(t, IsThrottledError(err))
+			assert.Equal(t, tt.wantRetryAfter, ThrottleRetryAfter(err))
+		})
+	}
+}
+
+func TestGHClient_ExecuteGHCommand_Throttled(t *testing.T) {
+	origRun := runGHCommand
+	origNow := throttleNow
+	defer func() {
+		runGHCommand = origRun
+		throttleNow = origNow
+	}()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	throttleNow = func() time.Time { return now }
+
+	calls := 0
+	runGHCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		calls++
+		if calls == 1 {
+			return []byte("HTTP 403: You have exceeded a secondary rate limit.\nRetry-After: 30"), errors.New("exit status 1")
+		}
+		return []byte("ok"), nil
+	}
+
+	client := &GHClient{}
+
+	// スロットリング応答は構造化エラーとして返し、待機時間を記録する
+	_, err := client.executeGHCommand(context.Background(), "issue", "list")
+	require.Error(t, err)
+	assert.True(t, IsThrottledError(err))
+
+	status := client.ThrottleStatus()
+	assert.True(t, status.Throttled)
+	assert.Equal(t, now.Add(30*time.Second), status.Until)
+
+	// スロットリング中はコマンドを実行せずに待機する（コンテキストのキャンセルで中断）
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.executeGHCommand(ctx, "issue", "list")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+
+	// Retry-Afterの経過後は通常どおり実行する
+	now = now.Add(31 * time.Second)
+	assert.False(t, client.ThrottleStatus().Throttled)
+	output, err := client.executeGHCommand(context.Background(), "issue", "list")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(output))
+	assert.Equal(t, 2, calls)
+}
@@ -3,6 +3,7 @@ package logger
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -24,8 +25,9 @@ type zapLogger struct {
 
 // Config はロガーの設定
 type Config struct {
-	Level  string
-	Format string
+	Level    string
+	Format   string
+	Location *time.Location // ログの時刻のタイムゾーン（nilの場合はホストのタイムゾーン）
 }
 
 // Option はロガーの設定オプション
@@ -45,6 +47,13 @@ func WithFormat(format string) Option {
 	}
 }
 
+// WithLocation はログの時刻のタイムゾーンを設定するオプション
+func WithLocation(loc *time.Location) Option {
+	return func(c *Config) {
+		c.Location = loc
+	}
+}
+
 // New は新しいロガーを作成する
 func New(opts ...Option) (Logger, error) {
 	config := &Config{
@@ -76,6 +85,11 @@ func New(opts ...Option) (Logger, error) {
 		EncodeDuration: zapcore.SecondsDurationEncoder,
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 	}
+	if loc := config.Location; loc != nil {
+		encoderConfig.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			zapcore.ISO8601TimeEncoder(t.In(loc), enc)
+		}
+	}
 
 	// エンコーダーの作成
 	var encoder zapcore.Encoder
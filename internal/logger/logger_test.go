@@ -2,6 +2,7 @@ package logger
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -87,6 +88,14 @@ func TestWithOptions(t *testing.T) {
 		assert.NotNil(t, logger)
 	})
 
+	t.Run("時刻のタイムゾーンを設定できる", func(t *testing.T) {
+		logger, err := New(WithLocation(time.UTC))
+		require.NoError(t, err)
+		assert.NotPanics(t, func() {
+			logger.Info("info message", "key", "value")
+		})
+	})
+
 	t.Run("複数のオプションを同時に設定できる", func(t *testing.T) {
 		logger, err := New(
 			WithLevel("debug"),
@@ -0,0 +1,240 @@
+// Package metrics は同じホストで動作する複数の監視プロセスのメトリクスを集約する
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/paths"
+)
+
+// ControlCommand は監視プロセスの制御ソケットにメトリクスを要求するコマンド
+const ControlCommand = "metrics"
+
+// Counters は試行回数と成否の内訳
+type Counters struct {
+	Total      int64 `json:"total"`
+	Successful int64 `json:"successful"`
+	Failed     int64 `json:"failed"`
+}
+
+// Snapshot は監視プロセス1つのメトリクス
+type Snapshot struct {
+	Repo             string    `json:"repo"` // owner/repo
+	PID              int       `json:"pid"`
+	StartTime        time.Time `json:"start_time"`
+	IssuePolls       Counters  `json:"issue_polls"`
+	PRPolls          Counters  `json:"pr_polls"`
+	LastIssuePoll    time.Time `json:"last_issue_poll"`
+	LastPRPoll       time.Time `json:"last_pr_poll"`
+	LabelTransitions Counters  `json:"label_transitions"`
+	AutoMerges       Counters  `json:"auto_merges"`
+	// Degraded はghコマンド・フェーズの開始の失敗が続いている不健全な状態の要約（健全な場合は空）
+	Degraded string `json:"degraded,omitempty"`
+}
+
+// Encode は制御ソケットの応答（1行）として送るJSONを返す
+func (s Snapshot) Encode() (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Daemon は制御ソケット1つから取得した結果
+type Daemon struct {
+	Socket   string
+	Snapshot *Snapshot // 取得できなかった場合はnil
+	Err      error
+}
+
+// Name はメトリクスのrepoラベルに使う名前を返す（取得できなかった場合はソケットのファイル名）
+func (d Daemon) Name() string {
+	if d.Snapshot != nil && d.Snapshot.Repo != "" {
+		return d.Snapshot.Repo
+	}
+	return strings.TrimSuffix(filepath.Base(d.Socket), ".sock")
+}
+
+// SendFunc は制御ソケットにコマンドを送り、応答メッセージを返す
+type SendFunc func(path, command string) (string, error)
+
+// Collector は実行ディレクトリにある制御ソケットすべてからメトリクスを取得する
+type Collector struct {
+	paths paths.PathManager
+	send  SendFunc
+}
+
+// NewCollector は新しいCollectorを作成する
+func NewCollector(pm paths.PathManager, send SendFunc) (*Collector, error) {
+	if pm == nil {
+		return nil, errors.New("path manager is required")
+	}
+	if send == nil {
+		return nil, errors.New("send function is required")
+	}
+	return &Collector{paths: pm, send: send}, nil
+}
+
+// Collect はすべての監視プロセスに並行してメトリクスを要求し、ソケットのパス順に返す
+// 応答しない監視プロセス（停止後に残ったソケットなど）はErrを設定して返す
+func (c *Collector) Collect() ([]Daemon, error) {
+	sockets, err := c.paths.AllControlSockets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list control sockets: %w", err)
+	}
+	sort.Strings(sockets)
+
+	daemons := make([]Daemon, len(sockets))
+	var wg sync.WaitGroup
+	for i, socket := range sockets {
+		wg.Add(1)
+		go func(i int, socket string) {
+			defer wg.Done()
+			daemons[i] = c.collectOne(socket)
+		}(i, socket)
+	}
+	wg.Wait()
+	return daemons, nil
+}
+
+func (c *Collector) collectOne(socket string) Daemon {
+	reply, err := c.send(socket, ControlCommand)
+	if err != nil {
+		return Daemon{Socket: socket, Err: err}
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal([]byte(reply), &snapshot); err != nil {
+		return Daemon{Socket: socket, Err: fmt.Errorf("failed to parse metrics: %w", err)}
+	}
+	return Daemon{Socket: socket, Snapshot: &snapshot}
+}
+
+// labelEscaper はPrometheusのラベル値をエスケープする
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WritePrometheus はPrometheusのテキスト形式でメトリクスを書き出す
+// どのメトリクスもrepoラベルで監視プロセスを区別する
+func WritePrometheus(w io.Writer, daemons []Daemon) error {
+	var b strings.Builder
+	family := func(name, kind, help string, samples func(d Daemon, repo string)) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+		for _, d := range daemons {
+			samples(d, labelEscaper.Replace(d.Name()))
+		}
+	}
+	counters := func(name, help string, get func(s *Snapshot) Counters) {
+		family(name, "counter", help, func(d Daemon, repo string) {
+			if d.Snapshot == nil {
+				return
+			}
+			c := get(d.Snapshot)
+			fmt.Fprintf(&b, "%s{repo=\"%s\",result=\"success\"} %d\n", name, repo, c.Successful)
+			fmt.Fprintf(&b, "%s{repo=\"%s\",result=\"failure\"} %d\n", name, repo, c.Failed)
+		})
+	}
+
+	family("osoba_daemon_up", "gauge", "Whether the daemon answered on its control socket.", func(d Daemon, repo string) {
+		up := 0
+		if d.Snapshot != nil {
+			up = 1
+		}
+		fmt.Fprintf(&b, "osoba_daemon_up{repo=\"%s\"} %d\n", repo, up)
+	})
+	family("osoba_daemon_start_time_seconds", "gauge", "Start time of the daemon in unix seconds.", func(d Daemon, repo string) {
+		if d.Snapshot != nil {
+			fmt.Fprintf(&b, "osoba_daemon_start_time_seconds{repo=\"%s\"} %d\n", repo, unixSeconds(d.Snapshot.StartTime))
+		}
+	})
+	family("osoba_last_poll_timestamp_seconds", "gauge", "Time of the last poll in unix seconds.", func(d Daemon, repo string) {
+		if d.Snapshot != nil {
+			fmt.Fprintf(&b, "osoba_last_poll_timestamp_seconds{repo=\"%s\",watcher=\"issue\"} %d\n", repo, unixSeconds(d.Snapshot.LastIssuePoll))
+			fmt.Fprintf(&b, "osoba_last_poll_timestamp_seconds{repo=\"%s\",watcher=\"pr\"} %d\n", repo, unixSeconds(d.Snapshot.LastPRPoll))
+		}
+	})
+	family("osoba_daemon_degraded", "gauge", "Whether the daemon is degraded by consecutive failures.", func(d Daemon, repo string) {
+		if d.Snapshot == nil {
+			return
+		}
+		degraded := 0
+		if d.Snapshot.Degraded != "" {
+			degraded = 1
+		}
+		fmt.Fprintf(&b, "osoba_daemon_degraded{repo=\"%s\"} %d\n", repo, degraded)
+	})
+	counters("osoba_issue_polls_total", "Issue polls by result.", func(s *Snapshot) Counters { return s.IssuePolls })
+	counters("osoba_pr_polls_total", "Pull request polls by result.", func(s *Snapshot) Counters { return s.PRPolls })
+	counters("osoba_label_transitions_total", "Label transitions by result.", func(s *Snapshot) Counters { return s.LabelTransitions })
+	counters("osoba_auto_merges_total", "Auto merge attempts by result.", func(s *Snapshot) Counters { return s.AutoMerges })
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// unixSeconds はUnix時刻（秒）を返す（ゼロ値の場合は0）
+func unixSeconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// Handler はスクレイプのたびにすべての監視プロセスのメトリクスを集約して返すHTTPハンドラーを返す
+func Handler(collector *Collector, log logger.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		daemons, err := collector.Collect()
+		if err != nil {
+			log.Warn("Failed to collect daemon metrics", "error", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, d := range daemons {
+			if d.Err != nil {
+				log.Debug("Daemon did not answer metrics request", "socket", d.Socket, "error", d.Err)
+			}
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := WritePrometheus(w, daemons); err != nil {
+			log.Warn("Failed to write metrics response", "error", err)
+		}
+	})
+}
+
+// HealthHandler はすべての監視プロセスが応答し、不健全な状態でない場合に200を返すHTTPハンドラーを返す
+// 応答しない監視プロセスや不健全な監視プロセスがある場合は503と、その監視プロセスの一覧を返す
+func HealthHandler(collector *Collector, log logger.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		daemons, err := collector.Collect()
+		if err != nil {
+			log.Warn("Failed to collect daemon health", "error", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		var problems []string
+		for _, d := range daemons {
+			switch {
+			case d.Snapshot == nil:
+				problems = append(problems, fmt.Sprintf("%s: down (%v)", d.Name(), d.Err))
+			case d.Snapshot.Degraded != "":
+				problems = append(problems, fmt.Sprintf("%s: degraded (%s)", d.Name(), d.Snapshot.Degraded))
+			}
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if len(problems) > 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = io.WriteString(w, strings.Join(problems, "\n")+"\n")
+			return
+		}
+		_, _ = io.WriteString(w, "ok\n")
+	})
+}
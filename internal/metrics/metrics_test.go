@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/paths"
+	"github.com/douhashi/osoba/internal/testutil/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// newTestCollector は実行ディレクトリに制御ソケット（空のファイル）を作成し、応答を返すCollectorを作成する
+func newTestCollector(t *testing.T, replies map[string]string) *Collector {
+	t.Helper()
+	pm := paths.NewPathManager(t.TempDir())
+	require.NoError(t, pm.EnsureDirectories())
+	for name := range replies {
+		require.NoError(t, os.WriteFile(filepath.Join(pm.RunDir(), name+".sock"), nil, 0600))
+	}
+	collector, err := NewCollector(pm, func(path, command string) (string, error) {
+		assert.Equal(t, ControlCommand, command)
+		reply := replies[strings.TrimSuffix(filepath.Base(path), ".sock")]
+		if reply == "" {
+			return "", errors.New("connection refused")
+		}
+		return reply, nil
+	})
+	require.NoError(t, err)
+	return collector
+}
+
+func encode(t *testing.T, s Snapshot) string {
+	t.Helper()
+	reply, err := s.Encode()
+	require.NoError(t, err)
+	return reply
+}
+
+func TestCollector_Collect(t *testing.T) {
+	collector := newTestCollector(t, map[string]string{
+		"douhashi_osoba": encode(t, Snapshot{Repo: "douhashi/osoba", PID: 100}),
+		"douhashi_other": "",
+		"douhashi_bad":   "not json",
+	})
+
+	daemons, err := collector.Collect()
+	require.NoError(t, err)
+	require.Len(t, daemons, 3)
+
+	// ソケットのパス順に返す
+	assert.Equal(t, "douhashi_bad", daemons[0].Name())
+	assert.ErrorContains(t, daemons[0].Err, "failed to parse metrics")
+	assert.Equal(t, "douhashi/osoba", daemons[1].Name())
+	require.NotNil(t, daemons[1].Snapshot)
+	assert.Equal(t, 100, daemons[1].Snapshot.PID)
+	assert.Equal(t, "douhashi_other", daemons[2].Name())
+	assert.Nil(t, daemons[2].Snapshot)
+	assert.Error(t, daemons[2].Err)
+}
+
+func TestWritePrometheus(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	daemons := []Daemon{
+		{Socket: "/run/douhashi_osoba.sock", Snapshot: &Snapshot{
+			Repo:             "douhashi/osoba",
+			StartTime:        start,
+			IssuePolls:       Counters{Total: 10, Successful: 9, Failed: 1},
+			PRPolls:          Counters{Total: 5, Successful: 5},
+			LastIssuePoll:    start.Add(time.Minute),
+			LabelTransitions: Counters{Total: 3, Successful: 2, Failed: 1},
+			AutoMerges:       Counters{Total: 1, Successful: 1},
+			Degraded:         "issues: 5回連続で失敗",
+		}},
+		{Socket: "/run/douhashi_other.sock", Err: errors.New("connection refused")},
+	}
+
+	var out strings.Builder
+	require.NoError(t, WritePrometheus(&out, daemons))
+	got := out.String()
+
+	for _, want := range []string{
+		"# TYPE osoba_daemon_up gauge\n",
+		`osoba_daemon_up{repo="douhashi/osoba"} 1`,
+		`osoba_daemon_up{repo="douhashi_other"} 0`,
+		`osoba_daemon_start_time_seconds{repo="douhashi/osoba"} 1735732800`,
+		`osoba_last_poll_timestamp_seconds{repo="douhashi/osoba",watcher="issue"} 1735732860`,
+		`osoba_last_poll_timestamp_seconds{repo="douhashi/osoba",watcher="pr"} 0`,
+		"# TYPE osoba_issue_polls_total counter\n",
+		`osoba_issue_polls_total{repo="douhashi/osoba",result="success"} 9`,
+		`osoba_issue_polls_total{repo="douhashi/osoba",result="failure"} 1`,
+		`osoba_pr_polls_total{repo="douhashi/osoba",result="success"} 5`,
+		`osoba_label_transitions_total{repo="douhashi/osoba",result="failure"} 1`,
+		`osoba_auto_merges_total{repo="douhashi/osoba",result="success"} 1`,
+		`osoba_daemon_degraded{repo="douhashi/osoba"} 1`,
+	} {
+		assert.Contains(t, got, want)
+	}
+	// 応答しない監視プロセスはosoba_daemon_up以外を出力しない
+	assert.Equal(t, 1, strings.Count(got, "douhashi_other"))
+}
+
+func TestHandler(t *testing.T) {
+	collector := newTestCollector(t, map[string]string{
+		"douhashi_osoba": encode(t, Snapshot{Repo: "douhashi/osoba"}),
+	})
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+
+	rec := httptest.NewRecorder()
+	Handler(collector, logger).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, rec.Body.String(), `osoba_daemon_up{repo="douhashi/osoba"} 1`)
+}
+
+func TestHealthHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		replies  map[string]string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "すべての監視プロセスが健全",
+			replies:  map[string]string{"douhashi_osoba": encode(t, Snapshot{Repo: "douhashi/osoba"})},
+			wantCode: http.StatusOK,
+			wantBody: "ok",
+		},
+		{
+			name: "失敗が続いている監視プロセスがある",
+			replies: map[string]string{
+				"douhashi_osoba": encode(t, Snapshot{Repo: "douhashi/osoba"}),
+				"douhashi_other": encode(t, Snapshot{Repo: "douhashi/other", Degraded: "issues: 5回連続で失敗"}),
+			},
+			wantCode: http.StatusServiceUnavailable,
+			wantBody: "douhashi/other: degraded (issues: 5回連続で失敗)",
+		},
+		{
+			name:     "応答しない監視プロセスがある",
+			replies:  map[string]string{"douhashi_osoba": ""},
+			wantCode: http.StatusServiceUnavailable,
+			wantBody: "douhashi_osoba: down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := newTestCollector(t, tt.replies)
+			logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+
+			rec := httptest.NewRecorder()
+			HealthHandler(collector, logger).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/douhashi/osoba/internal/watcher"
+)
+
+// legacyPhaseWindowPattern はフェーズごとにウィンドウを作成していた頃のウィンドウ名（144-plan）
+var legacyPhaseWindowPattern = regexp.MustCompile(`^(\d+)-(plan|implement|implementation|review)$`)
+
+// legacyWorktreePatterns はフェーズごとにworktreeを作成していた頃のパス
+var legacyWorktreePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`/\.git/worktree/[a-z]+/(\d+)$`),  // .git/worktree/plan/144
+	regexp.MustCompile(`/osoba/worktrees/(\d+)-[a-z]+$`), // .git/osoba/worktrees/144-plan
+}
+
+// migratePhaseWindows はフェーズ名のtmuxウィンドウをIssueのウィンドウ名に変更する
+// tmux.phasesでwindow: separateにしているフェーズのウィンドウは現在の形式のため変更しない
+func migratePhaseWindows(ctx context.Context, env *Env, journal *Journal) error {
+	if env.Tmux == nil {
+		return nil
+	}
+	fallbacks := fallbackWindows(env.PanesPath)
+	for _, sessionName := range env.Sessions {
+		windows, err := env.Tmux.ListWindows(sessionName)
+		if err != nil {
+			// セッションがない場合は移行するウィンドウもない
+			env.Logger.Debug("Skipping window migration for session", "session", sessionName, "error", err)
+			continue
+		}
+		existing := make(map[string]bool, len(windows))
+		for _, name := range windows {
+			existing[name] = true
+		}
+		sort.Strings(windows)
+
+		for _, name := range windows {
+			m := legacyPhaseWindowPattern.FindStringSubmatch(name)
+			if m == nil {
+				continue
+			}
+			phase := m[2]
+			if phase == "implementation" {
+				phase = "implement"
+			}
+			if env.Config.Tmux.GetPhasePaneConfig(phase).Window == config.WindowPolicySeparate {
+				continue
+			}
+			// 端末が小さいためにフォールバックしたフェーズ専用ウィンドウも現在の形式のため変更しない
+			if fallbacks[sessionName+":"+name] {
+				continue
+			}
+			issueNumber, _ := strconv.Atoi(m[1])
+			newName := tmux.GetWindowNameForIssue(issueNumber)
+			if existing[newName] {
+				env.Logger.Warn("Issue window already exists, leaving legacy window as is",
+					"session", sessionName, "window", name, "issue_window", newName)
+				continue
+			}
+
+			if err := env.Tmux.RenameWindow(sessionName, name, newName); err != nil {
+				return fmt.Errorf("failed to rename window %s:%s: %w", sessionName, name, err)
+			}
+			existing[newName] = true
+			session, oldName := sessionName, name
+			journal.Record(fmt.Sprintf("tmuxウィンドウ %s:%s → %s", session, oldName, newName), func() error {
+				return env.Tmux.RenameWindow(session, newName, oldName)
+			})
+		}
+	}
+	return nil
+}
+
+// migrateLegacyWorktrees はフェーズごとのworktreeをIssueのworktree（issue-<番号>）に移動する
+// 同じIssueに複数のフェーズのworktreeがある場合は最初の1つのみ移動し、残りは警告して手動での整理に任せる
+func migrateLegacyWorktrees(ctx context.Context, env *Env, journal *Journal) error {
+	if env.WorktreeManager == nil || env.Worktree == nil {
+		return nil
+	}
+	if env.Config.Worktree.Mode == config.WorktreeModeClone {
+		// Issueごとのcloneはgit worktreeとして移動できない
+		return nil
+	}
+	worktrees, err := env.WorktreeManager.ListAllWorktrees(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	paths := make([]string, 0, len(worktrees))
+	for _, wt := range worktrees {
+		paths = append(paths, wt.Path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		issueNumber := legacyWorktreeIssue(path)
+		if issueNumber == 0 {
+			continue
+		}
+		newPath := env.WorktreeManager.GetWorktreePathForIssue(issueNumber)
+		if _, err := os.Stat(newPath); err == nil {
+			env.Logger.Warn("Issue worktree already exists, leaving legacy worktree as is",
+				"worktree", path, "issue_worktree", newPath)
+			continue
+		}
+
+		if err := env.Worktree.Move(ctx, env.RepoRoot, path, newPath); err != nil {
+			return fmt.Errorf("failed to move worktree %s: %w", path, err)
+		}
+		oldPath := path
+		journal.Record(fmt.Sprintf("worktree %s → %s", oldPath, newPath), func() error {
+			return env.Worktree.Move(ctx, env.RepoRoot, newPath, oldPath)
+		})
+	}
+	return nil
+}
+
+// legacyWorktreeIssue は旧形式のworktreeのパスからIssue番号を取り出す（旧形式でない場合は0）
+func legacyWorktreeIssue(path string) int {
+	slashed := filepath.ToSlash(path)
+	for _, pattern := range legacyWorktreePatterns {
+		if m := pattern.FindStringSubmatch(slashed); m != nil {
+			if n, err := strconv.Atoi(m[1]); err == nil {
+				return n
+			}
+		}
+	}
+	return 0
+}
+
+// fallbackWindows は保存されたペインの配置のうち、フェーズ専用ウィンドウにフォールバックしたウィンドウ（session:window）を返す
+func fallbackWindows(panesPath string) map[string]bool {
+	windows := make(map[string]bool)
+	if panesPath == "" {
+		return windows
+	}
+	data, err := os.ReadFile(panesPath)
+	if err != nil {
+		return windows
+	}
+	var placements map[string]tmux.PanePlacement
+	if err := json.Unmarshal(data, &placements); err != nil {
+		return windows
+	}
+	for _, p := range placements {
+		if p.Fallback {
+			windows[p.SessionName+":"+p.WindowName] = true
+		}
+	}
+	return windows
+}
+
+// migrateStateFiles は現在の形式で読み込めない状態ファイル（以前のバージョンの形式や書きかけのファイル）を.legacyに退避する
+// 状態ファイルは監視プロセスがポーリングごとに、ペインの配置はフェーズの開始ごとに現在の形式で書き出し直す
+// 読み込めないまま残すと、osoba statusや起動時の突き合わせ、osoba tailが毎回警告やエラーを出すため
+func migrateStateFiles(ctx context.Context, env *Env, journal *Journal) error {
+	for _, file := range []struct {
+		path string
+		load func(path string) error // 現在の形式で読み込む
+	}{
+		{path: env.StatePath, load: func(path string) error { _, err := watcher.ReadStatusState(path); return err }},
+		{path: env.PanesPath, load: func(path string) error { _, err := tmux.LoadPaneRegistry(path); return err }},
+	} {
+		if file.path == "" {
+			continue
+		}
+		if _, err := os.Stat(file.path); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if file.load(file.path) == nil {
+			continue
+		}
+
+		path, legacy := file.path, file.path+".legacy"
+		if err := os.Rename(path, legacy); err != nil {
+			return fmt.Errorf("failed to set aside %s: %w", path, err)
+		}
+		journal.Record(fmt.Sprintf("読み込めない状態ファイル %s → %s", path, legacy), func() error {
+			return os.Rename(legacy, path)
+		})
+	}
+	return nil
+}
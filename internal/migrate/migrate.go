@@ -0,0 +1,293 @@
+// Package migrate は起動時に古いディスク・tmuxのレイアウトを現在の形式に移行する
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/tmux"
+)
+
+// VersionFile はレイアウトのバージョンを記録するファイル（リポジトリのルートからの相対パス）
+const VersionFile = ".git/osoba/layout.json"
+
+// backupDir は移行前のファイルと移行の記録を保存するディレクトリ（リポジトリのルートからの相対パス）
+const backupDir = ".git/osoba/migrations"
+
+// Env は移行の対象となる環境
+type Env struct {
+	RepoRoot        string
+	StatePath       string   // 監視プロセスの状態ファイル（空の場合はバックアップしない）
+	PanesPath       string   // 保存されたペインの配置（空の場合はバックアップしない）
+	Sessions        []string // Issueのウィンドウを作成するtmuxセッション（tmux.shardsを含む）
+	Tmux            tmux.Manager
+	WorktreeManager git.WorktreeManager
+	Worktree        WorktreeMover
+	Config          *config.Config
+	Logger          logger.Logger
+}
+
+// WorktreeMover はworktreeを移動する
+type WorktreeMover interface {
+	Move(ctx context.Context, repoPath, worktreePath, newPath string) error
+}
+
+// Migration はレイアウトを1つ上のバージョンに移行する
+type Migration struct {
+	Version     int
+	Description string
+	// Up は移行を行い、取り消すための手順をJournalに記録する
+	Up func(ctx context.Context, env *Env, journal *Journal) error
+}
+
+// migrations は登録済みの移行（バージョン順）
+var migrations = []Migration{
+	{Version: 1, Description: "フェーズ名のtmuxウィンドウ（144-plan）をIssueのウィンドウ（issue-144）に変更", Up: migratePhaseWindows},
+	{Version: 2, Description: "旧形式のworktree（.git/worktree/<フェーズ>/<番号>・<番号>-<フェーズ>）をissue-<番号>に移動", Up: migrateLegacyWorktrees},
+	{Version: 3, Description: "現在の形式で読み込めない状態ファイル（状態ファイル・ペインの配置）を退避", Up: migrateStateFiles},
+}
+
+// LatestVersion は現在のレイアウトのバージョンを返す
+func LatestVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// versionState はバージョンファイルの内容
+type versionState struct {
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReadVersion はリポジトリのレイアウトのバージョンを返す（ファイルがない場合は0）
+func ReadVersion(repoRoot string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(repoRoot, VersionFile))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	var state versionState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", VersionFile, err)
+	}
+	return state.Version, nil
+}
+
+func writeVersion(repoRoot string, version int, now time.Time) error {
+	path := filepath.Join(repoRoot, VersionFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(versionState{Version: version, UpdatedAt: now}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Journal は移行で行った変更と、それを取り消す手順の記録
+type Journal struct {
+	steps []journalStep
+}
+
+type journalStep struct {
+	description string
+	undo        func() error
+}
+
+// Record は行った変更と、それを取り消す手順を記録する
+func (j *Journal) Record(description string, undo func() error) {
+	j.steps = append(j.steps, journalStep{description: description, undo: undo})
+}
+
+// Steps は記録した変更の説明を返す
+func (j *Journal) Steps() []string {
+	steps := make([]string, 0, len(j.steps))
+	for _, s := range j.steps {
+		steps = append(steps, s.description)
+	}
+	return steps
+}
+
+// Rollback は記録した変更を逆順に取り消す（失敗した手順があっても残りの手順を続ける）
+func (j *Journal) Rollback() error {
+	var errs []error
+	for i := len(j.steps) - 1; i >= 0; i-- {
+		if err := j.steps[i].undo(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to undo %q: %w", j.steps[i].description, err))
+		}
+	}
+	j.steps = nil
+	return errors.Join(errs...)
+}
+
+// Result は移行の結果
+type Result struct {
+	From      int      // 移行前のバージョン
+	To        int      // 移行後のバージョン
+	Applied   []string // 行った変更
+	BackupDir string   // 移行前のファイルと移行の記録を保存したディレクトリ（移行しなかった場合は空）
+}
+
+// manifest はバックアップのディレクトリに保存する移行の記録
+type manifest struct {
+	From       int      `json:"from"`
+	To         int      `json:"to"`
+	Steps      []string `json:"steps"`
+	Error      string   `json:"error,omitempty"`
+	RolledBack bool     `json:"rolled_back,omitempty"`
+}
+
+// Runner は未適用の移行をバージョン順に適用する
+type Runner struct {
+	env        *Env
+	migrations []Migration
+	now        func() time.Time
+}
+
+// NewRunner は新しいRunnerを作成する
+func NewRunner(env *Env) (*Runner, error) {
+	if env == nil || env.RepoRoot == "" {
+		return nil, errors.New("repository root is required")
+	}
+	if env.Config == nil {
+		return nil, errors.New("config is required")
+	}
+	if env.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+	return &Runner{env: env, migrations: migrations, now: time.Now}, nil
+}
+
+// Run は未適用の移行を適用する
+// 移行の前にバージョンファイルと状態ファイルをバックアップし、移行に失敗した場合はその移行の変更を取り消して
+// ファイルを復元する（それまでに成功した移行は適用済みのまま残す）
+func (r *Runner) Run(ctx context.Context) (*Result, error) {
+	current, err := ReadVersion(r.env.RepoRoot)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{From: current, To: current}
+
+	var pending []Migration
+	for _, m := range r.migrations {
+		if m.Version > current {
+			pending = append(pending, m)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Version < pending[j].Version })
+	if len(pending) == 0 {
+		return result, nil
+	}
+
+	now := r.now()
+	result.BackupDir = filepath.Join(r.env.RepoRoot, backupDir, fmt.Sprintf("%s-v%d", now.Format("20060102-150405"), current))
+	backups, err := r.backup(result.BackupDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up before migration: %w", err)
+	}
+
+	record := manifest{From: current, Steps: []string{}}
+	for _, m := range pending {
+		r.env.Logger.Info("Applying layout migration", "version", m.Version, "description", m.Description)
+		journal := &Journal{}
+		if err := m.Up(ctx, r.env, journal); err != nil {
+			record.Error = fmt.Sprintf("v%d: %v", m.Version, err)
+			rollbackErr := journal.Rollback()
+			// バージョンファイルはそれまでに成功した移行を記録しているため復元しない
+			delete(backups, filepath.Join(r.env.RepoRoot, VersionFile))
+			if restoreErr := restore(backups); restoreErr != nil {
+				rollbackErr = errors.Join(rollbackErr, restoreErr)
+			}
+			record.RolledBack = rollbackErr == nil
+			record.To = result.To
+			_ = writeManifest(result.BackupDir, record)
+			if rollbackErr != nil {
+				return result, fmt.Errorf("migration to v%d failed: %w (rollback failed: %v)", m.Version, err, rollbackErr)
+			}
+			return result, fmt.Errorf("migration to v%d failed and was rolled back: %w", m.Version, err)
+		}
+		if err := writeVersion(r.env.RepoRoot, m.Version, r.now()); err != nil {
+			_ = journal.Rollback()
+			return result, fmt.Errorf("failed to record layout version %d: %w", m.Version, err)
+		}
+		result.To = m.Version
+		result.Applied = append(result.Applied, journal.Steps()...)
+		record.Steps = append(record.Steps, journal.Steps()...)
+	}
+
+	record.To = result.To
+	// 新しくインストールした場合など、変更がなかった場合はバックアップを残さない
+	if len(result.Applied) == 0 {
+		if err := os.RemoveAll(result.BackupDir); err != nil {
+			r.env.Logger.Warn("Failed to remove unused migration backup", "dir", result.BackupDir, "error", err)
+		}
+		// 以前の移行のバックアップがない場合はディレクトリも削除する（空でない場合は何もしない）
+		_ = os.Remove(filepath.Dir(result.BackupDir))
+		result.BackupDir = ""
+		return result, nil
+	}
+	if err := writeManifest(result.BackupDir, record); err != nil {
+		r.env.Logger.Warn("Failed to write migration manifest", "dir", result.BackupDir, "error", err)
+	}
+	return result, nil
+}
+
+// backup はバージョンファイルと状態ファイルをバックアップのディレクトリにコピーし、元のパスとコピー先の対応を返す
+func (r *Runner) backup(dir string) (map[string]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	backups := make(map[string]string)
+	for _, path := range []string{filepath.Join(r.env.RepoRoot, VersionFile), r.env.StatePath, r.env.PanesPath} {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		dst := filepath.Join(dir, filepath.Base(path))
+		if err := os.WriteFile(dst, data, 0644); err != nil {
+			return nil, err
+		}
+		backups[path] = dst
+	}
+	return backups, nil
+}
+
+// restore はバックアップしたファイルを元のパスに戻す
+func restore(backups map[string]string) error {
+	var errs []error
+	for path, dst := range backups {
+		data, err := os.ReadFile(dst)
+		if err == nil {
+			err = os.WriteFile(path, data, 0644)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s: %w", path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func writeManifest(dir string, record manifest) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "manifest.json"), append(data, '\n'), 0644)
+}
@@ -0,0 +1,204 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/testutil/helpers"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeMover はworktreeの移動を記録する
+type fakeMover struct {
+	moves  [][2]string
+	failOn string // このパスの移動を失敗させる
+}
+
+func (f *fakeMover) Move(ctx context.Context, repoPath, worktreePath, newPath string) error {
+	if worktreePath == f.failOn {
+		return errors.New("worktree is locked")
+	}
+	f.moves = append(f.moves, [2]string{worktreePath, newPath})
+	return nil
+}
+
+func newTestEnv(t *testing.T, mover *fakeMover) (*Env, *mocks.MockTmuxManager, *mocks.MockGitWorktreeManager) {
+	t.Helper()
+	root := t.TempDir()
+	worktreesDir := filepath.Join(root, ".git", "osoba", "worktrees")
+	require.NoError(t, os.MkdirAll(worktreesDir, 0755))
+
+	tmuxManager := mocks.NewMockTmuxManager()
+	worktreeManager := mocks.NewMockGitWorktreeManager()
+	for _, n := range []int{144, 150} {
+		worktreeManager.On("GetWorktreePathForIssue", n).Return(filepath.Join(worktreesDir, fmt.Sprintf("issue-%d", n))).Maybe()
+	}
+
+	cfg := config.NewConfig()
+	cfg.Tmux.Phases = map[string]config.PhasePaneConfig{"review": {Window: config.WindowPolicySeparate}}
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	return &Env{
+		RepoRoot:        root,
+		StatePath:       filepath.Join(root, "state.json"),
+		PanesPath:       filepath.Join(root, "panes.json"),
+		Sessions:        []string{"osoba-repo"},
+		Tmux:            tmuxManager,
+		WorktreeManager: worktreeManager,
+		Worktree:        mover,
+		Config:          cfg,
+		Logger:          logger,
+	}, tmuxManager, worktreeManager
+}
+
+func TestRunner_Run(t *testing.T) {
+	mover := &fakeMover{}
+	env, tmuxManager, worktreeManager := newTestEnv(t, mover)
+	require.NoError(t, os.WriteFile(env.StatePath, []byte(`{"owner":"o"}`), 0644))
+	require.NoError(t, os.WriteFile(env.PanesPath, []byte(`{"170/plan":{"session_name":"osoba-repo","window_name":"170-plan","fallback":true}}`), 0644))
+
+	tmuxManager.On("ListWindows", "osoba-repo").Return([]string{"issue-1", "1-plan", "144-plan", "150-implement", "160-review", "170-plan"}, nil)
+	tmuxManager.On("RenameWindow", "osoba-repo", "144-plan", "issue-144").Return(nil).Once()
+	tmuxManager.On("RenameWindow", "osoba-repo", "150-implement", "issue-150").Return(nil).Once()
+	legacyPlan := filepath.Join(env.RepoRoot, ".git", "worktree", "plan", "144")
+	legacyImplement := filepath.Join(env.RepoRoot, ".git", "osoba", "worktrees", "150-implement")
+	worktreeManager.On("ListAllWorktrees", mock.Anything).Return([]git.WorktreeInfo{
+		{Path: env.RepoRoot, Branch: "main"},
+		{Path: legacyPlan, Branch: "osoba/#144-plan"},
+		{Path: legacyImplement, Branch: "osoba/#150-implement"},
+	}, nil)
+
+	runner, err := NewRunner(env)
+	require.NoError(t, err)
+	result, err := runner.Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, result.From)
+	assert.Equal(t, LatestVersion(), result.To)
+	assert.Len(t, result.Applied, 4)
+	tmuxManager.AssertExpectations(t)
+	// Issueのウィンドウが既にある場合と、window: separateのフェーズ・フォールバックしたウィンドウ（現在の形式）は変更しない
+	tmuxManager.AssertNotCalled(t, "RenameWindow", "osoba-repo", "1-plan", mock.Anything)
+	tmuxManager.AssertNotCalled(t, "RenameWindow", "osoba-repo", "160-review", mock.Anything)
+	tmuxManager.AssertNotCalled(t, "RenameWindow", "osoba-repo", "170-plan", mock.Anything)
+	assert.Equal(t, [][2]string{
+		{legacyImplement, filepath.Join(env.RepoRoot, ".git", "osoba", "worktrees", "issue-150")},
+		{legacyPlan, filepath.Join(env.RepoRoot, ".git", "osoba", "worktrees", "issue-144")},
+	}, mover.moves)
+
+	version, err := ReadVersion(env.RepoRoot)
+	require.NoError(t, err)
+	assert.Equal(t, LatestVersion(), version)
+	assert.FileExists(t, filepath.Join(result.BackupDir, "state.json"))
+	assert.FileExists(t, filepath.Join(result.BackupDir, "panes.json"))
+	assert.FileExists(t, filepath.Join(result.BackupDir, "manifest.json"))
+
+	t.Run("適用済みの場合は何もしない", func(t *testing.T) {
+		result, err := runner.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, LatestVersion(), result.From)
+		assert.Empty(t, result.Applied)
+		assert.Empty(t, result.BackupDir)
+	})
+}
+
+func TestRunner_Run_FreshInstall(t *testing.T) {
+	env, tmuxManager, worktreeManager := newTestEnv(t, &fakeMover{})
+	tmuxManager.On("ListWindows", "osoba-repo").Return(nil, errors.New("can't find session"))
+	worktreeManager.On("ListAllWorktrees", mock.Anything).Return([]git.WorktreeInfo{{Path: env.RepoRoot, Branch: "main"}}, nil)
+
+	runner, err := NewRunner(env)
+	require.NoError(t, err)
+	result, err := runner.Run(context.Background())
+	require.NoError(t, err)
+
+	// 移行する対象がない場合はバージョンだけを記録し、バックアップを残さない
+	assert.Equal(t, LatestVersion(), result.To)
+	assert.Empty(t, result.Applied)
+	assert.Empty(t, result.BackupDir)
+	assert.NoDirExists(t, filepath.Join(env.RepoRoot, backupDir))
+	version, err := ReadVersion(env.RepoRoot)
+	require.NoError(t, err)
+	assert.Equal(t, LatestVersion(), version)
+}
+
+func TestMigrateStateFiles(t *testing.T) {
+	env, _, _ := newTestEnv(t, nil)
+	require.NoError(t, os.WriteFile(env.StatePath, []byte(`{"issues":[{"number":1}]}`), 0644))
+	require.NoError(t, os.WriteFile(env.PanesPath, []byte(`{"1/plan":{"session_name":"osoba-repo","window_name":"issue-1"}}`), 0644))
+
+	journal := &Journal{}
+	require.NoError(t, migrateStateFiles(context.Background(), env, journal))
+
+	// 読み込めない状態ファイルは退避し、読み込めるペインの配置はそのまま残す
+	assert.NoFileExists(t, env.StatePath)
+	assert.FileExists(t, env.StatePath+".legacy")
+	assert.FileExists(t, env.PanesPath)
+	assert.Len(t, journal.Steps(), 1)
+
+	require.NoError(t, journal.Rollback())
+	assert.FileExists(t, env.StatePath)
+	assert.NoFileExists(t, env.StatePath+".legacy")
+}
+
+func TestRunner_Run_RollsBackFailedMigration(t *testing.T) {
+	env, tmuxManager, worktreeManager := newTestEnv(t, nil)
+	legacyPlan := filepath.Join(env.RepoRoot, ".git", "worktree", "plan", "144")
+	legacyImplement := filepath.Join(env.RepoRoot, ".git", "osoba", "worktrees", "150-implement")
+	mover := &fakeMover{failOn: legacyPlan}
+	env.Worktree = mover
+
+	tmuxManager.On("ListWindows", "osoba-repo").Return([]string{}, nil)
+	worktreeManager.On("ListAllWorktrees", mock.Anything).Return([]git.WorktreeInfo{
+		{Path: legacyPlan},
+		{Path: legacyImplement},
+	}, nil)
+
+	runner, err := NewRunner(env)
+	require.NoError(t, err)
+	result, err := runner.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rolled back")
+
+	// 移動したworktreeは元のパスに戻す
+	issue150 := filepath.Join(env.RepoRoot, ".git", "osoba", "worktrees", "issue-150")
+	assert.Equal(t, [][2]string{{legacyImplement, issue150}, {issue150, legacyImplement}}, mover.moves)
+
+	// 成功した移行は適用済みのまま残す
+	assert.Equal(t, 1, result.To)
+	version, err := ReadVersion(env.RepoRoot)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+	manifest, err := os.ReadFile(filepath.Join(result.BackupDir, "manifest.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(manifest), `"rolled_back": true`)
+}
+
+func TestLegacyWorktreeIssue(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "フェーズごとのディレクトリ", path: "/repo/.git/worktree/implement/83", want: 83},
+		{name: "フェーズ付きのディレクトリ", path: "/repo/.git/osoba/worktrees/83-review", want: 83},
+		{name: "scratch_dir上のフェーズ付きのディレクトリ", path: "/scratch/repo/osoba/worktrees/12-plan", want: 12},
+		{name: "現在の形式", path: "/repo/.git/osoba/worktrees/issue-83", want: 0},
+		{name: "メインのworktree", path: "/repo", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, legacyWorktreeIssue(tt.path))
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// repeatInterval は同じIssue・PRの同じ種類のイベントを再度通知するまでの間隔
+// ポーリングのたびに同じ失敗（コンフリクトしたままのPRなど）を通知しないようにする
+const repeatInterval = 6 * time.Hour
+
+// テスト用にモック可能な関数変数
+var (
+	// sendMail はSMTPサーバーにメールを送信する
+	sendMail = smtp.SendMail
+)
+
+// EmailNotifier はSMTPでイベントをメール通知する
+// digestが設定されている場合は、イベントを溜めて一定間隔でまとめて送信する
+type EmailNotifier struct {
+	config   config.EmailNotificationConfig
+	logger   logger.Logger
+	clock    clock.Clock
+	location *time.Location // 本文の日時のタイムゾーン（nilの場合はそのまま）
+
+	mu      sync.Mutex
+	pending []Event              // ダイジェストで送信待ちのイベント
+	sent    map[string]time.Time // イベントのキーと最後に通知した日時（repeatIntervalを過ぎたものは記録時に破棄する）
+}
+
+// NewEmailNotifier は新しいEmailNotifierを作成する
+func NewEmailNotifier(cfg config.EmailNotificationConfig, logger logger.Logger) *EmailNotifier {
+	return &EmailNotifier{
+		config: cfg,
+		logger: logger,
+		clock:  clock.New(),
+		sent:   make(map[string]time.Time),
+	}
+}
+
+// SetLocation は通知の本文に表示する日時のタイムゾーンを設定する
+func (n *EmailNotifier) SetLocation(loc *time.Location) {
+	n.location = loc
+}
+
+// Notify はイベントを通知する
+// 通知対象外のイベントと、repeatInterval以内に通知済みの同じIssue・PRの同じ種類のイベントは無視する
+func (n *EmailNotifier) Notify(ctx context.Context, event Event) error {
+	if !n.config.Notifies(event.Kind) {
+		return nil
+	}
+
+	now := n.clock.Now()
+	if event.Time.IsZero() {
+		event.Time = now
+	}
+	if n.location != nil {
+		event.Time = event.Time.In(n.location)
+	}
+
+	n.mu.Lock()
+	if last, ok := n.sent[event.key()]; ok && now.Sub(last) < repeatInterval {
+		n.mu.Unlock()
+		return nil
+	}
+	n.forgetExpired(now)
+	n.sent[event.key()] = now
+	if n.config.Digest > 0 {
+		n.pending = append(n.pending, event)
+		n.mu.Unlock()
+		return nil
+	}
+	n.mu.Unlock()
+
+	if err := n.send("[osoba] "+event.Headline(), event.Text()); err != nil {
+		// 次回の発生時に再送できるよう記録を取り消す
+		n.mu.Lock()
+		delete(n.sent, event.key())
+		n.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// forgetExpired はrepeatIntervalを過ぎた通知の記録を破棄する（n.muを保持して呼び出す）
+func (n *EmailNotifier) forgetExpired(now time.Time) {
+	for key, last := range n.sent {
+		if now.Sub(last) >= repeatInterval {
+			delete(n.sent, key)
+		}
+	}
+}
+
+// Run はダイジェストの送信間隔ごとに溜まったイベントを送信する
+// コンテキストがキャンセルされると残りのイベントを送信して終了する（ダイジェストが無効の場合は何もしない）
+func (n *EmailNotifier) Run(ctx context.Context) {
+	if n.config.Digest <= 0 {
+		return
+	}
+
+	ticker := n.clock.NewTicker(n.config.Digest)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := n.Flush(); err != nil {
+				n.logger.Warn("Failed to send notification digest", "error", err)
+			}
+			return
+		case <-ticker.C():
+			if err := n.Flush(); err != nil {
+				n.logger.Warn("Failed to send notification digest", "error", err)
+			}
+		}
+	}
+}
+
+// Flush は溜まったイベントをダイジェストとして送信する
+func (n *EmailNotifier) Flush() error {
+	n.mu.Lock()
+	events := n.pending
+	n.pending = nil
+	n.mu.Unlock()
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	var body strings.Builder
+	for i, event := range events {
+		if i > 0 {
+			body.WriteString("\n----\n\n")
+		}
+		body.WriteString(event.Text())
+	}
+
+	subject := fmt.Sprintf("[osoba] %d件の通知", len(events))
+	if err := n.send(subject, body.String()); err != nil {
+		// 送信に失敗したイベントは次回のダイジェストに含める
+		n.mu.Lock()
+		n.pending = append(events, n.pending...)
+		n.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// send はメールを送信する
+func (n *EmailNotifier) send(subject, body string) error {
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)
+	}
+
+	msg, err := buildMessage(n.config.From, n.config.To, subject, body, n.clock.Now())
+	if err != nil {
+		return err
+	}
+	if err := sendMail(addr, auth, n.config.From, n.config.To, msg); err != nil {
+		return fmt.Errorf("failed to send notification email via %s: %w", addr, err)
+	}
+	n.logger.Info("Sent notification email",
+		"subject", subject,
+		"recipients", len(n.config.To))
+	return nil
+}
+
+// buildMessage はメールのメッセージを組み立てる
+func buildMessage(from string, to []string, subject, body string, date time.Time) ([]byte, error) {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	msg.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&msg)
+	if _, err := w.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n"))); err != nil {
+		return nil, fmt.Errorf("failed to encode notification email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode notification email: %w", err)
+	}
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,205 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sentMail は送信されたメール
+type sentMail struct {
+	addr    string
+	from    string
+	to      []string
+	subject string
+	body    string
+}
+
+// stubSendMail はsendMailを差し替え、送信されたメールを記録する
+func stubSendMail(t *testing.T, err error) *[]sentMail {
+	t.Helper()
+	var sent []sentMail
+	orig := sendMail
+	sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		if err != nil {
+			return err
+		}
+		parsed, parseErr := mail.ReadMessage(strings.NewReader(string(msg)))
+		require.NoError(t, parseErr)
+		subject, decodeErr := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+		require.NoError(t, decodeErr)
+		body, readErr := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+		require.NoError(t, readErr)
+		sent = append(sent, sentMail{addr: addr, from: from, to: to, subject: subject, body: string(body)})
+		return nil
+	}
+	t.Cleanup(func() { sendMail = orig })
+	return &sent
+}
+
+func newTestEmailNotifier(t *testing.T, cfg config.EmailNotificationConfig) (*EmailNotifier, *clock.Fake) {
+	t.Helper()
+	log, err := logger.New(logger.WithLevel("error"))
+	require.NoError(t, err)
+	notifier := NewEmailNotifier(cfg, log)
+	fake := clock.NewFake(time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC))
+	notifier.clock = fake
+	return notifier, fake
+}
+
+func testEmailConfig() config.EmailNotificationConfig {
+	return config.EmailNotificationConfig{
+		Enabled: true,
+		Host:    "smtp.example.com",
+		Port:    587,
+		From:    "osoba@example.com",
+		To:      []string{"dev@example.com", "ops@example.com"},
+	}
+}
+
+func TestEmailNotifier_Notify(t *testing.T) {
+	sent := stubSendMail(t, nil)
+	notifier, _ := newTestEmailNotifier(t, testEmailConfig())
+
+	event := Event{
+		Kind:        config.NotifyPhaseFailed,
+		Repository:  "owner/repo",
+		IssueNumber: 12,
+		Title:       "ログイン画面の改善",
+		Detail:      "failed to create worktree",
+	}
+	require.NoError(t, notifier.Notify(context.Background(), event))
+
+	require.Len(t, *sent, 1)
+	got := (*sent)[0]
+	assert.Equal(t, "smtp.example.com:587", got.addr)
+	assert.Equal(t, "osoba@example.com", got.from)
+	assert.Equal(t, []string{"dev@example.com", "ops@example.com"}, got.to)
+	assert.Equal(t, "[osoba] owner/repo: フェーズの実行に失敗しました (#12)", got.subject)
+	assert.Contains(t, got.body, "https://github.com/owner/repo/issues/12")
+	assert.Contains(t, got.body, "タイトル: ログイン画面の改善")
+	assert.Contains(t, got.body, "failed to create worktree")
+}
+
+func TestEmailNotifier_NotifyFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		events   []string
+		advance  time.Duration
+		detail   string // 2回目のイベントの詳細（空の場合は1回目と同じ）
+		wantSent int
+	}{
+		{
+			name:     "同じ内容のイベントは再通知しない",
+			wantSent: 1,
+		},
+		{
+			name:     "詳細だけが異なるイベントは再通知しない",
+			detail:   "boom (attempt 2)",
+			wantSent: 1,
+		},
+		{
+			name:     "再通知の間隔を過ぎた場合は再度通知",
+			advance:  repeatInterval,
+			wantSent: 2,
+		},
+		{
+			name:     "通知対象外のイベントは送信しない",
+			events:   []string{config.NotifyMergeBlocked},
+			wantSent: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := stubSendMail(t, nil)
+			cfg := testEmailConfig()
+			cfg.Events = tt.events
+			notifier, fake := newTestEmailNotifier(t, cfg)
+
+			event := Event{Kind: config.NotifyPhaseFailed, Repository: "owner/repo", IssueNumber: 12, Detail: "boom"}
+			require.NoError(t, notifier.Notify(context.Background(), event))
+			fake.Advance(tt.advance)
+			if tt.detail != "" {
+				event.Detail = tt.detail
+			}
+			require.NoError(t, notifier.Notify(context.Background(), event))
+
+			assert.Len(t, *sent, tt.wantSent)
+		})
+	}
+}
+
+func TestEmailNotifier_ForgetsExpiredEvents(t *testing.T) {
+	stubSendMail(t, nil)
+	notifier, fake := newTestEmailNotifier(t, testEmailConfig())
+
+	for issue := 1; issue <= 3; issue++ {
+		require.NoError(t, notifier.Notify(context.Background(), Event{Kind: config.NotifyPhaseFailed, Repository: "owner/repo", IssueNumber: issue}))
+	}
+	assert.Len(t, notifier.sent, 3)
+
+	// 再通知の間隔を過ぎた記録は次の通知の記録時に破棄する
+	fake.Advance(repeatInterval)
+	require.NoError(t, notifier.Notify(context.Background(), Event{Kind: config.NotifyPhaseFailed, Repository: "owner/repo", IssueNumber: 4}))
+	assert.Len(t, notifier.sent, 1)
+}
+
+func TestEmailNotifier_NotifySendFailure(t *testing.T) {
+	stubSendMail(t, errors.New("connection refused"))
+	notifier, _ := newTestEmailNotifier(t, testEmailConfig())
+
+	event := Event{Kind: config.NotifyMergeBlocked, Repository: "owner/repo", PRNumber: 34}
+	err := notifier.Notify(context.Background(), event)
+	assert.EqualError(t, err, "failed to send notification email via smtp.example.com:587: connection refused")
+
+	// 送信に失敗したイベントは次回の発生時に再送する
+	sent := stubSendMail(t, nil)
+	require.NoError(t, notifier.Notify(context.Background(), event))
+	require.Len(t, *sent, 1)
+	assert.Equal(t, "[osoba] owner/repo: 自動マージが停止しました (PR #34)", (*sent)[0].subject)
+}
+
+func TestEmailNotifier_Digest(t *testing.T) {
+	sent := stubSendMail(t, nil)
+	cfg := testEmailConfig()
+	cfg.Digest = time.Hour
+	notifier, _ := newTestEmailNotifier(t, cfg)
+
+	require.NoError(t, notifier.Notify(context.Background(), Event{Kind: config.NotifyPhaseFailed, Repository: "owner/repo", IssueNumber: 1}))
+	require.NoError(t, notifier.Notify(context.Background(), Event{Kind: config.NotifyMergeBlocked, Repository: "owner/repo", PRNumber: 2}))
+	assert.Empty(t, *sent, "ダイジェストモードではすぐに送信しない")
+
+	require.NoError(t, notifier.Flush())
+	require.Len(t, *sent, 1)
+	assert.Equal(t, "[osoba] 2件の通知", (*sent)[0].subject)
+	assert.Contains(t, (*sent)[0].body, "owner/repo: フェーズの実行に失敗しました (#1)")
+	assert.Contains(t, (*sent)[0].body, "owner/repo: 自動マージが停止しました (PR #2)")
+
+	// 送信済みのイベントは再送しない
+	require.NoError(t, notifier.Flush())
+	assert.Len(t, *sent, 1)
+}
+
+func TestWithRepository(t *testing.T) {
+	sent := stubSendMail(t, nil)
+	notifier, _ := newTestEmailNotifier(t, testEmailConfig())
+
+	require.NoError(t, WithRepository(notifier, "owner/repo").Notify(context.Background(), Event{Kind: config.NotifyPhaseFailed, IssueNumber: 5}))
+	require.Len(t, *sent, 1)
+	assert.Equal(t, "[osoba] owner/repo: フェーズの実行に失敗しました (#5)", (*sent)[0].subject)
+	assert.Nil(t, WithRepository(nil, "owner/repo"))
+}
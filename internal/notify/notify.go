@@ -0,0 +1,101 @@
+// Package notify はフェーズの失敗や自動マージの停止などの重要なイベントを外部に通知する
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+)
+
+// Event は通知するイベント
+type Event struct {
+	Kind        string    // イベントの種類（config.NotifyPhaseFailed など）
+	Repository  string    // owner/repo
+	IssueNumber int       // 対象のIssue番号（ない場合は0）
+	PRNumber    int       // 対象のPR番号（ない場合は0）
+	Title       string    // IssueまたはPRのタイトル
+	Detail      string    // エラー内容などの詳細
+	Time        time.Time // 発生日時
+}
+
+// Notifier はイベントを通知する
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// kindTitles はイベントの種類ごとの見出し
+var kindTitles = map[string]string{
+	config.NotifyPhaseFailed:    "フェーズの実行に失敗しました",
+	config.NotifyMergeBlocked:   "自動マージが停止しました",
+	config.NotifyBudgetExceeded: "同時実行数の上限に達しました",
+	config.NotifyPlanStale:      "計画後にIssueが編集されました",
+	config.NotifyDegraded:       "監視が失敗し続けています",
+}
+
+// Headline はイベントの見出しを返す
+func (e Event) Headline() string {
+	title, ok := kindTitles[e.Kind]
+	if !ok {
+		title = e.Kind
+	}
+	switch {
+	case e.IssueNumber > 0:
+		return fmt.Sprintf("%s: %s (#%d)", e.Repository, title, e.IssueNumber)
+	case e.PRNumber > 0:
+		return fmt.Sprintf("%s: %s (PR #%d)", e.Repository, title, e.PRNumber)
+	default:
+		return fmt.Sprintf("%s: %s", e.Repository, title)
+	}
+}
+
+// Text はイベントの本文を返す
+func (e Event) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", e.Headline())
+	fmt.Fprintf(&b, "- リポジトリ: %s\n", e.Repository)
+	if e.IssueNumber > 0 {
+		fmt.Fprintf(&b, "- Issue: https://github.com/%s/issues/%d\n", e.Repository, e.IssueNumber)
+	}
+	if e.PRNumber > 0 {
+		fmt.Fprintf(&b, "- PR: https://github.com/%s/pull/%d\n", e.Repository, e.PRNumber)
+	}
+	if e.Title != "" {
+		fmt.Fprintf(&b, "- タイトル: %s\n", e.Title)
+	}
+	fmt.Fprintf(&b, "- 日時: %s\n", e.Time.Format(time.RFC3339))
+	if e.Detail != "" {
+		fmt.Fprintf(&b, "\n%s\n", e.Detail)
+	}
+	return b.String()
+}
+
+// key は同じIssue・PRの同じ種類のイベントを識別するキーを返す
+// 発生日時や詳細（エラーメッセージなど）は含めず、詳細だけが異なる失敗を別のイベントとして通知しない
+func (e Event) key() string {
+	return fmt.Sprintf("%s|%s|%d|%d", e.Kind, e.Repository, e.IssueNumber, e.PRNumber)
+}
+
+// repositoryNotifier はイベントにリポジトリ名を設定して通知する
+type repositoryNotifier struct {
+	notifier   Notifier
+	repository string
+}
+
+// WithRepository はイベントにリポジトリ名（owner/repo）を設定して通知するNotifierを返す
+func WithRepository(notifier Notifier, repository string) Notifier {
+	if notifier == nil {
+		return nil
+	}
+	return &repositoryNotifier{notifier: notifier, repository: repository}
+}
+
+// Notify はリポジトリ名を設定してイベントを通知する
+func (n *repositoryNotifier) Notify(ctx context.Context, event Event) error {
+	if event.Repository == "" {
+		event.Repository = n.repository
+	}
+	return n.notifier.Notify(ctx, event)
+}
@@ -1,6 +1,7 @@
 package paths
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,8 +13,15 @@ type PathManager interface {
 	RunDir() string
 	LogDir(repoIdentifier string) string
 	PIDFile(repoIdentifier string) string
+	StateFile(repoIdentifier string) string
+	BadgeFile(repoIdentifier string) string
+	ControlSocket(repoIdentifier string) string
+	EventsFile(repoIdentifier string) string
+	AuditFile(repoIdentifier string) string
+	StoreFile(repoIdentifier, name string) string
 	EnsureDirectories() error
 	AllPIDFiles() ([]string, error)
+	AllControlSockets() ([]string, error)
 }
 
 type pathManager struct {
@@ -23,13 +31,27 @@ type pathManager struct {
 // NewPathManager は新しいPathManagerを作成します
 func NewPathManager(baseDir string) PathManager {
 	if baseDir == "" {
-		baseDir = filepath.Join(os.Getenv("HOME"), ".local", "share", "osoba")
+		baseDir = defaultBaseDir()
 	}
 	return &pathManager{
 		baseDir: baseDir,
 	}
 }
 
+// defaultBaseDir はデータディレクトリのデフォルトのパスを返します
+// OSOBA_DATA_DIRが指定されている場合はそのパスを使用します（コンテナでボリュームをマウントする場合など）
+// HOMEが未設定または"/"のコンテナでは、書き込み可能な一時ディレクトリ配下をユーザーごとに使用します
+func defaultBaseDir() string {
+	if dir := os.Getenv("OSOBA_DATA_DIR"); dir != "" {
+		return dir
+	}
+	home := os.Getenv("HOME")
+	if home == "" || home == "/" {
+		return filepath.Join(os.TempDir(), fmt.Sprintf("osoba-%d", os.Getuid()))
+	}
+	return filepath.Join(home, ".local", "share", "osoba")
+}
+
 // DataDir はデータディレクトリのパスを返します
 func (p *pathManager) DataDir() string {
 	return p.baseDir
@@ -52,6 +74,43 @@ func (p *pathManager) PIDFile(repoIdentifier string) string {
 	return filepath.Join(p.RunDir(), sanitized+".pid")
 }
 
+// StateFile は指定されたリポジトリの監視プロセスが書き出す状態ファイルのパスを返します
+func (p *pathManager) StateFile(repoIdentifier string) string {
+	sanitized := p.sanitizeIdentifier(repoIdentifier)
+	return filepath.Join(p.RunDir(), sanitized+".state.json")
+}
+
+// BadgeFile は指定されたリポジトリの監視プロセスが書き出すステータスバッジ（shields.ioのendpoint JSON）のパスを返します
+func (p *pathManager) BadgeFile(repoIdentifier string) string {
+	sanitized := p.sanitizeIdentifier(repoIdentifier)
+	return filepath.Join(p.RunDir(), sanitized+".badge.json")
+}
+
+// ControlSocket は指定されたリポジトリの監視プロセスが制御コマンドを受け付けるUnixソケットのパスを返します
+func (p *pathManager) ControlSocket(repoIdentifier string) string {
+	sanitized := p.sanitizeIdentifier(repoIdentifier)
+	return filepath.Join(p.RunDir(), sanitized+".sock")
+}
+
+// EventsFile は指定されたリポジトリのイベントストア（追記専用のJSON Lines）のパスを返します
+// 監視プロセスの再起動後も参照できるよう、run/ではなくデータディレクトリ配下に置きます
+func (p *pathManager) EventsFile(repoIdentifier string) string {
+	sanitized := p.sanitizeIdentifier(repoIdentifier)
+	return filepath.Join(p.baseDir, "events", sanitized+".jsonl")
+}
+
+// AuditFile は指定されたリポジトリの監査ログ（追記専用のJSON Lines）のパスを返します
+func (p *pathManager) AuditFile(repoIdentifier string) string {
+	sanitized := p.sanitizeIdentifier(repoIdentifier)
+	return filepath.Join(p.baseDir, "audit", sanitized+".jsonl")
+}
+
+// StoreFile は指定されたリポジトリの監視プロセスが再起動後も引き継ぐ状態（JSON）のパスを返します
+func (p *pathManager) StoreFile(repoIdentifier, name string) string {
+	sanitized := p.sanitizeIdentifier(repoIdentifier)
+	return filepath.Join(p.baseDir, "store", sanitized, name+".json")
+}
+
 // EnsureDirectories は必要なディレクトリを作成します
 func (p *pathManager) EnsureDirectories() error {
 	dirs := []string{
@@ -89,6 +148,27 @@ func (p *pathManager) AllPIDFiles() ([]string, error) {
 	return pidFiles, nil
 }
 
+// AllControlSockets はすべての監視プロセスの制御ソケットのパスを返します
+func (p *pathManager) AllControlSockets() ([]string, error) {
+	runDir := p.RunDir()
+	entries, err := os.ReadDir(runDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	var sockets []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".sock") {
+			sockets = append(sockets, filepath.Join(runDir, entry.Name()))
+		}
+	}
+
+	return sockets, nil
+}
+
 // sanitizeIdentifier はファイルシステムで安全な識別子に変換します
 func (p *pathManager) sanitizeIdentifier(identifier string) string {
 	replacer := strings.NewReplacer(
@@ -1,6 +1,7 @@
 package paths
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -36,6 +37,47 @@ func TestNewPathManager(t *testing.T) {
 	}
 }
 
+func TestDefaultBaseDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		dataDir  string
+		home     string
+		expected string
+	}{
+		{
+			name:     "OSOBA_DATA_DIR takes precedence",
+			dataDir:  "/var/lib/osoba",
+			home:     "/home/user",
+			expected: "/var/lib/osoba",
+		},
+		{
+			name:     "home directory",
+			home:     "/home/user",
+			expected: filepath.Join("/home/user", ".local", "share", "osoba"),
+		},
+		{
+			name:     "container without HOME",
+			home:     "",
+			expected: filepath.Join(os.TempDir(), fmt.Sprintf("osoba-%d", os.Getuid())),
+		},
+		{
+			name:     "container with root as HOME",
+			home:     "/",
+			expected: filepath.Join(os.TempDir(), fmt.Sprintf("osoba-%d", os.Getuid())),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OSOBA_DATA_DIR", tt.dataDir)
+			t.Setenv("HOME", tt.home)
+			if got := NewPathManager("").DataDir(); got != tt.expected {
+				t.Errorf("DataDir() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestPathManager_RunDir(t *testing.T) {
 	pm := NewPathManager("/test/base")
 	expected := "/test/base/run"
@@ -110,6 +152,48 @@ func TestPathManager_PIDFile(t *testing.T) {
 	}
 }
 
+func TestPathManager_StateFile(t *testing.T) {
+	pm := NewPathManager("/test/base")
+	if got, want := pm.StateFile("douhashi/osoba"), "/test/base/run/douhashi_osoba.state.json"; got != want {
+		t.Errorf("StateFile() = %v, want %v", got, want)
+	}
+}
+
+func TestPathManager_BadgeFile(t *testing.T) {
+	pm := NewPathManager("/test/base")
+	if got, want := pm.BadgeFile("douhashi/osoba"), "/test/base/run/douhashi_osoba.badge.json"; got != want {
+		t.Errorf("BadgeFile() = %v, want %v", got, want)
+	}
+}
+
+func TestPathManager_ControlSocket(t *testing.T) {
+	pm := NewPathManager("/test/base")
+	if got, want := pm.ControlSocket("douhashi/osoba"), "/test/base/run/douhashi_osoba.sock"; got != want {
+		t.Errorf("ControlSocket() = %v, want %v", got, want)
+	}
+}
+
+func TestPathManager_EventsFile(t *testing.T) {
+	pm := NewPathManager("/test/base")
+	if got, want := pm.EventsFile("douhashi/osoba"), "/test/base/events/douhashi_osoba.jsonl"; got != want {
+		t.Errorf("EventsFile() = %v, want %v", got, want)
+	}
+}
+
+func TestPathManager_AuditFile(t *testing.T) {
+	pm := NewPathManager("/test/base")
+	if got, want := pm.AuditFile("douhashi/osoba"), "/test/base/audit/douhashi_osoba.jsonl"; got != want {
+		t.Errorf("AuditFile() = %v, want %v", got, want)
+	}
+}
+
+func TestPathManager_StoreFile(t *testing.T) {
+	pm := NewPathManager("/test/base")
+	if got, want := pm.StoreFile("douhashi/osoba", "retry-budget"), "/test/base/store/douhashi_osoba/retry-budget.json"; got != want {
+		t.Errorf("StoreFile() = %v, want %v", got, want)
+	}
+}
+
 func TestPathManager_EnsureDirectories(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("Skipping directory creation test on Windows")
@@ -227,3 +311,38 @@ func TestPathManager_AllPIDFiles(t *testing.T) {
 		}
 	}
 }
+
+func TestPathManager_AllControlSockets(t *testing.T) {
+	tmpDir := t.TempDir()
+	pm := NewPathManager(tmpDir)
+
+	found, err := pm.AllControlSockets()
+	if err != nil {
+		t.Fatalf("AllControlSockets() error = %v", err)
+	}
+	if len(found) != 0 {
+		t.Errorf("AllControlSockets() returned %d sockets before run directory exists, want 0", len(found))
+	}
+
+	if err := pm.EnsureDirectories(); err != nil {
+		t.Fatalf("Failed to create directories: %v", err)
+	}
+	for _, file := range []string{"owner1_repo1.sock", "owner2_repo2.sock", "owner1_repo1.pid"} {
+		if err := os.WriteFile(filepath.Join(pm.RunDir(), file), nil, 0600); err != nil {
+			t.Fatalf("Failed to create test file %s: %v", file, err)
+		}
+	}
+
+	found, err = pm.AllControlSockets()
+	if err != nil {
+		t.Fatalf("AllControlSockets() error = %v", err)
+	}
+	if len(found) != 2 {
+		t.Errorf("AllControlSockets() returned %d sockets, want 2", len(found))
+	}
+	for _, path := range found {
+		if !strings.HasSuffix(path, ".sock") {
+			t.Errorf("AllControlSockets() returned non-socket file: %s", path)
+		}
+	}
+}
@@ -140,6 +140,10 @@ func (b *ConfigBuilder) Build() *config.Config {
 					phaseCopy.Args = make([]string, len(config.Args))
 					copy(phaseCopy.Args, config.Args)
 				}
+				if config.Permissions != nil {
+					permissionsCopy := *config.Permissions
+					phaseCopy.Permissions = &permissionsCopy
+				}
 				cfgCopy.Claude.Phases[phase] = &phaseCopy
 			}
 		}
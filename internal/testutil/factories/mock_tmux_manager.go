@@ -220,6 +220,30 @@ func (m *MockTmuxManager) KillWindow(sessionName, windowName string) error {
 	return nil
 }
 
+// RenameWindow renames a window.
+func (m *MockTmuxManager) RenameWindow(sessionName, windowName, newName string) error {
+	if err := m.getError("RenameWindow"); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, exists := m.sessions[sessionName]
+	if !exists {
+		return fmt.Errorf("session %s does not exist", sessionName)
+	}
+	window, exists := session.Windows[windowName]
+	if !exists {
+		return fmt.Errorf("window %s does not exist", windowName)
+	}
+
+	delete(session.Windows, windowName)
+	window.Name = newName
+	session.Windows[newName] = window
+	return nil
+}
+
 // CreateOrReplaceWindow creates or replaces a window.
 func (m *MockTmuxManager) CreateOrReplaceWindow(sessionName, windowName string) error {
 	if err := m.getError("CreateOrReplaceWindow"); err != nil {
@@ -35,6 +35,12 @@ func (m *MockLabelManager) RemoveLabel(ctx context.Context, issueNumber int, lab
 	return args.Error(0)
 }
 
+// EditLabels はラベルの追加と削除をまとめて行う
+func (m *MockLabelManager) EditLabels(ctx context.Context, issueNumber int, add, remove []string) error {
+	args := m.Called(ctx, issueNumber, add, remove)
+	return args.Error(0)
+}
+
 // GetPullRequestForIssue はIssueに関連するPRを取得する
 func (m *MockLabelManager) GetPullRequestForIssue(ctx context.Context, issueNumber int) (*github.PullRequest, error) {
 	args := m.Called(ctx, issueNumber)
@@ -85,5 +91,6 @@ func (m *MockLabelManager) WithDefaultBehavior() *MockLabelManager {
 	m.On("TransitionLabel", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Maybe().Return(nil)
 	m.On("AddLabel", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Maybe().Return(nil)
 	m.On("RemoveLabel", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Maybe().Return(nil)
+	m.On("EditLabels", mock.Anything, mock.AnythingOfType("int"), mock.Anything, mock.Anything).Maybe().Return(nil)
 	return m
 }
@@ -89,5 +89,14 @@ func (m *MockGitWorktreeManager) HasUncommittedChanges(ctx context.Context, work
 	return args.Bool(0), args.Error(1)
 }
 
+// PreflightWorktreeForIssue mocks the PreflightWorktreeForIssue method
+func (m *MockGitWorktreeManager) PreflightWorktreeForIssue(ctx context.Context, issueNumber int) (*git.WorktreeHealth, error) {
+	args := m.Called(ctx, issueNumber)
+	if health := args.Get(0); health != nil {
+		return health.(*git.WorktreeHealth), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 // Ensure MockGitWorktreeManager implements git.WorktreeManager interface
 var _ git.WorktreeManager = (*MockGitWorktreeManager)(nil)
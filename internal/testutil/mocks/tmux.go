@@ -31,6 +31,7 @@ func (m *MockTmuxManager) WithDefaultBehavior() *MockTmuxManager {
 	m.On("SwitchToWindow", mock.Anything, mock.Anything).Maybe().Return(nil)
 	m.On("WindowExists", mock.Anything, mock.Anything).Maybe().Return(true, nil)
 	m.On("KillWindow", mock.Anything, mock.Anything).Maybe().Return(nil)
+	m.On("RenameWindow", mock.Anything, mock.Anything, mock.Anything).Maybe().Return(nil)
 	m.On("CreateOrReplaceWindow", mock.Anything, mock.Anything).Maybe().Return(nil)
 	m.On("ListWindows", mock.Anything).Maybe().Return([]string{}, nil)
 
@@ -114,6 +115,12 @@ func (m *MockTmuxManager) KillWindow(sessionName, windowName string) error {
 	return args.Error(0)
 }
 
+// RenameWindow mocks the RenameWindow method
+func (m *MockTmuxManager) RenameWindow(sessionName, windowName, newName string) error {
+	args := m.Called(sessionName, windowName, newName)
+	return args.Error(0)
+}
+
 // CreateOrReplaceWindow mocks the CreateOrReplaceWindow method
 func (m *MockTmuxManager) CreateOrReplaceWindow(sessionName, windowName string) error {
 	args := m.Called(sessionName, windowName)
@@ -230,6 +237,18 @@ func (m *MockTmuxManager) KillPane(sessionName, windowName string, paneIndex int
 	return args.Error(0)
 }
 
+// RespawnPane mocks the RespawnPane method
+func (m *MockTmuxManager) RespawnPane(sessionName, windowName string, paneIndex int) error {
+	args := m.Called(sessionName, windowName, paneIndex)
+	return args.Error(0)
+}
+
+// CapturePane mocks the CapturePane method
+func (m *MockTmuxManager) CapturePane(sessionName, windowName string, paneIndex int, lines int) (string, error) {
+	args := m.Called(sessionName, windowName, paneIndex, lines)
+	return args.String(0), args.Error(1)
+}
+
 // GetPaneBaseIndex mocks the GetPaneBaseIndex method
 func (m *MockTmuxManager) GetPaneBaseIndex() (int, error) {
 	args := m.Called()
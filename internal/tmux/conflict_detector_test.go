@@ -53,6 +53,7 @@ func (m *MockConflictManager) WindowExists(sessionName, windowName string) (bool
 	return false, nil
 }
 func (m *MockConflictManager) KillWindow(sessionName, windowName string) error            { return nil }
+func (m *MockConflictManager) RenameWindow(sessionName, windowName, newName string) error { return nil }
 func (m *MockConflictManager) CreateOrReplaceWindow(sessionName, windowName string) error { return nil }
 func (m *MockConflictManager) ListWindows(sessionName string) ([]string, error)           { return nil, nil }
 func (m *MockConflictManager) SendKeys(sessionName, windowName, keys string) error        { return nil }
@@ -101,6 +102,12 @@ func (m *MockConflictManager) GetWindowSize(sessionName, windowName string) (wid
 func (m *MockConflictManager) KillPane(sessionName, windowName string, paneIndex int) error {
 	return nil
 }
+func (m *MockConflictManager) RespawnPane(sessionName, windowName string, paneIndex int) error {
+	return nil
+}
+func (m *MockConflictManager) CapturePane(sessionName, windowName string, paneIndex int, lines int) (string, error) {
+	return "", nil
+}
 
 // DiagnosticManager methods
 func (m *MockConflictManager) DiagnoseSession(sessionName string) (*SessionDiagnostics, error) {
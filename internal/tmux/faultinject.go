@@ -0,0 +1,10 @@
+package tmux
+
+import (
+	"github.com/douhashi/osoba/internal/faultinject"
+)
+
+// SetFaultInjector はtmuxコマンドの実行に障害を注入するInjectorを設定する（nilで無効）
+func SetFaultInjector(injector *faultinject.Injector) {
+	pkg.faultInjector = injector
+}
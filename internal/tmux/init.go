@@ -79,6 +79,10 @@ func (m *testWindowManager) KillWindow(sessionName, windowName string) error {
 	return nil
 }
 
+func (m *testWindowManager) RenameWindow(sessionName, windowName, newName string) error {
+	return nil
+}
+
 func (m *testWindowManager) CreateOrReplaceWindow(sessionName, windowName string) error {
 	return nil
 }
@@ -175,6 +179,16 @@ func (m *testPaneManager) KillPane(sessionName, windowName string, paneIndex int
 	return nil
 }
 
+func (m *testPaneManager) RespawnPane(sessionName, windowName string, paneIndex int) error {
+	// テスト環境では常に成功
+	return nil
+}
+
+func (m *testPaneManager) CapturePane(sessionName, windowName string, paneIndex int, lines int) (string, error) {
+	// テスト環境では空の出力を返す
+	return "", nil
+}
+
 // testDiagnosticManager はテスト用のDiagnosticManager実装
 type testDiagnosticManager struct{}
 
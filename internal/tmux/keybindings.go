@@ -0,0 +1,103 @@
+package tmux
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// keybindingFallbackOption はosobaのキーバインドをインストールする前にキーに割り当てられていたコマンドを保存するサーバーのユーザーオプション
+// （キーごとに "@osoba_keybinding_fallback_<キー>" に保存し、osobaを再起動しても元のコマンドを失わないようにする）
+const keybindingFallbackOption = "@osoba_keybinding_fallback_"
+
+// osobaMenuTitle はosobaのメニューのタイトル（キーバインドがosobaのものかの判定にも使う）
+const osobaMenuTitle = "#[align=centre]osoba"
+
+// prefixBindingPattern はtmux list-keysの出力からprefixテーブルのキーバインドのコマンドを取り出すパターン
+var prefixBindingPattern = regexp.MustCompile(`^bind-key\s+(?:-r\s+)?(?:-N\s+"(?:[^"\\]|\\.)*"\s+)?-T\s+prefix\s+\S+\s+(.+)$`)
+
+// issueWindowFilter はIssueのウィンドウ（issue-N、N-phase）に一致するフォーマット
+// メニューの項目はメニュー作成時にフォーマットが展開されるため、choose-treeの各行で評価されるよう##でエスケープする
+const issueWindowFilter = "##{||:##{m:issue-*,##{window_name}},##{m:[0-9]*-*,##{window_name}}}"
+
+// issueNumberFormat はウィンドウ名（issue-N、N-phase）からIssue番号を取り出すフォーマット
+const issueNumberFormat = "#{s/-[a-z].*$//;s/^issue-//:window_name}"
+
+// osobaMenuCommand はosobaのメニューを表示するtmuxコマンドを返す
+// IssueのURLの取得とScratchペインの作成は、ペインの作業ディレクトリ（worktree）のリポジトリを対象に実行する
+func osobaMenuCommand() string {
+	items := []string{
+		fmt.Sprintf(`display-menu -T "%s"`, osobaMenuTitle),
+		fmt.Sprintf(`"Issueウィンドウへ移動" j { choose-tree -Zw -f "%s" }`, issueWindowFilter),
+		fmt.Sprintf(`"IssueのURLを表示" u { run-shell -c "#{pane_current_path}" 'gh issue view %s --json url --jq .url' }`, issueNumberFormat),
+		fmt.Sprintf(`"Scratchペインを開く" s { run-shell -b -c "#{pane_current_path}" 'osoba scratch --issue %s' }`, issueNumberFormat),
+		`""`,
+		`"Issueのウィンドウを閉じる" k { confirm-before -p "#{window_name} を閉じますか? (y/n)" kill-window }`,
+	}
+	return strings.Join(items, " ")
+}
+
+// InstallKeybindings はosobaのセッションでメニューを開くキーバインドをインストールする
+func InstallKeybindings(sessionPrefix, key string) error {
+	return InstallKeybindingsWithExecutor(sessionPrefix, key, &DefaultCommandExecutor{})
+}
+
+// InstallKeybindingsWithExecutor はExecutorを使用してキーバインドをインストールする
+// tmuxのキーバインドはサーバー全体で共有されるため、セッション名がプレフィックスに一致する場合のみメニューを表示し、
+// それ以外のセッションではキーに元々割り当てられていたコマンドを実行する
+func InstallKeybindingsWithExecutor(sessionPrefix, key string, executor CommandExecutor) error {
+	if sessionPrefix == "" {
+		return fmt.Errorf("session prefix cannot be empty")
+	}
+	if key == "" {
+		return fmt.Errorf("key cannot be empty")
+	}
+
+	condition := fmt.Sprintf("#{m:%s*,#{session_name}}", sessionPrefix)
+	args := []string{"bind-key", "-T", "prefix", key, "if-shell", "-F", condition, osobaMenuCommand()}
+	if fallback := previousBinding(key, executor); fallback != "" {
+		args = append(args, fallback)
+	}
+	if _, err := executor.Execute("tmux", args...); err != nil {
+		return fmt.Errorf("failed to bind key %s: %w", key, err)
+	}
+
+	if logger := GetLogger(); logger != nil {
+		logger.Info("osobaのキーバインドをインストール",
+			"session_prefix", sessionPrefix,
+			"key", key)
+	}
+	return nil
+}
+
+// previousBinding はosobaのキーバインドをインストールする前にキーに割り当てられていたコマンドを返す（割り当てがない場合は空）
+// すでにosobaのキーバインドがインストールされている場合は、最初のインストール時に保存したコマンドを返す
+func previousBinding(key string, executor CommandExecutor) string {
+	option := keybindingFallbackOption + key
+	output, err := executor.Execute("tmux", "list-keys", "-T", "prefix", key)
+	if err != nil {
+		// キーが割り当てられていない
+		_, _ = executor.Execute("tmux", "set-option", "-gu", option)
+		return ""
+	}
+	line := strings.TrimSpace(strings.SplitN(output, "\n", 2)[0])
+	if strings.Contains(line, osobaMenuTitle) {
+		saved, err := executor.Execute("tmux", "show-options", "-gqv", option)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(saved)
+	}
+
+	match := prefixBindingPattern.FindStringSubmatch(line)
+	if match == nil {
+		return ""
+	}
+	command := strings.TrimSpace(match[1])
+	if _, err := executor.Execute("tmux", "set-option", "-g", option, command); err != nil {
+		if logger := GetLogger(); logger != nil {
+			logger.Warn("Failed to save previous key binding", "key", key, "error", err)
+		}
+	}
+	return command
+}
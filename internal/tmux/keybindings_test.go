@@ -0,0 +1,89 @@
+package tmux
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInstallKeybindingsWithExecutor(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefix       string
+		key          string
+		listKeys     string // tmux list-keysの出力（空の場合はキーが割り当てられていない）
+		saved        string // 保存済みの元のコマンド
+		execErr      error
+		wantKey      string
+		wantFallback string // osobaのセッション以外で実行するコマンド
+		wantErrMsg   string
+	}{
+		{name: "指定したキーにバインド", prefix: "osoba-", key: "M", wantKey: "M"},
+		{
+			name:         "元のキーバインドはosobaのセッション以外で実行",
+			prefix:       "osoba-",
+			key:          "M",
+			listKeys:     "bind-key    -T prefix       M                    rotate-window -D\n",
+			wantKey:      "M",
+			wantFallback: "rotate-window -D",
+		},
+		{
+			name:         "再インストールでは保存した元のキーバインドを使う",
+			prefix:       "osoba-",
+			key:          "M",
+			listKeys:     `bind-key -T prefix M if-shell -F "#{m:osoba-*,#{session_name}}" "display-menu -T \"#[align=centre]osoba\"" "rotate-window -D"`,
+			saved:        "rotate-window -D\n",
+			wantKey:      "M",
+			wantFallback: "rotate-window -D",
+		},
+		{name: "キーが空", prefix: "osoba-", wantErrMsg: "key cannot be empty"},
+		{name: "プレフィックスが空", key: "M", wantErrMsg: "session prefix cannot be empty"},
+		{name: "tmuxのエラー", prefix: "osoba-", key: "M", wantKey: "M", execErr: errors.New("unknown key"), wantErrMsg: "failed to bind key M: unknown key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := new(MockCommandExecutor)
+			if tt.wantKey != "" {
+				option := keybindingFallbackOption + tt.wantKey
+				if tt.listKeys == "" {
+					executor.On("Execute", "tmux", []string{"list-keys", "-T", "prefix", tt.wantKey}).Return("", errors.New("unknown key")).Once()
+					executor.On("Execute", "tmux", []string{"set-option", "-gu", option}).Return("", nil).Once()
+				} else {
+					executor.On("Execute", "tmux", []string{"list-keys", "-T", "prefix", tt.wantKey}).Return(tt.listKeys, nil).Once()
+				}
+				if tt.saved != "" {
+					executor.On("Execute", "tmux", []string{"show-options", "-gqv", option}).Return(tt.saved, nil).Once()
+				} else if tt.wantFallback != "" {
+					executor.On("Execute", "tmux", []string{"set-option", "-g", option, tt.wantFallback}).Return("", nil).Once()
+				}
+
+				wantArgs := 8
+				if tt.wantFallback != "" {
+					wantArgs = 9
+				}
+				executor.On("Execute", "tmux", mock.MatchedBy(func(args []string) bool {
+					return len(args) == wantArgs &&
+						args[0] == "bind-key" && args[2] == "prefix" && args[3] == tt.wantKey &&
+						args[6] == "#{m:osoba-*,#{session_name}}" &&
+						assert.Contains(t, args[7], "display-menu") &&
+						assert.Contains(t, args[7], "choose-tree -Zw") &&
+						assert.Contains(t, args[7], "kill-window") &&
+						assert.Contains(t, args[7], "gh issue view") &&
+						assert.Contains(t, args[7], "osoba scratch --issue") &&
+						(tt.wantFallback == "" || args[8] == tt.wantFallback)
+				})).Return("", tt.execErr).Once()
+			}
+
+			err := InstallKeybindingsWithExecutor(tt.prefix, tt.key, executor)
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+			executor.AssertExpectations(t)
+		})
+	}
+}
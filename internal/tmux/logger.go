@@ -1,12 +1,14 @@
 package tmux
 
 import (
+	"github.com/douhashi/osoba/internal/faultinject"
 	"github.com/douhashi/osoba/internal/logger"
 )
 
 // packageState はパッケージレベルの状態を保持
 type packageState struct {
-	logger logger.Logger
+	logger        logger.Logger
+	faultInjector *faultinject.Injector // 障害注入（無効の場合はnil）
 }
 
 // pkg はパッケージレベルの状態インスタンス
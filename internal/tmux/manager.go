@@ -38,6 +38,9 @@ type WindowManager interface {
 	// KillWindow 指定されたウィンドウを削除
 	KillWindow(sessionName, windowName string) error
 
+	// RenameWindow 指定されたウィンドウの名前を変更
+	RenameWindow(sessionName, windowName, newName string) error
+
 	// CreateOrReplaceWindow ウィンドウが存在する場合は削除してから新規作成
 	CreateOrReplaceWindow(sessionName, windowName string) error
 
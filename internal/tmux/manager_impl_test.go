@@ -125,6 +125,20 @@ func TestDefaultManager_CreateSession(t *testing.T) {
 			sessionName: "new-session",
 			setup: func(m *mocks.MockTmuxCommandExecutor) {
 				m.On("Execute", "tmux", []string{"new-session", "-d", "-s", "new-session"}).Return("", nil)
+				m.On("Execute", "tmux", []string{"-V"}).Return("tmux 3.3a\n", nil)
+				m.On("Execute", "tmux", []string{"set-option", "-w", "-t", "new-session:", "pane-border-status", "top"}).Return("", nil)
+				m.On("Execute", "tmux", []string{"set-option", "-w", "-t", "new-session:", "pane-border-format", " #{pane_title} "}).Return("", nil)
+				m.On("Execute", "tmux", []string{"set-hook", "-t", "new-session", "after-new-window",
+					"set-option -w pane-border-status 'top' ; set-option -w pane-border-format ' #{pane_title} '"}).Return("", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:        "ペインタイトルに未対応のtmuxではオプションを設定しない",
+			sessionName: "old-session",
+			setup: func(m *mocks.MockTmuxCommandExecutor) {
+				m.On("Execute", "tmux", []string{"new-session", "-d", "-s", "old-session"}).Return("", nil)
+				m.On("Execute", "tmux", []string{"-V"}).Return("tmux 2.9a\n", nil)
 			},
 			wantErr: false,
 		},
@@ -22,13 +22,14 @@ const (
 )
 
 // CreatePane 新しいペインを作成
+// ペイン数制限が有効で上限に達している場合は、分割せずに最古のフェーズペインを再利用する
 func (m *DefaultManager) CreatePane(sessionName, windowName string, opts PaneOptions) (*PaneInfo, error) {
 	// ペイン数制限のチェック（ペイン作成前）
 	if opts.Config != nil && opts.Config.LimitPanesEnabled {
-		if err := m.enforcePaneLimit(sessionName, windowName, opts.Config.MaxPanesPerWindow); err != nil {
-			// ペイン制限のエラーはログに記録するが、処理は継続（ベストエフォート）
-			// 実際の運用環境ではログを出力する
-			// log.Printf("Warning: Failed to enforce pane limit: %v", err)
+		reusable, err := m.findReusablePane(sessionName, windowName, opts.Config.MaxPanesPerWindow)
+		// ペイン一覧の取得に失敗した場合は通常の分割にフォールバック（ベストエフォート）
+		if err == nil && reusable != nil {
+			return m.reusePane(sessionName, windowName, reusable, opts.Title)
 		}
 	}
 
@@ -38,8 +39,19 @@ func (m *DefaultManager) CreatePane(sessionName, windowName string, opts PaneOpt
 		percentage = 50
 	}
 
+	split := opts.Split
+	if split == SplitAuto {
+		split = m.resolveAutoSplit(sessionName, windowName)
+	}
+
 	// split-windowコマンドの実行
-	args := []string{"split-window", opts.Split, "-p", strconv.Itoa(percentage), "-t", fmt.Sprintf("%s:%s", sessionName, windowName)}
+	args := []string{"split-window", split, "-p", strconv.Itoa(percentage), "-t", fmt.Sprintf("%s:%s", sessionName, windowName)}
+	if opts.StartDir != "" {
+		args = append(args, "-c", opts.StartDir)
+	}
+	for _, env := range opts.Env {
+		args = append(args, "-e", env)
+	}
 	if _, err := m.executor.Execute("tmux", args...); err != nil {
 		return nil, fmt.Errorf("failed to create pane: %w", err)
 	}
@@ -75,46 +87,79 @@ func (m *DefaultManager) CreatePane(sessionName, windowName string, opts PaneOpt
 	return newPane, nil
 }
 
-// enforcePaneLimit ペイン数が制限を超えている場合、最古の非アクティブペインを削除
-func (m *DefaultManager) enforcePaneLimit(sessionName, windowName string, maxPanes int) error {
+// findReusablePane ペイン数が上限に達している場合、再利用する最古の非アクティブペインを返す
+// 上限未満、または非アクティブペインが無い場合はnilを返す
+func (m *DefaultManager) findReusablePane(sessionName, windowName string, maxPanes int) (*PaneInfo, error) {
 	if maxPanes <= 0 {
 		maxPanes = 3 // デフォルト値
 	}
 
 	panes, err := m.ListPanes(sessionName, windowName)
 	if err != nil {
-		return fmt.Errorf("failed to list panes: %w", err)
+		return nil, fmt.Errorf("failed to list panes: %w", err)
 	}
 
-	// 現在のペイン数が上限以下の場合は何もしない
+	// 現在のペイン数が上限未満の場合は再利用しない
 	if len(panes) < maxPanes {
-		return nil
+		return nil, nil
 	}
 
-	// 最古の非アクティブペインを探す
-	var oldestNonActiveIndex int = -1
+	// 最古の非アクティブペインを探す（Scratchペインは手動の作業を消さないよう除外）
+	var oldest *PaneInfo
 	for _, pane := range panes {
-		if !pane.Active {
-			if oldestNonActiveIndex == -1 || pane.Index < oldestNonActiveIndex {
-				oldestNonActiveIndex = pane.Index
-			}
+		if pane.Active || pane.Title == ScratchPaneTitle {
+			continue
+		}
+		if oldest == nil || pane.Index < oldest.Index {
+			oldest = pane
 		}
 	}
 
-	// 非アクティブペインが見つかった場合は削除
-	if oldestNonActiveIndex >= 0 {
-		if err := m.KillPane(sessionName, windowName, oldestNonActiveIndex); err != nil {
-			return fmt.Errorf("failed to kill pane %d: %w", oldestNonActiveIndex, err)
-		}
+	return oldest, nil
+}
 
-		// ペイン削除後のレイアウト調整
-		if err := m.ResizePanesEvenlyWithRetry(sessionName, windowName); err != nil {
-			// レイアウト調整エラーは記録するが、処理は継続
-			// log.Printf("Warning: Failed to adjust layout after pane removal: %v", err)
+// reusePane 既存ペインのプロセスを再起動し、新しいフェーズ用に再利用する
+func (m *DefaultManager) reusePane(sessionName, windowName string, pane *PaneInfo, title string) (*PaneInfo, error) {
+	if err := m.RespawnPane(sessionName, windowName, pane.Index); err != nil {
+		return nil, err
+	}
+
+	if title != "" {
+		if err := m.SetPaneTitle(sessionName, windowName, pane.Index, title); err != nil {
+			return nil, fmt.Errorf("failed to set pane title: %w", err)
 		}
 	}
 
-	return nil
+	if err := m.SelectPane(sessionName, windowName, pane.Index); err != nil {
+		return nil, fmt.Errorf("failed to select reused pane: %w", err)
+	}
+
+	return &PaneInfo{
+		Index:  pane.Index,
+		Title:  title,
+		Active: true,
+		Width:  pane.Width,
+		Height: pane.Height,
+	}, nil
+}
+
+// resolveAutoSplit ウィンドウの縦横比から分割方向を決定する
+// 横長のウィンドウは左右（-h）、縦長のウィンドウは上下（-v）に分割する
+func (m *DefaultManager) resolveAutoSplit(sessionName, windowName string) string {
+	width, height, err := m.GetWindowSize(sessionName, windowName)
+	if err != nil {
+		return SplitHorizontal
+	}
+	return ResolveSplitFlag(width, height)
+}
+
+// ResolveSplitFlag ウィンドウサイズから分割フラグを返す
+// 端末セルは縦長のため、幅を高さの2倍と比較する
+func ResolveSplitFlag(width, height int) string {
+	if width >= height*2 {
+		return SplitHorizontal
+	}
+	return SplitVertical
 }
 
 // SelectPane 指定されたペインを選択
@@ -318,3 +363,38 @@ func (m *DefaultManager) KillPane(sessionName, windowName string, paneIndex int)
 	}
 	return nil
 }
+
+// RespawnPane 指定されたペインのプロセスを再起動し、スクロールバックを消去
+func (m *DefaultManager) RespawnPane(sessionName, windowName string, paneIndex int) error {
+	target := fmt.Sprintf("%s:%s.%d", sessionName, windowName, paneIndex)
+	if _, err := m.executor.Execute("tmux", "respawn-pane", "-k", "-t", target); err != nil {
+		return fmt.Errorf("failed to respawn pane %s: %w", target, err)
+	}
+	if _, err := m.executor.Execute("tmux", "clear-history", "-t", target); err != nil {
+		return fmt.Errorf("failed to clear history of pane %s: %w", target, err)
+	}
+	return nil
+}
+
+// CapturePane 指定されたペインの末尾lines行の出力を取得
+func (m *DefaultManager) CapturePane(sessionName, windowName string, paneIndex int, lines int) (string, error) {
+	target := fmt.Sprintf("%s:%s.%d", sessionName, windowName, paneIndex)
+	args := []string{"capture-pane", "-p", "-J", "-t", target}
+	if lines > 0 {
+		args = append(args, "-S", fmt.Sprintf("-%d", lines))
+	}
+	output, err := m.executor.Execute("tmux", args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to capture pane %s: %w", target, err)
+	}
+
+	// 末尾の空行を除去してからlines行に切り詰める
+	trimmed := strings.TrimRight(output, "\n ")
+	if lines > 0 {
+		all := strings.Split(trimmed, "\n")
+		if len(all) > lines {
+			trimmed = strings.Join(all[len(all)-lines:], "\n")
+		}
+	}
+	return trimmed, nil
+}
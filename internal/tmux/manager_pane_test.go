@@ -61,7 +61,7 @@ func TestCreatePane_WithPaneLimit(t *testing.T) {
 			expectedPaneIdx: 2,
 		},
 		{
-			name: "制限有効・上限到達・非アクティブペイン削除",
+			name: "制限有効・上限到達・最古ペイン再利用",
 			config: &PaneConfig{
 				LimitPanesEnabled: true,
 				MaxPanesPerWindow: 3,
@@ -73,54 +73,23 @@ func TestCreatePane_WithPaneLimit(t *testing.T) {
 					"#{pane_index}:#{pane_title}:#{pane_active}:#{pane_width}:#{pane_height}"}).
 					Return("0:Plan:0:80:24\n1:Implementation:0:80:24\n2:Review:1:80:24", nil).Once()
 
-				// KillPane (最古の非アクティブペイン削除)
-				m.On("Execute", "tmux", []string{"kill-pane", "-t", "test-session:test-window.0"}).
+				// RespawnPane (最古の非アクティブペインを再利用)
+				m.On("Execute", "tmux", []string{"respawn-pane", "-k", "-t", "test-session:test-window.0"}).
 					Return("", nil).Once()
-
-				// ResizePanesEvenlyWithRetry after kill - ListPanes
-				m.On("Execute", "tmux", []string{"list-panes", "-t", "test-session:test-window", "-F",
-					"#{pane_index}:#{pane_title}:#{pane_active}:#{pane_width}:#{pane_height}"}).
-					Return("0:Implementation:0:120:24\n1:Review:1:120:24", nil).Once()
-
-				// ResizePanesEvenlyWithRetry after kill - GetWindowSize
-				m.On("Execute", "tmux", []string{"display-message", "-p", "-t", "test-session:test-window",
-					"#{window_width} #{window_height}"}).
-					Return("240 24", nil).Once()
-
-				// ResizePanesEvenlyWithRetry after kill - select-layout
-				m.On("Execute", "tmux", []string{"select-layout", "-t", "test-session:test-window", "even-horizontal"}).
-					Return("", nil).Once()
-
-				// CreatePane
-				m.On("Execute", "tmux", []string{"split-window", "-h", "-p", "50", "-t", "test-session:test-window"}).
+				m.On("Execute", "tmux", []string{"clear-history", "-t", "test-session:test-window.0"}).
 					Return("", nil).Once()
 
-				// ListPanes (作成後)
-				m.On("Execute", "tmux", []string{"list-panes", "-t", "test-session:test-window", "-F",
-					"#{pane_index}:#{pane_title}:#{pane_active}:#{pane_width}:#{pane_height}"}).
-					Return("0:Implementation:0:80:24\n1:Review:0:80:24\n2::1:80:24", nil).Once()
-
 				// SetPaneTitle
-				m.On("Execute", "tmux", []string{"set-option", "-t", "test-session:test-window.2", "-p",
+				m.On("Execute", "tmux", []string{"set-option", "-t", "test-session:test-window.0", "-p",
 					"pane-border-format", " Debug "}).
 					Return("", nil).Once()
 
-				// ResizePanesEvenlyWithRetry - ListPanes
-				m.On("Execute", "tmux", []string{"list-panes", "-t", "test-session:test-window", "-F",
-					"#{pane_index}:#{pane_title}:#{pane_active}:#{pane_width}:#{pane_height}"}).
-					Return("0:Implementation:0:80:24\n1:Review:0:80:24\n2:Debug:1:80:24", nil).Once()
-
-				// ResizePanesEvenlyWithRetry - GetWindowSize
-				m.On("Execute", "tmux", []string{"display-message", "-p", "-t", "test-session:test-window",
-					"#{window_width} #{window_height}"}).
-					Return("240 24", nil).Once()
-
-				// ResizePanesEvenlyWithRetry - select-layout
-				m.On("Execute", "tmux", []string{"select-layout", "-t", "test-session:test-window", "even-horizontal"}).
+				// SelectPane
+				m.On("Execute", "tmux", []string{"select-pane", "-t", "test-session:test-window.0"}).
 					Return("", nil).Once()
 			},
 			expectedError:   false,
-			expectedPaneIdx: 2,
+			expectedPaneIdx: 0,
 		},
 		{
 			name:   "制限無効",
@@ -175,8 +144,8 @@ func TestCreatePane_WithPaneLimit(t *testing.T) {
 
 			// タイトルを設定してテスト
 			titles := map[string]string{
-				"制限有効・上限未満":             "Review",
-				"制限有効・上限到達・非アクティブペイン削除": "Debug",
+				"制限有効・上限未満":          "Review",
+				"制限有効・上限到達・最古ペイン再利用": "Debug",
 				"制限無効": "Implementation",
 			}
 
@@ -197,99 +166,128 @@ func TestCreatePane_WithPaneLimit(t *testing.T) {
 	}
 }
 
-func TestEnforcePaneLimit(t *testing.T) {
+func TestFindReusablePane(t *testing.T) {
 	tests := []struct {
 		name          string
 		maxPanes      int
 		existingPanes string
-		setupMock     func(*MockCommandExecutor)
-		expectedError bool
+		expectedIndex int // -1は再利用なし
 	}{
 		{
 			name:          "上限未満",
 			maxPanes:      3,
 			existingPanes: "0:Plan:1:120:24\n1:Implementation:0:120:24",
-			setupMock: func(m *MockCommandExecutor) {
-				// ListPanes
-				m.On("Execute", "tmux", []string{"list-panes", "-t", "test-session:test-window", "-F",
-					"#{pane_index}:#{pane_title}:#{pane_active}:#{pane_width}:#{pane_height}"}).
-					Return("0:Plan:1:120:24\n1:Implementation:0:120:24", nil).Once()
-			},
-			expectedError: false,
+			expectedIndex: -1,
 		},
 		{
-			name:          "上限到達・非アクティブペイン削除",
+			name:          "上限到達・最古の非アクティブペイン",
 			maxPanes:      2,
 			existingPanes: "0:Plan:0:120:24\n1:Implementation:1:120:24",
-			setupMock: func(m *MockCommandExecutor) {
-				// ListPanes
-				m.On("Execute", "tmux", []string{"list-panes", "-t", "test-session:test-window", "-F",
-					"#{pane_index}:#{pane_title}:#{pane_active}:#{pane_width}:#{pane_height}"}).
-					Return("0:Plan:0:120:24\n1:Implementation:1:120:24", nil).Once()
-
-				// KillPane
-				m.On("Execute", "tmux", []string{"kill-pane", "-t", "test-session:test-window.0"}).
-					Return("", nil).Once()
-
-				// ResizePanesEvenlyWithRetry - ListPanes
-				m.On("Execute", "tmux", []string{"list-panes", "-t", "test-session:test-window", "-F",
-					"#{pane_index}:#{pane_title}:#{pane_active}:#{pane_width}:#{pane_height}"}).
-					Return("0:Implementation:1:240:24", nil).Once()
-
-				// ResizePanesEvenlyWithRetry - スキップ（ペイン1個のため）
-			},
-			expectedError: false,
+			expectedIndex: 0,
+		},
+		{
+			name:          "上限到達・最古ペインがアクティブ",
+			maxPanes:      2,
+			existingPanes: "0:Plan:1:120:24\n1:Implementation:0:120:24",
+			expectedIndex: 1,
+		},
+		{
+			name:          "上限到達・Scratchペインは再利用しない",
+			maxPanes:      3,
+			existingPanes: "0:Scratch:0:120:24\n1:Plan:0:120:24\n2:Implementation:1:120:24",
+			expectedIndex: 1,
 		},
 		{
 			name:          "デフォルト値使用",
 			maxPanes:      0,
-			existingPanes: "0:P1:0:60:24\n1:P2:0:60:24\n2:P3:1:60:24\n3:P4:0:60:24",
-			setupMock: func(m *MockCommandExecutor) {
-				// ListPanes
-				m.On("Execute", "tmux", []string{"list-panes", "-t", "test-session:test-window", "-F",
-					"#{pane_index}:#{pane_title}:#{pane_active}:#{pane_width}:#{pane_height}"}).
-					Return("0:P1:0:60:24\n1:P2:0:60:24\n2:P3:1:60:24\n3:P4:0:60:24", nil).Once()
+			existingPanes: "0:P1:1:60:24\n1:P2:0:60:24\n2:P3:0:60:24",
+			expectedIndex: 1,
+		},
+	}
 
-				// KillPane (デフォルト値3なので、4個→3個に削減)
-				m.On("Execute", "tmux", []string{"kill-pane", "-t", "test-session:test-window.0"}).
-					Return("", nil).Once()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExec := new(MockCommandExecutor)
+			mockExec.On("Execute", "tmux", []string{"list-panes", "-t", "test-session:test-window", "-F",
+				"#{pane_index}:#{pane_title}:#{pane_active}:#{pane_width}:#{pane_height}"}).
+				Return(tt.existingPanes, nil).Once()
+			defer mockExec.AssertExpectations(t)
 
-				// ResizePanesEvenlyWithRetry - ListPanes
-				m.On("Execute", "tmux", []string{"list-panes", "-t", "test-session:test-window", "-F",
-					"#{pane_index}:#{pane_title}:#{pane_active}:#{pane_width}:#{pane_height}"}).
-					Return("0:P2:0:80:24\n1:P3:1:80:24\n2:P4:0:80:24", nil).Once()
+			manager := NewDefaultManagerWithExecutor(mockExec)
+			pane, err := manager.findReusablePane("test-session", "test-window", tt.maxPanes)
 
-				// ResizePanesEvenlyWithRetry - GetWindowSize
-				m.On("Execute", "tmux", []string{"display-message", "-p", "-t", "test-session:test-window",
-					"#{window_width} #{window_height}"}).
-					Return("240 24", nil).Once()
+			assert.NoError(t, err)
+			if tt.expectedIndex < 0 {
+				assert.Nil(t, pane)
+			} else {
+				if assert.NotNil(t, pane) {
+					assert.Equal(t, tt.expectedIndex, pane.Index)
+				}
+			}
+		})
+	}
+}
 
-				// ResizePanesEvenlyWithRetry - select-layout
-				m.On("Execute", "tmux", []string{"select-layout", "-t", "test-session:test-window", "even-horizontal"}).
-					Return("", nil).Once()
-			},
-			expectedError: false,
-		},
+func TestCreatePane_AutoSplit(t *testing.T) {
+	tests := []struct {
+		name         string
+		windowSize   string
+		expectedFlag string
+	}{
+		{name: "横長ウィンドウは左右分割", windowSize: "240 50", expectedFlag: "-h"},
+		{name: "縦長ウィンドウは上下分割", windowSize: "100 60", expectedFlag: "-v"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockExec := new(MockCommandExecutor)
-			tt.setupMock(mockExec)
 			defer mockExec.AssertExpectations(t)
 
+			mockExec.On("Execute", "tmux", []string{"display-message", "-p", "-t", "test-session:test-window",
+				"#{window_width} #{window_height}"}).
+				Return(tt.windowSize, nil).Once()
+			mockExec.On("Execute", "tmux", []string{"split-window", tt.expectedFlag, "-p", "50", "-t", "test-session:test-window"}).
+				Return("", nil).Once()
+			mockExec.On("Execute", "tmux", []string{"list-panes", "-t", "test-session:test-window", "-F",
+				"#{pane_index}:#{pane_title}:#{pane_active}:#{pane_width}:#{pane_height}"}).
+				Return("0:Plan:0:80:24", nil).Twice() // 作成後とリサイズ前（1ペインのためリサイズはスキップ）
+
 			manager := NewDefaultManagerWithExecutor(mockExec)
-			err := manager.enforcePaneLimit("test-session", "test-window", tt.maxPanes)
+			pane, err := manager.CreatePane("test-session", "test-window", PaneOptions{Split: SplitAuto})
 
-			if tt.expectedError {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-			}
+			assert.NoError(t, err)
+			assert.NotNil(t, pane)
 		})
 	}
 }
 
+func TestCreatePane_StartDirAndEnv(t *testing.T) {
+	mockExec := new(MockCommandExecutor)
+	defer mockExec.AssertExpectations(t)
+
+	mockExec.On("Execute", "tmux", []string{"split-window", "-v", "-p", "30", "-t", "test-session:issue-12",
+		"-c", "/tmp/worktree", "-e", "OSOBA_ISSUE_NUMBER=12", "-e", "OSOBA_REPO=owner/repo"}).
+		Return("", nil).Once()
+	mockExec.On("Execute", "tmux", []string{"list-panes", "-t", "test-session:issue-12", "-F",
+		"#{pane_index}:#{pane_title}:#{pane_active}:#{pane_width}:#{pane_height}"}).
+		Return("0:Plan:0:80:24", nil).Twice()
+	mockExec.On("Execute", "tmux", []string{"set-option", "-t", "test-session:issue-12.0", "-p",
+		"pane-border-format", " Scratch "}).
+		Return("", nil).Once()
+
+	manager := NewDefaultManagerWithExecutor(mockExec)
+	pane, err := manager.CreatePane("test-session", "issue-12", PaneOptions{
+		Split:      SplitVertical,
+		Percentage: 30,
+		Title:      ScratchPaneTitle,
+		StartDir:   "/tmp/worktree",
+		Env:        []string{"OSOBA_ISSUE_NUMBER=12", "OSOBA_REPO=owner/repo"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ScratchPaneTitle, pane.Title)
+}
+
 func TestCreatePane_Error(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -337,3 +335,17 @@ func TestCreatePane_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestCapturePane(t *testing.T) {
+	mockExec := new(MockCommandExecutor)
+	defer mockExec.AssertExpectations(t)
+
+	mockExec.On("Execute", "tmux", []string{"capture-pane", "-p", "-J", "-t", "test-session:test-window.1", "-S", "-3"}).
+		Return("line1\nline2\nline3\nline4\n\n\n", nil).Once()
+
+	manager := NewDefaultManagerWithExecutor(mockExec)
+	output, err := manager.CapturePane("test-session", "test-window", 1, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "line2\nline3\nline4", output)
+}
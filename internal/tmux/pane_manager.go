@@ -28,14 +28,33 @@ type PaneManager interface {
 
 	// KillPane 指定されたペインを削除
 	KillPane(sessionName, windowName string, paneIndex int) error
+
+	// RespawnPane 指定されたペインのプロセスを再起動し、スクロールバックを消去
+	RespawnPane(sessionName, windowName string, paneIndex int) error
+
+	// CapturePane 指定されたペインの末尾lines行の出力を取得
+	CapturePane(sessionName, windowName string, paneIndex int, lines int) (string, error)
 }
 
+// 分割フラグ
+const (
+	SplitHorizontal = "-h"   // 左右に分割
+	SplitVertical   = "-v"   // 上下に分割
+	SplitAuto       = "auto" // ウィンドウサイズから自動判定
+)
+
+// ScratchPaneTitle は手動で操作するためのScratchペインのタイトル
+// フェーズのペインではないため、ペイン数制限による再利用の対象にしない
+const ScratchPaneTitle = "Scratch"
+
 // PaneOptions ペイン作成時のオプション
 type PaneOptions struct {
-	Split      string      // "-v" (vertical) or "-h" (horizontal)
+	Split      string      // "-v" (vertical), "-h" (horizontal) or "auto"
 	Percentage int         // split percentage
 	Title      string      // pane title for border
 	Config     *PaneConfig // ペイン管理設定（オプション）
+	StartDir   string      // ペインの作業ディレクトリ（オプション）
+	Env        []string    // ペインのシェルに設定する環境変数（KEY=VALUE、オプション）
 }
 
 // PaneConfig ペイン管理設定
@@ -0,0 +1,190 @@
+package tmux
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PanePlacement はフェーズのペインを配置したセッション・ウィンドウ
+type PanePlacement struct {
+	SessionName string `json:"session_name"`
+	WindowName  string `json:"window_name"`
+	// Fallback は端末サイズが小さくIssueのウィンドウを分割せずにフェーズ専用ウィンドウへ配置したか
+	Fallback bool `json:"fallback,omitempty"`
+	// Reason はフォールバックした理由（分割後の推定サイズと最小サイズ）
+	Reason string `json:"reason,omitempty"`
+}
+
+// PaneRegistry はIssue・フェーズごとのペインの配置を記録する
+// ペインの出力を取得する処理（進捗コメント・osoba tail・osoba takeoverなど）が、フォールバックしたウィンドウを探せるようにする
+// SetStorePathを指定した場合は配置をファイルに保存し、別のプロセス（osobaのコマンド）や再起動後の監視プロセスから参照できる
+// nilの場合は記録しない
+type PaneRegistry struct {
+	mu         sync.RWMutex
+	path       string // 配置の保存先（空の場合は保存しない）
+	placements map[string]PanePlacement
+}
+
+// NewPaneRegistry は新しいPaneRegistryを作成する
+func NewPaneRegistry() *PaneRegistry {
+	return &PaneRegistry{placements: make(map[string]PanePlacement)}
+}
+
+// LoadPaneRegistry は保存先から配置を読み込んだPaneRegistryを作成する
+func LoadPaneRegistry(path string) (*PaneRegistry, error) {
+	r := NewPaneRegistry()
+	if err := r.SetStorePath(path); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SetStorePath は配置の保存先を設定し、保存済みの配置を読み込む（ファイルが存在しない場合は何も読み込まない）
+func (r *PaneRegistry) SetStorePath(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err == nil {
+		placements := make(map[string]PanePlacement)
+		if err := json.Unmarshal(data, &placements); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		r.placements = placements
+	}
+	r.path = path
+	return nil
+}
+
+func paneRegistryKey(issueNumber int, phase string) string {
+	return fmt.Sprintf("%d/%s", issueNumber, phase)
+}
+
+// Record はIssueのフェーズ（設定ファイルのキー: plan, implement, review, revise）のペインの配置を記録する
+func (r *PaneRegistry) Record(issueNumber int, phase string, placement PanePlacement) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.placements[paneRegistryKey(issueNumber, phase)] = placement
+	if err := r.saveLocked(); err != nil {
+		if logger := GetLogger(); logger != nil {
+			logger.Warn("Failed to save pane placements", "path", r.path, "error", err)
+		}
+	}
+}
+
+// PlacementsForIssue はIssueのすべてのフェーズのペインの配置をフェーズ名順に返す
+func (r *PaneRegistry) PlacementsForIssue(issueNumber int) []PanePlacement {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := r.keysForIssueLocked(issueNumber)
+	placements := make([]PanePlacement, 0, len(keys))
+	for _, key := range keys {
+		placements = append(placements, r.placements[key])
+	}
+	return placements
+}
+
+// EntriesForIssue はIssueの記録を「Issue番号/フェーズ」の形式でフェーズ名順に返す
+func (r *PaneRegistry) EntriesForIssue(issueNumber int) []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.keysForIssueLocked(issueNumber)
+}
+
+// Forget はIssueのすべてのフェーズの記録を削除する（Issueのクリーンアップ時に使用する）
+func (r *PaneRegistry) Forget(issueNumber int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := r.keysForIssueLocked(issueNumber)
+	if len(keys) == 0 {
+		return
+	}
+	for _, key := range keys {
+		delete(r.placements, key)
+	}
+	if err := r.saveLocked(); err != nil {
+		if logger := GetLogger(); logger != nil {
+			logger.Warn("Failed to save pane placements", "path", r.path, "error", err)
+		}
+	}
+}
+
+// keysForIssueLocked はIssueの記録のキーをフェーズ名順に返す（r.muを保持して呼び出す）
+func (r *PaneRegistry) keysForIssueLocked(issueNumber int) []string {
+	prefix := strconv.Itoa(issueNumber) + "/"
+	var keys []string
+	for key := range r.placements {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// saveLocked は配置を保存先に書き出す（r.muを保持して呼び出す）
+func (r *PaneRegistry) saveLocked() error {
+	if r.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(r.placements, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.path)
+}
+
+// Lookup はIssueのフェーズのペインの配置を返す
+func (r *PaneRegistry) Lookup(issueNumber int, phase string) (PanePlacement, bool) {
+	if r == nil {
+		return PanePlacement{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	placement, ok := r.placements[paneRegistryKey(issueNumber, phase)]
+	return placement, ok
+}
+
+// EstimateSplitPaneSize はpanes個のペインがあるウィンドウをもう一度分割し、均等に並べた場合のペインのサイズを返す
+// splitがSplitAutoの場合はウィンドウの縦横比から分割方向を決定する（ペイン間の境界線の1セルを除く）
+func EstimateSplitPaneSize(width, height, panes int, split string) (int, int) {
+	if panes < 1 {
+		panes = 1
+	}
+	if split == SplitAuto {
+		split = ResolveSplitFlag(width, height)
+	}
+	if split == SplitVertical {
+		return width, (height - panes) / (panes + 1)
+	}
+	return (width - panes) / (panes + 1), height
+}
@@ -0,0 +1,82 @@
+package tmux
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateSplitPaneSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		height     int
+		panes      int
+		split      string
+		wantWidth  int
+		wantHeight int
+	}{
+		{name: "左右に分割", width: 81, height: 24, panes: 1, split: SplitHorizontal, wantWidth: 40, wantHeight: 24},
+		{name: "3個目のペインを左右に分割", width: 80, height: 24, panes: 2, split: SplitHorizontal, wantWidth: 26, wantHeight: 24},
+		{name: "上下に分割", width: 80, height: 41, panes: 1, split: SplitVertical, wantWidth: 80, wantHeight: 20},
+		{name: "autoは縦長のウィンドウを上下に分割", width: 60, height: 50, panes: 1, split: SplitAuto, wantWidth: 60, wantHeight: 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := EstimateSplitPaneSize(tt.width, tt.height, tt.panes, tt.split)
+			assert.Equal(t, tt.wantWidth, width)
+			assert.Equal(t, tt.wantHeight, height)
+		})
+	}
+}
+
+func TestPaneRegistry(t *testing.T) {
+	registry := NewPaneRegistry()
+	_, ok := registry.Lookup(42, "review")
+	assert.False(t, ok)
+
+	registry.Record(42, "review", PanePlacement{SessionName: "osoba-repo", WindowName: "42-review", Fallback: true})
+	placement, ok := registry.Lookup(42, "review")
+	assert.True(t, ok)
+	assert.Equal(t, "42-review", placement.WindowName)
+	assert.True(t, placement.Fallback)
+
+	var nilRegistry *PaneRegistry
+	nilRegistry.Record(42, "review", placement)
+	_, ok = nilRegistry.Lookup(42, "review")
+	assert.False(t, ok)
+	assert.Empty(t, nilRegistry.PlacementsForIssue(42))
+}
+
+func TestPaneRegistry_StorePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store", "panes.json")
+	registry := NewPaneRegistry()
+	require.NoError(t, registry.SetStorePath(path))
+	registry.Record(42, "review", PanePlacement{SessionName: "osoba-repo-2", WindowName: "42-review", Fallback: true})
+	registry.Record(42, "implement", PanePlacement{SessionName: "osoba-repo", WindowName: "issue-42"})
+	registry.Record(7, "plan", PanePlacement{SessionName: "osoba-repo", WindowName: "issue-7"})
+
+	// 別のプロセスから保存した配置を参照できる
+	loaded, err := LoadPaneRegistry(path)
+	require.NoError(t, err)
+	assert.Equal(t, []PanePlacement{
+		{SessionName: "osoba-repo", WindowName: "issue-42"},
+		{SessionName: "osoba-repo-2", WindowName: "42-review", Fallback: true},
+	}, loaded.PlacementsForIssue(42))
+
+	// クリーンアップしたIssueの記録だけを削除して保存する
+	assert.Equal(t, []string{"42/implement", "42/review"}, registry.EntriesForIssue(42))
+	registry.Forget(42)
+	loaded, err = LoadPaneRegistry(path)
+	require.NoError(t, err)
+	assert.Empty(t, loaded.EntriesForIssue(42))
+	assert.Equal(t, []string{"7/plan"}, loaded.EntriesForIssue(7))
+
+	// 保存先がない場合は空のレジストリを返す
+	empty, err := LoadPaneRegistry(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.Empty(t, empty.PlacementsForIssue(42))
+}
@@ -0,0 +1,67 @@
+package tmux
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// sessionEnv はosobaのセッションに設定する環境変数（セッション内で起動するフェーズのペインに引き継ぐ）
+var sessionEnv struct {
+	sync.RWMutex
+	values map[string]string
+}
+
+// SetSessionEnv はosobaのセッションに設定する環境変数を変更する（以降に作成・確保するセッションから適用される）
+// 空文字列を指定すると設定しない
+func SetSessionEnv(key, value string) {
+	sessionEnv.Lock()
+	defer sessionEnv.Unlock()
+	if value == "" {
+		delete(sessionEnv.values, key)
+		return
+	}
+	if sessionEnv.values == nil {
+		sessionEnv.values = make(map[string]string)
+	}
+	sessionEnv.values[key] = value
+}
+
+// sessionEnvValues はセッションに設定する環境変数をキーの順に返す
+func sessionEnvValues() [][2]string {
+	sessionEnv.RLock()
+	defer sessionEnv.RUnlock()
+	values := make([][2]string, 0, len(sessionEnv.values))
+	for key, value := range sessionEnv.values {
+		values = append(values, [2]string{key, value})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i][0] < values[j][0] })
+	return values
+}
+
+// ApplySessionEnv はセッションに環境変数を設定する
+func ApplySessionEnv(sessionName string) error {
+	return ApplySessionEnvWithExecutor(sessionName, &DefaultCommandExecutor{})
+}
+
+// ApplySessionEnvWithExecutor はExecutorを使用してセッションに環境変数を設定する
+// tmuxのセッションの環境変数は以降に作成するウィンドウ・ペインに引き継がれ、実行中のペインには影響しない
+func ApplySessionEnvWithExecutor(sessionName string, executor CommandExecutor) error {
+	for _, env := range sessionEnvValues() {
+		if _, err := executor.Execute("tmux", "set-environment", "-t", sessionName, env[0], env[1]); err != nil {
+			return fmt.Errorf("failed to set %s: %w", env[0], err)
+		}
+	}
+	return nil
+}
+
+// applySessionEnv はセッションに環境変数を設定する（失敗してもセッションは利用できるため、警告のみ出力する）
+func (m *DefaultManager) applySessionEnv(sessionName string) {
+	if err := ApplySessionEnvWithExecutor(sessionName, m.executor); err != nil {
+		if logger := GetLogger(); logger != nil {
+			logger.Warn("tmuxセッションの環境変数の設定に失敗",
+				"session_name", sessionName,
+				"error", err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package tmux
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySessionEnvWithExecutor(t *testing.T) {
+	SetSessionEnv("GH_TOKEN", "ghs_rotated")
+	SetSessionEnv("GH_HOST", "github.example.com")
+	t.Cleanup(func() {
+		SetSessionEnv("GH_TOKEN", "")
+		SetSessionEnv("GH_HOST", "")
+	})
+
+	executor := new(MockCommandExecutor)
+	executor.On("Execute", "tmux", []string{"set-environment", "-t", "osoba-repo", "GH_HOST", "github.example.com"}).Return("", nil).Once()
+	executor.On("Execute", "tmux", []string{"set-environment", "-t", "osoba-repo", "GH_TOKEN", "ghs_rotated"}).Return("", nil).Once()
+	require.NoError(t, ApplySessionEnvWithExecutor("osoba-repo", executor))
+	executor.AssertExpectations(t)
+
+	t.Run("空文字列で設定を外す", func(t *testing.T) {
+		SetSessionEnv("GH_HOST", "")
+		executor := new(MockCommandExecutor)
+		executor.On("Execute", "tmux", []string{"set-environment", "-t", "osoba-repo", "GH_TOKEN", "ghs_rotated"}).Return("", nil).Once()
+		require.NoError(t, ApplySessionEnvWithExecutor("osoba-repo", executor))
+		executor.AssertExpectations(t)
+	})
+
+	t.Run("セッションがない", func(t *testing.T) {
+		executor := new(MockCommandExecutor)
+		executor.On("Execute", "tmux", mock.Anything).Return("", errors.New("can't find session: osoba-repo"))
+		err := ApplySessionEnvWithExecutor("osoba-repo", executor)
+		assert.EqualError(t, err, "failed to set GH_TOKEN: can't find session: osoba-repo")
+	})
+}
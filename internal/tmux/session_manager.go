@@ -81,6 +81,8 @@ func (m *DefaultManager) CreateSession(sessionName string) error {
 		}
 		return fmt.Errorf("tmuxセッションの作成に失敗: %w", err)
 	}
+	m.bootstrapSessionOptions(sessionName)
+	m.applySessionEnv(sessionName)
 
 	if logger := GetLogger(); logger != nil {
 		logger.Info("tmuxセッション作成完了",
@@ -108,6 +110,8 @@ func (m *DefaultManager) EnsureSession(sessionName string) error {
 		logger.Debug("セッションは既に存在します",
 			"session_name", sessionName)
 	}
+	// 以前の起動で作成したセッションにも、今回のトークンなどを設定する
+	m.applySessionEnv(sessionName)
 	return nil
 }
 
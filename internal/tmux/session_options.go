@@ -0,0 +1,88 @@
+package tmux
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ペインタイトルの表示に必要なtmuxのバージョン（ペイン単位のオプション指定 set-option -p は3.0以降）
+const (
+	minPaneTitleMajor = 3
+	minPaneTitleMinor = 0
+)
+
+// sessionWindowOptions はosobaのセッションのウィンドウに設定するオプション
+// ペインのタイトルはSetPaneTitleがペイン単位のpane-border-formatで上書きする
+var sessionWindowOptions = [][2]string{
+	{"pane-border-status", "top"},
+	{"pane-border-format", " #{pane_title} "},
+}
+
+var tmuxVersionPattern = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// bootstrapSessionOptions はペインタイトルの表示に必要なオプションをセッション内に限定して設定する
+// ユーザーのtmux.confに依存しないよう、既存のウィンドウに設定し、以降に作成されるウィンドウにはフックで設定する
+// 設定に失敗してもセッションは利用できるため、警告のみ出力する
+func (m *DefaultManager) bootstrapSessionOptions(sessionName string) {
+	version, supported := m.paneTitleSupported()
+	if !supported {
+		if logger := GetLogger(); logger != nil {
+			logger.Warn("tmuxのバージョンがペインタイトルの表示に対応していないため、表示設定をスキップします",
+				"session_name", sessionName,
+				"version", version,
+				"required", fmt.Sprintf("%d.%d", minPaneTitleMajor, minPaneTitleMinor))
+		}
+		return
+	}
+
+	hookCommands := make([]string, 0, len(sessionWindowOptions))
+	for _, opt := range sessionWindowOptions {
+		if _, err := m.executor.Execute("tmux", "set-option", "-w", "-t", sessionName+":", opt[0], opt[1]); err != nil {
+			if logger := GetLogger(); logger != nil {
+				logger.Warn("tmuxオプションの設定に失敗",
+					"session_name", sessionName,
+					"option", opt[0],
+					"error", err)
+			}
+		}
+		hookCommands = append(hookCommands, fmt.Sprintf("set-option -w %s '%s'", opt[0], opt[1]))
+	}
+
+	hook := strings.Join(hookCommands, " ; ")
+	if _, err := m.executor.Execute("tmux", "set-hook", "-t", sessionName, "after-new-window", hook); err != nil {
+		if logger := GetLogger(); logger != nil {
+			logger.Warn("tmuxフックの設定に失敗",
+				"session_name", sessionName,
+				"hook", "after-new-window",
+				"error", err)
+		}
+	}
+}
+
+// paneTitleSupported はtmuxのバージョンとペインタイトルの表示に対応しているかを返す
+// バージョンを判定できない場合（開発版など）は対応しているものとして扱う
+func (m *DefaultManager) paneTitleSupported() (string, bool) {
+	output, err := m.executor.Execute("tmux", "-V")
+	if err != nil {
+		return "", true
+	}
+	version := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(output), "tmux"))
+	major, minor, ok := parseTmuxVersion(version)
+	if !ok {
+		return version, true
+	}
+	return version, major > minPaneTitleMajor || (major == minPaneTitleMajor && minor >= minPaneTitleMinor)
+}
+
+// parseTmuxVersion は"3.3a"や"next-3.4"のようなバージョン文字列からメジャー・マイナーバージョンを取り出す
+func parseTmuxVersion(version string) (int, int, bool) {
+	m := tmuxVersionPattern.FindStringSubmatch(version)
+	if m == nil {
+		return 0, 0, false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	return major, minor, true
+}
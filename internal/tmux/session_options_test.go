@@ -0,0 +1,33 @@
+package tmux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTmuxVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		wantMajor int
+		wantMinor int
+		wantOK    bool
+	}{
+		{name: "リリース版", version: "3.3a", wantMajor: 3, wantMinor: 3, wantOK: true},
+		{name: "古いバージョン", version: "2.9", wantMajor: 2, wantMinor: 9, wantOK: true},
+		{name: "開発版", version: "next-3.4", wantMajor: 3, wantMinor: 4, wantOK: true},
+		{name: "判定できないバージョン", version: "master", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			major, minor, ok := parseTmuxVersion(tt.version)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.wantMajor, major)
+				assert.Equal(t, tt.wantMinor, minor)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package tmux
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ステータスラインで参照できるセッションのユーザーオプション（#{@osoba_active} など）
+const (
+	StatusOptionActive  = "@osoba_active"  // フェーズを実行中のIssueの数
+	StatusOptionWaiting = "@osoba_waiting" // フェーズの開始を待っているIssueの数
+	StatusOptionFailing = "@osoba_failing" // リトライを繰り返して失敗が続いているIssueの数
+	StatusOptionHealth  = "@osoba_health"  // パイプラインの健全性（healthy / 2 failing など）
+)
+
+// StatusOptions はステータスラインに公開するIssueの集計
+type StatusOptions struct {
+	Active  int
+	Waiting int
+	Failing int
+	Health  string
+}
+
+// values はユーザーオプションの名前と値を返す
+func (o StatusOptions) values() [][2]string {
+	return [][2]string{
+		{StatusOptionActive, strconv.Itoa(o.Active)},
+		{StatusOptionWaiting, strconv.Itoa(o.Waiting)},
+		{StatusOptionFailing, strconv.Itoa(o.Failing)},
+		{StatusOptionHealth, o.Health},
+	}
+}
+
+// PublishStatusOptions はセッションのユーザーオプションにIssueの集計を設定する
+func PublishStatusOptions(sessionName string, opts StatusOptions) error {
+	return PublishStatusOptionsWithExecutor(sessionName, opts, &DefaultCommandExecutor{})
+}
+
+// PublishStatusOptionsWithExecutor はExecutorを使用してセッションのユーザーオプションにIssueの集計を設定する
+// 同じtmuxサーバーで複数のリポジトリを監視できるよう、グローバルではなくセッションのオプションとして設定する
+func PublishStatusOptionsWithExecutor(sessionName string, opts StatusOptions, executor CommandExecutor) error {
+	if sessionName == "" {
+		return errors.New("session name cannot be empty")
+	}
+	for _, opt := range opts.values() {
+		if _, err := executor.Execute("tmux", "set-option", "-q", "-t", sessionName, opt[0], opt[1]); err != nil {
+			return fmt.Errorf("failed to set %s: %w", opt[0], err)
+		}
+	}
+	return nil
+}
+
+// ClearStatusOptions はセッションのユーザーオプションを削除する（監視の終了後に古い集計が表示されないようにする）
+func ClearStatusOptions(sessionName string) error {
+	return ClearStatusOptionsWithExecutor(sessionName, &DefaultCommandExecutor{})
+}
+
+// ClearStatusOptionsWithExecutor はExecutorを使用してセッションのユーザーオプションを削除する
+func ClearStatusOptionsWithExecutor(sessionName string, executor CommandExecutor) error {
+	if sessionName == "" {
+		return errors.New("session name cannot be empty")
+	}
+	for _, opt := range (StatusOptions{}).values() {
+		if _, err := executor.Execute("tmux", "set-option", "-q", "-u", "-t", sessionName, opt[0]); err != nil {
+			return fmt.Errorf("failed to unset %s: %w", opt[0], err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package tmux
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishStatusOptionsWithExecutor(t *testing.T) {
+	executor := new(MockCommandExecutor)
+	for _, opt := range [][2]string{
+		{"@osoba_active", "2"},
+		{"@osoba_waiting", "3"},
+		{"@osoba_failing", "1"},
+		{"@osoba_health", "1 failing"},
+	} {
+		executor.On("Execute", "tmux", []string{"set-option", "-q", "-t", "osoba-repo", opt[0], opt[1]}).Return("", nil).Once()
+	}
+
+	err := PublishStatusOptionsWithExecutor("osoba-repo", StatusOptions{Active: 2, Waiting: 3, Failing: 1, Health: "1 failing"}, executor)
+	require.NoError(t, err)
+	executor.AssertExpectations(t)
+
+	t.Run("セッションがない", func(t *testing.T) {
+		executor := new(MockCommandExecutor)
+		executor.On("Execute", "tmux", mock.Anything).Return("", errors.New("can't find session: osoba-repo"))
+		err := PublishStatusOptionsWithExecutor("osoba-repo", StatusOptions{}, executor)
+		assert.EqualError(t, err, "failed to set @osoba_active: can't find session: osoba-repo")
+	})
+}
+
+func TestClearStatusOptionsWithExecutor(t *testing.T) {
+	executor := new(MockCommandExecutor)
+	for _, name := range []string{"@osoba_active", "@osoba_waiting", "@osoba_failing", "@osoba_health"} {
+		executor.On("Execute", "tmux", []string{"set-option", "-q", "-u", "-t", "osoba-repo", name}).Return("", nil).Once()
+	}
+
+	require.NoError(t, ClearStatusOptionsWithExecutor("osoba-repo", executor))
+	executor.AssertExpectations(t)
+
+	assert.EqualError(t, ClearStatusOptionsWithExecutor("", executor), "session name cannot be empty")
+}
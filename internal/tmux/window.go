@@ -6,6 +6,9 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/douhashi/osoba/internal/faultinject"
+	"github.com/douhashi/osoba/internal/trace"
 )
 
 // CommandExecutor はコマンド実行のインターフェース
@@ -18,8 +21,13 @@ type DefaultCommandExecutor struct{}
 
 // Execute はコマンドを実行する
 func (e *DefaultCommandExecutor) Execute(cmd string, args ...string) (string, error) {
+	if err := pkg.faultInjector.Inject(faultinject.TargetTmux, args); err != nil {
+		return "", err
+	}
 	command := exec.Command(cmd, args...)
+	done := trace.Start(cmd, args, "")
 	output, err := command.Output()
+	done(err)
 	return string(output), err
 }
 
@@ -11,6 +11,11 @@ func GetWindowNameForIssue(issueNumber int) string {
 	return fmt.Sprintf("issue-%d", issueNumber)
 }
 
+// GetPhaseWindowNameForIssue はフェーズ専用ウィンドウの名前を生成する（"{番号}-{フェーズ}"形式）
+func GetPhaseWindowNameForIssue(issueNumber int, phase string) string {
+	return fmt.Sprintf("%d-%s", issueNumber, phase)
+}
+
 // ParseWindowNameForIssue はウィンドウ名からIssue番号を抽出する（フェーズを含まない形式）
 func ParseWindowNameForIssue(windowName string) (int, error) {
 	// "issue-123" 形式からIssue番号を抽出
@@ -209,6 +209,22 @@ func (m *DefaultManager) KillWindow(sessionName, windowName string) error {
 	return nil
 }
 
+// RenameWindow 指定されたウィンドウの名前を変更
+func (m *DefaultManager) RenameWindow(sessionName, windowName, newName string) error {
+	if sessionName == "" {
+		return fmt.Errorf("session name cannot be empty")
+	}
+	if windowName == "" || newName == "" {
+		return fmt.Errorf("window name cannot be empty")
+	}
+
+	target := fmt.Sprintf("%s:%s", sessionName, windowName)
+	if _, err := m.executor.Execute("tmux", "rename-window", "-t", target, newName); err != nil {
+		return fmt.Errorf("failed to rename window %s to %s: %w", target, newName, err)
+	}
+	return nil
+}
+
 // CreateOrReplaceWindow ウィンドウが存在する場合は削除してから新規作成
 func (m *DefaultManager) CreateOrReplaceWindow(sessionName, windowName string) error {
 	if logger := GetLogger(); logger != nil {
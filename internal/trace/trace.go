@@ -0,0 +1,204 @@
+// Package trace は外部コマンド（tmux, gh, git, claude）の実行をすべて記録し、
+// 不具合の再現やバグ報告への添付に使えるトランスクリプトをポーリングのサイクルごとに作成する
+//
+// トランスクリプトはシェルスクリプト形式で、コマンドごとに実行時刻・終了コード・所要時間をコメントとして記録する
+// sh で実行すると記録したコマンドを同じ順序で再実行できる
+package trace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Recorder は外部コマンドの実行をサイクルごとのトランスクリプトファイルに記録する
+// nilの場合は何も記録しない
+type Recorder struct {
+	dir string
+	now func() time.Time
+
+	mu    sync.Mutex
+	cycle int
+	file  *os.File
+	path  string
+}
+
+// NewRecorder はdir配下にトランスクリプトを作成するRecorderを作成する
+// 最初のサイクル（起動処理）のトランスクリプトを作成した状態で返す
+func NewRecorder(dir string) (*Recorder, error) {
+	if dir == "" {
+		return nil, errors.New("transcript directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create transcript directory: %w", err)
+	}
+	r := &Recorder{dir: dir, now: time.Now}
+	if err := r.StartCycle(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// StartCycle は現在のトランスクリプトを閉じ、次のサイクルのトランスクリプトを作成する
+func (r *Recorder) StartCycle() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file != nil {
+		_ = r.file.Close()
+		r.file = nil
+	}
+	r.cycle++
+	now := r.now()
+	path := filepath.Join(r.dir, fmt.Sprintf("cycle-%06d-%s.sh", r.cycle, now.UTC().Format("20060102T150405Z")))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create transcript: %w", err)
+	}
+	fmt.Fprintf(file, "#!/bin/sh\n# osoba command transcript (cycle %d, started %s)\n", r.cycle, now.UTC().Format(time.RFC3339))
+	r.file = file
+	r.path = path
+	return nil
+}
+
+// Path は現在のサイクルのトランスクリプトのパスを返す
+func (r *Recorder) Path() string {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.path
+}
+
+// Close は現在のトランスクリプトを閉じる
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Start はコマンドの実行の開始を記録し、実行が終わったときに結果のエラーを渡して呼び出す関数を返す
+// dirは作業ディレクトリ（空の場合はカレントディレクトリ）
+func (r *Recorder) Start(command string, args []string, dir string) func(err error) {
+	if r == nil {
+		return func(error) {}
+	}
+	started := r.now()
+	return func(err error) {
+		r.record(started, r.now().Sub(started), command, args, dir, err)
+	}
+}
+
+// record はコマンドの実行結果をトランスクリプトに書き込む
+// 並行して実行したコマンドの記録が混ざらないよう、1つのコマンドの記録は1回の書き込みで行う
+func (r *Recorder) record(started time.Time, duration time.Duration, command string, args []string, dir string, err error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s exit=%d duration=%s", started.UTC().Format("2006-01-02T15:04:05.000Z07:00"), ExitCode(err), duration.Round(time.Millisecond))
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		fmt.Fprintf(&b, " error=%q", err.Error())
+	}
+	b.WriteString("\n")
+	line := quoteCommand(command, args)
+	if dir != "" {
+		line = fmt.Sprintf("(cd %s && %s)", shellQuote(dir), line)
+	}
+	b.WriteString(line)
+	b.WriteString("\n")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return
+	}
+	_, _ = r.file.WriteString(b.String())
+}
+
+// ExitCode はコマンドの実行結果のエラーから終了コードを返す
+// 成功した場合は0、終了コードを取得できない失敗（起動できない、キャンセルされたなど）の場合は-1を返す
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// quoteCommand はコマンドと引数をシェルで実行できる形式に変換する
+func quoteCommand(command string, args []string) string {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, shellQuote(command))
+	for _, arg := range args {
+		parts = append(parts, shellQuote(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// shellQuote は必要な場合に引数をシングルクォートで囲む
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	safe := true
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("-_./:=@%+,#", c)) {
+			safe = false
+			break
+		}
+	}
+	if safe && s[0] != '#' {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// current はプロセス全体で使うRecorder（無効の場合はnil）
+var current struct {
+	sync.RWMutex
+	recorder *Recorder
+}
+
+// SetRecorder はプロセス全体で使うRecorderを設定する（nilで無効）
+// 外部コマンドはパッケージをまたいで実行されるため、各パッケージはStartでこのRecorderに記録する
+func SetRecorder(r *Recorder) {
+	current.Lock()
+	defer current.Unlock()
+	current.recorder = r
+}
+
+// Default はプロセス全体で使うRecorderを返す（無効の場合はnil）
+func Default() *Recorder {
+	current.RLock()
+	defer current.RUnlock()
+	return current.recorder
+}
+
+// Start はプロセス全体で使うRecorderにコマンドの実行の開始を記録する（無効の場合は何もしない）
+func Start(command string, args []string, dir string) func(err error) {
+	return Default().Start(command, args, dir)
+}
+
+// StartCycle はプロセス全体で使うRecorderのトランスクリプトを次のサイクルに切り替える（無効の場合は何もしない）
+func StartCycle() error {
+	return Default().StartCycle()
+}
@@ -0,0 +1,110 @@
+package trace
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecorderForTest(t *testing.T) *Recorder {
+	t.Helper()
+	r, err := NewRecorder(t.TempDir())
+	require.NoError(t, err)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	r.now = func() time.Time {
+		now = now.Add(250 * time.Millisecond)
+		return now
+	}
+	t.Cleanup(func() { _ = r.Close() })
+	return r
+}
+
+func TestRecorder_Start(t *testing.T) {
+	exitErr := exec.Command("sh", "-c", "exit 3").Run()
+	require.Error(t, exitErr)
+
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+		dir     string
+		err     error
+		want    string
+	}{
+		{
+			name:    "成功したコマンド",
+			command: "gh",
+			args:    []string{"issue", "list", "--label", "status:ready"},
+			want:    "# 2024-05-01T12:00:00.250Z exit=0 duration=250ms\ngh issue list --label status:ready\n",
+		},
+		{
+			name:    "作業ディレクトリと空白を含む引数",
+			command: "git",
+			args:    []string{"commit", "-m", "it's done"},
+			dir:     "/tmp/work tree",
+			err:     exitErr,
+			want:    "# 2024-05-01T12:00:00.250Z exit=3 duration=250ms\n(cd '/tmp/work tree' && git commit -m 'it'\\''s done')\n",
+		},
+		{
+			name:    "起動できなかったコマンド",
+			command: "claude",
+			args:    []string{"--version"},
+			err:     errors.New("executable file not found"),
+			want:    "# 2024-05-01T12:00:00.250Z exit=-1 duration=250ms error=\"executable file not found\"\nclaude --version\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRecorderForTest(t)
+			r.Start(tt.command, tt.args, tt.dir)(tt.err)
+
+			data, err := os.ReadFile(r.Path())
+			require.NoError(t, err)
+			assert.Contains(t, string(data), "#!/bin/sh\n")
+			assert.Contains(t, string(data), tt.want)
+		})
+	}
+}
+
+func TestRecorder_StartCycle(t *testing.T) {
+	r := newRecorderForTest(t)
+	first := r.Path()
+	r.Start("tmux", []string{"list-windows"}, "")(nil)
+
+	require.NoError(t, r.StartCycle())
+	second := r.Path()
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, filepath.Dir(first), filepath.Dir(second))
+	r.Start("git", []string{"fetch"}, "")(nil)
+
+	data, err := os.ReadFile(first)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "tmux list-windows")
+	assert.NotContains(t, string(data), "git fetch")
+
+	data, err = os.ReadFile(second)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "cycle 2")
+	assert.Contains(t, string(data), "git fetch")
+}
+
+func TestRecorder_NilDoesNothing(t *testing.T) {
+	var r *Recorder
+	r.Start("gh", []string{"api"}, "")(nil)
+	assert.NoError(t, r.StartCycle())
+	assert.Empty(t, r.Path())
+	assert.NoError(t, r.Close())
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, ExitCode(nil))
+	assert.Equal(t, -1, ExitCode(errors.New("context canceled")))
+	assert.Equal(t, 2, ExitCode(exec.Command("sh", "-c", "exit 2").Run()))
+}
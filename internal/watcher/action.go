@@ -89,22 +89,12 @@ func (m *ActionManager) GetActionForIssue(issue *github.Issue) ActionExecutor {
 	}
 	log.Printf("[DEBUG] Issue #%d has labels: %v", *issue.Number, labels)
 
-	// ラベルを確認して適切なアクションを返す
-	if hasLabel(issue, "status:needs-plan") {
-		log.Printf("[DEBUG] Issue #%d has status:needs-plan label, creating PlanAction", *issue.Number)
-		return m.actionFactory.CreatePlanAction()
-	}
-	if hasLabel(issue, "status:ready") {
-		log.Printf("[DEBUG] Issue #%d has status:ready label, creating ImplementationAction", *issue.Number)
-		return m.actionFactory.CreateImplementationAction()
-	}
-	if hasLabel(issue, "status:review-requested") {
-		log.Printf("[DEBUG] Issue #%d has status:review-requested label, creating ReviewAction", *issue.Number)
-		return m.actionFactory.CreateReviewAction()
-	}
-	if hasLabel(issue, "status:requires-changes") {
-		log.Printf("[DEBUG] Issue #%d has status:requires-changes label, creating ReviseAction", *issue.Number)
-		return m.actionFactory.CreateReviseAction()
+	// workflowの定義に従って、トリガーラベルのフェーズのアクションを返す
+	if t, ok := findWorkflowTransition(issue); ok {
+		log.Printf("[DEBUG] Issue #%d has %s label, creating action for phase %q", *issue.Number, t.From, t.Phase)
+		if action := createActionForPhase(m.actionFactory, t.Phase); action != nil {
+			return action
+		}
 	}
 
 	log.Printf("[DEBUG] No matching label found for issue #%d", *issue.Number)
@@ -30,6 +30,9 @@ type DefaultActionFactory struct {
 	config          *config.Config
 	owner           string
 	repo            string
+	botReviews      actions.BotReviewSource
+	artifactsRoot   string
+	paneRegistry    *tmux.PaneRegistry
 	logger          logger.Logger
 }
 
@@ -62,7 +65,7 @@ func NewDefaultActionFactory(
 
 // CreatePlanAction は計画フェーズのアクションを作成する
 func (f *DefaultActionFactory) CreatePlanAction() ActionExecutor {
-	return actions.NewPlanAction(
+	action := actions.NewPlanAction(
 		f.sessionName,
 		f.tmuxManager,
 		f.worktreeManager,
@@ -71,6 +74,9 @@ func (f *DefaultActionFactory) CreatePlanAction() ActionExecutor {
 		f.claudeConfig,
 		f.logger.WithFields("component", "PlanAction"),
 	)
+	action.SetArtifactsRoot(f.artifactsRoot)
+	action.SetPaneRegistry(f.paneRegistry)
+	return action
 }
 
 // CreateImplementationAction は実装フェーズのアクションを作成する
@@ -81,15 +87,19 @@ func (f *DefaultActionFactory) CreateImplementationAction() ActionExecutor {
 		Repo:         f.repo,
 	}
 
-	return actions.NewImplementationAction(
+	action := actions.NewImplementationAction(
 		f.sessionName,
 		f.tmuxManager,
 		labelManager,
 		f.worktreeManager,
+		f.config,
 		f.claudeExecutor,
 		f.claudeConfig,
 		f.logger.WithFields("component", "ImplementationAction"),
 	)
+	action.SetArtifactsRoot(f.artifactsRoot)
+	action.SetPaneRegistry(f.paneRegistry)
+	return action
 }
 
 // CreateReviewAction はレビューフェーズのアクションを作成する
@@ -100,15 +110,40 @@ func (f *DefaultActionFactory) CreateReviewAction() ActionExecutor {
 		Repo:         f.repo,
 	}
 
-	return actions.NewReviewAction(
+	action := actions.NewReviewAction(
 		f.sessionName,
 		f.tmuxManager,
 		labelManager,
 		f.worktreeManager,
+		f.config,
 		f.claudeExecutor,
 		f.claudeConfig,
 		f.logger.WithFields("component", "ReviewAction"),
 	)
+	action.SetArtifactsRoot(f.artifactsRoot)
+	action.SetPaneRegistry(f.paneRegistry)
+	return action
+}
+
+// CreatePullRequestAction はPRモードでIssueのないPRをレビュー・修正するアクションを作成する
+// phaseにはactions.PullRequestPhaseReviewまたはactions.PullRequestPhaseReviseを指定する
+func (f *DefaultActionFactory) CreatePullRequestAction(phase string) (ActionExecutor, error) {
+	action, err := actions.NewPullRequestAction(
+		phase,
+		f.sessionName,
+		f.tmuxManager,
+		f.worktreeManager,
+		f.config,
+		f.claudeExecutor,
+		f.claudeConfig,
+		f.logger.WithFields("component", "PullRequestAction", "phase", phase),
+	)
+	if err != nil {
+		return nil, err
+	}
+	action.SetArtifactsRoot(f.artifactsRoot)
+	action.SetPaneRegistry(f.paneRegistry)
+	return action, nil
 }
 
 // CreateReviseAction はレビュー指摘対応フェーズのアクションを作成する
@@ -119,15 +154,37 @@ func (f *DefaultActionFactory) CreateReviseAction() ActionExecutor {
 		Repo:         f.repo,
 	}
 
-	return actions.NewReviseAction(
+	action := actions.NewReviseAction(
 		f.sessionName,
 		f.tmuxManager,
 		labelManager,
 		f.worktreeManager,
+		f.config,
 		f.claudeExecutor,
 		f.claudeConfig,
 		f.logger.WithFields("component", "ReviseAction"),
 	)
+	if f.botReviews != nil {
+		action.SetBotReviewSource(f.botReviews)
+	}
+	action.SetArtifactsRoot(f.artifactsRoot)
+	action.SetPaneRegistry(f.paneRegistry)
+	return action
+}
+
+// SetBotReviewSource はreviseフェーズに渡すレビューボットのレビューの取得元を設定する
+func (f *DefaultActionFactory) SetBotReviewSource(source actions.BotReviewSource) {
+	f.botReviews = source
+}
+
+// SetArtifactsRoot はフェーズ間でファイルを受け渡す成果物ディレクトリ（.git/osoba/artifacts）を置くリポジトリのルートを設定する
+func (f *DefaultActionFactory) SetArtifactsRoot(root string) {
+	f.artifactsRoot = root
+}
+
+// SetPaneRegistry は端末サイズによるフェーズ専用ウィンドウへのフォールバックを記録するレジストリを設定する
+func (f *DefaultActionFactory) SetPaneRegistry(registry *tmux.PaneRegistry) {
+	f.paneRegistry = registry
 }
 
 // CreateNoOpAction は何もしないアクションを作成する
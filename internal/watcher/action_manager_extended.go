@@ -47,18 +47,9 @@ func (m *ActionManagerExtended) GetActionForIssue(issue *github.Issue) ActionExe
 		return nil
 	}
 
-	// ラベルを確認して適切なアクションを返す
-	if hasLabel(issue, "status:needs-plan") {
-		return m.factory.CreatePlanAction()
-	}
-	if hasLabel(issue, "status:ready") {
-		return m.factory.CreateImplementationAction()
-	}
-	if hasLabel(issue, "status:review-requested") {
-		return m.factory.CreateReviewAction()
-	}
-	if hasLabel(issue, "status:requires-changes") {
-		return m.factory.CreateReviseAction()
+	// workflowの定義に従って、トリガーラベルのフェーズのアクションを返す
+	if t, ok := findWorkflowTransition(issue); ok {
+		return createActionForPhase(m.factory, t.Phase)
 	}
 
 	return nil
@@ -2,10 +2,13 @@ package actions
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/douhashi/osoba/internal/claude"
 	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/git"
 	"github.com/douhashi/osoba/internal/github"
@@ -13,12 +16,36 @@ import (
 	tmuxpkg "github.com/douhashi/osoba/internal/tmux"
 )
 
+// ErrPhasePaused はIssueのフェーズ実行を一時停止したことを示す
+// このエラーが返された場合、呼び出し側はラベル遷移を行わずに次回のポーリングで再判定する
+var ErrPhasePaused = errors.New("phase execution paused")
+
+// changesSincePhaseEnd は前のフェーズの終了後にworktreeに加えられた変更を返す（テスト時に差し替え可能）
+var changesSincePhaseEnd = git.ChangesSincePhaseEnd
+
+// WorkspaceBlockedError はworktreeの事前チェックで自動修復できない問題が見つかったことを示す
+// ErrPhasePausedをラップするため、呼び出し側はラベル遷移を行わない
+type WorkspaceBlockedError struct {
+	IssueNumber int
+	Health      *git.WorktreeHealth
+}
+
+func (e *WorkspaceBlockedError) Error() string {
+	return fmt.Sprintf("%v: worktree %s is blocked: %s", ErrPhasePaused, e.Health.Path, e.Health.Diagnostics())
+}
+
+func (e *WorkspaceBlockedError) Unwrap() error {
+	return ErrPhasePaused
+}
+
 // WorkspaceInfo はワークスペース情報を表す構造体
 type WorkspaceInfo struct {
+	SessionName  string // ウィンドウを作成したセッション（tmux.shardsで振り分けた場合はシャードのセッション）
 	WindowName   string
 	WorktreePath string
 	PaneIndex    int
 	PaneTitle    string
+	ArtifactsDir string // フェーズ間でファイルを受け渡すIssueの成果物ディレクトリ（設定されていない場合は空）
 }
 
 const (
@@ -33,6 +60,10 @@ type BaseExecutor struct {
 	worktreeManager git.WorktreeManager
 	config          *config.Config
 	logger          logger.Logger
+	// artifactsRoot は成果物ディレクトリ（.git/osoba/artifacts）を置くリポジトリのルート（空の場合は成果物ディレクトリを使わない）
+	artifactsRoot string
+	// paneRegistry はフェーズのペインを配置したウィンドウを記録する（nilの場合は記録しない）
+	paneRegistry *tmuxpkg.PaneRegistry
 	// リサイズのデバウンス機能
 	lastResizeTime map[string]time.Time
 	resizeMutex    sync.Mutex
@@ -56,6 +87,14 @@ func NewBaseExecutor(
 	}
 }
 
+// currentConfig は設定を返す（BaseExecutorがない場合はnil）
+func (e *BaseExecutor) currentConfig() *config.Config {
+	if e == nil {
+		return nil
+	}
+	return e.config
+}
+
 // PrepareWorkspace はIssueに対するワークスペースを準備する
 func (e *BaseExecutor) PrepareWorkspace(ctx context.Context, issue *github.Issue, phase string) (*WorkspaceInfo, error) {
 	if issue == nil || issue.Number == nil {
@@ -63,38 +102,72 @@ func (e *BaseExecutor) PrepareWorkspace(ctx context.Context, issue *github.Issue
 	}
 
 	issueNumber := *issue.Number
+	paneConfig := e.phasePaneConfig(phase)
+	separateWindow := paneConfig.Window == config.WindowPolicySeparate
 	windowName := tmuxpkg.GetWindowNameForIssue(int(issueNumber))
+	if separateWindow {
+		windowName = tmuxpkg.GetPhaseWindowNameForIssue(int(issueNumber), phaseConfigKey(phase))
+	}
+
+	sessionName := e.sessionForIssue(issue)
 
 	e.logger.Info("Preparing workspace",
 		"issue_number", issueNumber,
 		"phase", phase,
+		"session_name", sessionName,
 		"window_name", windowName,
 	)
 
 	// セッションの存在確認と自動作成
-	sessionExists, err := e.tmuxManager.SessionExists(e.sessionName)
+	sessionExists, err := e.tmuxManager.SessionExists(sessionName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check session existence: %w", err)
 	}
 
 	if !sessionExists {
-		e.logger.Info("Session does not exist, creating new session", "session_name", e.sessionName)
-		if err := e.tmuxManager.EnsureSession(e.sessionName); err != nil {
+		e.logger.Info("Session does not exist, creating new session", "session_name", sessionName)
+		if err := e.tmuxManager.EnsureSession(sessionName); err != nil {
 			return nil, fmt.Errorf("failed to ensure session: %w", err)
 		}
-		e.logger.Info("Session created successfully", "session_name", e.sessionName)
+		e.logger.Info("Session created successfully", "session_name", sessionName)
 	}
 
 	// 1. Windowの存在確認と作成（新規判定付き）
 	isNewWindow := false
-	windowExists, err := e.tmuxManager.WindowExists(e.sessionName, windowName)
+	windowExists, err := e.tmuxManager.WindowExists(sessionName, windowName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check window existence: %w", err)
 	}
 
-	if !windowExists {
+	// 端末が小さくIssueのウィンドウをこれ以上分割できない場合はフェーズ専用ウィンドウに作成する
+	placement := tmuxpkg.PanePlacement{SessionName: sessionName, WindowName: windowName}
+	if windowExists && !separateWindow {
+		if reason := e.splitTooSmall(sessionName, windowName, phase, paneConfig.Pane); reason != "" {
+			windowName = tmuxpkg.GetPhaseWindowNameForIssue(int(issueNumber), phaseConfigKey(phase))
+			e.logger.Info("Terminal too small to split issue window, using dedicated phase window",
+				"issue_number", issueNumber,
+				"phase", phase,
+				"window_name", windowName,
+				"reason", reason,
+			)
+			placement = tmuxpkg.PanePlacement{SessionName: sessionName, WindowName: windowName, Fallback: true, Reason: reason}
+			separateWindow = true
+			windowExists, err = e.tmuxManager.WindowExists(sessionName, windowName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check window existence: %w", err)
+			}
+		}
+	}
+
+	if !windowExists && separateWindow {
+		e.logger.Info("Creating dedicated phase window", "window_name", windowName, "phase", phase)
+		if err := e.tmuxManager.CreateWindow(sessionName, windowName); err != nil {
+			return nil, fmt.Errorf("failed to create window: %w", err)
+		}
+		isNewWindow = true
+	} else if !windowExists {
 		e.logger.Info("Creating new window with detection", "window_name", windowName)
-		_, isNewWindow, err = e.tmuxManager.CreateWindowForIssueWithNewWindowDetection(e.sessionName, int(issueNumber))
+		_, isNewWindow, err = e.tmuxManager.CreateWindowForIssueWithNewWindowDetection(sessionName, int(issueNumber))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create window: %w", err)
 		}
@@ -102,6 +175,7 @@ func (e *BaseExecutor) PrepareWorkspace(ctx context.Context, issue *github.Issue
 	}
 
 	// 2. Worktreeの存在確認（なければ作成）
+	worktreePath := e.worktreeManager.GetWorktreePathForIssue(int(issueNumber))
 	worktreeExists, err := e.worktreeManager.WorktreeExistsForIssue(ctx, int(issueNumber))
 	if err != nil {
 		return nil, fmt.Errorf("failed to check worktree existence: %w", err)
@@ -112,38 +186,197 @@ func (e *BaseExecutor) PrepareWorkspace(ctx context.Context, issue *github.Issue
 		if err := e.worktreeManager.CreateWorktreeForIssue(ctx, int(issueNumber)); err != nil {
 			return nil, fmt.Errorf("failed to create worktree: %w", err)
 		}
+	} else {
+		// 前のフェーズの結果が残っていると今回のフェーズの結果と区別できないため削除する
+		if err := RemovePhaseResult(worktreePath); err != nil {
+			e.logger.Warn("Failed to remove stale phase result", "issue_number", issueNumber, "error", err)
+		}
+		if err := e.preflightWorktree(ctx, int(issueNumber), phase); err != nil {
+			return nil, err
+		}
+		if err := e.checkExternalEdits(ctx, int(issueNumber), phase); err != nil {
+			return nil, err
+		}
+		if err := e.cleanWorktree(ctx, int(issueNumber), phase, worktreePath); err != nil {
+			return nil, err
+		}
 	}
 
 	// 3. 適切なpaneの選択または作成
-	paneInfo, err := e.ensurePane(windowName, phase, isNewWindow)
+	paneInfo, err := e.ensurePane(sessionName, windowName, phase, isNewWindow, paneConfig.Pane)
 	if err != nil {
 		return nil, fmt.Errorf("failed to ensure pane: %w", err)
 	}
 
-	// 4. WorkspaceInfoの返却
-	worktreePath := e.worktreeManager.GetWorktreePathForIssue(int(issueNumber))
+	e.paneRegistry.Record(int(issueNumber), phaseConfigKey(phase), placement)
 
+	// ハートビートのファイルがコミットされたり未コミットの変更とみなされたりしないよう除外する
+	if e.config != nil && e.config.Heartbeat.Enabled && e.artifactsRoot != "" {
+		if err := git.EnsureHeartbeatExcluded(e.artifactsRoot); err != nil {
+			e.logger.Warn("Failed to exclude heartbeat file from git", "error", err)
+		}
+	}
+
+	// 4. WorkspaceInfoの返却
 	return &WorkspaceInfo{
+		SessionName:  sessionName,
 		WindowName:   windowName,
 		WorktreePath: worktreePath,
 		PaneIndex:    paneInfo.Index,
 		PaneTitle:    paneInfo.Title,
+		ArtifactsDir: e.ensureArtifactsDir(int(issueNumber)),
 	}, nil
 }
 
+// SetArtifactsRoot は成果物ディレクトリを置くリポジトリのルートを設定する
+func (e *BaseExecutor) SetArtifactsRoot(root string) {
+	e.artifactsRoot = root
+}
+
+// excludeFromGit はworktreeに書き出すファイルがコミットされたり未コミットの変更とみなされたりしないよう、.git/info/excludeに追加する
+func (e *BaseExecutor) excludeFromGit(file, comment string) {
+	if e.artifactsRoot == "" {
+		return
+	}
+	if err := git.EnsureExcluded(e.artifactsRoot, file, comment); err != nil {
+		e.logger.Warn("Failed to exclude file from git", "file", file, "error", err)
+	}
+}
+
+// heartbeatInstruction はheartbeat.enabledの場合にプロンプトに追加する、ハートビートの更新の指示
+const heartbeatInstruction = " (While working, run `mkdir -p .osoba && touch %s` in the worktree root at least every %s so osoba knows you are still active.)"
+
+// withHeartbeat はheartbeat.enabledの場合に、ハートビートの更新を指示したプロンプトの設定を返す
+func (e *BaseExecutor) withHeartbeat(phaseConfig *claude.PhaseConfig) *claude.PhaseConfig {
+	if e.config == nil || !e.config.Heartbeat.Enabled {
+		return phaseConfig
+	}
+	return withPromptInstruction(phaseConfig, fmt.Sprintf(heartbeatInstruction, git.HeartbeatFile, e.config.Heartbeat.Interval))
+}
+
+// withPhaseEndSnapshot はエージェントの終了時にworktreeの未コミットの変更を記録するよう設定した複製を返す
+// 次のフェーズの開始時に、記録より後の変更だけを外部の編集とみなすために使う
+func (e *BaseExecutor) withPhaseEndSnapshot(phaseConfig *claude.PhaseConfig, worktreePath string) *claude.PhaseConfig {
+	if e.config == nil || !e.config.Worktree.PauseOnExternalEdits || worktreePath == "" {
+		return phaseConfig
+	}
+	withSnapshot := *phaseConfig
+	withSnapshot.AfterExit = git.PhaseEndSnapshotCommand(worktreePath)
+	return &withSnapshot
+}
+
+// SetPaneRegistry はフェーズのペインを配置したウィンドウを記録するレジストリを設定する
+func (e *BaseExecutor) SetPaneRegistry(registry *tmuxpkg.PaneRegistry) {
+	e.paneRegistry = registry
+}
+
+// splitTooSmall は既存のIssueウィンドウを分割して新しいペインを作成する場合に、
+// 分割後のペインがtmux.min_pane_width・tmux.min_pane_heightを下回るかを判定し、下回る場合は理由を返す
+// 既存ペインの再利用・Planフェーズ・ペイン数の上限による再利用など分割しない場合と、判定できない場合は空文字列を返す
+func (e *BaseExecutor) splitTooSmall(sessionName, windowName, phase, panePolicy string) string {
+	if e.config == nil || (e.config.Tmux.MinPaneWidth <= 0 && e.config.Tmux.MinPaneHeight <= 0) || phase == "Plan" {
+		return ""
+	}
+	if panePolicy != config.PanePolicyAppend {
+		if pane, err := e.tmuxManager.GetPaneByTitle(sessionName, windowName, phase); err == nil && pane != nil {
+			return ""
+		}
+	}
+	panes, err := e.tmuxManager.ListPanes(sessionName, windowName)
+	if err != nil {
+		e.logger.Debug("Failed to list panes for split size check", "window_name", windowName, "error", err)
+		return ""
+	}
+	if e.config.Tmux.LimitPanesEnabled {
+		maxPanes := e.config.Tmux.MaxPanesPerWindow
+		if maxPanes <= 0 {
+			maxPanes = 3 // CreatePaneと同じデフォルト値
+		}
+		if len(panes) >= maxPanes {
+			return ""
+		}
+	}
+	width, height, err := e.tmuxManager.GetWindowSize(sessionName, windowName)
+	if err != nil {
+		e.logger.Debug("Failed to get window size for split size check", "window_name", windowName, "error", err)
+		return ""
+	}
+
+	paneWidth, paneHeight := tmuxpkg.EstimateSplitPaneSize(width, height, len(panes), e.paneSplitFlag())
+	minWidth, minHeight := e.config.Tmux.MinPaneWidth, e.config.Tmux.MinPaneHeight
+	if (minWidth > 0 && paneWidth < minWidth) || (minHeight > 0 && paneHeight < minHeight) {
+		return fmt.Sprintf("split pane would be %dx%d (minimum %dx%d)", paneWidth, paneHeight, minWidth, minHeight)
+	}
+	return ""
+}
+
+// ensureArtifactsDir はIssueの成果物ディレクトリを作成してパスを返す
+// 作成できない場合は成果物ディレクトリなしでフェーズを実行する
+func (e *BaseExecutor) ensureArtifactsDir(issueNumber int) string {
+	if e.artifactsRoot == "" {
+		return ""
+	}
+	dir, err := git.EnsureArtifactsDirForIssue(e.artifactsRoot, issueNumber)
+	if err != nil {
+		e.logger.Warn("Failed to prepare artifacts directory", "issue_number", issueNumber, "error", err)
+		return ""
+	}
+	return dir
+}
+
+// withArtifactsDir は成果物ディレクトリへのアクセスをclaudeに許可した複製を返す
+// 成果物ディレクトリはworktreeの外にあるため、--add-dirで作業ディレクトリに追加する
+func (e *BaseExecutor) withArtifactsDir(phaseConfig *claude.PhaseConfig, artifactsDir string) *claude.PhaseConfig {
+	if artifactsDir == "" {
+		return phaseConfig
+	}
+	withDir := *phaseConfig
+	withDir.Args = append(append([]string{}, phaseConfig.Args...), "--add-dir", artifactsDir)
+	return &withDir
+}
+
+// sessionForIssue はIssueのウィンドウを作成するセッション名を返す（tmux.shardsのラベル・マイルストーンで振り分ける）
+func (e *BaseExecutor) sessionForIssue(issue *github.Issue) string {
+	if e.config == nil || len(e.config.Tmux.Shards) == 0 {
+		return e.sessionName
+	}
+	labels := make([]string, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		if label != nil && label.Name != nil {
+			labels = append(labels, *label.Name)
+		}
+	}
+	milestone := ""
+	if issue.Milestone != nil && issue.Milestone.Title != nil {
+		milestone = *issue.Milestone.Title
+	}
+	return e.config.Tmux.ShardSessionName(e.sessionName, labels, milestone)
+}
+
 // ensurePane は指定されたフェーズ用のpaneを確保する
-func (e *BaseExecutor) ensurePane(windowName string, phase string, isNewWindow bool) (*tmuxpkg.PaneInfo, error) {
-	// まず既存のpaneを検索
-	existingPane, err := e.tmuxManager.GetPaneByTitle(e.sessionName, windowName, phase)
+// panePolicyはtmux.phases.<phase>.paneの値（reuse / replace / append）
+func (e *BaseExecutor) ensurePane(sessionName, windowName string, phase string, isNewWindow bool, panePolicy string) (*tmuxpkg.PaneInfo, error) {
+	// まず既存のpaneを検索（appendの場合は常に新しいpaneを使う）
+	var existingPane *tmuxpkg.PaneInfo
+	var err error
+	if panePolicy != config.PanePolicyAppend {
+		existingPane, err = e.tmuxManager.GetPaneByTitle(sessionName, windowName, phase)
+	}
 	if err == nil && existingPane != nil {
-		e.logger.Info("Using existing pane", "phase", phase, "pane_index", existingPane.Index)
+		e.logger.Info("Using existing pane", "phase", phase, "pane_index", existingPane.Index, "policy", panePolicy)
+		// replaceの場合は前回の出力を消去してから再利用する
+		if panePolicy == config.PanePolicyReplace {
+			if err := e.tmuxManager.RespawnPane(sessionName, windowName, existingPane.Index); err != nil {
+				return nil, fmt.Errorf("failed to respawn existing pane: %w", err)
+			}
+		}
 		// 既存のpaneを選択
-		if err := e.tmuxManager.SelectPane(e.sessionName, windowName, existingPane.Index); err != nil {
+		if err := e.tmuxManager.SelectPane(sessionName, windowName, existingPane.Index); err != nil {
 			return nil, fmt.Errorf("failed to select existing pane: %w", err)
 		}
 
 		// 既存ペイン使用時もリサイズを実行
-		e.executeAutoResize(windowName)
+		e.executeAutoResize(sessionName, windowName)
 
 		return existingPane, nil
 	}
@@ -165,12 +398,12 @@ func (e *BaseExecutor) ensurePane(windowName string, phase string, isNewWindow b
 		e.logger.Info("Got pane-base-index", "baseIndex", baseIndex)
 
 		// 既存のpaneのタイトルを設定
-		if err := e.tmuxManager.SetPaneTitle(e.sessionName, windowName, baseIndex, phase); err != nil {
+		if err := e.tmuxManager.SetPaneTitle(sessionName, windowName, baseIndex, phase); err != nil {
 			return nil, fmt.Errorf("failed to set pane title: %w", err)
 		}
 
 		// 新規ウィンドウでも自動リサイズを実行
-		e.executeAutoResize(windowName)
+		e.executeAutoResize(sessionName, windowName)
 
 		return &tmuxpkg.PaneInfo{
 			Index:  baseIndex,
@@ -190,8 +423,8 @@ func (e *BaseExecutor) ensurePane(windowName string, phase string, isNewWindow b
 	}
 
 	opts := tmuxpkg.PaneOptions{
-		Split:      "-h", // 水平分割（縦分割）
-		Percentage: 50,   // 50%で分割
+		Split:      e.paneSplitFlag(),
+		Percentage: 50, // 50%で分割
 		Title:      phase,
 		Config:     paneConfig,
 	}
@@ -208,12 +441,12 @@ func (e *BaseExecutor) ensurePane(windowName string, phase string, isNewWindow b
 		e.logger.Info("Got pane-base-index", "baseIndex", baseIndex)
 
 		// 既存のpaneのタイトルを設定
-		if err := e.tmuxManager.SetPaneTitle(e.sessionName, windowName, baseIndex, phase); err != nil {
+		if err := e.tmuxManager.SetPaneTitle(sessionName, windowName, baseIndex, phase); err != nil {
 			return nil, fmt.Errorf("failed to set pane title: %w", err)
 		}
 
 		// Planフェーズで既存ペイン使用時もリサイズを実行
-		e.executeAutoResize(windowName)
+		e.executeAutoResize(sessionName, windowName)
 
 		return &tmuxpkg.PaneInfo{
 			Index:  baseIndex,
@@ -224,19 +457,151 @@ func (e *BaseExecutor) ensurePane(windowName string, phase string, isNewWindow b
 
 	// Plan以外のフェーズでは新しいpaneを作成
 	// CreatePane内でペイン数制限とレイアウト調整が行われる
-	newPane, err := e.tmuxManager.CreatePane(e.sessionName, windowName, opts)
+	newPane, err := e.tmuxManager.CreatePane(sessionName, windowName, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pane: %w", err)
 	}
 
 	// ペイン作成後に自動リサイズを実行（CreatePane内でも行われるが、デバウンス機能のため追加実行）
-	e.executeAutoResize(windowName)
+	e.executeAutoResize(sessionName, windowName)
 
 	return newPane, nil
 }
 
+// preflightWorktree は既存worktreeの健全性を確認し、自動修復できない問題があればWorkspaceBlockedErrorを返す
+func (e *BaseExecutor) preflightWorktree(ctx context.Context, issueNumber int, phase string) error {
+	if e.config == nil || !e.config.Worktree.PreflightChecks {
+		return nil
+	}
+
+	health, err := e.worktreeManager.PreflightWorktreeForIssue(ctx, issueNumber)
+	if err != nil {
+		// 判定できない場合は処理を継続（ベストエフォート）
+		e.logger.Warn("Failed to run worktree preflight checks", "issue_number", issueNumber, "error", err)
+		return nil
+	}
+
+	for _, p := range health.Problems {
+		if p.Repaired {
+			e.logger.Info("Repaired worktree problem before phase start",
+				"issue_number", issueNumber,
+				"phase", phase,
+				"kind", p.Kind,
+				"detail", p.Detail,
+			)
+		}
+	}
+	if health.Healthy() {
+		return nil
+	}
+
+	e.logger.Warn("Worktree failed preflight checks, blocking automated phase",
+		"issue_number", issueNumber,
+		"phase", phase,
+		"worktree_path", health.Path,
+		"diagnostics", health.Diagnostics(),
+	)
+	return &WorkspaceBlockedError{IssueNumber: issueNumber, Health: health}
+}
+
+// checkExternalEdits は既存worktreeに人手による未コミットの変更がないかを確認する
+// 前のフェーズの終了時点の記録より後の変更だけを外部編集とみなし、前のフェーズが残した変更では停止しない
+// 記録がない場合（エージェントの終了前にペインが失われた場合など）は、未コミットの変更をすべて外部編集とみなす
+// Planフェーズはファイルを変更しないため対象外
+func (e *BaseExecutor) checkExternalEdits(ctx context.Context, issueNumber int, phase string) error {
+	if e.config == nil || !e.config.Worktree.PauseOnExternalEdits || phase == "Plan" {
+		return nil
+	}
+
+	worktreePath := e.worktreeManager.GetWorktreePathForIssue(issueNumber)
+	dirty, err := e.worktreeManager.HasUncommittedChanges(ctx, worktreePath)
+	if err != nil {
+		// 判定できない場合は処理を継続（ベストエフォート）
+		e.logger.Warn("Failed to check worktree for external edits", "issue_number", issueNumber, "error", err)
+		return nil
+	}
+	if !dirty {
+		return nil
+	}
+
+	changes, recorded, err := changesSincePhaseEnd(ctx, worktreePath)
+	if err != nil {
+		e.logger.Warn("Failed to compare worktree with the last phase end", "issue_number", issueNumber, "error", err)
+	}
+	if err == nil && recorded && len(changes) == 0 {
+		e.logger.Info("Uncommitted changes were left by the previous phase, continuing",
+			"issue_number", issueNumber,
+			"phase", phase,
+			"worktree_path", worktreePath)
+		return nil
+	}
+
+	e.logger.Warn("Worktree has uncommitted changes not made by osoba, pausing automated phase",
+		"issue_number", issueNumber,
+		"phase", phase,
+		"worktree_path", worktreePath,
+		"changes", changes,
+		"hint", "commit, stash or discard the changes, or run osoba release to accept them",
+	)
+	return fmt.Errorf("%w: worktree %s has external edits", ErrPhasePaused, worktreePath)
+}
+
+// cleanWorktree はworktree.clean_phasesのフェーズの開始時に、既存worktreeを最新のコミットの状態に戻す
+// 前のフェーズのビルド成果物や追跡していないファイルを削除し、フェーズの結果を再現できるようにする
+func (e *BaseExecutor) cleanWorktree(ctx context.Context, issueNumber int, phase, worktreePath string) error {
+	if e.config == nil || !e.config.Worktree.CleansPhase(phaseConfigKey(phase)) {
+		return nil
+	}
+	cleaner, ok := e.worktreeManager.(git.WorktreeCleaner)
+	if !ok {
+		e.logger.Warn("Worktree manager does not support cleaning worktrees", "issue_number", issueNumber, "phase", phase)
+		return nil
+	}
+
+	if err := cleaner.CleanWorktree(ctx, worktreePath, e.config.Worktree.CleanExclude); err != nil {
+		return fmt.Errorf("failed to clean worktree before %s phase: %w", phase, err)
+	}
+	e.logger.Info("Cleaned worktree before phase start",
+		"issue_number", issueNumber,
+		"phase", phase,
+		"worktree_path", worktreePath,
+		"exclude", e.config.Worktree.CleanExclude)
+	return nil
+}
+
+// phasePaneConfig は指定フェーズのペイン・ウィンドウ利用ポリシーを返す
+func (e *BaseExecutor) phasePaneConfig(phase string) config.PhasePaneConfig {
+	if e.config == nil {
+		return config.TmuxConfig{}.GetPhasePaneConfig(phaseConfigKey(phase))
+	}
+	return e.config.Tmux.GetPhasePaneConfig(phaseConfigKey(phase))
+}
+
+// phaseConfigKey はペインタイトルのフェーズ名を設定ファイルのキーに変換する
+func phaseConfigKey(phase string) string {
+	if phase == "Implementation" {
+		return "implement"
+	}
+	return strings.ToLower(phase)
+}
+
+// paneSplitFlag は設定のtmux.pane_splitをtmuxの分割フラグに変換する
+func (e *BaseExecutor) paneSplitFlag() string {
+	if e.config == nil {
+		return tmuxpkg.SplitHorizontal
+	}
+	switch e.config.Tmux.PaneSplit {
+	case config.PaneSplitVertical:
+		return tmuxpkg.SplitVertical
+	case config.PaneSplitAuto:
+		return tmuxpkg.SplitAuto
+	default:
+		return tmuxpkg.SplitHorizontal
+	}
+}
+
 // executeAutoResize はデバウンス機能付きでペインの自動リサイズを実行する
-func (e *BaseExecutor) executeAutoResize(windowName string) {
+func (e *BaseExecutor) executeAutoResize(sessionName, windowName string) {
 	// AutoResizePanesが無効な場合は何もしない
 	if e.config == nil || !e.config.Tmux.AutoResizePanes {
 		return
@@ -257,11 +622,11 @@ func (e *BaseExecutor) executeAutoResize(windowName string) {
 	}
 
 	// リサイズを実行
-	if err := e.tmuxManager.ResizePanesEvenly(e.sessionName, windowName); err != nil {
+	if err := e.tmuxManager.ResizePanesEvenly(sessionName, windowName); err != nil {
 		// リサイズの失敗はログに記録するが、処理は継続
 		e.logger.Warn("Failed to resize panes automatically", "error", err, "window", windowName)
 	} else {
-		e.logger.Info("Auto-resized panes evenly", "window", windowName, "session", e.sessionName)
+		e.logger.Info("Auto-resized panes evenly", "window", windowName, "session", sessionName)
 	}
 
 	// 最後のリサイズ時刻を更新
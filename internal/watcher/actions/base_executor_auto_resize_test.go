@@ -125,7 +125,7 @@ func TestBaseExecutor_ExecuteAutoResize(t *testing.T) {
 			}
 
 			// executeAutoResizeメソッドを実行
-			executor.executeAutoResize(tt.windowName)
+			executor.executeAutoResize(executor.sessionName, tt.windowName)
 
 			// モックの期待値を確認
 			mockTmux.AssertExpectations(t)
@@ -0,0 +1,91 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/testutil/builders"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	tmuxpkg "github.com/douhashi/osoba/internal/tmux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCleanableWorktreeManager はworktreeを最新のコミットの状態に戻せるWorktreeManagerのモック
+type mockCleanableWorktreeManager struct {
+	*mocks.MockGitWorktreeManager
+}
+
+func (m *mockCleanableWorktreeManager) CleanWorktree(ctx context.Context, worktreePath string, excludes []string) error {
+	return m.Called(ctx, worktreePath, excludes).Error(0)
+}
+
+func TestBaseExecutor_PrepareWorkspace_CleanWorktree(t *testing.T) {
+	tests := []struct {
+		name      string
+		phase     string
+		clean     []string
+		wantClean bool
+	}{
+		{name: "対象のフェーズ - worktreeを戻す", phase: "Review", clean: []string{"review"}, wantClean: true},
+		{name: "実装フェーズの設定キー", phase: "Implementation", clean: []string{"implement"}, wantClean: true},
+		{name: "対象外のフェーズ", phase: "Review", clean: []string{"implement"}},
+		{name: "設定なし", phase: "Review"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTmux := mocks.NewMockTmuxManager()
+			mockGit := &mockCleanableWorktreeManager{MockGitWorktreeManager: mocks.NewMockGitWorktreeManager()}
+			logger, _ := logger.New(logger.WithLevel("debug"))
+
+			mockTmux.On("SessionExists", "test-session").Return(true, nil).Once()
+			mockTmux.On("WindowExists", "test-session", "issue-7").Return(true, nil).Once()
+			mockTmux.On("GetPaneByTitle", "test-session", "issue-7", tt.phase).
+				Return(&tmuxpkg.PaneInfo{Index: 1, Title: tt.phase}, nil).Once()
+			mockTmux.On("SelectPane", "test-session", "issue-7", 1).Return(nil).Once()
+			mockGit.On("WorktreeExistsForIssue", mock.Anything, 7).Return(true, nil).Once()
+			mockGit.On("GetWorktreePathForIssue", 7).Return("/test/worktree/issue-7")
+			if tt.wantClean {
+				mockGit.On("CleanWorktree", mock.Anything, "/test/worktree/issue-7", []string{".env"}).Return(nil).Once()
+			}
+
+			cfg := &config.Config{Worktree: config.WorktreeConfig{CleanPhases: tt.clean, CleanExclude: []string{".env"}}}
+			executor := NewBaseExecutor("test-session", mockTmux, mockGit, cfg, logger)
+
+			issue := builders.NewIssueBuilder().WithNumber(7).WithTitle("Clean worktree").Build()
+			_, err := executor.PrepareWorkspace(context.Background(), issue, tt.phase)
+			require.NoError(t, err)
+
+			if tt.wantClean {
+				mockGit.AssertExpectations(t)
+			} else {
+				mockGit.AssertNotCalled(t, "CleanWorktree", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+
+	t.Run("worktreeを戻せない場合はフェーズを開始しない", func(t *testing.T) {
+		mockTmux := mocks.NewMockTmuxManager()
+		mockGit := &mockCleanableWorktreeManager{MockGitWorktreeManager: mocks.NewMockGitWorktreeManager()}
+		logger, _ := logger.New(logger.WithLevel("debug"))
+
+		mockTmux.On("SessionExists", "test-session").Return(true, nil).Once()
+		mockTmux.On("WindowExists", "test-session", "issue-7").Return(true, nil).Once()
+		mockGit.On("WorktreeExistsForIssue", mock.Anything, 7).Return(true, nil).Once()
+		mockGit.On("GetWorktreePathForIssue", 7).Return("/test/worktree/issue-7")
+		mockGit.On("CleanWorktree", mock.Anything, "/test/worktree/issue-7", []string(nil)).Return(assert.AnError).Once()
+
+		cfg := &config.Config{Worktree: config.WorktreeConfig{CleanPhases: []string{"review"}}}
+		executor := NewBaseExecutor("test-session", mockTmux, mockGit, cfg, logger)
+
+		issue := builders.NewIssueBuilder().WithNumber(7).WithTitle("Clean worktree").Build()
+		_, err := executor.PrepareWorkspace(context.Background(), issue, "Review")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to clean worktree before Review phase")
+		mockTmux.AssertNotCalled(t, "GetPaneByTitle", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,131 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/claude"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/testutil/builders"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	tmuxpkg "github.com/douhashi/osoba/internal/tmux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBaseExecutor_PrepareWorkspace_ExternalEdits(t *testing.T) {
+	tests := []struct {
+		name        string
+		phase       string
+		pause       bool
+		dirty       bool
+		recorded    bool     // 前のフェーズの終了時点の記録があるか
+		changes     []string // 記録より後の変更
+		checkCalled bool
+		wantPaused  bool
+	}{
+		{
+			name:        "未コミット変更あり・記録なし - 一時停止",
+			phase:       "Implementation",
+			pause:       true,
+			dirty:       true,
+			checkCalled: true,
+			wantPaused:  true,
+		},
+		{
+			name:        "前のフェーズが残した変更のみ - 続行",
+			phase:       "Review",
+			pause:       true,
+			dirty:       true,
+			recorded:    true,
+			checkCalled: true,
+		},
+		{
+			name:        "前のフェーズの終了後の変更あり - 一時停止",
+			phase:       "Implementation",
+			pause:       true,
+			dirty:       true,
+			recorded:    true,
+			changes:     []string{" M main.go"},
+			checkCalled: true,
+			wantPaused:  true,
+		},
+		{
+			name:        "変更なし - 続行",
+			phase:       "Review",
+			pause:       true,
+			dirty:       false,
+			checkCalled: true,
+		},
+		{
+			name:  "設定無効 - チェックしない",
+			phase: "Implementation",
+			pause: false,
+		},
+		{
+			name:  "Planフェーズ - チェックしない",
+			phase: "Plan",
+			pause: true,
+		},
+	}
+
+	origChanges := changesSincePhaseEnd
+	defer func() { changesSincePhaseEnd = origChanges }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changesSincePhaseEnd = func(ctx context.Context, worktreePath string) ([]string, bool, error) {
+				return tt.changes, tt.recorded, nil
+			}
+			mockTmux := mocks.NewMockTmuxManager()
+			mockGit := mocks.NewMockGitWorktreeManager()
+			logger, _ := logger.New(logger.WithLevel("debug"))
+
+			mockTmux.On("SessionExists", "test-session").Return(true, nil).Once()
+			mockTmux.On("WindowExists", "test-session", "issue-7").Return(true, nil).Once()
+			mockGit.On("WorktreeExistsForIssue", mock.Anything, 7).Return(true, nil).Once()
+			mockGit.On("GetWorktreePathForIssue", 7).Return("/test/worktree/issue-7")
+			if tt.checkCalled {
+				mockGit.On("HasUncommittedChanges", mock.Anything, "/test/worktree/issue-7").Return(tt.dirty, nil).Once()
+			}
+			if !tt.wantPaused {
+				mockTmux.On("GetPaneByTitle", "test-session", "issue-7", tt.phase).
+					Return(&tmuxpkg.PaneInfo{Index: 1, Title: tt.phase}, nil).Once()
+				mockTmux.On("SelectPane", "test-session", "issue-7", 1).Return(nil).Once()
+			}
+
+			cfg := &config.Config{Worktree: config.WorktreeConfig{PauseOnExternalEdits: tt.pause}}
+			executor := NewBaseExecutor("test-session", mockTmux, mockGit, cfg, logger)
+
+			issue := builders.NewIssueBuilder().WithNumber(7).WithTitle("External edits").Build()
+			_, err := executor.PrepareWorkspace(context.Background(), issue, tt.phase)
+
+			if tt.wantPaused {
+				assert.True(t, errors.Is(err, ErrPhasePaused))
+			} else {
+				assert.NoError(t, err)
+			}
+			if !tt.checkCalled {
+				mockGit.AssertNotCalled(t, "HasUncommittedChanges", mock.Anything, mock.Anything)
+			}
+			mockTmux.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBaseExecutor_WithPhaseEndSnapshot(t *testing.T) {
+	phaseConfig := &claude.PhaseConfig{Prompt: "/osoba:implement {{issue-number}}"}
+	logger, _ := logger.New(logger.WithLevel("debug"))
+
+	// 外部編集での一時停止が有効な場合は、エージェントの終了後に未コミットの変更を記録する
+	enabled := NewBaseExecutor("test-session", nil, nil, &config.Config{Worktree: config.WorktreeConfig{PauseOnExternalEdits: true}}, logger)
+	got := enabled.withPhaseEndSnapshot(phaseConfig, "/test/worktree/issue-7")
+	assert.Equal(t, git.PhaseEndSnapshotCommand("/test/worktree/issue-7"), got.AfterExit)
+	assert.Empty(t, phaseConfig.AfterExit, "元の設定は変更しない")
+
+	disabled := NewBaseExecutor("test-session", nil, nil, &config.Config{}, logger)
+	assert.Same(t, phaseConfig, disabled.withPhaseEndSnapshot(phaseConfig, "/test/worktree/issue-7"))
+}
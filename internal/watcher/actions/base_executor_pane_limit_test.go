@@ -214,3 +214,24 @@ func TestBaseExecutor_EnsurePane_WithPaneLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseExecutor_PaneSplitFlag(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *config.Config
+		wantSplit string
+	}{
+		{name: "設定なしは左右分割", config: nil, wantSplit: "-h"},
+		{name: "horizontal", config: &config.Config{Tmux: config.TmuxConfig{PaneSplit: config.PaneSplitHorizontal}}, wantSplit: "-h"},
+		{name: "vertical", config: &config.Config{Tmux: config.TmuxConfig{PaneSplit: config.PaneSplitVertical}}, wantSplit: "-v"},
+		{name: "auto", config: &config.Config{Tmux: config.TmuxConfig{PaneSplit: config.PaneSplitAuto}}, wantSplit: tmuxpkg.SplitAuto},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, _ := logger.New(logger.WithLevel("debug"))
+			executor := NewBaseExecutor("test-session", mocks.NewMockTmuxManager(), mocks.NewMockGitWorktreeManager(), tt.config, logger)
+			assert.Equal(t, tt.wantSplit, executor.paneSplitFlag())
+		})
+	}
+}
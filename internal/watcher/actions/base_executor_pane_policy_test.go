@@ -0,0 +1,154 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/testutil/builders"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	tmuxpkg "github.com/douhashi/osoba/internal/tmux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBaseExecutor_PrepareWorkspace_PanePolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		phase         string
+		phases        map[string]config.PhasePaneConfig
+		setupMocks    func(*mocks.MockTmuxManager)
+		wantWindow    string
+		wantPaneIndex int
+	}{
+		{
+			name:  "replace - 既存ペインをクリアして再利用",
+			phase: "Review",
+			phases: map[string]config.PhasePaneConfig{
+				"review": {Pane: config.PanePolicyReplace},
+			},
+			setupMocks: func(tmux *mocks.MockTmuxManager) {
+				tmux.On("WindowExists", "test-session", "issue-42").Return(true, nil).Once()
+				tmux.On("GetPaneByTitle", "test-session", "issue-42", "Review").
+					Return(&tmuxpkg.PaneInfo{Index: 2, Title: "Review"}, nil).Once()
+				tmux.On("RespawnPane", "test-session", "issue-42", 2).Return(nil).Once()
+				tmux.On("SelectPane", "test-session", "issue-42", 2).Return(nil).Once()
+			},
+			wantWindow:    "issue-42",
+			wantPaneIndex: 2,
+		},
+		{
+			name:  "append - 既存ペインがあっても新規ペインを作成",
+			phase: "Implementation",
+			phases: map[string]config.PhasePaneConfig{
+				"implement": {Pane: config.PanePolicyAppend},
+			},
+			setupMocks: func(tmux *mocks.MockTmuxManager) {
+				tmux.On("WindowExists", "test-session", "issue-42").Return(true, nil).Once()
+				tmux.On("CreatePane", "test-session", "issue-42", mock.AnythingOfType("tmux.PaneOptions")).
+					Return(&tmuxpkg.PaneInfo{Index: 3, Title: "Implementation", Active: true}, nil).Once()
+			},
+			wantWindow:    "issue-42",
+			wantPaneIndex: 3,
+		},
+		{
+			name:  "separate - フェーズ専用ウィンドウを作成",
+			phase: "Review",
+			phases: map[string]config.PhasePaneConfig{
+				"review": {Window: config.WindowPolicySeparate},
+			},
+			setupMocks: func(tmux *mocks.MockTmuxManager) {
+				tmux.On("WindowExists", "test-session", "42-review").Return(false, nil).Once()
+				tmux.On("CreateWindow", "test-session", "42-review").Return(nil).Once()
+				tmux.On("GetPaneByTitle", "test-session", "42-review", "Review").
+					Return(nil, assert.AnError).Once()
+				tmux.On("GetPaneBaseIndex").Return(0, nil).Once()
+				tmux.On("SetPaneTitle", "test-session", "42-review", 0, "Review").Return(nil).Once()
+			},
+			wantWindow:    "42-review",
+			wantPaneIndex: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTmux := mocks.NewMockTmuxManager()
+			mockGit := mocks.NewMockGitWorktreeManager()
+			logger, _ := logger.New(logger.WithLevel("debug"))
+
+			mockTmux.On("SessionExists", "test-session").Return(true, nil).Once()
+			mockGit.On("WorktreeExistsForIssue", mock.Anything, 42).Return(true, nil).Once()
+			mockGit.On("GetWorktreePathForIssue", 42).Return("/test/worktree/issue-42").Once()
+			tt.setupMocks(mockTmux)
+
+			cfg := &config.Config{Tmux: config.TmuxConfig{Phases: tt.phases}}
+			executor := NewBaseExecutor("test-session", mockTmux, mockGit, cfg, logger)
+
+			issue := builders.NewIssueBuilder().WithNumber(42).WithTitle("Pane policy").Build()
+			workspace, err := executor.PrepareWorkspace(context.Background(), issue, tt.phase)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantWindow, workspace.WindowName)
+			assert.Equal(t, tt.wantPaneIndex, workspace.PaneIndex)
+			mockTmux.AssertExpectations(t)
+			mockGit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBaseExecutor_PrepareWorkspace_SmallTerminalFallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		width        int
+		wantWindow   string
+		wantFallback bool
+	}{
+		{name: "分割後のペインが最小幅を下回る場合はフェーズ専用ウィンドウに作成", width: 80, wantWindow: "42-review", wantFallback: true},
+		{name: "十分な幅がある場合はIssueのウィンドウを分割", width: 200, wantWindow: "issue-42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTmux := mocks.NewMockTmuxManager()
+			mockGit := mocks.NewMockGitWorktreeManager()
+			logger, _ := logger.New(logger.WithLevel("debug"))
+
+			mockTmux.On("SessionExists", "test-session").Return(true, nil).Once()
+			mockGit.On("WorktreeExistsForIssue", mock.Anything, 42).Return(true, nil).Once()
+			mockGit.On("GetWorktreePathForIssue", 42).Return("/test/worktree/issue-42").Once()
+			mockTmux.On("WindowExists", "test-session", "issue-42").Return(true, nil).Once()
+			mockTmux.On("GetPaneByTitle", "test-session", "issue-42", "Review").Return(nil, assert.AnError)
+			mockTmux.On("ListPanes", "test-session", "issue-42").
+				Return([]*tmuxpkg.PaneInfo{{Index: 0, Title: "Plan"}, {Index: 1, Title: "Implementation", Active: true}}, nil).Once()
+			mockTmux.On("GetWindowSize", "test-session", "issue-42").Return(tt.width, 40, nil).Once()
+			if tt.wantFallback {
+				mockTmux.On("WindowExists", "test-session", "42-review").Return(false, nil).Once()
+				mockTmux.On("CreateWindow", "test-session", "42-review").Return(nil).Once()
+				mockTmux.On("GetPaneByTitle", "test-session", "42-review", "Review").Return(nil, assert.AnError).Once()
+				mockTmux.On("GetPaneBaseIndex").Return(0, nil).Once()
+				mockTmux.On("SetPaneTitle", "test-session", "42-review", 0, "Review").Return(nil).Once()
+			} else {
+				mockTmux.On("CreatePane", "test-session", "issue-42", mock.AnythingOfType("tmux.PaneOptions")).
+					Return(&tmuxpkg.PaneInfo{Index: 2, Title: "Review", Active: true}, nil).Once()
+			}
+
+			cfg := &config.Config{Tmux: config.TmuxConfig{MinPaneWidth: 40, MinPaneHeight: 8}}
+			executor := NewBaseExecutor("test-session", mockTmux, mockGit, cfg, logger)
+			registry := tmuxpkg.NewPaneRegistry()
+			executor.SetPaneRegistry(registry)
+
+			issue := builders.NewIssueBuilder().WithNumber(42).WithTitle("Small terminal").Build()
+			workspace, err := executor.PrepareWorkspace(context.Background(), issue, "Review")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantWindow, workspace.WindowName)
+			placement, ok := registry.Lookup(42, "review")
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantWindow, placement.WindowName)
+			assert.Equal(t, tt.wantFallback, placement.Fallback)
+			mockTmux.AssertExpectations(t)
+			mockGit.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/testutil/builders"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	tmuxpkg "github.com/douhashi/osoba/internal/tmux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// healthyWorktree は事前チェックで問題が見つからなかった場合の結果
+var healthyWorktree = &git.WorktreeHealth{}
+
+func TestBaseExecutor_PrepareWorkspace_Preflight(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     bool
+		health      *git.WorktreeHealth
+		healthErr   error
+		wantBlocked bool
+	}{
+		{
+			name:    "問題なし - 続行",
+			enabled: true,
+			health:  &git.WorktreeHealth{Path: "/test/worktree/issue-8"},
+		},
+		{
+			name:    "自動修復済み - 続行",
+			enabled: true,
+			health: &git.WorktreeHealth{
+				Path:     "/test/worktree/issue-8",
+				Problems: []git.WorktreeProblem{{Kind: git.ProblemLockFile, Detail: "removed stale index.lock", Repaired: true}},
+			},
+		},
+		{
+			name:    "修復できない問題 - 停止",
+			enabled: true,
+			health: &git.WorktreeHealth{
+				Path:     "/test/worktree/issue-8",
+				Problems: []git.WorktreeProblem{{Kind: git.ProblemUnresolvedMerge, Detail: "MERGE_HEAD is present"}},
+			},
+			wantBlocked: true,
+		},
+		{
+			name:      "チェック失敗 - 続行",
+			enabled:   true,
+			healthErr: errors.New("git not found"),
+		},
+		{
+			name: "設定無効 - チェックしない",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTmux := mocks.NewMockTmuxManager()
+			mockGit := mocks.NewMockGitWorktreeManager()
+			logger, _ := logger.New(logger.WithLevel("debug"))
+
+			mockTmux.On("SessionExists", "test-session").Return(true, nil).Once()
+			mockTmux.On("WindowExists", "test-session", "issue-8").Return(true, nil).Once()
+			mockGit.On("WorktreeExistsForIssue", mock.Anything, 8).Return(true, nil).Once()
+			mockGit.On("GetWorktreePathForIssue", 8).Return("/test/worktree/issue-8")
+			if tt.enabled {
+				mockGit.On("PreflightWorktreeForIssue", mock.Anything, 8).Return(tt.health, tt.healthErr).Once()
+			}
+			if !tt.wantBlocked {
+				mockTmux.On("GetPaneByTitle", "test-session", "issue-8", "Implementation").
+					Return(&tmuxpkg.PaneInfo{Index: 1, Title: "Implementation"}, nil).Once()
+				mockTmux.On("SelectPane", "test-session", "issue-8", 1).Return(nil).Once()
+			}
+
+			cfg := &config.Config{Worktree: config.WorktreeConfig{PreflightChecks: tt.enabled}}
+			executor := NewBaseExecutor("test-session", mockTmux, mockGit, cfg, logger)
+
+			issue := builders.NewIssueBuilder().WithNumber(8).WithTitle("Preflight").Build()
+			_, err := executor.PrepareWorkspace(context.Background(), issue, "Implementation")
+
+			if tt.wantBlocked {
+				var blocked *WorkspaceBlockedError
+				assert.True(t, errors.As(err, &blocked))
+				assert.True(t, errors.Is(err, ErrPhasePaused))
+				assert.Equal(t, 8, blocked.IssueNumber)
+			} else {
+				assert.NoError(t, err)
+			}
+			if !tt.enabled {
+				mockGit.AssertNotCalled(t, "PreflightWorktreeForIssue", mock.Anything, mock.Anything)
+			}
+			mockTmux.AssertExpectations(t)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/douhashi/osoba/internal/claude"
+	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/testutil/builders"
 	"github.com/douhashi/osoba/internal/testutil/helpers"
@@ -60,6 +62,7 @@ func TestBaseExecutor_PrepareWorkspace(t *testing.T) {
 				git.On("GetWorktreePathForIssue", 111).Return("/test/worktree/issue-111").Once()
 			},
 			want: &WorkspaceInfo{
+				SessionName:  "test-session",
 				WindowName:   "issue-111",
 				WorktreePath: "/test/worktree/issue-111",
 				PaneIndex:    0,
@@ -102,6 +105,7 @@ func TestBaseExecutor_PrepareWorkspace(t *testing.T) {
 				git.On("GetWorktreePathForIssue", 123).Return("/test/worktree/issue-123").Once()
 			},
 			want: &WorkspaceInfo{
+				SessionName:  "test-session",
 				WindowName:   "issue-123",
 				WorktreePath: "/test/worktree/issue-123",
 				PaneIndex:    0,
@@ -136,6 +140,7 @@ func TestBaseExecutor_PrepareWorkspace(t *testing.T) {
 				git.On("GetWorktreePathForIssue", 456).Return("/test/worktree/issue-456").Once()
 			},
 			want: &WorkspaceInfo{
+				SessionName:  "test-session",
 				WindowName:   "issue-456",
 				WorktreePath: "/test/worktree/issue-456",
 				PaneIndex:    1,
@@ -176,6 +181,7 @@ func TestBaseExecutor_PrepareWorkspace(t *testing.T) {
 				git.On("GetWorktreePathForIssue", 789).Return("/test/worktree/issue-789").Once()
 			},
 			want: &WorkspaceInfo{
+				SessionName:  "test-session",
 				WindowName:   "issue-789",
 				WorktreePath: "/test/worktree/issue-789",
 				PaneIndex:    1,
@@ -218,6 +224,7 @@ func TestBaseExecutor_PrepareWorkspace(t *testing.T) {
 				git.On("GetWorktreePathForIssue", 888).Return("/test/worktree/issue-888").Once()
 			},
 			want: &WorkspaceInfo{
+				SessionName:  "test-session",
 				WindowName:   "issue-888",
 				WorktreePath: "/test/worktree/issue-888",
 				PaneIndex:    0,
@@ -331,3 +338,87 @@ func TestBaseExecutor_PrepareWorkspace(t *testing.T) {
 }
 
 // ExecuteInWorkspaceメソッドが削除されたため、このテストも削除
+
+func TestBaseExecutor_SessionForIssue(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Tmux.Shards = []config.SessionShardConfig{
+		{Name: "frontend", Labels: []string{"area:frontend"}},
+		{Name: "backend", Labels: []string{"area:backend"}, Milestones: []string{"API v2"}},
+	}
+	milestone := "API v2"
+
+	tests := []struct {
+		name  string
+		issue *github.Issue
+		want  string
+	}{
+		{
+			name:  "ラベルでシャードのセッションに振り分ける",
+			issue: builders.NewIssueBuilder().WithNumber(1).WithLabels([]string{"status:needs-plan", "area:frontend"}).Build(),
+			want:  "test-session-frontend",
+		},
+		{
+			name: "マイルストーンでシャードのセッションに振り分ける",
+			issue: func() *github.Issue {
+				issue := builders.NewIssueBuilder().WithNumber(2).WithStatusLabel("needs-plan").Build()
+				issue.Milestone = &github.Milestone{Title: &milestone}
+				return issue
+			}(),
+			want: "test-session-backend",
+		},
+		{
+			name:  "一致するシャードがない場合は既定のセッション",
+			issue: builders.NewIssueBuilder().WithNumber(3).WithStatusLabel("needs-plan").Build(),
+			want:  "test-session",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+			executor := NewBaseExecutor("test-session", mocks.NewMockTmuxManager(), mocks.NewMockGitWorktreeManager(), cfg, logger)
+			assert.Equal(t, tt.want, executor.sessionForIssue(tt.issue))
+		})
+	}
+}
+
+func TestBaseExecutor_WithHeartbeat(t *testing.T) {
+	phaseConfig := &claude.PhaseConfig{
+		Prompt:   "/osoba:implement {{issue-number}}",
+		Variants: []claude.PromptVariant{{Labels: []string{"type:docs"}, Prompt: "/osoba:docs {{issue-number}}"}},
+	}
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+
+	t.Run("無効の場合はプロンプトを変更しない", func(t *testing.T) {
+		executor := NewBaseExecutor("test-session", mocks.NewMockTmuxManager(), mocks.NewMockGitWorktreeManager(), config.NewConfig(), logger)
+		assert.Same(t, phaseConfig, executor.withHeartbeat(phaseConfig))
+	})
+
+	t.Run("有効な場合はすべてのプロンプトにハートビートの指示を追加する", func(t *testing.T) {
+		cfg := config.NewConfig()
+		cfg.Heartbeat.Enabled = true
+		executor := NewBaseExecutor("test-session", mocks.NewMockTmuxManager(), mocks.NewMockGitWorktreeManager(), cfg, logger)
+
+		got := executor.withHeartbeat(phaseConfig)
+		assert.Contains(t, got.Prompt, "touch .osoba/heartbeat")
+		assert.Contains(t, got.Prompt, "every 5m0s")
+		assert.Contains(t, got.Variants[0].Prompt, "touch .osoba/heartbeat")
+		assert.Equal(t, "/osoba:implement {{issue-number}}", phaseConfig.Prompt, "元の設定は変更しない")
+	})
+}
+
+func TestBaseExecutor_WithArtifactsDir(t *testing.T) {
+	phaseConfig := &claude.PhaseConfig{Args: []string{"--dangerously-skip-permissions"}, Prompt: "/osoba:plan {{issue-number}}"}
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	executor := NewBaseExecutor("test-session", mocks.NewMockTmuxManager(), mocks.NewMockGitWorktreeManager(), config.NewConfig(), logger)
+
+	t.Run("成果物ディレクトリがない場合は引数を変更しない", func(t *testing.T) {
+		assert.Same(t, phaseConfig, executor.withArtifactsDir(phaseConfig, ""))
+	})
+
+	t.Run("成果物ディレクトリをclaudeの作業ディレクトリに追加する", func(t *testing.T) {
+		got := executor.withArtifactsDir(phaseConfig, "/repo/.git/osoba/artifacts/issue-7")
+		assert.Equal(t, []string{"--dangerously-skip-permissions", "--add-dir", "/repo/.git/osoba/artifacts/issue-7"}, got.Args)
+		assert.Equal(t, []string{"--dangerously-skip-permissions"}, phaseConfig.Args, "元の設定は変更しない")
+	})
+}
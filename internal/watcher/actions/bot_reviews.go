@@ -0,0 +1,33 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// BotReviewsFile はreviseフェーズにレビューボットのレビューを渡すworktree内のファイル（worktreeからの相対パス）
+const BotReviewsFile = ".osoba/bot-reviews.md"
+
+// BotReviewSource はreviseフェーズに渡すレビューボットのレビューを提供する
+type BotReviewSource interface {
+	// BotReviewFeedback はIssueのPRへのボットのレビューをMarkdownで返す（ない場合は空文字）
+	BotReviewFeedback(ctx context.Context, issueNumber int) (string, error)
+}
+
+// writeBotReviews はボットのレビューをworktreeに書き出す
+// レビューがない場合は以前のreviseで書き出したファイルを削除し、修正済みの指摘を再度渡さないようにする
+func writeBotReviews(worktreePath, feedback string) error {
+	path := filepath.Join(worktreePath, BotReviewsFile)
+	if feedback == "" {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(feedback), 0644)
+}
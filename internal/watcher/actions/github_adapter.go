@@ -41,7 +41,13 @@ func (a *GitHubAdapter) TransitionLabel(ctx context.Context, issueNumber int, fr
 		return a.transitioner.TransitionLabel(ctx, issueNumber, from, to)
 	}
 
-	// transitionerがnilの場合（ghクライアント）、手動でラベルを削除/追加する
+	// 一括変更に対応している場合（ghクライアント）、1回の操作で削除と追加を行う
+	if editor, ok := a.client.(github.LabelBatchEditor); ok {
+		log.Printf("DEBUG: Using batch label edit for issue #%d: %s -> %s", issueNumber, from, to)
+		return editor.BatchEditLabels(ctx, a.owner, a.repo, issueNumber, []string{to}, []string{from})
+	}
+
+	// どちらも利用できない場合、手動でラベルを削除/追加する
 	log.Printf("DEBUG: Using manual label transition for issue #%d: %s -> %s", issueNumber, from, to)
 
 	// 古いラベルを削除
@@ -1,6 +1,8 @@
 package actions
 
 import (
+	"github.com/douhashi/osoba/internal/claude"
+	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/github"
 )
 
@@ -18,6 +20,37 @@ func hasLabel(issue *github.Issue, labelName string) bool {
 	return false
 }
 
+// workflowTransitions は設定されたワークフローの遷移を返す（設定がない場合は既定の遷移）
+func workflowTransitions(cfg *config.Config) []config.WorkflowTransition {
+	if cfg == nil || len(cfg.GitHub.Workflow.Transitions) == 0 {
+		return config.DefaultWorkflowTransitions()
+	}
+	return cfg.GitHub.Workflow.Transitions
+}
+
+// phaseTransition はIssueのトリガーラベルに対応するフェーズの遷移を返す
+// 該当するトリガーラベルがない場合はフェーズを実行する最初の遷移を返す
+func phaseTransition(cfg *config.Config, issue *github.Issue, phase string) config.WorkflowTransition {
+	transitions := workflowTransitions(cfg)
+	for _, t := range transitions {
+		if t.Phase == phase && hasLabel(issue, t.From) {
+			return t
+		}
+	}
+	t, _ := config.WorkflowConfig{Transitions: transitions}.FindByPhase(phase)
+	return t
+}
+
+// canExecutePhase はIssueにフェーズを実行する遷移のトリガーラベルが付いているかを判定する
+func canExecutePhase(cfg *config.Config, issue *github.Issue, phase string) bool {
+	for _, t := range workflowTransitions(cfg) {
+		if t.Phase == phase && hasLabel(issue, t.From) {
+			return true
+		}
+	}
+	return false
+}
+
 // getIssueTitle はIssueのタイトルを取得する
 func getIssueTitle(issue *github.Issue) string {
 	if issue == nil || issue.Title == nil {
@@ -26,8 +59,42 @@ func getIssueTitle(issue *github.Issue) string {
 	return *issue.Title
 }
 
+// getIssueBody はIssueの本文を取得する
+func getIssueBody(issue *github.Issue) string {
+	if issue == nil || issue.Body == nil {
+		return ""
+	}
+	return *issue.Body
+}
+
+// getIssueLabels はIssueのラベル名を取得する
+func getIssueLabels(issue *github.Issue) []string {
+	if issue == nil {
+		return nil
+	}
+	var labels []string
+	for _, label := range issue.Labels {
+		if label != nil && label.Name != nil {
+			labels = append(labels, *label.Name)
+		}
+	}
+	return labels
+}
+
 // getRepoName はリポジトリ名を取得する（現在は固定値）
 func getRepoName() string {
 	// TODO: 実際のリポジトリ名を動的に取得
 	return "douhashi/osoba"
 }
+
+// withPromptInstruction はプロンプトとすべてのバリアントの末尾に指示を追加した設定の複製を返す（元の設定は変更しない）
+func withPromptInstruction(phaseConfig *claude.PhaseConfig, instruction string) *claude.PhaseConfig {
+	withInstruction := *phaseConfig
+	withInstruction.Prompt += instruction
+	withInstruction.Variants = make([]claude.PromptVariant, len(phaseConfig.Variants))
+	for i, variant := range phaseConfig.Variants {
+		variant.Prompt += instruction
+		withInstruction.Variants[i] = variant
+	}
+	return &withInstruction
+}
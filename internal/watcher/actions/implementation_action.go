@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/douhashi/osoba/internal/claude"
+	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/git"
 	"github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/logger"
@@ -20,15 +21,20 @@ type ImplementationAction struct {
 	sessionName    string
 	labelManager   ActionsLabelManager
 	claudeConfig   *claude.ClaudeConfig
+	config         *config.Config
 	logger         logger.Logger
 }
 
+// noPullRequestInstruction はPRの作成を無効にしている場合に実装フェーズのプロンプトに追加する指示
+const noPullRequestInstruction = " (features.auto_create_pr is disabled: do not create a pull request. Push the branch, comment the branch name on the Issue, and update the labels as usual.)"
+
 // NewImplementationAction は新しいImplementationActionを作成する
 func NewImplementationAction(
 	sessionName string,
 	tmuxManager tmuxpkg.Manager,
 	labelManager ActionsLabelManager,
 	worktreeManager git.WorktreeManager,
+	cfg *config.Config,
 	claudeExecutor claude.ClaudeExecutor,
 	claudeConfig *claude.ClaudeConfig,
 	logger logger.Logger,
@@ -37,7 +43,7 @@ func NewImplementationAction(
 		sessionName,
 		tmuxManager,
 		worktreeManager,
-		nil, // ClaudeCommandBuilderは不要になったのでnilを渡す
+		cfg,
 		logger,
 	)
 
@@ -48,10 +54,21 @@ func NewImplementationAction(
 		sessionName:    sessionName,
 		labelManager:   labelManager,
 		claudeConfig:   claudeConfig,
+		config:         cfg,
 		logger:         logger,
 	}
 }
 
+// SetArtifactsRoot はフェーズ間でファイルを受け渡す成果物ディレクトリを置くリポジトリのルートを設定する
+func (a *ImplementationAction) SetArtifactsRoot(root string) {
+	a.baseExecutor.SetArtifactsRoot(root)
+}
+
+// SetPaneRegistry はフェーズのペインを配置したウィンドウを記録するレジストリを設定する
+func (a *ImplementationAction) SetPaneRegistry(registry *tmuxpkg.PaneRegistry) {
+	a.baseExecutor.SetPaneRegistry(registry)
+}
+
 // Execute は実装フェーズのアクションを実行する
 func (a *ImplementationAction) Execute(ctx context.Context, issue *github.Issue) error {
 	if issue == nil || issue.Number == nil {
@@ -76,9 +93,12 @@ func (a *ImplementationAction) Execute(ctx context.Context, issue *github.Issue)
 
 	// Claude実行用の変数を準備
 	templateVars := &claude.TemplateVariables{
-		IssueNumber: int(issueNumber),
-		IssueTitle:  getIssueTitle(issue),
-		RepoName:    getRepoName(),
+		IssueNumber:  int(issueNumber),
+		IssueTitle:   getIssueTitle(issue),
+		IssueBody:    getIssueBody(issue),
+		RepoName:     getRepoName(),
+		Labels:       getIssueLabels(issue),
+		ArtifactsDir: workspace.ArtifactsDir,
 	}
 
 	// Claude設定を取得
@@ -86,33 +106,33 @@ func (a *ImplementationAction) Execute(ctx context.Context, issue *github.Issue)
 	if !exists {
 		return fmt.Errorf("implement phase config not found")
 	}
+	phaseConfig = a.baseExecutor.withHeartbeat(phaseConfig)
+	phaseConfig = a.baseExecutor.withArtifactsDir(phaseConfig, workspace.ArtifactsDir)
+	phaseConfig = a.baseExecutor.withPhaseEndSnapshot(phaseConfig, workspace.WorktreePath)
+	phaseConfig = implementPhaseConfig(a.config, phaseConfig)
 
 	// ClaudeExecutorを使用してtmuxウィンドウ内で実行
 	a.logger.Info("Executing Claude in tmux window",
 		"issue_number", issueNumber,
-		"session", a.sessionName,
+		"session", workspace.SessionName,
 		"window", workspace.WindowName,
 		"worktree_path", workspace.WorktreePath,
 	)
 
-	if err := a.claudeExecutor.ExecuteInTmux(ctx, phaseConfig, templateVars, a.sessionName, workspace.WindowName, workspace.WorktreePath); err != nil {
+	if err := a.claudeExecutor.ExecuteInTmux(ctx, phaseConfig, templateVars, workspace.SessionName, workspace.WindowName, workspace.WorktreePath); err != nil {
 		return fmt.Errorf("failed to execute Claude command: %w", err)
 	}
 
-	// ラベル更新: status:ready -> status:implementing
+	// ラベル更新: トリガーラベル（既定ではstatus:ready） -> 実行中ラベル（既定ではstatus:implementing）
 	if a.labelManager != nil {
+		t := phaseTransition(a.config, issue, config.PhaseImplement)
+		add, remove := []string{t.Executing}, []string{t.From}
 		a.logger.Info("Updating issue labels", "issue_number", issueNumber)
-		if err := a.labelManager.RemoveLabel(ctx, int(issueNumber), "status:ready"); err != nil {
-			a.logger.Error("Failed to remove label",
-				"issue_number", issueNumber,
-				"label", "status:ready",
-				"error", err,
-			)
-		}
-		if err := a.labelManager.AddLabel(ctx, int(issueNumber), "status:implementing"); err != nil {
-			a.logger.Error("Failed to add label",
+		if err := a.labelManager.EditLabels(ctx, int(issueNumber), add, remove); err != nil {
+			a.logger.Error("Failed to update labels",
 				"issue_number", issueNumber,
-				"label", "status:implementing",
+				"add", add,
+				"remove", remove,
 				"error", err,
 			)
 		}
@@ -125,7 +145,15 @@ func (a *ImplementationAction) Execute(ctx context.Context, issue *github.Issue)
 	return nil
 }
 
+// implementPhaseConfig はPRの作成を無効にしている場合に、PRを作成しないよう指示したプロンプトの設定を返す
+func implementPhaseConfig(cfg *config.Config, phaseConfig *claude.PhaseConfig) *claude.PhaseConfig {
+	if cfg == nil || cfg.Features.AutoCreatePR {
+		return phaseConfig
+	}
+	return withPromptInstruction(phaseConfig, noPullRequestInstruction)
+}
+
 // CanExecute は実装フェーズのアクションが実行可能かを判定する
 func (a *ImplementationAction) CanExecute(issue *github.Issue) bool {
-	return hasLabel(issue, "status:ready")
+	return canExecutePhase(a.config, issue, config.PhaseImplement)
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/douhashi/osoba/internal/claude"
+	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/testutil/builders"
 	"github.com/douhashi/osoba/internal/testutil/helpers"
@@ -68,8 +69,7 @@ func TestImplementationAction_Execute(t *testing.T) {
 				).Return(nil).Once()
 
 				// ラベル更新
-				labelManager.On("RemoveLabel", mock.Anything, 123, "status:ready").Return(nil).Once()
-				labelManager.On("AddLabel", mock.Anything, 123, "status:implementing").Return(nil).Once()
+				labelManager.On("EditLabels", mock.Anything, 123, []string{"status:implementing"}, []string{"status:ready"}).Return(nil).Once()
 			},
 			claudeConfig: &claude.ClaudeConfig{
 				Phases: map[string]*claude.PhaseConfig{
@@ -137,6 +137,7 @@ func TestImplementationAction_Execute(t *testing.T) {
 				tmuxManager,
 				labelManager,
 				worktreeManager,
+				nil,
 				claudeExecutor,
 				tt.claudeConfig,
 				logger,
@@ -207,3 +208,52 @@ func TestImplementationAction_CanExecute(t *testing.T) {
 		})
 	}
 }
+
+func TestImplementationAction_CanExecute_CustomWorkflow(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.GitHub.Workflow.Transitions = []config.WorkflowTransition{
+		{From: "stage:approved", Phase: config.PhaseImplement, Executing: "stage:coding", Next: "stage:review"},
+	}
+	action := &ImplementationAction{config: cfg}
+
+	// 設定したワークフローのトリガーラベルで判定する
+	assert.True(t, action.CanExecute(builders.NewIssueBuilder().WithNumber(1).WithLabel("stage:approved").Build()))
+	assert.False(t, action.CanExecute(builders.NewIssueBuilder().WithNumber(2).WithLabel("status:ready").Build()))
+
+	transition := phaseTransition(cfg, builders.NewIssueBuilder().WithNumber(1).WithLabel("stage:approved").Build(), config.PhaseImplement)
+	assert.Equal(t, "stage:approved", transition.From)
+	assert.Equal(t, "stage:coding", transition.Executing)
+}
+
+func TestImplementPhaseConfig(t *testing.T) {
+	phaseConfig := &claude.PhaseConfig{
+		Prompt:   "/osoba:implement {{issue-number}}",
+		Args:     []string{"--implement"},
+		Variants: []claude.PromptVariant{{Labels: []string{"type:bug"}, Prompt: "/osoba:implement-bugfix {{issue-number}}"}},
+	}
+
+	tests := []struct {
+		name         string
+		autoCreatePR bool
+		wantPrompt   string
+		wantVariant  string
+	}{
+		{name: "PRを作成する", autoCreatePR: true, wantPrompt: phaseConfig.Prompt, wantVariant: "/osoba:implement-bugfix {{issue-number}}"},
+		{name: "PRを作成しない", autoCreatePR: false, wantPrompt: phaseConfig.Prompt + noPullRequestInstruction, wantVariant: "/osoba:implement-bugfix {{issue-number}}" + noPullRequestInstruction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewConfig()
+			cfg.Features.AutoCreatePR = tt.autoCreatePR
+
+			got := implementPhaseConfig(cfg, phaseConfig)
+			assert.Equal(t, tt.wantPrompt, got.Prompt)
+			assert.Equal(t, tt.wantVariant, got.ResolvePrompt([]string{"type:bug"}))
+			assert.Equal(t, phaseConfig.Args, got.Args)
+			// 設定のプロンプトは変更しない
+			assert.Equal(t, "/osoba:implement {{issue-number}}", phaseConfig.Prompt)
+			assert.Equal(t, "/osoba:implement-bugfix {{issue-number}}", phaseConfig.Variants[0].Prompt)
+		})
+	}
+}
@@ -12,6 +12,7 @@ type ActionsLabelManager interface {
 	TransitionLabel(ctx context.Context, issueNumber int, from, to string) error
 	AddLabel(ctx context.Context, issueNumber int, label string) error
 	RemoveLabel(ctx context.Context, issueNumber int, label string) error
+	EditLabels(ctx context.Context, issueNumber int, add, remove []string) error
 	GetPullRequestForIssue(ctx context.Context, issueNumber int) (*github.PullRequest, error)
 }
 
@@ -24,18 +25,32 @@ type DefaultLabelManager struct {
 
 // TransitionLabel はラベルを遷移させる
 func (m *DefaultLabelManager) TransitionLabel(ctx context.Context, issueNumber int, from, to string) error {
+	return m.EditLabels(ctx, issueNumber, []string{to}, []string{from})
+}
+
+// EditLabels はラベルの追加と削除をまとめて行う
+// クライアントが一括変更に対応している場合は1回の操作で行い、ラベルが遷移の途中の状態で残らないようにする
+func (m *DefaultLabelManager) EditLabels(ctx context.Context, issueNumber int, add, remove []string) error {
 	if m.GitHubClient == nil {
 		return fmt.Errorf("GitHub client is not initialized")
 	}
 
+	if editor, ok := m.GitHubClient.(github.LabelBatchEditor); ok {
+		return editor.BatchEditLabels(ctx, m.Owner, m.Repo, issueNumber, add, remove)
+	}
+
 	// 古いラベルを削除
-	if err := m.RemoveLabel(ctx, issueNumber, from); err != nil {
-		return fmt.Errorf("failed to remove label %s: %w", from, err)
+	for _, label := range remove {
+		if err := m.RemoveLabel(ctx, issueNumber, label); err != nil {
+			return fmt.Errorf("failed to remove label %s: %w", label, err)
+		}
 	}
 
 	// 新しいラベルを追加
-	if err := m.AddLabel(ctx, issueNumber, to); err != nil {
-		return fmt.Errorf("failed to add label %s: %w", to, err)
+	for _, label := range add {
+		if err := m.AddLabel(ctx, issueNumber, label); err != nil {
+			return fmt.Errorf("failed to add label %s: %w", label, err)
+		}
 	}
 
 	return nil
@@ -212,6 +212,41 @@ func TestDefaultLabelManager_TransitionLabel(t *testing.T) {
 	}
 }
 
+// mockBatchEditClient はラベルの一括変更に対応したGitHubクライアントのモック
+type mockBatchEditClient struct {
+	mocks.MockGitHubClient
+}
+
+func (m *mockBatchEditClient) BatchEditLabels(ctx context.Context, owner, repo string, issueNumber int, add, remove []string) error {
+	args := m.Called(ctx, owner, repo, issueNumber, add, remove)
+	return args.Error(0)
+}
+
+func TestDefaultLabelManager_EditLabels(t *testing.T) {
+	t.Run("一括変更に対応している場合は1回の操作で行う", func(t *testing.T) {
+		client := new(mockBatchEditClient)
+		client.On("BatchEditLabels", mock.Anything, "owner", "repo", 123,
+			[]string{"status:revising"}, []string{"status:requires-changes", "status:reviewing"}).Return(nil).Once()
+
+		manager := &DefaultLabelManager{Owner: "owner", Repo: "repo", GitHubClient: client}
+		err := manager.EditLabels(context.Background(), 123, []string{"status:revising"}, []string{"status:requires-changes", "status:reviewing"})
+		assert.NoError(t, err)
+		client.AssertExpectations(t)
+		client.AssertNotCalled(t, "RemoveLabel", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("TransitionLabelも一括変更を使用する", func(t *testing.T) {
+		client := new(mockBatchEditClient)
+		client.On("BatchEditLabels", mock.Anything, "owner", "repo", 123,
+			[]string{"status:implementing"}, []string{"status:ready"}).Return(errors.New("labels are not in the expected state")).Once()
+
+		manager := &DefaultLabelManager{Owner: "owner", Repo: "repo", GitHubClient: client}
+		err := manager.TransitionLabel(context.Background(), 123, "status:ready", "status:implementing")
+		assert.EqualError(t, err, "labels are not in the expected state")
+		client.AssertExpectations(t)
+	})
+}
+
 func TestDefaultLabelManager_InitializationError(t *testing.T) {
 	tests := []struct {
 		name    string
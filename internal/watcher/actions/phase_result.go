@@ -0,0 +1,70 @@
+package actions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// PhaseResultFile はフェーズが結果を書き出すworktree内のファイル（worktreeからの相対パス）
+const PhaseResultFile = ".osoba/result.json"
+
+// フェーズの結果
+const (
+	PhaseResultSuccess = "success"
+	PhaseResultFailure = "failure"
+)
+
+// PhaseResult はフェーズがworktreeに書き出す結果（任意）
+// 書き出された場合、osobaはプロセスの終了ではなくこの内容でフェーズの成否と次のラベルを判断する
+type PhaseResult struct {
+	Status    string   `json:"status"`               // PhaseResultSuccess または PhaseResultFailure
+	Summary   string   `json:"summary,omitempty"`    // Issueコメントに含める結果の要約
+	Artifacts []string `json:"artifacts,omitempty"`  // 成果物（PRのURL・ファイルパスなど）
+	NextPhase string   `json:"next_phase,omitempty"` // 次に進めるフェーズ（plan / implement / review / revise）
+}
+
+// Validate は結果の内容を検証する
+func (r *PhaseResult) Validate() error {
+	switch r.Status {
+	case PhaseResultSuccess, PhaseResultFailure:
+	default:
+		return fmt.Errorf("invalid phase result status: %q (must be %s or %s)", r.Status, PhaseResultSuccess, PhaseResultFailure)
+	}
+	switch r.NextPhase {
+	case "", "plan", "implement", "review", "revise":
+	default:
+		return fmt.Errorf("invalid phase result next_phase: %q (must be plan, implement, review or revise)", r.NextPhase)
+	}
+	return nil
+}
+
+// ReadPhaseResult はworktreeのフェーズの結果を読み込む（書き出されていない場合はnil）
+func ReadPhaseResult(worktreePath string) (*PhaseResult, error) {
+	data, err := os.ReadFile(filepath.Join(worktreePath, PhaseResultFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read phase result: %w", err)
+	}
+
+	var result PhaseResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse phase result: %w", err)
+	}
+	if err := result.Validate(); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RemovePhaseResult はworktreeのフェーズの結果を削除する（存在しない場合は何もしない）
+func RemovePhaseResult(worktreePath string) error {
+	if err := os.Remove(filepath.Join(worktreePath, PhaseResultFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove phase result: %w", err)
+	}
+	return nil
+}
@@ -51,6 +51,16 @@ func NewPlanAction(
 	}
 }
 
+// SetArtifactsRoot はフェーズ間でファイルを受け渡す成果物ディレクトリを置くリポジトリのルートを設定する
+func (a *PlanAction) SetArtifactsRoot(root string) {
+	a.baseExecutor.SetArtifactsRoot(root)
+}
+
+// SetPaneRegistry はフェーズのペインを配置したウィンドウを記録するレジストリを設定する
+func (a *PlanAction) SetPaneRegistry(registry *tmuxpkg.PaneRegistry) {
+	a.baseExecutor.SetPaneRegistry(registry)
+}
+
 // Execute は計画フェーズのアクションを実行する
 func (a *PlanAction) Execute(ctx context.Context, issue *github.Issue) error {
 	if issue == nil || issue.Number == nil {
@@ -76,9 +86,12 @@ func (a *PlanAction) Execute(ctx context.Context, issue *github.Issue) error {
 
 	// Claude実行用の変数を準備
 	templateVars := &claude.TemplateVariables{
-		IssueNumber: int(issueNumber),
-		IssueTitle:  getIssueTitle(issue),
-		RepoName:    getRepoName(),
+		IssueNumber:  int(issueNumber),
+		IssueTitle:   getIssueTitle(issue),
+		IssueBody:    getIssueBody(issue),
+		RepoName:     getRepoName(),
+		Labels:       getIssueLabels(issue),
+		ArtifactsDir: workspace.ArtifactsDir,
 	}
 
 	// Claude設定を取得
@@ -86,16 +99,19 @@ func (a *PlanAction) Execute(ctx context.Context, issue *github.Issue) error {
 	if !exists {
 		return fmt.Errorf("plan phase config not found")
 	}
+	phaseConfig = a.baseExecutor.withHeartbeat(phaseConfig)
+	phaseConfig = a.baseExecutor.withArtifactsDir(phaseConfig, workspace.ArtifactsDir)
+	phaseConfig = a.baseExecutor.withPhaseEndSnapshot(phaseConfig, workspace.WorktreePath)
 
 	// ClaudeExecutorを使用してtmuxウィンドウ内で実行
 	a.logger.Info("Executing Claude in tmux window",
 		"issue_number", issueNumber,
-		"session", a.sessionName,
+		"session", workspace.SessionName,
 		"window", workspace.WindowName,
 		"worktree_path", workspace.WorktreePath,
 	)
 
-	if err := a.claudeExecutor.ExecuteInTmux(ctx, phaseConfig, templateVars, a.sessionName, workspace.WindowName, workspace.WorktreePath); err != nil {
+	if err := a.claudeExecutor.ExecuteInTmux(ctx, phaseConfig, templateVars, workspace.SessionName, workspace.WindowName, workspace.WorktreePath); err != nil {
 		return fmt.Errorf("failed to execute Claude command: %w", err)
 	}
 
@@ -108,7 +124,7 @@ func (a *PlanAction) Execute(ctx context.Context, issue *github.Issue) error {
 
 // CanExecute は計画フェーズのアクションが実行可能かを判定する
 func (a *PlanAction) CanExecute(issue *github.Issue) bool {
-	return hasLabel(issue, "status:needs-plan")
+	return canExecutePhase(a.baseExecutor.currentConfig(), issue, config.PhasePlan)
 }
 
 // worktreeConfig はworktreePath情報を保持する構造体
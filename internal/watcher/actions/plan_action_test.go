@@ -6,6 +6,7 @@ import (
 
 	"github.com/douhashi/osoba/internal/claude"
 	"github.com/douhashi/osoba/internal/config"
+	gitpkg "github.com/douhashi/osoba/internal/git"
 	"github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/testutil/builders"
 	"github.com/douhashi/osoba/internal/testutil/helpers"
@@ -47,8 +48,9 @@ func TestPlanActionV2_Execute(t *testing.T) {
 
 				// Claude実行 - ExecuteInTmuxを使用
 				expectedConfig := &claude.PhaseConfig{
-					Prompt: "prompts/plan.md",
-					Args:   []string{"--arg1", "--arg2"},
+					Prompt:    "prompts/plan.md",
+					Args:      []string{"--arg1", "--arg2"},
+					AfterExit: gitpkg.PhaseEndSnapshotCommand("/test/worktree/issue-123"),
 				}
 				expectedVars := &claude.TemplateVariables{
 					IssueNumber: 123,
@@ -89,6 +91,7 @@ func TestPlanActionV2_Execute(t *testing.T) {
 				tmux.On("SessionExists", "test-session").Return(true, nil).Once()
 				tmux.On("WindowExists", "test-session", "issue-456").Return(true, nil).Once()
 				git.On("WorktreeExistsForIssue", mock.Anything, 456).Return(true, nil).Once()
+				git.On("PreflightWorktreeForIssue", mock.Anything, 456).Return(healthyWorktree, nil).Once()
 				tmux.On("GetPaneByTitle", "test-session", "issue-456", "Plan").Return(nil, assert.AnError).Once()
 				tmux.On("GetPaneBaseIndex").Return(0, nil).Once()
 				tmux.On("SetPaneTitle", "test-session", "issue-456", 0, "Plan").Return(nil).Once()
@@ -97,8 +100,9 @@ func TestPlanActionV2_Execute(t *testing.T) {
 
 				// Claude実行 - ExecuteInTmuxを使用（args空配列）
 				expectedConfig := &claude.PhaseConfig{
-					Prompt: "prompts/plan.md",
-					Args:   []string{},
+					Prompt:    "prompts/plan.md",
+					Args:      []string{},
+					AfterExit: gitpkg.PhaseEndSnapshotCommand("/test/worktree/issue-456"),
 				}
 				claudeExec.On("ExecuteInTmux",
 					mock.Anything,
@@ -141,6 +145,7 @@ func TestPlanActionV2_Execute(t *testing.T) {
 				tmux.On("SessionExists", "test-session").Return(true, nil).Once()
 				tmux.On("WindowExists", "test-session", "issue-999").Return(true, nil).Once()
 				git.On("WorktreeExistsForIssue", mock.Anything, 999).Return(true, nil).Once()
+				git.On("PreflightWorktreeForIssue", mock.Anything, 999).Return(healthyWorktree, nil).Once()
 				tmux.On("GetPaneByTitle", "test-session", "issue-999", "Plan").Return(nil, assert.AnError).Once()
 				tmux.On("GetPaneBaseIndex").Return(0, nil).Once()
 				tmux.On("SetPaneTitle", "test-session", "issue-999", 0, "Plan").Return(nil).Once()
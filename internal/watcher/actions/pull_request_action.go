@@ -0,0 +1,117 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/douhashi/osoba/internal/claude"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+	tmuxpkg "github.com/douhashi/osoba/internal/tmux"
+	"github.com/douhashi/osoba/internal/types"
+)
+
+// PRモードのフェーズ（claude.phasesのキー）
+const (
+	PullRequestPhaseReview = "pr_review"
+	PullRequestPhaseRevise = "pr_revise"
+)
+
+// PullRequestAction はPRモードでIssueのないPRをレビュー・修正するアクション実装
+// 渡されるIssueはPRの番号・タイトル・ラベルを持つ（worktreeとtmuxウィンドウもPRの番号で作成する）
+// ラベルの更新はエージェントとPRModeWatcherが行う
+type PullRequestAction struct {
+	types.BaseAction
+	baseExecutor   *BaseExecutor
+	claudeExecutor claude.ClaudeExecutor
+	claudeConfig   *claude.ClaudeConfig
+	claudePhase    string // PullRequestPhaseReview / PullRequestPhaseRevise
+	paneTitle      string // ペインのタイトル（tmux.phasesのreview / reviseの設定を使う）
+	logger         logger.Logger
+}
+
+// NewPullRequestAction は新しいPullRequestActionを作成する
+func NewPullRequestAction(
+	claudePhase string,
+	sessionName string,
+	tmuxManager tmuxpkg.Manager,
+	worktreeManager git.WorktreeManager,
+	cfg *config.Config,
+	claudeExecutor claude.ClaudeExecutor,
+	claudeConfig *claude.ClaudeConfig,
+	logger logger.Logger,
+) (*PullRequestAction, error) {
+	action := &PullRequestAction{
+		baseExecutor:   NewBaseExecutor(sessionName, tmuxManager, worktreeManager, cfg, logger),
+		claudeExecutor: claudeExecutor,
+		claudeConfig:   claudeConfig,
+		claudePhase:    claudePhase,
+		logger:         logger,
+	}
+	switch claudePhase {
+	case PullRequestPhaseReview:
+		action.BaseAction = types.BaseAction{Type: types.ActionTypeReview}
+		action.paneTitle = "Review"
+	case PullRequestPhaseRevise:
+		action.BaseAction = types.BaseAction{Type: types.ActionTypeRevise}
+		action.paneTitle = "Revise"
+	default:
+		return nil, fmt.Errorf("unknown pull request phase: %s", claudePhase)
+	}
+	return action, nil
+}
+
+// SetArtifactsRoot はフェーズ間でファイルを受け渡す成果物ディレクトリを置くリポジトリのルートを設定する
+func (a *PullRequestAction) SetArtifactsRoot(root string) {
+	a.baseExecutor.SetArtifactsRoot(root)
+}
+
+// SetPaneRegistry はフェーズのペインを配置したウィンドウを記録するレジストリを設定する
+func (a *PullRequestAction) SetPaneRegistry(registry *tmuxpkg.PaneRegistry) {
+	a.baseExecutor.SetPaneRegistry(registry)
+}
+
+// Execute はPRのworktreeでレビュー・修正を実行する
+// worktreeは呼び出し側でPRのheadから作成しておく（ない場合はmainから作成されてしまう）
+func (a *PullRequestAction) Execute(ctx context.Context, issue *github.Issue) error {
+	if issue == nil || issue.Number == nil {
+		return fmt.Errorf("invalid pull request")
+	}
+
+	prNumber := *issue.Number
+	a.logger.Info("Executing pull request action", "pr_number", prNumber, "phase", a.claudePhase)
+
+	workspace, err := a.baseExecutor.PrepareWorkspace(ctx, issue, a.paneTitle)
+	if err != nil {
+		return fmt.Errorf("failed to prepare workspace: %w", err)
+	}
+
+	templateVars := &claude.TemplateVariables{
+		IssueNumber:  prNumber,
+		IssueTitle:   getIssueTitle(issue),
+		RepoName:     getRepoName(),
+		Labels:       getIssueLabels(issue),
+		ArtifactsDir: workspace.ArtifactsDir,
+	}
+
+	phaseConfig, exists := a.claudeConfig.GetPhase(a.claudePhase)
+	if !exists {
+		return fmt.Errorf("%s phase config not found", a.claudePhase)
+	}
+	phaseConfig = a.baseExecutor.withHeartbeat(phaseConfig)
+	phaseConfig = a.baseExecutor.withArtifactsDir(phaseConfig, workspace.ArtifactsDir)
+
+	if err := a.claudeExecutor.ExecuteInTmux(ctx, phaseConfig, templateVars, workspace.SessionName, workspace.WindowName, workspace.WorktreePath); err != nil {
+		return fmt.Errorf("failed to execute Claude command: %w", err)
+	}
+
+	a.logger.Info("Pull request action completed successfully", "pr_number", prNumber, "phase", a.claudePhase)
+	return nil
+}
+
+// CanExecute はPRモードのアクションが実行可能かを判定する（対象のPRはPRModeWatcherが選ぶ）
+func (a *PullRequestAction) CanExecute(issue *github.Issue) bool {
+	return issue != nil && issue.Number != nil
+}
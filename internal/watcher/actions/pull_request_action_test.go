@@ -0,0 +1,94 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/claude"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/testutil/builders"
+	"github.com/douhashi/osoba/internal/testutil/helpers"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestPullRequestAction_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		phase       string
+		paneTitle   string
+		prompt      string
+		wantErr     bool
+		errContains string
+	}{
+		{name: "レビュー", phase: PullRequestPhaseReview, paneTitle: "Review", prompt: "/osoba:review-pr {{issue-number}}"},
+		{name: "修正", phase: PullRequestPhaseRevise, paneTitle: "Revise", prompt: "/osoba:revise-pr {{issue-number}}"},
+		{name: "phase設定が見つからない", phase: PullRequestPhaseReview, paneTitle: "Review", wantErr: true, errContains: "pr_review phase config not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+			tmuxManager := mocks.NewMockTmuxManager()
+			worktreeManager := mocks.NewMockGitWorktreeManager()
+			claudeExecutor := mocks.NewMockClaudeExecutor()
+
+			// worktreeはPRModeWatcherがPRのheadから作成済み
+			tmuxManager.On("SessionExists", "test-session").Return(true, nil).Once()
+			tmuxManager.On("WindowExists", "test-session", "issue-42").Return(false, nil).Once()
+			tmuxManager.On("CreateWindowForIssueWithNewWindowDetection", "test-session", 42).Return("issue-42", true, nil).Once()
+			worktreeManager.On("WorktreeExistsForIssue", mock.Anything, 42).Return(true, nil).Once()
+			worktreeManager.On("PreflightWorktreeForIssue", mock.Anything, 42).Return(healthyWorktree, nil).Once()
+			tmuxManager.On("GetPaneByTitle", "test-session", "issue-42", tt.paneTitle).Return(nil, assert.AnError).Once()
+			tmuxManager.On("GetPaneBaseIndex").Return(0, nil).Once()
+			tmuxManager.On("SetPaneTitle", "test-session", "issue-42", 0, tt.paneTitle).Return(nil).Once()
+			tmuxManager.On("ResizePanesEvenly", "test-session", "issue-42").Return(nil).Once()
+			worktreeManager.On("GetWorktreePathForIssue", 42).Return("/test/worktree/issue-42")
+			worktreeManager.On("HasUncommittedChanges", mock.Anything, "/test/worktree/issue-42").Return(false, nil).Once()
+
+			claudeConfig := &claude.ClaudeConfig{Phases: map[string]*claude.PhaseConfig{}}
+			if tt.prompt != "" {
+				claudeConfig.Phases[tt.phase] = &claude.PhaseConfig{Prompt: tt.prompt}
+				claudeExecutor.On("ExecuteInTmux",
+					mock.Anything,
+					claudeConfig.Phases[tt.phase],
+					mock.MatchedBy(func(vars *claude.TemplateVariables) bool {
+						return vars.IssueNumber == 42 && vars.IssueTitle == "Add login form"
+					}),
+					"test-session",
+					"issue-42",
+					"/test/worktree/issue-42",
+				).Return(nil).Once()
+			}
+
+			action, err := NewPullRequestAction(tt.phase, "test-session", tmuxManager, worktreeManager,
+				config.NewConfig(), claudeExecutor, claudeConfig, logger)
+			require.NoError(t, err)
+
+			pr := builders.NewIssueBuilder().WithNumber(42).WithTitle("Add login form").Build()
+			err = action.Execute(context.Background(), pr)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+
+			tmuxManager.AssertExpectations(t)
+			worktreeManager.AssertExpectations(t)
+			claudeExecutor.AssertExpectations(t)
+			// 新しいworktreeをmainから作成しない
+			worktreeManager.AssertNotCalled(t, "CreateWorktreeForIssue", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestNewPullRequestAction_UnknownPhase(t *testing.T) {
+	logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+	_, err := NewPullRequestAction("review", "test-session", mocks.NewMockTmuxManager(), mocks.NewMockGitWorktreeManager(),
+		config.NewConfig(), mocks.NewMockClaudeExecutor(), &claude.ClaudeConfig{}, logger)
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/douhashi/osoba/internal/claude"
+	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/git"
 	"github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/logger"
@@ -29,6 +30,7 @@ func NewReviewAction(
 	tmuxManager tmuxpkg.Manager,
 	labelManager ActionsLabelManager,
 	worktreeManager git.WorktreeManager,
+	cfg *config.Config,
 	claudeExecutor claude.ClaudeExecutor,
 	claudeConfig *claude.ClaudeConfig,
 	logger logger.Logger,
@@ -37,7 +39,7 @@ func NewReviewAction(
 		sessionName,
 		tmuxManager,
 		worktreeManager,
-		nil, // ClaudeCommandBuilderは不要になったのでnilを渡す
+		cfg,
 		logger,
 	)
 
@@ -52,6 +54,16 @@ func NewReviewAction(
 	}
 }
 
+// SetArtifactsRoot はフェーズ間でファイルを受け渡す成果物ディレクトリを置くリポジトリのルートを設定する
+func (a *ReviewAction) SetArtifactsRoot(root string) {
+	a.baseExecutor.SetArtifactsRoot(root)
+}
+
+// SetPaneRegistry はフェーズのペインを配置したウィンドウを記録するレジストリを設定する
+func (a *ReviewAction) SetPaneRegistry(registry *tmuxpkg.PaneRegistry) {
+	a.baseExecutor.SetPaneRegistry(registry)
+}
+
 // Execute はレビューフェーズのアクションを実行する
 func (a *ReviewAction) Execute(ctx context.Context, issue *github.Issue) error {
 	if issue == nil || issue.Number == nil {
@@ -76,9 +88,12 @@ func (a *ReviewAction) Execute(ctx context.Context, issue *github.Issue) error {
 
 	// Claude実行用の変数を準備
 	templateVars := &claude.TemplateVariables{
-		IssueNumber: int(issueNumber),
-		IssueTitle:  getIssueTitle(issue),
-		RepoName:    getRepoName(),
+		IssueNumber:  int(issueNumber),
+		IssueTitle:   getIssueTitle(issue),
+		IssueBody:    getIssueBody(issue),
+		RepoName:     getRepoName(),
+		Labels:       getIssueLabels(issue),
+		ArtifactsDir: workspace.ArtifactsDir,
 	}
 
 	// Claude設定を取得
@@ -86,33 +101,32 @@ func (a *ReviewAction) Execute(ctx context.Context, issue *github.Issue) error {
 	if !exists {
 		return fmt.Errorf("review phase config not found")
 	}
+	phaseConfig = a.baseExecutor.withHeartbeat(phaseConfig)
+	phaseConfig = a.baseExecutor.withArtifactsDir(phaseConfig, workspace.ArtifactsDir)
+	phaseConfig = a.baseExecutor.withPhaseEndSnapshot(phaseConfig, workspace.WorktreePath)
 
 	// ClaudeExecutorを使用してtmuxウィンドウ内で実行
 	a.logger.Info("Executing Claude in tmux window",
 		"issue_number", issueNumber,
-		"session", a.sessionName,
+		"session", workspace.SessionName,
 		"window", workspace.WindowName,
 		"worktree_path", workspace.WorktreePath,
 	)
 
-	if err := a.claudeExecutor.ExecuteInTmux(ctx, phaseConfig, templateVars, a.sessionName, workspace.WindowName, workspace.WorktreePath); err != nil {
+	if err := a.claudeExecutor.ExecuteInTmux(ctx, phaseConfig, templateVars, workspace.SessionName, workspace.WindowName, workspace.WorktreePath); err != nil {
 		return fmt.Errorf("failed to execute Claude command: %w", err)
 	}
 
-	// ラベル更新: status:review-requested -> status:reviewed
+	// ラベル更新: トリガーラベル（既定ではstatus:review-requested） -> 実行中ラベル（既定ではstatus:reviewing）
 	if a.labelManager != nil {
+		t := phaseTransition(a.baseExecutor.currentConfig(), issue, config.PhaseReview)
+		add, remove := []string{t.Executing}, []string{t.From}
 		a.logger.Info("Updating issue labels", "issue_number", issueNumber)
-		if err := a.labelManager.RemoveLabel(ctx, int(issueNumber), "status:review-requested"); err != nil {
-			a.logger.Error("Failed to remove label",
-				"issue_number", issueNumber,
-				"label", "status:review-requested",
-				"error", err,
-			)
-		}
-		if err := a.labelManager.AddLabel(ctx, int(issueNumber), "status:reviewed"); err != nil {
-			a.logger.Error("Failed to add label",
+		if err := a.labelManager.EditLabels(ctx, int(issueNumber), add, remove); err != nil {
+			a.logger.Error("Failed to update labels",
 				"issue_number", issueNumber,
-				"label", "status:reviewed",
+				"add", add,
+				"remove", remove,
 				"error", err,
 			)
 		}
@@ -127,5 +141,5 @@ func (a *ReviewAction) Execute(ctx context.Context, issue *github.Issue) error {
 
 // CanExecute はレビューフェーズのアクションが実行可能かを判定する
 func (a *ReviewAction) CanExecute(issue *github.Issue) bool {
-	return hasLabel(issue, "status:review-requested")
+	return canExecutePhase(a.baseExecutor.currentConfig(), issue, config.PhaseReview)
 }
@@ -68,8 +68,7 @@ func TestReviewAction_Execute(t *testing.T) {
 				).Return(nil).Once()
 
 				// ラベル更新
-				labelManager.On("RemoveLabel", mock.Anything, 123, "status:review-requested").Return(nil).Once()
-				labelManager.On("AddLabel", mock.Anything, 123, "status:reviewed").Return(nil).Once()
+				labelManager.On("EditLabels", mock.Anything, 123, []string{"status:reviewing"}, []string{"status:review-requested"}).Return(nil).Once()
 			},
 			claudeConfig: &claude.ClaudeConfig{
 				Phases: map[string]*claude.PhaseConfig{
@@ -137,6 +136,7 @@ func TestReviewAction_Execute(t *testing.T) {
 				tmuxManager,
 				labelManager,
 				worktreeManager,
+				nil,
 				claudeExecutor,
 				tt.claudeConfig,
 				logger,
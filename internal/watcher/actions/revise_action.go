@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/douhashi/osoba/internal/claude"
+	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/git"
 	"github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/logger"
@@ -20,6 +21,7 @@ type ReviseAction struct {
 	sessionName    string
 	labelManager   ActionsLabelManager
 	claudeConfig   *claude.ClaudeConfig
+	botReviews     BotReviewSource // レビューボットのレビュー（未設定の場合はnil）
 	logger         logger.Logger
 }
 
@@ -29,6 +31,7 @@ func NewReviseAction(
 	tmuxManager tmuxpkg.Manager,
 	labelManager ActionsLabelManager,
 	worktreeManager git.WorktreeManager,
+	cfg *config.Config,
 	claudeExecutor claude.ClaudeExecutor,
 	claudeConfig *claude.ClaudeConfig,
 	logger logger.Logger,
@@ -37,7 +40,7 @@ func NewReviseAction(
 		sessionName,
 		tmuxManager,
 		worktreeManager,
-		nil, // ClaudeCommandBuilderは不要になったのでnilを渡す
+		cfg,
 		logger,
 	)
 
@@ -52,6 +55,21 @@ func NewReviseAction(
 	}
 }
 
+// SetBotReviewSource はプロンプトに渡すレビューボットのレビューの取得元を設定する
+func (a *ReviseAction) SetBotReviewSource(source BotReviewSource) {
+	a.botReviews = source
+}
+
+// SetArtifactsRoot はフェーズ間でファイルを受け渡す成果物ディレクトリを置くリポジトリのルートを設定する
+func (a *ReviseAction) SetArtifactsRoot(root string) {
+	a.baseExecutor.SetArtifactsRoot(root)
+}
+
+// SetPaneRegistry はフェーズのペインを配置したウィンドウを記録するレジストリを設定する
+func (a *ReviseAction) SetPaneRegistry(registry *tmuxpkg.PaneRegistry) {
+	a.baseExecutor.SetPaneRegistry(registry)
+}
+
 // Execute はレビュー指摘対応フェーズのアクションを実行する
 func (a *ReviseAction) Execute(ctx context.Context, issue *github.Issue) error {
 	if issue == nil || issue.Number == nil {
@@ -60,8 +78,10 @@ func (a *ReviseAction) Execute(ctx context.Context, issue *github.Issue) error {
 
 	issueNumber := int64(*issue.Number)
 	a.logger.Info("Executing revise action", "issue_number", issueNumber)
+	cfg := a.baseExecutor.currentConfig()
+	trigger := phaseTransition(cfg, issue, config.PhaseRevise).From
 
-	// PRのstatus:requires-changesラベルを削除（重複実行防止）
+	// PRのトリガーラベル（既定ではstatus:requires-changes）を削除（重複実行防止）
 	if a.labelManager != nil {
 		pr, err := a.labelManager.GetPullRequestForIssue(ctx, int(issueNumber))
 		if err != nil {
@@ -75,18 +95,17 @@ func (a *ReviseAction) Execute(ctx context.Context, issue *github.Issue) error {
 				"issue_number", issueNumber,
 				"pr_number", pr.Number,
 			)
-			// PRのstatus:requires-changesラベルを削除
-			if err := a.labelManager.RemoveLabel(ctx, pr.Number, "status:requires-changes"); err != nil {
+			if err := a.labelManager.RemoveLabel(ctx, pr.Number, trigger); err != nil {
 				a.logger.Error("Failed to remove PR label",
 					"pr_number", pr.Number,
-					"label", "status:requires-changes",
+					"label", trigger,
 					"error", err,
 				)
 				// エラーが発生しても処理を継続
 			} else {
 				a.logger.Info("Successfully removed PR label",
 					"pr_number", pr.Number,
-					"label", "status:requires-changes",
+					"label", trigger,
 				)
 			}
 		} else {
@@ -111,9 +130,34 @@ func (a *ReviseAction) Execute(ctx context.Context, issue *github.Issue) error {
 
 	// Claude実行用の変数を準備
 	templateVars := &claude.TemplateVariables{
-		IssueNumber: int(issueNumber),
-		IssueTitle:  getIssueTitle(issue),
-		RepoName:    getRepoName(),
+		IssueNumber:  int(issueNumber),
+		IssueTitle:   getIssueTitle(issue),
+		IssueBody:    getIssueBody(issue),
+		RepoName:     getRepoName(),
+		Labels:       getIssueLabels(issue),
+		ArtifactsDir: workspace.ArtifactsDir,
+	}
+
+	// レビューボットのレビューをプロンプトの変数とworktreeのファイルで渡す
+	if a.botReviews != nil {
+		feedback, err := a.botReviews.BotReviewFeedback(ctx, int(issueNumber))
+		if err != nil {
+			// ボットのレビューがなくてもPRのコメントから修正できるため、処理を継続する
+			a.logger.Warn("Failed to get review bot feedback",
+				"issue_number", issueNumber,
+				"error", err,
+			)
+		} else {
+			templateVars.BotReviews = feedback
+			a.baseExecutor.excludeFromGit(BotReviewsFile, "osobaがreviseフェーズに渡すレビューボットのレビュー")
+			if err := writeBotReviews(workspace.WorktreePath, feedback); err != nil {
+				a.logger.Warn("Failed to write review bot feedback",
+					"issue_number", issueNumber,
+					"path", BotReviewsFile,
+					"error", err,
+				)
+			}
+		}
 	}
 
 	// Claude設定を取得
@@ -121,41 +165,34 @@ func (a *ReviseAction) Execute(ctx context.Context, issue *github.Issue) error {
 	if !exists {
 		return fmt.Errorf("revise phase config not found")
 	}
+	phaseConfig = a.baseExecutor.withHeartbeat(phaseConfig)
+	phaseConfig = a.baseExecutor.withArtifactsDir(phaseConfig, workspace.ArtifactsDir)
+	phaseConfig = a.baseExecutor.withPhaseEndSnapshot(phaseConfig, workspace.WorktreePath)
 
 	// ClaudeExecutorを使用してtmuxウィンドウ内で実行
 	a.logger.Info("Executing Claude in tmux window",
 		"issue_number", issueNumber,
-		"session", a.sessionName,
+		"session", workspace.SessionName,
 		"window", workspace.WindowName,
 		"worktree_path", workspace.WorktreePath,
 	)
 
-	if err := a.claudeExecutor.ExecuteInTmux(ctx, phaseConfig, templateVars, a.sessionName, workspace.WindowName, workspace.WorktreePath); err != nil {
+	if err := a.claudeExecutor.ExecuteInTmux(ctx, phaseConfig, templateVars, workspace.SessionName, workspace.WindowName, workspace.WorktreePath); err != nil {
 		return fmt.Errorf("failed to execute Claude command: %w", err)
 	}
 
-	// ラベル更新: status:requires-changes -> status:revising
+	// ラベル更新: トリガーラベルとレビューの実行中ラベル -> 修正中ラベル（既定ではstatus:requires-changes・status:reviewing -> status:revising）
 	if a.labelManager != nil {
-		a.logger.Info("Updating issue labels", "issue_number", issueNumber)
-		if err := a.labelManager.RemoveLabel(ctx, int(issueNumber), "status:requires-changes"); err != nil {
-			a.logger.Error("Failed to remove label",
-				"issue_number", issueNumber,
-				"label", "status:requires-changes",
-				"error", err,
-			)
-		}
-		// status:reviewingラベルも削除（存在しない場合やエラーでも処理継続）
-		if err := a.labelManager.RemoveLabel(ctx, int(issueNumber), "status:reviewing"); err != nil {
-			a.logger.Error("Failed to remove label",
-				"issue_number", issueNumber,
-				"label", "status:reviewing",
-				"error", err,
-			)
+		add, remove := []string{revisingLabel(cfg)}, []string{trigger}
+		if reviewing := phaseTransition(cfg, nil, config.PhaseReview).Executing; reviewing != "" {
+			remove = append(remove, reviewing)
 		}
-		if err := a.labelManager.AddLabel(ctx, int(issueNumber), "status:revising"); err != nil {
-			a.logger.Error("Failed to add label",
+		a.logger.Info("Updating issue labels", "issue_number", issueNumber)
+		if err := a.labelManager.EditLabels(ctx, int(issueNumber), add, remove); err != nil {
+			a.logger.Error("Failed to update labels",
 				"issue_number", issueNumber,
-				"label", "status:revising",
+				"add", add,
+				"remove", remove,
 				"error", err,
 			)
 		}
@@ -170,5 +207,13 @@ func (a *ReviseAction) Execute(ctx context.Context, issue *github.Issue) error {
 
 // CanExecute はレビュー指摘対応フェーズのアクションが実行可能かを判定する
 func (a *ReviseAction) CanExecute(issue *github.Issue) bool {
-	return hasLabel(issue, "status:requires-changes")
+	return canExecutePhase(a.baseExecutor.currentConfig(), issue, config.PhaseRevise)
+}
+
+// revisingLabel はレビュー指摘の修正中に付与するラベルを返す
+func revisingLabel(cfg *config.Config) string {
+	if cfg == nil || cfg.GitHub.Labels.Revising == "" {
+		return "status:revising"
+	}
+	return cfg.GitHub.Labels.Revising
 }
@@ -2,6 +2,8 @@ package actions
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/douhashi/osoba/internal/claude"
@@ -12,6 +14,7 @@ import (
 	tmuxpkg "github.com/douhashi/osoba/internal/tmux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -72,9 +75,7 @@ func TestReviseAction_Execute(t *testing.T) {
 				).Return(nil).Once()
 
 				// ラベル更新
-				labelManager.On("RemoveLabel", mock.Anything, 123, "status:requires-changes").Return(nil).Once()
-				labelManager.On("RemoveLabel", mock.Anything, 123, "status:reviewing").Return(nil).Once()
-				labelManager.On("AddLabel", mock.Anything, 123, "status:revising").Return(nil).Once()
+				labelManager.On("EditLabels", mock.Anything, 123, []string{"status:revising"}, []string{"status:requires-changes", "status:reviewing"}).Return(nil).Once()
 			},
 			claudeConfig: &claude.ClaudeConfig{
 				Phases: map[string]*claude.PhaseConfig{
@@ -131,9 +132,7 @@ func TestReviseAction_Execute(t *testing.T) {
 				).Return(nil).Once()
 
 				// ラベル更新
-				labelManager.On("RemoveLabel", mock.Anything, 124, "status:requires-changes").Return(nil).Once()
-				labelManager.On("RemoveLabel", mock.Anything, 124, "status:reviewing").Return(nil).Once()
-				labelManager.On("AddLabel", mock.Anything, 124, "status:revising").Return(nil).Once()
+				labelManager.On("EditLabels", mock.Anything, 124, []string{"status:revising"}, []string{"status:requires-changes", "status:reviewing"}).Return(nil).Once()
 			},
 			claudeConfig: &claude.ClaudeConfig{
 				Phases: map[string]*claude.PhaseConfig{
@@ -194,9 +193,7 @@ func TestReviseAction_Execute(t *testing.T) {
 				).Return(nil).Once()
 
 				// ラベル更新
-				labelManager.On("RemoveLabel", mock.Anything, 125, "status:requires-changes").Return(nil).Once()
-				labelManager.On("RemoveLabel", mock.Anything, 125, "status:reviewing").Return(nil).Once()
-				labelManager.On("AddLabel", mock.Anything, 125, "status:revising").Return(nil).Once()
+				labelManager.On("EditLabels", mock.Anything, 125, []string{"status:revising"}, []string{"status:requires-changes", "status:reviewing"}).Return(nil).Once()
 			},
 			claudeConfig: &claude.ClaudeConfig{
 				Phases: map[string]*claude.PhaseConfig{
@@ -209,7 +206,7 @@ func TestReviseAction_Execute(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "status:reviewingラベル削除失敗でも処理継続",
+			name: "ラベル更新失敗でも処理継続",
 			issue: builders.NewIssueBuilder().
 				WithNumber(126).
 				WithTitle("Test Issue Reviewing Label Error").
@@ -253,11 +250,9 @@ func TestReviseAction_Execute(t *testing.T) {
 				).Return(nil).Once()
 
 				// ラベル更新
-				labelManager.On("RemoveLabel", mock.Anything, 126, "status:requires-changes").Return(nil).Once()
-				// status:reviewingラベル削除でエラーが発生
-				labelManager.On("RemoveLabel", mock.Anything, 126, "status:reviewing").Return(assert.AnError).Once()
+				// ラベルの一括変更でエラーが発生
+				labelManager.On("EditLabels", mock.Anything, 126, []string{"status:revising"}, []string{"status:requires-changes", "status:reviewing"}).Return(assert.AnError).Once()
 				// エラーでも処理継続
-				labelManager.On("AddLabel", mock.Anything, 126, "status:revising").Return(nil).Once()
 			},
 			claudeConfig: &claude.ClaudeConfig{
 				Phases: map[string]*claude.PhaseConfig{
@@ -363,6 +358,7 @@ func TestReviseAction_Execute(t *testing.T) {
 				tmuxManager,
 				labelManager,
 				worktreeManager,
+				nil,
 				claudeExecutor,
 				tt.claudeConfig,
 				logger,
@@ -434,6 +430,7 @@ func TestReviseAction_CanExecute(t *testing.T) {
 				mocks.NewMockTmuxManager(),
 				mocks.NewMockLabelManager(),
 				mocks.NewMockGitWorktreeManager(),
+				nil,
 				mocks.NewMockClaudeExecutor(),
 				&claude.ClaudeConfig{},
 				logger,
@@ -444,3 +441,68 @@ func TestReviseAction_CanExecute(t *testing.T) {
 		})
 	}
 }
+
+// stubBotReviewSource は固定のボットのレビューを返すBotReviewSource
+type stubBotReviewSource struct {
+	feedback string
+}
+
+func (s stubBotReviewSource) BotReviewFeedback(ctx context.Context, issueNumber int) (string, error) {
+	return s.feedback, nil
+}
+
+func TestReviseAction_Execute_BotReviews(t *testing.T) {
+	worktreePath := t.TempDir()
+	stale := filepath.Join(worktreePath, BotReviewsFile)
+
+	tests := []struct {
+		name     string
+		feedback string
+	}{
+		{name: "ボットのレビューをプロンプトとファイルで渡す", feedback: "# Review bot feedback for PR #456\n"},
+		{name: "レビューがない場合は以前のファイルを削除", feedback: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, _ := helpers.NewObservableLogger(zapcore.InfoLevel)
+			tmuxManager := mocks.NewMockTmuxManager()
+			worktreeManager := mocks.NewMockGitWorktreeManager()
+			claudeExecutor := mocks.NewMockClaudeExecutor()
+			labelManager := mocks.NewMockLabelManager()
+
+			labelManager.On("GetPullRequestForIssue", mock.Anything, 123).Return(nil, nil).Once()
+			tmuxManager.On("SessionExists", "test-session").Return(true, nil).Once()
+			tmuxManager.On("WindowExists", "test-session", "issue-123").Return(true, nil).Once()
+			worktreeManager.On("WorktreeExistsForIssue", mock.Anything, 123).Return(true, nil).Once()
+			tmuxManager.On("GetPaneByTitle", "test-session", "issue-123", "Revise").Return(nil, assert.AnError).Once()
+			tmuxManager.On("CreatePane", "test-session", "issue-123", mock.Anything).
+				Return(&tmuxpkg.PaneInfo{Index: 2, Title: "Revise", Active: true}, nil).Once()
+			worktreeManager.On("GetWorktreePathForIssue", 123).Return(worktreePath).Once()
+			claudeExecutor.On("ExecuteInTmux", mock.Anything, mock.Anything,
+				mock.MatchedBy(func(vars *claude.TemplateVariables) bool {
+					return vars.BotReviews == tt.feedback
+				}),
+				"test-session", "issue-123", worktreePath,
+			).Return(nil).Once()
+			labelManager.On("EditLabels", mock.Anything, 123, []string{"status:revising"}, []string{"status:requires-changes", "status:reviewing"}).Return(nil).Once()
+
+			action := NewReviseAction("test-session", tmuxManager, labelManager, worktreeManager, nil, claudeExecutor,
+				&claude.ClaudeConfig{Phases: map[string]*claude.PhaseConfig{"revise": {Prompt: "/osoba:revise {{issue-number}}"}}},
+				logger)
+			action.SetBotReviewSource(stubBotReviewSource{feedback: tt.feedback})
+
+			issue := builders.NewIssueBuilder().WithNumber(123).WithLabel("status:requires-changes").Build()
+			require.NoError(t, action.Execute(context.Background(), issue))
+			claudeExecutor.AssertExpectations(t)
+
+			if tt.feedback == "" {
+				assert.NoFileExists(t, stale)
+			} else {
+				content, err := os.ReadFile(stale)
+				require.NoError(t, err)
+				assert.Equal(t, tt.feedback, string(content))
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// Assigner はgithub.assignment.phasesのフェーズを実行中のIssueに担当者をアサインし、
+// フェーズが終わったIssue（実行中ラベルが外れた・閉じられた）からは外す
+// 外すのはosobaがアサインしたIssueのみで、既にアサインされていた（人が手動でアサインした）Issueの担当者は変更しない
+// SetStorePathを指定した場合はアサインしたIssueをファイルに保存し、停止中にフェーズが終わったIssueも再起動後に外す
+type Assigner struct {
+	client   github.GitHubClient
+	owner    string
+	repo     string
+	assignee string // アサインするユーザーのログイン名
+	config   *config.Config
+	logger   logger.Logger
+
+	mu       sync.Mutex
+	path     string       // アサインしたIssueの保存先（空の場合は保存しない）
+	assigned map[int]bool // アサインしたIssue
+	clock    clock.Clock
+}
+
+// NewAssigner は新しいAssignerを作成する
+func NewAssigner(client github.GitHubClient, owner, repo, assignee string, cfg *config.Config, logger logger.Logger) (*Assigner, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if assignee == "" {
+		return nil, errors.New("assignee is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if _, ok := client.(github.IssueAssigner); !ok {
+		return nil, errors.New("github client does not support editing issue assignees")
+	}
+
+	return &Assigner{
+		client:   client,
+		owner:    owner,
+		repo:     repo,
+		assignee: assignee,
+		config:   cfg,
+		logger:   logger,
+		assigned: make(map[int]bool),
+		clock:    clock.New(),
+	}, nil
+}
+
+// SetStorePath はアサインしたIssueの保存先を設定し、保存済みのIssueを読み込む
+func (a *Assigner) SetStorePath(path string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	assigned := make(map[int]bool)
+	if err := loadStoreFile(path, &assigned); err != nil {
+		return err
+	}
+	for number := range assigned {
+		a.assigned[number] = true
+	}
+	a.path = path
+	return nil
+}
+
+// Start は担当者の同期を開始する（ポーリング間隔ごとに同期する）
+func (a *Assigner) Start(ctx context.Context) {
+	interval := a.config.GitHub.PollInterval
+	a.logger.Info("Starting issue assigner", "assignee", a.assignee, "phases", a.config.GitHub.Assignment.Phases, "interval", interval)
+
+	if err := a.SyncOnce(ctx); err != nil {
+		a.logger.Warn("Failed to sync issue assignees", "error", err)
+	}
+
+	ticker := a.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.logger.Info("Issue assigner stopped")
+			return
+		case <-ticker.C():
+			if err := a.SyncOnce(ctx); err != nil {
+				a.logger.Warn("Failed to sync issue assignees", "error", err)
+			}
+		}
+	}
+}
+
+// SyncOnce は対象のフェーズを実行中のIssueをアサインし、フェーズが終わったIssueの担当者を外す
+func (a *Assigner) SyncOnce(ctx context.Context) error {
+	var labels []string
+	for _, p := range activeProgressPhases() {
+		if a.config.GitHub.Assignment.AssignsPhase(p.configKey) {
+			labels = append(labels, p.label)
+		}
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+
+	issues, err := a.client.ListIssuesByLabels(ctx, a.owner, a.repo, labels)
+	if err != nil {
+		return fmt.Errorf("failed to list in-progress issues: %w", err)
+	}
+
+	assigner := a.client.(github.IssueAssigner)
+	active := make(map[int]bool)
+	for _, issue := range issues {
+		if issue == nil || issue.Number == nil {
+			continue
+		}
+		phase, ok := findProgressPhase(issue)
+		if !ok || !a.config.GitHub.Assignment.AssignsPhase(phase.configKey) {
+			continue
+		}
+		number := *issue.Number
+		active[number] = true
+
+		// 既にアサインされているIssueは人がアサインした可能性があるため、記録せずに外さない
+		if a.isAssigned(number) || hasAssignee(issue, a.assignee) {
+			continue
+		}
+		if err := assigner.AddIssueAssignee(ctx, a.owner, a.repo, number, a.assignee); err != nil {
+			a.logger.Warn("Failed to assign issue",
+				"issue_number", number,
+				"assignee", a.assignee,
+				"error", err)
+			continue
+		}
+		a.logger.Info("Assigned issue", "issue_number", number, "assignee", a.assignee, "phase", phase.label)
+		a.setAssigned(number, true)
+	}
+
+	for _, number := range a.finished(active) {
+		if err := assigner.RemoveIssueAssignee(ctx, a.owner, a.repo, number, a.assignee); err != nil {
+			// 次回の同期で再び外す
+			a.logger.Warn("Failed to unassign issue",
+				"issue_number", number,
+				"assignee", a.assignee,
+				"error", err)
+			continue
+		}
+		a.logger.Info("Unassigned issue", "issue_number", number, "assignee", a.assignee)
+		a.setAssigned(number, false)
+	}
+	return nil
+}
+
+func (a *Assigner) isAssigned(number int) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.assigned[number]
+}
+
+func (a *Assigner) setAssigned(number int, assigned bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if assigned {
+		a.assigned[number] = true
+	} else {
+		delete(a.assigned, number)
+	}
+	if err := saveStoreFile(a.path, a.assigned); err != nil {
+		a.logger.Warn("Failed to save assigned issues", "path", a.path, "error", err)
+	}
+}
+
+// finished はアサインしたIssueのうち、対象のフェーズを実行中でなくなったIssueを返す
+func (a *Assigner) finished(active map[int]bool) []int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var numbers []int
+	for number := range a.assigned {
+		if !active[number] {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+	return numbers
+}
+
+// hasAssignee はIssueにユーザーがアサインされているかを返す（ログイン名の大文字・小文字は区別しない）
+func hasAssignee(issue *github.Issue, login string) bool {
+	for _, user := range issue.Assignees {
+		if user != nil && user.Login != nil && strings.EqualFold(*user.Login, login) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package watcher
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAssigneeClient は担当者の編集に対応したGitHubクライアントのモック
+type mockAssigneeClient struct {
+	MockGitHubClient
+}
+
+func (m *mockAssigneeClient) AddIssueAssignee(ctx context.Context, owner, repo string, issueNumber int, login string) error {
+	return m.Called(ctx, owner, repo, issueNumber, login).Error(0)
+}
+
+func (m *mockAssigneeClient) RemoveIssueAssignee(ctx context.Context, owner, repo string, issueNumber int, login string) error {
+	return m.Called(ctx, owner, repo, issueNumber, login).Error(0)
+}
+
+func TestAssigner_SyncOnce(t *testing.T) {
+	implementing := &gh.Issue{Number: intPtr(10), Labels: []*gh.Label{{Name: stringPtr("status:implementing")}}}
+	reviewing := &gh.Issue{Number: intPtr(11), Labels: []*gh.Label{{Name: stringPtr("status:reviewing")}}}
+	alreadyAssigned := &gh.Issue{
+		Number:    intPtr(12),
+		Labels:    []*gh.Label{{Name: stringPtr("status:implementing")}},
+		Assignees: []*gh.User{{Login: stringPtr("Osoba-Bot")}},
+	}
+
+	client := new(mockAssigneeClient)
+	cfg := config.NewConfig()
+	cfg.GitHub.Assignment = config.AssignmentConfig{Enabled: true, Phases: []string{"implement", "revise"}}
+	require.NoError(t, cfg.Validate())
+	assigner, err := NewAssigner(client, "owner", "repo", "osoba-bot", cfg, NewMockLogger())
+	require.NoError(t, err)
+
+	// 対象のフェーズ（implement・revise）の実行中ラベルのみで検索する
+	executing := []string{"status:implementing", "status:revising"}
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", executing).Return([]*gh.Issue{implementing, reviewing, alreadyAssigned}, nil).Once()
+	client.On("AddIssueAssignee", mock.Anything, "owner", "repo", 10, "osoba-bot").Return(nil).Once()
+	require.NoError(t, assigner.SyncOnce(context.Background()))
+
+	t.Run("アサイン済みのIssueは再びアサインしない", func(t *testing.T) {
+		client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", executing).Return([]*gh.Issue{implementing, alreadyAssigned}, nil).Once()
+		require.NoError(t, assigner.SyncOnce(context.Background()))
+	})
+
+	t.Run("フェーズが終わったIssueの担当者を外す", func(t *testing.T) {
+		client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", executing).Return([]*gh.Issue{}, nil).Once()
+		client.On("RemoveIssueAssignee", mock.Anything, "owner", "repo", 10, "osoba-bot").Return(assert.AnError).Once()
+		require.NoError(t, assigner.SyncOnce(context.Background()))
+	})
+
+	t.Run("外せなかったIssueは次回の同期で外す", func(t *testing.T) {
+		client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", executing).Return([]*gh.Issue{}, nil).Once()
+		client.On("RemoveIssueAssignee", mock.Anything, "owner", "repo", 10, "osoba-bot").Return(nil).Once()
+		require.NoError(t, assigner.SyncOnce(context.Background()))
+	})
+
+	client.AssertExpectations(t)
+	client.AssertNumberOfCalls(t, "AddIssueAssignee", 1)
+	client.AssertNotCalled(t, "AddIssueAssignee", mock.Anything, mock.Anything, mock.Anything, 11, mock.Anything)
+	// 既にアサインされていたIssueの担当者は外さない
+	client.AssertNotCalled(t, "RemoveIssueAssignee", mock.Anything, mock.Anything, mock.Anything, 12, mock.Anything)
+}
+
+func TestAssigner_SetStorePath(t *testing.T) {
+	implementing := &gh.Issue{Number: intPtr(10), Labels: []*gh.Label{{Name: stringPtr("status:implementing")}}}
+	storePath := filepath.Join(t.TempDir(), "assigner.json")
+	cfg := config.NewConfig()
+	cfg.GitHub.Assignment = config.AssignmentConfig{Enabled: true}
+	require.NoError(t, cfg.Validate())
+
+	client := new(mockAssigneeClient)
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", mock.Anything).Return([]*gh.Issue{implementing}, nil).Once()
+	client.On("AddIssueAssignee", mock.Anything, "owner", "repo", 10, "osoba-bot").Return(nil).Once()
+	assigner, err := NewAssigner(client, "owner", "repo", "osoba-bot", cfg, NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, assigner.SetStorePath(storePath))
+	require.NoError(t, assigner.SyncOnce(context.Background()))
+
+	// 停止中にフェーズが終わったIssueも、再起動後の同期で担当者を外す
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", mock.Anything).Return([]*gh.Issue{}, nil).Once()
+	client.On("RemoveIssueAssignee", mock.Anything, "owner", "repo", 10, "osoba-bot").Return(nil).Once()
+	restarted, err := NewAssigner(client, "owner", "repo", "osoba-bot", cfg, NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, restarted.SetStorePath(storePath))
+	require.NoError(t, restarted.SyncOnce(context.Background()))
+
+	client.AssertExpectations(t)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/notify"
 )
 
 // executeAutoMergeIfLGTM はstatus:lgtmラベルが付いたIssueのPRを自動マージする
@@ -19,8 +20,8 @@ func executeAutoMergeIfLGTM(
 	ghClient github.GitHubClient,
 	cleanupManager cleanup.Manager,
 ) error {
-	// auto_merge_lgtm設定が無効な場合はスキップ
-	if !cfg.GitHub.AutoMergeLGTM {
+	// auto_merge_lgtm設定が無効、または破壊的操作の確認が必要な場合はスキップ
+	if !cfg.GitHub.AutoMergeLGTM || cfg.Safety.RequiresConfirmation(config.OperationAutoMerge) {
 		return nil
 	}
 
@@ -76,6 +77,12 @@ func executeAutoMergeIfLGTMWithLogger(
 	cleanupManager cleanup.Manager,
 	log logger.Logger,
 	metrics *AutoMergeMetrics,
+	closure *IssueClosureVerifier,
+	policy *AutoMergePolicy,
+	notifier notify.Notifier,
+	queue *MergeQueue,
+	branches *RemoteBranchCleaner,
+	history *HistoryGuard,
 ) error {
 	log.Debug("Auto-merge: Configuration check",
 		"auto_merge_enabled", cfg != nil && cfg.GitHub.AutoMergeLGTM,
@@ -87,6 +94,15 @@ func executeAutoMergeIfLGTMWithLogger(
 		return nil
 	}
 
+	// 破壊的操作の確認が必要な場合は非対話で実行できないためスキップ
+	if cfg.Safety.RequiresConfirmation(config.OperationAutoMerge) {
+		log.Warn("Auto-merge: Skipping merge that requires confirmation",
+			"operation", config.OperationAutoMerge,
+			"hint", "run with --yes or add the operation to safety.allow",
+		)
+		return nil
+	}
+
 	// status:lgtmラベルがない場合はスキップ
 	if !hasLGTMLabel(issue) {
 		log.Debug("Auto-merge: No LGTM label found")
@@ -137,6 +153,57 @@ func executeAutoMergeIfLGTMWithLogger(
 		"checks_status", pr.ChecksStatus,
 	)
 
+	// マージキューに追加済みのPRはキューがマージするまで待つ
+	if queue.Tracking(pr.Number) {
+		log.Debug("Auto-merge: Pull request is waiting in merge queue",
+			"pr_number", pr.Number,
+		)
+		return nil
+	}
+
+	// 設定された自動マージの条件を確認（満たさない場合は次のポーリングで再確認する）
+	if reason, detail, err := policy.Check(ctx, pr.Number, issueNumber); err != nil {
+		log.Error("Auto-merge: Failed to check auto-merge rules",
+			"pr_number", pr.Number,
+			"error", err,
+		)
+		if metrics != nil {
+			metrics.RecordFailure(issueNumber, pr.Number, "rule_check_failed")
+		}
+		return fmt.Errorf("failed to check auto-merge rules for PR #%d: %w", pr.Number, err)
+	} else if reason != "" {
+		log.Info("Auto-merge: Pull request does not satisfy auto-merge rules",
+			"pr_number", pr.Number,
+			"reason", reason,
+			"detail", detail,
+		)
+		if metrics != nil {
+			metrics.RecordDeferral(issueNumber, pr.Number, reason)
+		}
+		return nil
+	}
+
+	// ブランチの履歴が書き換えられたPRは人が確認するまでマージしない
+	if detail, err := history.Check(ctx, issueNumber, pr.Number); err != nil {
+		log.Error("Auto-merge: Failed to check branch history",
+			"pr_number", pr.Number,
+			"error", err,
+		)
+		if metrics != nil {
+			metrics.RecordFailure(issueNumber, pr.Number, "history_check_failed")
+		}
+		return fmt.Errorf("failed to check branch history for PR #%d: %w", pr.Number, err)
+	} else if detail != "" {
+		log.Info("Auto-merge: Pull request history was rewritten",
+			"pr_number", pr.Number,
+			"detail", detail,
+		)
+		if metrics != nil {
+			metrics.RecordFailure(issueNumber, pr.Number, "history_rewritten")
+		}
+		return nil
+	}
+
 	// PRがマージ可能かチェック（リトライ機能付き）
 	mergeable, err := checkMergeableWithRetry(ctx, ghClient, pr, log)
 	if err != nil {
@@ -158,22 +225,30 @@ func executeAutoMergeIfLGTMWithLogger(
 			"is_draft", pr.IsDraft,
 			"checks_status", pr.ChecksStatus,
 		)
+		reason := notMergeableReason(pr)
 		if metrics != nil {
-			var reason string
-			switch {
-			case pr.State != "OPEN":
-				reason = "pr_closed"
-			case pr.IsDraft:
-				reason = "pr_draft"
-			case pr.Mergeable == "CONFLICTING":
-				reason = "pr_conflicting"
-			case pr.ChecksStatus == "FAILURE":
-				reason = "checks_failed"
-			default:
-				reason = "not_mergeable"
-			}
 			metrics.RecordFailure(issueNumber, pr.Number, reason)
 		}
+		// コンフリクトとチェックの失敗は人の対応が必要なため通知する
+		if reason == "pr_conflicting" || reason == "checks_failed" {
+			notifyMergeBlocked(ctx, notifier, log, issueNumber, pr, mergeBlockedDetails[reason])
+		}
+		return nil
+	}
+
+	// マージキューを使う場合はキューに追加し、クリーンアップはキューがマージした後に行う
+	if queue != nil {
+		if err := queue.Enqueue(ctx, issueNumber, pr); err != nil {
+			log.Error("Auto-merge: Failed to add pull request to merge queue",
+				"pr_number", pr.Number,
+				"error", err,
+			)
+			if metrics != nil {
+				metrics.RecordFailure(issueNumber, pr.Number, "merge_queue_error")
+			}
+			notifyMergeBlocked(ctx, notifier, log, issueNumber, pr, err.Error())
+			return fmt.Errorf("failed to add pull request #%d to merge queue: %w", pr.Number, err)
+		}
 		return nil
 	}
 
@@ -189,6 +264,7 @@ func executeAutoMergeIfLGTMWithLogger(
 		if metrics != nil {
 			metrics.RecordFailure(issueNumber, pr.Number, "merge_api_error")
 		}
+		notifyMergeBlocked(ctx, notifier, log, issueNumber, pr, err.Error())
 		return fmt.Errorf("failed to merge pull request #%d: %w", pr.Number, err)
 	}
 
@@ -201,6 +277,17 @@ func executeAutoMergeIfLGTMWithLogger(
 		metrics.RecordSuccess(issueNumber, pr.Number)
 	}
 
+	// リンクされたIssueがクローズされたかを確認する（失敗してもクリーンアップは継続）
+	if closure != nil {
+		if err := closure.Verify(ctx, issueNumber, pr.Number); err != nil {
+			log.Warn("Auto-merge: Failed to verify issue closure",
+				"issue_number", issueNumber,
+				"pr_number", pr.Number,
+				"error", err,
+			)
+		}
+	}
+
 	// マージ成功後、クリーンアップを実行
 	// クリーンアップエラーは警告ログのみで処理を継続
 	log.Info("Auto-merge: Cleaning up resources",
@@ -219,6 +306,9 @@ func executeAutoMergeIfLGTMWithLogger(
 		"issue_number", issueNumber,
 	)
 
+	// ローカルのクリーンアップに成功した場合はリモートのブランチも削除する
+	branches.Cleanup(ctx, pr.Number, pr.HeadRefName)
+
 	return nil
 }
 
@@ -229,13 +319,54 @@ func hasLGTMLabel(issue *github.Issue) bool {
 	}
 
 	for _, label := range issue.Labels {
-		if label != nil && label.Name != nil && *label.Name == "status:lgtm" {
+		if label != nil && label.Name != nil && *label.Name == lgtmLabel {
 			return true
 		}
 	}
 	return false
 }
 
+// notMergeableReason はPRがマージできない理由を返す
+func notMergeableReason(pr *github.PullRequest) string {
+	switch {
+	case pr.State != "OPEN":
+		return "pr_closed"
+	case pr.IsDraft:
+		return "pr_draft"
+	case pr.Mergeable == "CONFLICTING":
+		return "pr_conflicting"
+	case pr.ChecksStatus == "FAILURE":
+		return "checks_failed"
+	default:
+		return "not_mergeable"
+	}
+}
+
+// mergeBlockedDetails は通知するマージできない理由ごとの説明
+var mergeBlockedDetails = map[string]string{
+	"pr_conflicting": "PRにコンフリクトがあります。ベースブランチを取り込んで解消してください。",
+	"checks_failed":  "PRのチェックが失敗しています。",
+}
+
+// notifyMergeBlocked は自動マージが停止したPRを通知する（通知が無効の場合は何もしない）
+func notifyMergeBlocked(ctx context.Context, notifier notify.Notifier, log logger.Logger, issueNumber int, pr *github.PullRequest, detail string) {
+	if notifier == nil {
+		return
+	}
+	if err := notifier.Notify(ctx, notify.Event{
+		Kind:        config.NotifyMergeBlocked,
+		IssueNumber: issueNumber,
+		PRNumber:    pr.Number,
+		Title:       pr.Title,
+		Detail:      detail,
+	}); err != nil {
+		log.Warn("Auto-merge: Failed to send merge blocked notification",
+			"pr_number", pr.Number,
+			"error", err,
+		)
+	}
+}
+
 // isMergeable はPRがマージ可能かチェック
 func isMergeable(pr *github.PullRequest) bool {
 	if pr == nil {
@@ -287,12 +418,11 @@ func getPullRequestForIssueWithRetry(
 		)
 
 		// フォールバック: ブランチ名による検索を試行
-		log.Debug("Auto-merge: Attempting fallback search by branch name",
+		log.Debug("Auto-merge: Attempting fallback search for unlinked pull request",
 			"issue_number", issueNumber,
 		)
 
-		// フォールバック機能の実装
-		pr, err = searchPullRequestByBranchName(ctx, ghClient, issueNumber, log)
+		pr, err = searchUnlinkedPullRequest(ctx, ghClient, issueNumber, log)
 		if err != nil {
 			log.Error("Auto-merge: Fallback search also failed",
 				"issue_number", issueNumber,
@@ -310,6 +440,27 @@ func getPullRequestForIssueWithRetry(
 		}
 	}
 
+	// リンクされたPRがない場合はPR本文・コミットのトレーラー・ブランチ名から検索
+	if pr == nil {
+		if searcher, ok := ghClient.(github.PullRequestSearcher); ok {
+			pr, err = searcher.SearchPullRequestForIssue(ctx, issueNumber)
+			if err != nil {
+				log.Warn("Auto-merge: Failed to search unlinked pull request",
+					"issue_number", issueNumber,
+					"error", err,
+				)
+				return nil, nil
+			}
+			if pr != nil {
+				log.Info("Auto-merge: Found unlinked pull request",
+					"issue_number", issueNumber,
+					"pr_number", pr.Number,
+					"branch_name", pr.HeadRefName,
+				)
+			}
+		}
+	}
+
 	if pr != nil {
 		log.Debug("Auto-merge: Successfully found pull request",
 			"issue_number", issueNumber,
@@ -396,6 +547,20 @@ func checkMergeableWithRetry(
 	return false, nil // エラーではなく、マージ不可として扱う
 }
 
+// searchUnlinkedPullRequest はリンクされたPRの取得に失敗した場合のフォールバック
+// PR本文・コミットのトレーラー・ブランチ名から検索できるクライアントではそれを使い、それ以外はブランチ名で検索する
+func searchUnlinkedPullRequest(
+	ctx context.Context,
+	ghClient github.GitHubClient,
+	issueNumber int,
+	log logger.Logger,
+) (*github.PullRequest, error) {
+	if searcher, ok := ghClient.(github.PullRequestSearcher); ok {
+		return searcher.SearchPullRequestForIssue(ctx, issueNumber)
+	}
+	return searchPullRequestByBranchName(ctx, ghClient, issueNumber, log)
+}
+
 // searchPullRequestByBranchName はブランチ名パターンでPRを検索するフォールバック機能
 func searchPullRequestByBranchName(
 	ctx context.Context,
@@ -502,6 +667,12 @@ func executeAutoMergeForPRWithLogger(
 	cleanupManager cleanup.Manager,
 	log logger.Logger,
 	metrics *AutoMergeMetrics,
+	closure *IssueClosureVerifier,
+	policy *AutoMergePolicy,
+	notifier notify.Notifier,
+	queue *MergeQueue,
+	branches *RemoteBranchCleaner,
+	history *HistoryGuard,
 ) error {
 	if pr == nil || pr.Number == 0 {
 		return fmt.Errorf("invalid PR: nil PR or PR number")
@@ -518,6 +689,16 @@ func executeAutoMergeForPRWithLogger(
 		return nil
 	}
 
+	// 破壊的操作の確認が必要な場合は非対話で実行できないためスキップ
+	if cfg.Safety.RequiresConfirmation(config.OperationAutoMerge) {
+		log.Warn("Auto-merge for PR: Skipping merge that requires confirmation",
+			"operation", config.OperationAutoMerge,
+			"pr_number", pr.Number,
+			"hint", "run with --yes or add the operation to safety.allow",
+		)
+		return nil
+	}
+
 	log.Info("Auto-merge for PR: Processing PR",
 		"pr_number", pr.Number,
 		"state", pr.State,
@@ -526,6 +707,58 @@ func executeAutoMergeForPRWithLogger(
 		"checks_status", pr.ChecksStatus,
 	)
 
+	// マージキューに追加済みのPRはキューがマージするまで待つ
+	if queue.Tracking(pr.Number) {
+		log.Debug("Auto-merge for PR: Pull request is waiting in merge queue",
+			"pr_number", pr.Number,
+		)
+		return nil
+	}
+
+	// 設定された自動マージの条件を確認（満たさない場合は次のポーリングで再確認する）
+	if reason, detail, err := policy.Check(ctx, pr.Number, pr.Number); err != nil {
+		log.Error("Auto-merge for PR: Failed to check auto-merge rules",
+			"pr_number", pr.Number,
+			"error", err,
+		)
+		if metrics != nil {
+			metrics.RecordFailure(0, pr.Number, "rule_check_failed")
+		}
+		return fmt.Errorf("failed to check auto-merge rules for PR #%d: %w", pr.Number, err)
+	} else if reason != "" {
+		log.Info("Auto-merge for PR: Pull request does not satisfy auto-merge rules",
+			"pr_number", pr.Number,
+			"reason", reason,
+			"detail", detail,
+		)
+		if metrics != nil {
+			metrics.RecordDeferral(0, pr.Number, reason)
+		}
+		return nil
+	}
+
+	// ブランチの履歴が書き換えられたPRは人が確認するまでマージしない
+	// 通知はPRがクローズするIssue（特定できない場合はPR）に行う
+	if detail, err := history.Check(ctx, historyIssueNumber(ctx, ghClient, history, pr), pr.Number); err != nil {
+		log.Error("Auto-merge for PR: Failed to check branch history",
+			"pr_number", pr.Number,
+			"error", err,
+		)
+		if metrics != nil {
+			metrics.RecordFailure(0, pr.Number, "history_check_failed")
+		}
+		return fmt.Errorf("failed to check branch history for PR #%d: %w", pr.Number, err)
+	} else if detail != "" {
+		log.Info("Auto-merge for PR: Pull request history was rewritten",
+			"pr_number", pr.Number,
+			"detail", detail,
+		)
+		if metrics != nil {
+			metrics.RecordFailure(0, pr.Number, "history_rewritten")
+		}
+		return nil
+	}
+
 	// PRがマージ可能かチェック（リトライ機能付き）
 	mergeable, err := checkMergeableWithRetry(ctx, ghClient, pr, log)
 	if err != nil {
@@ -547,22 +780,30 @@ func executeAutoMergeForPRWithLogger(
 			"is_draft", pr.IsDraft,
 			"checks_status", pr.ChecksStatus,
 		)
+		reason := notMergeableReason(pr)
 		if metrics != nil {
-			var reason string
-			switch {
-			case pr.State != "OPEN":
-				reason = "pr_closed"
-			case pr.IsDraft:
-				reason = "pr_draft"
-			case pr.Mergeable == "CONFLICTING":
-				reason = "pr_conflicting"
-			case pr.ChecksStatus == "FAILURE":
-				reason = "checks_failed"
-			default:
-				reason = "not_mergeable"
-			}
 			metrics.RecordFailure(0, pr.Number, reason)
 		}
+		// コンフリクトとチェックの失敗は人の対応が必要なため通知する
+		if reason == "pr_conflicting" || reason == "checks_failed" {
+			notifyMergeBlocked(ctx, notifier, log, issueNumberFromBranch(pr.HeadRefName), pr, mergeBlockedDetails[reason])
+		}
+		return nil
+	}
+
+	// マージキューを使う場合はキューに追加し、クリーンアップはキューがマージした後に行う
+	if queue != nil {
+		if err := queue.Enqueue(ctx, 0, pr); err != nil {
+			log.Error("Auto-merge for PR: Failed to add pull request to merge queue",
+				"pr_number", pr.Number,
+				"error", err,
+			)
+			if metrics != nil {
+				metrics.RecordFailure(0, pr.Number, "merge_queue_error")
+			}
+			notifyMergeBlocked(ctx, notifier, log, issueNumberFromBranch(pr.HeadRefName), pr, err.Error())
+			return fmt.Errorf("failed to add pull request #%d to merge queue: %w", pr.Number, err)
+		}
 		return nil
 	}
 
@@ -578,6 +819,7 @@ func executeAutoMergeForPRWithLogger(
 		if metrics != nil {
 			metrics.RecordFailure(0, pr.Number, "merge_api_error")
 		}
+		notifyMergeBlocked(ctx, notifier, log, issueNumberFromBranch(pr.HeadRefName), pr, err.Error())
 		return fmt.Errorf("failed to merge pull request #%d: %w", pr.Number, err)
 	}
 
@@ -604,6 +846,22 @@ func executeAutoMergeForPRWithLogger(
 		return nil
 	}
 
+	// クローズキーワードからIssueを特定できない場合はosobaのブランチ名から特定する
+	if issueNumber == 0 {
+		issueNumber = issueNumberFromBranch(pr.HeadRefName)
+	}
+
+	// リンクされたIssueがクローズされたかを確認する（失敗してもクリーンアップは継続）
+	if issueNumber > 0 && closure != nil {
+		if err := closure.Verify(ctx, issueNumber, pr.Number); err != nil {
+			log.Warn("Auto-merge for PR: Failed to verify issue closure",
+				"pr_number", pr.Number,
+				"issue_number", issueNumber,
+				"error", err,
+			)
+		}
+	}
+
 	// Issue番号が取得できた場合のみクリーンアップを実行
 	if issueNumber > 0 {
 		log.Info("Auto-merge for PR: Cleaning up resources for issue",
@@ -623,6 +881,9 @@ func executeAutoMergeForPRWithLogger(
 			"pr_number", pr.Number,
 			"issue_number", issueNumber,
 		)
+
+		// ローカルのクリーンアップに成功した場合はリモートのブランチも削除する
+		branches.Cleanup(ctx, pr.Number, pr.HeadRefName)
 	} else {
 		log.Debug("Auto-merge for PR: No closing issue found, skipping cleanup",
 			"pr_number", pr.Number,
@@ -631,3 +892,18 @@ func executeAutoMergeForPRWithLogger(
 
 	return nil
 }
+
+// historyIssueNumber は履歴の書き換えを通知するIssueの番号を返す
+// PRがクローズするIssue、osobaのブランチ名のIssueの順に特定し、特定できない場合はPRの番号を返す
+func historyIssueNumber(ctx context.Context, ghClient github.GitHubClient, history *HistoryGuard, pr *github.PullRequest) int {
+	if history == nil {
+		return pr.Number
+	}
+	if number, err := ghClient.GetClosingIssueNumber(ctx, pr.Number); err == nil && number > 0 {
+		return number
+	}
+	if number := issueNumberFromBranch(pr.HeadRefName); number > 0 {
+		return number
+	}
+	return pr.Number
+}
@@ -14,6 +14,8 @@ type AutoMergeMetrics struct {
 	SuccessfulMerges int64            // 成功したマージ数
 	FailedMerges     int64            // 失敗したマージ数
 	FailureReasons   map[string]int64 // 失敗理由別の回数
+	DeferredMerges   int64            // 自動マージの条件を満たさず見送った回数（試行・失敗には含めない）
+	DeferralReasons  map[string]int64 // 見送った理由別の回数
 	StartTime        time.Time        // 開始時刻
 	LastAttemptTime  time.Time        // 最後の試行時刻
 }
@@ -31,6 +33,7 @@ func NewAutoMergeMetrics() *AutoMergeMetrics {
 		SuccessfulMerges: 0,
 		FailedMerges:     0,
 		FailureReasons:   make(map[string]int64),
+		DeferralReasons:  make(map[string]int64),
 		StartTime:        time.Now(),
 		LastAttemptTime:  time.Time{},
 	}
@@ -57,6 +60,16 @@ func (m *AutoMergeMetrics) RecordFailure(issueNumber int, prNumber int, reason s
 	m.LastAttemptTime = time.Now()
 }
 
+// RecordDeferral は自動マージの条件（auto_merge_policy）を満たさず見送ったマージを記録する
+// 条件を満たすまでポーリングのたびに見送るため、失敗とは別に数える
+func (m *AutoMergeMetrics) RecordDeferral(issueNumber int, prNumber int, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.DeferredMerges++
+	m.DeferralReasons[reason]++
+}
+
 // GetSuccessRate は成功率を百分率で返す
 func (m *AutoMergeMetrics) GetSuccessRate() float64 {
 	m.mu.RLock()
@@ -114,6 +127,8 @@ func (m *AutoMergeMetrics) Reset() {
 	m.SuccessfulMerges = 0
 	m.FailedMerges = 0
 	m.FailureReasons = make(map[string]int64)
+	m.DeferredMerges = 0
+	m.DeferralReasons = make(map[string]int64)
 	m.StartTime = time.Now()
 	m.LastAttemptTime = time.Time{}
 }
@@ -136,12 +151,18 @@ func (m *AutoMergeMetrics) GetSnapshot() AutoMergeMetricsSnapshot {
 	for k, v := range m.FailureReasons {
 		failureReasons[k] = v
 	}
+	deferralReasons := make(map[string]int64)
+	for k, v := range m.DeferralReasons {
+		deferralReasons[k] = v
+	}
 
 	return AutoMergeMetricsSnapshot{
 		TotalAttempts:    m.TotalAttempts,
 		SuccessfulMerges: m.SuccessfulMerges,
 		FailedMerges:     m.FailedMerges,
 		FailureReasons:   failureReasons,
+		DeferredMerges:   m.DeferredMerges,
+		DeferralReasons:  deferralReasons,
 		StartTime:        m.StartTime,
 		LastAttemptTime:  m.LastAttemptTime,
 		SuccessRate:      m.getSuccessRateUnsafe(),
@@ -163,6 +184,8 @@ type AutoMergeMetricsSnapshot struct {
 	SuccessfulMerges int64
 	FailedMerges     int64
 	FailureReasons   map[string]int64
+	DeferredMerges   int64
+	DeferralReasons  map[string]int64
 	StartTime        time.Time
 	LastAttemptTime  time.Time
 	SuccessRate      float64
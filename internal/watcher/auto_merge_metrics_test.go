@@ -63,6 +63,20 @@ func TestAutoMergeMetrics_RecordFailure(t *testing.T) {
 	}
 }
 
+func TestAutoMergeMetrics_RecordDeferral(t *testing.T) {
+	metrics := NewAutoMergeMetrics()
+
+	metrics.RecordDeferral(123, 456, "rule_min_age")
+	metrics.RecordDeferral(123, 456, "rule_min_age")
+
+	assert.Equal(t, int64(2), metrics.DeferredMerges)
+	assert.Equal(t, int64(2), metrics.DeferralReasons["rule_min_age"])
+	assert.Equal(t, int64(0), metrics.TotalAttempts)
+	assert.Equal(t, int64(0), metrics.FailedMerges)
+	assert.Empty(t, metrics.FailureReasons)
+	assert.Equal(t, int64(2), metrics.GetSnapshot().DeferralReasons["rule_min_age"])
+}
+
 func TestAutoMergeMetrics_RecordMultipleFailures(t *testing.T) {
 	metrics := NewAutoMergeMetrics()
 
@@ -0,0 +1,148 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// lgtmLabel は自動マージの対象を示すラベル
+const lgtmLabel = "status:lgtm"
+
+// AutoMergePolicy はstatus:lgtmラベルに加えて設定された自動マージの条件を確認する
+// 条件を満たさないPRはラベルを変更せずに見送るため、条件を満たした後のポーリングでマージされる
+type AutoMergePolicy struct {
+	client github.PullRequestMergeInfoReader
+	owner  string
+	repo   string
+	config config.AutoMergeConfig
+	logger logger.Logger
+	clock  clock.Clock
+	// requiredReviews はブランチ保護でマージに必要な承認数（ブランチ保護を検出していない場合は0）
+	requiredReviews int
+}
+
+// NewAutoMergePolicy は新しいAutoMergePolicyを作成する
+func NewAutoMergePolicy(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*AutoMergePolicy, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	reader, ok := client.(github.PullRequestMergeInfoReader)
+	if !ok {
+		return nil, errors.New("github client does not support reading pull request merge info")
+	}
+
+	return &AutoMergePolicy{
+		client: reader,
+		owner:  owner,
+		repo:   repo,
+		config: cfg.GitHub.AutoMerge,
+		logger: logger,
+		clock:  clock.New(),
+	}, nil
+}
+
+// SetBranchProtection はブランチ保護で必要な承認がないPRの自動マージを見送るよう設定する
+func (p *AutoMergePolicy) SetBranchProtection(protection *github.BranchProtection) {
+	if protection != nil {
+		p.requiredReviews = protection.RequiredApprovingReviews
+	}
+}
+
+// Check はPRが自動マージの条件を満たすかを確認する
+// 満たさない場合はメトリクスに記録する理由（rule_で始まる）と説明を返し、満たす場合は空文字列を返す
+// lgtmNumberはstatus:lgtmラベルが付与されたIssueまたはPRの番号
+func (p *AutoMergePolicy) Check(ctx context.Context, prNumber, lgtmNumber int) (reason, detail string, err error) {
+	if p == nil {
+		return "", "", nil
+	}
+
+	info, err := p.client.GetPullRequestMergeInfo(ctx, p.owner, p.repo, prNumber)
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(p.config.AllowedAuthors) > 0 && !containsFold(p.config.AllowedAuthors, info.Author) {
+		return "rule_author_not_allowed", fmt.Sprintf("PRの作成者 %s は自動マージの対象外です", info.Author), nil
+	}
+	if missing := missingLabels(p.config.RequiredLabels, info.Labels); len(missing) > 0 {
+		return "rule_missing_labels", fmt.Sprintf("必要なラベルがありません: %s", strings.Join(missing, ", ")), nil
+	}
+	if info.Approvals < p.config.MinApprovals {
+		return "rule_insufficient_approvals", fmt.Sprintf("承認が不足しています（%d/%d）", info.Approvals, p.config.MinApprovals), nil
+	}
+	if info.Approvals < p.requiredReviews {
+		return "rule_protection_reviews", fmt.Sprintf("ブランチ保護で必要な承認が不足しています（%d/%d）", info.Approvals, p.requiredReviews), nil
+	}
+	if failing := failingChecks(p.config.RequiredChecks, info.Checks); len(failing) > 0 {
+		return "rule_checks_not_passed", fmt.Sprintf("必要なチェックが成功していません: %s", strings.Join(failing, ", ")), nil
+	}
+
+	if p.config.MinAge > 0 {
+		addedAt, err := p.client.GetLabelAddedAt(ctx, p.owner, p.repo, lgtmNumber, lgtmLabel)
+		if err != nil {
+			return "", "", err
+		}
+		// 付与の記録がない場合は経過時間を判断できないため見送る
+		if addedAt == nil {
+			return "rule_min_age", fmt.Sprintf("%sラベルの付与日時が不明です", lgtmLabel), nil
+		}
+		if age := p.clock.Since(*addedAt); age < p.config.MinAge {
+			return "rule_min_age", fmt.Sprintf("%sラベルの付与から%sが経過していません（残り%s）", lgtmLabel, p.config.MinAge, (p.config.MinAge - age).Round(time.Second)), nil
+		}
+	}
+
+	p.logger.Debug("Auto-merge rules satisfied", "pr_number", prNumber)
+	return "", "", nil
+}
+
+// missingLabels は付与されていない必須ラベルを返す
+func missingLabels(required, labels []string) []string {
+	var missing []string
+	for _, label := range required {
+		if !containsFold(labels, label) {
+			missing = append(missing, label)
+		}
+	}
+	return missing
+}
+
+// failingChecks は成功していない必須チェックを返す（実行されていないチェックを含む）
+func failingChecks(required []string, checks map[string]string) []string {
+	var failing []string
+	for _, name := range required {
+		switch checks[name] {
+		case "SUCCESS", "NEUTRAL", "SKIPPED":
+		default:
+			failing = append(failing, name)
+		}
+	}
+	sort.Strings(failing)
+	return failing
+}
+
+func containsFold(values []string, v string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, v) {
+			return true
+		}
+	}
+	return false
+}
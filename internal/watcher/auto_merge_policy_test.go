@@ -0,0 +1,132 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockMergeInfoClient は自動マージの条件の判定に必要な情報の取得に対応したGitHubクライアントのモック
+type mockMergeInfoClient struct {
+	MockGitHubClient
+}
+
+func (m *mockMergeInfoClient) GetPullRequestMergeInfo(ctx context.Context, owner, repo string, prNumber int) (*gh.PullRequestMergeInfo, error) {
+	args := m.Called(ctx, owner, repo, prNumber)
+	info, _ := args.Get(0).(*gh.PullRequestMergeInfo)
+	return info, args.Error(1)
+}
+
+func (m *mockMergeInfoClient) GetLabelAddedAt(ctx context.Context, owner, repo string, number int, label string) (*time.Time, error) {
+	args := m.Called(ctx, owner, repo, number, label)
+	addedAt, _ := args.Get(0).(*time.Time)
+	return addedAt, args.Error(1)
+}
+
+func TestAutoMergePolicy_Check(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	lgtmAt := now.Add(-10 * time.Minute)
+	info := &gh.PullRequestMergeInfo{
+		Author:    "osoba-bot",
+		Labels:    []string{"status:lgtm", "safe-to-merge"},
+		Approvals: 1,
+		Checks:    map[string]string{"test": "SUCCESS", "lint": "FAILURE"},
+	}
+
+	tests := []struct {
+		name       string
+		rules      config.AutoMergeConfig
+		lgtmAt     *time.Time
+		wantReason string
+	}{
+		{name: "すべての条件を満たす", rules: config.AutoMergeConfig{RequiredLabels: []string{"safe-to-merge"}, MinApprovals: 1, RequiredChecks: []string{"test"}, AllowedAuthors: []string{"OSOBA-BOT"}, MinAge: 5 * time.Minute}, lgtmAt: &lgtmAt},
+		{name: "作成者が許可されていない", rules: config.AutoMergeConfig{AllowedAuthors: []string{"alice"}}, wantReason: "rule_author_not_allowed"},
+		{name: "必要なラベルがない", rules: config.AutoMergeConfig{RequiredLabels: []string{"deploy-ok"}}, wantReason: "rule_missing_labels"},
+		{name: "承認が不足している", rules: config.AutoMergeConfig{MinApprovals: 2}, wantReason: "rule_insufficient_approvals"},
+		{name: "必要なチェックが失敗している", rules: config.AutoMergeConfig{RequiredChecks: []string{"test", "lint"}}, wantReason: "rule_checks_not_passed"},
+		{name: "必要なチェックが実行されていない", rules: config.AutoMergeConfig{RequiredChecks: []string{"e2e"}}, wantReason: "rule_checks_not_passed"},
+		{name: "LGTMから時間が経過していない", rules: config.AutoMergeConfig{MinAge: 30 * time.Minute}, lgtmAt: &lgtmAt, wantReason: "rule_min_age"},
+		{name: "LGTMの付与日時が不明", rules: config.AutoMergeConfig{MinAge: 5 * time.Minute}, wantReason: "rule_min_age"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewConfig()
+			cfg.GitHub.AutoMerge = tt.rules
+
+			client := new(mockMergeInfoClient)
+			client.On("GetPullRequestMergeInfo", mock.Anything, "owner", "repo", 42).Return(info, nil).Once()
+			if tt.rules.MinAge > 0 {
+				client.On("GetLabelAddedAt", mock.Anything, "owner", "repo", 7, "status:lgtm").Return(tt.lgtmAt, nil).Once()
+			}
+
+			policy, err := NewAutoMergePolicy(client, "owner", "repo", cfg, NewMockLogger())
+			require.NoError(t, err)
+			policy.clock = clock.NewFake(now)
+
+			reason, detail, err := policy.Check(context.Background(), 42, 7)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReason, reason)
+			assert.Equal(t, tt.wantReason == "", detail == "")
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExecuteAutoMergeForPR_SkipsWhenRulesNotSatisfied(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.GitHub.AutoMerge.MinApprovals = 2
+
+	client := new(mockMergeInfoClient)
+	client.On("GetPullRequestMergeInfo", mock.Anything, "owner", "repo", 42).
+		Return(&gh.PullRequestMergeInfo{Approvals: 1, Checks: map[string]string{}}, nil).Once()
+	policy, err := NewAutoMergePolicy(client, "owner", "repo", cfg, NewMockLogger())
+	require.NoError(t, err)
+
+	metrics := NewAutoMergeMetrics()
+	pr := &gh.PullRequest{Number: 42, State: "OPEN", Mergeable: "MERGEABLE", ChecksStatus: "SUCCESS"}
+	err = executeAutoMergeForPRWithLogger(context.Background(), pr, cfg, client, nil, NewMockLogger(), metrics, nil, policy, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	// 条件を満たさないマージは失敗ではなく見送りとして記録する
+	assert.Equal(t, int64(1), metrics.DeferralReasons["rule_insufficient_approvals"])
+	assert.Equal(t, int64(1), metrics.DeferredMerges)
+	assert.Zero(t, metrics.FailedMerges)
+	assert.Zero(t, metrics.TotalAttempts)
+	client.AssertNotCalled(t, "MergePullRequest", mock.Anything, mock.Anything)
+	client.AssertExpectations(t)
+}
+
+func TestAutoMergePolicy_Check_BranchProtectionReviews(t *testing.T) {
+	tests := []struct {
+		name       string
+		approvals  int
+		wantReason string
+	}{
+		{name: "ブランチ保護で必要な承認がない", approvals: 0, wantReason: "rule_protection_reviews"},
+		{name: "ブランチ保護で必要な承認がある", approvals: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockMergeInfoClient)
+			client.On("GetPullRequestMergeInfo", mock.Anything, "owner", "repo", 42).
+				Return(&gh.PullRequestMergeInfo{Approvals: tt.approvals, Checks: map[string]string{}}, nil).Once()
+
+			policy, err := NewAutoMergePolicy(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+			require.NoError(t, err)
+			policy.SetBranchProtection(&gh.BranchProtection{Branch: "main", Protected: true, RequiredApprovingReviews: 1})
+
+			reason, _, err := policy.Check(context.Background(), 42, 42)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
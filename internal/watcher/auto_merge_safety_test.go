@@ -0,0 +1,86 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteAutoMerge_RequiresConfirmation(t *testing.T) {
+	issue := &github.Issue{
+		Number: github.Int(123),
+		Labels: []*github.Label{{Name: github.String("status:lgtm")}},
+	}
+	pr := &github.PullRequest{Number: 456, State: "OPEN", Mergeable: "MERGEABLE"}
+
+	tests := []struct {
+		name        string
+		safety      config.SafetyConfig
+		expectMerge bool
+	}{
+		{
+			name:        "確認が必要な場合はマージしない",
+			safety:      config.SafetyConfig{ConfirmDestructive: true},
+			expectMerge: false,
+		},
+		{
+			name:        "allowにauto_mergeを含む場合はマージする",
+			safety:      config.SafetyConfig{ConfirmDestructive: true, Allow: []string{config.OperationAutoMerge}},
+			expectMerge: true,
+		},
+		{
+			name:        "--yes指定時はマージする",
+			safety:      config.SafetyConfig{ConfirmDestructive: true, AssumeYes: true},
+			expectMerge: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				GitHub: config.GitHubConfig{AutoMergeLGTM: true},
+				Safety: tt.safety,
+			}
+
+			for _, run := range []struct {
+				name string
+				exec func(gh *MockGitHubClientForAutoMerge, cleanup *MockCleanupManager) error
+			}{
+				{
+					name: "issue",
+					exec: func(gh *MockGitHubClientForAutoMerge, cleanup *MockCleanupManager) error {
+						return executeAutoMergeIfLGTMWithLogger(context.Background(), issue, cfg, gh, cleanup, NewMockLogger(), nil, nil, nil, nil, nil, nil, nil)
+					},
+				},
+				{
+					name: "pr",
+					exec: func(gh *MockGitHubClientForAutoMerge, cleanup *MockCleanupManager) error {
+						return executeAutoMergeForPRWithLogger(context.Background(), pr, cfg, gh, cleanup, NewMockLogger(), nil, nil, nil, nil, nil, nil, nil)
+					},
+				},
+			} {
+				mockGH := new(MockGitHubClientForAutoMerge)
+				mockCleanup := new(MockCleanupManager)
+				mockGH.On("GetPullRequestForIssue", mock.Anything, 123).Return(pr, nil).Maybe()
+				mockGH.On("GetPullRequestStatus", mock.Anything, 456).Return(pr, nil).Maybe()
+				mockGH.On("MergePullRequest", mock.Anything, 456).Return(nil).Maybe()
+				mockGH.On("GetClosingIssueNumber", mock.Anything, 456).Return(123, nil).Maybe()
+				mockCleanup.On("CleanupIssueResources", mock.Anything, 123).Return(nil).Maybe()
+
+				require.NoError(t, run.exec(mockGH, mockCleanup), run.name)
+
+				if tt.expectMerge {
+					mockGH.AssertCalled(t, "MergePullRequest", mock.Anything, 456)
+				} else {
+					mockGH.AssertNotCalled(t, "MergePullRequest", mock.Anything, mock.Anything)
+					assert.Empty(t, mockCleanup.Calls, run.name)
+				}
+			}
+		})
+	}
+}
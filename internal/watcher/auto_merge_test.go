@@ -8,6 +8,7 @@ import (
 	"github.com/douhashi/osoba/internal/cleanup"
 	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/notify"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -139,6 +140,10 @@ func (m *MockCleanupManager) CleanupIssueResources(ctx context.Context, issueNum
 	return args.Error(0)
 }
 
+func (m *MockCleanupManager) Plan(ctx context.Context, issueNumber int) (*cleanup.Plan, error) {
+	return &cleanup.Plan{IssueNumber: issueNumber}, nil
+}
+
 // cleanup.Managerインターフェースを実装していることを確認
 var _ cleanup.Manager = (*MockCleanupManager)(nil)
 
@@ -916,3 +921,100 @@ func TestIsMergeable(t *testing.T) {
 		})
 	}
 }
+
+// recordingNotifier は通知されたイベントを記録する
+type recordingNotifier struct {
+	events []notify.Event
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, event notify.Event) error {
+	n.events = append(n.events, event)
+	return nil
+}
+
+func TestExecuteAutoMergeForPR_NotifiesMergeBlocked(t *testing.T) {
+	tests := []struct {
+		name       string
+		pr         *github.PullRequest
+		mergeErr   error
+		wantNotify bool
+		wantDetail string
+	}{
+		{
+			name:       "コンフリクトは通知する",
+			pr:         &github.PullRequest{Number: 456, Title: "Fix login", State: "OPEN", Mergeable: "CONFLICTING", HeadRefName: "osoba/#123"},
+			wantNotify: true,
+			wantDetail: mergeBlockedDetails["pr_conflicting"],
+		},
+		{
+			name:       "マージAPIのエラーは通知する",
+			pr:         &github.PullRequest{Number: 456, Title: "Fix login", State: "OPEN", Mergeable: "MERGEABLE", HeadRefName: "osoba/#123"},
+			mergeErr:   errors.New("base branch policy prohibits the merge"),
+			wantNotify: true,
+			wantDetail: "base branch policy prohibits the merge",
+		},
+		{
+			name: "ドラフトは通知しない",
+			pr:   &github.PullRequest{Number: 456, Title: "Fix login", State: "OPEN", Mergeable: "MERGEABLE", IsDraft: true, HeadRefName: "osoba/#123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{GitHub: config.GitHubConfig{AutoMergeLGTM: true}}
+			mockGH := new(MockGitHubClientForAutoMerge)
+			mockCleanup := new(MockCleanupManager)
+			mockGH.On("GetPullRequestStatus", mock.Anything, 456).Return(tt.pr, nil).Maybe()
+			mockGH.On("MergePullRequest", mock.Anything, 456).Return(tt.mergeErr).Maybe()
+			notifier := &recordingNotifier{}
+
+			_ = executeAutoMergeForPRWithLogger(context.Background(), tt.pr, cfg, mockGH, mockCleanup, NewMockLogger(), nil, nil, nil, notifier, nil, nil, nil)
+
+			if !tt.wantNotify {
+				assert.Empty(t, notifier.events)
+				return
+			}
+			require.Len(t, notifier.events, 1)
+			assert.Equal(t, config.NotifyMergeBlocked, notifier.events[0].Kind)
+			assert.Equal(t, 123, notifier.events[0].IssueNumber)
+			assert.Equal(t, 456, notifier.events[0].PRNumber)
+			assert.Equal(t, "Fix login", notifier.events[0].Title)
+			assert.Equal(t, tt.wantDetail, notifier.events[0].Detail)
+		})
+	}
+}
+
+// mockPullRequestSearcher はリンクされていないPRを検索できるGitHubクライアントのモック
+type mockPullRequestSearcher struct {
+	MockGitHubClientForAutoMerge
+}
+
+func (m *mockPullRequestSearcher) SearchPullRequestForIssue(ctx context.Context, issueNumber int) (*github.PullRequest, error) {
+	args := m.Called(ctx, issueNumber)
+	pr, _ := args.Get(0).(*github.PullRequest)
+	return pr, args.Error(1)
+}
+
+func TestGetPullRequestForIssueWithRetry_SearchesUnlinkedPullRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		linkedErr error
+	}{
+		{name: "リンクされたPRがない"},
+		{name: "リンクされたPRの取得に失敗", linkedErr: errors.New("api error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &github.PullRequest{Number: 25, HeadRefName: "osoba/#12"}
+			client := new(mockPullRequestSearcher)
+			client.On("GetPullRequestForIssue", mock.Anything, 12).Return(nil, tt.linkedErr)
+			client.On("SearchPullRequestForIssue", mock.Anything, 12).Return(pr, nil).Once()
+
+			got, err := getPullRequestForIssueWithRetry(context.Background(), client, 12, NewMockLogger())
+			require.NoError(t, err)
+			assert.Equal(t, pr, got)
+			client.AssertExpectations(t)
+		})
+	}
+}
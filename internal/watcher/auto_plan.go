@@ -35,6 +35,8 @@ func executeAutoPlanIfNoActiveIssues(
 		"status:lgtm",
 		"status:requires-changes",
 		"status:revising",
+		"status:manual",
+		"status:needs-human",
 	}
 
 	activeIssues, err := ghClient.ListIssuesByLabels(ctx, owner, repo, statusLabels)
@@ -201,6 +203,8 @@ func executeAutoPlanWithOptimisticLock(
 		"status:lgtm",
 		"status:requires-changes",
 		"status:revising",
+		"status:manual",
+		"status:needs-human",
 	}
 
 	// 最初のチェック: アクティブIssueの存在確認
@@ -138,7 +138,7 @@ func TestExecuteAutoPlanIfNoActiveIssues(t *testing.T) {
 
 		// status:*ラベル付きIssueなし
 		mockClient.On("ListIssuesByLabels", mock.Anything, "test-owner", "test-repo",
-			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising"}).
+			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising", "status:manual", "status:needs-human"}).
 			Return([]*github.Issue{}, nil)
 
 		// ラベルなしIssueが存在
@@ -210,7 +210,7 @@ func TestExecuteAutoPlanIfNoActiveIssues(t *testing.T) {
 			},
 		}
 		mockClient.On("ListIssuesByLabels", mock.Anything, "test-owner", "test-repo",
-			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising"}).
+			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising", "status:manual", "status:needs-human"}).
 			Return(activeIssues, nil)
 
 		cfg := &config.Config{
@@ -240,7 +240,7 @@ func TestExecuteAutoPlanIfNoActiveIssues(t *testing.T) {
 			},
 		}
 		mockClient.On("ListIssuesByLabels", mock.Anything, "test-owner", "test-repo",
-			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising"}).
+			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising", "status:manual", "status:needs-human"}).
 			Return(activeIssues, nil)
 
 		cfg := &config.Config{
@@ -261,7 +261,7 @@ func TestExecuteAutoPlanIfNoActiveIssues(t *testing.T) {
 
 		// status:*ラベル付きIssueなし
 		mockClient.On("ListIssuesByLabels", mock.Anything, "test-owner", "test-repo",
-			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising"}).
+			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising", "status:manual", "status:needs-human"}).
 			Return([]*github.Issue{}, nil)
 
 		// すべてのIssueがstatus:*ラベル付き
@@ -617,7 +617,7 @@ func TestExecuteAutoPlanWithOptimisticLock(t *testing.T) {
 
 		// 最初のチェック: status:*ラベル付きIssueなし
 		mockClient.On("ListIssuesByLabels", mock.Anything, "test-owner", "test-repo",
-			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising"}).
+			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising", "status:manual", "status:needs-human"}).
 			Return([]*github.Issue{}, nil).Once()
 
 		// オープンIssueにラベルなしIssueが存在
@@ -633,7 +633,7 @@ func TestExecuteAutoPlanWithOptimisticLock(t *testing.T) {
 
 		// 楽観的ロック: ラベル付与前の再確認（まだアクティブIssueなし）
 		mockClient.On("ListIssuesByLabels", mock.Anything, "test-owner", "test-repo",
-			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising"}).
+			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising", "status:manual", "status:needs-human"}).
 			Return([]*github.Issue{}, nil).Once()
 
 		// ラベル付与
@@ -657,7 +657,7 @@ func TestExecuteAutoPlanWithOptimisticLock(t *testing.T) {
 
 		// 最初のチェック: status:*ラベル付きIssueなし
 		mockClient.On("ListIssuesByLabels", mock.Anything, "test-owner", "test-repo",
-			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising"}).
+			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising", "status:manual", "status:needs-human"}).
 			Return([]*github.Issue{}, nil).Once()
 
 		// オープンIssueにラベルなしIssueが存在
@@ -682,7 +682,7 @@ func TestExecuteAutoPlanWithOptimisticLock(t *testing.T) {
 			},
 		}
 		mockClient.On("ListIssuesByLabels", mock.Anything, "test-owner", "test-repo",
-			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising"}).
+			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising", "status:manual", "status:needs-human"}).
 			Return(competingIssue, nil).Once()
 
 		// AddLabelは呼ばれない（競合検出でスキップ）
@@ -705,12 +705,12 @@ func TestExecuteAutoPlanWithOptimisticLock(t *testing.T) {
 
 		// 最初の呼び出しは失敗
 		mockClient.On("ListIssuesByLabels", mock.Anything, "test-owner", "test-repo",
-			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising"}).
+			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising", "status:manual", "status:needs-human"}).
 			Return(nil, errors.New("API error")).Once()
 
 		// リトライ後は成功
 		mockClient.On("ListIssuesByLabels", mock.Anything, "test-owner", "test-repo",
-			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising"}).
+			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising", "status:manual", "status:needs-human"}).
 			Return([]*github.Issue{}, nil).Once()
 
 		allIssues := []*github.Issue{
@@ -725,7 +725,7 @@ func TestExecuteAutoPlanWithOptimisticLock(t *testing.T) {
 
 		// 楽観的ロック再確認
 		mockClient.On("ListIssuesByLabels", mock.Anything, "test-owner", "test-repo",
-			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising"}).
+			[]string{"status:needs-plan", "status:planning", "status:ready", "status:implementing", "status:review-requested", "status:reviewing", "status:lgtm", "status:requires-changes", "status:revising", "status:manual", "status:needs-human"}).
 			Return([]*github.Issue{}, nil).Once()
 
 		mockClient.On("AddLabel", mock.Anything, "test-owner", "test-repo", 1, "status:needs-plan").
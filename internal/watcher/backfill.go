@@ -0,0 +1,161 @@
+package watcher
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// BackfillStatus は積み残しのIssueの開始の進み具合
+type BackfillStatus struct {
+	Pending      []int     `json:"pending"`                  // 開始を待っている積み残しのIssue
+	Started      int       `json:"started"`                  // 開始した積み残しのIssueの数
+	Max          int       `json:"max,omitempty"`            // 1回の起動で開始する上限（0で上限なし）
+	NextLaunchAt time.Time `json:"next_launch_at,omitempty"` // 次の積み残しのIssueを開始できる時刻
+}
+
+// Backfill は起動時にすでに処理待ちだったIssue（積み残し）のフェーズを、設定した間隔で1件ずつ開始する
+// 途中から導入したリポジトリでラベル付きのIssueのフェーズを一度に開始しないために使う
+// 起動後にラベルが付与されたIssueや、一度開始した積み残しのIssueの以降のフェーズは対象外
+// nilの場合はすべてのIssueをすぐに開始する
+type Backfill struct {
+	config   config.BackfillConfig
+	location *time.Location // 待機理由に表示する時刻のタイムゾーン
+	logger   logger.Logger
+	clock    clock.Clock
+
+	mu         sync.Mutex
+	observed   bool         // 最初のポーリングのIssueを記録済みか
+	pending    map[int]bool // 開始を待っている積み残しのIssue
+	started    int
+	lastLaunch time.Time
+}
+
+// NewBackfill は新しいBackfillを作成する
+func NewBackfill(cfg *config.Config, logger logger.Logger) (*Backfill, error) {
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if logger == nil {
+		return nil, errors.New("logger is required")
+	}
+	return &Backfill{
+		config:   cfg.Backfill,
+		location: cfg.Location(),
+		logger:   logger.WithFields("component", "backfill"),
+		clock:    clock.New(),
+		pending:  make(map[int]bool),
+	}, nil
+}
+
+// Observe は最初のポーリングで取得したIssueのうち、フェーズの開始を待っているものを積み残しとして記録する
+// 2回目以降の呼び出しでは何もしない
+func (b *Backfill) Observe(issues []*github.Issue) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.observed {
+		return
+	}
+	b.observed = true
+
+	for _, issue := range issues {
+		if issue == nil || issue.Number == nil {
+			continue
+		}
+		if shouldProcess, _ := ShouldProcessIssue(issue); !shouldProcess {
+			continue
+		}
+		if t, ok := findWorkflowTransition(issue); ok && t.Phase != "" {
+			b.pending[*issue.Number] = true
+		}
+	}
+	if len(b.pending) > 0 {
+		b.logger.Info("Backfilling issues that were waiting at startup",
+			"issues", len(b.pending),
+			"interval", b.config.Interval,
+			"max", b.config.Max)
+	}
+}
+
+// Ready はIssueのフェーズを今開始してよいかを返す（開始を待たせる場合は理由を返す）
+// 開始を記録しないため、フェーズを開始する直前にLaunchedを呼ぶ
+func (b *Backfill) Ready(issueNumber int) (bool, string) {
+	if b == nil {
+		return true, ""
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.pending[issueNumber] {
+		return true, ""
+	}
+
+	if b.config.Max > 0 && b.started >= b.config.Max {
+		return false, fmt.Sprintf("backfill limit of %d issues reached for this run", b.config.Max)
+	}
+	if next := b.nextLaunchAtLocked(); b.clock.Now().Before(next) {
+		return false, fmt.Sprintf("waiting for backfill slot at %s", next.In(b.location).Format(time.RFC3339))
+	}
+	return true, ""
+}
+
+// Launched はIssueのフェーズを開始したことを記録する（積み残しでない場合は何もしない）
+func (b *Backfill) Launched(issueNumber int) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.pending[issueNumber] {
+		return
+	}
+	delete(b.pending, issueNumber)
+	b.started++
+	b.lastLaunch = b.clock.Now()
+	b.logger.Info("Started backfilled issue",
+		"issueNumber", issueNumber,
+		"started", b.started,
+		"pending", len(b.pending))
+}
+
+// Status は積み残しの開始の進み具合を返す（積み残しがない場合はnil）
+func (b *Backfill) Status() *BackfillStatus {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.pending) == 0 {
+		return nil
+	}
+
+	status := &BackfillStatus{
+		Pending: make([]int, 0, len(b.pending)),
+		Started: b.started,
+		Max:     b.config.Max,
+	}
+	for number := range b.pending {
+		status.Pending = append(status.Pending, number)
+	}
+	sort.Ints(status.Pending)
+	if b.config.Max == 0 || b.started < b.config.Max {
+		status.NextLaunchAt = b.nextLaunchAtLocked()
+	}
+	return status
+}
+
+func (b *Backfill) nextLaunchAtLocked() time.Time {
+	if b.lastLaunch.IsZero() {
+		return time.Time{}
+	}
+	return b.lastLaunch.Add(b.config.Interval)
+}
@@ -0,0 +1,85 @@
+package watcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfill_Observe(t *testing.T) {
+	cfg := config.NewConfig()
+	backfill, err := NewBackfill(cfg, NewMockLogger())
+	require.NoError(t, err)
+
+	backfill.Observe([]*gh.Issue{
+		labeledIssue(1, "status:needs-plan"),
+		labeledIssue(2, "status:ready"),
+		labeledIssue(3, "status:planning"),
+		{Number: intPtr(4), Labels: []*gh.Label{{Name: stringPtr("status:needs-plan")}, {Name: stringPtr("status:manual")}}},
+		{Number: intPtr(5)},
+	})
+	// 2回目以降のポーリングで見つかったIssueは積み残しにしない
+	backfill.Observe([]*gh.Issue{labeledIssue(6, "status:needs-plan")})
+
+	status := backfill.Status()
+	require.NotNil(t, status)
+	assert.Equal(t, []int{1, 2}, status.Pending)
+}
+
+func TestBackfill_Ready(t *testing.T) {
+	tests := []struct {
+		name      string
+		max       int
+		launched  []int
+		advance   time.Duration
+		issue     int
+		wantReady bool
+	}{
+		{name: "最初の積み残しはすぐに開始", issue: 1, wantReady: true},
+		{name: "間隔が経過するまで次の積み残しを待たせる", launched: []int{1}, advance: 5 * time.Minute, issue: 2},
+		{name: "間隔が経過したら次の積み残しを開始", launched: []int{1}, advance: 10 * time.Minute, issue: 2, wantReady: true},
+		{name: "上限に達したら開始しない", max: 1, launched: []int{1}, advance: time.Hour, issue: 2},
+		{name: "積み残しでないIssueはすぐに開始", launched: []int{1}, issue: 9, wantReady: true},
+		{name: "開始済みの積み残しの以降のフェーズはすぐに開始", launched: []int{1}, issue: 1, wantReady: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewConfig()
+			cfg.Backfill = config.BackfillConfig{Enabled: true, Interval: 10 * time.Minute, Max: tt.max}
+			backfill, err := NewBackfill(cfg, NewMockLogger())
+			require.NoError(t, err)
+			fakeClock := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+			backfill.clock = fakeClock
+
+			backfill.Observe([]*gh.Issue{
+				labeledIssue(1, "status:needs-plan"),
+				labeledIssue(2, "status:needs-plan"),
+			})
+			for _, n := range tt.launched {
+				backfill.Launched(n)
+			}
+			fakeClock.Advance(tt.advance)
+
+			ready, detail := backfill.Ready(tt.issue)
+			assert.Equal(t, tt.wantReady, ready)
+			if !tt.wantReady {
+				assert.NotEmpty(t, detail)
+			}
+		})
+	}
+}
+
+func TestBackfill_NilIsNoop(t *testing.T) {
+	var backfill *Backfill
+	backfill.Observe([]*gh.Issue{labeledIssue(1, "status:needs-plan")})
+	ready, _ := backfill.Ready(1)
+	assert.True(t, ready)
+	backfill.Launched(1)
+	assert.Nil(t, backfill.Status())
+}
@@ -0,0 +1,196 @@
+package watcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+)
+
+// パイプラインの健全性（shields.ioの色の名前）
+const (
+	HealthGreen   = "green"
+	HealthYellow  = "yellow"
+	HealthRed     = "red"
+	HealthStopped = "lightgrey" // 監視プロセスが停止している
+)
+
+// badgeColors はSVGのバッジで使う色
+var badgeColors = map[string]string{
+	HealthGreen:   "#4c1",
+	HealthYellow:  "#dfb317",
+	HealthRed:     "#e05d44",
+	HealthStopped: "#9f9f9f",
+}
+
+// waitingLabels はosobaがフェーズを開始するのを待っているラベル（フェーズを実行する遷移のトリガーラベル）を返す
+func waitingLabels(cfg *config.Config) []string {
+	transitions := cfg.GitHub.Workflow.Transitions
+	if len(transitions) == 0 {
+		transitions = config.DefaultWorkflowTransitions()
+	}
+	var labels []string
+	for _, t := range transitions {
+		if t.Phase != "" {
+			labels = append(labels, t.From)
+		}
+	}
+	return labels
+}
+
+// openRetries はリトライの状態のうち、取得したオープンなIssueのものだけを返す
+// クローズ済みのIssueのリトライはカウンターに残り続けるため、バッジの判定に含めない
+func openRetries(retries []RetryStatus, issues []*github.Issue) []RetryStatus {
+	open := make(map[int]bool, len(issues))
+	for _, issue := range issues {
+		if issue != nil && issue.Number != nil {
+			open[*issue.Number] = true
+		}
+	}
+	var filtered []RetryStatus
+	for _, retry := range retries {
+		if open[retry.IssueNumber] {
+			filtered = append(filtered, retry)
+		}
+	}
+	return filtered
+}
+
+// PipelineHealth はステータスバッジで表示するパイプラインの健全性
+type PipelineHealth struct {
+	Color   string // green / yellow / red / lightgrey
+	Message string // バッジの右側に表示する文字列
+}
+
+// EvaluatePipelineHealth はリトライの深刻度と最も長く待っているIssue・PRの待ち時間から健全性を判定する
+// failingのIssueがあるか待ち時間がfail_afterを超えた場合は赤、degradedのIssueがあるか待ち時間がwarn_afterを超えた場合は黄色
+func EvaluatePipelineHealth(retries []RetryStatus, oldestWait time.Duration, cfg config.BadgeConfig) PipelineHealth {
+	var failing, degraded int
+	for _, retry := range retries {
+		switch retry.Severity {
+		case RetrySeverityFailing:
+			failing++
+		case RetrySeverityDegraded:
+			degraded++
+		}
+	}
+
+	switch {
+	case failing > 0:
+		return PipelineHealth{Color: HealthRed, Message: fmt.Sprintf("%d failing", failing)}
+	case oldestWait >= cfg.FailAfter:
+		return PipelineHealth{Color: HealthRed, Message: "stalled " + formatWait(oldestWait)}
+	case degraded > 0:
+		return PipelineHealth{Color: HealthYellow, Message: fmt.Sprintf("%d degraded", degraded)}
+	case oldestWait >= cfg.WarnAfter:
+		return PipelineHealth{Color: HealthYellow, Message: "queued " + formatWait(oldestWait)}
+	}
+	return PipelineHealth{Color: HealthGreen, Message: "healthy"}
+}
+
+// oldestWait は待ち状態のIssue（最後の更新から）とマージキューのPR（追加から）のうち最も長い待ち時間を返す
+func oldestWait(issues []*github.Issue, labels []string, queue []MergeQueueStatus, now time.Time) time.Duration {
+	var oldest time.Duration
+	for _, issue := range issues {
+		if issue == nil || issue.UpdatedAt == nil {
+			continue
+		}
+		for _, label := range labels {
+			if hasLabel(issue, label) {
+				if wait := now.Sub(*issue.UpdatedAt); wait > oldest {
+					oldest = wait
+				}
+				break
+			}
+		}
+	}
+	for _, entry := range queue {
+		if wait := now.Sub(entry.EnqueuedAt); wait > oldest {
+			oldest = wait
+		}
+	}
+	return oldest
+}
+
+// formatWait は待ち時間をバッジに収まる短い形式にする
+func formatWait(d time.Duration) string {
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// BadgePaths は設定したバッジのパスからendpoint JSONとSVGのバッジの書き出し先を返す
+// パスの拡張子が.svgの場合はSVGをそのパスに、endpoint JSONを拡張子を.jsonにしたパスに書き出す
+func BadgePaths(path string) (jsonPath, svgPath string) {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	if strings.EqualFold(ext, ".svg") {
+		return base + ".json", path
+	}
+	return path, base + ".svg"
+}
+
+// WriteBadge はshields.ioのendpoint JSONと、そのまま埋め込めるSVGのバッジを書き出す
+func WriteBadge(path, label string, health PipelineHealth) error {
+	endpoint := map[string]interface{}{
+		"schemaVersion": 1,
+		"label":         label,
+		"message":       health.Message,
+		"color":         health.Color,
+	}
+	data, err := json.Marshal(endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to encode badge: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create badge directory: %w", err)
+	}
+	jsonPath, svgPath := BadgePaths(path)
+	if err := writeFileAtomic(jsonPath, data); err != nil {
+		return fmt.Errorf("failed to write badge: %w", err)
+	}
+	if err := writeFileAtomic(svgPath, []byte(renderBadgeSVG(label, health))); err != nil {
+		return fmt.Errorf("failed to write badge svg: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic は配信中のバッジが書きかけにならないよう、一時ファイルから置き換える
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// renderBadgeSVG はshields.ioのflatスタイルに近いSVGのバッジを作成する
+func renderBadgeSVG(label string, health PipelineHealth) string {
+	// 文字幅は11pxのVerdanaでおおよそ7px
+	labelWidth := len(label)*7 + 10
+	messageWidth := len(health.Message)*7 + 10
+	width := labelWidth + messageWidth
+	color, ok := badgeColors[health.Color]
+	if !ok {
+		color = badgeColors[HealthStopped]
+	}
+	label = html.EscapeString(label)
+	message := html.EscapeString(health.Message)
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[2]s: %[3]s">`+
+		`<title>%[2]s: %[3]s</title>`+
+		`<rect width="%[4]d" height="20" fill="#555"/>`+
+		`<rect x="%[4]d" width="%[5]d" height="20" fill="%[6]s"/>`+
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`+
+		`<text x="%[7]d" y="14">%[2]s</text>`+
+		`<text x="%[8]d" y="14">%[3]s</text>`+
+		`</g></svg>`+"\n",
+		width, label, message, labelWidth, messageWidth, color, labelWidth/2, labelWidth+messageWidth/2)
+}
@@ -0,0 +1,174 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluatePipelineHealth(t *testing.T) {
+	cfg := config.NewConfig().Badge
+
+	tests := []struct {
+		name    string
+		retries []RetryStatus
+		wait    time.Duration
+		want    PipelineHealth
+	}{
+		{
+			name: "問題がない場合は緑",
+			wait: 30 * time.Minute,
+			want: PipelineHealth{Color: HealthGreen, Message: "healthy"},
+		},
+		{
+			name:    "degradedのIssueがある場合は黄色",
+			retries: []RetryStatus{{IssueNumber: 1, Severity: RetrySeverityDegraded}, {IssueNumber: 2}},
+			want:    PipelineHealth{Color: HealthYellow, Message: "1 degraded"},
+		},
+		{
+			name: "待ち時間がwarn_afterを超えた場合は黄色",
+			wait: 5*time.Hour + 10*time.Minute,
+			want: PipelineHealth{Color: HealthYellow, Message: "queued 5h"},
+		},
+		{
+			name:    "failingのIssueがある場合は赤",
+			retries: []RetryStatus{{IssueNumber: 1, Severity: RetrySeverityFailing}, {IssueNumber: 2, Severity: RetrySeverityDegraded}},
+			want:    PipelineHealth{Color: HealthRed, Message: "1 failing"},
+		},
+		{
+			name:    "待ち時間がfail_afterを超えた場合はdegradedより優先して赤",
+			retries: []RetryStatus{{IssueNumber: 1, Severity: RetrySeverityDegraded}},
+			wait:    30 * time.Hour,
+			want:    PipelineHealth{Color: HealthRed, Message: "stalled 30h"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EvaluatePipelineHealth(tt.retries, tt.wait, cfg))
+		})
+	}
+}
+
+func TestOldestWait(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+	issues := []*gh.Issue{
+		{Number: intPtr(1), UpdatedAt: at(10 * time.Hour), Labels: []*gh.Label{{Name: stringPtr("status:implementing")}}},
+		{Number: intPtr(2), UpdatedAt: at(2 * time.Hour), Labels: []*gh.Label{{Name: stringPtr("status:ready")}}},
+		{Number: intPtr(3), Labels: []*gh.Label{{Name: stringPtr("status:needs-plan")}}},
+	}
+
+	labels := waitingLabels(config.NewConfig())
+	assert.Equal(t, 2*time.Hour, oldestWait(issues, labels, nil, now), "実行中のIssueは待ち時間に含めない")
+	queue := []MergeQueueStatus{{PRNumber: 9, EnqueuedAt: now.Add(-3 * time.Hour)}}
+	assert.Equal(t, 3*time.Hour, oldestWait(issues, labels, queue, now))
+	assert.Zero(t, oldestWait(nil, labels, nil, now))
+	assert.Zero(t, oldestWait(issues, []string{"triage:accepted"}, nil, now), "ワークフローのトリガーラベルのIssueだけを待ちとする")
+}
+
+func TestWaitingLabels(t *testing.T) {
+	cfg := config.NewConfig()
+	assert.Equal(t, []string{"status:needs-plan", "status:ready", "status:review-requested", "status:requires-changes"}, waitingLabels(cfg))
+
+	cfg.GitHub.Workflow.Transitions = []config.WorkflowTransition{
+		{From: "triage:accepted", Phase: config.PhaseImplement, Executing: "status:implementing", Next: "status:review-requested"},
+		{From: "status:review-requested", Phase: config.PhaseReview, Executing: "status:reviewing"},
+		{From: "triage:wontfix", To: "status:manual"},
+	}
+	assert.Equal(t, []string{"triage:accepted", "status:review-requested"}, waitingLabels(cfg), "フェーズを実行しない遷移は含めない")
+}
+
+func TestOpenRetries(t *testing.T) {
+	retries := []RetryStatus{
+		{IssueNumber: 1, Severity: RetrySeverityFailing},
+		{IssueNumber: 2, Severity: RetrySeverityDegraded},
+	}
+	issues := []*gh.Issue{{Number: intPtr(2)}}
+	assert.Equal(t, []RetryStatus{{IssueNumber: 2, Severity: RetrySeverityDegraded}}, openRetries(retries, issues))
+	assert.Empty(t, openRetries(retries, nil))
+}
+
+func TestBadgePaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		wantJSON string
+		wantSVG  string
+	}{
+		{name: "JSONのパス", path: "/srv/badge/osoba.json", wantJSON: "/srv/badge/osoba.json", wantSVG: "/srv/badge/osoba.svg"},
+		{name: "SVGのパス", path: "/srv/badge/osoba.svg", wantJSON: "/srv/badge/osoba.json", wantSVG: "/srv/badge/osoba.svg"},
+		{name: "大文字のSVGのパス", path: "/srv/badge/osoba.SVG", wantJSON: "/srv/badge/osoba.json", wantSVG: "/srv/badge/osoba.SVG"},
+		{name: "拡張子なし", path: "/srv/badge/osoba", wantJSON: "/srv/badge/osoba", wantSVG: "/srv/badge/osoba.svg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonPath, svgPath := BadgePaths(tt.path)
+			assert.Equal(t, tt.wantJSON, jsonPath)
+			assert.Equal(t, tt.wantSVG, svgPath)
+		})
+	}
+}
+
+func TestWriteBadge_SVGPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "osoba.svg")
+	require.NoError(t, WriteBadge(path, "osoba", PipelineHealth{Color: HealthGreen, Message: "healthy"}))
+
+	svg, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), "<title>osoba: healthy</title>")
+
+	data, err := os.ReadFile(filepath.Join(dir, "osoba.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"message":"healthy"`)
+}
+
+func TestStatusStateWriter_WriteBadge(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	updatedAt := now.Add(-5 * time.Hour)
+	client := new(MockGitHubClient)
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", StatusLabels).Return([]*gh.Issue{
+		{Number: intPtr(2), Title: stringPtr("実装待ちのIssue"), UpdatedAt: &updatedAt, Labels: []*gh.Label{{Name: stringPtr("status:ready")}}},
+	}, nil).Once()
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:requires-changes"}).Return([]*gh.Issue{}, nil).Once()
+
+	dir := t.TempDir()
+	writer, err := NewStatusStateWriter(client, "owner", "repo", filepath.Join(dir, "owner-repo.state.json"), config.NewConfig(), NewMockLogger())
+	require.NoError(t, err)
+	writer.clock = clock.NewFake(now)
+	badgePath := filepath.Join(dir, "badge", "osoba.json")
+	writer.SetBadgePath(badgePath)
+
+	require.NoError(t, writer.WriteOnce(context.Background()))
+
+	data, err := os.ReadFile(badgePath)
+	require.NoError(t, err)
+	var endpoint map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &endpoint))
+	assert.Equal(t, map[string]interface{}{
+		"schemaVersion": float64(1),
+		"label":         "osoba",
+		"message":       "queued 5h",
+		"color":         "yellow",
+	}, endpoint)
+
+	svg, err := os.ReadFile(filepath.Join(dir, "badge", "osoba.svg"))
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), "<title>osoba: queued 5h</title>")
+	assert.Contains(t, string(svg), `fill="#dfb317"`)
+	client.AssertExpectations(t)
+}
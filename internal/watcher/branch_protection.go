@@ -0,0 +1,26 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+
+	"github.com/douhashi/osoba/internal/github"
+)
+
+// DetectBranchProtection はデフォルトブランチのブランチ保護ルールを取得する
+func DetectBranchProtection(ctx context.Context, client github.GitHubClient, owner, repo string) (*github.BranchProtection, error) {
+	inspector, ok := client.(github.RepositoryInspector)
+	if !ok {
+		return nil, errors.New("github client does not support inspecting repositories")
+	}
+	reader, ok := client.(github.BranchProtectionReader)
+	if !ok {
+		return nil, errors.New("github client does not support reading branch protection")
+	}
+
+	branch, err := inspector.GetDefaultBranch(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return reader.GetBranchProtection(ctx, owner, repo, branch)
+}
@@ -0,0 +1,119 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/watcher/actions"
+)
+
+// CanaryActionFactory はカナリアモード（canary.labels）で、カナリアのラベルが付いたIssueのフェーズを現在の設定のアクションで、
+// それ以外のIssueのフェーズを安定版の設定（canary.stable_config）のアクションで実行するActionFactory
+// 安定版のファクトリーがnilの場合、カナリアでないIssueのフェーズは実行しない
+type CanaryActionFactory struct {
+	canary ActionFactory
+	stable ActionFactory
+	config config.CanaryConfig
+	labels github.IssueLabelReader
+	owner  string
+	repo   string
+	logger logger.Logger
+}
+
+// NewCanaryActionFactory は新しいCanaryActionFactoryを作成する
+func NewCanaryActionFactory(canary, stable ActionFactory, cfg config.CanaryConfig, logger logger.Logger) *CanaryActionFactory {
+	return &CanaryActionFactory{canary: canary, stable: stable, config: cfg, logger: logger}
+}
+
+// SetIssueLabelReader はIssueの最新のラベルを取得するクライアントを設定する
+// PRの自動Reviseのようにラベルを持たないIssueで実行する場合に、カナリアかどうかを最新のラベルで判定する
+func (f *CanaryActionFactory) SetIssueLabelReader(reader github.IssueLabelReader, owner, repo string) {
+	f.labels = reader
+	f.owner = owner
+	f.repo = repo
+}
+
+// CreatePlanAction は計画フェーズのアクションを作成する
+func (f *CanaryActionFactory) CreatePlanAction() ActionExecutor {
+	return f.newAction(ActionFactory.CreatePlanAction)
+}
+
+// CreateImplementationAction は実装フェーズのアクションを作成する
+func (f *CanaryActionFactory) CreateImplementationAction() ActionExecutor {
+	return f.newAction(ActionFactory.CreateImplementationAction)
+}
+
+// CreateReviewAction はレビューフェーズのアクションを作成する
+func (f *CanaryActionFactory) CreateReviewAction() ActionExecutor {
+	return f.newAction(ActionFactory.CreateReviewAction)
+}
+
+// CreateReviseAction はレビュー指摘対応フェーズのアクションを作成する
+func (f *CanaryActionFactory) CreateReviseAction() ActionExecutor {
+	return f.newAction(ActionFactory.CreateReviseAction)
+}
+
+// CreateNoOpAction は何もしないアクションを作成する（設定によらないため振り分けない）
+func (f *CanaryActionFactory) CreateNoOpAction() ActionExecutor {
+	return f.canary.CreateNoOpAction()
+}
+
+// newAction はカナリアと安定版のファクトリーでアクションを作成し、Issueのラベルで振り分けるアクションを返す
+func (f *CanaryActionFactory) newAction(create func(ActionFactory) ActionExecutor) ActionExecutor {
+	action := &canaryAction{factory: f, canary: create(f.canary)}
+	if f.stable != nil {
+		action.stable = create(f.stable)
+	}
+	return action
+}
+
+// IsCanary はIssueがカナリアのラベルを持つかを返す
+// Issueのラベルにカナリアのラベルがなく、ラベルの取得クライアントが設定されている場合は最新のラベルで判定する
+func (f *CanaryActionFactory) IsCanary(ctx context.Context, issue *github.Issue) bool {
+	if f.config.Matches(getLabels(issue)) {
+		return true
+	}
+	if f.labels == nil {
+		return false
+	}
+	labels, err := f.labels.GetIssueLabels(ctx, f.owner, f.repo, *issue.Number)
+	if err != nil {
+		f.logger.Warn("Failed to get issue labels for canary check, using stable configuration",
+			"issue_number", *issue.Number,
+			"error", err)
+		return false
+	}
+	return f.config.Matches(labels)
+}
+
+// canaryAction はIssueのラベルによりカナリアと安定版のアクションを振り分ける
+type canaryAction struct {
+	factory *CanaryActionFactory
+	canary  ActionExecutor
+	stable  ActionExecutor // nilの場合はカナリアでないIssueのフェーズを実行しない
+}
+
+// Execute はIssueがカナリアの場合は現在の設定で、それ以外は安定版の設定でアクションを実行する
+func (a *canaryAction) Execute(ctx context.Context, issue *github.Issue) error {
+	if issue == nil || issue.Number == nil {
+		return a.canary.Execute(ctx, issue)
+	}
+	if a.factory.IsCanary(ctx, issue) {
+		a.factory.logger.Debug("Running phase with canary configuration", "issue_number", *issue.Number)
+		return a.canary.Execute(ctx, issue)
+	}
+	if a.stable == nil {
+		// 一時停止として返し、呼び出し側がフェーズを実行せずにラベルを遷移しないようにする
+		a.factory.logger.Info("Skipping phase for non-canary issue", "issue_number", *issue.Number, "canary_labels", a.factory.config.Labels)
+		return fmt.Errorf("%w: issue #%d does not carry a canary label", actions.ErrPhasePaused, *issue.Number)
+	}
+	return a.stable.Execute(ctx, issue)
+}
+
+// CanExecute はアクションが実行可能かを判定する（判定はラベルによるため、カナリアと安定版で同じ）
+func (a *canaryAction) CanExecute(issue *github.Issue) bool {
+	return a.canary.CanExecute(issue)
+}
@@ -0,0 +1,133 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/watcher/actions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIssueLabelReader は最新のラベルを返す
+type fakeIssueLabelReader struct {
+	labels map[int][]string
+}
+
+func (f *fakeIssueLabelReader) GetIssueLabels(ctx context.Context, owner, repo string, issueNumber int) ([]string, error) {
+	return f.labels[issueNumber], nil
+}
+
+func TestCanaryActionFactory(t *testing.T) {
+	canaryCfg := config.CanaryConfig{Labels: []string{"osoba-canary"}}
+
+	tests := []struct {
+		name       string
+		issue      *gh.Issue
+		latest     []string // ラベルの取得クライアントが返すラベル
+		noStable   bool
+		wantPaused bool // ラベルを遷移しないよう一時停止を返す
+		wantCanary bool
+		wantStable bool
+	}{
+		{
+			name: "カナリアのラベルが付いたIssue",
+			issue: &gh.Issue{Number: intPtr(1), Labels: []*gh.Label{
+				{Name: stringPtr("status:needs-plan")}, {Name: stringPtr("osoba-canary")},
+			}},
+			wantCanary: true,
+		},
+		{
+			name:       "カナリアでないIssueは安定版の設定で実行",
+			issue:      &gh.Issue{Number: intPtr(1), Labels: []*gh.Label{{Name: stringPtr("status:needs-plan")}}},
+			wantStable: true,
+		},
+		{
+			name:       "ラベルを持たないIssueは最新のラベルで判定",
+			issue:      &gh.Issue{Number: intPtr(1)},
+			latest:     []string{"status:requires-changes", "osoba-canary"},
+			wantCanary: true,
+		},
+		{
+			name:       "安定版の設定がない場合はカナリアでないIssueを実行しない",
+			issue:      &gh.Issue{Number: intPtr(1), Labels: []*gh.Label{{Name: stringPtr("status:needs-plan")}}},
+			noStable:   true,
+			wantPaused: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canaryPlan := new(MockActionExecutorExt)
+			stablePlan := new(MockActionExecutorExt)
+			if tt.wantCanary {
+				canaryPlan.On("Execute", mock.Anything, tt.issue).Return(nil).Once()
+			}
+			if tt.wantStable {
+				stablePlan.On("Execute", mock.Anything, tt.issue).Return(nil).Once()
+			}
+
+			var stable ActionFactory
+			if !tt.noStable {
+				stable = &mockActionFactory{planAction: stablePlan}
+			}
+			factory := NewCanaryActionFactory(&mockActionFactory{planAction: canaryPlan}, stable, canaryCfg, NewMockLogger())
+			factory.SetIssueLabelReader(&fakeIssueLabelReader{labels: map[int][]string{1: tt.latest}}, "owner", "repo")
+
+			err := factory.CreatePlanAction().Execute(context.Background(), tt.issue)
+			if tt.wantPaused {
+				assert.ErrorIs(t, err, actions.ErrPhasePaused)
+			} else {
+				require.NoError(t, err)
+			}
+
+			canaryPlan.AssertExpectations(t)
+			stablePlan.AssertExpectations(t)
+			if !tt.wantCanary {
+				canaryPlan.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+			}
+			if !tt.wantStable {
+				stablePlan.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestIssueWatcher_CanarySkipsNonCanaryIssues(t *testing.T) {
+	// 安定版の設定がない場合は、カナリアのラベルが付いたIssueのみを自動化する
+	issue := &gh.Issue{Number: intPtr(10), Labels: []*gh.Label{{Name: stringPtr("status:needs-plan")}}}
+	mockClient := new(MockGitHubClient)
+	mockClient.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:needs-plan"}).
+		Return([]*gh.Issue{issue}, nil)
+
+	cfg := config.NewConfig()
+	cfg.GitHub.AutoMergeLGTM = false
+	cfg.Canary.Labels = []string{"osoba-canary"}
+	actionManager := new(MockActionManager)
+	explainer := NewSkipExplainer()
+	watcher := &IssueWatcher{
+		client:        mockClient,
+		owner:         "owner",
+		repo:          "repo",
+		labels:        []string{"status:needs-plan"},
+		pollInterval:  100 * time.Millisecond,
+		actionManager: actionManager,
+		logger:        NewMockLogger(),
+		config:        cfg,
+		skipExplainer: explainer,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	watcher.StartWithActions(ctx)
+
+	actionManager.AssertNotCalled(t, "ExecuteAction", mock.Anything, mock.Anything)
+	explanations := explainer.Explanations()
+	require.Len(t, explanations, 1)
+	assert.Equal(t, SkipReasonFiltered, explanations[0].Reason)
+	assert.Equal(t, "canary mode: issue does not carry a canary label", explanations[0].Detail)
+}
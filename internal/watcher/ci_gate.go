@@ -0,0 +1,390 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// ciFailuresArtifact は失敗したジョブのログを書き出す成果物のファイル名
+// プロンプトでは{{.Artifact "ci-failures.md"}}で参照できる
+const ciFailuresArtifact = "ci-failures.md"
+
+// CIGate は実装フェーズの後、PRのCIチェックが完了するまでレビューフェーズの開始を保留する
+// 失敗したチェックがある場合は失敗したジョブのログをIssueにコメントし、実装フェーズに戻す
+// 失敗がmax_failures回に達した場合は実装フェーズに戻さず、ラベルを付与して人間に引き継ぐ
+// SetStorePathを指定した場合は待機の開始時刻と失敗の回数をファイルに保存し、監視プロセスの再起動後も引き継ぐ
+type CIGate struct {
+	client github.GitHubClient
+	owner  string
+	repo   string
+	config *config.Config
+	logger logger.Logger
+	// artifactsRoot は成果物ディレクトリ（.git/osoba/artifacts）を置くリポジトリのルート（空の場合はログを書き出さない）
+	artifactsRoot string
+
+	mu    sync.Mutex
+	path  string // 状態の保存先（空の場合は保存しない）
+	state ciGateState
+	clock clock.Clock
+}
+
+// ciGateState はCIGateがファイルに保存する状態
+type ciGateState struct {
+	Waiting  map[int]time.Time `json:"waiting"`  // Issueごとにチェックの完了を待ち始めた時刻
+	Failures map[int]int       `json:"failures"` // IssueごとにCIの失敗で実装フェーズに戻した回数
+}
+
+// NewCIGate は新しいCIGateを作成する
+func NewCIGate(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*CIGate, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if _, ok := client.(github.PullRequestChecksReader); !ok {
+		return nil, errors.New("github client does not support listing pull request checks")
+	}
+
+	return &CIGate{
+		client: client,
+		owner:  owner,
+		repo:   repo,
+		config: cfg,
+		logger: logger,
+		state: ciGateState{
+			Waiting:  make(map[int]time.Time),
+			Failures: make(map[int]int),
+		},
+		clock: clock.New(),
+	}, nil
+}
+
+// SetStorePath は状態の保存先を設定し、保存済みの状態を読み込む
+func (g *CIGate) SetStorePath(path string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state := ciGateState{}
+	if err := loadStoreFile(path, &state); err != nil {
+		return err
+	}
+	if state.Waiting != nil {
+		g.state.Waiting = state.Waiting
+	}
+	if state.Failures != nil {
+		g.state.Failures = state.Failures
+	}
+	g.path = path
+	return nil
+}
+
+// SetArtifactsRoot は失敗したジョブのログを書き出す成果物ディレクトリを置くリポジトリのルートを設定する
+func (g *CIGate) SetArtifactsRoot(root string) {
+	g.artifactsRoot = root
+}
+
+// CheckBeforeReview はレビュー待ちのIssueのPRのCIチェックを確認し、レビューフェーズを開始してよいかを返す
+// チェックが実行中の場合は開始しない理由を返し、失敗した場合はログをコメントして実装フェーズのトリガーラベルに戻す
+// PRがない場合、チェックが登録されないままno_checks_graceを過ぎた場合、待機が上限を超えた場合はレビューフェーズを開始する
+func (g *CIGate) CheckBeforeReview(ctx context.Context, issue *github.Issue) (bool, string, error) {
+	if issue == nil || issue.Number == nil {
+		return true, "", nil
+	}
+	t, ok := findWorkflowTransition(issue)
+	if !ok || t.Phase != config.PhaseReview {
+		return true, "", nil
+	}
+	issueNumber := *issue.Number
+
+	pr, err := g.client.GetPullRequestForIssue(ctx, issueNumber)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get pull request: %w", err)
+	}
+	if pr == nil {
+		g.forget(issueNumber)
+		return true, "", nil
+	}
+
+	checks, err := g.client.(github.PullRequestChecksReader).ListPullRequestChecks(ctx, g.owner, g.repo, pr.Number)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to list checks of PR #%d: %w", pr.Number, err)
+	}
+
+	var pending, failed []github.CheckResult
+	for _, check := range checks {
+		switch check.State {
+		case github.CheckStatePending:
+			pending = append(pending, check)
+		case github.CheckStateFail:
+			failed = append(failed, check)
+		}
+	}
+
+	switch {
+	case len(checks) == 0:
+		// プッシュ直後はチェックが登録されていないことがあるため、すぐには成功とみなさない
+		if !g.waitForChecks(issueNumber, pr.Number) {
+			return false, fmt.Sprintf("waiting for CI checks to be registered on PR #%d", pr.Number), nil
+		}
+		return true, "", nil
+	case len(pending) > 0:
+		return g.wait(issueNumber, pr.Number, pending), fmt.Sprintf("waiting for %d CI check(s) on PR #%d", len(pending), pr.Number), nil
+	case len(failed) > 0:
+		g.forget(issueNumber)
+		if max := g.config.GitHub.CIGate.MaxFailures; max > 0 && g.failureCount(issueNumber) >= max {
+			if err := g.escalate(ctx, issueNumber, t.From, pr.Number, failed); err != nil {
+				return false, "", err
+			}
+			return false, fmt.Sprintf("CI failed on PR #%d again, handed over to a human", pr.Number), nil
+		}
+		if err := g.returnToImplement(ctx, issueNumber, t.From, pr.Number, failed); err != nil {
+			return false, "", err
+		}
+		return false, fmt.Sprintf("CI failed on PR #%d, returned to implement phase", pr.Number), nil
+	}
+
+	g.forget(issueNumber)
+	g.resetFailures(issueNumber)
+	g.removeFailuresArtifact(issueNumber)
+	g.logger.Info("CI checks passed, starting review",
+		"issue_number", issueNumber,
+		"pr_number", pr.Number,
+		"checks", len(checks))
+	return true, "", nil
+}
+
+// waitForChecks はチェックの登録を待ち始めた時刻を記録し、no_checks_graceを過ぎた場合はtrueを返す
+func (g *CIGate) waitForChecks(issueNumber, prNumber int) bool {
+	grace := g.config.GitHub.CIGate.NoChecksGrace
+	if g.clock.Now().Sub(g.waitingSince(issueNumber)) < grace {
+		g.logger.Debug("Waiting for CI checks to be registered",
+			"issue_number", issueNumber,
+			"pr_number", prNumber)
+		return false
+	}
+	g.forget(issueNumber)
+	g.logger.Info("No CI checks registered, starting review",
+		"issue_number", issueNumber,
+		"pr_number", prNumber,
+		"grace", grace)
+	return true
+}
+
+// waitingSince は待ち始めた時刻を返す（記録がない場合は現在時刻を記録する）
+func (g *CIGate) waitingSince(issueNumber int) time.Time {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	since, ok := g.state.Waiting[issueNumber]
+	if !ok {
+		since = g.clock.Now()
+		g.state.Waiting[issueNumber] = since
+		g.saveLocked()
+	}
+	return since
+}
+
+// wait はチェックの完了を待ち始めた時刻を記録し、待機が上限を超えた場合はtrueを返す
+func (g *CIGate) wait(issueNumber, prNumber int, pending []github.CheckResult) bool {
+	now := g.clock.Now()
+	since := g.waitingSince(issueNumber)
+
+	timeout := g.config.GitHub.CIGate.Timeout
+	if timeout > 0 && now.Sub(since) >= timeout {
+		g.logger.Warn("Timed out waiting for CI checks, starting review",
+			"issue_number", issueNumber,
+			"pr_number", prNumber,
+			"pending", checkNames(pending),
+			"timeout", timeout)
+		g.forget(issueNumber)
+		return true
+	}
+	g.logger.Debug("Waiting for CI checks",
+		"issue_number", issueNumber,
+		"pr_number", prNumber,
+		"pending", checkNames(pending))
+	return false
+}
+
+func (g *CIGate) forget(issueNumber int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.state.Waiting[issueNumber]; ok {
+		delete(g.state.Waiting, issueNumber)
+		g.saveLocked()
+	}
+}
+
+// failureCount はCIの失敗で実装フェーズに戻した回数を返す
+func (g *CIGate) failureCount(issueNumber int) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.state.Failures[issueNumber]
+}
+
+func (g *CIGate) resetFailures(issueNumber int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.state.Failures[issueNumber]; ok {
+		delete(g.state.Failures, issueNumber)
+		g.saveLocked()
+	}
+}
+
+// saveLocked は状態を保存先に書き出す（g.muを保持して呼び出す）
+func (g *CIGate) saveLocked() {
+	if err := saveStoreFile(g.path, g.state); err != nil {
+		g.logger.Warn("Failed to save CI gate state", "path", g.path, "error", err)
+	}
+}
+
+// returnToImplement は失敗したジョブのログをコメントし、Issueを実装フェーズのトリガーラベルに戻す
+// コメントの投稿に失敗した場合はラベルを変更せず、次回のポーリングでやり直す
+func (g *CIGate) returnToImplement(ctx context.Context, issueNumber int, reviewLabel string, prNumber int, failed []github.CheckResult) error {
+	implement, ok := workflow.FindByPhase(config.PhaseImplement)
+	if !ok {
+		return errors.New("workflow has no implement phase to return to")
+	}
+
+	failures := g.formatFailures(ctx, failed)
+	g.writeFailuresArtifact(issueNumber, failures)
+
+	body := g.config.RenderComment(config.CommentCIFailed, map[string]string{
+		"issue-number": fmt.Sprintf("%d", issueNumber),
+		"pr-number":    fmt.Sprintf("%d", prNumber),
+		"label":        implement.From,
+		"failures":     failures,
+	})
+	if err := createInformationalComment(ctx, g.client, g.owner, g.repo, issueNumber, body); err != nil {
+		return fmt.Errorf("failed to post CI failure comment: %w", err)
+	}
+	if err := g.client.TransitionLabels(ctx, g.owner, g.repo, issueNumber, reviewLabel, implement.From); err != nil {
+		return fmt.Errorf("failed to transition labels to %s: %w", implement.From, err)
+	}
+
+	g.mu.Lock()
+	g.state.Failures[issueNumber]++
+	g.saveLocked()
+	g.mu.Unlock()
+
+	g.logger.Info("CI checks failed, returned issue to implement phase",
+		"issue_number", issueNumber,
+		"pr_number", prNumber,
+		"failed", checkNames(failed))
+	return nil
+}
+
+// escalate はCIの失敗が上限に達したIssueを実装フェーズに戻さず、ラベルを付与して人間に引き継ぐ
+func (g *CIGate) escalate(ctx context.Context, issueNumber int, reviewLabel string, prNumber int, failed []github.CheckResult) error {
+	label := g.config.GitHub.CIGate.Label
+	if err := g.client.TransitionLabels(ctx, g.owner, g.repo, issueNumber, reviewLabel, label); err != nil {
+		return fmt.Errorf("failed to transition labels to %s: %w", label, err)
+	}
+	count := g.failureCount(issueNumber) + 1
+	g.resetFailures(issueNumber)
+
+	failures := g.formatFailures(ctx, failed)
+	body := g.config.RenderComment(config.CommentCIEscalated, map[string]string{
+		"issue-number": fmt.Sprintf("%d", issueNumber),
+		"pr-number":    fmt.Sprintf("%d", prNumber),
+		"count":        fmt.Sprintf("%d", count),
+		"label":        label,
+		"failures":     failures,
+	})
+	if err := createInformationalComment(ctx, g.client, g.owner, g.repo, issueNumber, body); err != nil {
+		g.logger.Warn("Failed to post CI escalation comment", "issue_number", issueNumber, "error", err)
+	}
+
+	g.logger.Warn("CI failed repeatedly, handed issue over to a human",
+		"issue_number", issueNumber,
+		"pr_number", prNumber,
+		"failures", count,
+		"failed", checkNames(failed))
+	return nil
+}
+
+// formatFailures は失敗したチェックごとに、失敗したステップのログの末尾をMarkdownに整形する
+func (g *CIGate) formatFailures(ctx context.Context, failed []github.CheckResult) string {
+	reader := g.client.(github.PullRequestChecksReader)
+	var b strings.Builder
+	for _, check := range failed {
+		name := check.Name
+		if check.Workflow != "" {
+			name = check.Workflow + " / " + check.Name
+		}
+		fmt.Fprintf(&b, "#### ❌ %s\n\n", name)
+		if check.URL != "" {
+			fmt.Fprintf(&b, "[詳細](%s)\n\n", check.URL)
+		}
+
+		log, err := reader.GetFailedJobLog(ctx, g.owner, g.repo, check)
+		if err != nil {
+			g.logger.Debug("Failed to get CI job log", "check", check.Name, "error", err)
+			b.WriteString("ログを取得できませんでした。詳細のリンクから確認してください。\n\n")
+			continue
+		}
+		fmt.Fprintf(&b, "```\n%s\n```\n\n", tailLines(log, g.config.GitHub.CIGate.MaxLogLines))
+	}
+	return b.String()
+}
+
+// writeFailuresArtifact は実装フェーズのプロンプトから参照できるよう、失敗したジョブのログを成果物ディレクトリに書き出す
+func (g *CIGate) writeFailuresArtifact(issueNumber int, failures string) {
+	if g.artifactsRoot == "" {
+		return
+	}
+	dir, err := git.EnsureArtifactsDirForIssue(g.artifactsRoot, issueNumber)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, ciFailuresArtifact), []byte(failures), 0o644)
+	}
+	if err != nil {
+		g.logger.Warn("Failed to write CI failures artifact", "issue_number", issueNumber, "error", err)
+	}
+}
+
+// removeFailuresArtifact はCIが成功した後に、以前の失敗のログが実装フェーズに渡らないよう削除する
+func (g *CIGate) removeFailuresArtifact(issueNumber int) {
+	if g.artifactsRoot == "" {
+		return
+	}
+	path := filepath.Join(git.ArtifactsDirForIssue(g.artifactsRoot, issueNumber), ciFailuresArtifact)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		g.logger.Warn("Failed to remove CI failures artifact", "issue_number", issueNumber, "error", err)
+	}
+}
+
+// tailLines は文字列の末尾のn行を返す
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// checkNames はチェック名の一覧を返す
+func checkNames(checks []github.CheckResult) []string {
+	names := make([]string, 0, len(checks))
+	for _, c := range checks {
+		names = append(names, c.Name)
+	}
+	return names
+}
@@ -0,0 +1,270 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockChecksClient はPRのCIチェックの取得に対応したGitHubクライアントのモック
+type mockChecksClient struct {
+	MockGitHubClient
+}
+
+func (m *mockChecksClient) ListPullRequestChecks(ctx context.Context, owner, repo string, prNumber int) ([]gh.CheckResult, error) {
+	args := m.Called(ctx, owner, repo, prNumber)
+	return args.Get(0).([]gh.CheckResult), args.Error(1)
+}
+
+func (m *mockChecksClient) GetFailedJobLog(ctx context.Context, owner, repo string, check gh.CheckResult) (string, error) {
+	args := m.Called(ctx, owner, repo, check)
+	return args.String(0), args.Error(1)
+}
+
+func newCIGateTestConfig() *config.Config {
+	cfg := config.NewConfig()
+	cfg.GitHub.CIGate.Timeout = time.Hour
+	cfg.GitHub.CIGate.MaxLogLines = 2
+	return cfg
+}
+
+func TestCIGate_CheckBeforeReview(t *testing.T) {
+	reviewRequested := &gh.Issue{
+		Number: intPtr(30),
+		Labels: []*gh.Label{{Name: stringPtr("status:review-requested")}},
+	}
+	failedTest := gh.CheckResult{Name: "test", Workflow: "CI", State: gh.CheckStateFail, URL: "https://github.com/owner/repo/actions/runs/1/job/11"}
+	failedExternal := gh.CheckResult{Name: "ci/external", State: gh.CheckStateFail, URL: "https://ci.example.com/1"}
+
+	tests := []struct {
+		name        string
+		issue       *gh.Issue
+		pr          *gh.PullRequest
+		checks      []gh.CheckResult
+		wantProceed bool
+		wantDetail  string
+		wantReturn  bool // 失敗をコメントして実装フェーズに戻す
+	}{
+		{
+			name:        "すべてのチェックが成功",
+			issue:       reviewRequested,
+			pr:          &gh.PullRequest{Number: 5},
+			checks:      []gh.CheckResult{{Name: "test", State: gh.CheckStatePass}},
+			wantProceed: true,
+		},
+		{
+			name:        "チェックが実行中",
+			issue:       reviewRequested,
+			pr:          &gh.PullRequest{Number: 5},
+			checks:      []gh.CheckResult{{Name: "test", State: gh.CheckStatePending}, failedExternal},
+			wantProceed: false,
+			wantDetail:  "waiting for 1 CI check(s) on PR #5",
+		},
+		{
+			name:        "チェックが失敗",
+			issue:       reviewRequested,
+			pr:          &gh.PullRequest{Number: 5},
+			checks:      []gh.CheckResult{{Name: "lint", State: gh.CheckStatePass}, failedTest, failedExternal},
+			wantProceed: false,
+			wantDetail:  "CI failed on PR #5, returned to implement phase",
+			wantReturn:  true,
+		},
+		{
+			name:        "チェックが登録されていない",
+			issue:       reviewRequested,
+			pr:          &gh.PullRequest{Number: 5},
+			checks:      []gh.CheckResult{},
+			wantProceed: false,
+			wantDetail:  "waiting for CI checks to be registered on PR #5",
+		},
+		{
+			name:        "PRがない",
+			issue:       reviewRequested,
+			wantProceed: true,
+		},
+		{
+			name: "レビュー待ち以外のIssue",
+			issue: &gh.Issue{
+				Number: intPtr(30),
+				Labels: []*gh.Label{{Name: stringPtr("status:ready")}},
+			},
+			wantProceed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockChecksClient)
+			if hasLabel(tt.issue, "status:review-requested") {
+				if tt.pr != nil {
+					client.On("GetPullRequestForIssue", mock.Anything, 30).Return(tt.pr, nil)
+					client.On("ListPullRequestChecks", mock.Anything, "owner", "repo", 5).Return(tt.checks, nil)
+				} else {
+					client.On("GetPullRequestForIssue", mock.Anything, 30).Return(nil, nil)
+				}
+			}
+			if tt.wantReturn {
+				client.On("GetFailedJobLog", mock.Anything, "owner", "repo", failedTest).Return("line1\nline2\n--- FAIL: TestLogin\n", nil)
+				client.On("GetFailedJobLog", mock.Anything, "owner", "repo", failedExternal).Return("", assert.AnError)
+				client.On("CreateIssueComment", mock.Anything, "owner", "repo", 30, mock.MatchedBy(func(body string) bool {
+					return assert.Contains(t, body, "PR #5") &&
+						assert.Contains(t, body, "`status:ready`") &&
+						assert.Contains(t, body, "#### ❌ CI / test") &&
+						assert.Contains(t, body, "line2\n--- FAIL: TestLogin") &&
+						assert.NotContains(t, body, "line1") &&
+						assert.Contains(t, body, "#### ❌ ci/external")
+				})).Return(nil).Once()
+				client.On("TransitionLabels", mock.Anything, "owner", "repo", 30, "status:review-requested", "status:ready").Return(nil).Once()
+			}
+
+			gate, err := NewCIGate(client, "owner", "repo", newCIGateTestConfig(), NewMockLogger())
+			require.NoError(t, err)
+			root := t.TempDir()
+			gate.SetArtifactsRoot(root)
+
+			proceed, detail, err := gate.CheckBeforeReview(context.Background(), tt.issue)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProceed, proceed)
+			assert.Equal(t, tt.wantDetail, detail)
+			client.AssertExpectations(t)
+
+			_, statErr := os.Stat(filepath.Join(git.ArtifactsDirForIssue(root, 30), ciFailuresArtifact))
+			assert.Equal(t, tt.wantReturn, statErr == nil, "ci-failures.md")
+		})
+	}
+}
+
+func TestCIGate_TimesOutWaitingForChecks(t *testing.T) {
+	client := new(mockChecksClient)
+	issue := &gh.Issue{
+		Number: intPtr(30),
+		Labels: []*gh.Label{{Name: stringPtr("status:review-requested")}},
+	}
+	client.On("GetPullRequestForIssue", mock.Anything, 30).Return(&gh.PullRequest{Number: 5}, nil)
+	client.On("ListPullRequestChecks", mock.Anything, "owner", "repo", 5).Return([]gh.CheckResult{{Name: "test", State: gh.CheckStatePending}}, nil)
+
+	gate, err := NewCIGate(client, "owner", "repo", newCIGateTestConfig(), NewMockLogger())
+	require.NoError(t, err)
+	fakeClock := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	gate.clock = fakeClock
+
+	proceed, _, err := gate.CheckBeforeReview(context.Background(), issue)
+	require.NoError(t, err)
+	assert.False(t, proceed)
+
+	fakeClock.Advance(59 * time.Minute)
+	proceed, _, err = gate.CheckBeforeReview(context.Background(), issue)
+	require.NoError(t, err)
+	assert.False(t, proceed)
+
+	// 上限を超えた場合はCIの完了を待たずにレビューフェーズを開始する
+	fakeClock.Advance(time.Minute)
+	proceed, _, err = gate.CheckBeforeReview(context.Background(), issue)
+	require.NoError(t, err)
+	assert.True(t, proceed)
+}
+
+func TestCIGate_WaitsForChecksToBeRegistered(t *testing.T) {
+	client := new(mockChecksClient)
+	issue := &gh.Issue{
+		Number: intPtr(30),
+		Labels: []*gh.Label{{Name: stringPtr("status:review-requested")}},
+	}
+	client.On("GetPullRequestForIssue", mock.Anything, 30).Return(&gh.PullRequest{Number: 5}, nil)
+	client.On("ListPullRequestChecks", mock.Anything, "owner", "repo", 5).Return([]gh.CheckResult{}, nil)
+	storePath := filepath.Join(t.TempDir(), "ci-gate.json")
+	fakeClock := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	gate, err := NewCIGate(client, "owner", "repo", newCIGateTestConfig(), NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, gate.SetStorePath(storePath))
+	gate.clock = fakeClock
+	proceed, _, err := gate.CheckBeforeReview(context.Background(), issue)
+	require.NoError(t, err)
+	assert.False(t, proceed)
+
+	// 再起動後も待ち始めた時刻を引き継ぎ、no_checks_graceを過ぎるとレビューフェーズを開始する
+	fakeClock.Advance(5 * time.Minute)
+	restarted, err := NewCIGate(client, "owner", "repo", newCIGateTestConfig(), NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, restarted.SetStorePath(storePath))
+	restarted.clock = fakeClock
+	proceed, _, err = restarted.CheckBeforeReview(context.Background(), issue)
+	require.NoError(t, err)
+	assert.True(t, proceed)
+}
+
+func TestCIGate_EscalatesAfterMaxFailures(t *testing.T) {
+	client := new(mockChecksClient)
+	issue := &gh.Issue{
+		Number: intPtr(30),
+		Labels: []*gh.Label{{Name: stringPtr("status:review-requested")}},
+	}
+	failed := gh.CheckResult{Name: "test", State: gh.CheckStateFail}
+	client.On("GetPullRequestForIssue", mock.Anything, 30).Return(&gh.PullRequest{Number: 5}, nil)
+	client.On("ListPullRequestChecks", mock.Anything, "owner", "repo", 5).Return([]gh.CheckResult{failed}, nil)
+	client.On("GetFailedJobLog", mock.Anything, "owner", "repo", failed).Return("--- FAIL: TestLogin\n", nil)
+	client.On("CreateIssueComment", mock.Anything, "owner", "repo", 30, mock.MatchedBy(func(body string) bool {
+		return strings.Contains(body, "実装フェーズに戻します")
+	})).Return(nil).Once()
+	client.On("TransitionLabels", mock.Anything, "owner", "repo", 30, "status:review-requested", "status:ready").Return(nil).Once()
+	client.On("TransitionLabels", mock.Anything, "owner", "repo", 30, "status:review-requested", "status:needs-human").Return(nil).Once()
+	client.On("CreateIssueComment", mock.Anything, "owner", "repo", 30, mock.MatchedBy(func(body string) bool {
+		return strings.Contains(body, "2 回続けて失敗") && strings.Contains(body, "`status:needs-human`")
+	})).Return(nil).Once()
+
+	cfg := newCIGateTestConfig()
+	cfg.GitHub.CIGate.MaxFailures = 1
+	storePath := filepath.Join(t.TempDir(), "ci-gate.json")
+	gate, err := NewCIGate(client, "owner", "repo", cfg, NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, gate.SetStorePath(storePath))
+
+	_, detail, err := gate.CheckBeforeReview(context.Background(), issue)
+	require.NoError(t, err)
+	assert.Equal(t, "CI failed on PR #5, returned to implement phase", detail)
+
+	// 失敗の回数は再起動後も引き継ぐ
+	restarted, err := NewCIGate(client, "owner", "repo", cfg, NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, restarted.SetStorePath(storePath))
+	_, detail, err = restarted.CheckBeforeReview(context.Background(), issue)
+	require.NoError(t, err)
+	assert.Equal(t, "CI failed on PR #5 again, handed over to a human", detail)
+	client.AssertExpectations(t)
+	assert.Zero(t, restarted.failureCount(30))
+}
+
+func TestCIGate_RemovesStaleFailuresAfterSuccess(t *testing.T) {
+	client := new(mockChecksClient)
+	issue := &gh.Issue{
+		Number: intPtr(30),
+		Labels: []*gh.Label{{Name: stringPtr("status:review-requested")}},
+	}
+	client.On("GetPullRequestForIssue", mock.Anything, 30).Return(&gh.PullRequest{Number: 5}, nil)
+	client.On("ListPullRequestChecks", mock.Anything, "owner", "repo", 5).Return([]gh.CheckResult{{Name: "test", State: gh.CheckStatePass}}, nil)
+
+	gate, err := NewCIGate(client, "owner", "repo", newCIGateTestConfig(), NewMockLogger())
+	require.NoError(t, err)
+	root := t.TempDir()
+	gate.SetArtifactsRoot(root)
+	dir, err := git.EnsureArtifactsDirForIssue(root, 30)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ciFailuresArtifact), []byte("old failure"), 0o644))
+
+	proceed, _, err := gate.CheckBeforeReview(context.Background(), issue)
+	require.NoError(t, err)
+	assert.True(t, proceed)
+	assert.NoFileExists(t, filepath.Join(dir, ciFailuresArtifact))
+}
@@ -92,6 +92,9 @@ func (w *CleanupWatcher) performCleanup(ctx context.Context) {
 	// 閉じられたIssueを取得
 	closedIssues, err := w.client.ListClosedIssues(ctx, w.owner, w.repo)
 	if err != nil {
+		if w.logger != nil && logIfThrottled(w.logger, err, "list_closed_issues") {
+			return
+		}
 		if w.logger != nil {
 			w.logger.Error("Failed to list closed issues",
 				"error", err,
@@ -35,6 +35,10 @@ func (m *MockCleanupManagerForWatcher) CleanupIssueResources(ctx context.Context
 	return args.Error(0)
 }
 
+func (m *MockCleanupManagerForWatcher) Plan(ctx context.Context, issueNumber int) (*cleanup.Plan, error) {
+	return &cleanup.Plan{IssueNumber: issueNumber}, nil
+}
+
 func TestCleanupWatcher_NewCleanupWatcher(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -135,7 +135,7 @@ github:
 func TestConfigFromEnv(t *testing.T) {
 	// ghコマンドのモック
 	originalGhAuthTokenFunc := config.GhAuthTokenFunc
-	config.GhAuthTokenFunc = func() (string, error) {
+	config.GhAuthTokenFunc = func(string) (string, error) {
 		return "", fmt.Errorf("gh auth token not available")
 	}
 	defer func() {
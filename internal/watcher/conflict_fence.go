@@ -0,0 +1,197 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// ConflictFence は同じ領域（conflict_fences.areas）に触れるIssueの実装フェーズを同時に実行しない
+// 実装中のIssueは実行中ラベル（status:implementing）から特定し、順番を待つIssueにはラベルを付与する
+// 順番を待つIssueのトリガーラベルは変更しないため、実装中のIssueの実装フェーズが終わった後のポーリングで開始される
+// nilの場合はすべてのIssueの実装フェーズを開始する
+type ConflictFence struct {
+	client github.GitHubClient
+	owner  string
+	repo   string
+	config config.ConflictFencesConfig
+	logger logger.Logger
+	clock  clock.Clock
+
+	mu       sync.Mutex
+	launches map[int]phaseLaunch // 最近開始を許可した実装フェーズ（Issue番号ごと）
+	areas    map[int][]string    // 最近開始を許可したIssueが触れる領域
+}
+
+// NewConflictFence は新しいConflictFenceを作成する
+func NewConflictFence(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*ConflictFence, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return &ConflictFence{
+		client:   client,
+		owner:    owner,
+		repo:     repo,
+		config:   cfg.ConflictFences,
+		logger:   logger.WithFields("component", "conflict_fence"),
+		clock:    clock.New(),
+		launches: make(map[int]phaseLaunch),
+		areas:    make(map[int][]string),
+	}, nil
+}
+
+// AllowImplement はIssueの実装フェーズを開始してよいかを返す（開始を待たせる場合は理由を返す）
+// 同じ領域を実装中のIssueがある場合は順番を待つラベルを付与し、開始してよい場合はラベルを外して実装中として記録する
+// 実装中のIssueを取得できない場合は開始を妨げない
+func (f *ConflictFence) AllowImplement(ctx context.Context, issue *github.Issue) (bool, string) {
+	if f == nil || issue == nil || issue.Number == nil {
+		return true, ""
+	}
+	number := *issue.Number
+
+	areas := f.issueAreas(issue)
+	if len(areas) == 0 {
+		f.releaseLabel(ctx, issue)
+		return true, ""
+	}
+
+	running, err := f.client.ListIssuesByLabels(ctx, f.owner, f.repo, executingLabels(config.PhaseImplement))
+	if err != nil {
+		f.logger.Warn("Failed to list implementing issues, launching without checking conflicts",
+			"issueNumber", number,
+			"error", err)
+		return true, ""
+	}
+
+	f.mu.Lock()
+	conflicts := make(map[string]int)
+	for _, other := range running {
+		if other == nil || other.Number == nil || *other.Number == number {
+			continue
+		}
+		for _, area := range f.issueAreas(other) {
+			conflicts[area] = *other.Number
+		}
+	}
+	// 開始直後の実装フェーズは実行中ラベルが一覧に反映されていないことがあるため、記録から補う
+	now := f.clock.Now()
+	for other, launch := range f.launches {
+		if now.Sub(launch.at) > launchGracePeriod {
+			delete(f.launches, other)
+			delete(f.areas, other)
+			continue
+		}
+		if other == number {
+			continue
+		}
+		for _, area := range f.areas[other] {
+			conflicts[area] = other
+		}
+	}
+
+	for _, area := range areas {
+		if other, ok := conflicts[area]; ok {
+			f.mu.Unlock()
+			reason := fmt.Sprintf("area %q is being implemented by #%d", area, other)
+			f.logger.Info("Deferring implementation: conflicting area in progress",
+				"issueNumber", number,
+				"area", area,
+				"conflictingIssue", other)
+			f.holdLabel(ctx, issue)
+			return false, reason
+		}
+	}
+
+	f.launches[number] = phaseLaunch{phase: config.PhaseImplement, at: now}
+	f.areas[number] = areas
+	f.mu.Unlock()
+
+	f.releaseLabel(ctx, issue)
+	return true, ""
+}
+
+// Forget はIssueの実装フェーズの開始の記録を破棄する（nilの場合は何もしない）
+func (f *ConflictFence) Forget(issueNumber int) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.launches, issueNumber)
+	delete(f.areas, issueNumber)
+}
+
+// issueAreas はIssueが触れる領域の名前を返す
+func (f *ConflictFence) issueAreas(issue *github.Issue) []string {
+	body := safeString(issue.Body)
+	var areas []string
+	for _, area := range f.config.Areas {
+		if touchesArea(issue, body, area) {
+			areas = append(areas, area.Name)
+		}
+	}
+	sort.Strings(areas)
+	return areas
+}
+
+// touchesArea はIssueが領域に触れるか（領域のラベルが付いているか、本文に領域のパスが含まれるか）を返す
+func touchesArea(issue *github.Issue, body string, area config.ConflictArea) bool {
+	for _, label := range area.Labels {
+		if hasLabel(issue, label) {
+			return true
+		}
+	}
+	for _, path := range area.Paths {
+		if path != "" && strings.Contains(body, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// holdLabel は順番を待つIssueにラベルを付与する（付与済みの場合は何もしない）
+func (f *ConflictFence) holdLabel(ctx context.Context, issue *github.Issue) {
+	if hasLabel(issue, f.config.Label) {
+		return
+	}
+	if err := f.client.AddLabel(ctx, f.owner, f.repo, *issue.Number, f.config.Label); err != nil {
+		f.logger.Warn("Failed to add conflict label",
+			"issueNumber", *issue.Number,
+			"label", f.config.Label,
+			"error", err)
+	}
+}
+
+// releaseLabel は順番を待っていたIssueのラベルを外す（付与されていない場合は何もしない）
+func (f *ConflictFence) releaseLabel(ctx context.Context, issue *github.Issue) {
+	if !hasLabel(issue, f.config.Label) {
+		return
+	}
+	if err := f.client.RemoveLabel(ctx, f.owner, f.repo, *issue.Number, f.config.Label); err != nil {
+		f.logger.Warn("Failed to remove conflict label",
+			"issueNumber", *issue.Number,
+			"label", f.config.Label,
+			"error", err)
+	}
+}
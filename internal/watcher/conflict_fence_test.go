@@ -0,0 +1,147 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var conflictTestAreas = []config.ConflictArea{
+	{Name: "database", Paths: []string{"db/migrations/"}},
+	{Name: "billing", Labels: []string{"area:billing"}},
+}
+
+func newConflictFenceForTest(t *testing.T, client *MockGitHubClient) (*ConflictFence, *clock.Fake) {
+	t.Helper()
+	cfg := config.NewConfig()
+	cfg.ConflictFences.Areas = conflictTestAreas
+	fence, err := NewConflictFence(client, "owner", "repo", cfg, NewMockLogger())
+	require.NoError(t, err)
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	fence.clock = fake
+	return fence, fake
+}
+
+func conflictIssue(number int, body string, labels ...string) *gh.Issue {
+	issue := &gh.Issue{Number: gh.Int(number), Body: gh.String(body)}
+	for _, label := range labels {
+		issue.Labels = append(issue.Labels, &gh.Label{Name: gh.String(label)})
+	}
+	return issue
+}
+
+func TestConflictFence_AllowImplement(t *testing.T) {
+	tests := []struct {
+		name        string
+		issue       *gh.Issue
+		running     []*gh.Issue
+		listErr     error
+		want        bool
+		wantAdd     bool
+		wantRemove  bool
+		skipListing bool
+	}{
+		{
+			name:    "同じパスの領域を実装中",
+			issue:   conflictIssue(10, "Add column in db/migrations/002.sql", "status:ready"),
+			running: []*gh.Issue{conflictIssue(1, "Touches db/migrations/001.sql", "status:implementing")},
+			want:    false,
+			wantAdd: true,
+		},
+		{
+			name:    "同じラベルの領域を実装中で、順番待ちのラベルは付与済み",
+			issue:   conflictIssue(10, "", "status:ready", "area:billing", config.DefaultConflictLabel),
+			running: []*gh.Issue{conflictIssue(1, "", "status:implementing", "area:billing")},
+			want:    false,
+		},
+		{
+			name:    "別の領域を実装中",
+			issue:   conflictIssue(10, "db/migrations/", "status:ready"),
+			running: []*gh.Issue{conflictIssue(1, "", "status:implementing", "area:billing")},
+			want:    true,
+		},
+		{
+			name:       "競合がなくなったら順番待ちのラベルを外す",
+			issue:      conflictIssue(10, "db/migrations/", "status:ready", config.DefaultConflictLabel),
+			running:    []*gh.Issue{},
+			want:       true,
+			wantRemove: true,
+		},
+		{
+			name:        "領域に触れないIssue",
+			issue:       conflictIssue(10, "Fix typo in README", "status:ready"),
+			want:        true,
+			skipListing: true,
+		},
+		{
+			name:    "実装中のIssueを取得できない",
+			issue:   conflictIssue(10, "db/migrations/", "status:ready"),
+			listErr: errors.New("api error"),
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(MockGitHubClient)
+			if !tt.skipListing {
+				client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:implementing"}).
+					Return(tt.running, tt.listErr).Once()
+			}
+			if tt.wantAdd {
+				client.On("AddLabel", mock.Anything, "owner", "repo", 10, config.DefaultConflictLabel).Return(nil).Once()
+			}
+			if tt.wantRemove {
+				client.On("RemoveLabel", mock.Anything, "owner", "repo", 10, config.DefaultConflictLabel).Return(nil).Once()
+			}
+			fence, _ := newConflictFenceForTest(t, client)
+
+			allowed, reason := fence.AllowImplement(context.Background(), tt.issue)
+			assert.Equal(t, tt.want, allowed)
+			if !tt.want {
+				assert.Contains(t, reason, "#1")
+			}
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConflictFence_CountsRecentLaunches(t *testing.T) {
+	client := new(MockGitHubClient)
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:implementing"}).
+		Return([]*gh.Issue{}, nil)
+	client.On("AddLabel", mock.Anything, "owner", "repo", 2, config.DefaultConflictLabel).Return(nil)
+	fence, fake := newConflictFenceForTest(t, client)
+
+	// 実行中ラベルが一覧に反映される前でも、開始した実装フェーズの領域は実装中として扱う
+	allowed, _ := fence.AllowImplement(context.Background(), conflictIssue(1, "db/migrations/001.sql"))
+	assert.True(t, allowed)
+	allowed, _ = fence.AllowImplement(context.Background(), conflictIssue(2, "db/migrations/002.sql"))
+	assert.False(t, allowed)
+
+	// 開始しなかったフェーズの記録は破棄できる
+	fence.Forget(1)
+	allowed, _ = fence.AllowImplement(context.Background(), conflictIssue(3, "db/migrations/003.sql"))
+	assert.True(t, allowed)
+
+	// 猶予を過ぎた記録は数えない
+	fake.Advance(launchGracePeriod + time.Second)
+	allowed, _ = fence.AllowImplement(context.Background(), conflictIssue(2, "db/migrations/002.sql"))
+	assert.True(t, allowed)
+}
+
+func TestConflictFence_NilAllowsAll(t *testing.T) {
+	var fence *ConflictFence
+	allowed, reason := fence.AllowImplement(context.Background(), conflictIssue(1, "db/migrations/"))
+	assert.True(t, allowed)
+	assert.Empty(t, reason)
+	fence.Forget(1)
+}
@@ -0,0 +1,177 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/notify"
+)
+
+// 監視の失敗を数える対象
+const (
+	DegradationSourceIssues = "issues" // Issueの一覧の取得（gh）
+	DegradationSourcePRs    = "prs"    // PRの一覧の取得（gh）
+	DegradationSourcePhases = "phases" // フェーズの開始（tmux・worktree・gh）
+)
+
+// DegradedSource は失敗が続いている対象
+type DegradedSource struct {
+	Source    string `json:"source"`
+	Failures  int    `json:"failures"` // 連続した失敗の回数
+	LastError string `json:"last_error"`
+}
+
+// DegradationStatus は監視が不健全な状態
+type DegradationStatus struct {
+	Since   time.Time        `json:"since"`
+	Sources []DegradedSource `json:"sources"`
+}
+
+// Summary は失敗が続いている対象の1行の要約を返す
+func (s *DegradationStatus) Summary() string {
+	parts := make([]string, 0, len(s.Sources))
+	for _, source := range s.Sources {
+		parts = append(parts, fmt.Sprintf("%s: %d回連続で失敗", source.Source, source.Failures))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// degradationSource は対象ごとの連続した失敗
+type degradationSource struct {
+	failures  int
+	lastError string
+}
+
+// DegradationMonitor は監視のポーリング・フェーズの開始の連続した失敗を数え、しきい値に達した場合に不健全な状態にする
+// 不健全になった時点で失敗が続いている対象をまとめて1回だけ通知し、すべての対象が回復した時点で健全に戻す
+type DegradationMonitor struct {
+	threshold int
+	notifier  notify.Notifier
+	logger    logger.Logger
+	clock     clock.Clock
+
+	mu      sync.Mutex
+	sources map[string]*degradationSource
+	since   time.Time // 不健全になった時刻（健全な場合はゼロ値）
+}
+
+// NewDegradationMonitor は新しいDegradationMonitorを作成する
+func NewDegradationMonitor(cfg *config.Config, logger logger.Logger) (*DegradationMonitor, error) {
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if logger == nil {
+		return nil, errors.New("logger is required")
+	}
+	threshold := cfg.Degradation.Threshold
+	if threshold <= 0 {
+		threshold = config.DefaultDegradationThreshold
+	}
+	return &DegradationMonitor{
+		threshold: threshold,
+		logger:    logger,
+		clock:     clock.New(),
+		sources:   make(map[string]*degradationSource),
+	}, nil
+}
+
+// SetNotifier は不健全になった時の通知先を設定する
+func (m *DegradationMonitor) SetNotifier(notifier notify.Notifier, repository string) {
+	m.notifier = notify.WithRepository(notifier, repository)
+}
+
+// RecordSuccess は対象の成功を記録する（nilの場合は何もしない）
+func (m *DegradationMonitor) RecordSuccess(source string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sources, source)
+	if m.since.IsZero() {
+		return
+	}
+	for _, s := range m.sources {
+		if s.failures >= m.threshold {
+			return
+		}
+	}
+	m.logger.Info("Watcher recovered from degraded state",
+		"source", source,
+		"degraded_for", m.clock.Since(m.since).Truncate(time.Second))
+	m.since = time.Time{}
+}
+
+// RecordFailure は対象の失敗を記録し、連続した失敗がしきい値に達した場合は不健全な状態にして通知する（nilの場合は何もしない）
+func (m *DegradationMonitor) RecordFailure(ctx context.Context, source string, err error) {
+	if m == nil || err == nil {
+		return
+	}
+	m.mu.Lock()
+	s, ok := m.sources[source]
+	if !ok {
+		s = &degradationSource{}
+		m.sources[source] = s
+	}
+	s.failures++
+	s.lastError = err.Error()
+	if s.failures < m.threshold || !m.since.IsZero() {
+		m.mu.Unlock()
+		return
+	}
+	m.since = m.clock.Now()
+	status := m.statusLocked()
+	m.mu.Unlock()
+
+	m.logger.Error("Watcher degraded: consecutive failures reached threshold",
+		"source", source,
+		"failures", s.failures,
+		"threshold", m.threshold,
+		"error", err)
+	if m.notifier == nil {
+		return
+	}
+	details := make([]string, 0, len(status.Sources))
+	for _, ds := range status.Sources {
+		details = append(details, fmt.Sprintf("- %s: %d回連続で失敗（最後のエラー: %s）", ds.Source, ds.Failures, ds.LastError))
+	}
+	if notifyErr := m.notifier.Notify(ctx, notify.Event{
+		Kind:   config.NotifyDegraded,
+		Detail: strings.Join(details, "\n"),
+		Time:   status.Since,
+	}); notifyErr != nil {
+		m.logger.Warn("Failed to send notification", "kind", config.NotifyDegraded, "error", notifyErr)
+	}
+}
+
+// Status は不健全な状態を返す（健全な場合やnilの場合はnil）
+func (m *DegradationMonitor) Status() *DegradationStatus {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.since.IsZero() {
+		return nil
+	}
+	return m.statusLocked()
+}
+
+// statusLocked は失敗が続いている対象を名前順に返す（m.muを保持して呼び出す）
+func (m *DegradationMonitor) statusLocked() *DegradationStatus {
+	status := &DegradationStatus{Since: m.since}
+	for name, s := range m.sources {
+		status.Sources = append(status.Sources, DegradedSource{Source: name, Failures: s.failures, LastError: s.lastError})
+	}
+	sort.Slice(status.Sources, func(i, j int) bool { return status.Sources[i].Source < status.Sources[j].Source })
+	return status
+}
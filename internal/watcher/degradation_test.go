@@ -0,0 +1,80 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDegradationMonitor(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.NewConfig()
+	cfg.Degradation.Threshold = 3
+	monitor, err := NewDegradationMonitor(cfg, NewMockLogger())
+	require.NoError(t, err)
+	monitor.clock = clock.NewFake(start)
+	notifier := &recordingNotifier{}
+	monitor.SetNotifier(notifier, "owner/repo")
+	ctx := context.Background()
+	ghErr := errors.New("gh: error connecting to api.github.com")
+
+	// しきい値に達するまでは健全
+	monitor.RecordFailure(ctx, DegradationSourceIssues, ghErr)
+	monitor.RecordFailure(ctx, DegradationSourceIssues, ghErr)
+	assert.Nil(t, monitor.Status())
+
+	// 成功すると連続した失敗の回数を数え直す
+	monitor.RecordSuccess(DegradationSourceIssues)
+	monitor.RecordFailure(ctx, DegradationSourceIssues, ghErr)
+	monitor.RecordFailure(ctx, DegradationSourceIssues, ghErr)
+	assert.Nil(t, monitor.Status())
+
+	monitor.RecordFailure(ctx, DegradationSourcePRs, ghErr)
+	monitor.RecordFailure(ctx, DegradationSourceIssues, ghErr)
+	status := monitor.Status()
+	require.NotNil(t, status)
+	assert.Equal(t, start, status.Since)
+	assert.Equal(t, []DegradedSource{
+		{Source: DegradationSourceIssues, Failures: 3, LastError: ghErr.Error()},
+		{Source: DegradationSourcePRs, Failures: 1, LastError: ghErr.Error()},
+	}, status.Sources)
+	require.Len(t, notifier.events, 1)
+	assert.Equal(t, config.NotifyDegraded, notifier.events[0].Kind)
+	assert.Equal(t, "owner/repo", notifier.events[0].Repository)
+	assert.Contains(t, notifier.events[0].Detail, "issues: 3回連続で失敗")
+
+	t.Run("不健全な間は失敗が続いても再び通知しない", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			monitor.RecordFailure(ctx, DegradationSourcePRs, ghErr)
+		}
+		assert.Len(t, notifier.events, 1)
+	})
+
+	t.Run("しきい値に達した対象がすべて回復すると健全に戻る", func(t *testing.T) {
+		monitor.RecordSuccess(DegradationSourceIssues)
+		assert.NotNil(t, monitor.Status(), "prs is still failing")
+		monitor.RecordSuccess(DegradationSourcePRs)
+		assert.Nil(t, monitor.Status())
+	})
+
+	t.Run("再び不健全になると通知する", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			monitor.RecordFailure(ctx, DegradationSourcePhases, errors.New("tmux: no server running"))
+		}
+		require.NotNil(t, monitor.Status())
+		assert.Len(t, notifier.events, 2)
+	})
+}
+
+func TestDegradationMonitor_Nil(t *testing.T) {
+	var monitor *DegradationMonitor
+	monitor.RecordFailure(context.Background(), DegradationSourceIssues, errors.New("failed"))
+	monitor.RecordSuccess(DegradationSourceIssues)
+	assert.Nil(t, monitor.Status())
+}
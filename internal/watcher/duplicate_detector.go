@@ -0,0 +1,193 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+const (
+	// possibleDuplicateCommentMarker は重複の可能性の通知コメントを識別するためのマーカー
+	possibleDuplicateCommentMarker = "<!-- osoba:possible-duplicate -->"
+	// possibleDuplicateLabel は重複の可能性があるIssueに付与するラベル
+	possibleDuplicateLabel = "status:possible-duplicate"
+)
+
+// duplicateCandidate は重複の可能性がある既存Issue
+type duplicateCandidate struct {
+	issue *github.IssueSearchResult
+	score float64
+}
+
+// DuplicateDetector は計画フェーズの開始前に既存Issueとの重複を検出する
+type DuplicateDetector struct {
+	client github.GitHubClient
+	owner  string
+	repo   string
+	config *config.Config
+	logger logger.Logger
+}
+
+// NewDuplicateDetector は新しいDuplicateDetectorを作成する
+func NewDuplicateDetector(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*DuplicateDetector, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if _, ok := client.(github.IssueCommentEditor); !ok {
+		return nil, errors.New("github client does not support editing issue comments")
+	}
+	if _, ok := client.(github.IssueSearcher); !ok {
+		return nil, errors.New("github client does not support searching issues")
+	}
+
+	return &DuplicateDetector{
+		client: client,
+		owner:  owner,
+		repo:   repo,
+		config: cfg,
+		logger: logger,
+	}, nil
+}
+
+// CheckBeforePlan は計画待ちのIssueが既存Issueと重複していないかを確認する
+// 重複の可能性がある場合はコメントとラベルを付与してtrueを返し、呼び出し側は計画フェーズを開始しない
+func (d *DuplicateDetector) CheckBeforePlan(ctx context.Context, issue *github.Issue) (bool, error) {
+	if issue == nil || issue.Number == nil || issue.Title == nil || !hasLabel(issue, d.config.GitHub.Labels.Plan) {
+		return false, nil
+	}
+	number := *issue.Number
+
+	comments, err := d.client.(github.IssueCommentEditor).ListIssueComments(ctx, d.owner, d.repo, number)
+	if err != nil {
+		return false, fmt.Errorf("failed to list comments: %w", err)
+	}
+	for _, c := range comments {
+		if c.Body != nil && strings.HasPrefix(*c.Body, possibleDuplicateCommentMarker) {
+			// 通知済みのIssueに計画ラベルが付け直された場合は重複ではないと判断されたものとする
+			return false, nil
+		}
+	}
+
+	results, err := d.client.(github.IssueSearcher).SearchIssues(ctx, d.owner, d.repo, *issue.Title, d.config.GitHub.DuplicateDetection.MaxResults)
+	if err != nil {
+		return false, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	body := ""
+	if issue.Body != nil {
+		body = *issue.Body
+	}
+	candidates := findDuplicateCandidates(number, *issue.Title, body, results, d.config.GitHub.DuplicateDetection.Threshold)
+	if len(candidates) == 0 {
+		return false, nil
+	}
+
+	if err := d.client.CreateIssueComment(ctx, d.owner, d.repo, number, buildPossibleDuplicateComment(d.config, number, candidates)); err != nil {
+		return false, fmt.Errorf("failed to post possible duplicate comment: %w", err)
+	}
+	if err := d.client.TransitionLabels(ctx, d.owner, d.repo, number, d.config.GitHub.Labels.Plan, possibleDuplicateLabel); err != nil {
+		return true, fmt.Errorf("failed to label possible duplicate: %w", err)
+	}
+
+	d.logger.Info("Flagged issue as possible duplicate",
+		"issue_number", number,
+		"duplicate_of", candidates[0].issue.Number,
+		"score", candidates[0].score)
+	return true, nil
+}
+
+// findDuplicateCandidates は類似度がしきい値以上の既存Issueを類似度の高い順に返す
+func findDuplicateCandidates(number int, title, body string, results []*github.IssueSearchResult, threshold float64) []duplicateCandidate {
+	var candidates []duplicateCandidate
+	for _, r := range results {
+		if r == nil || r.Number == number {
+			continue
+		}
+		score := issueSimilarity(title, body, r.Title, r.Body)
+		if score >= threshold {
+			candidates = append(candidates, duplicateCandidate{issue: r, score: score})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+	return candidates
+}
+
+// issueSimilarity はタイトルと本文の類似度（0〜1）を返す
+// 本文はテンプレートの定型文で似やすいため、両方に本文がある場合のみ3割の重みで加味する
+func issueSimilarity(titleA, bodyA, titleB, bodyB string) float64 {
+	score := bigramSimilarity(titleA, titleB)
+	if strings.TrimSpace(bodyA) != "" && strings.TrimSpace(bodyB) != "" {
+		score = score*0.7 + bigramSimilarity(bodyA, bodyB)*0.3
+	}
+	return score
+}
+
+// bigramSimilarity は文字bigramのDice係数を返す（空白・記号と大文字小文字の違いは無視する）
+// 日本語のように単語を空白で区切らない文章でも比較できるよう、単語ではなく文字単位で比較する
+func bigramSimilarity(a, b string) float64 {
+	ba, bb := bigrams(a), bigrams(b)
+	total := 0
+	for _, n := range ba {
+		total += n
+	}
+	for _, n := range bb {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+
+	common := 0
+	for g, n := range ba {
+		common += min(n, bb[g])
+	}
+	return float64(2*common) / float64(total)
+}
+
+func bigrams(s string) map[string]int {
+	var runes []rune
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			runes = append(runes, r)
+		}
+	}
+	grams := make(map[string]int)
+	for i := 0; i+1 < len(runes); i++ {
+		grams[string(runes[i:i+2])]++
+	}
+	return grams
+}
+
+// buildPossibleDuplicateComment は重複の可能性の通知コメントを生成する
+func buildPossibleDuplicateComment(cfg *config.Config, number int, candidates []duplicateCandidate) string {
+	var list strings.Builder
+	for _, c := range candidates {
+		fmt.Fprintf(&list, "- #%d %s（%s、類似度 %.0f%%）\n", c.issue.Number, c.issue.Title, strings.ToLower(c.issue.State), c.score*100)
+	}
+
+	return possibleDuplicateCommentMarker + "\n" + cfg.RenderComment(config.CommentPossibleDuplicate, map[string]string{
+		"issue-number": strconv.Itoa(number),
+		"label":        possibleDuplicateLabel,
+		"plan-label":   cfg.GitHub.Labels.Plan,
+		"duplicates":   list.String(),
+	})
+}
@@ -0,0 +1,125 @@
+package watcher
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIssueSearchClient はIssue検索とコメント編集に対応したGitHubクライアントのモック
+type mockIssueSearchClient struct {
+	mockCommentEditorClient
+}
+
+func (m *mockIssueSearchClient) SearchIssues(ctx context.Context, owner, repo, query string, limit int) ([]*gh.IssueSearchResult, error) {
+	args := m.Called(ctx, owner, repo, query, limit)
+	return args.Get(0).([]*gh.IssueSearchResult), args.Error(1)
+}
+
+func TestBigramSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		min  float64
+		max  float64
+	}{
+		{name: "同一の文字列", a: "ログイン画面を追加", b: "ログイン画面を追加", min: 1, max: 1},
+		{name: "記号と大文字小文字の違いは無視", a: "Add login page", b: "add: LOGIN-page!", min: 1, max: 1},
+		{name: "語順が近い日本語", a: "ログイン画面を追加する", b: "ログイン画面の追加", min: 0.6, max: 0.9},
+		{name: "無関係な文字列", a: "ログイン画面を追加", b: "READMEのtypo修正", min: 0, max: 0.1},
+		{name: "空文字列", a: "", b: "ログイン", min: 0, max: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bigramSimilarity(tt.a, tt.b)
+			assert.GreaterOrEqual(t, got, tt.min)
+			assert.LessOrEqual(t, got, tt.max)
+		})
+	}
+}
+
+func TestDuplicateDetector_CheckBeforePlan(t *testing.T) {
+	planIssue := &gh.Issue{
+		Number: intPtr(30),
+		Title:  stringPtr("ログイン画面を追加する"),
+		Labels: []*gh.Label{{Name: stringPtr("status:needs-plan")}},
+	}
+
+	t.Run("重複の可能性がある場合はコメントしてラベルを付け替える", func(t *testing.T) {
+		client := new(mockIssueSearchClient)
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 30).Return([]*gh.IssueComment{}, nil).Once()
+		client.On("SearchIssues", mock.Anything, "owner", "repo", "ログイン画面を追加する", 5).Return([]*gh.IssueSearchResult{
+			{Number: 30, Title: "ログイン画面を追加する", State: "OPEN"},
+			{Number: 12, Title: "ログイン画面の追加", State: "CLOSED"},
+			{Number: 20, Title: "ログアウト処理の修正", State: "OPEN"},
+		}, nil).Once()
+		client.On("CreateIssueComment", mock.Anything, "owner", "repo", 30, mock.MatchedBy(func(body string) bool {
+			return strings.HasPrefix(body, possibleDuplicateCommentMarker) &&
+				strings.Contains(body, "- #12 ログイン画面の追加（closed") &&
+				!strings.Contains(body, "#20") &&
+				strings.Contains(body, "`status:possible-duplicate`")
+		})).Return(nil).Once()
+		client.On("TransitionLabels", mock.Anything, "owner", "repo", 30, "status:needs-plan", "status:possible-duplicate").Return(nil).Once()
+
+		detector, err := NewDuplicateDetector(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		flagged, err := detector.CheckBeforePlan(context.Background(), planIssue)
+		require.NoError(t, err)
+		assert.True(t, flagged)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("類似するIssueがない場合は計画を続行", func(t *testing.T) {
+		client := new(mockIssueSearchClient)
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 30).Return([]*gh.IssueComment{}, nil).Once()
+		client.On("SearchIssues", mock.Anything, "owner", "repo", "ログイン画面を追加する", 5).Return([]*gh.IssueSearchResult{
+			{Number: 20, Title: "ログアウト処理の修正", State: "OPEN"},
+		}, nil).Once()
+
+		detector, err := NewDuplicateDetector(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		flagged, err := detector.CheckBeforePlan(context.Background(), planIssue)
+		require.NoError(t, err)
+		assert.False(t, flagged)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("通知済みのIssueに計画ラベルが付け直された場合は検出しない", func(t *testing.T) {
+		client := new(mockIssueSearchClient)
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 30).Return([]*gh.IssueComment{
+			{ID: gh.Int64(1), Body: gh.String(possibleDuplicateCommentMarker + "\n### osoba: 重複の可能性があります")},
+		}, nil).Once()
+
+		detector, err := NewDuplicateDetector(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		flagged, err := detector.CheckBeforePlan(context.Background(), planIssue)
+		require.NoError(t, err)
+		assert.False(t, flagged)
+		client.AssertNotCalled(t, "SearchIssues", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("計画待ち以外のIssueは対象外", func(t *testing.T) {
+		client := new(mockIssueSearchClient)
+		detector, err := NewDuplicateDetector(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		flagged, err := detector.CheckBeforePlan(context.Background(), &gh.Issue{
+			Number: intPtr(31),
+			Title:  stringPtr("ログイン画面を追加する"),
+			Labels: []*gh.Label{{Name: stringPtr("status:ready")}},
+		})
+		require.NoError(t, err)
+		assert.False(t, flagged)
+		client.AssertExpectations(t)
+	})
+}
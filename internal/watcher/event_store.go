@@ -0,0 +1,111 @@
+package watcher
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// イベントの種類
+const (
+	// EventPullRequestMerged は自動マージしたPRとリンクされたIssueの対応
+	// Data["issue_closed_by"]はIssueをクローズしたもの（keyword: クローズキーワード、osoba: osobaが明示的にクローズ）
+	EventPullRequestMerged = "pr_merged"
+	// EventPullRequestReverted はosobaがマージしたPRのRevertを検出して元のIssueを戻した記録
+	// Data["revert_pr"]はRevertしたPRの番号
+	EventPullRequestReverted = "pr_reverted"
+	// EventReviewChangesRequested はレビューで修正が必要と判定された（status:requires-changesになった）記録
+	EventReviewChangesRequested = "review_changes_requested"
+	// EventReviewEscalated はレビューと修正の往復が上限に達し、人間のレビュアーに引き継いだ記録
+	// Data["reviewers"]はレビューを依頼したユーザー・チーム（カンマ区切り）
+	EventReviewEscalated = "review_escalated"
+)
+
+// Event はイベントストアに記録するイベント
+type Event struct {
+	Time        time.Time         `json:"time"`
+	Type        string            `json:"type"`
+	IssueNumber int               `json:"issue_number,omitempty"`
+	PRNumber    int               `json:"pr_number,omitempty"`
+	Data        map[string]string `json:"data,omitempty"`
+}
+
+// EventStore はイベントを1行1件のJSONでファイルに追記する
+type EventStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewEventStore は新しいEventStoreを作成する
+func NewEventStore(path string) (*EventStore, error) {
+	if path == "" {
+		return nil, errors.New("event store path is required")
+	}
+	return &EventStore{path: path}, nil
+}
+
+// Append はイベントを追記する
+func (s *EventStore) Append(event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create event store directory: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open event store: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	return nil
+}
+
+// Read はイベントストアのイベントを記録順に読み込む（ファイルが存在しない場合は空）
+func (s *EventStore) Read() ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events, err := ReadEvents(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return events, err
+}
+
+// ReadEvents はイベントストアのイベントを記録順に読み込む
+// 書き込み途中などで壊れた行は読み飛ばす
+func ReadEvents(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+	return events, nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/douhashi/osoba/internal/clock"
 	"github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/logger"
 	"github.com/stretchr/testify/assert"
@@ -148,7 +149,7 @@ func TestExecuteLabelTransition_Enhanced(t *testing.T) {
 }
 
 func TestExecuteLabelTransition_RetryTiming(t *testing.T) {
-	// テストモードではスリープをスキップするためこのテストは実行しない
+	// テストモードではバックオフの待機自体を行わないため、このテストは実行しない
 	if os.Getenv("OSOBA_TEST_MODE") == "true" {
 		t.Skip("Skipping retry timing test in test mode")
 	}
@@ -168,23 +169,31 @@ func TestExecuteLabelTransition_RetryTiming(t *testing.T) {
 	mockClient.On("TransitionLabels", ctx, "owner", "repo", 1, "status:needs-plan", "status:planning").
 		Return(nil).Once()
 
+	fakeClock := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
 	watcher := &IssueWatcher{
 		client: mockClient,
 		owner:  "owner",
 		repo:   "repo",
 		logger: log,
+		clock:  fakeClock,
 	}
 
 	// Act
-	start := time.Now()
-	err := watcher.executeLabelTransition(ctx, issue)
-	elapsed := time.Since(start)
+	start := fakeClock.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- watcher.executeLabelTransition(ctx, issue)
+	}()
+
+	// リトライのバックオフ（1秒、2秒）を仮想時間で進める
+	fakeClock.BlockUntil(1)
+	fakeClock.Advance(time.Second)
+	fakeClock.BlockUntil(1)
+	fakeClock.Advance(2 * time.Second)
 
 	// Assert
-	assert.NoError(t, err)
-	// リトライのバックオフ時間を確認（1秒 + 2秒 + 実行時間）
-	assert.Greater(t, elapsed, 3*time.Second)
-	assert.Less(t, elapsed, 5*time.Second)
+	assert.NoError(t, <-errCh)
+	assert.Equal(t, 3*time.Second, fakeClock.Since(start))
 
 	mockClient.AssertExpectations(t)
 }
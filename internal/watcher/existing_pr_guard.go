@@ -0,0 +1,126 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// awaitingExistingPRCommentMarker は既存のPRで対応中の通知コメントを識別するためのマーカー
+const awaitingExistingPRCommentMarker = "<!-- osoba:awaiting-existing-pr -->"
+
+// ExistingPRGuard は計画フェーズの開始前に、既存のオープンなPRで対応中のIssueを検出する
+// 人間がすでに取り組んでいるIssueをosobaが重複して計画・実装しないようにする
+type ExistingPRGuard struct {
+	client github.GitHubClient
+	owner  string
+	repo   string
+	config *config.Config
+	logger logger.Logger
+}
+
+// NewExistingPRGuard は新しいExistingPRGuardを作成する
+func NewExistingPRGuard(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*ExistingPRGuard, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if _, ok := client.(github.IssueCommentEditor); !ok {
+		return nil, errors.New("github client does not support editing issue comments")
+	}
+	if _, ok := client.(github.PullRequestReferenceFinder); !ok {
+		return nil, errors.New("github client does not support finding referencing pull requests")
+	}
+
+	return &ExistingPRGuard{
+		client: client,
+		owner:  owner,
+		repo:   repo,
+		config: cfg,
+		logger: logger,
+	}, nil
+}
+
+// CheckBeforePlan は計画待ちのIssueを参照するオープンなPRがないかを確認する
+// 既存のPRがある場合はコメントとラベルを付与してtrueを返し、呼び出し側は計画フェーズを開始しない
+func (g *ExistingPRGuard) CheckBeforePlan(ctx context.Context, issue *github.Issue) (bool, error) {
+	if issue == nil || issue.Number == nil || !hasLabel(issue, g.config.GitHub.Labels.Plan) {
+		return false, nil
+	}
+	number := *issue.Number
+
+	prs, err := g.client.(github.PullRequestReferenceFinder).ListOpenPullRequestsClosingIssue(ctx, g.owner, g.repo, number)
+	if err != nil {
+		return false, fmt.Errorf("failed to find referencing pull requests: %w", err)
+	}
+	// osoba自身のブランチのPR（再計画など）は既存の作業として扱わない
+	var others []*github.ReferencingPullRequest
+	for _, pr := range prs {
+		if issueNumberFromBranch(pr.HeadRefName) != number {
+			others = append(others, pr)
+		}
+	}
+	if len(others) == 0 {
+		return false, nil
+	}
+
+	comments, err := g.client.(github.IssueCommentEditor).ListIssueComments(ctx, g.owner, g.repo, number)
+	if err != nil {
+		return false, fmt.Errorf("failed to list comments: %w", err)
+	}
+	for _, c := range comments {
+		if c.Body != nil && strings.HasPrefix(*c.Body, awaitingExistingPRCommentMarker) {
+			// 通知済みのIssueに計画ラベルが付け直された場合はosobaでの対応が選ばれたものとする
+			return false, nil
+		}
+	}
+
+	// マーカー付きのコメントは通知済みの印になるため、ラベルの付け替えに成功した後にのみ投稿する
+	// （付け替えに失敗した場合は次回のポーリングで再度検出する）
+	label := g.config.GitHub.ExistingPRGuard.Label
+	if err := g.client.TransitionLabels(ctx, g.owner, g.repo, number, g.config.GitHub.Labels.Plan, label); err != nil {
+		return true, fmt.Errorf("failed to label issue awaiting existing pull request: %w", err)
+	}
+	if err := g.client.CreateIssueComment(ctx, g.owner, g.repo, number, buildAwaitingExistingPRComment(g.config, number, others)); err != nil {
+		return true, fmt.Errorf("failed to post awaiting existing pull request comment: %w", err)
+	}
+
+	g.logger.Info("Skipped planning issue already addressed by an open pull request",
+		"issue_number", number,
+		"pr_number", others[0].Number,
+		"label", label)
+	return true, nil
+}
+
+// buildAwaitingExistingPRComment は既存のPRで対応中の通知コメントを生成する
+func buildAwaitingExistingPRComment(cfg *config.Config, number int, prs []*github.ReferencingPullRequest) string {
+	var list strings.Builder
+	for _, pr := range prs {
+		fmt.Fprintf(&list, "- #%d %s", pr.Number, pr.Title)
+		if pr.Author != "" {
+			fmt.Fprintf(&list, "（@%s）", pr.Author)
+		}
+		list.WriteString("\n")
+	}
+
+	return awaitingExistingPRCommentMarker + "\n" + cfg.RenderComment(config.CommentAwaitingExistingPR, map[string]string{
+		"issue-number":  strconv.Itoa(number),
+		"label":         cfg.GitHub.ExistingPRGuard.Label,
+		"plan-label":    cfg.GitHub.Labels.Plan,
+		"pull-requests": list.String(),
+	})
+}
@@ -0,0 +1,118 @@
+package watcher
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPRReferenceClient は参照PRの検索とコメント編集に対応したGitHubクライアントのモック
+type mockPRReferenceClient struct {
+	mockCommentEditorClient
+}
+
+func (m *mockPRReferenceClient) ListOpenPullRequestsClosingIssue(ctx context.Context, owner, repo string, issueNumber int) ([]*gh.ReferencingPullRequest, error) {
+	args := m.Called(ctx, owner, repo, issueNumber)
+	return args.Get(0).([]*gh.ReferencingPullRequest), args.Error(1)
+}
+
+func TestExistingPRGuard_CheckBeforePlan(t *testing.T) {
+	planIssue := &gh.Issue{
+		Number: intPtr(40),
+		Title:  stringPtr("検索機能を追加する"),
+		Labels: []*gh.Label{{Name: stringPtr("status:needs-plan")}},
+	}
+
+	t.Run("既存のPRがある場合はコメントしてラベルを付け替える", func(t *testing.T) {
+		client := new(mockPRReferenceClient)
+		client.On("ListOpenPullRequestsClosingIssue", mock.Anything, "owner", "repo", 40).Return([]*gh.ReferencingPullRequest{
+			{Number: 55, Title: "検索機能の追加", HeadRefName: "feature/search", Author: "alice"},
+		}, nil).Once()
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 40).Return([]*gh.IssueComment{}, nil).Once()
+		client.On("CreateIssueComment", mock.Anything, "owner", "repo", 40, mock.MatchedBy(func(body string) bool {
+			return strings.HasPrefix(body, awaitingExistingPRCommentMarker) &&
+				strings.Contains(body, "- #55 検索機能の追加（@alice）") &&
+				strings.Contains(body, "`status:awaiting-existing-pr`")
+		})).Return(nil).Once()
+		client.On("TransitionLabels", mock.Anything, "owner", "repo", 40, "status:needs-plan", "status:awaiting-existing-pr").Return(nil).Once()
+
+		guard, err := NewExistingPRGuard(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		flagged, err := guard.CheckBeforePlan(context.Background(), planIssue)
+		require.NoError(t, err)
+		assert.True(t, flagged)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("ラベルの付け替えに失敗した場合はコメントしない", func(t *testing.T) {
+		client := new(mockPRReferenceClient)
+		client.On("ListOpenPullRequestsClosingIssue", mock.Anything, "owner", "repo", 40).Return([]*gh.ReferencingPullRequest{
+			{Number: 55, Title: "検索機能の追加", HeadRefName: "feature/search"},
+		}, nil).Once()
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 40).Return([]*gh.IssueComment{}, nil).Once()
+		client.On("TransitionLabels", mock.Anything, "owner", "repo", 40, "status:needs-plan", "status:awaiting-existing-pr").Return(assert.AnError).Once()
+
+		guard, err := NewExistingPRGuard(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		flagged, err := guard.CheckBeforePlan(context.Background(), planIssue)
+		require.Error(t, err)
+		assert.True(t, flagged, "計画フェーズは開始しない")
+		client.AssertExpectations(t)
+		client.AssertNotCalled(t, "CreateIssueComment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("osoba自身のブランチのPRのみの場合は計画を続行", func(t *testing.T) {
+		client := new(mockPRReferenceClient)
+		client.On("ListOpenPullRequestsClosingIssue", mock.Anything, "owner", "repo", 40).Return([]*gh.ReferencingPullRequest{
+			{Number: 56, Title: "検索機能を追加する", HeadRefName: "osoba/#40"},
+		}, nil).Once()
+
+		guard, err := NewExistingPRGuard(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		flagged, err := guard.CheckBeforePlan(context.Background(), planIssue)
+		require.NoError(t, err)
+		assert.False(t, flagged)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("通知済みのIssueに計画ラベルが付け直された場合は検出しない", func(t *testing.T) {
+		client := new(mockPRReferenceClient)
+		client.On("ListOpenPullRequestsClosingIssue", mock.Anything, "owner", "repo", 40).Return([]*gh.ReferencingPullRequest{
+			{Number: 55, Title: "検索機能の追加", HeadRefName: "feature/search"},
+		}, nil).Once()
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 40).Return([]*gh.IssueComment{
+			{ID: gh.Int64(1), Body: gh.String(awaitingExistingPRCommentMarker + "\n### osoba: 既存のPRで対応中です")},
+		}, nil).Once()
+
+		guard, err := NewExistingPRGuard(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		flagged, err := guard.CheckBeforePlan(context.Background(), planIssue)
+		require.NoError(t, err)
+		assert.False(t, flagged)
+		client.AssertNotCalled(t, "CreateIssueComment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("計画待ち以外のIssueは対象外", func(t *testing.T) {
+		client := new(mockPRReferenceClient)
+		guard, err := NewExistingPRGuard(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		flagged, err := guard.CheckBeforePlan(context.Background(), &gh.Issue{
+			Number: intPtr(41),
+			Labels: []*gh.Label{{Name: stringPtr("status:ready")}},
+		})
+		require.NoError(t, err)
+		assert.False(t, flagged)
+		client.AssertExpectations(t)
+	})
+}
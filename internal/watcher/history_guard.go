@@ -0,0 +1,222 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// historyGuardClient は履歴の書き換えの検出に使用するクライアント
+type historyGuardClient interface {
+	github.GitHubClient
+	github.ForcePushReader
+	github.PullRequestMergeInfoReader
+	github.IssueCommentEditor
+}
+
+// historyRewrittenCommentMarker は履歴の書き換えの通知コメントを識別するためのマーカー
+// 再起動後も通知済みの書き換えを再び通知しないよう、最後の書き換えより後のマーカー付きのコメントで判定する
+const historyRewrittenCommentMarker = "<!-- osoba:history-rewritten -->"
+
+// HistoryGuard は自動マージの前にPRのブランチの履歴が書き換えられていないか（force-push）を確認する
+// エージェントが共有の履歴を書き換えた場合はマージを見送り、Issueにラベルとコメントを付与して人の確認を求める
+// 人が確認してallow_labelをPRに付与した後は、付与したラベルを外し、それ以降に書き換えられない限りマージを再開する
+// nilの場合は確認しない
+type HistoryGuard struct {
+	client historyGuardClient
+	owner  string
+	repo   string
+	config *config.Config
+	logger logger.Logger
+
+	mu       sync.Mutex
+	flagged  map[int]time.Time // PRごとに通知した最後のforce-pushの日時
+	released map[int]bool      // 許可ラベルの付与を確認してラベルを外したPR
+}
+
+// NewHistoryGuard は新しいHistoryGuardを作成する
+func NewHistoryGuard(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*HistoryGuard, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if logger == nil {
+		return nil, errors.New("logger is required")
+	}
+	reader, ok := client.(historyGuardClient)
+	if !ok {
+		return nil, errors.New("github client does not support reading force-push events")
+	}
+
+	return &HistoryGuard{
+		client:   reader,
+		owner:    owner,
+		repo:     repo,
+		config:   cfg,
+		logger:   logger.WithFields("component", "history_guard"),
+		flagged:  make(map[int]time.Time),
+		released: make(map[int]bool),
+	}, nil
+}
+
+// Check はPRのブランチの履歴が書き換えられていないかを確認する
+// 書き換えられていてallow_labelが最後の書き換えより後に付与されていない場合は、マージを見送る説明を返す
+// 新しい書き換えを検出した場合はissueNumberのIssue（またはPR）にラベルとコメントを付与し、
+// 許可ラベルが付与された場合は付与したラベルを外す
+func (g *HistoryGuard) Check(ctx context.Context, issueNumber, prNumber int) (string, error) {
+	if g == nil {
+		return "", nil
+	}
+
+	pushes, err := g.client.ListForcePushes(ctx, g.owner, g.repo, prNumber)
+	if err != nil {
+		return "", err
+	}
+	if len(pushes) == 0 {
+		return "", nil
+	}
+	last := pushes[len(pushes)-1]
+
+	cfg := g.config.GitHub.HistoryGuard
+	allowedAt, err := g.client.GetLabelAddedAt(ctx, g.owner, g.repo, prNumber, cfg.AllowLabel)
+	if err != nil {
+		return "", err
+	}
+	if allowedAt != nil && allowedAt.After(last.CreatedAt) {
+		g.logger.Debug("Rewritten history was reviewed",
+			"pr_number", prNumber,
+			"force_pushes", len(pushes),
+			"allowed_at", allowedAt)
+		if err := g.release(ctx, issueNumber, prNumber); err != nil {
+			return "", err
+		}
+		return "", nil
+	}
+
+	detail := fmt.Sprintf("PRのブランチがforce-pushされています（%d回、最後は%s）", len(pushes), last.CreatedAt.In(g.config.Location()).Format(time.RFC3339))
+	if g.isFlagged(prNumber, last.CreatedAt) {
+		return detail, nil
+	}
+	comments, err := g.client.ListIssueComments(ctx, g.owner, g.repo, issueNumber)
+	if err != nil {
+		return "", fmt.Errorf("failed to list comments: %w", err)
+	}
+	if !hasHistoryRewrittenComment(comments, last.CreatedAt) {
+		g.flag(ctx, issueNumber, prNumber, pushes)
+	}
+	g.mu.Lock()
+	g.flagged[prNumber] = last.CreatedAt
+	delete(g.released, prNumber)
+	g.mu.Unlock()
+	return detail, nil
+}
+
+// isFlagged はPRの最後のforce-pushを通知済みかを返す
+func (g *HistoryGuard) isFlagged(prNumber int, lastPush time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	flagged, ok := g.flagged[prNumber]
+	return ok && !lastPush.After(flagged)
+}
+
+// hasHistoryRewrittenComment はsinceより後に履歴の書き換えの通知コメントがあるかを返す
+// sinceがゼロ値の場合は通知コメントがあるかを返す
+func hasHistoryRewrittenComment(comments []*github.IssueComment, since time.Time) bool {
+	for _, c := range comments {
+		if c.Body == nil || !strings.HasPrefix(*c.Body, historyRewrittenCommentMarker) {
+			continue
+		}
+		if since.IsZero() || (c.CreatedAt != nil && c.CreatedAt.After(since)) {
+			return true
+		}
+	}
+	return false
+}
+
+// release は許可ラベルが付与されたPRのIssueから、書き換えを通知したときに付与したラベルを外す
+// 通知していない（通知コメントがない）Issueのラベルは、他の機能が付与したものとして外さない
+func (g *HistoryGuard) release(ctx context.Context, issueNumber, prNumber int) error {
+	g.mu.Lock()
+	_, flagged := g.flagged[prNumber]
+	released := g.released[prNumber]
+	g.mu.Unlock()
+	if released {
+		return nil
+	}
+	if !flagged {
+		// 再起動前に通知した場合は通知コメントで判定する
+		comments, err := g.client.ListIssueComments(ctx, g.owner, g.repo, issueNumber)
+		if err != nil {
+			return fmt.Errorf("failed to list comments: %w", err)
+		}
+		flagged = hasHistoryRewrittenComment(comments, time.Time{})
+	}
+	if flagged {
+		label := g.config.GitHub.HistoryGuard.Label
+		if err := g.client.RemoveLabel(ctx, g.owner, g.repo, issueNumber, label); err != nil {
+			return fmt.Errorf("failed to remove history guard label: %w", err)
+		}
+		g.logger.Info("Rewritten history was reviewed, removed history guard label",
+			"issue_number", issueNumber,
+			"pr_number", prNumber,
+			"label", label)
+	}
+
+	g.mu.Lock()
+	delete(g.flagged, prNumber)
+	g.released[prNumber] = true
+	g.mu.Unlock()
+	return nil
+}
+
+// flag はIssueにラベルを付与し、force-pushの一覧と再開の方法をコメントする
+func (g *HistoryGuard) flag(ctx context.Context, issueNumber, prNumber int, pushes []github.ForcePush) {
+	cfg := g.config.GitHub.HistoryGuard
+	g.logger.Warn("Blocking auto-merge of pull request with rewritten history",
+		"issue_number", issueNumber,
+		"pr_number", prNumber,
+		"force_pushes", len(pushes),
+		"label", cfg.Label)
+
+	if err := g.client.AddLabel(ctx, g.owner, g.repo, issueNumber, cfg.Label); err != nil {
+		g.logger.Warn("Failed to add history guard label",
+			"issue_number", issueNumber,
+			"label", cfg.Label,
+			"error", err)
+	}
+
+	lines := make([]string, 0, len(pushes))
+	for _, push := range pushes {
+		actor := push.Actor
+		if actor == "" {
+			actor = "不明"
+		}
+		lines = append(lines, fmt.Sprintf("- %s（%s）", push.CreatedAt.In(g.config.Location()).Format(time.RFC3339), actor))
+	}
+	body := historyRewrittenCommentMarker + "\n" + g.config.RenderComment(config.CommentHistoryRewritten, map[string]string{
+		"issue-number": strconv.Itoa(issueNumber),
+		"pr":           fmt.Sprintf("#%d", prNumber),
+		"force-pushes": strings.Join(lines, "\n"),
+		"label":        cfg.Label,
+		"allow-label":  cfg.AllowLabel,
+	})
+	if err := g.client.CreateIssueComment(ctx, g.owner, g.repo, issueNumber, body); err != nil {
+		g.logger.Warn("Failed to post history guard comment", "issue_number", issueNumber, "error", err)
+	}
+}
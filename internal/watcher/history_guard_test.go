@@ -0,0 +1,130 @@
+package watcher
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockForcePushClient はforce-pushの一覧の取得に対応したGitHubクライアントのモック
+type mockForcePushClient struct {
+	mockMergeInfoClient
+}
+
+func (m *mockForcePushClient) ListForcePushes(ctx context.Context, owner, repo string, prNumber int) ([]gh.ForcePush, error) {
+	args := m.Called(ctx, owner, repo, prNumber)
+	pushes, _ := args.Get(0).([]gh.ForcePush)
+	return pushes, args.Error(1)
+}
+
+func (m *mockForcePushClient) ListIssueComments(ctx context.Context, owner, repo string, issueNumber int) ([]*gh.IssueComment, error) {
+	args := m.Called(ctx, owner, repo, issueNumber)
+	comments, _ := args.Get(0).([]*gh.IssueComment)
+	return comments, args.Error(1)
+}
+
+func (m *mockForcePushClient) UpdateIssueComment(ctx context.Context, owner, repo string, commentID int64, body string) error {
+	args := m.Called(ctx, owner, repo, commentID, body)
+	return args.Error(0)
+}
+
+func TestHistoryGuard_Check(t *testing.T) {
+	pushedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	before := pushedAt.Add(-time.Hour)
+	after := pushedAt.Add(time.Hour)
+	pushes := []gh.ForcePush{{Actor: "osoba-bot", CreatedAt: pushedAt}}
+	notified := func(at time.Time) []*gh.IssueComment {
+		return []*gh.IssueComment{{Body: stringPtr(historyRewrittenCommentMarker + "\n通知"), CreatedAt: &at}}
+	}
+
+	tests := []struct {
+		name        string
+		pushes      []gh.ForcePush
+		allowedAt   *time.Time
+		comments    []*gh.IssueComment
+		wantBlock   bool
+		wantNotify  bool
+		wantRelease bool
+	}{
+		{name: "force-pushされていない"},
+		{name: "force-pushされた", pushes: pushes, wantBlock: true, wantNotify: true},
+		{name: "force-pushの前に許可ラベルが付与された", pushes: pushes, allowedAt: &before, wantBlock: true, wantNotify: true},
+		{name: "force-pushの後に許可ラベルが付与された", pushes: pushes, allowedAt: &after},
+		{name: "再起動前に通知したforce-pushは通知しない", pushes: pushes, comments: notified(after), wantBlock: true},
+		{name: "以前の通知より後のforce-pushは通知する", pushes: pushes, comments: notified(before), wantBlock: true, wantNotify: true},
+		{name: "通知後に許可ラベルが付与されたらラベルを外す", pushes: pushes, allowedAt: &after, comments: notified(pushedAt.Add(time.Minute)), wantRelease: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockForcePushClient)
+			client.On("ListForcePushes", mock.Anything, "owner", "repo", 20).Return(tt.pushes, nil)
+			client.On("GetLabelAddedAt", mock.Anything, "owner", "repo", 20, "status:history-reviewed").Return(tt.allowedAt, nil).Maybe()
+			if len(tt.pushes) > 0 {
+				client.On("ListIssueComments", mock.Anything, "owner", "repo", 7).Return(tt.comments, nil).Once()
+			}
+			if tt.wantRelease {
+				client.On("RemoveLabel", mock.Anything, "owner", "repo", 7, "status:needs-human").Return(nil).Once()
+			}
+			if tt.wantNotify {
+				client.On("AddLabel", mock.Anything, "owner", "repo", 7, "status:needs-human").Return(nil).Once()
+				client.On("CreateIssueComment", mock.Anything, "owner", "repo", 7, mock.MatchedBy(func(body string) bool {
+					return assert.True(t, strings.HasPrefix(body, historyRewrittenCommentMarker)) && assert.Contains(t, body, "#20") && assert.Contains(t, body, "osoba-bot") && assert.Contains(t, body, "status:history-reviewed")
+				})).Return(nil).Once()
+			}
+			guard, err := NewHistoryGuard(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+			require.NoError(t, err)
+
+			detail, err := guard.Check(context.Background(), 7, 20)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBlock, detail != "")
+
+			// 同じforce-pushは一度だけ通知し、ラベルは一度だけ外す
+			_, err = guard.Check(context.Background(), 7, 20)
+			require.NoError(t, err)
+			client.AssertExpectations(t)
+			if !tt.wantNotify {
+				client.AssertNotCalled(t, "AddLabel", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestHistoryGuard_NilDoesNothing(t *testing.T) {
+	var guard *HistoryGuard
+	detail, err := guard.Check(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, detail)
+}
+
+func TestHistoryIssueNumber(t *testing.T) {
+	tests := []struct {
+		name        string
+		closing     int
+		headRefName string
+		want        int
+	}{
+		{name: "PRがクローズするIssue", closing: 7, headRefName: "osoba/#9", want: 7},
+		{name: "osobaのブランチ名のIssue", headRefName: "osoba/#9", want: 9},
+		{name: "Issueを特定できない場合はPR", headRefName: "feature", want: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockForcePushClient)
+			client.On("GetClosingIssueNumber", mock.Anything, 20).Return(tt.closing, nil).Once()
+			guard, err := NewHistoryGuard(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+			require.NoError(t, err)
+
+			got := historyIssueNumber(context.Background(), client, guard, &gh.PullRequest{Number: 20, HeadRefName: tt.headRefName})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package watcher
+
+import (
+	"context"
+
+	"github.com/douhashi/osoba/internal/github"
+)
+
+// createInformationalComment は状態を持たない通知のコメントをIssueに投稿する
+// クライアントがgithub.DeferredMutatorを実装している場合、GitHubに接続できない間はエラーにせず保留する
+// フェーズの遷移やマーカーの判定に使うコメントには使わない
+func createInformationalComment(ctx context.Context, client github.GitHubClient, owner, repo string, issueNumber int, body string) error {
+	if deferred, ok := client.(github.DeferredMutator); ok {
+		return deferred.CreateIssueCommentDeferred(ctx, owner, repo, issueNumber, body)
+	}
+	return client.CreateIssueComment(ctx, owner, repo, issueNumber, body)
+}
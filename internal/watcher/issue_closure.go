@@ -0,0 +1,137 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// issueClosureRecheckDelay はマージ直後にIssueがオープンだった場合に再確認するまでの待機時間
+// GitHubはクローズキーワードによるIssueのクローズを非同期に行うため、少し待ってから判定する
+const issueClosureRecheckDelay = 5 * time.Second
+
+// issueBranchPattern はosobaが作成するブランチ名（osoba/#<Issue番号>）
+var issueBranchPattern = regexp.MustCompile(`^osoba/#(\d+)$`)
+
+// issueClosureClient はIssueの状態取得とクローズに使用するクライアント
+type issueClosureClient interface {
+	GetIssueState(ctx context.Context, owner, repo string, issueNumber int) (string, error)
+	github.IssueCloser
+}
+
+// IssueClosureVerifier は自動マージ後にリンクされたIssueがクローズされたかを確認する
+// PRにクローズキーワードがなくIssueがオープンのままの場合は、PRを参照するコメントを付けてクローズする
+type IssueClosureVerifier struct {
+	client       issueClosureClient
+	owner        string
+	repo         string
+	config       *config.Config
+	events       *EventStore // 対応を記録するイベントストア（無効の場合はnil）
+	logger       logger.Logger
+	clock        clock.Clock
+	recheckDelay time.Duration
+}
+
+// NewIssueClosureVerifier は新しいIssueClosureVerifierを作成する
+func NewIssueClosureVerifier(client github.GitHubClient, owner, repo string, cfg *config.Config, events *EventStore, logger logger.Logger) (*IssueClosureVerifier, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	closer, ok := client.(issueClosureClient)
+	if !ok {
+		return nil, errors.New("github client does not support closing issues")
+	}
+
+	return &IssueClosureVerifier{
+		client:       closer,
+		owner:        owner,
+		repo:         repo,
+		config:       cfg,
+		events:       events,
+		logger:       logger,
+		clock:        clock.New(),
+		recheckDelay: issueClosureRecheckDelay,
+	}, nil
+}
+
+// Verify はマージしたPRにリンクされたIssueがクローズされたかを確認し、オープンのままの場合はクローズする
+func (v *IssueClosureVerifier) Verify(ctx context.Context, issueNumber, prNumber int) error {
+	closedBy := "keyword"
+
+	open, err := v.isOpen(ctx, issueNumber)
+	if err != nil {
+		return err
+	}
+	if open && v.recheckDelay > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-v.clock.After(v.recheckDelay):
+		}
+		if open, err = v.isOpen(ctx, issueNumber); err != nil {
+			return err
+		}
+	}
+	if open {
+		body := v.config.RenderComment(config.CommentIssueClosedByMerge, map[string]string{
+			"issue-number": strconv.Itoa(issueNumber),
+			"pr-number":    strconv.Itoa(prNumber),
+		})
+		if err := v.client.CloseIssue(ctx, v.owner, v.repo, issueNumber, body); err != nil {
+			return fmt.Errorf("failed to close issue #%d: %w", issueNumber, err)
+		}
+		closedBy = "osoba"
+		v.logger.Info("Closed issue left open after merge",
+			"issue_number", issueNumber,
+			"pr_number", prNumber)
+	}
+
+	if v.events != nil {
+		if err := v.events.Append(Event{
+			Time:        v.clock.Now(),
+			Type:        EventPullRequestMerged,
+			IssueNumber: issueNumber,
+			PRNumber:    prNumber,
+			Data:        map[string]string{"issue_closed_by": closedBy},
+		}); err != nil {
+			v.logger.Warn("Failed to record merge event",
+				"issue_number", issueNumber,
+				"pr_number", prNumber,
+				"error", err)
+		}
+	}
+	return nil
+}
+
+func (v *IssueClosureVerifier) isOpen(ctx context.Context, issueNumber int) (bool, error) {
+	state, err := v.client.GetIssueState(ctx, v.owner, v.repo, issueNumber)
+	if err != nil {
+		return false, fmt.Errorf("failed to get state of issue #%d: %w", issueNumber, err)
+	}
+	return strings.EqualFold(state, "OPEN"), nil
+}
+
+// issueNumberFromBranch はosobaが作成したブランチ名からIssue番号を取り出す（該当しない場合は0）
+func issueNumberFromBranch(branch string) int {
+	matches := issueBranchPattern.FindStringSubmatch(branch)
+	if matches == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(matches[1])
+	return n
+}
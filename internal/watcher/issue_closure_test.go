@@ -0,0 +1,104 @@
+package watcher
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIssueCloserClient はIssueのクローズに対応したGitHubクライアントのモック
+type mockIssueCloserClient struct {
+	mockIssueCreatorClient
+}
+
+func (m *mockIssueCloserClient) CloseIssue(ctx context.Context, owner, repo string, issueNumber int, comment string) error {
+	args := m.Called(ctx, owner, repo, issueNumber, comment)
+	return args.Error(0)
+}
+
+func TestIssueClosureVerifier_Verify(t *testing.T) {
+	tests := []struct {
+		name         string
+		states       []string
+		wantClose    bool
+		wantClosedBy string
+	}{
+		{
+			name:         "クローズキーワードでクローズ済み",
+			states:       []string{"CLOSED"},
+			wantClosedBy: "keyword",
+		},
+		{
+			name:         "再確認でクローズ済み",
+			states:       []string{"OPEN", "CLOSED"},
+			wantClosedBy: "keyword",
+		},
+		{
+			name:         "オープンのままの場合は明示的にクローズ",
+			states:       []string{"OPEN", "OPEN"},
+			wantClose:    true,
+			wantClosedBy: "osoba",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockIssueCloserClient)
+			for _, state := range tt.states {
+				client.On("GetIssueState", mock.Anything, "owner", "repo", 12).Return(state, nil).Once()
+			}
+			if tt.wantClose {
+				client.On("CloseIssue", mock.Anything, "owner", "repo", 12, mock.MatchedBy(func(body string) bool {
+					return strings.Contains(body, "#34 をマージしました") && strings.Contains(body, "Closes #12")
+				})).Return(nil).Once()
+			}
+
+			path := filepath.Join(t.TempDir(), "events", "owner-repo.jsonl")
+			events, err := NewEventStore(path)
+			require.NoError(t, err)
+			verifier, err := NewIssueClosureVerifier(client, "owner", "repo", config.NewConfig(), events, NewMockLogger())
+			require.NoError(t, err)
+			verifier.recheckDelay = time.Millisecond
+
+			require.NoError(t, verifier.Verify(context.Background(), 12, 34))
+			client.AssertExpectations(t)
+
+			recorded, err := ReadEvents(path)
+			require.NoError(t, err)
+			require.Len(t, recorded, 1)
+			assert.Equal(t, EventPullRequestMerged, recorded[0].Type)
+			assert.Equal(t, 12, recorded[0].IssueNumber)
+			assert.Equal(t, 34, recorded[0].PRNumber)
+			assert.Equal(t, tt.wantClosedBy, recorded[0].Data["issue_closed_by"])
+		})
+	}
+}
+
+func TestIssueClosureVerifier_Verify_Canceled(t *testing.T) {
+	client := new(mockIssueCloserClient)
+	client.On("GetIssueState", mock.Anything, "owner", "repo", 12).Return("OPEN", nil).Once()
+
+	verifier, err := NewIssueClosureVerifier(client, "owner", "repo", config.NewConfig(), nil, NewMockLogger())
+	require.NoError(t, err)
+	verifier.recheckDelay = time.Hour
+
+	// 再確認を待つ間にシャットダウンした場合は、Issueをクローズせずに終了する
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, verifier.Verify(ctx, 12, 34), context.Canceled)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "CloseIssue", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIssueNumberFromBranch(t *testing.T) {
+	assert.Equal(t, 42, issueNumberFromBranch("osoba/#42"))
+	assert.Equal(t, 0, issueNumberFromBranch("feature/42"))
+	assert.Equal(t, 0, issueNumberFromBranch(""))
+}
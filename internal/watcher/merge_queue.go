@@ -0,0 +1,322 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/cleanup"
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/notify"
+)
+
+// mergeQueueRegisterTimeout はキューに追加したPRがキューに入らないまま追跡を続ける時間
+// 超えた場合は追跡をやめ、次の自動マージの確認で改めてキューに追加する
+const mergeQueueRegisterTimeout = 30 * time.Minute
+
+// mergeQueueClient はマージキューの利用に使用するクライアント
+type mergeQueueClient interface {
+	github.GitHubClient
+	github.MergeQueueClient
+}
+
+// MergeQueueStatus はマージキューに追加したPRの追跡状態
+type MergeQueueStatus struct {
+	IssueNumber int       `json:"issue_number,omitempty"` // PRから特定できない場合は0（マージ後に特定する）
+	PRNumber    int       `json:"pr_number"`
+	HeadRefName string    `json:"head_ref_name,omitempty"`
+	State       string    `json:"state"`              // キュー内の状態（追加直後でキューに入る前は空）
+	Position    int       `json:"position,omitempty"` // キュー内の順番
+	EnqueuedAt  time.Time `json:"enqueued_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DetectMergeQueue はデフォルトブランチでGitHubのマージキューが必須になっているかを返す
+func DetectMergeQueue(ctx context.Context, client github.GitHubClient, owner, repo string) (bool, error) {
+	inspector, ok := client.(github.RepositoryInspector)
+	if !ok {
+		return false, errors.New("github client does not support inspecting repositories")
+	}
+	queue, ok := client.(github.MergeQueueClient)
+	if !ok {
+		return false, errors.New("github client does not support merge queues")
+	}
+
+	branch, err := inspector.GetDefaultBranch(ctx, owner, repo)
+	if err != nil {
+		return false, err
+	}
+	return queue.UsesMergeQueue(ctx, owner, repo, branch)
+}
+
+// MergeQueue はGitHubのマージキューを使うリポジトリで、自動マージするPRをキューに追加して状態を追跡する
+// キューが実際にマージした時点で、直接マージした場合と同じくIssueのクローズ確認とクリーンアップを行う
+// nilの場合は自動マージが直接マージする
+type MergeQueue struct {
+	client         mergeQueueClient
+	cleanupManager cleanup.Manager
+	owner          string
+	repo           string
+	config         *config.Config
+	logger         logger.Logger
+
+	closure  *IssueClosureVerifier
+	metrics  *AutoMergeMetrics
+	notifier notify.Notifier
+	branches *RemoteBranchCleaner
+
+	mu      sync.Mutex
+	entries map[int]*MergeQueueStatus // PR番号ごとの追跡状態
+	clock   clock.Clock
+}
+
+// NewMergeQueue は新しいMergeQueueを作成する
+func NewMergeQueue(client github.GitHubClient, cleanupManager cleanup.Manager, owner, repo string, cfg *config.Config, logger logger.Logger) (*MergeQueue, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if cleanupManager == nil {
+		return nil, errors.New("cleanup manager is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	queueClient, ok := client.(mergeQueueClient)
+	if !ok {
+		return nil, errors.New("github client does not support merge queues")
+	}
+
+	return &MergeQueue{
+		client:         queueClient,
+		cleanupManager: cleanupManager,
+		owner:          owner,
+		repo:           repo,
+		config:         cfg,
+		logger:         logger,
+		entries:        make(map[int]*MergeQueueStatus),
+		clock:          clock.New(),
+	}, nil
+}
+
+// SetRemoteBranchCleaner はマージ後のリモートのブランチの削除を設定する
+func (q *MergeQueue) SetRemoteBranchCleaner(cleaner *RemoteBranchCleaner) {
+	q.branches = cleaner
+}
+
+// SetIssueClosureVerifier はマージ後のIssueのクローズ確認を設定する
+func (q *MergeQueue) SetIssueClosureVerifier(verifier *IssueClosureVerifier) {
+	q.closure = verifier
+}
+
+// SetMetrics はマージの成功・失敗を記録するメトリクスを設定する
+func (q *MergeQueue) SetMetrics(metrics *AutoMergeMetrics) {
+	q.metrics = metrics
+}
+
+// SetNotifier はキューから外れたPRの通知先を設定する
+func (q *MergeQueue) SetNotifier(notifier notify.Notifier) {
+	q.notifier = notify.WithRepository(notifier, q.owner+"/"+q.repo)
+}
+
+// Tracking はPRをマージキューに追加済みで、マージを待っているかを返す
+func (q *MergeQueue) Tracking(prNumber int) bool {
+	if q == nil {
+		return false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, ok := q.entries[prNumber]
+	return ok
+}
+
+// Enqueue はPRをマージキューに追加し、マージされるまで追跡する
+// 再起動前に追加済みのPRは追加し直さずに追跡のみ再開する
+func (q *MergeQueue) Enqueue(ctx context.Context, issueNumber int, pr *github.PullRequest) error {
+	entry, err := q.client.GetMergeQueueEntry(ctx, q.owner, q.repo, pr.Number)
+	if err != nil {
+		return err
+	}
+	if !entry.Queued() && !entry.Merged() {
+		if err := q.client.EnqueuePullRequest(ctx, pr.Number); err != nil {
+			return err
+		}
+	}
+
+	now := q.clock.Now()
+	q.mu.Lock()
+	q.entries[pr.Number] = &MergeQueueStatus{
+		IssueNumber: issueNumber,
+		PRNumber:    pr.Number,
+		HeadRefName: pr.HeadRefName,
+		State:       entry.State,
+		Position:    entry.Position,
+		EnqueuedAt:  now,
+		UpdatedAt:   now,
+	}
+	q.mu.Unlock()
+
+	q.logger.Info("Added pull request to merge queue",
+		"issue_number", issueNumber,
+		"pr_number", pr.Number,
+		"state", entry.State)
+	return nil
+}
+
+// Start はマージキューの状態の確認を開始する
+func (q *MergeQueue) Start(ctx context.Context) {
+	interval := q.config.GitHub.PRPollInterval
+	if interval <= 0 {
+		interval = q.config.GitHub.PollInterval
+	}
+	q.logger.Info("Starting merge queue tracker", "interval", interval)
+
+	ticker := q.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.logger.Info("Merge queue tracker stopped")
+			return
+		case <-ticker.C():
+			if err := q.CheckOnce(ctx); err != nil {
+				q.logger.Warn("Failed to check merge queue", "error", err)
+			}
+		}
+	}
+}
+
+// CheckOnce は追跡中のPRの状態を確認し、マージされたPRのIssueをクリーンアップする
+// マージされずにキューから外れたPRは追跡をやめ、次の自動マージの確認で改めてキューに追加する
+func (q *MergeQueue) CheckOnce(ctx context.Context) error {
+	var errs []error
+	for _, status := range q.Entries() {
+		entry, err := q.client.GetMergeQueueEntry(ctx, q.owner, q.repo, status.PRNumber)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("PR #%d: %w", status.PRNumber, err))
+			continue
+		}
+
+		switch {
+		case entry.Merged():
+			q.untrack(status.PRNumber)
+			q.completeMerge(ctx, status)
+		case entry.PRState == "CLOSED":
+			q.untrack(status.PRNumber)
+			q.logger.Info("Stopped tracking closed pull request in merge queue", "pr_number", status.PRNumber)
+		case !entry.Queued() && status.State != "":
+			// キューに入った後に外れた（チェックの失敗やコンフリクトなど）
+			q.untrack(status.PRNumber)
+			q.logger.Warn("Pull request was removed from merge queue",
+				"issue_number", status.IssueNumber,
+				"pr_number", status.PRNumber,
+				"last_state", status.State)
+			if q.metrics != nil {
+				q.metrics.RecordFailure(status.IssueNumber, status.PRNumber, "merge_queue_removed")
+			}
+			notifyMergeBlocked(ctx, q.notifier, q.logger, status.IssueNumber, &github.PullRequest{Number: status.PRNumber},
+				fmt.Sprintf("PRがマージキューから外れました（最後の状態: %s）。", status.State))
+		case !entry.Queued() && q.clock.Since(status.EnqueuedAt) > mergeQueueRegisterTimeout:
+			// 追加したがキューに入らないまま時間が経過した（追加が受け付けられなかったなど）
+			q.untrack(status.PRNumber)
+			q.logger.Warn("Pull request did not enter merge queue",
+				"issue_number", status.IssueNumber,
+				"pr_number", status.PRNumber,
+				"timeout", mergeQueueRegisterTimeout)
+			if q.metrics != nil {
+				q.metrics.RecordFailure(status.IssueNumber, status.PRNumber, "merge_queue_not_entered")
+			}
+		default:
+			q.update(status.PRNumber, entry)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// completeMerge はキューがマージしたPRのIssueのクローズを確認し、リソースをクリーンアップする
+func (q *MergeQueue) completeMerge(ctx context.Context, status MergeQueueStatus) {
+	issueNumber := status.IssueNumber
+	if issueNumber == 0 {
+		number, err := q.client.GetClosingIssueNumber(ctx, status.PRNumber)
+		if err != nil {
+			q.logger.Warn("Failed to get closing issue number", "pr_number", status.PRNumber, "error", err)
+		}
+		issueNumber = number
+	}
+	if issueNumber == 0 {
+		issueNumber = issueNumberFromBranch(status.HeadRefName)
+	}
+
+	q.logger.Info("Merge queue merged pull request",
+		"issue_number", issueNumber,
+		"pr_number", status.PRNumber,
+		"queued_for", q.clock.Since(status.EnqueuedAt).Truncate(time.Second))
+	if q.metrics != nil {
+		q.metrics.RecordSuccess(issueNumber, status.PRNumber)
+	}
+	if issueNumber == 0 {
+		return
+	}
+
+	if q.closure != nil {
+		if err := q.closure.Verify(ctx, issueNumber, status.PRNumber); err != nil {
+			q.logger.Warn("Failed to verify issue closure",
+				"issue_number", issueNumber,
+				"pr_number", status.PRNumber,
+				"error", err)
+		}
+	}
+	if err := q.cleanupManager.CleanupIssueResources(ctx, issueNumber); err != nil {
+		q.logger.Warn("Failed to cleanup resources after merge queue merged",
+			"issue_number", issueNumber,
+			"error", err)
+		return
+	}
+	q.branches.Cleanup(ctx, status.PRNumber, status.HeadRefName)
+}
+
+// Entries は追跡中のPRをPR番号順に返す
+func (q *MergeQueue) Entries() []MergeQueueStatus {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entries := make([]MergeQueueStatus, 0, len(q.entries))
+	for _, status := range q.entries {
+		entries = append(entries, *status)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].PRNumber < entries[j].PRNumber
+	})
+	return entries
+}
+
+func (q *MergeQueue) update(prNumber int, entry *github.MergeQueueEntry) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if status, ok := q.entries[prNumber]; ok {
+		status.State = entry.State
+		status.Position = entry.Position
+		status.UpdatedAt = q.clock.Now()
+	}
+}
+
+func (q *MergeQueue) untrack(prNumber int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.entries, prNumber)
+}
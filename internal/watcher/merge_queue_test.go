@@ -0,0 +1,185 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockMergeQueueClient はマージキューに対応したGitHubクライアントのモック
+type mockMergeQueueClient struct {
+	MockGitHubClient
+}
+
+func (m *mockMergeQueueClient) UsesMergeQueue(ctx context.Context, owner, repo, branch string) (bool, error) {
+	args := m.Called(ctx, owner, repo, branch)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockMergeQueueClient) EnqueuePullRequest(ctx context.Context, prNumber int) error {
+	args := m.Called(ctx, prNumber)
+	return args.Error(0)
+}
+
+func (m *mockMergeQueueClient) GetMergeQueueEntry(ctx context.Context, owner, repo string, prNumber int) (*gh.MergeQueueEntry, error) {
+	args := m.Called(ctx, owner, repo, prNumber)
+	return args.Get(0).(*gh.MergeQueueEntry), args.Error(1)
+}
+
+func TestMergeQueue_Enqueue(t *testing.T) {
+	tests := []struct {
+		name        string
+		entry       *gh.MergeQueueEntry
+		wantEnqueue bool
+	}{
+		{name: "キューにないPRを追加", entry: &gh.MergeQueueEntry{PRNumber: 25, PRState: "OPEN"}, wantEnqueue: true},
+		{name: "追加済みのPRは追跡のみ再開", entry: &gh.MergeQueueEntry{PRNumber: 25, PRState: "OPEN", State: "QUEUED", Position: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockMergeQueueClient)
+			client.On("GetMergeQueueEntry", mock.Anything, "owner", "repo", 25).Return(tt.entry, nil).Once()
+			if tt.wantEnqueue {
+				client.On("EnqueuePullRequest", mock.Anything, 25).Return(nil).Once()
+			}
+
+			q, err := NewMergeQueue(client, new(MockCleanupManager), "owner", "repo", config.NewConfig(), NewMockLogger())
+			require.NoError(t, err)
+
+			require.NoError(t, q.Enqueue(context.Background(), 12, &gh.PullRequest{Number: 25, HeadRefName: "osoba/#12"}))
+			client.AssertExpectations(t)
+			assert.True(t, q.Tracking(25))
+			entries := q.Entries()
+			require.Len(t, entries, 1)
+			assert.Equal(t, 12, entries[0].IssueNumber)
+			assert.Equal(t, tt.entry.State, entries[0].State)
+		})
+	}
+}
+
+func TestMergeQueue_CheckOnce(t *testing.T) {
+	tests := []struct {
+		name         string
+		issueNumber  int
+		lastState    string
+		enqueuedAgo  time.Duration
+		entry        *gh.MergeQueueEntry
+		wantTracking bool
+		wantCleanup  bool
+		wantNotify   bool
+	}{
+		{
+			name:        "キューがマージしたらクリーンアップ",
+			issueNumber: 12,
+			lastState:   "MERGEABLE",
+			entry:       &gh.MergeQueueEntry{PRNumber: 25, PRState: "MERGED"},
+			wantCleanup: true,
+		},
+		{
+			name:        "PRからIssueを特定できない場合はブランチ名から特定",
+			lastState:   "MERGEABLE",
+			entry:       &gh.MergeQueueEntry{PRNumber: 25, PRState: "MERGED"},
+			wantCleanup: true,
+		},
+		{
+			name:         "キュー内の状態を更新",
+			issueNumber:  12,
+			lastState:    "QUEUED",
+			entry:        &gh.MergeQueueEntry{PRNumber: 25, PRState: "OPEN", State: "AWAITING_CHECKS", Position: 1},
+			wantTracking: true,
+		},
+		{
+			name:         "チェックの完了前でキューに入っていない",
+			issueNumber:  12,
+			entry:        &gh.MergeQueueEntry{PRNumber: 25, PRState: "OPEN"},
+			wantTracking: true,
+		},
+		{
+			name:        "キューに入らないまま時間が経過したら追跡をやめる",
+			issueNumber: 12,
+			enqueuedAgo: mergeQueueRegisterTimeout + time.Minute,
+			entry:       &gh.MergeQueueEntry{PRNumber: 25, PRState: "OPEN"},
+		},
+		{
+			name:        "キューから外れたら追跡をやめて通知",
+			issueNumber: 12,
+			lastState:   "UNMERGEABLE",
+			entry:       &gh.MergeQueueEntry{PRNumber: 25, PRState: "OPEN"},
+			wantNotify:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockMergeQueueClient)
+			cleanupManager := new(MockCleanupManager)
+			notifier := &recordingNotifier{}
+			client.On("GetMergeQueueEntry", mock.Anything, "owner", "repo", 25).Return(tt.entry, nil).Once()
+			if tt.wantCleanup {
+				if tt.issueNumber == 0 {
+					client.On("GetClosingIssueNumber", mock.Anything, 25).Return(0, nil).Once()
+				}
+				cleanupManager.On("CleanupIssueResources", mock.Anything, 12).Return(nil).Once()
+			}
+
+			q, err := NewMergeQueue(client, cleanupManager, "owner", "repo", config.NewConfig(), NewMockLogger())
+			require.NoError(t, err)
+			q.SetNotifier(notifier)
+			fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+			q.clock = fake
+			q.entries[25] = &MergeQueueStatus{IssueNumber: tt.issueNumber, PRNumber: 25, HeadRefName: "osoba/#12", State: tt.lastState, EnqueuedAt: fake.Now()}
+			fake.Advance(tt.enqueuedAgo)
+
+			require.NoError(t, q.CheckOnce(context.Background()))
+			client.AssertExpectations(t)
+			cleanupManager.AssertExpectations(t)
+			assert.Equal(t, tt.wantTracking, q.Tracking(25))
+			if tt.wantTracking {
+				assert.Equal(t, tt.entry.State, q.Entries()[0].State)
+			}
+			if tt.wantNotify {
+				require.Len(t, notifier.events, 1)
+				assert.Equal(t, 12, notifier.events[0].IssueNumber)
+			} else {
+				assert.Empty(t, notifier.events)
+			}
+		})
+	}
+}
+
+func TestExecuteAutoMergeIfLGTM_EnqueuesToMergeQueue(t *testing.T) {
+	issue := &gh.Issue{
+		Number: intPtr(12),
+		Labels: []*gh.Label{{Name: stringPtr("status:lgtm")}},
+	}
+	pr := &gh.PullRequest{Number: 25, State: "OPEN", Mergeable: "MERGEABLE", HeadRefName: "osoba/#12"}
+
+	client := new(mockMergeQueueClient)
+	cleanupManager := new(MockCleanupManager)
+	client.On("GetPullRequestForIssue", mock.Anything, 12).Return(pr, nil)
+	client.On("GetPullRequestStatus", mock.Anything, 25).Return(pr, nil)
+	client.On("GetMergeQueueEntry", mock.Anything, "owner", "repo", 25).Return(&gh.MergeQueueEntry{PRNumber: 25, PRState: "OPEN"}, nil).Once()
+	client.On("EnqueuePullRequest", mock.Anything, 25).Return(nil).Once()
+
+	cfg := config.NewConfig()
+	q, err := NewMergeQueue(client, cleanupManager, "owner", "repo", cfg, NewMockLogger())
+	require.NoError(t, err)
+
+	// 1回目はキューに追加し、2回目はキューのマージを待つ
+	for i := 0; i < 2; i++ {
+		require.NoError(t, executeAutoMergeIfLGTMWithLogger(context.Background(), issue, cfg, client, cleanupManager, NewMockLogger(), nil, nil, nil, nil, q, nil, nil))
+	}
+
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "MergePullRequest", mock.Anything, mock.Anything)
+	cleanupManager.AssertNotCalled(t, "CleanupIssueResources", mock.Anything, mock.Anything)
+	assert.True(t, q.Tracking(25))
+}
@@ -0,0 +1,98 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewOptionalFeatures_RequireClientSupport はオプション機能のコンストラクタが、
+// 必要な操作に対応していないクライアントを受け取った場合にエラーを返すことを確認する
+func TestNewOptionalFeatures_RequireClientSupport(t *testing.T) {
+	cfg := config.NewConfig()
+	tests := []struct {
+		name string
+		new  func() error
+	}{
+		{name: "Assigner", new: func() error {
+			_, err := NewAssigner(new(MockGitHubClient), "owner", "repo", "osoba-bot", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "AutoMergePolicy", new: func() error {
+			_, err := NewAutoMergePolicy(new(MockGitHubClient), "owner", "repo", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "CIGate", new: func() error {
+			_, err := NewCIGate(new(MockGitHubClient), "owner", "repo", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "DuplicateDetector", new: func() error {
+			_, err := NewDuplicateDetector(new(mockCommentEditorClient), "owner", "repo", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "ExistingPRGuard", new: func() error {
+			_, err := NewExistingPRGuard(new(mockCommentEditorClient), "owner", "repo", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "HistoryGuard", new: func() error {
+			_, err := NewHistoryGuard(new(MockGitHubClient), "owner", "repo", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "IssueClosureVerifier", new: func() error {
+			_, err := NewIssueClosureVerifier(new(mockIssueCreatorClient), "owner", "repo", cfg, nil, NewMockLogger())
+			return err
+		}},
+		{name: "MergeQueue", new: func() error {
+			_, err := NewMergeQueue(new(MockGitHubClient), new(MockCleanupManager), "owner", "repo", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "PlanApprovalGate", new: func() error {
+			_, err := NewPlanApprovalGate(new(mockCommentEditorClient), "owner", "repo", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "PlanStalenessDetector", new: func() error {
+			_, err := NewPlanStalenessDetector(new(mockCommentEditorClient), "owner", "repo", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "ProgressReporter", new: func() error {
+			_, err := NewProgressReporter(new(MockGitHubClient), mocks.NewMockTmuxManager(), "owner", "repo", "osoba-repo", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "RepoDiscoverer", new: func() error {
+			_, err := NewRepoDiscoverer(new(MockGitHubClient), new(mockRepoRunner), newOrgTestConfig(), NewMockLogger())
+			return err
+		}},
+		{name: "RevertDetector", new: func() error {
+			_, err := NewRevertDetector(new(mockIssueCreatorClient), "owner", "repo", cfg, nil, NewMockLogger())
+			return err
+		}},
+		{name: "ReviewBots", new: func() error {
+			_, err := NewReviewBots(new(MockGitHubClient), "owner", "repo", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "ReviewEscalator", new: func() error {
+			_, err := NewReviewEscalator(new(MockGitHubClient), "owner", "repo", cfg, nil, NewMockLogger())
+			return err
+		}},
+		{name: "SubIssueExpander", new: func() error {
+			_, err := NewSubIssueExpander(new(mockCommentEditorClient), "owner", "repo", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "WorkQueue", new: func() error {
+			_, err := NewWorkQueue(new(MockGitHubClient), "owner", "repo", ".osoba/queue", cfg, NewMockLogger())
+			return err
+		}},
+		{name: "WorktreePrefetcher", new: func() error {
+			_, err := NewWorktreePrefetcher(mocks.NewMockGitWorktreeManager(), cfg, NewMockLogger())
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.new(), "does not support")
+		})
+	}
+}
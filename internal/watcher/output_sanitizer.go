@@ -0,0 +1,120 @@
+package watcher
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/douhashi/osoba/internal/config"
+)
+
+// redactedPlaceholder は伏せ字にした値の置き換え先
+const redactedPlaceholder = "[REDACTED]"
+
+// ansiEscapePattern はANSIエスケープシーケンス（CSI、OSC、その他の2文字のシーケンス）
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// builtinRedactPatterns は設定に関係なく伏せ字にするトークンなどの値
+var builtinRedactPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\b(?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36,}\b`), // GitHubのトークン
+	regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{22,}\b`),             // GitHubのfine-grainedトークン
+	regexp.MustCompile(`\bsk-ant-[A-Za-z0-9_-]{20,}`),                  // Anthropic APIキー
+	regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`),                         // AWSのアクセスキーID
+	regexp.MustCompile(`(?i)\b(?:bearer|token)\s+[A-Za-z0-9._~+/-]{20,}=*`),
+}
+
+// spinnerRunes はCLIの進捗表示で行頭に使われるスピナーの文字
+const spinnerRunes = "⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏✻✽✶✳✢·"
+
+// OutputSanitizer はペインの出力をIssue・PRのコメントとして投稿する前に整形する
+// ANSIエスケープシーケンスの除去、スピナーの行の集約、トークンなどの伏せ字、文字数の切り詰めを行う
+type OutputSanitizer struct {
+	patterns  []*regexp.Regexp
+	maxLength int
+}
+
+// NewOutputSanitizer は新しいOutputSanitizerを作成する
+func NewOutputSanitizer(cfg config.OutputSanitizerConfig) (*OutputSanitizer, error) {
+	patterns := append([]*regexp.Regexp{}, builtinRedactPatterns...)
+	for _, pattern := range cfg.RedactPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		patterns = append(patterns, re)
+	}
+
+	maxLength := cfg.MaxLength
+	if maxLength <= 0 {
+		maxLength = config.DefaultOutputMaxLength
+	}
+	return &OutputSanitizer{patterns: patterns, maxLength: maxLength}, nil
+}
+
+// Sanitize はペインの出力を投稿できる形に整形する
+func (s *OutputSanitizer) Sanitize(output string) string {
+	output = ansiEscapePattern.ReplaceAllString(output, "")
+	output = collapseSpinnerLines(output)
+	for _, re := range s.patterns {
+		output = re.ReplaceAllString(output, redactedPlaceholder)
+	}
+	return truncateOutputTail(output, s.maxLength)
+}
+
+// collapseSpinnerLines は復帰（\r）で上書きされた表示を最後の内容に置き換え、
+// スピナーの文字だけが異なる連続した行を最後の1行にまとめる
+func collapseSpinnerLines(output string) string {
+	lines := strings.Split(output, "\n")
+	collapsed := make([]string, 0, len(lines))
+	lastKey := ""
+	for _, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if i := strings.LastIndex(line, "\r"); i >= 0 {
+			line = line[i+1:]
+		}
+
+		key, isSpinner := spinnerLineKey(line)
+		if isSpinner && len(collapsed) > 0 && key == lastKey {
+			collapsed[len(collapsed)-1] = line
+			continue
+		}
+		collapsed = append(collapsed, line)
+		if isSpinner {
+			lastKey = key
+		} else {
+			lastKey = ""
+		}
+	}
+	return strings.Join(collapsed, "\n")
+}
+
+// spinnerLineKey はスピナーの行から、スピナーの文字と経過時間などの数字を除いた比較用の文字列を返す
+func spinnerLineKey(line string) (string, bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	r, size := utf8.DecodeRuneInString(trimmed)
+	if r == utf8.RuneError || !strings.ContainsRune(spinnerRunes, r) {
+		return "", false
+	}
+	rest := strings.TrimSpace(trimmed[size:])
+	if rest == "" {
+		return "", false
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return -1
+		}
+		return r
+	}, rest), true
+}
+
+// truncateOutputTail は出力がmaxLength文字を超える場合に末尾を残して切り詰める
+func truncateOutputTail(output string, maxLength int) string {
+	length := utf8.RuneCountInString(output)
+	if length <= maxLength {
+		return output
+	}
+	runes := []rune(output)
+	omitted := length - maxLength
+	return fmt.Sprintf("...（先頭の%d文字を省略）\n", omitted) + string(runes[omitted:])
+}
@@ -0,0 +1,296 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/tmux"
+)
+
+const (
+	// reaperActivityLines は出力の変化（ペインのアクティビティ）を検出するために取得する行数
+	reaperActivityLines = 20
+	// reaperCaptureLines は削除前に保存するペイン出力の最大行数
+	reaperCaptureLines = 5000
+)
+
+// paneActivity はペインごとのアクティビティ追跡状態
+type paneActivity struct {
+	output       string    // 前回取得した末尾の出力
+	lastActivity time.Time // 出力が最後に変化した時刻
+	completedAt  time.Time // フェーズの完了を最初に検出した時刻
+}
+
+// PaneReaper はフェーズ完了後に無操作のまま残ったペインの出力を保存して削除する
+// ウィンドウの最後のペインは削除しない（ウィンドウの削除はCleanupWatcherに任せる）
+type PaneReaper struct {
+	client      github.GitHubClient
+	tmuxManager tmux.Manager
+	owner       string
+	repo        string
+	sessionName string
+	outputDir   string
+	config      *config.Config
+	logger      logger.Logger
+
+	mu    sync.Mutex
+	panes map[string]*paneActivity
+	clock clock.Clock
+}
+
+// NewPaneReaper は新しいPaneReaperを作成する
+// outputDirには削除前に取得したペイン出力を保存する
+func NewPaneReaper(
+	client github.GitHubClient,
+	tmuxManager tmux.Manager,
+	owner, repo, sessionName, outputDir string,
+	cfg *config.Config,
+	logger logger.Logger,
+) (*PaneReaper, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if tmuxManager == nil {
+		return nil, errors.New("tmux manager is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if outputDir == "" {
+		return nil, errors.New("output directory is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+
+	return &PaneReaper{
+		client:      client,
+		tmuxManager: tmuxManager,
+		owner:       owner,
+		repo:        repo,
+		sessionName: sessionName,
+		outputDir:   outputDir,
+		config:      cfg,
+		logger:      logger,
+		panes:       make(map[string]*paneActivity),
+		clock:       clock.New(),
+	}, nil
+}
+
+// Start はペインの監視を開始する
+func (r *PaneReaper) Start(ctx context.Context) {
+	interval := r.config.GitHub.PollInterval
+	r.logger.Info("Starting pane reaper", "interval", interval)
+
+	ticker := r.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Pane reaper stopped")
+			return
+		case <-ticker.C():
+			if err := r.ReapOnce(ctx); err != nil {
+				r.logger.Warn("Failed to reap idle panes", "error", err)
+			}
+		}
+	}
+}
+
+// ReapOnce はIssueウィンドウ内のペインのアクティビティを更新し、削除対象のペインを削除する
+func (r *PaneReaper) ReapOnce(ctx context.Context) error {
+	phases := activeProgressPhases()
+	labels := make([]string, 0, len(phases))
+	for _, p := range phases {
+		labels = append(labels, p.label)
+	}
+	issues, err := r.client.ListIssuesByLabels(ctx, r.owner, r.repo, labels)
+	if err != nil {
+		return fmt.Errorf("failed to list in-progress issues: %w", err)
+	}
+	running := make(map[int]string)
+	for _, issue := range issues {
+		if issue == nil || issue.Number == nil {
+			continue
+		}
+		if phase, ok := findProgressPhase(issue); ok {
+			running[*issue.Number] = phase.label
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, sessionName := range r.config.Tmux.SessionNames(r.sessionName) {
+		// まだウィンドウが振り分けられていないシャードのセッションは存在しない
+		if i > 0 {
+			if exists, err := r.tmuxManager.SessionExists(sessionName); err == nil && !exists {
+				continue
+			}
+		}
+		windows, err := r.tmuxManager.ListWindows(sessionName)
+		if err != nil {
+			return fmt.Errorf("failed to list windows: %w", err)
+		}
+
+		for _, windowName := range windows {
+			issueNumber, err := tmux.ParseWindowNameForIssue(windowName)
+			if err != nil {
+				// フェーズ専用ウィンドウはペインが1つのため対象外
+				continue
+			}
+			if err := r.reapWindow(sessionName, issueNumber, windowName, running[issueNumber], seen); err != nil {
+				r.logger.Warn("Failed to reap panes in window",
+					"issue_number", issueNumber,
+					"session", sessionName,
+					"window", windowName,
+					"error", err)
+			}
+		}
+	}
+
+	// 存在しなくなったペインの追跡状態を破棄
+	r.mu.Lock()
+	for key := range r.panes {
+		if !seen[key] {
+			delete(r.panes, key)
+		}
+	}
+	r.mu.Unlock()
+
+	return nil
+}
+
+// reapWindow は1つのIssueウィンドウのペインを処理する
+// 削除によってペイン番号がずれないよう、後ろのペインから処理する
+func (r *PaneReaper) reapWindow(sessionName string, issueNumber int, windowName, runningLabel string, seen map[string]bool) error {
+	panes, err := r.tmuxManager.ListPanes(sessionName, windowName)
+	if err != nil {
+		return err
+	}
+
+	remaining := len(panes)
+	for i := len(panes) - 1; i >= 0; i-- {
+		pane := panes[i]
+		phase, ok := findReapablePane(pane.Title)
+		if !ok {
+			continue
+		}
+		reapAfter := r.config.Tmux.GetPhasePaneConfig(phase.configKey).ReapAfter
+		if reapAfter <= 0 {
+			continue
+		}
+
+		key := fmt.Sprintf("%s:%s:%d:%s", sessionName, windowName, pane.Index, pane.Title)
+		seen[key] = true
+		idleSince, completed := r.trackActivity(key, sessionName, windowName, pane.Index, phase.label != "" && phase.label == runningLabel)
+		if !completed || r.clock.Since(idleSince) < reapAfter || remaining <= 1 {
+			continue
+		}
+
+		if err := r.reapPane(sessionName, issueNumber, windowName, pane, phase, idleSince); err != nil {
+			return err
+		}
+		remaining--
+		r.mu.Lock()
+		delete(r.panes, key)
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+// trackActivity はペインの出力の変化とフェーズの完了を記録し、無操作となった時刻を返す
+// フェーズが実行中の場合はcompletedにfalseを返す
+func (r *PaneReaper) trackActivity(key, sessionName, windowName string, paneIndex int, running bool) (idleSince time.Time, completed bool) {
+	output, err := r.tmuxManager.CapturePane(sessionName, windowName, paneIndex, reaperActivityLines)
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, ok := r.panes[key]
+	if !ok {
+		state = &paneActivity{output: output, lastActivity: now}
+		r.panes[key] = state
+	} else if err == nil && output != state.output {
+		state.output = output
+		state.lastActivity = now
+	}
+
+	if running {
+		state.completedAt = time.Time{}
+		return time.Time{}, false
+	}
+	if state.completedAt.IsZero() {
+		state.completedAt = now
+	}
+
+	idleSince = state.completedAt
+	if state.lastActivity.After(idleSince) {
+		idleSince = state.lastActivity
+	}
+	return idleSince, true
+}
+
+// reapPane はペインの出力をファイルに保存してからペインを削除する
+// 出力を保存できなかった場合はペインを削除しない
+func (r *PaneReaper) reapPane(sessionName string, issueNumber int, windowName string, pane *tmux.PaneInfo, phase progressPhase, idleSince time.Time) error {
+	output, err := r.tmuxManager.CapturePane(sessionName, windowName, pane.Index, reaperCaptureLines)
+	if err != nil {
+		return fmt.Errorf("failed to capture pane output: %w", err)
+	}
+
+	if err := os.MkdirAll(r.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create pane output directory: %w", err)
+	}
+	path := filepath.Join(r.outputDir, fmt.Sprintf("issue-%d-%s-%s.log", issueNumber, phase.configKey, r.clock.Now().Format("20060102-150405")))
+	if err := os.WriteFile(path, []byte(output+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to save pane output: %w", err)
+	}
+
+	if err := r.tmuxManager.KillPane(sessionName, windowName, pane.Index); err != nil {
+		return err
+	}
+
+	r.logger.Info("Reaped idle pane",
+		"issue_number", issueNumber,
+		"window", windowName,
+		"pane_index", pane.Index,
+		"phase", phase.configKey,
+		"idle", r.clock.Since(idleSince).Truncate(time.Second),
+		"output", path)
+	return nil
+}
+
+// findPhaseByPaneTitle はペインタイトルからフェーズを特定する
+func findPhaseByPaneTitle(title string) (progressPhase, bool) {
+	for _, p := range activeProgressPhases() {
+		if p.paneTitle == title {
+			return p, true
+		}
+	}
+	return progressPhase{}, false
+}
+
+// scratchPane はosoba scratchで作成したScratchペイン（tmux.phases.scratch.reap_afterで削除する）
+// フェーズのラベルを持たないため、実行中として扱わず出力の変化のみで無操作を判定する
+var scratchPane = progressPhase{paneTitle: tmux.ScratchPaneTitle, configKey: "scratch"}
+
+// findReapablePane はペインタイトルから削除の対象となるフェーズまたはScratchペインを特定する
+func findReapablePane(title string) (progressPhase, bool) {
+	if title == scratchPane.paneTitle {
+		return scratchPane, true
+	}
+	return findPhaseByPaneTitle(title)
+}
@@ -0,0 +1,161 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newReaperTestConfig() *config.Config {
+	cfg := config.NewConfig()
+	cfg.Tmux.Phases = map[string]config.PhasePaneConfig{
+		"plan": {ReapAfter: 10 * time.Minute},
+	}
+	return cfg
+}
+
+func TestPaneReaper_ReapOnce(t *testing.T) {
+	implementing := &gh.Issue{
+		Number: intPtr(10),
+		Labels: []*gh.Label{{Name: stringPtr("status:implementing")}},
+	}
+	panes := []*tmux.PaneInfo{
+		{Index: 0, Title: "Plan"},
+		{Index: 1, Title: "Implementation"},
+	}
+
+	tests := []struct {
+		name     string
+		issues   []*gh.Issue
+		panes    []*tmux.PaneInfo
+		output   []string // 各ポーリングでのPlanペインの末尾出力
+		elapsed  time.Duration
+		wantReap bool
+	}{
+		{
+			name:     "完了後に無操作のまま経過 - 出力を保存して削除",
+			issues:   []*gh.Issue{implementing},
+			panes:    panes,
+			output:   []string{"done", "done"},
+			elapsed:  11 * time.Minute,
+			wantReap: true,
+		},
+		{
+			name:    "経過時間が足りない",
+			issues:  []*gh.Issue{implementing},
+			panes:   panes,
+			output:  []string{"done", "done"},
+			elapsed: 5 * time.Minute,
+		},
+		{
+			name:    "完了後も出力が変化している",
+			issues:  []*gh.Issue{implementing},
+			panes:   panes,
+			output:  []string{"done", "user typed"},
+			elapsed: 11 * time.Minute,
+		},
+		{
+			name: "フェーズが実行中",
+			issues: []*gh.Issue{{
+				Number: intPtr(10),
+				Labels: []*gh.Label{{Name: stringPtr("status:planning")}},
+			}},
+			panes:   panes,
+			output:  []string{"thinking", "thinking"},
+			elapsed: 11 * time.Minute,
+		},
+		{
+			name:    "ウィンドウの最後のペインは削除しない",
+			panes:   panes[:1],
+			output:  []string{"done", "done"},
+			elapsed: 11 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(MockGitHubClient)
+			tmuxManager := mocks.NewMockTmuxManager()
+			outputDir := filepath.Join(t.TempDir(), "panes")
+
+			client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", progressLabels).Return(tt.issues, nil)
+			tmuxManager.On("ListWindows", "osoba-repo").Return([]string{"issue-10", "10-review"}, nil)
+			tmuxManager.On("ListPanes", "osoba-repo", "issue-10").Return(tt.panes, nil)
+			for _, output := range tt.output {
+				tmuxManager.On("CapturePane", "osoba-repo", "issue-10", 0, reaperActivityLines).Return(output, nil).Once()
+			}
+			if tt.wantReap {
+				tmuxManager.On("CapturePane", "osoba-repo", "issue-10", 0, reaperCaptureLines).Return("full plan output", nil).Once()
+				tmuxManager.On("KillPane", "osoba-repo", "issue-10", 0).Return(nil).Once()
+			}
+
+			reaper, err := NewPaneReaper(client, tmuxManager, "owner", "repo", "osoba-repo", outputDir, newReaperTestConfig(), NewMockLogger())
+			require.NoError(t, err)
+			fakeClock := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+			reaper.clock = fakeClock
+
+			require.NoError(t, reaper.ReapOnce(context.Background()))
+			fakeClock.Advance(tt.elapsed)
+			require.NoError(t, reaper.ReapOnce(context.Background()))
+
+			tmuxManager.AssertExpectations(t)
+			files, _ := filepath.Glob(filepath.Join(outputDir, "*.log"))
+			if !tt.wantReap {
+				tmuxManager.AssertNotCalled(t, "KillPane", mock.Anything, mock.Anything, mock.Anything)
+				assert.Empty(t, files)
+				return
+			}
+			require.Len(t, files, 1)
+			assert.Equal(t, "issue-10-plan-20250101-121100.log", filepath.Base(files[0]))
+			content, err := os.ReadFile(files[0])
+			require.NoError(t, err)
+			assert.Equal(t, "full plan output\n", string(content))
+		})
+	}
+}
+
+func TestPaneReaper_ReapOnce_ScratchPane(t *testing.T) {
+	client := new(MockGitHubClient)
+	tmuxManager := mocks.NewMockTmuxManager()
+	outputDir := filepath.Join(t.TempDir(), "panes")
+	cfg := config.NewConfig()
+	cfg.Tmux.Phases = map[string]config.PhasePaneConfig{
+		"scratch": {ReapAfter: 30 * time.Minute},
+	}
+
+	// 実行中のフェーズがなくてもScratchペインは出力の変化のみで判定する
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", progressLabels).Return([]*gh.Issue{}, nil)
+	tmuxManager.On("ListWindows", "osoba-repo").Return([]string{"issue-10"}, nil)
+	tmuxManager.On("ListPanes", "osoba-repo", "issue-10").Return([]*tmux.PaneInfo{
+		{Index: 0, Title: "Implementation"},
+		{Index: 1, Title: tmux.ScratchPaneTitle},
+	}, nil)
+	tmuxManager.On("CapturePane", "osoba-repo", "issue-10", 1, reaperActivityLines).Return("$ go test ./...", nil).Twice()
+	tmuxManager.On("CapturePane", "osoba-repo", "issue-10", 1, reaperCaptureLines).Return("$ go test ./...\nok", nil).Once()
+	tmuxManager.On("KillPane", "osoba-repo", "issue-10", 1).Return(nil).Once()
+
+	reaper, err := NewPaneReaper(client, tmuxManager, "owner", "repo", "osoba-repo", outputDir, cfg, NewMockLogger())
+	require.NoError(t, err)
+	fakeClock := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	reaper.clock = fakeClock
+
+	require.NoError(t, reaper.ReapOnce(context.Background()))
+	fakeClock.Advance(31 * time.Minute)
+	require.NoError(t, reaper.ReapOnce(context.Background()))
+
+	tmuxManager.AssertExpectations(t)
+	files, _ := filepath.Glob(filepath.Join(outputDir, "*.log"))
+	require.Len(t, files, 1)
+	assert.Equal(t, "issue-10-scratch-20250101-123100.log", filepath.Base(files[0]))
+}
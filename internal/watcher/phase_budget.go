@@ -0,0 +1,185 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// launchGracePeriod は開始したフェーズの実行中ラベルがIssue一覧に反映されるまでの猶予
+const launchGracePeriod = time.Minute
+
+// estimateBodyPattern はIssueの本文の見積もりの記載（Estimate: L・**見積もり**: XLなど）
+var estimateBodyPattern = regexp.MustCompile(`(?im)^[\s>*_-]*(?:estimate|見積もり|見積り)[*_]*\s*[:：]\s*[*_]*([A-Za-z0-9]+)`)
+
+// phaseLaunch はPhaseBudgetが開始を許可したフェーズ
+type phaseLaunch struct {
+	phase  string
+	weight int
+	at     time.Time
+}
+
+// PhaseBudget はフェーズごとに同時に実行するIssueの数を上限までに抑える
+// 実行中のIssueは実行中ラベル（status:reviewingなど）から数えるため、軽いレビューと重い実装で別々の上限を設定できる
+// 上限に達したフェーズのIssueはラベルを変更しないため、実行中のIssueが減った後のポーリングで開始される
+// concurrency.estimatesを設定した場合は、Issueの数ではなくIssueの見積もりの重みの合計を上限と比べる
+type PhaseBudget struct {
+	client github.GitHubClient
+	owner  string
+	repo   string
+	config config.ConcurrencyConfig
+	logger logger.Logger
+	clock  clock.Clock
+
+	mu       sync.Mutex
+	launches map[int]phaseLaunch // 最近開始を許可したフェーズ（Issue番号ごと）
+}
+
+// NewPhaseBudget は新しいPhaseBudgetを作成する
+func NewPhaseBudget(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*PhaseBudget, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+
+	return &PhaseBudget{
+		client:   client,
+		owner:    owner,
+		repo:     repo,
+		config:   cfg.Concurrency,
+		logger:   logger,
+		clock:    clock.New(),
+		launches: make(map[int]phaseLaunch),
+	}, nil
+}
+
+// AllowLaunch はIssueのフェーズを開始してよいかを返す（開始してよい場合は実行中として記録する）
+// 実行中のIssueを取得できない場合は開始を妨げない
+// 重みが上限を超えるIssueは、実行中のIssueがない場合にのみ開始する
+func (b *PhaseBudget) AllowLaunch(ctx context.Context, issue *github.Issue, phase string) bool {
+	limit := b.config.Limit(phase)
+	if limit <= 0 || issue == nil || issue.Number == nil {
+		return true
+	}
+	issueNumber := *issue.Number
+
+	running := make(map[int]int) // 実行中のIssueの重み（Issue番号ごと）
+	if labels := executingLabels(phase); len(labels) > 0 {
+		issues, err := b.client.ListIssuesByLabels(ctx, b.owner, b.repo, labels)
+		if err != nil {
+			b.logger.Warn("Failed to count running phases, launching without checking the limit",
+				"issueNumber", issueNumber,
+				"phase", phase,
+				"error", err)
+			return true
+		}
+		for _, issue := range issues {
+			if issue != nil && issue.Number != nil {
+				running[*issue.Number] = b.weight(issue)
+			}
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// 開始直後のフェーズは実行中ラベルが一覧に反映されていないことがあるため、記録から補う
+	now := b.clock.Now()
+	for number, launch := range b.launches {
+		if now.Sub(launch.at) > launchGracePeriod {
+			delete(b.launches, number)
+			continue
+		}
+		if _, ok := running[number]; !ok && launch.phase == phase {
+			running[number] = launch.weight
+		}
+	}
+	delete(running, issueNumber)
+
+	used := 0
+	for _, weight := range running {
+		used += weight
+	}
+	weight := b.weight(issue)
+	if !b.config.UsesEstimates() {
+		// 見積もりを使わない場合は実行中のIssueの数を上限と比べる
+		used, weight = len(running), 1
+	}
+
+	if len(running) > 0 && used+weight > limit {
+		b.logger.Info("Deferring phase launch: phase concurrency limit reached",
+			"issueNumber", issueNumber,
+			"phase", phase,
+			"running", len(running),
+			"used", used,
+			"weight", weight,
+			"limit", limit)
+		return false
+	}
+
+	b.launches[issueNumber] = phaseLaunch{phase: phase, weight: weight, at: now}
+	return true
+}
+
+// weight はIssueの見積もりの重みを返す（見積もりを使わない場合は1）
+func (b *PhaseBudget) weight(issue *github.Issue) int {
+	if !b.config.UsesEstimates() {
+		return 1
+	}
+	return b.config.EstimateWeight(issueEstimate(issue))
+}
+
+// issueEstimate はIssueの見積もりを返す（estimate:<見積もり>ラベルを優先し、なければ本文の記載から取り出す）
+func issueEstimate(issue *github.Issue) string {
+	for _, label := range issue.Labels {
+		if label == nil || label.Name == nil {
+			continue
+		}
+		if size, ok := strings.CutPrefix(*label.Name, config.EstimateLabelPrefix); ok && size != "" {
+			return strings.TrimSpace(size)
+		}
+	}
+	if issue.Body != nil {
+		if m := estimateBodyPattern.FindStringSubmatch(*issue.Body); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// Forget はIssueのフェーズの開始の記録を破棄する（nilの場合は何もしない）
+func (b *PhaseBudget) Forget(issueNumber int) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.launches, issueNumber)
+}
+
+// executingLabels はフェーズの実行中に付与されるラベルを返す
+func executingLabels(phase string) []string {
+	var labels []string
+	for _, t := range workflow.Transitions {
+		if t.Phase == phase && t.Executing != "" {
+			labels = append(labels, t.Executing)
+		}
+	}
+	return labels
+}
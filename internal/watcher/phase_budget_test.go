@@ -0,0 +1,132 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newPhaseBudgetForTest(t *testing.T, client *MockGitHubClient, limits config.ConcurrencyConfig) (*PhaseBudget, *clock.Fake) {
+	t.Helper()
+	cfg := config.NewConfig()
+	cfg.Concurrency = limits
+	budget, err := NewPhaseBudget(client, "owner", "repo", cfg, NewMockLogger())
+	require.NoError(t, err)
+	fake := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	budget.clock = fake
+	return budget, fake
+}
+
+func TestPhaseBudget_AllowLaunch(t *testing.T) {
+	tests := []struct {
+		name    string
+		phase   string
+		running []*gh.Issue
+		listErr error
+		want    bool
+	}{
+		{name: "上限未満", phase: config.PhaseReview, running: []*gh.Issue{{Number: gh.Int(1)}}, want: true},
+		{name: "上限に達している", phase: config.PhaseReview, running: []*gh.Issue{{Number: gh.Int(1)}, {Number: gh.Int(2)}}, want: false},
+		{name: "自身は実行中に数えない", phase: config.PhaseReview, running: []*gh.Issue{{Number: gh.Int(1)}, {Number: gh.Int(10)}}, want: true},
+		{name: "実行中のIssueを取得できない", phase: config.PhaseReview, listErr: errors.New("api error"), want: true},
+		{name: "上限のないフェーズ", phase: config.PhasePlan, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(MockGitHubClient)
+			if tt.phase == config.PhaseReview {
+				client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:reviewing"}).
+					Return(tt.running, tt.listErr).Once()
+			}
+			budget, _ := newPhaseBudgetForTest(t, client, config.ConcurrencyConfig{Implement: 1, Review: 2})
+
+			assert.Equal(t, tt.want, budget.AllowLaunch(context.Background(), &gh.Issue{Number: gh.Int(10)}, tt.phase))
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPhaseBudget_CountsRecentLaunches(t *testing.T) {
+	client := new(MockGitHubClient)
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:implementing"}).
+		Return([]*gh.Issue{}, nil)
+	budget, fake := newPhaseBudgetForTest(t, client, config.ConcurrencyConfig{Implement: 1})
+
+	// 実行中ラベルが一覧に反映される前でも、開始したフェーズは実行中として数える
+	assert.True(t, budget.AllowLaunch(context.Background(), &gh.Issue{Number: gh.Int(1)}, config.PhaseImplement))
+	assert.False(t, budget.AllowLaunch(context.Background(), &gh.Issue{Number: gh.Int(2)}, config.PhaseImplement))
+
+	// 猶予を過ぎた記録は数えない
+	fake.Advance(launchGracePeriod + time.Second)
+	assert.True(t, budget.AllowLaunch(context.Background(), &gh.Issue{Number: gh.Int(2)}, config.PhaseImplement))
+}
+
+func TestPhaseBudget_AllowLaunch_Estimates(t *testing.T) {
+	sized := func(number int, label, body string) *gh.Issue {
+		issue := &gh.Issue{Number: gh.Int(number), Body: gh.String(body)}
+		if label != "" {
+			issue.Labels = []*gh.Label{{Name: gh.String(label)}}
+		}
+		return issue
+	}
+
+	tests := []struct {
+		name    string
+		issue   *gh.Issue
+		running []*gh.Issue
+		want    bool
+	}{
+		{
+			name:    "重みの合計が上限以内",
+			issue:   sized(10, "estimate:M", ""),
+			running: []*gh.Issue{sized(1, "estimate:S", ""), sized(2, "estimate:M", "")},
+			want:    true,
+		},
+		{
+			name:    "重みの合計が上限を超える",
+			issue:   sized(10, "estimate:XL", ""),
+			running: []*gh.Issue{sized(1, "estimate:XL", "")},
+			want:    false,
+		},
+		{
+			name:    "本文の見積もり",
+			issue:   sized(10, "", "## 概要\n\n**Estimate**: L\n"),
+			running: []*gh.Issue{sized(1, "", "見積もり: L"), sized(2, "estimate:M", "")},
+			want:    false,
+		},
+		{
+			name:    "見積もりのないIssueはdefault_estimateで数える",
+			issue:   sized(10, "", ""),
+			running: []*gh.Issue{sized(1, "estimate:L", ""), sized(2, "", "")},
+			want:    true,
+		},
+		{
+			name:  "上限を超える重みでも実行中のIssueがなければ開始する",
+			issue: sized(10, "estimate:XXL", ""),
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(MockGitHubClient)
+			client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:implementing"}).
+				Return(tt.running, nil).Once()
+			budget, _ := newPhaseBudgetForTest(t, client, config.ConcurrencyConfig{
+				Implement: 8,
+				Estimates: map[string]int{"s": 1, "m": 2, "l": 4, "xl": 5, "xxl": 13},
+			})
+
+			assert.Equal(t, tt.want, budget.AllowLaunch(context.Background(), tt.issue, config.PhaseImplement))
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/watcher/actions"
+)
+
+// PhaseResultWatcher は実行中フェーズのworktreeに書き出された結果ファイル（.osoba/result.json）を読み込み、
+// その内容でラベルを遷移して結果をIssueにコメントする
+// 結果ファイルを書き出さないフェーズは従来どおりエージェント自身のラベル操作で遷移する
+type PhaseResultWatcher struct {
+	client          github.GitHubClient
+	worktreeManager git.WorktreeManager
+	owner           string
+	repo            string
+	config          *config.Config
+	logger          logger.Logger
+	clock           clock.Clock
+}
+
+// NewPhaseResultWatcher は新しいPhaseResultWatcherを作成する
+func NewPhaseResultWatcher(
+	client github.GitHubClient,
+	worktreeManager git.WorktreeManager,
+	owner, repo string,
+	cfg *config.Config,
+	logger logger.Logger,
+) (*PhaseResultWatcher, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if worktreeManager == nil {
+		return nil, errors.New("worktree manager is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+
+	return &PhaseResultWatcher{
+		client:          client,
+		worktreeManager: worktreeManager,
+		owner:           owner,
+		repo:            repo,
+		config:          cfg,
+		logger:          logger,
+		clock:           clock.New(),
+	}, nil
+}
+
+// Start は結果ファイルの監視を開始する
+func (w *PhaseResultWatcher) Start(ctx context.Context) {
+	interval := w.config.GitHub.PollInterval
+	w.logger.Info("Starting phase result watcher", "interval", interval)
+
+	ticker := w.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Phase result watcher stopped")
+			return
+		case <-ticker.C():
+			if err := w.CheckOnce(ctx); err != nil {
+				w.logger.Warn("Failed to check phase results", "error", err)
+			}
+		}
+	}
+}
+
+// CheckOnce は実行中フェーズのIssueすべてについて結果ファイルを確認する
+func (w *PhaseResultWatcher) CheckOnce(ctx context.Context) error {
+	phases := activeProgressPhases()
+	labels := make([]string, 0, len(phases))
+	for _, p := range phases {
+		labels = append(labels, p.label)
+	}
+
+	issues, err := w.client.ListIssuesByLabels(ctx, w.owner, w.repo, labels)
+	if err != nil {
+		return fmt.Errorf("failed to list in-progress issues: %w", err)
+	}
+
+	for _, issue := range issues {
+		if issue == nil || issue.Number == nil {
+			continue
+		}
+		phase, ok := findProgressPhase(issue)
+		if !ok {
+			continue
+		}
+		if err := w.handleIssue(ctx, *issue.Number, phase, hasLabel(issue, ManualLabel)); err != nil {
+			w.logger.Warn("Failed to handle phase result",
+				"issue_number", *issue.Number,
+				"phase", phase.label,
+				"error", err)
+		}
+	}
+	return nil
+}
+
+// handleIssue は1件のIssueの結果ファイルを処理する
+// ラベルの遷移に失敗した場合は結果ファイルを残し、次回のポーリングで再試行する
+// 手動対応中のIssueは、失敗の結果でstatus:manualを付与した後の再試行のみ処理する
+func (w *PhaseResultWatcher) handleIssue(ctx context.Context, issueNumber int, phase progressPhase, manual bool) error {
+	worktreePath := w.worktreeManager.GetWorktreePathForIssue(issueNumber)
+	result, err := actions.ReadPhaseResult(worktreePath)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	if manual && result.Status != actions.PhaseResultFailure {
+		return nil
+	}
+
+	nextLabel := phaseResultNextLabel(w.config, phase, result)
+	// ラベルの遷移を無効にしている場合は結果のコメントのみ行う
+	if !w.config.Features.AutoTransitionLabels {
+		nextLabel = ""
+	}
+	w.logger.Info("Phase result found",
+		"issue_number", issueNumber,
+		"phase", phase.configKey,
+		"status", result.Status,
+		"next_label", nextLabel)
+
+	if nextLabel == ManualLabel && result.Status == actions.PhaseResultFailure {
+		if err := w.pauseAtTrigger(ctx, issueNumber, phase, manual); err != nil {
+			return err
+		}
+	} else if nextLabel != "" {
+		if err := w.client.TransitionLabels(ctx, w.owner, w.repo, issueNumber, phase.label, nextLabel); err != nil {
+			return fmt.Errorf("failed to transition labels: %w", err)
+		}
+	}
+
+	body := buildPhaseResultComment(w.config, issueNumber, phase, result, nextLabel)
+	if err := w.client.CreateIssueComment(ctx, w.owner, w.repo, issueNumber, body); err != nil {
+		w.logger.Warn("Failed to post phase result comment", "issue_number", issueNumber, "error", err)
+	}
+
+	return actions.RemovePhaseResult(worktreePath)
+}
+
+// pauseAtTrigger は失敗したフェーズのIssueにstatus:manualを付与し、実行中ラベルをトリガーラベルに戻す
+// osoba releaseでstatus:manualを外すと、同じフェーズを次回のポーリングで開始できる
+// 先にstatus:manualを付与するため、トリガーラベルに戻した時点でフェーズが再開されることはない
+func (w *PhaseResultWatcher) pauseAtTrigger(ctx context.Context, issueNumber int, phase progressPhase, manual bool) error {
+	if !manual {
+		if err := w.client.AddLabel(ctx, w.owner, w.repo, issueNumber, ManualLabel); err != nil {
+			return fmt.Errorf("failed to add %s: %w", ManualLabel, err)
+		}
+	}
+	t, ok := workflow.FindByPhase(phase.configKey)
+	if !ok || t.From == "" {
+		return w.client.RemoveLabel(ctx, w.owner, w.repo, issueNumber, phase.label)
+	}
+	if err := w.client.TransitionLabels(ctx, w.owner, w.repo, issueNumber, phase.label, t.From); err != nil {
+		return fmt.Errorf("failed to transition labels: %w", err)
+	}
+	return nil
+}
+
+// phaseResultNextLabel は結果から次に付与するラベルを決定する（ラベルを変更しない場合は空文字列）
+// 成功した場合はnext_phaseの指定、指定がなければworkflowのnext、フェーズごとの既定の遷移先の順に使用する
+// レビューフェーズは結果（LGTM・修正依頼）によって遷移先が異なるため、next_phaseの指定がなければ変更しない
+func phaseResultNextLabel(cfg *config.Config, phase progressPhase, result *actions.PhaseResult) string {
+	if result.Status == actions.PhaseResultFailure {
+		return cfg.GitHub.PhaseResult.FailureLabel
+	}
+
+	next := result.NextPhase
+	if next == "" {
+		// workflowでフェーズの成功後のラベルが定義されていればそれを使用する
+		if t, ok := workflow.FindByPhase(phase.configKey); ok && t.Next != "" {
+			return t.Next
+		}
+		switch phase.configKey {
+		case "plan":
+			next = "implement"
+		case "implement", "revise":
+			next = "review"
+		}
+	}
+
+	labels := cfg.GitHub.Labels
+	switch next {
+	case "plan":
+		return labels.Plan
+	case "implement":
+		return labels.Ready
+	case "review":
+		return labels.Review
+	case "revise":
+		return labels.RequiresChanges
+	}
+	return ""
+}
+
+// buildPhaseResultComment はフェーズの結果のコメント本文を生成する
+func buildPhaseResultComment(cfg *config.Config, issueNumber int, phase progressPhase, result *actions.PhaseResult, nextLabel string) string {
+	next := "（変更なし）"
+	if nextLabel != "" {
+		next = "`" + nextLabel + "`"
+	}
+
+	var artifacts string
+	if len(result.Artifacts) > 0 {
+		var b strings.Builder
+		b.WriteString("\n#### 成果物\n\n")
+		for _, artifact := range result.Artifacts {
+			fmt.Fprintf(&b, "- %s\n", artifact)
+		}
+		artifacts = b.String()
+	}
+
+	return cfg.RenderComment(config.CommentPhaseResult, map[string]string{
+		"issue-number": fmt.Sprintf("%d", issueNumber),
+		"phase":        phase.configKey,
+		"status":       result.Status,
+		"next-label":   next,
+		"summary":      result.Summary,
+		"artifacts":    artifacts,
+	})
+}
@@ -0,0 +1,165 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	"github.com/douhashi/osoba/internal/watcher/actions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writePhaseResult(t *testing.T, worktreePath, content string) {
+	t.Helper()
+	path := filepath.Join(worktreePath, actions.PhaseResultFile)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestPhaseResultWatcher_CheckOnce(t *testing.T) {
+	tests := []struct {
+		name       string
+		label      string
+		result     string
+		wantLabel  string
+		manual     bool // status:manualが付与済み
+		wantManual bool // status:manualを付与する
+		wantRemain bool
+		noLabels   bool // features.auto_transition_labelsを無効にする
+	}{
+		{
+			name:      "計画フェーズの成功は実装待ちに遷移",
+			label:     "status:planning",
+			result:    `{"status":"success","summary":"計画を作成しました"}`,
+			wantLabel: "status:ready",
+		},
+		{
+			name:      "next_phaseの指定を優先",
+			label:     "status:reviewing",
+			result:    `{"status":"success","next_phase":"revise"}`,
+			wantLabel: "status:requires-changes",
+		},
+		{
+			name:   "レビューフェーズはnext_phaseがなければラベルを変更しない",
+			label:  "status:reviewing",
+			result: `{"status":"success","summary":"LGTM"}`,
+		},
+		{
+			name:       "失敗した場合はstatus:manualを付与してトリガーラベルに戻す",
+			label:      "status:implementing",
+			result:     `{"status":"failure","summary":"テストが通りません"}`,
+			wantLabel:  "status:ready",
+			wantManual: true,
+		},
+		{
+			name:      "status:manualの付与後にトリガーラベルへの遷移を再試行",
+			label:     "status:implementing",
+			manual:    true,
+			result:    `{"status":"failure","summary":"テストが通りません"}`,
+			wantLabel: "status:ready",
+		},
+		{
+			name:       "手動対応中のIssueの成功の結果は処理しない",
+			label:      "status:planning",
+			manual:     true,
+			result:     `{"status":"success","summary":"計画を作成しました"}`,
+			wantRemain: true,
+		},
+		{
+			name:     "ラベルの遷移を無効にしている場合はコメントのみ",
+			label:    "status:planning",
+			result:   `{"status":"success","summary":"計画を作成しました"}`,
+			noLabels: true,
+		},
+		{
+			name:       "不正な結果ファイルは処理しない",
+			label:      "status:implementing",
+			result:     `{"status":"done"}`,
+			wantRemain: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			worktreePath := t.TempDir()
+			writePhaseResult(t, worktreePath, tt.result)
+
+			client := new(MockGitHubClient)
+			worktreeManager := mocks.NewMockGitWorktreeManager()
+			issue := &gh.Issue{Number: intPtr(7), Labels: []*gh.Label{{Name: stringPtr(tt.label)}}}
+			if tt.manual {
+				issue.Labels = append(issue.Labels, &gh.Label{Name: stringPtr(ManualLabel)})
+			}
+			client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", progressLabels).Return([]*gh.Issue{issue}, nil)
+			worktreeManager.On("GetWorktreePathForIssue", 7).Return(worktreePath)
+			if tt.wantManual {
+				client.On("AddLabel", mock.Anything, "owner", "repo", 7, ManualLabel).Return(nil).Once()
+			}
+			if tt.wantLabel != "" {
+				client.On("TransitionLabels", mock.Anything, "owner", "repo", 7, tt.label, tt.wantLabel).Return(nil).Once()
+			}
+			if !tt.wantRemain {
+				client.On("CreateIssueComment", mock.Anything, "owner", "repo", 7, mock.Anything).Return(nil).Once()
+			}
+
+			cfg := config.NewConfig()
+			cfg.Features.AutoTransitionLabels = !tt.noLabels
+			w, err := NewPhaseResultWatcher(client, worktreeManager, "owner", "repo", cfg, NewMockLogger())
+			require.NoError(t, err)
+			require.NoError(t, w.CheckOnce(context.Background()))
+
+			client.AssertExpectations(t)
+			if !tt.wantManual {
+				client.AssertNotCalled(t, "AddLabel", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.wantLabel == "" {
+				client.AssertNotCalled(t, "TransitionLabels", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			_, err = os.Stat(filepath.Join(worktreePath, actions.PhaseResultFile))
+			assert.Equal(t, tt.wantRemain, err == nil)
+		})
+	}
+
+	t.Run("ラベルの遷移に失敗した場合は結果ファイルを残す", func(t *testing.T) {
+		worktreePath := t.TempDir()
+		writePhaseResult(t, worktreePath, `{"status":"success"}`)
+
+		client := new(MockGitHubClient)
+		worktreeManager := mocks.NewMockGitWorktreeManager()
+		issue := &gh.Issue{Number: intPtr(7), Labels: []*gh.Label{{Name: stringPtr("status:implementing")}}}
+		client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", progressLabels).Return([]*gh.Issue{issue}, nil)
+		worktreeManager.On("GetWorktreePathForIssue", 7).Return(worktreePath)
+		client.On("TransitionLabels", mock.Anything, "owner", "repo", 7, "status:implementing", "status:review-requested").Return(errors.New("api error")).Once()
+
+		w, err := NewPhaseResultWatcher(client, worktreeManager, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+		require.NoError(t, w.CheckOnce(context.Background()))
+
+		client.AssertNotCalled(t, "CreateIssueComment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		assert.FileExists(t, filepath.Join(worktreePath, actions.PhaseResultFile))
+	})
+}
+
+func TestBuildPhaseResultComment(t *testing.T) {
+	result := &actions.PhaseResult{
+		Status:    actions.PhaseResultSuccess,
+		Summary:   "実装しました",
+		Artifacts: []string{"https://github.com/owner/repo/pull/8"},
+	}
+	phase, _ := findProgressPhase(&gh.Issue{Labels: []*gh.Label{{Name: stringPtr("status:implementing")}}})
+
+	body := buildPhaseResultComment(config.NewConfig(), 7, phase, result, "status:review-requested")
+
+	assert.Contains(t, body, "implement の結果")
+	assert.Contains(t, body, "`success`")
+	assert.Contains(t, body, "`status:review-requested`")
+	assert.Contains(t, body, "実装しました")
+	assert.Contains(t, body, "- https://github.com/owner/repo/pull/8")
+}
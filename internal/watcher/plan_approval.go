@@ -0,0 +1,265 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+const (
+	// planApprovalCommentMarker は計画の承認待ちの通知コメントを識別するためのマーカー
+	planApprovalCommentMarker = "<!-- osoba:plan-approval -->"
+	// planCommentHeading は計画フェーズで投稿される実行計画コメントの見出し
+	planCommentHeading = "# 実行計画"
+)
+
+// approverPermissions は承認者の指定がない場合に承認できる権限
+var approverPermissions = []string{"admin", "maintain", "write"}
+
+// reactionEmojis は通知コメントに表示するリアクションの絵文字
+var reactionEmojis = map[string]string{
+	"+1":       "👍",
+	"-1":       "👎",
+	"laugh":    "😄",
+	"confused": "😕",
+	"heart":    "❤️",
+	"hooray":   "🎉",
+	"rocket":   "🚀",
+	"eyes":     "👀",
+}
+
+// PlanApprovalGate は計画コメントへのメンテナーの承認があるまで実装フェーズの開始を保留する
+type PlanApprovalGate struct {
+	client github.GitHubClient
+	owner  string
+	repo   string
+	config *config.Config
+	logger logger.Logger
+
+	mu          sync.Mutex
+	permissions map[string]bool // ユーザーごとの承認権限の有無（承認者の指定がない場合のみ使用）
+}
+
+// NewPlanApprovalGate は新しいPlanApprovalGateを作成する
+func NewPlanApprovalGate(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*PlanApprovalGate, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if _, ok := client.(github.IssueCommentEditor); !ok {
+		return nil, errors.New("github client does not support editing issue comments")
+	}
+	if _, ok := client.(github.PlanApprovalChecker); !ok {
+		return nil, errors.New("github client does not support listing comment reactions")
+	}
+
+	return &PlanApprovalGate{
+		client:      client,
+		owner:       owner,
+		repo:        repo,
+		config:      cfg,
+		logger:      logger,
+		permissions: make(map[string]bool),
+	}, nil
+}
+
+// IsApproved は実装待ちのIssueの計画が承認済みかを確認する
+// 未承認の場合は承認方法の通知コメントを計画ごとに1回投稿してfalseを返し、呼び出し側は実装フェーズを開始しない
+// 実行計画コメントがない場合（手動で実装待ちにした場合など）は承認済みとして扱う
+func (g *PlanApprovalGate) IsApproved(ctx context.Context, issue *github.Issue) (bool, error) {
+	if issue == nil || issue.Number == nil || !hasLabel(issue, g.config.GitHub.Labels.Ready) {
+		return true, nil
+	}
+	number := *issue.Number
+
+	comments, err := g.client.(github.IssueCommentEditor).ListIssueComments(ctx, g.owner, g.repo, number)
+	if err != nil {
+		return false, fmt.Errorf("failed to list comments: %w", err)
+	}
+	planIndex := findPlanComment(comments)
+	if planIndex < 0 {
+		return true, nil
+	}
+	plan := comments[planIndex]
+
+	approver, err := g.findApproval(ctx, plan, comments[planIndex+1:])
+	if err != nil {
+		return false, err
+	}
+	if approver != "" {
+		g.logger.Info("Plan approved",
+			"issue_number", number,
+			"approver", approver)
+		return true, nil
+	}
+
+	g.logger.Debug("Waiting for plan approval", "issue_number", number)
+	for _, c := range comments[planIndex+1:] {
+		if c.Body != nil && strings.HasPrefix(*c.Body, planApprovalCommentMarker) {
+			return false, nil
+		}
+	}
+	if err := g.client.CreateIssueComment(ctx, g.owner, g.repo, number, buildPlanApprovalComment(g.config, number, plan)); err != nil {
+		return false, fmt.Errorf("failed to post plan approval comment: %w", err)
+	}
+	return false, nil
+}
+
+// findApproval は計画コメントへのリアクションまたは以降の承認コメントから承認者を探す（見つからない場合は空文字）
+func (g *PlanApprovalGate) findApproval(ctx context.Context, plan *github.IssueComment, later []*github.IssueComment) (string, error) {
+	approval := g.config.GitHub.PlanApproval
+
+	if approval.Reaction != "" && plan.ID != nil {
+		reactions, err := g.client.(github.PlanApprovalChecker).ListCommentReactions(ctx, g.owner, g.repo, *plan.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to list plan comment reactions: %w", err)
+		}
+		for _, r := range reactions {
+			if r.Content == nil || *r.Content != approval.Reaction || r.User == nil || r.User.Login == nil {
+				continue
+			}
+			ok, err := g.canApprove(ctx, *r.User.Login)
+			if err != nil {
+				return "", err
+			}
+			if ok {
+				return *r.User.Login, nil
+			}
+		}
+	}
+
+	if strings.TrimSpace(approval.Comment) != "" {
+		for _, c := range later {
+			if c.Body == nil || c.User == nil || c.User.Login == nil || !isApprovalComment(*c.Body, approval.Comment) {
+				continue
+			}
+			ok, err := g.canApprove(ctx, *c.User.Login)
+			if err != nil {
+				return "", err
+			}
+			if ok {
+				return *c.User.Login, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// canApprove はユーザーが計画を承認できるかを判定する
+// 承認者が指定されていない場合はwrite権限以上のユーザーを承認者とする
+func (g *PlanApprovalGate) canApprove(ctx context.Context, login string) (bool, error) {
+	if approvers := g.config.GitHub.PlanApproval.Approvers; len(approvers) > 0 {
+		for _, a := range approvers {
+			if strings.EqualFold(a, login) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	g.mu.Lock()
+	ok, cached := g.permissions[login]
+	g.mu.Unlock()
+	if cached {
+		return ok, nil
+	}
+
+	permission, err := g.client.(github.PlanApprovalChecker).GetCollaboratorPermission(ctx, g.owner, g.repo, login)
+	if err != nil {
+		return false, fmt.Errorf("failed to get permission of %s: %w", login, err)
+	}
+	ok = false
+	for _, p := range approverPermissions {
+		if permission == p {
+			ok = true
+			break
+		}
+	}
+
+	g.mu.Lock()
+	g.permissions[login] = ok
+	g.mu.Unlock()
+	return ok, nil
+}
+
+// findPlanComment は最新の実行計画コメントの位置を返す（見つからない場合は-1）
+func findPlanComment(comments []*github.IssueComment) int {
+	for i := len(comments) - 1; i >= 0; i-- {
+		c := comments[i]
+		if c.Body == nil || strings.HasPrefix(*c.Body, osobaCommentPrefix) {
+			continue
+		}
+		for _, line := range strings.Split(*c.Body, "\n") {
+			if strings.HasPrefix(strings.TrimSpace(line), planCommentHeading) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// isApprovalComment はコメントの1行目が承認コメントと一致するかを判定する（大文字小文字は区別しない）
+// 「/approve 問題ありません」のように承認コメントに続けて補足を書いてもよい
+func isApprovalComment(body, approval string) bool {
+	firstLine := strings.TrimSpace(strings.SplitN(strings.TrimSpace(body), "\n", 2)[0])
+	approval = strings.TrimSpace(approval)
+	if strings.EqualFold(firstLine, approval) {
+		return true
+	}
+	return len(firstLine) > len(approval) &&
+		strings.EqualFold(firstLine[:len(approval)], approval) &&
+		firstLine[len(approval)] == ' '
+}
+
+// buildPlanApprovalComment は計画の承認待ちの通知コメントを生成する
+func buildPlanApprovalComment(cfg *config.Config, number int, plan *github.IssueComment) string {
+	approval := cfg.GitHub.PlanApproval
+
+	var methods strings.Builder
+	if approval.Reaction != "" {
+		emoji, ok := reactionEmojis[approval.Reaction]
+		if !ok {
+			emoji = approval.Reaction
+		}
+		fmt.Fprintf(&methods, "- 計画コメントに %s のリアクションを付ける\n", emoji)
+	}
+	if comment := strings.TrimSpace(approval.Comment); comment != "" {
+		fmt.Fprintf(&methods, "- `%s` とコメントする\n", comment)
+	}
+
+	approvers := "write権限以上のユーザー"
+	if len(approval.Approvers) > 0 {
+		names := make([]string, len(approval.Approvers))
+		for i, a := range approval.Approvers {
+			names[i] = "@" + a
+		}
+		approvers = strings.Join(names, " ")
+	}
+
+	planURL := ""
+	if plan.HTMLURL != nil {
+		planURL = *plan.HTMLURL
+	}
+
+	return planApprovalCommentMarker + "\n" + cfg.RenderComment(config.CommentPlanApprovalPending, map[string]string{
+		"issue-number": strconv.Itoa(number),
+		"plan-url":     planURL,
+		"approvers":    approvers,
+		"methods":      methods.String(),
+	})
+}
@@ -0,0 +1,172 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPlanApprovalClient はリアクション・権限の取得とコメント編集に対応したGitHubクライアントのモック
+type mockPlanApprovalClient struct {
+	mockCommentEditorClient
+}
+
+func (m *mockPlanApprovalClient) ListCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*gh.Reaction, error) {
+	args := m.Called(ctx, owner, repo, commentID)
+	return args.Get(0).([]*gh.Reaction), args.Error(1)
+}
+
+func (m *mockPlanApprovalClient) GetCollaboratorPermission(ctx context.Context, owner, repo, username string) (string, error) {
+	args := m.Called(ctx, owner, repo, username)
+	return args.String(0), args.Error(1)
+}
+
+func planComment(id int64, login string) *gh.IssueComment {
+	return &gh.IssueComment{
+		ID:      gh.Int64(id),
+		Body:    gh.String("# 実行計画: ログイン画面を追加する\n\n## 実装手順\n"),
+		User:    &gh.User{Login: gh.String(login)},
+		HTMLURL: gh.String(fmt.Sprintf("https://github.com/owner/repo/issues/40#issuecomment-%d", id)),
+	}
+}
+
+func userComment(login, body string) *gh.IssueComment {
+	return &gh.IssueComment{Body: gh.String(body), User: &gh.User{Login: gh.String(login)}}
+}
+
+func reaction(content, login string) *gh.Reaction {
+	return &gh.Reaction{Content: gh.String(content), User: &gh.User{Login: gh.String(login)}}
+}
+
+func TestIsApprovalComment(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{name: "完全一致", body: "/approve", want: true},
+		{name: "大文字小文字と前後の空白は無視", body: "  /APPROVE\n", want: true},
+		{name: "補足付き", body: "/approve 問題ありません", want: true},
+		{name: "別のコマンド", body: "/approved", want: false},
+		{name: "2行目以降は対象外", body: "確認しました\n/approve", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isApprovalComment(tt.body, "/approve"))
+		})
+	}
+}
+
+func TestPlanApprovalGate_IsApproved(t *testing.T) {
+	readyIssue := &gh.Issue{
+		Number: intPtr(40),
+		Labels: []*gh.Label{{Name: stringPtr("status:ready")}},
+	}
+
+	t.Run("write権限以上のユーザーのリアクションで承認", func(t *testing.T) {
+		client := new(mockPlanApprovalClient)
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 40).Return([]*gh.IssueComment{planComment(100, "osoba-bot")}, nil).Once()
+		client.On("ListCommentReactions", mock.Anything, "owner", "repo", int64(100)).Return([]*gh.Reaction{
+			reaction("+1", "outsider"),
+			reaction("eyes", "maintainer"),
+			reaction("+1", "maintainer"),
+		}, nil).Once()
+		client.On("GetCollaboratorPermission", mock.Anything, "owner", "repo", "outsider").Return("read", nil).Once()
+		client.On("GetCollaboratorPermission", mock.Anything, "owner", "repo", "maintainer").Return("maintain", nil).Once()
+
+		gate, err := NewPlanApprovalGate(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		approved, err := gate.IsApproved(context.Background(), readyIssue)
+		require.NoError(t, err)
+		assert.True(t, approved)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("指定された承認者の承認コメントで承認", func(t *testing.T) {
+		cfg := config.NewConfig()
+		cfg.GitHub.PlanApproval.Approvers = []string{"Alice"}
+
+		client := new(mockPlanApprovalClient)
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 40).Return([]*gh.IssueComment{
+			userComment("alice", "/approve"), // 計画より前のコメントは対象外
+			planComment(100, "osoba-bot"),
+			userComment("bob", "/approve"),
+			userComment("alice", "/approve LGTM"),
+		}, nil)
+		client.On("ListCommentReactions", mock.Anything, "owner", "repo", int64(100)).Return([]*gh.Reaction{reaction("+1", "bob")}, nil).Once()
+
+		gate, err := NewPlanApprovalGate(client, "owner", "repo", cfg, NewMockLogger())
+		require.NoError(t, err)
+
+		approved, err := gate.IsApproved(context.Background(), readyIssue)
+		require.NoError(t, err)
+		assert.True(t, approved)
+		client.AssertNotCalled(t, "GetCollaboratorPermission", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("未承認の場合は承認方法を一度だけ通知する", func(t *testing.T) {
+		client := new(mockPlanApprovalClient)
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 40).Return([]*gh.IssueComment{planComment(100, "osoba-bot")}, nil).Once()
+		client.On("ListCommentReactions", mock.Anything, "owner", "repo", int64(100)).Return([]*gh.Reaction{}, nil)
+		client.On("CreateIssueComment", mock.Anything, "owner", "repo", 40, mock.MatchedBy(func(body string) bool {
+			return strings.HasPrefix(body, planApprovalCommentMarker) &&
+				strings.Contains(body, "(https://github.com/owner/repo/issues/40#issuecomment-100)") &&
+				strings.Contains(body, "- 計画コメントに 👍 のリアクションを付ける") &&
+				strings.Contains(body, "- `/approve` とコメントする") &&
+				strings.Contains(body, "write権限以上のユーザー")
+		})).Return(nil).Once()
+
+		gate, err := NewPlanApprovalGate(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		approved, err := gate.IsApproved(context.Background(), readyIssue)
+		require.NoError(t, err)
+		assert.False(t, approved)
+
+		// 通知済みの場合は再投稿しない
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 40).Return([]*gh.IssueComment{
+			planComment(100, "osoba-bot"),
+			userComment("osoba-bot", planApprovalCommentMarker+"\n### osoba: 計画の承認待ち"),
+		}, nil).Once()
+		approved, err = gate.IsApproved(context.Background(), readyIssue)
+		require.NoError(t, err)
+		assert.False(t, approved)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("実行計画コメントがない場合は承認済みとして扱う", func(t *testing.T) {
+		client := new(mockPlanApprovalClient)
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 40).Return([]*gh.IssueComment{userComment("alice", "よろしくお願いします")}, nil).Once()
+
+		gate, err := NewPlanApprovalGate(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		approved, err := gate.IsApproved(context.Background(), readyIssue)
+		require.NoError(t, err)
+		assert.True(t, approved)
+		client.AssertNotCalled(t, "ListCommentReactions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("実装待ち以外のIssueは対象外", func(t *testing.T) {
+		client := new(mockPlanApprovalClient)
+		gate, err := NewPlanApprovalGate(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		approved, err := gate.IsApproved(context.Background(), &gh.Issue{
+			Number: intPtr(41),
+			Labels: []*gh.Label{{Name: stringPtr("status:needs-plan")}},
+		})
+		require.NoError(t, err)
+		assert.True(t, approved)
+		client.AssertNotCalled(t, "ListIssueComments", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
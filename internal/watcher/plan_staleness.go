@@ -0,0 +1,147 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// planStaleCommentMarker は計画後にIssueが編集された通知コメントを識別するためのマーカー
+const planStaleCommentMarker = "<!-- osoba:plan-stale -->"
+
+// PlanStalenessDetector は実装フェーズの開始前に、計画の作成後にIssue本文が編集されていないかを確認する
+// 要件の変更に気付かないまま古い計画で実装が進むことを防ぐ
+type PlanStalenessDetector struct {
+	client github.GitHubClient
+	owner  string
+	repo   string
+	config *config.Config
+	logger logger.Logger
+}
+
+// PlanStaleness は古くなった計画の検出結果
+type PlanStaleness struct {
+	IssueNumber int
+	PlannedAt   time.Time // 実行計画コメントの投稿日時
+	EditedAt    time.Time // Issue本文の最終編集日時
+	Replanned   bool      // 計画フェーズをやり直すラベルを付与したか
+}
+
+// NewPlanStalenessDetector は新しいPlanStalenessDetectorを作成する
+func NewPlanStalenessDetector(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*PlanStalenessDetector, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if _, ok := client.(github.IssueCommentEditor); !ok {
+		return nil, errors.New("github client does not support editing issue comments")
+	}
+	if _, ok := client.(github.IssueEditTimeReader); !ok {
+		return nil, errors.New("github client does not support reading issue edit times")
+	}
+
+	return &PlanStalenessDetector{
+		client: client,
+		owner:  owner,
+		repo:   repo,
+		config: cfg,
+		logger: logger,
+	}, nil
+}
+
+// CheckBeforeImplement は実装待ちのIssueの本文が実行計画コメントより後に編集されていないかを確認する
+// 編集されている場合はコメントとラベルを付与して結果を返し、呼び出し側は実装フェーズを開始しない
+// 通知後に実装待ちのラベルが付け直された場合は、現在の計画のまま実装することが選ばれたものとする
+func (d *PlanStalenessDetector) CheckBeforeImplement(ctx context.Context, issue *github.Issue) (*PlanStaleness, error) {
+	if issue == nil || issue.Number == nil || !hasLabel(issue, d.config.GitHub.Labels.Ready) {
+		return nil, nil
+	}
+	number := *issue.Number
+
+	editedAt, err := d.client.(github.IssueEditTimeReader).GetIssueLastEditedAt(ctx, d.owner, d.repo, number)
+	if err != nil {
+		return nil, err
+	}
+	if editedAt == nil {
+		return nil, nil
+	}
+
+	comments, err := d.client.(github.IssueCommentEditor).ListIssueComments(ctx, d.owner, d.repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	planIndex := findPlanComment(comments)
+	if planIndex < 0 {
+		return nil, nil
+	}
+	plan := comments[planIndex]
+	if plan.CreatedAt == nil || !editedAt.After(*plan.CreatedAt) {
+		return nil, nil
+	}
+	for _, c := range comments[planIndex+1:] {
+		if c.Body != nil && strings.HasPrefix(*c.Body, planStaleCommentMarker) && c.CreatedAt != nil && c.CreatedAt.After(*editedAt) {
+			return nil, nil
+		}
+	}
+
+	staleness := &PlanStaleness{
+		IssueNumber: number,
+		PlannedAt:   *plan.CreatedAt,
+		EditedAt:    *editedAt,
+		Replanned:   d.config.GitHub.PlanStaleness.AutoReplan,
+	}
+	if err := d.client.CreateIssueComment(ctx, d.owner, d.repo, number, buildPlanStaleComment(d.config, staleness, plan)); err != nil {
+		return nil, fmt.Errorf("failed to post plan stale comment: %w", err)
+	}
+	to := d.config.GitHub.PlanStaleness.Label
+	if staleness.Replanned {
+		to = d.config.GitHub.Labels.Plan
+	}
+	if err := d.client.TransitionLabels(ctx, d.owner, d.repo, number, d.config.GitHub.Labels.Ready, to); err != nil {
+		return staleness, fmt.Errorf("failed to label issue with stale plan: %w", err)
+	}
+
+	d.logger.Info("Issue was edited after planning, skipped implementation",
+		"issue_number", number,
+		"planned_at", staleness.PlannedAt,
+		"edited_at", staleness.EditedAt,
+		"label", to)
+	return staleness, nil
+}
+
+// buildPlanStaleComment は計画後にIssueが編集された通知コメントを生成する
+func buildPlanStaleComment(cfg *config.Config, staleness *PlanStaleness, plan *github.IssueComment) string {
+	planURL := ""
+	if plan.HTMLURL != nil {
+		planURL = *plan.HTMLURL
+	}
+	name := config.CommentPlanStale
+	if staleness.Replanned {
+		name = config.CommentPlanStaleReplan
+	}
+
+	return planStaleCommentMarker + "\n" + cfg.RenderComment(name, map[string]string{
+		"issue-number": strconv.Itoa(staleness.IssueNumber),
+		"label":        cfg.GitHub.PlanStaleness.Label,
+		"plan-label":   cfg.GitHub.Labels.Plan,
+		"ready-label":  cfg.GitHub.Labels.Ready,
+		"plan-url":     planURL,
+		"planned-at":   staleness.PlannedAt.In(cfg.Location()).Format(time.RFC3339),
+		"edited-at":    staleness.EditedAt.In(cfg.Location()).Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,132 @@
+package watcher
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockEditTimeClient はIssue本文の編集日時の取得とコメント編集に対応したGitHubクライアントのモック
+type mockEditTimeClient struct {
+	mockCommentEditorClient
+}
+
+func (m *mockEditTimeClient) GetIssueLastEditedAt(ctx context.Context, owner, repo string, issueNumber int) (*time.Time, error) {
+	args := m.Called(ctx, owner, repo, issueNumber)
+	editedAt, _ := args.Get(0).(*time.Time)
+	return editedAt, args.Error(1)
+}
+
+func TestPlanStalenessDetector_CheckBeforeImplement(t *testing.T) {
+	readyIssue := &gh.Issue{
+		Number: intPtr(30),
+		Title:  stringPtr("検索機能を追加する"),
+		Labels: []*gh.Label{{Name: stringPtr("status:ready")}},
+	}
+	plannedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	editedAt := plannedAt.Add(time.Hour)
+	editedBeforePlan := plannedAt.Add(-time.Hour)
+	notifiedAt := editedAt.Add(time.Minute)
+	planComment := &gh.IssueComment{
+		ID:        gh.Int64(1),
+		Body:      gh.String("# 実行計画: 検索機能を追加する\n\n..."),
+		CreatedAt: &plannedAt,
+		HTMLURL:   gh.String("https://github.com/owner/repo/issues/30#issuecomment-1"),
+	}
+
+	tests := []struct {
+		name       string
+		autoReplan bool
+		editedAt   *time.Time
+		comments   []*gh.IssueComment
+		wantLabel  string // 付け替え先のラベル（空の場合は検出しない）
+		wantText   string
+	}{
+		{
+			name:      "計画後に編集された場合はラベルを付け替える",
+			editedAt:  &editedAt,
+			comments:  []*gh.IssueComment{planComment},
+			wantLabel: "status:plan-stale",
+			wantText:  "`status:ready` を付け直してください",
+		},
+		{
+			name:       "自動再計画が有効な場合は計画ラベルを付与する",
+			autoReplan: true,
+			editedAt:   &editedAt,
+			comments:   []*gh.IssueComment{planComment},
+			wantLabel:  "status:needs-plan",
+			wantText:   "計画フェーズをやり直します",
+		},
+		{
+			name:     "本文が編集されていない",
+			editedAt: nil,
+		},
+		{
+			name:     "計画前の編集",
+			editedAt: &editedBeforePlan,
+			comments: []*gh.IssueComment{planComment},
+		},
+		{
+			name:     "通知後に実装待ちのラベルが付け直された",
+			editedAt: &editedAt,
+			comments: []*gh.IssueComment{
+				planComment,
+				{ID: gh.Int64(2), Body: gh.String(planStaleCommentMarker + "\n### osoba: 計画後にIssueが編集されました"), CreatedAt: &notifiedAt},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewConfig()
+			cfg.GitHub.PlanStaleness.AutoReplan = tt.autoReplan
+
+			client := new(mockEditTimeClient)
+			client.On("GetIssueLastEditedAt", mock.Anything, "owner", "repo", 30).Return(tt.editedAt, nil).Once()
+			if tt.editedAt != nil {
+				client.On("ListIssueComments", mock.Anything, "owner", "repo", 30).Return(tt.comments, nil).Once()
+			}
+			if tt.wantLabel != "" {
+				client.On("CreateIssueComment", mock.Anything, "owner", "repo", 30, mock.MatchedBy(func(body string) bool {
+					return strings.HasPrefix(body, planStaleCommentMarker) &&
+						strings.Contains(body, "https://github.com/owner/repo/issues/30#issuecomment-1") &&
+						strings.Contains(body, tt.wantText)
+				})).Return(nil).Once()
+				client.On("TransitionLabels", mock.Anything, "owner", "repo", 30, "status:ready", tt.wantLabel).Return(nil).Once()
+			}
+
+			detector, err := NewPlanStalenessDetector(client, "owner", "repo", cfg, NewMockLogger())
+			require.NoError(t, err)
+
+			staleness, err := detector.CheckBeforeImplement(context.Background(), readyIssue)
+			require.NoError(t, err)
+			if tt.wantLabel == "" {
+				assert.Nil(t, staleness)
+			} else {
+				require.NotNil(t, staleness)
+				assert.Equal(t, plannedAt, staleness.PlannedAt)
+				assert.Equal(t, editedAt, staleness.EditedAt)
+				assert.Equal(t, tt.autoReplan, staleness.Replanned)
+			}
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPlanStalenessDetector_IgnoresOtherLabels(t *testing.T) {
+	client := new(mockEditTimeClient)
+	detector, err := NewPlanStalenessDetector(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+	require.NoError(t, err)
+
+	staleness, err := detector.CheckBeforeImplement(context.Background(), createTestIssueWithLabels([]string{"status:needs-plan"}))
+	require.NoError(t, err)
+	assert.Nil(t, staleness)
+	client.AssertNotCalled(t, "GetIssueLastEditedAt", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
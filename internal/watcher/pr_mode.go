@@ -0,0 +1,285 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/douhashi/osoba/internal/cleanup"
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// PRModeWatcher はIssueを起点とせずにPRのラベルを監視し、人が作成したPRのレビュー・修正を実行する
+// pr_mode.review_labelのPRをレビューし、status:requires-changesのPRを修正する
+// Issueを閉じるPRは通常のIssueのワークフロー（PRWatcherの自動Revise）で扱うため対象外
+// マージ・クローズされたPRのworktree・ブランチ・tmuxウィンドウはcleanupManagerで削除する
+type PRModeWatcher struct {
+	client         github.GitHubClient
+	worktrees      git.PullRequestWorktreeCreator
+	review         ActionExecutor
+	revise         ActionExecutor
+	cleanupManager cleanup.Manager // マージ・クローズされたPRのリソースの削除（未設定の場合は削除しない）
+	owner          string
+	repo           string
+	config         *config.Config
+	logger         logger.Logger
+	clock          clock.Clock
+
+	mu sync.Mutex
+	// tracked はPRモードで扱ったオープンなPR（マージ・クローズされたらリソースを削除する）
+	tracked map[int]bool
+}
+
+// prModeTrigger はPRモードで実行するフェーズのきっかけとなるラベル
+type prModeTrigger struct {
+	label     string         // 実行を依頼するラベル
+	executing string         // 実行中に付与するラベル
+	action    ActionExecutor // 実行するアクション
+}
+
+// NewPRModeWatcher は新しいPRModeWatcherを作成する
+func NewPRModeWatcher(
+	client github.GitHubClient,
+	worktrees git.PullRequestWorktreeCreator,
+	review, revise ActionExecutor,
+	owner, repo string,
+	cfg *config.Config,
+	logger logger.Logger,
+) (*PRModeWatcher, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if worktrees == nil {
+		return nil, errors.New("pull request worktree creator is required")
+	}
+	if review == nil || revise == nil {
+		return nil, errors.New("review and revise actions are required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+
+	return &PRModeWatcher{
+		client:    client,
+		worktrees: worktrees,
+		review:    review,
+		revise:    revise,
+		owner:     owner,
+		repo:      repo,
+		config:    cfg,
+		logger:    logger,
+		clock:     clock.New(),
+		tracked:   make(map[int]bool),
+	}, nil
+}
+
+// SetCleanupManager はマージ・クローズされたPRのworktree・ブランチ・tmuxウィンドウを削除するよう設定する
+func (w *PRModeWatcher) SetCleanupManager(manager cleanup.Manager) {
+	w.cleanupManager = manager
+}
+
+// Start はPRの監視を開始する
+func (w *PRModeWatcher) Start(ctx context.Context) {
+	interval := w.config.GitHub.PRPollInterval
+	w.logger.Info("Starting PR mode watcher", "interval", interval, "review_label", w.config.PRMode.ReviewLabel)
+
+	if err := w.CheckOnce(ctx); err != nil {
+		w.logger.Warn("Failed to check pull requests for PR mode", "error", err)
+	}
+
+	ticker := w.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("PR mode watcher stopped")
+			return
+		case <-ticker.C():
+			if err := w.CheckOnce(ctx); err != nil {
+				w.logger.Warn("Failed to check pull requests for PR mode", "error", err)
+			}
+		}
+	}
+}
+
+// triggers はラベルの優先順（レビューの依頼を修正より優先する）
+func (w *PRModeWatcher) triggers() []prModeTrigger {
+	return []prModeTrigger{
+		{label: w.config.PRMode.ReviewLabel, executing: w.config.PRMode.ReviewingLabel, action: w.review},
+		{label: TriggerLabelRequiresChanges, executing: w.config.PRMode.RevisingLabel, action: w.revise},
+	}
+}
+
+// ownLabels はPRモードのみで使うラベル（付いているPRはPRモードで扱ったPRとして追跡する）
+func (w *PRModeWatcher) ownLabels() []string {
+	return []string{w.config.PRMode.ReviewLabel, w.config.PRMode.ReviewingLabel, w.config.PRMode.RevisingLabel, w.config.PRMode.ApprovedLabel}
+}
+
+// CheckOnce はラベルの付いたPRを1回確認し、レビュー・修正を実行する
+// 一覧にないPRのうち追跡しているPRは状態を確認し、マージ・クローズされていればリソースを削除する
+func (w *PRModeWatcher) CheckOnce(ctx context.Context) error {
+	labels := w.ownLabels()
+	labels = append(labels, TriggerLabelRequiresChanges)
+
+	prs, err := w.client.ListPullRequestsByLabels(ctx, w.owner, w.repo, labels)
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	sort.Slice(prs, func(i, j int) bool { return prs[i].Number < prs[j].Number })
+
+	listed := make(map[int]bool, len(prs))
+	for _, pr := range prs {
+		if pr == nil {
+			continue
+		}
+		listed[pr.Number] = true
+		// 再起動後も、PRモードのラベルが付いているPRはマージ・クローズ後に削除できるよう追跡する
+		for _, label := range w.ownLabels() {
+			if hasPRLabel(pr, label) {
+				w.track(pr.Number)
+				break
+			}
+		}
+		if pr.IsDraft {
+			continue
+		}
+		trigger, ok := w.findTrigger(pr)
+		if !ok {
+			continue
+		}
+		if err := w.process(ctx, pr, trigger); err != nil {
+			w.logger.Warn("Failed to run PR mode phase",
+				"pr_number", pr.Number,
+				"label", trigger.label,
+				"error", err)
+		}
+	}
+
+	w.cleanupClosed(ctx, listed)
+	return nil
+}
+
+// track はPRをマージ・クローズ後のリソースの削除の対象にする
+func (w *PRModeWatcher) track(prNumber int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.tracked[prNumber] = true
+}
+
+// cleanupClosed は一覧にない追跡中のPRの状態を確認し、マージ・クローズされたPRのリソースを削除する
+func (w *PRModeWatcher) cleanupClosed(ctx context.Context, listed map[int]bool) {
+	w.mu.Lock()
+	var candidates []int
+	for number := range w.tracked {
+		if !listed[number] {
+			candidates = append(candidates, number)
+		}
+	}
+	w.mu.Unlock()
+	sort.Ints(candidates)
+
+	for _, number := range candidates {
+		pr, err := w.client.GetPullRequestStatus(ctx, number)
+		if err != nil {
+			w.logger.Warn("Failed to get pull request status for PR mode cleanup", "pr_number", number, "error", err)
+			continue
+		}
+		if pr == nil || (pr.State != "MERGED" && pr.State != "CLOSED") {
+			continue
+		}
+		w.logger.Info("Cleaning up resources of closed PR mode pull request", "pr_number", number, "state", pr.State)
+		if w.cleanupManager != nil {
+			if err := w.cleanupManager.CleanupIssueResources(ctx, number); err != nil {
+				w.logger.Warn("Failed to clean up PR mode resources", "pr_number", number, "error", err)
+				continue
+			}
+		}
+		w.mu.Lock()
+		delete(w.tracked, number)
+		w.mu.Unlock()
+	}
+}
+
+// findTrigger はPRのラベルから実行するフェーズを選ぶ（実行中ラベルがある場合は実行しない）
+func (w *PRModeWatcher) findTrigger(pr *github.PullRequest) (prModeTrigger, bool) {
+	has := make(map[string]bool, len(pr.Labels))
+	for _, l := range pr.Labels {
+		has[l] = true
+	}
+	triggers := w.triggers()
+	for _, t := range triggers {
+		if has[t.executing] {
+			return prModeTrigger{}, false
+		}
+	}
+	for _, t := range triggers {
+		if has[t.label] {
+			return t, true
+		}
+	}
+	return prModeTrigger{}, false
+}
+
+// process はPRのheadのworktreeを用意してラベルを実行中に変更し、フェーズを実行する
+func (w *PRModeWatcher) process(ctx context.Context, pr *github.PullRequest, trigger prModeTrigger) error {
+	issueNumber, err := w.client.GetClosingIssueNumber(ctx, pr.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get closing issue: %w", err)
+	}
+	if issueNumber != 0 {
+		w.logger.Debug("Skipping pull request linked to an issue", "pr_number", pr.Number, "issue_number", issueNumber)
+		return nil
+	}
+
+	// worktreeの作成に失敗した場合はラベルを変更せず、次の確認で再試行する
+	if err := w.worktrees.CreateWorktreeForPullRequest(ctx, pr.Number); err != nil {
+		return fmt.Errorf("failed to prepare worktree: %w", err)
+	}
+	w.track(pr.Number)
+	if err := w.client.TransitionLabels(ctx, w.owner, w.repo, pr.Number, trigger.label, trigger.executing); err != nil {
+		return fmt.Errorf("failed to transition labels: %w", err)
+	}
+
+	w.logger.Info("Running PR mode phase",
+		"pr_number", pr.Number,
+		"head", pr.HeadRefName,
+		"label", trigger.executing)
+
+	labels := make([]*github.Label, 0, len(pr.Labels))
+	for _, l := range pr.Labels {
+		if l == trigger.label {
+			continue
+		}
+		name := l
+		labels = append(labels, &github.Label{Name: &name})
+	}
+	executing := trigger.executing
+	labels = append(labels, &github.Label{Name: &executing})
+	number, title := pr.Number, pr.Title
+	target := &github.Issue{Number: &number, Title: &title, Labels: labels}
+
+	if err := trigger.action.Execute(ctx, target); err != nil {
+		// 実行中ラベルが残ると以降の確認で対象にならないため、依頼のラベルに戻して次の確認で再試行する
+		if rollbackErr := w.client.TransitionLabels(ctx, w.owner, w.repo, pr.Number, trigger.executing, trigger.label); rollbackErr != nil {
+			w.logger.Error("Failed to roll back PR mode label",
+				"pr_number", pr.Number,
+				"label", trigger.executing,
+				"error", rollbackErr)
+		}
+		return fmt.Errorf("failed to execute action: %w", err)
+	}
+	return nil
+}
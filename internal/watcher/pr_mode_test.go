@@ -0,0 +1,156 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePRWorktrees はPRのworktreeの作成を記録する
+type fakePRWorktrees struct {
+	created []int
+	err     error
+}
+
+func (f *fakePRWorktrees) CreateWorktreeForPullRequest(ctx context.Context, prNumber int) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.created = append(f.created, prNumber)
+	return nil
+}
+
+func TestPRModeWatcher_CheckOnce(t *testing.T) {
+	prModeLabels := []string{"status:needs-review", "status:reviewing", "status:revising", "status:pr-approved", "status:requires-changes"}
+
+	tests := []struct {
+		name          string
+		pr            *gh.PullRequest
+		closingIssue  int
+		worktreeErr   error
+		actionErr     error
+		wantAction    string // review / revise（空の場合は実行しない）
+		wantExecuting string
+	}{
+		{
+			name:          "レビューを依頼されたPR",
+			pr:            &gh.PullRequest{Number: 42, Title: "Add login form", State: "OPEN", Labels: []string{"status:needs-review"}},
+			wantAction:    "review",
+			wantExecuting: "status:reviewing",
+		},
+		{
+			name:          "修正を依頼されたPR",
+			pr:            &gh.PullRequest{Number: 42, Title: "Add login form", State: "OPEN", Labels: []string{"status:requires-changes"}},
+			wantAction:    "revise",
+			wantExecuting: "status:revising",
+		},
+		{
+			name:          "フェーズの開始に失敗した場合は依頼のラベルに戻す",
+			pr:            &gh.PullRequest{Number: 42, Title: "Add login form", State: "OPEN", Labels: []string{"status:needs-review"}},
+			actionErr:     assert.AnError,
+			wantAction:    "review",
+			wantExecuting: "status:reviewing",
+		},
+		{
+			name: "承認済みのPRは何もしない",
+			pr:   &gh.PullRequest{Number: 42, State: "OPEN", Labels: []string{"status:pr-approved"}},
+		},
+		{
+			name:         "Issueを閉じるPRはIssueのワークフローに任せる",
+			pr:           &gh.PullRequest{Number: 42, State: "OPEN", Labels: []string{"status:requires-changes"}},
+			closingIssue: 7,
+		},
+		{
+			name: "実行中のPR",
+			pr:   &gh.PullRequest{Number: 42, State: "OPEN", Labels: []string{"status:needs-review", "status:revising"}},
+		},
+		{
+			name: "ドラフトのPR",
+			pr:   &gh.PullRequest{Number: 42, State: "OPEN", IsDraft: true, Labels: []string{"status:needs-review"}},
+		},
+		{
+			name:        "worktreeの作成に失敗した場合はラベルを変更しない",
+			pr:          &gh.PullRequest{Number: 42, State: "OPEN", Labels: []string{"status:needs-review"}},
+			worktreeErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(MockGitHubClient)
+			worktrees := &fakePRWorktrees{err: tt.worktreeErr}
+			review := new(MockActionExecutorExt)
+			revise := new(MockActionExecutorExt)
+			actions := map[string]*MockActionExecutorExt{"review": review, "revise": revise}
+
+			client.On("ListPullRequestsByLabels", mock.Anything, "owner", "repo", prModeLabels).Return([]*gh.PullRequest{tt.pr}, nil)
+			client.On("GetClosingIssueNumber", mock.Anything, 42).Return(tt.closingIssue, nil).Maybe()
+			if tt.wantAction != "" {
+				client.On("TransitionLabels", mock.Anything, "owner", "repo", 42, tt.pr.Labels[0], tt.wantExecuting).Return(nil).Once()
+				actions[tt.wantAction].On("Execute", mock.Anything, mock.MatchedBy(func(issue *gh.Issue) bool {
+					return *issue.Number == 42 && *issue.Title == "Add login form" &&
+						len(issue.Labels) == 1 && *issue.Labels[0].Name == tt.wantExecuting
+				})).Return(tt.actionErr).Once()
+				if tt.actionErr != nil {
+					client.On("TransitionLabels", mock.Anything, "owner", "repo", 42, tt.wantExecuting, tt.pr.Labels[0]).Return(nil).Once()
+				}
+			}
+
+			cfg := config.NewConfig()
+			cfg.PRMode.Enabled = true
+			w, err := NewPRModeWatcher(client, worktrees, review, revise, "owner", "repo", cfg, NewMockLogger())
+			require.NoError(t, err)
+
+			require.NoError(t, w.CheckOnce(context.Background()))
+
+			client.AssertExpectations(t)
+			review.AssertExpectations(t)
+			revise.AssertExpectations(t)
+			if tt.wantAction == "" {
+				client.AssertNotCalled(t, "TransitionLabels", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				review.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+				revise.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+			} else {
+				assert.Equal(t, []int{42}, worktrees.created)
+			}
+		})
+	}
+}
+
+func TestPRModeWatcher_CleansUpClosedPullRequests(t *testing.T) {
+	client := new(MockGitHubClient)
+	review := new(MockActionExecutorExt)
+	revise := new(MockActionExecutorExt)
+	cleanupManager := new(MockCleanupManager)
+
+	approved := &gh.PullRequest{Number: 42, State: "OPEN", Labels: []string{"status:pr-approved"}}
+	client.On("ListPullRequestsByLabels", mock.Anything, "owner", "repo", mock.Anything).Return([]*gh.PullRequest{approved}, nil).Once()
+	client.On("ListPullRequestsByLabels", mock.Anything, "owner", "repo", mock.Anything).Return([]*gh.PullRequest{}, nil)
+	client.On("GetPullRequestStatus", mock.Anything, 42).Return(&gh.PullRequest{Number: 42, State: "OPEN"}, nil).Once()
+	client.On("GetPullRequestStatus", mock.Anything, 42).Return(&gh.PullRequest{Number: 42, State: "MERGED"}, nil).Once()
+	cleanupManager.On("CleanupIssueResources", mock.Anything, 42).Return(nil).Once()
+
+	cfg := config.NewConfig()
+	cfg.PRMode.Enabled = true
+	w, err := NewPRModeWatcher(client, &fakePRWorktrees{}, review, revise, "owner", "repo", cfg, NewMockLogger())
+	require.NoError(t, err)
+	w.SetCleanupManager(cleanupManager)
+
+	// 1回目: 承認済みのPRを追跡する
+	require.NoError(t, w.CheckOnce(context.Background()))
+	// 2回目: 一覧から外れたがオープンのまま（ラベルを外しただけ）
+	require.NoError(t, w.CheckOnce(context.Background()))
+	cleanupManager.AssertNotCalled(t, "CleanupIssueResources", mock.Anything, mock.Anything)
+	// 3回目: マージされたのでリソースを削除し、追跡をやめる
+	require.NoError(t, w.CheckOnce(context.Background()))
+	require.NoError(t, w.CheckOnce(context.Background()))
+
+	client.AssertExpectations(t)
+	cleanupManager.AssertExpectations(t)
+	client.AssertNumberOfCalls(t, "GetPullRequestStatus", 2)
+}
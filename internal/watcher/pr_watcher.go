@@ -9,9 +9,12 @@ import (
 	"time"
 
 	"github.com/douhashi/osoba/internal/cleanup"
+	"github.com/douhashi/osoba/internal/clock"
 	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/notify"
+	"github.com/douhashi/osoba/internal/watcher/actions"
 )
 
 // PRCallback はPR検出時に呼ばれるコールバック関数
@@ -30,6 +33,15 @@ type PRWatcher struct {
 	autoMergeMetrics *AutoMergeMetrics
 	sessionName      string                 // tmuxセッション名（Reviseアクション用）
 	actionManager    ActionManagerInterface // ReviseAction実行用
+	clock            clock.Clock            // ポーリングとリトライの待機に使用する時計
+	pollNow          chan struct{}          // ポーリング間隔を待たない即時確認の要求
+	closureVerifier  *IssueClosureVerifier  // 自動マージ後のIssueのクローズ確認（無効の場合はnil）
+	remoteBranches   *RemoteBranchCleaner   // 自動マージ後のリモートのブランチの削除（無効の場合はnil）
+	historyGuard     *HistoryGuard          // 自動マージ前のブランチの履歴の書き換えの確認（無効の場合はnil）
+	autoMergePolicy  *AutoMergePolicy       // 自動マージの追加の条件（未設定の場合はnil）
+	mergeQueue       *MergeQueue            // 自動マージするPRのマージキューへの追加（使わない場合はnil）
+	notifier         notify.Notifier        // 重要なイベントの通知（無効の場合はnil）
+	degradation      *DegradationMonitor    // 連続した失敗による不健全な状態の検出（無効の場合はnil）
 
 	// ヘルスチェック用のフィールド
 	lastExecutionTime    time.Time
@@ -66,7 +78,11 @@ func NewPRWatcherWithConfig(client github.GitHubClient, owner, repo string, labe
 	// デフォルトのcleanupManagerを作成（必要に応じて）
 	// PRWatcherではsessionNameが取得できないため、空文字を渡す（従来の動作）
 	if cleanupMgr == nil {
-		cleanupMgr = cleanup.NewManager("", logger)
+		if cfg != nil {
+			cleanupMgr = cleanup.NewManagerWithSafety("", logger, cfg.Safety)
+		} else {
+			cleanupMgr = cleanup.NewManager("", logger)
+		}
 	}
 
 	return &PRWatcher{
@@ -76,6 +92,7 @@ func NewPRWatcherWithConfig(client github.GitHubClient, owner, repo string, labe
 		labels:           labels,
 		pollInterval:     pollInterval,
 		startTime:        time.Now(),
+		clock:            clock.New(),
 		logger:           logger.WithFields("component", "pr_watcher", "owner", owner, "repo", repo),
 		config:           cfg,
 		cleanupManager:   cleanupMgr,
@@ -113,6 +130,54 @@ func (w *PRWatcher) SetActionManager(am ActionManagerInterface) {
 	w.actionManager = am
 }
 
+// SetClock はポーリングとリトライの待機に使用する時計を設定する（テストで仮想時間を使う場合など）
+func (w *PRWatcher) SetClock(clk clock.Clock) {
+	w.clock = clk
+}
+
+// getClock は設定された時計を返す（未設定の場合は実時間）
+func (w *PRWatcher) getClock() clock.Clock {
+	if w.clock == nil {
+		return clock.New()
+	}
+	return w.clock
+}
+
+// SetRemoteBranchCleaner は自動マージ後のリモートのブランチの削除を設定する
+func (w *PRWatcher) SetRemoteBranchCleaner(cleaner *RemoteBranchCleaner) {
+	w.remoteBranches = cleaner
+}
+
+// SetHistoryGuard は自動マージ前のブランチの履歴の書き換えの確認を設定する
+func (w *PRWatcher) SetHistoryGuard(guard *HistoryGuard) {
+	w.historyGuard = guard
+}
+
+// SetIssueClosureVerifier は自動マージ後のIssueのクローズ確認を設定する
+func (w *PRWatcher) SetIssueClosureVerifier(verifier *IssueClosureVerifier) {
+	w.closureVerifier = verifier
+}
+
+// SetAutoMergePolicy は自動マージの追加の条件を設定する
+func (w *PRWatcher) SetAutoMergePolicy(policy *AutoMergePolicy) {
+	w.autoMergePolicy = policy
+}
+
+// SetMergeQueue は自動マージでPRをマージキューに追加するよう設定する
+func (w *PRWatcher) SetMergeQueue(queue *MergeQueue) {
+	w.mergeQueue = queue
+}
+
+// SetNotifier は重要なイベントの通知を設定する
+func (w *PRWatcher) SetNotifier(notifier notify.Notifier) {
+	w.notifier = notify.WithRepository(notifier, w.owner+"/"+w.repo)
+}
+
+// SetDegradationMonitor はPRの一覧の取得の連続した失敗を記録するよう設定する
+func (w *PRWatcher) SetDegradationMonitor(monitor *DegradationMonitor) {
+	w.degradation = monitor
+}
+
 // SetSessionName はtmuxセッション名を設定する
 func (w *PRWatcher) SetSessionName(sessionName string) {
 	w.sessionName = sessionName
@@ -144,23 +209,45 @@ func (w *PRWatcher) Start(ctx context.Context, callback PRCallback) {
 		"labels", w.labels,
 		"interval", pollInterval)
 
-	ticker := time.NewTicker(pollInterval)
+	ticker := w.getClock().NewTicker(pollInterval)
 	defer ticker.Stop()
 
 	// 初回実行
 	w.checkPRs(ctx, callback)
 
+	pollNow := w.pollRequests()
 	for {
 		select {
 		case <-ctx.Done():
 			w.logger.Info("Stopping PR watcher")
 			return
-		case <-ticker.C:
+		case <-ticker.C():
+			w.checkPRs(ctx, callback)
+		case <-pollNow:
+			w.logger.Info("Immediate poll requested")
 			w.checkPRs(ctx, callback)
 		}
 	}
 }
 
+// RequestPoll は次のポーリング間隔を待たずに確認を行うよう要求する
+// 確認中に複数回要求された場合は、確認の完了後に1回だけ再確認する
+func (w *PRWatcher) RequestPoll() {
+	select {
+	case w.pollRequests() <- struct{}{}:
+	default:
+	}
+}
+
+func (w *PRWatcher) pollRequests() chan struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.pollNow == nil {
+		w.pollNow = make(chan struct{}, 1)
+	}
+	return w.pollNow
+}
+
 // StartWithAutoMerge はPR監視を開始し、自動マージを実行する
 func (w *PRWatcher) StartWithAutoMerge(ctx context.Context) {
 	callback := func(pr *github.PullRequest) {
@@ -181,7 +268,7 @@ func (w *PRWatcher) StartWithAutoMerge(ctx context.Context) {
 				w.logger.Info("Executing auto-merge for PR with status:lgtm",
 					"prNumber", pr.Number,
 				)
-				if err := executeAutoMergeForPRWithLogger(ctx, pr, w.config, w.client, w.cleanupManager, w.logger, w.autoMergeMetrics); err != nil {
+				if err := executeAutoMergeForPRWithLogger(ctx, pr, w.config, w.client, w.cleanupManager, w.logger, w.autoMergeMetrics, w.closureVerifier, w.autoMergePolicy, w.notifier, w.mergeQueue, w.remoteBranches, w.historyGuard); err != nil {
 					w.logger.Error("Failed to execute auto-merge for PR",
 						"prNumber", pr.Number,
 						"error", err)
@@ -196,7 +283,12 @@ func (w *PRWatcher) StartWithAutoMerge(ctx context.Context) {
 				w.logger.Info("Executing auto-revise for PR with status:requires-changes",
 					"prNumber", pr.Number,
 				)
-				if err := executeAutoReviseIfRequiresChangesWithLogger(ctx, pr, w.config, w.client, w.actionManager, w.sessionName, w.logger); err != nil {
+				if err := executeAutoReviseIfRequiresChangesWithLogger(ctx, pr, w.config, w.client, w.actionManager, w.sessionName, w.logger); errors.Is(err, actions.ErrPhasePaused) {
+					// カナリアモードの対象外など、フェーズを一時停止した場合はラベルを残して次回のポーリングで再判定する
+					w.logger.Info("Auto-revise paused for PR",
+						"prNumber", pr.Number,
+						"reason", err)
+				} else if err != nil {
 					w.logger.Error("Failed to execute auto-revise for PR",
 						"prNumber", pr.Number,
 						"error", err)
@@ -232,7 +324,7 @@ func hasPRLabel(pr *github.PullRequest, labelName string) bool {
 // checkPRs は現在のPRをチェックし、新しいPRがあればコールバックを呼ぶ
 func (w *PRWatcher) checkPRs(ctx context.Context, callback PRCallback) {
 	// サイクル開始時刻
-	startTime := time.Now()
+	startTime := w.getClock().Now()
 	w.logger.Debug("Starting PR check cycle",
 		"startTime", startTime.Format(time.RFC3339))
 
@@ -245,7 +337,7 @@ func (w *PRWatcher) checkPRs(ctx context.Context, callback PRCallback) {
 	var processedCount, processedPRCount int
 	var executionSuccessful bool
 	defer func() {
-		elapsed := time.Since(startTime)
+		elapsed := w.getClock().Since(startTime)
 		w.logger.Debug("Completed PR check cycle",
 			"checkedPRs", processedCount,
 			"processedPRs", processedPRCount,
@@ -258,7 +350,7 @@ func (w *PRWatcher) checkPRs(ctx context.Context, callback PRCallback) {
 		} else {
 			w.failedExecutions++
 		}
-		w.lastExecutionTime = time.Now()
+		w.lastExecutionTime = w.getClock().Now()
 		w.mu.Unlock()
 	}()
 
@@ -281,21 +373,26 @@ func (w *PRWatcher) checkPRs(ctx context.Context, callback PRCallback) {
 		retryDelay = 100 * time.Millisecond
 	}
 
-	err := RetryWithBackoffLogger(ctx, w.logger, 3, retryDelay, func() error {
+	err := RetryWithBackoffClock(ctx, w.getClock(), w.logger, 3, retryDelay, func() error {
 		var err error
 		prs, err = w.client.ListPullRequestsByLabels(ctx, w.owner, w.repo, w.labels)
 		return err
 	})
 
 	if err != nil {
+		if logIfThrottled(w.logger, err, "list_pull_requests") {
+			return
+		}
 		w.logger.Error("Failed to list pull requests",
 			"error", err,
 			"labels", w.labels)
+		w.degradation.RecordFailure(ctx, DegradationSourcePRs, err)
 		return
 	}
 
 	// API呼び出しが成功
 	executionSuccessful = true
+	w.degradation.RecordSuccess(DegradationSourcePRs)
 
 	for _, pr := range prs {
 		if pr == nil || pr.Number == 0 {
@@ -378,7 +475,7 @@ func (w *PRWatcher) CheckHealth(maxInactivity time.Duration) HealthStatus {
 	}
 
 	// 最後の実行からの経過時間をチェック
-	timeSinceLastExecution := time.Since(lastExecution)
+	timeSinceLastExecution := w.getClock().Since(lastExecution)
 	if timeSinceLastExecution > maxInactivity {
 		return HealthStatus{
 			IsHealthy: false,
@@ -0,0 +1,165 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// codeownersPaths はGitHubがCODEOWNERSファイルを探す場所
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// PreconditionResult は前提条件1件の確認結果
+type PreconditionResult struct {
+	Rule      string `json:"rule"`
+	Action    string `json:"action"`           // 満たしていない場合の扱い（config.PreconditionWarnなど）
+	Satisfied bool   `json:"satisfied"`        // 前提条件を満たしているか
+	Detail    string `json:"detail,omitempty"` // 確認した対象（デフォルトブランチ名・ワークフローのファイル名・CODEOWNERSのパス）
+	Error     string `json:"error,omitempty"`  // 確認に失敗した場合のエラー
+}
+
+// Violated は前提条件を満たしていないことを確認できたかを返す（確認に失敗した場合はfalse）
+func (r PreconditionResult) Violated() bool {
+	return !r.Satisfied && r.Error == ""
+}
+
+// PreconditionReport は起動時の前提条件の確認結果
+type PreconditionReport struct {
+	Results []PreconditionResult `json:"results"`
+}
+
+// BlocksAll は自動処理を開始しない違反があるかを返す
+func (r *PreconditionReport) BlocksAll() bool {
+	return r.blocks(config.PreconditionBlockAll)
+}
+
+// BlocksAutoMerge は自動マージを無効にする違反があるかを返す（自動処理を開始しない違反を含む）
+func (r *PreconditionReport) BlocksAutoMerge() bool {
+	return r.blocks(config.PreconditionBlockAutoMerge) || r.BlocksAll()
+}
+
+func (r *PreconditionReport) blocks(action string) bool {
+	for _, result := range r.Results {
+		if result.Action == action && result.Violated() {
+			return true
+		}
+	}
+	return false
+}
+
+// PreconditionChecker は起動時にリポジトリの前提条件（ブランチ保護・CIワークフロー・CODEOWNERS）を確認する
+type PreconditionChecker struct {
+	inspector github.RepositoryInspector
+	owner     string
+	repo      string
+	config    config.PreconditionsConfig
+	logger    logger.Logger
+}
+
+// NewPreconditionChecker は新しいPreconditionCheckerを作成する
+func NewPreconditionChecker(client github.GitHubClient, owner, repo string, cfg config.PreconditionsConfig, logger logger.Logger) (*PreconditionChecker, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	inspector, ok := client.(github.RepositoryInspector)
+	if !ok {
+		return nil, errors.New("github client does not support inspecting repositories")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+
+	return &PreconditionChecker{
+		inspector: inspector,
+		owner:     owner,
+		repo:      repo,
+		config:    cfg,
+		logger:    logger,
+	}, nil
+}
+
+// Check は有効な前提条件をすべて確認する
+// 確認に失敗した前提条件はErrorに記録し、違反としては扱わない
+func (c *PreconditionChecker) Check(ctx context.Context) *PreconditionReport {
+	report := &PreconditionReport{}
+	for _, rule := range c.config.Rules() {
+		if rule.Action == config.PreconditionOff {
+			continue
+		}
+
+		result := PreconditionResult{Rule: rule.Name, Action: rule.Action}
+		var err error
+		switch rule.Name {
+		case config.PreconditionBranchProtection:
+			result.Satisfied, result.Detail, err = c.checkBranchProtection(ctx)
+		case config.PreconditionCIWorkflow:
+			result.Satisfied, result.Detail, err = c.checkCIWorkflow(ctx)
+		case config.PreconditionCodeowners:
+			result.Satisfied, result.Detail, err = c.checkCodeowners(ctx)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			c.logger.Warn("Failed to check repository precondition",
+				"rule", rule.Name,
+				"error", err)
+		} else if !result.Satisfied {
+			c.logger.Warn("Repository precondition is not satisfied",
+				"rule", rule.Name,
+				"action", rule.Action,
+				"detail", result.Detail)
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+// checkBranchProtection はデフォルトブランチが保護されているかを確認する
+func (c *PreconditionChecker) checkBranchProtection(ctx context.Context) (bool, string, error) {
+	branch, err := c.inspector.GetDefaultBranch(ctx, c.owner, c.repo)
+	if err != nil {
+		return false, "", err
+	}
+	protected, err := c.inspector.IsBranchProtected(ctx, c.owner, c.repo, branch)
+	if err != nil {
+		return false, "", err
+	}
+	return protected, branch, nil
+}
+
+// checkCIWorkflow は有効なGitHub Actionsワークフローがあるかを確認する
+func (c *PreconditionChecker) checkCIWorkflow(ctx context.Context) (bool, string, error) {
+	workflows, err := c.inspector.ListWorkflowPaths(ctx, c.owner, c.repo)
+	if err != nil {
+		return false, "", err
+	}
+	if len(workflows) == 0 {
+		return false, "", nil
+	}
+	names := make([]string, 0, len(workflows))
+	for _, workflow := range workflows {
+		names = append(names, path.Base(workflow))
+	}
+	return true, strings.Join(names, ", "), nil
+}
+
+// checkCodeowners はCODEOWNERSファイルがあるかを確認する
+func (c *PreconditionChecker) checkCodeowners(ctx context.Context) (bool, string, error) {
+	for _, p := range codeownersPaths {
+		exists, err := c.inspector.FileExists(ctx, c.owner, c.repo, p)
+		if err != nil {
+			return false, "", err
+		}
+		if exists {
+			return true, p, nil
+		}
+	}
+	return false, "", nil
+}
@@ -0,0 +1,137 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRepositoryInspectorClient はリポジトリの前提条件の確認に対応したGitHubクライアントのモック
+type mockRepositoryInspectorClient struct {
+	MockGitHubClient
+}
+
+func (m *mockRepositoryInspectorClient) GetDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
+	args := m.Called(ctx, owner, repo)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockRepositoryInspectorClient) IsBranchProtected(ctx context.Context, owner, repo, branch string) (bool, error) {
+	args := m.Called(ctx, owner, repo, branch)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockRepositoryInspectorClient) ListWorkflowPaths(ctx context.Context, owner, repo string) ([]string, error) {
+	args := m.Called(ctx, owner, repo)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockRepositoryInspectorClient) FileExists(ctx context.Context, owner, repo, path string) (bool, error) {
+	args := m.Called(ctx, owner, repo, path)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestPreconditionChecker_Check(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          config.PreconditionsConfig
+		setup           func(m *mockRepositoryInspectorClient)
+		want            []PreconditionResult
+		blocksAutoMerge bool
+		blocksAll       bool
+	}{
+		{
+			name: "すべての前提条件を満たしている",
+			config: config.PreconditionsConfig{
+				BranchProtection: config.PreconditionBlockAll,
+				CIWorkflow:       config.PreconditionBlockAutoMerge,
+			},
+			setup: func(m *mockRepositoryInspectorClient) {
+				m.On("GetDefaultBranch", mock.Anything, "owner", "repo").Return("main", nil)
+				m.On("IsBranchProtected", mock.Anything, "owner", "repo", "main").Return(true, nil)
+				m.On("ListWorkflowPaths", mock.Anything, "owner", "repo").Return([]string{".github/workflows/ci.yml"}, nil)
+				m.On("FileExists", mock.Anything, "owner", "repo", ".github/CODEOWNERS").Return(false, nil)
+				m.On("FileExists", mock.Anything, "owner", "repo", "CODEOWNERS").Return(true, nil)
+			},
+			want: []PreconditionResult{
+				{Rule: config.PreconditionBranchProtection, Action: config.PreconditionBlockAll, Satisfied: true, Detail: "main"},
+				{Rule: config.PreconditionCIWorkflow, Action: config.PreconditionBlockAutoMerge, Satisfied: true, Detail: "ci.yml"},
+				{Rule: config.PreconditionCodeowners, Action: config.PreconditionWarn, Satisfied: true, Detail: "CODEOWNERS"},
+			},
+		},
+		{
+			name: "違反があるルールの扱いに応じてブロック",
+			config: config.PreconditionsConfig{
+				BranchProtection: config.PreconditionBlockAutoMerge,
+				CIWorkflow:       config.PreconditionOff,
+				Codeowners:       config.PreconditionWarn,
+			},
+			setup: func(m *mockRepositoryInspectorClient) {
+				m.On("GetDefaultBranch", mock.Anything, "owner", "repo").Return("main", nil)
+				m.On("IsBranchProtected", mock.Anything, "owner", "repo", "main").Return(false, nil)
+				m.On("FileExists", mock.Anything, "owner", "repo", mock.Anything).Return(false, nil)
+			},
+			want: []PreconditionResult{
+				{Rule: config.PreconditionBranchProtection, Action: config.PreconditionBlockAutoMerge, Detail: "main"},
+				{Rule: config.PreconditionCodeowners, Action: config.PreconditionWarn},
+			},
+			blocksAutoMerge: true,
+		},
+		{
+			name: "確認に失敗したルールは違反として扱わない",
+			config: config.PreconditionsConfig{
+				BranchProtection: config.PreconditionOff,
+				CIWorkflow:       config.PreconditionBlockAll,
+				Codeowners:       config.PreconditionOff,
+			},
+			setup: func(m *mockRepositoryInspectorClient) {
+				m.On("ListWorkflowPaths", mock.Anything, "owner", "repo").Return([]string(nil), errors.New("HTTP 403"))
+			},
+			want: []PreconditionResult{
+				{Rule: config.PreconditionCIWorkflow, Action: config.PreconditionBlockAll, Error: "HTTP 403"},
+			},
+		},
+		{
+			name: "自動処理を開始しない違反",
+			config: config.PreconditionsConfig{
+				BranchProtection: config.PreconditionOff,
+				CIWorkflow:       config.PreconditionBlockAll,
+				Codeowners:       config.PreconditionOff,
+			},
+			setup: func(m *mockRepositoryInspectorClient) {
+				m.On("ListWorkflowPaths", mock.Anything, "owner", "repo").Return([]string{}, nil)
+			},
+			want: []PreconditionResult{
+				{Rule: config.PreconditionCIWorkflow, Action: config.PreconditionBlockAll},
+			},
+			blocksAutoMerge: true,
+			blocksAll:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockRepositoryInspectorClient{}
+			tt.setup(client)
+
+			checker, err := NewPreconditionChecker(client, "owner", "repo", tt.config, NewMockLogger())
+			require.NoError(t, err)
+
+			report := checker.Check(context.Background())
+			assert.Equal(t, tt.want, report.Results)
+			assert.Equal(t, tt.blocksAutoMerge, report.BlocksAutoMerge())
+			assert.Equal(t, tt.blocksAll, report.BlocksAll())
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewPreconditionChecker_UnsupportedClient(t *testing.T) {
+	_, err := NewPreconditionChecker(new(MockGitHubClient), "owner", "repo", config.PreconditionsConfig{}, NewMockLogger())
+	assert.EqualError(t, err, "github client does not support inspecting repositories")
+}
@@ -0,0 +1,338 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/tmux"
+)
+
+// progressCommentMarker は進捗コメントを識別するためのマーカー
+const progressCommentMarker = "<!-- osoba:progress -->"
+
+// progressPhase は実行中ラベルとフェーズの対応
+type progressPhase struct {
+	label     string // 実行中ラベル
+	paneTitle string // フェーズのペインタイトル
+	configKey string // tmux.phasesのキー
+}
+
+// progressPhases は進捗報告の対象となる実行中フェーズ
+var progressPhases = []progressPhase{
+	{label: "status:planning", paneTitle: "Plan", configKey: "plan"},
+	{label: "status:implementing", paneTitle: "Implementation", configKey: "implement"},
+	{label: "status:reviewing", paneTitle: "Review", configKey: "review"},
+	{label: "status:revising", paneTitle: "Revise", configKey: "revise"},
+}
+
+// progressState はIssueごとの進捗追跡状態
+type progressState struct {
+	label     string
+	startedAt time.Time
+	commentID int64
+	// created は進捗コメントを作成済みか（GitHubに接続できない間は保留中の場合がある）
+	created bool
+}
+
+// ProgressReporter は実行中フェーズの進捗をIssueコメントとして定期的に更新する
+type ProgressReporter struct {
+	client      github.GitHubClient
+	tmuxManager tmux.Manager
+	owner       string
+	repo        string
+	sessionName string
+	config      *config.Config
+	logger      logger.Logger
+	sanitizer   *OutputSanitizer
+	// panes はフェーズのペインを配置したウィンドウ（端末サイズによるフォールバックを含む）
+	panes *tmux.PaneRegistry
+
+	mu     sync.Mutex
+	states map[int]*progressState
+	clock  clock.Clock
+}
+
+// NewProgressReporter は新しいProgressReporterを作成する
+func NewProgressReporter(
+	client github.GitHubClient,
+	tmuxManager tmux.Manager,
+	owner, repo, sessionName string,
+	cfg *config.Config,
+	logger logger.Logger,
+) (*ProgressReporter, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if tmuxManager == nil {
+		return nil, errors.New("tmux manager is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if _, ok := client.(github.IssueCommentEditor); !ok {
+		return nil, errors.New("github client does not support editing issue comments")
+	}
+	sanitizer, err := NewOutputSanitizer(cfg.GitHub.OutputSanitizer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProgressReporter{
+		client:      client,
+		tmuxManager: tmuxManager,
+		owner:       owner,
+		repo:        repo,
+		sessionName: sessionName,
+		config:      cfg,
+		logger:      logger,
+		sanitizer:   sanitizer,
+		states:      make(map[int]*progressState),
+		clock:       clock.New(),
+	}, nil
+}
+
+// Start は進捗報告を開始する
+func (r *ProgressReporter) Start(ctx context.Context) {
+	interval := r.config.GitHub.ProgressComment.Interval
+	r.logger.Info("Starting progress reporter", "interval", interval)
+
+	ticker := r.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Progress reporter stopped")
+			return
+		case <-ticker.C():
+			if err := r.ReportOnce(ctx); err != nil {
+				r.logger.Warn("Failed to report progress", "error", err)
+			}
+		}
+	}
+}
+
+// ReportOnce は実行中フェーズのIssueすべてについて進捗コメントを更新する
+func (r *ProgressReporter) ReportOnce(ctx context.Context) error {
+	phases := activeProgressPhases()
+	labels := make([]string, 0, len(phases))
+	for _, p := range phases {
+		labels = append(labels, p.label)
+	}
+
+	issues, err := r.client.ListIssuesByLabels(ctx, r.owner, r.repo, labels)
+	if err != nil {
+		return fmt.Errorf("failed to list in-progress issues: %w", err)
+	}
+
+	active := make(map[int]bool)
+	for _, issue := range issues {
+		if issue == nil || issue.Number == nil {
+			continue
+		}
+		phase, ok := findProgressPhase(issue)
+		if !ok {
+			continue
+		}
+		active[*issue.Number] = true
+
+		if err := r.reportIssue(ctx, *issue.Number, phase); err != nil {
+			r.logger.Warn("Failed to update progress comment",
+				"issue_number", *issue.Number,
+				"phase", phase.label,
+				"error", err)
+		}
+	}
+
+	// 実行中でなくなったIssueの追跡状態を破棄
+	r.mu.Lock()
+	for number := range r.states {
+		if !active[number] {
+			delete(r.states, number)
+		}
+	}
+	r.mu.Unlock()
+
+	return nil
+}
+
+// reportIssue は1件のIssueの進捗コメントを作成または更新する
+func (r *ProgressReporter) reportIssue(ctx context.Context, issueNumber int, phase progressPhase) error {
+	r.mu.Lock()
+	state, ok := r.states[issueNumber]
+	if !ok || state.label != phase.label {
+		commentID, created := int64(0), false
+		if ok {
+			commentID, created = state.commentID, state.created
+		}
+		state = &progressState{label: phase.label, startedAt: r.clock.Now(), commentID: commentID, created: created}
+		r.states[issueNumber] = state
+	}
+	startedAt := state.startedAt
+	commentID := state.commentID
+	created := state.created
+	r.mu.Unlock()
+
+	output := r.sanitizer.Sanitize(r.capturePhaseOutput(issueNumber, phase))
+	body := buildProgressComment(r.config, phase.label, r.clock.Since(startedAt), output, r.config.GitHub.ProgressComment.TailLines, r.clock.Now().In(r.config.Location()))
+
+	editor := r.client.(github.IssueCommentEditor)
+	if commentID == 0 {
+		id, err := r.findProgressComment(ctx, editor, issueNumber)
+		if err != nil {
+			return err
+		}
+		commentID = id
+	}
+
+	if commentID == 0 {
+		if created && r.hasPendingMutations() {
+			// 保留中の進捗コメントが反映されるまで待ち、同じコメントを重ねて作成しない
+			return nil
+		}
+		// 初回は新規作成し、次回以降はマーカーで検索して更新する
+		// 進捗コメントは状態を持たないため、GitHubに接続できない間は保留できる
+		if err := createInformationalComment(ctx, r.client, r.owner, r.repo, issueNumber, body); err != nil {
+			return err
+		}
+		r.mu.Lock()
+		if s, ok := r.states[issueNumber]; ok {
+			s.created = true
+		}
+		r.mu.Unlock()
+		return nil
+	}
+
+	if err := editor.UpdateIssueComment(ctx, r.owner, r.repo, commentID, body); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	if s, ok := r.states[issueNumber]; ok {
+		s.commentID = commentID
+	}
+	r.mu.Unlock()
+	return nil
+}
+
+// hasPendingMutations はGitHubに接続できない間に保留した変更が残っているかを返す
+func (r *ProgressReporter) hasPendingMutations() bool {
+	status, ok := r.client.(github.OfflineQueueStatus)
+	return ok && status.PendingMutations() > 0
+}
+
+// findProgressComment はマーカー付きの既存進捗コメントのIDを返す（存在しない場合は0）
+func (r *ProgressReporter) findProgressComment(ctx context.Context, editor github.IssueCommentEditor, issueNumber int) (int64, error) {
+	comments, err := editor.ListIssueComments(ctx, r.owner, r.repo, issueNumber)
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range comments {
+		if c.ID != nil && c.Body != nil && strings.HasPrefix(*c.Body, progressCommentMarker) {
+			return *c.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+// SetPaneRegistry はフェーズのペインを配置したウィンドウを記録するレジストリを設定する
+func (r *ProgressReporter) SetPaneRegistry(registry *tmux.PaneRegistry) {
+	r.panes = registry
+}
+
+// capturePhaseOutput はフェーズのペイン出力を取得する（取得できない場合は空文字列）
+func (r *ProgressReporter) capturePhaseOutput(issueNumber int, phase progressPhase) string {
+	sessionName, windowName, pane := findPhasePane(r.tmuxManager, r.config, r.panes, r.sessionName, issueNumber, phase)
+	if pane == nil {
+		r.logger.Debug("Phase pane not found for progress report",
+			"issue_number", issueNumber,
+			"window", windowName,
+			"pane_title", phase.paneTitle)
+		return ""
+	}
+
+	output, err := r.tmuxManager.CapturePane(sessionName, windowName, pane.Index, r.config.GitHub.ProgressComment.TailLines)
+	if err != nil {
+		r.logger.Debug("Failed to capture pane output", "issue_number", issueNumber, "error", err)
+		return ""
+	}
+	return output
+}
+
+// findPhasePane はIssueのフェーズのペインを探し、セッション名・ウィンドウ名とともに返す（見つからない場合はペインがnil）
+func findPhasePane(tmuxManager tmux.Manager, cfg *config.Config, panes *tmux.PaneRegistry, baseSession string, issueNumber int, phase progressPhase) (string, string, *tmux.PaneInfo) {
+	windowName := tmux.GetWindowNameForIssue(issueNumber)
+	if placement, ok := panes.Lookup(issueNumber, phase.configKey); ok {
+		windowName = placement.WindowName
+	} else if cfg.Tmux.GetPhasePaneConfig(phase.configKey).Window == config.WindowPolicySeparate {
+		windowName = tmux.GetPhaseWindowNameForIssue(issueNumber, phase.configKey)
+	}
+
+	// tmux.shardsでウィンドウが振り分けられている場合はシャードのセッションから探す
+	for _, name := range cfg.Tmux.SessionNames(baseSession) {
+		if p, err := tmuxManager.GetPaneByTitle(name, windowName, phase.paneTitle); err == nil && p != nil {
+			return name, windowName, p
+		}
+	}
+	return "", windowName, nil
+}
+
+// findProgressPhase はIssueのラベルから実行中フェーズを特定する
+func findProgressPhase(issue *github.Issue) (progressPhase, bool) {
+	for _, p := range activeProgressPhases() {
+		if hasLabel(issue, p.label) {
+			return p, true
+		}
+	}
+	return progressPhase{}, false
+}
+
+// buildProgressComment は進捗コメントの本文を生成する
+func buildProgressComment(cfg *config.Config, label string, elapsed time.Duration, output string, tailLines int, updatedAt time.Time) string {
+	var details string
+	if output != "" {
+		fence := codeFence(output)
+		details = fmt.Sprintf("\n<details><summary>直近のペイン出力（%d行）</summary>\n\n", tailLines) +
+			fence + "\n" + output + "\n" + fence + "\n</details>\n"
+	}
+
+	return progressCommentMarker + "\n" + cfg.RenderComment(config.CommentProgress, map[string]string{
+		"phase":      label,
+		"elapsed":    elapsed.Truncate(time.Second).String(),
+		"updated-at": updatedAt.Format(time.RFC3339),
+		"output":     details,
+	})
+}
+
+// codeFence は出力内のバッククォートより長いコードフェンスを返す
+func codeFence(content string) string {
+	longest, run := 0, 0
+	for _, ch := range content {
+		if ch == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	if longest < 3 {
+		return "```"
+	}
+	return strings.Repeat("`", longest+1)
+}
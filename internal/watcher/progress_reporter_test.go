@@ -0,0 +1,173 @@
+package watcher
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCommentEditorClient はIssueコメント編集に対応したGitHubクライアントのモック
+type mockCommentEditorClient struct {
+	MockGitHubClient
+}
+
+func (m *mockCommentEditorClient) ListIssueComments(ctx context.Context, owner, repo string, issueNumber int) ([]*gh.IssueComment, error) {
+	args := m.Called(ctx, owner, repo, issueNumber)
+	return args.Get(0).([]*gh.IssueComment), args.Error(1)
+}
+
+func (m *mockCommentEditorClient) UpdateIssueComment(ctx context.Context, owner, repo string, commentID int64, body string) error {
+	args := m.Called(ctx, owner, repo, commentID, body)
+	return args.Error(0)
+}
+
+var progressLabels = []string{"status:planning", "status:implementing", "status:reviewing", "status:revising"}
+
+func newProgressTestConfig() *config.Config {
+	cfg := config.NewConfig()
+	cfg.GitHub.ProgressComment = config.ProgressCommentConfig{Interval: time.Minute, TailLines: 5}
+	return cfg
+}
+
+func TestProgressReporter_ReportOnce(t *testing.T) {
+	issue := &gh.Issue{
+		Number: intPtr(10),
+		Labels: []*gh.Label{{Name: stringPtr("status:implementing")}},
+	}
+
+	t.Run("初回は新規コメントを作成", func(t *testing.T) {
+		client := new(mockCommentEditorClient)
+		tmuxManager := mocks.NewMockTmuxManager()
+
+		client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", progressLabels).Return([]*gh.Issue{issue}, nil).Once()
+		tmuxManager.On("GetPaneByTitle", "osoba-repo", "issue-10", "Implementation").
+			Return(&tmux.PaneInfo{Index: 1, Title: "Implementation"}, nil).Once()
+		tmuxManager.On("CapturePane", "osoba-repo", "issue-10", 1, 5).Return("running tests...", nil).Once()
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 10).
+			Return([]*gh.IssueComment{{ID: gh.Int64(1), Body: gh.String("LGTM")}}, nil).Once()
+		client.On("CreateIssueComment", mock.Anything, "owner", "repo", 10, mock.MatchedBy(func(body string) bool {
+			return strings.HasPrefix(body, progressCommentMarker) &&
+				strings.Contains(body, "status:implementing") &&
+				strings.Contains(body, "running tests...")
+		})).Return(nil).Once()
+
+		reporter, err := NewProgressReporter(client, tmuxManager, "owner", "repo", "osoba-repo", newProgressTestConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		require.NoError(t, reporter.ReportOnce(context.Background()))
+		client.AssertExpectations(t)
+		tmuxManager.AssertExpectations(t)
+	})
+
+	t.Run("既存の進捗コメントを更新し経過時間を保持", func(t *testing.T) {
+		client := new(mockCommentEditorClient)
+		tmuxManager := mocks.NewMockTmuxManager()
+
+		client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", progressLabels).Return([]*gh.Issue{issue}, nil).Twice()
+		tmuxManager.On("GetPaneByTitle", "osoba-repo", "issue-10", "Implementation").Return(nil, assert.AnError).Twice()
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 10).
+			Return([]*gh.IssueComment{{ID: gh.Int64(99), Body: gh.String(progressCommentMarker + "\nold")}}, nil).Once()
+		client.On("UpdateIssueComment", mock.Anything, "owner", "repo", int64(99), mock.MatchedBy(func(body string) bool {
+			return strings.Contains(body, "経過時間: 0s")
+		})).Return(nil).Once()
+		client.On("UpdateIssueComment", mock.Anything, "owner", "repo", int64(99), mock.MatchedBy(func(body string) bool {
+			return strings.Contains(body, "経過時間: 6m0s")
+		})).Return(nil).Once()
+
+		reporter, err := NewProgressReporter(client, tmuxManager, "owner", "repo", "osoba-repo", newProgressTestConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		fakeClock := clock.NewFake(time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC))
+		reporter.clock = fakeClock
+		require.NoError(t, reporter.ReportOnce(context.Background()))
+
+		fakeClock.Advance(6 * time.Minute)
+		require.NoError(t, reporter.ReportOnce(context.Background()))
+
+		// 2回目はコメントIDをキャッシュしているため一覧取得は1回のみ
+		client.AssertExpectations(t)
+	})
+}
+
+func TestBuildProgressComment_EscapesCodeFence(t *testing.T) {
+	body := buildProgressComment(config.NewConfig(), "status:reviewing", 90*time.Second, "```go\nfmt.Println()\n```", 20, time.Now())
+
+	assert.True(t, strings.HasPrefix(body, progressCommentMarker))
+	assert.Contains(t, body, "経過時間: 1m30s")
+	assert.Contains(t, body, "````\n```go")
+}
+
+// mockDeferredCommentClient はGitHubに接続できない間のコメントを保留するクライアントのモック
+type mockDeferredCommentClient struct {
+	mockCommentEditorClient
+	pending []string
+	posted  []string
+}
+
+func (m *mockDeferredCommentClient) CreateIssueCommentDeferred(ctx context.Context, owner, repo string, issueNumber int, comment string) error {
+	m.pending = append(m.pending, comment)
+	return nil
+}
+
+func (m *mockDeferredCommentClient) AddInformationalLabel(ctx context.Context, owner, repo string, issueNumber int, label string) error {
+	return nil
+}
+
+func (m *mockDeferredCommentClient) PendingMutations() int {
+	return len(m.pending)
+}
+
+func (m *mockDeferredCommentClient) ListIssueComments(ctx context.Context, owner, repo string, issueNumber int) ([]*gh.IssueComment, error) {
+	comments := make([]*gh.IssueComment, 0, len(m.posted))
+	for i, body := range m.posted {
+		comments = append(comments, &gh.IssueComment{ID: gh.Int64(int64(i + 1)), Body: gh.String(body)})
+	}
+	return comments, nil
+}
+
+// flush は接続が戻った時と同様に保留したコメントを投稿する
+func (m *mockDeferredCommentClient) flush() {
+	m.posted = append(m.posted, m.pending...)
+	m.pending = nil
+}
+
+func TestProgressReporter_ReportOnce_DeferredComment(t *testing.T) {
+	issue := &gh.Issue{
+		Number: intPtr(10),
+		Labels: []*gh.Label{{Name: stringPtr("status:implementing")}},
+	}
+	client := new(mockDeferredCommentClient)
+	tmuxManager := mocks.NewMockTmuxManager()
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", progressLabels).Return([]*gh.Issue{issue}, nil)
+	tmuxManager.On("GetPaneByTitle", "osoba-repo", "issue-10", "Implementation").Return(nil, assert.AnError)
+
+	reporter, err := NewProgressReporter(client, tmuxManager, "owner", "repo", "osoba-repo", newProgressTestConfig(), NewMockLogger())
+	require.NoError(t, err)
+
+	// GitHubに接続できない間は進捗コメントを保留し、次のポーリングでも重ねて作成しない
+	require.NoError(t, reporter.ReportOnce(context.Background()))
+	require.NoError(t, reporter.ReportOnce(context.Background()))
+	require.Len(t, client.pending, 1)
+	assert.True(t, strings.HasPrefix(client.pending[0], progressCommentMarker))
+
+	// 接続が戻り保留したコメントが投稿された後は、そのコメントを更新する
+	client.flush()
+	client.On("UpdateIssueComment", mock.Anything, "owner", "repo", int64(1), mock.MatchedBy(func(body string) bool {
+		return strings.HasPrefix(body, progressCommentMarker)
+	})).Return(nil).Once()
+	require.NoError(t, reporter.ReportOnce(context.Background()))
+
+	assert.Empty(t, client.pending)
+	assert.Len(t, client.posted, 1)
+	client.AssertExpectations(t)
+}
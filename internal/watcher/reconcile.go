@@ -0,0 +1,273 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/tmux"
+)
+
+// issueWorktreePattern はosobaが作成するworktreeのパス（.git/osoba/worktrees/issue-<番号> または <番号>-<フェーズ>）
+// worktree.scratch_dirを設定した場合は<scratch_dir>/<リポジトリ>/osoba/worktrees/配下になる
+var issueWorktreePattern = regexp.MustCompile(`/osoba/worktrees/(?:issue-(\d+)|(\d+)-[a-z]+)$`)
+
+// ReconcileItem は起動時の突き合わせで見つかった項目
+type ReconcileItem struct {
+	IssueNumber int    `json:"issue_number"`
+	Label       string `json:"label,omitempty"`     // 対象のステータスラベル
+	NewLabel    string `json:"new_label,omitempty"` // 修復後・現在のステータスラベル
+	Resource    string `json:"resource,omitempty"`  // tmuxウィンドウ名またはworktreeのパス
+}
+
+// ReconcileReport は起動時の突き合わせ結果
+type ReconcileReport struct {
+	PreviousRun time.Time       `json:"previous_run,omitempty"` // 前回の状態ファイルの更新日時（ない場合はゼロ値）
+	Resumed     []ReconcileItem `json:"resumed"`                // 実行中ラベルとtmuxウィンドウが残っており、作業を継続するIssue
+	Repaired    []ReconcileItem `json:"repaired"`               // 実行中ラベルのままtmuxウィンドウがないため、トリガーラベルに戻したIssue
+	Orphans     []ReconcileItem `json:"orphans"`                // オープンでないIssueのtmuxウィンドウ・worktree
+	Changed     []ReconcileItem `json:"changed"`                // 停止中にステータスが変わったIssue
+}
+
+// StartupReconciler は起動時にGitHubのラベル・tmuxウィンドウ・worktree・前回の状態ファイルを突き合わせ、
+// 前回の実行で中断された作業を整理する
+type StartupReconciler struct {
+	client          github.GitHubClient
+	tmuxManager     tmux.Manager
+	worktreeManager git.WorktreeManager
+	owner           string
+	repo            string
+	sessionName     string
+	shardSessions   []string // tmux.shardsのセッション
+	statePath       string   // 前回の状態ファイル（osoba statusのキャッシュ）
+	logger          logger.Logger
+}
+
+// NewStartupReconciler は新しいStartupReconcilerを作成する
+func NewStartupReconciler(client github.GitHubClient, tmuxManager tmux.Manager, worktreeManager git.WorktreeManager, owner, repo, sessionName, statePath string, logger logger.Logger) (*StartupReconciler, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if tmuxManager == nil {
+		return nil, errors.New("tmux manager is required")
+	}
+	if worktreeManager == nil {
+		return nil, errors.New("worktree manager is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+
+	return &StartupReconciler{
+		client:          client,
+		tmuxManager:     tmuxManager,
+		worktreeManager: worktreeManager,
+		owner:           owner,
+		repo:            repo,
+		sessionName:     sessionName,
+		statePath:       statePath,
+		logger:          logger,
+	}, nil
+}
+
+// SetShardSessions はIssueのウィンドウが振り分けられているtmux.shardsのセッションを設定する
+func (r *StartupReconciler) SetShardSessions(sessions []string) {
+	r.shardSessions = sessions
+}
+
+// Reconcile は突き合わせを行い、結果を返す
+// tmuxウィンドウやworktreeの取得に失敗した場合は警告を記録し、取得できた情報で突き合わせる
+func (r *StartupReconciler) Reconcile(ctx context.Context) (*ReconcileReport, error) {
+	issues, err := r.client.ListAllOpenIssues(ctx, r.owner, r.repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open issues: %w", err)
+	}
+	openIssues := make(map[int]*github.Issue, len(issues))
+	for _, issue := range issues {
+		if issue != nil && issue.Number != nil {
+			openIssues[*issue.Number] = issue
+		}
+	}
+
+	windows := r.issueWindows()
+	worktrees := r.issueWorktrees(ctx)
+	report := &ReconcileReport{}
+
+	for _, number := range sortedIssueNumbers(openIssues) {
+		issue := openIssues[number]
+		if hasLabel(issue, ManualLabel) {
+			continue
+		}
+		for _, trigger := range getTriggerLabelPriority() {
+			execution := GetTriggerLabelMapping()[trigger]
+			if execution == "" || !hasLabel(issue, execution) {
+				continue
+			}
+			if len(windows[number]) > 0 {
+				report.Resumed = append(report.Resumed, ReconcileItem{IssueNumber: number, Label: execution, Resource: windows[number][0]})
+				continue
+			}
+			if err := r.repairLabel(ctx, issue, execution, trigger); err != nil {
+				r.logger.Warn("Failed to repair execution label",
+					"issueNumber", number,
+					"label", execution,
+					"error", err)
+				continue
+			}
+			report.Repaired = append(report.Repaired, ReconcileItem{IssueNumber: number, Label: execution, NewLabel: trigger})
+		}
+	}
+
+	for _, number := range sortedIssueNumbers(windows) {
+		if _, ok := openIssues[number]; ok {
+			continue
+		}
+		for _, name := range windows[number] {
+			report.Orphans = append(report.Orphans, ReconcileItem{IssueNumber: number, Resource: name})
+		}
+	}
+	for _, number := range sortedIssueNumbers(worktrees) {
+		if _, ok := openIssues[number]; ok {
+			continue
+		}
+		for _, path := range worktrees[number] {
+			report.Orphans = append(report.Orphans, ReconcileItem{IssueNumber: number, Resource: path})
+		}
+	}
+
+	r.compareWithPreviousState(report, openIssues)
+
+	r.logger.Info("Startup reconciliation completed",
+		"resumed", len(report.Resumed),
+		"repaired", len(report.Repaired),
+		"orphans", len(report.Orphans),
+		"changed", len(report.Changed))
+	return report, nil
+}
+
+// repairLabel は実行中ラベルをトリガーラベルに戻し、次回のポーリングでフェーズを再開させる
+func (r *StartupReconciler) repairLabel(ctx context.Context, issue *github.Issue, execution, trigger string) error {
+	if hasLabel(issue, trigger) {
+		return r.client.RemoveLabel(ctx, r.owner, r.repo, *issue.Number, execution)
+	}
+	return r.client.TransitionLabels(ctx, r.owner, r.repo, *issue.Number, execution, trigger)
+}
+
+// issueWindows はセッション（シャードのセッションを含む）内のIssueごとのtmuxウィンドウ名を返す
+func (r *StartupReconciler) issueWindows() map[int][]string {
+	names, err := r.tmuxManager.ListWindows(r.sessionName)
+	if err != nil {
+		r.logger.Warn("Failed to list tmux windows for reconciliation",
+			"session", r.sessionName,
+			"error", err)
+		return nil
+	}
+	for _, session := range r.shardSessions {
+		// まだウィンドウが振り分けられていないシャードのセッションは存在しない
+		if exists, err := r.tmuxManager.SessionExists(session); err == nil && !exists {
+			continue
+		}
+		shardNames, err := r.tmuxManager.ListWindows(session)
+		if err != nil {
+			r.logger.Warn("Failed to list tmux windows for reconciliation",
+				"session", session,
+				"error", err)
+			continue
+		}
+		names = append(names, shardNames...)
+	}
+	windows := make(map[int][]string)
+	for _, name := range names {
+		number, err := tmux.ParseWindowNameForIssue(name)
+		if err != nil {
+			var ok bool
+			if number, _, ok = tmux.ParseWindowName(name); !ok {
+				continue
+			}
+		}
+		windows[number] = append(windows[number], name)
+	}
+	return windows
+}
+
+// issueWorktrees はIssueごとのworktreeのパスを返す
+func (r *StartupReconciler) issueWorktrees(ctx context.Context) map[int][]string {
+	list, err := r.worktreeManager.ListAllWorktrees(ctx)
+	if err != nil {
+		r.logger.Warn("Failed to list worktrees for reconciliation", "error", err)
+		return nil
+	}
+	worktrees := make(map[int][]string)
+	for _, wt := range list {
+		matches := issueWorktreePattern.FindStringSubmatch(wt.Path)
+		if matches == nil {
+			continue
+		}
+		number, _ := strconv.Atoi(matches[1] + matches[2])
+		worktrees[number] = append(worktrees[number], wt.Path)
+	}
+	return worktrees
+}
+
+// compareWithPreviousState は前回の状態ファイルと現在のラベルを比較し、停止中に変わったIssueを記録する
+func (r *StartupReconciler) compareWithPreviousState(report *ReconcileReport, openIssues map[int]*github.Issue) {
+	if r.statePath == "" {
+		return
+	}
+	previous, err := ReadStatusState(r.statePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			r.logger.Warn("Failed to read previous status state", "path", r.statePath, "error", err)
+		}
+		return
+	}
+	report.PreviousRun = previous.UpdatedAt
+
+	for _, label := range StatusLabels {
+		for _, item := range previous.Issues[label] {
+			issue, open := openIssues[item.Number]
+			switch {
+			case !open:
+				report.Changed = append(report.Changed, ReconcileItem{IssueNumber: item.Number, Label: label, NewLabel: "closed"})
+			case !hasLabel(issue, label):
+				report.Changed = append(report.Changed, ReconcileItem{IssueNumber: item.Number, Label: label, NewLabel: strings.Join(statusLabelsOf(issue), ",")})
+			}
+		}
+	}
+	sort.SliceStable(report.Changed, func(i, j int) bool {
+		return report.Changed[i].IssueNumber < report.Changed[j].IssueNumber
+	})
+}
+
+// statusLabelsOf はIssueに付いているstatus:ラベルを返す
+func statusLabelsOf(issue *github.Issue) []string {
+	var labels []string
+	for _, label := range issue.Labels {
+		if label != nil && label.Name != nil && strings.HasPrefix(*label.Name, "status:") {
+			labels = append(labels, *label.Name)
+		}
+	}
+	return labels
+}
+
+// sortedIssueNumbers はIssue番号をキーとするマップのキーを昇順で返す
+func sortedIssueNumbers[V any](m map[int]V) []int {
+	numbers := make([]int, 0, len(m))
+	for number := range m {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	return numbers
+}
@@ -0,0 +1,118 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newReconcileTestIssue(number int, labels ...string) *github.Issue {
+	issue := createTestIssueWithLabels(labels)
+	issue.Number = github.Int(number)
+	return issue
+}
+
+func TestStartupReconciler_Reconcile(t *testing.T) {
+	client := new(MockGitHubClient)
+	client.On("ListAllOpenIssues", mock.Anything, "owner", "repo").Return([]*github.Issue{
+		newReconcileTestIssue(10, "status:implementing"),
+		newReconcileTestIssue(11, "status:planning"),
+		newReconcileTestIssue(12, "status:reviewing", "status:review-requested"),
+		newReconcileTestIssue(13, "status:implementing", "status:manual"),
+		newReconcileTestIssue(14, "status:ready"),
+	}, nil)
+	client.On("TransitionLabels", mock.Anything, "owner", "repo", 11, "status:planning", "status:needs-plan").Return(nil).Once()
+	client.On("RemoveLabel", mock.Anything, "owner", "repo", 12, "status:reviewing").Return(nil).Once()
+
+	tmuxManager := mocks.NewMockTmuxManager()
+	tmuxManager.On("ListWindows", "osoba-repo").Return([]string{"issue-10", "9-implement", "scratch"}, nil)
+
+	worktreeManager := mocks.NewMockGitWorktreeManager()
+	worktreeManager.On("ListAllWorktrees", mock.Anything).Return([]git.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/repo/.git/osoba/worktrees/issue-10", Branch: "osoba/#10"},
+		{Path: "/repo/.git/osoba/worktrees/issue-8", Branch: "osoba/#8"},
+	}, nil)
+
+	// 前回の実行時は#14が計画中、#7がレビュー待ちだった
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	previousRun := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	data, err := json.Marshal(&StatusState{
+		UpdatedAt: previousRun,
+		Issues: map[string][]StatusStateIssue{
+			"status:planning":         {{Number: 14}},
+			"status:review-requested": {{Number: 7}},
+			"status:implementing":     {{Number: 10}},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(statePath, data, 0644))
+
+	reconciler, err := NewStartupReconciler(client, tmuxManager, worktreeManager, "owner", "repo", "osoba-repo", statePath, NewMockLogger())
+	require.NoError(t, err)
+
+	report, err := reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+
+	assert.Equal(t, previousRun, report.PreviousRun)
+	assert.Equal(t, []ReconcileItem{{IssueNumber: 10, Label: "status:implementing", Resource: "issue-10"}}, report.Resumed)
+	assert.Equal(t, []ReconcileItem{
+		{IssueNumber: 11, Label: "status:planning", NewLabel: "status:needs-plan"},
+		{IssueNumber: 12, Label: "status:reviewing", NewLabel: "status:review-requested"},
+	}, report.Repaired)
+	assert.Equal(t, []ReconcileItem{
+		{IssueNumber: 9, Resource: "9-implement"},
+		{IssueNumber: 8, Resource: "/repo/.git/osoba/worktrees/issue-8"},
+	}, report.Orphans)
+	assert.Equal(t, []ReconcileItem{
+		{IssueNumber: 7, Label: "status:review-requested", NewLabel: "closed"},
+		{IssueNumber: 14, Label: "status:planning", NewLabel: "status:ready"},
+	}, report.Changed)
+}
+
+func TestStartupReconciler_ReconcileWithoutLocalState(t *testing.T) {
+	client := new(MockGitHubClient)
+	client.On("ListAllOpenIssues", mock.Anything, "owner", "repo").Return([]*github.Issue{
+		newReconcileTestIssue(10, "status:implementing"),
+	}, nil)
+	client.On("TransitionLabels", mock.Anything, "owner", "repo", 10, "status:implementing", "status:ready").Return(errors.New("gh failed")).Once()
+
+	tmuxManager := mocks.NewMockTmuxManager()
+	tmuxManager.On("ListWindows", "osoba-repo").Return(nil, errors.New("no server running"))
+	worktreeManager := mocks.NewMockGitWorktreeManager()
+	worktreeManager.On("ListAllWorktrees", mock.Anything).Return([]git.WorktreeInfo(nil), errors.New("not a git repository"))
+
+	reconciler, err := NewStartupReconciler(client, tmuxManager, worktreeManager, "owner", "repo", "osoba-repo", filepath.Join(t.TempDir(), "missing.json"), NewMockLogger())
+	require.NoError(t, err)
+
+	report, err := reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.True(t, report.PreviousRun.IsZero())
+	assert.Empty(t, report.Resumed)
+	assert.Empty(t, report.Repaired, "修復に失敗したIssueは含めない")
+	assert.Empty(t, report.Orphans)
+	assert.Empty(t, report.Changed)
+}
+
+func TestStartupReconciler_ReconcileListIssuesError(t *testing.T) {
+	client := new(MockGitHubClient)
+	client.On("ListAllOpenIssues", mock.Anything, "owner", "repo").Return(nil, errors.New("rate limited"))
+
+	reconciler, err := NewStartupReconciler(client, mocks.NewMockTmuxManager(), mocks.NewMockGitWorktreeManager(), "owner", "repo", "osoba-repo", "", NewMockLogger())
+	require.NoError(t, err)
+
+	_, err = reconciler.Reconcile(context.Background())
+	assert.EqualError(t, err, "failed to list open issues: rate limited")
+}
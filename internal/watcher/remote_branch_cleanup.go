@@ -0,0 +1,210 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// remoteBranchClient はリモートのブランチの削除に使用するクライアント
+type remoteBranchClient interface {
+	GetPullRequestStatus(ctx context.Context, prNumber int) (*github.PullRequest, error)
+	github.BranchDeleter
+}
+
+// RemoteBranchCleaner は自動マージとローカルのクリーンアップの後に、マージしたPRのリモートのブランチを削除する
+// github.remote_branch_cleanupのパターンに一致しないブランチと保護されたブランチは削除しない
+// --autoでマージを予約したPRのブランチは保留として記録し、マージされた後のポーリング（ProcessPending）で削除する
+// nilの場合は何もしない
+type RemoteBranchCleaner struct {
+	client    remoteBranchClient
+	inspector github.RepositoryInspector // ブランチ保護の確認（サポートしないクライアントの場合はnil）
+	owner     string
+	repo      string
+	config    config.RemoteBranchCleanupConfig
+	safety    config.SafetyConfig
+	logger    logger.Logger
+
+	mu      sync.Mutex
+	path    string         // 保留の保存先（空の場合は保存しない）
+	pending map[int]string // マージを待っているPR番号とブランチ
+}
+
+// NewRemoteBranchCleaner は新しいRemoteBranchCleanerを作成する
+func NewRemoteBranchCleaner(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*RemoteBranchCleaner, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if logger == nil {
+		return nil, errors.New("logger is required")
+	}
+	branchClient, ok := client.(remoteBranchClient)
+	if !ok {
+		return nil, errors.New("github client does not support deleting branches")
+	}
+	inspector, _ := client.(github.RepositoryInspector)
+
+	return &RemoteBranchCleaner{
+		client:    branchClient,
+		inspector: inspector,
+		owner:     owner,
+		repo:      repo,
+		config:    cfg.GitHub.RemoteBranchCleanup,
+		safety:    cfg.Safety,
+		logger:    logger.WithFields("component", "remote_branch_cleaner"),
+		pending:   make(map[int]string),
+	}, nil
+}
+
+// SetStorePath は保留の保存先を設定し、保存済みの保留を読み込む
+func (c *RemoteBranchCleaner) SetStorePath(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending := make(map[int]string)
+	if err := loadStoreFile(path, &pending); err != nil {
+		return err
+	}
+	for prNumber, branch := range pending {
+		c.pending[prNumber] = branch
+	}
+	c.path = path
+	return nil
+}
+
+// ProcessPending はマージを待っているPRのうち、マージされたPRのリモートのブランチを削除する
+// マージされずにクローズされたPRは保留から外す
+func (c *RemoteBranchCleaner) ProcessPending(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	prNumbers := make([]int, 0, len(c.pending))
+	for prNumber := range c.pending {
+		prNumbers = append(prNumbers, prNumber)
+	}
+	c.mu.Unlock()
+	sort.Ints(prNumbers)
+
+	for _, prNumber := range prNumbers {
+		if ctx.Err() != nil {
+			return
+		}
+		c.mu.Lock()
+		branch := c.pending[prNumber]
+		c.mu.Unlock()
+		c.Cleanup(ctx, prNumber, branch)
+	}
+}
+
+// setPending はkeepの場合はPRを保留に追加し、それ以外の場合は保留から外す
+func (c *RemoteBranchCleaner) setPending(prNumber int, branch string, keep bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	current, exists := c.pending[prNumber]
+	switch {
+	case keep && exists && current == branch, !keep && !exists:
+		return
+	case keep:
+		c.pending[prNumber] = branch
+	default:
+		delete(c.pending, prNumber)
+	}
+	if err := saveStoreFile(c.path, c.pending); err != nil {
+		c.logger.Warn("Failed to save pending remote branch deletions", "path", c.path, "error", err)
+	}
+}
+
+// Cleanup はマージしたPRのリモートのブランチを削除し、削除した場合はtrueを返す
+// まだマージされていないPRと、状態を取得できなかったPRは保留に記録し、ProcessPendingで再試行する
+// 失敗はログに記録するのみで、呼び出し元の処理は継続する
+func (c *RemoteBranchCleaner) Cleanup(ctx context.Context, prNumber int, branch string) bool {
+	if c == nil || branch == "" {
+		return false
+	}
+	keep := false
+	defer func() { c.setPending(prNumber, branch, keep) }()
+
+	if !c.config.Matches(branch) {
+		c.logger.Debug("Keeping remote branch not matching cleanup patterns",
+			"pr_number", prNumber,
+			"branch", branch)
+		return false
+	}
+	if c.safety.RequiresConfirmation(config.OperationDeleteBranch) {
+		c.logger.Warn("Skipping remote branch deletion that requires confirmation",
+			"pr_number", prNumber,
+			"branch", branch,
+			"operation", config.OperationDeleteBranch,
+			"hint", "run with --yes or add the operation to safety.allow")
+		return false
+	}
+
+	// --autoでマージを予約した場合はチェックの完了を待っている可能性があるため、マージ済みの場合のみ削除する
+	pr, err := c.client.GetPullRequestStatus(ctx, prNumber)
+	if err != nil {
+		c.logger.Warn("Failed to get pull request state before deleting remote branch",
+			"pr_number", prNumber,
+			"branch", branch,
+			"error", err)
+		keep = true
+		return false
+	}
+	if pr != nil && strings.EqualFold(pr.State, "OPEN") {
+		c.logger.Info("Keeping remote branch of pull request not merged yet, will retry after merge",
+			"pr_number", prNumber,
+			"branch", branch)
+		keep = true
+		return false
+	}
+	if pr == nil || !strings.EqualFold(pr.State, "MERGED") {
+		c.logger.Info("Keeping remote branch of pull request closed without merge",
+			"pr_number", prNumber,
+			"branch", branch)
+		return false
+	}
+
+	if c.inspector != nil {
+		protected, err := c.inspector.IsBranchProtected(ctx, c.owner, c.repo, branch)
+		if err != nil {
+			c.logger.Warn("Failed to check branch protection before deleting remote branch",
+				"pr_number", prNumber,
+				"branch", branch,
+				"error", err)
+			return false
+		}
+		if protected {
+			c.logger.Info("Keeping protected remote branch",
+				"pr_number", prNumber,
+				"branch", branch)
+			return false
+		}
+	}
+
+	if err := c.client.DeleteBranch(ctx, c.owner, c.repo, branch); err != nil {
+		c.logger.Warn("Failed to delete remote branch",
+			"pr_number", prNumber,
+			"branch", branch,
+			"error", err)
+		return false
+	}
+	c.logger.Info("Deleted remote branch of merged pull request",
+		"pr_number", prNumber,
+		"branch", branch)
+	return true
+}
@@ -0,0 +1,131 @@
+package watcher
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRemoteBranchClient はリモートのブランチの削除に対応したGitHubクライアントのモック
+type mockRemoteBranchClient struct {
+	mockRepositoryInspectorClient
+}
+
+func (m *mockRemoteBranchClient) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	args := m.Called(ctx, owner, repo, branch)
+	return args.Error(0)
+}
+
+func TestRemoteBranchCleaner_Cleanup(t *testing.T) {
+	tests := []struct {
+		name        string
+		branch      string
+		deny        []string
+		confirm     bool
+		setup       func(m *mockRemoteBranchClient)
+		wantDeleted bool
+	}{
+		{
+			name:   "マージ済みのosobaのブランチを削除する",
+			branch: "osoba/#12",
+			setup: func(m *mockRemoteBranchClient) {
+				m.On("GetPullRequestStatus", mock.Anything, 34).Return(&github.PullRequest{Number: 34, State: "MERGED"}, nil)
+				m.On("IsBranchProtected", mock.Anything, "owner", "repo", "osoba/#12").Return(false, nil)
+				m.On("DeleteBranch", mock.Anything, "owner", "repo", "osoba/#12").Return(nil)
+			},
+			wantDeleted: true,
+		},
+		{
+			name:   "パターンに一致しないブランチは削除しない",
+			branch: "feature/login",
+		},
+		{
+			name:   "denyに一致するブランチは削除しない",
+			branch: "osoba/#12",
+			deny:   []string{"osoba/#1*"},
+		},
+		{
+			name:    "確認が必要な場合は削除しない",
+			branch:  "osoba/#12",
+			confirm: true,
+		},
+		{
+			name:   "マージを予約しただけのPRのブランチは削除しない",
+			branch: "osoba/#12",
+			setup: func(m *mockRemoteBranchClient) {
+				m.On("GetPullRequestStatus", mock.Anything, 34).Return(&github.PullRequest{Number: 34, State: "OPEN"}, nil)
+			},
+		},
+		{
+			name:   "保護されたブランチは削除しない",
+			branch: "osoba/#12",
+			setup: func(m *mockRemoteBranchClient) {
+				m.On("GetPullRequestStatus", mock.Anything, 34).Return(&github.PullRequest{Number: 34, State: "MERGED"}, nil)
+				m.On("IsBranchProtected", mock.Anything, "owner", "repo", "osoba/#12").Return(true, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockRemoteBranchClient{}
+			if tt.setup != nil {
+				tt.setup(client)
+			}
+			cfg := config.NewConfig()
+			cfg.GitHub.RemoteBranchCleanup.Enabled = true
+			cfg.GitHub.RemoteBranchCleanup.Deny = tt.deny
+			cfg.Safety.ConfirmDestructive = tt.confirm
+			cleaner, err := NewRemoteBranchCleaner(client, "owner", "repo", cfg, NewMockLogger())
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantDeleted, cleaner.Cleanup(context.Background(), 34, tt.branch))
+			client.AssertExpectations(t)
+			if !tt.wantDeleted {
+				client.AssertNotCalled(t, "DeleteBranch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRemoteBranchCleaner_ProcessPending(t *testing.T) {
+	client := &mockRemoteBranchClient{}
+	client.On("GetPullRequestStatus", mock.Anything, 34).Return(&github.PullRequest{Number: 34, State: "OPEN"}, nil).Once()
+	client.On("GetPullRequestStatus", mock.Anything, 35).Return(&github.PullRequest{Number: 35, State: "OPEN"}, nil).Once()
+
+	cfg := config.NewConfig()
+	cfg.GitHub.RemoteBranchCleanup.Enabled = true
+	storePath := filepath.Join(t.TempDir(), "remote-branches.json")
+	cleaner, err := NewRemoteBranchCleaner(client, "owner", "repo", cfg, NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, cleaner.SetStorePath(storePath))
+
+	// マージを予約しただけのPRは保留に記録する
+	assert.False(t, cleaner.Cleanup(context.Background(), 34, "osoba/#12"))
+	assert.False(t, cleaner.Cleanup(context.Background(), 35, "osoba/#13"))
+
+	// 再起動後のポーリングで、マージされたPRのブランチを削除し、クローズされたPRは保留から外す
+	restarted, err := NewRemoteBranchCleaner(client, "owner", "repo", cfg, NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, restarted.SetStorePath(storePath))
+	client.On("GetPullRequestStatus", mock.Anything, 34).Return(&github.PullRequest{Number: 34, State: "MERGED"}, nil).Once()
+	client.On("GetPullRequestStatus", mock.Anything, 35).Return(&github.PullRequest{Number: 35, State: "CLOSED"}, nil).Once()
+	client.On("IsBranchProtected", mock.Anything, "owner", "repo", "osoba/#12").Return(false, nil).Once()
+	client.On("DeleteBranch", mock.Anything, "owner", "repo", "osoba/#12").Return(nil).Once()
+	restarted.ProcessPending(context.Background())
+
+	client.AssertExpectations(t)
+	assert.Empty(t, restarted.pending)
+}
+
+func TestRemoteBranchCleaner_NilDoesNothing(t *testing.T) {
+	var cleaner *RemoteBranchCleaner
+	assert.False(t, cleaner.Cleanup(context.Background(), 1, "osoba/#1"))
+	cleaner.ProcessPending(context.Background())
+}
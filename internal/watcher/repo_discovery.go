@@ -0,0 +1,156 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// RepoRunner はリポジトリごとのwatcherを起動・停止する
+type RepoRunner interface {
+	// StartRepo はリポジトリのwatcherを起動する（起動後すぐに戻る）
+	StartRepo(ctx context.Context, repo *github.OrgRepository) error
+	// StopRepo はリポジトリのwatcherを停止する
+	StopRepo(repo *github.OrgRepository) error
+	// IsRunning はリポジトリのwatcherが動作中かを返す
+	IsRunning(repo *github.OrgRepository) bool
+}
+
+// RepoDiscoverer は組織のリポジトリを定期的に検出し、条件に一致するリポジトリのwatcherを起動・停止する
+type RepoDiscoverer struct {
+	lister github.OrgRepositoryLister
+	runner RepoRunner
+	org    string
+	config *config.Config
+	logger logger.Logger
+
+	running map[string]*github.OrgRepository
+}
+
+// NewRepoDiscoverer は新しいRepoDiscovererを作成する
+func NewRepoDiscoverer(client github.GitHubClient, runner RepoRunner, cfg *config.Config, logger logger.Logger) (*RepoDiscoverer, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if runner == nil {
+		return nil, errors.New("repo runner is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if cfg.GitHub.Org == "" {
+		return nil, errors.New("org is required")
+	}
+	lister, ok := client.(github.OrgRepositoryLister)
+	if !ok {
+		return nil, errors.New("github client does not support listing organization repositories")
+	}
+
+	return &RepoDiscoverer{
+		lister:  lister,
+		runner:  runner,
+		org:     cfg.GitHub.Org,
+		config:  cfg,
+		logger:  logger,
+		running: make(map[string]*github.OrgRepository),
+	}, nil
+}
+
+// Start はリポジトリの検出を開始し、終了時にすべてのwatcherを停止する
+func (d *RepoDiscoverer) Start(ctx context.Context) {
+	interval := d.config.GitHub.OrgRepos.DiscoveryInterval
+	d.logger.Info("Starting repository discovery", "org", d.org, "interval", interval)
+
+	if err := d.DiscoverOnce(ctx); err != nil {
+		d.logger.Warn("Failed to discover repositories", "org", d.org, "error", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.StopAll()
+			d.logger.Info("Repository discovery stopped", "org", d.org)
+			return
+		case <-ticker.C:
+			if err := d.DiscoverOnce(ctx); err != nil {
+				d.logger.Warn("Failed to discover repositories", "org", d.org, "error", err)
+			}
+		}
+	}
+}
+
+// DiscoverOnce はリポジトリ一覧を取得し、新たに条件に一致したリポジトリのwatcherを起動し、
+// アーカイブ済み・条件に一致しなくなった・削除されたリポジトリのwatcherを停止する
+func (d *RepoDiscoverer) DiscoverOnce(ctx context.Context) error {
+	repos, err := d.lister.ListOrgRepositories(ctx, d.org)
+	if err != nil {
+		return fmt.Errorf("failed to list repositories of %s: %w", d.org, err)
+	}
+
+	wanted := make(map[string]*github.OrgRepository)
+	for _, repo := range repos {
+		if repo.Archived || !d.config.GitHub.OrgRepos.Matches(repo.Name, repo.Topics) {
+			continue
+		}
+		wanted[repo.FullName()] = repo
+	}
+
+	for _, name := range sortedRepoNames(d.running) {
+		if _, ok := wanted[name]; ok {
+			continue
+		}
+		if err := d.runner.StopRepo(d.running[name]); err != nil {
+			d.logger.Warn("Failed to stop repository watcher", "repo", name, "error", err)
+			continue
+		}
+		delete(d.running, name)
+		d.logger.Info("Stopped repository watcher", "repo", name)
+	}
+
+	for _, name := range sortedRepoNames(wanted) {
+		if repo, ok := d.running[name]; ok {
+			if d.runner.IsRunning(repo) {
+				continue
+			}
+			d.logger.Warn("Repository watcher exited unexpectedly, restarting", "repo", name)
+			delete(d.running, name)
+		}
+		if err := d.runner.StartRepo(ctx, wanted[name]); err != nil {
+			// 次回の検出で再試行する
+			d.logger.Warn("Failed to start repository watcher", "repo", name, "error", err)
+			continue
+		}
+		d.running[name] = wanted[name]
+		d.logger.Info("Started repository watcher", "repo", name)
+	}
+
+	return nil
+}
+
+// StopAll は起動中のすべてのwatcherを停止する
+func (d *RepoDiscoverer) StopAll() {
+	for _, name := range sortedRepoNames(d.running) {
+		if err := d.runner.StopRepo(d.running[name]); err != nil {
+			d.logger.Warn("Failed to stop repository watcher", "repo", name, "error", err)
+		}
+		delete(d.running, name)
+	}
+}
+
+func sortedRepoNames(repos map[string]*github.OrgRepository) []string {
+	names := make([]string, 0, len(repos))
+	for name := range repos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,93 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockOrgRepoClient は組織のリポジトリ一覧の取得に対応したGitHubクライアントのモック
+type mockOrgRepoClient struct {
+	MockGitHubClient
+}
+
+func (m *mockOrgRepoClient) ListOrgRepositories(ctx context.Context, org string) ([]*gh.OrgRepository, error) {
+	args := m.Called(ctx, org)
+	return args.Get(0).([]*gh.OrgRepository), args.Error(1)
+}
+
+// mockRepoRunner はRepoRunnerのモック
+type mockRepoRunner struct {
+	mock.Mock
+}
+
+func (m *mockRepoRunner) StartRepo(ctx context.Context, repo *gh.OrgRepository) error {
+	return m.Called(repo.FullName()).Error(0)
+}
+
+func (m *mockRepoRunner) StopRepo(repo *gh.OrgRepository) error {
+	return m.Called(repo.FullName()).Error(0)
+}
+
+func (m *mockRepoRunner) IsRunning(repo *gh.OrgRepository) bool {
+	return m.Called(repo.FullName()).Bool(0)
+}
+
+func newOrgTestConfig() *config.Config {
+	cfg := config.NewConfig()
+	cfg.GitHub.Org = "myorg"
+	cfg.GitHub.OrgRepos.Topics = []string{"osoba"}
+	return cfg
+}
+
+func TestRepoDiscoverer_DiscoverOnce(t *testing.T) {
+	api := &gh.OrgRepository{Owner: "myorg", Name: "api", Topics: []string{"osoba"}}
+	web := &gh.OrgRepository{Owner: "myorg", Name: "web", Topics: []string{"osoba"}}
+	docs := &gh.OrgRepository{Owner: "myorg", Name: "docs"}
+	archivedWeb := &gh.OrgRepository{Owner: "myorg", Name: "web", Topics: []string{"osoba"}, Archived: true}
+
+	client := new(mockOrgRepoClient)
+	runner := new(mockRepoRunner)
+
+	// 1回目: 条件に一致するリポジトリのwatcherを起動（webは起動に失敗）
+	client.On("ListOrgRepositories", mock.Anything, "myorg").Return([]*gh.OrgRepository{api, web, docs}, nil).Once()
+	runner.On("StartRepo", "myorg/api").Return(nil).Once()
+	runner.On("StartRepo", "myorg/web").Return(errors.New("clone failed")).Once()
+
+	// 2回目: 失敗したwebを再試行し、異常終了したapiを再起動
+	client.On("ListOrgRepositories", mock.Anything, "myorg").Return([]*gh.OrgRepository{api, web, docs}, nil).Once()
+	runner.On("IsRunning", "myorg/api").Return(false).Once()
+	runner.On("StartRepo", "myorg/api").Return(nil).Once()
+	runner.On("StartRepo", "myorg/web").Return(nil).Once()
+
+	// 3回目: アーカイブされたwebのwatcherを停止
+	client.On("ListOrgRepositories", mock.Anything, "myorg").Return([]*gh.OrgRepository{api, archivedWeb, docs}, nil).Once()
+	runner.On("IsRunning", "myorg/api").Return(true).Once()
+	runner.On("StopRepo", "myorg/web").Return(nil).Once()
+
+	discoverer, err := NewRepoDiscoverer(client, runner, newOrgTestConfig(), NewMockLogger())
+	require.NoError(t, err)
+
+	require.NoError(t, discoverer.DiscoverOnce(context.Background()))
+	assert.Equal(t, []string{"myorg/api"}, sortedRepoNames(discoverer.running))
+
+	require.NoError(t, discoverer.DiscoverOnce(context.Background()))
+	assert.Equal(t, []string{"myorg/api", "myorg/web"}, sortedRepoNames(discoverer.running))
+
+	require.NoError(t, discoverer.DiscoverOnce(context.Background()))
+	assert.Equal(t, []string{"myorg/api"}, sortedRepoNames(discoverer.running))
+
+	// 終了時は起動中のwatcherをすべて停止
+	runner.On("StopRepo", "myorg/api").Return(nil).Once()
+	discoverer.StopAll()
+	assert.Empty(t, discoverer.running)
+
+	client.AssertExpectations(t)
+	runner.AssertExpectations(t)
+}
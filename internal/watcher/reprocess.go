@@ -0,0 +1,98 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+)
+
+// Reprocess はIssueに残っている実行中ラベルをトリガーラベルに戻し、処理状態の記録を破棄して次回の確認でアクションを再評価させる
+// 監視プロセスがフェーズを開始済みとみなしているのに何も実行されていない場合の復旧に使う
+// 再評価できない場合（トリガーラベルも実行中ラベルもない・人間が引き継いでいる・ラベルの変更に失敗した）はエラーを返す
+func (w *IssueWatcher) Reprocess(ctx context.Context, issueNumber int) error {
+	issue, err := w.findReprocessTarget(ctx, issueNumber)
+	if err != nil {
+		return err
+	}
+	if err := w.prepareReprocess(ctx, issue); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	delete(w.issueLabels, int64(issueNumber))
+	delete(w.blockedWorkspaces, issueNumber)
+	w.mu.Unlock()
+
+	w.phaseBudget.Forget(issueNumber)
+	w.logger.Info("Reprocess requested", "issueNumber", issueNumber)
+	w.RequestPoll()
+	return nil
+}
+
+// findReprocessTarget はトリガーラベルまたは実行中ラベルが付いたオープンなIssueから再評価するIssueを探す
+func (w *IssueWatcher) findReprocessTarget(ctx context.Context, issueNumber int) (*github.Issue, error) {
+	labels := append([]string(nil), w.labels...)
+	for _, t := range workflow.Transitions {
+		if t.Executing != "" {
+			labels = append(labels, t.Executing)
+		}
+	}
+
+	issues, err := w.client.ListIssuesByLabels(ctx, w.owner, w.repo, labels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+	for _, issue := range issues {
+		if issue.Number != nil && *issue.Number == issueNumber {
+			return issue, nil
+		}
+	}
+	return nil, fmt.Errorf("issue #%d has no trigger or execution label", issueNumber)
+}
+
+// prepareReprocess は再評価するIssueに残っている実行中ラベルを外し、トリガーラベルから処理できる状態にする
+// トリガーラベルがなく実行中ラベルのみが残っている場合は、実行中ラベルをその遷移のトリガーラベルに戻す
+func (w *IssueWatcher) prepareReprocess(ctx context.Context, issue *github.Issue) error {
+	if hasLabel(issue, ManualLabel) {
+		return fmt.Errorf("issue #%d is handled manually (%s)", *issue.Number, ManualLabel)
+	}
+
+	t, ok := findWorkflowTransition(issue)
+	if !ok {
+		executing, found := findExecutingTransition(issue)
+		if !found {
+			return fmt.Errorf("issue #%d has no trigger or execution label", *issue.Number)
+		}
+		if err := w.client.TransitionLabels(ctx, w.owner, w.repo, *issue.Number, executing.Executing, executing.From); err != nil {
+			return fmt.Errorf("failed to restore trigger label %s: %w", executing.From, err)
+		}
+		w.logger.Info("Restored trigger label for reprocess",
+			"issueNumber", *issue.Number,
+			"from", executing.Executing,
+			"to", executing.From)
+		return nil
+	}
+
+	if t.Executing != "" && hasLabel(issue, t.Executing) {
+		if err := w.client.RemoveLabel(ctx, w.owner, w.repo, *issue.Number, t.Executing); err != nil {
+			return fmt.Errorf("failed to remove stale execution label %s: %w", t.Executing, err)
+		}
+	}
+
+	w.logger.Info("Reprocessing issue",
+		"issueNumber", *issue.Number,
+		"trigger", t.From)
+	return nil
+}
+
+// findExecutingTransition はIssueに付いている実行中ラベルの遷移を返す
+func findExecutingTransition(issue *github.Issue) (config.WorkflowTransition, bool) {
+	for _, t := range workflow.Transitions {
+		if t.Executing != "" && hasLabel(issue, t.Executing) {
+			return t, true
+		}
+	}
+	return config.WorkflowTransition{}, false
+}
@@ -0,0 +1,82 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/testutil/builders"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueWatcher_Reprocess(t *testing.T) {
+	tests := []struct {
+		name          string
+		labels        []string
+		wantRemoved   string
+		wantRestored  bool
+		wantErr       string
+		wantProcessed bool
+	}{
+		{name: "実行中ラベルが残っている", labels: []string{"status:ready", "status:implementing"}, wantRemoved: "status:implementing", wantProcessed: true},
+		{name: "実行中ラベルのみが残っている", labels: []string{"status:implementing"}, wantRestored: true, wantProcessed: true},
+		{name: "人間が引き継いでいる", labels: []string{"status:ready", "status:implementing", "status:manual"}, wantErr: "handled manually"},
+		{name: "トリガーラベルも実行中ラベルもない", labels: []string{"bug"}, wantErr: "no trigger or execution label"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := builders.NewIssueBuilder().WithNumber(5).WithLabels(tt.labels).Build()
+			mockGH := mocks.NewMockGitHubClient()
+			mockGH.On("ListIssuesByLabels", mock.Anything, "owner", "repo", mock.Anything).Return([]*gh.Issue{issue}, nil)
+			if tt.wantRemoved != "" {
+				mockGH.On("RemoveLabel", mock.Anything, "owner", "repo", 5, tt.wantRemoved).Return(nil).Once().Run(func(mock.Arguments) {
+					issue.Labels = builders.NewIssueBuilder().WithLabels([]string{"status:ready"}).Build().Labels
+				})
+			}
+			if tt.wantRestored {
+				mockGH.On("TransitionLabels", mock.Anything, "owner", "repo", 5, "status:implementing", "status:ready").Return(nil).Once().Run(func(mock.Arguments) {
+					issue.Labels = builders.NewIssueBuilder().WithLabels([]string{"status:ready"}).Build().Labels
+				})
+			}
+
+			watcher, err := NewIssueWatcher(mockGH, "owner", "repo", "test-session", []string{"status:ready"}, time.Minute, NewMockLogger())
+			require.NoError(t, err)
+
+			var processed []*gh.Issue
+			callback := func(issue *gh.Issue) { processed = append(processed, issue) }
+
+			err = watcher.Reprocess(context.Background(), 5)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			watcher.checkIssues(context.Background(), callback)
+			if tt.wantProcessed {
+				require.Len(t, processed, 1)
+				assert.Equal(t, []string{"status:ready"}, getLabels(processed[0]))
+			} else {
+				assert.Empty(t, processed)
+			}
+			mockGH.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIssueWatcher_Reprocess_NotFound(t *testing.T) {
+	mockGH := mocks.NewMockGitHubClient()
+	mockGH.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:ready", "status:planning", "status:implementing", "status:reviewing"}).Return([]*gh.Issue{}, nil)
+
+	watcher, err := NewIssueWatcher(mockGH, "owner", "repo", "test-session", []string{"status:ready"}, time.Minute, NewMockLogger())
+	require.NoError(t, err)
+
+	// トリガーラベルも実行中ラベルもないIssueは再評価しない
+	assert.ErrorContains(t, watcher.Reprocess(context.Background(), 5), "no trigger or execution label")
+	mockGH.AssertExpectations(t)
+}
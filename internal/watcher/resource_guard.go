@@ -0,0 +1,222 @@
+package watcher
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// ResourceSample はマシンの負荷の測定結果
+type ResourceSample struct {
+	LoadAverage       float64 // 1分間のロードアベレージ
+	CPUs              int     // CPUコア数
+	AvailableMemoryMB int64   // 利用可能なメモリ（MB）
+}
+
+// LoadPerCPU はCPUコアあたりのロードアベレージを返す
+func (s ResourceSample) LoadPerCPU() float64 {
+	if s.CPUs <= 0 {
+		return s.LoadAverage
+	}
+	return s.LoadAverage / float64(s.CPUs)
+}
+
+// ResourcePressure は負荷が高いために新しいフェーズの開始を保留している状態
+type ResourcePressure struct {
+	Since             time.Time `json:"since"`
+	LoadPerCPU        float64   `json:"load_per_cpu"`
+	AvailableMemoryMB int64     `json:"available_memory_mb"`
+	DeferredIssues    []int     `json:"deferred_issues"` // 開始を保留したIssue
+}
+
+// sampleResources はマシンの負荷を測定する（テスト時に差し替え可能）
+var sampleResources = func() (ResourceSample, error) {
+	sample, err := sampleSystemResources()
+	sample.CPUs = runtime.NumCPU()
+	return sample, err
+}
+
+// ResourceGuard はマシンの負荷が閾値を超えている間、新しいフェーズの開始を保留する
+// 保留したIssueのラベルは変更しないため、負荷が下がった後のポーリングで開始される
+type ResourceGuard struct {
+	config config.ResourceGuardConfig
+	logger logger.Logger
+	clock  clock.Clock
+
+	mu           sync.Mutex
+	pressure     *ResourcePressure // 保留していない場合はnil
+	sampleFailed bool              // 測定の失敗を記録済みか
+}
+
+// NewResourceGuard は新しいResourceGuardを作成する
+func NewResourceGuard(cfg *config.Config, logger logger.Logger) (*ResourceGuard, error) {
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if logger == nil {
+		return nil, errors.New("logger is required")
+	}
+	return &ResourceGuard{
+		config: cfg.ResourceGuard,
+		logger: logger.WithFields("component", "resource_guard"),
+		clock:  clock.New(),
+	}, nil
+}
+
+// AllowLaunch はIssueのフェーズを開始してよいかを返す
+// 負荷が閾値を超えている場合はIssueを保留中として記録してfalseを返す
+// 負荷を測定できない場合は開始を妨げない
+func (g *ResourceGuard) AllowLaunch(issueNumber int) bool {
+	sample, err := sampleResources()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err != nil {
+		if !g.sampleFailed {
+			g.logger.Warn("Failed to sample system resources, launching phases without checking load",
+				"error", err)
+			g.sampleFailed = true
+		}
+		return true
+	}
+
+	if !g.overloaded(sample) {
+		if g.pressure != nil {
+			g.logger.Info("System resources recovered, resuming phase launches",
+				"loadPerCPU", sample.LoadPerCPU(),
+				"availableMemoryMB", sample.AvailableMemoryMB,
+				"deferredIssues", g.pressure.DeferredIssues,
+				"deferredFor", g.clock.Since(g.pressure.Since))
+			g.pressure = nil
+		}
+		return true
+	}
+
+	if g.pressure == nil {
+		g.pressure = &ResourcePressure{Since: g.clock.Now()}
+	}
+	g.pressure.LoadPerCPU = sample.LoadPerCPU()
+	g.pressure.AvailableMemoryMB = sample.AvailableMemoryMB
+	if !containsInt(g.pressure.DeferredIssues, issueNumber) {
+		g.pressure.DeferredIssues = append(g.pressure.DeferredIssues, issueNumber)
+		sort.Ints(g.pressure.DeferredIssues)
+	}
+	g.logger.Warn("Deferring phase launch: system is overloaded",
+		"issueNumber", issueNumber,
+		"loadPerCPU", sample.LoadPerCPU(),
+		"maxLoadPerCPU", g.config.MaxLoadPerCPU,
+		"availableMemoryMB", sample.AvailableMemoryMB,
+		"minAvailableMemoryMB", g.config.MinAvailableMemoryMB)
+	return false
+}
+
+// Pressure は保留中の状態を返す（保留していない場合はnil）
+func (g *ResourceGuard) Pressure() *ResourcePressure {
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.pressure == nil {
+		return nil
+	}
+	pressure := *g.pressure
+	pressure.DeferredIssues = append([]int(nil), g.pressure.DeferredIssues...)
+	return &pressure
+}
+
+// overloaded は測定結果が閾値を超えているかを返す
+func (g *ResourceGuard) overloaded(sample ResourceSample) bool {
+	if g.config.MaxLoadPerCPU > 0 && sample.LoadPerCPU() > g.config.MaxLoadPerCPU {
+		return true
+	}
+	return g.config.MinAvailableMemoryMB > 0 && sample.AvailableMemoryMB < int64(g.config.MinAvailableMemoryMB)
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// parseLoadAverage はロードアベレージの出力（/proc/loadavgまたはsysctl vm.loadavg）から1分間の値を取り出す
+func parseLoadAverage(data []byte) (float64, error) {
+	fields := strings.Fields(strings.Trim(strings.TrimSpace(string(data)), "{}"))
+	if len(fields) == 0 {
+		return 0, errors.New("empty load average")
+	}
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid load average %q: %w", fields[0], err)
+	}
+	return load, nil
+}
+
+// parseMemAvailable は/proc/meminfoから利用可能なメモリ（MB）を取り出す
+func parseMemAvailable(data []byte) (int64, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemAvailable:" {
+			continue
+		}
+		kb, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid MemAvailable %q: %w", fields[1], err)
+		}
+		return kb / 1024, nil
+	}
+	return 0, errors.New("MemAvailable not found in meminfo")
+}
+
+// parseVMStat はvm_statの出力から利用可能なメモリ（空き・非アクティブ・投機的なページ、MB）を取り出す
+func parseVMStat(data []byte) (int64, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	var pageSize, pages int64
+	found := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "Mach Virtual Memory Statistics") {
+			// 例: Mach Virtual Memory Statistics: (page size of 16384 bytes)
+			if i := strings.Index(line, "page size of "); i >= 0 {
+				fields := strings.Fields(line[i+len("page size of "):])
+				if len(fields) > 0 {
+					pageSize, _ = strconv.ParseInt(fields[0], 10, 64)
+				}
+			}
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch name {
+		case "Pages free", "Pages inactive", "Pages speculative":
+			n, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimSpace(value), "."), 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+			}
+			pages += n
+			found = true
+		}
+	}
+	if pageSize == 0 || !found {
+		return 0, errors.New("unexpected vm_stat output")
+	}
+	return pages * pageSize / (1024 * 1024), nil
+}
@@ -0,0 +1,107 @@
+package watcher
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setResourceSample はテスト中だけ負荷の測定結果を差し替える
+func setResourceSample(t *testing.T, sample *ResourceSample, err *error) {
+	t.Helper()
+	orig := sampleResources
+	t.Cleanup(func() { sampleResources = orig })
+	sampleResources = func() (ResourceSample, error) { return *sample, *err }
+}
+
+func newTestResourceGuard(t *testing.T) *ResourceGuard {
+	t.Helper()
+	cfg := config.NewConfig()
+	cfg.ResourceGuard = config.ResourceGuardConfig{Enabled: true, MaxLoadPerCPU: 1.5, MinAvailableMemoryMB: 1024}
+	guard, err := NewResourceGuard(cfg, NewMockLogger())
+	require.NoError(t, err)
+	return guard
+}
+
+func TestResourceGuard_AllowLaunch(t *testing.T) {
+	tests := []struct {
+		name   string
+		sample ResourceSample
+		err    error
+		want   bool
+	}{
+		{name: "閾値以下", sample: ResourceSample{LoadAverage: 4, CPUs: 4, AvailableMemoryMB: 4096}, want: true},
+		{name: "ロードアベレージが高い", sample: ResourceSample{LoadAverage: 8, CPUs: 4, AvailableMemoryMB: 4096}, want: false},
+		{name: "メモリが不足", sample: ResourceSample{LoadAverage: 1, CPUs: 4, AvailableMemoryMB: 512}, want: false},
+		{name: "測定できない場合は開始する", err: errors.New("unsupported"), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setResourceSample(t, &tt.sample, &tt.err)
+			guard := newTestResourceGuard(t)
+
+			assert.Equal(t, tt.want, guard.AllowLaunch(1))
+			assert.Equal(t, !tt.want, guard.Pressure() != nil)
+		})
+	}
+}
+
+func TestResourceGuard_ResumesWhenRecovered(t *testing.T) {
+	sample := ResourceSample{LoadAverage: 8, CPUs: 4, AvailableMemoryMB: 512}
+	var sampleErr error
+	setResourceSample(t, &sample, &sampleErr)
+	guard := newTestResourceGuard(t)
+
+	assert.False(t, guard.AllowLaunch(3))
+	assert.False(t, guard.AllowLaunch(1))
+	assert.False(t, guard.AllowLaunch(3))
+
+	pressure := guard.Pressure()
+	require.NotNil(t, pressure)
+	assert.Equal(t, []int{1, 3}, pressure.DeferredIssues)
+	assert.Equal(t, 2.0, pressure.LoadPerCPU)
+	assert.Equal(t, int64(512), pressure.AvailableMemoryMB)
+
+	sample = ResourceSample{LoadAverage: 2, CPUs: 4, AvailableMemoryMB: 4096}
+	assert.True(t, guard.AllowLaunch(1))
+	assert.Nil(t, guard.Pressure())
+}
+
+func TestParseLoadAverage(t *testing.T) {
+	load, err := parseLoadAverage([]byte("3.52 2.10 1.05 2/1234 5678\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 3.52, load)
+
+	// macOSのsysctl -n vm.loadavgの形式
+	load, err = parseLoadAverage([]byte("{ 1.25 1.40 1.50 }\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 1.25, load)
+
+	_, err = parseLoadAverage([]byte(""))
+	assert.Error(t, err)
+}
+
+func TestParseMemAvailable(t *testing.T) {
+	mem, err := parseMemAvailable([]byte("MemTotal:       16318412 kB\nMemFree:          512000 kB\nMemAvailable:    2097152 kB\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2048), mem)
+
+	_, err = parseMemAvailable([]byte("MemTotal:       16318412 kB\n"))
+	assert.Error(t, err)
+}
+
+func TestParseVMStat(t *testing.T) {
+	output := `Mach Virtual Memory Statistics: (page size of 16384 bytes)
+Pages free:                               32768.
+Pages active:                            500000.
+Pages inactive:                           16384.
+Pages speculative:                        16384.
+`
+	mem, err := parseVMStat([]byte(output))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024), mem)
+}
@@ -0,0 +1,24 @@
+//go:build darwin
+// +build darwin
+
+package watcher
+
+import "os/exec"
+
+// sampleSystemResources はsysctlとvm_statからロードアベレージと利用可能なメモリを測定する
+func sampleSystemResources() (ResourceSample, error) {
+	var sample ResourceSample
+	loadavg, err := exec.Command("sysctl", "-n", "vm.loadavg").Output()
+	if err != nil {
+		return sample, err
+	}
+	if sample.LoadAverage, err = parseLoadAverage(loadavg); err != nil {
+		return sample, err
+	}
+	vmstat, err := exec.Command("vm_stat").Output()
+	if err != nil {
+		return sample, err
+	}
+	sample.AvailableMemoryMB, err = parseVMStat(vmstat)
+	return sample, err
+}
@@ -0,0 +1,24 @@
+//go:build linux
+// +build linux
+
+package watcher
+
+import "os"
+
+// sampleSystemResources は/procからロードアベレージと利用可能なメモリを測定する
+func sampleSystemResources() (ResourceSample, error) {
+	var sample ResourceSample
+	loadavg, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return sample, err
+	}
+	if sample.LoadAverage, err = parseLoadAverage(loadavg); err != nil {
+		return sample, err
+	}
+	meminfo, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return sample, err
+	}
+	sample.AvailableMemoryMB, err = parseMemAvailable(meminfo)
+	return sample, err
+}
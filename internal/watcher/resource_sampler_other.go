@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package watcher
+
+import "errors"
+
+// sampleSystemResources はこのプラットフォームでは負荷を測定できない
+func sampleSystemResources() (ResourceSample, error) {
+	return ResourceSample{}, errors.New("sampling system resources is not supported on this platform")
+}
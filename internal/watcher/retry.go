@@ -8,31 +8,42 @@ import (
 	"math"
 	"math/rand"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/douhashi/osoba/internal/clock"
 	"github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/logger"
 )
 
 // defaultLogger はグローバル関数用のデフォルトロガー
-var defaultLogger logger.Logger
-
-// init はパッケージ初期化時にデフォルトロガーを設定
-func init() {
-	// エラーハンドリングを簡略化するため、エラーが発生した場合はnilロガーを使用
-	l, err := logger.New(logger.WithLevel("info"))
-	if err != nil {
-		// エラーが発生した場合は標準ログに出力
-		log.Printf("Failed to initialize default logger: %v", err)
-		// nilチェックを避けるため、モックロガーを使用
-		defaultLogger = &mockLogger{}
-	} else {
+// パッケージを利用しないコマンドの起動を遅くしないよう、初回の利用時に作成する
+var (
+	defaultLogger     logger.Logger
+	defaultLoggerOnce sync.Once
+)
+
+// getDefaultLogger はデフォルトロガーを返す（未設定の場合は作成する）
+func getDefaultLogger() logger.Logger {
+	defaultLoggerOnce.Do(func() {
+		// エラーハンドリングを簡略化するため、エラーが発生した場合はnilロガーを使用
+		l, err := logger.New(logger.WithLevel("info"))
+		if err != nil {
+			// エラーが発生した場合は標準ログに出力
+			log.Printf("Failed to initialize default logger: %v", err)
+			// nilチェックを避けるため、モックロガーを使用
+			defaultLogger = &mockLogger{}
+			return
+		}
 		defaultLogger = l
-	}
+	})
+	return defaultLogger
 }
 
 // SetDefaultLogger はテスト用にデフォルトロガーを設定する
 func SetDefaultLogger(l logger.Logger) {
+	// 設定したロガーが初回利用時の作成で上書きされないようにする
+	defaultLoggerOnce.Do(func() {})
 	defaultLogger = l
 }
 
@@ -103,6 +114,11 @@ func RetryWithBackoff(ctx context.Context, maxRetries int, baseDelay time.Durati
 
 // RetryWithBackoffLogger は指数バックオフでリトライを実行する（logger付き）
 func RetryWithBackoffLogger(ctx context.Context, logger logger.Logger, maxRetries int, baseDelay time.Duration, operation func() error) error {
+	return RetryWithBackoffClock(ctx, clock.New(), logger, maxRetries, baseDelay, operation)
+}
+
+// RetryWithBackoffClock は指数バックオフでリトライを実行する（バックオフの待機にclkを使用）
+func RetryWithBackoffClock(ctx context.Context, clk clock.Clock, logger logger.Logger, maxRetries int, baseDelay time.Duration, operation func() error) error {
 	if maxRetries <= 0 {
 		maxRetries = 1
 	}
@@ -163,7 +179,7 @@ func RetryWithBackoffLogger(ctx context.Context, logger logger.Logger, maxRetrie
 
 		// バックオフ時間待機
 		select {
-		case <-time.After(backoff):
+		case <-clk.After(backoff):
 			// 次の試行へ
 		case <-ctx.Done():
 			return fmt.Errorf("operation cancelled during backoff: %w", ctx.Err())
@@ -179,7 +195,7 @@ func RetryWithBackoffLogger(ctx context.Context, logger logger.Logger, maxRetrie
 // IsRetryableError はエラーがリトライ可能かどうかを判定する
 // Deprecated: Use IsRetryableErrorLogger instead. This function will be removed in a future version.
 func IsRetryableError(err error) bool {
-	return IsRetryableErrorLogger(defaultLogger, err)
+	return IsRetryableErrorLogger(getDefaultLogger(), err)
 }
 
 // IsRetryableErrorLogger はエラーがリトライ可能かどうかを判定する（logger付き）
@@ -241,7 +257,7 @@ func IsRetryableErrorLogger(logger logger.Logger, err error) bool {
 // CalculateBackoff は指数バックオフの遅延時間を計算する
 // Deprecated: Use CalculateBackoffLogger instead. This function will be removed in a future version.
 func CalculateBackoff(attempt int, baseDelay time.Duration) time.Duration {
-	return CalculateBackoffLogger(defaultLogger, attempt, baseDelay)
+	return CalculateBackoffLogger(getDefaultLogger(), attempt, baseDelay)
 }
 
 // CalculateBackoffLogger は指数バックオフの遅延時間を計算する（logger付き）
@@ -277,7 +293,7 @@ func CalculateBackoffLogger(logger logger.Logger, attempt int, baseDelay time.Du
 // HandleRateLimitError はGitHub APIのレート制限エラーを処理する
 // Deprecated: Use HandleRateLimitErrorLogger instead. This function will be removed in a future version.
 func HandleRateLimitError(err error) (time.Duration, bool) {
-	return HandleRateLimitErrorLogger(defaultLogger, err)
+	return HandleRateLimitErrorLogger(getDefaultLogger(), err)
 }
 
 // HandleRateLimitErrorLogger はGitHub APIのレート制限エラーを処理する（logger付き）
@@ -305,3 +321,16 @@ func HandleRateLimitErrorLogger(logger logger.Logger, err error) (time.Duration,
 		"error", err)
 	return 0, false
 }
+
+// logIfThrottled はGitHubによるスロットリングの場合に警告ログを出力してtrueを返す
+// スロットリング中の待機はGitHubクライアントが行うため、汎用のエラーログは出力しない
+func logIfThrottled(logger logger.Logger, err error, operation string) bool {
+	if !github.IsThrottledError(err) {
+		return false
+	}
+	logger.Warn("GitHub throttled, skipping this poll",
+		"operation", operation,
+		"retry_after", github.ThrottleRetryAfter(err),
+		"error", err)
+	return true
+}
@@ -0,0 +1,248 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// リトライの深刻度
+const (
+	RetrySeverityNone     = ""
+	RetrySeverityDegraded = "degraded"
+	RetrySeverityFailing  = "failing"
+)
+
+// RetryCounters はIssueごとのリトライの回数
+type RetryCounters struct {
+	GHFailures    int `json:"gh_failures"`    // ラベルの遷移に失敗したghコマンドの回数
+	PhaseFailures int `json:"phase_failures"` // フェーズの実行に失敗した回数
+	PhaseRestarts int `json:"phase_restarts"` // 完了していないフェーズを再び開始した回数
+}
+
+// Total はリトライの合計回数を返す
+func (c RetryCounters) Total() int {
+	return c.GHFailures + c.PhaseFailures + c.PhaseRestarts
+}
+
+// RetryStatus はosoba statusで表示するIssueのリトライの状況
+type RetryStatus struct {
+	IssueNumber int    `json:"issue_number"`
+	Severity    string `json:"severity,omitempty"` // degraded / failing（しきい値に達していない場合は空）
+	RetryCounters
+}
+
+// RetryBudget はIssueごとのリトライの回数を数え、しきい値を超えたIssueに深刻度のラベルを付与する
+// retry_budget.degraded_after回でstatus:degradedを、failing_after回でstatus:failingに付け替える
+// SetStorePathを指定した場合は回数をファイルに保存し、監視プロセスの再起動後も引き継ぐ
+// nilの場合は何も記録しない
+type RetryBudget struct {
+	client github.GitHubClient
+	owner  string
+	repo   string
+	config config.RetryBudgetConfig
+	logger logger.Logger
+
+	mu    sync.Mutex
+	path  string // 回数の保存先（空の場合は保存しない）
+	state retryBudgetState
+}
+
+// retryBudgetState はRetryBudgetがファイルに保存する状態
+type retryBudgetState struct {
+	Counters  map[int]*RetryCounters `json:"counters"`
+	LastPhase map[int]string         `json:"last_phase"` // Issueごとに最後に開始し、失敗していないフェーズ
+	Applied   map[int]string         `json:"applied"`    // Issueごとに付与した深刻度
+}
+
+// NewRetryBudget は新しいRetryBudgetを作成する
+func NewRetryBudget(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*RetryBudget, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return &RetryBudget{
+		client: client,
+		owner:  owner,
+		repo:   repo,
+		config: cfg.RetryBudget,
+		logger: logger.WithFields("component", "retry_budget"),
+		state: retryBudgetState{
+			Counters:  make(map[int]*RetryCounters),
+			LastPhase: make(map[int]string),
+			Applied:   make(map[int]string),
+		},
+	}, nil
+}
+
+// SetStorePath は回数の保存先を設定し、保存済みの回数を読み込む
+func (b *RetryBudget) SetStorePath(path string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := retryBudgetState{}
+	if err := loadStoreFile(path, &state); err != nil {
+		return err
+	}
+	if state.Counters != nil {
+		b.state.Counters = state.Counters
+	}
+	if state.LastPhase != nil {
+		b.state.LastPhase = state.LastPhase
+	}
+	if state.Applied != nil {
+		b.state.Applied = state.Applied
+	}
+	b.path = path
+	return nil
+}
+
+// RecordGHFailure はIssueのラベルの遷移でghコマンドが失敗したことを記録する
+func (b *RetryBudget) RecordGHFailure(ctx context.Context, issueNumber int) {
+	b.record(ctx, issueNumber, func(c *RetryCounters) { c.GHFailures++ })
+}
+
+// RecordPhaseFailure はIssueのフェーズの実行に失敗したことを記録する
+// 失敗したフェーズを次に開始した場合は再実行として数えない
+func (b *RetryBudget) RecordPhaseFailure(ctx context.Context, issueNumber int) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	delete(b.state.LastPhase, issueNumber)
+	b.mu.Unlock()
+	b.record(ctx, issueNumber, func(c *RetryCounters) { c.PhaseFailures++ })
+}
+
+// RecordPhaseLaunch はIssueのフェーズを開始したことを記録する
+// 直前に開始したフェーズが失敗せず、別のフェーズを挟まずに同じフェーズを開始した場合
+// （ペインの異常終了や手動での再開始など）だけを再実行として数える
+// レビューと修正の往復のように別のフェーズを挟んだ開始は数えない
+func (b *RetryBudget) RecordPhaseLaunch(ctx context.Context, issueNumber int, phase string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	restarted := b.state.LastPhase[issueNumber] == phase
+	b.state.LastPhase[issueNumber] = phase
+	if !restarted {
+		b.saveLocked()
+	}
+	b.mu.Unlock()
+
+	if restarted {
+		b.record(ctx, issueNumber, func(c *RetryCounters) { c.PhaseRestarts++ })
+	}
+}
+
+// Status はリトライしたIssueの状況をIssue番号順に返す
+func (b *RetryBudget) Status() []RetryStatus {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	statuses := make([]RetryStatus, 0, len(b.state.Counters))
+	for number, counters := range b.state.Counters {
+		statuses = append(statuses, RetryStatus{
+			IssueNumber:   number,
+			Severity:      b.severity(counters.Total()),
+			RetryCounters: *counters,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].IssueNumber < statuses[j].IssueNumber })
+	return statuses
+}
+
+// record はリトライを記録し、深刻度が上がった場合はラベルを付け替える
+func (b *RetryBudget) record(ctx context.Context, issueNumber int, increment func(*RetryCounters)) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	counters, ok := b.state.Counters[issueNumber]
+	if !ok {
+		counters = &RetryCounters{}
+		b.state.Counters[issueNumber] = counters
+	}
+	increment(counters)
+	total := counters.Total()
+	severity := b.severity(total)
+	previous := b.state.Applied[issueNumber]
+	escalate := severity != previous && severity != RetrySeverityNone && previous != RetrySeverityFailing
+	if escalate {
+		b.state.Applied[issueNumber] = severity
+	}
+	b.saveLocked()
+	b.mu.Unlock()
+	if !escalate {
+		return
+	}
+
+	b.logger.Warn("Escalating issue with accumulated retries",
+		"issueNumber", issueNumber,
+		"severity", severity,
+		"retries", total,
+		"ghFailures", counters.GHFailures,
+		"phaseFailures", counters.PhaseFailures,
+		"phaseRestarts", counters.PhaseRestarts)
+	b.applyLabel(ctx, issueNumber, severity, previous)
+}
+
+// saveLocked は回数を保存先に書き出す（b.muを保持して呼び出す）
+func (b *RetryBudget) saveLocked() {
+	if err := saveStoreFile(b.path, b.state); err != nil {
+		b.logger.Warn("Failed to save retry counts", "path", b.path, "error", err)
+	}
+}
+
+// severity はリトライの合計回数から深刻度を返す
+func (b *RetryBudget) severity(total int) string {
+	switch {
+	case total >= b.config.FailingAfter:
+		return RetrySeverityFailing
+	case total >= b.config.DegradedAfter:
+		return RetrySeverityDegraded
+	}
+	return RetrySeverityNone
+}
+
+// applyLabel は深刻度のラベルを付与し、付与済みの低い深刻度のラベルを外す
+func (b *RetryBudget) applyLabel(ctx context.Context, issueNumber int, severity, previous string) {
+	label := b.config.DegradedLabel
+	if severity == RetrySeverityFailing {
+		label = b.config.FailingLabel
+	}
+	if err := b.client.AddLabel(ctx, b.owner, b.repo, issueNumber, label); err != nil {
+		b.logger.Warn("Failed to add retry severity label",
+			"issueNumber", issueNumber,
+			"label", label,
+			"error", err)
+	}
+	if previous == RetrySeverityDegraded {
+		if err := b.client.RemoveLabel(ctx, b.owner, b.repo, issueNumber, b.config.DegradedLabel); err != nil {
+			b.logger.Warn("Failed to remove retry severity label",
+				"issueNumber", issueNumber,
+				"label", b.config.DegradedLabel,
+				"error", err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package watcher
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRetryBudgetForTest(t *testing.T, client *MockGitHubClient) *RetryBudget {
+	t.Helper()
+	cfg := config.NewConfig()
+	cfg.RetryBudget.DegradedAfter = 2
+	cfg.RetryBudget.FailingAfter = 4
+	budget, err := NewRetryBudget(client, "owner", "repo", cfg, NewMockLogger())
+	require.NoError(t, err)
+	return budget
+}
+
+func TestRetryBudget_Escalation(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockGitHubClient)
+	client.On("AddLabel", mock.Anything, "owner", "repo", 7, config.DefaultDegradedLabel).Return(nil).Once()
+	client.On("AddLabel", mock.Anything, "owner", "repo", 7, config.DefaultFailingLabel).Return(nil).Once()
+	client.On("RemoveLabel", mock.Anything, "owner", "repo", 7, config.DefaultDegradedLabel).Return(nil).Once()
+	budget := newRetryBudgetForTest(t, client)
+
+	// 初めて開始したフェーズは再実行として数えない
+	budget.RecordPhaseLaunch(ctx, 7, config.PhaseImplement)
+	budget.RecordGHFailure(ctx, 7)
+	client.AssertNotCalled(t, "AddLabel", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// しきい値に達したらstatus:degradedを付与する
+	budget.RecordPhaseFailure(ctx, 7)
+	client.AssertCalled(t, "AddLabel", mock.Anything, "owner", "repo", 7, config.DefaultDegradedLabel)
+
+	// 失敗したフェーズの開始は再実行として数えず、続けて開始した場合だけ数える
+	// 同じ深刻度の間はラベルを付け直さない
+	budget.RecordPhaseLaunch(ctx, 7, config.PhaseImplement)
+	budget.RecordPhaseLaunch(ctx, 7, config.PhaseImplement)
+
+	// status:failingに付け替え、以降は何もしない
+	budget.RecordGHFailure(ctx, 7)
+	budget.RecordGHFailure(ctx, 7)
+	client.AssertExpectations(t)
+
+	assert.Equal(t, []RetryStatus{{
+		IssueNumber:   7,
+		Severity:      RetrySeverityFailing,
+		RetryCounters: RetryCounters{GHFailures: 3, PhaseFailures: 1, PhaseRestarts: 1},
+	}}, budget.Status())
+}
+
+func TestRetryBudget_RecordPhaseLaunch(t *testing.T) {
+	tests := []struct {
+		name   string
+		phases []string
+		want   int
+	}{
+		{
+			name:   "フェーズを順に進めた場合は数えない",
+			phases: []string{config.PhasePlan, config.PhaseImplement, config.PhaseReview},
+			want:   0,
+		},
+		{
+			name:   "レビューと修正の往復は数えない",
+			phases: []string{config.PhaseReview, config.PhaseRevise, config.PhaseReview, config.PhaseRevise},
+			want:   0,
+		},
+		{
+			name:   "完了していないフェーズを続けて開始した場合は数える",
+			phases: []string{config.PhaseImplement, config.PhaseImplement, config.PhaseImplement},
+			want:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := newRetryBudgetForTest(t, new(MockGitHubClient))
+			budget.config.DegradedAfter = 10
+			budget.config.FailingAfter = 20
+			for _, phase := range tt.phases {
+				budget.RecordPhaseLaunch(context.Background(), 5, phase)
+			}
+
+			restarts := 0
+			for _, status := range budget.Status() {
+				restarts += status.PhaseRestarts
+			}
+			assert.Equal(t, tt.want, restarts)
+		})
+	}
+}
+
+func TestRetryBudget_SetStorePath(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "store", "retry-budget.json")
+
+	budget := newRetryBudgetForTest(t, new(MockGitHubClient))
+	require.NoError(t, budget.SetStorePath(path))
+	budget.RecordPhaseLaunch(ctx, 9, config.PhaseImplement)
+	budget.RecordGHFailure(ctx, 9)
+
+	// 再起動後も回数と最後に開始したフェーズを引き継ぐ
+	restored := newRetryBudgetForTest(t, new(MockGitHubClient))
+	restored.config.DegradedAfter = 10
+	restored.config.FailingAfter = 20
+	require.NoError(t, restored.SetStorePath(path))
+	restored.RecordPhaseLaunch(ctx, 9, config.PhaseImplement)
+	assert.Equal(t, []RetryStatus{{
+		IssueNumber:   9,
+		RetryCounters: RetryCounters{GHFailures: 1, PhaseRestarts: 1},
+	}}, restored.Status())
+}
+
+func TestRetryBudget_Status(t *testing.T) {
+	ctx := context.Background()
+	budget := newRetryBudgetForTest(t, new(MockGitHubClient))
+
+	budget.RecordGHFailure(ctx, 12)
+	budget.RecordPhaseLaunch(ctx, 3, config.PhasePlan)
+	budget.RecordPhaseLaunch(ctx, 3, config.PhaseImplement)
+	budget.RecordGHFailure(ctx, 3)
+
+	statuses := budget.Status()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, 3, statuses[0].IssueNumber)
+	assert.Equal(t, 1, statuses[0].Total())
+	assert.Equal(t, RetrySeverityNone, statuses[0].Severity)
+	assert.Equal(t, 12, statuses[1].IssueNumber)
+}
+
+func TestRetryBudget_NilDoesNothing(t *testing.T) {
+	var budget *RetryBudget
+	budget.RecordGHFailure(context.Background(), 1)
+	budget.RecordPhaseFailure(context.Background(), 1)
+	budget.RecordPhaseLaunch(context.Background(), 1, config.PhasePlan)
+	assert.Nil(t, budget.Status())
+}
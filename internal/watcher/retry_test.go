@@ -3,10 +3,12 @@ package watcher
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/douhashi/osoba/internal/clock"
 	"github.com/douhashi/osoba/internal/github"
 )
 
@@ -114,6 +116,36 @@ func TestRetryWithBackoff_ContextCancellation(t *testing.T) {
 	})
 }
 
+func TestRetryWithBackoffClock(t *testing.T) {
+	fakeClock := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	start := fakeClock.Now()
+
+	var attempts int32
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- RetryWithBackoffClock(context.Background(), fakeClock, NewMockLogger(), 3, time.Minute, func() error {
+			atomic.AddInt32(&attempts, 1)
+			return &github.ErrorResponse{Message: "Service Unavailable"}
+		})
+	}()
+
+	// 実時間では数分かかるバックオフを仮想時間で進める
+	for i := 0; i < 2; i++ {
+		fakeClock.BlockUntil(1)
+		fakeClock.Advance(time.Hour)
+	}
+
+	if err := <-errCh; err == nil {
+		t.Error("RetryWithBackoffClock() should return error after max retries")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("RetryWithBackoffClock() attempts = %v, want 3", got)
+	}
+	if elapsed := fakeClock.Since(start); elapsed != 2*time.Hour {
+		t.Errorf("virtual elapsed = %v, want %v", elapsed, 2*time.Hour)
+	}
+}
+
 func TestIsRetryableError(t *testing.T) {
 	tests := []struct {
 		name string
@@ -269,3 +301,21 @@ func TestHandleRateLimitError(t *testing.T) {
 		})
 	}
 }
+
+func TestLogIfThrottled(t *testing.T) {
+	throttled := fmt.Errorf("failed to list issues: %w",
+		github.ParseGHError("HTTP 403: You have exceeded a secondary rate limit.\nRetry-After: 30", errors.New("exit status 1")))
+
+	log := NewMockLogger().(*mockLogger)
+	if !logIfThrottled(log, throttled, "list_issues") {
+		t.Fatal("expected throttled error to be detected")
+	}
+	logs := log.GetLogs()
+	if len(logs) != 1 || logs[0].Level != "WARN" || logs[0].Message != "GitHub throttled, skipping this poll" {
+		t.Errorf("unexpected logs: %+v", logs)
+	}
+
+	if logIfThrottled(NewMockLogger(), errors.New("connection refused"), "list_issues") {
+		t.Error("expected non-throttled error not to be detected")
+	}
+}
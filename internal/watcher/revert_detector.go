@@ -0,0 +1,256 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// revertCheckOverlap は前回の確認時刻から遡って確認する時間（マージの反映の遅れを吸収する）
+const revertCheckOverlap = time.Minute
+
+// revertDetectorClient はRevertの検出に使用するクライアント
+type revertDetectorClient interface {
+	github.GitHubClient
+	github.RevertTracker
+	GetIssueState(ctx context.Context, owner, repo string, issueNumber int) (string, error)
+}
+
+// RevertDetector はosobaがマージしたPRがRevertされたことを検出し、元のIssueを再オープンしてラベルとコメントを付与する
+// osobaが作成したブランチ（osoba/#<Issue番号>）のPR、またはIssueをクローズするPRを、osobaがマージしたPRとみなす
+type RevertDetector struct {
+	client revertDetectorClient
+	owner  string
+	repo   string
+	config *config.Config
+	events *EventStore // 対応を記録するイベントストア（無効の場合はnil）
+	logger logger.Logger
+	clock  clock.Clock
+
+	mu        sync.Mutex
+	since     time.Time                         // 次回確認するマージ日時の下限
+	processed map[int]bool                      // 対応済みのRevert PR番号
+	pending   map[int]*github.MergedPullRequest // 対応に失敗したRevert PR（次回の確認で再試行する）
+}
+
+// NewRevertDetector は新しいRevertDetectorを作成する
+func NewRevertDetector(client github.GitHubClient, owner, repo string, cfg *config.Config, events *EventStore, logger logger.Logger) (*RevertDetector, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	tracker, ok := client.(revertDetectorClient)
+	if !ok {
+		return nil, errors.New("github client does not support tracking reverted pull requests")
+	}
+
+	return &RevertDetector{
+		client:    tracker,
+		owner:     owner,
+		repo:      repo,
+		config:    cfg,
+		events:    events,
+		logger:    logger,
+		clock:     clock.New(),
+		processed: make(map[int]bool),
+		pending:   make(map[int]*github.MergedPullRequest),
+	}, nil
+}
+
+// Start はRevertの検出を開始する
+func (d *RevertDetector) Start(ctx context.Context) {
+	interval := d.config.GitHub.RevertDetection.Interval
+	d.logger.Info("Starting revert detector", "interval", interval)
+
+	if err := d.CheckOnce(ctx); err != nil {
+		d.logger.Warn("Failed to check reverted pull requests", "error", err)
+	}
+
+	ticker := d.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Revert detector stopped")
+			return
+		case <-ticker.C():
+			if err := d.CheckOnce(ctx); err != nil {
+				d.logger.Warn("Failed to check reverted pull requests", "error", err)
+			}
+		}
+	}
+}
+
+// CheckOnce は前回の確認以降にマージされたPRからRevertを検出して対応する
+// 初回はイベントストアから対応済みのRevertを読み込み、lookbackの期間を遡って確認する
+// 対応に失敗したRevertは個別に記録して次回再試行し、確認範囲は他のPRの確認のために進める
+func (d *RevertDetector) CheckOnce(ctx context.Context) error {
+	now := d.clock.Now()
+
+	d.mu.Lock()
+	if d.since.IsZero() {
+		d.since = now.Add(-d.config.GitHub.RevertDetection.Lookback)
+		d.loadProcessed()
+	}
+	since := d.since
+	d.mu.Unlock()
+
+	prs, err := d.client.ListMergedPullRequests(ctx, d.owner, d.repo, since)
+	if err != nil {
+		return fmt.Errorf("failed to list merged pull requests: %w", err)
+	}
+
+	// 前回までに対応に失敗したRevertは、確認範囲から外れていても再試行する
+	d.mu.Lock()
+	listed := make(map[int]bool, len(prs))
+	for _, pr := range prs {
+		listed[pr.Number] = true
+	}
+	for number, pr := range d.pending {
+		if !listed[number] {
+			prs = append(prs, pr)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, pr := range prs {
+		revertedNumber, ok := pr.RevertedNumber()
+		if !ok || d.isProcessed(pr.Number) {
+			continue
+		}
+		err := d.handleRevert(ctx, pr, revertedNumber)
+		d.mu.Lock()
+		if err != nil {
+			d.pending[pr.Number] = pr
+		} else {
+			delete(d.pending, pr.Number)
+		}
+		d.mu.Unlock()
+		if err != nil {
+			d.logger.Warn("Failed to handle reverted pull request",
+				"revert_pr", pr.Number,
+				"reverted_pr", revertedNumber,
+				"error", err)
+		}
+	}
+
+	d.mu.Lock()
+	d.since = now.Add(-revertCheckOverlap)
+	d.mu.Unlock()
+	return nil
+}
+
+// handleRevert はRevertされたPRがosobaのものであれば、元のIssueを再オープンしてラベルとコメントを付与する
+func (d *RevertDetector) handleRevert(ctx context.Context, revert *github.MergedPullRequest, revertedNumber int) error {
+	reverted, err := d.client.GetMergedPullRequest(ctx, d.owner, d.repo, revertedNumber)
+	if err != nil {
+		return err
+	}
+	issueNumber := issueNumberFromBranch(reverted.HeadRefName)
+	if issueNumber == 0 {
+		issueNumber = reverted.ClosingIssueNumber()
+	}
+	if issueNumber == 0 || !strings.EqualFold(reverted.State, "MERGED") {
+		// osobaがマージしたPRではない
+		d.markProcessed(revert.Number)
+		return nil
+	}
+
+	state, err := d.client.GetIssueState(ctx, d.owner, d.repo, issueNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get state of issue #%d: %w", issueNumber, err)
+	}
+	if !strings.EqualFold(state, "OPEN") {
+		if err := d.client.ReopenIssue(ctx, d.owner, d.repo, issueNumber); err != nil {
+			return err
+		}
+	}
+
+	label := d.config.GitHub.RevertDetection.Label
+	if err := d.client.AddLabel(ctx, d.owner, d.repo, issueNumber, label); err != nil {
+		return fmt.Errorf("failed to add label to issue #%d: %w", issueNumber, err)
+	}
+
+	body := d.config.RenderComment(config.CommentReverted, map[string]string{
+		"issue-number":  strconv.Itoa(issueNumber),
+		"pr-number":     strconv.Itoa(revertedNumber),
+		"revert-number": strconv.Itoa(revert.Number),
+		"revert-url":    revert.URL,
+		"label":         label,
+	})
+	if err := createInformationalComment(ctx, d.client, d.owner, d.repo, issueNumber, body); err != nil {
+		d.logger.Warn("Failed to post revert comment", "issue_number", issueNumber, "error", err)
+	}
+
+	d.logger.Info("Reopened issue after merged pull request was reverted",
+		"issue_number", issueNumber,
+		"reverted_pr", revertedNumber,
+		"revert_pr", revert.Number)
+
+	d.markProcessed(revert.Number)
+	if d.events != nil {
+		if err := d.events.Append(Event{
+			Time:        d.clock.Now(),
+			Type:        EventPullRequestReverted,
+			IssueNumber: issueNumber,
+			PRNumber:    revertedNumber,
+			Data:        map[string]string{"revert_pr": strconv.Itoa(revert.Number)},
+		}); err != nil {
+			d.logger.Warn("Failed to record revert event",
+				"issue_number", issueNumber,
+				"revert_pr", revert.Number,
+				"error", err)
+		}
+	}
+	return nil
+}
+
+// loadProcessed はイベントストアから対応済みのRevertを読み込む（d.muを保持した状態で呼び出す）
+func (d *RevertDetector) loadProcessed() {
+	if d.events == nil {
+		return
+	}
+	events, err := d.events.Read()
+	if err != nil {
+		d.logger.Warn("Failed to read events for revert detection", "error", err)
+		return
+	}
+	for _, event := range events {
+		if event.Type != EventPullRequestReverted {
+			continue
+		}
+		if n, err := strconv.Atoi(event.Data["revert_pr"]); err == nil {
+			d.processed[n] = true
+		}
+	}
+}
+
+func (d *RevertDetector) isProcessed(revertNumber int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.processed[revertNumber]
+}
+
+func (d *RevertDetector) markProcessed(revertNumber int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.processed[revertNumber] = true
+}
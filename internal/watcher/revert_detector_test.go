@@ -0,0 +1,167 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRevertTrackerClient はRevertの検出に対応したGitHubクライアントのモック
+type mockRevertTrackerClient struct {
+	mockIssueCreatorClient
+}
+
+func (m *mockRevertTrackerClient) ListMergedPullRequests(ctx context.Context, owner, repo string, since time.Time) ([]*gh.MergedPullRequest, error) {
+	args := m.Called(ctx, owner, repo, since)
+	return args.Get(0).([]*gh.MergedPullRequest), args.Error(1)
+}
+
+func (m *mockRevertTrackerClient) GetMergedPullRequest(ctx context.Context, owner, repo string, prNumber int) (*gh.MergedPullRequest, error) {
+	args := m.Called(ctx, owner, repo, prNumber)
+	return args.Get(0).(*gh.MergedPullRequest), args.Error(1)
+}
+
+func (m *mockRevertTrackerClient) ReopenIssue(ctx context.Context, owner, repo string, issueNumber int) error {
+	args := m.Called(ctx, owner, repo, issueNumber)
+	return args.Error(0)
+}
+
+func TestRevertDetector_CheckOnce(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	revert := &gh.MergedPullRequest{
+		Number: 30,
+		Title:  `Revert "Add feature"`,
+		Body:   "Reverts owner/repo#25",
+		State:  "MERGED",
+		URL:    "https://github.com/owner/repo/pull/30",
+	}
+
+	tests := []struct {
+		name       string
+		reverted   *gh.MergedPullRequest
+		issueState string
+		wantReopen bool
+		wantHandle bool
+	}{
+		{
+			name:       "クローズ済みのIssueを再オープンしてラベルを付与",
+			reverted:   &gh.MergedPullRequest{Number: 25, State: "MERGED", HeadRefName: "osoba/#12"},
+			issueState: "CLOSED",
+			wantReopen: true,
+			wantHandle: true,
+		},
+		{
+			name:       "オープンのIssueにはラベルのみ付与",
+			reverted:   &gh.MergedPullRequest{Number: 25, State: "MERGED", HeadRefName: "osoba/#12"},
+			issueState: "OPEN",
+			wantHandle: true,
+		},
+		{
+			name: "Issueをクローズするosoba以外のブランチのPR",
+			reverted: &gh.MergedPullRequest{Number: 25, State: "MERGED", HeadRefName: "feature/foo",
+				ClosingIssuesReferences: []struct {
+					Number int `json:"number"`
+				}{{Number: 12}}},
+			issueState: "OPEN",
+			wantHandle: true,
+		},
+		{
+			name:     "osobaのブランチでなくIssueもクローズしないPRは対象外",
+			reverted: &gh.MergedPullRequest{Number: 25, State: "MERGED", HeadRefName: "feature/foo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockRevertTrackerClient)
+			client.On("ListMergedPullRequests", mock.Anything, "owner", "repo", now.Add(-24*time.Hour)).
+				Return([]*gh.MergedPullRequest{{Number: 29, Body: "通常のPR"}, revert}, nil).Once()
+			client.On("GetMergedPullRequest", mock.Anything, "owner", "repo", 25).Return(tt.reverted, nil).Once()
+			if tt.wantHandle {
+				client.On("GetIssueState", mock.Anything, "owner", "repo", 12).Return(tt.issueState, nil).Once()
+				client.On("AddLabel", mock.Anything, "owner", "repo", 12, "status:reverted").Return(nil).Once()
+				client.On("CreateIssueComment", mock.Anything, "owner", "repo", 12,
+					mock.MatchedBy(func(body string) bool {
+						return assert.Contains(t, body, "#25 の変更が #30 でRevert") &&
+							assert.Contains(t, body, revert.URL)
+					})).Return(nil).Once()
+			}
+			if tt.wantReopen {
+				client.On("ReopenIssue", mock.Anything, "owner", "repo", 12).Return(nil).Once()
+			}
+
+			events, err := NewEventStore(filepath.Join(t.TempDir(), "events.jsonl"))
+			require.NoError(t, err)
+			d, err := NewRevertDetector(client, "owner", "repo", config.NewConfig(), events, NewMockLogger())
+			require.NoError(t, err)
+			d.clock = clock.NewFake(now)
+
+			require.NoError(t, d.CheckOnce(context.Background()))
+			client.AssertExpectations(t)
+
+			recorded, err := events.Read()
+			require.NoError(t, err)
+			if tt.wantHandle {
+				require.Len(t, recorded, 1)
+				assert.Equal(t, EventPullRequestReverted, recorded[0].Type)
+				assert.Equal(t, "30", recorded[0].Data["revert_pr"])
+			} else {
+				assert.Empty(t, recorded)
+			}
+		})
+	}
+
+	t.Run("対応に失敗したRevertは確認範囲を進めたうえで次回再試行する", func(t *testing.T) {
+		other := &gh.MergedPullRequest{Number: 31, Body: "Reverts owner/repo#26", State: "MERGED"}
+		client := new(mockRevertTrackerClient)
+		client.On("ListMergedPullRequests", mock.Anything, "owner", "repo", now.Add(-24*time.Hour)).
+			Return([]*gh.MergedPullRequest{revert, other}, nil).Once()
+		client.On("GetMergedPullRequest", mock.Anything, "owner", "repo", 25).Return((*gh.MergedPullRequest)(nil), errors.New("api error")).Once()
+		client.On("GetMergedPullRequest", mock.Anything, "owner", "repo", 26).
+			Return(&gh.MergedPullRequest{Number: 26, State: "MERGED", HeadRefName: "feature/bar"}, nil).Once()
+
+		d, err := NewRevertDetector(client, "owner", "repo", config.NewConfig(), nil, NewMockLogger())
+		require.NoError(t, err)
+		fake := clock.NewFake(now)
+		d.clock = fake
+		require.NoError(t, d.CheckOnce(context.Background()))
+
+		fake.Advance(10 * time.Minute)
+		client.On("ListMergedPullRequests", mock.Anything, "owner", "repo", now.Add(-revertCheckOverlap)).
+			Return([]*gh.MergedPullRequest{}, nil).Once()
+		client.On("GetMergedPullRequest", mock.Anything, "owner", "repo", 25).
+			Return(&gh.MergedPullRequest{Number: 25, State: "MERGED", HeadRefName: "osoba/#12"}, nil).Once()
+		client.On("GetIssueState", mock.Anything, "owner", "repo", 12).Return("OPEN", nil).Once()
+		client.On("AddLabel", mock.Anything, "owner", "repo", 12, "status:reverted").Return(nil).Once()
+		client.On("CreateIssueComment", mock.Anything, "owner", "repo", 12, mock.Anything).Return(nil).Once()
+		require.NoError(t, d.CheckOnce(context.Background()))
+
+		client.AssertExpectations(t)
+		assert.Empty(t, d.pending)
+	})
+
+	t.Run("対応済みのRevertは再起動後も対応しない", func(t *testing.T) {
+		events, err := NewEventStore(filepath.Join(t.TempDir(), "events.jsonl"))
+		require.NoError(t, err)
+		require.NoError(t, events.Append(Event{Type: EventPullRequestReverted, IssueNumber: 12, PRNumber: 25, Data: map[string]string{"revert_pr": "30"}}))
+
+		client := new(mockRevertTrackerClient)
+		client.On("ListMergedPullRequests", mock.Anything, "owner", "repo", mock.Anything).Return([]*gh.MergedPullRequest{revert}, nil).Once()
+
+		d, err := NewRevertDetector(client, "owner", "repo", config.NewConfig(), events, NewMockLogger())
+		require.NoError(t, err)
+		require.NoError(t, d.CheckOnce(context.Background()))
+
+		client.AssertExpectations(t)
+		client.AssertNotCalled(t, "GetMergedPullRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,249 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/watcher/actions"
+)
+
+// reviewBotsClient はレビューボットのレビューの取得に使用するクライアント
+type reviewBotsClient interface {
+	github.GitHubClient
+	github.PullRequestReviewReader
+}
+
+// BotReviewFeedback はレビューボットがPRの最新のコミットに投稿したレビューとコメント
+type BotReviewFeedback struct {
+	PRNumber   int
+	HeadCommit string
+	Bots       []string // レビューしたボット（設定の表記）
+	Reviews    []github.PullRequestReview
+	Comments   []github.PullRequestReviewComment
+}
+
+// RequestsChanges はボットが修正を求めているかを返す（いずれかのボットの最後のレビューが変更の要求の場合）
+// 行へのコメントは提案や補足のこともあるため、reviseに渡すだけで判定には使わない
+func (f *BotReviewFeedback) RequestsChanges() bool {
+	latest := make(map[string]github.PullRequestReview)
+	for _, review := range f.Reviews {
+		login := normalizeBotLogin(review.Author)
+		if prev, ok := latest[login]; !ok || !review.SubmittedAt.Before(prev.SubmittedAt) {
+			latest[login] = review
+		}
+	}
+	for _, review := range latest {
+		if review.State == "CHANGES_REQUESTED" {
+			return true
+		}
+	}
+	return false
+}
+
+// Markdown はreviseのプロンプトに渡すMarkdownを返す
+func (f *BotReviewFeedback) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Review bot feedback for PR #%d (%s)\n", f.PRNumber, f.HeadCommit)
+	for _, review := range f.Reviews {
+		body := strings.TrimSpace(review.Body)
+		if body == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s (%s)\n\n%s\n", review.Author, review.State, body)
+	}
+	if len(f.Comments) > 0 {
+		b.WriteString("\n## Inline comments\n")
+		for _, comment := range f.Comments {
+			location := comment.Path
+			if comment.Line > 0 {
+				location = fmt.Sprintf("%s:%d", comment.Path, comment.Line)
+			}
+			fmt.Fprintf(&b, "\n### %s (%s)\n\n%s\n", location, comment.Author, strings.TrimSpace(comment.Body))
+		}
+	}
+	return b.String()
+}
+
+// ReviewBots は既存のPRレビューボット（coderabbit、copilotなど）のレビューをosobaのワークフローに取り込む
+// ボットが最新のコミットに投稿したレビューとコメントをreviseのプロンプトに渡し、
+// 設定で有効な場合はボットがレビュー済みのPRでosobaのレビューフェーズを省略する
+type ReviewBots struct {
+	client reviewBotsClient
+	owner  string
+	repo   string
+	config *config.Config
+	logger logger.Logger
+	bots   map[string]string // 正規化したアカウント名と設定の表記
+}
+
+var _ actions.BotReviewSource = (*ReviewBots)(nil)
+
+// NewReviewBots は新しいReviewBotsを作成する
+func NewReviewBots(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*ReviewBots, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	reader, ok := client.(reviewBotsClient)
+	if !ok {
+		return nil, errors.New("github client does not support reading pull request reviews")
+	}
+
+	bots := make(map[string]string)
+	for _, account := range cfg.GitHub.ReviewBots.Accounts {
+		bots[normalizeBotLogin(account)] = account
+	}
+
+	return &ReviewBots{
+		client: reader,
+		owner:  owner,
+		repo:   repo,
+		config: cfg,
+		logger: logger,
+		bots:   bots,
+	}, nil
+}
+
+// normalizeBotLogin はアカウント名を比較用に正規化する
+// APIによってapp/coderabbitai、coderabbitai[bot]、coderabbitaiのように表記が異なるため
+func normalizeBotLogin(login string) string {
+	login = strings.ToLower(strings.TrimSpace(login))
+	login = strings.TrimPrefix(login, "app/")
+	return strings.TrimSuffix(login, "[bot]")
+}
+
+// Feedback はIssueのPRにボットが最新のコミットへ投稿したレビューとコメントを返す
+// PRがない場合やボットのレビューがない場合はnilを返す
+func (r *ReviewBots) Feedback(ctx context.Context, issueNumber int) (*BotReviewFeedback, error) {
+	pr, err := r.client.GetPullRequestForIssue(ctx, issueNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+	if pr == nil {
+		return nil, nil
+	}
+
+	reviews, err := r.client.GetPullRequestReviews(ctx, r.owner, r.repo, pr.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	// 以前のコミットへの指摘は修正済みの可能性があるため、最新のコミットへのものだけを使う
+	feedback := &BotReviewFeedback{PRNumber: pr.Number, HeadCommit: reviews.HeadCommit}
+	seen := make(map[string]bool)
+	addBot := func(login string) {
+		account := r.bots[normalizeBotLogin(login)]
+		if !seen[account] {
+			seen[account] = true
+			feedback.Bots = append(feedback.Bots, account)
+		}
+	}
+	for _, review := range reviews.Reviews {
+		if !r.isBot(review.Author) || review.Commit != reviews.HeadCommit {
+			continue
+		}
+		feedback.Reviews = append(feedback.Reviews, review)
+		addBot(review.Author)
+	}
+	for _, comment := range reviews.Comments {
+		if !r.isBot(comment.Author) || comment.Commit != reviews.HeadCommit {
+			continue
+		}
+		feedback.Comments = append(feedback.Comments, comment)
+		addBot(comment.Author)
+	}
+	if len(feedback.Bots) == 0 {
+		return nil, nil
+	}
+	return feedback, nil
+}
+
+// BotReviewFeedback はreviseのプロンプトに渡すボットのレビューをMarkdownで返す（ない場合は空文字）
+func (r *ReviewBots) BotReviewFeedback(ctx context.Context, issueNumber int) (string, error) {
+	feedback, err := r.Feedback(ctx, issueNumber)
+	if err != nil || feedback == nil {
+		return "", err
+	}
+	return feedback.Markdown(), nil
+}
+
+// CheckBeforeReview はレビューフェーズの開始前に、ボットがPRの最新のコミットをレビュー済みかを確認する
+// レビュー済みの場合はosobaのレビューフェーズを省略し、レビューフェーズと同じくIssueを実行中ラベルに遷移して
+// ボットの判定に応じたラベルをPRに付与し、trueを返す
+func (r *ReviewBots) CheckBeforeReview(ctx context.Context, issue *github.Issue) (bool, error) {
+	if !r.config.GitHub.ReviewBots.SkipReview || issue == nil || issue.Number == nil {
+		return false, nil
+	}
+	t, ok := findWorkflowTransition(issue)
+	if !ok || t.Phase != config.PhaseReview {
+		return false, nil
+	}
+
+	issueNumber := *issue.Number
+	feedback, err := r.Feedback(ctx, issueNumber)
+	if err != nil || feedback == nil {
+		return false, err
+	}
+
+	// PRのラベルを先に付与し、Issueの遷移に失敗した場合も次回の確認でやり直せるようにする
+	label, stale := "status:lgtm", "status:requires-changes"
+	if feedback.RequestsChanges() {
+		label, stale = stale, label
+	}
+	if err := r.client.AddLabel(ctx, r.owner, r.repo, feedback.PRNumber, label); err != nil {
+		return false, fmt.Errorf("failed to add %s to PR #%d: %w", label, feedback.PRNumber, err)
+	}
+	// 以前のコミットへの判定のラベルが残っていると、PRの監視が古い判定で動くため外す
+	if err := r.client.RemoveLabel(ctx, r.owner, r.repo, feedback.PRNumber, stale); err != nil {
+		r.logger.Warn("Failed to remove stale review label", "pr_number", feedback.PRNumber, "label", stale, "error", err)
+	}
+	if err := r.client.TransitionLabels(ctx, r.owner, r.repo, issueNumber, t.From, t.Executing); err != nil {
+		return false, fmt.Errorf("failed to transition labels to %s: %w", t.Executing, err)
+	}
+
+	body := r.config.RenderComment(config.CommentReviewBotsUsed, map[string]string{
+		"issue-number": strconv.Itoa(issueNumber),
+		"pr":           fmt.Sprintf("#%d", feedback.PRNumber),
+		"bots":         strings.Join(feedback.Bots, ", "),
+		"commit":       shortCommit(feedback.HeadCommit),
+		"label":        label,
+	})
+	if err := createInformationalComment(ctx, r.client, r.owner, r.repo, issueNumber, body); err != nil {
+		r.logger.Warn("Failed to post review bots comment", "issue_number", issueNumber, "error", err)
+	}
+
+	r.logger.Info("Skipped review phase using review bot feedback",
+		"issue_number", issueNumber,
+		"pr_number", feedback.PRNumber,
+		"bots", feedback.Bots,
+		"commit", feedback.HeadCommit,
+		"label", label)
+	return true, nil
+}
+
+func (r *ReviewBots) isBot(login string) bool {
+	_, ok := r.bots[normalizeBotLogin(login)]
+	return ok
+}
+
+// shortCommit はコメントに表示する短いコミットハッシュを返す
+func shortCommit(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
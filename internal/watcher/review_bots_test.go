@@ -0,0 +1,208 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockReviewReaderClient はPRのレビューの取得に対応したGitHubクライアントのモック
+type mockReviewReaderClient struct {
+	MockGitHubClient
+}
+
+func (m *mockReviewReaderClient) GetPullRequestReviews(ctx context.Context, owner, repo string, prNumber int) (*gh.PullRequestReviews, error) {
+	args := m.Called(ctx, owner, repo, prNumber)
+	return args.Get(0).(*gh.PullRequestReviews), args.Error(1)
+}
+
+func newReviewBotsTestConfig() *config.Config {
+	cfg := config.NewConfig()
+	cfg.GitHub.ReviewBots.Accounts = []string{"coderabbitai", "Copilot"}
+	cfg.GitHub.ReviewBots.SkipReview = true
+	return cfg
+}
+
+func TestNormalizeBotLogin(t *testing.T) {
+	for _, login := range []string{"coderabbitai", "coderabbitai[bot]", "app/coderabbitai", "CodeRabbitAI"} {
+		assert.Equal(t, "coderabbitai", normalizeBotLogin(login), login)
+	}
+}
+
+func TestReviewBots_Feedback(t *testing.T) {
+	client := new(mockReviewReaderClient)
+	client.On("GetPullRequestForIssue", mock.Anything, 12).Return(&gh.PullRequest{Number: 25}, nil)
+	client.On("GetPullRequestReviews", mock.Anything, "owner", "repo", 25).Return(&gh.PullRequestReviews{
+		HeadCommit: "abc1234567",
+		Reviews: []gh.PullRequestReview{
+			{Author: "coderabbitai", State: "CHANGES_REQUESTED", Body: "nil チェックがありません", Commit: "abc1234567"},
+			{Author: "copilot-pull-request-reviewer[bot]", State: "COMMENTED", Body: "対象外のボット", Commit: "abc1234567"},
+			{Author: "alice", State: "APPROVED", Body: "人間のレビュー", Commit: "abc1234567"},
+			{Author: "coderabbitai", State: "COMMENTED", Body: "古いコミットへの指摘", Commit: "old0000000"},
+		},
+		Comments: []gh.PullRequestReviewComment{
+			{Author: "coderabbitai[bot]", Path: "main.go", Line: 12, Body: "errを確認してください", Commit: "abc1234567"},
+			{Author: "coderabbitai[bot]", Path: "main.go", Line: 3, Body: "修正済みの指摘", Commit: "old0000000"},
+		},
+	}, nil)
+
+	r, err := NewReviewBots(client, "owner", "repo", newReviewBotsTestConfig(), NewMockLogger())
+	require.NoError(t, err)
+
+	feedback, err := r.Feedback(context.Background(), 12)
+	require.NoError(t, err)
+	require.NotNil(t, feedback)
+	assert.Equal(t, []string{"coderabbitai"}, feedback.Bots)
+	assert.Len(t, feedback.Reviews, 1, "最新のコミットへのボットのレビューのみ")
+	assert.Len(t, feedback.Comments, 1)
+	assert.True(t, feedback.RequestsChanges())
+
+	markdown, err := r.BotReviewFeedback(context.Background(), 12)
+	require.NoError(t, err)
+	assert.Contains(t, markdown, "nil チェックがありません")
+	assert.Contains(t, markdown, "### main.go:12 (coderabbitai[bot])")
+	assert.NotContains(t, markdown, "人間のレビュー")
+	assert.NotContains(t, markdown, "修正済みの指摘")
+}
+
+func TestBotReviewFeedback_RequestsChanges(t *testing.T) {
+	at := func(minute int) time.Time { return time.Date(2025, 1, 2, 3, minute, 0, 0, time.UTC) }
+	tests := []struct {
+		name     string
+		feedback BotReviewFeedback
+		want     bool
+	}{
+		{
+			name: "変更の要求",
+			feedback: BotReviewFeedback{Reviews: []gh.PullRequestReview{
+				{Author: "coderabbitai", State: "CHANGES_REQUESTED", SubmittedAt: at(1)},
+			}},
+			want: true,
+		},
+		{
+			name: "行へのコメントだけでは変更の要求とみなさない",
+			feedback: BotReviewFeedback{
+				Reviews:  []gh.PullRequestReview{{Author: "Copilot", State: "COMMENTED", SubmittedAt: at(1)}},
+				Comments: []gh.PullRequestReviewComment{{Author: "Copilot", Path: "main.go", Line: 3, Body: "nit: 名前を変えてもよいかもしれません"}},
+			},
+			want: false,
+		},
+		{
+			name: "変更の要求の後に承認した",
+			feedback: BotReviewFeedback{Reviews: []gh.PullRequestReview{
+				{Author: "coderabbitai[bot]", State: "APPROVED", SubmittedAt: at(2)},
+				{Author: "coderabbitai", State: "CHANGES_REQUESTED", SubmittedAt: at(1)},
+			}},
+			want: false,
+		},
+		{
+			name: "別のボットが変更を要求した",
+			feedback: BotReviewFeedback{Reviews: []gh.PullRequestReview{
+				{Author: "coderabbitai", State: "APPROVED", SubmittedAt: at(2)},
+				{Author: "Copilot", State: "CHANGES_REQUESTED", SubmittedAt: at(1)},
+			}},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.feedback.RequestsChanges())
+		})
+	}
+}
+
+func TestReviewBots_CheckBeforeReview(t *testing.T) {
+	reviewIssue := &gh.Issue{
+		Number: intPtr(12),
+		Labels: []*gh.Label{{Name: stringPtr("status:review-requested")}},
+	}
+
+	tests := []struct {
+		name        string
+		issue       *gh.Issue
+		skipReview  bool
+		reviews     *gh.PullRequestReviews
+		wantSkipped bool
+		wantLabel   string
+		wantRemoved string
+	}{
+		{
+			name:       "ボットが変更を要求した場合はrequires-changesを付与",
+			issue:      reviewIssue,
+			skipReview: true,
+			reviews: &gh.PullRequestReviews{HeadCommit: "abc1234567", Reviews: []gh.PullRequestReview{
+				{Author: "coderabbitai[bot]", State: "CHANGES_REQUESTED", Body: "修正してください", Commit: "abc1234567"},
+			}},
+			wantSkipped: true,
+			wantLabel:   "status:requires-changes",
+			wantRemoved: "status:lgtm",
+		},
+		{
+			name:       "指摘がない場合はlgtmを付与",
+			issue:      reviewIssue,
+			skipReview: true,
+			reviews: &gh.PullRequestReviews{HeadCommit: "abc1234567", Reviews: []gh.PullRequestReview{
+				{Author: "Copilot", State: "COMMENTED", Body: "問題は見つかりませんでした", Commit: "abc1234567"},
+			}},
+			wantSkipped: true,
+			wantLabel:   "status:lgtm",
+			wantRemoved: "status:requires-changes",
+		},
+		{
+			name:       "最新のコミットをレビューしていない場合はレビューフェーズを実行",
+			issue:      reviewIssue,
+			skipReview: true,
+			reviews: &gh.PullRequestReviews{HeadCommit: "abc1234567", Reviews: []gh.PullRequestReview{
+				{Author: "coderabbitai", State: "APPROVED", Commit: "old0000000"},
+			}},
+		},
+		{
+			name:  "skip_reviewが無効の場合はレビューフェーズを実行",
+			issue: reviewIssue,
+		},
+		{
+			name: "レビューフェーズ以外は対象外",
+			issue: &gh.Issue{
+				Number: intPtr(12),
+				Labels: []*gh.Label{{Name: stringPtr("status:ready")}},
+			},
+			skipReview: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newReviewBotsTestConfig()
+			cfg.GitHub.ReviewBots.SkipReview = tt.skipReview
+			client := new(mockReviewReaderClient)
+			if tt.reviews != nil {
+				client.On("GetPullRequestForIssue", mock.Anything, 12).Return(&gh.PullRequest{Number: 25}, nil).Once()
+				client.On("GetPullRequestReviews", mock.Anything, "owner", "repo", 25).Return(tt.reviews, nil).Once()
+			}
+			if tt.wantSkipped {
+				client.On("AddLabel", mock.Anything, "owner", "repo", 25, tt.wantLabel).Return(nil).Once()
+				client.On("RemoveLabel", mock.Anything, "owner", "repo", 25, tt.wantRemoved).Return(nil).Once()
+				client.On("TransitionLabels", mock.Anything, "owner", "repo", 12, "status:review-requested", "status:reviewing").Return(nil).Once()
+				client.On("CreateIssueComment", mock.Anything, "owner", "repo", 12,
+					mock.MatchedBy(func(body string) bool {
+						return assert.Contains(t, body, "（abc1234）") && assert.Contains(t, body, "`"+tt.wantLabel+"`")
+					})).Return(nil).Once()
+			}
+
+			r, err := NewReviewBots(client, "owner", "repo", cfg, NewMockLogger())
+			require.NoError(t, err)
+
+			skipped, err := r.CheckBeforeReview(context.Background(), tt.issue)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSkipped, skipped)
+			client.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// reviewEscalatorClient はレビューの引き継ぎに使用するクライアント
+type reviewEscalatorClient interface {
+	github.GitHubClient
+	github.ReviewRequester
+}
+
+// ReviewEscalator はレビューと修正の往復が続くIssueを人間のレビュアーに引き継ぐ
+// レビューでstatus:requires-changesになった回数を数え、上限に達したら自動の修正を止めて
+// PRにレビュアーを追加し、引き継ぎのラベルとコメントを付与する
+type ReviewEscalator struct {
+	client reviewEscalatorClient
+	owner  string
+	repo   string
+	config *config.Config
+	events *EventStore // 回数を記録するイベントストア（無効の場合はプロセス内でのみ数える）
+	logger logger.Logger
+	clock  clock.Clock
+
+	mu     sync.Mutex
+	cycles map[int]int // イベントストアが無効の場合のIssueごとの往復回数
+}
+
+// NewReviewEscalator は新しいReviewEscalatorを作成する
+func NewReviewEscalator(client github.GitHubClient, owner, repo string, cfg *config.Config, events *EventStore, logger logger.Logger) (*ReviewEscalator, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	requester, ok := client.(reviewEscalatorClient)
+	if !ok {
+		return nil, errors.New("github client does not support requesting reviewers")
+	}
+
+	return &ReviewEscalator{
+		client: requester,
+		owner:  owner,
+		repo:   repo,
+		config: cfg,
+		events: events,
+		logger: logger,
+		clock:  clock.New(),
+		cycles: make(map[int]int),
+	}, nil
+}
+
+// HandleChangesRequested はIssueがレビューでstatus:requires-changesになったことを記録し、
+// 往復回数が上限に達した場合は人間のレビュアーに引き継いでtrueを返す
+// trueを返した場合、呼び出し元は通常のstatus:readyへの遷移を行わない
+// 前回の引き継ぎに失敗して往復回数が上限に達したままの場合は、往復を数え直さずに引き継ぎをやり直す
+func (e *ReviewEscalator) HandleChangesRequested(ctx context.Context, issueNumber int) (bool, error) {
+	maxCycles := e.config.GitHub.ReviewEscalation.MaxCycles
+	cycles := e.currentCycles(issueNumber)
+	if cycles < maxCycles {
+		cycles = e.recordChangesRequested(issueNumber)
+	}
+	e.logger.Info("Review requested changes",
+		"issue_number", issueNumber,
+		"cycles", cycles,
+		"max_cycles", maxCycles)
+	if cycles < maxCycles {
+		return false, nil
+	}
+
+	if err := e.escalate(ctx, issueNumber, cycles); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// escalate はIssueのラベルをstatus:requires-changesから引き継ぎのラベルに変更し、PRにレビュアーを追加する
+// 自動の修正を確実に止めるためラベルを先に変更し、レビュアーの追加に失敗した場合はコメントに記載する
+func (e *ReviewEscalator) escalate(ctx context.Context, issueNumber, cycles int) error {
+	cfg := e.config.GitHub.ReviewEscalation
+
+	if err := e.client.TransitionLabels(ctx, e.owner, e.repo, issueNumber, "status:requires-changes", cfg.Label); err != nil {
+		return fmt.Errorf("failed to transition labels to %s: %w", cfg.Label, err)
+	}
+
+	pr, err := e.client.GetPullRequestForIssue(ctx, issueNumber)
+	if err != nil {
+		// PRが見つからなくてもIssueのラベルで自動処理は止められるため、処理を継続する
+		e.logger.Warn("Failed to find pull request for review escalation",
+			"issue_number", issueNumber,
+			"error", err)
+		pr = nil
+	}
+
+	reviewers := "なし"
+	prRef := "なし"
+	prNumber := 0
+	if pr != nil {
+		prNumber = pr.Number
+		prRef = fmt.Sprintf("#%d", pr.Number)
+		if len(cfg.Reviewers) > 0 {
+			reviewers = strings.Join(cfg.Reviewers, ", ")
+			if err := e.client.RequestPullRequestReviewers(ctx, e.owner, e.repo, pr.Number, cfg.Reviewers); err != nil {
+				e.logger.Warn("Failed to request reviewers for review escalation",
+					"issue_number", issueNumber,
+					"pr_number", pr.Number,
+					"error", err)
+				reviewers += "（依頼に失敗しました。手動で依頼してください）"
+			}
+		}
+	}
+
+	body := e.config.RenderComment(config.CommentReviewEscalated, map[string]string{
+		"issue-number": strconv.Itoa(issueNumber),
+		"pr":           prRef,
+		"reviewers":    reviewers,
+		"cycles":       strconv.Itoa(cycles),
+		"label":        cfg.Label,
+	})
+	if err := createInformationalComment(ctx, e.client, e.owner, e.repo, issueNumber, body); err != nil {
+		e.logger.Warn("Failed to post review escalation comment", "issue_number", issueNumber, "error", err)
+	}
+
+	e.logger.Info("Escalated review loop to human reviewers",
+		"issue_number", issueNumber,
+		"pr_number", prNumber,
+		"cycles", cycles,
+		"reviewers", cfg.Reviewers,
+		"label", cfg.Label)
+
+	e.recordEscalated(issueNumber, prNumber)
+	return nil
+}
+
+// recordChangesRequested は往復を1回記録し、前回の引き継ぎ以降の往復回数を返す
+func (e *ReviewEscalator) recordChangesRequested(issueNumber int) int {
+	if e.events != nil {
+		if err := e.events.Append(Event{
+			Time:        e.clock.Now(),
+			Type:        EventReviewChangesRequested,
+			IssueNumber: issueNumber,
+		}); err != nil {
+			e.logger.Warn("Failed to record review event", "issue_number", issueNumber, "error", err)
+		} else if cycles, err := e.countCycles(issueNumber); err == nil {
+			return cycles
+		} else {
+			e.logger.Warn("Failed to read review events", "issue_number", issueNumber, "error", err)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cycles[issueNumber]++
+	return e.cycles[issueNumber]
+}
+
+// currentCycles は往復を記録せずに、前回の引き継ぎ以降の往復回数を返す
+func (e *ReviewEscalator) currentCycles(issueNumber int) int {
+	if e.events != nil {
+		if cycles, err := e.countCycles(issueNumber); err == nil {
+			return cycles
+		}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.cycles[issueNumber]
+}
+
+// countCycles はイベントストアから前回の引き継ぎ以降の往復回数を数える
+func (e *ReviewEscalator) countCycles(issueNumber int) (int, error) {
+	events, err := e.events.Read()
+	if err != nil {
+		return 0, err
+	}
+	cycles := 0
+	for _, event := range events {
+		if event.IssueNumber != issueNumber {
+			continue
+		}
+		switch event.Type {
+		case EventReviewChangesRequested:
+			cycles++
+		case EventReviewEscalated:
+			cycles = 0
+		}
+	}
+	return cycles, nil
+}
+
+// recordEscalated は引き継ぎを記録し、往復回数をリセットする
+func (e *ReviewEscalator) recordEscalated(issueNumber, prNumber int) {
+	e.mu.Lock()
+	delete(e.cycles, issueNumber)
+	e.mu.Unlock()
+
+	if e.events == nil {
+		return
+	}
+	if err := e.events.Append(Event{
+		Time:        e.clock.Now(),
+		Type:        EventReviewEscalated,
+		IssueNumber: issueNumber,
+		PRNumber:    prNumber,
+		Data:        map[string]string{"reviewers": strings.Join(e.config.GitHub.ReviewEscalation.Reviewers, ",")},
+	}); err != nil {
+		e.logger.Warn("Failed to record review escalation event", "issue_number", issueNumber, "error", err)
+	}
+}
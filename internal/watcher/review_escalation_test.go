@@ -0,0 +1,190 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockReviewRequesterClient はレビュー依頼に対応したGitHubクライアントのモック
+type mockReviewRequesterClient struct {
+	MockGitHubClient
+}
+
+func (m *mockReviewRequesterClient) RequestPullRequestReviewers(ctx context.Context, owner, repo string, prNumber int, reviewers []string) error {
+	args := m.Called(ctx, owner, repo, prNumber, reviewers)
+	return args.Error(0)
+}
+
+func newReviewEscalationConfig(reviewers ...string) *config.Config {
+	cfg := config.NewConfig()
+	cfg.GitHub.ReviewEscalation.MaxCycles = 2
+	cfg.GitHub.ReviewEscalation.Reviewers = reviewers
+	return cfg
+}
+
+func TestReviewEscalator_HandleChangesRequested(t *testing.T) {
+	tests := []struct {
+		name          string
+		reviewers     []string
+		pr            *gh.PullRequest
+		prErr         error
+		wantReviewers bool
+		wantComment   []string
+	}{
+		{
+			name:          "上限に達したらレビュアーを追加してラベルを付与",
+			reviewers:     []string{"alice", "org/team"},
+			pr:            &gh.PullRequest{Number: 25},
+			wantReviewers: true,
+			wantComment:   []string{"2 回に達した", "`status:needs-human`", "- PR: #25", "- レビュー依頼: alice, org/team"},
+		},
+		{
+			name:        "レビュアーの設定がない場合はラベルのみ付与",
+			pr:          &gh.PullRequest{Number: 25},
+			wantComment: []string{"- PR: #25", "- レビュー依頼: なし"},
+		},
+		{
+			name:        "PRが見つからない場合もラベルを付与",
+			reviewers:   []string{"alice"},
+			prErr:       errors.New("not found"),
+			wantComment: []string{"- PR: なし", "- レビュー依頼: なし"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockReviewRequesterClient)
+			events, err := NewEventStore(filepath.Join(t.TempDir(), "events.jsonl"))
+			require.NoError(t, err)
+			e, err := NewReviewEscalator(client, "owner", "repo", newReviewEscalationConfig(tt.reviewers...), events, NewMockLogger())
+			require.NoError(t, err)
+
+			escalated, err := e.HandleChangesRequested(context.Background(), 12)
+			require.NoError(t, err)
+			assert.False(t, escalated)
+
+			client.On("GetPullRequestForIssue", mock.Anything, 12).Return(tt.pr, tt.prErr).Once()
+			if tt.wantReviewers {
+				client.On("RequestPullRequestReviewers", mock.Anything, "owner", "repo", 25, tt.reviewers).Return(nil).Once()
+			}
+			client.On("TransitionLabels", mock.Anything, "owner", "repo", 12, "status:requires-changes", "status:needs-human").Return(nil).Once()
+			client.On("CreateIssueComment", mock.Anything, "owner", "repo", 12,
+				mock.MatchedBy(func(body string) bool {
+					for _, want := range tt.wantComment {
+						if !assert.Contains(t, body, want) {
+							return false
+						}
+					}
+					return true
+				})).Return(nil).Once()
+
+			escalated, err = e.HandleChangesRequested(context.Background(), 12)
+			require.NoError(t, err)
+			assert.True(t, escalated)
+			client.AssertExpectations(t)
+
+			// 引き継ぎ後は往復回数を数え直す
+			escalated, err = e.HandleChangesRequested(context.Background(), 12)
+			require.NoError(t, err)
+			assert.False(t, escalated)
+		})
+	}
+
+	t.Run("往復回数は再起動後も引き継ぐ", func(t *testing.T) {
+		events, err := NewEventStore(filepath.Join(t.TempDir(), "events.jsonl"))
+		require.NoError(t, err)
+		require.NoError(t, events.Append(Event{Time: time.Now(), Type: EventReviewChangesRequested, IssueNumber: 12}))
+		require.NoError(t, events.Append(Event{Time: time.Now(), Type: EventReviewChangesRequested, IssueNumber: 34}))
+
+		client := new(mockReviewRequesterClient)
+		client.On("GetPullRequestForIssue", mock.Anything, 12).Return(nil, nil).Once()
+		client.On("TransitionLabels", mock.Anything, "owner", "repo", 12, "status:requires-changes", "status:needs-human").Return(nil).Once()
+		client.On("CreateIssueComment", mock.Anything, "owner", "repo", 12, mock.Anything).Return(nil).Once()
+
+		e, err := NewReviewEscalator(client, "owner", "repo", newReviewEscalationConfig(), events, NewMockLogger())
+		require.NoError(t, err)
+		escalated, err := e.HandleChangesRequested(context.Background(), 12)
+		require.NoError(t, err)
+		assert.True(t, escalated)
+		client.AssertExpectations(t)
+
+		recorded, err := events.Read()
+		require.NoError(t, err)
+		require.Len(t, recorded, 4)
+		assert.Equal(t, EventReviewEscalated, recorded[3].Type)
+	})
+
+	t.Run("イベントストアが無効の場合はプロセス内で数える", func(t *testing.T) {
+		client := new(mockReviewRequesterClient)
+		e, err := NewReviewEscalator(client, "owner", "repo", newReviewEscalationConfig(), nil, NewMockLogger())
+		require.NoError(t, err)
+
+		escalated, err := e.HandleChangesRequested(context.Background(), 12)
+		require.NoError(t, err)
+		assert.False(t, escalated)
+		escalated, err = e.HandleChangesRequested(context.Background(), 34)
+		require.NoError(t, err)
+		assert.False(t, escalated)
+		client.AssertNotCalled(t, "TransitionLabels", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("レビュアーの追加に失敗した場合もラベルを付与してコメントに記載", func(t *testing.T) {
+		client := new(mockReviewRequesterClient)
+		client.On("TransitionLabels", mock.Anything, "owner", "repo", 12, "status:requires-changes", "status:needs-human").Return(nil).Once()
+		client.On("GetPullRequestForIssue", mock.Anything, 12).Return(&gh.PullRequest{Number: 25}, nil).Once()
+		client.On("RequestPullRequestReviewers", mock.Anything, "owner", "repo", 25, []string{"alice"}).Return(errors.New("forbidden")).Once()
+		client.On("CreateIssueComment", mock.Anything, "owner", "repo", 12,
+			mock.MatchedBy(func(body string) bool { return assert.Contains(t, body, "alice（依頼に失敗しました") })).Return(nil).Once()
+
+		cfg := newReviewEscalationConfig("alice")
+		cfg.GitHub.ReviewEscalation.MaxCycles = 1
+		e, err := NewReviewEscalator(client, "owner", "repo", cfg, nil, NewMockLogger())
+		require.NoError(t, err)
+
+		escalated, err := e.HandleChangesRequested(context.Background(), 12)
+		require.NoError(t, err)
+		assert.True(t, escalated)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("ラベルの変更に失敗した場合は往復を数え直さずにやり直す", func(t *testing.T) {
+		events, err := NewEventStore(filepath.Join(t.TempDir(), "events.jsonl"))
+		require.NoError(t, err)
+		client := new(mockReviewRequesterClient)
+		client.On("TransitionLabels", mock.Anything, "owner", "repo", 12, "status:requires-changes", "status:needs-human").Return(errors.New("network error")).Once()
+
+		cfg := newReviewEscalationConfig()
+		cfg.GitHub.ReviewEscalation.MaxCycles = 1
+		e, err := NewReviewEscalator(client, "owner", "repo", cfg, events, NewMockLogger())
+		require.NoError(t, err)
+
+		escalated, err := e.HandleChangesRequested(context.Background(), 12)
+		assert.ErrorContains(t, err, "failed to transition labels")
+		assert.False(t, escalated)
+		client.AssertNotCalled(t, "RequestPullRequestReviewers", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		client.On("TransitionLabels", mock.Anything, "owner", "repo", 12, "status:requires-changes", "status:needs-human").Return(nil).Once()
+		client.On("GetPullRequestForIssue", mock.Anything, 12).Return(nil, nil).Once()
+		client.On("CreateIssueComment", mock.Anything, "owner", "repo", 12,
+			mock.MatchedBy(func(body string) bool { return assert.Contains(t, body, "1 回に達した") })).Return(nil).Once()
+		escalated, err = e.HandleChangesRequested(context.Background(), 12)
+		require.NoError(t, err)
+		assert.True(t, escalated)
+		client.AssertExpectations(t)
+
+		recorded, err := events.Read()
+		require.NoError(t, err)
+		require.Len(t, recorded, 2)
+		assert.Equal(t, EventReviewChangesRequested, recorded[0].Type)
+		assert.Equal(t, EventReviewEscalated, recorded[1].Type)
+	})
+}
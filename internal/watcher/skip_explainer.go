@@ -0,0 +1,136 @@
+package watcher
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+)
+
+// Issueに対して何もしなかった理由の種類
+const (
+	SkipReasonFiltered       = "filtered"        // ラベルの状態により処理対象外（実行中ラベル・status:manualなど）
+	SkipReasonOverBudget     = "over_budget"     // フェーズの同時実行数の上限に達している
+	SkipReasonBlocked        = "blocked"         // 既存のPR・重複の可能性・計画の承認待ちなどで開始を止めている
+	SkipReasonPaused         = "paused"          // マシンの負荷やworktreeの状態によりフェーズを一時停止している
+	SkipReasonDependencyOpen = "dependency_open" // サブIssueがクローズされるまで待機している
+	SkipReasonBackfill       = "backfill"        // 起動時の積み残しのIssueとして開始の順番を待っている
+	SkipReasonDisabled       = "disabled"        // 機能の設定（features）でフェーズの実行やラベルの遷移を無効にしている
+	SkipReasonConflict       = "conflict"        // 同じ領域（conflict_fences）を実装中のIssueがあるため順番を待っている
+)
+
+// SkipExplanation はIssueに対して何もしなかった理由
+type SkipExplanation struct {
+	IssueNumber int       `json:"issue_number"`
+	Reason      string    `json:"reason"`           // SkipReason* のいずれか
+	Detail      string    `json:"detail,omitempty"` // 理由の詳細
+	At          time.Time `json:"at"`
+}
+
+// SkipExplainer はポーリングの各サイクルでIssueに対して何もしなかった理由を記録する
+// 最後に完了したサイクルの理由をosoba status --explainで表示するため、状態ファイルに含める
+// nilの場合は何も記録しない
+type SkipExplainer struct {
+	clock clock.Clock
+
+	mu           sync.Mutex
+	pending      map[int]SkipExplanation // 確認中のサイクルで記録した理由
+	latest       map[int]SkipExplanation // 最後に完了したサイクルの理由
+	dependencies map[int]SkipExplanation // サブIssueの完了待ちの親Issue（確認ごとに置き換える）
+}
+
+// NewSkipExplainer は新しいSkipExplainerを作成する
+func NewSkipExplainer() *SkipExplainer {
+	return &SkipExplainer{
+		clock:        clock.New(),
+		latest:       make(map[int]SkipExplanation),
+		dependencies: make(map[int]SkipExplanation),
+	}
+}
+
+// BeginCycle はポーリングのサイクルの開始を記録する
+func (e *SkipExplainer) BeginCycle() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.pending = make(map[int]SkipExplanation)
+}
+
+// EndCycle はサイクルで記録した理由を最新の理由として確定する
+func (e *SkipExplainer) EndCycle() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.pending == nil {
+		return
+	}
+	e.latest = e.pending
+	e.pending = nil
+}
+
+// Record はIssueに対して何もしなかった理由を記録する（同じサイクルでは後の記録が優先される）
+func (e *SkipExplainer) Record(issueNumber int, reason, detail string) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.pending == nil {
+		e.pending = make(map[int]SkipExplanation)
+	}
+	e.pending[issueNumber] = SkipExplanation{
+		IssueNumber: issueNumber,
+		Reason:      reason,
+		Detail:      detail,
+		At:          e.clock.Now(),
+	}
+}
+
+// SetDependencies はサブIssueの完了待ちの親Issueと詳細を置き換える
+func (e *SkipExplainer) SetDependencies(details map[int]string) {
+	if e == nil {
+		return
+	}
+	now := e.clock.Now()
+	dependencies := make(map[int]SkipExplanation, len(details))
+	for issueNumber, detail := range details {
+		dependencies[issueNumber] = SkipExplanation{
+			IssueNumber: issueNumber,
+			Reason:      SkipReasonDependencyOpen,
+			Detail:      detail,
+			At:          now,
+		}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.dependencies = dependencies
+}
+
+// Explanations は最後に完了したサイクルの理由とサブIssueの完了待ちをIssue番号順に返す
+func (e *SkipExplainer) Explanations() []SkipExplanation {
+	if e == nil {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var explanations []SkipExplanation
+	for _, explanation := range e.latest {
+		explanations = append(explanations, explanation)
+	}
+	for issueNumber, explanation := range e.dependencies {
+		if _, ok := e.latest[issueNumber]; ok {
+			continue
+		}
+		explanations = append(explanations, explanation)
+	}
+	sort.Slice(explanations, func(i, j int) bool {
+		return explanations[i].IssueNumber < explanations[j].IssueNumber
+	})
+	return explanations
+}
@@ -0,0 +1,140 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipExplainer_Cycles(t *testing.T) {
+	explainer := NewSkipExplainer()
+	explainer.SetDependencies(map[int]string{5: "waiting for sub-issues: #6"})
+
+	explainer.BeginCycle()
+	explainer.Record(3, SkipReasonOverBudget, "implement phase concurrency limit reached")
+	explainer.Record(1, SkipReasonFiltered, "Manual label 'status:manual' found")
+	assert.Len(t, explainer.Explanations(), 1, "サイクルの完了までは前回の理由を返す")
+	explainer.EndCycle()
+
+	explanations := explainer.Explanations()
+	require.Len(t, explanations, 3)
+	assert.Equal(t, []int{1, 3, 5}, []int{explanations[0].IssueNumber, explanations[1].IssueNumber, explanations[2].IssueNumber})
+	assert.Equal(t, SkipReasonDependencyOpen, explanations[2].Reason)
+
+	// 次のサイクルで記録されなかったIssueの理由は残さない
+	explainer.BeginCycle()
+	explainer.EndCycle()
+	assert.Len(t, explainer.Explanations(), 1)
+
+	var nilExplainer *SkipExplainer
+	nilExplainer.Record(1, SkipReasonPaused, "")
+	assert.Nil(t, nilExplainer.Explanations())
+}
+
+func TestStartWithActions_RecordsSkipExplanations(t *testing.T) {
+	ready := &gh.Issue{Number: intPtr(10), Labels: []*gh.Label{{Name: stringPtr("status:ready")}}}
+	running := &gh.Issue{Number: intPtr(11), Labels: []*gh.Label{{Name: stringPtr("status:ready")}, {Name: stringPtr("status:implementing")}}}
+
+	mockClient := new(MockGitHubClient)
+	mockClient.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:ready"}).
+		Return([]*gh.Issue{ready, running}, nil)
+	mockClient.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:implementing"}).
+		Return([]*gh.Issue{running}, nil)
+
+	explainer := NewSkipExplainer()
+	notifier := &recordingNotifier{}
+	budget, _ := newPhaseBudgetForTest(t, mockClient, config.ConcurrencyConfig{Implement: 1})
+	watcher := &IssueWatcher{
+		notifier:      notifier,
+		client:        mockClient,
+		owner:         "owner",
+		repo:          "repo",
+		labels:        []string{"status:ready"},
+		pollInterval:  100 * time.Millisecond,
+		actionManager: new(MockActionManager),
+		logger:        NewMockLogger(),
+		phaseBudget:   budget,
+		skipExplainer: explainer,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	watcher.StartWithActions(ctx)
+
+	explanations := explainer.Explanations()
+	require.Len(t, explanations, 2)
+	assert.Equal(t, SkipReasonOverBudget, explanations[0].Reason)
+	assert.Equal(t, "implement phase concurrency limit reached", explanations[0].Detail)
+	assert.Equal(t, SkipReasonFiltered, explanations[1].Reason)
+	assert.Contains(t, explanations[1].Detail, "status:implementing")
+
+	// 上限で開始を保留したIssueはbudget_exceededとして通知する
+	require.NotEmpty(t, notifier.events)
+	assert.Equal(t, config.NotifyBudgetExceeded, notifier.events[0].Kind)
+	assert.Equal(t, 10, notifier.events[0].IssueNumber)
+	assert.Equal(t, "implement phase concurrency limit reached", notifier.events[0].Detail)
+}
+
+func TestStartWithActions_SkipsDisabledFeatures(t *testing.T) {
+	tests := []struct {
+		name       string
+		label      string
+		features   func(*config.FeaturesConfig)
+		wantDetail string
+	}{
+		{
+			name:       "フェーズの実行を無効",
+			label:      "status:ready",
+			features:   func(f *config.FeaturesConfig) { f.AutoExecutePhases = false },
+			wantDetail: "features.auto_execute_phases is disabled",
+		},
+		{
+			name:       "レビューフェーズを無効",
+			label:      "status:review-requested",
+			features:   func(f *config.FeaturesConfig) { f.AutoReview = false },
+			wantDetail: "features.auto_review is disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := &gh.Issue{Number: intPtr(10), Labels: []*gh.Label{{Name: stringPtr(tt.label)}}}
+			mockClient := new(MockGitHubClient)
+			mockClient.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{tt.label}).
+				Return([]*gh.Issue{issue}, nil)
+
+			cfg := config.NewConfig()
+			cfg.GitHub.AutoMergeLGTM = false
+			tt.features(&cfg.Features)
+			actionManager := new(MockActionManager)
+			explainer := NewSkipExplainer()
+			watcher := &IssueWatcher{
+				client:        mockClient,
+				owner:         "owner",
+				repo:          "repo",
+				labels:        []string{tt.label},
+				pollInterval:  100 * time.Millisecond,
+				actionManager: actionManager,
+				logger:        NewMockLogger(),
+				config:        cfg,
+				skipExplainer: explainer,
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			watcher.StartWithActions(ctx)
+
+			actionManager.AssertNotCalled(t, "ExecuteAction", mock.Anything, mock.Anything)
+			explanations := explainer.Explanations()
+			require.Len(t, explanations, 1)
+			assert.Equal(t, SkipReasonDisabled, explanations[0].Reason)
+			assert.Equal(t, tt.wantDetail, explanations[0].Detail)
+		})
+	}
+}
@@ -22,25 +22,28 @@ const (
 	ExecutionLabelReviewing    = "status:reviewing"
 )
 
+// ManualLabel は人間が作業を引き継いだIssueに付与するラベル（osoba takeover / release）
+// このラベルがあるIssueはトリガーラベルがあっても自動処理しない
+const ManualLabel = "status:manual"
+
 // getTriggerLabelPriority はトリガーラベルの優先順位順に返す
-// 優先順位: needs-plan > ready > review-requested > requires-changes
+// 優先順位はworkflowの定義順（既定: needs-plan > ready > review-requested > requires-changes）
 func getTriggerLabelPriority() []string {
-	return []string{
-		TriggerLabelNeedsPlan,
-		TriggerLabelReady,
-		TriggerLabelReviewRequested,
-		TriggerLabelRequiresChanges,
+	labels := make([]string, 0, len(workflow.Transitions))
+	for _, t := range workflow.Transitions {
+		labels = append(labels, t.From)
 	}
+	return labels
 }
 
 // GetTriggerLabelMapping はトリガーラベルと実行中ラベルの対応関係を返す
+// 実行中ラベルを使わずに遷移するトリガーラベル（既定ではrequires-changes）は空文字列になる
 func GetTriggerLabelMapping() map[string]string {
-	return map[string]string{
-		TriggerLabelNeedsPlan:       ExecutionLabelPlanning,
-		TriggerLabelReady:           ExecutionLabelImplementing,
-		TriggerLabelReviewRequested: ExecutionLabelReviewing,
-		TriggerLabelRequiresChanges: "", // requires-changesには対応する実行中ラベルがない（直接readyに遷移）
+	mapping := make(map[string]string, len(workflow.Transitions))
+	for _, t := range workflow.Transitions {
+		mapping[t.From] = t.Executing
 	}
+	return mapping
 }
 
 // ShouldProcessIssue はIssueを処理すべきかをラベルベースで判定する
@@ -65,6 +68,11 @@ func ShouldProcessIssue(issue *github.Issue) (bool, string) {
 		}
 	}
 
+	// 人間が引き継いだIssueは処理しない
+	if issueLabels[ManualLabel] {
+		return false, fmt.Sprintf("Manual label '%s' found", ManualLabel)
+	}
+
 	// トリガーラベルを優先順位順に判定
 	triggerPriority := getTriggerLabelPriority()
 	for _, trigger := range triggerPriority {
@@ -125,6 +133,15 @@ func ShouldProcessIssueWithLogger(issue *github.Issue, log logger.Logger) (bool,
 		"totalLabels", len(issue.Labels),
 		"validLabels", labelCount)
 
+	// 人間が引き継いだIssueは処理しない
+	if issueLabels[ManualLabel] {
+		reason := fmt.Sprintf("Manual label '%s' found", ManualLabel)
+		log.Debug("ShouldProcessIssue: skip processing",
+			"issue", issueNumber,
+			"reason", reason)
+		return false, reason
+	}
+
 	// トリガーラベルを優先順位順に判定
 	triggerPriority := getTriggerLabelPriority()
 	for _, trigger := range triggerPriority {
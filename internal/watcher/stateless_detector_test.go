@@ -50,6 +50,12 @@ func TestShouldProcessIssue(t *testing.T) {
 			expectedResult: false,
 			expectedReason: "Execution label 'status:reviewing' already exists for trigger 'status:review-requested'",
 		},
+		{
+			name:           "トリガーラベル ready があるが、手動対応中の場合は処理しない",
+			issueLabels:    []string{"status:ready", "status:manual"},
+			expectedResult: false,
+			expectedReason: "Manual label 'status:manual' found",
+		},
 		{
 			name:           "トリガーラベルがない場合は処理しない",
 			issueLabels:    []string{"bug", "enhancement"},
@@ -0,0 +1,423 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/faultinject"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/tmux"
+)
+
+// StatusLabels はosoba statusで表示するステータスラベル（表示順）
+var StatusLabels = []string{
+	"status:planning",
+	"status:implementing",
+	"status:reviewing",
+	"status:needs-plan",
+	"status:ready",
+	"status:review-requested",
+	"status:manual",
+	"status:needs-human",
+}
+
+// StatusState は監視プロセスが書き出し、osoba statusが参照するキャッシュ状態
+type StatusState struct {
+	UpdatedAt time.Time                     `json:"updated_at"`
+	Owner     string                        `json:"owner"`
+	Repo      string                        `json:"repo"`
+	Issues    map[string][]StatusStateIssue `json:"issues"` // ステータスラベルごとのIssue
+	// ResourcePressure はマシンの負荷が高いために新しいフェーズの開始を保留している状態（保留していない場合はnil）
+	ResourcePressure *ResourcePressure `json:"resource_pressure,omitempty"`
+	// BranchProtection は起動時に検出したデフォルトブランチの保護ルール（取得できなかった場合はnil）
+	BranchProtection *github.BranchProtection `json:"branch_protection,omitempty"`
+	// Explanations は最後のポーリングでIssueに対して何もしなかった理由（osoba status --explainで表示する）
+	Explanations []SkipExplanation `json:"explanations,omitempty"`
+	// MergeQueue はマージキューに追加してマージを待っているPR
+	MergeQueue []MergeQueueStatus `json:"merge_queue,omitempty"`
+	// Backfill は起動時の積み残しのIssueの開始の進み具合（積み残しがない場合はnil）
+	Backfill *BackfillStatus `json:"backfill,omitempty"`
+	// Retries はリトライしたIssueのリトライの回数と深刻度
+	Retries []RetryStatus `json:"retries,omitempty"`
+	// Degradation はghコマンド・フェーズの開始の失敗が続いている不健全な状態（健全な場合はnil）
+	Degradation *DegradationStatus `json:"degradation,omitempty"`
+	// Throttle はGitHubによるスロットリングでAPI呼び出しを待機している状態（待機していない場合はnil）
+	Throttle *github.ThrottleStatus `json:"throttle,omitempty"`
+	// FaultInjection は--fault-injectionで注入した障害の対象ごとの回数（無効の場合はnil）
+	FaultInjection map[string]faultinject.Stats `json:"fault_injection,omitempty"`
+}
+
+// StatusStateIssue はキャッシュ状態に含まれるIssue
+type StatusStateIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// ReadStatusState は状態ファイルを読み込む
+func ReadStatusState(path string) (*StatusState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var state StatusState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse status state: %w", err)
+	}
+	return &state, nil
+}
+
+// StatusStateWriter はIssueの状態を定期的に取得して状態ファイルに書き出す
+// osoba statusはこのファイルを読むことで、ghコマンドを実行せずに状態を表示できる
+type StatusStateWriter struct {
+	client github.GitHubClient
+	owner  string
+	repo   string
+	path   string
+	config *config.Config
+	logger logger.Logger
+	clock  clock.Clock
+	guard  *ResourceGuard // フェーズ開始の保留状態の取得元（無効の場合はnil）
+	// protection は状態ファイルに含めるブランチ保護ルール（取得できなかった場合はnil）
+	protection *github.BranchProtection
+	explainer  *SkipExplainer // 何もしなかった理由の取得元（未設定の場合はnil）
+	mergeQueue *MergeQueue    // マージキューの追跡状態の取得元（使わない場合はnil）
+	backfill   *Backfill      // 積み残しの開始の進み具合の取得元（無効の場合はnil）
+	retries    *RetryBudget   // リトライの回数の取得元（無効の場合はnil）
+	// degradation は不健全な状態の取得元（無効の場合はnil）
+	degradation *DegradationMonitor
+	badgePath   string // ステータスバッジの書き出し先（無効の場合は空）
+	// faultInjector は注入した障害の回数の取得元（無効の場合はnil）
+	faultInjector *faultinject.Injector
+	// statusLineSessions はIssueの集計をユーザーオプション（@osoba_active など）として公開するtmuxセッション
+	statusLineSessions []string
+	tmuxExecutor       tmux.CommandExecutor
+}
+
+// NewStatusStateWriter は新しいStatusStateWriterを作成する
+func NewStatusStateWriter(client github.GitHubClient, owner, repo, path string, cfg *config.Config, logger logger.Logger) (*StatusStateWriter, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if path == "" {
+		return nil, errors.New("state file path is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+
+	return &StatusStateWriter{
+		client: client,
+		owner:  owner,
+		repo:   repo,
+		path:   path,
+		config: cfg,
+		logger: logger,
+		clock:  clock.New(),
+
+		tmuxExecutor: &tmux.DefaultCommandExecutor{},
+	}, nil
+}
+
+// SetResourceGuard はフェーズ開始の保留状態を状態ファイルに含めるよう設定する
+func (w *StatusStateWriter) SetResourceGuard(guard *ResourceGuard) {
+	w.guard = guard
+}
+
+// SetBranchProtection はブランチ保護ルールを状態ファイルに含めるよう設定する
+func (w *StatusStateWriter) SetBranchProtection(protection *github.BranchProtection) {
+	w.protection = protection
+}
+
+// SetFaultInjector は注入した障害の回数を状態ファイルに含めるよう設定する
+func (w *StatusStateWriter) SetFaultInjector(injector *faultinject.Injector) {
+	w.faultInjector = injector
+}
+
+// SetSkipExplainer はIssueに対して何もしなかった理由を状態ファイルに含めるよう設定する
+func (w *StatusStateWriter) SetSkipExplainer(explainer *SkipExplainer) {
+	w.explainer = explainer
+}
+
+// SetMergeQueue はマージキューで待っているPRを状態ファイルに含めるよう設定する
+func (w *StatusStateWriter) SetMergeQueue(queue *MergeQueue) {
+	w.mergeQueue = queue
+}
+
+// SetRetryBudget はIssueごとのリトライの回数を状態ファイルに含めるよう設定する
+func (w *StatusStateWriter) SetRetryBudget(budget *RetryBudget) {
+	w.retries = budget
+}
+
+// SetDegradationMonitor は監視の不健全な状態を状態ファイルに含めるよう設定する
+// 不健全な間はステータスバッジとtmuxのユーザーオプションの健全性もdegradedにする
+func (w *StatusStateWriter) SetDegradationMonitor(monitor *DegradationMonitor) {
+	w.degradation = monitor
+}
+
+// SetBackfill は積み残しのIssueの開始の進み具合を状態ファイルに含めるよう設定する
+func (w *StatusStateWriter) SetBackfill(backfill *Backfill) {
+	w.backfill = backfill
+}
+
+// SetBadgePath は状態ファイルと一緒にパイプラインの健全性を示すステータスバッジを書き出すよう設定する
+func (w *StatusStateWriter) SetBadgePath(path string) {
+	w.badgePath = path
+}
+
+// SetStatusLineSessions は状態ファイルと一緒に、Issueの集計をtmuxセッションのユーザーオプションとして公開するよう設定する
+func (w *StatusStateWriter) SetStatusLineSessions(sessions []string) {
+	w.statusLineSessions = sessions
+}
+
+// Start は状態ファイルの定期的な書き出しを開始する
+// 終了時は古い状態が参照されないよう状態ファイルを削除し、ステータスバッジは停止中にしてtmuxのユーザーオプションを削除する
+func (w *StatusStateWriter) Start(ctx context.Context) {
+	interval := w.config.GitHub.PollInterval
+	w.logger.Info("Starting status state writer", "interval", interval, "path", w.path)
+
+	if err := w.WriteOnce(ctx); err != nil {
+		w.logger.Warn("Failed to write status state", "error", err)
+	}
+
+	ticker := w.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := os.Remove(w.path); err != nil && !os.IsNotExist(err) {
+				w.logger.Warn("Failed to remove status state", "error", err)
+			}
+			if w.badgePath != "" {
+				stopped := PipelineHealth{Color: HealthStopped, Message: "stopped"}
+				if err := WriteBadge(w.badgePath, w.config.Badge.Label, stopped); err != nil {
+					w.logger.Warn("Failed to write status badge", "error", err)
+				}
+			}
+			for _, session := range w.statusLineSessions {
+				if err := tmux.ClearStatusOptionsWithExecutor(session, w.tmuxExecutor); err != nil {
+					w.logger.Debug("Failed to clear tmux status options", "session", session, "error", err)
+				}
+			}
+			w.logger.Info("Status state writer stopped")
+			return
+		case <-ticker.C():
+			if err := w.WriteOnce(ctx); err != nil {
+				w.logger.Warn("Failed to write status state", "error", err)
+			}
+		}
+	}
+}
+
+// WriteOnce はIssueの状態を取得して状態ファイルに書き出す
+func (w *StatusStateWriter) WriteOnce(ctx context.Context) error {
+	// スロットリング中はIssueを取得できない（解除まで待機する）ため、前回の状態ファイルに待機中であることだけを反映する
+	if throttle := w.throttleStatus(); throttle != nil {
+		return w.writeThrottle(throttle)
+	}
+
+	issues, err := w.client.ListIssuesByLabels(ctx, w.owner, w.repo, StatusLabels)
+	if err != nil {
+		// GitHubに接続できない間も、不健全な状態は前回の状態ファイルに反映する
+		if degradation := w.degradation.Status(); degradation != nil {
+			if writeErr := w.writeDegradation(degradation); writeErr != nil {
+				w.logger.Warn("Failed to write degraded status", "error", writeErr)
+			}
+		}
+		return fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	state := &StatusState{
+		UpdatedAt:        w.clock.Now(),
+		Owner:            w.owner,
+		Repo:             w.repo,
+		Issues:           make(map[string][]StatusStateIssue),
+		ResourcePressure: w.guard.Pressure(),
+		BranchProtection: w.protection,
+		Explanations:     w.explainer.Explanations(),
+		MergeQueue:       w.mergeQueue.Entries(),
+		Backfill:         w.backfill.Status(),
+		Retries:          w.retries.Status(),
+		Degradation:      w.degradation.Status(),
+		FaultInjection:   w.faultInjector.Stats(),
+	}
+	for _, label := range StatusLabels {
+		for _, issue := range issues {
+			if issue == nil || issue.Number == nil || !hasLabel(issue, label) {
+				continue
+			}
+			state.Issues[label] = append(state.Issues[label], StatusStateIssue{
+				Number: *issue.Number,
+				Title:  safeString(issue.Title),
+			})
+		}
+	}
+
+	if err := WriteStatusState(w.path, state); err != nil {
+		return err
+	}
+	if w.badgePath == "" && len(w.statusLineSessions) == 0 {
+		return nil
+	}
+	waiting, err := w.listWaitingIssues(ctx, issues)
+	if err != nil {
+		return err
+	}
+	retries := openRetries(state.Retries, waiting)
+	health := EvaluatePipelineHealth(retries, oldestWait(waiting, waitingLabels(w.config), state.MergeQueue, state.UpdatedAt), w.config.Badge)
+	if state.Degradation != nil {
+		health = PipelineHealth{Color: HealthRed, Message: "degraded"}
+	}
+	w.publishStatusLine(waiting, retries, health)
+	if w.badgePath == "" {
+		return nil
+	}
+	return WriteBadge(w.badgePath, w.config.Badge.Label, health)
+}
+
+// listWaitingIssues は取得済みのIssueに、ワークフローのトリガーラベルのうちStatusLabelsにないラベルのIssueを加えて返す
+func (w *StatusStateWriter) listWaitingIssues(ctx context.Context, issues []*github.Issue) ([]*github.Issue, error) {
+	listed := make(map[string]bool, len(StatusLabels))
+	for _, label := range StatusLabels {
+		listed[label] = true
+	}
+	var extra []string
+	for _, label := range waitingLabels(w.config) {
+		if !listed[label] {
+			extra = append(extra, label)
+		}
+	}
+	if len(extra) == 0 {
+		return issues, nil
+	}
+	more, err := w.client.ListIssuesByLabels(ctx, w.owner, w.repo, extra)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waiting issues: %w", err)
+	}
+	merged := append([]*github.Issue{}, issues...)
+	seen := make(map[int]bool, len(issues))
+	for _, issue := range issues {
+		if issue != nil && issue.Number != nil {
+			seen[*issue.Number] = true
+		}
+	}
+	for _, issue := range more {
+		if issue != nil && issue.Number != nil && !seen[*issue.Number] {
+			merged = append(merged, issue)
+		}
+	}
+	return merged, nil
+}
+
+// throttleStatus はクライアントがスロットリング中であればその状態を返す
+func (w *StatusStateWriter) throttleStatus() *github.ThrottleStatus {
+	provider, ok := w.client.(github.ThrottleStatusProvider)
+	if !ok {
+		return nil
+	}
+	status := provider.ThrottleStatus()
+	if !status.Throttled {
+		return nil
+	}
+	return &status
+}
+
+// writeThrottle は前回の状態ファイルにスロットリングで待機中であることを反映する（状態ファイルがない場合は新しく作成する）
+func (w *StatusStateWriter) writeThrottle(throttle *github.ThrottleStatus) error {
+	state, err := ReadStatusState(w.path)
+	if err != nil {
+		state = &StatusState{Owner: w.owner, Repo: w.repo, Issues: make(map[string][]StatusStateIssue)}
+	}
+	state.Throttle = throttle
+	return WriteStatusState(w.path, state)
+}
+
+// writeDegradation は前回の状態ファイルとステータスバッジに不健全な状態を反映する（状態ファイルがない場合は新しく作成する）
+// Issueの集計は取得できないため、tmuxのユーザーオプションは更新しない
+func (w *StatusStateWriter) writeDegradation(degradation *DegradationStatus) error {
+	state, err := ReadStatusState(w.path)
+	if err != nil {
+		state = &StatusState{Owner: w.owner, Repo: w.repo, Issues: make(map[string][]StatusStateIssue)}
+	}
+	state.Degradation = degradation
+	if err := WriteStatusState(w.path, state); err != nil {
+		return err
+	}
+	if w.badgePath == "" {
+		return nil
+	}
+	return WriteBadge(w.badgePath, w.config.Badge.Label, PipelineHealth{Color: HealthRed, Message: "degraded"})
+}
+
+// publishStatusLine はIssueの集計をtmuxセッションのユーザーオプションに設定する
+// シャードのセッションはIssueが振り分けられるまで作成されないため、設定に失敗しても警告しない
+func (w *StatusStateWriter) publishStatusLine(issues []*github.Issue, retries []RetryStatus, health PipelineHealth) {
+	if len(w.statusLineSessions) == 0 {
+		return
+	}
+	opts := tmux.StatusOptions{Health: health.Message}
+	phases := activeProgressPhases()
+	waiting := waitingLabels(w.config)
+	for _, issue := range issues {
+		if issue == nil {
+			continue
+		}
+		for _, p := range phases {
+			if hasLabel(issue, p.label) {
+				opts.Active++
+				break
+			}
+		}
+		for _, label := range waiting {
+			if hasLabel(issue, label) {
+				opts.Waiting++
+				break
+			}
+		}
+	}
+	for _, retry := range retries {
+		if retry.Severity == RetrySeverityFailing {
+			opts.Failing++
+		}
+	}
+
+	for _, session := range w.statusLineSessions {
+		if err := tmux.PublishStatusOptionsWithExecutor(session, opts, w.tmuxExecutor); err != nil {
+			w.logger.Debug("Failed to publish tmux status options", "session", session, "error", err)
+		}
+	}
+}
+
+// WriteStatusState は状態ファイルを書き出す
+func WriteStatusState(path string, state *StatusState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode status state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	// 読み込み中のosoba statusが書きかけのファイルを読まないよう、一時ファイルから置き換える
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write status state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace status state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package watcher
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/faultinject"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusStateWriter_WriteOnce(t *testing.T) {
+	client := new(MockGitHubClient)
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", StatusLabels).Return([]*gh.Issue{
+		{Number: intPtr(1), Title: stringPtr("計画中のIssue"), Labels: []*gh.Label{{Name: stringPtr("status:planning")}}},
+		{Number: intPtr(2), Title: stringPtr("実装待ちのIssue"), Labels: []*gh.Label{{Name: stringPtr("status:ready")}}},
+		{Number: intPtr(3), Title: stringPtr("ラベルなし")},
+	}, nil).Once()
+
+	path := filepath.Join(t.TempDir(), "run", "owner-repo.state.json")
+	writer, err := NewStatusStateWriter(client, "owner", "repo", path, config.NewConfig(), NewMockLogger())
+	require.NoError(t, err)
+	updatedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	writer.clock = clock.NewFake(updatedAt)
+
+	require.NoError(t, writer.WriteOnce(context.Background()))
+
+	state, err := ReadStatusState(path)
+	require.NoError(t, err)
+	assert.True(t, updatedAt.Equal(state.UpdatedAt))
+	assert.Equal(t, "owner", state.Owner)
+	assert.Equal(t, "repo", state.Repo)
+	assert.Equal(t, map[string][]StatusStateIssue{
+		"status:planning": {{Number: 1, Title: "計画中のIssue"}},
+		"status:ready":    {{Number: 2, Title: "実装待ちのIssue"}},
+	}, state.Issues)
+	client.AssertExpectations(t)
+}
+
+func TestStatusStateWriter_WriteOnce_FaultInjection(t *testing.T) {
+	client := new(MockGitHubClient)
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", StatusLabels).Return([]*gh.Issue{}, nil).Once()
+
+	injector, err := faultinject.Parse("fail=1,targets=gh")
+	require.NoError(t, err)
+	require.Error(t, injector.Inject(faultinject.TargetGH, []string{"issue", "list"}))
+
+	path := filepath.Join(t.TempDir(), "owner-repo.state.json")
+	writer, err := NewStatusStateWriter(client, "owner", "repo", path, config.NewConfig(), NewMockLogger())
+	require.NoError(t, err)
+	writer.SetFaultInjector(injector)
+	require.NoError(t, writer.WriteOnce(context.Background()))
+
+	state, err := ReadStatusState(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]faultinject.Stats{faultinject.TargetGH: {Calls: 1, Failures: 1}}, state.FaultInjection)
+}
+
+// throttledGitHubClient はスロットリング状態を返すGitHubクライアント
+type throttledGitHubClient struct {
+	*MockGitHubClient
+	status gh.ThrottleStatus
+}
+
+func (c *throttledGitHubClient) ThrottleStatus() gh.ThrottleStatus {
+	return c.status
+}
+
+func TestStatusStateWriter_WriteOnce_Throttled(t *testing.T) {
+	mockClient := new(MockGitHubClient)
+	client := &throttledGitHubClient{MockGitHubClient: mockClient}
+	mockClient.On("ListIssuesByLabels", mock.Anything, "owner", "repo", StatusLabels).Return([]*gh.Issue{
+		{Number: intPtr(2), Title: stringPtr("実装待ちのIssue"), Labels: []*gh.Label{{Name: stringPtr("status:ready")}}},
+	}, nil).Once()
+
+	path := filepath.Join(t.TempDir(), "owner-repo.state.json")
+	writer, err := NewStatusStateWriter(client, "owner", "repo", path, config.NewConfig(), NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteOnce(context.Background()))
+
+	// スロットリング中はIssueを取得せず、前回の集計に待機中であることを加える
+	until := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	client.status = gh.ThrottleStatus{Throttled: true, Until: until, Reason: "secondary rate limit"}
+	require.NoError(t, writer.WriteOnce(context.Background()))
+
+	state, err := ReadStatusState(path)
+	require.NoError(t, err)
+	require.NotNil(t, state.Throttle)
+	assert.True(t, until.Equal(state.Throttle.Until))
+	assert.Equal(t, "secondary rate limit", state.Throttle.Reason)
+	assert.Equal(t, []StatusStateIssue{{Number: 2, Title: "実装待ちのIssue"}}, state.Issues["status:ready"])
+
+	// 解除後は通常どおり書き出し、待機中の状態を消す
+	client.status = gh.ThrottleStatus{}
+	mockClient.On("ListIssuesByLabels", mock.Anything, "owner", "repo", StatusLabels).Return([]*gh.Issue{}, nil).Once()
+	require.NoError(t, writer.WriteOnce(context.Background()))
+	state, err = ReadStatusState(path)
+	require.NoError(t, err)
+	assert.Nil(t, state.Throttle)
+	mockClient.AssertExpectations(t)
+}
+
+func TestStatusStateWriter_PublishesStatusLine(t *testing.T) {
+	client := new(MockGitHubClient)
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", StatusLabels).Return([]*gh.Issue{
+		{Number: intPtr(1), Labels: []*gh.Label{{Name: stringPtr("status:planning")}}},
+		{Number: intPtr(2), Labels: []*gh.Label{{Name: stringPtr("status:implementing")}}},
+		{Number: intPtr(3), Labels: []*gh.Label{{Name: stringPtr("status:ready")}}},
+		{Number: intPtr(4), Labels: []*gh.Label{{Name: stringPtr("status:manual")}}},
+	}, nil)
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:requires-changes"}).Return([]*gh.Issue{}, nil)
+
+	writer, err := NewStatusStateWriter(client, "owner", "repo", filepath.Join(t.TempDir(), "state.json"), config.NewConfig(), NewMockLogger())
+	require.NoError(t, err)
+	writer.clock = clock.NewFake(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	executor := mocks.NewMockTmuxCommandExecutor()
+	writer.tmuxExecutor = executor
+	writer.SetStatusLineSessions([]string{"osoba-repo", "osoba-repo-frontend"})
+
+	for _, opt := range [][2]string{{"@osoba_active", "2"}, {"@osoba_waiting", "1"}, {"@osoba_failing", "0"}, {"@osoba_health", "healthy"}} {
+		executor.On("Execute", "tmux", []string{"set-option", "-q", "-t", "osoba-repo", opt[0], opt[1]}).Return("", nil).Once()
+	}
+	// シャードのセッションがまだない場合も状態ファイルの書き出しは続ける
+	executor.On("Execute", "tmux", []string{"set-option", "-q", "-t", "osoba-repo-frontend", "@osoba_active", "2"}).Return("", assert.AnError).Once()
+
+	require.NoError(t, writer.WriteOnce(context.Background()))
+	executor.AssertExpectations(t)
+}
@@ -0,0 +1,242 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/tmux"
+)
+
+// stuckState はIssueごとの停止の検出の追跡状態
+type stuckState struct {
+	label      string    // 実行中ラベル
+	startedAt  time.Time // フェーズの実行を最初に検出した時刻
+	output     string    // 前回取得したペインの末尾の出力
+	lastOutput time.Time // ペインの出力が最後に変化した時刻
+	notified   bool      // 停止を通知済みか
+}
+
+// StuckDetector は実行中フェーズのハートビート（.osoba/heartbeat）とペインの出力を監視し、
+// どちらも更新されない状態が続いたIssueにラベルを付与して人の確認を求める
+// 出力が止まっていてもハートビートが更新されていれば考え中とみなし、ペインがなくなった場合はセッションの終了とみなす
+type StuckDetector struct {
+	client          github.GitHubClient
+	tmuxManager     tmux.Manager
+	worktreeManager git.WorktreeManager
+	owner           string
+	repo            string
+	sessionName     string
+	config          *config.Config
+	logger          logger.Logger
+	// panes はフェーズのペインを配置したウィンドウ（端末サイズによるフォールバックを含む）
+	panes *tmux.PaneRegistry
+
+	mu     sync.Mutex
+	states map[int]*stuckState
+	clock  clock.Clock
+}
+
+// NewStuckDetector は新しいStuckDetectorを作成する
+func NewStuckDetector(
+	client github.GitHubClient,
+	tmuxManager tmux.Manager,
+	worktreeManager git.WorktreeManager,
+	owner, repo, sessionName string,
+	cfg *config.Config,
+	logger logger.Logger,
+) (*StuckDetector, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if tmuxManager == nil {
+		return nil, errors.New("tmux manager is required")
+	}
+	if worktreeManager == nil {
+		return nil, errors.New("worktree manager is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+
+	return &StuckDetector{
+		client:          client,
+		tmuxManager:     tmuxManager,
+		worktreeManager: worktreeManager,
+		owner:           owner,
+		repo:            repo,
+		sessionName:     sessionName,
+		config:          cfg,
+		logger:          logger,
+		states:          make(map[int]*stuckState),
+		clock:           clock.New(),
+	}, nil
+}
+
+// SetPaneRegistry はフェーズのペインを配置したウィンドウを記録するレジストリを設定する
+func (d *StuckDetector) SetPaneRegistry(registry *tmux.PaneRegistry) {
+	d.panes = registry
+}
+
+// Start は停止の検出を開始する
+func (d *StuckDetector) Start(ctx context.Context) {
+	interval := d.config.Heartbeat.Interval
+	d.logger.Info("Starting stuck detector", "interval", interval, "stuck_after", d.config.Heartbeat.StuckAfter)
+
+	ticker := d.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Stuck detector stopped")
+			return
+		case <-ticker.C():
+			if err := d.CheckOnce(ctx); err != nil {
+				d.logger.Warn("Failed to check stuck agents", "error", err)
+			}
+		}
+	}
+}
+
+// CheckOnce は実行中フェーズのIssueすべてについてハートビートとペインの出力を確認する
+func (d *StuckDetector) CheckOnce(ctx context.Context) error {
+	phases := activeProgressPhases()
+	labels := make([]string, 0, len(phases))
+	for _, p := range phases {
+		labels = append(labels, p.label)
+	}
+
+	issues, err := d.client.ListIssuesByLabels(ctx, d.owner, d.repo, labels)
+	if err != nil {
+		return fmt.Errorf("failed to list in-progress issues: %w", err)
+	}
+
+	active := make(map[int]bool)
+	for _, issue := range issues {
+		if issue == nil || issue.Number == nil {
+			continue
+		}
+		phase, ok := findProgressPhase(issue)
+		if !ok {
+			continue
+		}
+		active[*issue.Number] = true
+
+		if err := d.checkIssue(ctx, issue, phase); err != nil {
+			d.logger.Warn("Failed to notify stuck agent",
+				"issue_number", *issue.Number,
+				"phase", phase.label,
+				"error", err)
+		}
+	}
+
+	// 実行中でなくなったIssueの追跡状態を破棄
+	d.mu.Lock()
+	for number := range d.states {
+		if !active[number] {
+			delete(d.states, number)
+		}
+	}
+	d.mu.Unlock()
+
+	return nil
+}
+
+// checkIssue は1件のIssueの最後のアクティビティを確認し、停止している場合は通知する
+func (d *StuckDetector) checkIssue(ctx context.Context, issue *github.Issue, phase progressPhase) error {
+	issueNumber := *issue.Number
+	now := d.clock.Now()
+
+	d.mu.Lock()
+	state, ok := d.states[issueNumber]
+	if !ok || state.label != phase.label {
+		state = &stuckState{label: phase.label, startedAt: now, lastOutput: now}
+		d.states[issueNumber] = state
+	}
+	d.mu.Unlock()
+
+	sessionName, windowName, pane := findPhasePane(d.tmuxManager, d.config, d.panes, d.sessionName, issueNumber, phase)
+	if pane != nil {
+		output, err := d.tmuxManager.CapturePane(sessionName, windowName, pane.Index, reaperActivityLines)
+		if err == nil && output != state.output {
+			state.output = output
+			state.lastOutput = now
+		}
+	}
+	heartbeat, hasHeartbeat := git.ReadHeartbeat(d.worktreeManager.GetWorktreePathForIssue(issueNumber))
+
+	lastActivity := state.lastOutput
+	if hasHeartbeat && heartbeat.After(lastActivity) {
+		lastActivity = heartbeat
+	}
+
+	var reason string
+	switch {
+	case pane == nil && now.Sub(state.startedAt) >= d.config.Heartbeat.Interval:
+		// 起動直後はペインの作成を待つ
+		reason = "フェーズのペインが見つかりません（セッションが終了した可能性があります）"
+	case pane != nil && now.Sub(lastActivity) >= d.config.Heartbeat.StuckAfter:
+		reason = "ペインは残っていますが、ハートビートも出力も更新されていません"
+	default:
+		// アクティビティが再開した場合は、再び停止したときに通知する
+		state.notified = false
+		return nil
+	}
+	if state.notified {
+		return nil
+	}
+	if hasLabel(issue, d.config.Heartbeat.Label) {
+		state.notified = true
+		return nil
+	}
+
+	idle := now.Sub(lastActivity).Truncate(time.Second)
+	d.logger.Warn("Agent appears to be stuck",
+		"issue_number", issueNumber,
+		"phase", phase.label,
+		"idle", idle,
+		"pane_found", pane != nil,
+		"heartbeat_found", hasHeartbeat)
+
+	// 停止の通知は状態を持たないため、GitHubに接続できない間は保留できる
+	deferred, canDefer := d.client.(github.DeferredMutator)
+	addLabel, comment := d.client.AddLabel, d.client.CreateIssueComment
+	if canDefer {
+		addLabel, comment = deferred.AddInformationalLabel, deferred.CreateIssueCommentDeferred
+	}
+	if err := addLabel(ctx, d.owner, d.repo, issueNumber, d.config.Heartbeat.Label); err != nil {
+		return fmt.Errorf("failed to add %s label: %w", d.config.Heartbeat.Label, err)
+	}
+	state.notified = true
+
+	lastHeartbeat := "なし"
+	if hasHeartbeat {
+		lastHeartbeat = heartbeat.In(d.config.Location()).Format(time.RFC3339)
+	}
+	body := d.config.RenderComment(config.CommentAgentStuck, map[string]string{
+		"phase":          phase.label,
+		"idle":           idle.String(),
+		"label":          d.config.Heartbeat.Label,
+		"last-heartbeat": lastHeartbeat,
+		"last-output":    state.lastOutput.In(d.config.Location()).Format(time.RFC3339),
+		"reason":         reason,
+	})
+	if err := comment(ctx, d.owner, d.repo, issueNumber, body); err != nil {
+		return fmt.Errorf("failed to post stuck agent comment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStuckDetector_CheckOnce(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	implementing := &gh.Issue{
+		Number: intPtr(10),
+		Labels: []*gh.Label{{Name: stringPtr("status:implementing")}},
+	}
+	pane := &tmux.PaneInfo{Index: 1, Title: "Implementation"}
+
+	tests := []struct {
+		name       string
+		issue      *gh.Issue
+		pane       *tmux.PaneInfo
+		output     []string      // 各確認でのペインの末尾出力
+		heartbeat  time.Duration // 開始からハートビートが更新されるまでの時間（0の場合はファイルなし）
+		wantNotify bool
+		wantReason string
+	}{
+		{
+			name:       "ハートビートも出力も更新されない - ラベルを付与してコメント",
+			issue:      implementing,
+			pane:       pane,
+			output:     []string{"thinking", "thinking"},
+			wantNotify: true,
+			wantReason: "ハートビートも出力も更新されていません",
+		},
+		{
+			name:      "出力が止まっていてもハートビートが更新されている",
+			issue:     implementing,
+			pane:      pane,
+			output:    []string{"thinking", "thinking"},
+			heartbeat: 30 * time.Minute,
+		},
+		{
+			name:   "出力が変化している",
+			issue:  implementing,
+			pane:   pane,
+			output: []string{"thinking", "editing main.go"},
+		},
+		{
+			name:       "ペインが見つからない - セッションの終了とみなす",
+			issue:      implementing,
+			wantNotify: true,
+			wantReason: "セッションが終了した可能性があります",
+		},
+		{
+			name: "既にラベルが付与されている",
+			issue: &gh.Issue{
+				Number: intPtr(10),
+				Labels: []*gh.Label{{Name: stringPtr("status:implementing")}, {Name: stringPtr("status:needs-human")}},
+			},
+			pane:   pane,
+			output: []string{"thinking", "thinking"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(MockGitHubClient)
+			tmuxManager := mocks.NewMockTmuxManager()
+			worktreeManager := mocks.NewMockGitWorktreeManager()
+			worktreePath := t.TempDir()
+			if tt.heartbeat > 0 {
+				path := git.HeartbeatPath(worktreePath)
+				touchedAt := start.Add(tt.heartbeat)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, nil, 0o644))
+				require.NoError(t, os.Chtimes(path, touchedAt, touchedAt))
+			}
+
+			client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", progressLabels).Return([]*gh.Issue{tt.issue}, nil)
+			worktreeManager.On("GetWorktreePathForIssue", 10).Return(worktreePath)
+			if tt.pane != nil {
+				tmuxManager.On("GetPaneByTitle", "osoba-repo", "issue-10", "Implementation").Return(tt.pane, nil)
+				for _, output := range tt.output {
+					tmuxManager.On("CapturePane", "osoba-repo", "issue-10", 1, reaperActivityLines).Return(output, nil).Once()
+				}
+			} else {
+				tmuxManager.On("GetPaneByTitle", "osoba-repo", "issue-10", "Implementation").Return(nil, assert.AnError)
+			}
+			if tt.wantNotify {
+				client.On("AddLabel", mock.Anything, "owner", "repo", 10, "status:needs-human").Return(nil).Once()
+				client.On("CreateIssueComment", mock.Anything, "owner", "repo", 10, mock.MatchedBy(func(body string) bool {
+					return assert.Contains(t, body, "status:implementing") && assert.Contains(t, body, tt.wantReason)
+				})).Return(nil).Once()
+			}
+
+			cfg := config.NewConfig()
+			cfg.Heartbeat.Enabled = true
+			detector, err := NewStuckDetector(client, tmuxManager, worktreeManager, "owner", "repo", "osoba-repo", cfg, NewMockLogger())
+			require.NoError(t, err)
+			fakeClock := clock.NewFake(start)
+			detector.clock = fakeClock
+
+			require.NoError(t, detector.CheckOnce(context.Background()))
+			fakeClock.Advance(31 * time.Minute)
+			require.NoError(t, detector.CheckOnce(context.Background()))
+
+			client.AssertExpectations(t)
+			if !tt.wantNotify {
+				client.AssertNotCalled(t, "AddLabel", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestStuckDetector_NotifiesOnce(t *testing.T) {
+	client := new(MockGitHubClient)
+	tmuxManager := mocks.NewMockTmuxManager()
+	worktreeManager := mocks.NewMockGitWorktreeManager()
+	issue := &gh.Issue{
+		Number: intPtr(10),
+		Labels: []*gh.Label{{Name: stringPtr("status:reviewing")}},
+	}
+
+	client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", progressLabels).Return([]*gh.Issue{issue}, nil)
+	worktreeManager.On("GetWorktreePathForIssue", 10).Return(t.TempDir())
+	tmuxManager.On("GetPaneByTitle", "osoba-repo", "issue-10", "Review").Return(&tmux.PaneInfo{Index: 0, Title: "Review"}, nil)
+	tmuxManager.On("CapturePane", "osoba-repo", "issue-10", 0, reaperActivityLines).Return("waiting", nil)
+	client.On("AddLabel", mock.Anything, "owner", "repo", 10, "status:needs-human").Return(nil).Once()
+	client.On("CreateIssueComment", mock.Anything, "owner", "repo", 10, mock.Anything).Return(nil).Once()
+
+	cfg := config.NewConfig()
+	cfg.Heartbeat.Enabled = true
+	detector, err := NewStuckDetector(client, tmuxManager, worktreeManager, "owner", "repo", "osoba-repo", cfg, NewMockLogger())
+	require.NoError(t, err)
+	fakeClock := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	detector.clock = fakeClock
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, detector.CheckOnce(context.Background()))
+		fakeClock.Advance(31 * time.Minute)
+	}
+
+	// ラベルは外されていなくても、通知は停止ごとに1回のみ
+	client.AssertNumberOfCalls(t, "AddLabel", 1)
+	client.AssertNumberOfCalls(t, "CreateIssueComment", 1)
+}
@@ -0,0 +1,384 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+const (
+	// subIssuesCommentMarker はサブIssueの一覧コメントを識別するためのマーカー
+	subIssuesCommentMarker = "<!-- osoba:sub-issues -->"
+	// subIssuesProgressMarker は展開の途中で作成済みのサブIssueを記録するコメントのマーカー
+	subIssuesProgressMarker = "<!-- osoba:sub-issues-progress -->"
+	// blockedLabel はサブIssueの完了待ちの親Issueに付与するラベル
+	blockedLabel = "status:blocked"
+	// osobaCommentPrefix はosobaが投稿するコメントの共通プレフィックス
+	osobaCommentPrefix = "<!-- osoba:"
+)
+
+var (
+	// subTaskHeadings はサブタスクのチェックリストを含む見出し（小文字で比較）
+	subTaskHeadings = []string{"サブタスク", "sub-tasks", "subtasks"}
+
+	uncheckedTaskPattern  = regexp.MustCompile(`^\s*[-*]\s+\[ \]\s+(.+?)\s*$`)
+	issueReferencePattern = regexp.MustCompile(`^#(\d+)\b`)
+	subIssueLinePattern   = regexp.MustCompile(`^- \[( |x)\] #(\d+)(.*)$`)
+)
+
+// subIssue はサブIssueの一覧コメントの1行
+type subIssue struct {
+	number int
+	title  string
+	closed bool
+}
+
+// SubIssueExpander は計画のサブタスクをサブIssueに展開し、完了まで親Issueをブロックする
+type SubIssueExpander struct {
+	client github.GitHubClient
+	owner  string
+	repo   string
+	config *config.Config
+	logger logger.Logger
+	// explainer はサブIssueの完了待ちの親Issueの記録先（未設定の場合はnil）
+	explainer *SkipExplainer
+}
+
+// NewSubIssueExpander は新しいSubIssueExpanderを作成する
+func NewSubIssueExpander(client github.GitHubClient, owner, repo string, cfg *config.Config, logger logger.Logger) (*SubIssueExpander, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if _, ok := client.(github.IssueCommentEditor); !ok {
+		return nil, errors.New("github client does not support editing issue comments")
+	}
+	if _, ok := client.(github.IssueCreator); !ok {
+		return nil, errors.New("github client does not support creating issues")
+	}
+
+	return &SubIssueExpander{
+		client: client,
+		owner:  owner,
+		repo:   repo,
+		config: cfg,
+		logger: logger,
+	}, nil
+}
+
+// Start はブロック中の親Issueの監視を開始する
+func (e *SubIssueExpander) Start(ctx context.Context) {
+	interval := e.config.GitHub.PollInterval
+	e.logger.Info("Starting sub-issue tracker", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.logger.Info("Sub-issue tracker stopped")
+			return
+		case <-ticker.C:
+			if err := e.CheckBlockedOnce(ctx); err != nil {
+				e.logger.Warn("Failed to check blocked issues", "error", err)
+			}
+		}
+	}
+}
+
+// ExpandIfPlanned は計画済みIssueのサブタスクをサブIssueとして作成し、親Issueをブロックする
+// 展開した場合（サブIssueの作成が途中で失敗した場合を含む）はtrueを返し、呼び出し側は実装フェーズを開始しない
+// 作成が途中で失敗した場合は作成済みのサブIssueを記録し、次回のポーリングで残りのサブIssueから作成を再開する
+func (e *SubIssueExpander) ExpandIfPlanned(ctx context.Context, issue *github.Issue) (bool, error) {
+	if issue == nil || issue.Number == nil || !hasLabel(issue, e.config.GitHub.Labels.Ready) {
+		return false, nil
+	}
+	parent := *issue.Number
+
+	comments, err := e.client.(github.IssueCommentEditor).ListIssueComments(ctx, e.owner, e.repo, parent)
+	if err != nil {
+		return false, fmt.Errorf("failed to list comments: %w", err)
+	}
+	var progress *github.IssueComment
+	for _, c := range comments {
+		if c.Body == nil {
+			continue
+		}
+		if strings.HasPrefix(*c.Body, subIssuesCommentMarker) {
+			// 展開済み（サブIssueがすべてクローズされて再開した場合を含む）
+			return false, nil
+		}
+		if c.ID != nil && strings.HasPrefix(*c.Body, subIssuesProgressMarker) {
+			progress = c
+		}
+	}
+
+	tasks := latestSubTasks(comments)
+	if len(tasks) == 0 {
+		return false, nil
+	}
+
+	// 前回のポーリングで作成済みのサブIssue（タイトルで対応付ける）
+	created := make(map[string]int)
+	if progress != nil {
+		for _, c := range parseSubIssuesComment(*progress.Body) {
+			created[c.title] = c.number
+		}
+	}
+
+	creator := e.client.(github.IssueCreator)
+	var children []subIssue
+	for _, task := range tasks {
+		if m := issueReferencePattern.FindStringSubmatch(task); m != nil {
+			number, _ := strconv.Atoi(m[1])
+			children = append(children, subIssue{number: number, title: strings.TrimSpace(strings.TrimPrefix(task, m[0]))})
+			continue
+		}
+		if number, ok := created[task]; ok {
+			children = append(children, subIssue{number: number, title: task})
+			continue
+		}
+
+		body := e.config.RenderComment(config.CommentSubIssueBody, map[string]string{
+			"parent-number": strconv.Itoa(parent),
+		})
+		number, err := creator.CreateIssue(ctx, e.owner, e.repo, task, body, []string{e.config.GitHub.SubIssues.Label})
+		if err != nil {
+			// 親Issueはブロックせず、作成済みのサブIssueを記録して次回のポーリングで残りから再開する
+			createErr := fmt.Errorf("failed to create sub-issue %q: %w", task, err)
+			if recordErr := e.recordProgress(ctx, parent, progress, children); recordErr != nil {
+				return true, errors.Join(createErr, recordErr)
+			}
+			return true, createErr
+		}
+		children = append(children, subIssue{number: number, title: task})
+	}
+
+	if err := e.client.CreateIssueComment(ctx, e.owner, e.repo, parent, buildSubIssuesComment(e.config, parent, children)); err != nil {
+		return true, fmt.Errorf("failed to post sub-issues comment: %w", err)
+	}
+	if err := e.client.TransitionLabels(ctx, e.owner, e.repo, parent, e.config.GitHub.Labels.Ready, blockedLabel); err != nil {
+		return true, fmt.Errorf("failed to block parent issue: %w", err)
+	}
+
+	e.logger.Info("Expanded plan into sub-issues",
+		"issue_number", parent,
+		"sub_issues", len(children))
+	return true, nil
+}
+
+// recordProgress は作成済みのサブIssueを親Issueの進捗コメントに記録する（既にある場合は更新する）
+func (e *SubIssueExpander) recordProgress(ctx context.Context, parent int, progress *github.IssueComment, children []subIssue) error {
+	var body strings.Builder
+	body.WriteString(subIssuesProgressMarker + "\n")
+	fmt.Fprintf(&body, "サブIssueの作成が途中で失敗しました。次回の確認で残りのサブIssueを作成します。\n\n")
+	for _, c := range children {
+		fmt.Fprintf(&body, "- [ ] #%d %s\n", c.number, c.title)
+	}
+
+	if progress != nil {
+		if err := e.client.(github.IssueCommentEditor).UpdateIssueComment(ctx, e.owner, e.repo, *progress.ID, body.String()); err != nil {
+			return fmt.Errorf("failed to update sub-issues progress comment: %w", err)
+		}
+		return nil
+	}
+	if err := e.client.CreateIssueComment(ctx, e.owner, e.repo, parent, body.String()); err != nil {
+		return fmt.Errorf("failed to post sub-issues progress comment: %w", err)
+	}
+	return nil
+}
+
+// CheckBlockedOnce はブロック中の親IssueのサブIssue状況を更新し、すべてクローズされていれば再開する
+func (e *SubIssueExpander) CheckBlockedOnce(ctx context.Context) error {
+	issues, err := e.client.ListIssuesByLabels(ctx, e.owner, e.repo, []string{blockedLabel})
+	if err != nil {
+		return fmt.Errorf("failed to list blocked issues: %w", err)
+	}
+
+	dependencies := make(map[int]string)
+	for _, issue := range issues {
+		if issue == nil || issue.Number == nil {
+			continue
+		}
+		open, err := e.checkParent(ctx, *issue.Number)
+		if err != nil {
+			e.logger.Warn("Failed to check sub-issues",
+				"issue_number", *issue.Number,
+				"error", err)
+			continue
+		}
+		if len(open) > 0 {
+			dependencies[*issue.Number] = "waiting for sub-issues: " + formatIssueNumbers(open)
+		}
+	}
+	e.explainer.SetDependencies(dependencies)
+	return nil
+}
+
+// SetSkipExplainer はサブIssueの完了待ちの親Issueの記録先を設定する
+func (e *SubIssueExpander) SetSkipExplainer(explainer *SkipExplainer) {
+	e.explainer = explainer
+}
+
+// checkParent は1件の親IssueについてサブIssueの状態を確認し、クローズされていないサブIssueを返す
+func (e *SubIssueExpander) checkParent(ctx context.Context, parent int) ([]int, error) {
+	editor := e.client.(github.IssueCommentEditor)
+	comments, err := editor.ListIssueComments(ctx, e.owner, e.repo, parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	var tracking *github.IssueComment
+	for _, c := range comments {
+		if c.ID != nil && c.Body != nil && strings.HasPrefix(*c.Body, subIssuesCommentMarker) {
+			tracking = c
+		}
+	}
+	if tracking == nil {
+		// osoba以外がブロックしたIssueは対象外
+		return nil, nil
+	}
+
+	children := parseSubIssuesComment(*tracking.Body)
+	var open []int
+	for i := range children {
+		if children[i].closed {
+			continue
+		}
+		state, err := e.client.(github.IssueCreator).GetIssueState(ctx, e.owner, e.repo, children[i].number)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(state, "CLOSED") {
+			children[i].closed = true
+		} else {
+			open = append(open, children[i].number)
+		}
+	}
+
+	if body := buildSubIssuesComment(e.config, parent, children); body != *tracking.Body {
+		if err := editor.UpdateIssueComment(ctx, e.owner, e.repo, *tracking.ID, body); err != nil {
+			return nil, err
+		}
+	}
+	if len(open) > 0 {
+		return open, nil
+	}
+
+	if err := e.client.TransitionLabels(ctx, e.owner, e.repo, parent, blockedLabel, e.config.GitHub.Labels.Ready); err != nil {
+		return nil, fmt.Errorf("failed to unblock parent issue: %w", err)
+	}
+	e.logger.Info("All sub-issues closed, unblocked parent issue", "issue_number", parent)
+	return nil, nil
+}
+
+// latestSubTasks は最新の計画コメントからサブタスク見出し配下の未完了項目を取り出す
+func latestSubTasks(comments []*github.IssueComment) []string {
+	for i := len(comments) - 1; i >= 0; i-- {
+		c := comments[i]
+		if c.Body == nil || strings.HasPrefix(*c.Body, osobaCommentPrefix) {
+			continue
+		}
+		if tasks, ok := parseSubTasks(*c.Body); ok {
+			return tasks
+		}
+	}
+	return nil
+}
+
+// parseSubTasks はサブタスク見出しを含む場合に、その配下の未完了項目を返す
+func parseSubTasks(body string) ([]string, bool) {
+	var tasks []string
+	found, inSection := false, false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") && !issueReferencePattern.MatchString(trimmed) {
+			inSection = isSubTaskHeading(trimmed)
+			found = found || inSection
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		m := uncheckedTaskPattern.FindStringSubmatch(line)
+		if m == nil || (strings.HasPrefix(m[1], "[") && strings.HasSuffix(m[1], "]")) {
+			// テンプレートのプレースホルダーは対象外
+			continue
+		}
+		tasks = append(tasks, m[1])
+	}
+	return tasks, found
+}
+
+func isSubTaskHeading(heading string) bool {
+	lower := strings.ToLower(heading)
+	for _, h := range subTaskHeadings {
+		if strings.Contains(lower, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildSubIssuesComment はサブIssueの一覧コメントを生成する
+// 一覧の行はサブIssueの追跡に使うため、テンプレートに関わらず同じ形式で出力する
+func buildSubIssuesComment(cfg *config.Config, parent int, children []subIssue) string {
+	var list strings.Builder
+	for _, c := range children {
+		mark := " "
+		if c.closed {
+			mark = "x"
+		}
+		fmt.Fprintf(&list, "- [%s] #%d", mark, c.number)
+		if c.title != "" {
+			list.WriteString(" " + c.title)
+		}
+		list.WriteString("\n")
+	}
+
+	return subIssuesCommentMarker + "\n" + cfg.RenderComment(config.CommentSubIssues, map[string]string{
+		"issue-number":  strconv.Itoa(parent),
+		"blocked-label": blockedLabel,
+		"sub-issues":    list.String(),
+	})
+}
+
+// parseSubIssuesComment はサブIssueの一覧コメントからサブIssueを取り出す
+func parseSubIssuesComment(body string) []subIssue {
+	var children []subIssue
+	for _, line := range strings.Split(body, "\n") {
+		m := subIssueLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		number, _ := strconv.Atoi(m[2])
+		children = append(children, subIssue{number: number, title: strings.TrimSpace(m[3]), closed: m[1] == "x"})
+	}
+	return children
+}
+
+// formatIssueNumbers はIssue番号を「#1, #2」の形式で返す
+func formatIssueNumbers(numbers []int) string {
+	refs := make([]string, 0, len(numbers))
+	for _, n := range numbers {
+		refs = append(refs, fmt.Sprintf("#%d", n))
+	}
+	return strings.Join(refs, ", ")
+}
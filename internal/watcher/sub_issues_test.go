@@ -0,0 +1,204 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIssueCreatorClient はIssue作成とコメント編集に対応したGitHubクライアントのモック
+type mockIssueCreatorClient struct {
+	mockCommentEditorClient
+}
+
+func (m *mockIssueCreatorClient) CreateIssue(ctx context.Context, owner, repo, title, body string, labels []string) (int, error) {
+	args := m.Called(ctx, owner, repo, title, body, labels)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockIssueCreatorClient) GetIssueState(ctx context.Context, owner, repo string, issueNumber int) (string, error) {
+	args := m.Called(ctx, owner, repo, issueNumber)
+	return args.String(0), args.Error(1)
+}
+
+const planWithSubTasks = `# 実行計画: 大きな機能
+
+## 実装ステップ
+1. 全体設計
+- [ ] これはサブタスクではない
+
+## サブタスク（任意: 1つのPRに収まらない場合のみ）
+- [ ] APIを追加する
+- [x] 調査する
+- [ ] #77 既存のIssue
+- [ ] [独立して実装・レビューできるタスク]
+
+## テスト計画
+- [ ] 受け入れテスト
+`
+
+func TestParseSubTasks(t *testing.T) {
+	tasks, found := parseSubTasks(planWithSubTasks)
+	assert.True(t, found)
+	assert.Equal(t, []string{"APIを追加する", "#77 既存のIssue"}, tasks)
+
+	_, found = parseSubTasks("# 実行計画\n- [ ] 受け入れ条件")
+	assert.False(t, found)
+}
+
+func TestSubIssueExpander_ExpandIfPlanned(t *testing.T) {
+	readyIssue := &gh.Issue{
+		Number: intPtr(50),
+		Labels: []*gh.Label{{Name: stringPtr("status:ready")}},
+	}
+
+	t.Run("サブタスクをサブIssueに展開して親をブロック", func(t *testing.T) {
+		client := new(mockIssueCreatorClient)
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 50).
+			Return([]*gh.IssueComment{{ID: gh.Int64(1), Body: gh.String(planWithSubTasks)}}, nil).Once()
+		client.On("CreateIssue", mock.Anything, "owner", "repo", "APIを追加する", "Part of #50", []string{"status:needs-plan"}).
+			Return(51, nil).Once()
+		client.On("CreateIssueComment", mock.Anything, "owner", "repo", 50, mock.MatchedBy(func(body string) bool {
+			return strings.HasPrefix(body, subIssuesCommentMarker) &&
+				strings.Contains(body, "- [ ] #51 APIを追加する") &&
+				strings.Contains(body, "- [ ] #77 既存のIssue")
+		})).Return(nil).Once()
+		client.On("TransitionLabels", mock.Anything, "owner", "repo", 50, "status:ready", "status:blocked").Return(nil).Once()
+
+		expander, err := NewSubIssueExpander(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		expanded, err := expander.ExpandIfPlanned(context.Background(), readyIssue)
+		require.NoError(t, err)
+		assert.True(t, expanded)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("展開済みの場合は何もしない", func(t *testing.T) {
+		client := new(mockIssueCreatorClient)
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 50).
+			Return([]*gh.IssueComment{
+				{ID: gh.Int64(1), Body: gh.String(planWithSubTasks)},
+				{ID: gh.Int64(2), Body: gh.String(subIssuesCommentMarker + "\n- [x] #51 APIを追加する\n")},
+			}, nil).Once()
+
+		expander, err := NewSubIssueExpander(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		expanded, err := expander.ExpandIfPlanned(context.Background(), readyIssue)
+		require.NoError(t, err)
+		assert.False(t, expanded)
+		client.AssertNotCalled(t, "CreateIssue", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("作成に失敗した場合は親をブロックせず、次回に残りから再開", func(t *testing.T) {
+		plan := "## サブタスク\n- [ ] 1つ目\n- [ ] 2つ目\n"
+		client := new(mockIssueCreatorClient)
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 50).
+			Return([]*gh.IssueComment{{ID: gh.Int64(1), Body: gh.String(plan)}}, nil).Once()
+		client.On("CreateIssue", mock.Anything, "owner", "repo", "1つ目", "Part of #50", []string{"status:needs-plan"}).Return(61, nil).Once()
+		client.On("CreateIssue", mock.Anything, "owner", "repo", "2つ目", "Part of #50", []string{"status:needs-plan"}).
+			Return(0, errors.New("was submitted too quickly")).Once()
+		var progress string
+		client.On("CreateIssueComment", mock.Anything, "owner", "repo", 50, mock.MatchedBy(func(body string) bool {
+			return strings.HasPrefix(body, subIssuesProgressMarker)
+		})).Run(func(args mock.Arguments) { progress = args.String(4) }).Return(nil).Once()
+
+		expander, err := NewSubIssueExpander(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+		require.NoError(t, err)
+
+		expanded, err := expander.ExpandIfPlanned(context.Background(), readyIssue)
+		assert.Error(t, err)
+		assert.True(t, expanded, "implementation must not start while sub-issues are missing")
+		assert.Contains(t, progress, "- [ ] #61 1つ目")
+		assert.NotContains(t, progress, "2つ目")
+		client.AssertNotCalled(t, "TransitionLabels", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		// 次回のポーリング: 作成済みのサブIssueは作り直さず、残りを作成してから親をブロックする
+		client.On("ListIssueComments", mock.Anything, "owner", "repo", 50).
+			Return([]*gh.IssueComment{
+				{ID: gh.Int64(1), Body: gh.String(plan)},
+				{ID: gh.Int64(2), Body: gh.String(progress)},
+			}, nil).Once()
+		client.On("CreateIssue", mock.Anything, "owner", "repo", "2つ目", "Part of #50", []string{"status:needs-plan"}).Return(62, nil).Once()
+		client.On("CreateIssueComment", mock.Anything, "owner", "repo", 50, mock.MatchedBy(func(body string) bool {
+			return strings.HasPrefix(body, subIssuesCommentMarker) &&
+				strings.Contains(body, "#61 1つ目") && strings.Contains(body, "#62 2つ目")
+		})).Return(nil).Once()
+		client.On("TransitionLabels", mock.Anything, "owner", "repo", 50, "status:ready", "status:blocked").Return(nil).Once()
+
+		expanded, err = expander.ExpandIfPlanned(context.Background(), readyIssue)
+		require.NoError(t, err)
+		assert.True(t, expanded)
+		client.AssertExpectations(t)
+		client.AssertNumberOfCalls(t, "CreateIssue", 3)
+	})
+}
+
+func TestSubIssueExpander_CheckBlockedOnce(t *testing.T) {
+	blockedIssue := &gh.Issue{
+		Number: intPtr(50),
+		Labels: []*gh.Label{{Name: stringPtr("status:blocked")}},
+	}
+	tracking := buildSubIssuesComment(config.NewConfig(), 50, []subIssue{
+		{number: 51, title: "APIを追加する", closed: true},
+		{number: 52, title: "UIを追加する"},
+	})
+
+	tests := []struct {
+		name        string
+		state       string
+		wantUnblock bool
+	}{
+		{
+			name:  "未完了のサブIssueあり - ブロック継続",
+			state: "OPEN",
+		},
+		{
+			name:        "すべてクローズ - 親を再開",
+			state:       "CLOSED",
+			wantUnblock: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockIssueCreatorClient)
+			client.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:blocked"}).
+				Return([]*gh.Issue{blockedIssue}, nil).Once()
+			client.On("ListIssueComments", mock.Anything, "owner", "repo", 50).
+				Return([]*gh.IssueComment{{ID: gh.Int64(9), Body: gh.String(tracking)}}, nil).Once()
+			client.On("GetIssueState", mock.Anything, "owner", "repo", 52).Return(tt.state, nil).Once()
+			if tt.wantUnblock {
+				client.On("UpdateIssueComment", mock.Anything, "owner", "repo", int64(9), mock.MatchedBy(func(body string) bool {
+					return strings.Contains(body, "- [x] #52 UIを追加する")
+				})).Return(nil).Once()
+				client.On("TransitionLabels", mock.Anything, "owner", "repo", 50, "status:blocked", "status:ready").Return(nil).Once()
+			}
+
+			expander, err := NewSubIssueExpander(client, "owner", "repo", config.NewConfig(), NewMockLogger())
+			require.NoError(t, err)
+			explainer := NewSkipExplainer()
+			expander.SetSkipExplainer(explainer)
+
+			require.NoError(t, expander.CheckBlockedOnce(context.Background()))
+			client.AssertExpectations(t)
+			if !tt.wantUnblock {
+				client.AssertNotCalled(t, "TransitionLabels", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				explanations := explainer.Explanations()
+				require.Len(t, explanations, 1)
+				assert.Equal(t, SkipReasonDependencyOpen, explanations[0].Reason)
+				assert.Equal(t, "waiting for sub-issues: #52", explanations[0].Detail)
+			} else {
+				assert.Empty(t, explainer.Explanations())
+			}
+		})
+	}
+}
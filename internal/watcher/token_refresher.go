@@ -0,0 +1,100 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// TokenRefresher はGitHubトークン（gh auth tokenまたはtoken_commandの出力）の変更を定期的に確認する
+// 変更を検出した場合は登録された関数に新しいトークンを渡すため、監視を再起動せずに以降のghコマンドへ適用できる
+// トークンを取得できない場合は現在のトークンを使い続ける
+type TokenRefresher struct {
+	config *config.Config
+	logger logger.Logger
+	clock  clock.Clock
+	fetch  func(*config.Config) (string, string) // テスト時に差し替え可能
+
+	mu       sync.Mutex
+	token    string
+	onRotate []func(token string)
+}
+
+// NewTokenRefresher は新しいTokenRefresherを作成する（tokenは起動時に取得したトークン）
+func NewTokenRefresher(cfg *config.Config, token string, logger logger.Logger) (*TokenRefresher, error) {
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+
+	return &TokenRefresher{
+		config: cfg,
+		logger: logger,
+		clock:  clock.New(),
+		fetch:  config.GetGitHubToken,
+		token:  token,
+	}, nil
+}
+
+// OnRotate はトークンが変更されたときに呼び出す関数を登録する
+func (r *TokenRefresher) OnRotate(fn func(token string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onRotate = append(r.onRotate, fn)
+}
+
+// Start はトークンの定期的な確認を開始する
+func (r *TokenRefresher) Start(ctx context.Context) {
+	interval := r.config.GitHub.CLI.TokenRefreshInterval
+	r.logger.Info("Starting token refresher", "interval", interval)
+
+	ticker := r.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Token refresher stopped")
+			return
+		case <-ticker.C():
+			r.CheckOnce(ctx)
+		}
+	}
+}
+
+// CheckOnce はトークンを取得し直し、変更されていた場合は登録された関数に渡してtrueを返す
+func (r *TokenRefresher) CheckOnce(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	token, source := r.fetch(r.config)
+	if token == "" {
+		r.logger.Warn("Failed to read GitHub token, keeping the current credential")
+		return false
+	}
+
+	r.mu.Lock()
+	previous := r.token
+	if token == previous {
+		r.mu.Unlock()
+		return false
+	}
+	r.token = token
+	callbacks := append([]func(token string){}, r.onRotate...)
+	r.mu.Unlock()
+
+	// トークン自体はログに出力せず、フィンガープリントのみを記録する
+	r.logger.Info("GitHub token rotated",
+		"source", source,
+		"previous", github.TokenFingerprint(previous),
+		"current", github.TokenFingerprint(token))
+	for _, fn := range callbacks {
+		fn(token)
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenRefresher_CheckOnce(t *testing.T) {
+	log := NewMockLogger().(*mockLogger)
+	refresher, err := NewTokenRefresher(config.NewConfig(), "old-token", log)
+	require.NoError(t, err)
+
+	tokens := []string{"old-token", "", "new-token"}
+	refresher.fetch = func(*config.Config) (string, string) {
+		token := tokens[0]
+		tokens = tokens[1:]
+		return token, "gh auth token"
+	}
+	var rotated []string
+	refresher.OnRotate(func(token string) { rotated = append(rotated, token) })
+
+	assert.False(t, refresher.CheckOnce(context.Background()), "変更がない場合")
+	assert.False(t, refresher.CheckOnce(context.Background()), "取得できない場合は現在のトークンを使い続ける")
+	assert.True(t, refresher.CheckOnce(context.Background()), "変更された場合")
+	assert.Equal(t, []string{"new-token"}, rotated)
+
+	for _, entry := range log.GetLogs() {
+		assert.NotContains(t, fmt.Sprint(entry.Fields...), "new-token", "トークンをログに出力しない")
+	}
+}
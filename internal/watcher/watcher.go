@@ -10,11 +10,15 @@ import (
 	"time"
 
 	"github.com/douhashi/osoba/internal/cleanup"
+	"github.com/douhashi/osoba/internal/clock"
 	"github.com/douhashi/osoba/internal/config"
 	"github.com/douhashi/osoba/internal/github"
 	gh "github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/logger"
+	"github.com/douhashi/osoba/internal/notify"
 	"github.com/douhashi/osoba/internal/tmux"
+	"github.com/douhashi/osoba/internal/trace"
+	"github.com/douhashi/osoba/internal/watcher/actions"
 )
 
 // IssueCallback はIssue検出時に呼ばれるコールバック関数
@@ -60,6 +64,32 @@ type IssueWatcher struct {
 	cleanupManager         cleanup.Manager         // クリーンアップマネージャー
 	autoMergeMetrics       *AutoMergeMetrics       // 自動マージメトリクス
 	labelTransitionMetrics *LabelTransitionMetrics // ラベル遷移メトリクス
+	blockedWorkspaces      map[int]string          // worktree事前チェックで停止中のIssueと通知済みの診断結果
+	subIssueExpander       *SubIssueExpander       // 計画のサブタスク展開（無効の場合はnil）
+	duplicateDetector      *DuplicateDetector      // 計画前の重複Issue検出（無効の場合はnil）
+	existingPRGuard        *ExistingPRGuard        // 計画前の既存PRの確認（無効の場合はnil）
+	planStalenessDetector  *PlanStalenessDetector  // 実装前の計画後のIssue編集の確認（無効の場合はnil）
+	planApprovalGate       *PlanApprovalGate       // 実装前の計画承認の確認（無効の場合はnil）
+	ciGate                 *CIGate                 // レビュー前のCIの完了の確認（無効の場合はnil）
+	worktreePrefetcher     *WorktreePrefetcher     // 複数Issueのworktreeの事前作成（無効の場合はnil）
+	pendingLaunches        []pendingLaunch         // worktreeの事前作成後に開始するフェーズ（サイクルごと）
+	closureVerifier        *IssueClosureVerifier   // 自動マージ後のIssueのクローズ確認（無効の場合はnil）
+	remoteBranches         *RemoteBranchCleaner    // 自動マージ後のリモートのブランチの削除（無効の場合はnil）
+	historyGuard           *HistoryGuard           // 自動マージ前のブランチの履歴の書き換えの確認（無効の場合はnil）
+	autoMergePolicy        *AutoMergePolicy        // 自動マージの追加の条件（未設定の場合はnil）
+	mergeQueue             *MergeQueue             // 自動マージするPRのマージキューへの追加（使わない場合はnil）
+	reviewEscalator        *ReviewEscalator        // レビューと修正の往復の人間への引き継ぎ（無効の場合はnil）
+	reviewBots             *ReviewBots             // レビューボットのレビューによるレビューフェーズの省略（無効の場合はnil）
+	resourceGuard          *ResourceGuard          // マシンの負荷が高い場合のフェーズ開始の保留（無効の場合はnil）
+	phaseBudget            *PhaseBudget            // フェーズごとの同時実行数の上限（上限がない場合はnil）
+	conflictFence          *ConflictFence          // 同じ領域に触れるIssueの実装フェーズを同時に実行しない（領域がない場合はnil）
+	backfill               *Backfill               // 起動時の積み残しのIssueを少しずつ開始する（無効の場合はnil）
+	retryBudget            *RetryBudget            // リトライが積み重なったIssueに深刻度のラベルを付与する（無効の場合はnil）
+	skipExplainer          *SkipExplainer          // 何もしなかった理由の記録（未設定の場合はnil）
+	notifier               notify.Notifier         // 重要なイベントの通知（無効の場合はnil）
+	degradation            *DegradationMonitor     // 連続した失敗による不健全な状態の検出（無効の場合はnil）
+	clock                  clock.Clock             // ポーリングとリトライの待機に使用する時計
+	pollNow                chan struct{}           // ポーリング間隔を待たない即時確認の要求
 
 	// ヘルスチェック用のフィールド
 	lastExecutionTime    time.Time
@@ -99,7 +129,11 @@ func NewIssueWatcherWithConfig(client github.GitHubClient, owner, repo, sessionN
 
 	// デフォルトのcleanupManagerを作成（必要に応じて）
 	if cleanupMgr == nil {
-		cleanupMgr = cleanup.NewManager(sessionName, logger)
+		if cfg != nil {
+			cleanupMgr = cleanup.NewManagerWithShards(cfg.Tmux.SessionNames(sessionName), logger, cfg.Safety)
+		} else {
+			cleanupMgr = cleanup.NewManager(sessionName, logger)
+		}
 	}
 
 	return &IssueWatcher{
@@ -113,6 +147,7 @@ func NewIssueWatcherWithConfig(client github.GitHubClient, owner, repo, sessionN
 		labelChangeTracking:    false,
 		issueLabels:            make(map[int64][]string),
 		startTime:              time.Now(),
+		clock:                  clock.New(),
 		logger:                 logger.WithFields("component", "watcher", "owner", owner, "repo", repo),
 		config:                 cfg,
 		cleanupManager:         cleanupMgr,
@@ -177,23 +212,45 @@ func (w *IssueWatcher) Start(ctx context.Context, callback IssueCallback) {
 		"labels", w.labels,
 		"interval", pollInterval)
 
-	ticker := time.NewTicker(pollInterval)
+	ticker := w.getClock().NewTicker(pollInterval)
 	defer ticker.Stop()
 
 	// 初回実行
 	w.checkIssues(ctx, callback)
 
+	pollNow := w.pollRequests()
 	for {
 		select {
 		case <-ctx.Done():
 			w.logger.Info("Stopping issue watcher")
 			return
-		case <-ticker.C:
+		case <-ticker.C():
+			w.checkIssues(ctx, callback)
+		case <-pollNow:
+			w.logger.Info("Immediate poll requested")
 			w.checkIssues(ctx, callback)
 		}
 	}
 }
 
+// RequestPoll は次のポーリング間隔を待たずに確認を行うよう要求する
+// 確認中に複数回要求された場合は、確認の完了後に1回だけ再確認する
+func (w *IssueWatcher) RequestPoll() {
+	select {
+	case w.pollRequests() <- struct{}{}:
+	default:
+	}
+}
+
+func (w *IssueWatcher) pollRequests() chan struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.pollNow == nil {
+		w.pollNow = make(chan struct{}, 1)
+	}
+	return w.pollNow
+}
+
 // StartWithActions はIssue監視を開始し、ラベルに基づいてアクションを実行する
 func (w *IssueWatcher) StartWithActions(ctx context.Context) {
 	callback := func(issue *gh.Issue) {
@@ -202,56 +259,309 @@ func (w *IssueWatcher) StartWithActions(ctx context.Context) {
 			"title", safeString(issue.Title),
 			"labels", getLabels(issue))
 
-		// ActionManagerを使用してアクションを実行
-		if err := w.actionManager.ExecuteAction(ctx, issue); err != nil {
-			w.logger.Error("Failed to execute action for issue",
-				"issueNumber", *issue.Number,
-				"error", err)
+		// 機能の設定で無効にしたフェーズの実行やラベルの遷移は行わない
+		t, ok := findWorkflowTransition(issue)
+		if ok && w.config != nil {
+			if t.Phase != "" {
+				if enabled, reason := w.config.Features.PhaseEnabled(t.Phase); !enabled {
+					w.skipExplainer.Record(*issue.Number, SkipReasonDisabled, reason)
+					return
+				}
+			} else if !w.config.Features.AutoTransitionLabels {
+				w.skipExplainer.Record(*issue.Number, SkipReasonDisabled, "features.auto_transition_labels is disabled")
+				return
+			}
 		}
 
-		// アクション実行後、必ずラベル遷移を実行
-		if err := w.executeLabelTransition(ctx, issue); err != nil {
-			w.logger.Error("Failed to execute label transition for issue",
-				"issueNumber", *issue.Number,
-				"error", err)
+		// カナリアモードで安定版の設定がない場合は、カナリアのラベルが付いたIssueのみを自動化する
+		if w.config != nil && w.config.Canary.Enabled() && w.config.Canary.StableConfig == "" &&
+			!w.config.Canary.Matches(getLabels(issue)) {
+			w.skipExplainer.Record(*issue.Number, SkipReasonFiltered, "canary mode: issue does not carry a canary label")
+			return
+		}
+
+		// 既存のPRで対応中の場合は計画フェーズを開始しない
+		if w.existingPRGuard != nil {
+			flagged, err := w.existingPRGuard.CheckBeforePlan(ctx, issue)
+			if err != nil {
+				w.logger.Warn("Failed to check for existing pull requests",
+					"issueNumber", *issue.Number,
+					"error", err)
+			}
+			if flagged {
+				w.skipExplainer.Record(*issue.Number, SkipReasonBlocked, "an open pull request already addresses this issue")
+				return
+			}
+		}
+
+		// 既存Issueと重複の可能性がある場合は計画フェーズを開始しない
+		if w.duplicateDetector != nil {
+			flagged, err := w.duplicateDetector.CheckBeforePlan(ctx, issue)
+			if err != nil {
+				w.logger.Warn("Failed to check for duplicate issues",
+					"issueNumber", *issue.Number,
+					"error", err)
+			}
+			if flagged {
+				w.skipExplainer.Record(*issue.Number, SkipReasonBlocked, "possible duplicate of an existing issue")
+				return
+			}
+		}
+
+		// 計画の作成後にIssue本文が編集されている場合は古い計画で実装フェーズを開始しない
+		if w.planStalenessDetector != nil {
+			staleness, err := w.planStalenessDetector.CheckBeforeImplement(ctx, issue)
+			if err != nil {
+				w.logger.Warn("Failed to check plan staleness",
+					"issueNumber", *issue.Number,
+					"error", err)
+			}
+			if staleness != nil {
+				w.notify(ctx, notify.Event{
+					Kind:        config.NotifyPlanStale,
+					IssueNumber: *issue.Number,
+					Title:       safeString(issue.Title),
+					Detail:      fmt.Sprintf("planned at %s, edited at %s", staleness.PlannedAt.In(w.config.Location()).Format(time.RFC3339), staleness.EditedAt.In(w.config.Location()).Format(time.RFC3339)),
+				})
+				w.skipExplainer.Record(*issue.Number, SkipReasonBlocked, "issue was edited after the plan was written")
+				return
+			}
 		}
 
-		// ラベル遷移後、Issue情報を再取得して最新状態で自動マージ処理を実行
-		if w.config != nil && w.config.GitHub.AutoMergeLGTM && issue.Number != nil {
-			// ラベル遷移後のタイミング問題に対応するため、少し待機
-			// テストモードではスリープをスキップ
-			if os.Getenv("OSOBA_TEST_MODE") != "true" {
-				time.Sleep(1 * time.Second)
+		// 計画が承認されるまで実装フェーズ（サブIssueの展開を含む）を開始しない
+		if w.planApprovalGate != nil {
+			approved, err := w.planApprovalGate.IsApproved(ctx, issue)
+			if err != nil {
+				w.logger.Warn("Failed to check plan approval",
+					"issueNumber", *issue.Number,
+					"error", err)
+				w.skipExplainer.Record(*issue.Number, SkipReasonBlocked, fmt.Sprintf("failed to check plan approval: %v", err))
+				return
 			}
+			if !approved {
+				w.skipExplainer.Record(*issue.Number, SkipReasonBlocked, "waiting for plan approval")
+				return
+			}
+		}
 
-			// 最新のIssue状態を取得
-			updatedIssue, err := w.getUpdatedIssueState(ctx, *issue.Number)
+		// 計画にサブタスクがある場合はサブIssueに展開し、実装フェーズを開始しない
+		if w.subIssueExpander != nil {
+			expanded, err := w.subIssueExpander.ExpandIfPlanned(ctx, issue)
 			if err != nil {
-				w.logger.Warn("Failed to get updated issue state for auto-merge",
+				w.logger.Warn("Failed to expand sub-issues",
 					"issueNumber", *issue.Number,
 					"error", err)
-				// エラーでも元のIssueで処理を続行
-				updatedIssue = issue
 			}
+			if expanded {
+				return
+			}
+		}
 
-			if err := executeAutoMergeIfLGTMWithLogger(ctx, updatedIssue, w.config, w.client, w.cleanupManager, w.logger, w.autoMergeMetrics); err != nil {
-				w.logger.Error("Failed to execute auto-merge for issue",
+		// PRのCIが完了するまでレビューフェーズを開始せず、失敗した場合は実装フェーズに戻す
+		if w.ciGate != nil {
+			proceed, detail, err := w.ciGate.CheckBeforeReview(ctx, issue)
+			if err != nil {
+				w.logger.Warn("Failed to check CI status",
 					"issueNumber", *issue.Number,
 					"error", err)
+				w.skipExplainer.Record(*issue.Number, SkipReasonBlocked, fmt.Sprintf("failed to check CI status: %v", err))
+				return
+			}
+			if !proceed {
+				w.skipExplainer.Record(*issue.Number, SkipReasonBlocked, detail)
+				return
 			}
 		}
+
+		// レビューボットが最新のコミットをレビュー済みの場合はosobaのレビューフェーズを開始しない
+		if w.reviewBots != nil {
+			skipped, err := w.reviewBots.CheckBeforeReview(ctx, issue)
+			if err != nil {
+				w.logger.Warn("Failed to check review bot feedback",
+					"issueNumber", *issue.Number,
+					"error", err)
+			}
+			if skipped {
+				return
+			}
+		}
+
+		// 起動時の積み残しのIssueは設定した間隔で1件ずつ開始し、順番が来るまでラベルを変更せずに待つ
+		if ok && t.Phase != "" {
+			if ready, detail := w.backfill.Ready(*issue.Number); !ready {
+				w.skipExplainer.Record(*issue.Number, SkipReasonBackfill, detail)
+				return
+			}
+		}
+
+		// マシンの負荷が高い場合は新しいフェーズを開始せず、ラベルを変更しないまま次回のポーリングで再判定する
+		if ok && t.Phase != "" && w.resourceGuard != nil && !w.resourceGuard.AllowLaunch(*issue.Number) {
+			w.skipExplainer.Record(*issue.Number, SkipReasonPaused, "machine is under resource pressure")
+			return
+		}
+
+		// 同じ領域を実装中のIssueがある場合は、その実装フェーズが終わった後のポーリングで再判定する
+		if ok && t.Phase == config.PhaseImplement {
+			if allowed, detail := w.conflictFence.AllowImplement(ctx, issue); !allowed {
+				w.skipExplainer.Record(*issue.Number, SkipReasonConflict, detail)
+				return
+			}
+		}
+
+		// フェーズの同時実行数が上限に達している場合も同様に次回のポーリングで再判定する
+		if ok && t.Phase != "" && w.phaseBudget != nil && !w.phaseBudget.AllowLaunch(ctx, issue, t.Phase) {
+			w.conflictFence.Forget(*issue.Number)
+			detail := fmt.Sprintf("%s phase concurrency limit reached", t.Phase)
+			w.skipExplainer.Record(*issue.Number, SkipReasonOverBudget, detail)
+			w.notify(ctx, notify.Event{
+				Kind:        config.NotifyBudgetExceeded,
+				IssueNumber: *issue.Number,
+				Title:       safeString(issue.Title),
+				Detail:      detail,
+			})
+			return
+		}
+		if ok && t.Phase != "" {
+			w.backfill.Launched(*issue.Number)
+		}
+
+		// 複数のIssueのworktreeをまとめて作成する場合は、開始が決まったIssueのフェーズをサイクルの最後に開始する
+		if ok && t.Phase != "" && w.worktreePrefetcher != nil {
+			w.pendingLaunches = append(w.pendingLaunches, pendingLaunch{
+				issue:  issue,
+				launch: func() { w.launchIssue(ctx, issue, t, ok) },
+			})
+			return
+		}
+		w.launchIssue(ctx, issue, t, ok)
 	}
 
 	w.Start(ctx, callback)
 }
 
+// pendingLaunch はworktreeの事前作成後に開始するIssueのフェーズ
+type pendingLaunch struct {
+	issue  *gh.Issue
+	launch func()
+}
+
+// runPendingLaunches はサイクル中に開始が決まったIssueのworktreeをまとめて作成し、フェーズを開始する
+// 予算・領域の競合・CIなどで開始しないIssueは対象に含まれないため、不要なworktreeを作成しない
+func (w *IssueWatcher) runPendingLaunches(ctx context.Context) {
+	launches := w.pendingLaunches
+	w.pendingLaunches = nil
+	if len(launches) == 0 {
+		return
+	}
+
+	issues := make([]*gh.Issue, 0, len(launches))
+	for _, l := range launches {
+		issues = append(issues, l.issue)
+	}
+	w.worktreePrefetcher.Prefetch(ctx, issues)
+
+	for _, l := range launches {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					w.logger.Error("Panic recovered in callback",
+						"issueNumber", *l.issue.Number,
+						"panic", r,
+						"stackTrace", string(debug.Stack()))
+				}
+			}()
+			l.launch()
+		}()
+	}
+}
+
+// launchIssue はIssueのフェーズを開始し、ラベルの遷移と自動マージを行う
+func (w *IssueWatcher) launchIssue(ctx context.Context, issue *gh.Issue, t config.WorkflowTransition, ok bool) {
+	// ActionManagerを使用してアクションを実行（フェーズを実行しない遷移の場合はラベルの遷移のみ行う）
+	if ok && t.Phase == "" {
+		w.logger.Debug("Skipping action for transition without a phase",
+			"issueNumber", *issue.Number,
+			"from", t.From,
+			"to", t.To)
+	} else if err := w.actionManager.ExecuteAction(ctx, issue); err != nil {
+		// 一時停止の場合はラベル遷移を行わず、次回のポーリングで再判定する
+		if errors.Is(err, actions.ErrPhasePaused) {
+			w.logger.Warn("Automated phase paused for issue",
+				"issueNumber", *issue.Number,
+				"reason", err)
+			var blocked *actions.WorkspaceBlockedError
+			if errors.As(err, &blocked) {
+				w.notifyWorkspaceBlocked(ctx, blocked)
+				w.skipExplainer.Record(*issue.Number, SkipReasonBlocked, err.Error())
+			} else {
+				w.skipExplainer.Record(*issue.Number, SkipReasonPaused, err.Error())
+			}
+			return
+		}
+		w.logger.Error("Failed to execute action for issue",
+			"issueNumber", *issue.Number,
+			"error", err)
+		w.retryBudget.RecordPhaseFailure(ctx, *issue.Number)
+		w.degradation.RecordFailure(ctx, DegradationSourcePhases, err)
+		w.notify(ctx, notify.Event{
+			Kind:        config.NotifyPhaseFailed,
+			IssueNumber: *issue.Number,
+			Title:       safeString(issue.Title),
+			Detail:      err.Error(),
+		})
+	} else {
+		w.clearWorkspaceBlocked(*issue.Number)
+		w.degradation.RecordSuccess(DegradationSourcePhases)
+		if ok {
+			w.retryBudget.RecordPhaseLaunch(ctx, *issue.Number, t.Phase)
+		}
+	}
+
+	// アクション実行後、必ずラベル遷移を実行
+	if err := w.executeLabelTransition(ctx, issue); err != nil {
+		w.logger.Error("Failed to execute label transition for issue",
+			"issueNumber", *issue.Number,
+			"error", err)
+	}
+
+	// ラベル遷移後、Issue情報を再取得して最新状態で自動マージ処理を実行
+	if w.config != nil && w.config.GitHub.AutoMergeLGTM && issue.Number != nil {
+		// ラベル遷移後のタイミング問題に対応するため、少し待機
+		// テストモードではスリープをスキップ
+		if os.Getenv("OSOBA_TEST_MODE") != "true" {
+			<-w.getClock().After(1 * time.Second)
+		}
+
+		// 最新のIssue状態を取得
+		updatedIssue, err := w.getUpdatedIssueState(ctx, *issue.Number)
+		if err != nil {
+			w.logger.Warn("Failed to get updated issue state for auto-merge",
+				"issueNumber", *issue.Number,
+				"error", err)
+			// エラーでも元のIssueで処理を続行
+			updatedIssue = issue
+		}
+
+		if err := executeAutoMergeIfLGTMWithLogger(ctx, updatedIssue, w.config, w.client, w.cleanupManager, w.logger, w.autoMergeMetrics, w.closureVerifier, w.autoMergePolicy, w.notifier, w.mergeQueue, w.remoteBranches, w.historyGuard); err != nil {
+			w.logger.Error("Failed to execute auto-merge for issue",
+				"issueNumber", *issue.Number,
+				"error", err)
+		}
+	}
+}
+
 // checkIssues は現在のIssueをチェックし、新しいIssueがあればコールバックを呼ぶ
 func (w *IssueWatcher) checkIssues(ctx context.Context, callback IssueCallback) {
 	// サイクル開始時刻
-	startTime := time.Now()
+	startTime := w.getClock().Now()
 	w.logger.Debug("Starting issue check cycle",
 		"startTime", startTime.Format(time.RFC3339))
 
+	// コマンドのトランスクリプトをサイクルごとに分ける（--trace-commandsが無効の場合は何もしない）
+	if err := trace.StartCycle(); err != nil {
+		w.logger.Warn("Failed to start command transcript for cycle", "error", err)
+	}
+
 	// 統計情報の更新
 	w.mu.Lock()
 	w.totalExecutions++
@@ -261,7 +571,7 @@ func (w *IssueWatcher) checkIssues(ctx context.Context, callback IssueCallback)
 	var processedCount, processedIssueCount int
 	var executionSuccessful bool
 	defer func() {
-		elapsed := time.Since(startTime)
+		elapsed := w.getClock().Since(startTime)
 		w.logger.Debug("Completed issue check cycle",
 			"checkedIssues", processedCount,
 			"processedIssues", processedIssueCount,
@@ -274,7 +584,7 @@ func (w *IssueWatcher) checkIssues(ctx context.Context, callback IssueCallback)
 		} else {
 			w.failedExecutions++
 		}
-		w.lastExecutionTime = time.Now()
+		w.lastExecutionTime = w.getClock().Now()
 		w.mu.Unlock()
 	}()
 
@@ -297,21 +607,30 @@ func (w *IssueWatcher) checkIssues(ctx context.Context, callback IssueCallback)
 		// ポーリング間隔が1秒未満の場合（テスト環境）は短いリトライ間隔を使用
 		retryDelay = 100 * time.Millisecond
 	}
-	err := RetryWithBackoffLogger(ctx, w.logger, 3, retryDelay, func() error {
+	err := RetryWithBackoffClock(ctx, w.getClock(), w.logger, 3, retryDelay, func() error {
 		var err error
 		issues, err = w.client.ListIssuesByLabels(ctx, w.owner, w.repo, w.labels)
 		return err
 	})
 
 	if err != nil {
+		if logIfThrottled(w.logger, err, "list_issues") {
+			return
+		}
 		w.logger.Error("Failed to list issues",
 			"error", err,
 			"labels", w.labels)
+		w.degradation.RecordFailure(ctx, DegradationSourceIssues, err)
 		return
 	}
 
 	// API呼び出しが成功
 	executionSuccessful = true
+	w.degradation.RecordSuccess(DegradationSourceIssues)
+	w.skipExplainer.BeginCycle()
+	defer w.skipExplainer.EndCycle()
+
+	w.backfill.Observe(issues)
 
 	for _, issue := range issues {
 		if issue.Number == nil {
@@ -363,6 +682,8 @@ func (w *IssueWatcher) checkIssues(ctx context.Context, callback IssueCallback)
 				}()
 				callback(issue)
 			}()
+		} else {
+			w.skipExplainer.Record(*issue.Number, SkipReasonFiltered, reason)
 		}
 
 		// ラベル変更の追跡
@@ -398,6 +719,12 @@ func (w *IssueWatcher) checkIssues(ctx context.Context, callback IssueCallback)
 		}
 	}
 
+	// 開始が決まったIssueのworktreeをまとめて作成してからフェーズを開始する
+	w.runPendingLaunches(ctx)
+
+	// マージを予約していたPRがマージされた場合はリモートのブランチを削除する
+	w.remoteBranches.ProcessPending(ctx)
+
 	// Issue処理サイクルの最後に自動計画機能を実行
 	if w.config != nil && w.config.GitHub.AutoPlanIssue {
 		if err := w.executeAutoPlanWithMutex(ctx); err != nil {
@@ -463,7 +790,7 @@ func (w *IssueWatcher) CheckHealth(maxInactivity time.Duration) HealthStatus {
 	}
 
 	// 最後の実行からの経過時間をチェック
-	timeSinceLastExecution := time.Since(lastExecution)
+	timeSinceLastExecution := w.getClock().Since(lastExecution)
 	if timeSinceLastExecution > maxInactivity {
 		return HealthStatus{
 			IsHealthy: false,
@@ -529,11 +856,159 @@ func (w *IssueWatcher) SetEventNotifier(notifier *EventNotifier) {
 	w.eventNotifier = notifier
 }
 
+// SetSubIssueExpander はサブタスク展開を設定する
+func (w *IssueWatcher) SetSubIssueExpander(expander *SubIssueExpander) {
+	w.subIssueExpander = expander
+}
+
+// SetClock はポーリングとリトライの待機に使用する時計を設定する（テストで仮想時間を使う場合など）
+func (w *IssueWatcher) SetClock(clk clock.Clock) {
+	w.clock = clk
+}
+
+// getClock は設定された時計を返す（未設定の場合は実時間）
+func (w *IssueWatcher) getClock() clock.Clock {
+	if w.clock == nil {
+		return clock.New()
+	}
+	return w.clock
+}
+
+// SetDuplicateDetector は計画前の重複Issue検出を設定する
+func (w *IssueWatcher) SetDuplicateDetector(detector *DuplicateDetector) {
+	w.duplicateDetector = detector
+}
+
+// SetExistingPRGuard は計画前の既存PRの確認を設定する
+func (w *IssueWatcher) SetExistingPRGuard(guard *ExistingPRGuard) {
+	w.existingPRGuard = guard
+}
+
+// SetCIGate はレビュー前のCIの完了の確認を設定する
+func (w *IssueWatcher) SetCIGate(gate *CIGate) {
+	w.ciGate = gate
+}
+
+// SetPlanApprovalGate は実装前の計画承認の確認を設定する
+func (w *IssueWatcher) SetPlanApprovalGate(gate *PlanApprovalGate) {
+	w.planApprovalGate = gate
+}
+
+// SetWorktreePrefetcher は複数Issueのworktreeの事前作成を設定する
+func (w *IssueWatcher) SetWorktreePrefetcher(prefetcher *WorktreePrefetcher) {
+	w.worktreePrefetcher = prefetcher
+}
+
+// SetRemoteBranchCleaner は自動マージ後のリモートのブランチの削除を設定する
+func (w *IssueWatcher) SetRemoteBranchCleaner(cleaner *RemoteBranchCleaner) {
+	w.remoteBranches = cleaner
+}
+
+// SetHistoryGuard は自動マージ前のブランチの履歴の書き換えの確認を設定する
+func (w *IssueWatcher) SetHistoryGuard(guard *HistoryGuard) {
+	w.historyGuard = guard
+}
+
+// SetIssueClosureVerifier は自動マージ後のIssueのクローズ確認を設定する
+func (w *IssueWatcher) SetIssueClosureVerifier(verifier *IssueClosureVerifier) {
+	w.closureVerifier = verifier
+}
+
+// SetAutoMergePolicy は自動マージの追加の条件を設定する
+func (w *IssueWatcher) SetAutoMergePolicy(policy *AutoMergePolicy) {
+	w.autoMergePolicy = policy
+}
+
+// SetMergeQueue は自動マージでPRをマージキューに追加するよう設定する
+// キューがマージした結果も自動マージメトリクスに記録する
+func (w *IssueWatcher) SetMergeQueue(queue *MergeQueue) {
+	w.mergeQueue = queue
+	if queue != nil {
+		queue.SetMetrics(w.autoMergeMetrics)
+	}
+}
+
+// SetReviewEscalator はレビューと修正の往復が続くIssueの人間への引き継ぎを設定する
+func (w *IssueWatcher) SetReviewEscalator(escalator *ReviewEscalator) {
+	w.reviewEscalator = escalator
+}
+
+// SetReviewBots はレビューボットのレビューによるレビューフェーズの省略を設定する
+func (w *IssueWatcher) SetReviewBots(reviewBots *ReviewBots) {
+	w.reviewBots = reviewBots
+}
+
+// SetSkipExplainer はIssueに対して何もしなかった理由の記録を設定する
+func (w *IssueWatcher) SetSkipExplainer(explainer *SkipExplainer) {
+	w.skipExplainer = explainer
+}
+
+// SetPlanStalenessDetector は実装前の計画後のIssue編集の確認を設定する
+func (w *IssueWatcher) SetPlanStalenessDetector(detector *PlanStalenessDetector) {
+	w.planStalenessDetector = detector
+}
+
+// SetResourceGuard はマシンの負荷が高い場合のフェーズ開始の保留を設定する
+func (w *IssueWatcher) SetResourceGuard(guard *ResourceGuard) {
+	w.resourceGuard = guard
+}
+
+// SetConflictFence は同じ領域に触れるIssueの実装フェーズを同時に実行しないよう設定する
+func (w *IssueWatcher) SetConflictFence(fence *ConflictFence) {
+	w.conflictFence = fence
+}
+
+// SetPhaseBudget はフェーズごとの同時実行数の上限を設定する
+func (w *IssueWatcher) SetPhaseBudget(budget *PhaseBudget) {
+	w.phaseBudget = budget
+}
+
+// SetRetryBudget はリトライが積み重なったIssueに深刻度のラベルを付与するよう設定する
+func (w *IssueWatcher) SetRetryBudget(budget *RetryBudget) {
+	w.retryBudget = budget
+}
+
+// SetBackfill は起動時の積み残しのIssueを少しずつ開始するよう設定する
+func (w *IssueWatcher) SetBackfill(backfill *Backfill) {
+	w.backfill = backfill
+}
+
+// SetNotifier は重要なイベントの通知を設定する
+func (w *IssueWatcher) SetNotifier(notifier notify.Notifier) {
+	w.notifier = notify.WithRepository(notifier, w.owner+"/"+w.repo)
+}
+
+// SetDegradationMonitor はIssueの一覧の取得とフェーズの開始の連続した失敗を記録するよう設定する
+func (w *IssueWatcher) SetDegradationMonitor(monitor *DegradationMonitor) {
+	w.degradation = monitor
+}
+
+// notify は重要なイベントを通知する（通知が無効の場合は何もしない）
+func (w *IssueWatcher) notify(ctx context.Context, event notify.Event) {
+	if w.notifier == nil {
+		return
+	}
+	if err := w.notifier.Notify(ctx, event); err != nil {
+		w.logger.Warn("Failed to send notification",
+			"kind", event.Kind,
+			"issueNumber", event.IssueNumber,
+			"error", err)
+	}
+}
+
 // EnableLabelChangeTracking はラベル変更追跡を有効/無効にする
 func (w *IssueWatcher) EnableLabelChangeTracking(enable bool) {
 	w.labelChangeTracking = enable
 }
 
+// sessionNames はIssueのウィンドウが作成され得るセッション名を返す（先頭は既定のセッション、以降はtmux.shardsのセッション）
+func (w *IssueWatcher) sessionNames() []string {
+	if w.config == nil {
+		return []string{w.sessionName}
+	}
+	return w.config.Tmux.SessionNames(w.sessionName)
+}
+
 // NewIssueWatcherWithLabelTracking はラベル変更追跡機能付きのIssueWatcherを作成する
 func NewIssueWatcherWithLabelTracking(client github.GitHubClient, owner, repo, sessionName string, labels []string, pollInterval time.Duration, logger logger.Logger) (*IssueWatcher, error) {
 	watcher, err := NewIssueWatcher(client, owner, repo, sessionName, labels, pollInterval, logger)
@@ -577,42 +1052,30 @@ func (w *IssueWatcher) executeLabelTransition(ctx context.Context, issue *gh.Iss
 		return fmt.Errorf("invalid issue: nil issue or issue number")
 	}
 
-	currentLabels := getLabels(issue)
+	transitions := workflow.Transitions
 
-	// status:requires-changesの特別な処理を最初に確認
-	for _, label := range currentLabels {
-		if label == "status:requires-changes" {
-			return w.executeRequiresChangesTransition(ctx, issue)
+	// 実行中ラベルを使わない遷移（既定ではstatus:requires-changes）を最初に確認
+	for _, transition := range transitions {
+		if transition.To != "" && hasLabel(issue, transition.From) {
+			return w.executeDirectTransition(ctx, issue, transition.From, transition.To)
 		}
 	}
 
-	// 通常のラベル遷移
-	transitions := []struct {
-		from string
-		to   string
-	}{
-		{"status:needs-plan", "status:planning"},
-		{"status:ready", "status:implementing"},
-		{"status:review-requested", "status:reviewing"},
-	}
-
+	// 通常のラベル遷移（トリガーラベル → 実行中ラベル）
 	for _, transition := range transitions {
-		// 現在のラベルが遷移元ラベルを含んでいるか確認
-		hasFromLabel := false
-		for _, label := range currentLabels {
-			if label == transition.from {
-				hasFromLabel = true
-				break
-			}
+		if transition.Executing == "" {
+			continue
 		}
+		from, to := transition.From, transition.Executing
+		hasFromLabel := hasLabel(issue, from)
 
 		if hasFromLabel {
 			w.logger.Info("Executing label transition",
 				"issueNumber", *issue.Number,
-				"from", transition.from,
-				"to", transition.to)
+				"from", from,
+				"to", to)
 
-			transitionType := fmt.Sprintf("%s->%s", transition.from, transition.to)
+			transitionType := fmt.Sprintf("%s->%s", from, to)
 
 			// リトライメカニズムを実装
 			const maxRetries = 3
@@ -621,20 +1084,21 @@ func (w *IssueWatcher) executeLabelTransition(ctx context.Context, issue *gh.Iss
 
 			for attempt := 1; attempt <= maxRetries; attempt++ {
 				// 原子的にラベルを遷移（削除と追加を同時に実行）
-				if err := w.client.TransitionLabels(ctx, w.owner, w.repo, *issue.Number, transition.from, transition.to); err != nil {
-					lastErr = fmt.Errorf("failed to transition labels from %s to %s (attempt %d/%d): %w", transition.from, transition.to, attempt, maxRetries, err)
-					failureReason = fmt.Sprintf("transition_error_%s_to_%s", transition.from, transition.to)
+				if err := w.client.TransitionLabels(ctx, w.owner, w.repo, *issue.Number, from, to); err != nil {
+					lastErr = fmt.Errorf("failed to transition labels from %s to %s (attempt %d/%d): %w", from, to, attempt, maxRetries, err)
+					failureReason = fmt.Sprintf("transition_error_%s_to_%s", from, to)
+					w.retryBudget.RecordGHFailure(ctx, *issue.Number)
 					w.logger.Warn("Failed to transition labels, retrying",
 						"issueNumber", *issue.Number,
-						"from", transition.from,
-						"to", transition.to,
+						"from", from,
+						"to", to,
 						"attempt", attempt,
 						"error", err)
 
 					if attempt < maxRetries {
 						// テストモードではスリープをスキップ
 						if os.Getenv("OSOBA_TEST_MODE") != "true" {
-							time.Sleep(time.Duration(attempt) * time.Second) // バックオフ付きリトライ
+							<-w.getClock().After(time.Duration(attempt) * time.Second) // バックオフ付きリトライ
 						}
 						continue
 					}
@@ -651,8 +1115,8 @@ func (w *IssueWatcher) executeLabelTransition(ctx context.Context, issue *gh.Iss
 				}
 				w.logger.Info("Successfully transitioned label",
 					"issueNumber", *issue.Number,
-					"from", transition.from,
-					"to", transition.to,
+					"from", from,
+					"to", to,
 					"attempt", attempt)
 				return nil
 			}
@@ -668,20 +1132,34 @@ func (w *IssueWatcher) executeLabelTransition(ctx context.Context, issue *gh.Iss
 	return nil
 }
 
-// executeRequiresChangesTransition はstatus:requires-changesラベルの特別な遷移処理を実行する
-func (w *IssueWatcher) executeRequiresChangesTransition(ctx context.Context, issue *gh.Issue) error {
+// executeDirectTransition は実行中ラベルを使わない遷移（既定ではstatus:requires-changes → status:ready）を実行する
+// 遷移先のフェーズを新しいウィンドウで開始するため、Issueのtmuxウィンドウを削除してからラベルを遷移する
+func (w *IssueWatcher) executeDirectTransition(ctx context.Context, issue *gh.Issue, from, to string) error {
 	if issue == nil || issue.Number == nil {
 		return fmt.Errorf("invalid issue: nil issue or issue number")
 	}
 
 	issueNumber := *issue.Number
 
-	w.logger.Info("Executing requires-changes transition",
+	// 往復回数が上限に達した場合は人間のレビュアーに引き継ぎ、status:readyには戻さない
+	// 引き継いだIssueのtmuxウィンドウは、人間が確認できるようにそのまま残す
+	if w.reviewEscalator != nil && from == TriggerLabelRequiresChanges {
+		escalated, err := w.reviewEscalator.HandleChangesRequested(ctx, issueNumber)
+		if err != nil {
+			w.logger.Warn("Failed to escalate review loop, continuing with requires-changes transition",
+				"issueNumber", issueNumber,
+				"error", err)
+		} else if escalated {
+			return nil
+		}
+	}
+
+	w.logger.Info("Executing direct transition",
 		"issueNumber", issueNumber,
-		"from", "status:requires-changes",
-		"to", "status:ready")
+		"from", from,
+		"to", to)
 
-	transitionType := "status:requires-changes->status:ready"
+	transitionType := fmt.Sprintf("%s->%s", from, to)
 
 	// tmuxウィンドウの削除（sessionNameが設定されている場合のみ）
 	if w.sessionName != "" {
@@ -689,17 +1167,31 @@ func (w *IssueWatcher) executeRequiresChangesTransition(ctx context.Context, iss
 			"issueNumber", issueNumber,
 			"sessionName", w.sessionName)
 
-		// tmuxパッケージのインポートが必要
-		if err := tmux.KillWindowsForIssue(w.sessionName, issueNumber); err != nil {
-			// tmuxウィンドウ削除エラーは警告ログに記録し、処理は継続
-			w.logger.Warn("Failed to kill tmux windows, continuing with label transition",
+		if w.config != nil && w.config.Safety.RequiresConfirmation(config.OperationKillWindow) {
+			w.logger.Warn("Skipping destructive operation that requires confirmation",
+				"operation", config.OperationKillWindow,
 				"issueNumber", issueNumber,
-				"sessionName", w.sessionName,
-				"error", err)
+				"hint", "run with --yes or add the operation to safety.allow")
 		} else {
-			w.logger.Info("Successfully cleaned up tmux windows",
-				"issueNumber", issueNumber,
-				"sessionName", w.sessionName)
+			for i, sessionName := range w.sessionNames() {
+				// まだウィンドウが振り分けられていないシャードのセッションは存在しない
+				if i > 0 {
+					if exists, err := tmux.SessionExists(sessionName); err == nil && !exists {
+						continue
+					}
+				}
+				if err := tmux.KillWindowsForIssue(sessionName, issueNumber); err != nil {
+					// tmuxウィンドウ削除エラーは警告ログに記録し、処理は継続
+					w.logger.Warn("Failed to kill tmux windows, continuing with label transition",
+						"issueNumber", issueNumber,
+						"sessionName", sessionName,
+						"error", err)
+				} else {
+					w.logger.Info("Successfully cleaned up tmux windows",
+						"issueNumber", issueNumber,
+						"sessionName", sessionName)
+				}
+			}
 		}
 	}
 
@@ -709,21 +1201,22 @@ func (w *IssueWatcher) executeRequiresChangesTransition(ctx context.Context, iss
 	var failureReason string
 
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		// 原子的にラベルを遷移（requires-changes → ready）
-		if err := w.client.TransitionLabels(ctx, w.owner, w.repo, issueNumber, "status:requires-changes", "status:ready"); err != nil {
-			lastErr = fmt.Errorf("failed to transition labels from status:requires-changes to status:ready (attempt %d/%d): %w", attempt, maxRetries, err)
-			failureReason = "transition_error_status:requires-changes_to_status:ready"
+		// 原子的にラベルを遷移（既定ではrequires-changes → ready）
+		if err := w.client.TransitionLabels(ctx, w.owner, w.repo, issueNumber, from, to); err != nil {
+			lastErr = fmt.Errorf("failed to transition labels from %s to %s (attempt %d/%d): %w", from, to, attempt, maxRetries, err)
+			failureReason = fmt.Sprintf("transition_error_%s_to_%s", from, to)
+			w.retryBudget.RecordGHFailure(ctx, issueNumber)
 			w.logger.Warn("Failed to transition labels, retrying",
 				"issueNumber", issueNumber,
-				"from", "status:requires-changes",
-				"to", "status:ready",
+				"from", from,
+				"to", to,
 				"attempt", attempt,
 				"error", err)
 
 			if attempt < maxRetries {
 				// テストモードではスリープをスキップ
 				if os.Getenv("OSOBA_TEST_MODE") != "true" {
-					time.Sleep(time.Duration(attempt) * time.Second) // バックオフ付きリトライ
+					<-w.getClock().After(time.Duration(attempt) * time.Second) // バックオフ付きリトライ
 				}
 				continue
 			}
@@ -738,8 +1231,10 @@ func (w *IssueWatcher) executeRequiresChangesTransition(ctx context.Context, iss
 		if w.labelTransitionMetrics != nil {
 			w.labelTransitionMetrics.RecordSuccess(issueNumber, transitionType)
 		}
-		w.logger.Info("Successfully transitioned requires-changes to ready",
+		w.logger.Info("Successfully transitioned label",
 			"issueNumber", issueNumber,
+			"from", from,
+			"to", to,
 			"attempt", attempt)
 		return nil
 	}
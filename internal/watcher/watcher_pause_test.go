@@ -0,0 +1,101 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/git"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/watcher/actions"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestStartWithActions_PausedPhaseSkipsLabelTransition はフェーズが一時停止された場合にラベル遷移を行わないことを確認する
+func TestStartWithActions_PausedPhaseSkipsLabelTransition(t *testing.T) {
+	issue := &gh.Issue{
+		Number: intPtr(321),
+		Labels: []*gh.Label{
+			{Name: stringPtr("status:ready")},
+		},
+	}
+
+	mockClient := new(MockGitHubClient)
+	mockActionManager := new(MockActionManager)
+
+	mockClient.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:ready"}).
+		Return([]*gh.Issue{issue}, nil)
+	mockActionManager.On("ExecuteAction", mock.Anything, issue).
+		Return(fmt.Errorf("failed to prepare workspace: %w", actions.ErrPhasePaused))
+
+	watcher := &IssueWatcher{
+		client:        mockClient,
+		owner:         "owner",
+		repo:          "repo",
+		labels:        []string{"status:ready"},
+		pollInterval:  100 * time.Millisecond,
+		actionManager: mockActionManager,
+		logger:        NewMockLogger(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	go watcher.StartWithActions(ctx)
+	time.Sleep(150 * time.Millisecond)
+
+	mockActionManager.AssertCalled(t, "ExecuteAction", mock.Anything, issue)
+	mockClient.AssertNotCalled(t, "TransitionLabels", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestStartWithActions_BlockedWorkspaceCommentsOnce はworktree事前チェックで停止した場合に診断結果を一度だけコメントすることを確認する
+func TestStartWithActions_BlockedWorkspaceCommentsOnce(t *testing.T) {
+	issue := &gh.Issue{
+		Number: intPtr(322),
+		Labels: []*gh.Label{
+			{Name: stringPtr("status:ready")},
+		},
+	}
+	blocked := &actions.WorkspaceBlockedError{
+		IssueNumber: 322,
+		Health: &git.WorktreeHealth{
+			Path:           "/repo/.git/osoba/worktrees/issue-322",
+			ExpectedBranch: "osoba/#322",
+			Problems: []git.WorktreeProblem{
+				{Kind: git.ProblemUnresolvedMerge, Detail: "MERGE_HEAD is present"},
+			},
+		},
+	}
+
+	mockClient := new(MockGitHubClient)
+	mockActionManager := new(MockActionManager)
+
+	mockClient.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:ready"}).
+		Return([]*gh.Issue{issue}, nil)
+	mockClient.On("CreateIssueComment", mock.Anything, "owner", "repo", 322, mock.MatchedBy(func(body string) bool {
+		return strings.HasPrefix(body, workspaceBlockedCommentMarker) && strings.Contains(body, "MERGE_HEAD is present")
+	})).Return(nil).Once()
+	mockActionManager.On("ExecuteAction", mock.Anything, issue).
+		Return(fmt.Errorf("failed to prepare workspace: %w", blocked))
+
+	watcher := &IssueWatcher{
+		client:        mockClient,
+		owner:         "owner",
+		repo:          "repo",
+		labels:        []string{"status:ready"},
+		pollInterval:  50 * time.Millisecond,
+		actionManager: mockActionManager,
+		logger:        NewMockLogger(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 180*time.Millisecond)
+	defer cancel()
+
+	go watcher.StartWithActions(ctx)
+	time.Sleep(150 * time.Millisecond)
+
+	mockClient.AssertNumberOfCalls(t, "CreateIssueComment", 1)
+	mockClient.AssertNotCalled(t, "TransitionLabels", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/douhashi/osoba/internal/clock"
 	gh "github.com/douhashi/osoba/internal/github"
 	"github.com/douhashi/osoba/internal/testutil/builders"
 	"github.com/douhashi/osoba/internal/testutil/mocks"
@@ -212,6 +213,72 @@ func TestIssueWatcher_Stop(t *testing.T) {
 	}
 }
 
+func TestIssueWatcher_PollsOnClockTicks(t *testing.T) {
+	mockGH := mocks.NewMockGitHubClient()
+	polled := make(chan struct{}, 10)
+	mockGH.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:needs-plan"}).
+		Return([]*gh.Issue{}, nil).
+		Run(func(args mock.Arguments) { polled <- struct{}{} })
+
+	watcher, err := NewIssueWatcher(mockGH, "owner", "repo", "test-session", []string{"status:needs-plan"}, time.Minute, NewMockLogger())
+	if err != nil {
+		t.Fatalf("NewIssueWatcher() error = %v", err)
+	}
+	fakeClock := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	watcher.SetClock(fakeClock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watcher.Start(ctx, func(issue *gh.Issue) {})
+	}()
+
+	// 初回のポーリング後は、仮想時間がポーリング間隔分進むたびにポーリングする
+	fakeClock.BlockUntil(1)
+	<-polled
+	for i := 0; i < 3; i++ {
+		fakeClock.Advance(time.Minute)
+		<-polled
+	}
+
+	cancel()
+	<-done
+	mockGH.AssertNumberOfCalls(t, "ListIssuesByLabels", 4)
+}
+
+func TestIssueWatcher_RequestPoll(t *testing.T) {
+	mockGH := mocks.NewMockGitHubClient()
+	polled := make(chan struct{}, 10)
+	mockGH.On("ListIssuesByLabels", mock.Anything, "owner", "repo", []string{"status:needs-plan"}).
+		Return([]*gh.Issue{}, nil).
+		Run(func(args mock.Arguments) { polled <- struct{}{} })
+
+	watcher, err := NewIssueWatcher(mockGH, "owner", "repo", "test-session", []string{"status:needs-plan"}, time.Minute, NewMockLogger())
+	if err != nil {
+		t.Fatalf("NewIssueWatcher() error = %v", err)
+	}
+	watcher.SetClock(clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watcher.Start(ctx, func(issue *gh.Issue) {})
+	}()
+
+	// 仮想時間を進めなくても、要求するたびにポーリングする
+	<-polled
+	for i := 0; i < 2; i++ {
+		watcher.RequestPoll()
+		<-polled
+	}
+
+	cancel()
+	<-done
+	mockGH.AssertNumberOfCalls(t, "ListIssuesByLabels", 3)
+}
+
 func TestIssueWatcher_RateLimitHandling(t *testing.T) {
 	tests := []struct {
 		name      string
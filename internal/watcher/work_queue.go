@@ -0,0 +1,254 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/douhashi/osoba/internal/clock"
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// 処理済みの作業指示ファイルの移動先（キューのディレクトリからの相対パス）
+const (
+	workQueueProcessedDir = "processed"
+	workQueueFailedDir    = "failed"
+)
+
+// workQueueSettleTime は書き込み中のファイルを読み込まないよう、最後の更新から待つ時間
+const workQueueSettleTime = 2 * time.Second
+
+// WorkOrder はキューに置かれた作業指示
+type WorkOrder struct {
+	Title  string   `json:"title" yaml:"title"`
+	Body   string   `json:"body" yaml:"body"`
+	Labels []string `json:"labels" yaml:"labels"`
+}
+
+// WorkQueue はディレクトリに置かれた作業指示ファイル（YAML/JSON）をIssueに変換する
+// 作成したIssueにはwork_queue.labelを付与するため、通常のIssueと同様にフェーズが開始される
+// 変換したファイルはprocessed/に、読み込めないファイルと再試行しても作成できないファイルはfailed/に移動する
+// 一時的な理由でIssueの作成に失敗したファイルはそのまま残し、次回の確認で再試行する
+// 書き込み中のファイルを読み込まないよう、最後の更新からworkQueueSettleTimeが経過したファイルのみ処理する
+type WorkQueue struct {
+	client github.IssueCreator
+	owner  string
+	repo   string
+	dir    string
+	config *config.Config
+	logger logger.Logger
+	clock  clock.Clock
+}
+
+// NewWorkQueue は新しいWorkQueueを作成する
+func NewWorkQueue(client github.GitHubClient, owner, repo, dir string, cfg *config.Config, logger logger.Logger) (*WorkQueue, error) {
+	if client == nil {
+		return nil, errors.New("github client is required")
+	}
+	if owner == "" {
+		return nil, errors.New("owner is required")
+	}
+	if repo == "" {
+		return nil, errors.New("repo is required")
+	}
+	if dir == "" {
+		return nil, errors.New("queue directory is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	creator, ok := client.(github.IssueCreator)
+	if !ok {
+		return nil, errors.New("github client does not support creating issues")
+	}
+
+	return &WorkQueue{
+		client: creator,
+		owner:  owner,
+		repo:   repo,
+		dir:    dir,
+		config: cfg,
+		logger: logger,
+		clock:  clock.New(),
+	}, nil
+}
+
+// Start はキューの定期的な確認を開始する
+func (q *WorkQueue) Start(ctx context.Context) {
+	interval := q.config.GitHub.PollInterval
+	q.logger.Info("Starting work queue", "dir", q.dir, "interval", interval)
+
+	if _, err := q.ProcessOnce(ctx); err != nil {
+		q.logger.Warn("Failed to process work queue", "error", err)
+	}
+
+	ticker := q.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.logger.Info("Work queue stopped")
+			return
+		case <-ticker.C():
+			if _, err := q.ProcessOnce(ctx); err != nil {
+				q.logger.Warn("Failed to process work queue", "error", err)
+			}
+		}
+	}
+}
+
+// ProcessOnce はキューに置かれた作業指示ファイルをファイル名順にIssueに変換し、作成したIssue番号を返す
+// キューのディレクトリが存在しない場合は何もしない
+func (q *WorkQueue) ProcessOnce(ctx context.Context) ([]int, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read work queue: %w", err)
+	}
+
+	now := q.clock.Now()
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || !isWorkOrderFile(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if now.Sub(info.ModTime()) < workQueueSettleTime {
+			q.logger.Debug("Work order is still being written, will retry", "file", entry.Name())
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	var created []int
+	for _, name := range names {
+		if ctx.Err() != nil {
+			return created, ctx.Err()
+		}
+		path := filepath.Join(q.dir, name)
+
+		order, err := ReadWorkOrder(path)
+		if err != nil {
+			q.logger.Warn("Invalid work order, moving to failed",
+				"file", name,
+				"error", err)
+			if err := q.move(name, workQueueFailedDir); err != nil {
+				q.logger.Warn("Failed to move work order", "file", name, "error", err)
+			}
+			continue
+		}
+
+		number, err := q.client.CreateIssue(ctx, q.owner, q.repo, order.Title, order.Body, q.labelsFor(order))
+		if err != nil && isPermanentCreateError(err) {
+			q.logger.Warn("Work order was rejected by GitHub, moving to failed",
+				"file", name,
+				"error", err)
+			if err := q.move(name, workQueueFailedDir); err != nil {
+				q.logger.Warn("Failed to move work order", "file", name, "error", err)
+			}
+			continue
+		}
+		if err != nil {
+			q.logger.Warn("Failed to create issue from work order, will retry",
+				"file", name,
+				"error", err)
+			continue
+		}
+		q.logger.Info("Created issue from work order",
+			"file", name,
+			"issueNumber", number)
+		created = append(created, number)
+
+		if err := q.move(name, workQueueProcessedDir); err != nil {
+			// 移動できないと次回の確認で重複して作成されるため、確認を中断する
+			return created, fmt.Errorf("failed to move processed work order %s: %w", name, err)
+		}
+	}
+	return created, nil
+}
+
+// labelsFor は作業指示のラベルにキューのラベルを加えたものを返す
+func (q *WorkQueue) labelsFor(order *WorkOrder) []string {
+	labels := append([]string(nil), order.Labels...)
+	if label := q.config.GitHub.WorkQueue.Label; label != "" && !containsFold(labels, label) {
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// move は作業指示ファイルをキューのサブディレクトリに移動する
+// 同じ名前のファイルが既にある場合は上書きせず、名前に連番を付ける（01-login.1.ymlなど）
+func (q *WorkQueue) move(name, subdir string) error {
+	dir := filepath.Join(q.dir, subdir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	dest := filepath.Join(dir, name)
+	for i := 1; ; i++ {
+		if _, err := os.Lstat(dest); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		dest = filepath.Join(dir, fmt.Sprintf("%s.%d%s", base, i, ext))
+	}
+	return os.Rename(filepath.Join(q.dir, name), dest)
+}
+
+// isPermanentCreateError は再試行しても成功しないIssueの作成エラーかを返す
+// 存在しないラベルの指定と入力の検証エラー（HTTP 422）は、作業指示ファイルを直さない限り成功しない
+func isPermanentCreateError(err error) bool {
+	if strings.Contains(err.Error(), "could not add label") {
+		return true
+	}
+	return github.ParseGHError(err.Error(), err).StatusCode == 422
+}
+
+// ReadWorkOrder は作業指示ファイルを読み込む（拡張子が.jsonの場合はJSON、それ以外はYAML）
+func ReadWorkOrder(path string) (*WorkOrder, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var order WorkOrder
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &order)
+	} else {
+		err = yaml.Unmarshal(data, &order)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse work order: %w", err)
+	}
+	order.Title = strings.TrimSpace(order.Title)
+	if order.Title == "" {
+		return nil, errors.New("work order title is required")
+	}
+	return &order, nil
+}
+
+// isWorkOrderFile は作業指示ファイルの拡張子かを返す
+func isWorkOrderFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yml", ".yaml", ".json":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writeWorkOrder(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	// 書き込みが終わったファイルとして扱われるよう、更新時刻を過去にする
+	past := time.Now().Add(-time.Minute)
+	require.NoError(t, os.Chtimes(path, past, past))
+}
+
+func TestWorkQueue_ProcessOnce(t *testing.T) {
+	dir := t.TempDir()
+	writeWorkOrder(t, dir, "01-login.yml", "title: ログイン画面を追加\nbody: |\n  OAuthでログインする\nlabels: [feature]\n")
+	writeWorkOrder(t, dir, "02-fix.json", `{"title": "バグ修正", "body": "落ちる", "labels": ["status:needs-plan"]}`)
+	writeWorkOrder(t, dir, "03-invalid.yaml", "body: タイトルなし\n")
+	writeWorkOrder(t, dir, "04-retry.yml", "title: 後で再試行\n")
+	writeWorkOrder(t, dir, "05-bad-label.yml", "title: 存在しないラベル\nlabels: [unknown]\n")
+	writeWorkOrder(t, dir, "README.md", "作業指示ではない")
+	// 書き込み中のファイルは次回の確認まで読み込まない
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "06-writing.yml"), []byte("title: 書き込み中\n"), 0644))
+	// 処理済みの同名のファイルは上書きしない
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "processed"), 0755))
+	writeWorkOrder(t, filepath.Join(dir, "processed"), "01-login.yml", "title: 以前の作業指示\n")
+
+	client := new(mockIssueCreatorClient)
+	client.On("CreateIssue", mock.Anything, "owner", "repo", "ログイン画面を追加", "OAuthでログインする\n", []string{"feature", "status:needs-plan"}).Return(10, nil).Once()
+	client.On("CreateIssue", mock.Anything, "owner", "repo", "バグ修正", "落ちる", []string{"status:needs-plan"}).Return(11, nil).Once()
+	client.On("CreateIssue", mock.Anything, "owner", "repo", "後で再試行", "", []string{"status:needs-plan"}).Return(0, errors.New("api error")).Once()
+	client.On("CreateIssue", mock.Anything, "owner", "repo", "存在しないラベル", "", []string{"unknown", "status:needs-plan"}).
+		Return(0, errors.New("failed to create issue: gh command failed: exit status 1, output: could not add label: 'unknown' not found")).Once()
+
+	queue, err := NewWorkQueue(client, "owner", "repo", dir, config.NewConfig(), NewMockLogger())
+	require.NoError(t, err)
+
+	created, err := queue.ProcessOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 11}, created)
+	client.AssertExpectations(t)
+
+	assert.FileExists(t, filepath.Join(dir, "processed", "01-login.yml"))
+	assert.FileExists(t, filepath.Join(dir, "processed", "01-login.1.yml"))
+	content, err := os.ReadFile(filepath.Join(dir, "processed", "01-login.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "title: 以前の作業指示\n", string(content))
+	assert.FileExists(t, filepath.Join(dir, "processed", "02-fix.json"))
+	assert.FileExists(t, filepath.Join(dir, "failed", "03-invalid.yaml"))
+	assert.FileExists(t, filepath.Join(dir, "failed", "05-bad-label.yml"))
+	assert.FileExists(t, filepath.Join(dir, "06-writing.yml"))
+	// 作成に失敗したファイルは次回の確認で再試行するため残す
+	assert.FileExists(t, filepath.Join(dir, "04-retry.yml"))
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+}
+
+func TestWorkQueue_ProcessOnce_MissingDir(t *testing.T) {
+	queue, err := NewWorkQueue(new(mockIssueCreatorClient), "owner", "repo", filepath.Join(t.TempDir(), "queue"), config.NewConfig(), NewMockLogger())
+	require.NoError(t, err)
+
+	created, err := queue.ProcessOnce(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, created)
+}
@@ -0,0 +1,72 @@
+package watcher
+
+import (
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/github"
+)
+
+// workflow はラベルによる状態遷移の定義（起動時にSetWorkflowで設定する）
+var workflow = config.WorkflowConfig{Transitions: config.DefaultWorkflowTransitions()}
+
+// SetWorkflow はラベルによる状態遷移の定義を変更する
+// トリガーラベルの判定はパッケージ全体の関数で行われるため、監視ごとではなくパッケージ全体に適用する
+func SetWorkflow(w config.WorkflowConfig) {
+	if len(w.Transitions) == 0 {
+		w.Transitions = config.DefaultWorkflowTransitions()
+	}
+	workflow = w
+}
+
+// phasePaneTitles はフェーズとペインタイトルの対応
+var phasePaneTitles = map[string]string{
+	config.PhasePlan:      "Plan",
+	config.PhaseImplement: "Implementation",
+	config.PhaseReview:    "Review",
+	config.PhaseRevise:    "Revise",
+}
+
+// findWorkflowTransition はIssueのトリガーラベルのうち、優先順位が最も高い遷移を返す
+func findWorkflowTransition(issue *github.Issue) (config.WorkflowTransition, bool) {
+	for _, t := range workflow.Transitions {
+		if hasLabel(issue, t.From) {
+			return t, true
+		}
+	}
+	return config.WorkflowTransition{}, false
+}
+
+// createActionForPhase はフェーズを実行するアクションを作成する（フェーズがない場合はnil）
+func createActionForPhase(factory ActionFactory, phase string) ActionExecutor {
+	switch phase {
+	case config.PhasePlan:
+		return factory.CreatePlanAction()
+	case config.PhaseImplement:
+		return factory.CreateImplementationAction()
+	case config.PhaseReview:
+		return factory.CreateReviewAction()
+	case config.PhaseRevise:
+		return factory.CreateReviseAction()
+	}
+	return nil
+}
+
+// activeProgressPhases は実行中フェーズの一覧を返す（workflowで追加した実行中ラベルを含む）
+func activeProgressPhases() []progressPhase {
+	phases := append([]progressPhase(nil), progressPhases...)
+	for _, t := range workflow.Transitions {
+		if t.Executing == "" {
+			continue
+		}
+		known := false
+		for _, p := range phases {
+			if p.label == t.Executing {
+				known = true
+				break
+			}
+		}
+		if !known {
+			phases = append(phases, progressPhase{label: t.Executing, paneTitle: phasePaneTitles[t.Phase], configKey: t.Phase})
+		}
+	}
+	return phases
+}
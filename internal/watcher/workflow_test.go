@@ -0,0 +1,97 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// setTestWorkflow はテスト中だけworkflowを差し替える
+func setTestWorkflow(t *testing.T, transitions []config.WorkflowTransition) {
+	t.Helper()
+	orig := workflow
+	t.Cleanup(func() { workflow = orig })
+	SetWorkflow(config.WorkflowConfig{Transitions: transitions})
+}
+
+// レビューを省略し、実装後にstatus:lgtmへ進むワークフロー
+var noReviewTransitions = []config.WorkflowTransition{
+	{From: "status:needs-plan", Phase: config.PhasePlan, Executing: "status:planning", Next: "status:ready"},
+	{From: "status:ready", Phase: config.PhaseImplement, Executing: "status:implementing", Next: "status:lgtm"},
+	{From: "status:requires-changes", To: "status:ready"},
+}
+
+func TestSetWorkflow_TriggerLabels(t *testing.T) {
+	setTestWorkflow(t, noReviewTransitions)
+
+	assert.Equal(t, []string{"status:needs-plan", "status:ready", "status:requires-changes"}, getTriggerLabelPriority())
+	assert.Equal(t, map[string]string{
+		"status:needs-plan":       "status:planning",
+		"status:ready":            "status:implementing",
+		"status:requires-changes": "",
+	}, GetTriggerLabelMapping())
+
+	// workflowにないトリガーラベルは処理しない
+	shouldProcess, _ := ShouldProcessIssue(createTestIssueWithLabels([]string{"status:review-requested"}))
+	assert.False(t, shouldProcess)
+}
+
+func TestSetWorkflow_EmptyUsesDefaults(t *testing.T) {
+	setTestWorkflow(t, nil)
+	assert.Equal(t, config.DefaultWorkflowTransitions(), workflow.Transitions)
+}
+
+func TestActionManagerExtended_GetActionForIssue_Workflow(t *testing.T) {
+	setTestWorkflow(t, noReviewTransitions)
+
+	factory := new(MockActionFactory)
+	implementation := new(MockActionExecutorExt)
+	factory.On("CreateImplementationAction").Return(implementation)
+	manager := NewActionManagerExtended("test-session", factory)
+
+	assert.Equal(t, implementation, manager.GetActionForIssue(createTestIssueWithLabels([]string{"status:ready"})))
+	// フェーズを実行しない遷移にはアクションがない
+	assert.Nil(t, manager.GetActionForIssue(createTestIssueWithLabels([]string{"status:requires-changes"})))
+	factory.AssertNotCalled(t, "CreateReviseAction")
+}
+
+func TestExecuteLabelTransition_Workflow(t *testing.T) {
+	setTestWorkflow(t, []config.WorkflowTransition{
+		{From: "status:needs-plan", Phase: config.PhasePlan, Executing: "status:planning"},
+		{From: "status:needs-qa", Phase: config.PhaseReview, Executing: "status:qa"},
+		{From: "status:qa-failed", To: "status:needs-qa"},
+	})
+	ctx := context.Background()
+
+	t.Run("追加した状態の実行中ラベルに遷移する", func(t *testing.T) {
+		client := new(mockGitHubClientForTransition)
+		client.On("TransitionLabels", ctx, "owner", "repo", 1, "status:needs-qa", "status:qa").Return(nil).Once()
+		w := &IssueWatcher{client: client, owner: "owner", repo: "repo", logger: NewMockLogger()}
+
+		assert.NoError(t, w.executeLabelTransition(ctx, createTestIssueWithLabels([]string{"status:needs-qa"})))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("フェーズを実行しない遷移を優先する", func(t *testing.T) {
+		client := new(mockGitHubClientForTransition)
+		client.On("TransitionLabels", ctx, "owner", "repo", 1, "status:qa-failed", "status:needs-qa").Return(nil).Once()
+		w := &IssueWatcher{client: client, owner: "owner", repo: "repo", logger: NewMockLogger()}
+
+		assert.NoError(t, w.executeLabelTransition(ctx, createTestIssueWithLabels([]string{"status:needs-plan", "status:qa-failed"})))
+		client.AssertExpectations(t)
+		client.AssertNotCalled(t, "TransitionLabels", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "status:needs-plan", mock.Anything)
+	})
+}
+
+func TestActiveProgressPhases_Workflow(t *testing.T) {
+	setTestWorkflow(t, []config.WorkflowTransition{
+		{From: "status:needs-qa", Phase: config.PhaseReview, Executing: "status:qa"},
+	})
+
+	phases := activeProgressPhases()
+	assert.Contains(t, phases, progressPhase{label: "status:qa", paneTitle: "Review", configKey: "review"})
+	assert.Contains(t, phases, progressPhase{label: "status:planning", paneTitle: "Plan", configKey: "plan"})
+}
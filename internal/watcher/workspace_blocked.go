@@ -0,0 +1,64 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/watcher/actions"
+)
+
+// workspaceBlockedCommentMarker はworktree事前チェック失敗のコメントを識別するためのマーカー
+const workspaceBlockedCommentMarker = "<!-- osoba:workspace-blocked -->"
+
+// notifyWorkspaceBlocked はworktreeの事前チェックで停止したIssueに診断結果をコメントする
+// 同じ診断結果は一度だけ投稿し、ポーリングのたびにコメントが増えないようにする
+func (w *IssueWatcher) notifyWorkspaceBlocked(ctx context.Context, blocked *actions.WorkspaceBlockedError) {
+	diagnostics := blocked.Health.Diagnostics()
+
+	w.mu.Lock()
+	if w.blockedWorkspaces == nil {
+		w.blockedWorkspaces = make(map[int]string)
+	}
+	if w.blockedWorkspaces[blocked.IssueNumber] == diagnostics {
+		w.mu.Unlock()
+		return
+	}
+	w.blockedWorkspaces[blocked.IssueNumber] = diagnostics
+	w.mu.Unlock()
+
+	body := buildWorkspaceBlockedComment(w.config, blocked)
+	if err := w.client.CreateIssueComment(ctx, w.owner, w.repo, blocked.IssueNumber, body); err != nil {
+		w.logger.Warn("Failed to post workspace diagnostics comment",
+			"issueNumber", blocked.IssueNumber,
+			"error", err)
+		// 次回のポーリングで再投稿できるよう記録を取り消す
+		w.mu.Lock()
+		delete(w.blockedWorkspaces, blocked.IssueNumber)
+		w.mu.Unlock()
+	}
+}
+
+// clearWorkspaceBlocked はフェーズを開始できたIssueの停止記録を破棄する
+func (w *IssueWatcher) clearWorkspaceBlocked(issueNumber int) {
+	w.mu.Lock()
+	delete(w.blockedWorkspaces, issueNumber)
+	w.mu.Unlock()
+}
+
+// buildWorkspaceBlockedComment はworktree事前チェック失敗のコメント本文を生成する
+func buildWorkspaceBlockedComment(cfg *config.Config, blocked *actions.WorkspaceBlockedError) string {
+	var diagnostics strings.Builder
+	for _, p := range blocked.Health.Unresolved() {
+		fmt.Fprintf(&diagnostics, "- `%s`: %s\n", p.Kind, p.Detail)
+	}
+
+	return workspaceBlockedCommentMarker + "\n" + cfg.RenderComment(config.CommentWorkspaceBlocked, map[string]string{
+		"issue-number":    strconv.Itoa(blocked.IssueNumber),
+		"worktree-path":   blocked.Health.Path,
+		"expected-branch": blocked.Health.ExpectedBranch,
+		"diagnostics":     diagnostics.String(),
+	})
+}
@@ -0,0 +1,69 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+
+	"github.com/douhashi/osoba/internal/config"
+	"github.com/douhashi/osoba/internal/git"
+	"github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/logger"
+)
+
+// WorktreePrefetcher は1回のポーリングで複数のIssueのフェーズを開始する場合に、
+// それらのworktreeをフェーズ開始前にまとめて作成する
+// mainブランチの取得を1回にまとめ、worktreeを並行して作成することで、Issueごとに直列で作成する待ち時間を減らす
+type WorktreePrefetcher struct {
+	preparer    git.WorktreeBatchPreparer
+	maxParallel int
+	logger      logger.Logger
+}
+
+// NewWorktreePrefetcher は新しいWorktreePrefetcherを作成する
+func NewWorktreePrefetcher(manager git.WorktreeManager, cfg *config.Config, logger logger.Logger) (*WorktreePrefetcher, error) {
+	if manager == nil {
+		return nil, errors.New("worktree manager is required")
+	}
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	preparer, ok := manager.(git.WorktreeBatchPreparer)
+	if !ok {
+		return nil, errors.New("worktree manager does not support batch preparation")
+	}
+
+	return &WorktreePrefetcher{
+		preparer:    preparer,
+		maxParallel: cfg.Worktree.MaxParallel,
+		logger:      logger,
+	}, nil
+}
+
+// Prefetch はフェーズの開始が決まったIssueが2件以上ある場合に、未作成のworktreeを並行して作成する
+// 作成に失敗したIssueは警告のみ出力し、フェーズ開始時に従来どおり個別に作成する
+func (p *WorktreePrefetcher) Prefetch(ctx context.Context, issues []*github.Issue) {
+	if p.maxParallel <= 1 {
+		return
+	}
+
+	var issueNumbers []int
+	for _, issue := range issues {
+		if issue != nil && issue.Number != nil {
+			issueNumbers = append(issueNumbers, *issue.Number)
+		}
+	}
+	if len(issueNumbers) < 2 {
+		return
+	}
+
+	p.logger.Info("Prefetching worktrees",
+		"issues", issueNumbers,
+		"max_parallel", p.maxParallel)
+
+	failed := p.preparer.PrepareWorktreesForIssues(ctx, issueNumbers, p.maxParallel)
+	for issueNumber, err := range failed {
+		p.logger.Warn("Failed to prefetch worktree, it will be created when the phase starts",
+			"issue_number", issueNumber,
+			"error", err)
+	}
+}
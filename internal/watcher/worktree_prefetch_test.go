@@ -0,0 +1,110 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/douhashi/osoba/internal/config"
+	gh "github.com/douhashi/osoba/internal/github"
+	"github.com/douhashi/osoba/internal/testutil/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockBatchWorktreeManager はworktreeの一括作成に対応したWorktreeManagerのモック
+type mockBatchWorktreeManager struct {
+	*mocks.MockGitWorktreeManager
+}
+
+func (m *mockBatchWorktreeManager) PrepareWorktreesForIssues(ctx context.Context, issueNumbers []int, maxParallel int) map[int]error {
+	args := m.Called(ctx, issueNumbers, maxParallel)
+	return args.Get(0).(map[int]error)
+}
+
+func labeledIssue(number int, label string) *gh.Issue {
+	return &gh.Issue{Number: intPtr(number), Labels: []*gh.Label{{Name: stringPtr(label)}}}
+}
+
+func TestWorktreePrefetcher_Prefetch(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxParallel int
+		issues      []*gh.Issue
+		wantNumbers []int
+	}{
+		{
+			name:        "開始が決まったIssueをまとめて作成",
+			maxParallel: 3,
+			issues: []*gh.Issue{
+				labeledIssue(1, "status:needs-plan"),
+				{}, // 番号のないIssueは対象外
+				labeledIssue(3, "status:ready"),
+				labeledIssue(4, "status:review-requested"),
+			},
+			wantNumbers: []int{1, 3, 4},
+		},
+		{
+			name:        "対象が1件の場合は事前作成しない",
+			maxParallel: 3,
+			issues:      []*gh.Issue{labeledIssue(1, "status:needs-plan")},
+		},
+		{
+			name:        "上限が1の場合は事前作成しない",
+			maxParallel: 1,
+			issues: []*gh.Issue{
+				labeledIssue(1, "status:needs-plan"),
+				labeledIssue(3, "status:ready"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewConfig()
+			cfg.Worktree.MaxParallel = tt.maxParallel
+			manager := &mockBatchWorktreeManager{mocks.NewMockGitWorktreeManager()}
+			if tt.wantNumbers != nil {
+				manager.On("PrepareWorktreesForIssues", mock.Anything, tt.wantNumbers, tt.maxParallel).
+					Return(map[int]error{3: errors.New("failed to create worktree")}).Once()
+			}
+
+			prefetcher, err := NewWorktreePrefetcher(manager, cfg, NewMockLogger())
+			require.NoError(t, err)
+			prefetcher.Prefetch(context.Background(), tt.issues)
+
+			manager.AssertExpectations(t)
+			if tt.wantNumbers == nil {
+				manager.AssertNotCalled(t, "PrepareWorktreesForIssues", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestIssueWatcher_RunPendingLaunches(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Worktree.MaxParallel = 2
+	manager := &mockBatchWorktreeManager{mocks.NewMockGitWorktreeManager()}
+	// 開始が決まったIssueのworktreeだけを作成してからフェーズを開始する
+	var launched []int
+	manager.On("PrepareWorktreesForIssues", mock.Anything, []int{1, 3}, 2).
+		Run(func(mock.Arguments) { assert.Empty(t, launched) }).
+		Return(map[int]error{}).Once()
+	prefetcher, err := NewWorktreePrefetcher(manager, cfg, NewMockLogger())
+	require.NoError(t, err)
+
+	w := &IssueWatcher{logger: NewMockLogger(), worktreePrefetcher: prefetcher}
+	for _, n := range []int{1, 3} {
+		number := n
+		w.pendingLaunches = append(w.pendingLaunches, pendingLaunch{
+			issue:  labeledIssue(number, "status:needs-plan"),
+			launch: func() { launched = append(launched, number) },
+		})
+	}
+	w.runPendingLaunches(context.Background())
+
+	manager.AssertExpectations(t)
+	assert.Equal(t, []int{1, 3}, launched)
+	assert.Empty(t, w.pendingLaunches)
+}
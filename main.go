@@ -1,9 +1,18 @@
 package main
 
 import (
+	"os"
+	// タイムゾーンのデータベースがないコンテナ・サーバーでも timezone の設定を読み込めるようにする
+	_ "time/tzdata"
+
 	"github.com/douhashi/osoba/cmd"
+	"github.com/douhashi/osoba/internal/daemon"
 )
 
 func main() {
+	// コンテナでPID 1として起動された場合は、子プロセスの回収を行うinitとして動作する
+	if daemon.ShouldRunAsInit() {
+		os.Exit(daemon.RunAsInit(os.Args[1:]))
+	}
 	cmd.Execute()
 }